@@ -26,6 +26,7 @@ const (
 	defDomainsURL      string = defURL + ":8189"
 	defCertsURL        string = defURL + ":9019"
 	defInvitationsURL  string = defURL + ":9020"
+	defREURL           string = defURL + ":9021"
 	defHTTPURL         string = defURL + ":9016/http"
 	defTLSVerification bool   = false
 	defOffset          string = "0"
@@ -42,6 +43,7 @@ type remotes struct {
 	HTTPAdapterURL  string `toml:"http_adapter_url"`
 	BootstrapURL    string `toml:"bootstrap_url"`
 	CertsURL        string `toml:"certs_url"`
+	REURL           string `toml:"re_url"`
 	TLSVerification bool   `toml:"tls_verification"`
 }
 
@@ -110,6 +112,7 @@ func ParseConfig(sdkConf mgxsdk.Config) (mgxsdk.Config, error) {
 				HTTPAdapterURL:  defHTTPURL,
 				BootstrapURL:    defBootstrapURL,
 				CertsURL:        defCertsURL,
+				REURL:           defREURL,
 				TLSVerification: defTLSVerification,
 			},
 			Filter: filter{
@@ -188,6 +191,10 @@ func ParseConfig(sdkConf mgxsdk.Config) (mgxsdk.Config, error) {
 		sdkConf.CertsURL = config.Remotes.CertsURL
 	}
 
+	if sdkConf.REURL == "" && config.Remotes.REURL != "" {
+		sdkConf.REURL = config.Remotes.REURL
+	}
+
 	sdkConf.TLSVerification = config.Remotes.TLSVerification || sdkConf.TLSVerification
 
 	return sdkConf, nil
@@ -241,6 +248,7 @@ func setConfigValue(key, value string) error {
 		"http_adapter_url": &config.Remotes.HTTPAdapterURL,
 		"bootstrap_url":    &config.Remotes.BootstrapURL,
 		"certs_url":        &config.Remotes.CertsURL,
+		"re_url":           &config.Remotes.REURL,
 		"tls_verification": &config.Remotes.TLSVerification,
 		"offset":           &config.Filter.Offset,
 		"limit":            &config.Filter.Limit,
@@ -0,0 +1,2488 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"time"
+
+	mgxsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var cmdStreams = []cobra.Command{
+	{
+		Use:   "create <JSON_stream> <user_auth_token>",
+		Short: "Create stream",
+		Long: "Create new stream reading messages from the channel or another data source\n" +
+			"Stream type is one of mainflux (default), mqtt, memory, file and httppush and format is one of\n" +
+			"json (default), binary, delimited (with optional delimiter) and protobuf (with schema_id)\n" +
+			"SenML streams (with senml set) are created with the fields of the SenML record\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re streams create '{\"name\":\"temperature\", \"topic\":\"<channel_id>\", \"fields\":[{\"name\":\"v\", \"type\":\"float\"}, {\"name\":\"n\", \"type\":\"string\"}]}' $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli re streams create '{\"name\":\"csv\", \"topic\":\"<channel_id>\", \"type\":\"mqtt\", \"format\":\"delimited\", \"delimiter\":\";\", \"fields\":[{\"name\":\"v\", \"type\":\"float\"}]}' $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli re streams create '{\"name\":\"readings\", \"topic\":\"<channel_id>\", \"senml\":true}' $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli re streams create '{\"name\":\"webhook\", \"topic\":\"<channel_id>\", \"type\":\"httppush\", \"fields\":[{\"name\":\"v\", \"type\":\"float\"}]}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var stream mgxsdk.Stream
+			if err := json.Unmarshal([]byte(args[0]), &stream); err != nil {
+				logError(err)
+				return
+			}
+
+			res, err := sdk.CreateStream(stream, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List streams",
+		Long: "List streams of the user\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re streams list $USER_AUTH_TOKEN --offset 0 --limit 10 --name temp\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			labels, err := convertLabels(Labels)
+			if err != nil {
+				logError(err)
+				return
+			}
+			pm := mgxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+				Name:   Name,
+				Labels: labels,
+				Owner:  Owner,
+			}
+			page, err := sdk.Streams(pm, args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(page)
+		},
+	},
+	{
+		Use:   "view <name> <user_auth_token>",
+		Short: "View stream",
+		Long:  `View stream with the given name`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			stream, err := sdk.ViewStream(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(stream)
+		},
+	},
+	{
+		Use:   "rename <name> <new_name> <user_auth_token>",
+		Short: "Rename stream",
+		Long:  `Rename stream, updating the rules reading from it`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.RenameStream(args[0], args[1], args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "ingest <ingestion_name> <message> <thing_key>",
+		Short: "Push message to stream",
+		Long: "Push message to the httppush stream with the given ingestion name, the last segment of\n" +
+			"the stream's ingestion URL, as the thing connected to the stream's channel\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re streams ingest u1234_temperature '{\"v\": 21.5}' $THING_KEY\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.IngestStream(args[0], args[1], args[2]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+var cmdTables = []cobra.Command{
+	{
+		Use:   "create <JSON_table> <user_auth_token>",
+		Short: "Create table",
+		Long: "Create new table holding the reference data rules join the streams with\n" +
+			"Table type is one of file (default) and memory and kind is one of scan (default)\n" +
+			"and lookup. Lookup tables must be memory tables with the key field set\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re tables create '{\"name\":\"devices\", \"topic\":\"devices.json\", \"fields\":[{\"name\":\"id\", \"type\":\"string\"}, {\"name\":\"location\", \"type\":\"string\"}]}' $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli re tables create '{\"name\":\"limits\", \"topic\":\"limits\", \"type\":\"memory\", \"kind\":\"lookup\", \"key\":\"id\", \"fields\":[{\"name\":\"id\", \"type\":\"string\"}, {\"name\":\"max\", \"type\":\"float\"}]}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var table mgxsdk.Table
+			if err := json.Unmarshal([]byte(args[0]), &table); err != nil {
+				logError(err)
+				return
+			}
+
+			res, err := sdk.CreateTable(table, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List tables",
+		Long: "List tables of the user\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re tables list $USER_AUTH_TOKEN --offset 0 --limit 10 --name dev\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			labels, err := convertLabels(Labels)
+			if err != nil {
+				logError(err)
+				return
+			}
+			pm := mgxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+				Name:   Name,
+				Labels: labels,
+				Owner:  Owner,
+			}
+			page, err := sdk.Tables(pm, args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(page)
+		},
+	},
+	{
+		Use:   "view <name> <user_auth_token>",
+		Short: "View table",
+		Long:  `View table with the given name`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			table, err := sdk.ViewTable(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(table)
+		},
+	},
+	{
+		Use:   "delete <name> <user_auth_token>",
+		Short: "Delete table",
+		Long:  `Delete table with the given name`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.DeleteTable(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+}
+
+var cmdRules = []cobra.Command{
+	{
+		Use:   "create <JSON_rule> <user_auth_token>",
+		Short: "Create rule",
+		Long: "Create new rule sending results to the actions\n" +
+			"Action sink is one of mainflux, rest, mqtt, log, nop, writer, email and sms\n" +
+			"Optional rule options are qos, checkpointInterval, isEventTime, lateTolerance, sendMetaToSink and concurrency\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules create '{\"id\":\"alarm\", \"sql\":\"SELECT * FROM temperature WHERE v > 30\", \"actions\":[{\"mainflux\":{\"channel\":\"<channel_id>\"}}]}' $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli re rules create '{\"id\":\"alarm\", \"sql\":\"SELECT * FROM temperature WHERE v > 30\", \"actions\":[{\"mainflux\":{\"channel\":\"<channel_id>\"}}], \"options\":{\"qos\":1, \"checkpointInterval\":60000}}' $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli re rules create '{\"id\":\"alarm\", \"sql\":\"SELECT * FROM temperature WHERE v > 30\", \"actions\":[{\"rest\":{\"url\":\"https://example.com/alarms\"}}]}' $USER_AUTH_TOKEN\n" +
+			"Scheduled rules run only within their schedule, e.g. during business hours:\n" +
+			"\tmagistrala-cli re rules create '{\"id\":\"alarm\", \"sql\":\"SELECT * FROM temperature WHERE v > 30\", \"actions\":[{\"mainflux\":{\"channel\":\"<channel_id>\"}}], \"schedule\":{\"windows\":[{\"days\":[\"mon\",\"tue\",\"wed\",\"thu\",\"fri\"], \"start\":\"09:00\", \"end\":\"17:00\"}]}}' $USER_AUTH_TOKEN\n" +
+			"Temporary rules are removed once their --ttl passes, e.g. the rule logging everything from the device for 2 hours:\n" +
+			"\tmagistrala-cli re rules create '{\"id\":\"debug\", \"sql\":\"SELECT * FROM device\", \"actions\":[{\"log\":{}}]}' $USER_AUTH_TOKEN --ttl 2h\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var rule mgxsdk.Rule
+			if err := json.Unmarshal([]byte(args[0]), &rule); err != nil {
+				logError(err)
+				return
+			}
+			if TTL > 0 {
+				rule.ExpiresAt = time.Now().UTC().Add(TTL)
+			}
+
+			res, err := sdk.CreateRule(rule, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "patch <rule_id> <JSON_patch> <user_auth_token>",
+		Short: "Patch rule",
+		Long: "Change only the sql, actions or options of the rule, keeping the rest\n" +
+			"The patched rule is validated and restarted, unless it should stay stopped\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules patch alarm '{\"sql\":\"SELECT * FROM temperature WHERE v > 40\"}' $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli re rules patch alarm '{\"options\":{\"qos\":1}}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var patch mgxsdk.RulePatch
+			if err := json.Unmarshal([]byte(args[1]), &patch); err != nil {
+				logError(err)
+				return
+			}
+
+			res, err := sdk.PatchRule(args[0], patch, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "validate <JSON_rule> <user_auth_token>",
+		Short: "Validate rule",
+		Long: "Validate rule without creating it, printing the diagnostics of all the problems found\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules validate '{\"id\":\"alarm\", \"sql\":\"SELECT * FROM temperature WHERE v > 30\", \"actions\":[{\"mainflux\":{\"channel\":\"<channel_id>\"}}]}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var rule mgxsdk.Rule
+			if err := json.Unmarshal([]byte(args[0]), &rule); err != nil {
+				logError(err)
+				return
+			}
+
+			res, err := sdk.ValidateRule(rule, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "test <JSON_trial> <user_auth_token>",
+		Short: "Test rule",
+		Long: "Test rule against the sample messages without creating it, printing the rule results\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules test '{\"rule\":{\"id\":\"alarm\", \"sql\":\"SELECT * FROM temperature WHERE v > 30\"}, \"samples\":{\"temperature\":[{\"v\":25},{\"v\":35}]}}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var trial mgxsdk.RuleTrial
+			if err := json.Unmarshal([]byte(args[0]), &trial); err != nil {
+				logError(err)
+				return
+			}
+
+			res, err := sdk.TestRule(trial, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List rules",
+		Long: "List rules of the user\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules list $USER_AUTH_TOKEN --offset 0 --limit 10 --name alarm\n" +
+			"\tmagistrala-cli re rules list $USER_AUTH_TOKEN --labels '{\"env\":\"prod\"}'\n" +
+			"\tmagistrala-cli re rules list $USER_AUTH_TOKEN --folder site_a\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			labels, err := convertLabels(Labels)
+			if err != nil {
+				logError(err)
+				return
+			}
+			pm := mgxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+				Name:   Name,
+				Labels: labels,
+				Owner:  Owner,
+				Folder: Folder,
+			}
+			page, err := sdk.Rules(pm, args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(page)
+		},
+	},
+	{
+		Use:   "search [stream | channel] <name_or_id> <user_auth_token>",
+		Short: "Search rules",
+		Long: "List rules reading from the stream or publishing to the channel\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules search stream temperature $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli re rules search channel <channel_id> $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var stream, channel string
+			switch args[0] {
+			case "stream":
+				stream = args[1]
+			case "channel":
+				channel = args[1]
+			default:
+				logUsage(cmd.Use)
+				return
+			}
+			pm := mgxsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+				Owner:  Owner,
+			}
+			page, err := sdk.SearchRules(stream, channel, pm, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(page)
+		},
+	},
+	{
+		Use:   "draft <JSON_rule> <user_auth_token>",
+		Short: "Save draft rule",
+		Long: "Save the rule as the draft, which isn't deployed until it's published\n" +
+			"Saving the existing draft replaces it\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules draft '{\"id\":\"alarm\", \"sql\":\"SELECT * FROM temperature WHERE v > 30\", \"actions\":[{\"mainflux\":{\"channel\":\"<channel_id>\"}}]}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var rule mgxsdk.Rule
+			if err := json.Unmarshal([]byte(args[0]), &rule); err != nil {
+				logError(err)
+				return
+			}
+
+			res, err := sdk.SaveDraftRule(rule, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "publish <id> <user_auth_token>",
+		Short: "Publish rule",
+		Long:  `Deploy the draft rule with the given ID`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.PublishRule(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "unpublish <id> <user_auth_token>",
+		Short: "Unpublish rule",
+		Long:  `Withdraw the rule with the given ID, keeping it as the draft`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.UnpublishRule(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "restore <id> <user_auth_token>",
+		Short: "Restore rule",
+		Long:  `Restore the deleted rule with the given ID while its restore window is open`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.RestoreRule(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "start <id> <user_auth_token>",
+		Short: "Start rule",
+		Long:  `Start rule with the given ID`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.StartRule(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "stop <id> <user_auth_token>",
+		Short: "Stop rule",
+		Long:  `Stop rule with the given ID`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.StopRule(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "status <id> <user_auth_token>",
+		Short: "Rule status",
+		Long:  `View runtime status and metrics of the rule with the given ID`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			status, err := sdk.RuleStatus(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(status)
+		},
+	},
+	{
+		Use:   "topology <id> <user_auth_token>",
+		Short: "Rule topology",
+		Long:  `View graph of the operators the rule with the given ID runs as`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			topo, err := sdk.RuleTopology(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(topo)
+		},
+	},
+	{
+		Use:   "logs <id> [since] <user_auth_token>",
+		Short: "Rule logs",
+		Long: "View the latest Kuiper log lines of the rule, logged since the given RFC 3339 time if given\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules logs alarm 2024-05-01T00:00:00Z $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 2 || len(args) > 3 {
+				logUsage(cmd.Use)
+				return
+			}
+			var since time.Time
+			if len(args) == 3 {
+				var err error
+				if since, err = time.Parse(time.RFC3339, args[1]); err != nil {
+					logError(err)
+					return
+				}
+			}
+
+			logs, err := sdk.RuleLogs(args[0], since, args[len(args)-1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(logs)
+		},
+	},
+	{
+		Use:   "replay <id> <from> <to> <user_auth_token>",
+		Short: "Replay rule",
+		Long: "Replay the historical messages received between from and to (RFC 3339 times) through the rule\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules replay alarm 2024-05-01T00:00:00Z 2024-05-02T00:00:00Z $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			from, err := time.Parse(time.RFC3339, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+			to, err := time.Parse(time.RFC3339, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			res, sdkErr := sdk.ReplayRule(args[0], from, to, args[3])
+			if sdkErr != nil {
+				logError(sdkErr)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "rename <id> <new_id> <user_auth_token>",
+		Short: "Rename rule",
+		Long:  `Rename rule, keeping its metadata and shares`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.RenameRule(args[0], args[1], args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "clone <id> <new_id> [channel_id] <user_auth_token>",
+		Short: "Clone rule",
+		Long: "Create the rule with the new id and the sql, actions and options of the rule\n" +
+			"If the channel is given, the mainflux actions of the clone publish to it\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re rules clone alarm alarm_test <channel_id> $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 3 || len(args) > 4 {
+				logUsage(cmd.Use)
+				return
+			}
+			if len(args) == 3 {
+				args = []string{args[0], args[1], "", args[2]}
+			}
+
+			res, err := sdk.CloneRule(args[0], args[1], args[2], args[3])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+}
+
+var cmdBulk = []cobra.Command{
+	{
+		Use:   "create <JSON_ruleset> <user_auth_token>",
+		Short: "Bulk create streams and rules",
+		Long: "Create the streams and then the rules concurrently, printing the result of each of them\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re bulk create '{\"streams\":[{\"name\":\"temperature\", \"topic\":\"<channel_id>\"}], \"rules\":[{\"id\":\"alarm\", \"sql\":\"SELECT * FROM temperature WHERE v > 30\", \"actions\":[{\"mainflux\":{\"channel\":\"<channel_id>\"}}]}]}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var rs mgxsdk.Ruleset
+			if err := json.Unmarshal([]byte(args[0]), &rs); err != nil {
+				logError(err)
+				return
+			}
+
+			report, err := sdk.BulkCreate(rs, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+	{
+		Use:   "delete <JSON_names> <user_auth_token>",
+		Short: "Bulk delete streams and rules",
+		Long: "Delete the rules and then the streams concurrently, printing the result of each of them\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re bulk delete '{\"streams\":[\"temperature\"], \"rules\":[\"alarm\"]}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var bd mgxsdk.BulkDeletion
+			if err := json.Unmarshal([]byte(args[0]), &bd); err != nil {
+				logError(err)
+				return
+			}
+
+			report, err := sdk.BulkDelete(bd, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+	{
+		Use:   "start <JSON_selection> <user_auth_token>",
+		Short: "Bulk start rules",
+		Long: "Start the rules selected either by their IDs or by their labels concurrently, printing the result of each of them\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re bulk start '{\"labels\":{\"site\":\"plant_a\"}}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var sel mgxsdk.RuleSelection
+			if err := json.Unmarshal([]byte(args[0]), &sel); err != nil {
+				logError(err)
+				return
+			}
+
+			report, err := sdk.StartRules(sel, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+	{
+		Use:   "stop <JSON_selection> <user_auth_token>",
+		Short: "Bulk stop rules",
+		Long: "Stop the rules selected either by their IDs or by their labels concurrently, printing the result of each of them\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re bulk stop '{\"ids\":[\"alarm\", \"overheat\"]}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var sel mgxsdk.RuleSelection
+			if err := json.Unmarshal([]byte(args[0]), &sel); err != nil {
+				logError(err)
+				return
+			}
+
+			report, err := sdk.StopRules(sel, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+}
+
+var cmdAll = []cobra.Command{
+	{
+		Use:   "streams <user_auth_token>",
+		Short: "List streams of all users",
+		Long:  `List streams of all users grouped by owner`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			all, err := sdk.ListAllStreams(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(all)
+		},
+	},
+	{
+		Use:   "rules <user_auth_token>",
+		Short: "List rules of all users",
+		Long:  `List rules of all users grouped by owner, with the number of rules in each state`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			all, err := sdk.ListAllRules(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(all)
+		},
+	},
+}
+
+var cmdQuotas = []cobra.Command{
+	{
+		Use:   "view <user_id> <user_auth_token>",
+		Short: "View quota",
+		Long:  `View quota of the user along with the numbers of streams and rules the user has`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			q, err := sdk.RulesQuota(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(q)
+		},
+	},
+	{
+		Use:   "set <user_id> <JSON_quota> <user_auth_token>",
+		Short: "Set quota",
+		Long: "Set limits of streams and rules the user can create, 0 meaning no limit\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re quotas set <user_id> '{\"max_streams\":10, \"max_rules\":50}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var q mgxsdk.RulesQuota
+			if err := json.Unmarshal([]byte(args[1]), &q); err != nil {
+				logError(err)
+				return
+			}
+
+			uq, err := sdk.SetRulesQuota(args[0], q, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(uq)
+		},
+	},
+	{
+		Use:   "remove <user_id> <user_auth_token>",
+		Short: "Remove quota",
+		Long:  `Remove quota set for the user, so the default quota applies`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteRulesQuota(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+var cmdAlerts = []cobra.Command{
+	{
+		Use:   "view <user_auth_token>",
+		Short: "View alert policy",
+		Long:  `View policy the user is alerted about failing rules with`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			p, err := sdk.RulesAlertPolicy(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(p)
+		},
+	},
+	{
+		Use:   "set <JSON_policy> <user_auth_token>",
+		Short: "Set alert policy",
+		Long: "Alert about rules in error and rules raising more exceptions between checks than the threshold, 0 disabling it\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re alerts set '{\"exceptions\":10, \"webhook\":\"https://example.com/alerts\"}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var p mgxsdk.RulesAlertPolicy
+			if err := json.Unmarshal([]byte(args[0]), &p); err != nil {
+				logError(err)
+				return
+			}
+
+			p, err := sdk.SetRulesAlertPolicy(p, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(p)
+		},
+	},
+	{
+		Use:   "remove <user_auth_token>",
+		Short: "Remove alert policy",
+		Long:  `Remove alert policy, so the user is no longer alerted about failing rules`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteRulesAlertPolicy(args[0]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+var cmdWebhooks = []cobra.Command{
+	{
+		Use:   "create <JSON_webhook> <user_auth_token>",
+		Short: "Create webhook",
+		Long: "Create webhook called on rule.created, rule.started, rule.stopped and rule.errored events, all of them if no events are given\n" +
+			"The secret signing the payloads is generated if it isn't given and it's only shown once\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re webhooks create '{\"url\":\"https://ci.example.com/hooks\", \"events\":[\"rule.errored\"]}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var wh mgxsdk.RulesWebhook
+			if err := json.Unmarshal([]byte(args[0]), &wh); err != nil {
+				logError(err)
+				return
+			}
+
+			wh, err := sdk.CreateRulesWebhook(wh, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(wh)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List webhooks",
+		Long:  `List webhooks called on lifecycle events of the user's rules`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			whs, err := sdk.RulesWebhooks(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(whs)
+		},
+	},
+	{
+		Use:   "remove <webhook_id> <user_auth_token>",
+		Short: "Remove webhook",
+		Long:  `Remove webhook`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteRulesWebhook(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+var cmdFolders = []cobra.Command{
+	{
+		Use:   "create <JSON_folder> <user_auth_token>",
+		Short: "Create folder",
+		Long: "Create folder the user's rules are organized in\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re folders create '{\"name\":\"site_a\", \"description\":\"Site A rules\"}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var f mgxsdk.RulesFolder
+			if err := json.Unmarshal([]byte(args[0]), &f); err != nil {
+				logError(err)
+				return
+			}
+
+			f, err := sdk.CreateRulesFolder(f, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(f)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List folders",
+		Long:  `List folders of the user's rules along with the number of the rules in them`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			folders, err := sdk.RulesFolders(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(folders)
+		},
+	},
+	{
+		Use:   "move <folder> <JSON_ids> <user_auth_token>",
+		Short: "Move rules to folder",
+		Long: "Move rules to folder, or out of their folders if the folder is empty\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re folders move site_a '[\"overheat\", \"humidity\"]' $USER_AUTH_TOKEN\n" +
+			"\tmagistrala-cli re folders move \"\" '[\"overheat\"]' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var ids []string
+			if err := json.Unmarshal([]byte(args[1]), &ids); err != nil {
+				logError(err)
+				return
+			}
+
+			report, err := sdk.MoveRules(args[0], ids, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+	{
+		Use:   "remove <name> <user_auth_token>",
+		Short: "Remove folder",
+		Long:  `Remove folder, leaving its rules without a folder`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteRulesFolder(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+var cmdMaintenance = []cobra.Command{
+	{
+		Use:   "pause <user_auth_token>",
+		Short: "Pause rules",
+		Long:  `Enter maintenance mode, stopping all of the user's running rules and recording which rules were running`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			report, err := sdk.PauseRules(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+	{
+		Use:   "resume <user_auth_token>",
+		Short: "Resume rules",
+		Long:  `Leave maintenance mode, starting exactly the rules that were running when the rules were paused`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			report, err := sdk.ResumeRules(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+	{
+		Use:   "get <user_auth_token>",
+		Short: "Get maintenance mode",
+		Long:  `Get maintenance mode along with the rules that were running when the rules were paused`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			m, err := sdk.RulesMaintenance(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(m)
+		},
+	},
+}
+
+var cmdChains = []cobra.Command{
+	{
+		Use:   "create <JSON_chain> <user_auth_token>",
+		Short: "Create chain",
+		Long: "Create chain feeding the results of the from rule to the to rule through the memory stream with the chain name\n" +
+			"The to rule reads from the stream once the chain is created.\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re chains create '{\"name\":\"filtered\", \"from\":\"filter\", \"to\":\"alarm\", \"fields\":[{\"name\":\"v\", \"type\":\"float\"}]}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var c mgxsdk.RulesChain
+			if err := json.Unmarshal([]byte(args[0]), &c); err != nil {
+				logError(err)
+				return
+			}
+
+			c, err := sdk.CreateRulesChain(c, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(c)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List chains",
+		Long:  `List chains of the user's rules`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			chains, err := sdk.RulesChains(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(chains)
+		},
+	},
+	{
+		Use:   "remove <name> <user_auth_token>",
+		Short: "Remove chain",
+		Long:  `Remove chain no rule reads from, along with its stream and the sink publishing to it`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteRulesChain(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+var cmdSecrets = []cobra.Command{
+	{
+		Use:   "save <name> <value> <user_auth_token>",
+		Short: "Save secret",
+		Long: "Create or replace the secret the rule actions refer to with {{secret \"<name>\"}}\n" +
+			"The value is stored encrypted and is never returned.\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re secrets save mqtt-password s3cr3t $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			s, err := sdk.SaveRulesSecret(args[0], args[1], args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(s)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List secrets",
+		Long:  `List secrets of the user without the values`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			secrets, err := sdk.RulesSecrets(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(secrets)
+		},
+	},
+	{
+		Use:   "remove <name> <user_auth_token>",
+		Short: "Remove secret",
+		Long:  `Remove secret of the user`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteRulesSecret(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+var cmdInstances = []cobra.Command{
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List instances",
+		Long:  `List Kuiper instances along with the users assigned to them, for the platform administrator`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			instances, err := sdk.RulesEngineInstances(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(instances)
+		},
+	},
+	{
+		Use:   "assign <user_id> <instance> <user_auth_token>",
+		Short: "Assign user to instance",
+		Long: "Assign user to Kuiper instance, before the user creates streams, tables or rules\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re instances assign <user_id> dedicated $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			a, err := sdk.AssignRulesEngineInstance(args[0], args[1], args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(a)
+		},
+	},
+	{
+		Use:   "unassign <user_id> <user_auth_token>",
+		Short: "Unassign user",
+		Long:  `Remove assignment of the user, leaving the user on the instance picked by the pool strategy`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			a, err := sdk.UnassignRulesEngineInstance(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(a)
+		},
+	},
+}
+
+var cmdGateways = []cobra.Command{
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List gateways",
+		Long:  `List edge gateways the rules are deployed to`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			gws, err := sdk.RulesEngineGateways(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(gws)
+		},
+	},
+	{
+		Use:   "save <JSON_gateway> <user_auth_token>",
+		Short: "Save gateway",
+		Long: "Register or update edge gateway, using the control channel of its bootstrap configuration if the channel is empty\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re gateways save '{\"id\":\"<thing_id>\",\"name\":\"plant\",\"labels\":{\"site\":\"north\"}}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var gw mgxsdk.RulesEngineGateway
+			if err := json.Unmarshal([]byte(args[0]), &gw); err != nil {
+				logError(err)
+				return
+			}
+			gw, err := sdk.SaveRulesEngineGateway(gw, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(gw)
+		},
+	},
+	{
+		Use:   "remove <gateway_id> <user_auth_token>",
+		Short: "Remove gateway",
+		Long:  `Remove edge gateway without rules deployed to it`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.RemoveRulesEngineGateway(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+var cmdDeployments = []cobra.Command{
+	{
+		Use:   "list <rule_id> <user_auth_token>",
+		Short: "List deployments",
+		Long:  `List deployments of the rule to edge gateways along with their statuses`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			ds, err := sdk.RuleDeployments(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(ds)
+		},
+	},
+	{
+		Use:   "deploy <rule_id> <gateway_id> <user_auth_token>",
+		Short: "Deploy rule",
+		Long:  `Deploy rule along with its streams to edge gateway`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			d, err := sdk.DeployRule(args[0], args[1], args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(d)
+		},
+	},
+	{
+		Use:   "undeploy <rule_id> <gateway_id> <user_auth_token>",
+		Short: "Undeploy rule",
+		Long:  `Remove rule from edge gateway`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			d, err := sdk.UndeployRule(args[0], args[1], args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(d)
+		},
+	},
+	{
+		Use:   "fleet <rule_id> <JSON_labels> <user_auth_token>",
+		Short: "Deploy rule to fleet",
+		Long: "Deploy rule to all the edge gateways having the labels, or to all the gateways with empty labels\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re deployments fleet alarm '{\"site\":\"north\"}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var labels map[string]string
+			if err := json.Unmarshal([]byte(args[1]), &labels); err != nil {
+				logError(err)
+				return
+			}
+			r, err := sdk.DeployFleet(args[0], labels, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(r)
+		},
+	},
+	{
+		Use:   "retry <rule_id> <user_auth_token>",
+		Short: "Retry failed deployments",
+		Long:  `Deploy rule again to the edge gateways it failed on`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			r, err := sdk.RetryDeployments(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(r)
+		},
+	},
+	{
+		Use:   "rollout <rule_id> <user_auth_token>",
+		Short: "View rollout",
+		Long:  `View aggregate status of the rule deployed to the edge gateways`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			r, err := sdk.ViewRollout(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(r)
+		},
+	},
+}
+
+var cmdShares = []cobra.Command{
+	{
+		Use:   "share <stream | rule> <name> <JSON_share> <user_auth_token>",
+		Short: "Share stream or rule",
+		Long: "Share stream or rule with user or members of group, with view or manage access\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re shares share rule alarm '{\"grantee\":\"<user_id>\", \"grantee_type\":\"user\", \"access\":\"view\"}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var s mgxsdk.EntityShare
+			if err := json.Unmarshal([]byte(args[2]), &s); err != nil {
+				logError(err)
+				return
+			}
+
+			var err error
+			switch args[0] {
+			case "stream":
+				s, err = sdk.ShareStream(args[1], s, args[3])
+			case "rule":
+				s, err = sdk.ShareRule(args[1], s, args[3])
+			default:
+				logUsage(cmd.Use)
+				return
+			}
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(s)
+		},
+	},
+	{
+		Use:   "list <stream | rule> <name> <user_auth_token>",
+		Short: "List shares",
+		Long:  `List users and groups the stream or rule is shared with`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var shares []mgxsdk.EntityShare
+			var err error
+			switch args[0] {
+			case "stream":
+				shares, err = sdk.StreamShares(args[1], args[2])
+			case "rule":
+				shares, err = sdk.RuleShares(args[1], args[2])
+			default:
+				logUsage(cmd.Use)
+				return
+			}
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(shares)
+		},
+	},
+	{
+		Use:   "unshare <stream | rule> <name> <grantee_id> <user_auth_token>",
+		Short: "Unshare stream or rule",
+		Long:  `Revoke share of stream or rule with user or group`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var err error
+			switch args[0] {
+			case "stream":
+				err = sdk.UnshareStream(args[1], args[2], args[3])
+			case "rule":
+				err = sdk.UnshareRule(args[1], args[2], args[3])
+			default:
+				logUsage(cmd.Use)
+				return
+			}
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+var cmdDrift = []cobra.Command{
+	{
+		Use:   "view <user_auth_token>",
+		Short: "View drift",
+		Long:  `View streams and rules that exist only in Kuiper or only in the metadata store`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			report, err := sdk.Drift(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+	{
+		Use:   "repair <user_auth_token>",
+		Short: "Repair drift",
+		Long:  `Remove metadata of the entities missing in Kuiper and create missing metadata of the Kuiper entities`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			report, err := sdk.RepairDrift(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+}
+
+// orphansMinAge is the age entities must reach before they're orphans.
+var orphansMinAge time.Duration
+
+var cmdOrphans = []cobra.Command{
+	{
+		Use:   "view <user_auth_token> [--min-age]",
+		Short: "View orphans",
+		Long:  `View streams no rule reads from and rules reading from missing streams or publishing to removed channels`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			report, err := sdk.Orphans(orphansMinAge, args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+	{
+		Use:   "remove <user_auth_token> [--min-age]",
+		Short: "Remove orphans",
+		Long:  `Remove streams no rule reads from and rules reading from missing streams or publishing to removed channels`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			report, err := sdk.RemoveOrphans(orphansMinAge, args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	},
+}
+
+var cmdTemplates = []cobra.Command{
+	{
+		Use:   "create <JSON_template> <user_auth_token>",
+		Short: "Create rule template",
+		Long:  `Create parameterized rule template, available to platform administrator`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var tmpl mgxsdk.RuleTemplate
+			if err := json.Unmarshal([]byte(args[0]), &tmpl); err != nil {
+				logError(err)
+				return
+			}
+
+			tmpl, err := sdk.CreateRuleTemplate(tmpl, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(tmpl)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List rule templates",
+		Long:  `List all rule templates`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			tmpls, err := sdk.RuleTemplates(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(tmpls)
+		},
+	},
+	{
+		Use:   "view <name> <user_auth_token>",
+		Short: "View rule template",
+		Long:  `View rule template with the given name`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			tmpl, err := sdk.RuleTemplate(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(tmpl)
+		},
+	},
+	{
+		Use:   "delete <name> <user_auth_token>",
+		Short: "Delete rule template",
+		Long:  `Delete rule template with the given name, keeping the rules created from it`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteRuleTemplate(args[0], args[1]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	{
+		Use:   "instantiate <name> <JSON_instance> <user_auth_token>",
+		Short: "Instantiate rule template",
+		Long:  `Create rule from the template with the given name using the values of the template variables`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var inst mgxsdk.TemplateInstance
+			if err := json.Unmarshal([]byte(args[1]), &inst); err != nil {
+				logError(err)
+				return
+			}
+
+			rule, err := sdk.InstantiateRuleTemplate(args[0], inst, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(rule)
+		},
+	},
+}
+
+var cmdPlugins = []cobra.Command{
+	{
+		Use:   "create <sources | sinks | functions> <JSON_plugin> <user_auth_token>",
+		Short: "Create plugin",
+		Long: "Install Kuiper plugin from the zip file Kuiper downloads from the file URL\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re plugins create sinks '{\"name\":\"mainflux\", \"file\":\"https://example.com/plugins/sinks/mainflux.zip\"}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var plugin mgxsdk.RulesEnginePlugin
+			if err := json.Unmarshal([]byte(args[1]), &plugin); err != nil {
+				logError(err)
+				return
+			}
+
+			res, err := sdk.CreateRulesEnginePlugin(args[0], plugin, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "list <sources | sinks | functions> <user_auth_token>",
+		Short: "List plugins",
+		Long:  `List installed Kuiper plugins of the given kind`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			plugins, err := sdk.RulesEnginePlugins(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(plugins)
+		},
+	},
+	{
+		Use:   "delete <sources | sinks | functions> <name> <user_auth_token>",
+		Short: "Delete plugin",
+		Long:  `Delete Kuiper plugin of the given kind with the given name`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.DeleteRulesEnginePlugin(args[0], args[1], args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+}
+
+var cmdServices = []cobra.Command{
+	{
+		Use:   "register <JSON_service> <user_auth_token>",
+		Short: "Register external service",
+		Long: "Register external service Kuiper calls as SQL functions from the zip file Kuiper downloads from the file URL\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re services register '{\"name\":\"geo\", \"file\":\"https://example.com/services/geo.zip\"}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var es mgxsdk.ExternalService
+			if err := json.Unmarshal([]byte(args[0]), &es); err != nil {
+				logError(err)
+				return
+			}
+
+			res, err := sdk.RegisterExternalService(es, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List external services",
+		Long:  `List registered external services`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			services, err := sdk.ExternalServices(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(services)
+		},
+	},
+	{
+		Use:   "delete <name> <user_auth_token>",
+		Short: "Delete external service",
+		Long:  `Delete external service with the given name`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.DeleteExternalService(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "functions <user_auth_token>",
+		Short: "List external functions",
+		Long:  `List SQL functions of the registered external services`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			funcs, err := sdk.ExternalFunctions(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(funcs)
+		},
+	},
+}
+
+var cmdConfKeys = []cobra.Command{
+	{
+		Use:   "save <name> <JSON_conf> <user_auth_token>",
+		Short: "Save conf key",
+		Long: "Create or replace MQTT broker configuration the user's MQTT streams subscribe with\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re confkeys save broker '{\"server\":\"ssl://localhost:8883\", \"username\":\"<thing_id>\", \"password\":\"<thing_key>\"}' $USER_AUTH_TOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var conf mgxsdk.MQTTConf
+			if err := json.Unmarshal([]byte(args[1]), &conf); err != nil {
+				logError(err)
+				return
+			}
+
+			res, err := sdk.SaveConfKey(args[0], conf, args[2])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List conf keys",
+		Long:  `List names of the user's MQTT broker configurations`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			keys, err := sdk.ConfKeys(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(keys)
+		},
+	},
+	{
+		Use:   "delete <name> <user_auth_token>",
+		Short: "Delete conf key",
+		Long:  `Delete MQTT broker configuration with the given name`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.DeleteConfKey(args[0], args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	},
+}
+
+// NewRulesEngineCmd returns rules engine command.
+func NewRulesEngineCmd() *cobra.Command {
+	streamsCmd := cobra.Command{
+		Use:   "streams [create | list | view | delete | rename]",
+		Short: "Streams management",
+		Long:  `Streams management: create, list, view or delete rules engine streams`,
+	}
+	for i := range cmdStreams {
+		streamsCmd.AddCommand(&cmdStreams[i])
+	}
+
+	var cascade bool
+	deleteStreamCmd := cobra.Command{
+		Use:   "delete <name> <user_auth_token> [--cascade]",
+		Short: "Delete stream",
+		Long:  `Delete stream with the given name, refusing if rules read from it unless cascade is set`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			res, err := sdk.DeleteStream(args[0], cascade, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(res)
+		},
+	}
+	deleteStreamCmd.Flags().BoolVar(&cascade, "cascade", false, "delete the rules reading from the stream as well")
+	streamsCmd.AddCommand(&deleteStreamCmd)
+
+	tablesCmd := cobra.Command{
+		Use:   "tables [create | list | view | delete]",
+		Short: "Tables management",
+		Long:  `Tables management: create, list, view or delete rules engine tables`,
+	}
+	for i := range cmdTables {
+		tablesCmd.AddCommand(&cmdTables[i])
+	}
+
+	rulesCmd := cobra.Command{
+		Use:   "rules [create | patch | validate | test | list | search | draft | publish | unpublish | restore | start | stop | status | replay | rename | clone]",
+		Short: "Rules management",
+		Long:  `Rules management: create, patch, validate, list, start, stop or view status of rules engine rules`,
+	}
+	for i := range cmdRules {
+		rulesCmd.AddCommand(&cmdRules[i])
+	}
+
+	driftCmd := cobra.Command{
+		Use:   "drift [view | repair]",
+		Short: "Drift management",
+		Long:  `Drift management: view or repair drift between Kuiper and the metadata store`,
+	}
+	for i := range cmdDrift {
+		driftCmd.AddCommand(&cmdDrift[i])
+	}
+
+	orphansCmd := cobra.Command{
+		Use:   "orphans [view | remove]",
+		Short: "Orphans management",
+		Long:  `Orphans management: view or remove streams and rules that can't do any useful work`,
+	}
+	for i := range cmdOrphans {
+		orphansCmd.AddCommand(&cmdOrphans[i])
+	}
+	orphansCmd.PersistentFlags().DurationVar(&orphansMinAge, "min-age", 0, "keep the entities changed more recently")
+
+	var dryRun bool
+	restoreCmd := cobra.Command{
+		Use:   "restore <user_auth_token> [--dry-run]",
+		Short: "Restore Kuiper",
+		Long:  `Replay the stored stream and rule definitions missing in Kuiper, streams first`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			report, err := sdk.RestoreKuiper(dryRun, args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	}
+	restoreCmd.Flags().BoolVar(&dryRun, "dry-run", false, "only report the entities that would be restored")
+
+	statsCmd := cobra.Command{
+		Use:   "stats <user_auth_token>",
+		Short: "Engine statistics",
+		Long:  `Count streams, tables and rules by state, and records and exceptions in total and since the previous statistics`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			stats, err := sdk.RulesEngineStats(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(stats)
+		},
+	}
+
+	exportCmd := cobra.Command{
+		Use:   "export <user_auth_token>",
+		Short: "Export ruleset",
+		Long:  `Export all streams and rules of the user as a single JSON document`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			rs, err := sdk.ExportRuleset(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(rs)
+		},
+	}
+
+	var conflict string
+	importCmd := cobra.Command{
+		Use:   "import <JSON_ruleset> <user_auth_token> [--conflict skip | overwrite | rename]",
+		Short: "Import ruleset",
+		Long: "Import the exported streams and rules, streams first\n" +
+			"Existing streams and rules are kept (skip), replaced (overwrite) or the\n" +
+			"imported ones are created under a new name (rename)",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var rs mgxsdk.Ruleset
+			if err := json.Unmarshal([]byte(args[0]), &rs); err != nil {
+				logError(err)
+				return
+			}
+
+			report, err := sdk.ImportRuleset(rs, conflict, args[1])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(report)
+		},
+	}
+	importCmd.Flags().StringVar(&conflict, "conflict", "skip", "strategy resolving conflicts with the existing entities")
+
+	rulesetCmd := cobra.Command{
+		Use:   "ruleset [export | import]",
+		Short: "Ruleset management",
+		Long:  `Ruleset management: export or import all streams and rules of the user`,
+	}
+	rulesetCmd.AddCommand(&exportCmd, &importCmd)
+
+	bulkCmd := cobra.Command{
+		Use:   "bulk [create | delete]",
+		Short: "Bulk operations",
+		Long:  `Bulk operations: create or delete many streams and rules in one call`,
+	}
+	for i := range cmdBulk {
+		bulkCmd.AddCommand(&cmdBulk[i])
+	}
+
+	allCmd := cobra.Command{
+		Use:   "all [streams | rules]",
+		Short: "Streams and rules of all users",
+		Long:  `Streams and rules of all users: list streams or rules of all users, for the platform administrator`,
+	}
+	for i := range cmdAll {
+		allCmd.AddCommand(&cmdAll[i])
+	}
+
+	quotasCmd := cobra.Command{
+		Use:   "quotas [view | set | remove]",
+		Short: "Quotas management",
+		Long:  `Quotas management: view, set or remove limits of streams and rules of the users`,
+	}
+	for i := range cmdQuotas {
+		quotasCmd.AddCommand(&cmdQuotas[i])
+	}
+
+	alertsCmd := cobra.Command{
+		Use:   "alerts [view | set | remove]",
+		Short: "Alerts management",
+		Long:  `Alerts management: view, set or remove policy the user is alerted about failing rules with`,
+	}
+	for i := range cmdAlerts {
+		alertsCmd.AddCommand(&cmdAlerts[i])
+	}
+
+	webhooksCmd := cobra.Command{
+		Use:   "webhooks [create | list | remove]",
+		Short: "Webhooks management",
+		Long:  `Webhooks management: create, list or remove webhooks called on rule lifecycle events`,
+	}
+	for i := range cmdWebhooks {
+		webhooksCmd.AddCommand(&cmdWebhooks[i])
+	}
+
+	foldersCmd := cobra.Command{
+		Use:   "folders [create | list | move | remove]",
+		Short: "Rule folders management",
+		Long:  `Rule folders management: create, list or remove folders and move rules to them`,
+	}
+	for i := range cmdFolders {
+		foldersCmd.AddCommand(&cmdFolders[i])
+	}
+
+	maintenanceCmd := cobra.Command{
+		Use:   "maintenance [pause | resume | get]",
+		Short: "Maintenance mode management",
+		Long:  `Maintenance mode management: pause all of the user's running rules and resume them`,
+	}
+	for i := range cmdMaintenance {
+		maintenanceCmd.AddCommand(&cmdMaintenance[i])
+	}
+
+	chainsCmd := cobra.Command{
+		Use:   "chains [create | list | remove]",
+		Short: "Rule chains management",
+		Long:  `Rule chains management: create, list or remove chains feeding the results of rules to other rules`,
+	}
+	for i := range cmdChains {
+		chainsCmd.AddCommand(&cmdChains[i])
+	}
+
+	secretsCmd := cobra.Command{
+		Use:   "secrets [save | list | remove]",
+		Short: "Secrets management",
+		Long:  `Secrets management: save, list or remove credentials the rule actions refer to`,
+	}
+	for i := range cmdSecrets {
+		secretsCmd.AddCommand(&cmdSecrets[i])
+	}
+
+	instancesCmd := cobra.Command{
+		Use:   "instances [list | assign | unassign]",
+		Short: "Kuiper instances management",
+		Long:  `Kuiper instances management: list instances, assign users to instances or remove assignments`,
+	}
+	for i := range cmdInstances {
+		instancesCmd.AddCommand(&cmdInstances[i])
+	}
+
+	gatewaysCmd := cobra.Command{
+		Use:   "gateways [list | save | remove]",
+		Short: "Edge gateways management",
+		Long:  `Edge gateways management: register, list or remove gateways the rules are deployed to`,
+	}
+	for i := range cmdGateways {
+		gatewaysCmd.AddCommand(&cmdGateways[i])
+	}
+
+	deploymentsCmd := cobra.Command{
+		Use:   "deployments [list | deploy | undeploy | fleet | retry | rollout]",
+		Short: "Edge deployments management",
+		Long:  `Edge deployments management: deploy rules to edge gateways or fleets of them, remove them, retry failed deployments or view their statuses`,
+	}
+	for i := range cmdDeployments {
+		deploymentsCmd.AddCommand(&cmdDeployments[i])
+	}
+
+	sharesCmd := cobra.Command{
+		Use:   "shares [share | list | unshare]",
+		Short: "Shares management",
+		Long:  `Shares management: share streams and rules with users and groups, list or revoke shares`,
+	}
+	for i := range cmdShares {
+		sharesCmd.AddCommand(&cmdShares[i])
+	}
+
+	templatesCmd := cobra.Command{
+		Use:   "templates [create | list | view | delete | instantiate]",
+		Short: "Rule templates management",
+		Long:  `Rule templates management: create, list, view, delete or instantiate rule templates`,
+	}
+	for i := range cmdTemplates {
+		templatesCmd.AddCommand(&cmdTemplates[i])
+	}
+
+	pluginsCmd := cobra.Command{
+		Use:   "plugins [create | list | delete]",
+		Short: "Plugins management",
+		Long:  `Plugins management: create, list or delete Kuiper source, sink and function plugins`,
+	}
+	for i := range cmdPlugins {
+		pluginsCmd.AddCommand(&cmdPlugins[i])
+	}
+
+	servicesCmd := cobra.Command{
+		Use:   "services [register | list | delete | functions]",
+		Short: "External services management",
+		Long:  `External services management: register, list or delete external services and list their functions`,
+	}
+	for i := range cmdServices {
+		servicesCmd.AddCommand(&cmdServices[i])
+	}
+
+	confKeysCmd := cobra.Command{
+		Use:   "confkeys [save | list | delete]",
+		Short: "Conf keys management",
+		Long:  `Conf keys management: save, list or delete MQTT broker configurations of MQTT streams`,
+	}
+	for i := range cmdConfKeys {
+		confKeysCmd.AddCommand(&cmdConfKeys[i])
+	}
+
+	var auditQuery mgxsdk.AuditQuery
+	var auditFrom, auditTo string
+	auditCmd := cobra.Command{
+		Use:   "audit <user_auth_token> [--user <user_id>] [--kind stream | table | rule] [--entity <name>] [--from <time>] [--to <time>]",
+		Short: "Audit log",
+		Long: "List the audit events of the operations performed on streams, tables and rules, newest first\n" +
+			"For example:\n" +
+			"\tmagistrala-cli re audit $USER_AUTH_TOKEN --kind rule --entity alarm --from 2024-01-01T00:00:00Z\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			q := auditQuery
+			q.Offset, q.Limit, q.Owner = Offset, Limit, Owner
+			var err error
+			if auditFrom != "" {
+				if q.From, err = time.Parse(time.RFC3339, auditFrom); err != nil {
+					logError(err)
+					return
+				}
+			}
+			if auditTo != "" {
+				if q.To, err = time.Parse(time.RFC3339, auditTo); err != nil {
+					logError(err)
+					return
+				}
+			}
+			page, err := sdk.AuditEvents(q, args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(page)
+		},
+	}
+	auditCmd.Flags().StringVar(&auditQuery.User, "user", "", "ID of the user who performed the operations")
+	auditCmd.Flags().StringVar(&auditQuery.Kind, "kind", "", "kind of the entities: stream, table or rule")
+	auditCmd.Flags().StringVar(&auditQuery.Entity, "entity", "", "name of the entity")
+	auditCmd.Flags().StringVar(&auditFrom, "from", "", "RFC3339 time the events start at")
+	auditCmd.Flags().StringVar(&auditTo, "to", "", "RFC3339 time the events end at")
+
+	cmd := cobra.Command{
+		Use:   "re [streams | tables | rules | drift | restore | ruleset | bulk | all | quotas | instances | gateways | deployments | shares | templates | plugins | services | confkeys | audit]",
+		Short: "Rules engine management",
+		Long:  `Rules engine management: manage streams, tables and rules of the rules engine`,
+	}
+	cmd.AddCommand(&streamsCmd, &tablesCmd, &rulesCmd, &driftCmd, &orphansCmd, &restoreCmd, &statsCmd, &rulesetCmd, &bulkCmd, &allCmd, &quotasCmd, &alertsCmd, &webhooksCmd, &foldersCmd, &maintenanceCmd, &chainsCmd, &secretsCmd, &instancesCmd, &gatewaysCmd, &deploymentsCmd, &sharesCmd, &templatesCmd, &pluginsCmd, &servicesCmd, &confKeysCmd, &auditCmd)
+
+	return &cmd
+}
@@ -34,6 +34,14 @@ var (
 	Topic string = ""
 	// Contact query parameter.
 	Contact string = ""
+	// Owner query parameter.
+	Owner string = ""
+	// Labels query parameter.
+	Labels string = ""
+	// Folder query parameter.
+	Folder string = ""
+	// TTL time to live of the temporary rule.
+	TTL time.Duration = 0
 	// RawOutput raw output mode.
 	RawOutput bool = false
 )
@@ -87,6 +95,17 @@ func logRevokedTime(t time.Time) {
 	}
 }
 
+func convertLabels(l string) (map[string]string, error) {
+	if l == "" {
+		return nil, nil
+	}
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(l), &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
 func convertMetadata(m string) (map[string]interface{}, error) {
 	var metadata map[string]interface{}
 	if m == "" {
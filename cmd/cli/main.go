@@ -52,6 +52,7 @@ func main() {
 	subscriptionsCmd := cli.NewSubscriptionCmd()
 	configCmd := cli.NewConfigCmd()
 	invitationsCmd := cli.NewInvitationsCmd()
+	reCmd := cli.NewRulesEngineCmd()
 
 	// Root Commands
 	rootCmd.AddCommand(healthCmd)
@@ -67,6 +68,7 @@ func main() {
 	rootCmd.AddCommand(subscriptionsCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(invitationsCmd)
+	rootCmd.AddCommand(reCmd)
 
 	// Root Flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -133,6 +135,14 @@ func main() {
 		"Inivitations URL",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.REURL,
+		"re-url",
+		"e",
+		sdkConf.REURL,
+		"Rules engine service URL",
+	)
+
 	rootCmd.PersistentFlags().StringVarP(
 		&sdkConf.HostURL,
 		"host-url",
@@ -245,6 +255,37 @@ func main() {
 		"",
 		"Subscription contact query parameter",
 	)
+
+	rootCmd.PersistentFlags().StringVarP(
+		&cli.Owner,
+		"owner",
+		"O",
+		"",
+		"Rules engine group owner query parameter",
+	)
+
+	rootCmd.PersistentFlags().StringVarP(
+		&cli.Labels,
+		"labels",
+		"L",
+		"",
+		"Rules engine labels query parameter",
+	)
+
+	rootCmd.PersistentFlags().StringVarP(
+		&cli.Folder,
+		"folder",
+		"F",
+		"",
+		"Rules engine rule folder query parameter",
+	)
+
+	rootCmd.PersistentFlags().DurationVar(
+		&cli.TTL,
+		"ttl",
+		0,
+		"Rules engine temporary rule time to live",
+	)
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,681 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains rules engine main function to start the rules engine service.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal"
+	"github.com/absmach/magistrala/internal/clients/jaeger"
+	clientspg "github.com/absmach/magistrala/internal/clients/postgres"
+	"github.com/absmach/magistrala/internal/postgres"
+	"github.com/absmach/magistrala/internal/server"
+	grpcserver "github.com/absmach/magistrala/internal/server/grpc"
+	httpserver "github.com/absmach/magistrala/internal/server/http"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/auth"
+	mgevents "github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/api"
+	grpcapi "github.com/absmach/magistrala/re/api/grpc"
+	"github.com/absmach/magistrala/re/events"
+	"github.com/absmach/magistrala/re/events/consumer"
+	repg "github.com/absmach/magistrala/re/postgres"
+	"github.com/absmach/magistrala/re/tracing"
+	"github.com/caarlos0/env/v10"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const (
+	svcName        = "re"
+	envPrefixHTTP  = "MG_RE_HTTP_"
+	envPrefixGRPC  = "MG_RE_GRPC_"
+	envPrefixAuth  = "MG_AUTH_GRPC_"
+	envPrefixKuip  = "MG_RE_KUIPER_"
+	envPrefixDB    = "MG_RE_DB_"
+	defDB          = "re"
+	defSvcHTTPPort = "9021"
+	defSvcGRPCPort = "7021"
+	thingsStream   = "events.magistrala.things"
+	// statusTopic matches the deployment statuses the edge gateways report
+	// on their control channels.
+	statusTopic = "channels.*." + re.StatusSubtopic
+)
+
+type config struct {
+	LogLevel         string        `env:"MG_RE_LOG_LEVEL"             envDefault:"info"`
+	ThingsURL        string        `env:"MG_THINGS_URL"               envDefault:"http://localhost:9000"`
+	ReaderURL        string        `env:"MG_READER_URL"               envDefault:"http://localhost:9011"`
+	BootstrapURL     string        `env:"MG_BOOTSTRAP_URL"            envDefault:"http://localhost:9013"`
+	EdgeBrokerURL    string        `env:"MG_RE_EDGE_BROKER_URL"       envDefault:""`
+	SMTPNotifierURL  string        `env:"MG_RE_SMTP_NOTIFIER_URL"     envDefault:""`
+	SMPPNotifierURL  string        `env:"MG_RE_SMPP_NOTIFIER_URL"     envDefault:""`
+	ESURL            string        `env:"MG_ES_URL"                   envDefault:"nats://localhost:4222"`
+	ESConsumerName   string        `env:"MG_RE_EVENT_CONSUMER"        envDefault:"re"`
+	AutoStreams      bool          `env:"MG_RE_AUTO_STREAMS"          envDefault:"false"`
+	ReconcileEvery   time.Duration `env:"MG_RE_RECONCILE_INTERVAL"    envDefault:"1h"`
+	ReconcileRepair  bool          `env:"MG_RE_RECONCILE_REPAIR"      envDefault:"false"`
+	StateCheckEvery  time.Duration `env:"MG_RE_STATE_CHECK_INTERVAL"  envDefault:"30s"`
+	OrphansEvery     time.Duration `env:"MG_RE_ORPHANS_INTERVAL"      envDefault:"24h"`
+	OrphansRemove    bool          `env:"MG_RE_ORPHANS_REMOVE"        envDefault:"false"`
+	OrphansMinAge    time.Duration `env:"MG_RE_ORPHANS_MIN_AGE"       envDefault:"24h"`
+	PurgeEvery       time.Duration `env:"MG_RE_PURGE_INTERVAL"        envDefault:"1h"`
+	ExpireEvery      time.Duration `env:"MG_RE_EXPIRE_INTERVAL"       envDefault:"1m"`
+	ScheduleEvery    time.Duration `env:"MG_RE_SCHEDULE_INTERVAL"     envDefault:"30s"`
+	RuleMetricsEvery time.Duration `env:"MG_RE_RULE_METRICS_INTERVAL" envDefault:"30s"`
+	AlertsEvery      time.Duration `env:"MG_RE_ALERTS_INTERVAL"       envDefault:"1m"`
+	AlertsBrokerURL  string        `env:"MG_RE_ALERTS_BROKER_URL"     envDefault:""`
+	JaegerURL        url.URL       `env:"MG_JAEGER_URL"               envDefault:"http://localhost:14268/api/traces"`
+	TraceRatio       float64       `env:"MG_JAEGER_TRACE_RATIO"       envDefault:"1.0"`
+	InstanceID       string        `env:"MG_RE_INSTANCE_ID"           envDefault:""`
+	SendTelemetry    bool          `env:"MG_SEND_TELEMETRY"           envDefault:"true"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err.Error())
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	kuiperConfig := re.Config{}
+	if err := env.ParseWithOptions(&kuiperConfig, env.Options{Prefix: envPrefixKuip}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s Kuiper configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	if _, err := kuiperConfig.TLS.Load(); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s Kuiper TLS configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	if err := kuiperConfig.Auth.Validate(); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s Kuiper authentication configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	if err := kuiperConfig.Pool.Validate(); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s Kuiper pool configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	if err := kuiperConfig.Secrets.Validate(); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s secrets configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	if err := kuiperConfig.Encryption.Validate(); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s encryption configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	if err := kuiperConfig.Push.Validate(); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s httppush configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	dbConfig := clientspg.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s database configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	db, err := clientspg.Setup(dbConfig, *repg.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	authConfig := auth.Config{}
+	if err := env.ParseWithOptions(&authConfig, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load auth configuration : %s", err.Error()))
+		exitCode = 1
+		return
+	}
+	authClient, authHandler, err := auth.Setup(authConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authHandler.Close()
+	logger.Info("Successfully connected to auth grpc server " + authHandler.Secure())
+
+	tp, err := jaeger.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("error shutting down tracer provider: %v", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	repo := repg.NewRepository(postgres.NewDatabase(db, dbConfig, tracer))
+	// The service and the background jobs share the engine, so they share
+	// its HTTP client and circuit breakers, and the Kuiper versions detected
+	// at startup apply to all of them.
+	engine := re.NewEngine(kuiperConfig, repo)
+
+	sdk := mgsdk.NewSDK(mgsdk.Config{ThingsURL: cfg.ThingsURL, ReaderURL: cfg.ReaderURL, BootstrapURL: cfg.BootstrapURL})
+	notifiers := re.Notifiers{}
+	if cfg.SMTPNotifierURL != "" {
+		notifiers.Email = mgsdk.NewSDK(mgsdk.Config{UsersURL: cfg.SMTPNotifierURL})
+	}
+	if cfg.SMPPNotifierURL != "" {
+		notifiers.SMS = mgsdk.NewSDK(mgsdk.Config{UsersURL: cfg.SMPPNotifierURL})
+	}
+	// Rules are deployed to the edge gateways only with the message broker
+	// the gateways' control channels are reached through.
+	var edge messaging.Publisher
+	if cfg.EdgeBrokerURL != "" {
+		pubSub, err := brokers.NewPubSub(ctx, cfg.EdgeBrokerURL, logger)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+			exitCode = 1
+			return
+		}
+		defer pubSub.Close()
+		if err := subscribeToStatuses(ctx, pubSub, re.NewDeploymentsHandler(repo), logger); err != nil {
+			logger.Error(fmt.Sprintf("failed to subscribe to edge deployment statuses: %s", err))
+			exitCode = 1
+			return
+		}
+		edge = pubSub
+	}
+
+	svc, err := newService(ctx, engine, kuiperConfig, authClient, sdk, notifiers, edge, repo, cfg.ESURL, tracer, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s service: %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	switch info, err := svc.Info(ctx); {
+	case err != nil:
+		logger.Warn(fmt.Sprintf("failed to detect Kuiper version, assuming eKuiper 1.x: %s", err))
+	case info.Version == "":
+		logger.Warn("Kuiper is unavailable, assuming eKuiper 1.x")
+	default:
+		logger.Info(fmt.Sprintf("Connected to Kuiper %s", info.Version))
+	}
+
+	if err = subscribeToThingsES(ctx, re.NewChannelsHandler(engine, kuiperConfig, authClient, repo), cfg, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to things event store: %s", err))
+		exitCode = 1
+		return
+	}
+
+	logger.Info("Subscribed to Event Store")
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	hs := httpserver.New(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, kuiperConfig, logger, cfg.InstanceID), logger)
+
+	grpcServerConfig := server.Config{Port: defSvcGRPCPort}
+	if err := env.ParseWithOptions(&grpcServerConfig, env.Options{Prefix: envPrefixGRPC}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s gRPC server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	registerRulesEngineServer := func(srv *grpc.Server) {
+		reflection.Register(srv)
+		grpcapi.RegisterRulesEngineServiceServer(srv, grpcapi.NewServer(svc))
+	}
+	gs := grpcserver.New(ctx, cancel, svcName, grpcServerConfig, registerRulesEngineServer, logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return gs.Start()
+	})
+
+	reconciler := re.NewReconciler(engine, kuiperConfig, repo)
+	if cfg.ReconcileEvery > 0 {
+		g.Go(func() error {
+			reconcile(ctx, reconciler, cfg, logger)
+			return nil
+		})
+	}
+	if cfg.StateCheckEvery > 0 {
+		g.Go(func() error {
+			restoreStates(ctx, reconciler, cfg, logger)
+			return nil
+		})
+	}
+	if cfg.OrphansEvery > 0 {
+		collector := re.NewCollector(engine, kuiperConfig, authClient, repo)
+		g.Go(func() error {
+			collectOrphans(ctx, collector, cfg, logger)
+			return nil
+		})
+	}
+	if cfg.PurgeEvery > 0 && kuiperConfig.DeleteRetention > 0 {
+		purger := re.NewPurger(engine, kuiperConfig, repo)
+		g.Go(func() error {
+			purgeRules(ctx, purger, cfg, logger)
+			return nil
+		})
+	}
+	if cfg.ExpireEvery > 0 {
+		expirer, err := events.NewExpirerMiddleware(ctx, re.NewExpirer(engine, kuiperConfig, repo), cfg.ESURL)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to create rules expirer: %s", err))
+			exitCode = 1
+			return
+		}
+		g.Go(func() error {
+			expireRules(ctx, expirer, cfg, logger)
+			return nil
+		})
+	}
+	if cfg.ScheduleEvery > 0 {
+		scheduler := re.NewScheduler(engine, kuiperConfig, repo)
+		g.Go(func() error {
+			scheduleRules(ctx, scheduler, cfg, logger)
+			return nil
+		})
+	}
+
+	if cfg.RuleMetricsEvery > 0 {
+		collector := api.NewRuleCollector(svcName)
+		prometheus.MustRegister(collector)
+		scraper := re.NewScraper(engine, kuiperConfig, repo)
+		g.Go(func() error {
+			scrapeRuleMetrics(ctx, scraper, collector, cfg, logger)
+			return nil
+		})
+	}
+
+	if cfg.AlertsEvery > 0 {
+		// Alerts reach the notifiers only through the message broker, and
+		// without it only the webhooks are alerted.
+		var alerts messaging.Publisher
+		if cfg.AlertsBrokerURL != "" {
+			pub, err := brokers.NewPublisher(ctx, cfg.AlertsBrokerURL)
+			if err != nil {
+				logger.Error(fmt.Sprintf("failed to connect to alerts message broker: %s", err))
+				exitCode = 1
+				return
+			}
+			defer pub.Close()
+			alerts = pub
+		}
+		monitor := re.NewMonitor(engine, kuiperConfig, repo, alerts)
+		g.Go(func() error {
+			monitorRules(ctx, monitor, cfg, logger)
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs, gs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("%s service terminated: %s", svcName, err))
+	}
+}
+
+func newService(ctx context.Context, engine re.RuleEngine, kuiperConfig re.Config, authClient magistrala.AuthServiceClient, sdk mgsdk.SDK, notifiers re.Notifiers, edge messaging.Publisher, repo re.Repository, esURL string, tracer trace.Tracer, logger *slog.Logger) (re.Service, error) {
+	svc := re.NewWithEngine(engine, kuiperConfig, authClient, sdk, notifiers, edge, repo)
+	svc, err := events.NewEventStoreMiddleware(ctx, svc, esURL)
+	if err != nil {
+		return nil, err
+	}
+	svc = tracing.New(svc, tracer)
+	svc = api.LoggingMiddleware(svc, logger)
+	counter, latency := internal.MakeMetrics(svcName, "api")
+	svc = api.MetricsMiddleware(svc, counter, latency)
+
+	return svc, nil
+}
+
+func subscribeToThingsES(ctx context.Context, handler re.ChannelsHandler, cfg config, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, cfg.ESURL, logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := mgevents.SubscriberConfig{
+		Stream:   thingsStream,
+		Consumer: cfg.ESConsumerName,
+		Handler:  consumer.NewEventHandler(handler, cfg.AutoStreams),
+	}
+	return subscriber.Subscribe(ctx, subConfig)
+}
+
+// subscribeToStatuses updates the rule deployments with the statuses the
+// edge gateways report.
+func subscribeToStatuses(ctx context.Context, sub messaging.Subscriber, handler re.DeploymentsHandler, logger *slog.Logger) error {
+	subConfig := messaging.SubscriberConfig{
+		ID:    svcName,
+		Topic: statusTopic,
+		Handler: handlerFunc(func(msg *messaging.Message) error {
+			var report re.DeploymentReport
+			if err := json.Unmarshal(msg.GetPayload(), &report); err != nil {
+				logger.Warn(fmt.Sprintf("malformed deployment status of gateway %s: %s", msg.GetPublisher(), err))
+				return nil
+			}
+			report.Gateway, report.Channel = msg.GetPublisher(), msg.GetChannel()
+			if err := handler.ReportDeploymentHandler(ctx, report); err != nil {
+				logger.Warn(fmt.Sprintf("failed to update deployment status of rule %s on gateway %s: %s", report.Rule, report.Gateway, err))
+			}
+			return nil
+		}),
+	}
+
+	return sub.Subscribe(ctx, subConfig)
+}
+
+type handlerFunc func(msg *messaging.Message) error
+
+func (h handlerFunc) Handle(msg *messaging.Message) error {
+	return h(msg)
+}
+
+func (h handlerFunc) Cancel() error {
+	return nil
+}
+
+// reconcile periodically compares the stored metadata with the Kuiper state
+// and logs the drifts found, repairing them if configured to.
+func reconcile(ctx context.Context, r re.Reconciler, cfg config, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.ReconcileEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := r.Reconcile(ctx, cfg.ReconcileRepair)
+			if err != nil {
+				logger.Warn(fmt.Sprintf("failed to reconcile metadata with Kuiper: %s", err))
+				continue
+			}
+			for _, d := range report.Drifts {
+				logger.Warn("Drift between metadata and Kuiper",
+					slog.String("kind", d.Kind),
+					slog.String("name", d.Name),
+					slog.String("missing", d.Missing),
+					slog.Bool("repaired", d.Repaired),
+				)
+			}
+		}
+	}
+}
+
+// restoreStates periodically checks whether Kuiper restarted and, if it did,
+// brings the rules back to the states their owners want them in.
+func restoreStates(ctx context.Context, r re.Reconciler, cfg config, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.StateCheckEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changes, err := r.RestoreStates(ctx)
+			if err != nil {
+				logger.Warn(fmt.Sprintf("failed to restore rule states: %s", err))
+				continue
+			}
+			for _, c := range changes {
+				if c.Error != "" {
+					logger.Warn("Failed to restore rule state",
+						slog.String("name", c.Name),
+						slog.String("state", c.State),
+						slog.String("error", c.Error),
+					)
+					continue
+				}
+				logger.Info("Restored rule state",
+					slog.String("name", c.Name),
+					slog.String("state", c.State),
+				)
+			}
+		}
+	}
+}
+
+// collectOrphans periodically looks for the streams and rules that can't do
+// any useful work and logs them, removing them if configured to.
+func collectOrphans(ctx context.Context, c re.Collector, cfg config, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.OrphansEvery)
+	defer ticker.Stop()
+
+	policy := re.OrphanPolicy{Remove: cfg.OrphansRemove, MinAge: cfg.OrphansMinAge}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := c.CollectOrphans(ctx, policy)
+			if err != nil {
+				logger.Warn(fmt.Sprintf("failed to collect orphans: %s", err))
+				continue
+			}
+			for _, o := range report.Orphans {
+				logger.Warn("Orphaned stream or rule",
+					slog.String("kind", o.Kind),
+					slog.String("name", o.Name),
+					slog.String("reason", o.Reason),
+					slog.Bool("removed", o.Removed),
+				)
+			}
+		}
+	}
+}
+
+// purgeRules periodically removes the rules deleted longer than the
+// retention period ago.
+func purgeRules(ctx context.Context, p re.Purger, cfg config, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.PurgeEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := p.Purge(ctx)
+			if err != nil {
+				logger.Warn(fmt.Sprintf("failed to purge deleted rules: %s", err))
+				continue
+			}
+			for _, r := range purged {
+				if r.Error != "" {
+					logger.Warn("Failed to purge deleted rule",
+						slog.String("name", r.Name),
+						slog.String("error", r.Error),
+					)
+					continue
+				}
+				logger.Info("Purged deleted rule",
+					slog.String("name", r.Name),
+					slog.String("owner", r.Owner),
+					slog.Time("deleted_at", r.DeletedAt),
+				)
+			}
+		}
+	}
+}
+
+// expireRules periodically removes the temporary rules whose expiry time
+// passed.
+func expireRules(ctx context.Context, e re.Expirer, cfg config, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.ExpireEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := e.Expire(ctx)
+			if err != nil {
+				logger.Warn(fmt.Sprintf("failed to remove expired rules: %s", err))
+				continue
+			}
+			for _, r := range expired {
+				if r.Error != "" {
+					logger.Warn("Failed to remove expired rule",
+						slog.String("name", r.Name),
+						slog.String("error", r.Error),
+					)
+					continue
+				}
+				logger.Info("Removed expired rule",
+					slog.String("name", r.Name),
+					slog.String("owner", r.Owner),
+					slog.Time("expires_at", r.ExpiresAt),
+				)
+			}
+		}
+	}
+}
+
+// scheduleRules periodically starts the scheduled rules whose schedules
+// became active and stops the ones whose schedules became inactive.
+func scheduleRules(ctx context.Context, s re.Scheduler, cfg config, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.ScheduleEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changes, err := s.Schedule(ctx)
+			if err != nil {
+				logger.Warn(fmt.Sprintf("failed to schedule rules: %s", err))
+				continue
+			}
+			for _, c := range changes {
+				if c.Error != "" {
+					logger.Warn("Failed to schedule rule",
+						slog.String("name", c.Name),
+						slog.String("state", c.State),
+						slog.String("error", c.Error),
+					)
+					continue
+				}
+				logger.Info("Scheduled rule",
+					slog.String("name", c.Name),
+					slog.String("state", c.State),
+				)
+			}
+		}
+	}
+}
+
+// scrapeRuleMetrics periodically reads the execution metrics of all the
+// rules from Kuiper and exposes them on /metrics.
+func scrapeRuleMetrics(ctx context.Context, s re.Scraper, c *api.RuleCollector, cfg config, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.RuleMetricsEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			metrics, err := s.Scrape(ctx)
+			if err != nil {
+				logger.Warn(fmt.Sprintf("failed to scrape rule metrics: %s", err))
+				continue
+			}
+			c.Update(metrics)
+		}
+	}
+}
+
+// monitorRules periodically checks the rules of the owners with alert
+// policies and logs the alerts sent about the failing rules.
+func monitorRules(ctx context.Context, m re.Monitor, cfg config, logger *slog.Logger) {
+	ticker := time.NewTicker(cfg.AlertsEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			alerts, err := m.Check(ctx)
+			if err != nil {
+				logger.Warn(fmt.Sprintf("failed to check failing rules: %s", err))
+				continue
+			}
+			for _, a := range alerts {
+				args := []any{
+					slog.String("owner", a.Owner),
+					slog.String("rule", a.Rule),
+					slog.String("reason", a.Reason),
+					slog.String("error", a.Error),
+				}
+				if a.Delivery != "" {
+					logger.Warn("Failed to alert about failing rule", append(args, slog.String("delivery", a.Delivery))...)
+					continue
+				}
+				logger.Info("Alerted about failing rule", args...)
+			}
+		}
+	}
+}
@@ -130,6 +130,16 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, svcerr.ErrPasswordFormat),
 		errors.Contains(err, apiutil.ErrInvalidLevel),
 		errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		errors.Contains(err, apiutil.ErrMissingSQL),
+		errors.Contains(err, apiutil.ErrMissingFields),
+		errors.Contains(err, apiutil.ErrMissingTopic),
+		errors.Contains(err, apiutil.ErrMissingPluginFile),
+		errors.Contains(err, apiutil.ErrMissingServiceFile),
+		errors.Contains(err, apiutil.ErrMissingBrokerServer),
+		errors.Contains(err, apiutil.ErrMissingFrom),
+		errors.Contains(err, apiutil.ErrMissingTo),
+		errors.Contains(err, apiutil.ErrMissingNewName),
+		errors.Contains(err, apiutil.ErrMissingReference),
 		errors.Contains(err, apiutil.ErrValidation):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, svcerr.ErrAuthentication),
@@ -176,4 +176,29 @@ var (
 
 	// ErrMissingTo indicates missing to value.
 	ErrMissingTo = errors.New("missing to time value")
+
+	// ErrMissingNewName indicates missing new name of renamed entity.
+	ErrMissingNewName = errors.New("missing new name")
+
+	// ErrMissingReference indicates missing stream or channel the searched
+	// rules reference.
+	ErrMissingReference = errors.New("missing stream or channel")
+
+	// ErrMissingSQL indicates missing rule SQL.
+	ErrMissingSQL = errors.New("missing rule SQL")
+
+	// ErrMissingFields indicates missing stream fields.
+	ErrMissingFields = errors.New("missing stream fields")
+
+	// ErrMissingTopic indicates missing stream topic.
+	ErrMissingTopic = errors.New("missing stream topic")
+
+	// ErrMissingPluginFile indicates missing plugin file URL.
+	ErrMissingPluginFile = errors.New("missing plugin file")
+
+	// ErrMissingServiceFile indicates missing external service file URL.
+	ErrMissingServiceFile = errors.New("missing external service file")
+
+	// ErrMissingBrokerServer indicates missing MQTT broker server URL.
+	ErrMissingBrokerServer = errors.New("missing broker server")
 )
@@ -44,6 +44,8 @@ func (sdk mgSDK) Health(service string) (HealthInfo, errors.SDKError) {
 		url = fmt.Sprintf("%s/health", sdk.readerURL)
 	case "http-adapter":
 		url = fmt.Sprintf("%s/health", sdk.httpAdapterURL)
+	case "re":
+		url = fmt.Sprintf("%s/health", sdk.reURL)
 	}
 
 	resp, err := sdk.client.Get(url)
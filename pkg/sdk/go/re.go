@@ -0,0 +1,2451 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	streamsEndpoint   = "streams"
+	tablesEndpoint    = "tables"
+	rulesEndpoint     = "rules"
+	searchEndpoint    = "search"
+	driftEndpoint     = "drift"
+	orphansEndpoint   = "orphans"
+	restoreEndpoint   = "restore"
+	rulesetEndpoint   = "ruleset"
+	bulkEndpoint      = "bulk"
+	allEndpoint       = "all"
+	quotasEndpoint    = "quotas"
+	sharesEndpoint    = "shares"
+	templatesEndpoint = "templates"
+	pluginsEndpoint   = "plugins"
+	servicesEndpoint  = "services"
+	functionsEndpoint = "functions"
+	confKeysEndpoint  = "confkeys"
+	auditEndpoint     = "audit"
+	instancesEndpoint = "instances"
+	gatewaysEndpoint  = "gateways"
+	deploysEndpoint   = "deployments"
+	rolloutEndpoint   = "rollout"
+	statsEndpoint     = "stats"
+	alertsEndpoint    = "alerts"
+	webhooksEndpoint  = "webhooks"
+	foldersEndpoint   = "folders"
+	maintEndpoint     = "maintenance"
+	chainsEndpoint    = "chains"
+	secretsEndpoint   = "secrets"
+	ingestEndpoint    = "ingest"
+)
+
+// Stream represents the rules engine stream definition. Fields is the stream
+// schema and Topic is the data source of the stream, whose meaning depends on
+// Type: the channel ID for mainflux (default) and mqtt streams, the memory
+// topic for memory streams, the file name for file streams and the channel
+// ID of the things pushing the messages for httppush streams. Format is one
+// of json (default), binary, delimited and protobuf. Delimiter is used by the
+// delimited format and SchemaID identifies the message of the protobuf format.
+// SenML streams are created with the fields of the SenML record. MQTT streams
+// subscribe with the user's ConfKey, if set. Description, Labels and the
+// free-form Attributes are stored as the stream metadata.
+type Stream struct {
+	Name      string        `json:"name"`
+	Topic     string        `json:"topic,omitempty"`
+	Fields    []SchemaField `json:"fields,omitempty"`
+	Type      string        `json:"type,omitempty"`
+	Format    string        `json:"format,omitempty"`
+	Delimiter string        `json:"delimiter,omitempty"`
+	SchemaID  string        `json:"schema_id,omitempty"`
+	SenML     bool          `json:"senml,omitempty"`
+	ConfKey   string        `json:"conf_key,omitempty"`
+
+	Description string                 `json:"description,omitempty"`
+	Labels      map[string]string      `json:"labels,omitempty"`
+	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// SchemaField represents the field of the stream schema. Type is one of
+// bigint, float, string, datetime, boolean, bytea, array and struct. Items is
+// the element type of the array field, while Fields contains fields of the
+// struct field or of the array elements that are structs.
+type SchemaField struct {
+	Name   string        `json:"name"`
+	Type   string        `json:"type"`
+	Items  string        `json:"items,omitempty"`
+	Fields []SchemaField `json:"fields,omitempty"`
+}
+
+// StreamInfo represents the stream as defined in Kuiper, along with the
+// stream metadata.
+type StreamInfo struct {
+	Name         string            `json:"Name"`
+	StreamFields []StreamField     `json:"StreamFields"`
+	Options      map[string]string `json:"Options"`
+	Metadata     *EntityMetadata   `json:"metadata,omitempty"`
+	IngestURL    string            `json:"ingest_url,omitempty"`
+}
+
+// StreamsBatch contains the streams fetched by ViewStreams and the names of
+// the requested streams that don't exist.
+type StreamsBatch struct {
+	Streams []StreamInfo `json:"streams"`
+	Missing []string     `json:"missing,omitempty"`
+}
+
+// EntityMetadata contains the rules engine stream and rule information
+// Kuiper doesn't store.
+type EntityMetadata struct {
+	ID            string                 `json:"id,omitempty"`
+	Owner         string                 `json:"owner"`
+	Description   string                 `json:"description,omitempty"`
+	Labels        map[string]string      `json:"labels,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at,omitempty"`
+	LastStartedAt time.Time              `json:"last_started_at,omitempty"`
+	Stopped       bool                   `json:"stopped,omitempty"`
+	Draft         bool                   `json:"draft,omitempty"`
+	Folder        string                 `json:"folder,omitempty"`
+	ExpiresAt     time.Time              `json:"expires_at,omitempty"`
+	Schedule      *RuleSchedule          `json:"schedule,omitempty"`
+}
+
+// StreamField represents the stream schema field.
+type StreamField struct {
+	Name      string      `json:"Name"`
+	FieldType interface{} `json:"FieldType"`
+}
+
+// StreamsPage contains page related metadata as well as list of stream names
+// and the stream metadata mapped by the names.
+type StreamsPage struct {
+	Total    uint64                    `json:"total"`
+	Offset   uint64                    `json:"offset"`
+	Limit    uint64                    `json:"limit"`
+	Streams  []string                  `json:"streams"`
+	Metadata map[string]EntityMetadata `json:"metadata,omitempty"`
+}
+
+// Table represents the rules engine table holding the reference data rules
+// join the streams with. Topic is the file name for file (default) tables and
+// the memory topic for memory tables. Kind is either scan (default) or
+// lookup, whose tables must be memory tables looking up the rows by the Key
+// field. Description and Labels are stored as the table metadata.
+type Table struct {
+	Name   string        `json:"name"`
+	Topic  string        `json:"topic,omitempty"`
+	Fields []SchemaField `json:"fields,omitempty"`
+	Type   string        `json:"type,omitempty"`
+	Kind   string        `json:"kind,omitempty"`
+	Key    string        `json:"key,omitempty"`
+
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// TableInfo represents the table as defined in Kuiper, along with the table
+// metadata.
+type TableInfo struct {
+	Name         string            `json:"Name"`
+	StreamFields []StreamField     `json:"StreamFields"`
+	Options      map[string]string `json:"Options"`
+	Metadata     *EntityMetadata   `json:"metadata,omitempty"`
+}
+
+// TablesPage contains page related metadata as well as list of table names
+// and the table metadata mapped by the names.
+type TablesPage struct {
+	Total    uint64                    `json:"total"`
+	Offset   uint64                    `json:"offset"`
+	Limit    uint64                    `json:"limit"`
+	Tables   []string                  `json:"tables"`
+	Metadata map[string]EntityMetadata `json:"metadata,omitempty"`
+}
+
+// RulesEnginePlugin represents the Kuiper plugin installed from the zip file
+// Kuiper downloads from the File URL. ShellParas are the arguments of the
+// plugin install script and Functions lists the functions exported by the
+// function plugin.
+type RulesEnginePlugin struct {
+	Name       string   `json:"name"`
+	File       string   `json:"file"`
+	ShellParas []string `json:"shellParas,omitempty"`
+	Functions  []string `json:"functions,omitempty"`
+}
+
+// ExternalService represents the external service Kuiper calls as SQL
+// functions, registered from the zip file Kuiper downloads from the File URL.
+type ExternalService struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
+// ExternalFunction represents the SQL function mapped to the method of the
+// interface of the external service.
+type ExternalFunction struct {
+	Name          string `json:"name"`
+	ServiceName   string `json:"serviceName"`
+	InterfaceName string `json:"interfaceName"`
+	MethodName    string `json:"methodName"`
+}
+
+// MQTTConf represents the configuration of the connection to the MQTT
+// broker the user's MQTT streams subscribe with, usually with the ID and the
+// key of the user's thing as Username and Password. The TLS client
+// certificate, private key and root CA are base64 encoded PEM.
+type MQTTConf struct {
+	Server             string `json:"server"`
+	Username           string `json:"username,omitempty"`
+	Password           string `json:"password,omitempty"`
+	ClientID           string `json:"clientid,omitempty"`
+	QoS                int    `json:"qos,omitempty"`
+	ProtocolVersion    string `json:"protocolVersion,omitempty"`
+	CertificationRaw   string `json:"certificationRaw,omitempty"`
+	PrivateKeyRaw      string `json:"privateKeyRaw,omitempty"`
+	RootCARaw          string `json:"rootCaRaw,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+// Rule represents the rules engine rule which processes stream messages with
+// SQL and sends the results to the actions. Description, Labels, the
+// free-form Attributes, ExpiresAt, the time the temporary rule is removed
+// at, and Schedule, the times the rule runs at, are stored as the rule
+// metadata, returned in Metadata when the rule is viewed.
+type Rule struct {
+	ID      string       `json:"id"`
+	SQL     string       `json:"sql"`
+	Actions []RuleAction `json:"actions"`
+	Options *RuleOptions `json:"options,omitempty"`
+
+	Description string                 `json:"description,omitempty"`
+	Labels      map[string]string      `json:"labels,omitempty"`
+	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+	ExpiresAt   time.Time              `json:"expires_at,omitempty"`
+	Schedule    *RuleSchedule          `json:"schedule,omitempty"`
+	Metadata    *EntityMetadata        `json:"metadata,omitempty"`
+}
+
+// RuleSchedule runs the rule either for Duration, e.g. "8h", after each time
+// matching the Cron expression, e.g. "0 9 * * 1-5", or within any of the
+// daily Windows, in the Timezone, UTC by default.
+type RuleSchedule struct {
+	Cron     string           `json:"cron,omitempty"`
+	Duration string           `json:"duration,omitempty"`
+	Windows  []ScheduleWindow `json:"windows,omitempty"`
+	Timezone string           `json:"timezone,omitempty"`
+}
+
+// ScheduleWindow is the daily window from Start to End, e.g. "09:00" and
+// "17:00", on the given Days, e.g. "mon", or every day if Days are empty.
+type ScheduleWindow struct {
+	Days  []string `json:"days,omitempty"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+}
+
+// RulePatch contains the rule fields changed by PatchRule. The fields that
+// aren't set are kept.
+type RulePatch struct {
+	SQL     *string      `json:"sql,omitempty"`
+	Actions []RuleAction `json:"actions,omitempty"`
+	Options *RuleOptions `json:"options,omitempty"`
+}
+
+// RuleOptions represents the optional rule options. QoS is 0 (at most once),
+// 1 (at least once) or 2 (exactly once) and CheckpointInterval and
+// LateTolerance are in milliseconds.
+type RuleOptions struct {
+	QoS                int   `json:"qos,omitempty"`
+	CheckpointInterval int64 `json:"checkpointInterval,omitempty"`
+	IsEventTime        bool  `json:"isEventTime,omitempty"`
+	LateTolerance      int64 `json:"lateTolerance,omitempty"`
+	SendMetaToSink     bool  `json:"sendMetaToSink,omitempty"`
+	Concurrency        int   `json:"concurrency,omitempty"`
+}
+
+// RuleAction represents the rule action. Exactly one of the sinks must be set,
+// while the optional Delivery and DataTemplate apply to any of them.
+type RuleAction struct {
+	Mainflux     *MainfluxSink     `json:"mainflux,omitempty"`
+	REST         *RESTSink         `json:"rest,omitempty"`
+	MQTT         *MQTTSink         `json:"mqtt,omitempty"`
+	Log          *LogSink          `json:"log,omitempty"`
+	Nop          *NopSink          `json:"nop,omitempty"`
+	Writer       *WriterSink       `json:"writer,omitempty"`
+	Email        *NotificationSink `json:"email,omitempty"`
+	SMS          *NotificationSink `json:"sms,omitempty"`
+	Memory       *MemorySink       `json:"memory,omitempty"`
+	Delivery     *Delivery         `json:"delivery,omitempty"`
+	DataTemplate string            `json:"dataTemplate,omitempty"`
+}
+
+// MainfluxSink publishes the rule results to the channel.
+type MainfluxSink struct {
+	Host     string `json:"host,omitempty"`
+	Port     string `json:"port,omitempty"`
+	Channel  string `json:"channel"`
+	Subtopic string `json:"subtopic,omitempty"`
+}
+
+// RESTSink sends the rule results to the HTTP endpoint. Timeout is in
+// milliseconds.
+type RESTSink struct {
+	URL          string            `json:"url"`
+	Method       string            `json:"method,omitempty"`
+	BodyType     string            `json:"bodyType,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Timeout      int64             `json:"timeout,omitempty"`
+	SendSingle   bool              `json:"sendSingle,omitempty"`
+	DataTemplate string            `json:"dataTemplate,omitempty"`
+}
+
+// MQTTSink publishes the rule results to the topic of the external broker.
+type MQTTSink struct {
+	Server     string `json:"server"`
+	Topic      string `json:"topic"`
+	QoS        int    `json:"qos,omitempty"`
+	ClientID   string `json:"clientId,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+	Retained   bool   `json:"retained,omitempty"`
+	SendSingle bool   `json:"sendSingle,omitempty"`
+}
+
+// LogSink writes the rule results to the rules engine log.
+type LogSink struct{}
+
+// NopSink discards the rule results.
+type NopSink struct {
+	Log bool `json:"log,omitempty"`
+}
+
+// NotificationSink sends the rule results to the contacts through the email
+// or SMS notifier.
+type NotificationSink struct {
+	Channel  string   `json:"channel"`
+	Contacts []string `json:"contacts"`
+}
+
+// MemorySink publishes the rule results to the memory topic the memory
+// streams of other rules read from.
+type MemorySink struct {
+	Topic string `json:"topic"`
+}
+
+// Delivery sets the retries and the cache of the action sink, so the results
+// the target fails to receive are retried, cached and resent, or published
+// to the dead-letter channel. Intervals are in milliseconds.
+type Delivery struct {
+	RetryCount           int         `json:"retryCount,omitempty"`
+	RetryInterval        int64       `json:"retryInterval,omitempty"`
+	EnableCache          bool        `json:"enableCache,omitempty"`
+	MemoryCacheThreshold int         `json:"memoryCacheThreshold,omitempty"`
+	MaxDiskCache         int         `json:"maxDiskCache,omitempty"`
+	BufferPageSize       int         `json:"bufferPageSize,omitempty"`
+	ResendInterval       int64       `json:"resendInterval,omitempty"`
+	CleanCacheAtStop     bool        `json:"cleanCacheAtStop,omitempty"`
+	DeadLetter           *DeadLetter `json:"deadLetter,omitempty"`
+}
+
+// DeadLetter publishes the results the sink failed to deliver to the
+// channel subtopic. Only the sinks publishing to the broker accept it.
+type DeadLetter struct {
+	Channel  string `json:"channel"`
+	Subtopic string `json:"subtopic,omitempty"`
+}
+
+// WriterSink writes the rule results to the table of the writer database.
+// Type is one of influxdb, postgres and timescale.
+type WriterSink struct {
+	Type   string   `json:"type"`
+	Table  string   `json:"table"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+// RuleInfo contains rule ID and status.
+type RuleInfo struct {
+	ID       string          `json:"id"`
+	Status   string          `json:"status"`
+	Metadata *EntityMetadata `json:"metadata,omitempty"`
+}
+
+// RulesBatch contains the rules fetched by ViewRules and the IDs of the
+// requested rules that don't exist.
+type RulesBatch struct {
+	Rules   []Rule   `json:"rules"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+// RulesPage contains page related metadata as well as list of rules.
+type RulesPage struct {
+	Total  uint64     `json:"total"`
+	Offset uint64     `json:"offset"`
+	Limit  uint64     `json:"limit"`
+	Rules  []RuleInfo `json:"rules"`
+}
+
+// RuleStatus contains runtime status and metrics of the rule.
+type RuleStatus struct {
+	Status    string            `json:"status"`
+	Message   string            `json:"message,omitempty"`
+	Operators []OperatorMetrics `json:"operators,omitempty"`
+}
+
+// RuleLogEntry is the line of the Kuiper log related to the rule.
+type RuleLogEntry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+	File    string    `json:"file,omitempty"`
+}
+
+// RuleTopology is the graph of the operators the rule runs as. Nodes are
+// of the source, operator or sink type, and the data flows along the edges.
+type RuleTopology struct {
+	Nodes []TopologyNode `json:"nodes"`
+	Edges []TopologyEdge `json:"edges"`
+}
+
+// TopologyNode is the operator of the rule topology.
+type TopologyNode struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TopologyEdge connects the node sending the data to the node receiving it.
+type TopologyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// RuleDiagnostic is the problem found in the validated rule. Field is the
+// rule field it refers to, or empty for the whole rule, and Position is the
+// 1-based byte offset in the rule SQL.
+type RuleDiagnostic struct {
+	Field    string `json:"field,omitempty"`
+	Position int    `json:"position,omitempty"`
+	Message  string `json:"message"`
+}
+
+// RuleValidation is the result of the rule validation.
+type RuleValidation struct {
+	Valid       bool             `json:"valid"`
+	Diagnostics []RuleDiagnostic `json:"diagnostics"`
+}
+
+// RuleTrial contains the rule to test and the sample messages fed to it,
+// keyed by the names of the streams the rule reads from.
+type RuleTrial struct {
+	Rule    Rule                                `json:"rule"`
+	Samples map[string][]map[string]interface{} `json:"samples"`
+}
+
+// TrialResult contains the results the rule produced from the samples.
+type TrialResult struct {
+	Results []map[string]interface{} `json:"results"`
+}
+
+// ReplayResult contains the results the rule produced from the replayed
+// historical messages. Truncated is set if the oldest messages of the
+// period were left out.
+type ReplayResult struct {
+	Messages  int                      `json:"messages"`
+	Truncated bool                     `json:"truncated"`
+	Results   []map[string]interface{} `json:"results"`
+}
+
+// Drift is the stream or rule that exists only in Kuiper or only in the
+// rules engine metadata store. Name is the Kuiper name of the entity and
+// Missing is the store the entity is missing from, kuiper or metadata.
+type Drift struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Owner    string `json:"owner,omitempty"`
+	Missing  string `json:"missing"`
+	Repaired bool   `json:"repaired"`
+	Error    string `json:"error,omitempty"`
+}
+
+// DriftReport contains the drifts between the rules engine metadata store
+// and Kuiper found at the given time.
+type DriftReport struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Drifts    []Drift   `json:"drifts"`
+}
+
+// Orphan is the stream or rule that can't do any useful work. Name is the
+// Kuiper name of the entity and Reason is one of unused (stream no rule reads
+// from), missing_stream and missing_channel (rule reading from a missing
+// stream or publishing to a removed channel).
+type Orphan struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Owner   string `json:"owner,omitempty"`
+	Reason  string `json:"reason"`
+	Removed bool   `json:"removed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// OrphanReport contains the orphans found at the given time.
+type OrphanReport struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Orphans   []Orphan  `json:"orphans"`
+}
+
+// RestoredEntity is the stream or rule replayed into Kuiper. Name is the
+// Kuiper name of the entity and Status is one of restored, pending (dry
+// run), exists, skipped (no stored definition) and failed.
+type RestoredEntity struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Owner  string `json:"owner"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RestoreReport is the progress report of the Kuiper restore. Counts maps
+// the statuses to the number of entities with the status.
+type RestoreReport struct {
+	DryRun     bool             `json:"dry_run"`
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+	Counts     map[string]int   `json:"counts"`
+	Entities   []RestoredEntity `json:"entities"`
+}
+
+// Ruleset contains all the streams and rules of the user, named without the
+// owner prefix, so they can be imported into another environment. Skipped
+// contains the names of the streams that can't be exported, since they were
+// created before their definitions were stored.
+type Ruleset struct {
+	Streams []Stream `json:"streams"`
+	Rules   []Rule   `json:"rules"`
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// ImportedEntity is the imported stream or rule. Renamed is the name the
+// entity was created under and Status is one of created, skipped,
+// overwritten, renamed and failed.
+type ImportedEntity struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Renamed string `json:"renamed,omitempty"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ImportReport is the report of the ruleset import using the conflict
+// strategy. Counts maps the statuses to the number of entities with the
+// status.
+type ImportReport struct {
+	Conflict string           `json:"conflict"`
+	Counts   map[string]int   `json:"counts"`
+	Entities []ImportedEntity `json:"entities"`
+}
+
+// AuditEvent records the operation the user performed on the rules engine
+// stream, table or rule. Owner is the ID of the entity owner and Before and
+// After are the SQL of the entity before and after the operation.
+type AuditEvent struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"`
+	Owner     string    `json:"owner"`
+	Kind      string    `json:"kind"`
+	Entity    string    `json:"entity"`
+	Operation string    `json:"operation"`
+	Before    string    `json:"before,omitempty"`
+	After     string    `json:"after,omitempty"`
+}
+
+// AuditQuery filters the audit events by the user who performed the
+// operation, the entity owner, kind and name, and the time range. Empty
+// fields match all the events.
+type AuditQuery struct {
+	Offset uint64
+	Limit  uint64
+	User   string
+	Owner  string
+	Kind   string
+	Entity string
+	From   time.Time
+	To     time.Time
+}
+
+// AuditPage contains the page of the audit events, newest first.
+type AuditPage struct {
+	Total  uint64       `json:"total"`
+	Offset uint64       `json:"offset"`
+	Limit  uint64       `json:"limit"`
+	Events []AuditEvent `json:"events"`
+}
+
+// RulesEngineInstanceInfo is the information the Kuiper instance reported.
+type RulesEngineInstanceInfo struct {
+	Version       string `json:"version"`
+	OS            string `json:"os"`
+	UpTimeSeconds int    `json:"upTimeSeconds"`
+	Breaker       string `json:"breaker"`
+}
+
+// RulesEngineInstance describes the Kuiper instance the rules engine spreads
+// the users over. Info is left empty and Error set if the instance couldn't
+// be reached. Users are the IDs of the users assigned to the instance.
+type RulesEngineInstance struct {
+	Name    string                   `json:"name"`
+	Default bool                     `json:"default"`
+	Info    *RulesEngineInstanceInfo `json:"info,omitempty"`
+	Error   string                   `json:"error,omitempty"`
+	Users   []string                 `json:"users"`
+}
+
+// RulesEngineAssignment is the Kuiper instance the user's streams, tables
+// and rules are on. Assigned reports whether the platform administrator
+// assigned the instance, rather than the pool strategy picking it.
+type RulesEngineAssignment struct {
+	UserID   string `json:"user_id"`
+	Instance string `json:"instance"`
+	Assigned bool   `json:"assigned"`
+}
+
+// RulesEngineGateway is the edge gateway running its own Kuiper instance.
+// ID is the ID of the gateway thing and Channel the control channel the
+// gateway receives the rules on. Gateways registered without the channel
+// use the first channel of their bootstrap configuration.
+type RulesEngineGateway struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name,omitempty"`
+	Channel   string            `json:"channel,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// RuleDeployment is the rule deployed to the edge gateway. Status is pending
+// until the gateway reports the rule running or failed, and removing until
+// the gateway reports the rule removed.
+type RuleDeployment struct {
+	Rule      string    `json:"rule"`
+	Gateway   string    `json:"gateway"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RuleRollout is the aggregate status of the rule deployed to the fleet of
+// edge gateways: pending while any gateway hasn't reported the rule yet,
+// running once all of them run it, failed if all of them failed, degraded
+// if only some did, and empty without deployments.
+type RuleRollout struct {
+	Rule        string           `json:"rule"`
+	Status      string           `json:"status"`
+	Total       int              `json:"total"`
+	Pending     int              `json:"pending"`
+	Running     int              `json:"running"`
+	Failed      int              `json:"failed"`
+	Removing    int              `json:"removing"`
+	Deployments []RuleDeployment `json:"deployments"`
+}
+
+// RulesEngineStats summarizes the user's streams, tables and rules. States
+// count the rules running, stopped and in error. The records are the records
+// the rules read and sent out and their exceptions since each rule started.
+// Interval holds the same counts since the user's previous statistics, and
+// is omitted for the first ones.
+type RulesEngineStats struct {
+	Streams    int                  `json:"streams"`
+	Tables     int                  `json:"tables"`
+	Rules      int                  `json:"rules"`
+	States     map[string]int       `json:"states"`
+	RecordsIn  int64                `json:"records_in"`
+	RecordsOut int64                `json:"records_out"`
+	Exceptions int64                `json:"exceptions"`
+	Interval   *RulesEngineInterval `json:"interval,omitempty"`
+}
+
+// RulesEngineInterval contains the records and the exceptions of the user's
+// rules since the previous statistics were taken.
+type RulesEngineInterval struct {
+	Since      time.Time `json:"since"`
+	RecordsIn  int64     `json:"records_in"`
+	RecordsOut int64     `json:"records_out"`
+	Exceptions int64     `json:"exceptions"`
+}
+
+// BulkDeletion contains the names of the streams and the IDs of the rules
+// removed by BulkDelete.
+type BulkDeletion struct {
+	Streams []string `json:"streams"`
+	Rules   []string `json:"rules"`
+}
+
+// RuleSelection selects the rules StartRules and StopRules control either by
+// their IDs or by the labels the rules must all have.
+type RuleSelection struct {
+	IDs    []string          `json:"ids,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// BulkItem is the result of the bulk operation on the stream or rule with
+// the given name. Error is the reason the operation failed.
+type BulkItem struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkReport is the report of the bulk operation, listing the items in the
+// order the streams and rules were sent in.
+type BulkReport struct {
+	Succeeded int        `json:"succeeded"`
+	Failed    int        `json:"failed"`
+	Items     []BulkItem `json:"items"`
+}
+
+// OwnerStreams contains the streams of the owner.
+type OwnerStreams struct {
+	Owner   string   `json:"owner"`
+	Email   string   `json:"email,omitempty"`
+	Streams []string `json:"streams"`
+}
+
+// AllStreams contains the streams of all the users, grouped by owner.
+type AllStreams struct {
+	Total  int            `json:"total"`
+	Owners []OwnerStreams `json:"owners"`
+}
+
+// OwnerRules contains the rules of the owner and their counts by state.
+type OwnerRules struct {
+	Owner  string         `json:"owner"`
+	Email  string         `json:"email,omitempty"`
+	States map[string]int `json:"states"`
+	Rules  []RuleInfo     `json:"rules"`
+}
+
+// AllRules contains the rules of all the users, grouped by owner, and their
+// counts by state.
+type AllRules struct {
+	Total  int            `json:"total"`
+	States map[string]int `json:"states"`
+	Owners []OwnerRules   `json:"owners"`
+}
+
+// RulesQuota contains the limits of the rules engine streams and rules the
+// user can create. Zero means no limit.
+type RulesQuota struct {
+	MaxStreams int `json:"max_streams"`
+	MaxRules   int `json:"max_rules"`
+}
+
+// UserRulesQuota is the rules engine quota of the user along with the
+// numbers of the streams and rules the user has. Override reports whether
+// the quota was set for the user, replacing the default quota.
+type UserRulesQuota struct {
+	UserID     string `json:"user_id"`
+	MaxStreams int    `json:"max_streams"`
+	MaxRules   int    `json:"max_rules"`
+	Override   bool   `json:"override"`
+	Streams    int    `json:"streams"`
+	Rules      int    `json:"rules"`
+}
+
+// RulesAlertPolicy defines how the user is alerted about the failing rules
+// engine rules: the rules Kuiper stopped on error and, unless Exceptions is
+// zero, the rules raising more than Exceptions exceptions between two
+// checks. Alerts are posted to the Webhook and sent to the Email and SMS
+// contacts.
+type RulesAlertPolicy struct {
+	Exceptions int64             `json:"exceptions"`
+	Webhook    string            `json:"webhook,omitempty"`
+	Email      *NotificationSink `json:"email,omitempty"`
+	SMS        *NotificationSink `json:"sms,omitempty"`
+}
+
+// RulesWebhook is the URL called on the lifecycle events of the user's
+// rules: rule.created, rule.started, rule.stopped and rule.errored, or all
+// of them if no events are given. The payloads are signed with the Secret,
+// which is only returned when the webhook is created.
+type RulesWebhook struct {
+	ID        string    `json:"id,omitempty"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// RulesFolder organizes the user's rules. Rules is the number of the rules
+// in the folder.
+type RulesFolder struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Rules       int       `json:"rules,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+// RulesMaintenance is the user's maintenance mode. Rules are the IDs of the
+// rules that were running when the rules were paused.
+type RulesMaintenance struct {
+	Rules []string  `json:"rules"`
+	Since time.Time `json:"since"`
+}
+
+// RulesChain feeds the results of the From rule to the To rule through the
+// memory stream with the chain Name and the Fields of the From rule results.
+// The To rule reads from the stream once the chain is created.
+type RulesChain struct {
+	Name      string        `json:"name"`
+	From      string        `json:"from"`
+	To        string        `json:"to"`
+	Fields    []SchemaField `json:"fields"`
+	CreatedAt time.Time     `json:"created_at,omitempty"`
+}
+
+// RulesSecret is the user's credential the rule actions refer to with
+// {{secret "<name>"}}, e.g. in the REST sink headers or the MQTT sink
+// password. The Value is only sent when the secret is saved.
+type RulesSecret struct {
+	Name      string    `json:"name,omitempty"`
+	Value     string    `json:"value,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// EntityShare grants the user or the members of the group the view or
+// manage access to the rules engine stream or rule of another user. Users
+// the entity is shared with refer to it as "<owner ID>:<name>".
+type EntityShare struct {
+	Grantee     string `json:"grantee"`
+	GranteeType string `json:"grantee_type"`
+	Access      string `json:"access"`
+}
+
+// RuleTemplate is the parameterized rule registered by the platform
+// administrator. The SQL and the string settings of the actions contain
+// placeholders, e.g. "{threshold}", replaced by the variable values.
+type RuleTemplate struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Variables   []TemplateVariable `json:"variables"`
+	SQL         string             `json:"sql"`
+	Actions     []RuleAction       `json:"actions"`
+	Options     *RuleOptions       `json:"options,omitempty"`
+	CreatedAt   time.Time          `json:"created_at,omitempty"`
+}
+
+// TemplateVariable is the template variable. Type is one of stream, field,
+// number, string and channel. Variables without the default value are
+// required.
+type TemplateVariable struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+}
+
+// TemplateInstance contains the ID of the rule created from the template
+// and the values of the template variables mapped by the variable names.
+type TemplateInstance struct {
+	ID          string            `json:"id"`
+	Values      map[string]string `json:"values"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// OperatorMetrics contains metrics of the rule source, operator or sink.
+type OperatorMetrics struct {
+	Name              string `json:"name"`
+	RecordsIn         int64  `json:"records_in"`
+	RecordsOut        int64  `json:"records_out"`
+	Exceptions        int64  `json:"exceptions"`
+	LastException     string `json:"last_exception,omitempty"`
+	LastExceptionTime string `json:"last_exception_time,omitempty"`
+	ProcessLatencyUs  int64  `json:"process_latency_us"`
+	BufferLength      int64  `json:"buffer_length"`
+	LastInvocation    string `json:"last_invocation,omitempty"`
+}
+
+// RulesEngineResult represents the outcome of the operation successfully
+// performed by the rules engine. Status is the HTTP status code and Message
+// is the message returned by Kuiper. Failed operations are returned as
+// errors.
+type RulesEngineResult struct {
+	Name    string `json:"name"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func (sdk mgSDK) CreateStream(stream Stream, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(stream)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.reURL, streamsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) Streams(pm PageMetadata, token string) (StreamsPage, errors.SDKError) {
+	url, err := sdk.withQueryParams(sdk.reURL, streamsEndpoint, pm)
+	if err != nil {
+		return StreamsPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return StreamsPage{}, sdkerr
+	}
+
+	var sp StreamsPage
+	if err := json.Unmarshal(body, &sp); err != nil {
+		return StreamsPage{}, errors.NewSDKError(err)
+	}
+
+	return sp, nil
+}
+
+func (sdk mgSDK) ViewStream(name, token string) (StreamInfo, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, streamsEndpoint, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return StreamInfo{}, sdkerr
+	}
+
+	var si StreamInfo
+	if err := json.Unmarshal(body, &si); err != nil {
+		return StreamInfo{}, errors.NewSDKError(err)
+	}
+
+	return si, nil
+}
+
+func (sdk mgSDK) ViewStreams(names []string, token string) (StreamsBatch, errors.SDKError) {
+	data, err := json.Marshal(map[string][]string{"names": names})
+	if err != nil {
+		return StreamsBatch{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/batch", sdk.reURL, streamsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return StreamsBatch{}, sdkerr
+	}
+
+	var batch StreamsBatch
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return StreamsBatch{}, errors.NewSDKError(err)
+	}
+
+	return batch, nil
+}
+
+func (sdk mgSDK) UpdateStream(stream Stream, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(stream)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, streamsEndpoint, stream.Name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) DeleteStream(name string, cascade bool, token string) (RulesEngineResult, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s?cascade=%t", sdk.reURL, streamsEndpoint, name, cascade)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) IngestStream(stream, msg, key string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, ingestEndpoint, stream)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, ThingPrefix+key, []byte(msg), nil, http.StatusAccepted)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) CreateTable(table Table, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(table)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.reURL, tablesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) Tables(pm PageMetadata, token string) (TablesPage, errors.SDKError) {
+	url, err := sdk.withQueryParams(sdk.reURL, tablesEndpoint, pm)
+	if err != nil {
+		return TablesPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return TablesPage{}, sdkerr
+	}
+
+	var tp TablesPage
+	if err := json.Unmarshal(body, &tp); err != nil {
+		return TablesPage{}, errors.NewSDKError(err)
+	}
+
+	return tp, nil
+}
+
+func (sdk mgSDK) ViewTable(name, token string) (TableInfo, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, tablesEndpoint, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return TableInfo{}, sdkerr
+	}
+
+	var ti TableInfo
+	if err := json.Unmarshal(body, &ti); err != nil {
+		return TableInfo{}, errors.NewSDKError(err)
+	}
+
+	return ti, nil
+}
+
+func (sdk mgSDK) DeleteTable(name, token string) (RulesEngineResult, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, tablesEndpoint, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) CreateRule(rule Rule, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.reURL, rulesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) Rules(pm PageMetadata, token string) (RulesPage, errors.SDKError) {
+	url, err := sdk.withQueryParams(sdk.reURL, rulesEndpoint, pm)
+	if err != nil {
+		return RulesPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesPage{}, sdkerr
+	}
+
+	var rp RulesPage
+	if err := json.Unmarshal(body, &rp); err != nil {
+		return RulesPage{}, errors.NewSDKError(err)
+	}
+
+	return rp, nil
+}
+
+func (sdk mgSDK) SearchRules(stream, channel string, pm PageMetadata, token string) (RulesPage, errors.SDKError) {
+	endpoint, err := sdk.withQueryParams(sdk.reURL, searchEndpoint, pm)
+	if err != nil {
+		return RulesPage{}, errors.NewSDKError(err)
+	}
+	refs := url.Values{}
+	if stream != "" {
+		refs.Set("stream", stream)
+	}
+	if channel != "" {
+		refs.Set("channel", channel)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, endpoint+"&"+refs.Encode(), token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesPage{}, sdkerr
+	}
+
+	var rp RulesPage
+	if err := json.Unmarshal(body, &rp); err != nil {
+		return RulesPage{}, errors.NewSDKError(err)
+	}
+
+	return rp, nil
+}
+
+func (sdk mgSDK) ViewRule(id, token string) (Rule, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, rulesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Rule{}, sdkerr
+	}
+
+	var rule Rule
+	if err := json.Unmarshal(body, &rule); err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+
+	return rule, nil
+}
+
+func (sdk mgSDK) ViewRules(ids []string, token string) (RulesBatch, errors.SDKError) {
+	data, err := json.Marshal(map[string][]string{"ids": ids})
+	if err != nil {
+		return RulesBatch{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/batch", sdk.reURL, rulesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesBatch{}, sdkerr
+	}
+
+	var batch RulesBatch
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return RulesBatch{}, errors.NewSDKError(err)
+	}
+
+	return batch, nil
+}
+
+func (sdk mgSDK) UpdateRule(rule Rule, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, rulesEndpoint, rule.ID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) PatchRule(id string, patch RulePatch, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, rulesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPatch, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) CloneRule(id, newID, channel, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(map[string]string{"id": newID, "channel": channel})
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/clone", sdk.reURL, rulesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) ValidateRule(rule Rule, token string) (RuleValidation, errors.SDKError) {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return RuleValidation{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/validate", sdk.reURL, rulesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleValidation{}, sdkerr
+	}
+
+	var rv RuleValidation
+	if err := json.Unmarshal(body, &rv); err != nil {
+		return RuleValidation{}, errors.NewSDKError(err)
+	}
+
+	return rv, nil
+}
+
+func (sdk mgSDK) TestRule(trial RuleTrial, token string) (TrialResult, errors.SDKError) {
+	data, err := json.Marshal(trial)
+	if err != nil {
+		return TrialResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/test", sdk.reURL, rulesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return TrialResult{}, sdkerr
+	}
+
+	var tr TrialResult
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return TrialResult{}, errors.NewSDKError(err)
+	}
+
+	return tr, nil
+}
+
+func (sdk mgSDK) DeleteRule(id, token string) (RulesEngineResult, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, rulesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) StartRule(id, token string) (RulesEngineResult, errors.SDKError) {
+	return sdk.controlRule(id, "start", token)
+}
+
+func (sdk mgSDK) StopRule(id, token string) (RulesEngineResult, errors.SDKError) {
+	return sdk.controlRule(id, "stop", token)
+}
+
+func (sdk mgSDK) RestartRule(id, token string) (RulesEngineResult, errors.SDKError) {
+	return sdk.controlRule(id, "restart", token)
+}
+
+func (sdk mgSDK) SaveDraftRule(rule Rule, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/draft", sdk.reURL, rulesEndpoint, rule.ID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusCreated, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) PublishRule(id, token string) (RulesEngineResult, errors.SDKError) {
+	return sdk.controlRule(id, "publish", token)
+}
+
+func (sdk mgSDK) UnpublishRule(id, token string) (RulesEngineResult, errors.SDKError) {
+	return sdk.controlRule(id, "unpublish", token)
+}
+
+func (sdk mgSDK) RestoreRule(id, token string) (RulesEngineResult, errors.SDKError) {
+	return sdk.controlRule(id, "restore", token)
+}
+
+func (sdk mgSDK) RuleStatus(id, token string) (RuleStatus, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/status", sdk.reURL, rulesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleStatus{}, sdkerr
+	}
+
+	var rs RuleStatus
+	if err := json.Unmarshal(body, &rs); err != nil {
+		return RuleStatus{}, errors.NewSDKError(err)
+	}
+
+	return rs, nil
+}
+
+func (sdk mgSDK) RuleTopology(id, token string) (RuleTopology, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/topology", sdk.reURL, rulesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleTopology{}, sdkerr
+	}
+
+	var topo RuleTopology
+	if err := json.Unmarshal(body, &topo); err != nil {
+		return RuleTopology{}, errors.NewSDKError(err)
+	}
+
+	return topo, nil
+}
+
+func (sdk mgSDK) RuleLogs(id string, since time.Time, token string) ([]RuleLogEntry, errors.SDKError) {
+	params := url.Values{}
+	if !since.IsZero() {
+		params.Set("since", since.Format(time.RFC3339))
+	}
+	endpoint := fmt.Sprintf("%s/%s/%s/logs?%s", sdk.reURL, rulesEndpoint, id, params.Encode())
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, endpoint, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Logs []RuleLogEntry `json:"logs"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Logs, nil
+}
+
+func (sdk mgSDK) ReplayRule(id string, from, to time.Time, token string) (ReplayResult, errors.SDKError) {
+	data, err := json.Marshal(map[string]time.Time{"from": from, "to": to})
+	if err != nil {
+		return ReplayResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/replay", sdk.reURL, rulesEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return ReplayResult{}, sdkerr
+	}
+
+	var rr ReplayResult
+	if err := json.Unmarshal(body, &rr); err != nil {
+		return ReplayResult{}, errors.NewSDKError(err)
+	}
+
+	return rr, nil
+}
+
+func (sdk mgSDK) Drift(token string) (DriftReport, errors.SDKError) {
+	return sdk.reconcile(http.MethodGet, token)
+}
+
+func (sdk mgSDK) RepairDrift(token string) (DriftReport, errors.SDKError) {
+	return sdk.reconcile(http.MethodPost, token)
+}
+
+func (sdk mgSDK) reconcile(method, token string) (DriftReport, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, driftEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(method, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return DriftReport{}, sdkerr
+	}
+
+	var report DriftReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return DriftReport{}, errors.NewSDKError(err)
+	}
+
+	return report, nil
+}
+
+func (sdk mgSDK) Orphans(minAge time.Duration, token string) (OrphanReport, errors.SDKError) {
+	return sdk.collectOrphans(http.MethodGet, minAge, token)
+}
+
+func (sdk mgSDK) RemoveOrphans(minAge time.Duration, token string) (OrphanReport, errors.SDKError) {
+	return sdk.collectOrphans(http.MethodPost, minAge, token)
+}
+
+func (sdk mgSDK) collectOrphans(method string, minAge time.Duration, token string) (OrphanReport, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s?min_age=%s", sdk.reURL, orphansEndpoint, minAge)
+
+	_, body, sdkerr := sdk.processRequest(method, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return OrphanReport{}, sdkerr
+	}
+
+	var report OrphanReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return OrphanReport{}, errors.NewSDKError(err)
+	}
+
+	return report, nil
+}
+
+func (sdk mgSDK) RestoreKuiper(dryRun bool, token string) (RestoreReport, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s?dry_run=%t", sdk.reURL, restoreEndpoint, dryRun)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RestoreReport{}, sdkerr
+	}
+
+	var report RestoreReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return RestoreReport{}, errors.NewSDKError(err)
+	}
+
+	return report, nil
+}
+
+func (sdk mgSDK) ExportRuleset(token string) (Ruleset, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, rulesetEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Ruleset{}, sdkerr
+	}
+
+	var rs Ruleset
+	if err := json.Unmarshal(body, &rs); err != nil {
+		return Ruleset{}, errors.NewSDKError(err)
+	}
+
+	return rs, nil
+}
+
+func (sdk mgSDK) BulkCreate(rs Ruleset, token string) (BulkReport, errors.SDKError) {
+	data, err := json.Marshal(rs)
+	if err != nil {
+		return BulkReport{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.reURL, bulkEndpoint)
+
+	return sdk.bulk(url, data, token)
+}
+
+func (sdk mgSDK) BulkDelete(bd BulkDeletion, token string) (BulkReport, errors.SDKError) {
+	data, err := json.Marshal(bd)
+	if err != nil {
+		return BulkReport{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/delete", sdk.reURL, bulkEndpoint)
+
+	return sdk.bulk(url, data, token)
+}
+
+func (sdk mgSDK) StartRules(sel RuleSelection, token string) (BulkReport, errors.SDKError) {
+	return sdk.controlRules(sel, "start", token)
+}
+
+func (sdk mgSDK) StopRules(sel RuleSelection, token string) (BulkReport, errors.SDKError) {
+	return sdk.controlRules(sel, "stop", token)
+}
+
+func (sdk mgSDK) controlRules(sel RuleSelection, command, token string) (BulkReport, errors.SDKError) {
+	data, err := json.Marshal(sel)
+	if err != nil {
+		return BulkReport{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, bulkEndpoint, command)
+
+	return sdk.bulk(url, data, token)
+}
+
+func (sdk mgSDK) bulk(url string, data []byte, token string) (BulkReport, errors.SDKError) {
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return BulkReport{}, sdkerr
+	}
+
+	var report BulkReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return BulkReport{}, errors.NewSDKError(err)
+	}
+
+	return report, nil
+}
+
+func (sdk mgSDK) ListAllStreams(token string) (AllStreams, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, allEndpoint, streamsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return AllStreams{}, sdkerr
+	}
+
+	var all AllStreams
+	if err := json.Unmarshal(body, &all); err != nil {
+		return AllStreams{}, errors.NewSDKError(err)
+	}
+
+	return all, nil
+}
+
+func (sdk mgSDK) ListAllRules(token string) (AllRules, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, allEndpoint, rulesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return AllRules{}, sdkerr
+	}
+
+	var all AllRules
+	if err := json.Unmarshal(body, &all); err != nil {
+		return AllRules{}, errors.NewSDKError(err)
+	}
+
+	return all, nil
+}
+
+func (sdk mgSDK) RulesQuota(userID, token string) (UserRulesQuota, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, quotasEndpoint, userID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return UserRulesQuota{}, sdkerr
+	}
+
+	var q UserRulesQuota
+	if err := json.Unmarshal(body, &q); err != nil {
+		return UserRulesQuota{}, errors.NewSDKError(err)
+	}
+
+	return q, nil
+}
+
+func (sdk mgSDK) SetRulesQuota(userID string, q RulesQuota, token string) (UserRulesQuota, errors.SDKError) {
+	data, err := json.Marshal(q)
+	if err != nil {
+		return UserRulesQuota{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, quotasEndpoint, userID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return UserRulesQuota{}, sdkerr
+	}
+
+	var uq UserRulesQuota
+	if err := json.Unmarshal(body, &uq); err != nil {
+		return UserRulesQuota{}, errors.NewSDKError(err)
+	}
+
+	return uq, nil
+}
+
+func (sdk mgSDK) DeleteRulesQuota(userID, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, quotasEndpoint, userID)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) SetRulesAlertPolicy(p RulesAlertPolicy, token string) (RulesAlertPolicy, errors.SDKError) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return RulesAlertPolicy{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.reURL, alertsEndpoint)
+
+	return sdk.alertPolicy(http.MethodPut, url, token, data)
+}
+
+func (sdk mgSDK) RulesAlertPolicy(token string) (RulesAlertPolicy, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, alertsEndpoint)
+
+	return sdk.alertPolicy(http.MethodGet, url, token, nil)
+}
+
+func (sdk mgSDK) DeleteRulesAlertPolicy(token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, alertsEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) alertPolicy(method, url, token string, data []byte) (RulesAlertPolicy, errors.SDKError) {
+	_, body, sdkerr := sdk.processRequest(method, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesAlertPolicy{}, sdkerr
+	}
+
+	var p RulesAlertPolicy
+	if err := json.Unmarshal(body, &p); err != nil {
+		return RulesAlertPolicy{}, errors.NewSDKError(err)
+	}
+
+	return p, nil
+}
+
+func (sdk mgSDK) CreateRulesWebhook(wh RulesWebhook, token string) (RulesWebhook, errors.SDKError) {
+	data, err := json.Marshal(wh)
+	if err != nil {
+		return RulesWebhook{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s", sdk.reURL, webhooksEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RulesWebhook{}, sdkerr
+	}
+
+	var created RulesWebhook
+	if err := json.Unmarshal(body, &created); err != nil {
+		return RulesWebhook{}, errors.NewSDKError(err)
+	}
+
+	return created, nil
+}
+
+func (sdk mgSDK) RulesWebhooks(token string) ([]RulesWebhook, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, webhooksEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Webhooks []RulesWebhook `json:"webhooks"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Webhooks, nil
+}
+
+func (sdk mgSDK) DeleteRulesWebhook(id, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, webhooksEndpoint, id)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) CreateRulesFolder(f RulesFolder, token string) (RulesFolder, errors.SDKError) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return RulesFolder{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s", sdk.reURL, foldersEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RulesFolder{}, sdkerr
+	}
+
+	var created RulesFolder
+	if err := json.Unmarshal(body, &created); err != nil {
+		return RulesFolder{}, errors.NewSDKError(err)
+	}
+
+	return created, nil
+}
+
+func (sdk mgSDK) RulesFolders(token string) ([]RulesFolder, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, foldersEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Folders []RulesFolder `json:"folders"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Folders, nil
+}
+
+func (sdk mgSDK) DeleteRulesFolder(name, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, foldersEndpoint, name)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) MoveRules(folder string, ids []string, token string) (BulkReport, errors.SDKError) {
+	data, err := json.Marshal(map[string]interface{}{"folder": folder, "ids": ids})
+	if err != nil {
+		return BulkReport{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/move", sdk.reURL, rulesEndpoint)
+
+	return sdk.bulk(url, data, token)
+}
+
+func (sdk mgSDK) PauseRules(token string) (BulkReport, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, maintEndpoint)
+
+	return sdk.bulk(url, nil, token)
+}
+
+func (sdk mgSDK) ResumeRules(token string) (BulkReport, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, maintEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return BulkReport{}, sdkerr
+	}
+
+	var report BulkReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return BulkReport{}, errors.NewSDKError(err)
+	}
+
+	return report, nil
+}
+
+func (sdk mgSDK) RulesMaintenance(token string) (RulesMaintenance, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, maintEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesMaintenance{}, sdkerr
+	}
+
+	var m RulesMaintenance
+	if err := json.Unmarshal(body, &m); err != nil {
+		return RulesMaintenance{}, errors.NewSDKError(err)
+	}
+
+	return m, nil
+}
+
+func (sdk mgSDK) CreateRulesChain(c RulesChain, token string) (RulesChain, errors.SDKError) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return RulesChain{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s", sdk.reURL, chainsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RulesChain{}, sdkerr
+	}
+
+	var created RulesChain
+	if err := json.Unmarshal(body, &created); err != nil {
+		return RulesChain{}, errors.NewSDKError(err)
+	}
+
+	return created, nil
+}
+
+func (sdk mgSDK) RulesChains(token string) ([]RulesChain, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, chainsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Chains []RulesChain `json:"chains"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Chains, nil
+}
+
+func (sdk mgSDK) DeleteRulesChain(name, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, chainsEndpoint, name)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) SaveRulesSecret(name, value, token string) (RulesSecret, errors.SDKError) {
+	data, err := json.Marshal(RulesSecret{Value: value})
+	if err != nil {
+		return RulesSecret{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, secretsEndpoint, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesSecret{}, sdkerr
+	}
+
+	var saved RulesSecret
+	if err := json.Unmarshal(body, &saved); err != nil {
+		return RulesSecret{}, errors.NewSDKError(err)
+	}
+
+	return saved, nil
+}
+
+func (sdk mgSDK) RulesSecrets(token string) ([]RulesSecret, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, secretsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Secrets []RulesSecret `json:"secrets"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Secrets, nil
+}
+
+func (sdk mgSDK) DeleteRulesSecret(name, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, secretsEndpoint, name)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) ShareStream(name string, s EntityShare, token string) (EntityShare, errors.SDKError) {
+	return sdk.share(streamsEndpoint, name, s, token)
+}
+
+func (sdk mgSDK) StreamShares(name, token string) ([]EntityShare, errors.SDKError) {
+	return sdk.shares(streamsEndpoint, name, token)
+}
+
+func (sdk mgSDK) UnshareStream(name, grantee, token string) errors.SDKError {
+	return sdk.unshare(streamsEndpoint, name, grantee, token)
+}
+
+func (sdk mgSDK) ShareRule(id string, s EntityShare, token string) (EntityShare, errors.SDKError) {
+	return sdk.share(rulesEndpoint, id, s, token)
+}
+
+func (sdk mgSDK) RuleShares(id, token string) ([]EntityShare, errors.SDKError) {
+	return sdk.shares(rulesEndpoint, id, token)
+}
+
+func (sdk mgSDK) UnshareRule(id, grantee, token string) errors.SDKError {
+	return sdk.unshare(rulesEndpoint, id, grantee, token)
+}
+
+func (sdk mgSDK) share(endpoint, name string, s EntityShare, token string) (EntityShare, errors.SDKError) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return EntityShare{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.reURL, endpoint, name, sharesEndpoint, s.Grantee)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return EntityShare{}, sdkerr
+	}
+
+	var res EntityShare
+	if err := json.Unmarshal(body, &res); err != nil {
+		return EntityShare{}, errors.NewSDKError(err)
+	}
+
+	return res, nil
+}
+
+func (sdk mgSDK) shares(endpoint, name, token string) ([]EntityShare, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.reURL, endpoint, name, sharesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Shares []EntityShare `json:"shares"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Shares, nil
+}
+
+func (sdk mgSDK) unshare(endpoint, name, grantee, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.reURL, endpoint, name, sharesEndpoint, grantee)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) RenameStream(name, newName, token string) (RulesEngineResult, errors.SDKError) {
+	return sdk.rename(streamsEndpoint, name, newName, token)
+}
+
+func (sdk mgSDK) RenameRule(id, newID, token string) (RulesEngineResult, errors.SDKError) {
+	return sdk.rename(rulesEndpoint, id, newID, token)
+}
+
+func (sdk mgSDK) rename(endpoint, name, newName, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(map[string]string{"name": newName})
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/rename", sdk.reURL, endpoint, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) AuditEvents(q AuditQuery, token string) (AuditPage, errors.SDKError) {
+	params := url.Values{}
+	params.Set("offset", fmt.Sprint(q.Offset))
+	params.Set("limit", fmt.Sprint(q.Limit))
+	if q.User != "" {
+		params.Set("user", q.User)
+	}
+	if q.Owner != "" {
+		params.Set("owner", q.Owner)
+	}
+	if q.Kind != "" {
+		params.Set("kind", q.Kind)
+	}
+	if q.Entity != "" {
+		params.Set("entity", q.Entity)
+	}
+	if !q.From.IsZero() {
+		params.Set("from", q.From.Format(time.RFC3339))
+	}
+	if !q.To.IsZero() {
+		params.Set("to", q.To.Format(time.RFC3339))
+	}
+	endpoint := fmt.Sprintf("%s/%s?%s", sdk.reURL, auditEndpoint, params.Encode())
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, endpoint, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return AuditPage{}, sdkerr
+	}
+
+	var page AuditPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return AuditPage{}, errors.NewSDKError(err)
+	}
+
+	return page, nil
+}
+
+func (sdk mgSDK) RulesEngineInstances(token string) ([]RulesEngineInstance, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, instancesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Instances []RulesEngineInstance `json:"instances"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Instances, nil
+}
+
+func (sdk mgSDK) AssignRulesEngineInstance(userID, instance, token string) (RulesEngineAssignment, errors.SDKError) {
+	data, err := json.Marshal(map[string]string{"instance": instance})
+	if err != nil {
+		return RulesEngineAssignment{}, errors.NewSDKError(err)
+	}
+
+	return sdk.assignInstance(http.MethodPut, userID, data, token)
+}
+
+func (sdk mgSDK) UnassignRulesEngineInstance(userID, token string) (RulesEngineAssignment, errors.SDKError) {
+	return sdk.assignInstance(http.MethodDelete, userID, nil, token)
+}
+
+func (sdk mgSDK) assignInstance(method, userID string, data []byte, token string) (RulesEngineAssignment, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/assignments/%s", sdk.reURL, instancesEndpoint, userID)
+
+	_, body, sdkerr := sdk.processRequest(method, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineAssignment{}, sdkerr
+	}
+
+	var a RulesEngineAssignment
+	if err := json.Unmarshal(body, &a); err != nil {
+		return RulesEngineAssignment{}, errors.NewSDKError(err)
+	}
+
+	return a, nil
+}
+
+func (sdk mgSDK) SaveRulesEngineGateway(gw RulesEngineGateway, token string) (RulesEngineGateway, errors.SDKError) {
+	data, err := json.Marshal(gw)
+	if err != nil {
+		return RulesEngineGateway{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, gatewaysEndpoint, gw.ID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineGateway{}, sdkerr
+	}
+
+	var saved RulesEngineGateway
+	if err := json.Unmarshal(body, &saved); err != nil {
+		return RulesEngineGateway{}, errors.NewSDKError(err)
+	}
+
+	return saved, nil
+}
+
+func (sdk mgSDK) RulesEngineGateways(token string) ([]RulesEngineGateway, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, gatewaysEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Gateways []RulesEngineGateway `json:"gateways"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Gateways, nil
+}
+
+func (sdk mgSDK) RemoveRulesEngineGateway(id, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, gatewaysEndpoint, id)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) DeployRule(id, gateway, token string) (RuleDeployment, errors.SDKError) {
+	return sdk.deployRule(http.MethodPut, id, gateway, token)
+}
+
+func (sdk mgSDK) UndeployRule(id, gateway, token string) (RuleDeployment, errors.SDKError) {
+	return sdk.deployRule(http.MethodDelete, id, gateway, token)
+}
+
+func (sdk mgSDK) deployRule(method, id, gateway, token string) (RuleDeployment, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.reURL, rulesEndpoint, id, deploysEndpoint, gateway)
+
+	_, body, sdkerr := sdk.processRequest(method, url, token, nil, nil, http.StatusAccepted)
+	if sdkerr != nil {
+		return RuleDeployment{}, sdkerr
+	}
+
+	var d RuleDeployment
+	if err := json.Unmarshal(body, &d); err != nil {
+		return RuleDeployment{}, errors.NewSDKError(err)
+	}
+
+	return d, nil
+}
+
+func (sdk mgSDK) RuleDeployments(id, token string) ([]RuleDeployment, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.reURL, rulesEndpoint, id, deploysEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Deployments []RuleDeployment `json:"deployments"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Deployments, nil
+}
+
+func (sdk mgSDK) DeployFleet(id string, labels map[string]string, token string) (RuleRollout, errors.SDKError) {
+	data, err := json.Marshal(map[string]interface{}{"labels": labels})
+	if err != nil {
+		return RuleRollout{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.reURL, rulesEndpoint, id, rolloutEndpoint)
+
+	return sdk.rollout(http.MethodPost, url, token, data, http.StatusAccepted)
+}
+
+func (sdk mgSDK) RetryDeployments(id, token string) (RuleRollout, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s/retry", sdk.reURL, rulesEndpoint, id, rolloutEndpoint)
+
+	return sdk.rollout(http.MethodPost, url, token, nil, http.StatusAccepted)
+}
+
+func (sdk mgSDK) ViewRollout(id, token string) (RuleRollout, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.reURL, rulesEndpoint, id, rolloutEndpoint)
+
+	return sdk.rollout(http.MethodGet, url, token, nil, http.StatusOK)
+}
+
+func (sdk mgSDK) rollout(method, url, token string, data []byte, status int) (RuleRollout, errors.SDKError) {
+	_, body, sdkerr := sdk.processRequest(method, url, token, data, nil, status)
+	if sdkerr != nil {
+		return RuleRollout{}, sdkerr
+	}
+
+	var r RuleRollout
+	if err := json.Unmarshal(body, &r); err != nil {
+		return RuleRollout{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mgSDK) RulesEngineStats(token string) (RulesEngineStats, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, statsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineStats{}, sdkerr
+	}
+
+	var stats RulesEngineStats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return RulesEngineStats{}, errors.NewSDKError(err)
+	}
+
+	return stats, nil
+}
+
+func (sdk mgSDK) ImportRuleset(rs Ruleset, conflict, token string) (ImportReport, errors.SDKError) {
+	data, err := json.Marshal(rs)
+	if err != nil {
+		return ImportReport{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.reURL, rulesetEndpoint)
+	if conflict != "" {
+		url = fmt.Sprintf("%s?conflict=%s", url, conflict)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return ImportReport{}, sdkerr
+	}
+
+	var report ImportReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return ImportReport{}, errors.NewSDKError(err)
+	}
+
+	return report, nil
+}
+
+func (sdk mgSDK) CreateRuleTemplate(tmpl RuleTemplate, token string) (RuleTemplate, errors.SDKError) {
+	data, err := json.Marshal(tmpl)
+	if err != nil {
+		return RuleTemplate{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.reURL, templatesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RuleTemplate{}, sdkerr
+	}
+
+	var res RuleTemplate
+	if err := json.Unmarshal(body, &res); err != nil {
+		return RuleTemplate{}, errors.NewSDKError(err)
+	}
+
+	return res, nil
+}
+
+func (sdk mgSDK) RuleTemplates(token string) ([]RuleTemplate, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, templatesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Templates []RuleTemplate `json:"templates"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Templates, nil
+}
+
+func (sdk mgSDK) RuleTemplate(name, token string) (RuleTemplate, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, templatesEndpoint, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleTemplate{}, sdkerr
+	}
+
+	var tmpl RuleTemplate
+	if err := json.Unmarshal(body, &tmpl); err != nil {
+		return RuleTemplate{}, errors.NewSDKError(err)
+	}
+
+	return tmpl, nil
+}
+
+func (sdk mgSDK) DeleteRuleTemplate(name, token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, templatesEndpoint, name)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) InstantiateRuleTemplate(name string, inst TemplateInstance, token string) (Rule, errors.SDKError) {
+	data, err := json.Marshal(inst)
+	if err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.reURL, templatesEndpoint, name, rulesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Rule{}, sdkerr
+	}
+
+	var rule Rule
+	if err := json.Unmarshal(body, &rule); err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+
+	return rule, nil
+}
+
+func (sdk mgSDK) CreateRulesEnginePlugin(kind string, plugin RulesEnginePlugin, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(plugin)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, pluginsEndpoint, kind)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) RulesEnginePlugins(kind, token string) ([]string, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, pluginsEndpoint, kind)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Plugins []string `json:"plugins"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Plugins, nil
+}
+
+func (sdk mgSDK) DeleteRulesEnginePlugin(kind, name, token string) (RulesEngineResult, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.reURL, pluginsEndpoint, kind, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) RegisterExternalService(es ExternalService, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(es)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.reURL, servicesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) ExternalServices(token string) ([]string, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, servicesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Services []string `json:"services"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Services, nil
+}
+
+func (sdk mgSDK) DeleteExternalService(name, token string) (RulesEngineResult, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, servicesEndpoint, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) ExternalFunctions(token string) ([]ExternalFunction, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, functionsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		Functions []ExternalFunction `json:"functions"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.Functions, nil
+}
+
+func (sdk mgSDK) SaveConfKey(name string, conf MQTTConf, token string) (RulesEngineResult, errors.SDKError) {
+	data, err := json.Marshal(conf)
+	if err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, confKeysEndpoint, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPut, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) ConfKeys(token string) ([]string, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s", sdk.reURL, confKeysEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return nil, sdkerr
+	}
+
+	var res struct {
+		ConfKeys []string `json:"conf_keys"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, errors.NewSDKError(err)
+	}
+
+	return res.ConfKeys, nil
+}
+
+func (sdk mgSDK) DeleteConfKey(name, token string) (RulesEngineResult, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.reURL, confKeysEndpoint, name)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func (sdk mgSDK) controlRule(id, command, token string) (RulesEngineResult, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.reURL, rulesEndpoint, id, command)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RulesEngineResult{}, sdkerr
+	}
+
+	return decodeRulesEngineResult(body)
+}
+
+func decodeRulesEngineResult(body []byte) (RulesEngineResult, errors.SDKError) {
+	var res RulesEngineResult
+	if err := json.Unmarshal(body, &res); err != nil {
+		return RulesEngineResult{}, errors.NewSDKError(err)
+	}
+
+	return res, nil
+}
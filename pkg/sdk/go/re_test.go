@@ -0,0 +1,1145 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdk "github.com/absmach/magistrala/pkg/sdk/go"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	reapi "github.com/absmach/magistrala/re/api"
+	remocks "github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const reChannelID = "b1c2d3e4-f5a6-4b7c-8d9e-0f1a2b3c4d5e"
+
+var (
+	rePrefix   = "u" + strings.ReplaceAll(validID, "-", "") + "_"
+	controlled = map[string]string{
+		"start":   "started",
+		"stop":    "stopped",
+		"restart": "restarted",
+	}
+)
+
+// kuiper is a minimal in-memory fake of the Kuiper streams and rules REST
+// API.
+type kuiper struct {
+	streams map[string]string
+	rules   map[string]re.Rule
+}
+
+func (k kuiper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch parts[0] {
+	case "streams":
+		k.serveStreams(w, r, parts)
+	case "rules":
+		k.serveRules(w, r, parts)
+	case "tables":
+		// The fake has no tables, listed while rule SQL is validated.
+		_ = json.NewEncoder(w).Encode([]string{})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (k kuiper) serveStreams(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		names := []string{}
+		for name := range k.streams {
+			names = append(names, name)
+		}
+		_ = json.NewEncoder(w).Encode(names)
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		name := strings.Fields(body["sql"])[2]
+		if _, ok := k.streams[name]; ok {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "Stream %s already exists.", name)
+			return
+		}
+		k.streams[name] = body["sql"]
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "Stream %s is created.", name)
+	default:
+		if _, ok := k.streams[parts[1]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(k.streams, parts[1])
+			fmt.Fprintf(w, "Stream %s is dropped.", parts[1])
+			return
+		}
+		_ = json.NewEncoder(w).Encode(re.Stream{Name: parts[1], Options: map[string]string{"DATASOURCE": reChannelID}})
+	}
+}
+
+func (k kuiper) serveRules(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 1 && r.Method == http.MethodGet {
+		rules := []re.RuleInfo{}
+		for id := range k.rules {
+			rules = append(rules, re.RuleInfo{ID: id, Status: "Running"})
+		}
+		_ = json.NewEncoder(w).Encode(rules)
+		return
+	}
+	if len(parts) == 1 && r.Method == http.MethodPost {
+		var rule re.Rule
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+		if _, ok := k.rules[rule.ID]; ok {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "Rule %s already exists.", rule.ID)
+			return
+		}
+		k.rules[rule.ID] = rule
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "Rule %s was created successfully.", rule.ID)
+		return
+	}
+	rule, ok := k.rules[parts[1]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch {
+	case len(parts) == 3 && parts[2] == "status":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "running",
+			"source_" + rule.ID + "_0_records_in_total":  10,
+			"source_" + rule.ID + "_0_records_out_total": 9,
+		})
+	case len(parts) == 3 && parts[2] == "topo":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"sources": []string{"source_" + rePrefix + "temperature"},
+			"edges": map[string][]string{
+				"source_" + rePrefix + "temperature": {"op_2_filter"},
+				"op_2_filter":                        {"sink_mqtt_0"},
+			},
+		})
+	case len(parts) == 3:
+		fmt.Fprintf(w, "Rule %s was %s.", parts[1], controlled[parts[2]])
+	case r.Method == http.MethodPut:
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+		k.rules[parts[1]] = rule
+		fmt.Fprintf(w, "Rule %s was updated successfully.", parts[1])
+	case r.Method == http.MethodDelete:
+		delete(k.rules, parts[1])
+		fmt.Fprintf(w, "Rule %s is dropped.", parts[1])
+	default:
+		_ = json.NewEncoder(w).Encode(rule)
+	}
+}
+
+// authorizeREChannel mocks the check of the write access of the user to the
+// rules engine channel.
+func authorizeREChannel(auth *authmocks.AuthClient) *mock.Call {
+	return auth.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+		SubjectType: "user",
+		SubjectKind: "token",
+		Subject:     validToken,
+		Permission:  "edit",
+		ObjectType:  "group",
+		Object:      reChannelID,
+	}).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+}
+
+func setupRulesEngine(t *testing.T) (*httptest.Server, *authmocks.AuthClient, *sdkmocks.SDK) {
+	kuiper := httptest.NewServer(kuiper{
+		streams: map[string]string{
+			rePrefix + "temperature":                     "",
+			"u00000000000000000000000000000000_humidity": "",
+		},
+		rules: map[string]re.Rule{
+			rePrefix + "alarm": {
+				ID:      rePrefix + "alarm",
+				SQL:     "SELECT * FROM " + rePrefix + "temperature WHERE v > 30",
+				Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: reChannelID}}},
+			},
+		},
+	})
+	t.Cleanup(kuiper.Close)
+
+	auth := new(authmocks.AuthClient)
+	things := new(sdkmocks.SDK)
+	cfg := re.Config{URL: kuiper.URL}
+	svc := re.New(cfg, auth, things, re.Notifiers{}, nil, remocks.NewRepository())
+	logger := mglog.NewMock()
+
+	return httptest.NewServer(reapi.MakeHandler(svc, cfg, logger, instanceID)), auth, things
+}
+
+func TestCreateStream(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	fields := []sdk.SchemaField{{Name: "v", Type: "float"}}
+	cases := []struct {
+		desc   string
+		stream sdk.Stream
+		status int
+	}{
+		{
+			desc:   "create stream",
+			stream: sdk.Stream{Name: "pressure", Topic: reChannelID, Fields: fields},
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "create existing stream",
+			stream: sdk.Stream{Name: "temperature", Topic: reChannelID, Fields: fields},
+			status: http.StatusConflict,
+		},
+		{
+			desc:   "create stream with malformed name",
+			stream: sdk.Stream{Name: "1pressure", Topic: reChannelID, Fields: fields},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create stream without fields",
+			stream: sdk.Stream{Name: "pressure", Topic: reChannelID},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := mgsdk.CreateStream(tc.stream, validToken)
+		if tc.status != http.StatusCreated {
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+			assert.Equal(t, tc.status, err.StatusCode(), fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, err.StatusCode()))
+			continue
+		}
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.stream.Name, res.Name, fmt.Sprintf("%s: expected name %s got %s", tc.desc, tc.stream.Name, res.Name))
+		assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, res.Status))
+	}
+}
+
+func TestStreams(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, mock.Anything).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	page, err := mgsdk.Streams(sdk.PageMetadata{Limit: 10}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := sdk.StreamsPage{Total: 1, Limit: 10, Streams: []string{"temperature"}}
+	assert.Equal(t, expected, page, fmt.Sprintf("expected %v got %v", expected, page))
+
+	_, err = mgsdk.Streams(sdk.PageMetadata{Limit: 10}, invalidToken)
+	assert.Equal(t, http.StatusUnauthorized, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusUnauthorized, err.StatusCode()))
+}
+
+func TestViewStream(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc   string
+		name   string
+		stream sdk.StreamInfo
+		status int
+	}{
+		{
+			desc:   "view stream",
+			name:   "temperature",
+			stream: sdk.StreamInfo{Name: "temperature", Options: map[string]string{"DATASOURCE": reChannelID}},
+		},
+		{
+			desc:   "view stream of other user",
+			name:   "humidity",
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view stream with malformed name",
+			name:   "x..%2Fhumidity",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		stream, err := mgsdk.ViewStream(tc.name, validToken)
+		if tc.status != 0 {
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+			assert.Equal(t, tc.status, err.StatusCode(), fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, err.StatusCode()))
+		} else {
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		}
+		assert.Equal(t, tc.stream, stream, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.stream, stream))
+	}
+}
+
+func TestDeleteStream(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	res, err := mgsdk.DeleteStream("temperature", false, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "temperature", res.Name, fmt.Sprintf("expected name temperature got %s", res.Name))
+
+	_, err = mgsdk.DeleteStream("temperature", false, validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestRules(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	page, err := mgsdk.Rules(sdk.PageMetadata{Limit: 10}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := sdk.RulesPage{Total: 1, Limit: 10, Rules: []sdk.RuleInfo{{ID: "alarm", Status: "Running"}}}
+	assert.Equal(t, expected, page, fmt.Sprintf("expected %v got %v", expected, page))
+
+	page, err = mgsdk.Rules(sdk.PageMetadata{Limit: 10, Labels: map[string]string{"env": "prod"}}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected = sdk.RulesPage{Limit: 10, Rules: []sdk.RuleInfo{}}
+	assert.Equal(t, expected, page, fmt.Sprintf("expected rules without labels to be filtered out got %v", page))
+}
+
+func TestSearchRules(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	page, err := mgsdk.SearchRules("temperature", reChannelID, sdk.PageMetadata{Limit: 10}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := sdk.RulesPage{Total: 1, Limit: 10, Rules: []sdk.RuleInfo{{ID: "alarm", Status: "Running"}}}
+	assert.Equal(t, expected, page, fmt.Sprintf("expected %v got %v", expected, page))
+
+	page, err = mgsdk.SearchRules("humidity", "", sdk.PageMetadata{Limit: 10}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected = sdk.RulesPage{Limit: 10, Rules: []sdk.RuleInfo{}}
+	assert.Equal(t, expected, page, fmt.Sprintf("expected %v got %v", expected, page))
+
+	_, err = mgsdk.SearchRules("", "", sdk.PageMetadata{Limit: 10}, validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+}
+
+func TestRuleStatus(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	status, err := mgsdk.RuleStatus("alarm", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := sdk.RuleStatus{
+		Status:    "running",
+		Operators: []sdk.OperatorMetrics{{Name: "source_alarm_0", RecordsIn: 10, RecordsOut: 9}},
+	}
+	assert.Equal(t, expected, status, fmt.Sprintf("expected %v got %v", expected, status))
+
+	_, err = mgsdk.RuleStatus("unknown", validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestRuleTopology(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	topo, err := mgsdk.RuleTopology("alarm", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := sdk.RuleTopology{
+		Nodes: []sdk.TopologyNode{{Name: "op_2_filter", Type: "operator"}, {Name: "sink_mqtt_0", Type: "sink"}, {Name: "source_temperature", Type: "source"}},
+		Edges: []sdk.TopologyEdge{{From: "op_2_filter", To: "sink_mqtt_0"}, {From: "source_temperature", To: "op_2_filter"}},
+	}
+	assert.Equal(t, expected, topo, fmt.Sprintf("expected %v got %v", expected, topo))
+
+	_, err = mgsdk.RuleTopology("unknown", validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestRulesEngineStats(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	stats, err := mgsdk.RulesEngineStats(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := sdk.RulesEngineStats{
+		Streams:   1,
+		Rules:     1,
+		States:    map[string]int{re.StatsRunning: 1, re.StatsStopped: 0, re.StatsError: 0},
+		RecordsIn: 10,
+	}
+	assert.Equal(t, expected, stats, fmt.Sprintf("expected %v got %v", expected, stats))
+
+	stats, err = mgsdk.RulesEngineStats(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	if assert.NotNil(t, stats.Interval, "expected interval since the previous stats") {
+		assert.Zero(t, stats.Interval.RecordsIn, fmt.Sprintf("expected no records in the interval got %d", stats.Interval.RecordsIn))
+	}
+
+	_, err = mgsdk.RulesEngineStats("")
+	assert.Equal(t, http.StatusUnauthorized, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusUnauthorized, err.StatusCode()))
+}
+
+func TestShareRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	share := sdk.EntityShare{Grantee: "00000000-0000-0000-0000-000000000000", GranteeType: re.UserGrantee, Access: re.ViewAccess}
+	res, err := mgsdk.ShareRule("alarm", share, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, share, res, fmt.Sprintf("expected %v got %v", share, res))
+
+	shares, err := mgsdk.RuleShares("alarm", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, []sdk.EntityShare{share}, shares, fmt.Sprintf("expected %v got %v", []sdk.EntityShare{share}, shares))
+
+	err = mgsdk.UnshareRule("alarm", share.Grantee, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	err = mgsdk.UnshareRule("alarm", share.Grantee, validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestRename(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	_, err := mgsdk.CreateStream(sdk.Stream{Name: "readings", Topic: reChannelID, SenML: true}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	res, err := mgsdk.RenameStream("readings", "celsius", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "celsius", res.Name, fmt.Sprintf("expected name %s got %s", "celsius", res.Name))
+
+	_, err = mgsdk.RenameRule("alarm", "overheat", validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+	_, err = mgsdk.RenameStream("celsius", "", validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+}
+
+func TestDrift(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+
+	report, err := mgsdk.Drift(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := []sdk.Drift{
+		{Kind: re.RuleKind, Name: rePrefix + "alarm", Owner: validID, Missing: re.MissingInMetadata},
+		{Kind: re.StreamKind, Name: rePrefix + "temperature", Owner: validID, Missing: re.MissingInMetadata},
+		{Kind: re.StreamKind, Name: "u00000000000000000000000000000000_humidity", Owner: "00000000-0000-0000-0000-000000000000", Missing: re.MissingInMetadata},
+	}
+	assert.ElementsMatch(t, expected, report.Drifts, fmt.Sprintf("expected %v got %v", expected, report.Drifts))
+
+	report, err = mgsdk.RepairDrift(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Len(t, report.Drifts, len(expected), fmt.Sprintf("expected %d drifts got %d", len(expected), len(report.Drifts)))
+	report, err = mgsdk.Drift(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Empty(t, report.Drifts, fmt.Sprintf("expected no drifts got %v", report.Drifts))
+	authCall1.Unset()
+
+	authCall1 = auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: false}, nil)
+	defer authCall1.Unset()
+	_, err = mgsdk.Drift(validToken)
+	assert.Equal(t, http.StatusForbidden, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusForbidden, err.StatusCode()))
+}
+
+func TestOrphans(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	authCall2 := auth.On("ListAllSubjects", mock.Anything, mock.Anything).Return(&magistrala.ListSubjectsRes{Policies: []string{validID}}, nil)
+	defer authCall2.Unset()
+
+	report, err := mgsdk.Orphans(0, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := []sdk.Orphan{
+		{Kind: re.StreamKind, Name: "u00000000000000000000000000000000_humidity", Owner: "00000000-0000-0000-0000-000000000000", Reason: re.OrphanUnused},
+	}
+	assert.Equal(t, expected, report.Orphans, fmt.Sprintf("expected %v got %v", expected, report.Orphans))
+
+	report, err = mgsdk.RemoveOrphans(time.Hour, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected[0].Removed = true
+	assert.Equal(t, expected, report.Orphans, fmt.Sprintf("expected %v got %v", expected, report.Orphans))
+	report, err = mgsdk.Orphans(0, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Empty(t, report.Orphans, fmt.Sprintf("expected no orphans got %v", report.Orphans))
+	authCall1.Unset()
+
+	authCall1 = auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: false}, nil)
+	defer authCall1.Unset()
+	_, err = mgsdk.Orphans(0, validToken)
+	assert.Equal(t, http.StatusForbidden, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusForbidden, err.StatusCode()))
+}
+
+func TestRestoreKuiper(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	defer authCall1.Unset()
+
+	_, err := mgsdk.CreateStream(sdk.Stream{Name: "readings", Topic: reChannelID, SenML: true}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	report, err := mgsdk.RestoreKuiper(true, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := []sdk.RestoredEntity{{Kind: re.StreamKind, Name: rePrefix + "readings", Owner: validID, Status: re.RestoreExists}}
+	assert.Equal(t, expected, report.Entities, fmt.Sprintf("expected %v got %v", expected, report.Entities))
+	assert.True(t, report.DryRun, "expected dry run report")
+}
+
+func TestRuleset(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	_, err := mgsdk.CreateStream(sdk.Stream{Name: "readings", Topic: reChannelID, SenML: true}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	rs, err := mgsdk.ExportRuleset(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := sdk.Ruleset{
+		Streams: []sdk.Stream{{Name: "readings", Topic: reChannelID, Type: re.MainfluxSource, Format: re.JSONFormat, SenML: true}},
+		Rules: []sdk.Rule{{
+			ID:      "alarm",
+			SQL:     "SELECT * FROM temperature WHERE v > 30",
+			Actions: []sdk.RuleAction{{Mainflux: &sdk.MainfluxSink{Channel: reChannelID}}},
+		}},
+		Skipped: []string{"temperature"},
+	}
+	assert.Equal(t, expected, rs, fmt.Sprintf("expected %v got %v", expected, rs))
+
+	report, err := mgsdk.ImportRuleset(rs, re.ConflictRename, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	entities := []sdk.ImportedEntity{
+		{Kind: re.StreamKind, Name: "readings", Status: re.ImportSkipped},
+		{Kind: re.RuleKind, Name: "alarm", Renamed: "alarm_1", Status: re.ImportRenamed},
+	}
+	assert.Equal(t, entities, report.Entities, fmt.Sprintf("expected %v got %v", entities, report.Entities))
+
+	_, err = mgsdk.ImportRuleset(rs, "merge", validToken)
+	assert.NotNil(t, err, "expected error importing ruleset with unknown conflict strategy")
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+}
+
+func TestRuleTemplates(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	defer authCall1.Unset()
+
+	tmpl := sdk.RuleTemplate{
+		Name: "threshold",
+		Variables: []sdk.TemplateVariable{
+			{Name: "stream", Type: re.StreamVariable},
+			{Name: "threshold", Type: re.NumberVariable, Default: "30"},
+		},
+		SQL:     "SELECT * FROM {stream} WHERE v > {threshold}",
+		Actions: []sdk.RuleAction{{Mainflux: &sdk.MainfluxSink{Channel: reChannelID}}},
+	}
+	created, err := mgsdk.CreateRuleTemplate(tmpl, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.False(t, created.CreatedAt.IsZero(), "expected template creation time")
+	tmpl.CreatedAt = created.CreatedAt
+	assert.Equal(t, tmpl, created, fmt.Sprintf("expected %v got %v", tmpl, created))
+
+	_, err = mgsdk.CreateRuleTemplate(tmpl, validToken)
+	assert.NotNil(t, err, "expected error creating existing template")
+	assert.Equal(t, http.StatusConflict, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusConflict, err.StatusCode()))
+
+	tmpls, err := mgsdk.RuleTemplates(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, []sdk.RuleTemplate{created}, tmpls, fmt.Sprintf("expected %v got %v", []sdk.RuleTemplate{created}, tmpls))
+
+	view, err := mgsdk.RuleTemplate(tmpl.Name, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, created, view, fmt.Sprintf("expected %v got %v", created, view))
+
+	inst := sdk.TemplateInstance{ID: "hot", Values: map[string]string{"stream": "temperature"}}
+	rule, err := mgsdk.InstantiateRuleTemplate(tmpl.Name, inst, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "SELECT * FROM temperature WHERE v > 30", rule.SQL, fmt.Sprintf("expected rendered SQL got %s", rule.SQL))
+
+	inst = sdk.TemplateInstance{ID: "cold", Values: map[string]string{"stream": "temperature", "threshold": "thirty"}}
+	_, err = mgsdk.InstantiateRuleTemplate(tmpl.Name, inst, validToken)
+	assert.NotNil(t, err, "expected error instantiating template with invalid value")
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+
+	err = mgsdk.DeleteRuleTemplate(tmpl.Name, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	_, err = mgsdk.RuleTemplate(tmpl.Name, validToken)
+	assert.NotNil(t, err, "expected error viewing removed template")
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestViewRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		authn  error
+		rule   sdk.Rule
+		status int
+	}{
+		{
+			desc:  "view rule",
+			id:    "alarm",
+			token: validToken,
+			rule: sdk.Rule{
+				ID:      "alarm",
+				SQL:     "SELECT * FROM temperature WHERE v > 30",
+				Actions: []sdk.RuleAction{{Mainflux: &sdk.MainfluxSink{Channel: reChannelID}}},
+			},
+		},
+		{
+			desc:   "view non-existing rule",
+			id:     "unknown",
+			token:  validToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view rule with invalid token",
+			id:     "alarm",
+			token:  invalidToken,
+			authn:  svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(&magistrala.IdentityRes{UserId: validID}, tc.authn)
+		rule, err := mgsdk.ViewRule(tc.id, tc.token)
+		if tc.status != 0 {
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+			assert.Equal(t, tc.status, err.StatusCode(), fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, err.StatusCode()))
+		} else {
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		}
+		assert.Equal(t, tc.rule, rule, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.rule, rule))
+		authCall.Unset()
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	cases := []struct {
+		desc   string
+		id     string
+		status int
+	}{
+		{
+			desc:   "create rule",
+			id:     "overheat",
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "create existing rule",
+			id:     "alarm",
+			status: http.StatusConflict,
+		},
+		{
+			desc:   "create rule with malformed ID",
+			id:     "x/../alarm",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		rule := sdk.Rule{
+			ID:      tc.id,
+			SQL:     "SELECT * FROM temperature WHERE v > 40",
+			Actions: []sdk.RuleAction{{Mainflux: &sdk.MainfluxSink{Channel: reChannelID}}},
+		}
+		res, err := mgsdk.CreateRule(rule, validToken)
+		if tc.status != http.StatusCreated {
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+			assert.Equal(t, tc.status, err.StatusCode(), fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, err.StatusCode()))
+			assert.Equal(t, sdk.RulesEngineResult{}, res, fmt.Sprintf("%s: expected empty result got %v", tc.desc, res))
+			continue
+		}
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.id, res.Name, fmt.Sprintf("%s: expected name %s got %s", tc.desc, tc.id, res.Name))
+		assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, res.Status))
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	rule := sdk.Rule{
+		ID:      "overheat",
+		SQL:     "SELECT * FROM temperature WHERE v > 40",
+		Actions: []sdk.RuleAction{{Mainflux: &sdk.MainfluxSink{Channel: reChannelID}}},
+	}
+	res, err := mgsdk.ValidateRule(rule, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := sdk.RuleValidation{Valid: true, Diagnostics: []sdk.RuleDiagnostic{}}
+	assert.Equal(t, expected, res, fmt.Sprintf("expected %v got %v", expected, res))
+
+	rule.SQL = "SELECT * FROM humidity WHERE v > 40"
+	res, err = mgsdk.ValidateRule(rule, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected = sdk.RuleValidation{Diagnostics: []sdk.RuleDiagnostic{{Field: "sql", Position: 15, Message: "stream humidity doesn't exist"}}}
+	assert.Equal(t, expected, res, fmt.Sprintf("expected %v got %v", expected, res))
+
+	_, err = mgsdk.ViewRule(rule.ID, validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestTestRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	trial := sdk.RuleTrial{Rule: sdk.Rule{ID: "overheat", SQL: "SELECT * FROM temperature WHERE v > 40"}}
+	_, err := mgsdk.TestRule(trial, validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+
+	// The fake Kuiper doesn't support rule tests, like Kuiper before 1.11.
+	trial.Samples = map[string][]map[string]interface{}{"temperature": {{"v": 42}}}
+	_, err = mgsdk.TestRule(trial, validToken)
+	assert.Equal(t, http.StatusInternalServerError, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusInternalServerError, err.StatusCode()))
+}
+
+func TestUpdateRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	rule := sdk.Rule{
+		ID:      "alarm",
+		SQL:     "SELECT * FROM temperature WHERE v > 40",
+		Actions: []sdk.RuleAction{{Mainflux: &sdk.MainfluxSink{Channel: reChannelID}}},
+		Options: &sdk.RuleOptions{QoS: 1, CheckpointInterval: 60000},
+	}
+	res, err := mgsdk.UpdateRule(rule, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "alarm", res.Name, fmt.Sprintf("expected name alarm got %s", res.Name))
+	assert.Equal(t, http.StatusOK, res.Status, fmt.Sprintf("expected status %d got %d", http.StatusOK, res.Status))
+
+	updated, err := mgsdk.ViewRule(rule.ID, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	if assert.NotNil(t, updated.Metadata, "expected rule metadata") {
+		assert.False(t, updated.Metadata.UpdatedAt.IsZero(), "expected rule update time")
+	}
+	updated.Metadata = nil
+	assert.Equal(t, rule, updated, fmt.Sprintf("expected %v got %v", rule, updated))
+}
+
+func TestCloneRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	res, err := mgsdk.CloneRule("alarm", "alarm_test", "", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "alarm_test", res.Name, fmt.Sprintf("expected name alarm_test got %s", res.Name))
+
+	rule, err := mgsdk.ViewRule("alarm", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	clone, err := mgsdk.ViewRule("alarm_test", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, rule.SQL, clone.SQL, fmt.Sprintf("expected SQL %s got %s", rule.SQL, clone.SQL))
+	assert.Equal(t, rule.Actions, clone.Actions, fmt.Sprintf("expected actions %v got %v", rule.Actions, clone.Actions))
+
+	res, err = mgsdk.CloneRule("alarm", "alarm_test", "", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "alarm_test", res.Name, fmt.Sprintf("expected name alarm_test got %s", res.Name))
+	_, err = mgsdk.CloneRule("alarm_test", "alarm", "", validToken)
+	assert.Equal(t, http.StatusConflict, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusConflict, err.StatusCode()))
+	_, err = mgsdk.CloneRule("alarm", "", "", validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+}
+
+func TestDrafts(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	rule := sdk.Rule{
+		ID:      "overheat",
+		SQL:     "SELECT * FROM temperature WHERE v > 40",
+		Actions: []sdk.RuleAction{{Mainflux: &sdk.MainfluxSink{Channel: reChannelID}}},
+	}
+	res, err := mgsdk.SaveDraftRule(rule, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "overheat", res.Name, fmt.Sprintf("expected name overheat got %s", res.Name))
+	draft, err := mgsdk.ViewRule(rule.ID, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.True(t, draft.Metadata.Draft, "expected draft rule")
+
+	_, err = mgsdk.SaveDraftRule(sdk.Rule{ID: "alarm", SQL: rule.SQL, Actions: rule.Actions}, validToken)
+	assert.Equal(t, http.StatusConflict, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusConflict, err.StatusCode()))
+
+	_, err = mgsdk.PublishRule(rule.ID, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	published, err := mgsdk.ViewRule(rule.ID, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.False(t, published.Metadata.Draft, "expected published rule")
+	assert.Equal(t, rule.SQL, published.SQL, fmt.Sprintf("expected SQL %s got %s", rule.SQL, published.SQL))
+
+	_, err = mgsdk.UnpublishRule(rule.ID, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	_, err = mgsdk.UnpublishRule(rule.ID, validToken)
+	assert.Equal(t, http.StatusConflict, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusConflict, err.StatusCode()))
+	_, err = mgsdk.PublishRule("unknown", validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestRestoreRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	rule := sdk.Rule{
+		ID:      "overheat",
+		SQL:     "SELECT * FROM temperature WHERE v > 40",
+		Actions: []sdk.RuleAction{{Mainflux: &sdk.MainfluxSink{Channel: reChannelID}}},
+	}
+	_, err := mgsdk.SaveDraftRule(rule, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	_, err = mgsdk.RestoreRule(rule.ID, validToken)
+	assert.Equal(t, http.StatusConflict, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusConflict, err.StatusCode()))
+	_, err = mgsdk.RestoreRule("unknown", validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestAuditEvents(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	rule := sdk.Rule{
+		ID:      "overheat",
+		SQL:     "SELECT * FROM temperature WHERE v > 40",
+		Actions: []sdk.RuleAction{{Mainflux: &sdk.MainfluxSink{Channel: reChannelID}}},
+	}
+	_, err := mgsdk.SaveDraftRule(rule, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	page, err := mgsdk.AuditEvents(sdk.AuditQuery{Limit: 10, Owner: validID, Kind: "rule", Entity: rule.ID}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	if assert.Len(t, page.Events, 1, "expected one audit event") {
+		assert.Equal(t, "draft", page.Events[0].Operation, fmt.Sprintf("expected operation draft got %s", page.Events[0].Operation))
+		assert.Equal(t, rule.SQL, page.Events[0].After, fmt.Sprintf("expected SQL %s got %s", rule.SQL, page.Events[0].After))
+	}
+
+	_, err = mgsdk.AuditEvents(sdk.AuditQuery{Limit: 10, Owner: validID, Kind: "function"}, validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+}
+
+func TestRulesEngineInstances(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	adminCall := auth.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+		SubjectType: "user",
+		SubjectKind: "users",
+		Subject:     validID,
+		Permission:  "admin",
+		ObjectType:  "platform",
+		Object:      "magistrala",
+	}).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	defer adminCall.Unset()
+
+	a, err := mgsdk.AssignRulesEngineInstance(validID, "default", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, sdk.RulesEngineAssignment{UserID: validID, Instance: "default", Assigned: true}, a, fmt.Sprintf("unexpected assignment %v", a))
+
+	instances, err := mgsdk.RulesEngineInstances(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	if assert.Len(t, instances, 1, "expected the default instance") {
+		assert.True(t, instances[0].Default, "expected default instance to be marked")
+		assert.Equal(t, []string{validID}, instances[0].Users, fmt.Sprintf("expected assigned user got %v", instances[0].Users))
+	}
+
+	a, err = mgsdk.UnassignRulesEngineInstance(validID, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.False(t, a.Assigned, "expected assignment to be removed")
+
+	_, err = mgsdk.AssignRulesEngineInstance(validID, "unknown", validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+}
+
+func TestRulesEngineGateways(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeREChannel(auth)
+	defer channelCall.Unset()
+
+	gw, err := mgsdk.SaveRulesEngineGateway(sdk.RulesEngineGateway{ID: "gateway", Name: "plant", Channel: reChannelID}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, reChannelID, gw.Channel, fmt.Sprintf("expected channel %s got %s", reChannelID, gw.Channel))
+
+	gws, err := mgsdk.RulesEngineGateways(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Len(t, gws, 1, fmt.Sprintf("expected one gateway got %v", gws))
+
+	// The service without the edge broker doesn't deploy the rules.
+	_, err = mgsdk.DeployRule("alarm", "gateway", validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+
+	ds, err := mgsdk.RuleDeployments("alarm", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Empty(t, ds, fmt.Sprintf("expected no deployments got %v", ds))
+
+	_, err = mgsdk.DeployFleet("alarm", map[string]string{"site": "north"}, validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+
+	r, err := mgsdk.ViewRollout("alarm", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, sdk.RuleRollout{Rule: "alarm", Status: "empty", Deployments: []sdk.RuleDeployment{}}, r, fmt.Sprintf("unexpected rollout %v", r))
+
+	err = mgsdk.RemoveRulesEngineGateway("gateway", validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+}
+
+func TestReplayRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	to := time.Now()
+	_, err := mgsdk.ReplayRule("alarm", to, to.Add(-time.Hour), validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+
+	_, err = mgsdk.ReplayRule("unknown", to.Add(-time.Hour), to, validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestControlRule(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc    string
+		control func(id, token string) (sdk.RulesEngineResult, errors.SDKError)
+		message string
+	}{
+		{
+			desc:    "start rule",
+			control: mgsdk.StartRule,
+			message: "Rule " + rePrefix + "alarm was started.",
+		},
+		{
+			desc:    "stop rule",
+			control: mgsdk.StopRule,
+			message: "Rule " + rePrefix + "alarm was stopped.",
+		},
+		{
+			desc:    "restart rule",
+			control: mgsdk.RestartRule,
+			message: "Rule " + rePrefix + "alarm was restarted.",
+		},
+		{
+			desc:    "delete rule",
+			control: mgsdk.DeleteRule,
+			message: "Rule " + rePrefix + "alarm is dropped.",
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := tc.control("alarm", validToken)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, "alarm", res.Name, fmt.Sprintf("%s: expected name alarm got %s", tc.desc, res.Name))
+		assert.Equal(t, tc.message, res.Message, fmt.Sprintf("%s: expected message %s got %s", tc.desc, tc.message, res.Message))
+	}
+
+	_, err := mgsdk.ViewRule("alarm", validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+
+	for _, tc := range cases {
+		_, err := tc.control("alarm", validToken)
+		assert.NotNil(t, err, fmt.Sprintf("%s: expected error for removed rule", tc.desc))
+		assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("%s: expected status %d got %d", tc.desc, http.StatusNotFound, err.StatusCode()))
+	}
+}
+
+func TestRulesAlertPolicy(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	policy := sdk.RulesAlertPolicy{Exceptions: 10, Webhook: "https://example.com/alerts"}
+	p, err := mgsdk.SetRulesAlertPolicy(policy, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, policy, p, fmt.Sprintf("expected %v got %v", policy, p))
+	_, err = mgsdk.SetRulesAlertPolicy(sdk.RulesAlertPolicy{Exceptions: 10}, validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+
+	p, err = mgsdk.RulesAlertPolicy(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, policy, p, fmt.Sprintf("expected %v got %v", policy, p))
+
+	err = mgsdk.DeleteRulesAlertPolicy(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	_, err = mgsdk.RulesAlertPolicy(validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
+
+func TestRulesWebhooks(t *testing.T) {
+	ts, auth, _ := setupRulesEngine(t)
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{REURL: ts.URL})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: validID}, nil)
+	defer authCall.Unset()
+
+	wh, err := mgsdk.CreateRulesWebhook(sdk.RulesWebhook{URL: "https://example.com/hooks", Secret: "secret"}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "secret", wh.Secret, fmt.Sprintf("expected secret %s got %s", "secret", wh.Secret))
+	_, err = mgsdk.CreateRulesWebhook(sdk.RulesWebhook{URL: "https://example.com/hooks", Events: []string{"rule.updated"}}, validToken)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusBadRequest, err.StatusCode()))
+
+	whs, err := mgsdk.RulesWebhooks(validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	if assert.Len(t, whs, 1, fmt.Sprintf("expected single webhook got %v", whs)) {
+		assert.Equal(t, wh.ID, whs[0].ID, fmt.Sprintf("expected webhook %s got %s", wh.ID, whs[0].ID))
+		assert.Empty(t, whs[0].Secret, fmt.Sprintf("expected no secret got %s", whs[0].Secret))
+	}
+
+	err = mgsdk.DeleteRulesWebhook(wh.ID, validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	err = mgsdk.DeleteRulesWebhook(wh.ID, validToken)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode(), fmt.Sprintf("expected status %d got %d", http.StatusNotFound, err.StatusCode()))
+}
@@ -86,34 +86,37 @@ type MessagePageMetadata struct {
 }
 
 type PageMetadata struct {
-	Total           uint64   `json:"total"`
-	Offset          uint64   `json:"offset"`
-	Limit           uint64   `json:"limit"`
-	Order           string   `json:"order,omitempty"`
-	Direction       string   `json:"direction,omitempty"`
-	Level           uint64   `json:"level,omitempty"`
-	Identity        string   `json:"identity,omitempty"`
-	Name            string   `json:"name,omitempty"`
-	Type            string   `json:"type,omitempty"`
-	Metadata        Metadata `json:"metadata,omitempty"`
-	Status          string   `json:"status,omitempty"`
-	Action          string   `json:"action,omitempty"`
-	Subject         string   `json:"subject,omitempty"`
-	Object          string   `json:"object,omitempty"`
-	Permission      string   `json:"permission,omitempty"`
-	Tag             string   `json:"tag,omitempty"`
-	Owner           string   `json:"owner,omitempty"`
-	SharedBy        string   `json:"shared_by,omitempty"`
-	Visibility      string   `json:"visibility,omitempty"`
-	OwnerID         string   `json:"owner_id,omitempty"`
-	Topic           string   `json:"topic,omitempty"`
-	Contact         string   `json:"contact,omitempty"`
-	State           string   `json:"state,omitempty"`
-	ListPermissions string   `json:"list_perms,omitempty"`
-	InvitedBy       string   `json:"invited_by,omitempty"`
-	UserID          string   `json:"user_id,omitempty"`
-	DomainID        string   `json:"domain_id,omitempty"`
-	Relation        string   `json:"relation,omitempty"`
+	Total           uint64            `json:"total"`
+	Offset          uint64            `json:"offset"`
+	Limit           uint64            `json:"limit"`
+	Order           string            `json:"order,omitempty"`
+	Direction       string            `json:"direction,omitempty"`
+	Level           uint64            `json:"level,omitempty"`
+	Identity        string            `json:"identity,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Type            string            `json:"type,omitempty"`
+	Metadata        Metadata          `json:"metadata,omitempty"`
+	Status          string            `json:"status,omitempty"`
+	Action          string            `json:"action,omitempty"`
+	Subject         string            `json:"subject,omitempty"`
+	Object          string            `json:"object,omitempty"`
+	Permission      string            `json:"permission,omitempty"`
+	Tag             string            `json:"tag,omitempty"`
+	Owner           string            `json:"owner,omitempty"`
+	SharedBy        string            `json:"shared_by,omitempty"`
+	Visibility      string            `json:"visibility,omitempty"`
+	OwnerID         string            `json:"owner_id,omitempty"`
+	Topic           string            `json:"topic,omitempty"`
+	Contact         string            `json:"contact,omitempty"`
+	State           string            `json:"state,omitempty"`
+	ListPermissions string            `json:"list_perms,omitempty"`
+	InvitedBy       string            `json:"invited_by,omitempty"`
+	UserID          string            `json:"user_id,omitempty"`
+	DomainID        string            `json:"domain_id,omitempty"`
+	Relation        string            `json:"relation,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Deleted         bool              `json:"deleted,omitempty"`
+	Folder          string            `json:"folder,omitempty"`
 }
 
 // Credentials represent client credentials: it contains
@@ -1174,6 +1177,979 @@ type SDK interface {
 	//  err := sdk.DeleteInvitation("userID", "domainID", "token")
 	//  fmt.Println(err)
 	DeleteInvitation(userID, domainID, token string) (err error)
+
+	// CreateStream creates new rules engine stream reading messages from the
+	// channel with the given ID.
+	//
+	// example:
+	//  stream := sdk.Stream{
+	//    Name:   "temperature",
+	//    Topic:  "channelID",
+	//    Fields: []sdk.SchemaField{
+	//      {Name: "v", Type: "float"},
+	//      {Name: "n", Type: "string"},
+	//    },
+	//  }
+	//  res, _ := sdk.CreateStream(stream, "token")
+	//  fmt.Println(res)
+	CreateStream(stream Stream, token string) (RulesEngineResult, errors.SDKError)
+
+	// Streams returns page of the rules engine streams. Streams of the group
+	// the user is a member of are listed by setting the page Owner to the
+	// group ID.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  streams, _ := sdk.Streams(pm, "token")
+	//  fmt.Println(streams)
+	Streams(pm PageMetadata, token string) (StreamsPage, errors.SDKError)
+
+	// ViewStream returns the rules engine stream with the given name.
+	//
+	// example:
+	//  stream, _ := sdk.ViewStream("temperature", "token")
+	//  fmt.Println(stream)
+	ViewStream(name, token string) (StreamInfo, errors.SDKError)
+
+	// ViewStreams returns the rules engine streams with the given names,
+	// fetched concurrently, and the names of the streams that don't exist.
+	//
+	// example:
+	//  batch, _ := sdk.ViewStreams([]string{"temperature", "humidity"}, "token")
+	//  fmt.Println(batch)
+	ViewStreams(names []string, token string) (StreamsBatch, errors.SDKError)
+
+	// UpdateStream replaces the definition of the rules engine stream with
+	// the name of the given stream.
+	//
+	// example:
+	//  stream := sdk.Stream{
+	//    Name:   "temperature",
+	//    Topic:  "channelID",
+	//    Fields: []sdk.SchemaField{
+	//      {Name: "v", Type: "float"},
+	//      {Name: "n", Type: "string"},
+	//      {Name: "t", Type: "float"},
+	//    },
+	//  }
+	//  res, _ := sdk.UpdateStream(stream, "token")
+	//  fmt.Println(res)
+	UpdateStream(stream Stream, token string) (RulesEngineResult, errors.SDKError)
+
+	// DeleteStream removes the rules engine stream with the given name. The
+	// streams the rules read from aren't removed, unless cascade is set,
+	// which removes the rules as well.
+	//
+	// example:
+	//  res, _ := sdk.DeleteStream("temperature", false, "token")
+	//  fmt.Println(res)
+	DeleteStream(name string, cascade bool, token string) (RulesEngineResult, errors.SDKError)
+
+	// IngestStream pushes the message to the rules engine httppush stream
+	// with the given ingestion name, the last segment of the stream's
+	// ingestion URL. The thing key must allow publishing to the stream's
+	// channel.
+	//
+	// example:
+	//  err := sdk.IngestStream("u1234_temperature", `{"v": 21.5}`, "thingKey")
+	//  fmt.Println(err)
+	IngestStream(stream, msg, key string) errors.SDKError
+
+	// CreateTable creates new rules engine table holding the reference data
+	// rules join the streams with.
+	//
+	// example:
+	//  table := sdk.Table{
+	//    Name:   "devices",
+	//    Topic:  "devices.json",
+	//    Fields: []sdk.SchemaField{
+	//      {Name: "id", Type: "string"},
+	//      {Name: "location", Type: "string"},
+	//    },
+	//  }
+	//  res, _ := sdk.CreateTable(table, "token")
+	//  fmt.Println(res)
+	CreateTable(table Table, token string) (RulesEngineResult, errors.SDKError)
+
+	// Tables returns page of the rules engine tables. Tables of the group
+	// the user is a member of are listed by setting the page Owner to the
+	// group ID.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  tables, _ := sdk.Tables(pm, "token")
+	//  fmt.Println(tables)
+	Tables(pm PageMetadata, token string) (TablesPage, errors.SDKError)
+
+	// ViewTable returns the rules engine table with the given name.
+	//
+	// example:
+	//  table, _ := sdk.ViewTable("devices", "token")
+	//  fmt.Println(table)
+	ViewTable(name, token string) (TableInfo, errors.SDKError)
+
+	// DeleteTable removes the rules engine table with the given name.
+	//
+	// example:
+	//  res, _ := sdk.DeleteTable("devices", "token")
+	//  fmt.Println(res)
+	DeleteTable(name, token string) (RulesEngineResult, errors.SDKError)
+
+	// CreateRule creates new rules engine rule.
+	//
+	// example:
+	//  rule := sdk.Rule{
+	//    ID:  "alarm",
+	//    SQL: "SELECT * FROM temperature WHERE v > 30",
+	//    Actions: []sdk.RuleAction{
+	//      {Mainflux: &sdk.MainfluxSink{Channel: "channelID"}},
+	//    },
+	//  }
+	//  res, _ := sdk.CreateRule(rule, "token")
+	//  fmt.Println(res)
+	CreateRule(rule Rule, token string) (RulesEngineResult, errors.SDKError)
+
+	// Rules returns page of the rules engine rules. Rules of the group
+	// the user is a member of are listed by setting the page Owner to the
+	// group ID.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  rules, _ := sdk.Rules(pm, "token")
+	//  fmt.Println(rules)
+	Rules(pm PageMetadata, token string) (RulesPage, errors.SDKError)
+
+	// SearchRules returns page of the rules engine rules that read from the
+	// stream with the given name or publish to the channel with the given
+	// ID. If both are set, the rules must reference both.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  rules, _ := sdk.SearchRules("temperature", "", pm, "token")
+	//  fmt.Println(rules)
+	SearchRules(stream, channel string, pm PageMetadata, token string) (RulesPage, errors.SDKError)
+
+	// ViewRule returns the rules engine rule with the given ID.
+	//
+	// example:
+	//  rule, _ := sdk.ViewRule("alarm", "token")
+	//  fmt.Println(rule)
+	ViewRule(id, token string) (Rule, errors.SDKError)
+
+	// ViewRules returns the rules engine rules with the given IDs, fetched
+	// concurrently, and the IDs of the rules that don't exist.
+	//
+	// example:
+	//  batch, _ := sdk.ViewRules([]string{"alarm", "average"}, "token")
+	//  fmt.Println(batch)
+	ViewRules(ids []string, token string) (RulesBatch, errors.SDKError)
+
+	// UpdateRule replaces the SQL and actions of the rules engine rule with
+	// the ID of the given rule.
+	//
+	// example:
+	//  rule := sdk.Rule{
+	//    ID:  "alarm",
+	//    SQL: "SELECT * FROM temperature WHERE v > 40",
+	//    Actions: []sdk.RuleAction{
+	//      {Mainflux: &sdk.MainfluxSink{Channel: "channelID"}},
+	//    },
+	//  }
+	//  res, _ := sdk.UpdateRule(rule, "token")
+	//  fmt.Println(res)
+	UpdateRule(rule Rule, token string) (RulesEngineResult, errors.SDKError)
+
+	// PatchRule changes only the SQL, actions or options of the rules
+	// engine rule set in the patch. The patched rule is validated and
+	// restarted.
+	//
+	// example:
+	//  sql := "SELECT * FROM temperature WHERE v > 45"
+	//  res, _ := sdk.PatchRule("alarm", sdk.RulePatch{SQL: &sql}, "token")
+	//  fmt.Println(res)
+	PatchRule(id string, patch RulePatch, token string) (RulesEngineResult, errors.SDKError)
+
+	// CloneRule creates the rules engine rule with the new ID and the SQL,
+	// actions and options of the rule with the given ID. If the channel
+	// isn't empty, the Mainflux actions of the clone publish to it.
+	//
+	// example:
+	//  res, _ := sdk.CloneRule("alarm", "alarm_test", "testChannelID", "token")
+	//  fmt.Println(res)
+	CloneRule(id, newID, channel, token string) (RulesEngineResult, errors.SDKError)
+
+	// ValidateRule checks the rules engine rule without creating it and
+	// returns the diagnostics of all the problems found, so the rule can be
+	// corrected before it's created.
+	//
+	// example:
+	//  rule := sdk.Rule{
+	//    ID:  "alarm",
+	//    SQL: "SELECT * FROM temperature WHERE v > 40",
+	//    Actions: []sdk.RuleAction{
+	//      {Mainflux: &sdk.MainfluxSink{Channel: "channelID"}},
+	//    },
+	//  }
+	//  res, _ := sdk.ValidateRule(rule, "token")
+	//  fmt.Println(res.Valid, res.Diagnostics)
+	ValidateRule(rule Rule, token string) (RuleValidation, errors.SDKError)
+
+	// TestRule runs the rules engine rule against the sample messages and
+	// returns the results the rule produced. The rule isn't created and
+	// its actions aren't executed.
+	//
+	// example:
+	//  trial := sdk.RuleTrial{
+	//    Rule: sdk.Rule{ID: "alarm", SQL: "SELECT * FROM temperature WHERE v > 40"},
+	//    Samples: map[string][]map[string]interface{}{
+	//      "temperature": {{"v": 35}, {"v": 42}},
+	//    },
+	//  }
+	//  res, _ := sdk.TestRule(trial, "token")
+	//  fmt.Println(res.Results)
+	TestRule(trial RuleTrial, token string) (TrialResult, errors.SDKError)
+
+	// DeleteRule removes the rules engine rule with the given ID.
+	//
+	// example:
+	//  res, _ := sdk.DeleteRule("alarm", "token")
+	//  fmt.Println(res)
+	DeleteRule(id, token string) (RulesEngineResult, errors.SDKError)
+
+	// StartRule starts the rules engine rule with the given ID.
+	//
+	// example:
+	//  res, _ := sdk.StartRule("alarm", "token")
+	//  fmt.Println(res)
+	StartRule(id, token string) (RulesEngineResult, errors.SDKError)
+
+	// StopRule stops the rules engine rule with the given ID.
+	//
+	// example:
+	//  res, _ := sdk.StopRule("alarm", "token")
+	//  fmt.Println(res)
+	StopRule(id, token string) (RulesEngineResult, errors.SDKError)
+
+	// RestartRule restarts the rules engine rule with the given ID.
+	//
+	// example:
+	//  res, _ := sdk.RestartRule("alarm", "token")
+	//  fmt.Println(res)
+	RestartRule(id, token string) (RulesEngineResult, errors.SDKError)
+
+	// SaveDraftRule validates the rules engine rule and saves it as the
+	// draft, which isn't deployed until it's published. Saving the existing
+	// draft replaces it.
+	//
+	// example:
+	//  rule := sdk.Rule{
+	//    ID:  "alarm",
+	//    SQL: "SELECT * FROM temperature WHERE v > 30",
+	//    Actions: []sdk.RuleAction{
+	//      {Mainflux: &sdk.MainfluxSink{Channel: "channelID"}},
+	//    },
+	//  }
+	//  res, _ := sdk.SaveDraftRule(rule, "token")
+	//  fmt.Println(res)
+	SaveDraftRule(rule Rule, token string) (RulesEngineResult, errors.SDKError)
+
+	// PublishRule deploys the draft rules engine rule with the given ID.
+	//
+	// example:
+	//  res, _ := sdk.PublishRule("alarm", "token")
+	//  fmt.Println(res)
+	PublishRule(id, token string) (RulesEngineResult, errors.SDKError)
+
+	// UnpublishRule withdraws the published rules engine rule with the
+	// given ID, keeping it as the draft.
+	//
+	// example:
+	//  res, _ := sdk.UnpublishRule("alarm", "token")
+	//  fmt.Println(res)
+	UnpublishRule(id, token string) (RulesEngineResult, errors.SDKError)
+
+	// RestoreRule restores the deleted rules engine rule with the given ID,
+	// while its restore window is open.
+	//
+	// example:
+	//  res, _ := sdk.RestoreRule("alarm", "token")
+	//  fmt.Println(res)
+	RestoreRule(id, token string) (RulesEngineResult, errors.SDKError)
+
+	// RuleStatus returns runtime status and metrics of the rules engine rule
+	// with the given ID.
+	//
+	// example:
+	//  status, _ := sdk.RuleStatus("alarm", "token")
+	//  fmt.Println(status)
+	RuleStatus(id, token string) (RuleStatus, errors.SDKError)
+
+	// RuleTopology returns the graph of the operators the rules engine rule
+	// with the given ID runs as.
+	//
+	// example:
+	//  topo, _ := sdk.RuleTopology("alarm", "token")
+	//  fmt.Println(topo.Edges)
+	RuleTopology(id, token string) (RuleTopology, errors.SDKError)
+
+	// RuleLogs returns the latest Kuiper log lines of the rules engine rule
+	// with the given ID logged since the given time, or all the latest
+	// lines if the time is zero.
+	//
+	// example:
+	//  logs, _ := sdk.RuleLogs("alarm", time.Now().Add(-time.Hour), "token")
+	//  fmt.Println(logs)
+	RuleLogs(id string, since time.Time, token string) ([]RuleLogEntry, errors.SDKError)
+
+	// ReplayRule replays the historical messages of the channels the rules
+	// engine rule reads from, received between from and to, through the
+	// rule and returns the results the rule produced.
+	//
+	// example:
+	//  to := time.Now()
+	//  res, _ := sdk.ReplayRule("alarm", to.Add(-24*time.Hour), to, "token")
+	//  fmt.Println(res.Messages, res.Results)
+	ReplayRule(id string, from, to time.Time, token string) (ReplayResult, errors.SDKError)
+
+	// Drift returns the streams and rules that exist only in Kuiper or only
+	// in the rules engine metadata store. Only the platform administrator
+	// can view the drift.
+	//
+	// example:
+	//  report, _ := sdk.Drift("token")
+	//  fmt.Println(report)
+	Drift(token string) (DriftReport, errors.SDKError)
+
+	// RepairDrift repairs the drift between Kuiper and the rules engine
+	// metadata store and returns the repaired drifts. Metadata of the
+	// entities missing in Kuiper is removed and the missing metadata of the
+	// Kuiper entities is created.
+	//
+	// example:
+	//  report, _ := sdk.RepairDrift("token")
+	//  fmt.Println(report)
+	RepairDrift(token string) (DriftReport, errors.SDKError)
+
+	// Orphans returns the streams no rule reads from and the rules reading
+	// from missing streams or publishing to removed channels, leaving out
+	// the entities changed less than minAge ago. Only the platform
+	// administrator can view the orphans.
+	//
+	// example:
+	//  report, _ := sdk.Orphans(24*time.Hour, "token")
+	//  fmt.Println(report)
+	Orphans(minAge time.Duration, token string) (OrphanReport, errors.SDKError)
+
+	// RemoveOrphans removes the orphans Orphans returns and reports whether
+	// each of them was removed.
+	//
+	// example:
+	//  report, _ := sdk.RemoveOrphans(24*time.Hour, "token")
+	//  fmt.Println(report)
+	RemoveOrphans(minAge time.Duration, token string) (OrphanReport, errors.SDKError)
+
+	// RestoreKuiper replays the stored stream and rule definitions missing
+	// in Kuiper, streams first. Dry run only reports what would be
+	// restored. Only the platform administrator can restore.
+	//
+	// example:
+	//  report, _ := sdk.RestoreKuiper(true, "token")
+	//  fmt.Println(report)
+	RestoreKuiper(dryRun bool, token string) (RestoreReport, errors.SDKError)
+
+	// ExportRuleset returns all the rules engine streams and rules of the
+	// user, named without the owner prefix.
+	//
+	// example:
+	//  rs, _ := sdk.ExportRuleset("token")
+	//  fmt.Println(rs)
+	ExportRuleset(token string) (Ruleset, errors.SDKError)
+
+	// ImportRuleset creates the streams and rules of the ruleset, streams
+	// first. Conflict is the strategy resolving the conflicts with the
+	// existing entities: skip (default), overwrite or rename.
+	//
+	// example:
+	//  report, _ := sdk.ImportRuleset(rs, "rename", "token")
+	//  fmt.Println(report)
+	ImportRuleset(rs Ruleset, conflict, token string) (ImportReport, errors.SDKError)
+
+	// BulkCreate creates the streams and then the rules concurrently and
+	// reports the result of each of them, so failing streams and rules don't
+	// stop the rest.
+	//
+	// example:
+	//  rs := sdk.Ruleset{
+	//    Streams: []sdk.Stream{{Name: "temperature", Topic: "channelID"}},
+	//    Rules:   []sdk.Rule{rule},
+	//  }
+	//  report, _ := sdk.BulkCreate(rs, "token")
+	//  fmt.Println(report)
+	BulkCreate(rs Ruleset, token string) (BulkReport, errors.SDKError)
+
+	// BulkDelete removes the rules and then the streams concurrently and
+	// reports the result of each of them.
+	//
+	// example:
+	//  bd := sdk.BulkDeletion{Streams: []string{"temperature"}, Rules: []string{"alarm"}}
+	//  report, _ := sdk.BulkDelete(bd, "token")
+	//  fmt.Println(report)
+	BulkDelete(bd BulkDeletion, token string) (BulkReport, errors.SDKError)
+
+	// StartRules starts the rules selected either by their IDs or by their
+	// labels concurrently and reports the result of each of them.
+	//
+	// example:
+	//  sel := sdk.RuleSelection{Labels: map[string]string{"site": "plant_a"}}
+	//  report, _ := sdk.StartRules(sel, "token")
+	//  fmt.Println(report)
+	StartRules(sel RuleSelection, token string) (BulkReport, errors.SDKError)
+
+	// StopRules stops the rules selected either by their IDs or by their
+	// labels concurrently and reports the result of each of them.
+	//
+	// example:
+	//  sel := sdk.RuleSelection{IDs: []string{"alarm", "overheat"}}
+	//  report, _ := sdk.StopRules(sel, "token")
+	//  fmt.Println(report)
+	StopRules(sel RuleSelection, token string) (BulkReport, errors.SDKError)
+
+	// ListAllStreams returns the rules engine streams of all the users,
+	// grouped by owner. Only the platform administrator can list them.
+	//
+	// example:
+	//  all, _ := sdk.ListAllStreams("token")
+	//  fmt.Println(all)
+	ListAllStreams(token string) (AllStreams, errors.SDKError)
+
+	// ListAllRules returns the rules of all the users, grouped by owner,
+	// along with their counts by state. Only the platform administrator can
+	// list them.
+	//
+	// example:
+	//  all, _ := sdk.ListAllRules("token")
+	//  fmt.Println(all.States)
+	ListAllRules(token string) (AllRules, errors.SDKError)
+
+	// RulesQuota returns the rules engine quota of the user along with the
+	// numbers of the streams and rules the user has. Users view their own
+	// quotas, while the platform administrator views any.
+	//
+	// example:
+	//  q, _ := sdk.RulesQuota("userID", "token")
+	//  fmt.Println(q)
+	RulesQuota(userID, token string) (UserRulesQuota, errors.SDKError)
+
+	// SetRulesQuota replaces the default rules engine quota of the user.
+	// Only the platform administrator can set quotas.
+	//
+	// example:
+	//  q := sdk.RulesQuota{MaxStreams: 10, MaxRules: 50}
+	//  uq, _ := sdk.SetRulesQuota("userID", q, "token")
+	//  fmt.Println(uq)
+	SetRulesQuota(userID string, q RulesQuota, token string) (UserRulesQuota, errors.SDKError)
+
+	// DeleteRulesQuota removes the rules engine quota set for the user, so
+	// the default quota applies.
+	//
+	// example:
+	//  err := sdk.DeleteRulesQuota("userID", "token")
+	//  fmt.Println(err)
+	DeleteRulesQuota(userID, token string) errors.SDKError
+
+	// SetRulesAlertPolicy replaces the policy the user is alerted about the
+	// failing rules engine rules with.
+	//
+	// example:
+	//  p := sdk.RulesAlertPolicy{Exceptions: 10, Webhook: "https://example.com/alerts"}
+	//  p, _ = sdk.SetRulesAlertPolicy(p, "token")
+	//  fmt.Println(p)
+	SetRulesAlertPolicy(p RulesAlertPolicy, token string) (RulesAlertPolicy, errors.SDKError)
+
+	// RulesAlertPolicy returns the user's rules engine alert policy.
+	//
+	// example:
+	//  p, _ := sdk.RulesAlertPolicy("token")
+	//  fmt.Println(p)
+	RulesAlertPolicy(token string) (RulesAlertPolicy, errors.SDKError)
+
+	// DeleteRulesAlertPolicy removes the user's rules engine alert policy,
+	// so the user is no longer alerted about the failing rules.
+	//
+	// example:
+	//  err := sdk.DeleteRulesAlertPolicy("token")
+	//  fmt.Println(err)
+	DeleteRulesAlertPolicy(token string) errors.SDKError
+
+	// CreateRulesWebhook registers the webhook called on the lifecycle
+	// events of the user's rules. The secret signing the payloads is
+	// generated if it isn't given, and it's returned only here.
+	//
+	// example:
+	//  wh := sdk.RulesWebhook{
+	//    URL:    "https://ci.example.com/hooks/rules",
+	//    Events: []string{"rule.created", "rule.errored"},
+	//  }
+	//  wh, _ := sdk.CreateRulesWebhook(wh, "token")
+	//  fmt.Println(wh.Secret)
+	CreateRulesWebhook(wh RulesWebhook, token string) (RulesWebhook, errors.SDKError)
+
+	// RulesWebhooks returns the user's rules engine webhooks without their
+	// secrets.
+	//
+	// example:
+	//  whs, _ := sdk.RulesWebhooks("token")
+	//  fmt.Println(whs)
+	RulesWebhooks(token string) ([]RulesWebhook, errors.SDKError)
+
+	// DeleteRulesWebhook removes the user's rules engine webhook.
+	//
+	// example:
+	//  err := sdk.DeleteRulesWebhook("webhookID", "token")
+	//  fmt.Println(err)
+	DeleteRulesWebhook(id, token string) errors.SDKError
+
+	// CreateRulesFolder creates the folder the user's rules are organized
+	// in, by project or site for example.
+	//
+	// example:
+	//  f := sdk.RulesFolder{Name: "site_a", Description: "Site A rules"}
+	//  f, _ = sdk.CreateRulesFolder(f, "token")
+	//  fmt.Println(f)
+	CreateRulesFolder(f RulesFolder, token string) (RulesFolder, errors.SDKError)
+
+	// RulesFolders returns the user's rule folders along with the number of
+	// the rules in them. The rules of the folder are listed by the folder
+	// filter of the rules page.
+	//
+	// example:
+	//  folders, _ := sdk.RulesFolders("token")
+	//  fmt.Println(folders)
+	RulesFolders(token string) ([]RulesFolder, errors.SDKError)
+
+	// DeleteRulesFolder removes the user's rule folder, leaving its rules
+	// without a folder.
+	//
+	// example:
+	//  err := sdk.DeleteRulesFolder("site_a", "token")
+	//  fmt.Println(err)
+	DeleteRulesFolder(name, token string) errors.SDKError
+
+	// MoveRules moves the user's rules to the folder, or out of their
+	// folders if the folder is empty, reporting the result for each rule.
+	//
+	// example:
+	//  report, _ := sdk.MoveRules("site_a", []string{"overheat", "humidity"}, "token")
+	//  fmt.Println(report)
+	MoveRules(folder string, ids []string, token string) (BulkReport, errors.SDKError)
+
+	// PauseRules puts the user in the maintenance mode, stopping all of the
+	// user's running rules and reporting the result for each of them.
+	//
+	// example:
+	//  report, _ := sdk.PauseRules("token")
+	//  fmt.Println(report)
+	PauseRules(token string) (BulkReport, errors.SDKError)
+
+	// ResumeRules ends the user's maintenance mode, starting exactly the
+	// rules that were running when the rules were paused.
+	//
+	// example:
+	//  report, _ := sdk.ResumeRules("token")
+	//  fmt.Println(report)
+	ResumeRules(token string) (BulkReport, errors.SDKError)
+
+	// RulesMaintenance returns the user's maintenance mode, failing if the
+	// user's rules aren't paused.
+	//
+	// example:
+	//  m, _ := sdk.RulesMaintenance("token")
+	//  fmt.Println(m)
+	RulesMaintenance(token string) (RulesMaintenance, errors.SDKError)
+
+	// CreateRulesChain feeds the results of the user's rule to another rule
+	// through the memory stream the service creates along with the sink
+	// publishing to it.
+	//
+	// example:
+	//  c := sdk.RulesChain{
+	//    Name:   "filtered",
+	//    From:   "filter",
+	//    To:     "alarm",
+	//    Fields: []sdk.SchemaField{{Name: "v", Type: "float"}},
+	//  }
+	//  c, _ = sdk.CreateRulesChain(c, "token")
+	//  fmt.Println(c)
+	CreateRulesChain(c RulesChain, token string) (RulesChain, errors.SDKError)
+
+	// RulesChains returns the user's rule chains.
+	//
+	// example:
+	//  chains, _ := sdk.RulesChains("token")
+	//  fmt.Println(chains)
+	RulesChains(token string) ([]RulesChain, errors.SDKError)
+
+	// DeleteRulesChain removes the user's rule chain no rule reads from,
+	// along with its stream and the sink publishing to it.
+	//
+	// example:
+	//  err := sdk.DeleteRulesChain("filtered", "token")
+	//  fmt.Println(err)
+	DeleteRulesChain(name, token string) errors.SDKError
+
+	// SaveRulesSecret creates or replaces the user's secret the rule actions
+	// refer to with {{secret "<name>"}}. The value is stored encrypted and
+	// is never returned.
+	//
+	// example:
+	//  s, _ := sdk.SaveRulesSecret("mqtt-password", "s3cr3t", "token")
+	//  fmt.Println(s)
+	SaveRulesSecret(name, value, token string) (RulesSecret, errors.SDKError)
+
+	// RulesSecrets returns the user's secrets without the values.
+	//
+	// example:
+	//  secrets, _ := sdk.RulesSecrets("token")
+	//  fmt.Println(secrets)
+	RulesSecrets(token string) ([]RulesSecret, errors.SDKError)
+
+	// DeleteRulesSecret removes the user's secret.
+	//
+	// example:
+	//  err := sdk.DeleteRulesSecret("mqtt-password", "token")
+	//  fmt.Println(err)
+	DeleteRulesSecret(name, token string) errors.SDKError
+
+	// ShareStream shares the user's rules engine stream with another user or
+	// the members of a group, with the view or manage access.
+	//
+	// example:
+	//  s := sdk.EntityShare{Grantee: "userID", GranteeType: "user", Access: "view"}
+	//  s, _ = sdk.ShareStream("temperature", s, "token")
+	//  fmt.Println(s)
+	ShareStream(name string, s EntityShare, token string) (EntityShare, errors.SDKError)
+
+	// StreamShares returns the shares of the user's rules engine stream.
+	//
+	// example:
+	//  shares, _ := sdk.StreamShares("temperature", "token")
+	//  fmt.Println(shares)
+	StreamShares(name, token string) ([]EntityShare, errors.SDKError)
+
+	// UnshareStream revokes the share of the user's rules engine stream with
+	// the grantee.
+	//
+	// example:
+	//  err := sdk.UnshareStream("temperature", "userID", "token")
+	//  fmt.Println(err)
+	UnshareStream(name, grantee, token string) errors.SDKError
+
+	// ShareRule shares the user's rules engine rule with another user or the
+	// members of a group, with the view or manage access.
+	//
+	// example:
+	//  s := sdk.EntityShare{Grantee: "groupID", GranteeType: "group", Access: "manage"}
+	//  s, _ = sdk.ShareRule("alarm", s, "token")
+	//  fmt.Println(s)
+	ShareRule(id string, s EntityShare, token string) (EntityShare, errors.SDKError)
+
+	// RuleShares returns the shares of the user's rules engine rule.
+	//
+	// example:
+	//  shares, _ := sdk.RuleShares("alarm", "token")
+	//  fmt.Println(shares)
+	RuleShares(id, token string) ([]EntityShare, errors.SDKError)
+
+	// UnshareRule revokes the share of the user's rules engine rule with the
+	// grantee.
+	//
+	// example:
+	//  err := sdk.UnshareRule("alarm", "groupID", "token")
+	//  fmt.Println(err)
+	UnshareRule(id, grantee, token string) errors.SDKError
+
+	// RenameStream renames the user's rules engine stream. The rules reading
+	// from the stream are updated to read from it under the new name.
+	//
+	// example:
+	//  res, _ := sdk.RenameStream("temperature", "celsius", "token")
+	//  fmt.Println(res)
+	RenameStream(name, newName, token string) (RulesEngineResult, errors.SDKError)
+
+	// RenameRule renames the user's rules engine rule.
+	//
+	// example:
+	//  res, _ := sdk.RenameRule("alarm", "overheat", "token")
+	//  fmt.Println(res)
+	RenameRule(id, newID, token string) (RulesEngineResult, errors.SDKError)
+
+	// AuditEvents returns the page of the audit events of the operations
+	// performed on the rules engine streams, tables and rules, newest
+	// first. Users view the events of their own entities and the platform
+	// administrator the events of all the entities.
+	//
+	// example:
+	//  q := sdk.AuditQuery{
+	//    Limit:  10,
+	//    Kind:   "rule",
+	//    Entity: "alarm",
+	//    From:   time.Now().Add(-24 * time.Hour),
+	//  }
+	//  page, _ := sdk.AuditEvents(q, "token")
+	//  fmt.Println(page)
+	AuditEvents(q AuditQuery, token string) (AuditPage, errors.SDKError)
+
+	// RulesEngineInstances returns the Kuiper instances the rules engine
+	// spreads the users over, along with the users assigned to them. Only
+	// the platform administrator can list them.
+	//
+	// example:
+	//  instances, _ := sdk.RulesEngineInstances("token")
+	//  fmt.Println(instances)
+	RulesEngineInstances(token string) ([]RulesEngineInstance, errors.SDKError)
+
+	// AssignRulesEngineInstance assigns the user to the Kuiper instance.
+	// Users are moved to another instance only before they create streams,
+	// tables or rules. Only the platform administrator can assign users.
+	//
+	// example:
+	//  a, _ := sdk.AssignRulesEngineInstance("userID", "dedicated", "token")
+	//  fmt.Println(a)
+	AssignRulesEngineInstance(userID, instance, token string) (RulesEngineAssignment, errors.SDKError)
+
+	// UnassignRulesEngineInstance removes the assignment of the user, leaving
+	// the user on the instance the pool strategy picks.
+	//
+	// example:
+	//  a, _ := sdk.UnassignRulesEngineInstance("userID", "token")
+	//  fmt.Println(a)
+	UnassignRulesEngineInstance(userID, token string) (RulesEngineAssignment, errors.SDKError)
+
+	// SaveRulesEngineGateway registers the edge gateway running its own
+	// Kuiper instance, or updates the registered one. Gateways registered
+	// without the control channel use the first channel of their bootstrap
+	// configuration.
+	//
+	// example:
+	//  gw := sdk.RulesEngineGateway{
+	//    ID:      "thingID",
+	//    Name:    "plant",
+	//    Channel: "channelID",
+	//  }
+	//  gw, _ := sdk.SaveRulesEngineGateway(gw, "token")
+	//  fmt.Println(gw)
+	SaveRulesEngineGateway(gw RulesEngineGateway, token string) (RulesEngineGateway, errors.SDKError)
+
+	// RulesEngineGateways returns the edge gateways the user registered.
+	//
+	// example:
+	//  gws, _ := sdk.RulesEngineGateways("token")
+	//  fmt.Println(gws)
+	RulesEngineGateways(token string) ([]RulesEngineGateway, errors.SDKError)
+
+	// RemoveRulesEngineGateway removes the edge gateway without deployed
+	// rules.
+	//
+	// example:
+	//  err := sdk.RemoveRulesEngineGateway("thingID", "token")
+	//  fmt.Println(err)
+	RemoveRulesEngineGateway(id, token string) errors.SDKError
+
+	// DeployRule sends the rule, along with the streams and tables it reads
+	// from, to the edge gateway. The deployment is pending until the gateway
+	// reports its status.
+	//
+	// example:
+	//  d, _ := sdk.DeployRule("ruleID", "thingID", "token")
+	//  fmt.Println(d)
+	DeployRule(id, gateway, token string) (RuleDeployment, errors.SDKError)
+
+	// UndeployRule removes the rule from the edge gateway.
+	//
+	// example:
+	//  d, _ := sdk.UndeployRule("ruleID", "thingID", "token")
+	//  fmt.Println(d)
+	UndeployRule(id, gateway, token string) (RuleDeployment, errors.SDKError)
+
+	// RuleDeployments returns the deployments of the rule to the edge
+	// gateways along with their statuses.
+	//
+	// example:
+	//  ds, _ := sdk.RuleDeployments("ruleID", "token")
+	//  fmt.Println(ds)
+	RuleDeployments(id, token string) ([]RuleDeployment, errors.SDKError)
+
+	// DeployFleet deploys the rule to all the edge gateways having the
+	// labels, or to all the gateways if the labels are empty.
+	//
+	// example:
+	//  r, _ := sdk.DeployFleet("ruleID", map[string]string{"site": "north"}, "token")
+	//  fmt.Println(r.Status)
+	DeployFleet(id string, labels map[string]string, token string) (RuleRollout, errors.SDKError)
+
+	// RetryDeployments deploys the rule again to the edge gateways it
+	// failed on.
+	//
+	// example:
+	//  r, _ := sdk.RetryDeployments("ruleID", "token")
+	//  fmt.Println(r.Status)
+	RetryDeployments(id, token string) (RuleRollout, errors.SDKError)
+
+	// ViewRollout returns the aggregate status of the rule deployed to the
+	// edge gateways.
+	//
+	// example:
+	//  r, _ := sdk.ViewRollout("ruleID", "token")
+	//  fmt.Println(r)
+	ViewRollout(id, token string) (RuleRollout, errors.SDKError)
+
+	// RulesEngineStats returns the counts of the user's streams, tables and
+	// rules by state, along with the records and the exceptions of the
+	// rules, in total and since the user's previous statistics.
+	//
+	// example:
+	//  stats, _ := sdk.RulesEngineStats("token")
+	//  fmt.Println(stats.States)
+	RulesEngineStats(token string) (RulesEngineStats, errors.SDKError)
+
+	// CreateRuleTemplate registers the parameterized rule template. Only the
+	// platform administrator can register templates.
+	//
+	// example:
+	//  tmpl := sdk.RuleTemplate{
+	//    Name:      "threshold",
+	//    Variables: []sdk.TemplateVariable{{Name: "stream", Type: "stream"}, {Name: "threshold", Type: "number"}},
+	//    SQL:       "SELECT * FROM {stream} WHERE v > {threshold}",
+	//    Actions:   []sdk.RuleAction{{Log: &sdk.LogSink{}}},
+	//  }
+	//  tmpl, _ = sdk.CreateRuleTemplate(tmpl, "token")
+	//  fmt.Println(tmpl)
+	CreateRuleTemplate(tmpl RuleTemplate, token string) (RuleTemplate, errors.SDKError)
+
+	// RuleTemplates returns all the rule templates sorted by name.
+	//
+	// example:
+	//  tmpls, _ := sdk.RuleTemplates("token")
+	//  fmt.Println(tmpls)
+	RuleTemplates(token string) ([]RuleTemplate, errors.SDKError)
+
+	// RuleTemplate returns the rule template with the given name.
+	//
+	// example:
+	//  tmpl, _ := sdk.RuleTemplate("threshold", "token")
+	//  fmt.Println(tmpl)
+	RuleTemplate(name, token string) (RuleTemplate, errors.SDKError)
+
+	// DeleteRuleTemplate removes the rule template. Rules created from the
+	// template are kept.
+	//
+	// example:
+	//  err := sdk.DeleteRuleTemplate("threshold", "token")
+	//  fmt.Println(err)
+	DeleteRuleTemplate(name, token string) errors.SDKError
+
+	// InstantiateRuleTemplate creates the rule from the template using the
+	// values of the template variables.
+	//
+	// example:
+	//  inst := sdk.TemplateInstance{ID: "alarm", Values: map[string]string{"stream": "temperature", "threshold": "30"}}
+	//  rule, _ := sdk.InstantiateRuleTemplate("threshold", inst, "token")
+	//  fmt.Println(rule)
+	InstantiateRuleTemplate(name string, inst TemplateInstance, token string) (Rule, errors.SDKError)
+
+	// CreateRulesEnginePlugin installs the Kuiper plugin of the given kind:
+	// sources, sinks or functions. Only the platform administrator can
+	// manage plugins.
+	//
+	// example:
+	//  plugin := sdk.RulesEnginePlugin{Name: "mainflux", File: "https://example.com/plugins/sinks/mainflux.zip"}
+	//  res, _ := sdk.CreateRulesEnginePlugin("sinks", plugin, "token")
+	//  fmt.Println(res)
+	CreateRulesEnginePlugin(kind string, plugin RulesEnginePlugin, token string) (RulesEngineResult, errors.SDKError)
+
+	// RulesEnginePlugins returns the names of the installed Kuiper plugins
+	// of the given kind.
+	//
+	// example:
+	//  plugins, _ := sdk.RulesEnginePlugins("sinks", "token")
+	//  fmt.Println(plugins)
+	RulesEnginePlugins(kind, token string) ([]string, errors.SDKError)
+
+	// DeleteRulesEnginePlugin removes the Kuiper plugin of the given kind
+	// with the given name.
+	//
+	// example:
+	//  res, _ := sdk.DeleteRulesEnginePlugin("sinks", "mainflux", "token")
+	//  fmt.Println(res)
+	DeleteRulesEnginePlugin(kind, name, token string) (RulesEngineResult, errors.SDKError)
+
+	// RegisterExternalService registers the external service the rules
+	// engine calls as SQL functions. Only the platform administrator can
+	// manage external services.
+	//
+	// example:
+	//  es := sdk.ExternalService{Name: "geo", File: "https://example.com/services/geo.zip"}
+	//  res, _ := sdk.RegisterExternalService(es, "token")
+	//  fmt.Println(res)
+	RegisterExternalService(es ExternalService, token string) (RulesEngineResult, errors.SDKError)
+
+	// ExternalServices returns the names of the registered external services.
+	//
+	// example:
+	//  services, _ := sdk.ExternalServices("token")
+	//  fmt.Println(services)
+	ExternalServices(token string) ([]string, errors.SDKError)
+
+	// DeleteExternalService removes the external service with the given name.
+	//
+	// example:
+	//  res, _ := sdk.DeleteExternalService("geo", "token")
+	//  fmt.Println(res)
+	DeleteExternalService(name, token string) (RulesEngineResult, errors.SDKError)
+
+	// ExternalFunctions returns the SQL functions of the registered external
+	// services, which all the users can call in their rules.
+	//
+	// example:
+	//  funcs, _ := sdk.ExternalFunctions("token")
+	//  fmt.Println(funcs)
+	ExternalFunctions(token string) ([]ExternalFunction, errors.SDKError)
+
+	// SaveConfKey creates or replaces the MQTT broker configuration with the
+	// given name, which the user's MQTT streams subscribe with.
+	//
+	// example:
+	//  conf := sdk.MQTTConf{Server: "ssl://localhost:8883", Username: "thingID", Password: "thingKey"}
+	//  res, _ := sdk.SaveConfKey("broker", conf, "token")
+	//  fmt.Println(res)
+	SaveConfKey(name string, conf MQTTConf, token string) (RulesEngineResult, errors.SDKError)
+
+	// ConfKeys returns the names of the user's MQTT broker configurations.
+	//
+	// example:
+	//  keys, _ := sdk.ConfKeys("token")
+	//  fmt.Println(keys)
+	ConfKeys(token string) ([]string, errors.SDKError)
+
+	// DeleteConfKey removes the user's MQTT broker configuration with the
+	// given name.
+	//
+	// example:
+	//  res, _ := sdk.DeleteConfKey("broker", "token")
+	//  fmt.Println(res)
+	DeleteConfKey(name, token string) (RulesEngineResult, errors.SDKError)
 }
 
 type mgSDK struct {
@@ -1185,6 +2161,7 @@ type mgSDK struct {
 	usersURL       string
 	domainsURL     string
 	invitationsURL string
+	reURL          string
 	HostURL        string
 
 	msgContentType ContentType
@@ -1201,6 +2178,7 @@ type Config struct {
 	UsersURL       string
 	DomainsURL     string
 	InvitationsURL string
+	REURL          string
 	HostURL        string
 
 	MsgContentType  ContentType
@@ -1218,6 +2196,7 @@ func NewSDK(conf Config) SDK {
 		usersURL:       conf.UsersURL,
 		domainsURL:     conf.DomainsURL,
 		invitationsURL: conf.InvitationsURL,
+		reURL:          conf.REURL,
 		HostURL:        conf.HostURL,
 
 		msgContentType: conf.MsgContentType,
@@ -1369,6 +2348,19 @@ func (pm PageMetadata) query() (string, error) {
 	if pm.Relation != "" {
 		q.Add("relation", pm.Relation)
 	}
+	if pm.Labels != nil {
+		l, err := json.Marshal(pm.Labels)
+		if err != nil {
+			return "", errors.NewSDKError(err)
+		}
+		q.Add("labels", string(l))
+	}
+	if pm.Deleted {
+		q.Add("deleted", "true")
+	}
+	if pm.Folder != "" {
+		q.Add("folder", pm.Folder)
+	}
 
 	return q.Encode(), nil
 }
@@ -146,6 +146,66 @@ func (_m *SDK) AddUserToGroup(groupID string, req sdk.UsersRelationRequest, toke
 	return r0
 }
 
+// AssignRulesEngineInstance provides a mock function with given fields: userID, instance, token
+func (_m *SDK) AssignRulesEngineInstance(userID string, instance string, token string) (sdk.RulesEngineAssignment, errors.SDKError) {
+	ret := _m.Called(userID, instance, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRulesEngineInstance")
+	}
+
+	var r0 sdk.RulesEngineAssignment
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.RulesEngineAssignment, errors.SDKError)); ok {
+		return rf(userID, instance, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.RulesEngineAssignment); ok {
+		r0 = rf(userID, instance, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineAssignment)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(userID, instance, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// AuditEvents provides a mock function with given fields: q, token
+func (_m *SDK) AuditEvents(q sdk.AuditQuery, token string) (sdk.AuditPage, errors.SDKError) {
+	ret := _m.Called(q, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditEvents")
+	}
+
+	var r0 sdk.AuditPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.AuditQuery, string) (sdk.AuditPage, errors.SDKError)); ok {
+		return rf(q, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.AuditQuery, string) sdk.AuditPage); ok {
+		r0 = rf(q, token)
+	} else {
+		r0 = ret.Get(0).(sdk.AuditPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.AuditQuery, string) errors.SDKError); ok {
+		r1 = rf(q, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Bootstrap provides a mock function with given fields: externalID, externalKey
 func (_m *SDK) Bootstrap(externalID string, externalKey string) (sdk.BootstrapConfig, errors.SDKError) {
 	ret := _m.Called(externalID, externalKey)
@@ -236,6 +296,66 @@ func (_m *SDK) Bootstraps(pm sdk.PageMetadata, token string) (sdk.BootstrapPage,
 	return r0, r1
 }
 
+// BulkCreate provides a mock function with given fields: rs, token
+func (_m *SDK) BulkCreate(rs sdk.Ruleset, token string) (sdk.BulkReport, errors.SDKError) {
+	ret := _m.Called(rs, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreate")
+	}
+
+	var r0 sdk.BulkReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Ruleset, string) (sdk.BulkReport, errors.SDKError)); ok {
+		return rf(rs, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Ruleset, string) sdk.BulkReport); ok {
+		r0 = rf(rs, token)
+	} else {
+		r0 = ret.Get(0).(sdk.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Ruleset, string) errors.SDKError); ok {
+		r1 = rf(rs, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// BulkDelete provides a mock function with given fields: bd, token
+func (_m *SDK) BulkDelete(bd sdk.BulkDeletion, token string) (sdk.BulkReport, errors.SDKError) {
+	ret := _m.Called(bd, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkDelete")
+	}
+
+	var r0 sdk.BulkReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.BulkDeletion, string) (sdk.BulkReport, errors.SDKError)); ok {
+		return rf(bd, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.BulkDeletion, string) sdk.BulkReport); ok {
+		r0 = rf(bd, token)
+	} else {
+		r0 = ret.Get(0).(sdk.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.BulkDeletion, string) errors.SDKError); ok {
+		r1 = rf(bd, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Channel provides a mock function with given fields: id, token
 func (_m *SDK) Channel(id string, token string) (sdk.Channel, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -386,6 +506,68 @@ func (_m *SDK) Children(id string, pm sdk.PageMetadata, token string) (sdk.Group
 	return r0, r1
 }
 
+// CloneRule provides a mock function with given fields: id, newID, channel, token
+func (_m *SDK) CloneRule(id string, newID string, channel string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(id, newID, channel, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(id, newID, channel, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(id, newID, channel, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string, string) errors.SDKError); ok {
+		r1 = rf(id, newID, channel, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ConfKeys provides a mock function with given fields: token
+func (_m *SDK) ConfKeys(token string) ([]string, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfKeys")
+	}
+
+	var r0 []string
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]string, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Connect provides a mock function with given fields: conns, token
 func (_m *SDK) Connect(conns sdk.Connection, token string) errors.SDKError {
 	ret := _m.Called(conns, token)
@@ -548,27 +730,27 @@ func (_m *SDK) CreateGroup(group sdk.Group, token string) (sdk.Group, errors.SDK
 	return r0, r1
 }
 
-// CreateSubscription provides a mock function with given fields: topic, contact, token
-func (_m *SDK) CreateSubscription(topic string, contact string, token string) (string, errors.SDKError) {
-	ret := _m.Called(topic, contact, token)
+// CreateRule provides a mock function with given fields: rule, token
+func (_m *SDK) CreateRule(rule sdk.Rule, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(rule, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateSubscription")
+		panic("no return value specified for CreateRule")
 	}
 
-	var r0 string
+	var r0 sdk.RulesEngineResult
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string, string) (string, errors.SDKError)); ok {
-		return rf(topic, contact, token)
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(rule, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
-		r0 = rf(topic, contact, token)
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string) sdk.RulesEngineResult); ok {
+		r0 = rf(rule, token)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
-		r1 = rf(topic, contact, token)
+	if rf, ok := ret.Get(1).(func(sdk.Rule, string) errors.SDKError); ok {
+		r1 = rf(rule, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -578,27 +760,27 @@ func (_m *SDK) CreateSubscription(topic string, contact string, token string) (s
 	return r0, r1
 }
 
-// CreateThing provides a mock function with given fields: thing, token
-func (_m *SDK) CreateThing(thing sdk.Thing, token string) (sdk.Thing, errors.SDKError) {
-	ret := _m.Called(thing, token)
+// CreateRuleTemplate provides a mock function with given fields: tmpl, token
+func (_m *SDK) CreateRuleTemplate(tmpl sdk.RuleTemplate, token string) (sdk.RuleTemplate, errors.SDKError) {
+	ret := _m.Called(tmpl, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateThing")
+		panic("no return value specified for CreateRuleTemplate")
 	}
 
-	var r0 sdk.Thing
+	var r0 sdk.RuleTemplate
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.Thing, string) (sdk.Thing, errors.SDKError)); ok {
-		return rf(thing, token)
+	if rf, ok := ret.Get(0).(func(sdk.RuleTemplate, string) (sdk.RuleTemplate, errors.SDKError)); ok {
+		return rf(tmpl, token)
 	}
-	if rf, ok := ret.Get(0).(func(sdk.Thing, string) sdk.Thing); ok {
-		r0 = rf(thing, token)
+	if rf, ok := ret.Get(0).(func(sdk.RuleTemplate, string) sdk.RuleTemplate); ok {
+		r0 = rf(tmpl, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Thing)
+		r0 = ret.Get(0).(sdk.RuleTemplate)
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.Thing, string) errors.SDKError); ok {
-		r1 = rf(thing, token)
+	if rf, ok := ret.Get(1).(func(sdk.RuleTemplate, string) errors.SDKError); ok {
+		r1 = rf(tmpl, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -608,29 +790,27 @@ func (_m *SDK) CreateThing(thing sdk.Thing, token string) (sdk.Thing, errors.SDK
 	return r0, r1
 }
 
-// CreateThings provides a mock function with given fields: things, token
-func (_m *SDK) CreateThings(things []sdk.Thing, token string) ([]sdk.Thing, errors.SDKError) {
-	ret := _m.Called(things, token)
+// CreateRulesChain provides a mock function with given fields: c, token
+func (_m *SDK) CreateRulesChain(c sdk.RulesChain, token string) (sdk.RulesChain, errors.SDKError) {
+	ret := _m.Called(c, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateThings")
+		panic("no return value specified for CreateRulesChain")
 	}
 
-	var r0 []sdk.Thing
+	var r0 sdk.RulesChain
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func([]sdk.Thing, string) ([]sdk.Thing, errors.SDKError)); ok {
-		return rf(things, token)
+	if rf, ok := ret.Get(0).(func(sdk.RulesChain, string) (sdk.RulesChain, errors.SDKError)); ok {
+		return rf(c, token)
 	}
-	if rf, ok := ret.Get(0).(func([]sdk.Thing, string) []sdk.Thing); ok {
-		r0 = rf(things, token)
+	if rf, ok := ret.Get(0).(func(sdk.RulesChain, string) sdk.RulesChain); ok {
+		r0 = rf(c, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]sdk.Thing)
-		}
+		r0 = ret.Get(0).(sdk.RulesChain)
 	}
 
-	if rf, ok := ret.Get(1).(func([]sdk.Thing, string) errors.SDKError); ok {
-		r1 = rf(things, token)
+	if rf, ok := ret.Get(1).(func(sdk.RulesChain, string) errors.SDKError); ok {
+		r1 = rf(c, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -640,27 +820,27 @@ func (_m *SDK) CreateThings(things []sdk.Thing, token string) ([]sdk.Thing, erro
 	return r0, r1
 }
 
-// CreateToken provides a mock function with given fields: lt
-func (_m *SDK) CreateToken(lt sdk.Login) (sdk.Token, errors.SDKError) {
-	ret := _m.Called(lt)
+// CreateRulesEnginePlugin provides a mock function with given fields: kind, plugin, token
+func (_m *SDK) CreateRulesEnginePlugin(kind string, plugin sdk.RulesEnginePlugin, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(kind, plugin, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateToken")
+		panic("no return value specified for CreateRulesEnginePlugin")
 	}
 
-	var r0 sdk.Token
+	var r0 sdk.RulesEngineResult
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.Login) (sdk.Token, errors.SDKError)); ok {
-		return rf(lt)
+	if rf, ok := ret.Get(0).(func(string, sdk.RulesEnginePlugin, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(kind, plugin, token)
 	}
-	if rf, ok := ret.Get(0).(func(sdk.Login) sdk.Token); ok {
-		r0 = rf(lt)
+	if rf, ok := ret.Get(0).(func(string, sdk.RulesEnginePlugin, string) sdk.RulesEngineResult); ok {
+		r0 = rf(kind, plugin, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Token)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.Login) errors.SDKError); ok {
-		r1 = rf(lt)
+	if rf, ok := ret.Get(1).(func(string, sdk.RulesEnginePlugin, string) errors.SDKError); ok {
+		r1 = rf(kind, plugin, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -670,27 +850,27 @@ func (_m *SDK) CreateToken(lt sdk.Login) (sdk.Token, errors.SDKError) {
 	return r0, r1
 }
 
-// CreateUser provides a mock function with given fields: user, token
-func (_m *SDK) CreateUser(user sdk.User, token string) (sdk.User, errors.SDKError) {
-	ret := _m.Called(user, token)
+// CreateRulesFolder provides a mock function with given fields: f, token
+func (_m *SDK) CreateRulesFolder(f sdk.RulesFolder, token string) (sdk.RulesFolder, errors.SDKError) {
+	ret := _m.Called(f, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for CreateRulesFolder")
 	}
 
-	var r0 sdk.User
+	var r0 sdk.RulesFolder
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.User, string) (sdk.User, errors.SDKError)); ok {
-		return rf(user, token)
+	if rf, ok := ret.Get(0).(func(sdk.RulesFolder, string) (sdk.RulesFolder, errors.SDKError)); ok {
+		return rf(f, token)
 	}
-	if rf, ok := ret.Get(0).(func(sdk.User, string) sdk.User); ok {
-		r0 = rf(user, token)
+	if rf, ok := ret.Get(0).(func(sdk.RulesFolder, string) sdk.RulesFolder); ok {
+		r0 = rf(f, token)
 	} else {
-		r0 = ret.Get(0).(sdk.User)
+		r0 = ret.Get(0).(sdk.RulesFolder)
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.User, string) errors.SDKError); ok {
-		r1 = rf(user, token)
+	if rf, ok := ret.Get(1).(func(sdk.RulesFolder, string) errors.SDKError); ok {
+		r1 = rf(f, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -700,125 +880,147 @@ func (_m *SDK) CreateUser(user sdk.User, token string) (sdk.User, errors.SDKErro
 	return r0, r1
 }
 
-// DeleteChannel provides a mock function with given fields: id, token
-func (_m *SDK) DeleteChannel(id string, token string) errors.SDKError {
-	ret := _m.Called(id, token)
+// CreateRulesWebhook provides a mock function with given fields: wh, token
+func (_m *SDK) CreateRulesWebhook(wh sdk.RulesWebhook, token string) (sdk.RulesWebhook, errors.SDKError) {
+	ret := _m.Called(wh, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteChannel")
+		panic("no return value specified for CreateRulesWebhook")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
-		r0 = rf(id, token)
+	var r0 sdk.RulesWebhook
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.RulesWebhook, string) (sdk.RulesWebhook, errors.SDKError)); ok {
+		return rf(wh, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.RulesWebhook, string) sdk.RulesWebhook); ok {
+		r0 = rf(wh, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		r0 = ret.Get(0).(sdk.RulesWebhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.RulesWebhook, string) errors.SDKError); ok {
+		r1 = rf(wh, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// DeleteGroup provides a mock function with given fields: id, token
-func (_m *SDK) DeleteGroup(id string, token string) errors.SDKError {
-	ret := _m.Called(id, token)
+// CreateStream provides a mock function with given fields: stream, token
+func (_m *SDK) CreateStream(stream sdk.Stream, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(stream, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteGroup")
+		panic("no return value specified for CreateStream")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
-		r0 = rf(id, token)
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Stream, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(stream, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Stream, string) sdk.RulesEngineResult); ok {
+		r0 = rf(stream, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Stream, string) errors.SDKError); ok {
+		r1 = rf(stream, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// DeleteInvitation provides a mock function with given fields: userID, domainID, token
-func (_m *SDK) DeleteInvitation(userID string, domainID string, token string) error {
-	ret := _m.Called(userID, domainID, token)
+// CreateSubscription provides a mock function with given fields: topic, contact, token
+func (_m *SDK) CreateSubscription(topic string, contact string, token string) (string, errors.SDKError) {
+	ret := _m.Called(topic, contact, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteInvitation")
+		panic("no return value specified for CreateSubscription")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(userID, domainID, token)
+	var r0 string
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (string, errors.SDKError)); ok {
+		return rf(topic, contact, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(topic, contact, token)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(topic, contact, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
 }
 
-// DeleteSubscription provides a mock function with given fields: id, token
-func (_m *SDK) DeleteSubscription(id string, token string) errors.SDKError {
-	ret := _m.Called(id, token)
+// CreateTable provides a mock function with given fields: table, token
+func (_m *SDK) CreateTable(table sdk.Table, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(table, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteSubscription")
+		panic("no return value specified for CreateTable")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
-		r0 = rf(id, token)
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Table, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(table, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Table, string) sdk.RulesEngineResult); ok {
+		r0 = rf(table, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
-		}
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
-	return r0
-}
-
-// DeleteThing provides a mock function with given fields: id, token
-func (_m *SDK) DeleteThing(id string, token string) errors.SDKError {
-	ret := _m.Called(id, token)
-
-	if len(ret) == 0 {
-		panic("no return value specified for DeleteThing")
-	}
-
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
-		r0 = rf(id, token)
+	if rf, ok := ret.Get(1).(func(sdk.Table, string) errors.SDKError); ok {
+		r1 = rf(table, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// DisableChannel provides a mock function with given fields: id, token
-func (_m *SDK) DisableChannel(id string, token string) (sdk.Channel, errors.SDKError) {
-	ret := _m.Called(id, token)
+// CreateThing provides a mock function with given fields: thing, token
+func (_m *SDK) CreateThing(thing sdk.Thing, token string) (sdk.Thing, errors.SDKError) {
+	ret := _m.Called(thing, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DisableChannel")
+		panic("no return value specified for CreateThing")
 	}
 
-	var r0 sdk.Channel
+	var r0 sdk.Thing
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Channel, errors.SDKError)); ok {
-		return rf(id, token)
+	if rf, ok := ret.Get(0).(func(sdk.Thing, string) (sdk.Thing, errors.SDKError)); ok {
+		return rf(thing, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Channel); ok {
-		r0 = rf(id, token)
+	if rf, ok := ret.Get(0).(func(sdk.Thing, string) sdk.Thing); ok {
+		r0 = rf(thing, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Channel)
+		r0 = ret.Get(0).(sdk.Thing)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+	if rf, ok := ret.Get(1).(func(sdk.Thing, string) errors.SDKError); ok {
+		r1 = rf(thing, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -828,47 +1030,29 @@ func (_m *SDK) DisableChannel(id string, token string) (sdk.Channel, errors.SDKE
 	return r0, r1
 }
 
-// DisableDomain provides a mock function with given fields: domainID, token
-func (_m *SDK) DisableDomain(domainID string, token string) errors.SDKError {
-	ret := _m.Called(domainID, token)
-
-	if len(ret) == 0 {
-		panic("no return value specified for DisableDomain")
-	}
-
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
-		r0 = rf(domainID, token)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
-		}
-	}
-
-	return r0
-}
-
-// DisableGroup provides a mock function with given fields: id, token
-func (_m *SDK) DisableGroup(id string, token string) (sdk.Group, errors.SDKError) {
-	ret := _m.Called(id, token)
+// CreateThings provides a mock function with given fields: things, token
+func (_m *SDK) CreateThings(things []sdk.Thing, token string) ([]sdk.Thing, errors.SDKError) {
+	ret := _m.Called(things, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DisableGroup")
+		panic("no return value specified for CreateThings")
 	}
 
-	var r0 sdk.Group
+	var r0 []sdk.Thing
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Group, errors.SDKError)); ok {
-		return rf(id, token)
+	if rf, ok := ret.Get(0).(func([]sdk.Thing, string) ([]sdk.Thing, errors.SDKError)); ok {
+		return rf(things, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Group); ok {
-		r0 = rf(id, token)
+	if rf, ok := ret.Get(0).(func([]sdk.Thing, string) []sdk.Thing); ok {
+		r0 = rf(things, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Group)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.Thing)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+	if rf, ok := ret.Get(1).(func([]sdk.Thing, string) errors.SDKError); ok {
+		r1 = rf(things, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -878,27 +1062,27 @@ func (_m *SDK) DisableGroup(id string, token string) (sdk.Group, errors.SDKError
 	return r0, r1
 }
 
-// DisableThing provides a mock function with given fields: id, token
-func (_m *SDK) DisableThing(id string, token string) (sdk.Thing, errors.SDKError) {
-	ret := _m.Called(id, token)
+// CreateToken provides a mock function with given fields: lt
+func (_m *SDK) CreateToken(lt sdk.Login) (sdk.Token, errors.SDKError) {
+	ret := _m.Called(lt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DisableThing")
+		panic("no return value specified for CreateToken")
 	}
 
-	var r0 sdk.Thing
+	var r0 sdk.Token
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Thing, errors.SDKError)); ok {
-		return rf(id, token)
+	if rf, ok := ret.Get(0).(func(sdk.Login) (sdk.Token, errors.SDKError)); ok {
+		return rf(lt)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Thing); ok {
-		r0 = rf(id, token)
+	if rf, ok := ret.Get(0).(func(sdk.Login) sdk.Token); ok {
+		r0 = rf(lt)
 	} else {
-		r0 = ret.Get(0).(sdk.Thing)
+		r0 = ret.Get(0).(sdk.Token)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+	if rf, ok := ret.Get(1).(func(sdk.Login) errors.SDKError); ok {
+		r1 = rf(lt)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -908,27 +1092,27 @@ func (_m *SDK) DisableThing(id string, token string) (sdk.Thing, errors.SDKError
 	return r0, r1
 }
 
-// DisableUser provides a mock function with given fields: id, token
-func (_m *SDK) DisableUser(id string, token string) (sdk.User, errors.SDKError) {
-	ret := _m.Called(id, token)
+// CreateUser provides a mock function with given fields: user, token
+func (_m *SDK) CreateUser(user sdk.User, token string) (sdk.User, errors.SDKError) {
+	ret := _m.Called(user, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DisableUser")
+		panic("no return value specified for CreateUser")
 	}
 
 	var r0 sdk.User
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.User, errors.SDKError)); ok {
-		return rf(id, token)
+	if rf, ok := ret.Get(0).(func(sdk.User, string) (sdk.User, errors.SDKError)); ok {
+		return rf(user, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.User); ok {
-		r0 = rf(id, token)
+	if rf, ok := ret.Get(0).(func(sdk.User, string) sdk.User); ok {
+		r0 = rf(user, token)
 	} else {
 		r0 = ret.Get(0).(sdk.User)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+	if rf, ok := ret.Get(1).(func(sdk.User, string) errors.SDKError); ok {
+		r1 = rf(user, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -938,37 +1122,17 @@ func (_m *SDK) DisableUser(id string, token string) (sdk.User, errors.SDKError)
 	return r0, r1
 }
 
-// Disconnect provides a mock function with given fields: connIDs, token
-func (_m *SDK) Disconnect(connIDs sdk.Connection, token string) errors.SDKError {
-	ret := _m.Called(connIDs, token)
-
-	if len(ret) == 0 {
-		panic("no return value specified for Disconnect")
-	}
-
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.Connection, string) errors.SDKError); ok {
-		r0 = rf(connIDs, token)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
-		}
-	}
-
-	return r0
-}
-
-// DisconnectThing provides a mock function with given fields: thingID, chanID, token
-func (_m *SDK) DisconnectThing(thingID string, chanID string, token string) errors.SDKError {
-	ret := _m.Called(thingID, chanID, token)
+// DeleteChannel provides a mock function with given fields: id, token
+func (_m *SDK) DeleteChannel(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DisconnectThing")
+		panic("no return value specified for DeleteChannel")
 	}
 
 	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
-		r0 = rf(thingID, chanID, token)
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.SDKError)
@@ -978,27 +1142,27 @@ func (_m *SDK) DisconnectThing(thingID string, chanID string, token string) erro
 	return r0
 }
 
-// Domain provides a mock function with given fields: domainID, token
-func (_m *SDK) Domain(domainID string, token string) (sdk.Domain, errors.SDKError) {
-	ret := _m.Called(domainID, token)
+// DeleteConfKey provides a mock function with given fields: name, token
+func (_m *SDK) DeleteConfKey(name string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(name, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Domain")
+		panic("no return value specified for DeleteConfKey")
 	}
 
-	var r0 sdk.Domain
+	var r0 sdk.RulesEngineResult
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Domain, errors.SDKError)); ok {
-		return rf(domainID, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(name, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Domain); ok {
-		r0 = rf(domainID, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(name, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Domain)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(domainID, token)
+		r1 = rf(name, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1008,27 +1172,27 @@ func (_m *SDK) Domain(domainID string, token string) (sdk.Domain, errors.SDKErro
 	return r0, r1
 }
 
-// DomainPermissions provides a mock function with given fields: domainID, token
-func (_m *SDK) DomainPermissions(domainID string, token string) (sdk.Domain, errors.SDKError) {
-	ret := _m.Called(domainID, token)
+// DeleteExternalService provides a mock function with given fields: name, token
+func (_m *SDK) DeleteExternalService(name string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(name, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DomainPermissions")
+		panic("no return value specified for DeleteExternalService")
 	}
 
-	var r0 sdk.Domain
+	var r0 sdk.RulesEngineResult
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Domain, errors.SDKError)); ok {
-		return rf(domainID, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(name, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Domain); ok {
-		r0 = rf(domainID, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(name, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Domain)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(domainID, token)
+		r1 = rf(name, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1038,53 +1202,61 @@ func (_m *SDK) DomainPermissions(domainID string, token string) (sdk.Domain, err
 	return r0, r1
 }
 
-// Domains provides a mock function with given fields: pm, token
-func (_m *SDK) Domains(pm sdk.PageMetadata, token string) (sdk.DomainsPage, errors.SDKError) {
-	ret := _m.Called(pm, token)
+// DeleteGroup provides a mock function with given fields: id, token
+func (_m *SDK) DeleteGroup(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Domains")
+		panic("no return value specified for DeleteGroup")
 	}
 
-	var r0 sdk.DomainsPage
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.DomainsPage, errors.SDKError)); ok {
-		return rf(pm, token)
-	}
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.DomainsPage); ok {
-		r0 = rf(pm, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.DomainsPage)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(pm, token)
+	return r0
+}
+
+// DeleteInvitation provides a mock function with given fields: userID, domainID, token
+func (_m *SDK) DeleteInvitation(userID string, domainID string, token string) error {
+	ret := _m.Called(userID, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(userID, domainID, token)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
-		}
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// EnableChannel provides a mock function with given fields: id, token
-func (_m *SDK) EnableChannel(id string, token string) (sdk.Channel, errors.SDKError) {
+// DeleteRule provides a mock function with given fields: id, token
+func (_m *SDK) DeleteRule(id string, token string) (sdk.RulesEngineResult, errors.SDKError) {
 	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnableChannel")
+		panic("no return value specified for DeleteRule")
 	}
 
-	var r0 sdk.Channel
+	var r0 sdk.RulesEngineResult
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Channel, errors.SDKError)); ok {
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
 		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Channel); ok {
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
 		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Channel)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
@@ -1098,17 +1270,17 @@ func (_m *SDK) EnableChannel(id string, token string) (sdk.Channel, errors.SDKEr
 	return r0, r1
 }
 
-// EnableDomain provides a mock function with given fields: domainID, token
-func (_m *SDK) EnableDomain(domainID string, token string) errors.SDKError {
-	ret := _m.Called(domainID, token)
+// DeleteRuleTemplate provides a mock function with given fields: name, token
+func (_m *SDK) DeleteRuleTemplate(name string, token string) errors.SDKError {
+	ret := _m.Called(name, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnableDomain")
+		panic("no return value specified for DeleteRuleTemplate")
 	}
 
 	var r0 errors.SDKError
 	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
-		r0 = rf(domainID, token)
+		r0 = rf(name, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.SDKError)
@@ -1118,57 +1290,67 @@ func (_m *SDK) EnableDomain(domainID string, token string) errors.SDKError {
 	return r0
 }
 
-// EnableGroup provides a mock function with given fields: id, token
-func (_m *SDK) EnableGroup(id string, token string) (sdk.Group, errors.SDKError) {
-	ret := _m.Called(id, token)
+// DeleteRulesAlertPolicy provides a mock function with given fields: token
+func (_m *SDK) DeleteRulesAlertPolicy(token string) errors.SDKError {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnableGroup")
+		panic("no return value specified for DeleteRulesAlertPolicy")
 	}
 
-	var r0 sdk.Group
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Group, errors.SDKError)); ok {
-		return rf(id, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Group); ok {
-		r0 = rf(id, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) errors.SDKError); ok {
+		r0 = rf(token)
 	} else {
-		r0 = ret.Get(0).(sdk.Group)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+	return r0
+}
+
+// DeleteRulesChain provides a mock function with given fields: name, token
+func (_m *SDK) DeleteRulesChain(name string, token string) errors.SDKError {
+	ret := _m.Called(name, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRulesChain")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(name, token)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
 		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// EnableThing provides a mock function with given fields: id, token
-func (_m *SDK) EnableThing(id string, token string) (sdk.Thing, errors.SDKError) {
-	ret := _m.Called(id, token)
+// DeleteRulesEnginePlugin provides a mock function with given fields: kind, name, token
+func (_m *SDK) DeleteRulesEnginePlugin(kind string, name string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(kind, name, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnableThing")
+		panic("no return value specified for DeleteRulesEnginePlugin")
 	}
 
-	var r0 sdk.Thing
+	var r0 sdk.RulesEngineResult
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Thing, errors.SDKError)); ok {
-		return rf(id, token)
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(kind, name, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Thing); ok {
-		r0 = rf(id, token)
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(kind, name, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Thing)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(kind, name, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1178,117 +1360,107 @@ func (_m *SDK) EnableThing(id string, token string) (sdk.Thing, errors.SDKError)
 	return r0, r1
 }
 
-// EnableUser provides a mock function with given fields: id, token
-func (_m *SDK) EnableUser(id string, token string) (sdk.User, errors.SDKError) {
-	ret := _m.Called(id, token)
+// DeleteRulesFolder provides a mock function with given fields: name, token
+func (_m *SDK) DeleteRulesFolder(name string, token string) errors.SDKError {
+	ret := _m.Called(name, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnableUser")
-	}
-
-	var r0 sdk.User
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.User, errors.SDKError)); ok {
-		return rf(id, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.User); ok {
-		r0 = rf(id, token)
-	} else {
-		r0 = ret.Get(0).(sdk.User)
+		panic("no return value specified for DeleteRulesFolder")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(name, token)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
 		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Group provides a mock function with given fields: id, token
-func (_m *SDK) Group(id string, token string) (sdk.Group, errors.SDKError) {
-	ret := _m.Called(id, token)
+// DeleteRulesQuota provides a mock function with given fields: userID, token
+func (_m *SDK) DeleteRulesQuota(userID string, token string) errors.SDKError {
+	ret := _m.Called(userID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Group")
+		panic("no return value specified for DeleteRulesQuota")
 	}
 
-	var r0 sdk.Group
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Group, errors.SDKError)); ok {
-		return rf(id, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Group); ok {
-		r0 = rf(id, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(userID, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Group)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+	return r0
+}
+
+// DeleteRulesSecret provides a mock function with given fields: name, token
+func (_m *SDK) DeleteRulesSecret(name string, token string) errors.SDKError {
+	ret := _m.Called(name, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRulesSecret")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(name, token)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
 		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GroupPermissions provides a mock function with given fields: id, token
-func (_m *SDK) GroupPermissions(id string, token string) (sdk.Group, errors.SDKError) {
+// DeleteRulesWebhook provides a mock function with given fields: id, token
+func (_m *SDK) DeleteRulesWebhook(id string, token string) errors.SDKError {
 	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GroupPermissions")
+		panic("no return value specified for DeleteRulesWebhook")
 	}
 
-	var r0 sdk.Group
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Group, errors.SDKError)); ok {
-		return rf(id, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Group); ok {
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
 		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Group)
-	}
-
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
 		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Groups provides a mock function with given fields: pm, token
-func (_m *SDK) Groups(pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
-	ret := _m.Called(pm, token)
+// DeleteStream provides a mock function with given fields: name, cascade, token
+func (_m *SDK) DeleteStream(name string, cascade bool, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(name, cascade, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Groups")
+		panic("no return value specified for DeleteStream")
 	}
 
-	var r0 sdk.GroupsPage
+	var r0 sdk.RulesEngineResult
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
-		return rf(pm, token)
+	if rf, ok := ret.Get(0).(func(string, bool, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(name, cascade, token)
 	}
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.GroupsPage); ok {
-		r0 = rf(pm, token)
+	if rf, ok := ret.Get(0).(func(string, bool, string) sdk.RulesEngineResult); ok {
+		r0 = rf(name, cascade, token)
 	} else {
-		r0 = ret.Get(0).(sdk.GroupsPage)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(pm, token)
+	if rf, ok := ret.Get(1).(func(string, bool, string) errors.SDKError); ok {
+		r1 = rf(name, cascade, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1298,57 +1470,47 @@ func (_m *SDK) Groups(pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors
 	return r0, r1
 }
 
-// Health provides a mock function with given fields: service
-func (_m *SDK) Health(service string) (sdk.HealthInfo, errors.SDKError) {
-	ret := _m.Called(service)
+// DeleteSubscription provides a mock function with given fields: id, token
+func (_m *SDK) DeleteSubscription(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Health")
-	}
-
-	var r0 sdk.HealthInfo
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string) (sdk.HealthInfo, errors.SDKError)); ok {
-		return rf(service)
-	}
-	if rf, ok := ret.Get(0).(func(string) sdk.HealthInfo); ok {
-		r0 = rf(service)
-	} else {
-		r0 = ret.Get(0).(sdk.HealthInfo)
+		panic("no return value specified for DeleteSubscription")
 	}
 
-	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
-		r1 = rf(service)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
 		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// IdentifyThing provides a mock function with given fields: key
-func (_m *SDK) IdentifyThing(key string) (string, errors.SDKError) {
-	ret := _m.Called(key)
+// DeleteTable provides a mock function with given fields: name, token
+func (_m *SDK) DeleteTable(name string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(name, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IdentifyThing")
+		panic("no return value specified for DeleteTable")
 	}
 
-	var r0 string
+	var r0 sdk.RulesEngineResult
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string) (string, errors.SDKError)); ok {
-		return rf(key)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(name, token)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(key)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(name, token)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
-		r1 = rf(key)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(name, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1358,83 +1520,77 @@ func (_m *SDK) IdentifyThing(key string) (string, errors.SDKError) {
 	return r0, r1
 }
 
-// Invitation provides a mock function with given fields: userID, domainID, token
-func (_m *SDK) Invitation(userID string, domainID string, token string) (sdk.Invitation, error) {
-	ret := _m.Called(userID, domainID, token)
+// DeleteThing provides a mock function with given fields: id, token
+func (_m *SDK) DeleteThing(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Invitation")
-	}
-
-	var r0 sdk.Invitation
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Invitation, error)); ok {
-		return rf(userID, domainID, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Invitation); ok {
-		r0 = rf(userID, domainID, token)
-	} else {
-		r0 = ret.Get(0).(sdk.Invitation)
+		panic("no return value specified for DeleteThing")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(userID, domainID, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Invitations provides a mock function with given fields: pm, token
-func (_m *SDK) Invitations(pm sdk.PageMetadata, token string) (sdk.InvitationPage, error) {
-	ret := _m.Called(pm, token)
+// DeployFleet provides a mock function with given fields: id, labels, token
+func (_m *SDK) DeployFleet(id string, labels map[string]string, token string) (sdk.RuleRollout, errors.SDKError) {
+	ret := _m.Called(id, labels, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Invitations")
+		panic("no return value specified for DeployFleet")
 	}
 
-	var r0 sdk.InvitationPage
-	var r1 error
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.InvitationPage, error)); ok {
-		return rf(pm, token)
+	var r0 sdk.RuleRollout
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, map[string]string, string) (sdk.RuleRollout, errors.SDKError)); ok {
+		return rf(id, labels, token)
 	}
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.InvitationPage); ok {
-		r0 = rf(pm, token)
+	if rf, ok := ret.Get(0).(func(string, map[string]string, string) sdk.RuleRollout); ok {
+		r0 = rf(id, labels, token)
 	} else {
-		r0 = ret.Get(0).(sdk.InvitationPage)
+		r0 = ret.Get(0).(sdk.RuleRollout)
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) error); ok {
-		r1 = rf(pm, token)
+	if rf, ok := ret.Get(1).(func(string, map[string]string, string) errors.SDKError); ok {
+		r1 = rf(id, labels, token)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
 	}
 
 	return r0, r1
 }
 
-// IssueCert provides a mock function with given fields: thingID, valid, token
-func (_m *SDK) IssueCert(thingID string, valid string, token string) (sdk.Cert, errors.SDKError) {
-	ret := _m.Called(thingID, valid, token)
+// DeployRule provides a mock function with given fields: id, gateway, token
+func (_m *SDK) DeployRule(id string, gateway string, token string) (sdk.RuleDeployment, errors.SDKError) {
+	ret := _m.Called(id, gateway, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IssueCert")
+		panic("no return value specified for DeployRule")
 	}
 
-	var r0 sdk.Cert
+	var r0 sdk.RuleDeployment
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Cert, errors.SDKError)); ok {
-		return rf(thingID, valid, token)
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.RuleDeployment, errors.SDKError)); ok {
+		return rf(id, gateway, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Cert); ok {
-		r0 = rf(thingID, valid, token)
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.RuleDeployment); ok {
+		r0 = rf(id, gateway, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Cert)
+		r0 = ret.Get(0).(sdk.RuleDeployment)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
-		r1 = rf(thingID, valid, token)
+		r1 = rf(id, gateway, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1444,28 +1600,28 @@ func (_m *SDK) IssueCert(thingID string, valid string, token string) (sdk.Cert,
 	return r0, r1
 }
 
-// ListChannelUserGroups provides a mock function with given fields: channelID, pm, token
-func (_m *SDK) ListChannelUserGroups(channelID string, pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
-	ret := _m.Called(channelID, pm, token)
+// DisableChannel provides a mock function with given fields: id, token
+func (_m *SDK) DisableChannel(id string, token string) (sdk.Channel, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListChannelUserGroups")
+		panic("no return value specified for DisableChannel")
 	}
 
-	var r0 sdk.GroupsPage
+	var r0 sdk.Channel
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
-		return rf(channelID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Channel, errors.SDKError)); ok {
+		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.GroupsPage); ok {
-		r0 = rf(channelID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Channel); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.GroupsPage)
+		r0 = ret.Get(0).(sdk.Channel)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(channelID, pm, token)
-	} else {
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
 		}
@@ -1474,57 +1630,47 @@ func (_m *SDK) ListChannelUserGroups(channelID string, pm sdk.PageMetadata, toke
 	return r0, r1
 }
 
-// ListChannelUsers provides a mock function with given fields: channelID, pm, token
-func (_m *SDK) ListChannelUsers(channelID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
-	ret := _m.Called(channelID, pm, token)
+// DisableDomain provides a mock function with given fields: domainID, token
+func (_m *SDK) DisableDomain(domainID string, token string) errors.SDKError {
+	ret := _m.Called(domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListChannelUsers")
-	}
-
-	var r0 sdk.UsersPage
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
-		return rf(channelID, pm, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
-		r0 = rf(channelID, pm, token)
-	} else {
-		r0 = ret.Get(0).(sdk.UsersPage)
+		panic("no return value specified for DisableDomain")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(channelID, pm, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(domainID, token)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
 		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListDomainUsers provides a mock function with given fields: domainID, pm, token
-func (_m *SDK) ListDomainUsers(domainID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
-	ret := _m.Called(domainID, pm, token)
+// DisableGroup provides a mock function with given fields: id, token
+func (_m *SDK) DisableGroup(id string, token string) (sdk.Group, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListDomainUsers")
+		panic("no return value specified for DisableGroup")
 	}
 
-	var r0 sdk.UsersPage
+	var r0 sdk.Group
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
-		return rf(domainID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Group, errors.SDKError)); ok {
+		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
-		r0 = rf(domainID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Group); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.UsersPage)
+		r0 = ret.Get(0).(sdk.Group)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(domainID, pm, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1534,27 +1680,27 @@ func (_m *SDK) ListDomainUsers(domainID string, pm sdk.PageMetadata, token strin
 	return r0, r1
 }
 
-// ListGroupChannels provides a mock function with given fields: groupID, pm, token
-func (_m *SDK) ListGroupChannels(groupID string, pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
-	ret := _m.Called(groupID, pm, token)
+// DisableThing provides a mock function with given fields: id, token
+func (_m *SDK) DisableThing(id string, token string) (sdk.Thing, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListGroupChannels")
+		panic("no return value specified for DisableThing")
 	}
 
-	var r0 sdk.GroupsPage
+	var r0 sdk.Thing
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
-		return rf(groupID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Thing, errors.SDKError)); ok {
+		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.GroupsPage); ok {
-		r0 = rf(groupID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Thing); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.GroupsPage)
+		r0 = ret.Get(0).(sdk.Thing)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(groupID, pm, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1564,27 +1710,27 @@ func (_m *SDK) ListGroupChannels(groupID string, pm sdk.PageMetadata, token stri
 	return r0, r1
 }
 
-// ListGroupUsers provides a mock function with given fields: groupID, pm, token
-func (_m *SDK) ListGroupUsers(groupID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
-	ret := _m.Called(groupID, pm, token)
+// DisableUser provides a mock function with given fields: id, token
+func (_m *SDK) DisableUser(id string, token string) (sdk.User, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListGroupUsers")
+		panic("no return value specified for DisableUser")
 	}
 
-	var r0 sdk.UsersPage
+	var r0 sdk.User
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
-		return rf(groupID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.User, errors.SDKError)); ok {
+		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
-		r0 = rf(groupID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.User); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.UsersPage)
+		r0 = ret.Get(0).(sdk.User)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(groupID, pm, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1594,57 +1740,67 @@ func (_m *SDK) ListGroupUsers(groupID string, pm sdk.PageMetadata, token string)
 	return r0, r1
 }
 
-// ListSubscriptions provides a mock function with given fields: pm, token
-func (_m *SDK) ListSubscriptions(pm sdk.PageMetadata, token string) (sdk.SubscriptionPage, errors.SDKError) {
-	ret := _m.Called(pm, token)
+// Disconnect provides a mock function with given fields: connIDs, token
+func (_m *SDK) Disconnect(connIDs sdk.Connection, token string) errors.SDKError {
+	ret := _m.Called(connIDs, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListSubscriptions")
+		panic("no return value specified for Disconnect")
 	}
 
-	var r0 sdk.SubscriptionPage
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.SubscriptionPage, errors.SDKError)); ok {
-		return rf(pm, token)
-	}
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.SubscriptionPage); ok {
-		r0 = rf(pm, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Connection, string) errors.SDKError); ok {
+		r0 = rf(connIDs, token)
 	} else {
-		r0 = ret.Get(0).(sdk.SubscriptionPage)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(pm, token)
+	return r0
+}
+
+// DisconnectThing provides a mock function with given fields: thingID, chanID, token
+func (_m *SDK) DisconnectThing(thingID string, chanID string, token string) errors.SDKError {
+	ret := _m.Called(thingID, chanID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisconnectThing")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(thingID, chanID, token)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
 		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ListThingUsers provides a mock function with given fields: thingID, pm, token
-func (_m *SDK) ListThingUsers(thingID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
-	ret := _m.Called(thingID, pm, token)
+// Domain provides a mock function with given fields: domainID, token
+func (_m *SDK) Domain(domainID string, token string) (sdk.Domain, errors.SDKError) {
+	ret := _m.Called(domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListThingUsers")
+		panic("no return value specified for Domain")
 	}
 
-	var r0 sdk.UsersPage
+	var r0 sdk.Domain
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
-		return rf(thingID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Domain, errors.SDKError)); ok {
+		return rf(domainID, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
-		r0 = rf(thingID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Domain); ok {
+		r0 = rf(domainID, token)
 	} else {
-		r0 = ret.Get(0).(sdk.UsersPage)
+		r0 = ret.Get(0).(sdk.Domain)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(thingID, pm, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(domainID, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1654,27 +1810,27 @@ func (_m *SDK) ListThingUsers(thingID string, pm sdk.PageMetadata, token string)
 	return r0, r1
 }
 
-// ListUserChannels provides a mock function with given fields: userID, pm, token
-func (_m *SDK) ListUserChannels(userID string, pm sdk.PageMetadata, token string) (sdk.ChannelsPage, errors.SDKError) {
-	ret := _m.Called(userID, pm, token)
+// DomainPermissions provides a mock function with given fields: domainID, token
+func (_m *SDK) DomainPermissions(domainID string, token string) (sdk.Domain, errors.SDKError) {
+	ret := _m.Called(domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUserChannels")
+		panic("no return value specified for DomainPermissions")
 	}
 
-	var r0 sdk.ChannelsPage
+	var r0 sdk.Domain
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.ChannelsPage, errors.SDKError)); ok {
-		return rf(userID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Domain, errors.SDKError)); ok {
+		return rf(domainID, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.ChannelsPage); ok {
-		r0 = rf(userID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Domain); ok {
+		r0 = rf(domainID, token)
 	} else {
-		r0 = ret.Get(0).(sdk.ChannelsPage)
+		r0 = ret.Get(0).(sdk.Domain)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(userID, pm, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(domainID, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1684,27 +1840,27 @@ func (_m *SDK) ListUserChannels(userID string, pm sdk.PageMetadata, token string
 	return r0, r1
 }
 
-// ListUserDomains provides a mock function with given fields: userID, pm, token
-func (_m *SDK) ListUserDomains(userID string, pm sdk.PageMetadata, token string) (sdk.DomainsPage, errors.SDKError) {
-	ret := _m.Called(userID, pm, token)
+// Domains provides a mock function with given fields: pm, token
+func (_m *SDK) Domains(pm sdk.PageMetadata, token string) (sdk.DomainsPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUserDomains")
+		panic("no return value specified for Domains")
 	}
 
 	var r0 sdk.DomainsPage
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.DomainsPage, errors.SDKError)); ok {
-		return rf(userID, pm, token)
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.DomainsPage, errors.SDKError)); ok {
+		return rf(pm, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.DomainsPage); ok {
-		r0 = rf(userID, pm, token)
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.DomainsPage); ok {
+		r0 = rf(pm, token)
 	} else {
 		r0 = ret.Get(0).(sdk.DomainsPage)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(userID, pm, token)
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1714,27 +1870,27 @@ func (_m *SDK) ListUserDomains(userID string, pm sdk.PageMetadata, token string)
 	return r0, r1
 }
 
-// ListUserGroups provides a mock function with given fields: userID, pm, token
-func (_m *SDK) ListUserGroups(userID string, pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
-	ret := _m.Called(userID, pm, token)
+// Drift provides a mock function with given fields: token
+func (_m *SDK) Drift(token string) (sdk.DriftReport, errors.SDKError) {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUserGroups")
+		panic("no return value specified for Drift")
 	}
 
-	var r0 sdk.GroupsPage
+	var r0 sdk.DriftReport
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
-		return rf(userID, pm, token)
+	if rf, ok := ret.Get(0).(func(string) (sdk.DriftReport, errors.SDKError)); ok {
+		return rf(token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.GroupsPage); ok {
-		r0 = rf(userID, pm, token)
+	if rf, ok := ret.Get(0).(func(string) sdk.DriftReport); ok {
+		r0 = rf(token)
 	} else {
-		r0 = ret.Get(0).(sdk.GroupsPage)
+		r0 = ret.Get(0).(sdk.DriftReport)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(userID, pm, token)
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1744,27 +1900,27 @@ func (_m *SDK) ListUserGroups(userID string, pm sdk.PageMetadata, token string)
 	return r0, r1
 }
 
-// ListUserThings provides a mock function with given fields: userID, pm, token
-func (_m *SDK) ListUserThings(userID string, pm sdk.PageMetadata, token string) (sdk.ThingsPage, errors.SDKError) {
-	ret := _m.Called(userID, pm, token)
+// EnableChannel provides a mock function with given fields: id, token
+func (_m *SDK) EnableChannel(id string, token string) (sdk.Channel, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListUserThings")
+		panic("no return value specified for EnableChannel")
 	}
 
-	var r0 sdk.ThingsPage
+	var r0 sdk.Channel
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.ThingsPage, errors.SDKError)); ok {
-		return rf(userID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Channel, errors.SDKError)); ok {
+		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.ThingsPage); ok {
-		r0 = rf(userID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Channel); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.ThingsPage)
+		r0 = ret.Get(0).(sdk.Channel)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(userID, pm, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1774,57 +1930,47 @@ func (_m *SDK) ListUserThings(userID string, pm sdk.PageMetadata, token string)
 	return r0, r1
 }
 
-// Parents provides a mock function with given fields: id, pm, token
-func (_m *SDK) Parents(id string, pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
-	ret := _m.Called(id, pm, token)
+// EnableDomain provides a mock function with given fields: domainID, token
+func (_m *SDK) EnableDomain(domainID string, token string) errors.SDKError {
+	ret := _m.Called(domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Parents")
-	}
-
-	var r0 sdk.GroupsPage
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
-		return rf(id, pm, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.GroupsPage); ok {
-		r0 = rf(id, pm, token)
-	} else {
-		r0 = ret.Get(0).(sdk.GroupsPage)
+		panic("no return value specified for EnableDomain")
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(id, pm, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(domainID, token)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
 		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ReadMessages provides a mock function with given fields: pm, chanID, token
-func (_m *SDK) ReadMessages(pm sdk.MessagePageMetadata, chanID string, token string) (sdk.MessagesPage, errors.SDKError) {
-	ret := _m.Called(pm, chanID, token)
+// EnableGroup provides a mock function with given fields: id, token
+func (_m *SDK) EnableGroup(id string, token string) (sdk.Group, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ReadMessages")
+		panic("no return value specified for EnableGroup")
 	}
 
-	var r0 sdk.MessagesPage
+	var r0 sdk.Group
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.MessagePageMetadata, string, string) (sdk.MessagesPage, errors.SDKError)); ok {
-		return rf(pm, chanID, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Group, errors.SDKError)); ok {
+		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(sdk.MessagePageMetadata, string, string) sdk.MessagesPage); ok {
-		r0 = rf(pm, chanID, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Group); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.MessagesPage)
+		r0 = ret.Get(0).(sdk.Group)
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.MessagePageMetadata, string, string) errors.SDKError); ok {
-		r1 = rf(pm, chanID, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1834,27 +1980,27 @@ func (_m *SDK) ReadMessages(pm sdk.MessagePageMetadata, chanID string, token str
 	return r0, r1
 }
 
-// RefreshToken provides a mock function with given fields: lt, token
-func (_m *SDK) RefreshToken(lt sdk.Login, token string) (sdk.Token, errors.SDKError) {
-	ret := _m.Called(lt, token)
+// EnableThing provides a mock function with given fields: id, token
+func (_m *SDK) EnableThing(id string, token string) (sdk.Thing, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RefreshToken")
+		panic("no return value specified for EnableThing")
 	}
 
-	var r0 sdk.Token
+	var r0 sdk.Thing
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.Login, string) (sdk.Token, errors.SDKError)); ok {
-		return rf(lt, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Thing, errors.SDKError)); ok {
+		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(sdk.Login, string) sdk.Token); ok {
-		r0 = rf(lt, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Thing); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Token)
+		r0 = ret.Get(0).(sdk.Thing)
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.Login, string) errors.SDKError); ok {
-		r1 = rf(lt, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -1864,167 +2010,151 @@ func (_m *SDK) RefreshToken(lt sdk.Login, token string) (sdk.Token, errors.SDKEr
 	return r0, r1
 }
 
-// RemoveBootstrap provides a mock function with given fields: id, token
-func (_m *SDK) RemoveBootstrap(id string, token string) errors.SDKError {
+// EnableUser provides a mock function with given fields: id, token
+func (_m *SDK) EnableUser(id string, token string) (sdk.User, errors.SDKError) {
 	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveBootstrap")
+		panic("no return value specified for EnableUser")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+	var r0 sdk.User
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.User, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.User); ok {
 		r0 = rf(id, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
-		}
-	}
-
-	return r0
-}
-
-// RemoveUserFromChannel provides a mock function with given fields: channelID, req, token
-func (_m *SDK) RemoveUserFromChannel(channelID string, req sdk.UsersRelationRequest, token string) errors.SDKError {
-	ret := _m.Called(channelID, req, token)
-
-	if len(ret) == 0 {
-		panic("no return value specified for RemoveUserFromChannel")
+		r0 = ret.Get(0).(sdk.User)
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.UsersRelationRequest, string) errors.SDKError); ok {
-		r0 = rf(channelID, req, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// RemoveUserFromDomain provides a mock function with given fields: domainID, req, token
-func (_m *SDK) RemoveUserFromDomain(domainID string, req sdk.UsersRelationRequest, token string) errors.SDKError {
-	ret := _m.Called(domainID, req, token)
+// ExportRuleset provides a mock function with given fields: token
+func (_m *SDK) ExportRuleset(token string) (sdk.Ruleset, errors.SDKError) {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveUserFromDomain")
+		panic("no return value specified for ExportRuleset")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.UsersRelationRequest, string) errors.SDKError); ok {
-		r0 = rf(domainID, req, token)
+	var r0 sdk.Ruleset
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.Ruleset, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.Ruleset); ok {
+		r0 = rf(token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		r0 = ret.Get(0).(sdk.Ruleset)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// RemoveUserFromGroup provides a mock function with given fields: groupID, req, token
-func (_m *SDK) RemoveUserFromGroup(groupID string, req sdk.UsersRelationRequest, token string) errors.SDKError {
-	ret := _m.Called(groupID, req, token)
+// ExternalFunctions provides a mock function with given fields: token
+func (_m *SDK) ExternalFunctions(token string) ([]sdk.ExternalFunction, errors.SDKError) {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveUserFromGroup")
+		panic("no return value specified for ExternalFunctions")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.UsersRelationRequest, string) errors.SDKError); ok {
-		r0 = rf(groupID, req, token)
+	var r0 []sdk.ExternalFunction
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]sdk.ExternalFunction, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []sdk.ExternalFunction); ok {
+		r0 = rf(token)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+			r0 = ret.Get(0).([]sdk.ExternalFunction)
 		}
 	}
 
-	return r0
-}
-
-// RemoveUserGroupFromChannel provides a mock function with given fields: channelID, req, token
-func (_m *SDK) RemoveUserGroupFromChannel(channelID string, req sdk.UserGroupsRequest, token string) errors.SDKError {
-	ret := _m.Called(channelID, req, token)
-
-	if len(ret) == 0 {
-		panic("no return value specified for RemoveUserGroupFromChannel")
-	}
-
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.UserGroupsRequest, string) errors.SDKError); ok {
-		r0 = rf(channelID, req, token)
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// ResetPassword provides a mock function with given fields: password, confPass, token
-func (_m *SDK) ResetPassword(password string, confPass string, token string) errors.SDKError {
-	ret := _m.Called(password, confPass, token)
+// ExternalServices provides a mock function with given fields: token
+func (_m *SDK) ExternalServices(token string) ([]string, errors.SDKError) {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResetPassword")
+		panic("no return value specified for ExternalServices")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
-		r0 = rf(password, confPass, token)
+	var r0 []string
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]string, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(token)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	return r0
-}
-
-// ResetPasswordRequest provides a mock function with given fields: email
-func (_m *SDK) ResetPasswordRequest(email string) errors.SDKError {
-	ret := _m.Called(email)
-
-	if len(ret) == 0 {
-		panic("no return value specified for ResetPasswordRequest")
-	}
-
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string) errors.SDKError); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// RevokeCert provides a mock function with given fields: thingID, token
-func (_m *SDK) RevokeCert(thingID string, token string) (time.Time, errors.SDKError) {
-	ret := _m.Called(thingID, token)
+// Group provides a mock function with given fields: id, token
+func (_m *SDK) Group(id string, token string) (sdk.Group, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RevokeCert")
+		panic("no return value specified for Group")
 	}
 
-	var r0 time.Time
+	var r0 sdk.Group
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (time.Time, errors.SDKError)); ok {
-		return rf(thingID, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Group, errors.SDKError)); ok {
+		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) time.Time); ok {
-		r0 = rf(thingID, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Group); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(time.Time)
+		r0 = ret.Get(0).(sdk.Group)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(thingID, token)
+		r1 = rf(id, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -2034,35 +2164,167 @@ func (_m *SDK) RevokeCert(thingID string, token string) (time.Time, errors.SDKEr
 	return r0, r1
 }
 
-// SendInvitation provides a mock function with given fields: invitation, token
-func (_m *SDK) SendInvitation(invitation sdk.Invitation, token string) error {
-	ret := _m.Called(invitation, token)
+// GroupPermissions provides a mock function with given fields: id, token
+func (_m *SDK) GroupPermissions(id string, token string) (sdk.Group, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendInvitation")
+		panic("no return value specified for GroupPermissions")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(sdk.Invitation, string) error); ok {
-		r0 = rf(invitation, token)
+	var r0 sdk.Group
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Group, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Group); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(sdk.Group)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
 }
 
-// SendMessage provides a mock function with given fields: chanID, msg, key
-func (_m *SDK) SendMessage(chanID string, msg string, key string) errors.SDKError {
-	ret := _m.Called(chanID, msg, key)
+// Groups provides a mock function with given fields: pm, token
+func (_m *SDK) Groups(pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendMessage")
+		panic("no return value specified for Groups")
+	}
+
+	var r0 sdk.GroupsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.GroupsPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.GroupsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Health provides a mock function with given fields: service
+func (_m *SDK) Health(service string) (sdk.HealthInfo, errors.SDKError) {
+	ret := _m.Called(service)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Health")
+	}
+
+	var r0 sdk.HealthInfo
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.HealthInfo, errors.SDKError)); ok {
+		return rf(service)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.HealthInfo); ok {
+		r0 = rf(service)
+	} else {
+		r0 = ret.Get(0).(sdk.HealthInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(service)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// IdentifyThing provides a mock function with given fields: key
+func (_m *SDK) IdentifyThing(key string) (string, errors.SDKError) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IdentifyThing")
+	}
+
+	var r0 string
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (string, errors.SDKError)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ImportRuleset provides a mock function with given fields: rs, conflict, token
+func (_m *SDK) ImportRuleset(rs sdk.Ruleset, conflict string, token string) (sdk.ImportReport, errors.SDKError) {
+	ret := _m.Called(rs, conflict, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportRuleset")
+	}
+
+	var r0 sdk.ImportReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Ruleset, string, string) (sdk.ImportReport, errors.SDKError)); ok {
+		return rf(rs, conflict, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Ruleset, string, string) sdk.ImportReport); ok {
+		r0 = rf(rs, conflict, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ImportReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Ruleset, string, string) errors.SDKError); ok {
+		r1 = rf(rs, conflict, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// IngestStream provides a mock function with given fields: stream, msg, key
+func (_m *SDK) IngestStream(stream string, msg string, key string) errors.SDKError {
+	ret := _m.Called(stream, msg, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IngestStream")
 	}
 
 	var r0 errors.SDKError
 	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
-		r0 = rf(chanID, msg, key)
+		r0 = rf(stream, msg, key)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.SDKError)
@@ -2072,67 +2334,2595 @@ func (_m *SDK) SendMessage(chanID string, msg string, key string) errors.SDKErro
 	return r0
 }
 
-// SetContentType provides a mock function with given fields: ct
-func (_m *SDK) SetContentType(ct sdk.ContentType) errors.SDKError {
-	ret := _m.Called(ct)
+// InstantiateRuleTemplate provides a mock function with given fields: name, inst, token
+func (_m *SDK) InstantiateRuleTemplate(name string, inst sdk.TemplateInstance, token string) (sdk.Rule, errors.SDKError) {
+	ret := _m.Called(name, inst, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetContentType")
+		panic("no return value specified for InstantiateRuleTemplate")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.ContentType) errors.SDKError); ok {
-		r0 = rf(ct)
+	var r0 sdk.Rule
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.TemplateInstance, string) (sdk.Rule, errors.SDKError)); ok {
+		return rf(name, inst, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.TemplateInstance, string) sdk.Rule); ok {
+		r0 = rf(name, inst, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		r0 = ret.Get(0).(sdk.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.TemplateInstance, string) errors.SDKError); ok {
+		r1 = rf(name, inst, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// ShareThing provides a mock function with given fields: thingID, req, token
-func (_m *SDK) ShareThing(thingID string, req sdk.UsersRelationRequest, token string) errors.SDKError {
-	ret := _m.Called(thingID, req, token)
+// Invitation provides a mock function with given fields: userID, domainID, token
+func (_m *SDK) Invitation(userID string, domainID string, token string) (sdk.Invitation, error) {
+	ret := _m.Called(userID, domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ShareThing")
+		panic("no return value specified for Invitation")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.UsersRelationRequest, string) errors.SDKError); ok {
-		r0 = rf(thingID, req, token)
+	var r0 sdk.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Invitation, error)); ok {
+		return rf(userID, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Invitation); ok {
+		r0 = rf(userID, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Invitation)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(userID, domainID, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Invitations provides a mock function with given fields: pm, token
+func (_m *SDK) Invitations(pm sdk.PageMetadata, token string) (sdk.InvitationPage, error) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invitations")
+	}
+
+	var r0 sdk.InvitationPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.InvitationPage, error)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.InvitationPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.InvitationPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) error); ok {
+		r1 = rf(pm, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueCert provides a mock function with given fields: thingID, valid, token
+func (_m *SDK) IssueCert(thingID string, valid string, token string) (sdk.Cert, errors.SDKError) {
+	ret := _m.Called(thingID, valid, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueCert")
+	}
+
+	var r0 sdk.Cert
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Cert, errors.SDKError)); ok {
+		return rf(thingID, valid, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Cert); ok {
+		r0 = rf(thingID, valid, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Cert)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(thingID, valid, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListAllRules provides a mock function with given fields: token
+func (_m *SDK) ListAllRules(token string) (sdk.AllRules, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllRules")
+	}
+
+	var r0 sdk.AllRules
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.AllRules, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.AllRules); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.AllRules)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListAllStreams provides a mock function with given fields: token
+func (_m *SDK) ListAllStreams(token string) (sdk.AllStreams, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllStreams")
+	}
+
+	var r0 sdk.AllStreams
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.AllStreams, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.AllStreams); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.AllStreams)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListChannelUserGroups provides a mock function with given fields: channelID, pm, token
+func (_m *SDK) ListChannelUserGroups(channelID string, pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
+	ret := _m.Called(channelID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChannelUserGroups")
+	}
+
+	var r0 sdk.GroupsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
+		return rf(channelID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.GroupsPage); ok {
+		r0 = rf(channelID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.GroupsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(channelID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListChannelUsers provides a mock function with given fields: channelID, pm, token
+func (_m *SDK) ListChannelUsers(channelID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
+	ret := _m.Called(channelID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChannelUsers")
+	}
+
+	var r0 sdk.UsersPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
+		return rf(channelID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
+		r0 = rf(channelID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.UsersPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(channelID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListDomainUsers provides a mock function with given fields: domainID, pm, token
+func (_m *SDK) ListDomainUsers(domainID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
+	ret := _m.Called(domainID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDomainUsers")
+	}
+
+	var r0 sdk.UsersPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
+		return rf(domainID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
+		r0 = rf(domainID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.UsersPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(domainID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListGroupChannels provides a mock function with given fields: groupID, pm, token
+func (_m *SDK) ListGroupChannels(groupID string, pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
+	ret := _m.Called(groupID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroupChannels")
+	}
+
+	var r0 sdk.GroupsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
+		return rf(groupID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.GroupsPage); ok {
+		r0 = rf(groupID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.GroupsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(groupID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListGroupUsers provides a mock function with given fields: groupID, pm, token
+func (_m *SDK) ListGroupUsers(groupID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
+	ret := _m.Called(groupID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroupUsers")
+	}
+
+	var r0 sdk.UsersPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
+		return rf(groupID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
+		r0 = rf(groupID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.UsersPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(groupID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListSubscriptions provides a mock function with given fields: pm, token
+func (_m *SDK) ListSubscriptions(pm sdk.PageMetadata, token string) (sdk.SubscriptionPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubscriptions")
+	}
+
+	var r0 sdk.SubscriptionPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.SubscriptionPage, errors.SDKError)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.SubscriptionPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.SubscriptionPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListThingUsers provides a mock function with given fields: thingID, pm, token
+func (_m *SDK) ListThingUsers(thingID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
+	ret := _m.Called(thingID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListThingUsers")
+	}
+
+	var r0 sdk.UsersPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
+		return rf(thingID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
+		r0 = rf(thingID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.UsersPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(thingID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListUserChannels provides a mock function with given fields: userID, pm, token
+func (_m *SDK) ListUserChannels(userID string, pm sdk.PageMetadata, token string) (sdk.ChannelsPage, errors.SDKError) {
+	ret := _m.Called(userID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserChannels")
+	}
+
+	var r0 sdk.ChannelsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.ChannelsPage, errors.SDKError)); ok {
+		return rf(userID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.ChannelsPage); ok {
+		r0 = rf(userID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ChannelsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(userID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListUserDomains provides a mock function with given fields: userID, pm, token
+func (_m *SDK) ListUserDomains(userID string, pm sdk.PageMetadata, token string) (sdk.DomainsPage, errors.SDKError) {
+	ret := _m.Called(userID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserDomains")
+	}
+
+	var r0 sdk.DomainsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.DomainsPage, errors.SDKError)); ok {
+		return rf(userID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.DomainsPage); ok {
+		r0 = rf(userID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.DomainsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(userID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListUserGroups provides a mock function with given fields: userID, pm, token
+func (_m *SDK) ListUserGroups(userID string, pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
+	ret := _m.Called(userID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserGroups")
+	}
+
+	var r0 sdk.GroupsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
+		return rf(userID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.GroupsPage); ok {
+		r0 = rf(userID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.GroupsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(userID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListUserThings provides a mock function with given fields: userID, pm, token
+func (_m *SDK) ListUserThings(userID string, pm sdk.PageMetadata, token string) (sdk.ThingsPage, errors.SDKError) {
+	ret := _m.Called(userID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserThings")
+	}
+
+	var r0 sdk.ThingsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.ThingsPage, errors.SDKError)); ok {
+		return rf(userID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.ThingsPage); ok {
+		r0 = rf(userID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ThingsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(userID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// MoveRules provides a mock function with given fields: folder, ids, token
+func (_m *SDK) MoveRules(folder string, ids []string, token string) (sdk.BulkReport, errors.SDKError) {
+	ret := _m.Called(folder, ids, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveRules")
+	}
+
+	var r0 sdk.BulkReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, []string, string) (sdk.BulkReport, errors.SDKError)); ok {
+		return rf(folder, ids, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string, string) sdk.BulkReport); ok {
+		r0 = rf(folder, ids, token)
+	} else {
+		r0 = ret.Get(0).(sdk.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string, string) errors.SDKError); ok {
+		r1 = rf(folder, ids, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Orphans provides a mock function with given fields: minAge, token
+func (_m *SDK) Orphans(minAge time.Duration, token string) (sdk.OrphanReport, errors.SDKError) {
+	ret := _m.Called(minAge, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Orphans")
+	}
+
+	var r0 sdk.OrphanReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(time.Duration, string) (sdk.OrphanReport, errors.SDKError)); ok {
+		return rf(minAge, token)
+	}
+	if rf, ok := ret.Get(0).(func(time.Duration, string) sdk.OrphanReport); ok {
+		r0 = rf(minAge, token)
+	} else {
+		r0 = ret.Get(0).(sdk.OrphanReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Duration, string) errors.SDKError); ok {
+		r1 = rf(minAge, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Parents provides a mock function with given fields: id, pm, token
+func (_m *SDK) Parents(id string, pm sdk.PageMetadata, token string) (sdk.GroupsPage, errors.SDKError) {
+	ret := _m.Called(id, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Parents")
+	}
+
+	var r0 sdk.GroupsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.GroupsPage, errors.SDKError)); ok {
+		return rf(id, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.GroupsPage); ok {
+		r0 = rf(id, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.GroupsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(id, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// PatchRule provides a mock function with given fields: id, patch, token
+func (_m *SDK) PatchRule(id string, patch sdk.RulePatch, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(id, patch, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.RulePatch, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(id, patch, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.RulePatch, string) sdk.RulesEngineResult); ok {
+		r0 = rf(id, patch, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.RulePatch, string) errors.SDKError); ok {
+		r1 = rf(id, patch, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// PauseRules provides a mock function with given fields: token
+func (_m *SDK) PauseRules(token string) (sdk.BulkReport, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseRules")
+	}
+
+	var r0 sdk.BulkReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.BulkReport, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.BulkReport); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// PublishRule provides a mock function with given fields: id, token
+func (_m *SDK) PublishRule(id string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ReadMessages provides a mock function with given fields: pm, chanID, token
+func (_m *SDK) ReadMessages(pm sdk.MessagePageMetadata, chanID string, token string) (sdk.MessagesPage, errors.SDKError) {
+	ret := _m.Called(pm, chanID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadMessages")
+	}
+
+	var r0 sdk.MessagesPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.MessagePageMetadata, string, string) (sdk.MessagesPage, errors.SDKError)); ok {
+		return rf(pm, chanID, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.MessagePageMetadata, string, string) sdk.MessagesPage); ok {
+		r0 = rf(pm, chanID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.MessagesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.MessagePageMetadata, string, string) errors.SDKError); ok {
+		r1 = rf(pm, chanID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RefreshToken provides a mock function with given fields: lt, token
+func (_m *SDK) RefreshToken(lt sdk.Login, token string) (sdk.Token, errors.SDKError) {
+	ret := _m.Called(lt, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 sdk.Token
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Login, string) (sdk.Token, errors.SDKError)); ok {
+		return rf(lt, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Login, string) sdk.Token); ok {
+		r0 = rf(lt, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Token)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Login, string) errors.SDKError); ok {
+		r1 = rf(lt, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RegisterExternalService provides a mock function with given fields: es, token
+func (_m *SDK) RegisterExternalService(es sdk.ExternalService, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(es, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterExternalService")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.ExternalService, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(es, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.ExternalService, string) sdk.RulesEngineResult); ok {
+		r0 = rf(es, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.ExternalService, string) errors.SDKError); ok {
+		r1 = rf(es, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RemoveBootstrap provides a mock function with given fields: id, token
+func (_m *SDK) RemoveBootstrap(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBootstrap")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// RemoveOrphans provides a mock function with given fields: minAge, token
+func (_m *SDK) RemoveOrphans(minAge time.Duration, token string) (sdk.OrphanReport, errors.SDKError) {
+	ret := _m.Called(minAge, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveOrphans")
+	}
+
+	var r0 sdk.OrphanReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(time.Duration, string) (sdk.OrphanReport, errors.SDKError)); ok {
+		return rf(minAge, token)
+	}
+	if rf, ok := ret.Get(0).(func(time.Duration, string) sdk.OrphanReport); ok {
+		r0 = rf(minAge, token)
+	} else {
+		r0 = ret.Get(0).(sdk.OrphanReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Duration, string) errors.SDKError); ok {
+		r1 = rf(minAge, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RemoveRulesEngineGateway provides a mock function with given fields: id, token
+func (_m *SDK) RemoveRulesEngineGateway(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveRulesEngineGateway")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// RemoveUserFromChannel provides a mock function with given fields: channelID, req, token
+func (_m *SDK) RemoveUserFromChannel(channelID string, req sdk.UsersRelationRequest, token string) errors.SDKError {
+	ret := _m.Called(channelID, req, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveUserFromChannel")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.UsersRelationRequest, string) errors.SDKError); ok {
+		r0 = rf(channelID, req, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// RemoveUserFromDomain provides a mock function with given fields: domainID, req, token
+func (_m *SDK) RemoveUserFromDomain(domainID string, req sdk.UsersRelationRequest, token string) errors.SDKError {
+	ret := _m.Called(domainID, req, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveUserFromDomain")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.UsersRelationRequest, string) errors.SDKError); ok {
+		r0 = rf(domainID, req, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// RemoveUserFromGroup provides a mock function with given fields: groupID, req, token
+func (_m *SDK) RemoveUserFromGroup(groupID string, req sdk.UsersRelationRequest, token string) errors.SDKError {
+	ret := _m.Called(groupID, req, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveUserFromGroup")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.UsersRelationRequest, string) errors.SDKError); ok {
+		r0 = rf(groupID, req, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// RemoveUserGroupFromChannel provides a mock function with given fields: channelID, req, token
+func (_m *SDK) RemoveUserGroupFromChannel(channelID string, req sdk.UserGroupsRequest, token string) errors.SDKError {
+	ret := _m.Called(channelID, req, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveUserGroupFromChannel")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.UserGroupsRequest, string) errors.SDKError); ok {
+		r0 = rf(channelID, req, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// RenameRule provides a mock function with given fields: id, newID, token
+func (_m *SDK) RenameRule(id string, newID string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(id, newID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(id, newID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(id, newID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(id, newID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RenameStream provides a mock function with given fields: name, newName, token
+func (_m *SDK) RenameStream(name string, newName string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(name, newName, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameStream")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(name, newName, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(name, newName, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(name, newName, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RepairDrift provides a mock function with given fields: token
+func (_m *SDK) RepairDrift(token string) (sdk.DriftReport, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepairDrift")
+	}
+
+	var r0 sdk.DriftReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.DriftReport, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.DriftReport); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.DriftReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ReplayRule provides a mock function with given fields: id, from, to, token
+func (_m *SDK) ReplayRule(id string, from time.Time, to time.Time, token string) (sdk.ReplayResult, errors.SDKError) {
+	ret := _m.Called(id, from, to, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayRule")
+	}
+
+	var r0 sdk.ReplayResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time, string) (sdk.ReplayResult, errors.SDKError)); ok {
+		return rf(id, from, to, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time, string) sdk.ReplayResult); ok {
+		r0 = rf(id, from, to, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ReplayResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time, string) errors.SDKError); ok {
+		r1 = rf(id, from, to, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: password, confPass, token
+func (_m *SDK) ResetPassword(password string, confPass string, token string) errors.SDKError {
+	ret := _m.Called(password, confPass, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(password, confPass, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// ResetPasswordRequest provides a mock function with given fields: email
+func (_m *SDK) ResetPasswordRequest(email string) errors.SDKError {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPasswordRequest")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) errors.SDKError); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// RestartRule provides a mock function with given fields: id, token
+func (_m *SDK) RestartRule(id string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestartRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RestoreKuiper provides a mock function with given fields: dryRun, token
+func (_m *SDK) RestoreKuiper(dryRun bool, token string) (sdk.RestoreReport, errors.SDKError) {
+	ret := _m.Called(dryRun, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreKuiper")
+	}
+
+	var r0 sdk.RestoreReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(bool, string) (sdk.RestoreReport, errors.SDKError)); ok {
+		return rf(dryRun, token)
+	}
+	if rf, ok := ret.Get(0).(func(bool, string) sdk.RestoreReport); ok {
+		r0 = rf(dryRun, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RestoreReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(bool, string) errors.SDKError); ok {
+		r1 = rf(dryRun, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RestoreRule provides a mock function with given fields: id, token
+func (_m *SDK) RestoreRule(id string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ResumeRules provides a mock function with given fields: token
+func (_m *SDK) ResumeRules(token string) (sdk.BulkReport, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeRules")
+	}
+
+	var r0 sdk.BulkReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.BulkReport, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.BulkReport); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RetryDeployments provides a mock function with given fields: id, token
+func (_m *SDK) RetryDeployments(id string, token string) (sdk.RuleRollout, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryDeployments")
+	}
+
+	var r0 sdk.RuleRollout
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RuleRollout, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RuleRollout); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleRollout)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RevokeCert provides a mock function with given fields: thingID, token
+func (_m *SDK) RevokeCert(thingID string, token string) (time.Time, errors.SDKError) {
+	ret := _m.Called(thingID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeCert")
+	}
+
+	var r0 time.Time
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (time.Time, errors.SDKError)); ok {
+		return rf(thingID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) time.Time); ok {
+		r0 = rf(thingID, token)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(thingID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RuleDeployments provides a mock function with given fields: id, token
+func (_m *SDK) RuleDeployments(id string, token string) ([]sdk.RuleDeployment, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleDeployments")
+	}
+
+	var r0 []sdk.RuleDeployment
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) ([]sdk.RuleDeployment, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []sdk.RuleDeployment); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RuleDeployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RuleLogs provides a mock function with given fields: id, since, token
+func (_m *SDK) RuleLogs(id string, since time.Time, token string) ([]sdk.RuleLogEntry, errors.SDKError) {
+	ret := _m.Called(id, since, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleLogs")
+	}
+
+	var r0 []sdk.RuleLogEntry
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, time.Time, string) ([]sdk.RuleLogEntry, errors.SDKError)); ok {
+		return rf(id, since, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, string) []sdk.RuleLogEntry); ok {
+		r0 = rf(id, since, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RuleLogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, string) errors.SDKError); ok {
+		r1 = rf(id, since, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RuleShares provides a mock function with given fields: id, token
+func (_m *SDK) RuleShares(id string, token string) ([]sdk.EntityShare, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleShares")
+	}
+
+	var r0 []sdk.EntityShare
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) ([]sdk.EntityShare, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []sdk.EntityShare); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.EntityShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RuleStatus provides a mock function with given fields: id, token
+func (_m *SDK) RuleStatus(id string, token string) (sdk.RuleStatus, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleStatus")
+	}
+
+	var r0 sdk.RuleStatus
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RuleStatus, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RuleStatus); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RuleTemplate provides a mock function with given fields: name, token
+func (_m *SDK) RuleTemplate(name string, token string) (sdk.RuleTemplate, errors.SDKError) {
+	ret := _m.Called(name, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleTemplate")
+	}
+
+	var r0 sdk.RuleTemplate
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RuleTemplate, errors.SDKError)); ok {
+		return rf(name, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RuleTemplate); ok {
+		r0 = rf(name, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleTemplate)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(name, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RuleTemplates provides a mock function with given fields: token
+func (_m *SDK) RuleTemplates(token string) ([]sdk.RuleTemplate, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleTemplates")
+	}
+
+	var r0 []sdk.RuleTemplate
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]sdk.RuleTemplate, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []sdk.RuleTemplate); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RuleTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RuleTopology provides a mock function with given fields: id, token
+func (_m *SDK) RuleTopology(id string, token string) (sdk.RuleTopology, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleTopology")
+	}
+
+	var r0 sdk.RuleTopology
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RuleTopology, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RuleTopology); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleTopology)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Rules provides a mock function with given fields: pm, token
+func (_m *SDK) Rules(pm sdk.PageMetadata, token string) (sdk.RulesPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rules")
+	}
+
+	var r0 sdk.RulesPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.RulesPage, errors.SDKError)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.RulesPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesAlertPolicy provides a mock function with given fields: token
+func (_m *SDK) RulesAlertPolicy(token string) (sdk.RulesAlertPolicy, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesAlertPolicy")
+	}
+
+	var r0 sdk.RulesAlertPolicy
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.RulesAlertPolicy, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.RulesAlertPolicy); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesAlertPolicy)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesChains provides a mock function with given fields: token
+func (_m *SDK) RulesChains(token string) ([]sdk.RulesChain, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesChains")
+	}
+
+	var r0 []sdk.RulesChain
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]sdk.RulesChain, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []sdk.RulesChain); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RulesChain)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesEngineGateways provides a mock function with given fields: token
+func (_m *SDK) RulesEngineGateways(token string) ([]sdk.RulesEngineGateway, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesEngineGateways")
+	}
+
+	var r0 []sdk.RulesEngineGateway
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]sdk.RulesEngineGateway, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []sdk.RulesEngineGateway); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RulesEngineGateway)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesEngineInstances provides a mock function with given fields: token
+func (_m *SDK) RulesEngineInstances(token string) ([]sdk.RulesEngineInstance, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesEngineInstances")
+	}
+
+	var r0 []sdk.RulesEngineInstance
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]sdk.RulesEngineInstance, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []sdk.RulesEngineInstance); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RulesEngineInstance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesEnginePlugins provides a mock function with given fields: kind, token
+func (_m *SDK) RulesEnginePlugins(kind string, token string) ([]string, errors.SDKError) {
+	ret := _m.Called(kind, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesEnginePlugins")
+	}
+
+	var r0 []string
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) ([]string, errors.SDKError)); ok {
+		return rf(kind, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(kind, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(kind, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesEngineStats provides a mock function with given fields: token
+func (_m *SDK) RulesEngineStats(token string) (sdk.RulesEngineStats, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesEngineStats")
+	}
+
+	var r0 sdk.RulesEngineStats
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.RulesEngineStats, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.RulesEngineStats); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesFolders provides a mock function with given fields: token
+func (_m *SDK) RulesFolders(token string) ([]sdk.RulesFolder, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesFolders")
+	}
+
+	var r0 []sdk.RulesFolder
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]sdk.RulesFolder, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []sdk.RulesFolder); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RulesFolder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesMaintenance provides a mock function with given fields: token
+func (_m *SDK) RulesMaintenance(token string) (sdk.RulesMaintenance, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesMaintenance")
+	}
+
+	var r0 sdk.RulesMaintenance
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.RulesMaintenance, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.RulesMaintenance); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesMaintenance)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesQuota provides a mock function with given fields: userID, token
+func (_m *SDK) RulesQuota(userID string, token string) (sdk.UserRulesQuota, errors.SDKError) {
+	ret := _m.Called(userID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesQuota")
+	}
+
+	var r0 sdk.UserRulesQuota
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.UserRulesQuota, errors.SDKError)); ok {
+		return rf(userID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.UserRulesQuota); ok {
+		r0 = rf(userID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.UserRulesQuota)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(userID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesSecrets provides a mock function with given fields: token
+func (_m *SDK) RulesSecrets(token string) ([]sdk.RulesSecret, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesSecrets")
+	}
+
+	var r0 []sdk.RulesSecret
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]sdk.RulesSecret, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []sdk.RulesSecret); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RulesSecret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// RulesWebhooks provides a mock function with given fields: token
+func (_m *SDK) RulesWebhooks(token string) ([]sdk.RulesWebhook, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RulesWebhooks")
+	}
+
+	var r0 []sdk.RulesWebhook
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) ([]sdk.RulesWebhook, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []sdk.RulesWebhook); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.RulesWebhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SaveConfKey provides a mock function with given fields: name, conf, token
+func (_m *SDK) SaveConfKey(name string, conf sdk.MQTTConf, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(name, conf, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveConfKey")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.MQTTConf, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(name, conf, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.MQTTConf, string) sdk.RulesEngineResult); ok {
+		r0 = rf(name, conf, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.MQTTConf, string) errors.SDKError); ok {
+		r1 = rf(name, conf, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SaveDraftRule provides a mock function with given fields: rule, token
+func (_m *SDK) SaveDraftRule(rule sdk.Rule, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(rule, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDraftRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(rule, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string) sdk.RulesEngineResult); ok {
+		r0 = rf(rule, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Rule, string) errors.SDKError); ok {
+		r1 = rf(rule, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SaveRulesEngineGateway provides a mock function with given fields: gw, token
+func (_m *SDK) SaveRulesEngineGateway(gw sdk.RulesEngineGateway, token string) (sdk.RulesEngineGateway, errors.SDKError) {
+	ret := _m.Called(gw, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRulesEngineGateway")
+	}
+
+	var r0 sdk.RulesEngineGateway
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.RulesEngineGateway, string) (sdk.RulesEngineGateway, errors.SDKError)); ok {
+		return rf(gw, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.RulesEngineGateway, string) sdk.RulesEngineGateway); ok {
+		r0 = rf(gw, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineGateway)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.RulesEngineGateway, string) errors.SDKError); ok {
+		r1 = rf(gw, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SaveRulesSecret provides a mock function with given fields: name, value, token
+func (_m *SDK) SaveRulesSecret(name string, value string, token string) (sdk.RulesSecret, errors.SDKError) {
+	ret := _m.Called(name, value, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRulesSecret")
+	}
+
+	var r0 sdk.RulesSecret
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.RulesSecret, errors.SDKError)); ok {
+		return rf(name, value, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.RulesSecret); ok {
+		r0 = rf(name, value, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesSecret)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(name, value, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SearchRules provides a mock function with given fields: stream, channel, pm, token
+func (_m *SDK) SearchRules(stream string, channel string, pm sdk.PageMetadata, token string) (sdk.RulesPage, errors.SDKError) {
+	ret := _m.Called(stream, channel, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchRules")
+	}
+
+	var r0 sdk.RulesPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) (sdk.RulesPage, errors.SDKError)); ok {
+		return rf(stream, channel, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) sdk.RulesPage); ok {
+		r0 = rf(stream, channel, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(stream, channel, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SendInvitation provides a mock function with given fields: invitation, token
+func (_m *SDK) SendInvitation(invitation sdk.Invitation, token string) error {
+	ret := _m.Called(invitation, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(sdk.Invitation, string) error); ok {
+		r0 = rf(invitation, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendMessage provides a mock function with given fields: chanID, msg, key
+func (_m *SDK) SendMessage(chanID string, msg string, key string) errors.SDKError {
+	ret := _m.Called(chanID, msg, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessage")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(chanID, msg, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SetContentType provides a mock function with given fields: ct
+func (_m *SDK) SetContentType(ct sdk.ContentType) errors.SDKError {
+	ret := _m.Called(ct)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetContentType")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.ContentType) errors.SDKError); ok {
+		r0 = rf(ct)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SetRulesAlertPolicy provides a mock function with given fields: p, token
+func (_m *SDK) SetRulesAlertPolicy(p sdk.RulesAlertPolicy, token string) (sdk.RulesAlertPolicy, errors.SDKError) {
+	ret := _m.Called(p, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRulesAlertPolicy")
+	}
+
+	var r0 sdk.RulesAlertPolicy
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.RulesAlertPolicy, string) (sdk.RulesAlertPolicy, errors.SDKError)); ok {
+		return rf(p, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.RulesAlertPolicy, string) sdk.RulesAlertPolicy); ok {
+		r0 = rf(p, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesAlertPolicy)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.RulesAlertPolicy, string) errors.SDKError); ok {
+		r1 = rf(p, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SetRulesQuota provides a mock function with given fields: userID, q, token
+func (_m *SDK) SetRulesQuota(userID string, q sdk.RulesQuota, token string) (sdk.UserRulesQuota, errors.SDKError) {
+	ret := _m.Called(userID, q, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRulesQuota")
+	}
+
+	var r0 sdk.UserRulesQuota
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.RulesQuota, string) (sdk.UserRulesQuota, errors.SDKError)); ok {
+		return rf(userID, q, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.RulesQuota, string) sdk.UserRulesQuota); ok {
+		r0 = rf(userID, q, token)
+	} else {
+		r0 = ret.Get(0).(sdk.UserRulesQuota)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.RulesQuota, string) errors.SDKError); ok {
+		r1 = rf(userID, q, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ShareRule provides a mock function with given fields: id, s, token
+func (_m *SDK) ShareRule(id string, s sdk.EntityShare, token string) (sdk.EntityShare, errors.SDKError) {
+	ret := _m.Called(id, s, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShareRule")
+	}
+
+	var r0 sdk.EntityShare
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.EntityShare, string) (sdk.EntityShare, errors.SDKError)); ok {
+		return rf(id, s, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.EntityShare, string) sdk.EntityShare); ok {
+		r0 = rf(id, s, token)
+	} else {
+		r0 = ret.Get(0).(sdk.EntityShare)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.EntityShare, string) errors.SDKError); ok {
+		r1 = rf(id, s, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ShareStream provides a mock function with given fields: name, s, token
+func (_m *SDK) ShareStream(name string, s sdk.EntityShare, token string) (sdk.EntityShare, errors.SDKError) {
+	ret := _m.Called(name, s, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShareStream")
+	}
+
+	var r0 sdk.EntityShare
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.EntityShare, string) (sdk.EntityShare, errors.SDKError)); ok {
+		return rf(name, s, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.EntityShare, string) sdk.EntityShare); ok {
+		r0 = rf(name, s, token)
+	} else {
+		r0 = ret.Get(0).(sdk.EntityShare)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.EntityShare, string) errors.SDKError); ok {
+		r1 = rf(name, s, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ShareThing provides a mock function with given fields: thingID, req, token
+func (_m *SDK) ShareThing(thingID string, req sdk.UsersRelationRequest, token string) errors.SDKError {
+	ret := _m.Called(thingID, req, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShareThing")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.UsersRelationRequest, string) errors.SDKError); ok {
+		r0 = rf(thingID, req, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// StartRule provides a mock function with given fields: id, token
+func (_m *SDK) StartRule(id string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// StartRules provides a mock function with given fields: sel, token
+func (_m *SDK) StartRules(sel sdk.RuleSelection, token string) (sdk.BulkReport, errors.SDKError) {
+	ret := _m.Called(sel, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartRules")
+	}
+
+	var r0 sdk.BulkReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.RuleSelection, string) (sdk.BulkReport, errors.SDKError)); ok {
+		return rf(sel, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.RuleSelection, string) sdk.BulkReport); ok {
+		r0 = rf(sel, token)
+	} else {
+		r0 = ret.Get(0).(sdk.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.RuleSelection, string) errors.SDKError); ok {
+		r1 = rf(sel, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// StopRule provides a mock function with given fields: id, token
+func (_m *SDK) StopRule(id string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// StopRules provides a mock function with given fields: sel, token
+func (_m *SDK) StopRules(sel sdk.RuleSelection, token string) (sdk.BulkReport, errors.SDKError) {
+	ret := _m.Called(sel, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopRules")
+	}
+
+	var r0 sdk.BulkReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.RuleSelection, string) (sdk.BulkReport, errors.SDKError)); ok {
+		return rf(sel, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.RuleSelection, string) sdk.BulkReport); ok {
+		r0 = rf(sel, token)
+	} else {
+		r0 = ret.Get(0).(sdk.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.RuleSelection, string) errors.SDKError); ok {
+		r1 = rf(sel, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// StreamShares provides a mock function with given fields: name, token
+func (_m *SDK) StreamShares(name string, token string) ([]sdk.EntityShare, errors.SDKError) {
+	ret := _m.Called(name, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamShares")
+	}
+
+	var r0 []sdk.EntityShare
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) ([]sdk.EntityShare, errors.SDKError)); ok {
+		return rf(name, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []sdk.EntityShare); ok {
+		r0 = rf(name, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sdk.EntityShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(name, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Streams provides a mock function with given fields: pm, token
+func (_m *SDK) Streams(pm sdk.PageMetadata, token string) (sdk.StreamsPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Streams")
+	}
+
+	var r0 sdk.StreamsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.StreamsPage, errors.SDKError)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.StreamsPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.StreamsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Tables provides a mock function with given fields: pm, token
+func (_m *SDK) Tables(pm sdk.PageMetadata, token string) (sdk.TablesPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tables")
+	}
+
+	var r0 sdk.TablesPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.TablesPage, errors.SDKError)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.TablesPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.TablesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// TestRule provides a mock function with given fields: trial, token
+func (_m *SDK) TestRule(trial sdk.RuleTrial, token string) (sdk.TrialResult, errors.SDKError) {
+	ret := _m.Called(trial, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestRule")
+	}
+
+	var r0 sdk.TrialResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.RuleTrial, string) (sdk.TrialResult, errors.SDKError)); ok {
+		return rf(trial, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.RuleTrial, string) sdk.TrialResult); ok {
+		r0 = rf(trial, token)
+	} else {
+		r0 = ret.Get(0).(sdk.TrialResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.RuleTrial, string) errors.SDKError); ok {
+		r1 = rf(trial, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Thing provides a mock function with given fields: id, token
+func (_m *SDK) Thing(id string, token string) (sdk.Thing, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Thing")
+	}
+
+	var r0 sdk.Thing
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Thing, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Thing); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Thing)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ThingPermissions provides a mock function with given fields: id, token
+func (_m *SDK) ThingPermissions(id string, token string) (sdk.Thing, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ThingPermissions")
+	}
+
+	var r0 sdk.Thing
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Thing, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Thing); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Thing)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Things provides a mock function with given fields: pm, token
+func (_m *SDK) Things(pm sdk.PageMetadata, token string) (sdk.ThingsPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Things")
+	}
+
+	var r0 sdk.ThingsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.ThingsPage, errors.SDKError)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.ThingsPage); ok {
+		r0 = rf(pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ThingsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ThingsByChannel provides a mock function with given fields: chanID, pm, token
+func (_m *SDK) ThingsByChannel(chanID string, pm sdk.PageMetadata, token string) (sdk.ThingsPage, errors.SDKError) {
+	ret := _m.Called(chanID, pm, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ThingsByChannel")
+	}
+
+	var r0 sdk.ThingsPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.ThingsPage, errors.SDKError)); ok {
+		return rf(chanID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.ThingsPage); ok {
+		r0 = rf(chanID, pm, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ThingsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(chanID, pm, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// Thing provides a mock function with given fields: id, token
-func (_m *SDK) Thing(id string, token string) (sdk.Thing, errors.SDKError) {
-	ret := _m.Called(id, token)
+// UnassignRulesEngineInstance provides a mock function with given fields: userID, token
+func (_m *SDK) UnassignRulesEngineInstance(userID string, token string) (sdk.RulesEngineAssignment, errors.SDKError) {
+	ret := _m.Called(userID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Thing")
+		panic("no return value specified for UnassignRulesEngineInstance")
 	}
 
-	var r0 sdk.Thing
+	var r0 sdk.RulesEngineAssignment
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Thing, errors.SDKError)); ok {
-		return rf(id, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineAssignment, errors.SDKError)); ok {
+		return rf(userID, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Thing); ok {
-		r0 = rf(id, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineAssignment); ok {
+		r0 = rf(userID, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Thing)
+		r0 = ret.Get(0).(sdk.RulesEngineAssignment)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+		r1 = rf(userID, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -2142,27 +4932,27 @@ func (_m *SDK) Thing(id string, token string) (sdk.Thing, errors.SDKError) {
 	return r0, r1
 }
 
-// ThingPermissions provides a mock function with given fields: id, token
-func (_m *SDK) ThingPermissions(id string, token string) (sdk.Thing, errors.SDKError) {
-	ret := _m.Called(id, token)
+// UndeployRule provides a mock function with given fields: id, gateway, token
+func (_m *SDK) UndeployRule(id string, gateway string, token string) (sdk.RuleDeployment, errors.SDKError) {
+	ret := _m.Called(id, gateway, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ThingPermissions")
+		panic("no return value specified for UndeployRule")
 	}
 
-	var r0 sdk.Thing
+	var r0 sdk.RuleDeployment
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.Thing, errors.SDKError)); ok {
-		return rf(id, token)
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.RuleDeployment, errors.SDKError)); ok {
+		return rf(id, gateway, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.Thing); ok {
-		r0 = rf(id, token)
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.RuleDeployment); ok {
+		r0 = rf(id, gateway, token)
 	} else {
-		r0 = ret.Get(0).(sdk.Thing)
+		r0 = ret.Get(0).(sdk.RuleDeployment)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
-		r1 = rf(id, token)
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(id, gateway, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -2172,27 +4962,27 @@ func (_m *SDK) ThingPermissions(id string, token string) (sdk.Thing, errors.SDKE
 	return r0, r1
 }
 
-// Things provides a mock function with given fields: pm, token
-func (_m *SDK) Things(pm sdk.PageMetadata, token string) (sdk.ThingsPage, errors.SDKError) {
-	ret := _m.Called(pm, token)
+// UnpublishRule provides a mock function with given fields: id, token
+func (_m *SDK) UnpublishRule(id string, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Things")
+		panic("no return value specified for UnpublishRule")
 	}
 
-	var r0 sdk.ThingsPage
+	var r0 sdk.RulesEngineResult
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.ThingsPage, errors.SDKError)); ok {
-		return rf(pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(id, token)
 	}
-	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.ThingsPage); ok {
-		r0 = rf(pm, token)
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RulesEngineResult); ok {
+		r0 = rf(id, token)
 	} else {
-		r0 = ret.Get(0).(sdk.ThingsPage)
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(pm, token)
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -2202,34 +4992,44 @@ func (_m *SDK) Things(pm sdk.PageMetadata, token string) (sdk.ThingsPage, errors
 	return r0, r1
 }
 
-// ThingsByChannel provides a mock function with given fields: chanID, pm, token
-func (_m *SDK) ThingsByChannel(chanID string, pm sdk.PageMetadata, token string) (sdk.ThingsPage, errors.SDKError) {
-	ret := _m.Called(chanID, pm, token)
+// UnshareRule provides a mock function with given fields: id, grantee, token
+func (_m *SDK) UnshareRule(id string, grantee string, token string) errors.SDKError {
+	ret := _m.Called(id, grantee, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ThingsByChannel")
+		panic("no return value specified for UnshareRule")
 	}
 
-	var r0 sdk.ThingsPage
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.ThingsPage, errors.SDKError)); ok {
-		return rf(chanID, pm, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.ThingsPage); ok {
-		r0 = rf(chanID, pm, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(id, grantee, token)
 	} else {
-		r0 = ret.Get(0).(sdk.ThingsPage)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(chanID, pm, token)
+	return r0
+}
+
+// UnshareStream provides a mock function with given fields: name, grantee, token
+func (_m *SDK) UnshareStream(name string, grantee string, token string) errors.SDKError {
+	ret := _m.Called(name, grantee, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnshareStream")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(name, grantee, token)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(errors.SDKError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
 		}
 	}
 
-	return r0, r1
+	return r0
 }
 
 // UnshareThing provides a mock function with given fields: thingID, req, token
@@ -2442,6 +5242,66 @@ func (_m *SDK) UpdatePassword(oldPass string, newPass string, token string) (sdk
 	return r0, r1
 }
 
+// UpdateRule provides a mock function with given fields: rule, token
+func (_m *SDK) UpdateRule(rule sdk.Rule, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(rule, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRule")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(rule, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string) sdk.RulesEngineResult); ok {
+		r0 = rf(rule, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Rule, string) errors.SDKError); ok {
+		r1 = rf(rule, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateStream provides a mock function with given fields: stream, token
+func (_m *SDK) UpdateStream(stream sdk.Stream, token string) (sdk.RulesEngineResult, errors.SDKError) {
+	ret := _m.Called(stream, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStream")
+	}
+
+	var r0 sdk.RulesEngineResult
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Stream, string) (sdk.RulesEngineResult, errors.SDKError)); ok {
+		return rf(stream, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Stream, string) sdk.RulesEngineResult); ok {
+		r0 = rf(stream, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesEngineResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Stream, string) errors.SDKError); ok {
+		r1 = rf(stream, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // UpdateThing provides a mock function with given fields: thing, token
 func (_m *SDK) UpdateThing(thing sdk.Thing, token string) (sdk.Thing, errors.SDKError) {
 	ret := _m.Called(thing, token)
@@ -2742,6 +5602,36 @@ func (_m *SDK) Users(pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.S
 	return r0, r1
 }
 
+// ValidateRule provides a mock function with given fields: rule, token
+func (_m *SDK) ValidateRule(rule sdk.Rule, token string) (sdk.RuleValidation, errors.SDKError) {
+	ret := _m.Called(rule, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateRule")
+	}
+
+	var r0 sdk.RuleValidation
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string) (sdk.RuleValidation, errors.SDKError)); ok {
+		return rf(rule, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Rule, string) sdk.RuleValidation); ok {
+		r0 = rf(rule, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleValidation)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Rule, string) errors.SDKError); ok {
+		r1 = rf(rule, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ViewBootstrap provides a mock function with given fields: id, token
 func (_m *SDK) ViewBootstrap(id string, token string) (sdk.BootstrapConfig, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -2832,6 +5722,156 @@ func (_m *SDK) ViewCertByThing(thingID string, token string) (sdk.CertSerials, e
 	return r0, r1
 }
 
+// ViewRollout provides a mock function with given fields: id, token
+func (_m *SDK) ViewRollout(id string, token string) (sdk.RuleRollout, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRollout")
+	}
+
+	var r0 sdk.RuleRollout
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.RuleRollout, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.RuleRollout); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleRollout)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ViewRule provides a mock function with given fields: id, token
+func (_m *SDK) ViewRule(id string, token string) (sdk.Rule, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRule")
+	}
+
+	var r0 sdk.Rule
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Rule, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Rule); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ViewRules provides a mock function with given fields: ids, token
+func (_m *SDK) ViewRules(ids []string, token string) (sdk.RulesBatch, errors.SDKError) {
+	ret := _m.Called(ids, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRules")
+	}
+
+	var r0 sdk.RulesBatch
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func([]string, string) (sdk.RulesBatch, errors.SDKError)); ok {
+		return rf(ids, token)
+	}
+	if rf, ok := ret.Get(0).(func([]string, string) sdk.RulesBatch); ok {
+		r0 = rf(ids, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RulesBatch)
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, string) errors.SDKError); ok {
+		r1 = rf(ids, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ViewStream provides a mock function with given fields: name, token
+func (_m *SDK) ViewStream(name string, token string) (sdk.StreamInfo, errors.SDKError) {
+	ret := _m.Called(name, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewStream")
+	}
+
+	var r0 sdk.StreamInfo
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.StreamInfo, errors.SDKError)); ok {
+		return rf(name, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.StreamInfo); ok {
+		r0 = rf(name, token)
+	} else {
+		r0 = ret.Get(0).(sdk.StreamInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(name, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ViewStreams provides a mock function with given fields: names, token
+func (_m *SDK) ViewStreams(names []string, token string) (sdk.StreamsBatch, errors.SDKError) {
+	ret := _m.Called(names, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewStreams")
+	}
+
+	var r0 sdk.StreamsBatch
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func([]string, string) (sdk.StreamsBatch, errors.SDKError)); ok {
+		return rf(names, token)
+	}
+	if rf, ok := ret.Get(0).(func([]string, string) sdk.StreamsBatch); ok {
+		r0 = rf(names, token)
+	} else {
+		r0 = ret.Get(0).(sdk.StreamsBatch)
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, string) errors.SDKError); ok {
+		r1 = rf(names, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // ViewSubscription provides a mock function with given fields: id, token
 func (_m *SDK) ViewSubscription(id string, token string) (sdk.Subscription, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -2862,6 +5902,36 @@ func (_m *SDK) ViewSubscription(id string, token string) (sdk.Subscription, erro
 	return r0, r1
 }
 
+// ViewTable provides a mock function with given fields: name, token
+func (_m *SDK) ViewTable(name string, token string) (sdk.TableInfo, errors.SDKError) {
+	ret := _m.Called(name, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewTable")
+	}
+
+	var r0 sdk.TableInfo
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.TableInfo, errors.SDKError)); ok {
+		return rf(name, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.TableInfo); ok {
+		r0 = rf(name, token)
+	} else {
+		r0 = ret.Get(0).(sdk.TableInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(name, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
 // Whitelist provides a mock function with given fields: cfg, token
 func (_m *SDK) Whitelist(cfg sdk.BootstrapConfig, token string) errors.SDKError {
 	ret := _m.Called(cfg, token)
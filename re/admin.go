@@ -0,0 +1,137 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// OwnerStreams contains the streams of the owner. Email is left empty if
+// the owner can't be resolved.
+type OwnerStreams struct {
+	Owner   string   `json:"owner"`
+	Email   string   `json:"email,omitempty"`
+	Streams []string `json:"streams"`
+}
+
+// AllStreams contains the streams of all the users, grouped by owner.
+type AllStreams struct {
+	Total  int            `json:"total"`
+	Owners []OwnerStreams `json:"owners"`
+}
+
+// OwnerRules contains the rules of the owner along with the number of the
+// rules in each state. Email is left empty if the owner can't be resolved.
+type OwnerRules struct {
+	Owner  string         `json:"owner"`
+	Email  string         `json:"email,omitempty"`
+	States map[string]int `json:"states"`
+	Rules  []RuleInfo     `json:"rules"`
+}
+
+// AllRules contains the rules of all the users, grouped by owner, along
+// with the number of the rules in each state.
+type AllRules struct {
+	Total  int            `json:"total"`
+	States map[string]int `json:"states"`
+	Owners []OwnerRules   `json:"owners"`
+}
+
+func (svc *reService) ListAllStreams(ctx context.Context, token string) (AllStreams, error) {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return AllStreams{}, err
+	}
+	names, err := svc.engine.ListStreams(ctx, StreamKind)
+	if err != nil {
+		return AllStreams{}, err
+	}
+
+	all := AllStreams{Owners: []OwnerStreams{}}
+	owners := map[string]int{}
+	for _, name := range names {
+		owner, ok := nameOwner(name)
+		if !ok {
+			continue
+		}
+		i, ok := owners[owner]
+		if !ok {
+			i = len(all.Owners)
+			owners[owner] = i
+			all.Owners = append(all.Owners, OwnerStreams{Owner: owner, Email: svc.email(owner, token), Streams: []string{}})
+		}
+		all.Owners[i].Streams = append(all.Owners[i].Streams, strings.TrimPrefix(name, prefix(owner)))
+		all.Total++
+	}
+	for _, o := range all.Owners {
+		sort.Strings(o.Streams)
+	}
+	sort.Slice(all.Owners, func(i, j int) bool {
+		return all.Owners[i].Owner < all.Owners[j].Owner
+	})
+
+	return all, nil
+}
+
+func (svc *reService) ListAllRules(ctx context.Context, token string) (AllRules, error) {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return AllRules{}, err
+	}
+	rules, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return AllRules{}, err
+	}
+
+	all := AllRules{States: map[string]int{}, Owners: []OwnerRules{}}
+	owners := map[string]int{}
+	for _, r := range rules {
+		owner, ok := nameOwner(r.ID)
+		if !ok {
+			continue
+		}
+		i, ok := owners[owner]
+		if !ok {
+			i = len(all.Owners)
+			owners[owner] = i
+			all.Owners = append(all.Owners, OwnerRules{Owner: owner, Email: svc.email(owner, token), States: map[string]int{}, Rules: []RuleInfo{}})
+		}
+		state := ruleState(r.Status)
+		r.ID = strings.TrimPrefix(r.ID, prefix(owner))
+		all.Owners[i].Rules = append(all.Owners[i].Rules, r)
+		all.Owners[i].States[state]++
+		all.States[state]++
+		all.Total++
+	}
+	for _, o := range all.Owners {
+		sort.Slice(o.Rules, func(i, j int) bool {
+			return o.Rules[i].ID < o.Rules[j].ID
+		})
+	}
+	sort.Slice(all.Owners, func(i, j int) bool {
+		return all.Owners[i].Owner < all.Owners[j].Owner
+	})
+
+	return all, nil
+}
+
+// email returns the email of the user with the given ID, looked up using
+// the admin token, or an empty string if the user can't be retrieved.
+func (svc *reService) email(userID, token string) string {
+	user, err := svc.sdk.User(userID, token)
+	if err != nil {
+		svc.forget(token, err)
+		return ""
+	}
+
+	return user.Credentials.Identity
+}
+
+// ruleState returns the lowercase state of the rule with the given Kuiper
+// status, which may be followed by the reason, as in "Stopped: canceled
+// manually.".
+func ruleState(status string) string {
+	state, _, _ := strings.Cut(status, ":")
+	return strings.ToLower(strings.TrimSpace(state))
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const userEmail = "user@example.com"
+
+func TestListAllStreams(t *testing.T) {
+	cases := []struct {
+		desc       string
+		token      string
+		authorized bool
+		failure    string
+		all        re.AllStreams
+		err        error
+	}{
+		{
+			desc:       "list all streams",
+			token:      validToken,
+			authorized: true,
+			all: re.AllStreams{
+				Total: 3,
+				Owners: []re.OwnerStreams{
+					{Owner: otherUserID, Streams: []string{"stream"}},
+					{Owner: userID, Email: userEmail, Streams: []string{"alerts", "stream"}},
+				},
+			},
+		},
+		{
+			desc:  "list all streams with invalid token",
+			token: invalidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "list all streams as non-admin user",
+			token: validToken,
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "list all streams with failed streams lookup",
+			token:      validToken,
+			authorized: true,
+			failure:    "/streams",
+			err:        re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, k, auth, sdk := newService(t)
+		k.streams["demo"] = ""
+		k.streams[userPrefix+"alerts"] = ""
+		if tc.failure != "" {
+			k.failures[tc.failure] = http.StatusInternalServerError
+		}
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+		authCall2 := authorizeAdmin(auth, tc.authorized)
+		sdkCall := sdk.On("User", userID, validToken).Return(mgsdk.User{ID: userID, Credentials: mgsdk.Credentials{Identity: userEmail}}, nil)
+		sdkCall1 := sdk.On("User", otherUserID, validToken).Return(mgsdk.User{}, errors.NewSDKError(svcerr.ErrNotFound))
+
+		all, err := svc.ListAllStreams(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.all, all, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.all, all))
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+		sdkCall.Unset()
+		sdkCall1.Unset()
+	}
+}
+
+func TestListAllRules(t *testing.T) {
+	cases := []struct {
+		desc       string
+		token      string
+		authorized bool
+		failure    string
+		all        re.AllRules
+		err        error
+	}{
+		{
+			desc:       "list all rules",
+			token:      validToken,
+			authorized: true,
+			all: re.AllRules{
+				Total:  3,
+				States: map[string]int{"running": 2, "stopped": 1},
+				Owners: []re.OwnerRules{
+					{
+						Owner:  otherUserID,
+						States: map[string]int{"running": 1},
+						Rules:  []re.RuleInfo{{ID: "rule", Status: "Running"}},
+					},
+					{
+						Owner:  userID,
+						Email:  userEmail,
+						States: map[string]int{"running": 1, "stopped": 1},
+						Rules: []re.RuleInfo{
+							{ID: "alarm", Status: "Stopped: canceled manually."},
+							{ID: "rule", Status: "Running"},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:  "list all rules with invalid token",
+			token: invalidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "list all rules as non-admin user",
+			token: validToken,
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "list all rules with failed rules lookup",
+			token:      validToken,
+			authorized: true,
+			failure:    "/rules",
+			err:        re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, k, auth, sdk := newService(t)
+		k.rules["demo"] = re.Rule{ID: "demo"}
+		k.rules[userPrefix+"alarm"] = re.Rule{ID: userPrefix + "alarm"}
+		k.stopped[userPrefix+"alarm"] = true
+		if tc.failure != "" {
+			k.failures[tc.failure] = http.StatusInternalServerError
+		}
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+		authCall2 := authorizeAdmin(auth, tc.authorized)
+		sdkCall := sdk.On("User", userID, validToken).Return(mgsdk.User{ID: userID, Credentials: mgsdk.Credentials{Identity: userEmail}}, nil)
+		sdkCall1 := sdk.On("User", otherUserID, validToken).Return(mgsdk.User{}, errors.NewSDKError(svcerr.ErrNotFound))
+
+		all, err := svc.ListAllRules(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.all, all, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.all, all))
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+		sdkCall.Unset()
+		sdkCall1.Unset()
+	}
+}
@@ -0,0 +1,426 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+)
+
+// Reasons of the alerts about the failing rules.
+const (
+	AlertError      = "error"
+	AlertExceptions = "exceptions"
+)
+
+// alertsSubtopic is the subtopic prefix of the alerts published for the
+// notifiers, followed by the notification type.
+const alertsSubtopic = "alerts"
+
+// alertsPublisher is the publisher of the alert messages.
+const alertsPublisher = "re"
+
+// webhookTimeout limits the webhook and the alert webhook requests.
+const webhookTimeout = 10 * time.Second
+
+var (
+	errNoAlertTargets  = errors.New("alert policy must contain webhook, email or sms")
+	errWebhook         = errors.New("webhook must be http or https URL")
+	errAlertExceptions = errors.New("exceptions threshold must not be negative")
+	errAlertsDisabled  = errors.New("alert notifications are not configured")
+)
+
+// AlertPolicy defines how the owner is alerted about the failing rules.
+// Rules fail when Kuiper stops them on error, or when their operators raise
+// more than Exceptions exceptions between two checks, zero meaning that the
+// exceptions aren't alerted about. The exceptions of the rule are counted
+// from its first check on. Alerts are posted to the Webhook and sent to the
+// Email and SMS contacts by the notifiers, through the channel of the
+// notification.
+type AlertPolicy struct {
+	Exceptions int64             `json:"exceptions"`
+	Webhook    string            `json:"webhook,omitempty"`
+	Email      *NotificationSink `json:"email,omitempty"`
+	SMS        *NotificationSink `json:"sms,omitempty"`
+}
+
+// Alert reports the failing rule to its owner. Error is the Kuiper error
+// message, either the reason Kuiper stopped the rule or the last exception
+// of its operators. Delivery is why the alert couldn't be delivered.
+type Alert struct {
+	Owner      string    `json:"owner"`
+	Rule       string    `json:"rule"`
+	Reason     string    `json:"reason"`
+	Exceptions int64     `json:"exceptions"`
+	Error      string    `json:"error,omitempty"`
+	Time       time.Time `json:"time"`
+	Delivery   string    `json:"-"`
+}
+
+// AlertRepository specifies the persistence API of the alert policies of
+// the owners.
+type AlertRepository interface {
+	// SaveAlertPolicy stores the owner's policy, replacing the existing one.
+	SaveAlertPolicy(ctx context.Context, owner string, p AlertPolicy) error
+
+	// RetrieveAlertPolicy returns the owner's policy.
+	RetrieveAlertPolicy(ctx context.Context, owner string) (AlertPolicy, error)
+
+	// RetrieveAlertPolicies returns the policies of all the owners mapped
+	// by the owner IDs.
+	RetrieveAlertPolicies(ctx context.Context) (map[string]AlertPolicy, error)
+
+	// RemoveAlertPolicy removes the owner's policy.
+	RemoveAlertPolicy(ctx context.Context, owner string) error
+}
+
+func (svc *reService) SetAlertPolicy(ctx context.Context, token string, p AlertPolicy) (AlertPolicy, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return AlertPolicy{}, err
+	}
+	if err := svc.validateAlertPolicy(ctx, token, p); err != nil {
+		return AlertPolicy{}, err
+	}
+	if err := svc.removeAlertSubscriptions(ctx, token, userID); err != nil {
+		return AlertPolicy{}, err
+	}
+	for typ, n := range p.notifications() {
+		topic := notificationTopic(n.Channel, alertSubtopic(typ))
+		for _, c := range n.Contacts {
+			if _, err := svc.notifiers.notifier(typ).CreateSubscription(topic, c, token); err != nil {
+				return AlertPolicy{}, errors.Wrap(ErrNotifier, err)
+			}
+		}
+	}
+	if err := svc.repo.SaveAlertPolicy(ctx, userID, p); err != nil {
+		return AlertPolicy{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return p, nil
+}
+
+func (svc *reService) ViewAlertPolicy(ctx context.Context, token string) (AlertPolicy, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return AlertPolicy{}, err
+	}
+
+	switch p, err := svc.repo.RetrieveAlertPolicy(ctx, userID); {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return AlertPolicy{}, errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return AlertPolicy{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	default:
+		return p, nil
+	}
+}
+
+func (svc *reService) RemoveAlertPolicy(ctx context.Context, token string) error {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.removeAlertSubscriptions(ctx, token, userID); err != nil {
+		return err
+	}
+
+	switch err := svc.repo.RemoveAlertPolicy(ctx, userID); {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// validateAlertPolicy validates the policy and checks that the user can
+// publish to the channels of its notifications.
+func (svc *reService) validateAlertPolicy(ctx context.Context, token string, p AlertPolicy) error {
+	if p.Exceptions < 0 {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errAlertExceptions)
+	}
+	if p.Webhook == "" && p.Email == nil && p.SMS == nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errNoAlertTargets)
+	}
+	if p.Webhook != "" {
+		if err := svc.webhooks.validateURL(p.Webhook); err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
+	for typ, n := range p.notifications() {
+		if err := n.validate(typ); err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		if svc.notifiers.notifier(typ) == nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(errNotifierDisabled, errors.New(typ)))
+		}
+		if err := svc.authorizeChannel(ctx, token, n.Channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeAlertSubscriptions removes the subscriptions of the contacts of the
+// user's current alert policy, if any.
+func (svc *reService) removeAlertSubscriptions(ctx context.Context, token, userID string) error {
+	p, err := svc.repo.RetrieveAlertPolicy(ctx, userID)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return nil
+	case err != nil:
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for typ, n := range p.notifications() {
+		notifier := svc.notifiers.notifier(typ)
+		if notifier == nil {
+			continue
+		}
+		pm := mgsdk.PageMetadata{Topic: notificationTopic(n.Channel, alertSubtopic(typ)), Limit: maxContacts}
+		page, err := notifier.ListSubscriptions(pm, token)
+		if err != nil {
+			return errors.Wrap(ErrNotifier, err)
+		}
+		for _, sub := range page.Subscriptions {
+			if err := notifier.DeleteSubscription(sub.ID, token); err != nil {
+				return errors.Wrap(ErrNotifier, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// notifications returns the notifications of the policy mapped by type.
+func (p AlertPolicy) notifications() map[string]*NotificationSink {
+	ns := make(map[string]*NotificationSink)
+	if p.Email != nil {
+		ns[EmailSinkType] = p.Email
+	}
+	if p.SMS != nil {
+		ns[SMSSinkType] = p.SMS
+	}
+
+	return ns
+}
+
+// alertSubtopic returns the subtopic the alerts are published to for the
+// notifier of the type.
+func alertSubtopic(typ string) string {
+	return alertsSubtopic + "." + typ
+}
+
+// Monitor checks the rules of the owners with alert policies or webhooks and
+// alerts them about the failing rules, so it's used by the background
+// monitoring job and never exposed over the API.
+type Monitor interface {
+	// Check alerts the owners about the rules that started failing since
+	// the previous check and returns the alerts. Rules are alerted about
+	// once, until they recover. The rules Kuiper stopped on error since the
+	// previous check are reported to the webhooks as errored as well.
+	Check(ctx context.Context) ([]Alert, error)
+}
+
+// ruleHealth is the outcome of the previous check of the rule.
+type ruleHealth struct {
+	exceptions int64
+	failing    bool
+	errored    bool
+}
+
+type monitor struct {
+	svc    *reService
+	pub    messaging.Publisher
+	mu     sync.Mutex
+	health map[string]ruleHealth
+}
+
+// NewMonitor instantiates the monitor running the rules in the given engine.
+// Alerts are published to the notifiers through the publisher, and without
+// the publisher only the webhooks are alerted.
+func NewMonitor(engine RuleEngine, cfg Config, repo Repository, pub messaging.Publisher) Monitor {
+	return &monitor{
+		svc:    newService(engine, cfg, nil, nil, Notifiers{}, nil, repo),
+		pub:    pub,
+		health: make(map[string]ruleHealth),
+	}
+}
+
+func (m *monitor) Check(ctx context.Context) ([]Alert, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	policies, err := m.svc.repo.RetrieveAlertPolicies(ctx)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	all, err := m.svc.engine.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	whs, err := m.svc.repo.RetrieveWebhooks(ctx, "")
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	owners := make(map[string]string, len(policies))
+	for owner := range policies {
+		owners[prefix(owner)] = owner
+	}
+	for _, wh := range whs {
+		if wh.subscribed(EventRuleErrored) {
+			owners[prefix(wh.Owner)] = wh.Owner
+		}
+	}
+	var rules []RuleInfo
+	for _, r := range all {
+		if _, ok := owners[ownerPrefix(r.ID)]; ok {
+			rules = append(rules, r)
+		}
+	}
+
+	checked := make([]*Alert, len(rules))
+	health := make([]*ruleHealth, len(rules))
+	m.svc.bulk(RuleKind, len(rules), func(i int) (string, error) {
+		status, err := m.svc.engine.RuleStatus(ctx, rules[i].ID)
+		if err != nil {
+			return rules[i].ID, err
+		}
+		owner := owners[ownerPrefix(rules[i].ID)]
+		prev, seen := m.health[rules[i].ID]
+		c := status.counters()
+		h := ruleHealth{exceptions: c.exceptions}
+		a := Alert{
+			Owner:      owner,
+			Rule:       strings.TrimPrefix(rules[i].ID, prefix(owner)),
+			Exceptions: delta(c.exceptions, prev.exceptions),
+			Time:       time.Now().UTC(),
+		}
+		switch threshold := policies[owner].Exceptions; {
+		case statsState(rules[i].Status) == StatsError:
+			a.Reason = AlertError
+			_, a.Error, _ = strings.Cut(rules[i].Status, ":")
+			a.Error = strings.TrimSpace(a.Error)
+		case seen && threshold > 0 && a.Exceptions > threshold:
+			a.Reason = AlertExceptions
+			a.Error = status.lastException()
+		}
+		h.failing = a.Reason != ""
+		h.errored = a.Reason == AlertError
+		health[i] = &h
+		if h.errored && !prev.errored {
+			m.svc.emit(ctx, "", owner, EventRuleErrored, a.Rule, a.Error)
+		}
+		// The owners with webhooks only aren't alerted.
+		if _, ok := policies[owner]; ok && h.failing && !prev.failing {
+			checked[i] = &a
+		}
+		return rules[i].ID, nil
+	})
+
+	// Rules whose status couldn't be read keep their previous health, and
+	// the removed rules are forgotten.
+	current := make(map[string]ruleHealth, len(rules))
+	alerts := []Alert{}
+	for i, r := range rules {
+		h, ok := m.health[r.ID]
+		if health[i] != nil {
+			h, ok = *health[i], true
+		}
+		if ok {
+			current[r.ID] = h
+		}
+		if a := checked[i]; a != nil {
+			if err := m.deliver(ctx, policies[a.Owner], *a); err != nil {
+				a.Delivery = err.Error()
+			}
+			alerts = append(alerts, *a)
+		}
+	}
+	m.health = current
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Owner != alerts[j].Owner {
+			return alerts[i].Owner < alerts[j].Owner
+		}
+		return alerts[i].Rule < alerts[j].Rule
+	})
+
+	return alerts, nil
+}
+
+// deliver posts the alert to the policy webhook and publishes it to the
+// channels of the policy notifications.
+func (m *monitor) deliver(ctx context.Context, p AlertPolicy, a Alert) error {
+	payload, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if p.Webhook != "" {
+		if err := m.post(ctx, p.Webhook, payload); err != nil {
+			return err
+		}
+	}
+	for typ, n := range p.notifications() {
+		if m.pub == nil {
+			return errAlertsDisabled
+		}
+		msg := &messaging.Message{
+			Channel:   n.Channel,
+			Subtopic:  alertSubtopic(typ),
+			Publisher: alertsPublisher,
+			Payload:   payload,
+			Created:   time.Now().UnixNano(),
+		}
+		if err := m.pub.Publish(ctx, n.Channel, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *monitor) post(ctx context.Context, webhook string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := m.svc.webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// lastException returns the last exception of the rule operators, if any.
+func (rs RuleStatus) lastException() string {
+	var last, at string
+	for _, op := range rs.Operators {
+		if op.LastException != "" && op.LastExceptionTime >= at {
+			last, at = op.LastException, op.LastExceptionTime
+		}
+	}
+
+	return last
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetAlertPolicy(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc   string
+		policy re.AlertPolicy
+		err    error
+	}{
+		{
+			desc:   "set policy with webhook",
+			policy: re.AlertPolicy{Exceptions: 10, Webhook: "https://example.com/alerts"},
+		},
+		{
+			desc:   "set policy without targets",
+			policy: re.AlertPolicy{Exceptions: 10},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "set policy with negative exceptions",
+			policy: re.AlertPolicy{Exceptions: -1, Webhook: "https://example.com/alerts"},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "set policy with invalid webhook",
+			policy: re.AlertPolicy{Webhook: "ftp://example.com/alerts"},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "set policy with disabled notifier",
+			policy: re.AlertPolicy{Email: &re.NotificationSink{Channel: channelID, Contacts: []string{"ops@example.com"}}},
+			err:    svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		p, err := svc.SetAlertPolicy(context.Background(), validToken, tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.policy, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policy, p))
+		}
+	}
+
+	// The invalid policies don't replace the valid one.
+	p, err := svc.ViewAlertPolicy(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("view policy: expected no error got %s\n", err))
+	assert.Equal(t, cases[0].policy, p, fmt.Sprintf("view policy: expected %v got %v\n", cases[0].policy, p))
+
+	err = svc.RemoveAlertPolicy(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("remove policy: expected no error got %s\n", err))
+	_, err = svc.ViewAlertPolicy(context.Background(), validToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("view removed policy: expected %s got %s\n", svcerr.ErrNotFound, err))
+	err = svc.RemoveAlertPolicy(context.Background(), validToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("remove removed policy: expected %s got %s\n", svcerr.ErrNotFound, err))
+}
+
+func TestMonitorCheck(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		posted []re.Alert
+	)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var a re.Alert
+		_ = json.NewDecoder(r.Body).Decode(&a)
+		mu.Lock()
+		posted = append(posted, a)
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+
+	k, url := newKuiper(t)
+	k.failed[otherPrefix+"rule"] = "connection refused."
+	repo := mocks.NewRepository()
+	err := repo.SaveAlertPolicy(context.Background(), userID, re.AlertPolicy{Webhook: webhook.URL})
+	assert.Nil(t, err, fmt.Sprintf("save policy: expected no error got %s\n", err))
+	cfg := re.Config{URL: url, Webhooks: re.WebhooksConfig{AllowPrivate: true}}
+	m := re.NewMonitor(re.NewKuiper(cfg), cfg, repo, nil)
+
+	// The other user's rule fails, but the user has no policy.
+	alerts, err := m.Check(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("first check: expected no error got %s\n", err))
+	assert.Empty(t, alerts, fmt.Sprintf("first check: expected no alerts got %v\n", alerts))
+
+	k.failed[userPrefix+"rule"] = "connection refused."
+	alerts, err = m.Check(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("failing rule check: expected no error got %s\n", err))
+	if assert.Len(t, alerts, 1, fmt.Sprintf("failing rule check: expected single alert got %v\n", alerts)) {
+		assert.Equal(t, userID, alerts[0].Owner, fmt.Sprintf("expected owner %s got %s\n", userID, alerts[0].Owner))
+		assert.Equal(t, "rule", alerts[0].Rule, fmt.Sprintf("expected rule %s got %s\n", "rule", alerts[0].Rule))
+		assert.Equal(t, re.AlertError, alerts[0].Reason, fmt.Sprintf("expected reason %s got %s\n", re.AlertError, alerts[0].Reason))
+		assert.Equal(t, "connection refused.", alerts[0].Error, fmt.Sprintf("expected error %s got %s\n", "connection refused.", alerts[0].Error))
+		assert.Empty(t, alerts[0].Delivery, fmt.Sprintf("expected delivered alert got %s\n", alerts[0].Delivery))
+	}
+	mu.Lock()
+	assert.Len(t, posted, 1, fmt.Sprintf("expected alert posted to webhook got %v\n", posted))
+	mu.Unlock()
+
+	// The rule is alerted about once, until it recovers.
+	alerts, err = m.Check(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("still failing rule check: expected no error got %s\n", err))
+	assert.Empty(t, alerts, fmt.Sprintf("still failing rule check: expected no alerts got %v\n", alerts))
+	delete(k.failed, userPrefix+"rule")
+	alerts, err = m.Check(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("recovered rule check: expected no error got %s\n", err))
+	assert.Empty(t, alerts, fmt.Sprintf("recovered rule check: expected no alerts got %v\n", alerts))
+	k.failed[userPrefix+"rule"] = "connection refused."
+	alerts, err = m.Check(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("failing again rule check: expected no error got %s\n", err))
+	assert.Len(t, alerts, 1, fmt.Sprintf("failing again rule check: expected single alert got %v\n", alerts))
+}
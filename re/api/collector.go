@@ -0,0 +1,74 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"sync"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ prometheus.Collector = (*RuleCollector)(nil)
+
+// RuleCollector exposes the latest scraped rule metrics on /metrics,
+// labeled by the owner and the rule. The records and the exceptions are
+// exposed as counters, which restart from zero along with the rule.
+type RuleCollector struct {
+	mu         sync.Mutex
+	rules      []re.RuleMetrics
+	running    *prometheus.Desc
+	recordsIn  *prometheus.Desc
+	recordsOut *prometheus.Desc
+	exceptions *prometheus.Desc
+	latency    *prometheus.Desc
+}
+
+// NewRuleCollector instantiates the collector of the rule metrics.
+func NewRuleCollector(namespace string) *RuleCollector {
+	labels := []string{"owner", "rule"}
+	name := func(metric string) string {
+		return prometheus.BuildFQName(namespace, "rule", metric)
+	}
+
+	return &RuleCollector{
+		running:    prometheus.NewDesc(name("running"), "Whether the rule is running.", labels, nil),
+		recordsIn:  prometheus.NewDesc(name("records_in_total"), "Records the rule sources read.", labels, nil),
+		recordsOut: prometheus.NewDesc(name("records_out_total"), "Records the rule sinks sent out.", labels, nil),
+		exceptions: prometheus.NewDesc(name("exceptions_total"), "Exceptions of the rule operators.", labels, nil),
+		latency:    prometheus.NewDesc(name("process_latency_microseconds"), "Time a record takes through the rule.", labels, nil),
+	}
+}
+
+// Update replaces the exposed metrics with the scraped ones, so the metrics
+// of the removed rules are no longer exposed.
+func (c *RuleCollector) Update(rules []re.RuleMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rules = rules
+}
+
+func (c *RuleCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.running
+	ch <- c.recordsIn
+	ch <- c.recordsOut
+	ch <- c.exceptions
+	ch <- c.latency
+}
+
+func (c *RuleCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.rules {
+		var running float64
+		if r.Running {
+			running = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.running, prometheus.GaugeValue, running, r.Owner, r.Rule)
+		ch <- prometheus.MustNewConstMetric(c.recordsIn, prometheus.CounterValue, float64(r.RecordsIn), r.Owner, r.Rule)
+		ch <- prometheus.MustNewConstMetric(c.recordsOut, prometheus.CounterValue, float64(r.RecordsOut), r.Owner, r.Rule)
+		ch <- prometheus.MustNewConstMetric(c.exceptions, prometheus.CounterValue, float64(r.Exceptions), r.Owner, r.Rule)
+		ch <- prometheus.MustNewConstMetric(c.latency, prometheus.GaugeValue, float64(r.LatencyUs), r.Owner, r.Rule)
+	}
+}
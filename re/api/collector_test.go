@@ -0,0 +1,40 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/api"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleCollector(t *testing.T) {
+	c := api.NewRuleCollector("re")
+	c.Update([]re.RuleMetrics{
+		{Owner: "user", Rule: "alarm", Running: true, RecordsIn: 10, RecordsOut: 8, Exceptions: 1, LatencyUs: 150},
+		{Owner: "user", Rule: "stopped"},
+	})
+
+	expected := `
+# HELP re_rule_records_in_total Records the rule sources read.
+# TYPE re_rule_records_in_total counter
+re_rule_records_in_total{owner="user",rule="alarm"} 10
+re_rule_records_in_total{owner="user",rule="stopped"} 0
+# HELP re_rule_running Whether the rule is running.
+# TYPE re_rule_running gauge
+re_rule_running{owner="user",rule="alarm"} 1
+re_rule_running{owner="user",rule="stopped"} 0
+`
+	err := testutil.CollectAndCompare(c, strings.NewReader(expected), "re_rule_records_in_total", "re_rule_running")
+	assert.Nil(t, err, fmt.Sprintf("unexpected rule metrics: %s", err))
+
+	// The metrics of the removed rules are no longer exposed.
+	c.Update([]re.RuleMetrics{{Owner: "user", Rule: "alarm", Running: true}})
+	assert.Equal(t, 5, testutil.CollectAndCount(c), "expected metrics of the remaining rule")
+}
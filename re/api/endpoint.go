@@ -0,0 +1,1540 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/absmach/magistrala/internal/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/re"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func infoEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, _ interface{}) (interface{}, error) {
+		info, err := svc.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return infoRes{Info: info}, nil
+	}
+}
+
+// readyEndpoint probes Kuiper and reports the service as ready only if
+// Kuiper is reachable. Probe failures are part of the response rather than
+// endpoint errors, so the readiness response is the same for all failures.
+func readyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, _ interface{}) (interface{}, error) {
+		info, err := svc.Info(ctx)
+		switch {
+		case err != nil:
+			return readyRes{Status: statusFail, Kuiper: kuiperStatus{Error: err.Error()}}, nil
+		case info.Version == "":
+			// Info reports only the breaker state while the breaker is open.
+			return readyRes{Status: statusFail, Kuiper: kuiperStatus{Breaker: info.Breaker, Error: re.ErrKuiperUnavailable.Error()}}, nil
+		}
+
+		kuiper := kuiperStatus{
+			Reachable: true,
+			Version:   info.Version,
+			Breaker:   info.Breaker,
+		}
+
+		return readyRes{Status: statusPass, Kuiper: kuiper}, nil
+	}
+}
+
+func createStreamEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(streamReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.CreateStream(ctx, req.token, req.StreamDef, req.update)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res, created: !req.update && res.Status != http.StatusOK}, nil
+	}
+}
+
+func listStreamsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListStreams(ctx, req.token, req.PageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return listStreamsRes{StreamsPage: page}, nil
+	}
+}
+
+func viewStreamEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		stream, err := svc.ViewStream(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewStreamRes{Stream: stream}, nil
+	}
+}
+
+func viewStreamsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewStreamsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		batch, err := svc.ViewStreams(ctx, req.token, req.Names)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewStreamsRes{StreamsBatch: batch}, nil
+	}
+}
+
+func deleteStreamEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteStreamReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.DeleteStream(ctx, req.token, req.id, req.cascade)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
+
+func createTableEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(tableReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.CreateTable(ctx, req.token, req.TableDef)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res, created: res.Status != http.StatusOK}, nil
+	}
+}
+
+func listTablesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListTables(ctx, req.token, req.PageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return listTablesRes{TablesPage: page}, nil
+	}
+}
+
+func viewTableEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		table, err := svc.ViewTable(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewTableRes{Table: table}, nil
+	}
+}
+
+func deleteTableEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.DeleteTable(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
+
+func createRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.CreateRule(ctx, req.token, req.Rule)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res, created: res.Status != http.StatusOK}, nil
+	}
+}
+
+func updateRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.UpdateRule(ctx, req.token, req.Rule)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
+
+func saveDraftEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.SaveDraft(ctx, req.token, req.Rule)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res, created: res.Status == http.StatusCreated}, nil
+	}
+}
+
+func patchRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(patchRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.PatchRule(ctx, req.token, req.id, req.RulePatch)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
+
+func validateRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(validateRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.ValidateRule(ctx, req.token, req.Rule)
+		if err != nil {
+			return nil, err
+		}
+
+		return validateRuleRes{RuleValidation: res}, nil
+	}
+}
+
+func testRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(testRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.TestRule(ctx, req.token, req.RuleTrial)
+		if err != nil {
+			return nil, err
+		}
+
+		return trialRes{TrialResult: res}, nil
+	}
+}
+
+func listRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListRules(ctx, req.token, req.PageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return listRulesRes{RulesPage: page}, nil
+	}
+}
+
+func searchRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(searchRulesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.SearchRules(ctx, req.token, req.RuleQuery, req.PageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return listRulesRes{RulesPage: page}, nil
+	}
+}
+
+func replayRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(replayRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.ReplayRule(ctx, req.token, req.id, req.From, req.To)
+		if err != nil {
+			return nil, err
+		}
+
+		return replayRes{ReplayResult: res}, nil
+	}
+}
+
+func pushTailEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(pushTailReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.PushTail(ctx, req.session, req.result); err != nil {
+			return nil, err
+		}
+
+		return pushTailRes{}, nil
+	}
+}
+
+func ingestEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ingestReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.Ingest(ctx, req.key, req.stream, req.payload); err != nil {
+			return nil, err
+		}
+
+		return ingestRes{}, nil
+	}
+}
+
+func viewRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		rule, err := svc.ViewRule(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewRuleRes{Rule: rule}, nil
+	}
+}
+
+func viewRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewRulesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		batch, err := svc.ViewRules(ctx, req.token, req.IDs)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewRulesRes{RulesBatch: batch}, nil
+	}
+}
+
+func moveRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveRulesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.MoveRules(ctx, req.token, req.Folder, req.IDs)
+		if err != nil {
+			return nil, err
+		}
+
+		return bulkRes{BulkReport: report}, nil
+	}
+}
+
+func pauseEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.Pause(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return bulkRes{BulkReport: report}, nil
+	}
+}
+
+func resumeEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.Resume(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return bulkRes{BulkReport: report}, nil
+	}
+}
+
+func viewMaintenanceEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		m, err := svc.ViewMaintenance(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return maintenanceRes{Maintenance: m}, nil
+	}
+}
+
+func deleteRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return ruleCommandEndpoint(svc.DeleteRule)
+}
+
+func startRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return ruleCommandEndpoint(svc.StartRule)
+}
+
+func stopRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return ruleCommandEndpoint(svc.StopRule)
+}
+
+func restartRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return ruleCommandEndpoint(svc.RestartRule)
+}
+
+func publishRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return ruleCommandEndpoint(svc.PublishRule)
+}
+
+func unpublishRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return ruleCommandEndpoint(svc.UnpublishRule)
+}
+
+func restoreRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return ruleCommandEndpoint(svc.RestoreRule)
+}
+
+func ruleStatusEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		status, err := svc.RuleStatus(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return ruleStatusRes{RuleStatus: status}, nil
+	}
+}
+
+func ruleTopologyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		topo, err := svc.RuleTopology(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return ruleTopologyRes{RuleTopology: topo}, nil
+	}
+}
+
+func ruleLogsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleLogsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		logs, err := svc.RuleLogs(ctx, req.token, req.id, req.since)
+		if err != nil {
+			return nil, err
+		}
+
+		return ruleLogsRes{Logs: logs}, nil
+	}
+}
+
+func reconcileEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reconcileReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.Reconcile(ctx, req.token, req.repair)
+		if err != nil {
+			return nil, err
+		}
+
+		return driftRes{DriftReport: report}, nil
+	}
+}
+
+func collectOrphansEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(collectOrphansReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.CollectOrphans(ctx, req.token, req.policy)
+		if err != nil {
+			return nil, err
+		}
+
+		return orphansRes{OrphanReport: report}, nil
+	}
+}
+
+func restoreEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(restoreReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.Restore(ctx, req.token, req.dryRun)
+		if err != nil {
+			return nil, err
+		}
+
+		return restoreRes{RestoreReport: report}, nil
+	}
+}
+
+// ruleCommandEndpoint creates an endpoint for the service method that
+// performs an action over the rule with the given ID.
+func ruleCommandEndpoint(command func(ctx context.Context, token, id string) (re.Result, error)) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := command(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
+
+func exportRulesetEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportRulesetReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		rs, err := svc.ExportRuleset(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return rulesetRes{Ruleset: rs}, nil
+	}
+}
+
+func importRulesetEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(importRulesetReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.ImportRuleset(ctx, req.token, req.Ruleset, req.conflict)
+		if err != nil {
+			return nil, err
+		}
+
+		return importRes{ImportReport: report}, nil
+	}
+}
+
+func bulkCreateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(bulkCreateReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.BulkCreate(ctx, req.token, req.Ruleset)
+		if err != nil {
+			return nil, err
+		}
+
+		return bulkRes{BulkReport: report}, nil
+	}
+}
+
+func bulkDeleteEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(bulkDeleteReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.BulkDelete(ctx, req.token, req.BulkDeletion)
+		if err != nil {
+			return nil, err
+		}
+
+		return bulkRes{BulkReport: report}, nil
+	}
+}
+
+func startRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleSelectionReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.StartRules(ctx, req.token, req.RuleSelection)
+		if err != nil {
+			return nil, err
+		}
+
+		return bulkRes{BulkReport: report}, nil
+	}
+}
+
+func stopRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleSelectionReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		report, err := svc.StopRules(ctx, req.token, req.RuleSelection)
+		if err != nil {
+			return nil, err
+		}
+
+		return bulkRes{BulkReport: report}, nil
+	}
+}
+
+func listAllStreamsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		all, err := svc.ListAllStreams(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return allStreamsRes{AllStreams: all}, nil
+	}
+}
+
+func listAllRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		all, err := svc.ListAllRules(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return allRulesRes{AllRules: all}, nil
+	}
+}
+
+func setAlertPolicyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(alertPolicyReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		p, err := svc.SetAlertPolicy(ctx, req.token, req.AlertPolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		return alertPolicyRes{AlertPolicy: p}, nil
+	}
+}
+
+func viewAlertPolicyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		p, err := svc.ViewAlertPolicy(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return alertPolicyRes{AlertPolicy: p}, nil
+	}
+}
+
+func removeAlertPolicyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveAlertPolicy(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return removeAlertPolicyRes{}, nil
+	}
+}
+
+func createWebhookEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		wh, err := svc.CreateWebhook(ctx, req.token, req.Webhook)
+		if err != nil {
+			return nil, err
+		}
+
+		return webhookRes{Webhook: wh}, nil
+	}
+}
+
+func listWebhooksEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		whs, err := svc.ListWebhooks(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listWebhooksRes{Webhooks: whs}, nil
+	}
+}
+
+func removeWebhookEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveWebhook(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeWebhookRes{}, nil
+	}
+}
+
+func createFolderEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(folderReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		f, err := svc.CreateFolder(ctx, req.token, req.Folder)
+		if err != nil {
+			return nil, err
+		}
+
+		return folderRes{Folder: f}, nil
+	}
+}
+
+func listFoldersEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		folders, err := svc.ListFolders(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listFoldersRes{Folders: folders}, nil
+	}
+}
+
+func removeFolderEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveFolder(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeFolderRes{}, nil
+	}
+}
+
+func createChainEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(chainReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		c, err := svc.CreateChain(ctx, req.token, req.Chain)
+		if err != nil {
+			return nil, err
+		}
+
+		return chainRes{Chain: c}, nil
+	}
+}
+
+func listChainsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		chains, err := svc.ListChains(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listChainsRes{Chains: chains}, nil
+	}
+}
+
+func removeChainEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveChain(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeChainRes{}, nil
+	}
+}
+
+func saveSecretEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(secretReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		s, err := svc.SaveSecret(ctx, req.token, req.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return secretRes{Secret: s}, nil
+	}
+}
+
+func listSecretsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		secrets, err := svc.ListSecrets(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listSecretsRes{Secrets: secrets}, nil
+	}
+}
+
+func removeSecretEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveSecret(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeSecretRes{}, nil
+	}
+}
+
+func viewQuotaEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		q, err := svc.ViewQuota(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return quotaRes{UserQuota: q}, nil
+	}
+}
+
+func setQuotaEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(quotaReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		q, err := svc.SetQuota(ctx, req.token, req.userID, req.Quota)
+		if err != nil {
+			return nil, err
+		}
+
+		return quotaRes{UserQuota: q}, nil
+	}
+}
+
+func shareEntityEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		s, err := svc.ShareEntity(ctx, req.token, req.kind, req.name, req.Share)
+		if err != nil {
+			return nil, err
+		}
+
+		return shareRes{Share: s}, nil
+	}
+}
+
+func listSharesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listSharesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		shares, err := svc.ListShares(ctx, req.token, req.kind, req.name)
+		if err != nil {
+			return nil, err
+		}
+
+		return listSharesRes{Shares: shares}, nil
+	}
+}
+
+func unshareEntityEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(unshareReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.UnshareEntity(ctx, req.token, req.kind, req.name, req.grantee); err != nil {
+			return nil, err
+		}
+
+		return unshareRes{}, nil
+	}
+}
+
+func cloneRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(cloneRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.CloneRule(ctx, req.token, req.id, req.NewID, req.Channel)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res, created: true}, nil
+	}
+}
+
+func renameEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(renameReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.Rename(ctx, req.token, req.kind, req.name, req.NewName)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
+
+func listAuditEventsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(auditReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		page, err := svc.ListAuditEvents(ctx, req.token, req.AuditQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		return auditPageRes{AuditPage: page}, nil
+	}
+}
+
+func removeQuotaEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveQuota(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeQuotaRes{}, nil
+	}
+}
+
+func listInstancesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		instances, err := svc.ListInstances(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listInstancesRes{Instances: instances}, nil
+	}
+}
+
+func assignInstanceEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(assignInstanceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		a, err := svc.AssignInstance(ctx, req.token, req.userID, req.Instance)
+		if err != nil {
+			return nil, err
+		}
+
+		return assignmentRes{Assignment: a}, nil
+	}
+}
+
+func saveGatewayEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(gatewayReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		gw, err := svc.SaveGateway(ctx, req.token, req.Gateway)
+		if err != nil {
+			return nil, err
+		}
+
+		return gatewayRes{Gateway: gw}, nil
+	}
+}
+
+func listGatewaysEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		gws, err := svc.ListGateways(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listGatewaysRes{Gateways: gws}, nil
+	}
+}
+
+func removeGatewayEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveGateway(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeGatewayRes{}, nil
+	}
+}
+
+func deployRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deployReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		d, err := svc.DeployRule(ctx, req.token, req.id, req.gateway)
+		if err != nil {
+			return nil, err
+		}
+
+		return deploymentRes{Deployment: d}, nil
+	}
+}
+
+func undeployRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deployReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		d, err := svc.UndeployRule(ctx, req.token, req.id, req.gateway)
+		if err != nil {
+			return nil, err
+		}
+
+		return deploymentRes{Deployment: d}, nil
+	}
+}
+
+func listDeploymentsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		ds, err := svc.ListDeployments(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return listDeploymentsRes{Deployments: ds}, nil
+	}
+}
+
+func deployFleetEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deployFleetReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		r, err := svc.DeployFleet(ctx, req.token, req.id, req.Labels)
+		if err != nil {
+			return nil, err
+		}
+
+		return rolloutRes{Rollout: r, accepted: true}, nil
+	}
+}
+
+func retryDeploymentsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		r, err := svc.RetryDeployments(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return rolloutRes{Rollout: r, accepted: true}, nil
+	}
+}
+
+func viewRolloutEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		r, err := svc.ViewRollout(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return rolloutRes{Rollout: r}, nil
+	}
+}
+
+func engineStatsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		stats, err := svc.EngineStats(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return engineStatsRes{EngineStats: stats}, nil
+	}
+}
+
+func createTemplateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(templateReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		tmpl, err := svc.CreateTemplate(ctx, req.token, req.Template)
+		if err != nil {
+			return nil, err
+		}
+
+		return templateRes{Template: tmpl, created: true}, nil
+	}
+}
+
+func viewTemplateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		tmpl, err := svc.ViewTemplate(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return templateRes{Template: tmpl}, nil
+	}
+}
+
+func listTemplatesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listTemplatesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		tmpls, err := svc.ListTemplates(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listTemplatesRes{Templates: tmpls}, nil
+	}
+}
+
+func removeTemplateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := svc.RemoveTemplate(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeTemplateRes{}, nil
+	}
+}
+
+func instantiateTemplateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(instantiateReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		rule, err := svc.InstantiateTemplate(ctx, req.token, req.name, req.TemplateInstance)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewRuleRes{Rule: rule, created: true}, nil
+	}
+}
+
+func createPluginEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(pluginReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.CreatePlugin(ctx, req.token, req.kind, req.Plugin)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res, created: true}, nil
+	}
+}
+
+func listPluginsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listPluginsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		plugins, err := svc.ListPlugins(ctx, req.token, req.kind)
+		if err != nil {
+			return nil, err
+		}
+
+		return listPluginsRes{Plugins: plugins}, nil
+	}
+}
+
+func deletePluginEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deletePluginReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.DeletePlugin(ctx, req.token, req.kind, req.name)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
+
+func registerExternalServiceEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(externalServiceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.RegisterExternalService(ctx, req.token, req.ExternalService)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res, created: true}, nil
+	}
+}
+
+func listExternalServicesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listExternalServicesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		services, err := svc.ListExternalServices(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listExternalServicesRes{Services: services}, nil
+	}
+}
+
+func deleteExternalServiceEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteExternalServiceReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.DeleteExternalService(ctx, req.token, req.name)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
+
+func listExternalFunctionsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listExternalFunctionsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		funcs, err := svc.ListExternalFunctions(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listExternalFunctionsRes{Functions: funcs}, nil
+	}
+}
+
+func saveConfKeyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(confKeyReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.SaveConfKey(ctx, req.token, req.name, req.MQTTConf)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
+
+func listConfKeysEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listConfKeysReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		keys, err := svc.ListConfKeys(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return listConfKeysRes{ConfKeys: keys}, nil
+	}
+}
+
+func deleteConfKeyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteConfKeyReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		res, err := svc.DeleteConfKey(ctx, req.token, req.name)
+		if err != nil {
+			return nil, err
+		}
+
+		return resultRes{Result: res}, nil
+	}
+}
@@ -0,0 +1,4132 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/apiutil"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/api"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	instanceID  = "5de9b29a-feb9-11ed-be56-0242ac120002"
+	validToken  = "valid"
+	contentType = "application/json"
+	channelID   = "b1c2d3e4-f5a6-4b7c-8d9e-0f1a2b3c4d5e"
+)
+
+var (
+	stream = fmt.Sprintf(`{"name": "temperature", "topic": "%s", "fields": [{"name": "v", "type": "float"}]}`, channelID)
+	table  = `{"name": "devices", "topic": "devices.json", "fields": [{"name": "id", "type": "string"}]}`
+	rule   = fmt.Sprintf(`{"id": "alarm", "sql": "SELECT * FROM temperature", "actions": [{"mainflux": {"channel": "%s"}}]}`, channelID)
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	token       string
+	contentType string
+	ifMatch     string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	if tr.ifMatch != "" {
+		req.Header.Set("If-Match", tr.ifMatch)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newREServer() (*httptest.Server, *mocks.Service) {
+	svc := new(mocks.Service)
+	mux := api.MakeHandler(svc, re.Config{}, mglog.NewMock(), instanceID)
+
+	return httptest.NewServer(mux), svc
+}
+
+func newServer(kuiperURL string) *httptest.Server {
+	cfg := re.Config{URL: kuiperURL}
+	svc := re.New(cfg, nil, nil, re.Notifiers{}, nil, mocks.NewRepository())
+	return httptest.NewServer(api.MakeHandler(svc, cfg, mglog.NewMock(), instanceID))
+}
+
+func TestReady(t *testing.T) {
+	kuiper := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(re.Info{Version: "1.10.0"})
+	}))
+	defer kuiper.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	cases := []struct {
+		desc      string
+		kuiperURL string
+		status    int
+		reachable bool
+		version   string
+	}{
+		{
+			desc:      "ready with reachable Kuiper",
+			kuiperURL: kuiper.URL,
+			status:    http.StatusOK,
+			reachable: true,
+			version:   "1.10.0",
+		},
+		{
+			desc:      "not ready with unreachable Kuiper",
+			kuiperURL: down.URL,
+			status:    http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newServer(tc.kuiperURL)
+		res, err := http.Get(ts.URL + "/ready")
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var body struct {
+			Kuiper struct {
+				Reachable bool   `json:"reachable"`
+				Version   string `json:"version"`
+			} `json:"kuiper"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.reachable, body.Kuiper.Reachable, fmt.Sprintf("%s: expected reachable %t got %t", tc.desc, tc.reachable, body.Kuiper.Reachable))
+		assert.Equal(t, tc.version, body.Kuiper.Version, fmt.Sprintf("%s: expected version %s got %s", tc.desc, tc.version, body.Kuiper.Version))
+		res.Body.Close()
+		ts.Close()
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	var kuiperID string
+	kuiper := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kuiperID = r.Header.Get(re.RequestIDHeader)
+		_ = json.NewEncoder(w).Encode(re.Info{Version: "1.10.0"})
+	}))
+	defer kuiper.Close()
+	ts := newServer(kuiper.URL)
+	defer ts.Close()
+
+	cases := []struct {
+		desc string
+		id   string
+	}{
+		{
+			desc: "view info with request ID",
+			id:   "0b6e6b4c-request",
+		},
+		{
+			desc: "view info without request ID",
+		},
+		{
+			desc: "view info with too long request ID",
+			id:   strings.Repeat("a", 129),
+		},
+	}
+
+	for _, tc := range cases {
+		kuiperID = ""
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/info", nil)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.id != "" {
+			req.Header.Set(re.RequestIDHeader, tc.id)
+		}
+		res, err := ts.Client().Do(req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		res.Body.Close()
+
+		id := res.Header.Get(re.RequestIDHeader)
+		assert.NotEmpty(t, id, fmt.Sprintf("%s: expected request ID in response", tc.desc))
+		if len(tc.id) <= 128 && tc.id != "" {
+			assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected request ID %s got %s", tc.desc, tc.id, id))
+		} else {
+			assert.NotEqual(t, tc.id, id, fmt.Sprintf("%s: expected generated request ID", tc.desc))
+		}
+		assert.Equal(t, id, kuiperID, fmt.Sprintf("%s: expected Kuiper request ID %s got %s", tc.desc, id, kuiperID))
+	}
+}
+
+func TestCreateStream(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "create stream",
+			token:       validToken,
+			data:        stream,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create stream with invalid content type",
+			token:       validToken,
+			data:        stream,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create stream with malformed body",
+			token:       validToken,
+			data:        "{",
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create stream without fields",
+			token:       validToken,
+			data:        fmt.Sprintf(`{"name": "temperature", "topic": "%s"}`, channelID),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create stream without token",
+			data:        stream,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create stream rejected by the service",
+			token:       validToken,
+			data:        stream,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "create existing stream",
+			token:       validToken,
+			data:        stream,
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+		},
+		{
+			desc:        "create stream with Kuiper failure",
+			token:       validToken,
+			data:        stream,
+			contentType: contentType,
+			status:      http.StatusInternalServerError,
+			svcErr:      re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CreateStream", mock.Anything, tc.token, mock.Anything, false).Return(re.Result{Name: "temperature"}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/streams",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestUpdateStream(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	named := mock.MatchedBy(func(def re.StreamDef) bool {
+		return def.Name == "humidity"
+	})
+	svc.On("CreateStream", mock.Anything, validToken, named, true).Return(re.Result{Name: "humidity"}, nil)
+
+	req := testRequest{
+		client:      ts.Client(),
+		method:      http.MethodPut,
+		url:         ts.URL + "/streams/humidity",
+		token:       validToken,
+		contentType: contentType,
+		body:        strings.NewReader(stream),
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+
+	var body re.Result
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "humidity", body.Name, fmt.Sprintf("expected name humidity got %s", body.Name))
+	svc.AssertCalled(t, "CreateStream", mock.Anything, validToken, named, true)
+}
+
+func TestDeleteStream(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc    string
+		token   string
+		query   string
+		cascade bool
+		status  int
+		svcErr  error
+	}{
+		{
+			desc:   "delete stream",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:    "delete stream with cascade",
+			token:   validToken,
+			query:   "?cascade=true",
+			cascade: true,
+			status:  http.StatusOK,
+		},
+		{
+			desc:   "delete stream referenced by rules",
+			token:  validToken,
+			status: http.StatusConflict,
+			svcErr: errors.Wrap(svcerr.ErrConflict, re.ErrStreamInUse),
+		},
+		{
+			desc:   "delete stream with invalid cascade",
+			token:  validToken,
+			query:  "?cascade=yes",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "delete stream without token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("DeleteStream", mock.Anything, tc.token, "temperature", tc.cascade).Return(re.Result{Status: http.StatusOK, Name: "temperature"}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    ts.URL + "/streams/temperature" + tc.query,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestCreateTable(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "create table",
+			token:       validToken,
+			data:        table,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create table with invalid content type",
+			token:       validToken,
+			data:        table,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create table without topic",
+			token:       validToken,
+			data:        `{"name": "devices", "fields": [{"name": "id", "type": "string"}]}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create table without fields",
+			token:       validToken,
+			data:        `{"name": "devices", "topic": "devices.json"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create table without token",
+			data:        table,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create existing table",
+			token:       validToken,
+			data:        table,
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CreateTable", mock.Anything, tc.token, mock.Anything).Return(re.Result{Name: "devices"}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/tables",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestViewTable(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	svc.On("ViewTable", mock.Anything, validToken, "devices").Return(re.Table{Name: "devices"}, nil)
+	svc.On("ViewTable", mock.Anything, validToken, "unknown").Return(re.Table{}, svcerr.ErrNotFound)
+
+	cases := []struct {
+		desc   string
+		token  string
+		name   string
+		status int
+	}{
+		{
+			desc:   "view table",
+			token:  validToken,
+			name:   "devices",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view non-existing table",
+			token:  validToken,
+			name:   "unknown",
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view table without token",
+			name:   "devices",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/tables/" + tc.name,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcStatus   int
+		svcErr      error
+		retryAfter  string
+	}{
+		{
+			desc:        "create rule",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create rule with identical existing rule",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusOK,
+			svcStatus:   http.StatusOK,
+		},
+		{
+			desc:        "create rule with invalid content type",
+			token:       validToken,
+			data:        rule,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create rule without SQL",
+			token:       validToken,
+			data:        `{"id": "alarm", "actions": [{}]}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without token",
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule with unauthorized channel",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			svcErr:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "create existing rule",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+		},
+		{
+			desc:        "create rule over quota",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			svcErr:      re.ErrQuotaExceeded,
+		},
+		{
+			desc:        "create rule over rate limit",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusTooManyRequests,
+			svcErr:      errors.Wrap(re.ErrRateLimited, &re.RetryError{After: 1500 * time.Millisecond}),
+			retryAfter:  "2",
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CreateRule", mock.Anything, tc.token, mock.Anything).Return(re.Result{Name: "alarm", Status: tc.svcStatus}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/rules",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.retryAfter, res.Header.Get("Retry-After"), fmt.Sprintf("%s: expected Retry-After %q got %q", tc.desc, tc.retryAfter, res.Header.Get("Retry-After")))
+		svcCall.Unset()
+	}
+}
+
+func TestSaveDraft(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		result      re.Result
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "save new draft",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			result:      re.Result{Name: "alarm", Status: http.StatusCreated},
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "save existing draft",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			result:      re.Result{Name: "alarm", Status: http.StatusOK},
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "save draft with invalid content type",
+			token:       validToken,
+			data:        rule,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "save draft of published rule",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+		},
+		{
+			desc:        "save draft without token",
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("SaveDraft", mock.Anything, tc.token, mock.Anything).Return(tc.result, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         ts.URL + "/rules/alarm/draft",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestPatchRule(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "patch rule SQL",
+			token:       validToken,
+			data:        `{"sql": "SELECT * FROM temperature WHERE v > 40"}`,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "patch rule with invalid content type",
+			token:       validToken,
+			data:        `{"sql": "SELECT * FROM temperature WHERE v > 40"}`,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "patch rule with empty actions",
+			token:       validToken,
+			data:        `{"actions": []}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "patch rule without token",
+			data:        `{"sql": "SELECT * FROM temperature WHERE v > 40"}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "patch rule with invalid patched rule",
+			token:       validToken,
+			data:        `{"sql": "SELECT * FROM missing"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "patch non-existing rule",
+			token:       validToken,
+			data:        `{"sql": "SELECT * FROM temperature WHERE v > 40"}`,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+			svcErr:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("PatchRule", mock.Anything, tc.token, "alarm", mock.Anything).Return(re.Result{Name: "alarm"}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPatch,
+			url:         ts.URL + "/rules/alarm",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRuleRevision(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	viewed := re.Rule{ID: "alarm", SQL: "SELECT * FROM temperature", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	svcCall := svc.On("ViewRule", mock.Anything, validToken, "alarm").Return(viewed, nil)
+	res, err := testRequest{client: ts.Client(), method: http.MethodGet, url: ts.URL + "/rules/alarm", token: validToken}.make()
+	assert.Nil(t, err, fmt.Sprintf("view rule: unexpected error %s", err))
+	etag := `"` + viewed.Revision() + `"`
+	assert.Equal(t, etag, res.Header.Get("ETag"), fmt.Sprintf("view rule: expected ETag %s got %s", etag, res.Header.Get("ETag")))
+	svcCall.Unset()
+
+	cases := []struct {
+		desc     string
+		method   string
+		ifMatch  string
+		revision string
+		status   int
+		svcErr   error
+	}{
+		{
+			desc:     "update rule with revision",
+			method:   http.MethodPut,
+			ifMatch:  etag,
+			revision: viewed.Revision(),
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "update rule with stale revision",
+			method:   http.MethodPut,
+			ifMatch:  `"0123456789abcdef"`,
+			revision: "0123456789abcdef",
+			status:   http.StatusConflict,
+			svcErr:   errors.Wrap(svcerr.ErrConflict, re.ErrStaleRevision),
+		},
+		{
+			desc:   "update rule without required revision",
+			method: http.MethodPut,
+			status: http.StatusPreconditionRequired,
+			svcErr: re.ErrRevisionRequired,
+		},
+		{
+			desc:     "delete rule with weak revision",
+			method:   http.MethodDelete,
+			ifMatch:  "W/" + etag,
+			revision: viewed.Revision(),
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "delete rule with any revision",
+			method:   http.MethodDelete,
+			ifMatch:  re.AnyRevision,
+			revision: re.AnyRevision,
+			status:   http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		var revision string
+		expect := func(args mock.Arguments) {
+			revision = re.ExpectedRevision(args.Get(0).(context.Context))
+		}
+		updateCall := svc.On("UpdateRule", mock.Anything, validToken, mock.Anything).Run(expect).Return(re.Result{Name: "alarm"}, tc.svcErr)
+		deleteCall := svc.On("DeleteRule", mock.Anything, validToken, "alarm").Run(expect).Return(re.Result{Name: "alarm"}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         ts.URL + "/rules/alarm",
+			token:       validToken,
+			contentType: contentType,
+			ifMatch:     tc.ifMatch,
+			body:        strings.NewReader(rule),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.revision, revision, fmt.Sprintf("%s: expected revision %s got %s", tc.desc, tc.revision, revision))
+		updateCall.Unset()
+		deleteCall.Unset()
+	}
+}
+
+func TestViewRules(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	ids := `{"ids": ["alarm", "unknown"]}`
+	batch := re.RulesBatch{Rules: []re.Rule{{ID: "alarm", SQL: "SELECT * FROM temperature"}}, Missing: []string{"unknown"}}
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "view rules",
+			token:       validToken,
+			data:        ids,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "view rules without IDs",
+			token:       validToken,
+			data:        `{}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "view too many rules",
+			token:       validToken,
+			data:        ids,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "view rules with invalid content type",
+			token:       validToken,
+			data:        ids,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "view rules without token",
+			data:        ids,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ViewRules", mock.Anything, tc.token, []string{"alarm", "unknown"}).Return(batch, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/rules/batch",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var got re.RulesBatch
+			err := json.NewDecoder(res.Body).Decode(&got)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, batch, got, fmt.Sprintf("%s: expected %v got %v", tc.desc, batch, got))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "validate rule",
+			token:       validToken,
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "validate rule without SQL",
+			token:       validToken,
+			data:        `{"id": "alarm", "actions": [{}]}`,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "validate rule with malformed body",
+			token:       validToken,
+			data:        `{"id": "alarm"`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "validate rule with invalid token",
+			token:       "invalid",
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			svcErr:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "validate rule without token",
+			data:        rule,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ValidateRule", mock.Anything, tc.token, mock.Anything).Return(re.RuleValidation{Valid: true, Diagnostics: []re.Diagnostic{}}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/rules/validate",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestTestRule(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	trial := `{"rule": {"id": "alarm", "sql": "SELECT * FROM temperature WHERE v > 10"}, "samples": {"temperature": [{"v": 15}]}}`
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "test rule",
+			token:       validToken,
+			data:        trial,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "test rule without SQL",
+			token:       validToken,
+			data:        `{"rule": {"id": "alarm"}, "samples": {"temperature": [{"v": 15}]}}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "test rule without samples",
+			token:       validToken,
+			data:        `{"rule": {"id": "alarm", "sql": "SELECT * FROM temperature"}}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "test rule with malformed body",
+			token:       validToken,
+			data:        `{"rule": {"id": "alarm"`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "test rule with invalid content type",
+			token:       validToken,
+			data:        trial,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "test rule with invalid token",
+			token:       "invalid",
+			data:        trial,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			svcErr:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "test rule with unsupported Kuiper",
+			token:       validToken,
+			data:        trial,
+			contentType: contentType,
+			status:      http.StatusInternalServerError,
+			svcErr:      re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("TestRule", mock.Anything, tc.token, mock.Anything).Return(re.TrialResult{Results: []map[string]interface{}{{"v": 15}}}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/rules/test",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestSearchRules(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		query  string
+		rq     re.RuleQuery
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "search rules by stream",
+			token:  validToken,
+			query:  "stream=temperature",
+			rq:     re.RuleQuery{Stream: "temperature"},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "search rules by stream and channel",
+			token:  validToken,
+			query:  "stream=temperature&channel=channel",
+			rq:     re.RuleQuery{Stream: "temperature", Channel: "channel"},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "search rules without stream and channel",
+			token:  validToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "search rules with invalid limit",
+			token:  validToken,
+			query:  "channel=channel&limit=1000",
+			rq:     re.RuleQuery{Channel: "channel"},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "search rules without token",
+			query:  "stream=temperature",
+			rq:     re.RuleQuery{Stream: "temperature"},
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		page := re.RulesPage{Total: 1, Limit: 10, Rules: []re.RuleInfo{{ID: "alarm", Status: "Running"}}}
+		svcCall := svc.On("SearchRules", mock.Anything, tc.token, tc.rq, mock.Anything).Return(page, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/search?" + tc.query,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body re.RulesPage
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, page, body, fmt.Sprintf("%s: expected page %v got %v", tc.desc, page, body))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestReplayRule(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	period := `{"from": "2024-05-01T00:00:00Z", "to": "2024-05-02T00:00:00Z"}`
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "replay rule",
+			token:       validToken,
+			data:        period,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "replay rule without from",
+			token:       validToken,
+			data:        `{"to": "2024-05-02T00:00:00Z"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "replay rule without to",
+			token:       validToken,
+			data:        `{"from": "2024-05-01T00:00:00Z"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "replay rule with malformed time",
+			token:       validToken,
+			data:        `{"from": "yesterday", "to": "2024-05-02T00:00:00Z"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "replay rule with invalid content type",
+			token:       validToken,
+			data:        period,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "replay rule with invalid token",
+			token:       "invalid",
+			data:        period,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			svcErr:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "replay rule with invalid period",
+			token:       validToken,
+			data:        period,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "replay unknown rule",
+			token:       validToken,
+			data:        period,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+			svcErr:      svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ReplayRule", mock.Anything, tc.token, "alarm", mock.Anything, mock.Anything).Return(re.ReplayResult{Messages: 1, Results: []map[string]interface{}{{"v": 15}}}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/rules/alarm/replay",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestTailRule(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	results := make(chan map[string]interface{}, 1)
+	results <- map[string]interface{}{"v": 15.0}
+	close(results)
+	svcCall := svc.On("TailRule", mock.Anything, validToken, "alarm").Return((<-chan map[string]interface{})(results), nil)
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/rules/alarm/tail"
+	dialer := websocket.Dialer{Subprotocols: []string{"tail", "bearer." + validToken}}
+	conn, wsRes, err := dialer.Dial(wsURL, http.Header{"Origin": []string{ts.URL}})
+	assert.Nil(t, err, fmt.Sprintf("tail rule: unexpected error %s", err))
+	assert.Equal(t, "tail", conn.Subprotocol(), fmt.Sprintf("tail rule: expected tail subprotocol got %s", conn.Subprotocol()))
+	var res map[string]interface{}
+	err = conn.ReadJSON(&res)
+	assert.Nil(t, err, fmt.Sprintf("tail rule: unexpected error %s", err))
+	assert.Equal(t, map[string]interface{}{"v": 15.0}, res, fmt.Sprintf("tail rule: expected result got %v", res))
+	conn.Close()
+	wsRes.Body.Close()
+	svcCall.Unset()
+
+	// The token isn't read from the query.
+	svcCall = svc.On("TailRule", mock.Anything, mock.Anything, "alarm").Return(nil, svcerr.ErrAuthentication)
+	_, wsRes, err = websocket.DefaultDialer.Dial(wsURL+"?authorization="+validToken, nil)
+	assert.NotNil(t, err, "tail rule with query token: expected error")
+	assert.Equal(t, http.StatusUnauthorized, wsRes.StatusCode, fmt.Sprintf("tail rule with query token: expected status code %d got %d", http.StatusUnauthorized, wsRes.StatusCode))
+	wsRes.Body.Close()
+	svcCall.Unset()
+
+	// The rejected origin leaves the rule untouched.
+	results = make(chan map[string]interface{})
+	close(results)
+	svcCall = svc.On("TailRule", mock.Anything, validToken, "alarm").Return((<-chan map[string]interface{})(results), nil)
+	calls := len(svc.Calls)
+	_, wsRes, err = dialer.Dial(wsURL, http.Header{"Origin": []string{"https://app.example.com"}})
+	assert.NotNil(t, err, "tail rule from foreign origin: expected error")
+	assert.Equal(t, http.StatusForbidden, wsRes.StatusCode, fmt.Sprintf("tail rule from foreign origin: expected status code %d got %d", http.StatusForbidden, wsRes.StatusCode))
+	assert.Len(t, svc.Calls, calls, "tail rule from foreign origin: expected rule not to be tailed")
+	wsRes.Body.Close()
+
+	allowed := httptest.NewServer(api.MakeHandler(svc, re.Config{Tail: re.TailConfig{Origins: []string{"https://app.example.com"}}}, mglog.NewMock(), instanceID))
+	defer allowed.Close()
+	conn, wsRes, err = dialer.Dial("ws"+strings.TrimPrefix(allowed.URL, "http")+"/rules/alarm/tail", http.Header{"Origin": []string{"https://app.example.com"}})
+	assert.Nil(t, err, fmt.Sprintf("tail rule from allowed origin: unexpected error %s", err))
+	if err == nil {
+		conn.Close()
+	}
+	wsRes.Body.Close()
+	svcCall.Unset()
+
+	// The requests without the token are rejected before the upgrade, and
+	// the failures of the upgraded requests close the WebSocket with 4000
+	// plus the HTTP status.
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "tail rule without token",
+			id:     "alarm",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "tail rule with invalid token",
+			token:  "invalid",
+			id:     "alarm",
+			status: 4000 + http.StatusUnauthorized,
+			svcErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "tail unknown rule",
+			token:  validToken,
+			id:     "unknown",
+			status: 4000 + http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "tail rule with tails disabled",
+			token:  validToken,
+			id:     "alarm",
+			status: 4000 + http.StatusBadRequest,
+			svcErr: svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("TailRule", mock.Anything, tc.token, tc.id).Return(nil, tc.svcErr)
+		dialer := websocket.Dialer{Subprotocols: []string{"tail", "bearer." + tc.token}}
+		conn, wsRes, err := dialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/rules/"+tc.id+"/tail", nil)
+		switch {
+		case tc.svcErr == nil:
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+			assert.Equal(t, tc.status, wsRes.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, wsRes.StatusCode))
+		default:
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			_, _, err = conn.ReadMessage()
+			assert.True(t, websocket.IsCloseError(err, tc.status), fmt.Sprintf("%s: expected close code %d got %s", tc.desc, tc.status, err))
+			conn.Close()
+		}
+		wsRes.Body.Close()
+		svcCall.Unset()
+	}
+}
+
+func TestWatchRules(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	changes := make(chan re.RuleStateChange, 1)
+	changes <- re.RuleStateChange{Rule: "alarm", From: re.StatsRunning, To: re.StatsError, Error: "connection refused."}
+	close(changes)
+	svcCall := svc.On("WatchRules", mock.Anything, validToken).Return((<-chan re.RuleStateChange)(changes), nil)
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    ts.URL + "/rules/events",
+		token:  validToken,
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("watch rules: unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("watch rules: expected status code %d got %d", http.StatusOK, res.StatusCode))
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"), fmt.Sprintf("watch rules: expected event stream got %s", res.Header.Get("Content-Type")))
+	body, err := io.ReadAll(res.Body)
+	assert.Nil(t, err, fmt.Sprintf("watch rules: unexpected error %s", err))
+	res.Body.Close()
+	expected := "event: state\ndata: {\"rule\":\"alarm\",\"from\":\"running\",\"to\":\"error\",\"error\":\"connection refused.\",\"time\":\"0001-01-01T00:00:00Z\"}\n\n"
+	assert.Equal(t, expected, string(body), fmt.Sprintf("watch rules: expected events %s got %s", expected, body))
+	svcCall.Unset()
+
+	// The token isn't read from the query.
+	svcCall = svc.On("WatchRules", mock.Anything, mock.Anything).Return(nil, svcerr.ErrAuthentication)
+	calls := len(svc.Calls)
+	res, err = ts.Client().Get(ts.URL + "/rules/events?authorization=" + validToken)
+	assert.Nil(t, err, fmt.Sprintf("watch rules with query token: unexpected error %s", err))
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode, fmt.Sprintf("watch rules with query token: expected status code %d got %d", http.StatusUnauthorized, res.StatusCode))
+	assert.Len(t, svc.Calls, calls, "watch rules with query token: expected rules not to be watched")
+	res.Body.Close()
+	svcCall.Unset()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "watch rules without token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "watch rules with invalid token",
+			token:  "invalid",
+			status: http.StatusUnauthorized,
+			svcErr: svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("WatchRules", mock.Anything, tc.token).Return(nil, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/rules/events", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestPushTail(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		session     string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "push tail result",
+			session:     "session",
+			data:        `{"v": 15}`,
+			contentType: contentType,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "push tail result to unknown session",
+			session:     "unknown",
+			data:        `{"v": 15}`,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+			svcErr:      svcerr.ErrNotFound,
+		},
+		{
+			desc:        "push tail result with invalid content type",
+			session:     "session",
+			data:        `{"v": 15}`,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "push malformed tail result",
+			session:     "session",
+			data:        `[15]`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("PushTail", mock.Anything, tc.session, map[string]interface{}{"v": 15.0}).Return(tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tail/%s", ts.URL, tc.session),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestControlRule(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc    string
+		command string
+		method  string
+		status  int
+		svcErr  error
+	}{
+		{
+			desc:    "start rule",
+			command: "start",
+			method:  "StartRule",
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "stop rule",
+			command: "stop",
+			method:  "StopRule",
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "restart rule",
+			command: "restart",
+			method:  "RestartRule",
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "publish rule",
+			command: "publish",
+			method:  "PublishRule",
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "unpublish rule",
+			command: "unpublish",
+			method:  "UnpublishRule",
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "restore rule",
+			command: "restore",
+			method:  "RestoreRule",
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "restore rule that is not deleted",
+			command: "restore",
+			method:  "RestoreRule",
+			status:  http.StatusConflict,
+			svcErr:  svcerr.ErrConflict,
+		},
+		{
+			desc:    "publish published rule",
+			command: "publish",
+			method:  "PublishRule",
+			status:  http.StatusConflict,
+			svcErr:  svcerr.ErrConflict,
+		},
+		{
+			desc:    "start non-existing rule",
+			command: "start",
+			method:  "StartRule",
+			status:  http.StatusNotFound,
+			svcErr:  svcerr.ErrNotFound,
+		},
+		{
+			desc:    "stop rule with malformed ID",
+			command: "stop",
+			method:  "StopRule",
+			status:  http.StatusBadRequest,
+			svcErr:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On(tc.method, mock.Anything, validToken, "alarm").Return(re.Result{Name: "alarm"}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    ts.URL + "/rules/alarm/" + tc.command,
+			token:  validToken,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		method string
+		token  string
+		repair bool
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "view drift",
+			method: http.MethodGet,
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "repair drift",
+			method: http.MethodPost,
+			token:  validToken,
+			repair: true,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view drift without token",
+			method: http.MethodGet,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view drift as non-admin user",
+			method: http.MethodGet,
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("Reconcile", mock.Anything, tc.token, tc.repair).Return(re.DriftReport{}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: tc.method,
+			url:    ts.URL + "/drift",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestCollectOrphans(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		method string
+		token  string
+		query  string
+		policy re.OrphanPolicy
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "view orphans",
+			method: http.MethodGet,
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "remove orphans with minimum age",
+			method: http.MethodPost,
+			token:  validToken,
+			query:  "?min_age=24h",
+			policy: re.OrphanPolicy{Remove: true, MinAge: 24 * time.Hour},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view orphans with invalid minimum age",
+			method: http.MethodGet,
+			token:  validToken,
+			query:  "?min_age=day",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "view orphans with negative minimum age",
+			method: http.MethodGet,
+			token:  validToken,
+			query:  "?min_age=-1h",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "view orphans without token",
+			method: http.MethodGet,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view orphans as non-admin user",
+			method: http.MethodGet,
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CollectOrphans", mock.Anything, tc.token, tc.policy).Return(re.OrphanReport{}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: tc.method,
+			url:    ts.URL + "/orphans" + tc.query,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRestore(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		dryRun bool
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "restore",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "dry run restore",
+			query:  "?dry_run=true",
+			token:  validToken,
+			dryRun: true,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "restore with invalid dry run",
+			query:  "?dry_run=yes",
+			token:  validToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "restore without token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "restore as non-admin user",
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("Restore", mock.Anything, tc.token, tc.dryRun).Return(re.RestoreReport{}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    ts.URL + "/restore" + tc.query,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestExportRuleset(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "export ruleset",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "export ruleset without token",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "export ruleset with Kuiper failure",
+			token:  validToken,
+			status: http.StatusInternalServerError,
+			svcErr: re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ExportRuleset", mock.Anything, tc.token).Return(re.Ruleset{}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/ruleset",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestImportRuleset(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	ruleset := fmt.Sprintf(`{"streams": [{"name": "temperature", "topic": "%s", "senml": true}], "rules": []}`, channelID)
+	cases := []struct {
+		desc        string
+		query       string
+		token       string
+		data        string
+		contentType string
+		conflict    string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "import ruleset",
+			token:       validToken,
+			data:        ruleset,
+			contentType: contentType,
+			conflict:    re.ConflictSkip,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "import ruleset renaming existing entities",
+			query:       "?conflict=rename",
+			token:       validToken,
+			data:        ruleset,
+			contentType: contentType,
+			conflict:    re.ConflictRename,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "import ruleset with unknown conflict strategy",
+			query:       "?conflict=merge",
+			token:       validToken,
+			data:        ruleset,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "import ruleset with invalid content type",
+			token:       validToken,
+			data:        ruleset,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "import malformed ruleset",
+			token:       validToken,
+			data:        "{",
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "import ruleset without token",
+			data:        ruleset,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ImportRuleset", mock.Anything, tc.token, mock.Anything, tc.conflict).Return(re.ImportReport{}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/ruleset" + tc.query,
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestBulkCreate(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	ruleset := fmt.Sprintf(`{"streams": [{"name": "temperature", "topic": "%s", "senml": true}], "rules": []}`, channelID)
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "bulk create streams and rules",
+			token:       validToken,
+			data:        ruleset,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "bulk create without streams and rules",
+			token:       validToken,
+			data:        `{"streams": [], "rules": []}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "bulk create too many streams and rules",
+			token:       validToken,
+			data:        ruleset,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "bulk create with invalid content type",
+			token:       validToken,
+			data:        ruleset,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "bulk create without token",
+			data:        ruleset,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("BulkCreate", mock.Anything, tc.token, mock.Anything).Return(re.BulkReport{}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/bulk",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestBulkDelete(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	deletion := `{"streams": ["temperature"], "rules": ["alarm"]}`
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "bulk delete streams and rules",
+			token:       validToken,
+			data:        deletion,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "bulk delete without streams and rules",
+			token:       validToken,
+			data:        `{}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "bulk delete with invalid content type",
+			token:       validToken,
+			data:        deletion,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "bulk delete without token",
+			data:        deletion,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("BulkDelete", mock.Anything, tc.token, mock.Anything).Return(re.BulkReport{}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/bulk/delete",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestCreateTemplate(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	tmpl := fmt.Sprintf(`{"name": "threshold", "variables": [{"name": "threshold", "type": "number"}], "sql": "SELECT * FROM stream WHERE v > {threshold}", "actions": [{"mainflux": {"channel": "%s"}}]}`, channelID)
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "create template",
+			token:       validToken,
+			data:        tmpl,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create existing template",
+			token:       validToken,
+			data:        tmpl,
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+		},
+		{
+			desc:        "create template as non-admin user",
+			token:       validToken,
+			data:        tmpl,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			svcErr:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "create template without actions",
+			token:       validToken,
+			data:        `{"name": "threshold", "sql": "SELECT * FROM stream"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create template with invalid content type",
+			token:       validToken,
+			data:        tmpl,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create template without token",
+			data:        tmpl,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CreateTemplate", mock.Anything, tc.token, mock.Anything).Return(re.Template{}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/templates",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRemoveTemplate(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "remove template",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove non-existing template",
+			token:  validToken,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "remove template without token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("RemoveTemplate", mock.Anything, tc.token, "threshold").Return(tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    ts.URL + "/templates/threshold",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	inst := `{"id": "alarm", "values": {"threshold": "30"}}`
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "instantiate template",
+			token:       validToken,
+			data:        inst,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "instantiate template with invalid values",
+			token:       validToken,
+			data:        inst,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "instantiate template without rule ID",
+			token:       validToken,
+			data:        `{"values": {"threshold": "30"}}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "instantiate template with malformed body",
+			token:       validToken,
+			data:        "{",
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "instantiate template without token",
+			data:        inst,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("InstantiateTemplate", mock.Anything, tc.token, "threshold", mock.Anything).Return(re.Rule{}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/templates/threshold/rules",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestCreatePlugin(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	plugin := `{"name": "mainflux", "file": "https://example.com/plugins/sinks/mainflux.zip"}`
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "create plugin",
+			token:       validToken,
+			data:        plugin,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create plugin without file",
+			token:       validToken,
+			data:        `{"name": "mainflux"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create plugin with invalid content type",
+			token:       validToken,
+			data:        plugin,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create plugin without token",
+			data:        plugin,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create plugin by non-admin user",
+			token:       validToken,
+			data:        plugin,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			svcErr:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CreatePlugin", mock.Anything, tc.token, re.SinkPlugin, mock.Anything).Return(re.Result{Name: "mainflux"}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/plugins/sinks",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestListPlugins(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	svc.On("ListPlugins", mock.Anything, validToken, re.SinkPlugin).Return([]string{"mainflux"}, nil)
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    ts.URL + "/plugins/sinks",
+		token:  validToken,
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+
+	var body struct {
+		Plugins []string `json:"plugins"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, []string{"mainflux"}, body.Plugins, fmt.Sprintf("expected plugins [mainflux] got %v", body.Plugins))
+}
+
+func TestRegisterExternalService(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	service := `{"name": "geo", "file": "https://example.com/services/geo.zip"}`
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "register external service",
+			token:       validToken,
+			data:        service,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "register external service without file",
+			token:       validToken,
+			data:        `{"name": "geo"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "register external service without token",
+			data:        service,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "register external service by non-admin user",
+			token:       validToken,
+			data:        service,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			svcErr:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("RegisterExternalService", mock.Anything, tc.token, mock.Anything).Return(re.Result{Name: "geo"}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/services",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestListExternalFunctions(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	funcs := []re.ExternalFunction{{Name: "distance", ServiceName: "geo", InterfaceName: "geo", MethodName: "Distance"}}
+	svc.On("ListExternalFunctions", mock.Anything, validToken).Return(funcs, nil)
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    ts.URL + "/functions",
+		token:  validToken,
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+
+	var body struct {
+		Functions []re.ExternalFunction `json:"functions"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, funcs, body.Functions, fmt.Sprintf("expected functions %v got %v", funcs, body.Functions))
+}
+
+func TestSaveConfKey(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	conf := `{"server": "ssl://mqtt.example.com:8883", "username": "thing", "password": "key"}`
+	cases := []struct {
+		desc        string
+		token       string
+		name        string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "save conf key",
+			token:       validToken,
+			name:        "broker",
+			data:        conf,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "save conf key without server",
+			token:       validToken,
+			name:        "broker",
+			data:        `{"username": "thing"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save conf key with invalid content type",
+			token:       validToken,
+			name:        "broker",
+			data:        conf,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "save conf key without token",
+			name:        "broker",
+			data:        conf,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "save malformed conf key",
+			token:       validToken,
+			name:        "broker",
+			data:        conf,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("SaveConfKey", mock.Anything, tc.token, tc.name, mock.Anything).Return(re.Result{Name: tc.name}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         ts.URL + "/confkeys/" + tc.name,
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	kuiperErr := errors.Wrap(svcerr.ErrNotFound, errors.New("stream u1234_temperature is not found"))
+	svcCall := svc.On("ViewStream", mock.Anything, validToken, "temperature").Return(re.Stream{}, kuiperErr)
+	defer svcCall.Unset()
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    ts.URL + "/streams/temperature",
+		token:  validToken,
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusNotFound, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusNotFound, res.StatusCode))
+	assert.Equal(t, contentType, res.Header.Get("Content-Type"), fmt.Sprintf("expected content type %s got %s", contentType, res.Header.Get("Content-Type")))
+
+	var body struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, svcerr.ErrNotFound.Error(), body.Message, fmt.Sprintf("expected message %s got %s", svcerr.ErrNotFound, body.Message))
+	assert.Equal(t, "stream u1234_temperature is not found", body.Error, fmt.Sprintf("expected error %s got %s", "stream u1234_temperature is not found", body.Error))
+}
+
+func TestEncodeKuiperError(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		svcErr error
+		typed  error
+		cause  string
+		status int
+	}{
+		{
+			desc:   "invalid SQL",
+			svcErr: svcerr.ErrMalformedEntity,
+			typed:  re.ErrInvalidSQL,
+			cause:  `found "FORM", expected FROM`,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "rule not found",
+			svcErr: svcerr.ErrNotFound,
+			typed:  re.ErrRuleNotFound,
+			cause:  "rule u1234_alarm is not found in registry",
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "stream in use",
+			svcErr: svcerr.ErrConflict,
+			typed:  re.ErrStreamInUse,
+			cause:  "stream u1234_temperature is referenced by rules [u1234_alarm]",
+			status: http.StatusConflict,
+		},
+		{
+			desc:   "existing entity",
+			svcErr: svcerr.ErrConflict,
+			typed:  re.ErrConflict,
+			cause:  "stream u1234_temperature already exists",
+			status: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		kuiperErr := errors.Wrap(tc.svcErr, errors.Wrap(tc.typed, errors.New(tc.cause)))
+		svcCall := svc.On("ViewStream", mock.Anything, validToken, "temperature").Return(re.Stream{}, kuiperErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/streams/temperature",
+			token:  validToken,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, contentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: expected content type %s got %s", tc.desc, contentType, res.Header.Get("Content-Type")))
+
+		var body struct {
+			Error   string `json:"error"`
+			Message string `json:"message"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.typed.Error(), body.Message, fmt.Sprintf("%s: expected message %s got %s", tc.desc, tc.typed, body.Message))
+		assert.Equal(t, tc.cause, body.Error, fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.cause, body.Error))
+		svcCall.Unset()
+	}
+}
+
+func TestListAllStreams(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "list all streams",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list all streams as non-admin user",
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "list all streams without token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ListAllStreams", mock.Anything, tc.token).Return(re.AllStreams{}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/all/streams",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestListAllRules(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "list all rules",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list all rules as non-admin user",
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "list all rules without token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ListAllRules", mock.Anything, tc.token).Return(re.AllRules{}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/all/rules",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestViewQuota(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "view quota",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view quota as non-admin user",
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "view quota without token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ViewQuota", mock.Anything, tc.token, "user").Return(re.UserQuota{UserID: "user"}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/quotas/user",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestSetQuota(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	quota := `{"max_streams":10,"max_rules":20}`
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "set quota",
+			token:       validToken,
+			data:        quota,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "set quota with invalid content type",
+			token:       validToken,
+			data:        quota,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "set quota with malformed body",
+			token:       validToken,
+			data:        `{"max_rules":"many"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "set negative quota",
+			token:       validToken,
+			data:        `{"max_rules":-1}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "set quota as non-admin user",
+			token:       validToken,
+			data:        quota,
+			contentType: contentType,
+			status:      http.StatusForbidden,
+			svcErr:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "set quota without token",
+			data:        quota,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("SetQuota", mock.Anything, tc.token, "user", mock.Anything).Return(re.UserQuota{UserID: "user"}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         ts.URL + "/quotas/user",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRemoveQuota(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "remove quota",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove missing quota",
+			token:  validToken,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "remove quota without token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("RemoveQuota", mock.Anything, tc.token, "user").Return(tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    ts.URL + "/quotas/user",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestListInstances(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	instances := []re.Instance{
+		{Name: "dedicated", Users: []string{"user"}},
+		{Name: re.DefaultInstance, Default: true, Info: &re.Info{Version: "1.10.0"}, Users: []string{}},
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "list instances",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list instances as non-admin user",
+			token:  validToken,
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "list instances without token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ListInstances", mock.Anything, tc.token).Return(instances, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/instances",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Instances []re.Instance `json:"instances"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, instances, body.Instances, fmt.Sprintf("%s: expected instances %v got %v", tc.desc, instances, body.Instances))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestAssignInstance(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		method      string
+		token       string
+		data        string
+		contentType string
+		instance    string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "assign instance",
+			method:      http.MethodPut,
+			token:       validToken,
+			data:        `{"instance":"dedicated"}`,
+			contentType: contentType,
+			instance:    "dedicated",
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "assign instance with invalid content type",
+			method:      http.MethodPut,
+			token:       validToken,
+			data:        `{"instance":"dedicated"}`,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "assign instance with malformed body",
+			method:      http.MethodPut,
+			token:       validToken,
+			data:        `{"instance":1}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "assign unknown instance",
+			method:      http.MethodPut,
+			token:       validToken,
+			data:        `{"instance":"unknown"}`,
+			contentType: contentType,
+			instance:    "unknown",
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "move user with streams",
+			method:      http.MethodPut,
+			token:       validToken,
+			data:        `{"instance":"dedicated"}`,
+			contentType: contentType,
+			instance:    "dedicated",
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+		},
+		{
+			desc:   "unassign instance",
+			method: http.MethodDelete,
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "unassign instance without token",
+			method: http.MethodDelete,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("AssignInstance", mock.Anything, tc.token, "user", tc.instance).Return(re.Assignment{UserID: "user", Instance: "dedicated"}, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         ts.URL + "/instances/assignments/user",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRuleTopology(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "view rule topology",
+			token:  validToken,
+			id:     "rule",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view topology of unknown rule",
+			token:  validToken,
+			id:     "unknown",
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "view rule topology without token",
+			id:     "rule",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		topo := re.RuleTopology{
+			Nodes: []re.TopologyNode{{Name: "source_stream", Type: re.SourceNode}, {Name: "sink_mqtt_0", Type: re.SinkNode}},
+			Edges: []re.TopologyEdge{{From: "source_stream", To: "sink_mqtt_0"}},
+		}
+		svcCall := svc.On("RuleTopology", mock.Anything, tc.token, tc.id).Return(topo, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/rules/%s/topology", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body re.RuleTopology
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, topo, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, topo, body))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestRuleLogs(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	since := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		query  string
+		since  time.Time
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "view rule logs",
+			token:  validToken,
+			id:     "rule",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view rule logs since time",
+			token:  validToken,
+			id:     "rule",
+			query:  "?since=2024-05-01T10:00:00Z",
+			since:  since,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view rule logs with invalid since",
+			token:  validToken,
+			id:     "rule",
+			query:  "?since=yesterday",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "view logs of unknown rule",
+			token:  validToken,
+			id:     "unknown",
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "view rule logs without token",
+			id:     "rule",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		logs := []re.LogEntry{{Time: since, Level: "error", Message: "rest sink fails to send out the data", File: "sink/rest_sink.go:90"}}
+		svcCall := svc.On("RuleLogs", mock.Anything, tc.token, tc.id, tc.since).Return(logs, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/rules/%s/logs%s", ts.URL, tc.id, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Logs []re.LogEntry `json:"logs"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, logs, body.Logs, fmt.Sprintf("%s: expected %v got %v", tc.desc, logs, body.Logs))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestEngineStats(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	stats := re.EngineStats{
+		Streams:    2,
+		Rules:      1,
+		States:     map[string]int{re.StatsRunning: 1, re.StatsStopped: 0, re.StatsError: 0},
+		RecordsIn:  10,
+		Exceptions: 1,
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "view engine stats",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view engine stats with invalid token",
+			token:  "invalid",
+			status: http.StatusUnauthorized,
+			svcErr: svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "view engine stats without token",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("EngineStats", mock.Anything, tc.token).Return(stats, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/stats",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body re.EngineStats
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, stats, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, stats, body))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestSaveGateway(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "save gateway",
+			token:       validToken,
+			data:        `{"name":"plant","channel":"channel"}`,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "save gateway with invalid content type",
+			token:       validToken,
+			data:        `{"name":"plant","channel":"channel"}`,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "save gateway with malformed body",
+			token:       validToken,
+			data:        `{"channel":1}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save gateway without token",
+			data:        `{"name":"plant","channel":"channel"}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "save gateway of other user",
+			token:       validToken,
+			data:        `{"name":"plant","channel":"channel"}`,
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		gw := re.Gateway{ID: "gateway", Name: "plant", Channel: "channel"}
+		svcCall := svc.On("SaveGateway", mock.Anything, tc.token, gw).Return(gw, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         ts.URL + "/gateways/gateway",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestDeployRule(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		method string
+		token  string
+		url    string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "deploy rule",
+			method: http.MethodPut,
+			token:  validToken,
+			url:    "/rules/rule/deployments/gateway",
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "deploy rule to unknown gateway",
+			method: http.MethodPut,
+			token:  validToken,
+			url:    "/rules/rule/deployments/gateway",
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "deploy rule without token",
+			method: http.MethodPut,
+			url:    "/rules/rule/deployments/gateway",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "undeploy rule",
+			method: http.MethodDelete,
+			token:  validToken,
+			url:    "/rules/rule/deployments/gateway",
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "list deployments",
+			method: http.MethodGet,
+			token:  validToken,
+			url:    "/rules/rule/deployments",
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		d := re.Deployment{Rule: "rule", Gateway: "gateway", Status: re.DeployPending}
+		deployCall := svc.On("DeployRule", mock.Anything, tc.token, "rule", "gateway").Return(d, tc.svcErr)
+		undeployCall := svc.On("UndeployRule", mock.Anything, tc.token, "rule", "gateway").Return(d, tc.svcErr)
+		listCall := svc.On("ListDeployments", mock.Anything, tc.token, "rule").Return([]re.Deployment{d}, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: tc.method,
+			url:    ts.URL + tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		deployCall.Unset()
+		undeployCall.Unset()
+		listCall.Unset()
+	}
+}
+
+func TestDeployFleet(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		method      string
+		token       string
+		url         string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "deploy rule to fleet",
+			method:      http.MethodPost,
+			token:       validToken,
+			url:         "/rules/rule/rollout",
+			data:        `{"labels":{"site":"north"}}`,
+			contentType: contentType,
+			status:      http.StatusAccepted,
+		},
+		{
+			desc:        "deploy rule to fleet with invalid content type",
+			method:      http.MethodPost,
+			token:       validToken,
+			url:         "/rules/rule/rollout",
+			data:        `{"labels":{"site":"north"}}`,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "deploy rule to fleet with malformed labels",
+			method:      http.MethodPost,
+			token:       validToken,
+			url:         "/rules/rule/rollout",
+			data:        `{"labels":["north"]}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "deploy rule to fleet without matching gateways",
+			method:      http.MethodPost,
+			token:       validToken,
+			url:         "/rules/rule/rollout",
+			data:        `{"labels":{"site":"north"}}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "retry deployments",
+			method: http.MethodPost,
+			token:  validToken,
+			url:    "/rules/rule/rollout/retry",
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "view rollout",
+			method: http.MethodGet,
+			token:  validToken,
+			url:    "/rules/rule/rollout",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view rollout without token",
+			method: http.MethodGet,
+			url:    "/rules/rule/rollout",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		r := re.Rollout{Rule: "rule", Status: re.RolloutPending, Total: 1, Pending: 1}
+		deployCall := svc.On("DeployFleet", mock.Anything, tc.token, "rule", map[string]string{"site": "north"}).Return(r, tc.svcErr)
+		retryCall := svc.On("RetryDeployments", mock.Anything, tc.token, "rule").Return(r, tc.svcErr)
+		viewCall := svc.On("ViewRollout", mock.Anything, tc.token, "rule").Return(r, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         ts.URL + tc.url,
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		deployCall.Unset()
+		retryCall.Unset()
+		viewCall.Unset()
+	}
+}
+
+func TestShareEntity(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	share := `{"grantee_type":"user","access":"view"}`
+
+	cases := []struct {
+		desc        string
+		url         string
+		kind        string
+		name        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "share stream",
+			url:         "/streams/stream/shares/user",
+			kind:        re.StreamKind,
+			name:        "stream",
+			token:       validToken,
+			data:        share,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "share rule",
+			url:         "/rules/rule/shares/user",
+			kind:        re.RuleKind,
+			name:        "rule",
+			token:       validToken,
+			data:        share,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "share rule with invalid content type",
+			url:         "/rules/rule/shares/user",
+			kind:        re.RuleKind,
+			name:        "rule",
+			token:       validToken,
+			data:        share,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "share rule with malformed body",
+			url:         "/rules/rule/shares/user",
+			kind:        re.RuleKind,
+			name:        "rule",
+			token:       validToken,
+			data:        `{"access":1}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share rule with invalid access",
+			url:         "/rules/rule/shares/user",
+			kind:        re.RuleKind,
+			name:        "rule",
+			token:       validToken,
+			data:        `{"grantee_type":"user","access":"admin"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "share non-existing rule",
+			url:         "/rules/rule/shares/user",
+			kind:        re.RuleKind,
+			name:        "rule",
+			token:       validToken,
+			data:        share,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+			svcErr:      svcerr.ErrNotFound,
+		},
+		{
+			desc:        "share rule without token",
+			url:         "/rules/rule/shares/user",
+			kind:        re.RuleKind,
+			name:        "rule",
+			data:        share,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		s := re.Share{Grantee: "user", GranteeType: re.UserGrantee, Access: re.ViewAccess}
+		svcCall := svc.On("ShareEntity", mock.Anything, tc.token, tc.kind, tc.name, mock.Anything).Return(s, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         ts.URL + tc.url,
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body re.Share
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, s, body, fmt.Sprintf("%s: expected share %v got %v", tc.desc, s, body))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestListShares(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	shares := []re.Share{{Grantee: "group", GranteeType: re.GroupGrantee, Access: re.ManageAccess}}
+
+	cases := []struct {
+		desc   string
+		url    string
+		kind   string
+		name   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "list shares of stream",
+			url:    "/streams/stream/shares",
+			kind:   re.StreamKind,
+			name:   "stream",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list shares of rule",
+			url:    "/rules/rule/shares",
+			kind:   re.RuleKind,
+			name:   "rule",
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list shares of non-existing rule",
+			url:    "/rules/rule/shares",
+			kind:   re.RuleKind,
+			name:   "rule",
+			token:  validToken,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "list shares without token",
+			url:    "/rules/rule/shares",
+			kind:   re.RuleKind,
+			name:   "rule",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("ListShares", mock.Anything, tc.token, tc.kind, tc.name).Return(shares, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Shares []re.Share `json:"shares"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, shares, body.Shares, fmt.Sprintf("%s: expected shares %v got %v", tc.desc, shares, body.Shares))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestUnshareEntity(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		url    string
+		kind   string
+		name   string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "unshare stream",
+			url:    "/streams/stream/shares/user",
+			kind:   re.StreamKind,
+			name:   "stream",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "unshare rule",
+			url:    "/rules/rule/shares/user",
+			kind:   re.RuleKind,
+			name:   "rule",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "unshare unshared rule",
+			url:    "/rules/rule/shares/user",
+			kind:   re.RuleKind,
+			name:   "rule",
+			token:  validToken,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "unshare rule without token",
+			url:    "/rules/rule/shares/user",
+			kind:   re.RuleKind,
+			name:   "rule",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("UnshareEntity", mock.Anything, tc.token, tc.kind, tc.name, "user").Return(tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    ts.URL + tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+	}
+}
+
+func TestRename(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	rename := `{"name":"renamed"}`
+
+	cases := []struct {
+		desc        string
+		url         string
+		kind        string
+		name        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "rename stream",
+			url:         "/streams/stream/rename",
+			kind:        re.StreamKind,
+			name:        "stream",
+			token:       validToken,
+			data:        rename,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "rename rule",
+			url:         "/rules/rule/rename",
+			kind:        re.RuleKind,
+			name:        "rule",
+			token:       validToken,
+			data:        rename,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "rename rule with invalid content type",
+			url:         "/rules/rule/rename",
+			kind:        re.RuleKind,
+			name:        "rule",
+			token:       validToken,
+			data:        rename,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "rename rule without new name",
+			url:         "/rules/rule/rename",
+			kind:        re.RuleKind,
+			name:        "rule",
+			token:       validToken,
+			data:        `{}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "rename rule to existing rule",
+			url:         "/rules/rule/rename",
+			kind:        re.RuleKind,
+			name:        "rule",
+			token:       validToken,
+			data:        rename,
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+		},
+		{
+			desc:        "rename rule without token",
+			url:         "/rules/rule/rename",
+			kind:        re.RuleKind,
+			name:        "rule",
+			data:        rename,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		result := re.Result{Name: "renamed", Status: http.StatusCreated}
+		svcCall := svc.On("Rename", mock.Anything, tc.token, tc.kind, tc.name, "renamed").Return(result, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + tc.url,
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body re.Result
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, result, body, fmt.Sprintf("%s: expected result %v got %v", tc.desc, result, body))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestListAuditEvents(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		desc   string
+		token  string
+		query  string
+		aq     re.AuditQuery
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "list audit events",
+			token:  validToken,
+			aq:     re.AuditQuery{Limit: 10},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list audit events of rule since time",
+			token:  validToken,
+			query:  "kind=rule&entity=alarm&from=2024-05-01T00:00:00Z",
+			aq:     re.AuditQuery{Limit: 10, Kind: re.RuleKind, Entity: "alarm", From: from},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list audit events with invalid time",
+			token:  validToken,
+			query:  "from=yesterday",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list audit events with invalid limit",
+			token:  validToken,
+			query:  "limit=1000",
+			aq:     re.AuditQuery{Limit: 1000},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list audit events of other owner as non-admin user",
+			token:  validToken,
+			query:  "owner=other",
+			aq:     re.AuditQuery{Limit: 10, Owner: "other"},
+			status: http.StatusForbidden,
+			svcErr: svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "list audit events without token",
+			aq:     re.AuditQuery{Limit: 10},
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		page := re.AuditPage{Total: 1, Limit: 10, Events: []re.AuditEvent{{ID: "event", Time: from, User: "user", Owner: "user", Kind: re.RuleKind, Entity: "alarm", Operation: re.AuditCreate, After: "SELECT * FROM temperature"}}}
+		svcCall := svc.On("ListAuditEvents", mock.Anything, tc.token, tc.aq).Return(page, tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + "/audit?" + tc.query,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body re.AuditPage
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, page, body, fmt.Sprintf("%s: expected page %v got %v", tc.desc, page, body))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestCloneRule(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		channel     string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "clone rule",
+			token:       validToken,
+			data:        `{"id":"copy"}`,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "clone rule retargeting sink channel",
+			token:       validToken,
+			data:        `{"id":"copy","channel":"channel"}`,
+			channel:     "channel",
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "clone rule with invalid content type",
+			token:       validToken,
+			data:        `{"id":"copy"}`,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "clone rule without new ID",
+			token:       validToken,
+			data:        `{"channel":"channel"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "clone rule to existing rule",
+			token:       validToken,
+			data:        `{"id":"copy"}`,
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+		},
+		{
+			desc:        "clone rule without token",
+			data:        `{"id":"copy"}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		result := re.Result{Name: "copy", Status: http.StatusCreated}
+		svcCall := svc.On("CloneRule", mock.Anything, tc.token, "rule", "copy", tc.channel).Return(result, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/rules/rule/clone",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusCreated {
+			var body re.Result
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, result, body, fmt.Sprintf("%s: expected result %v got %v", tc.desc, result, body))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestSetAlertPolicy(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	policy := re.AlertPolicy{Exceptions: 10, Webhook: "https://example.com/alerts"}
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "set alert policy",
+			token:       validToken,
+			data:        `{"exceptions":10,"webhook":"https://example.com/alerts"}`,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "set alert policy with invalid content type",
+			token:       validToken,
+			data:        `{"exceptions":10,"webhook":"https://example.com/alerts"}`,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "set alert policy with malformed body",
+			token:       validToken,
+			data:        `{"exceptions":"10"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "set alert policy without token",
+			data:        `{"exceptions":10,"webhook":"https://example.com/alerts"}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "set invalid alert policy",
+			token:       validToken,
+			data:        `{"exceptions":10,"webhook":"https://example.com/alerts"}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("SetAlertPolicy", mock.Anything, tc.token, policy).Return(policy, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         ts.URL + "/alerts",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body re.AlertPolicy
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, policy, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, policy, body))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestAlertPolicy(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	policy := re.AlertPolicy{Webhook: "https://example.com/alerts"}
+
+	cases := []struct {
+		desc   string
+		method string
+		token  string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "view alert policy",
+			method: http.MethodGet,
+			token:  validToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view missing alert policy",
+			method: http.MethodGet,
+			token:  validToken,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "view alert policy without token",
+			method: http.MethodGet,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove alert policy",
+			method: http.MethodDelete,
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove missing alert policy",
+			method: http.MethodDelete,
+			token:  validToken,
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "remove alert policy without token",
+			method: http.MethodDelete,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		viewCall := svc.On("ViewAlertPolicy", mock.Anything, tc.token).Return(policy, tc.svcErr)
+		removeCall := svc.On("RemoveAlertPolicy", mock.Anything, tc.token).Return(tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: tc.method,
+			url:    ts.URL + "/alerts",
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body re.AlertPolicy
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, policy, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, policy, body))
+		}
+		viewCall.Unset()
+		removeCall.Unset()
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	wh := re.Webhook{URL: "https://example.com/hooks", Events: []string{re.EventRuleErrored}}
+	created := re.Webhook{ID: "webhook", URL: wh.URL, Secret: "secret", Events: wh.Events}
+
+	cases := []struct {
+		desc        string
+		token       string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+	}{
+		{
+			desc:        "create webhook",
+			token:       validToken,
+			data:        `{"url":"https://example.com/hooks","events":["rule.errored"]}`,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create webhook with invalid content type",
+			token:       validToken,
+			data:        `{"url":"https://example.com/hooks","events":["rule.errored"]}`,
+			contentType: "text/plain",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "create webhook with malformed body",
+			token:       validToken,
+			data:        `{"url":1}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create webhook without token",
+			data:        `{"url":"https://example.com/hooks","events":["rule.errored"]}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create invalid webhook",
+			token:       validToken,
+			data:        `{"url":"https://example.com/hooks","events":["rule.errored"]}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CreateWebhook", mock.Anything, tc.token, wh).Return(created, tc.svcErr)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         ts.URL + "/webhooks",
+			token:       tc.token,
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusCreated {
+			var body re.Webhook
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, created, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, created, body))
+		}
+		svcCall.Unset()
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	ts, svc := newREServer()
+	defer ts.Close()
+
+	whs := []re.Webhook{{ID: "webhook", URL: "https://example.com/hooks"}}
+
+	cases := []struct {
+		desc   string
+		method string
+		token  string
+		url    string
+		status int
+		svcErr error
+	}{
+		{
+			desc:   "list webhooks",
+			method: http.MethodGet,
+			token:  validToken,
+			url:    "/webhooks",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list webhooks without token",
+			method: http.MethodGet,
+			url:    "/webhooks",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove webhook",
+			method: http.MethodDelete,
+			token:  validToken,
+			url:    "/webhooks/webhook",
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove missing webhook",
+			method: http.MethodDelete,
+			token:  validToken,
+			url:    "/webhooks/webhook",
+			status: http.StatusNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:   "remove webhook without token",
+			method: http.MethodDelete,
+			url:    "/webhooks/webhook",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		listCall := svc.On("ListWebhooks", mock.Anything, tc.token).Return(whs, tc.svcErr)
+		removeCall := svc.On("RemoveWebhook", mock.Anything, tc.token, "webhook").Return(tc.svcErr)
+		req := testRequest{
+			client: ts.Client(),
+			method: tc.method,
+			url:    ts.URL + tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Webhooks []re.Webhook `json:"webhooks"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, whs, body.Webhooks, fmt.Sprintf("%s: expected %v got %v", tc.desc, whs, body.Webhooks))
+		}
+		listCall.Unset()
+		removeCall.Unset()
+	}
+}
+
+func TestIngest(t *testing.T) {
+	svc := new(mocks.Service)
+	ts := httptest.NewServer(api.MakeHandler(svc, re.Config{Push: re.PushConfig{MaxSize: 16}}, mglog.NewMock(), instanceID))
+	defer ts.Close()
+
+	cases := []struct {
+		desc    string
+		key     string
+		payload string
+		called  bool
+		status  int
+		svcErr  error
+	}{
+		{
+			desc:    "ingest message",
+			key:     "key",
+			payload: `{"v":23.5}`,
+			called:  true,
+			status:  http.StatusAccepted,
+		},
+		{
+			desc:    "ingest message over maximum size",
+			key:     "key",
+			payload: `{"v":23.5,"u":"Cel"}`,
+			status:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:    "ingest message the service finds too large",
+			key:     "key",
+			payload: `{"v":23.5,"n":1}`,
+			called:  true,
+			status:  http.StatusRequestEntityTooLarge,
+			svcErr:  errors.Wrap(svcerr.ErrMalformedEntity, re.ErrMessageTooLarge),
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("Ingest", mock.Anything, tc.key, "stream", []byte(tc.payload)).Return(tc.svcErr)
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/ingest/stream", strings.NewReader(tc.payload))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.key != "" {
+			req.Header.Set("Authorization", apiutil.ThingPrefix+tc.key)
+		}
+		res, err := ts.Client().Do(req)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if !tc.called {
+			svc.AssertNotCalled(t, "Ingest", mock.Anything, tc.key, "stream", []byte(tc.payload))
+		}
+		svcCall.Unset()
+	}
+}
@@ -0,0 +1,1844 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/go-kit/kit/endpoint"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const svcName = "re.RulesEngineService"
+
+var _ re.Service = (*grpcClient)(nil)
+
+type grpcClient struct {
+	stub         RulesEngineServiceClient
+	timeout      time.Duration
+	info         endpoint.Endpoint
+	createStream endpoint.Endpoint
+	listStreams  endpoint.Endpoint
+	viewStream   endpoint.Endpoint
+	viewStreams  endpoint.Endpoint
+	deleteStream endpoint.Endpoint
+	createTable  endpoint.Endpoint
+	listTables   endpoint.Endpoint
+	viewTable    endpoint.Endpoint
+	deleteTable  endpoint.Endpoint
+	createRule   endpoint.Endpoint
+	updateRule   endpoint.Endpoint
+	patchRule    endpoint.Endpoint
+	cloneRule    endpoint.Endpoint
+	validateRule endpoint.Endpoint
+	testRule     endpoint.Endpoint
+	replayRule   endpoint.Endpoint
+	pushTail     endpoint.Endpoint
+	ingest       endpoint.Endpoint
+	viewRule     endpoint.Endpoint
+	viewRules    endpoint.Endpoint
+	listRules    endpoint.Endpoint
+	searchRules  endpoint.Endpoint
+	deleteRule   endpoint.Endpoint
+	startRule    endpoint.Endpoint
+	stopRule     endpoint.Endpoint
+	restartRule  endpoint.Endpoint
+	saveDraft    endpoint.Endpoint
+	publishRule  endpoint.Endpoint
+	unpublish    endpoint.Endpoint
+	restoreRule  endpoint.Endpoint
+	ruleStatus   endpoint.Endpoint
+	ruleTopo     endpoint.Endpoint
+	ruleLogs     endpoint.Endpoint
+	reconcile    endpoint.Endpoint
+	orphans      endpoint.Endpoint
+	restore      endpoint.Endpoint
+	exportRules  endpoint.Endpoint
+	importRules  endpoint.Endpoint
+	bulkCreate   endpoint.Endpoint
+	bulkDelete   endpoint.Endpoint
+	startRules   endpoint.Endpoint
+	stopRules    endpoint.Endpoint
+	allStreams   endpoint.Endpoint
+	allRules     endpoint.Endpoint
+	viewQuota    endpoint.Endpoint
+	setQuota     endpoint.Endpoint
+	removeQuota  endpoint.Endpoint
+	share        endpoint.Endpoint
+	listShares   endpoint.Endpoint
+	unshare      endpoint.Endpoint
+	rename       endpoint.Endpoint
+	auditEvents  endpoint.Endpoint
+	instances    endpoint.Endpoint
+	assignInst   endpoint.Endpoint
+	saveGw       endpoint.Endpoint
+	listGws      endpoint.Endpoint
+	removeGw     endpoint.Endpoint
+	deploy       endpoint.Endpoint
+	undeploy     endpoint.Endpoint
+	deployments  endpoint.Endpoint
+	deployFleet  endpoint.Endpoint
+	retryDeploy  endpoint.Endpoint
+	rollout      endpoint.Endpoint
+	stats        endpoint.Endpoint
+	setAlerts    endpoint.Endpoint
+	viewAlerts   endpoint.Endpoint
+	removeAlerts endpoint.Endpoint
+	createHook   endpoint.Endpoint
+	listHooks    endpoint.Endpoint
+	removeHook   endpoint.Endpoint
+	createFolder endpoint.Endpoint
+	listFolders  endpoint.Endpoint
+	removeFolder endpoint.Endpoint
+	moveRules    endpoint.Endpoint
+	pause        endpoint.Endpoint
+	resume       endpoint.Endpoint
+	viewMaint    endpoint.Endpoint
+	createChain  endpoint.Endpoint
+	listChains   endpoint.Endpoint
+	removeChain  endpoint.Endpoint
+	saveSecret   endpoint.Endpoint
+	listSecrets  endpoint.Endpoint
+	removeSecret endpoint.Endpoint
+	createTmpl   endpoint.Endpoint
+	viewTmpl     endpoint.Endpoint
+	listTmpls    endpoint.Endpoint
+	removeTmpl   endpoint.Endpoint
+	instantiate  endpoint.Endpoint
+	createPlugin endpoint.Endpoint
+	listPlugins  endpoint.Endpoint
+	deletePlugin endpoint.Endpoint
+	registerSvc  endpoint.Endpoint
+	listSvcs     endpoint.Endpoint
+	deleteSvc    endpoint.Endpoint
+	listFuncs    endpoint.Endpoint
+	saveConfKey  endpoint.Endpoint
+	listConfKeys endpoint.Endpoint
+	delConfKey   endpoint.Endpoint
+}
+
+// NewClient returns new gRPC client instance. The client implements the rules
+// engine service, so other services can use it as if the service was local.
+func NewClient(conn *grpc.ClientConn, timeout time.Duration) re.Service {
+	newEndpoint := func(method string, enc kitgrpc.EncodeRequestFunc, dec kitgrpc.DecodeResponseFunc, res interface{}) endpoint.Endpoint {
+		return kitgrpc.NewClient(conn, svcName, method, enc, dec, res, kitgrpc.ClientBefore(writeRequestID, writeRevision)).Endpoint()
+	}
+
+	return &grpcClient{
+		stub:         NewRulesEngineServiceClient(conn),
+		timeout:      timeout,
+		info:         newEndpoint("Info", encodeInfoRequest, decodeInfoResponse, InfoRes{}),
+		createStream: newEndpoint("CreateStream", encodeCreateStreamRequest, decodeResultResponse, Result{}),
+		listStreams:  newEndpoint("ListStreams", encodeListRequest, decodeStreamsPageResponse, StreamsPage{}),
+		viewStream:   newEndpoint("ViewStream", encodeEntityRequest, decodeStreamResponse, Stream{}),
+		viewStreams:  newEndpoint("ViewStreams", encodeBatchRequest, decodeStreamsBatchResponse, StreamsBatch{}),
+		deleteStream: newEndpoint("DeleteStream", encodeDeleteStreamRequest, decodeResultResponse, Result{}),
+		createTable:  newEndpoint("CreateTable", encodeCreateTableRequest, decodeResultResponse, Result{}),
+		listTables:   newEndpoint("ListTables", encodeListRequest, decodeTablesPageResponse, TablesPage{}),
+		viewTable:    newEndpoint("ViewTable", encodeEntityRequest, decodeTableResponse, Table{}),
+		deleteTable:  newEndpoint("DeleteTable", encodeEntityRequest, decodeResultResponse, Result{}),
+		createRule:   newEndpoint("CreateRule", encodeRuleRequest, decodeResultResponse, Result{}),
+		updateRule:   newEndpoint("UpdateRule", encodeRuleRequest, decodeResultResponse, Result{}),
+		patchRule:    newEndpoint("PatchRule", encodePatchRuleRequest, decodeResultResponse, Result{}),
+		cloneRule:    newEndpoint("CloneRule", encodeCloneRuleRequest, decodeResultResponse, Result{}),
+		validateRule: newEndpoint("ValidateRule", encodeRuleRequest, decodeRuleValidationResponse, RuleValidation{}),
+		testRule:     newEndpoint("TestRule", encodeTestRuleRequest, decodeTrialResultResponse, TrialResult{}),
+		replayRule:   newEndpoint("ReplayRule", encodeReplayRuleRequest, decodeReplayResultResponse, ReplayResult{}),
+		pushTail:     newEndpoint("PushTail", encodePushTailRequest, decodePushTailResponse, PushTailRes{}),
+		ingest:       newEndpoint("Ingest", encodeIngestRequest, decodeIngestResponse, IngestRes{}),
+		viewRule:     newEndpoint("ViewRule", encodeEntityRequest, decodeRuleResponse, Rule{}),
+		viewRules:    newEndpoint("ViewRules", encodeBatchRequest, decodeRulesBatchResponse, RulesBatch{}),
+		listRules:    newEndpoint("ListRules", encodeListRequest, decodeRulesPageResponse, RulesPage{}),
+		searchRules:  newEndpoint("SearchRules", encodeSearchRulesRequest, decodeRulesPageResponse, RulesPage{}),
+		deleteRule:   newEndpoint("DeleteRule", encodeEntityRequest, decodeResultResponse, Result{}),
+		startRule:    newEndpoint("StartRule", encodeEntityRequest, decodeResultResponse, Result{}),
+		stopRule:     newEndpoint("StopRule", encodeEntityRequest, decodeResultResponse, Result{}),
+		restartRule:  newEndpoint("RestartRule", encodeEntityRequest, decodeResultResponse, Result{}),
+		saveDraft:    newEndpoint("SaveDraft", encodeRuleRequest, decodeResultResponse, Result{}),
+		publishRule:  newEndpoint("PublishRule", encodeEntityRequest, decodeResultResponse, Result{}),
+		unpublish:    newEndpoint("UnpublishRule", encodeEntityRequest, decodeResultResponse, Result{}),
+		restoreRule:  newEndpoint("RestoreRule", encodeEntityRequest, decodeResultResponse, Result{}),
+		ruleStatus:   newEndpoint("RuleStatus", encodeEntityRequest, decodeRuleStatusResponse, RuleStatusRes{}),
+		ruleTopo:     newEndpoint("RuleTopology", encodeEntityRequest, decodeRuleTopologyResponse, RuleTopologyRes{}),
+		ruleLogs:     newEndpoint("RuleLogs", encodeRuleLogsRequest, decodeRuleLogsResponse, RuleLogsRes{}),
+		reconcile:    newEndpoint("Reconcile", encodeReconcileRequest, decodeDriftReportResponse, DriftReport{}),
+		orphans:      newEndpoint("CollectOrphans", encodeCollectOrphansRequest, decodeOrphanReportResponse, OrphanReport{}),
+		restore:      newEndpoint("Restore", encodeRestoreRequest, decodeRestoreReportResponse, RestoreReport{}),
+		exportRules:  newEndpoint("ExportRuleset", encodeExportRulesetRequest, decodeRulesetResponse, Ruleset{}),
+		importRules:  newEndpoint("ImportRuleset", encodeImportRulesetRequest, decodeImportReportResponse, ImportReport{}),
+		bulkCreate:   newEndpoint("BulkCreate", encodeBulkCreateRequest, decodeBulkReportResponse, BulkReport{}),
+		bulkDelete:   newEndpoint("BulkDelete", encodeBulkDeleteRequest, decodeBulkReportResponse, BulkReport{}),
+		startRules:   newEndpoint("StartRules", encodeRuleSelectionRequest, decodeBulkReportResponse, BulkReport{}),
+		stopRules:    newEndpoint("StopRules", encodeRuleSelectionRequest, decodeBulkReportResponse, BulkReport{}),
+		allStreams:   newEndpoint("ListAllStreams", encodeListAllRequest, decodeAllStreamsResponse, AllStreams{}),
+		allRules:     newEndpoint("ListAllRules", encodeListAllRequest, decodeAllRulesResponse, AllRules{}),
+		viewQuota:    newEndpoint("ViewQuota", encodeEntityRequest, decodeUserQuotaResponse, UserQuota{}),
+		setQuota:     newEndpoint("SetQuota", encodeQuotaRequest, decodeUserQuotaResponse, UserQuota{}),
+		removeQuota:  newEndpoint("RemoveQuota", encodeEntityRequest, decodeRemoveQuotaResponse, RemoveQuotaRes{}),
+		share:        newEndpoint("ShareEntity", encodeShareRequest, decodeShareResponse, Share{}),
+		listShares:   newEndpoint("ListShares", encodeSharesRequest, decodeSharesResponse, SharesRes{}),
+		unshare:      newEndpoint("UnshareEntity", encodeUnshareRequest, decodeUnshareResponse, UnshareRes{}),
+		rename:       newEndpoint("Rename", encodeRenameRequest, decodeResultResponse, Result{}),
+		auditEvents:  newEndpoint("ListAuditEvents", encodeAuditRequest, decodeAuditPageResponse, AuditPage{}),
+		instances:    newEndpoint("ListInstances", encodeListAllRequest, decodeInstancesResponse, InstancesRes{}),
+		assignInst:   newEndpoint("AssignInstance", encodeAssignInstanceRequest, decodeAssignmentResponse, Assignment{}),
+		saveGw:       newEndpoint("SaveGateway", encodeGatewayRequest, decodeGatewayResponse, Gateway{}),
+		listGws:      newEndpoint("ListGateways", encodeListAllRequest, decodeGatewaysResponse, GatewaysRes{}),
+		removeGw:     newEndpoint("RemoveGateway", encodeEntityRequest, decodeRemoveGatewayResponse, RemoveGatewayRes{}),
+		deploy:       newEndpoint("DeployRule", encodeDeployRequest, decodeDeploymentResponse, Deployment{}),
+		undeploy:     newEndpoint("UndeployRule", encodeDeployRequest, decodeDeploymentResponse, Deployment{}),
+		deployments:  newEndpoint("ListDeployments", encodeEntityRequest, decodeDeploymentsResponse, DeploymentsRes{}),
+		deployFleet:  newEndpoint("DeployFleet", encodeDeployFleetRequest, decodeRolloutResponse, Rollout{}),
+		retryDeploy:  newEndpoint("RetryDeployments", encodeEntityRequest, decodeRolloutResponse, Rollout{}),
+		rollout:      newEndpoint("ViewRollout", encodeEntityRequest, decodeRolloutResponse, Rollout{}),
+		stats:        newEndpoint("EngineStats", encodeListAllRequest, decodeEngineStatsResponse, EngineStatsRes{}),
+		setAlerts:    newEndpoint("SetAlertPolicy", encodeAlertPolicyRequest, decodeAlertPolicyResponse, AlertPolicy{}),
+		viewAlerts:   newEndpoint("ViewAlertPolicy", encodeListAllRequest, decodeAlertPolicyResponse, AlertPolicy{}),
+		removeAlerts: newEndpoint("RemoveAlertPolicy", encodeListAllRequest, decodeRemoveAlertPolicyResponse, RemoveAlertPolicyRes{}),
+		createHook:   newEndpoint("CreateWebhook", encodeWebhookRequest, decodeWebhookResponse, Webhook{}),
+		listHooks:    newEndpoint("ListWebhooks", encodeListAllRequest, decodeWebhooksResponse, WebhooksRes{}),
+		removeHook:   newEndpoint("RemoveWebhook", encodeEntityRequest, decodeRemoveWebhookResponse, RemoveWebhookRes{}),
+		createFolder: newEndpoint("CreateFolder", encodeFolderRequest, decodeFolderResponse, Folder{}),
+		listFolders:  newEndpoint("ListFolders", encodeListAllRequest, decodeFoldersResponse, FoldersRes{}),
+		removeFolder: newEndpoint("RemoveFolder", encodeEntityRequest, decodeRemoveFolderResponse, RemoveFolderRes{}),
+		moveRules:    newEndpoint("MoveRules", encodeMoveRulesRequest, decodeBulkReportResponse, BulkReport{}),
+		pause:        newEndpoint("Pause", encodeListAllRequest, decodeBulkReportResponse, BulkReport{}),
+		resume:       newEndpoint("Resume", encodeListAllRequest, decodeBulkReportResponse, BulkReport{}),
+		viewMaint:    newEndpoint("ViewMaintenance", encodeListAllRequest, decodeMaintenanceResponse, Maintenance{}),
+		createChain:  newEndpoint("CreateChain", encodeChainRequest, decodeChainResponse, Chain{}),
+		listChains:   newEndpoint("ListChains", encodeListAllRequest, decodeChainsResponse, ChainsRes{}),
+		removeChain:  newEndpoint("RemoveChain", encodeEntityRequest, decodeRemoveChainResponse, RemoveChainRes{}),
+		saveSecret:   newEndpoint("SaveSecret", encodeSecretRequest, decodeSecretResponse, Secret{}),
+		listSecrets:  newEndpoint("ListSecrets", encodeListAllRequest, decodeSecretsResponse, SecretsRes{}),
+		removeSecret: newEndpoint("RemoveSecret", encodeEntityRequest, decodeRemoveSecretResponse, RemoveSecretRes{}),
+		createTmpl:   newEndpoint("CreateTemplate", encodeTemplateRequest, decodeTemplateResponse, Template{}),
+		viewTmpl:     newEndpoint("ViewTemplate", encodeEntityRequest, decodeTemplateResponse, Template{}),
+		listTmpls:    newEndpoint("ListTemplates", encodeListTemplatesRequest, decodeTemplatesResponse, TemplatesRes{}),
+		removeTmpl:   newEndpoint("RemoveTemplate", encodeEntityRequest, decodeRemoveTemplateResponse, RemoveTemplateRes{}),
+		instantiate:  newEndpoint("InstantiateTemplate", encodeInstantiateRequest, decodeRuleResponse, Rule{}),
+		createPlugin: newEndpoint("CreatePlugin", encodePluginRequest, decodeResultResponse, Result{}),
+		listPlugins:  newEndpoint("ListPlugins", encodeListPluginsRequest, decodePluginsResponse, PluginsRes{}),
+		deletePlugin: newEndpoint("DeletePlugin", encodeDeletePluginRequest, decodeResultResponse, Result{}),
+		registerSvc:  newEndpoint("RegisterExternalService", encodeExternalServiceRequest, decodeResultResponse, Result{}),
+		listSvcs:     newEndpoint("ListExternalServices", encodeListExternalServicesRequest, decodeExternalServicesResponse, ExternalServicesRes{}),
+		deleteSvc:    newEndpoint("DeleteExternalService", encodeEntityRequest, decodeResultResponse, Result{}),
+		listFuncs:    newEndpoint("ListExternalFunctions", encodeListExternalFunctionsRequest, decodeExternalFunctionsResponse, ExternalFunctionsRes{}),
+		saveConfKey:  newEndpoint("SaveConfKey", encodeConfKeyRequest, decodeResultResponse, Result{}),
+		listConfKeys: newEndpoint("ListConfKeys", encodeListConfKeysRequest, decodeConfKeysResponse, ConfKeysRes{}),
+		delConfKey:   newEndpoint("DeleteConfKey", encodeEntityRequest, decodeResultResponse, Result{}),
+	}
+}
+
+func (client grpcClient) Info(ctx context.Context) (re.Info, error) {
+	res, err := client.call(ctx, client.info, nil)
+	if err != nil {
+		return re.Info{}, err
+	}
+
+	return res.(re.Info), nil
+}
+
+func (client grpcClient) CreateStream(ctx context.Context, token string, def re.StreamDef, update bool) (re.Result, error) {
+	req := createStreamReq{token: token, def: def, update: update}
+	return client.result(ctx, client.createStream, req)
+}
+
+func (client grpcClient) ListStreams(ctx context.Context, token string, pm re.PageMetadata) (re.StreamsPage, error) {
+	res, err := client.call(ctx, client.listStreams, listReq{token: token, pm: pm})
+	if err != nil {
+		return re.StreamsPage{}, err
+	}
+
+	return res.(re.StreamsPage), nil
+}
+
+func (client grpcClient) ViewStream(ctx context.Context, token, name string) (re.Stream, error) {
+	res, err := client.call(ctx, client.viewStream, entityReq{token: token, id: name})
+	if err != nil {
+		return re.Stream{}, err
+	}
+
+	return res.(re.Stream), nil
+}
+
+func (client grpcClient) ViewStreams(ctx context.Context, token string, names []string) (re.StreamsBatch, error) {
+	res, err := client.call(ctx, client.viewStreams, batchReq{token: token, names: names})
+	if err != nil {
+		return re.StreamsBatch{}, err
+	}
+
+	return res.(re.StreamsBatch), nil
+}
+
+func (client grpcClient) DeleteStream(ctx context.Context, token, name string, cascade bool) (re.Result, error) {
+	return client.result(ctx, client.deleteStream, deleteStreamReq{entityReq: entityReq{token: token, id: name}, cascade: cascade})
+}
+
+func (client grpcClient) CreateTable(ctx context.Context, token string, def re.TableDef) (re.Result, error) {
+	return client.result(ctx, client.createTable, createTableReq{token: token, def: def})
+}
+
+func (client grpcClient) ListTables(ctx context.Context, token string, pm re.PageMetadata) (re.TablesPage, error) {
+	res, err := client.call(ctx, client.listTables, listReq{token: token, pm: pm})
+	if err != nil {
+		return re.TablesPage{}, err
+	}
+
+	return res.(re.TablesPage), nil
+}
+
+func (client grpcClient) ViewTable(ctx context.Context, token, name string) (re.Table, error) {
+	res, err := client.call(ctx, client.viewTable, entityReq{token: token, id: name})
+	if err != nil {
+		return re.Table{}, err
+	}
+
+	return res.(re.Table), nil
+}
+
+func (client grpcClient) DeleteTable(ctx context.Context, token, name string) (re.Result, error) {
+	return client.result(ctx, client.deleteTable, entityReq{token: token, id: name})
+}
+
+func (client grpcClient) CreateRule(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	return client.result(ctx, client.createRule, ruleReq{token: token, rule: rule})
+}
+
+func (client grpcClient) UpdateRule(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	return client.result(ctx, client.updateRule, ruleReq{token: token, rule: rule})
+}
+
+func (client grpcClient) PatchRule(ctx context.Context, token, id string, patch re.RulePatch) (re.Result, error) {
+	return client.result(ctx, client.patchRule, patchRuleReq{token: token, id: id, patch: patch})
+}
+
+func (client grpcClient) CloneRule(ctx context.Context, token, id, newID, channel string) (re.Result, error) {
+	return client.result(ctx, client.cloneRule, cloneRuleReq{token: token, id: id, newID: newID, channel: channel})
+}
+
+func (client grpcClient) ValidateRule(ctx context.Context, token string, rule re.Rule) (re.RuleValidation, error) {
+	res, err := client.call(ctx, client.validateRule, ruleReq{token: token, rule: rule})
+	if err != nil {
+		return re.RuleValidation{}, err
+	}
+
+	return res.(re.RuleValidation), nil
+}
+
+func (client grpcClient) TestRule(ctx context.Context, token string, trial re.RuleTrial) (re.TrialResult, error) {
+	res, err := client.call(ctx, client.testRule, testRuleReq{token: token, trial: trial})
+	if err != nil {
+		return re.TrialResult{}, err
+	}
+
+	return res.(re.TrialResult), nil
+}
+
+func (client grpcClient) ReplayRule(ctx context.Context, token, id string, from, to time.Time) (re.ReplayResult, error) {
+	res, err := client.call(ctx, client.replayRule, replayRuleReq{token: token, id: id, from: from, to: to})
+	if err != nil {
+		return re.ReplayResult{}, err
+	}
+
+	return res.(re.ReplayResult), nil
+}
+
+// TailRule streams the rule results from the server. Unlike the other
+// methods, it isn't limited by the client timeout, since the stream lasts
+// until the context is done.
+func (client grpcClient) TailRule(ctx context.Context, token, id string) (<-chan map[string]interface{}, error) {
+	if rid := re.RequestID(ctx); rid != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestIDKey, rid)
+	}
+	stream, err := client.stub.TailRule(ctx, &EntityReq{Token: token, Id: id})
+	if err != nil {
+		return nil, decodeError(err)
+	}
+	// The server sends the headers once the sink is attached, or ends the
+	// stream with the error without sending them.
+	md, err := stream.Header()
+	if err != nil {
+		return nil, decodeError(err)
+	}
+	if md == nil {
+		_, err := stream.Recv()
+		return nil, decodeError(err)
+	}
+
+	results := make(chan map[string]interface{})
+	go func() {
+		defer close(results)
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case results <- res.AsMap():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
+
+func (client grpcClient) PushTail(ctx context.Context, session string, result map[string]interface{}) error {
+	_, err := client.call(ctx, client.pushTail, pushTailReq{session: session, result: result})
+	return err
+}
+
+func (client grpcClient) Ingest(ctx context.Context, key, stream string, payload []byte) error {
+	_, err := client.call(ctx, client.ingest, ingestReq{key: key, stream: stream, payload: payload})
+	return err
+}
+
+// WatchRules streams the rule state changes from the server. Like TailRule,
+// it isn't limited by the client timeout.
+func (client grpcClient) WatchRules(ctx context.Context, token string) (<-chan re.RuleStateChange, error) {
+	if rid := re.RequestID(ctx); rid != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, requestIDKey, rid)
+	}
+	stream, err := client.stub.WatchRules(ctx, &ListAllReq{Token: token})
+	if err != nil {
+		return nil, decodeError(err)
+	}
+	// The server sends the headers once the watch starts, or ends the
+	// stream with the error without sending them.
+	md, err := stream.Header()
+	if err != nil {
+		return nil, decodeError(err)
+	}
+	if md == nil {
+		_, err := stream.Recv()
+		return nil, decodeError(err)
+	}
+
+	changes := make(chan re.RuleStateChange)
+	go func() {
+		defer close(changes)
+		for {
+			c, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case changes <- fromProtoRuleStateChange(c):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+func (client grpcClient) ViewRule(ctx context.Context, token, id string) (re.Rule, error) {
+	res, err := client.call(ctx, client.viewRule, entityReq{token: token, id: id})
+	if err != nil {
+		return re.Rule{}, err
+	}
+
+	return res.(re.Rule), nil
+}
+
+func (client grpcClient) ViewRules(ctx context.Context, token string, ids []string) (re.RulesBatch, error) {
+	res, err := client.call(ctx, client.viewRules, batchReq{token: token, names: ids})
+	if err != nil {
+		return re.RulesBatch{}, err
+	}
+
+	return res.(re.RulesBatch), nil
+}
+
+func (client grpcClient) ListRules(ctx context.Context, token string, pm re.PageMetadata) (re.RulesPage, error) {
+	res, err := client.call(ctx, client.listRules, listReq{token: token, pm: pm})
+	if err != nil {
+		return re.RulesPage{}, err
+	}
+
+	return res.(re.RulesPage), nil
+}
+
+func (client grpcClient) SearchRules(ctx context.Context, token string, q re.RuleQuery, pm re.PageMetadata) (re.RulesPage, error) {
+	res, err := client.call(ctx, client.searchRules, searchRulesReq{listReq: listReq{token: token, pm: pm}, q: q})
+	if err != nil {
+		return re.RulesPage{}, err
+	}
+
+	return res.(re.RulesPage), nil
+}
+
+func (client grpcClient) DeleteRule(ctx context.Context, token, id string) (re.Result, error) {
+	return client.result(ctx, client.deleteRule, entityReq{token: token, id: id})
+}
+
+func (client grpcClient) StartRule(ctx context.Context, token, id string) (re.Result, error) {
+	return client.result(ctx, client.startRule, entityReq{token: token, id: id})
+}
+
+func (client grpcClient) StopRule(ctx context.Context, token, id string) (re.Result, error) {
+	return client.result(ctx, client.stopRule, entityReq{token: token, id: id})
+}
+
+func (client grpcClient) RestartRule(ctx context.Context, token, id string) (re.Result, error) {
+	return client.result(ctx, client.restartRule, entityReq{token: token, id: id})
+}
+
+func (client grpcClient) SaveDraft(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	return client.result(ctx, client.saveDraft, ruleReq{token: token, rule: rule})
+}
+
+func (client grpcClient) PublishRule(ctx context.Context, token, id string) (re.Result, error) {
+	return client.result(ctx, client.publishRule, entityReq{token: token, id: id})
+}
+
+func (client grpcClient) UnpublishRule(ctx context.Context, token, id string) (re.Result, error) {
+	return client.result(ctx, client.unpublish, entityReq{token: token, id: id})
+}
+
+func (client grpcClient) RestoreRule(ctx context.Context, token, id string) (re.Result, error) {
+	return client.result(ctx, client.restoreRule, entityReq{token: token, id: id})
+}
+
+func (client grpcClient) RuleStatus(ctx context.Context, token, id string) (re.RuleStatus, error) {
+	res, err := client.call(ctx, client.ruleStatus, entityReq{token: token, id: id})
+	if err != nil {
+		return re.RuleStatus{}, err
+	}
+
+	return res.(re.RuleStatus), nil
+}
+
+func (client grpcClient) RuleTopology(ctx context.Context, token, id string) (re.RuleTopology, error) {
+	res, err := client.call(ctx, client.ruleTopo, entityReq{token: token, id: id})
+	if err != nil {
+		return re.RuleTopology{}, err
+	}
+
+	return res.(re.RuleTopology), nil
+}
+
+func (client grpcClient) RuleLogs(ctx context.Context, token, id string, since time.Time) ([]re.LogEntry, error) {
+	res, err := client.call(ctx, client.ruleLogs, ruleLogsReq{token: token, id: id, since: since})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.LogEntry), nil
+}
+
+func (client grpcClient) Reconcile(ctx context.Context, token string, repair bool) (re.DriftReport, error) {
+	res, err := client.call(ctx, client.reconcile, reconcileReq{token: token, repair: repair})
+	if err != nil {
+		return re.DriftReport{}, err
+	}
+
+	return res.(re.DriftReport), nil
+}
+
+func (client grpcClient) CollectOrphans(ctx context.Context, token string, policy re.OrphanPolicy) (re.OrphanReport, error) {
+	res, err := client.call(ctx, client.orphans, collectOrphansReq{token: token, policy: policy})
+	if err != nil {
+		return re.OrphanReport{}, err
+	}
+
+	return res.(re.OrphanReport), nil
+}
+
+func (client grpcClient) Restore(ctx context.Context, token string, dryRun bool) (re.RestoreReport, error) {
+	res, err := client.call(ctx, client.restore, restoreReq{token: token, dryRun: dryRun})
+	if err != nil {
+		return re.RestoreReport{}, err
+	}
+
+	return res.(re.RestoreReport), nil
+}
+
+func (client grpcClient) ExportRuleset(ctx context.Context, token string) (re.Ruleset, error) {
+	res, err := client.call(ctx, client.exportRules, exportRulesetReq{token: token})
+	if err != nil {
+		return re.Ruleset{}, err
+	}
+
+	return res.(re.Ruleset), nil
+}
+
+func (client grpcClient) ImportRuleset(ctx context.Context, token string, rs re.Ruleset, conflict string) (re.ImportReport, error) {
+	res, err := client.call(ctx, client.importRules, importRulesetReq{token: token, rs: rs, conflict: conflict})
+	if err != nil {
+		return re.ImportReport{}, err
+	}
+
+	return res.(re.ImportReport), nil
+}
+
+func (client grpcClient) BulkCreate(ctx context.Context, token string, rs re.Ruleset) (re.BulkReport, error) {
+	res, err := client.call(ctx, client.bulkCreate, bulkCreateReq{token: token, rs: rs})
+	if err != nil {
+		return re.BulkReport{}, err
+	}
+
+	return res.(re.BulkReport), nil
+}
+
+func (client grpcClient) BulkDelete(ctx context.Context, token string, bd re.BulkDeletion) (re.BulkReport, error) {
+	res, err := client.call(ctx, client.bulkDelete, bulkDeleteReq{token: token, bd: bd})
+	if err != nil {
+		return re.BulkReport{}, err
+	}
+
+	return res.(re.BulkReport), nil
+}
+
+func (client grpcClient) StartRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	res, err := client.call(ctx, client.startRules, ruleSelectionReq{token: token, sel: sel})
+	if err != nil {
+		return re.BulkReport{}, err
+	}
+
+	return res.(re.BulkReport), nil
+}
+
+func (client grpcClient) StopRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	res, err := client.call(ctx, client.stopRules, ruleSelectionReq{token: token, sel: sel})
+	if err != nil {
+		return re.BulkReport{}, err
+	}
+
+	return res.(re.BulkReport), nil
+}
+
+func (client grpcClient) ListAllStreams(ctx context.Context, token string) (re.AllStreams, error) {
+	res, err := client.call(ctx, client.allStreams, listAllReq{token: token})
+	if err != nil {
+		return re.AllStreams{}, err
+	}
+
+	return res.(re.AllStreams), nil
+}
+
+func (client grpcClient) ListAllRules(ctx context.Context, token string) (re.AllRules, error) {
+	res, err := client.call(ctx, client.allRules, listAllReq{token: token})
+	if err != nil {
+		return re.AllRules{}, err
+	}
+
+	return res.(re.AllRules), nil
+}
+
+func (client grpcClient) ViewQuota(ctx context.Context, token, userID string) (re.UserQuota, error) {
+	res, err := client.call(ctx, client.viewQuota, entityReq{token: token, id: userID})
+	if err != nil {
+		return re.UserQuota{}, err
+	}
+
+	return res.(re.UserQuota), nil
+}
+
+func (client grpcClient) SetQuota(ctx context.Context, token, userID string, q re.Quota) (re.UserQuota, error) {
+	res, err := client.call(ctx, client.setQuota, quotaReq{token: token, userID: userID, quota: q})
+	if err != nil {
+		return re.UserQuota{}, err
+	}
+
+	return res.(re.UserQuota), nil
+}
+
+func (client grpcClient) RemoveQuota(ctx context.Context, token, userID string) error {
+	_, err := client.call(ctx, client.removeQuota, entityReq{token: token, id: userID})
+	return err
+}
+
+func (client grpcClient) ShareEntity(ctx context.Context, token, kind, name string, s re.Share) (re.Share, error) {
+	res, err := client.call(ctx, client.share, shareReq{token: token, kind: kind, name: name, share: s})
+	if err != nil {
+		return re.Share{}, err
+	}
+
+	return res.(re.Share), nil
+}
+
+func (client grpcClient) ListShares(ctx context.Context, token, kind, name string) ([]re.Share, error) {
+	res, err := client.call(ctx, client.listShares, sharesReq{token: token, kind: kind, name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.Share), nil
+}
+
+func (client grpcClient) UnshareEntity(ctx context.Context, token, kind, name, grantee string) error {
+	_, err := client.call(ctx, client.unshare, unshareReq{token: token, kind: kind, name: name, grantee: grantee})
+	return err
+}
+
+func (client grpcClient) Rename(ctx context.Context, token, kind, name, newName string) (re.Result, error) {
+	return client.result(ctx, client.rename, renameReq{token: token, kind: kind, name: name, newName: newName})
+}
+
+func (client grpcClient) ListAuditEvents(ctx context.Context, token string, q re.AuditQuery) (re.AuditPage, error) {
+	res, err := client.call(ctx, client.auditEvents, auditReq{token: token, q: q})
+	if err != nil {
+		return re.AuditPage{}, err
+	}
+
+	return res.(re.AuditPage), nil
+}
+
+func (client grpcClient) ListInstances(ctx context.Context, token string) ([]re.Instance, error) {
+	res, err := client.call(ctx, client.instances, listAllReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.Instance), nil
+}
+
+func (client grpcClient) AssignInstance(ctx context.Context, token, userID, instance string) (re.Assignment, error) {
+	res, err := client.call(ctx, client.assignInst, assignInstanceReq{token: token, userID: userID, instance: instance})
+	if err != nil {
+		return re.Assignment{}, err
+	}
+
+	return res.(re.Assignment), nil
+}
+
+func (client grpcClient) SaveGateway(ctx context.Context, token string, gw re.Gateway) (re.Gateway, error) {
+	res, err := client.call(ctx, client.saveGw, gatewayReq{token: token, gw: gw})
+	if err != nil {
+		return re.Gateway{}, err
+	}
+
+	return res.(re.Gateway), nil
+}
+
+func (client grpcClient) ListGateways(ctx context.Context, token string) ([]re.Gateway, error) {
+	res, err := client.call(ctx, client.listGws, listAllReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.Gateway), nil
+}
+
+func (client grpcClient) RemoveGateway(ctx context.Context, token, id string) error {
+	_, err := client.call(ctx, client.removeGw, entityReq{token: token, id: id})
+	return err
+}
+
+func (client grpcClient) DeployRule(ctx context.Context, token, id, gateway string) (re.Deployment, error) {
+	res, err := client.call(ctx, client.deploy, deployReq{token: token, id: id, gateway: gateway})
+	if err != nil {
+		return re.Deployment{}, err
+	}
+
+	return res.(re.Deployment), nil
+}
+
+func (client grpcClient) UndeployRule(ctx context.Context, token, id, gateway string) (re.Deployment, error) {
+	res, err := client.call(ctx, client.undeploy, deployReq{token: token, id: id, gateway: gateway})
+	if err != nil {
+		return re.Deployment{}, err
+	}
+
+	return res.(re.Deployment), nil
+}
+
+func (client grpcClient) ListDeployments(ctx context.Context, token, id string) ([]re.Deployment, error) {
+	res, err := client.call(ctx, client.deployments, entityReq{token: token, id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.Deployment), nil
+}
+
+func (client grpcClient) DeployFleet(ctx context.Context, token, id string, labels map[string]string) (re.Rollout, error) {
+	res, err := client.call(ctx, client.deployFleet, deployFleetReq{token: token, id: id, labels: labels})
+	if err != nil {
+		return re.Rollout{}, err
+	}
+
+	return res.(re.Rollout), nil
+}
+
+func (client grpcClient) RetryDeployments(ctx context.Context, token, id string) (re.Rollout, error) {
+	res, err := client.call(ctx, client.retryDeploy, entityReq{token: token, id: id})
+	if err != nil {
+		return re.Rollout{}, err
+	}
+
+	return res.(re.Rollout), nil
+}
+
+func (client grpcClient) ViewRollout(ctx context.Context, token, id string) (re.Rollout, error) {
+	res, err := client.call(ctx, client.rollout, entityReq{token: token, id: id})
+	if err != nil {
+		return re.Rollout{}, err
+	}
+
+	return res.(re.Rollout), nil
+}
+
+func (client grpcClient) EngineStats(ctx context.Context, token string) (re.EngineStats, error) {
+	res, err := client.call(ctx, client.stats, listAllReq{token: token})
+	if err != nil {
+		return re.EngineStats{}, err
+	}
+
+	return res.(re.EngineStats), nil
+}
+
+func (client grpcClient) SetAlertPolicy(ctx context.Context, token string, p re.AlertPolicy) (re.AlertPolicy, error) {
+	res, err := client.call(ctx, client.setAlerts, alertPolicyReq{token: token, policy: p})
+	if err != nil {
+		return re.AlertPolicy{}, err
+	}
+
+	return res.(re.AlertPolicy), nil
+}
+
+func (client grpcClient) ViewAlertPolicy(ctx context.Context, token string) (re.AlertPolicy, error) {
+	res, err := client.call(ctx, client.viewAlerts, listAllReq{token: token})
+	if err != nil {
+		return re.AlertPolicy{}, err
+	}
+
+	return res.(re.AlertPolicy), nil
+}
+
+func (client grpcClient) RemoveAlertPolicy(ctx context.Context, token string) error {
+	_, err := client.call(ctx, client.removeAlerts, listAllReq{token: token})
+	return err
+}
+
+func (client grpcClient) CreateWebhook(ctx context.Context, token string, wh re.Webhook) (re.Webhook, error) {
+	res, err := client.call(ctx, client.createHook, webhookReq{token: token, wh: wh})
+	if err != nil {
+		return re.Webhook{}, err
+	}
+
+	return res.(re.Webhook), nil
+}
+
+func (client grpcClient) ListWebhooks(ctx context.Context, token string) ([]re.Webhook, error) {
+	res, err := client.call(ctx, client.listHooks, listAllReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.Webhook), nil
+}
+
+func (client grpcClient) RemoveWebhook(ctx context.Context, token, id string) error {
+	_, err := client.call(ctx, client.removeHook, entityReq{token: token, id: id})
+	return err
+}
+
+func (client grpcClient) CreateFolder(ctx context.Context, token string, f re.Folder) (re.Folder, error) {
+	res, err := client.call(ctx, client.createFolder, folderReq{token: token, folder: f})
+	if err != nil {
+		return re.Folder{}, err
+	}
+
+	return res.(re.Folder), nil
+}
+
+func (client grpcClient) ListFolders(ctx context.Context, token string) ([]re.Folder, error) {
+	res, err := client.call(ctx, client.listFolders, listAllReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.Folder), nil
+}
+
+func (client grpcClient) RemoveFolder(ctx context.Context, token, name string) error {
+	_, err := client.call(ctx, client.removeFolder, entityReq{token: token, id: name})
+	return err
+}
+
+func (client grpcClient) MoveRules(ctx context.Context, token, folder string, ids []string) (re.BulkReport, error) {
+	res, err := client.call(ctx, client.moveRules, moveRulesReq{token: token, folder: folder, ids: ids})
+	if err != nil {
+		return re.BulkReport{}, err
+	}
+
+	return res.(re.BulkReport), nil
+}
+
+func (client grpcClient) Pause(ctx context.Context, token string) (re.BulkReport, error) {
+	res, err := client.call(ctx, client.pause, listAllReq{token: token})
+	if err != nil {
+		return re.BulkReport{}, err
+	}
+
+	return res.(re.BulkReport), nil
+}
+
+func (client grpcClient) Resume(ctx context.Context, token string) (re.BulkReport, error) {
+	res, err := client.call(ctx, client.resume, listAllReq{token: token})
+	if err != nil {
+		return re.BulkReport{}, err
+	}
+
+	return res.(re.BulkReport), nil
+}
+
+func (client grpcClient) ViewMaintenance(ctx context.Context, token string) (re.Maintenance, error) {
+	res, err := client.call(ctx, client.viewMaint, listAllReq{token: token})
+	if err != nil {
+		return re.Maintenance{}, err
+	}
+
+	return res.(re.Maintenance), nil
+}
+
+func (client grpcClient) CreateChain(ctx context.Context, token string, c re.Chain) (re.Chain, error) {
+	res, err := client.call(ctx, client.createChain, chainReq{token: token, chain: c})
+	if err != nil {
+		return re.Chain{}, err
+	}
+
+	return res.(re.Chain), nil
+}
+
+func (client grpcClient) ListChains(ctx context.Context, token string) ([]re.Chain, error) {
+	res, err := client.call(ctx, client.listChains, listAllReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.Chain), nil
+}
+
+func (client grpcClient) RemoveChain(ctx context.Context, token, name string) error {
+	_, err := client.call(ctx, client.removeChain, entityReq{token: token, id: name})
+	return err
+}
+
+func (client grpcClient) SaveSecret(ctx context.Context, token string, s re.Secret) (re.Secret, error) {
+	res, err := client.call(ctx, client.saveSecret, secretReq{token: token, secret: s})
+	if err != nil {
+		return re.Secret{}, err
+	}
+
+	return res.(re.Secret), nil
+}
+
+func (client grpcClient) ListSecrets(ctx context.Context, token string) ([]re.Secret, error) {
+	res, err := client.call(ctx, client.listSecrets, listAllReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.Secret), nil
+}
+
+func (client grpcClient) RemoveSecret(ctx context.Context, token, name string) error {
+	_, err := client.call(ctx, client.removeSecret, entityReq{token: token, id: name})
+	return err
+}
+
+func (client grpcClient) CreateTemplate(ctx context.Context, token string, tmpl re.Template) (re.Template, error) {
+	res, err := client.call(ctx, client.createTmpl, templateReq{token: token, tmpl: tmpl})
+	if err != nil {
+		return re.Template{}, err
+	}
+
+	return res.(re.Template), nil
+}
+
+func (client grpcClient) ViewTemplate(ctx context.Context, token, name string) (re.Template, error) {
+	res, err := client.call(ctx, client.viewTmpl, entityReq{token: token, id: name})
+	if err != nil {
+		return re.Template{}, err
+	}
+
+	return res.(re.Template), nil
+}
+
+func (client grpcClient) ListTemplates(ctx context.Context, token string) ([]re.Template, error) {
+	res, err := client.call(ctx, client.listTmpls, listTemplatesReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.Template), nil
+}
+
+func (client grpcClient) RemoveTemplate(ctx context.Context, token, name string) error {
+	_, err := client.call(ctx, client.removeTmpl, entityReq{token: token, id: name})
+	return err
+}
+
+func (client grpcClient) InstantiateTemplate(ctx context.Context, token, name string, inst re.TemplateInstance) (re.Rule, error) {
+	res, err := client.call(ctx, client.instantiate, instantiateReq{token: token, name: name, inst: inst})
+	if err != nil {
+		return re.Rule{}, err
+	}
+
+	return res.(re.Rule), nil
+}
+
+func (client grpcClient) CreatePlugin(ctx context.Context, token, kind string, plugin re.Plugin) (re.Result, error) {
+	return client.result(ctx, client.createPlugin, pluginReq{token: token, kind: kind, plugin: plugin})
+}
+
+func (client grpcClient) ListPlugins(ctx context.Context, token, kind string) ([]string, error) {
+	res, err := client.call(ctx, client.listPlugins, listPluginsReq{token: token, kind: kind})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]string), nil
+}
+
+func (client grpcClient) DeletePlugin(ctx context.Context, token, kind, name string) (re.Result, error) {
+	return client.result(ctx, client.deletePlugin, deletePluginReq{token: token, kind: kind, name: name})
+}
+
+func (client grpcClient) RegisterExternalService(ctx context.Context, token string, es re.ExternalService) (re.Result, error) {
+	return client.result(ctx, client.registerSvc, externalServiceReq{token: token, service: es})
+}
+
+func (client grpcClient) ListExternalServices(ctx context.Context, token string) ([]string, error) {
+	res, err := client.call(ctx, client.listSvcs, listExternalServicesReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]string), nil
+}
+
+func (client grpcClient) DeleteExternalService(ctx context.Context, token, name string) (re.Result, error) {
+	return client.result(ctx, client.deleteSvc, entityReq{token: token, id: name})
+}
+
+func (client grpcClient) ListExternalFunctions(ctx context.Context, token string) ([]re.ExternalFunction, error) {
+	res, err := client.call(ctx, client.listFuncs, listExternalFunctionsReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]re.ExternalFunction), nil
+}
+
+func (client grpcClient) SaveConfKey(ctx context.Context, token, name string, conf re.MQTTConf) (re.Result, error) {
+	return client.result(ctx, client.saveConfKey, confKeyReq{token: token, name: name, conf: conf})
+}
+
+func (client grpcClient) ListConfKeys(ctx context.Context, token string) ([]string, error) {
+	res, err := client.call(ctx, client.listConfKeys, listConfKeysReq{token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.([]string), nil
+}
+
+func (client grpcClient) DeleteConfKey(ctx context.Context, token, name string) (re.Result, error) {
+	return client.result(ctx, client.delConfKey, entityReq{token: token, id: name})
+}
+
+// call invokes the endpoint with the client timeout and decodes gRPC errors
+// to the service errors.
+func (client grpcClient) call(ctx context.Context, e endpoint.Endpoint, req interface{}) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := e(ctx, req)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	return res, nil
+}
+
+func (client grpcClient) result(ctx context.Context, e endpoint.Endpoint, req interface{}) (re.Result, error) {
+	res, err := client.call(ctx, e, req)
+	if err != nil {
+		return re.Result{}, err
+	}
+
+	return res.(re.Result), nil
+}
+
+func encodeInfoRequest(_ context.Context, _ interface{}) (interface{}, error) {
+	return &InfoReq{}, nil
+}
+
+func encodeCreateStreamRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(createStreamReq)
+	return &CreateStreamReq{
+		Token:       req.token,
+		Name:        req.def.Name,
+		Topic:       req.def.Topic,
+		Fields:      toProtoFields(req.def.Fields),
+		Update:      req.update,
+		Type:        req.def.Type,
+		Format:      req.def.Format,
+		Delimiter:   req.def.Delimiter,
+		SchemaId:    req.def.SchemaID,
+		Senml:       req.def.SenML,
+		Description: req.def.Description,
+		Labels:      req.def.Labels,
+		ConfKey:     req.def.ConfKey,
+		Attributes:  toProtoAttributes(req.def.Attributes),
+	}, nil
+}
+
+func encodeCreateTableRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(createTableReq)
+	return &CreateTableReq{
+		Token:       req.token,
+		Name:        req.def.Name,
+		Topic:       req.def.Topic,
+		Fields:      toProtoFields(req.def.Fields),
+		Type:        req.def.Type,
+		Kind:        req.def.Kind,
+		Key:         req.def.Key,
+		Description: req.def.Description,
+		Labels:      req.def.Labels,
+	}, nil
+}
+
+func encodeListRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listReq)
+	return &ListReq{
+		Token:   req.token,
+		Offset:  req.pm.Offset,
+		Limit:   req.pm.Limit,
+		Name:    req.pm.Name,
+		Labels:  req.pm.Labels,
+		Owner:   req.pm.Owner,
+		Deleted: req.pm.Deleted,
+		Order:   req.pm.Order,
+		Dir:     req.pm.Dir,
+		Folder:  req.pm.Folder,
+	}, nil
+}
+
+func encodeSearchRulesRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(searchRulesReq)
+	list, err := encodeListRequest(ctx, req.listReq)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchRulesReq{List: list.(*ListReq), Stream: req.q.Stream, Channel: req.q.Channel}, nil
+}
+
+func encodeEntityRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(entityReq)
+	return &EntityReq{Token: req.token, Id: req.id}, nil
+}
+
+func encodeBatchRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(batchReq)
+	return &BatchReq{Token: req.token, Names: req.names}, nil
+}
+
+func encodeDeleteStreamRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(deleteStreamReq)
+	return &DeleteStreamReq{Token: req.token, Name: req.id, Cascade: req.cascade}, nil
+}
+
+func encodeRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(ruleReq)
+	return &RuleReq{Token: req.token, Rule: toProtoRule(req.rule)}, nil
+}
+
+func encodePatchRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(patchRuleReq)
+	actions := make([]*Action, len(req.patch.Actions))
+	for i, a := range req.patch.Actions {
+		actions[i] = toProtoAction(a)
+	}
+
+	return &PatchRuleReq{Token: req.token, Id: req.id, Sql: req.patch.SQL, Actions: actions, Options: toProtoRuleOptions(req.patch.Options)}, nil
+}
+
+func encodeCloneRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(cloneRuleReq)
+	return &CloneRuleReq{Token: req.token, Id: req.id, NewId: req.newID, Channel: req.channel}, nil
+}
+
+func encodeTestRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(testRuleReq)
+	samples, err := toProtoSamples(req.trial.Samples)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TestRuleReq{Token: req.token, Rule: toProtoRule(req.trial.Rule), Samples: samples}, nil
+}
+
+func encodeReplayRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(replayRuleReq)
+
+	return &ReplayReq{Token: req.token, Id: req.id, From: timestamppb.New(req.from), To: timestamppb.New(req.to)}, nil
+}
+
+func encodeRuleLogsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(ruleLogsReq)
+	res := &RuleLogsReq{Token: req.token, Id: req.id}
+	if !req.since.IsZero() {
+		res.Since = timestamppb.New(req.since)
+	}
+
+	return res, nil
+}
+
+func encodePushTailRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(pushTailReq)
+	result, err := structpb.NewStruct(req.result)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	return &PushTailReq{Session: req.session, Result: result}, nil
+}
+
+func encodeIngestRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(ingestReq)
+	return &IngestReq{Key: req.key, Stream: req.stream, Payload: req.payload}, nil
+}
+
+func encodeReconcileRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(reconcileReq)
+	return &ReconcileReq{Token: req.token, Repair: req.repair}, nil
+}
+
+func encodeCollectOrphansRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(collectOrphansReq)
+	return &CollectOrphansReq{Token: req.token, Remove: req.policy.Remove, MinAge: durationpb.New(req.policy.MinAge)}, nil
+}
+
+func encodeRestoreRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(restoreReq)
+	return &RestoreReq{Token: req.token, DryRun: req.dryRun}, nil
+}
+
+func encodeExportRulesetRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(exportRulesetReq)
+	return &ExportRulesetReq{Token: req.token}, nil
+}
+
+func encodeImportRulesetRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(importRulesetReq)
+	return &ImportRulesetReq{Token: req.token, Ruleset: toProtoRuleset(req.rs), Conflict: req.conflict}, nil
+}
+
+func encodeBulkCreateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(bulkCreateReq)
+	return &BulkCreateReq{Token: req.token, Ruleset: toProtoRuleset(req.rs)}, nil
+}
+
+func encodeBulkDeleteRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(bulkDeleteReq)
+	return &BulkDeleteReq{Token: req.token, Streams: req.bd.Streams, Rules: req.bd.Rules}, nil
+}
+
+func encodeRuleSelectionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(ruleSelectionReq)
+	return &RuleSelectionReq{Token: req.token, Ids: req.sel.IDs, Labels: req.sel.Labels}, nil
+}
+
+func encodeListAllRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listAllReq)
+	return &ListAllReq{Token: req.token}, nil
+}
+
+func encodeQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(quotaReq)
+	return &QuotaReq{
+		Token:      req.token,
+		UserId:     req.userID,
+		MaxStreams: int64(req.quota.MaxStreams),
+		MaxRules:   int64(req.quota.MaxRules),
+	}, nil
+}
+
+func encodeAlertPolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(alertPolicyReq)
+	return &AlertPolicyReq{Token: req.token, Policy: toProtoAlertPolicy(req.policy)}, nil
+}
+
+func encodeWebhookRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(webhookReq)
+	return &WebhookReq{Token: req.token, Webhook: toProtoWebhook(req.wh)}, nil
+}
+
+func encodeFolderRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(folderReq)
+	return &FolderReq{Token: req.token, Folder: toProtoFolder(req.folder)}, nil
+}
+
+func encodeMoveRulesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(moveRulesReq)
+	return &MoveRulesReq{Token: req.token, Folder: req.folder, Ids: req.ids}, nil
+}
+
+func encodeChainRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(chainReq)
+	return &ChainReq{Token: req.token, Chain: toProtoChain(req.chain)}, nil
+}
+
+func encodeSecretRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(secretReq)
+	return &SecretReq{Token: req.token, Secret: toProtoSecret(req.secret)}, nil
+}
+
+func encodeShareRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(shareReq)
+	return &ShareReq{Token: req.token, Kind: req.kind, Name: req.name, Share: toProtoShare(req.share)}, nil
+}
+
+func encodeSharesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(sharesReq)
+	return &SharesReq{Token: req.token, Kind: req.kind, Name: req.name}, nil
+}
+
+func encodeUnshareRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(unshareReq)
+	return &SharesReq{Token: req.token, Kind: req.kind, Name: req.name, Grantee: req.grantee}, nil
+}
+
+func encodeRenameRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(renameReq)
+	return &RenameReq{Token: req.token, Kind: req.kind, Name: req.name, NewName: req.newName}, nil
+}
+
+func encodeAuditRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(auditReq)
+	res := &AuditReq{
+		Token:  req.token,
+		Offset: req.q.Offset,
+		Limit:  req.q.Limit,
+		User:   req.q.User,
+		Owner:  req.q.Owner,
+		Kind:   req.q.Kind,
+		Entity: req.q.Entity,
+	}
+	if !req.q.From.IsZero() {
+		res.From = timestamppb.New(req.q.From)
+	}
+	if !req.q.To.IsZero() {
+		res.To = timestamppb.New(req.q.To)
+	}
+
+	return res, nil
+}
+
+func encodeAssignInstanceRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(assignInstanceReq)
+	return &AssignInstanceReq{Token: req.token, UserId: req.userID, Instance: req.instance}, nil
+}
+
+func encodeGatewayRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(gatewayReq)
+	return &GatewayReq{Token: req.token, Gateway: toProtoGateway(req.gw)}, nil
+}
+
+func encodeDeployRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(deployReq)
+	return &DeployReq{Token: req.token, Id: req.id, Gateway: req.gateway}, nil
+}
+
+func encodeDeployFleetRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(deployFleetReq)
+	return &DeployFleetReq{Token: req.token, Id: req.id, Labels: req.labels}, nil
+}
+
+func encodeTemplateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(templateReq)
+	return &TemplateReq{Token: req.token, Template: toProtoTemplate(req.tmpl)}, nil
+}
+
+func encodeListTemplatesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listTemplatesReq)
+	return &ListTemplatesReq{Token: req.token}, nil
+}
+
+func encodeInstantiateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(instantiateReq)
+	return &InstantiateReq{
+		Token:       req.token,
+		Name:        req.name,
+		Id:          req.inst.ID,
+		Values:      req.inst.Values,
+		Description: req.inst.Description,
+		Labels:      req.inst.Labels,
+	}, nil
+}
+
+func decodeInfoResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*InfoRes)
+	return re.Info{
+		Version:       res.GetVersion(),
+		OS:            res.GetOs(),
+		UpTimeSeconds: int(res.GetUpTimeSeconds()),
+		Breaker:       res.GetBreaker(),
+	}, nil
+}
+
+func decodeResultResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoResult(grpcRes.(*Result)), nil
+}
+
+func decodeStreamsPageResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoStreamsPage(grpcRes.(*StreamsPage)), nil
+}
+
+func decodeStreamResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoStream(grpcRes.(*Stream)), nil
+}
+
+func decodeStreamsBatchResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoStreamsBatch(grpcRes.(*StreamsBatch)), nil
+}
+
+func decodeTablesPageResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoTablesPage(grpcRes.(*TablesPage)), nil
+}
+
+func decodeTableResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoTable(grpcRes.(*Table)), nil
+}
+
+func decodeRuleResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoRule(grpcRes.(*Rule)), nil
+}
+
+func decodeRulesBatchResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoRulesBatch(grpcRes.(*RulesBatch)), nil
+}
+
+func decodeRuleValidationResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoRuleValidation(grpcRes.(*RuleValidation)), nil
+}
+
+func decodeTrialResultResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return re.TrialResult{Results: fromProtoStructs(grpcRes.(*TrialResult).GetResults())}, nil
+}
+
+func decodeReplayResultResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoReplayResult(grpcRes.(*ReplayResult)), nil
+}
+
+func decodePushTailResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeIngestResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeRulesPageResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoRulesPage(grpcRes.(*RulesPage)), nil
+}
+
+func decodeAuditPageResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoAuditPage(grpcRes.(*AuditPage)), nil
+}
+
+func decodeInstancesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoInstances(grpcRes.(*InstancesRes)), nil
+}
+
+func decodeAssignmentResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	a := grpcRes.(*Assignment)
+	return re.Assignment{UserID: a.GetUserId(), Instance: a.GetInstance(), Assigned: a.GetAssigned()}, nil
+}
+
+func decodeGatewayResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoGateway(grpcRes.(*Gateway)), nil
+}
+
+func decodeGatewaysResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*GatewaysRes)
+	gws := make([]re.Gateway, len(res.GetGateways()))
+	for i, gw := range res.GetGateways() {
+		gws[i] = fromProtoGateway(gw)
+	}
+
+	return gws, nil
+}
+
+func decodeRemoveGatewayResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeDeploymentResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoDeployment(grpcRes.(*Deployment)), nil
+}
+
+func decodeDeploymentsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*DeploymentsRes)
+	ds := make([]re.Deployment, len(res.GetDeployments()))
+	for i, d := range res.GetDeployments() {
+		ds[i] = fromProtoDeployment(d)
+	}
+
+	return ds, nil
+}
+
+func decodeRolloutResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoRollout(grpcRes.(*Rollout)), nil
+}
+
+func decodeEngineStatsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoEngineStats(grpcRes.(*EngineStatsRes)), nil
+}
+
+func decodeRuleStatusResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoRuleStatus(grpcRes.(*RuleStatusRes)), nil
+}
+
+func decodeRuleTopologyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoRuleTopology(grpcRes.(*RuleTopologyRes)), nil
+}
+
+func decodeRuleLogsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*RuleLogsRes)
+	logs := make([]re.LogEntry, len(res.GetLogs()))
+	for i, l := range res.GetLogs() {
+		logs[i] = fromProtoLogEntry(l)
+	}
+
+	return logs, nil
+}
+
+func decodeDriftReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoDriftReport(grpcRes.(*DriftReport)), nil
+}
+
+func decodeOrphanReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoOrphanReport(grpcRes.(*OrphanReport)), nil
+}
+
+func decodeRestoreReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoRestoreReport(grpcRes.(*RestoreReport)), nil
+}
+
+func decodeRulesetResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoRuleset(grpcRes.(*Ruleset)), nil
+}
+
+func decodeImportReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoImportReport(grpcRes.(*ImportReport)), nil
+}
+
+func decodeBulkReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoBulkReport(grpcRes.(*BulkReport)), nil
+}
+
+func decodeAllStreamsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoAllStreams(grpcRes.(*AllStreams)), nil
+}
+
+func decodeAllRulesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoAllRules(grpcRes.(*AllRules)), nil
+}
+
+func decodeUserQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoUserQuota(grpcRes.(*UserQuota)), nil
+}
+
+func decodeRemoveQuotaResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeAlertPolicyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoAlertPolicy(grpcRes.(*AlertPolicy)), nil
+}
+
+func decodeRemoveAlertPolicyResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeWebhookResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoWebhook(grpcRes.(*Webhook)), nil
+}
+
+func decodeWebhooksResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*WebhooksRes)
+	whs := make([]re.Webhook, len(res.GetWebhooks()))
+	for i, wh := range res.GetWebhooks() {
+		whs[i] = fromProtoWebhook(wh)
+	}
+
+	return whs, nil
+}
+
+func decodeRemoveWebhookResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeFolderResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoFolder(grpcRes.(*Folder)), nil
+}
+
+func decodeFoldersResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*FoldersRes)
+	folders := make([]re.Folder, len(res.GetFolders()))
+	for i, f := range res.GetFolders() {
+		folders[i] = fromProtoFolder(f)
+	}
+
+	return folders, nil
+}
+
+func decodeRemoveFolderResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeMaintenanceResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoMaintenance(grpcRes.(*Maintenance)), nil
+}
+
+func decodeChainResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoChain(grpcRes.(*Chain)), nil
+}
+
+func decodeChainsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*ChainsRes)
+	chains := make([]re.Chain, len(res.GetChains()))
+	for i, c := range res.GetChains() {
+		chains[i] = fromProtoChain(c)
+	}
+
+	return chains, nil
+}
+
+func decodeRemoveChainResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeSecretResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoSecret(grpcRes.(*Secret)), nil
+}
+
+func decodeSecretsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*SecretsRes)
+	secrets := make([]re.Secret, len(res.GetSecrets()))
+	for i, s := range res.GetSecrets() {
+		secrets[i] = fromProtoSecret(s)
+	}
+
+	return secrets, nil
+}
+
+func decodeRemoveSecretResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeShareResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoShare(grpcRes.(*Share)), nil
+}
+
+func decodeSharesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*SharesRes)
+	shares := make([]re.Share, len(res.GetShares()))
+	for i, s := range res.GetShares() {
+		shares[i] = fromProtoShare(s)
+	}
+
+	return shares, nil
+}
+
+func decodeUnshareResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeTemplateResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return fromProtoTemplate(grpcRes.(*Template)), nil
+}
+
+func decodeTemplatesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*TemplatesRes)
+	tmpls := make([]re.Template, len(res.GetTemplates()))
+	for i, tmpl := range res.GetTemplates() {
+		tmpls[i] = fromProtoTemplate(tmpl)
+	}
+
+	return tmpls, nil
+}
+
+func decodeRemoveTemplateResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func encodePluginRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(pluginReq)
+	return &PluginReq{
+		Token:      req.token,
+		Kind:       req.kind,
+		Name:       req.plugin.Name,
+		File:       req.plugin.File,
+		ShellParas: req.plugin.ShellParas,
+		Functions:  req.plugin.Functions,
+	}, nil
+}
+
+func encodeListPluginsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listPluginsReq)
+	return &ListPluginsReq{Token: req.token, Kind: req.kind}, nil
+}
+
+func encodeDeletePluginRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(deletePluginReq)
+	return &DeletePluginReq{Token: req.token, Kind: req.kind, Name: req.name}, nil
+}
+
+func decodePluginsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	plugins := grpcRes.(*PluginsRes).GetPlugins()
+	if plugins == nil {
+		plugins = []string{}
+	}
+
+	return plugins, nil
+}
+
+func encodeExternalServiceRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(externalServiceReq)
+	return &ExternalServiceReq{Token: req.token, Name: req.service.Name, File: req.service.File}, nil
+}
+
+func encodeListExternalServicesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listExternalServicesReq)
+	return &ListExternalServicesReq{Token: req.token}, nil
+}
+
+func decodeExternalServicesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	services := grpcRes.(*ExternalServicesRes).GetServices()
+	if services == nil {
+		services = []string{}
+	}
+
+	return services, nil
+}
+
+func encodeListExternalFunctionsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listExternalFunctionsReq)
+	return &ListExternalFunctionsReq{Token: req.token}, nil
+}
+
+func decodeExternalFunctionsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*ExternalFunctionsRes)
+	funcs := make([]re.ExternalFunction, len(res.GetFunctions()))
+	for i, f := range res.GetFunctions() {
+		funcs[i] = fromProtoExternalFunction(f)
+	}
+
+	return funcs, nil
+}
+
+func encodeConfKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(confKeyReq)
+	return &ConfKeyReq{
+		Token:              req.token,
+		Name:               req.name,
+		Server:             req.conf.Server,
+		Username:           req.conf.Username,
+		Password:           req.conf.Password,
+		ClientId:           req.conf.ClientID,
+		Qos:                int32(req.conf.QoS),
+		ProtocolVersion:    req.conf.ProtocolVersion,
+		CertificationRaw:   req.conf.CertificationRaw,
+		PrivateKeyRaw:      req.conf.PrivateKeyRaw,
+		RootCaRaw:          req.conf.RootCARaw,
+		InsecureSkipVerify: req.conf.InsecureSkipVerify,
+	}, nil
+}
+
+func encodeListConfKeysRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listConfKeysReq)
+	return &ListConfKeysReq{Token: req.token}, nil
+}
+
+func decodeConfKeysResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	keys := grpcRes.(*ConfKeysRes).GetConfKeys()
+	if keys == nil {
+		keys = []string{}
+	}
+
+	return keys, nil
+}
+
+// writeRequestID passes the request ID the context carries to the server.
+func writeRequestID(ctx context.Context, md *metadata.MD) context.Context {
+	if id := re.RequestID(ctx); id != "" {
+		md.Set(requestIDKey, id)
+	}
+
+	return ctx
+}
+
+// writeRevision passes the entity revision the context carries to the
+// server.
+func writeRevision(ctx context.Context, md *metadata.MD) context.Context {
+	if rev := re.ExpectedRevision(ctx); rev != "" {
+		md.Set(revisionKey, rev)
+	}
+
+	return ctx
+}
+
+func decodeError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unauthenticated:
+			return errors.Wrap(svcerr.ErrAuthentication, errors.New(st.Message()))
+		case codes.PermissionDenied:
+			return errors.Wrap(svcerr.ErrAuthorization, errors.New(st.Message()))
+		case codes.InvalidArgument:
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New(st.Message()))
+		case codes.NotFound:
+			return errors.Wrap(svcerr.ErrNotFound, errors.New(st.Message()))
+		case codes.AlreadyExists:
+			return errors.Wrap(svcerr.ErrConflict, errors.New(st.Message()))
+		case codes.Aborted:
+			return errors.Wrap(svcerr.ErrConflict, errors.Wrap(re.ErrStaleRevision, errors.New(st.Message())))
+		case codes.FailedPrecondition:
+			return errors.Wrap(re.ErrRevisionRequired, errors.New(st.Message()))
+		case codes.Unimplemented:
+			return errors.Wrap(re.ErrNotSupported, errors.New(st.Message()))
+		case codes.ResourceExhausted:
+			for _, det := range st.Details() {
+				if info, ok := det.(*errdetails.RetryInfo); ok {
+					return errors.Wrap(re.ErrRateLimited, &re.RetryError{After: info.GetRetryDelay().AsDuration()})
+				}
+			}
+			return errors.Wrap(re.ErrQuotaExceeded, errors.New(st.Message()))
+		case codes.Canceled:
+			return context.Canceled
+		case codes.DeadlineExceeded:
+			return context.DeadlineExceeded
+		case codes.OK:
+			if msg := st.Message(); msg != "" {
+				return errors.Wrap(errors.ErrUnidentified, errors.New(msg))
+			}
+			return nil
+		default:
+			return errors.Wrap(fmt.Errorf("unexpected gRPC status: %s (status code:%v)", st.Code().String(), st.Code()), errors.New(st.Message()))
+		}
+	}
+	return err
+}
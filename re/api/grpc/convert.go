@@ -0,0 +1,1273 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Conversions between the rules engine domain types and their protobuf
+// counterparts, shared by the gRPC server and client.
+
+func toProtoResult(res re.Result) *Result {
+	return &Result{Name: res.Name, Status: int32(res.Status), Message: res.Message}
+}
+
+func fromProtoResult(res *Result) re.Result {
+	return re.Result{Name: res.GetName(), Status: int(res.GetStatus()), Message: res.GetMessage()}
+}
+
+func toProtoFields(fields []re.Field) []*Field {
+	res := make([]*Field, len(fields))
+	for i, f := range fields {
+		res[i] = &Field{Name: f.Name, Type: f.Type, Items: f.Items, Fields: toProtoFields(f.Fields)}
+	}
+
+	return res
+}
+
+func fromProtoFields(fields []*Field) []re.Field {
+	if len(fields) == 0 {
+		return nil
+	}
+	res := make([]re.Field, len(fields))
+	for i, f := range fields {
+		res[i] = re.Field{Name: f.GetName(), Type: f.GetType(), Items: f.GetItems(), Fields: fromProtoFields(f.GetFields())}
+	}
+
+	return res
+}
+
+func toProtoStream(stream re.Stream) (*Stream, error) {
+	fields := make([]*StreamField, len(stream.StreamFields))
+	for i, f := range stream.StreamFields {
+		t, err := structpb.NewValue(f.FieldType)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		fields[i] = &StreamField{Name: f.Name, Type: t}
+	}
+
+	return &Stream{Name: stream.Name, Fields: fields, Options: stream.Options, Metadata: toProtoMetadata(stream.Metadata), IngestUrl: stream.IngestURL}, nil
+}
+
+func fromProtoStream(stream *Stream) re.Stream {
+	fields := make([]re.StreamField, len(stream.GetFields()))
+	for i, f := range stream.GetFields() {
+		fields[i] = re.StreamField{Name: f.GetName(), FieldType: f.GetType().AsInterface()}
+	}
+
+	return re.Stream{Name: stream.GetName(), StreamFields: fields, Options: stream.GetOptions(), Metadata: fromProtoMetadata(stream.GetMetadata()), IngestURL: stream.GetIngestUrl()}
+}
+
+func toProtoMetadata(md *re.Metadata) *Metadata {
+	if md == nil {
+		return nil
+	}
+	res := &Metadata{Owner: md.Owner, Description: md.Description, Labels: md.Labels, Attributes: toProtoAttributes(md.Attributes), Folder: md.Folder, CreatedAt: timestamppb.New(md.CreatedAt)}
+	if !md.UpdatedAt.IsZero() {
+		res.UpdatedAt = timestamppb.New(md.UpdatedAt)
+	}
+	if !md.LastStartedAt.IsZero() {
+		res.LastStartedAt = timestamppb.New(md.LastStartedAt)
+	}
+	if !md.ExpiresAt.IsZero() {
+		res.ExpiresAt = timestamppb.New(md.ExpiresAt)
+	}
+	res.Schedule = toProtoSchedule(md.Schedule)
+
+	return res
+}
+
+func fromProtoMetadata(md *Metadata) *re.Metadata {
+	if md == nil {
+		return nil
+	}
+	res := &re.Metadata{Owner: md.GetOwner(), Description: md.GetDescription(), Labels: md.GetLabels(), Attributes: fromProtoAttributes(md.GetAttributes()), Folder: md.GetFolder(), CreatedAt: md.GetCreatedAt().AsTime()}
+	if md.GetUpdatedAt() != nil {
+		res.UpdatedAt = md.GetUpdatedAt().AsTime()
+	}
+	if md.GetLastStartedAt() != nil {
+		res.LastStartedAt = md.GetLastStartedAt().AsTime()
+	}
+	if md.GetExpiresAt() != nil {
+		res.ExpiresAt = md.GetExpiresAt().AsTime()
+	}
+	res.Schedule = fromProtoSchedule(md.GetSchedule())
+
+	return res
+}
+
+func toProtoSchedule(sch *re.Schedule) *Schedule {
+	if sch == nil {
+		return nil
+	}
+	var windows []*Window
+	for _, w := range sch.Windows {
+		windows = append(windows, &Window{Days: w.Days, Start: w.Start, End: w.End})
+	}
+
+	return &Schedule{Cron: sch.Cron, Duration: sch.Duration, Windows: windows, Timezone: sch.Timezone}
+}
+
+func fromProtoSchedule(sch *Schedule) *re.Schedule {
+	if sch == nil {
+		return nil
+	}
+	var windows []re.Window
+	for _, w := range sch.GetWindows() {
+		windows = append(windows, re.Window{Days: w.GetDays(), Start: w.GetStart(), End: w.GetEnd()})
+	}
+
+	return &re.Schedule{Cron: sch.GetCron(), Duration: sch.GetDuration(), Windows: windows, Timezone: sch.GetTimezone()}
+}
+
+func toProtoStreamsPage(page re.StreamsPage) *StreamsPage {
+	var mds map[string]*Metadata
+	if len(page.Metadata) > 0 {
+		mds = make(map[string]*Metadata, len(page.Metadata))
+		for name, md := range page.Metadata {
+			md := md
+			mds[name] = toProtoMetadata(&md)
+		}
+	}
+
+	return &StreamsPage{Total: page.Total, Offset: page.Offset, Limit: page.Limit, Streams: page.Streams, Metadata: mds}
+}
+
+func fromProtoStreamsPage(page *StreamsPage) re.StreamsPage {
+	streams := page.GetStreams()
+	if streams == nil {
+		streams = []string{}
+	}
+	var mds map[string]re.Metadata
+	if len(page.GetMetadata()) > 0 {
+		mds = make(map[string]re.Metadata, len(page.GetMetadata()))
+		for name, md := range page.GetMetadata() {
+			mds[name] = *fromProtoMetadata(md)
+		}
+	}
+
+	return re.StreamsPage{Total: page.GetTotal(), Offset: page.GetOffset(), Limit: page.GetLimit(), Streams: streams, Metadata: mds}
+}
+
+func toProtoTable(table re.Table) (*Table, error) {
+	stream, err := toProtoStream(re.Stream{Name: table.Name, StreamFields: table.StreamFields, Options: table.Options, Metadata: table.Metadata})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{Name: stream.GetName(), Fields: stream.GetFields(), Options: stream.GetOptions(), Metadata: stream.GetMetadata()}, nil
+}
+
+func fromProtoTable(table *Table) re.Table {
+	stream := &Stream{Name: table.GetName(), Fields: table.GetFields(), Options: table.GetOptions(), Metadata: table.GetMetadata()}
+
+	s := fromProtoStream(stream)
+
+	return re.Table{Name: s.Name, StreamFields: s.StreamFields, Options: s.Options, Metadata: s.Metadata}
+}
+
+func toProtoTablesPage(page re.TablesPage) *TablesPage {
+	sp := toProtoStreamsPage(re.StreamsPage{Total: page.Total, Offset: page.Offset, Limit: page.Limit, Streams: page.Tables, Metadata: page.Metadata})
+
+	return &TablesPage{Total: sp.GetTotal(), Offset: sp.GetOffset(), Limit: sp.GetLimit(), Tables: sp.GetStreams(), Metadata: sp.GetMetadata()}
+}
+
+func fromProtoTablesPage(page *TablesPage) re.TablesPage {
+	sp := fromProtoStreamsPage(&StreamsPage{Total: page.GetTotal(), Offset: page.GetOffset(), Limit: page.GetLimit(), Streams: page.GetTables(), Metadata: page.GetMetadata()})
+
+	return re.TablesPage{Total: sp.Total, Offset: sp.Offset, Limit: sp.Limit, Tables: sp.Streams, Metadata: sp.Metadata}
+}
+
+func toProtoRule(rule re.Rule) *Rule {
+	actions := make([]*Action, len(rule.Actions))
+	for i, a := range rule.Actions {
+		actions[i] = toProtoAction(a)
+	}
+
+	res := &Rule{
+		Id:          rule.ID,
+		Sql:         rule.SQL,
+		Actions:     actions,
+		Options:     toProtoRuleOptions(rule.Options),
+		Description: rule.Description,
+		Labels:      rule.Labels,
+		Attributes:  toProtoAttributes(rule.Attributes),
+		Schedule:    toProtoSchedule(rule.Schedule),
+		Metadata:    toProtoMetadata(rule.Metadata),
+	}
+	if !rule.ExpiresAt.IsZero() {
+		res.ExpiresAt = timestamppb.New(rule.ExpiresAt)
+	}
+
+	return res
+}
+
+func fromProtoRule(rule *Rule) re.Rule {
+	actions := make([]re.Action, len(rule.GetActions()))
+	for i, a := range rule.GetActions() {
+		actions[i] = fromProtoAction(a)
+	}
+
+	res := re.Rule{
+		ID:          rule.GetId(),
+		SQL:         rule.GetSql(),
+		Actions:     actions,
+		Options:     fromProtoRuleOptions(rule.GetOptions()),
+		Description: rule.GetDescription(),
+		Labels:      rule.GetLabels(),
+		Attributes:  fromProtoAttributes(rule.GetAttributes()),
+		Schedule:    fromProtoSchedule(rule.GetSchedule()),
+		Metadata:    fromProtoMetadata(rule.GetMetadata()),
+	}
+	if rule.GetExpiresAt() != nil {
+		res.ExpiresAt = rule.GetExpiresAt().AsTime()
+	}
+
+	return res
+}
+
+func toProtoAction(a re.Action) *Action {
+	action := &Action{}
+	if s := a.Mainflux; s != nil {
+		action.Mainflux = &MainfluxSink{Host: s.Host, Port: s.Port, Channel: s.Channel, Subtopic: s.Subtopic}
+	}
+	if s := a.REST; s != nil {
+		action.Rest = &RESTSink{
+			Url:          s.URL,
+			Method:       s.Method,
+			BodyType:     s.BodyType,
+			Headers:      s.Headers,
+			Timeout:      s.Timeout,
+			SendSingle:   s.SendSingle,
+			DataTemplate: s.DataTemplate,
+		}
+	}
+	if s := a.MQTT; s != nil {
+		action.Mqtt = &MQTTSink{
+			Server:     s.Server,
+			Topic:      s.Topic,
+			Qos:        int32(s.QoS),
+			ClientId:   s.ClientID,
+			Username:   s.Username,
+			Password:   s.Password,
+			Retained:   s.Retained,
+			SendSingle: s.SendSingle,
+		}
+	}
+	if a.Log != nil {
+		action.Log = &LogSink{}
+	}
+	if s := a.Nop; s != nil {
+		action.Nop = &NopSink{Log: s.Log}
+	}
+	if s := a.Writer; s != nil {
+		action.Writer = &WriterSink{Type: s.Type, Table: s.Table, Fields: s.Fields}
+	}
+	if s := a.Email; s != nil {
+		action.Email = &NotificationSink{Channel: s.Channel, Contacts: s.Contacts}
+	}
+	if s := a.SMS; s != nil {
+		action.Sms = &NotificationSink{Channel: s.Channel, Contacts: s.Contacts}
+	}
+	if s := a.Memory; s != nil {
+		action.Memory = &MemorySink{Topic: s.Topic}
+	}
+	action.Delivery = toProtoDelivery(a.Delivery)
+	action.DataTemplate = a.DataTemplate
+
+	return action
+}
+
+func toProtoDelivery(d *re.Delivery) *Delivery {
+	if d == nil {
+		return nil
+	}
+	res := &Delivery{
+		RetryCount:           int32(d.RetryCount),
+		RetryInterval:        d.RetryInterval,
+		EnableCache:          d.EnableCache,
+		MemoryCacheThreshold: int32(d.MemoryCacheThreshold),
+		MaxDiskCache:         int32(d.MaxDiskCache),
+		BufferPageSize:       int32(d.BufferPageSize),
+		ResendInterval:       d.ResendInterval,
+		CleanCacheAtStop:     d.CleanCacheAtStop,
+	}
+	if dl := d.DeadLetter; dl != nil {
+		res.DeadLetter = &DeadLetter{Channel: dl.Channel, Subtopic: dl.Subtopic}
+	}
+
+	return res
+}
+
+func fromProtoAction(a *Action) re.Action {
+	var action re.Action
+	if s := a.GetMainflux(); s != nil {
+		action.Mainflux = &re.MainfluxSink{Host: s.GetHost(), Port: s.GetPort(), Channel: s.GetChannel(), Subtopic: s.GetSubtopic()}
+	}
+	if s := a.GetRest(); s != nil {
+		action.REST = &re.RESTSink{
+			URL:          s.GetUrl(),
+			Method:       s.GetMethod(),
+			BodyType:     s.GetBodyType(),
+			Headers:      s.GetHeaders(),
+			Timeout:      s.GetTimeout(),
+			SendSingle:   s.GetSendSingle(),
+			DataTemplate: s.GetDataTemplate(),
+		}
+	}
+	if s := a.GetMqtt(); s != nil {
+		action.MQTT = &re.MQTTSink{
+			Server:     s.GetServer(),
+			Topic:      s.GetTopic(),
+			QoS:        int(s.GetQos()),
+			ClientID:   s.GetClientId(),
+			Username:   s.GetUsername(),
+			Password:   s.GetPassword(),
+			Retained:   s.GetRetained(),
+			SendSingle: s.GetSendSingle(),
+		}
+	}
+	if a.GetLog() != nil {
+		action.Log = &re.LogSink{}
+	}
+	if s := a.GetNop(); s != nil {
+		action.Nop = &re.NopSink{Log: s.GetLog()}
+	}
+	if s := a.GetWriter(); s != nil {
+		action.Writer = &re.WriterSink{Type: s.GetType(), Table: s.GetTable(), Fields: s.GetFields()}
+	}
+	if s := a.GetEmail(); s != nil {
+		action.Email = &re.NotificationSink{Channel: s.GetChannel(), Contacts: s.GetContacts()}
+	}
+	if s := a.GetSms(); s != nil {
+		action.SMS = &re.NotificationSink{Channel: s.GetChannel(), Contacts: s.GetContacts()}
+	}
+	if s := a.GetMemory(); s != nil {
+		action.Memory = &re.MemorySink{Topic: s.GetTopic()}
+	}
+	action.Delivery = fromProtoDelivery(a.GetDelivery())
+	action.DataTemplate = a.GetDataTemplate()
+
+	return action
+}
+
+func fromProtoDelivery(d *Delivery) *re.Delivery {
+	if d == nil {
+		return nil
+	}
+	res := &re.Delivery{
+		RetryCount:           int(d.GetRetryCount()),
+		RetryInterval:        d.GetRetryInterval(),
+		EnableCache:          d.GetEnableCache(),
+		MemoryCacheThreshold: int(d.GetMemoryCacheThreshold()),
+		MaxDiskCache:         int(d.GetMaxDiskCache()),
+		BufferPageSize:       int(d.GetBufferPageSize()),
+		ResendInterval:       d.GetResendInterval(),
+		CleanCacheAtStop:     d.GetCleanCacheAtStop(),
+	}
+	if dl := d.GetDeadLetter(); dl != nil {
+		res.DeadLetter = &re.DeadLetter{Channel: dl.GetChannel(), Subtopic: dl.GetSubtopic()}
+	}
+
+	return res
+}
+
+func toProtoRuleOptions(opts *re.RuleOptions) *RuleOptions {
+	if opts == nil {
+		return nil
+	}
+
+	return &RuleOptions{
+		Qos:                int32(opts.QoS),
+		CheckpointInterval: opts.CheckpointInterval,
+		IsEventTime:        opts.IsEventTime,
+		LateTolerance:      opts.LateTolerance,
+		SendMetaToSink:     opts.SendMetaToSink,
+		Concurrency:        int32(opts.Concurrency),
+	}
+}
+
+func fromProtoRuleOptions(opts *RuleOptions) *re.RuleOptions {
+	if opts == nil {
+		return nil
+	}
+
+	return &re.RuleOptions{
+		QoS:                int(opts.GetQos()),
+		CheckpointInterval: opts.GetCheckpointInterval(),
+		IsEventTime:        opts.GetIsEventTime(),
+		LateTolerance:      opts.GetLateTolerance(),
+		SendMetaToSink:     opts.GetSendMetaToSink(),
+		Concurrency:        int(opts.GetConcurrency()),
+	}
+}
+
+func toProtoRulesPage(page re.RulesPage) *RulesPage {
+	rules := make([]*RuleInfo, len(page.Rules))
+	for i, r := range page.Rules {
+		rules[i] = &RuleInfo{Id: r.ID, Status: r.Status, Metadata: toProtoMetadata(r.Metadata)}
+	}
+
+	return &RulesPage{Total: page.Total, Offset: page.Offset, Limit: page.Limit, Rules: rules}
+}
+
+func fromProtoRulesPage(page *RulesPage) re.RulesPage {
+	rules := make([]re.RuleInfo, len(page.GetRules()))
+	for i, r := range page.GetRules() {
+		rules[i] = re.RuleInfo{ID: r.GetId(), Status: r.GetStatus(), Metadata: fromProtoMetadata(r.GetMetadata())}
+	}
+
+	return re.RulesPage{Total: page.GetTotal(), Offset: page.GetOffset(), Limit: page.GetLimit(), Rules: rules}
+}
+
+func toProtoAuditPage(page re.AuditPage) *AuditPage {
+	events := make([]*AuditEvent, len(page.Events))
+	for i, ev := range page.Events {
+		events[i] = &AuditEvent{
+			Id:        ev.ID,
+			Time:      timestamppb.New(ev.Time),
+			User:      ev.User,
+			Owner:     ev.Owner,
+			Kind:      ev.Kind,
+			Entity:    ev.Entity,
+			Operation: ev.Operation,
+			Before:    ev.Before,
+			After:     ev.After,
+		}
+	}
+
+	return &AuditPage{Total: page.Total, Offset: page.Offset, Limit: page.Limit, Events: events}
+}
+
+func fromProtoAuditPage(page *AuditPage) re.AuditPage {
+	events := make([]re.AuditEvent, len(page.GetEvents()))
+	for i, ev := range page.GetEvents() {
+		events[i] = re.AuditEvent{
+			ID:        ev.GetId(),
+			Time:      ev.GetTime().AsTime(),
+			User:      ev.GetUser(),
+			Owner:     ev.GetOwner(),
+			Kind:      ev.GetKind(),
+			Entity:    ev.GetEntity(),
+			Operation: ev.GetOperation(),
+			Before:    ev.GetBefore(),
+			After:     ev.GetAfter(),
+		}
+	}
+
+	return re.AuditPage{Total: page.GetTotal(), Offset: page.GetOffset(), Limit: page.GetLimit(), Events: events}
+}
+
+func toProtoInstances(instances []re.Instance) *InstancesRes {
+	res := make([]*Instance, len(instances))
+	for i, inst := range instances {
+		res[i] = &Instance{Name: inst.Name, IsDefault: inst.Default, Error: inst.Error, Users: inst.Users}
+		if inst.Info != nil {
+			res[i].Info = &InfoRes{
+				Version:       inst.Info.Version,
+				Os:            inst.Info.OS,
+				UpTimeSeconds: int64(inst.Info.UpTimeSeconds),
+				Breaker:       inst.Info.Breaker,
+			}
+		}
+	}
+
+	return &InstancesRes{Instances: res}
+}
+
+func fromProtoInstances(res *InstancesRes) []re.Instance {
+	instances := make([]re.Instance, len(res.GetInstances()))
+	for i, inst := range res.GetInstances() {
+		instances[i] = re.Instance{Name: inst.GetName(), Default: inst.GetIsDefault(), Error: inst.GetError(), Users: inst.GetUsers()}
+		if instances[i].Users == nil {
+			instances[i].Users = []string{}
+		}
+		if info := inst.GetInfo(); info != nil {
+			instances[i].Info = &re.Info{
+				Version:       info.GetVersion(),
+				OS:            info.GetOs(),
+				UpTimeSeconds: int(info.GetUpTimeSeconds()),
+				Breaker:       info.GetBreaker(),
+			}
+		}
+	}
+
+	return instances
+}
+
+func toProtoRuleValidation(v re.RuleValidation) *RuleValidation {
+	diags := make([]*Diagnostic, len(v.Diagnostics))
+	for i, d := range v.Diagnostics {
+		diags[i] = &Diagnostic{Field: d.Field, Position: int32(d.Position), Message: d.Message}
+	}
+
+	return &RuleValidation{Valid: v.Valid, Diagnostics: diags}
+}
+
+func fromProtoRuleValidation(v *RuleValidation) re.RuleValidation {
+	diags := make([]re.Diagnostic, len(v.GetDiagnostics()))
+	for i, d := range v.GetDiagnostics() {
+		diags[i] = re.Diagnostic{Field: d.GetField(), Position: int(d.GetPosition()), Message: d.GetMessage()}
+	}
+
+	return re.RuleValidation{Valid: v.GetValid(), Diagnostics: diags}
+}
+
+func toProtoStructs(msgs []map[string]interface{}) ([]*structpb.Struct, error) {
+	res := make([]*structpb.Struct, len(msgs))
+	for i, m := range msgs {
+		s, err := structpb.NewStruct(m)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		res[i] = s
+	}
+
+	return res, nil
+}
+
+// toProtoAttributes returns the free-form attributes as the struct. The
+// attributes decoded from JSON always convert, while the attributes that
+// don't are left out.
+func toProtoAttributes(attrs map[string]interface{}) *structpb.Struct {
+	if len(attrs) == 0 {
+		return nil
+	}
+	s, err := structpb.NewStruct(attrs)
+	if err != nil {
+		return nil
+	}
+
+	return s
+}
+
+func fromProtoAttributes(s *structpb.Struct) map[string]interface{} {
+	if len(s.GetFields()) == 0 {
+		return nil
+	}
+
+	return s.AsMap()
+}
+
+func fromProtoStructs(msgs []*structpb.Struct) []map[string]interface{} {
+	res := make([]map[string]interface{}, len(msgs))
+	for i, m := range msgs {
+		res[i] = m.AsMap()
+	}
+
+	return res
+}
+
+func toProtoSamples(samples map[string][]map[string]interface{}) (map[string]*Samples, error) {
+	res := make(map[string]*Samples, len(samples))
+	for name, msgs := range samples {
+		structs, err := toProtoStructs(msgs)
+		if err != nil {
+			return nil, err
+		}
+		res[name] = &Samples{Messages: structs}
+	}
+
+	return res, nil
+}
+
+func fromProtoSamples(samples map[string]*Samples) map[string][]map[string]interface{} {
+	res := make(map[string][]map[string]interface{}, len(samples))
+	for name, s := range samples {
+		res[name] = fromProtoStructs(s.GetMessages())
+	}
+
+	return res
+}
+
+func toProtoReplayResult(res re.ReplayResult) (*ReplayResult, error) {
+	results, err := toProtoStructs(res.Results)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayResult{Messages: int64(res.Messages), Truncated: res.Truncated, Results: results}, nil
+}
+
+func fromProtoReplayResult(res *ReplayResult) re.ReplayResult {
+	return re.ReplayResult{
+		Messages:  int(res.GetMessages()),
+		Truncated: res.GetTruncated(),
+		Results:   fromProtoStructs(res.GetResults()),
+	}
+}
+
+func toProtoRuleStatus(status re.RuleStatus) *RuleStatusRes {
+	ops := make([]*OperatorMetrics, len(status.Operators))
+	for i, op := range status.Operators {
+		ops[i] = &OperatorMetrics{
+			Name:              op.Name,
+			RecordsIn:         op.RecordsIn,
+			RecordsOut:        op.RecordsOut,
+			Exceptions:        op.Exceptions,
+			LastException:     op.LastException,
+			LastExceptionTime: op.LastExceptionTime,
+			ProcessLatencyUs:  op.ProcessLatencyUs,
+			BufferLength:      op.BufferLength,
+			LastInvocation:    op.LastInvocation,
+		}
+	}
+
+	return &RuleStatusRes{Status: status.Status, Message: status.Message, Operators: ops}
+}
+
+func fromProtoRuleStatus(status *RuleStatusRes) re.RuleStatus {
+	var ops []re.OperatorMetrics
+	for _, op := range status.GetOperators() {
+		ops = append(ops, re.OperatorMetrics{
+			Name:              op.GetName(),
+			RecordsIn:         op.GetRecordsIn(),
+			RecordsOut:        op.GetRecordsOut(),
+			Exceptions:        op.GetExceptions(),
+			LastException:     op.GetLastException(),
+			LastExceptionTime: op.GetLastExceptionTime(),
+			ProcessLatencyUs:  op.GetProcessLatencyUs(),
+			BufferLength:      op.GetBufferLength(),
+			LastInvocation:    op.GetLastInvocation(),
+		})
+	}
+
+	return re.RuleStatus{Status: status.GetStatus(), Message: status.GetMessage(), Operators: ops}
+}
+
+func toProtoRuleTopology(topo re.RuleTopology) *RuleTopologyRes {
+	res := &RuleTopologyRes{
+		Nodes: make([]*TopologyNode, len(topo.Nodes)),
+		Edges: make([]*TopologyEdge, len(topo.Edges)),
+	}
+	for i, n := range topo.Nodes {
+		res.Nodes[i] = &TopologyNode{Name: n.Name, Type: n.Type}
+	}
+	for i, e := range topo.Edges {
+		res.Edges[i] = &TopologyEdge{From: e.From, To: e.To}
+	}
+
+	return res
+}
+
+func fromProtoRuleTopology(topo *RuleTopologyRes) re.RuleTopology {
+	res := re.RuleTopology{
+		Nodes: make([]re.TopologyNode, len(topo.GetNodes())),
+		Edges: make([]re.TopologyEdge, len(topo.GetEdges())),
+	}
+	for i, n := range topo.GetNodes() {
+		res.Nodes[i] = re.TopologyNode{Name: n.GetName(), Type: n.GetType()}
+	}
+	for i, e := range topo.GetEdges() {
+		res.Edges[i] = re.TopologyEdge{From: e.GetFrom(), To: e.GetTo()}
+	}
+
+	return res
+}
+
+func toProtoDriftReport(report re.DriftReport) *DriftReport {
+	drifts := make([]*Drift, len(report.Drifts))
+	for i, d := range report.Drifts {
+		drifts[i] = &Drift{Kind: d.Kind, Name: d.Name, Owner: d.Owner, Missing: d.Missing, Repaired: d.Repaired, Error: d.Error}
+	}
+
+	return &DriftReport{CheckedAt: timestamppb.New(report.CheckedAt), Drifts: drifts}
+}
+
+func fromProtoDriftReport(report *DriftReport) re.DriftReport {
+	drifts := make([]re.Drift, len(report.GetDrifts()))
+	for i, d := range report.GetDrifts() {
+		drifts[i] = re.Drift{Kind: d.GetKind(), Name: d.GetName(), Owner: d.GetOwner(), Missing: d.GetMissing(), Repaired: d.GetRepaired(), Error: d.GetError()}
+	}
+
+	return re.DriftReport{CheckedAt: report.GetCheckedAt().AsTime(), Drifts: drifts}
+}
+
+func toProtoOrphanReport(report re.OrphanReport) *OrphanReport {
+	orphans := make([]*Orphan, len(report.Orphans))
+	for i, o := range report.Orphans {
+		orphans[i] = &Orphan{Kind: o.Kind, Name: o.Name, Owner: o.Owner, Reason: o.Reason, Removed: o.Removed, Error: o.Error}
+	}
+
+	return &OrphanReport{CheckedAt: timestamppb.New(report.CheckedAt), Orphans: orphans}
+}
+
+func fromProtoOrphanReport(report *OrphanReport) re.OrphanReport {
+	orphans := make([]re.Orphan, len(report.GetOrphans()))
+	for i, o := range report.GetOrphans() {
+		orphans[i] = re.Orphan{Kind: o.GetKind(), Name: o.GetName(), Owner: o.GetOwner(), Reason: o.GetReason(), Removed: o.GetRemoved(), Error: o.GetError()}
+	}
+
+	return re.OrphanReport{CheckedAt: report.GetCheckedAt().AsTime(), Orphans: orphans}
+}
+
+func toProtoRestoreReport(report re.RestoreReport) *RestoreReport {
+	counts := make(map[string]int64, len(report.Counts))
+	for status, n := range report.Counts {
+		counts[status] = int64(n)
+	}
+	entities := make([]*RestoredEntity, len(report.Entities))
+	for i, e := range report.Entities {
+		entities[i] = &RestoredEntity{Kind: e.Kind, Name: e.Name, Owner: e.Owner, Status: e.Status, Error: e.Error}
+	}
+
+	return &RestoreReport{
+		DryRun:     report.DryRun,
+		StartedAt:  timestamppb.New(report.StartedAt),
+		FinishedAt: timestamppb.New(report.FinishedAt),
+		Counts:     counts,
+		Entities:   entities,
+	}
+}
+
+func fromProtoRestoreReport(report *RestoreReport) re.RestoreReport {
+	counts := make(map[string]int, len(report.GetCounts()))
+	for status, n := range report.GetCounts() {
+		counts[status] = int(n)
+	}
+	entities := make([]re.RestoredEntity, len(report.GetEntities()))
+	for i, e := range report.GetEntities() {
+		entities[i] = re.RestoredEntity{Kind: e.GetKind(), Name: e.GetName(), Owner: e.GetOwner(), Status: e.GetStatus(), Error: e.GetError()}
+	}
+
+	return re.RestoreReport{
+		DryRun:     report.GetDryRun(),
+		StartedAt:  report.GetStartedAt().AsTime(),
+		FinishedAt: report.GetFinishedAt().AsTime(),
+		Counts:     counts,
+		Entities:   entities,
+	}
+}
+
+func toProtoStreamDef(def re.StreamDef) *StreamDef {
+	return &StreamDef{
+		Name:        def.Name,
+		Topic:       def.Topic,
+		Fields:      toProtoFields(def.Fields),
+		Type:        def.Type,
+		Format:      def.Format,
+		Delimiter:   def.Delimiter,
+		SchemaId:    def.SchemaID,
+		Senml:       def.SenML,
+		Description: def.Description,
+		Labels:      def.Labels,
+		ConfKey:     def.ConfKey,
+		Attributes:  toProtoAttributes(def.Attributes),
+	}
+}
+
+func fromProtoStreamDef(def *StreamDef) re.StreamDef {
+	return re.StreamDef{
+		Name:        def.GetName(),
+		Topic:       def.GetTopic(),
+		Fields:      fromProtoFields(def.GetFields()),
+		Type:        def.GetType(),
+		Format:      def.GetFormat(),
+		Delimiter:   def.GetDelimiter(),
+		SchemaID:    def.GetSchemaId(),
+		SenML:       def.GetSenml(),
+		Description: def.GetDescription(),
+		Labels:      def.GetLabels(),
+		ConfKey:     def.GetConfKey(),
+		Attributes:  fromProtoAttributes(def.GetAttributes()),
+	}
+}
+
+func toProtoRuleset(rs re.Ruleset) *Ruleset {
+	streams := make([]*StreamDef, len(rs.Streams))
+	for i, def := range rs.Streams {
+		streams[i] = toProtoStreamDef(def)
+	}
+	rules := make([]*Rule, len(rs.Rules))
+	for i, rule := range rs.Rules {
+		rules[i] = toProtoRule(rule)
+	}
+
+	return &Ruleset{Streams: streams, Rules: rules, Skipped: rs.Skipped}
+}
+
+func fromProtoRuleset(rs *Ruleset) re.Ruleset {
+	streams := make([]re.StreamDef, len(rs.GetStreams()))
+	for i, def := range rs.GetStreams() {
+		streams[i] = fromProtoStreamDef(def)
+	}
+	rules := make([]re.Rule, len(rs.GetRules()))
+	for i, rule := range rs.GetRules() {
+		rules[i] = fromProtoRule(rule)
+	}
+
+	return re.Ruleset{Streams: streams, Rules: rules, Skipped: rs.GetSkipped()}
+}
+
+func toProtoImportReport(report re.ImportReport) *ImportReport {
+	counts := make(map[string]int64, len(report.Counts))
+	for status, n := range report.Counts {
+		counts[status] = int64(n)
+	}
+	entities := make([]*ImportedEntity, len(report.Entities))
+	for i, e := range report.Entities {
+		entities[i] = &ImportedEntity{Kind: e.Kind, Name: e.Name, Renamed: e.Renamed, Status: e.Status, Error: e.Error}
+	}
+
+	return &ImportReport{Conflict: report.Conflict, Counts: counts, Entities: entities}
+}
+
+func fromProtoImportReport(report *ImportReport) re.ImportReport {
+	counts := make(map[string]int, len(report.GetCounts()))
+	for status, n := range report.GetCounts() {
+		counts[status] = int(n)
+	}
+	entities := make([]re.ImportedEntity, len(report.GetEntities()))
+	for i, e := range report.GetEntities() {
+		entities[i] = re.ImportedEntity{Kind: e.GetKind(), Name: e.GetName(), Renamed: e.GetRenamed(), Status: e.GetStatus(), Error: e.GetError()}
+	}
+
+	return re.ImportReport{Conflict: report.GetConflict(), Counts: counts, Entities: entities}
+}
+
+func toProtoBulkReport(report re.BulkReport) *BulkReport {
+	items := make([]*BulkItem, len(report.Items))
+	for i, item := range report.Items {
+		items[i] = &BulkItem{Kind: item.Kind, Name: item.Name, Success: item.Success, Error: item.Error}
+	}
+
+	return &BulkReport{Succeeded: int64(report.Succeeded), Failed: int64(report.Failed), Items: items}
+}
+
+func fromProtoBulkReport(report *BulkReport) re.BulkReport {
+	items := make([]re.BulkItem, len(report.GetItems()))
+	for i, item := range report.GetItems() {
+		items[i] = re.BulkItem{Kind: item.GetKind(), Name: item.GetName(), Success: item.GetSuccess(), Error: item.GetError()}
+	}
+
+	return re.BulkReport{Succeeded: int(report.GetSucceeded()), Failed: int(report.GetFailed()), Items: items}
+}
+
+func toProtoAllStreams(all re.AllStreams) *AllStreams {
+	owners := make([]*OwnerStreams, len(all.Owners))
+	for i, o := range all.Owners {
+		owners[i] = &OwnerStreams{Owner: o.Owner, Email: o.Email, Streams: o.Streams}
+	}
+
+	return &AllStreams{Total: int64(all.Total), Owners: owners}
+}
+
+func fromProtoAllStreams(all *AllStreams) re.AllStreams {
+	owners := make([]re.OwnerStreams, len(all.GetOwners()))
+	for i, o := range all.GetOwners() {
+		owners[i] = re.OwnerStreams{Owner: o.GetOwner(), Email: o.GetEmail(), Streams: o.GetStreams()}
+	}
+
+	return re.AllStreams{Total: int(all.GetTotal()), Owners: owners}
+}
+
+func toProtoAllRules(all re.AllRules) *AllRules {
+	owners := make([]*OwnerRules, len(all.Owners))
+	for i, o := range all.Owners {
+		rules := make([]*RuleInfo, len(o.Rules))
+		for j, r := range o.Rules {
+			rules[j] = &RuleInfo{Id: r.ID, Status: r.Status, Metadata: toProtoMetadata(r.Metadata)}
+		}
+		owners[i] = &OwnerRules{Owner: o.Owner, Email: o.Email, States: toProtoCounts(o.States), Rules: rules}
+	}
+
+	return &AllRules{Total: int64(all.Total), States: toProtoCounts(all.States), Owners: owners}
+}
+
+func fromProtoAllRules(all *AllRules) re.AllRules {
+	owners := make([]re.OwnerRules, len(all.GetOwners()))
+	for i, o := range all.GetOwners() {
+		rules := make([]re.RuleInfo, len(o.GetRules()))
+		for j, r := range o.GetRules() {
+			rules[j] = re.RuleInfo{ID: r.GetId(), Status: r.GetStatus(), Metadata: fromProtoMetadata(r.GetMetadata())}
+		}
+		owners[i] = re.OwnerRules{Owner: o.GetOwner(), Email: o.GetEmail(), States: fromProtoCounts(o.GetStates()), Rules: rules}
+	}
+
+	return re.AllRules{Total: int(all.GetTotal()), States: fromProtoCounts(all.GetStates()), Owners: owners}
+}
+
+func toProtoUserQuota(q re.UserQuota) *UserQuota {
+	return &UserQuota{
+		UserId:     q.UserID,
+		MaxStreams: int64(q.MaxStreams),
+		MaxRules:   int64(q.MaxRules),
+		Override:   q.Override,
+		Streams:    int64(q.Streams),
+		Rules:      int64(q.Rules),
+	}
+}
+
+func fromProtoUserQuota(q *UserQuota) re.UserQuota {
+	return re.UserQuota{
+		UserID:   q.GetUserId(),
+		Quota:    re.Quota{MaxStreams: int(q.GetMaxStreams()), MaxRules: int(q.GetMaxRules())},
+		Override: q.GetOverride(),
+		Streams:  int(q.GetStreams()),
+		Rules:    int(q.GetRules()),
+	}
+}
+
+func toProtoShare(s re.Share) *Share {
+	return &Share{Grantee: s.Grantee, GranteeType: s.GranteeType, Access: s.Access}
+}
+
+func fromProtoShare(s *Share) re.Share {
+	return re.Share{Grantee: s.GetGrantee(), GranteeType: s.GetGranteeType(), Access: s.GetAccess()}
+}
+
+func toProtoCounts(counts map[string]int) map[string]int64 {
+	res := make(map[string]int64, len(counts))
+	for k, n := range counts {
+		res[k] = int64(n)
+	}
+
+	return res
+}
+
+func fromProtoCounts(counts map[string]int64) map[string]int {
+	res := make(map[string]int, len(counts))
+	for k, n := range counts {
+		res[k] = int(n)
+	}
+
+	return res
+}
+
+func toProtoTemplate(tmpl re.Template) *Template {
+	vars := make([]*Variable, len(tmpl.Variables))
+	for i, v := range tmpl.Variables {
+		vars[i] = &Variable{Name: v.Name, Type: v.Type, Description: v.Description, Default: v.Default}
+	}
+	actions := make([]*Action, len(tmpl.Actions))
+	for i, a := range tmpl.Actions {
+		actions[i] = toProtoAction(a)
+	}
+
+	return &Template{
+		Name:        tmpl.Name,
+		Description: tmpl.Description,
+		Variables:   vars,
+		Sql:         tmpl.SQL,
+		Actions:     actions,
+		Options:     toProtoRuleOptions(tmpl.Options),
+		CreatedAt:   timestamppb.New(tmpl.CreatedAt),
+	}
+}
+
+func fromProtoTemplate(tmpl *Template) re.Template {
+	vars := make([]re.Variable, len(tmpl.GetVariables()))
+	for i, v := range tmpl.GetVariables() {
+		vars[i] = re.Variable{Name: v.GetName(), Type: v.GetType(), Description: v.GetDescription(), Default: v.GetDefault()}
+	}
+	actions := make([]re.Action, len(tmpl.GetActions()))
+	for i, a := range tmpl.GetActions() {
+		actions[i] = fromProtoAction(a)
+	}
+
+	return re.Template{
+		Name:        tmpl.GetName(),
+		Description: tmpl.GetDescription(),
+		Variables:   vars,
+		SQL:         tmpl.GetSql(),
+		Actions:     actions,
+		Options:     fromProtoRuleOptions(tmpl.GetOptions()),
+		CreatedAt:   tmpl.GetCreatedAt().AsTime(),
+	}
+}
+
+func toProtoExternalFunction(f re.ExternalFunction) *ExternalFunction {
+	return &ExternalFunction{
+		Name:          f.Name,
+		ServiceName:   f.ServiceName,
+		InterfaceName: f.InterfaceName,
+		MethodName:    f.MethodName,
+	}
+}
+
+func fromProtoExternalFunction(f *ExternalFunction) re.ExternalFunction {
+	return re.ExternalFunction{
+		Name:          f.GetName(),
+		ServiceName:   f.GetServiceName(),
+		InterfaceName: f.GetInterfaceName(),
+		MethodName:    f.GetMethodName(),
+	}
+}
+
+func toProtoGateway(gw re.Gateway) *Gateway {
+	return &Gateway{Id: gw.ID, Name: gw.Name, Channel: gw.Channel, Labels: gw.Labels, CreatedAt: timestamppb.New(gw.CreatedAt)}
+}
+
+func fromProtoGateway(gw *Gateway) re.Gateway {
+	res := re.Gateway{ID: gw.GetId(), Name: gw.GetName(), Channel: gw.GetChannel(), Labels: gw.GetLabels()}
+	if gw.GetCreatedAt() != nil {
+		res.CreatedAt = gw.GetCreatedAt().AsTime()
+	}
+
+	return res
+}
+
+func toProtoDeployment(d re.Deployment) *Deployment {
+	return &Deployment{Rule: d.Rule, Gateway: d.Gateway, Status: d.Status, Error: d.Error, UpdatedAt: timestamppb.New(d.UpdatedAt)}
+}
+
+func fromProtoDeployment(d *Deployment) re.Deployment {
+	return re.Deployment{Rule: d.GetRule(), Gateway: d.GetGateway(), Status: d.GetStatus(), Error: d.GetError(), UpdatedAt: d.GetUpdatedAt().AsTime()}
+}
+
+func toProtoRollout(r re.Rollout) *Rollout {
+	ds := make([]*Deployment, len(r.Deployments))
+	for i, d := range r.Deployments {
+		ds[i] = toProtoDeployment(d)
+	}
+
+	return &Rollout{
+		Rule:        r.Rule,
+		Status:      r.Status,
+		Total:       int64(r.Total),
+		Pending:     int64(r.Pending),
+		Running:     int64(r.Running),
+		Failed:      int64(r.Failed),
+		Removing:    int64(r.Removing),
+		Deployments: ds,
+	}
+}
+
+func fromProtoRollout(r *Rollout) re.Rollout {
+	ds := make([]re.Deployment, len(r.GetDeployments()))
+	for i, d := range r.GetDeployments() {
+		ds[i] = fromProtoDeployment(d)
+	}
+
+	return re.Rollout{
+		Rule:        r.GetRule(),
+		Status:      r.GetStatus(),
+		Total:       int(r.GetTotal()),
+		Pending:     int(r.GetPending()),
+		Running:     int(r.GetRunning()),
+		Failed:      int(r.GetFailed()),
+		Removing:    int(r.GetRemoving()),
+		Deployments: ds,
+	}
+}
+
+func toProtoEngineStats(stats re.EngineStats) *EngineStatsRes {
+	res := &EngineStatsRes{
+		Streams:    int64(stats.Streams),
+		Tables:     int64(stats.Tables),
+		Rules:      int64(stats.Rules),
+		States:     make(map[string]int64, len(stats.States)),
+		RecordsIn:  stats.RecordsIn,
+		RecordsOut: stats.RecordsOut,
+		Exceptions: stats.Exceptions,
+	}
+	for state, n := range stats.States {
+		res.States[state] = int64(n)
+	}
+	if iv := stats.Interval; iv != nil {
+		res.Interval = &StatsInterval{
+			Since:      timestamppb.New(iv.Since),
+			RecordsIn:  iv.RecordsIn,
+			RecordsOut: iv.RecordsOut,
+			Exceptions: iv.Exceptions,
+		}
+	}
+
+	return res
+}
+
+func fromProtoEngineStats(stats *EngineStatsRes) re.EngineStats {
+	res := re.EngineStats{
+		Streams:    int(stats.GetStreams()),
+		Tables:     int(stats.GetTables()),
+		Rules:      int(stats.GetRules()),
+		States:     make(map[string]int, len(stats.GetStates())),
+		RecordsIn:  stats.GetRecordsIn(),
+		RecordsOut: stats.GetRecordsOut(),
+		Exceptions: stats.GetExceptions(),
+	}
+	for state, n := range stats.GetStates() {
+		res.States[state] = int(n)
+	}
+	if iv := stats.GetInterval(); iv != nil {
+		res.Interval = &re.StatsInterval{
+			Since:      iv.GetSince().AsTime(),
+			RecordsIn:  iv.GetRecordsIn(),
+			RecordsOut: iv.GetRecordsOut(),
+			Exceptions: iv.GetExceptions(),
+		}
+	}
+
+	return res
+}
+
+func toProtoAlertPolicy(p re.AlertPolicy) *AlertPolicy {
+	res := &AlertPolicy{Exceptions: p.Exceptions, Webhook: p.Webhook}
+	if p.Email != nil {
+		res.Email = &NotificationSink{Channel: p.Email.Channel, Contacts: p.Email.Contacts}
+	}
+	if p.SMS != nil {
+		res.Sms = &NotificationSink{Channel: p.SMS.Channel, Contacts: p.SMS.Contacts}
+	}
+
+	return res
+}
+
+func fromProtoAlertPolicy(p *AlertPolicy) re.AlertPolicy {
+	res := re.AlertPolicy{Exceptions: p.GetExceptions(), Webhook: p.GetWebhook()}
+	if n := p.GetEmail(); n != nil {
+		res.Email = &re.NotificationSink{Channel: n.GetChannel(), Contacts: n.GetContacts()}
+	}
+	if n := p.GetSms(); n != nil {
+		res.SMS = &re.NotificationSink{Channel: n.GetChannel(), Contacts: n.GetContacts()}
+	}
+
+	return res
+}
+
+func toProtoWebhook(wh re.Webhook) *Webhook {
+	return &Webhook{Id: wh.ID, Url: wh.URL, Secret: wh.Secret, Events: wh.Events, CreatedAt: timestamppb.New(wh.CreatedAt)}
+}
+
+func fromProtoWebhook(wh *Webhook) re.Webhook {
+	res := re.Webhook{ID: wh.GetId(), URL: wh.GetUrl(), Secret: wh.GetSecret(), Events: wh.GetEvents()}
+	if wh.GetCreatedAt() != nil {
+		res.CreatedAt = wh.GetCreatedAt().AsTime()
+	}
+
+	return res
+}
+
+func toProtoFolder(f re.Folder) *Folder {
+	return &Folder{Name: f.Name, Description: f.Description, Rules: int64(f.Rules), CreatedAt: timestamppb.New(f.CreatedAt)}
+}
+
+func fromProtoFolder(f *Folder) re.Folder {
+	res := re.Folder{Name: f.GetName(), Description: f.GetDescription(), Rules: int(f.GetRules())}
+	if f.GetCreatedAt() != nil {
+		res.CreatedAt = f.GetCreatedAt().AsTime()
+	}
+
+	return res
+}
+
+func toProtoMaintenance(m re.Maintenance) *Maintenance {
+	return &Maintenance{Rules: m.Rules, Since: timestamppb.New(m.Since)}
+}
+
+func fromProtoMaintenance(m *Maintenance) re.Maintenance {
+	res := re.Maintenance{Rules: m.GetRules()}
+	if m.GetSince() != nil {
+		res.Since = m.GetSince().AsTime()
+	}
+
+	return res
+}
+
+func toProtoChain(c re.Chain) *Chain {
+	return &Chain{Name: c.Name, From: c.From, To: c.To, Fields: toProtoFields(c.Fields), CreatedAt: timestamppb.New(c.CreatedAt)}
+}
+
+func fromProtoChain(c *Chain) re.Chain {
+	res := re.Chain{Name: c.GetName(), From: c.GetFrom(), To: c.GetTo(), Fields: fromProtoFields(c.GetFields())}
+	if c.GetCreatedAt() != nil {
+		res.CreatedAt = c.GetCreatedAt().AsTime()
+	}
+
+	return res
+}
+
+func toProtoSecret(s re.Secret) *Secret {
+	res := &Secret{Name: s.Name, Value: s.Value}
+	if !s.CreatedAt.IsZero() {
+		res.CreatedAt = timestamppb.New(s.CreatedAt)
+		res.UpdatedAt = timestamppb.New(s.UpdatedAt)
+	}
+
+	return res
+}
+
+func fromProtoSecret(s *Secret) re.Secret {
+	res := re.Secret{Name: s.GetName(), Value: s.GetValue()}
+	if s.GetCreatedAt() != nil {
+		res.CreatedAt = s.GetCreatedAt().AsTime()
+	}
+	if s.GetUpdatedAt() != nil {
+		res.UpdatedAt = s.GetUpdatedAt().AsTime()
+	}
+
+	return res
+}
+
+func toProtoRuleStateChange(c re.RuleStateChange) *RuleStateChange {
+	return &RuleStateChange{Rule: c.Rule, From: c.From, To: c.To, Error: c.Error, Time: timestamppb.New(c.Time)}
+}
+
+func fromProtoRuleStateChange(c *RuleStateChange) re.RuleStateChange {
+	res := re.RuleStateChange{Rule: c.GetRule(), From: c.GetFrom(), To: c.GetTo(), Error: c.GetError()}
+	if c.GetTime() != nil {
+		res.Time = c.GetTime().AsTime()
+	}
+
+	return res
+}
+
+func toProtoLogEntry(l re.LogEntry) *LogEntry {
+	return &LogEntry{Time: timestamppb.New(l.Time), Level: l.Level, Message: l.Message, File: l.File}
+}
+
+func fromProtoLogEntry(l *LogEntry) re.LogEntry {
+	res := re.LogEntry{Level: l.GetLevel(), Message: l.GetMessage(), File: l.GetFile()}
+	if l.GetTime() != nil {
+		res.Time = l.GetTime().AsTime()
+	}
+
+	return res
+}
+
+func toProtoStreamsBatch(batch re.StreamsBatch) (*StreamsBatch, error) {
+	streams := make([]*Stream, len(batch.Streams))
+	for i, s := range batch.Streams {
+		stream, err := toProtoStream(s)
+		if err != nil {
+			return nil, err
+		}
+		streams[i] = stream
+	}
+
+	return &StreamsBatch{Streams: streams, Missing: batch.Missing}, nil
+}
+
+func fromProtoStreamsBatch(batch *StreamsBatch) re.StreamsBatch {
+	streams := make([]re.Stream, len(batch.GetStreams()))
+	for i, s := range batch.GetStreams() {
+		streams[i] = fromProtoStream(s)
+	}
+
+	return re.StreamsBatch{Streams: streams, Missing: batch.GetMissing()}
+}
+
+func toProtoRulesBatch(batch re.RulesBatch) *RulesBatch {
+	rules := make([]*Rule, len(batch.Rules))
+	for i, r := range batch.Rules {
+		rules[i] = toProtoRule(r)
+	}
+
+	return &RulesBatch{Rules: rules, Missing: batch.Missing}
+}
+
+func fromProtoRulesBatch(batch *RulesBatch) re.RulesBatch {
+	rules := make([]re.Rule, len(batch.GetRules()))
+	for i, r := range batch.GetRules() {
+		rules[i] = fromProtoRule(r)
+	}
+
+	return re.RulesBatch{Rules: rules, Missing: batch.GetMissing()}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertAction(t *testing.T) {
+	cases := []struct {
+		desc   string
+		action re.Action
+	}{
+		{
+			desc:   "mainflux action",
+			action: re.Action{Mainflux: &re.MainfluxSink{Host: "nats", Port: "4222", Channel: "channel", Subtopic: "alarms"}},
+		},
+		{
+			desc:   "rest action",
+			action: re.Action{REST: &re.RESTSink{URL: "https://example.com", Method: "post", BodyType: "json", Headers: map[string]string{"X-Key": "key"}, Timeout: 1000, SendSingle: true, DataTemplate: "{{.v}}"}},
+		},
+		{
+			desc:   "mqtt action",
+			action: re.Action{MQTT: &re.MQTTSink{Server: "tcp://broker:1883", Topic: "alarms", QoS: 1, ClientID: "re", Username: "user", Password: "pass", Retained: true, SendSingle: true}},
+		},
+		{
+			desc:   "log action",
+			action: re.Action{Log: &re.LogSink{}},
+		},
+		{
+			desc:   "nop action",
+			action: re.Action{Nop: &re.NopSink{Log: true}},
+		},
+		{
+			desc:   "writer action",
+			action: re.Action{Writer: &re.WriterSink{Type: re.PostgresWriter, Table: "alarms", Fields: []string{"v"}}},
+		},
+		{
+			desc:   "email action",
+			action: re.Action{Email: &re.NotificationSink{Channel: "channel", Contacts: []string{"admin@example.com"}}},
+		},
+		{
+			desc:   "sms action",
+			action: re.Action{SMS: &re.NotificationSink{Channel: "channel", Contacts: []string{"+381601234567"}}},
+		},
+		{
+			desc:   "memory action",
+			action: re.Action{Memory: &re.MemorySink{Topic: "chains/filtered"}},
+		},
+		{
+			desc:   "action with data template",
+			action: re.Action{Mainflux: &re.MainfluxSink{Channel: "channel"}, DataTemplate: `{"temp": {{.v}}}`},
+		},
+		{
+			desc: "action with delivery",
+			action: re.Action{
+				REST: &re.RESTSink{URL: "https://example.com"},
+				Delivery: &re.Delivery{
+					RetryCount:           3,
+					RetryInterval:        500,
+					EnableCache:          true,
+					MemoryCacheThreshold: 1024,
+					MaxDiskCache:         4096,
+					BufferPageSize:       256,
+					ResendInterval:       100,
+					CleanCacheAtStop:     true,
+					DeadLetter:           &re.DeadLetter{Channel: "channel", Subtopic: "failed"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		action := fromProtoAction(toProtoAction(tc.action))
+		assert.Equal(t, tc.action, action, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.action, action))
+	}
+}
+
+func TestConvertMetadata(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	cases := []struct {
+		desc string
+		md   *re.Metadata
+	}{
+		{
+			desc: "no metadata",
+		},
+		{
+			desc: "created metadata",
+			md:   &re.Metadata{Owner: "owner", Description: "description", Labels: map[string]string{"site": "plant"}, CreatedAt: created},
+		},
+		{
+			desc: "updated metadata",
+			md:   &re.Metadata{Owner: "owner", CreatedAt: created, UpdatedAt: created.Add(time.Hour)},
+		},
+		{
+			desc: "started metadata",
+			md:   &re.Metadata{Owner: "owner", CreatedAt: created, LastStartedAt: created.Add(2 * time.Hour)},
+		},
+		{
+			desc: "metadata with attributes",
+			md:   &re.Metadata{Owner: "owner", Attributes: map[string]interface{}{"runbook": "https://example.com/runbook", "priority": float64(2), "tags": []interface{}{"a", "b"}}, CreatedAt: created},
+		},
+		{
+			desc: "metadata of rule in folder",
+			md:   &re.Metadata{Owner: "owner", Folder: "site_a", CreatedAt: created},
+		},
+		{
+			desc: "metadata of temporary rule",
+			md:   &re.Metadata{Owner: "owner", CreatedAt: created, ExpiresAt: created.Add(2 * time.Hour)},
+		},
+		{
+			desc: "metadata of cron scheduled rule",
+			md:   &re.Metadata{Owner: "owner", CreatedAt: created, Schedule: &re.Schedule{Cron: "0 9 * * 1-5", Duration: "8h", Timezone: "Europe/Belgrade"}},
+		},
+		{
+			desc: "metadata of rule scheduled in windows",
+			md:   &re.Metadata{Owner: "owner", CreatedAt: created, Schedule: &re.Schedule{Windows: []re.Window{{Days: []string{"sat", "sun"}, Start: "22:00", End: "06:00"}}}},
+		},
+	}
+
+	for _, tc := range cases {
+		md := fromProtoMetadata(toProtoMetadata(tc.md))
+		assert.Equal(t, tc.md, md, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.md, md))
+	}
+
+	page := re.StreamsPage{Total: 1, Limit: 10, Streams: []string{"s"}, Metadata: map[string]re.Metadata{"s": *cases[1].md}}
+	res := fromProtoStreamsPage(toProtoStreamsPage(page))
+	assert.Equal(t, page, res, fmt.Sprintf("streams page: expected %v got %v\n", page, res))
+}
+
+func TestConvertDriftReport(t *testing.T) {
+	report := re.DriftReport{
+		CheckedAt: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		Drifts: []re.Drift{
+			{Kind: re.RuleKind, Name: "u1234_rule", Owner: "owner", Missing: re.MissingInKuiper, Repaired: true},
+			{Kind: re.StreamKind, Name: "u1234_stream", Owner: "owner", Missing: re.MissingInMetadata, Error: "failed"},
+		},
+	}
+
+	res := fromProtoDriftReport(toProtoDriftReport(report))
+	assert.Equal(t, report, res, fmt.Sprintf("expected %v got %v\n", report, res))
+}
+
+func TestConvertRuleValidation(t *testing.T) {
+	validation := re.RuleValidation{
+		Diagnostics: []re.Diagnostic{
+			{Field: "sql", Position: 15, Message: "expected stream name"},
+			{Field: "actions[0]", Message: "missing sink"},
+		},
+	}
+
+	res := fromProtoRuleValidation(toProtoRuleValidation(validation))
+	assert.Equal(t, validation, res, fmt.Sprintf("expected %v got %v\n", validation, res))
+}
+
+func TestConvertSamples(t *testing.T) {
+	samples := map[string][]map[string]interface{}{
+		"temperature": {{"v": 15.0, "unit": "C"}, {"v": 25.0, "tags": []interface{}{"a", "b"}}},
+		"humidity":    {},
+	}
+
+	res, err := toProtoSamples(samples)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, samples, fromProtoSamples(res), fmt.Sprintf("expected %v got %v\n", samples, fromProtoSamples(res)))
+
+	_, err = toProtoSamples(map[string][]map[string]interface{}{"temperature": {{"v": make(chan int)}}})
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+}
+
+func TestConvertReplayResult(t *testing.T) {
+	result := re.ReplayResult{
+		Messages:  3,
+		Truncated: true,
+		Results:   []map[string]interface{}{{"n": "temp", "v": 15.0}, {"n": "temp", "v": 25.0}},
+	}
+
+	res, err := toProtoReplayResult(result)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, result, fromProtoReplayResult(res), fmt.Sprintf("expected %v got %v\n", result, fromProtoReplayResult(res)))
+}
+
+func TestConvertRestoreReport(t *testing.T) {
+	started := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	report := re.RestoreReport{
+		DryRun:     true,
+		StartedAt:  started,
+		FinishedAt: started.Add(time.Second),
+		Counts:     map[string]int{re.RestorePending: 1, re.RestoreSkipped: 1},
+		Entities: []re.RestoredEntity{
+			{Kind: re.StreamKind, Name: "u1234_stream", Owner: "owner", Status: re.RestorePending},
+			{Kind: re.RuleKind, Name: "u1234_rule", Owner: "owner", Status: re.RestoreSkipped},
+		},
+	}
+
+	res := fromProtoRestoreReport(toProtoRestoreReport(report))
+	assert.Equal(t, report, res, fmt.Sprintf("expected %v got %v\n", report, res))
+}
+
+func TestConvertRuleset(t *testing.T) {
+	rs := re.Ruleset{
+		Streams: []re.StreamDef{
+			{Name: "readings", Topic: "channel", Type: re.MainfluxSource, Format: re.JSONFormat, SenML: true, Description: "readings", Labels: map[string]string{"site": "a"}},
+			{Name: "events", Topic: "events", Type: re.MemorySource, Format: re.JSONFormat, Fields: []re.Field{{Name: "v", Type: "float"}}},
+		},
+		Rules:   []re.Rule{{ID: "alarm", SQL: "SELECT * FROM readings", Actions: []re.Action{{Log: &re.LogSink{}}}, Description: "alarm"}},
+		Skipped: []string{"legacy"},
+	}
+
+	res := fromProtoRuleset(toProtoRuleset(rs))
+	assert.Equal(t, rs, res, fmt.Sprintf("expected %v got %v\n", rs, res))
+}
+
+func TestConvertImportReport(t *testing.T) {
+	report := re.ImportReport{
+		Conflict: re.ConflictRename,
+		Counts:   map[string]int{re.ImportRenamed: 1, re.ImportFailed: 1},
+		Entities: []re.ImportedEntity{
+			{Kind: re.StreamKind, Name: "readings", Renamed: "readings_1", Status: re.ImportRenamed},
+			{Kind: re.RuleKind, Name: "alarm", Status: re.ImportFailed, Error: "malformed entity"},
+		},
+	}
+
+	res := fromProtoImportReport(toProtoImportReport(report))
+	assert.Equal(t, report, res, fmt.Sprintf("expected %v got %v\n", report, res))
+}
+
+func TestConvertTemplate(t *testing.T) {
+	tmpl := re.Template{
+		Name:        "threshold",
+		Description: "threshold alarm",
+		Variables: []re.Variable{
+			{Name: "stream", Type: re.StreamVariable},
+			{Name: "threshold", Type: re.NumberVariable, Description: "alarm threshold", Default: "30"},
+		},
+		SQL:       "SELECT * FROM {stream} WHERE v > {threshold}",
+		Actions:   []re.Action{{Log: &re.LogSink{}}},
+		Options:   &re.RuleOptions{QoS: 1},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	res := fromProtoTemplate(toProtoTemplate(tmpl))
+	assert.Equal(t, tmpl, res, fmt.Sprintf("expected %v got %v\n", tmpl, res))
+}
@@ -0,0 +1,5 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package grpc contains implementation of rules engine service gRPC API.
+package grpc
@@ -0,0 +1,1001 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func infoEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return svc.Info(ctx)
+	}
+}
+
+func createStreamEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createStreamReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.CreateStream(ctx, req.token, req.def, req.update)
+	}
+}
+
+func listStreamsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return re.StreamsPage{}, err
+		}
+
+		return svc.ListStreams(ctx, req.token, req.pm)
+	}
+}
+
+func viewStreamEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.Stream{}, err
+		}
+
+		return svc.ViewStream(ctx, req.token, req.id)
+	}
+}
+
+func viewStreamsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(batchReq)
+		if err := req.validate(); err != nil {
+			return re.StreamsBatch{}, err
+		}
+
+		return svc.ViewStreams(ctx, req.token, req.names)
+	}
+}
+
+func createTableEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createTableReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.CreateTable(ctx, req.token, req.def)
+	}
+}
+
+func listTablesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return re.TablesPage{}, err
+		}
+
+		return svc.ListTables(ctx, req.token, req.pm)
+	}
+}
+
+func viewTableEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.Table{}, err
+		}
+
+		return svc.ViewTable(ctx, req.token, req.id)
+	}
+}
+
+func createRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.CreateRule(ctx, req.token, req.rule)
+	}
+}
+
+func updateRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.UpdateRule(ctx, req.token, req.rule)
+	}
+}
+
+func saveDraftEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.SaveDraft(ctx, req.token, req.rule)
+	}
+}
+
+func patchRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(patchRuleReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.PatchRule(ctx, req.token, req.id, req.patch)
+	}
+}
+
+func cloneRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(cloneRuleReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.CloneRule(ctx, req.token, req.id, req.newID, req.channel)
+	}
+}
+
+func validateRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := validateRuleReq(request.(ruleReq))
+		if err := req.validate(); err != nil {
+			return re.RuleValidation{}, err
+		}
+
+		return svc.ValidateRule(ctx, req.token, req.rule)
+	}
+}
+
+func testRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(testRuleReq)
+		if err := req.validate(); err != nil {
+			return re.TrialResult{}, err
+		}
+
+		return svc.TestRule(ctx, req.token, req.trial)
+	}
+}
+
+func replayRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(replayRuleReq)
+		if err := req.validate(); err != nil {
+			return re.ReplayResult{}, err
+		}
+
+		return svc.ReplayRule(ctx, req.token, req.id, req.from, req.to)
+	}
+}
+
+func pushTailEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(pushTailReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.PushTail(ctx, req.session, req.result)
+	}
+}
+
+func ingestEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ingestReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.Ingest(ctx, req.key, req.stream, req.payload)
+	}
+}
+
+func viewRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.Rule{}, err
+		}
+
+		return svc.ViewRule(ctx, req.token, req.id)
+	}
+}
+
+func viewRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(batchReq)
+		if err := req.validate(); err != nil {
+			return re.RulesBatch{}, err
+		}
+
+		return svc.ViewRules(ctx, req.token, req.names)
+	}
+}
+
+func listRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return re.RulesPage{}, err
+		}
+
+		return svc.ListRules(ctx, req.token, req.pm)
+	}
+}
+
+func searchRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(searchRulesReq)
+		if err := req.validate(); err != nil {
+			return re.RulesPage{}, err
+		}
+
+		return svc.SearchRules(ctx, req.token, req.q, req.pm)
+	}
+}
+
+func ruleStatusEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.RuleStatus{}, err
+		}
+
+		return svc.RuleStatus(ctx, req.token, req.id)
+	}
+}
+
+func ruleTopologyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.RuleTopology{}, err
+		}
+
+		return svc.RuleTopology(ctx, req.token, req.id)
+	}
+}
+
+func ruleLogsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleLogsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return svc.RuleLogs(ctx, req.token, req.id, req.since)
+	}
+}
+
+func reconcileEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(reconcileReq)
+		if err := req.validate(); err != nil {
+			return re.DriftReport{}, err
+		}
+
+		return svc.Reconcile(ctx, req.token, req.repair)
+	}
+}
+
+func collectOrphansEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(collectOrphansReq)
+		if err := req.validate(); err != nil {
+			return re.OrphanReport{}, err
+		}
+
+		return svc.CollectOrphans(ctx, req.token, req.policy)
+	}
+}
+
+func restoreEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(restoreReq)
+		if err := req.validate(); err != nil {
+			return re.RestoreReport{}, err
+		}
+
+		return svc.Restore(ctx, req.token, req.dryRun)
+	}
+}
+
+func exportRulesetEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportRulesetReq)
+		if err := req.validate(); err != nil {
+			return re.Ruleset{}, err
+		}
+
+		return svc.ExportRuleset(ctx, req.token)
+	}
+}
+
+func importRulesetEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(importRulesetReq)
+		if err := req.validate(); err != nil {
+			return re.ImportReport{}, err
+		}
+
+		return svc.ImportRuleset(ctx, req.token, req.rs, req.conflict)
+	}
+}
+
+func bulkCreateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(bulkCreateReq)
+		if err := req.validate(); err != nil {
+			return re.BulkReport{}, err
+		}
+
+		return svc.BulkCreate(ctx, req.token, req.rs)
+	}
+}
+
+func bulkDeleteEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(bulkDeleteReq)
+		if err := req.validate(); err != nil {
+			return re.BulkReport{}, err
+		}
+
+		return svc.BulkDelete(ctx, req.token, req.bd)
+	}
+}
+
+func startRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleSelectionReq)
+		if err := req.validate(); err != nil {
+			return re.BulkReport{}, err
+		}
+
+		return svc.StartRules(ctx, req.token, req.sel)
+	}
+}
+
+func stopRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleSelectionReq)
+		if err := req.validate(); err != nil {
+			return re.BulkReport{}, err
+		}
+
+		return svc.StopRules(ctx, req.token, req.sel)
+	}
+}
+
+func listAllStreamsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return re.AllStreams{}, err
+		}
+
+		return svc.ListAllStreams(ctx, req.token)
+	}
+}
+
+func listAllRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return re.AllRules{}, err
+		}
+
+		return svc.ListAllRules(ctx, req.token)
+	}
+}
+
+func viewQuotaEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.UserQuota{}, err
+		}
+
+		return svc.ViewQuota(ctx, req.token, req.id)
+	}
+}
+
+func setQuotaEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(quotaReq)
+		if err := req.validate(); err != nil {
+			return re.UserQuota{}, err
+		}
+
+		return svc.SetQuota(ctx, req.token, req.userID, req.quota)
+	}
+}
+
+func setAlertPolicyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(alertPolicyReq)
+		if err := req.validate(); err != nil {
+			return re.AlertPolicy{}, err
+		}
+
+		return svc.SetAlertPolicy(ctx, req.token, req.policy)
+	}
+}
+
+func viewAlertPolicyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return re.AlertPolicy{}, err
+		}
+
+		return svc.ViewAlertPolicy(ctx, req.token)
+	}
+}
+
+func removeAlertPolicyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.RemoveAlertPolicy(ctx, req.token)
+	}
+}
+
+func createWebhookEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(webhookReq)
+		if err := req.validate(); err != nil {
+			return re.Webhook{}, err
+		}
+
+		return svc.CreateWebhook(ctx, req.token, req.wh)
+	}
+}
+
+func listWebhooksEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return svc.ListWebhooks(ctx, req.token)
+	}
+}
+
+func removeWebhookEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.RemoveWebhook(ctx, req.token, req.id)
+	}
+}
+
+func createFolderEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(folderReq)
+		if err := req.validate(); err != nil {
+			return re.Folder{}, err
+		}
+
+		return svc.CreateFolder(ctx, req.token, req.folder)
+	}
+}
+
+func listFoldersEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return svc.ListFolders(ctx, req.token)
+	}
+}
+
+func removeFolderEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.RemoveFolder(ctx, req.token, req.id)
+	}
+}
+
+func moveRulesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveRulesReq)
+		if err := req.validate(); err != nil {
+			return re.BulkReport{}, err
+		}
+
+		return svc.MoveRules(ctx, req.token, req.folder, req.ids)
+	}
+}
+
+func pauseEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return re.BulkReport{}, err
+		}
+
+		return svc.Pause(ctx, req.token)
+	}
+}
+
+func resumeEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return re.BulkReport{}, err
+		}
+
+		return svc.Resume(ctx, req.token)
+	}
+}
+
+func viewMaintenanceEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return re.Maintenance{}, err
+		}
+
+		return svc.ViewMaintenance(ctx, req.token)
+	}
+}
+
+func createChainEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(chainReq)
+		if err := req.validate(); err != nil {
+			return re.Chain{}, err
+		}
+
+		return svc.CreateChain(ctx, req.token, req.chain)
+	}
+}
+
+func listChainsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return svc.ListChains(ctx, req.token)
+	}
+}
+
+func removeChainEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.RemoveChain(ctx, req.token, req.id)
+	}
+}
+
+func saveSecretEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(secretReq)
+		if err := req.validate(); err != nil {
+			return re.Secret{}, err
+		}
+
+		return svc.SaveSecret(ctx, req.token, req.secret)
+	}
+}
+
+func listSecretsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return svc.ListSecrets(ctx, req.token)
+	}
+}
+
+func removeSecretEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.RemoveSecret(ctx, req.token, req.id)
+	}
+}
+
+func shareEntityEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+		if err := req.validate(); err != nil {
+			return re.Share{}, err
+		}
+
+		return svc.ShareEntity(ctx, req.token, req.kind, req.name, req.share)
+	}
+}
+
+func listSharesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sharesReq)
+		if err := req.validate(); err != nil {
+			return []re.Share{}, err
+		}
+
+		return svc.ListShares(ctx, req.token, req.kind, req.name)
+	}
+}
+
+func unshareEntityEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(unshareReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.UnshareEntity(ctx, req.token, req.kind, req.name, req.grantee)
+	}
+}
+
+func deleteStreamEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteStreamReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.DeleteStream(ctx, req.token, req.id, req.cascade)
+	}
+}
+
+func renameEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(renameReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.Rename(ctx, req.token, req.kind, req.name, req.newName)
+	}
+}
+
+func listAuditEventsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(auditReq)
+		if err := req.validate(); err != nil {
+			return re.AuditPage{}, err
+		}
+
+		return svc.ListAuditEvents(ctx, req.token, req.q)
+	}
+}
+
+func listInstancesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return svc.ListInstances(ctx, req.token)
+	}
+}
+
+func assignInstanceEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(assignInstanceReq)
+		if err := req.validate(); err != nil {
+			return re.Assignment{}, err
+		}
+
+		return svc.AssignInstance(ctx, req.token, req.userID, req.instance)
+	}
+}
+
+func saveGatewayEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(gatewayReq)
+		if err := req.validate(); err != nil {
+			return re.Gateway{}, err
+		}
+
+		return svc.SaveGateway(ctx, req.token, req.gw)
+	}
+}
+
+func listGatewaysEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return svc.ListGateways(ctx, req.token)
+	}
+}
+
+func removeGatewayEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.RemoveGateway(ctx, req.token, req.id)
+	}
+}
+
+func deployRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deployReq)
+		if err := req.validate(); err != nil {
+			return re.Deployment{}, err
+		}
+
+		return svc.DeployRule(ctx, req.token, req.id, req.gateway)
+	}
+}
+
+func undeployRuleEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deployReq)
+		if err := req.validate(); err != nil {
+			return re.Deployment{}, err
+		}
+
+		return svc.UndeployRule(ctx, req.token, req.id, req.gateway)
+	}
+}
+
+func listDeploymentsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return svc.ListDeployments(ctx, req.token, req.id)
+	}
+}
+
+func deployFleetEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deployFleetReq)
+		if err := req.validate(); err != nil {
+			return re.Rollout{}, err
+		}
+
+		return svc.DeployFleet(ctx, req.token, req.id, req.labels)
+	}
+}
+
+func retryDeploymentsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.Rollout{}, err
+		}
+
+		return svc.RetryDeployments(ctx, req.token, req.id)
+	}
+}
+
+func viewRolloutEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.Rollout{}, err
+		}
+
+		return svc.ViewRollout(ctx, req.token, req.id)
+	}
+}
+
+func engineStatsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listAllReq)
+		if err := req.validate(); err != nil {
+			return re.EngineStats{}, err
+		}
+
+		return svc.EngineStats(ctx, req.token)
+	}
+}
+
+func removeQuotaEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.RemoveQuota(ctx, req.token, req.id)
+	}
+}
+
+func createTemplateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(templateReq)
+		if err := req.validate(); err != nil {
+			return re.Template{}, err
+		}
+
+		return svc.CreateTemplate(ctx, req.token, req.tmpl)
+	}
+}
+
+func viewTemplateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.Template{}, err
+		}
+
+		return svc.ViewTemplate(ctx, req.token, req.id)
+	}
+}
+
+func listTemplatesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listTemplatesReq)
+		if err := req.validate(); err != nil {
+			return []re.Template{}, err
+		}
+
+		return svc.ListTemplates(ctx, req.token)
+	}
+}
+
+func removeTemplateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		return nil, svc.RemoveTemplate(ctx, req.token, req.id)
+	}
+}
+
+func instantiateTemplateEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(instantiateReq)
+		if err := req.validate(); err != nil {
+			return re.Rule{}, err
+		}
+
+		return svc.InstantiateTemplate(ctx, req.token, req.name, req.inst)
+	}
+}
+
+func registerExternalServiceEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(externalServiceReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.RegisterExternalService(ctx, req.token, req.service)
+	}
+}
+
+func listExternalServicesEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listExternalServicesReq)
+		if err := req.validate(); err != nil {
+			return []string{}, err
+		}
+
+		return svc.ListExternalServices(ctx, req.token)
+	}
+}
+
+func listExternalFunctionsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listExternalFunctionsReq)
+		if err := req.validate(); err != nil {
+			return []re.ExternalFunction{}, err
+		}
+
+		return svc.ListExternalFunctions(ctx, req.token)
+	}
+}
+
+func saveConfKeyEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(confKeyReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.SaveConfKey(ctx, req.token, req.name, req.conf)
+	}
+}
+
+func listConfKeysEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listConfKeysReq)
+		if err := req.validate(); err != nil {
+			return []string{}, err
+		}
+
+		return svc.ListConfKeys(ctx, req.token)
+	}
+}
+
+// entityCommandEndpoint creates an endpoint for the service method that
+// takes the entity name or ID and returns the operation result, such as
+// DeleteTable, DeleteRule, StartRule, StopRule, RestartRule,
+// PublishRule, UnpublishRule, RestoreRule, DeleteExternalService and
+// DeleteConfKey.
+func entityCommandEndpoint(command func(ctx context.Context, token, id string) (re.Result, error)) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(entityReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return command(ctx, req.token, req.id)
+	}
+}
+
+func createPluginEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(pluginReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.CreatePlugin(ctx, req.token, req.kind, req.plugin)
+	}
+}
+
+func listPluginsEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listPluginsReq)
+		if err := req.validate(); err != nil {
+			return []string{}, err
+		}
+
+		return svc.ListPlugins(ctx, req.token, req.kind)
+	}
+}
+
+func deletePluginEndpoint(svc re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deletePluginReq)
+		if err := req.validate(); err != nil {
+			return re.Result{}, err
+		}
+
+		return svc.DeletePlugin(ctx, req.token, req.kind, req.name)
+	}
+}
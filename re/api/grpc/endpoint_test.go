@@ -0,0 +1,911 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	grpcapi "github.com/absmach/magistrala/re/api/grpc"
+	remocks "github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	validToken   = "valid"
+	invalidToken = "invalid"
+	userID       = "6f8a2b1c-3d4e-4f50-8a9b-0c1d2e3f4a5b"
+	userPrefix   = "u6f8a2b1c3d4e4f508a9b0c1d2e3f4a5b_"
+)
+
+// kuiper serves the fixed Kuiper info, rules and tables.
+func kuiper(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/":
+		_ = json.NewEncoder(w).Encode(re.Info{Version: "1.10.0", OS: "linux"})
+	case "/rules":
+		_ = json.NewEncoder(w).Encode([]re.RuleInfo{{ID: userPrefix + "rule", Status: "Running"}})
+	case "/rules/" + userPrefix + "rule":
+		_ = json.NewEncoder(w).Encode(re.Rule{
+			ID:      userPrefix + "rule",
+			SQL:     "SELECT * FROM " + userPrefix + "stream",
+			Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: "channel"}}, {REST: &re.RESTSink{URL: "https://example.com", Headers: map[string]string{"X-Key": "key"}}}},
+			Options: &re.RuleOptions{QoS: 1, CheckpointInterval: 60000, IsEventTime: true, LateTolerance: 1000},
+		})
+	case "/rules/" + userPrefix + "rule/topo":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"sources": []string{"source_" + userPrefix + "stream"},
+			"edges":   map[string][]string{"source_" + userPrefix + "stream": {"sink_mqtt_0"}},
+		})
+	case "/rules/" + userPrefix + "rule/status":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "running",
+			"source_" + userPrefix + "stream_0_records_in_total": 10,
+			"sink_mqtt_0_records_out_total":                      8,
+		})
+	case "/streams":
+		_ = json.NewEncoder(w).Encode([]string{userPrefix + "stream"})
+	case "/tables":
+		_ = json.NewEncoder(w).Encode([]string{userPrefix + "devices"})
+	case "/tables/" + userPrefix + "devices":
+		_ = json.NewEncoder(w).Encode(re.Stream{
+			Name:         userPrefix + "devices",
+			StreamFields: []re.StreamField{{Name: "id", FieldType: "string"}},
+			Options:      map[string]string{"datasource": userPrefix + "devices.json", "kind": "scan"},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newClient(t *testing.T) re.Service {
+	return newClientWithKuiper(t, http.HandlerFunc(kuiper), re.Config{})
+}
+
+func newClientWithKuiper(t *testing.T, kuiper http.Handler, cfg re.Config) re.Service {
+	ks := httptest.NewServer(kuiper)
+	t.Cleanup(ks.Close)
+	cfg.URL = ks.URL
+
+	auth := new(authmocks.AuthClient)
+	auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(&magistrala.IdentityRes{}, svcerr.ErrAuthentication)
+	auth.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+		SubjectType: "user",
+		SubjectKind: "users",
+		Subject:     userID,
+		Permission:  "admin",
+		ObjectType:  "platform",
+		Object:      "magistrala",
+	}).Return(&magistrala.AuthorizeRes{Authorized: true}, nil)
+	svc := re.New(cfg, auth, new(sdkmocks.SDK), re.Notifiers{}, nil, remocks.NewRepository())
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.Nil(t, err, fmt.Sprintf("failed to obtain port: %s", err))
+	server := grpc.NewServer()
+	grpcapi.RegisterRulesEngineServiceServer(server, grpcapi.NewServer(svc))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("failed to dial gRPC server: %s", err))
+	t.Cleanup(func() { conn.Close() })
+
+	return grpcapi.NewClient(conn, time.Second)
+}
+
+func TestInfo(t *testing.T) {
+	client := newClient(t)
+
+	info, err := client.Info(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	expected := re.Info{Version: "1.10.0", OS: "linux", Breaker: "closed"}
+	assert.Equal(t, expected, info, fmt.Sprintf("expected %v got %v", expected, info))
+}
+
+func TestRequestID(t *testing.T) {
+	var kuiperID string
+	client := newClientWithKuiper(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kuiperID = r.Header.Get(re.RequestIDHeader)
+		kuiper(w, r)
+	}), re.Config{})
+
+	_, err := client.Info(re.WithRequestID(context.Background(), "0b6e6b4c-request"))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, "0b6e6b4c-request", kuiperID, fmt.Sprintf("expected Kuiper request ID 0b6e6b4c-request got %s", kuiperID))
+
+	_, err = client.Info(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.NotEmpty(t, kuiperID, "expected generated Kuiper request ID")
+	assert.NotEqual(t, "0b6e6b4c-request", kuiperID, "expected new Kuiper request ID")
+}
+
+func TestRevision(t *testing.T) {
+	client := newClient(t)
+
+	rule, err := client.ViewRule(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = client.DeleteRule(re.WithRevision(context.Background(), "0123456789abcdef"), validToken, "rule")
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("expected %s got %s", svcerr.ErrConflict, err))
+	assert.True(t, errors.Contains(err, re.ErrStaleRevision), fmt.Sprintf("expected %s got %s", re.ErrStaleRevision, err))
+
+	// The revision of the rule viewed through the client matches the one
+	// of the service.
+	_, err = client.DeleteRule(re.WithRevision(context.Background(), rule.Revision()), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	client = newClientWithKuiper(t, http.HandlerFunc(kuiper), re.Config{RequireRevision: true})
+	_, err = client.DeleteRule(context.Background(), validToken, "rule")
+	assert.True(t, errors.Contains(err, re.ErrRevisionRequired), fmt.Sprintf("expected %s got %s", re.ErrRevisionRequired, err))
+}
+
+func TestViewRule(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		rule  re.Rule
+		err   error
+	}{
+		{
+			desc:  "view existing rule",
+			token: validToken,
+			id:    "rule",
+			rule: re.Rule{
+				ID:      "rule",
+				SQL:     "SELECT * FROM stream",
+				Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: "channel"}}, {REST: &re.RESTSink{URL: "https://example.com", Headers: map[string]string{"X-Key": re.MaskedValue}}}},
+				Options: &re.RuleOptions{QoS: 1, CheckpointInterval: 60000, IsEventTime: true, LateTolerance: 1000},
+			},
+		},
+		{
+			desc:  "view non-existing rule",
+			token: validToken,
+			id:    "missing",
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "view rule with invalid token",
+			token: invalidToken,
+			id:    "rule",
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "view rule with empty ID",
+			token: validToken,
+			err:   errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		rule, err := client.ViewRule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.rule, rule, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.rule, rule))
+	}
+}
+
+func TestViewRules(t *testing.T) {
+	client := newClient(t)
+
+	batch, err := client.ViewRules(context.Background(), validToken, []string{"rule", "missing"})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, batch.Rules, 1, fmt.Sprintf("expected 1 rule got %d", len(batch.Rules)))
+	assert.Equal(t, "rule", batch.Rules[0].ID, fmt.Sprintf("expected rule got %s", batch.Rules[0].ID))
+	assert.Equal(t, []string{"missing"}, batch.Missing, fmt.Sprintf("expected missing rule got %v", batch.Missing))
+
+	_, err = client.ViewRules(context.Background(), validToken, nil)
+	assert.True(t, errors.Contains(err, errors.ErrMalformedEntity), fmt.Sprintf("expected %s got %s", errors.ErrMalformedEntity, err))
+}
+
+func TestListRules(t *testing.T) {
+	client := newClient(t)
+
+	page, err := client.ListRules(context.Background(), validToken, re.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	expected := re.RulesPage{Total: 1, Limit: 10, Rules: []re.RuleInfo{{ID: "rule", Status: "Running"}}}
+	assert.Equal(t, expected, page, fmt.Sprintf("expected %v got %v", expected, page))
+}
+
+func TestViewTable(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		name  string
+		table re.Table
+		err   error
+	}{
+		{
+			desc:  "view existing table",
+			token: validToken,
+			name:  "devices",
+			table: re.Table{
+				Name:         "devices",
+				StreamFields: []re.StreamField{{Name: "id", FieldType: "string"}},
+				Options:      map[string]string{"datasource": userPrefix + "devices.json", "kind": "scan"},
+			},
+		},
+		{
+			desc:  "view non-existing table",
+			token: validToken,
+			name:  "missing",
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "view table with invalid token",
+			token: invalidToken,
+			name:  "devices",
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		table, err := client.ViewTable(context.Background(), tc.token, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.table, table, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.table, table))
+	}
+
+	page, err := client.ListTables(context.Background(), validToken, re.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list tables: unexpected error: %s", err))
+	expected := re.TablesPage{Total: 1, Limit: 10, Tables: []string{"devices"}}
+	assert.Equal(t, expected, page, fmt.Sprintf("list tables: expected %v got %v", expected, page))
+}
+
+func TestViewTemplate(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		name  string
+		err   error
+	}{
+		{
+			desc:  "view non-existing template",
+			token: validToken,
+			name:  "missing",
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "view template with invalid token",
+			token: invalidToken,
+			name:  "missing",
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "view template with empty name",
+			token: validToken,
+			err:   errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.ViewTemplate(context.Background(), tc.token, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+
+	tmpls, err := client.ListTemplates(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list templates: unexpected error: %s", err))
+	assert.Empty(t, tmpls, fmt.Sprintf("list templates: expected no templates got %v", tmpls))
+}
+
+func TestTailRule(t *testing.T) {
+	sessions := make(chan string, 1)
+	tailed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var rule re.Rule
+			_ = json.NewDecoder(r.Body).Decode(&rule)
+			for _, a := range rule.Actions {
+				if a.REST != nil && strings.HasPrefix(a.REST.URL, "http://re/tail/") {
+					sessions <- strings.TrimPrefix(a.REST.URL, "http://re/tail/")
+				}
+			}
+		}
+		kuiper(w, r)
+	})
+	client := newClientWithKuiper(t, tailed, re.Config{Tail: re.TailConfig{URL: "http://re", Buffer: 10}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := client.TailRule(ctx, validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("tail rule: unexpected error: %s", err))
+	session := <-sessions
+
+	err = client.PushTail(context.Background(), session, map[string]interface{}{"v": 15.0})
+	assert.Nil(t, err, fmt.Sprintf("push tail: unexpected error: %s", err))
+	select {
+	case res := <-results:
+		assert.Equal(t, map[string]interface{}{"v": 15.0}, res, fmt.Sprintf("tail rule: expected result got %v", res))
+	case <-time.After(time.Second):
+		t.Fatal("tail rule: result wasn't received")
+	}
+	cancel()
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "tail rule with invalid token",
+			token: invalidToken,
+			id:    "rule",
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "tail unknown rule",
+			token: validToken,
+			id:    "unknown",
+			err:   svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.TailRule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+
+	err = client.PushTail(context.Background(), "unknown", map[string]interface{}{"v": 15.0})
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("push tail to unknown session: expected %s got %s", svcerr.ErrNotFound, err))
+}
+
+func TestWatchRules(t *testing.T) {
+	client := newClientWithKuiper(t, http.HandlerFunc(kuiper), re.Config{Watch: re.WatchConfig{Interval: time.Hour}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := client.WatchRules(ctx, validToken)
+	assert.Nil(t, err, fmt.Sprintf("watch rules: unexpected error: %s", err))
+	select {
+	case c := <-changes:
+		assert.Equal(t, "rule", c.Rule, fmt.Sprintf("watch rules: expected rule %s got %s", "rule", c.Rule))
+		assert.Empty(t, c.From, fmt.Sprintf("watch rules: expected initial state got %s", c.From))
+		assert.NotEmpty(t, c.To, fmt.Sprintf("watch rules: expected state got %v", c))
+		assert.False(t, c.Time.IsZero(), fmt.Sprintf("watch rules: expected time got %v", c))
+	case <-time.After(time.Second):
+		t.Fatal("watch rules: state change wasn't received")
+	}
+	cancel()
+
+	_, err = client.WatchRules(context.Background(), invalidToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("watch rules with invalid token: expected %s got %s", svcerr.ErrAuthentication, err))
+}
+
+func TestRateLimit(t *testing.T) {
+	client := newClientWithKuiper(t, http.HandlerFunc(kuiper), re.Config{RateLimit: re.RateLimitConfig{Rate: 0.001, Burst: 1}})
+
+	// The rule doesn't exist in Kuiper, but the stop still takes a token.
+	_, err := client.StopRule(context.Background(), validToken, "missing")
+	assert.False(t, errors.Contains(err, re.ErrRateLimited), fmt.Sprintf("stop rule within burst: unexpected error %s", err))
+
+	_, err = client.StopRule(context.Background(), validToken, "missing")
+	assert.True(t, errors.Contains(err, re.ErrRateLimited), fmt.Sprintf("stop rule over burst: expected %s got %s", re.ErrRateLimited, err))
+	after, ok := re.RetryAfter(err)
+	assert.True(t, ok && after > 0, fmt.Sprintf("stop rule over burst: expected positive retry time got %s", after))
+}
+
+func TestShareRule(t *testing.T) {
+	client := newClient(t)
+
+	s := re.Share{Grantee: "00000000-0000-0000-0000-000000000000", GranteeType: re.UserGrantee, Access: re.ManageAccess}
+	res, err := client.ShareEntity(context.Background(), validToken, re.RuleKind, "rule", s)
+	assert.Nil(t, err, fmt.Sprintf("share rule: unexpected error: %s", err))
+	assert.Equal(t, s, res, fmt.Sprintf("share rule: expected %v got %v", s, res))
+
+	shares, err := client.ListShares(context.Background(), validToken, re.RuleKind, "rule")
+	assert.Nil(t, err, fmt.Sprintf("list shares: unexpected error: %s", err))
+	assert.Equal(t, []re.Share{s}, shares, fmt.Sprintf("list shares: expected %v got %v", []re.Share{s}, shares))
+
+	cases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			desc: "unshare rule",
+		},
+		{
+			desc: "unshare unshared rule",
+			err:  svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := client.UnshareEntity(context.Background(), validToken, re.RuleKind, "rule", s.Grantee)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestDeleteStream(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		name  string
+		err   error
+	}{
+		{
+			desc:  "delete non-existing stream with cascade",
+			token: validToken,
+			name:  "unknown",
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "delete stream without name",
+			token: validToken,
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "delete stream with invalid token",
+			token: invalidToken,
+			name:  "unknown",
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.DeleteStream(context.Background(), tc.token, tc.name, true)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestCollectOrphans(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc   string
+		token  string
+		policy re.OrphanPolicy
+		err    error
+	}{
+		{
+			desc:   "view orphans with negative minimum age",
+			token:  validToken,
+			policy: re.OrphanPolicy{MinAge: -time.Hour},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "view orphans with invalid token",
+			token: invalidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.CollectOrphans(context.Background(), tc.token, tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestRename(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc    string
+		token   string
+		newName string
+		err     error
+	}{
+		{
+			desc:    "rename rule without stored definition",
+			token:   validToken,
+			newName: "renamed",
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "rename rule without new name",
+			token: validToken,
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "rename rule with invalid token",
+			token:   invalidToken,
+			newName: "renamed",
+			err:     svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.Rename(context.Background(), tc.token, re.RuleKind, "rule", tc.newName)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestListAuditEvents(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		query re.AuditQuery
+		err   error
+	}{
+		{
+			desc:  "list own audit events",
+			token: validToken,
+			query: re.AuditQuery{Limit: 10, Owner: userID},
+		},
+		{
+			desc:  "list audit events of unknown kind",
+			token: validToken,
+			query: re.AuditQuery{Limit: 10, Owner: userID, Kind: "function"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "list audit events with invalid token",
+			token: invalidToken,
+			query: re.AuditQuery{Limit: 10},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := client.ListAuditEvents(context.Background(), tc.token, tc.query)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Empty(t, page.Events, fmt.Sprintf("%s: expected no events got %v", tc.desc, page.Events))
+		}
+	}
+}
+
+func TestListInstances(t *testing.T) {
+	client := newClient(t)
+
+	instances, err := client.ListInstances(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list instances: unexpected error %s", err))
+	if assert.Len(t, instances, 1, "expected the default instance") {
+		assert.Equal(t, re.DefaultInstance, instances[0].Name, fmt.Sprintf("expected default instance got %s", instances[0].Name))
+		assert.True(t, instances[0].Default, "expected default instance to be marked")
+		assert.Equal(t, []string{}, instances[0].Users, fmt.Sprintf("expected no assigned users got %v", instances[0].Users))
+	}
+
+	_, err = client.ListInstances(context.Background(), invalidToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("list instances with invalid token: expected %s got %s", svcerr.ErrAuthentication, err))
+}
+
+func TestAssignInstance(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc     string
+		token    string
+		userID   string
+		instance string
+		err      error
+	}{
+		{
+			desc:     "assign default instance",
+			token:    validToken,
+			userID:   "user",
+			instance: re.DefaultInstance,
+		},
+		{
+			desc:   "unassign instance",
+			token:  validToken,
+			userID: "user",
+		},
+		{
+			desc:     "assign unknown instance",
+			token:    validToken,
+			userID:   "user",
+			instance: "unknown",
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "assign instance without user ID",
+			token:    validToken,
+			instance: re.DefaultInstance,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "assign instance with invalid token",
+			token:    invalidToken,
+			userID:   "user",
+			instance: re.DefaultInstance,
+			err:      svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		a, err := client.AssignInstance(context.Background(), tc.token, tc.userID, tc.instance)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, re.DefaultInstance, a.Instance, fmt.Sprintf("%s: expected default instance got %s", tc.desc, a.Instance))
+		}
+	}
+}
+
+func TestGateways(t *testing.T) {
+	client := newClient(t)
+
+	gws, err := client.ListGateways(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list gateways: unexpected error %s", err))
+	assert.Empty(t, gws, fmt.Sprintf("expected no gateways got %v", gws))
+
+	cases := []struct {
+		desc  string
+		token string
+		gw    re.Gateway
+		err   error
+	}{
+		{
+			desc:  "save gateway without ID",
+			token: validToken,
+			gw:    re.Gateway{Name: "plant", Channel: "channel"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "save gateway with invalid token",
+			token: invalidToken,
+			gw:    re.Gateway{ID: "gateway", Name: "plant", Channel: "channel"},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.SaveGateway(context.Background(), tc.token, tc.gw)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+
+	// The service without the edge broker doesn't deploy the rules.
+	_, err = client.DeployRule(context.Background(), validToken, "rule", "gateway")
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("deploy rule: expected %s got %s", svcerr.ErrMalformedEntity, err))
+	_, err = client.DeployFleet(context.Background(), validToken, "rule", map[string]string{"site": "north"})
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("deploy fleet: expected %s got %s", svcerr.ErrMalformedEntity, err))
+	_, err = client.ListDeployments(context.Background(), invalidToken, "rule")
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("list deployments with invalid token: expected %s got %s", svcerr.ErrAuthentication, err))
+}
+
+func TestRuleTopology(t *testing.T) {
+	client := newClient(t)
+
+	topo, err := client.RuleTopology(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("rule topology: unexpected error %s", err))
+	expected := re.RuleTopology{
+		Nodes: []re.TopologyNode{{Name: "sink_mqtt_0", Type: re.SinkNode}, {Name: "source_stream", Type: re.SourceNode}},
+		Edges: []re.TopologyEdge{{From: "source_stream", To: "sink_mqtt_0"}},
+	}
+	assert.Equal(t, expected, topo, fmt.Sprintf("expected %v got %v", expected, topo))
+
+	_, err = client.RuleTopology(context.Background(), validToken, "unknown")
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("topology of unknown rule: expected %s got %s", svcerr.ErrNotFound, err))
+}
+
+func TestRuleLogs(t *testing.T) {
+	logs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `time="2024-05-01 10:00:00" level=error msg="rest sink fails to send out the data" file="sink/rest_sink.go:90" rule=`+userPrefix+`rule`)
+	}))
+	defer logs.Close()
+	client := newClientWithKuiper(t, http.HandlerFunc(kuiper), re.Config{Logs: re.LogsConfig{URL: logs.URL, Limit: 10}})
+
+	entries, err := client.RuleLogs(context.Background(), validToken, "rule", time.Time{})
+	assert.Nil(t, err, fmt.Sprintf("rule logs: unexpected error %s", err))
+	expected := []re.LogEntry{{Time: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Level: "error", Message: "rest sink fails to send out the data", File: "sink/rest_sink.go:90"}}
+	assert.Equal(t, expected, entries, fmt.Sprintf("expected %v got %v", expected, entries))
+
+	entries, err = client.RuleLogs(context.Background(), validToken, "rule", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err, fmt.Sprintf("rule logs since time: unexpected error %s", err))
+	assert.Empty(t, entries, fmt.Sprintf("rule logs since time: expected no logs got %v", entries))
+
+	_, err = client.RuleLogs(context.Background(), validToken, "unknown", time.Time{})
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("logs of unknown rule: expected %s got %s", svcerr.ErrNotFound, err))
+}
+
+func TestEngineStats(t *testing.T) {
+	client := newClient(t)
+
+	stats, err := client.EngineStats(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("engine stats: unexpected error %s", err))
+	expected := re.EngineStats{
+		Streams:    1,
+		Tables:     1,
+		Rules:      1,
+		States:     map[string]int{re.StatsRunning: 1, re.StatsStopped: 0, re.StatsError: 0},
+		RecordsIn:  10,
+		RecordsOut: 8,
+	}
+	assert.Equal(t, expected, stats, fmt.Sprintf("expected %v got %v", expected, stats))
+
+	stats, err = client.EngineStats(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("engine stats: unexpected error %s", err))
+	assert.NotNil(t, stats.Interval, "expected interval since the previous stats")
+
+	_, err = client.EngineStats(context.Background(), invalidToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("engine stats with invalid token: expected %s got %s", svcerr.ErrAuthentication, err))
+}
+
+func TestCloneRule(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		newID string
+		err   error
+	}{
+		{
+			desc:  "clone non-existing rule",
+			token: validToken,
+			id:    "missing",
+			newID: "copy",
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "clone rule without new ID",
+			token: validToken,
+			id:    "rule",
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "clone rule with invalid token",
+			token: invalidToken,
+			id:    "rule",
+			newID: "copy",
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.CloneRule(context.Background(), tc.token, tc.id, tc.newID, "")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestPublishRule(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc    string
+		token   string
+		publish bool
+		err     error
+	}{
+		{
+			desc:    "publish rule with invalid token",
+			token:   invalidToken,
+			publish: true,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "unpublish rule with invalid token",
+			token: invalidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		command := client.UnpublishRule
+		if tc.publish {
+			command = client.PublishRule
+		}
+		_, err := command(context.Background(), tc.token, "rule")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestRestoreRule(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "restore rule without ID",
+			token: validToken,
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "restore non-existing rule",
+			token: validToken,
+			id:    "missing",
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "restore rule with invalid token",
+			token: invalidToken,
+			id:    "rule",
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.RestoreRule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestSearchRules(t *testing.T) {
+	client := newClient(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		query re.RuleQuery
+		err   error
+	}{
+		{
+			desc:  "search rules without stream and channel",
+			token: validToken,
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "search rules with invalid token",
+			token: invalidToken,
+			query: re.RuleQuery{Stream: "temperature"},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.SearchRules(context.Background(), tc.token, tc.query, re.PageMetadata{Limit: 10})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestAlertPolicy(t *testing.T) {
+	client := newClient(t)
+
+	policy := re.AlertPolicy{Exceptions: 10, Webhook: "https://example.com/alerts"}
+	p, err := client.SetAlertPolicy(context.Background(), validToken, policy)
+	assert.Nil(t, err, fmt.Sprintf("set alert policy: unexpected error %s", err))
+	assert.Equal(t, policy, p, fmt.Sprintf("set alert policy: expected %v got %v", policy, p))
+	_, err = client.SetAlertPolicy(context.Background(), validToken, re.AlertPolicy{Exceptions: 10})
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("set alert policy without targets: expected %s got %s", svcerr.ErrMalformedEntity, err))
+
+	p, err = client.ViewAlertPolicy(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("view alert policy: unexpected error %s", err))
+	assert.Equal(t, policy, p, fmt.Sprintf("view alert policy: expected %v got %v", policy, p))
+
+	err = client.RemoveAlertPolicy(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("remove alert policy: unexpected error %s", err))
+	_, err = client.ViewAlertPolicy(context.Background(), validToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("view removed alert policy: expected %s got %s", svcerr.ErrNotFound, err))
+}
+
+func TestWebhooks(t *testing.T) {
+	client := newClient(t)
+
+	wh, err := client.CreateWebhook(context.Background(), validToken, re.Webhook{URL: "https://example.com/hooks", Events: []string{re.EventRuleErrored}})
+	assert.Nil(t, err, fmt.Sprintf("create webhook: unexpected error %s", err))
+	assert.NotEmpty(t, wh.Secret, "create webhook: expected generated secret")
+	_, err = client.CreateWebhook(context.Background(), validToken, re.Webhook{URL: "example.com/hooks"})
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("create webhook with invalid URL: expected %s got %s", svcerr.ErrMalformedEntity, err))
+
+	whs, err := client.ListWebhooks(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list webhooks: unexpected error %s", err))
+	wh.Secret = ""
+	assert.Equal(t, []re.Webhook{wh}, whs, fmt.Sprintf("list webhooks: expected %v got %v", []re.Webhook{wh}, whs))
+
+	err = client.RemoveWebhook(context.Background(), validToken, wh.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove webhook: unexpected error %s", err))
+	err = client.RemoveWebhook(context.Background(), validToken, wh.ID)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("remove removed webhook: expected %s got %s", svcerr.ErrNotFound, err))
+}
@@ -0,0 +1,3868 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: re/api/grpc/re.proto
+
+package grpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RulesEngineService_Info_FullMethodName                    = "/re.RulesEngineService/Info"
+	RulesEngineService_CreateStream_FullMethodName            = "/re.RulesEngineService/CreateStream"
+	RulesEngineService_ListStreams_FullMethodName             = "/re.RulesEngineService/ListStreams"
+	RulesEngineService_ViewStream_FullMethodName              = "/re.RulesEngineService/ViewStream"
+	RulesEngineService_ViewStreams_FullMethodName             = "/re.RulesEngineService/ViewStreams"
+	RulesEngineService_DeleteStream_FullMethodName            = "/re.RulesEngineService/DeleteStream"
+	RulesEngineService_CreateTable_FullMethodName             = "/re.RulesEngineService/CreateTable"
+	RulesEngineService_ListTables_FullMethodName              = "/re.RulesEngineService/ListTables"
+	RulesEngineService_ViewTable_FullMethodName               = "/re.RulesEngineService/ViewTable"
+	RulesEngineService_DeleteTable_FullMethodName             = "/re.RulesEngineService/DeleteTable"
+	RulesEngineService_CreateRule_FullMethodName              = "/re.RulesEngineService/CreateRule"
+	RulesEngineService_UpdateRule_FullMethodName              = "/re.RulesEngineService/UpdateRule"
+	RulesEngineService_PatchRule_FullMethodName               = "/re.RulesEngineService/PatchRule"
+	RulesEngineService_CloneRule_FullMethodName               = "/re.RulesEngineService/CloneRule"
+	RulesEngineService_ValidateRule_FullMethodName            = "/re.RulesEngineService/ValidateRule"
+	RulesEngineService_TestRule_FullMethodName                = "/re.RulesEngineService/TestRule"
+	RulesEngineService_ReplayRule_FullMethodName              = "/re.RulesEngineService/ReplayRule"
+	RulesEngineService_TailRule_FullMethodName                = "/re.RulesEngineService/TailRule"
+	RulesEngineService_PushTail_FullMethodName                = "/re.RulesEngineService/PushTail"
+	RulesEngineService_Ingest_FullMethodName                  = "/re.RulesEngineService/Ingest"
+	RulesEngineService_WatchRules_FullMethodName              = "/re.RulesEngineService/WatchRules"
+	RulesEngineService_ViewRule_FullMethodName                = "/re.RulesEngineService/ViewRule"
+	RulesEngineService_ViewRules_FullMethodName               = "/re.RulesEngineService/ViewRules"
+	RulesEngineService_ListRules_FullMethodName               = "/re.RulesEngineService/ListRules"
+	RulesEngineService_SearchRules_FullMethodName             = "/re.RulesEngineService/SearchRules"
+	RulesEngineService_DeleteRule_FullMethodName              = "/re.RulesEngineService/DeleteRule"
+	RulesEngineService_StartRule_FullMethodName               = "/re.RulesEngineService/StartRule"
+	RulesEngineService_StopRule_FullMethodName                = "/re.RulesEngineService/StopRule"
+	RulesEngineService_RestartRule_FullMethodName             = "/re.RulesEngineService/RestartRule"
+	RulesEngineService_SaveDraft_FullMethodName               = "/re.RulesEngineService/SaveDraft"
+	RulesEngineService_PublishRule_FullMethodName             = "/re.RulesEngineService/PublishRule"
+	RulesEngineService_UnpublishRule_FullMethodName           = "/re.RulesEngineService/UnpublishRule"
+	RulesEngineService_RestoreRule_FullMethodName             = "/re.RulesEngineService/RestoreRule"
+	RulesEngineService_RuleStatus_FullMethodName              = "/re.RulesEngineService/RuleStatus"
+	RulesEngineService_RuleTopology_FullMethodName            = "/re.RulesEngineService/RuleTopology"
+	RulesEngineService_RuleLogs_FullMethodName                = "/re.RulesEngineService/RuleLogs"
+	RulesEngineService_Reconcile_FullMethodName               = "/re.RulesEngineService/Reconcile"
+	RulesEngineService_CollectOrphans_FullMethodName          = "/re.RulesEngineService/CollectOrphans"
+	RulesEngineService_Restore_FullMethodName                 = "/re.RulesEngineService/Restore"
+	RulesEngineService_ExportRuleset_FullMethodName           = "/re.RulesEngineService/ExportRuleset"
+	RulesEngineService_ImportRuleset_FullMethodName           = "/re.RulesEngineService/ImportRuleset"
+	RulesEngineService_BulkCreate_FullMethodName              = "/re.RulesEngineService/BulkCreate"
+	RulesEngineService_BulkDelete_FullMethodName              = "/re.RulesEngineService/BulkDelete"
+	RulesEngineService_StartRules_FullMethodName              = "/re.RulesEngineService/StartRules"
+	RulesEngineService_StopRules_FullMethodName               = "/re.RulesEngineService/StopRules"
+	RulesEngineService_ListAllStreams_FullMethodName          = "/re.RulesEngineService/ListAllStreams"
+	RulesEngineService_ListAllRules_FullMethodName            = "/re.RulesEngineService/ListAllRules"
+	RulesEngineService_ViewQuota_FullMethodName               = "/re.RulesEngineService/ViewQuota"
+	RulesEngineService_SetQuota_FullMethodName                = "/re.RulesEngineService/SetQuota"
+	RulesEngineService_RemoveQuota_FullMethodName             = "/re.RulesEngineService/RemoveQuota"
+	RulesEngineService_ShareEntity_FullMethodName             = "/re.RulesEngineService/ShareEntity"
+	RulesEngineService_ListShares_FullMethodName              = "/re.RulesEngineService/ListShares"
+	RulesEngineService_UnshareEntity_FullMethodName           = "/re.RulesEngineService/UnshareEntity"
+	RulesEngineService_Rename_FullMethodName                  = "/re.RulesEngineService/Rename"
+	RulesEngineService_ListAuditEvents_FullMethodName         = "/re.RulesEngineService/ListAuditEvents"
+	RulesEngineService_ListInstances_FullMethodName           = "/re.RulesEngineService/ListInstances"
+	RulesEngineService_AssignInstance_FullMethodName          = "/re.RulesEngineService/AssignInstance"
+	RulesEngineService_SaveGateway_FullMethodName             = "/re.RulesEngineService/SaveGateway"
+	RulesEngineService_ListGateways_FullMethodName            = "/re.RulesEngineService/ListGateways"
+	RulesEngineService_RemoveGateway_FullMethodName           = "/re.RulesEngineService/RemoveGateway"
+	RulesEngineService_DeployRule_FullMethodName              = "/re.RulesEngineService/DeployRule"
+	RulesEngineService_UndeployRule_FullMethodName            = "/re.RulesEngineService/UndeployRule"
+	RulesEngineService_ListDeployments_FullMethodName         = "/re.RulesEngineService/ListDeployments"
+	RulesEngineService_DeployFleet_FullMethodName             = "/re.RulesEngineService/DeployFleet"
+	RulesEngineService_RetryDeployments_FullMethodName        = "/re.RulesEngineService/RetryDeployments"
+	RulesEngineService_ViewRollout_FullMethodName             = "/re.RulesEngineService/ViewRollout"
+	RulesEngineService_EngineStats_FullMethodName             = "/re.RulesEngineService/EngineStats"
+	RulesEngineService_SetAlertPolicy_FullMethodName          = "/re.RulesEngineService/SetAlertPolicy"
+	RulesEngineService_ViewAlertPolicy_FullMethodName         = "/re.RulesEngineService/ViewAlertPolicy"
+	RulesEngineService_RemoveAlertPolicy_FullMethodName       = "/re.RulesEngineService/RemoveAlertPolicy"
+	RulesEngineService_CreateWebhook_FullMethodName           = "/re.RulesEngineService/CreateWebhook"
+	RulesEngineService_ListWebhooks_FullMethodName            = "/re.RulesEngineService/ListWebhooks"
+	RulesEngineService_RemoveWebhook_FullMethodName           = "/re.RulesEngineService/RemoveWebhook"
+	RulesEngineService_CreateFolder_FullMethodName            = "/re.RulesEngineService/CreateFolder"
+	RulesEngineService_ListFolders_FullMethodName             = "/re.RulesEngineService/ListFolders"
+	RulesEngineService_RemoveFolder_FullMethodName            = "/re.RulesEngineService/RemoveFolder"
+	RulesEngineService_MoveRules_FullMethodName               = "/re.RulesEngineService/MoveRules"
+	RulesEngineService_Pause_FullMethodName                   = "/re.RulesEngineService/Pause"
+	RulesEngineService_Resume_FullMethodName                  = "/re.RulesEngineService/Resume"
+	RulesEngineService_ViewMaintenance_FullMethodName         = "/re.RulesEngineService/ViewMaintenance"
+	RulesEngineService_CreateChain_FullMethodName             = "/re.RulesEngineService/CreateChain"
+	RulesEngineService_ListChains_FullMethodName              = "/re.RulesEngineService/ListChains"
+	RulesEngineService_RemoveChain_FullMethodName             = "/re.RulesEngineService/RemoveChain"
+	RulesEngineService_SaveSecret_FullMethodName              = "/re.RulesEngineService/SaveSecret"
+	RulesEngineService_ListSecrets_FullMethodName             = "/re.RulesEngineService/ListSecrets"
+	RulesEngineService_RemoveSecret_FullMethodName            = "/re.RulesEngineService/RemoveSecret"
+	RulesEngineService_CreateTemplate_FullMethodName          = "/re.RulesEngineService/CreateTemplate"
+	RulesEngineService_ViewTemplate_FullMethodName            = "/re.RulesEngineService/ViewTemplate"
+	RulesEngineService_ListTemplates_FullMethodName           = "/re.RulesEngineService/ListTemplates"
+	RulesEngineService_RemoveTemplate_FullMethodName          = "/re.RulesEngineService/RemoveTemplate"
+	RulesEngineService_InstantiateTemplate_FullMethodName     = "/re.RulesEngineService/InstantiateTemplate"
+	RulesEngineService_CreatePlugin_FullMethodName            = "/re.RulesEngineService/CreatePlugin"
+	RulesEngineService_ListPlugins_FullMethodName             = "/re.RulesEngineService/ListPlugins"
+	RulesEngineService_DeletePlugin_FullMethodName            = "/re.RulesEngineService/DeletePlugin"
+	RulesEngineService_RegisterExternalService_FullMethodName = "/re.RulesEngineService/RegisterExternalService"
+	RulesEngineService_ListExternalServices_FullMethodName    = "/re.RulesEngineService/ListExternalServices"
+	RulesEngineService_DeleteExternalService_FullMethodName   = "/re.RulesEngineService/DeleteExternalService"
+	RulesEngineService_ListExternalFunctions_FullMethodName   = "/re.RulesEngineService/ListExternalFunctions"
+	RulesEngineService_SaveConfKey_FullMethodName             = "/re.RulesEngineService/SaveConfKey"
+	RulesEngineService_ListConfKeys_FullMethodName            = "/re.RulesEngineService/ListConfKeys"
+	RulesEngineService_DeleteConfKey_FullMethodName           = "/re.RulesEngineService/DeleteConfKey"
+)
+
+// RulesEngineServiceClient is the client API for RulesEngineService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RulesEngineServiceClient interface {
+	Info(ctx context.Context, in *InfoReq, opts ...grpc.CallOption) (*InfoRes, error)
+	CreateStream(ctx context.Context, in *CreateStreamReq, opts ...grpc.CallOption) (*Result, error)
+	ListStreams(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*StreamsPage, error)
+	ViewStream(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Stream, error)
+	ViewStreams(ctx context.Context, in *BatchReq, opts ...grpc.CallOption) (*StreamsBatch, error)
+	DeleteStream(ctx context.Context, in *DeleteStreamReq, opts ...grpc.CallOption) (*Result, error)
+	CreateTable(ctx context.Context, in *CreateTableReq, opts ...grpc.CallOption) (*Result, error)
+	ListTables(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*TablesPage, error)
+	ViewTable(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Table, error)
+	DeleteTable(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+	CreateRule(ctx context.Context, in *RuleReq, opts ...grpc.CallOption) (*Result, error)
+	UpdateRule(ctx context.Context, in *RuleReq, opts ...grpc.CallOption) (*Result, error)
+	PatchRule(ctx context.Context, in *PatchRuleReq, opts ...grpc.CallOption) (*Result, error)
+	CloneRule(ctx context.Context, in *CloneRuleReq, opts ...grpc.CallOption) (*Result, error)
+	ValidateRule(ctx context.Context, in *RuleReq, opts ...grpc.CallOption) (*RuleValidation, error)
+	TestRule(ctx context.Context, in *TestRuleReq, opts ...grpc.CallOption) (*TrialResult, error)
+	ReplayRule(ctx context.Context, in *ReplayReq, opts ...grpc.CallOption) (*ReplayResult, error)
+	TailRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (RulesEngineService_TailRuleClient, error)
+	PushTail(ctx context.Context, in *PushTailReq, opts ...grpc.CallOption) (*PushTailRes, error)
+	Ingest(ctx context.Context, in *IngestReq, opts ...grpc.CallOption) (*IngestRes, error)
+	WatchRules(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (RulesEngineService_WatchRulesClient, error)
+	ViewRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Rule, error)
+	ViewRules(ctx context.Context, in *BatchReq, opts ...grpc.CallOption) (*RulesBatch, error)
+	ListRules(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*RulesPage, error)
+	SearchRules(ctx context.Context, in *SearchRulesReq, opts ...grpc.CallOption) (*RulesPage, error)
+	DeleteRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+	StartRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+	StopRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+	RestartRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+	SaveDraft(ctx context.Context, in *RuleReq, opts ...grpc.CallOption) (*Result, error)
+	PublishRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+	UnpublishRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+	RestoreRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+	RuleStatus(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RuleStatusRes, error)
+	RuleTopology(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RuleTopologyRes, error)
+	RuleLogs(ctx context.Context, in *RuleLogsReq, opts ...grpc.CallOption) (*RuleLogsRes, error)
+	Reconcile(ctx context.Context, in *ReconcileReq, opts ...grpc.CallOption) (*DriftReport, error)
+	CollectOrphans(ctx context.Context, in *CollectOrphansReq, opts ...grpc.CallOption) (*OrphanReport, error)
+	Restore(ctx context.Context, in *RestoreReq, opts ...grpc.CallOption) (*RestoreReport, error)
+	ExportRuleset(ctx context.Context, in *ExportRulesetReq, opts ...grpc.CallOption) (*Ruleset, error)
+	ImportRuleset(ctx context.Context, in *ImportRulesetReq, opts ...grpc.CallOption) (*ImportReport, error)
+	BulkCreate(ctx context.Context, in *BulkCreateReq, opts ...grpc.CallOption) (*BulkReport, error)
+	BulkDelete(ctx context.Context, in *BulkDeleteReq, opts ...grpc.CallOption) (*BulkReport, error)
+	StartRules(ctx context.Context, in *RuleSelectionReq, opts ...grpc.CallOption) (*BulkReport, error)
+	StopRules(ctx context.Context, in *RuleSelectionReq, opts ...grpc.CallOption) (*BulkReport, error)
+	ListAllStreams(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*AllStreams, error)
+	ListAllRules(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*AllRules, error)
+	ViewQuota(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*UserQuota, error)
+	SetQuota(ctx context.Context, in *QuotaReq, opts ...grpc.CallOption) (*UserQuota, error)
+	RemoveQuota(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveQuotaRes, error)
+	ShareEntity(ctx context.Context, in *ShareReq, opts ...grpc.CallOption) (*Share, error)
+	ListShares(ctx context.Context, in *SharesReq, opts ...grpc.CallOption) (*SharesRes, error)
+	UnshareEntity(ctx context.Context, in *SharesReq, opts ...grpc.CallOption) (*UnshareRes, error)
+	Rename(ctx context.Context, in *RenameReq, opts ...grpc.CallOption) (*Result, error)
+	ListAuditEvents(ctx context.Context, in *AuditReq, opts ...grpc.CallOption) (*AuditPage, error)
+	ListInstances(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*InstancesRes, error)
+	AssignInstance(ctx context.Context, in *AssignInstanceReq, opts ...grpc.CallOption) (*Assignment, error)
+	SaveGateway(ctx context.Context, in *GatewayReq, opts ...grpc.CallOption) (*Gateway, error)
+	ListGateways(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*GatewaysRes, error)
+	RemoveGateway(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveGatewayRes, error)
+	DeployRule(ctx context.Context, in *DeployReq, opts ...grpc.CallOption) (*Deployment, error)
+	UndeployRule(ctx context.Context, in *DeployReq, opts ...grpc.CallOption) (*Deployment, error)
+	ListDeployments(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DeploymentsRes, error)
+	DeployFleet(ctx context.Context, in *DeployFleetReq, opts ...grpc.CallOption) (*Rollout, error)
+	RetryDeployments(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Rollout, error)
+	ViewRollout(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Rollout, error)
+	EngineStats(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*EngineStatsRes, error)
+	SetAlertPolicy(ctx context.Context, in *AlertPolicyReq, opts ...grpc.CallOption) (*AlertPolicy, error)
+	ViewAlertPolicy(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*AlertPolicy, error)
+	RemoveAlertPolicy(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*RemoveAlertPolicyRes, error)
+	CreateWebhook(ctx context.Context, in *WebhookReq, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*WebhooksRes, error)
+	RemoveWebhook(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveWebhookRes, error)
+	CreateFolder(ctx context.Context, in *FolderReq, opts ...grpc.CallOption) (*Folder, error)
+	ListFolders(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*FoldersRes, error)
+	RemoveFolder(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveFolderRes, error)
+	MoveRules(ctx context.Context, in *MoveRulesReq, opts ...grpc.CallOption) (*BulkReport, error)
+	Pause(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*BulkReport, error)
+	Resume(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*BulkReport, error)
+	ViewMaintenance(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*Maintenance, error)
+	CreateChain(ctx context.Context, in *ChainReq, opts ...grpc.CallOption) (*Chain, error)
+	ListChains(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*ChainsRes, error)
+	RemoveChain(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveChainRes, error)
+	SaveSecret(ctx context.Context, in *SecretReq, opts ...grpc.CallOption) (*Secret, error)
+	ListSecrets(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*SecretsRes, error)
+	RemoveSecret(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveSecretRes, error)
+	CreateTemplate(ctx context.Context, in *TemplateReq, opts ...grpc.CallOption) (*Template, error)
+	ViewTemplate(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Template, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesReq, opts ...grpc.CallOption) (*TemplatesRes, error)
+	RemoveTemplate(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveTemplateRes, error)
+	InstantiateTemplate(ctx context.Context, in *InstantiateReq, opts ...grpc.CallOption) (*Rule, error)
+	CreatePlugin(ctx context.Context, in *PluginReq, opts ...grpc.CallOption) (*Result, error)
+	ListPlugins(ctx context.Context, in *ListPluginsReq, opts ...grpc.CallOption) (*PluginsRes, error)
+	DeletePlugin(ctx context.Context, in *DeletePluginReq, opts ...grpc.CallOption) (*Result, error)
+	RegisterExternalService(ctx context.Context, in *ExternalServiceReq, opts ...grpc.CallOption) (*Result, error)
+	ListExternalServices(ctx context.Context, in *ListExternalServicesReq, opts ...grpc.CallOption) (*ExternalServicesRes, error)
+	DeleteExternalService(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+	ListExternalFunctions(ctx context.Context, in *ListExternalFunctionsReq, opts ...grpc.CallOption) (*ExternalFunctionsRes, error)
+	SaveConfKey(ctx context.Context, in *ConfKeyReq, opts ...grpc.CallOption) (*Result, error)
+	ListConfKeys(ctx context.Context, in *ListConfKeysReq, opts ...grpc.CallOption) (*ConfKeysRes, error)
+	DeleteConfKey(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error)
+}
+
+type rulesEngineServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRulesEngineServiceClient(cc grpc.ClientConnInterface) RulesEngineServiceClient {
+	return &rulesEngineServiceClient{cc}
+}
+
+func (c *rulesEngineServiceClient) Info(ctx context.Context, in *InfoReq, opts ...grpc.CallOption) (*InfoRes, error) {
+	out := new(InfoRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_Info_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CreateStream(ctx context.Context, in *CreateStreamReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_CreateStream_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListStreams(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*StreamsPage, error) {
+	out := new(StreamsPage)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListStreams_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ViewStream(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Stream, error) {
+	out := new(Stream)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewStream_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ViewStreams(ctx context.Context, in *BatchReq, opts ...grpc.CallOption) (*StreamsBatch, error) {
+	out := new(StreamsBatch)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewStreams_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) DeleteStream(ctx context.Context, in *DeleteStreamReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_DeleteStream_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CreateTable(ctx context.Context, in *CreateTableReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_CreateTable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListTables(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*TablesPage, error) {
+	out := new(TablesPage)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListTables_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ViewTable(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Table, error) {
+	out := new(Table)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewTable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) DeleteTable(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_DeleteTable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CreateRule(ctx context.Context, in *RuleReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_CreateRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) UpdateRule(ctx context.Context, in *RuleReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_UpdateRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) PatchRule(ctx context.Context, in *PatchRuleReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_PatchRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CloneRule(ctx context.Context, in *CloneRuleReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_CloneRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ValidateRule(ctx context.Context, in *RuleReq, opts ...grpc.CallOption) (*RuleValidation, error) {
+	out := new(RuleValidation)
+	err := c.cc.Invoke(ctx, RulesEngineService_ValidateRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) TestRule(ctx context.Context, in *TestRuleReq, opts ...grpc.CallOption) (*TrialResult, error) {
+	out := new(TrialResult)
+	err := c.cc.Invoke(ctx, RulesEngineService_TestRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ReplayRule(ctx context.Context, in *ReplayReq, opts ...grpc.CallOption) (*ReplayResult, error) {
+	out := new(ReplayResult)
+	err := c.cc.Invoke(ctx, RulesEngineService_ReplayRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) TailRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (RulesEngineService_TailRuleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RulesEngineService_ServiceDesc.Streams[0], RulesEngineService_TailRule_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rulesEngineServiceTailRuleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RulesEngineService_TailRuleClient interface {
+	Recv() (*structpb.Struct, error)
+	grpc.ClientStream
+}
+
+type rulesEngineServiceTailRuleClient struct {
+	grpc.ClientStream
+}
+
+func (x *rulesEngineServiceTailRuleClient) Recv() (*structpb.Struct, error) {
+	m := new(structpb.Struct)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rulesEngineServiceClient) PushTail(ctx context.Context, in *PushTailReq, opts ...grpc.CallOption) (*PushTailRes, error) {
+	out := new(PushTailRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_PushTail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) Ingest(ctx context.Context, in *IngestReq, opts ...grpc.CallOption) (*IngestRes, error) {
+	out := new(IngestRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_Ingest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) WatchRules(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (RulesEngineService_WatchRulesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RulesEngineService_ServiceDesc.Streams[1], RulesEngineService_WatchRules_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rulesEngineServiceWatchRulesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RulesEngineService_WatchRulesClient interface {
+	Recv() (*RuleStateChange, error)
+	grpc.ClientStream
+}
+
+type rulesEngineServiceWatchRulesClient struct {
+	grpc.ClientStream
+}
+
+func (x *rulesEngineServiceWatchRulesClient) Recv() (*RuleStateChange, error) {
+	m := new(RuleStateChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rulesEngineServiceClient) ViewRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Rule, error) {
+	out := new(Rule)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ViewRules(ctx context.Context, in *BatchReq, opts ...grpc.CallOption) (*RulesBatch, error) {
+	out := new(RulesBatch)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListRules(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*RulesPage, error) {
+	out := new(RulesPage)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) SearchRules(ctx context.Context, in *SearchRulesReq, opts ...grpc.CallOption) (*RulesPage, error) {
+	out := new(RulesPage)
+	err := c.cc.Invoke(ctx, RulesEngineService_SearchRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) DeleteRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_DeleteRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) StartRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_StartRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) StopRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_StopRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RestartRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_RestartRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) SaveDraft(ctx context.Context, in *RuleReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_SaveDraft_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) PublishRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_PublishRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) UnpublishRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_UnpublishRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RestoreRule(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_RestoreRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RuleStatus(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RuleStatusRes, error) {
+	out := new(RuleStatusRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RuleStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RuleTopology(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RuleTopologyRes, error) {
+	out := new(RuleTopologyRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RuleTopology_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RuleLogs(ctx context.Context, in *RuleLogsReq, opts ...grpc.CallOption) (*RuleLogsRes, error) {
+	out := new(RuleLogsRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RuleLogs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) Reconcile(ctx context.Context, in *ReconcileReq, opts ...grpc.CallOption) (*DriftReport, error) {
+	out := new(DriftReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_Reconcile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CollectOrphans(ctx context.Context, in *CollectOrphansReq, opts ...grpc.CallOption) (*OrphanReport, error) {
+	out := new(OrphanReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_CollectOrphans_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) Restore(ctx context.Context, in *RestoreReq, opts ...grpc.CallOption) (*RestoreReport, error) {
+	out := new(RestoreReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_Restore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ExportRuleset(ctx context.Context, in *ExportRulesetReq, opts ...grpc.CallOption) (*Ruleset, error) {
+	out := new(Ruleset)
+	err := c.cc.Invoke(ctx, RulesEngineService_ExportRuleset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ImportRuleset(ctx context.Context, in *ImportRulesetReq, opts ...grpc.CallOption) (*ImportReport, error) {
+	out := new(ImportReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_ImportRuleset_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) BulkCreate(ctx context.Context, in *BulkCreateReq, opts ...grpc.CallOption) (*BulkReport, error) {
+	out := new(BulkReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_BulkCreate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) BulkDelete(ctx context.Context, in *BulkDeleteReq, opts ...grpc.CallOption) (*BulkReport, error) {
+	out := new(BulkReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_BulkDelete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) StartRules(ctx context.Context, in *RuleSelectionReq, opts ...grpc.CallOption) (*BulkReport, error) {
+	out := new(BulkReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_StartRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) StopRules(ctx context.Context, in *RuleSelectionReq, opts ...grpc.CallOption) (*BulkReport, error) {
+	out := new(BulkReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_StopRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListAllStreams(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*AllStreams, error) {
+	out := new(AllStreams)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListAllStreams_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListAllRules(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*AllRules, error) {
+	out := new(AllRules)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListAllRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ViewQuota(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*UserQuota, error) {
+	out := new(UserQuota)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewQuota_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) SetQuota(ctx context.Context, in *QuotaReq, opts ...grpc.CallOption) (*UserQuota, error) {
+	out := new(UserQuota)
+	err := c.cc.Invoke(ctx, RulesEngineService_SetQuota_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RemoveQuota(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveQuotaRes, error) {
+	out := new(RemoveQuotaRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RemoveQuota_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ShareEntity(ctx context.Context, in *ShareReq, opts ...grpc.CallOption) (*Share, error) {
+	out := new(Share)
+	err := c.cc.Invoke(ctx, RulesEngineService_ShareEntity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListShares(ctx context.Context, in *SharesReq, opts ...grpc.CallOption) (*SharesRes, error) {
+	out := new(SharesRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListShares_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) UnshareEntity(ctx context.Context, in *SharesReq, opts ...grpc.CallOption) (*UnshareRes, error) {
+	out := new(UnshareRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_UnshareEntity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) Rename(ctx context.Context, in *RenameReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_Rename_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListAuditEvents(ctx context.Context, in *AuditReq, opts ...grpc.CallOption) (*AuditPage, error) {
+	out := new(AuditPage)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListAuditEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListInstances(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*InstancesRes, error) {
+	out := new(InstancesRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListInstances_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) AssignInstance(ctx context.Context, in *AssignInstanceReq, opts ...grpc.CallOption) (*Assignment, error) {
+	out := new(Assignment)
+	err := c.cc.Invoke(ctx, RulesEngineService_AssignInstance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) SaveGateway(ctx context.Context, in *GatewayReq, opts ...grpc.CallOption) (*Gateway, error) {
+	out := new(Gateway)
+	err := c.cc.Invoke(ctx, RulesEngineService_SaveGateway_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListGateways(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*GatewaysRes, error) {
+	out := new(GatewaysRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListGateways_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RemoveGateway(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveGatewayRes, error) {
+	out := new(RemoveGatewayRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RemoveGateway_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) DeployRule(ctx context.Context, in *DeployReq, opts ...grpc.CallOption) (*Deployment, error) {
+	out := new(Deployment)
+	err := c.cc.Invoke(ctx, RulesEngineService_DeployRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) UndeployRule(ctx context.Context, in *DeployReq, opts ...grpc.CallOption) (*Deployment, error) {
+	out := new(Deployment)
+	err := c.cc.Invoke(ctx, RulesEngineService_UndeployRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListDeployments(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DeploymentsRes, error) {
+	out := new(DeploymentsRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListDeployments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) DeployFleet(ctx context.Context, in *DeployFleetReq, opts ...grpc.CallOption) (*Rollout, error) {
+	out := new(Rollout)
+	err := c.cc.Invoke(ctx, RulesEngineService_DeployFleet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RetryDeployments(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Rollout, error) {
+	out := new(Rollout)
+	err := c.cc.Invoke(ctx, RulesEngineService_RetryDeployments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ViewRollout(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Rollout, error) {
+	out := new(Rollout)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewRollout_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) EngineStats(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*EngineStatsRes, error) {
+	out := new(EngineStatsRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_EngineStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) SetAlertPolicy(ctx context.Context, in *AlertPolicyReq, opts ...grpc.CallOption) (*AlertPolicy, error) {
+	out := new(AlertPolicy)
+	err := c.cc.Invoke(ctx, RulesEngineService_SetAlertPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ViewAlertPolicy(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*AlertPolicy, error) {
+	out := new(AlertPolicy)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewAlertPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RemoveAlertPolicy(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*RemoveAlertPolicyRes, error) {
+	out := new(RemoveAlertPolicyRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RemoveAlertPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CreateWebhook(ctx context.Context, in *WebhookReq, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, RulesEngineService_CreateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListWebhooks(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*WebhooksRes, error) {
+	out := new(WebhooksRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListWebhooks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RemoveWebhook(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveWebhookRes, error) {
+	out := new(RemoveWebhookRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RemoveWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CreateFolder(ctx context.Context, in *FolderReq, opts ...grpc.CallOption) (*Folder, error) {
+	out := new(Folder)
+	err := c.cc.Invoke(ctx, RulesEngineService_CreateFolder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListFolders(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*FoldersRes, error) {
+	out := new(FoldersRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListFolders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RemoveFolder(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveFolderRes, error) {
+	out := new(RemoveFolderRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RemoveFolder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) MoveRules(ctx context.Context, in *MoveRulesReq, opts ...grpc.CallOption) (*BulkReport, error) {
+	out := new(BulkReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_MoveRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) Pause(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*BulkReport, error) {
+	out := new(BulkReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_Pause_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) Resume(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*BulkReport, error) {
+	out := new(BulkReport)
+	err := c.cc.Invoke(ctx, RulesEngineService_Resume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ViewMaintenance(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*Maintenance, error) {
+	out := new(Maintenance)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CreateChain(ctx context.Context, in *ChainReq, opts ...grpc.CallOption) (*Chain, error) {
+	out := new(Chain)
+	err := c.cc.Invoke(ctx, RulesEngineService_CreateChain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListChains(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*ChainsRes, error) {
+	out := new(ChainsRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListChains_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RemoveChain(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveChainRes, error) {
+	out := new(RemoveChainRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RemoveChain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) SaveSecret(ctx context.Context, in *SecretReq, opts ...grpc.CallOption) (*Secret, error) {
+	out := new(Secret)
+	err := c.cc.Invoke(ctx, RulesEngineService_SaveSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListSecrets(ctx context.Context, in *ListAllReq, opts ...grpc.CallOption) (*SecretsRes, error) {
+	out := new(SecretsRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListSecrets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RemoveSecret(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveSecretRes, error) {
+	out := new(RemoveSecretRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RemoveSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CreateTemplate(ctx context.Context, in *TemplateReq, opts ...grpc.CallOption) (*Template, error) {
+	out := new(Template)
+	err := c.cc.Invoke(ctx, RulesEngineService_CreateTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ViewTemplate(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Template, error) {
+	out := new(Template)
+	err := c.cc.Invoke(ctx, RulesEngineService_ViewTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListTemplates(ctx context.Context, in *ListTemplatesReq, opts ...grpc.CallOption) (*TemplatesRes, error) {
+	out := new(TemplatesRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListTemplates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RemoveTemplate(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*RemoveTemplateRes, error) {
+	out := new(RemoveTemplateRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_RemoveTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) InstantiateTemplate(ctx context.Context, in *InstantiateReq, opts ...grpc.CallOption) (*Rule, error) {
+	out := new(Rule)
+	err := c.cc.Invoke(ctx, RulesEngineService_InstantiateTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) CreatePlugin(ctx context.Context, in *PluginReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_CreatePlugin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListPlugins(ctx context.Context, in *ListPluginsReq, opts ...grpc.CallOption) (*PluginsRes, error) {
+	out := new(PluginsRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListPlugins_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) DeletePlugin(ctx context.Context, in *DeletePluginReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_DeletePlugin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) RegisterExternalService(ctx context.Context, in *ExternalServiceReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_RegisterExternalService_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListExternalServices(ctx context.Context, in *ListExternalServicesReq, opts ...grpc.CallOption) (*ExternalServicesRes, error) {
+	out := new(ExternalServicesRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListExternalServices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) DeleteExternalService(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_DeleteExternalService_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListExternalFunctions(ctx context.Context, in *ListExternalFunctionsReq, opts ...grpc.CallOption) (*ExternalFunctionsRes, error) {
+	out := new(ExternalFunctionsRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListExternalFunctions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) SaveConfKey(ctx context.Context, in *ConfKeyReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_SaveConfKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) ListConfKeys(ctx context.Context, in *ListConfKeysReq, opts ...grpc.CallOption) (*ConfKeysRes, error) {
+	out := new(ConfKeysRes)
+	err := c.cc.Invoke(ctx, RulesEngineService_ListConfKeys_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rulesEngineServiceClient) DeleteConfKey(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, RulesEngineService_DeleteConfKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RulesEngineServiceServer is the server API for RulesEngineService service.
+// All implementations must embed UnimplementedRulesEngineServiceServer
+// for forward compatibility
+type RulesEngineServiceServer interface {
+	Info(context.Context, *InfoReq) (*InfoRes, error)
+	CreateStream(context.Context, *CreateStreamReq) (*Result, error)
+	ListStreams(context.Context, *ListReq) (*StreamsPage, error)
+	ViewStream(context.Context, *EntityReq) (*Stream, error)
+	ViewStreams(context.Context, *BatchReq) (*StreamsBatch, error)
+	DeleteStream(context.Context, *DeleteStreamReq) (*Result, error)
+	CreateTable(context.Context, *CreateTableReq) (*Result, error)
+	ListTables(context.Context, *ListReq) (*TablesPage, error)
+	ViewTable(context.Context, *EntityReq) (*Table, error)
+	DeleteTable(context.Context, *EntityReq) (*Result, error)
+	CreateRule(context.Context, *RuleReq) (*Result, error)
+	UpdateRule(context.Context, *RuleReq) (*Result, error)
+	PatchRule(context.Context, *PatchRuleReq) (*Result, error)
+	CloneRule(context.Context, *CloneRuleReq) (*Result, error)
+	ValidateRule(context.Context, *RuleReq) (*RuleValidation, error)
+	TestRule(context.Context, *TestRuleReq) (*TrialResult, error)
+	ReplayRule(context.Context, *ReplayReq) (*ReplayResult, error)
+	TailRule(*EntityReq, RulesEngineService_TailRuleServer) error
+	PushTail(context.Context, *PushTailReq) (*PushTailRes, error)
+	Ingest(context.Context, *IngestReq) (*IngestRes, error)
+	WatchRules(*ListAllReq, RulesEngineService_WatchRulesServer) error
+	ViewRule(context.Context, *EntityReq) (*Rule, error)
+	ViewRules(context.Context, *BatchReq) (*RulesBatch, error)
+	ListRules(context.Context, *ListReq) (*RulesPage, error)
+	SearchRules(context.Context, *SearchRulesReq) (*RulesPage, error)
+	DeleteRule(context.Context, *EntityReq) (*Result, error)
+	StartRule(context.Context, *EntityReq) (*Result, error)
+	StopRule(context.Context, *EntityReq) (*Result, error)
+	RestartRule(context.Context, *EntityReq) (*Result, error)
+	SaveDraft(context.Context, *RuleReq) (*Result, error)
+	PublishRule(context.Context, *EntityReq) (*Result, error)
+	UnpublishRule(context.Context, *EntityReq) (*Result, error)
+	RestoreRule(context.Context, *EntityReq) (*Result, error)
+	RuleStatus(context.Context, *EntityReq) (*RuleStatusRes, error)
+	RuleTopology(context.Context, *EntityReq) (*RuleTopologyRes, error)
+	RuleLogs(context.Context, *RuleLogsReq) (*RuleLogsRes, error)
+	Reconcile(context.Context, *ReconcileReq) (*DriftReport, error)
+	CollectOrphans(context.Context, *CollectOrphansReq) (*OrphanReport, error)
+	Restore(context.Context, *RestoreReq) (*RestoreReport, error)
+	ExportRuleset(context.Context, *ExportRulesetReq) (*Ruleset, error)
+	ImportRuleset(context.Context, *ImportRulesetReq) (*ImportReport, error)
+	BulkCreate(context.Context, *BulkCreateReq) (*BulkReport, error)
+	BulkDelete(context.Context, *BulkDeleteReq) (*BulkReport, error)
+	StartRules(context.Context, *RuleSelectionReq) (*BulkReport, error)
+	StopRules(context.Context, *RuleSelectionReq) (*BulkReport, error)
+	ListAllStreams(context.Context, *ListAllReq) (*AllStreams, error)
+	ListAllRules(context.Context, *ListAllReq) (*AllRules, error)
+	ViewQuota(context.Context, *EntityReq) (*UserQuota, error)
+	SetQuota(context.Context, *QuotaReq) (*UserQuota, error)
+	RemoveQuota(context.Context, *EntityReq) (*RemoveQuotaRes, error)
+	ShareEntity(context.Context, *ShareReq) (*Share, error)
+	ListShares(context.Context, *SharesReq) (*SharesRes, error)
+	UnshareEntity(context.Context, *SharesReq) (*UnshareRes, error)
+	Rename(context.Context, *RenameReq) (*Result, error)
+	ListAuditEvents(context.Context, *AuditReq) (*AuditPage, error)
+	ListInstances(context.Context, *ListAllReq) (*InstancesRes, error)
+	AssignInstance(context.Context, *AssignInstanceReq) (*Assignment, error)
+	SaveGateway(context.Context, *GatewayReq) (*Gateway, error)
+	ListGateways(context.Context, *ListAllReq) (*GatewaysRes, error)
+	RemoveGateway(context.Context, *EntityReq) (*RemoveGatewayRes, error)
+	DeployRule(context.Context, *DeployReq) (*Deployment, error)
+	UndeployRule(context.Context, *DeployReq) (*Deployment, error)
+	ListDeployments(context.Context, *EntityReq) (*DeploymentsRes, error)
+	DeployFleet(context.Context, *DeployFleetReq) (*Rollout, error)
+	RetryDeployments(context.Context, *EntityReq) (*Rollout, error)
+	ViewRollout(context.Context, *EntityReq) (*Rollout, error)
+	EngineStats(context.Context, *ListAllReq) (*EngineStatsRes, error)
+	SetAlertPolicy(context.Context, *AlertPolicyReq) (*AlertPolicy, error)
+	ViewAlertPolicy(context.Context, *ListAllReq) (*AlertPolicy, error)
+	RemoveAlertPolicy(context.Context, *ListAllReq) (*RemoveAlertPolicyRes, error)
+	CreateWebhook(context.Context, *WebhookReq) (*Webhook, error)
+	ListWebhooks(context.Context, *ListAllReq) (*WebhooksRes, error)
+	RemoveWebhook(context.Context, *EntityReq) (*RemoveWebhookRes, error)
+	CreateFolder(context.Context, *FolderReq) (*Folder, error)
+	ListFolders(context.Context, *ListAllReq) (*FoldersRes, error)
+	RemoveFolder(context.Context, *EntityReq) (*RemoveFolderRes, error)
+	MoveRules(context.Context, *MoveRulesReq) (*BulkReport, error)
+	Pause(context.Context, *ListAllReq) (*BulkReport, error)
+	Resume(context.Context, *ListAllReq) (*BulkReport, error)
+	ViewMaintenance(context.Context, *ListAllReq) (*Maintenance, error)
+	CreateChain(context.Context, *ChainReq) (*Chain, error)
+	ListChains(context.Context, *ListAllReq) (*ChainsRes, error)
+	RemoveChain(context.Context, *EntityReq) (*RemoveChainRes, error)
+	SaveSecret(context.Context, *SecretReq) (*Secret, error)
+	ListSecrets(context.Context, *ListAllReq) (*SecretsRes, error)
+	RemoveSecret(context.Context, *EntityReq) (*RemoveSecretRes, error)
+	CreateTemplate(context.Context, *TemplateReq) (*Template, error)
+	ViewTemplate(context.Context, *EntityReq) (*Template, error)
+	ListTemplates(context.Context, *ListTemplatesReq) (*TemplatesRes, error)
+	RemoveTemplate(context.Context, *EntityReq) (*RemoveTemplateRes, error)
+	InstantiateTemplate(context.Context, *InstantiateReq) (*Rule, error)
+	CreatePlugin(context.Context, *PluginReq) (*Result, error)
+	ListPlugins(context.Context, *ListPluginsReq) (*PluginsRes, error)
+	DeletePlugin(context.Context, *DeletePluginReq) (*Result, error)
+	RegisterExternalService(context.Context, *ExternalServiceReq) (*Result, error)
+	ListExternalServices(context.Context, *ListExternalServicesReq) (*ExternalServicesRes, error)
+	DeleteExternalService(context.Context, *EntityReq) (*Result, error)
+	ListExternalFunctions(context.Context, *ListExternalFunctionsReq) (*ExternalFunctionsRes, error)
+	SaveConfKey(context.Context, *ConfKeyReq) (*Result, error)
+	ListConfKeys(context.Context, *ListConfKeysReq) (*ConfKeysRes, error)
+	DeleteConfKey(context.Context, *EntityReq) (*Result, error)
+	mustEmbedUnimplementedRulesEngineServiceServer()
+}
+
+// UnimplementedRulesEngineServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRulesEngineServiceServer struct {
+}
+
+func (UnimplementedRulesEngineServiceServer) Info(context.Context, *InfoReq) (*InfoRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CreateStream(context.Context, *CreateStreamReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStream not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListStreams(context.Context, *ListReq) (*StreamsPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStreams not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewStream(context.Context, *EntityReq) (*Stream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewStream not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewStreams(context.Context, *BatchReq) (*StreamsBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewStreams not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) DeleteStream(context.Context, *DeleteStreamReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStream not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CreateTable(context.Context, *CreateTableReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTable not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListTables(context.Context, *ListReq) (*TablesPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTables not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewTable(context.Context, *EntityReq) (*Table, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewTable not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) DeleteTable(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTable not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CreateRule(context.Context, *RuleReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) UpdateRule(context.Context, *RuleReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) PatchRule(context.Context, *PatchRuleReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CloneRule(context.Context, *CloneRuleReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ValidateRule(context.Context, *RuleReq) (*RuleValidation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) TestRule(context.Context, *TestRuleReq) (*TrialResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ReplayRule(context.Context, *ReplayReq) (*ReplayResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) TailRule(*EntityReq, RulesEngineService_TailRuleServer) error {
+	return status.Errorf(codes.Unimplemented, "method TailRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) PushTail(context.Context, *PushTailReq) (*PushTailRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushTail not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) Ingest(context.Context, *IngestReq) (*IngestRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ingest not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) WatchRules(*ListAllReq, RulesEngineService_WatchRulesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRules not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewRule(context.Context, *EntityReq) (*Rule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewRules(context.Context, *BatchReq) (*RulesBatch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewRules not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListRules(context.Context, *ListReq) (*RulesPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRules not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) SearchRules(context.Context, *SearchRulesReq) (*RulesPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchRules not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) DeleteRule(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) StartRule(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) StopRule(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RestartRule(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) SaveDraft(context.Context, *RuleReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveDraft not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) PublishRule(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) UnpublishRule(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpublishRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RestoreRule(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RuleStatus(context.Context, *EntityReq) (*RuleStatusRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RuleStatus not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RuleTopology(context.Context, *EntityReq) (*RuleTopologyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RuleTopology not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RuleLogs(context.Context, *RuleLogsReq) (*RuleLogsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RuleLogs not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) Reconcile(context.Context, *ReconcileReq) (*DriftReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CollectOrphans(context.Context, *CollectOrphansReq) (*OrphanReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectOrphans not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) Restore(context.Context, *RestoreReq) (*RestoreReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ExportRuleset(context.Context, *ExportRulesetReq) (*Ruleset, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportRuleset not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ImportRuleset(context.Context, *ImportRulesetReq) (*ImportReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportRuleset not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) BulkCreate(context.Context, *BulkCreateReq) (*BulkReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCreate not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) BulkDelete(context.Context, *BulkDeleteReq) (*BulkReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDelete not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) StartRules(context.Context, *RuleSelectionReq) (*BulkReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartRules not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) StopRules(context.Context, *RuleSelectionReq) (*BulkReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopRules not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListAllStreams(context.Context, *ListAllReq) (*AllStreams, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllStreams not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListAllRules(context.Context, *ListAllReq) (*AllRules, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllRules not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewQuota(context.Context, *EntityReq) (*UserQuota, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewQuota not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) SetQuota(context.Context, *QuotaReq) (*UserQuota, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetQuota not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RemoveQuota(context.Context, *EntityReq) (*RemoveQuotaRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveQuota not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ShareEntity(context.Context, *ShareReq) (*Share, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareEntity not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListShares(context.Context, *SharesReq) (*SharesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShares not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) UnshareEntity(context.Context, *SharesReq) (*UnshareRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareEntity not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) Rename(context.Context, *RenameReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rename not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListAuditEvents(context.Context, *AuditReq) (*AuditPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListInstances(context.Context, *ListAllReq) (*InstancesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInstances not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) AssignInstance(context.Context, *AssignInstanceReq) (*Assignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignInstance not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) SaveGateway(context.Context, *GatewayReq) (*Gateway, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveGateway not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListGateways(context.Context, *ListAllReq) (*GatewaysRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGateways not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RemoveGateway(context.Context, *EntityReq) (*RemoveGatewayRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGateway not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) DeployRule(context.Context, *DeployReq) (*Deployment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) UndeployRule(context.Context, *DeployReq) (*Deployment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeployRule not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListDeployments(context.Context, *EntityReq) (*DeploymentsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeployments not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) DeployFleet(context.Context, *DeployFleetReq) (*Rollout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployFleet not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RetryDeployments(context.Context, *EntityReq) (*Rollout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryDeployments not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewRollout(context.Context, *EntityReq) (*Rollout, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewRollout not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) EngineStats(context.Context, *ListAllReq) (*EngineStatsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EngineStats not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) SetAlertPolicy(context.Context, *AlertPolicyReq) (*AlertPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAlertPolicy not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewAlertPolicy(context.Context, *ListAllReq) (*AlertPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewAlertPolicy not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RemoveAlertPolicy(context.Context, *ListAllReq) (*RemoveAlertPolicyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAlertPolicy not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CreateWebhook(context.Context, *WebhookReq) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListWebhooks(context.Context, *ListAllReq) (*WebhooksRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RemoveWebhook(context.Context, *EntityReq) (*RemoveWebhookRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWebhook not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CreateFolder(context.Context, *FolderReq) (*Folder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFolder not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListFolders(context.Context, *ListAllReq) (*FoldersRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFolders not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RemoveFolder(context.Context, *EntityReq) (*RemoveFolderRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFolder not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) MoveRules(context.Context, *MoveRulesReq) (*BulkReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveRules not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) Pause(context.Context, *ListAllReq) (*BulkReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) Resume(context.Context, *ListAllReq) (*BulkReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewMaintenance(context.Context, *ListAllReq) (*Maintenance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewMaintenance not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CreateChain(context.Context, *ChainReq) (*Chain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChain not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListChains(context.Context, *ListAllReq) (*ChainsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChains not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RemoveChain(context.Context, *EntityReq) (*RemoveChainRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChain not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) SaveSecret(context.Context, *SecretReq) (*Secret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveSecret not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListSecrets(context.Context, *ListAllReq) (*SecretsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecrets not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RemoveSecret(context.Context, *EntityReq) (*RemoveSecretRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSecret not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CreateTemplate(context.Context, *TemplateReq) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTemplate not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ViewTemplate(context.Context, *EntityReq) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewTemplate not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListTemplates(context.Context, *ListTemplatesReq) (*TemplatesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RemoveTemplate(context.Context, *EntityReq) (*RemoveTemplateRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTemplate not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) InstantiateTemplate(context.Context, *InstantiateReq) (*Rule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstantiateTemplate not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) CreatePlugin(context.Context, *PluginReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePlugin not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListPlugins(context.Context, *ListPluginsReq) (*PluginsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlugins not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) DeletePlugin(context.Context, *DeletePluginReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePlugin not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) RegisterExternalService(context.Context, *ExternalServiceReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterExternalService not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListExternalServices(context.Context, *ListExternalServicesReq) (*ExternalServicesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExternalServices not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) DeleteExternalService(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteExternalService not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListExternalFunctions(context.Context, *ListExternalFunctionsReq) (*ExternalFunctionsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExternalFunctions not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) SaveConfKey(context.Context, *ConfKeyReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveConfKey not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) ListConfKeys(context.Context, *ListConfKeysReq) (*ConfKeysRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConfKeys not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) DeleteConfKey(context.Context, *EntityReq) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConfKey not implemented")
+}
+func (UnimplementedRulesEngineServiceServer) mustEmbedUnimplementedRulesEngineServiceServer() {}
+
+// UnsafeRulesEngineServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RulesEngineServiceServer will
+// result in compilation errors.
+type UnsafeRulesEngineServiceServer interface {
+	mustEmbedUnimplementedRulesEngineServiceServer()
+}
+
+func RegisterRulesEngineServiceServer(s grpc.ServiceRegistrar, srv RulesEngineServiceServer) {
+	s.RegisterService(&RulesEngineService_ServiceDesc, srv)
+}
+
+func _RulesEngineService_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_Info_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).Info(ctx, req.(*InfoReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CreateStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStreamReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CreateStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CreateStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CreateStream(ctx, req.(*CreateStreamReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListStreams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListStreams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListStreams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListStreams(ctx, req.(*ListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ViewStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewStream(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ViewStreams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewStreams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewStreams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewStreams(ctx, req.(*BatchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_DeleteStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStreamReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).DeleteStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_DeleteStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).DeleteStream(ctx, req.(*DeleteStreamReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CreateTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTableReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CreateTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CreateTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CreateTable(ctx, req.(*CreateTableReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListTables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListTables(ctx, req.(*ListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ViewTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewTable(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_DeleteTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).DeleteTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_DeleteTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).DeleteTable(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CreateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CreateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CreateRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CreateRule(ctx, req.(*RuleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_UpdateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).UpdateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_UpdateRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).UpdateRule(ctx, req.(*RuleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_PatchRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchRuleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).PatchRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_PatchRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).PatchRule(ctx, req.(*PatchRuleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CloneRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneRuleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CloneRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CloneRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CloneRule(ctx, req.(*CloneRuleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ValidateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ValidateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ValidateRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ValidateRule(ctx, req.(*RuleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_TestRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestRuleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).TestRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_TestRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).TestRule(ctx, req.(*TestRuleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ReplayRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ReplayRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ReplayRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ReplayRule(ctx, req.(*ReplayReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_TailRule_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EntityReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RulesEngineServiceServer).TailRule(m, &rulesEngineServiceTailRuleServer{stream})
+}
+
+type RulesEngineService_TailRuleServer interface {
+	Send(*structpb.Struct) error
+	grpc.ServerStream
+}
+
+type rulesEngineServiceTailRuleServer struct {
+	grpc.ServerStream
+}
+
+func (x *rulesEngineServiceTailRuleServer) Send(m *structpb.Struct) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RulesEngineService_PushTail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushTailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).PushTail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_PushTail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).PushTail(ctx, req.(*PushTailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_Ingest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).Ingest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_Ingest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).Ingest(ctx, req.(*IngestReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_WatchRules_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAllReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RulesEngineServiceServer).WatchRules(m, &rulesEngineServiceWatchRulesServer{stream})
+}
+
+type RulesEngineService_WatchRulesServer interface {
+	Send(*RuleStateChange) error
+	grpc.ServerStream
+}
+
+type rulesEngineServiceWatchRulesServer struct {
+	grpc.ServerStream
+}
+
+func (x *rulesEngineServiceWatchRulesServer) Send(m *RuleStateChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RulesEngineService_ViewRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewRule(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ViewRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewRules(ctx, req.(*BatchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListRules(ctx, req.(*ListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_SearchRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRulesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).SearchRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_SearchRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).SearchRules(ctx, req.(*SearchRulesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).DeleteRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_DeleteRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).DeleteRule(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_StartRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).StartRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_StartRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).StartRule(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_StopRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).StopRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_StopRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).StopRule(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RestartRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RestartRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RestartRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RestartRule(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).SaveDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_SaveDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).SaveDraft(ctx, req.(*RuleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_PublishRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).PublishRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_PublishRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).PublishRule(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_UnpublishRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).UnpublishRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_UnpublishRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).UnpublishRule(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RestoreRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RestoreRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RestoreRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RestoreRule(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RuleStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RuleStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RuleStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RuleStatus(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RuleTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RuleTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RuleTopology_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RuleTopology(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RuleLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleLogsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RuleLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RuleLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RuleLogs(ctx, req.(*RuleLogsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_Reconcile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).Reconcile(ctx, req.(*ReconcileReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CollectOrphans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectOrphansReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CollectOrphans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CollectOrphans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CollectOrphans(ctx, req.(*CollectOrphansReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).Restore(ctx, req.(*RestoreReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ExportRuleset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRulesetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ExportRuleset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ExportRuleset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ExportRuleset(ctx, req.(*ExportRulesetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ImportRuleset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRulesetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ImportRuleset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ImportRuleset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ImportRuleset(ctx, req.(*ImportRulesetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_BulkCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCreateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).BulkCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_BulkCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).BulkCreate(ctx, req.(*BulkCreateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_BulkDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).BulkDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_BulkDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).BulkDelete(ctx, req.(*BulkDeleteReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_StartRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleSelectionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).StartRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_StartRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).StartRules(ctx, req.(*RuleSelectionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_StopRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RuleSelectionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).StopRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_StopRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).StopRules(ctx, req.(*RuleSelectionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListAllStreams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListAllStreams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListAllStreams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListAllStreams(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListAllRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListAllRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListAllRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListAllRules(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ViewQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewQuota(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_SetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuotaReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).SetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_SetQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).SetQuota(ctx, req.(*QuotaReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RemoveQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RemoveQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RemoveQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RemoveQuota(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ShareEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ShareEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ShareEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ShareEntity(ctx, req.(*ShareReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListShares(ctx, req.(*SharesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_UnshareEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).UnshareEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_UnshareEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).UnshareEntity(ctx, req.(*SharesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_Rename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).Rename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_Rename_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).Rename(ctx, req.(*RenameReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListAuditEvents(ctx, req.(*AuditReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListInstances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListInstances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListInstances(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_AssignInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignInstanceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).AssignInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_AssignInstance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).AssignInstance(ctx, req.(*AssignInstanceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_SaveGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GatewayReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).SaveGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_SaveGateway_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).SaveGateway(ctx, req.(*GatewayReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListGateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListGateways(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListGateways_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListGateways(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RemoveGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RemoveGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RemoveGateway_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RemoveGateway(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_DeployRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).DeployRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_DeployRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).DeployRule(ctx, req.(*DeployReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_UndeployRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).UndeployRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_UndeployRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).UndeployRule(ctx, req.(*DeployReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListDeployments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListDeployments(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_DeployFleet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployFleetReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).DeployFleet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_DeployFleet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).DeployFleet(ctx, req.(*DeployFleetReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RetryDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RetryDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RetryDeployments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RetryDeployments(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ViewRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewRollout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewRollout(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_EngineStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).EngineStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_EngineStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).EngineStats(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_SetAlertPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlertPolicyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).SetAlertPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_SetAlertPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).SetAlertPolicy(ctx, req.(*AlertPolicyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ViewAlertPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewAlertPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewAlertPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewAlertPolicy(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RemoveAlertPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RemoveAlertPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RemoveAlertPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RemoveAlertPolicy(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CreateWebhook(ctx, req.(*WebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListWebhooks(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RemoveWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RemoveWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RemoveWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RemoveWebhook(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FolderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CreateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CreateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CreateFolder(ctx, req.(*FolderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListFolders(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RemoveFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RemoveFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RemoveFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RemoveFolder(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_MoveRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRulesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).MoveRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_MoveRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).MoveRules(ctx, req.(*MoveRulesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_Pause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).Pause(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_Resume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).Resume(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ViewMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewMaintenance(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CreateChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CreateChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CreateChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CreateChain(ctx, req.(*ChainReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListChains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListChains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListChains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListChains(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RemoveChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RemoveChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RemoveChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RemoveChain(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_SaveSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).SaveSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_SaveSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).SaveSecret(ctx, req.(*SecretReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListSecrets(ctx, req.(*ListAllReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RemoveSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RemoveSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RemoveSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RemoveSecret(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CreateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CreateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CreateTemplate(ctx, req.(*TemplateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ViewTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ViewTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ViewTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ViewTemplate(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListTemplates(ctx, req.(*ListTemplatesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RemoveTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RemoveTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RemoveTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RemoveTemplate(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_InstantiateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstantiateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).InstantiateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_InstantiateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).InstantiateTemplate(ctx, req.(*InstantiateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_CreatePlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PluginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).CreatePlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_CreatePlugin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).CreatePlugin(ctx, req.(*PluginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListPlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPluginsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListPlugins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListPlugins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListPlugins(ctx, req.(*ListPluginsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_DeletePlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePluginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).DeletePlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_DeletePlugin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).DeletePlugin(ctx, req.(*DeletePluginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_RegisterExternalService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalServiceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).RegisterExternalService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_RegisterExternalService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).RegisterExternalService(ctx, req.(*ExternalServiceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListExternalServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExternalServicesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListExternalServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListExternalServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListExternalServices(ctx, req.(*ListExternalServicesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_DeleteExternalService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).DeleteExternalService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_DeleteExternalService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).DeleteExternalService(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListExternalFunctions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExternalFunctionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListExternalFunctions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListExternalFunctions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListExternalFunctions(ctx, req.(*ListExternalFunctionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_SaveConfKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfKeyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).SaveConfKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_SaveConfKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).SaveConfKey(ctx, req.(*ConfKeyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_ListConfKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfKeysReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).ListConfKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_ListConfKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).ListConfKeys(ctx, req.(*ListConfKeysReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RulesEngineService_DeleteConfKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RulesEngineServiceServer).DeleteConfKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RulesEngineService_DeleteConfKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RulesEngineServiceServer).DeleteConfKey(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RulesEngineService_ServiceDesc is the grpc.ServiceDesc for RulesEngineService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RulesEngineService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "re.RulesEngineService",
+	HandlerType: (*RulesEngineServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Info",
+			Handler:    _RulesEngineService_Info_Handler,
+		},
+		{
+			MethodName: "CreateStream",
+			Handler:    _RulesEngineService_CreateStream_Handler,
+		},
+		{
+			MethodName: "ListStreams",
+			Handler:    _RulesEngineService_ListStreams_Handler,
+		},
+		{
+			MethodName: "ViewStream",
+			Handler:    _RulesEngineService_ViewStream_Handler,
+		},
+		{
+			MethodName: "ViewStreams",
+			Handler:    _RulesEngineService_ViewStreams_Handler,
+		},
+		{
+			MethodName: "DeleteStream",
+			Handler:    _RulesEngineService_DeleteStream_Handler,
+		},
+		{
+			MethodName: "CreateTable",
+			Handler:    _RulesEngineService_CreateTable_Handler,
+		},
+		{
+			MethodName: "ListTables",
+			Handler:    _RulesEngineService_ListTables_Handler,
+		},
+		{
+			MethodName: "ViewTable",
+			Handler:    _RulesEngineService_ViewTable_Handler,
+		},
+		{
+			MethodName: "DeleteTable",
+			Handler:    _RulesEngineService_DeleteTable_Handler,
+		},
+		{
+			MethodName: "CreateRule",
+			Handler:    _RulesEngineService_CreateRule_Handler,
+		},
+		{
+			MethodName: "UpdateRule",
+			Handler:    _RulesEngineService_UpdateRule_Handler,
+		},
+		{
+			MethodName: "PatchRule",
+			Handler:    _RulesEngineService_PatchRule_Handler,
+		},
+		{
+			MethodName: "CloneRule",
+			Handler:    _RulesEngineService_CloneRule_Handler,
+		},
+		{
+			MethodName: "ValidateRule",
+			Handler:    _RulesEngineService_ValidateRule_Handler,
+		},
+		{
+			MethodName: "TestRule",
+			Handler:    _RulesEngineService_TestRule_Handler,
+		},
+		{
+			MethodName: "ReplayRule",
+			Handler:    _RulesEngineService_ReplayRule_Handler,
+		},
+		{
+			MethodName: "PushTail",
+			Handler:    _RulesEngineService_PushTail_Handler,
+		},
+		{
+			MethodName: "Ingest",
+			Handler:    _RulesEngineService_Ingest_Handler,
+		},
+		{
+			MethodName: "ViewRule",
+			Handler:    _RulesEngineService_ViewRule_Handler,
+		},
+		{
+			MethodName: "ViewRules",
+			Handler:    _RulesEngineService_ViewRules_Handler,
+		},
+		{
+			MethodName: "ListRules",
+			Handler:    _RulesEngineService_ListRules_Handler,
+		},
+		{
+			MethodName: "SearchRules",
+			Handler:    _RulesEngineService_SearchRules_Handler,
+		},
+		{
+			MethodName: "DeleteRule",
+			Handler:    _RulesEngineService_DeleteRule_Handler,
+		},
+		{
+			MethodName: "StartRule",
+			Handler:    _RulesEngineService_StartRule_Handler,
+		},
+		{
+			MethodName: "StopRule",
+			Handler:    _RulesEngineService_StopRule_Handler,
+		},
+		{
+			MethodName: "RestartRule",
+			Handler:    _RulesEngineService_RestartRule_Handler,
+		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _RulesEngineService_SaveDraft_Handler,
+		},
+		{
+			MethodName: "PublishRule",
+			Handler:    _RulesEngineService_PublishRule_Handler,
+		},
+		{
+			MethodName: "UnpublishRule",
+			Handler:    _RulesEngineService_UnpublishRule_Handler,
+		},
+		{
+			MethodName: "RestoreRule",
+			Handler:    _RulesEngineService_RestoreRule_Handler,
+		},
+		{
+			MethodName: "RuleStatus",
+			Handler:    _RulesEngineService_RuleStatus_Handler,
+		},
+		{
+			MethodName: "RuleTopology",
+			Handler:    _RulesEngineService_RuleTopology_Handler,
+		},
+		{
+			MethodName: "RuleLogs",
+			Handler:    _RulesEngineService_RuleLogs_Handler,
+		},
+		{
+			MethodName: "Reconcile",
+			Handler:    _RulesEngineService_Reconcile_Handler,
+		},
+		{
+			MethodName: "CollectOrphans",
+			Handler:    _RulesEngineService_CollectOrphans_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _RulesEngineService_Restore_Handler,
+		},
+		{
+			MethodName: "ExportRuleset",
+			Handler:    _RulesEngineService_ExportRuleset_Handler,
+		},
+		{
+			MethodName: "ImportRuleset",
+			Handler:    _RulesEngineService_ImportRuleset_Handler,
+		},
+		{
+			MethodName: "BulkCreate",
+			Handler:    _RulesEngineService_BulkCreate_Handler,
+		},
+		{
+			MethodName: "BulkDelete",
+			Handler:    _RulesEngineService_BulkDelete_Handler,
+		},
+		{
+			MethodName: "StartRules",
+			Handler:    _RulesEngineService_StartRules_Handler,
+		},
+		{
+			MethodName: "StopRules",
+			Handler:    _RulesEngineService_StopRules_Handler,
+		},
+		{
+			MethodName: "ListAllStreams",
+			Handler:    _RulesEngineService_ListAllStreams_Handler,
+		},
+		{
+			MethodName: "ListAllRules",
+			Handler:    _RulesEngineService_ListAllRules_Handler,
+		},
+		{
+			MethodName: "ViewQuota",
+			Handler:    _RulesEngineService_ViewQuota_Handler,
+		},
+		{
+			MethodName: "SetQuota",
+			Handler:    _RulesEngineService_SetQuota_Handler,
+		},
+		{
+			MethodName: "RemoveQuota",
+			Handler:    _RulesEngineService_RemoveQuota_Handler,
+		},
+		{
+			MethodName: "ShareEntity",
+			Handler:    _RulesEngineService_ShareEntity_Handler,
+		},
+		{
+			MethodName: "ListShares",
+			Handler:    _RulesEngineService_ListShares_Handler,
+		},
+		{
+			MethodName: "UnshareEntity",
+			Handler:    _RulesEngineService_UnshareEntity_Handler,
+		},
+		{
+			MethodName: "Rename",
+			Handler:    _RulesEngineService_Rename_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _RulesEngineService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "ListInstances",
+			Handler:    _RulesEngineService_ListInstances_Handler,
+		},
+		{
+			MethodName: "AssignInstance",
+			Handler:    _RulesEngineService_AssignInstance_Handler,
+		},
+		{
+			MethodName: "SaveGateway",
+			Handler:    _RulesEngineService_SaveGateway_Handler,
+		},
+		{
+			MethodName: "ListGateways",
+			Handler:    _RulesEngineService_ListGateways_Handler,
+		},
+		{
+			MethodName: "RemoveGateway",
+			Handler:    _RulesEngineService_RemoveGateway_Handler,
+		},
+		{
+			MethodName: "DeployRule",
+			Handler:    _RulesEngineService_DeployRule_Handler,
+		},
+		{
+			MethodName: "UndeployRule",
+			Handler:    _RulesEngineService_UndeployRule_Handler,
+		},
+		{
+			MethodName: "ListDeployments",
+			Handler:    _RulesEngineService_ListDeployments_Handler,
+		},
+		{
+			MethodName: "DeployFleet",
+			Handler:    _RulesEngineService_DeployFleet_Handler,
+		},
+		{
+			MethodName: "RetryDeployments",
+			Handler:    _RulesEngineService_RetryDeployments_Handler,
+		},
+		{
+			MethodName: "ViewRollout",
+			Handler:    _RulesEngineService_ViewRollout_Handler,
+		},
+		{
+			MethodName: "EngineStats",
+			Handler:    _RulesEngineService_EngineStats_Handler,
+		},
+		{
+			MethodName: "SetAlertPolicy",
+			Handler:    _RulesEngineService_SetAlertPolicy_Handler,
+		},
+		{
+			MethodName: "ViewAlertPolicy",
+			Handler:    _RulesEngineService_ViewAlertPolicy_Handler,
+		},
+		{
+			MethodName: "RemoveAlertPolicy",
+			Handler:    _RulesEngineService_RemoveAlertPolicy_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _RulesEngineService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _RulesEngineService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "RemoveWebhook",
+			Handler:    _RulesEngineService_RemoveWebhook_Handler,
+		},
+		{
+			MethodName: "CreateFolder",
+			Handler:    _RulesEngineService_CreateFolder_Handler,
+		},
+		{
+			MethodName: "ListFolders",
+			Handler:    _RulesEngineService_ListFolders_Handler,
+		},
+		{
+			MethodName: "RemoveFolder",
+			Handler:    _RulesEngineService_RemoveFolder_Handler,
+		},
+		{
+			MethodName: "MoveRules",
+			Handler:    _RulesEngineService_MoveRules_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _RulesEngineService_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _RulesEngineService_Resume_Handler,
+		},
+		{
+			MethodName: "ViewMaintenance",
+			Handler:    _RulesEngineService_ViewMaintenance_Handler,
+		},
+		{
+			MethodName: "CreateChain",
+			Handler:    _RulesEngineService_CreateChain_Handler,
+		},
+		{
+			MethodName: "ListChains",
+			Handler:    _RulesEngineService_ListChains_Handler,
+		},
+		{
+			MethodName: "RemoveChain",
+			Handler:    _RulesEngineService_RemoveChain_Handler,
+		},
+		{
+			MethodName: "SaveSecret",
+			Handler:    _RulesEngineService_SaveSecret_Handler,
+		},
+		{
+			MethodName: "ListSecrets",
+			Handler:    _RulesEngineService_ListSecrets_Handler,
+		},
+		{
+			MethodName: "RemoveSecret",
+			Handler:    _RulesEngineService_RemoveSecret_Handler,
+		},
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _RulesEngineService_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "ViewTemplate",
+			Handler:    _RulesEngineService_ViewTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _RulesEngineService_ListTemplates_Handler,
+		},
+		{
+			MethodName: "RemoveTemplate",
+			Handler:    _RulesEngineService_RemoveTemplate_Handler,
+		},
+		{
+			MethodName: "InstantiateTemplate",
+			Handler:    _RulesEngineService_InstantiateTemplate_Handler,
+		},
+		{
+			MethodName: "CreatePlugin",
+			Handler:    _RulesEngineService_CreatePlugin_Handler,
+		},
+		{
+			MethodName: "ListPlugins",
+			Handler:    _RulesEngineService_ListPlugins_Handler,
+		},
+		{
+			MethodName: "DeletePlugin",
+			Handler:    _RulesEngineService_DeletePlugin_Handler,
+		},
+		{
+			MethodName: "RegisterExternalService",
+			Handler:    _RulesEngineService_RegisterExternalService_Handler,
+		},
+		{
+			MethodName: "ListExternalServices",
+			Handler:    _RulesEngineService_ListExternalServices_Handler,
+		},
+		{
+			MethodName: "DeleteExternalService",
+			Handler:    _RulesEngineService_DeleteExternalService_Handler,
+		},
+		{
+			MethodName: "ListExternalFunctions",
+			Handler:    _RulesEngineService_ListExternalFunctions_Handler,
+		},
+		{
+			MethodName: "SaveConfKey",
+			Handler:    _RulesEngineService_SaveConfKey_Handler,
+		},
+		{
+			MethodName: "ListConfKeys",
+			Handler:    _RulesEngineService_ListConfKeys_Handler,
+		},
+		{
+			MethodName: "DeleteConfKey",
+			Handler:    _RulesEngineService_DeleteConfKey_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TailRule",
+			Handler:       _RulesEngineService_TailRule_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchRules",
+			Handler:       _RulesEngineService_WatchRules_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "re/api/grpc/re.proto",
+}
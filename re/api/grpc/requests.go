@@ -0,0 +1,869 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"time"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/internal/apiutil"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+)
+
+type createStreamReq struct {
+	token  string
+	def    re.StreamDef
+	update bool
+}
+
+func (req createStreamReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.def.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.def.Topic == "" {
+		return apiutil.ErrMissingTopic
+	}
+	if len(req.def.Fields) == 0 && !req.def.SenML {
+		return apiutil.ErrMissingFields
+	}
+
+	return nil
+}
+
+type createTableReq struct {
+	token string
+	def   re.TableDef
+}
+
+func (req createTableReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.def.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.def.Topic == "" {
+		return apiutil.ErrMissingTopic
+	}
+	if len(req.def.Fields) == 0 {
+		return apiutil.ErrMissingFields
+	}
+
+	return nil
+}
+
+type ruleReq struct {
+	token string
+	rule  re.Rule
+}
+
+func (req ruleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.rule.ID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.rule.SQL == "" {
+		return apiutil.ErrMissingSQL
+	}
+
+	return nil
+}
+
+type patchRuleReq struct {
+	token string
+	id    string
+	patch re.RulePatch
+}
+
+func (req patchRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.patch.SQL != nil && *req.patch.SQL == "" {
+		return apiutil.ErrMissingSQL
+	}
+
+	return nil
+}
+
+type cloneRuleReq struct {
+	token   string
+	id      string
+	newID   string
+	channel string
+}
+
+func (req cloneRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.newID == "" {
+		return apiutil.ErrMissingNewName
+	}
+
+	return nil
+}
+
+// validateRuleReq contains the rule to validate. Missing rule fields are
+// reported as the rule diagnostics.
+type validateRuleReq ruleReq
+
+func (req validateRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type testRuleReq struct {
+	token string
+	trial re.RuleTrial
+}
+
+func (req testRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.trial.Rule.ID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.trial.Rule.SQL == "" {
+		return apiutil.ErrMissingSQL
+	}
+	if len(req.trial.Samples) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type ruleLogsReq struct {
+	token string
+	id    string
+	since time.Time
+}
+
+func (req ruleLogsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type replayRuleReq struct {
+	token string
+	id    string
+	from  time.Time
+	to    time.Time
+}
+
+func (req replayRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.from.IsZero() {
+		return apiutil.ErrMissingFrom
+	}
+	if req.to.IsZero() {
+		return apiutil.ErrMissingTo
+	}
+
+	return nil
+}
+
+type pushTailReq struct {
+	session string
+	result  map[string]interface{}
+}
+
+func (req pushTailReq) validate() error {
+	if req.session == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type ingestReq struct {
+	key     string
+	stream  string
+	payload []byte
+}
+
+func (req ingestReq) validate() error {
+	if req.key == "" {
+		return apiutil.ErrBearerKey
+	}
+	if req.stream == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token string
+	pm    re.PageMetadata
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.pm.Limit > api.MaxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	switch req.pm.Order {
+	case "", re.OrderName, re.OrderCreated, re.OrderUpdated, re.OrderState:
+	default:
+		return apiutil.ErrInvalidOrder
+	}
+	switch req.pm.Dir {
+	case "", re.DirAsc, re.DirDesc:
+	default:
+		return apiutil.ErrInvalidDirection
+	}
+
+	return nil
+}
+
+type searchRulesReq struct {
+	listReq
+	q re.RuleQuery
+}
+
+func (req searchRulesReq) validate() error {
+	if err := req.listReq.validate(); err != nil {
+		return err
+	}
+	if req.q.Stream == "" && req.q.Channel == "" {
+		return apiutil.ErrMissingReference
+	}
+
+	return nil
+}
+
+// entityReq identifies the stream by name or the rule by ID.
+type entityReq struct {
+	token string
+	id    string
+}
+
+func (req entityReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type deleteStreamReq struct {
+	entityReq
+	cascade bool
+}
+
+type reconcileReq struct {
+	token  string
+	repair bool
+}
+
+func (req reconcileReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type collectOrphansReq struct {
+	token  string
+	policy re.OrphanPolicy
+}
+
+func (req collectOrphansReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.policy.MinAge < 0 {
+		return svcerr.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type restoreReq struct {
+	token  string
+	dryRun bool
+}
+
+func (req restoreReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type exportRulesetReq struct {
+	token string
+}
+
+func (req exportRulesetReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type importRulesetReq struct {
+	token    string
+	rs       re.Ruleset
+	conflict string
+}
+
+func (req importRulesetReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type bulkCreateReq struct {
+	token string
+	rs    re.Ruleset
+}
+
+func (req bulkCreateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.rs.Streams)+len(req.rs.Rules) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type bulkDeleteReq struct {
+	token string
+	bd    re.BulkDeletion
+}
+
+func (req bulkDeleteReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.bd.Streams)+len(req.bd.Rules) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type ruleSelectionReq struct {
+	token string
+	sel   re.RuleSelection
+}
+
+func (req ruleSelectionReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.sel.IDs)+len(req.sel.Labels) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type listAllReq struct {
+	token string
+}
+
+func (req listAllReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type alertPolicyReq struct {
+	token  string
+	policy re.AlertPolicy
+}
+
+func (req alertPolicyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type webhookReq struct {
+	token string
+	wh    re.Webhook
+}
+
+func (req webhookReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type folderReq struct {
+	token  string
+	folder re.Folder
+}
+
+func (req folderReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.folder.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+type moveRulesReq struct {
+	token  string
+	folder string
+	ids    []string
+}
+
+func (req moveRulesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.ids) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type chainReq struct {
+	token string
+	chain re.Chain
+}
+
+func (req chainReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.chain.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+type secretReq struct {
+	token  string
+	secret re.Secret
+}
+
+func (req secretReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.secret.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+type quotaReq struct {
+	token  string
+	userID string
+	quota  re.Quota
+}
+
+func (req quotaReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type shareReq struct {
+	token string
+	kind  string
+	name  string
+	share re.Share
+}
+
+func (req shareReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" || req.share.Grantee == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type sharesReq struct {
+	token string
+	kind  string
+	name  string
+}
+
+func (req sharesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type unshareReq struct {
+	token   string
+	kind    string
+	name    string
+	grantee string
+}
+
+func (req unshareReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" || req.grantee == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type renameReq struct {
+	token   string
+	kind    string
+	name    string
+	newName string
+}
+
+func (req renameReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.newName == "" {
+		return apiutil.ErrMissingNewName
+	}
+
+	return nil
+}
+
+type auditReq struct {
+	token string
+	q     re.AuditQuery
+}
+
+func (req auditReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.q.Limit > api.MaxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type assignInstanceReq struct {
+	token    string
+	userID   string
+	instance string
+}
+
+func (req assignInstanceReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type gatewayReq struct {
+	token string
+	gw    re.Gateway
+}
+
+func (req gatewayReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.gw.ID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type deployReq struct {
+	token   string
+	id      string
+	gateway string
+}
+
+func (req deployReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" || req.gateway == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type deployFleetReq struct {
+	token  string
+	id     string
+	labels map[string]string
+}
+
+func (req deployFleetReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type templateReq struct {
+	token string
+	tmpl  re.Template
+}
+
+func (req templateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.tmpl.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.tmpl.SQL == "" {
+		return apiutil.ErrMissingSQL
+	}
+
+	return nil
+}
+
+type listTemplatesReq struct {
+	token string
+}
+
+func (req listTemplatesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type pluginReq struct {
+	token  string
+	kind   string
+	plugin re.Plugin
+}
+
+func (req pluginReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.plugin.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.plugin.File == "" {
+		return apiutil.ErrMissingPluginFile
+	}
+
+	return nil
+}
+
+type listPluginsReq struct {
+	token string
+	kind  string
+}
+
+func (req listPluginsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type deletePluginReq struct {
+	token string
+	kind  string
+	name  string
+}
+
+func (req deletePluginReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type externalServiceReq struct {
+	token   string
+	service re.ExternalService
+}
+
+func (req externalServiceReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.service.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.service.File == "" {
+		return apiutil.ErrMissingServiceFile
+	}
+
+	return nil
+}
+
+type listExternalServicesReq struct {
+	token string
+}
+
+func (req listExternalServicesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type listExternalFunctionsReq struct {
+	token string
+}
+
+func (req listExternalFunctionsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type confKeyReq struct {
+	token string
+	name  string
+	conf  re.MQTTConf
+}
+
+func (req confKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.conf.Server == "" {
+		return apiutil.ErrMissingBrokerServer
+	}
+
+	return nil
+}
+
+type listConfKeysReq struct {
+	token string
+}
+
+func (req listConfKeysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type instantiateReq struct {
+	token string
+	name  string
+	inst  re.TemplateInstance
+}
+
+func (req instantiateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" || req.inst.ID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type batchReq struct {
+	token string
+	names []string
+}
+
+func (req batchReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.names) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
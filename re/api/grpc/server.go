@@ -0,0 +1,1786 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/internal/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// requestIDKey is the metadata key of the request ID.
+	requestIDKey = "x-request-id"
+	// maxRequestIDSize limits the request IDs the clients send, which are
+	// written to the logs.
+	maxRequestIDSize = 128
+	// revisionKey is the metadata key of the entity revision the updates
+	// and the deletions expect.
+	revisionKey = "if-match"
+)
+
+var _ RulesEngineServiceServer = (*grpcServer)(nil)
+
+type grpcServer struct {
+	UnimplementedRulesEngineServiceServer
+	svc          re.Service
+	info         kitgrpc.Handler
+	createStream kitgrpc.Handler
+	listStreams  kitgrpc.Handler
+	viewStream   kitgrpc.Handler
+	viewStreams  kitgrpc.Handler
+	deleteStream kitgrpc.Handler
+	createTable  kitgrpc.Handler
+	listTables   kitgrpc.Handler
+	viewTable    kitgrpc.Handler
+	deleteTable  kitgrpc.Handler
+	createRule   kitgrpc.Handler
+	updateRule   kitgrpc.Handler
+	patchRule    kitgrpc.Handler
+	cloneRule    kitgrpc.Handler
+	validateRule kitgrpc.Handler
+	testRule     kitgrpc.Handler
+	replayRule   kitgrpc.Handler
+	pushTail     kitgrpc.Handler
+	ingest       kitgrpc.Handler
+	viewRule     kitgrpc.Handler
+	viewRules    kitgrpc.Handler
+	listRules    kitgrpc.Handler
+	searchRules  kitgrpc.Handler
+	deleteRule   kitgrpc.Handler
+	startRule    kitgrpc.Handler
+	stopRule     kitgrpc.Handler
+	restartRule  kitgrpc.Handler
+	saveDraft    kitgrpc.Handler
+	publishRule  kitgrpc.Handler
+	unpublish    kitgrpc.Handler
+	restoreRule  kitgrpc.Handler
+	ruleStatus   kitgrpc.Handler
+	ruleTopo     kitgrpc.Handler
+	ruleLogs     kitgrpc.Handler
+	reconcile    kitgrpc.Handler
+	orphans      kitgrpc.Handler
+	restore      kitgrpc.Handler
+	exportRules  kitgrpc.Handler
+	importRules  kitgrpc.Handler
+	bulkCreate   kitgrpc.Handler
+	bulkDelete   kitgrpc.Handler
+	startRules   kitgrpc.Handler
+	stopRules    kitgrpc.Handler
+	allStreams   kitgrpc.Handler
+	allRules     kitgrpc.Handler
+	viewQuota    kitgrpc.Handler
+	setQuota     kitgrpc.Handler
+	removeQuota  kitgrpc.Handler
+	share        kitgrpc.Handler
+	listShares   kitgrpc.Handler
+	unshare      kitgrpc.Handler
+	rename       kitgrpc.Handler
+	auditEvents  kitgrpc.Handler
+	instances    kitgrpc.Handler
+	assignInst   kitgrpc.Handler
+	saveGw       kitgrpc.Handler
+	listGws      kitgrpc.Handler
+	removeGw     kitgrpc.Handler
+	deploy       kitgrpc.Handler
+	undeploy     kitgrpc.Handler
+	deployments  kitgrpc.Handler
+	deployFleet  kitgrpc.Handler
+	retryDeploy  kitgrpc.Handler
+	rollout      kitgrpc.Handler
+	stats        kitgrpc.Handler
+	setAlerts    kitgrpc.Handler
+	viewAlerts   kitgrpc.Handler
+	removeAlerts kitgrpc.Handler
+	createHook   kitgrpc.Handler
+	listHooks    kitgrpc.Handler
+	removeHook   kitgrpc.Handler
+	createFolder kitgrpc.Handler
+	listFolders  kitgrpc.Handler
+	removeFolder kitgrpc.Handler
+	moveRules    kitgrpc.Handler
+	pause        kitgrpc.Handler
+	resume       kitgrpc.Handler
+	viewMaint    kitgrpc.Handler
+	createChain  kitgrpc.Handler
+	listChains   kitgrpc.Handler
+	removeChain  kitgrpc.Handler
+	saveSecret   kitgrpc.Handler
+	listSecrets  kitgrpc.Handler
+	removeSecret kitgrpc.Handler
+	createTmpl   kitgrpc.Handler
+	viewTmpl     kitgrpc.Handler
+	listTmpls    kitgrpc.Handler
+	removeTmpl   kitgrpc.Handler
+	instantiate  kitgrpc.Handler
+	createPlugin kitgrpc.Handler
+	listPlugins  kitgrpc.Handler
+	deletePlugin kitgrpc.Handler
+	registerSvc  kitgrpc.Handler
+	listSvcs     kitgrpc.Handler
+	deleteSvc    kitgrpc.Handler
+	listFuncs    kitgrpc.Handler
+	saveConfKey  kitgrpc.Handler
+	listConfKeys kitgrpc.Handler
+	delConfKey   kitgrpc.Handler
+}
+
+// NewServer returns new RulesEngineServiceServer instance.
+func NewServer(svc re.Service) RulesEngineServiceServer {
+	opts := []kitgrpc.ServerOption{kitgrpc.ServerBefore(readRequestID, readRevision)}
+
+	return &grpcServer{
+		svc:          svc,
+		info:         kitgrpc.NewServer(infoEndpoint(svc), decodeInfoRequest, encodeInfoResponse, opts...),
+		createStream: kitgrpc.NewServer(createStreamEndpoint(svc), decodeCreateStreamRequest, encodeResultResponse, opts...),
+		listStreams:  kitgrpc.NewServer(listStreamsEndpoint(svc), decodeListRequest, encodeStreamsPageResponse, opts...),
+		viewStream:   kitgrpc.NewServer(viewStreamEndpoint(svc), decodeEntityRequest, encodeStreamResponse, opts...),
+		viewStreams:  kitgrpc.NewServer(viewStreamsEndpoint(svc), decodeBatchRequest, encodeStreamsBatchResponse, opts...),
+		deleteStream: kitgrpc.NewServer(deleteStreamEndpoint(svc), decodeDeleteStreamRequest, encodeResultResponse, opts...),
+		createTable:  kitgrpc.NewServer(createTableEndpoint(svc), decodeCreateTableRequest, encodeResultResponse, opts...),
+		listTables:   kitgrpc.NewServer(listTablesEndpoint(svc), decodeListRequest, encodeTablesPageResponse, opts...),
+		viewTable:    kitgrpc.NewServer(viewTableEndpoint(svc), decodeEntityRequest, encodeTableResponse, opts...),
+		deleteTable:  kitgrpc.NewServer(entityCommandEndpoint(svc.DeleteTable), decodeEntityRequest, encodeResultResponse, opts...),
+		createRule:   kitgrpc.NewServer(createRuleEndpoint(svc), decodeRuleRequest, encodeResultResponse, opts...),
+		updateRule:   kitgrpc.NewServer(updateRuleEndpoint(svc), decodeRuleRequest, encodeResultResponse, opts...),
+		patchRule:    kitgrpc.NewServer(patchRuleEndpoint(svc), decodePatchRuleRequest, encodeResultResponse, opts...),
+		cloneRule:    kitgrpc.NewServer(cloneRuleEndpoint(svc), decodeCloneRuleRequest, encodeResultResponse, opts...),
+		validateRule: kitgrpc.NewServer(validateRuleEndpoint(svc), decodeRuleRequest, encodeRuleValidationResponse, opts...),
+		testRule:     kitgrpc.NewServer(testRuleEndpoint(svc), decodeTestRuleRequest, encodeTrialResultResponse, opts...),
+		replayRule:   kitgrpc.NewServer(replayRuleEndpoint(svc), decodeReplayRuleRequest, encodeReplayResultResponse, opts...),
+		pushTail:     kitgrpc.NewServer(pushTailEndpoint(svc), decodePushTailRequest, encodePushTailResponse, opts...),
+		ingest:       kitgrpc.NewServer(ingestEndpoint(svc), decodeIngestRequest, encodeIngestResponse, opts...),
+		viewRule:     kitgrpc.NewServer(viewRuleEndpoint(svc), decodeEntityRequest, encodeRuleResponse, opts...),
+		viewRules:    kitgrpc.NewServer(viewRulesEndpoint(svc), decodeBatchRequest, encodeRulesBatchResponse, opts...),
+		listRules:    kitgrpc.NewServer(listRulesEndpoint(svc), decodeListRequest, encodeRulesPageResponse, opts...),
+		searchRules:  kitgrpc.NewServer(searchRulesEndpoint(svc), decodeSearchRulesRequest, encodeRulesPageResponse, opts...),
+		deleteRule:   kitgrpc.NewServer(entityCommandEndpoint(svc.DeleteRule), decodeEntityRequest, encodeResultResponse, opts...),
+		startRule:    kitgrpc.NewServer(entityCommandEndpoint(svc.StartRule), decodeEntityRequest, encodeResultResponse, opts...),
+		stopRule:     kitgrpc.NewServer(entityCommandEndpoint(svc.StopRule), decodeEntityRequest, encodeResultResponse, opts...),
+		restartRule:  kitgrpc.NewServer(entityCommandEndpoint(svc.RestartRule), decodeEntityRequest, encodeResultResponse, opts...),
+		saveDraft:    kitgrpc.NewServer(saveDraftEndpoint(svc), decodeRuleRequest, encodeResultResponse, opts...),
+		publishRule:  kitgrpc.NewServer(entityCommandEndpoint(svc.PublishRule), decodeEntityRequest, encodeResultResponse, opts...),
+		unpublish:    kitgrpc.NewServer(entityCommandEndpoint(svc.UnpublishRule), decodeEntityRequest, encodeResultResponse, opts...),
+		restoreRule:  kitgrpc.NewServer(entityCommandEndpoint(svc.RestoreRule), decodeEntityRequest, encodeResultResponse, opts...),
+		ruleStatus:   kitgrpc.NewServer(ruleStatusEndpoint(svc), decodeEntityRequest, encodeRuleStatusResponse, opts...),
+		ruleTopo:     kitgrpc.NewServer(ruleTopologyEndpoint(svc), decodeEntityRequest, encodeRuleTopologyResponse, opts...),
+		ruleLogs:     kitgrpc.NewServer(ruleLogsEndpoint(svc), decodeRuleLogsRequest, encodeRuleLogsResponse, opts...),
+		reconcile:    kitgrpc.NewServer(reconcileEndpoint(svc), decodeReconcileRequest, encodeDriftReportResponse, opts...),
+		orphans:      kitgrpc.NewServer(collectOrphansEndpoint(svc), decodeCollectOrphansRequest, encodeOrphanReportResponse, opts...),
+		restore:      kitgrpc.NewServer(restoreEndpoint(svc), decodeRestoreRequest, encodeRestoreReportResponse, opts...),
+		exportRules:  kitgrpc.NewServer(exportRulesetEndpoint(svc), decodeExportRulesetRequest, encodeRulesetResponse, opts...),
+		importRules:  kitgrpc.NewServer(importRulesetEndpoint(svc), decodeImportRulesetRequest, encodeImportReportResponse, opts...),
+		bulkCreate:   kitgrpc.NewServer(bulkCreateEndpoint(svc), decodeBulkCreateRequest, encodeBulkReportResponse, opts...),
+		bulkDelete:   kitgrpc.NewServer(bulkDeleteEndpoint(svc), decodeBulkDeleteRequest, encodeBulkReportResponse, opts...),
+		startRules:   kitgrpc.NewServer(startRulesEndpoint(svc), decodeRuleSelectionRequest, encodeBulkReportResponse, opts...),
+		stopRules:    kitgrpc.NewServer(stopRulesEndpoint(svc), decodeRuleSelectionRequest, encodeBulkReportResponse, opts...),
+		allStreams:   kitgrpc.NewServer(listAllStreamsEndpoint(svc), decodeListAllRequest, encodeAllStreamsResponse, opts...),
+		allRules:     kitgrpc.NewServer(listAllRulesEndpoint(svc), decodeListAllRequest, encodeAllRulesResponse, opts...),
+		viewQuota:    kitgrpc.NewServer(viewQuotaEndpoint(svc), decodeEntityRequest, encodeUserQuotaResponse, opts...),
+		setQuota:     kitgrpc.NewServer(setQuotaEndpoint(svc), decodeQuotaRequest, encodeUserQuotaResponse, opts...),
+		removeQuota:  kitgrpc.NewServer(removeQuotaEndpoint(svc), decodeEntityRequest, encodeRemoveQuotaResponse, opts...),
+		share:        kitgrpc.NewServer(shareEntityEndpoint(svc), decodeShareRequest, encodeShareResponse, opts...),
+		listShares:   kitgrpc.NewServer(listSharesEndpoint(svc), decodeSharesRequest, encodeSharesResponse, opts...),
+		unshare:      kitgrpc.NewServer(unshareEntityEndpoint(svc), decodeUnshareRequest, encodeUnshareResponse, opts...),
+		rename:       kitgrpc.NewServer(renameEndpoint(svc), decodeRenameRequest, encodeResultResponse, opts...),
+		auditEvents:  kitgrpc.NewServer(listAuditEventsEndpoint(svc), decodeAuditRequest, encodeAuditPageResponse, opts...),
+		instances:    kitgrpc.NewServer(listInstancesEndpoint(svc), decodeListAllRequest, encodeInstancesResponse, opts...),
+		assignInst:   kitgrpc.NewServer(assignInstanceEndpoint(svc), decodeAssignInstanceRequest, encodeAssignmentResponse, opts...),
+		saveGw:       kitgrpc.NewServer(saveGatewayEndpoint(svc), decodeGatewayRequest, encodeGatewayResponse, opts...),
+		listGws:      kitgrpc.NewServer(listGatewaysEndpoint(svc), decodeListAllRequest, encodeGatewaysResponse, opts...),
+		removeGw:     kitgrpc.NewServer(removeGatewayEndpoint(svc), decodeEntityRequest, encodeRemoveGatewayResponse, opts...),
+		deploy:       kitgrpc.NewServer(deployRuleEndpoint(svc), decodeDeployRequest, encodeDeploymentResponse, opts...),
+		undeploy:     kitgrpc.NewServer(undeployRuleEndpoint(svc), decodeDeployRequest, encodeDeploymentResponse, opts...),
+		deployments:  kitgrpc.NewServer(listDeploymentsEndpoint(svc), decodeEntityRequest, encodeDeploymentsResponse, opts...),
+		deployFleet:  kitgrpc.NewServer(deployFleetEndpoint(svc), decodeDeployFleetRequest, encodeRolloutResponse, opts...),
+		retryDeploy:  kitgrpc.NewServer(retryDeploymentsEndpoint(svc), decodeEntityRequest, encodeRolloutResponse, opts...),
+		rollout:      kitgrpc.NewServer(viewRolloutEndpoint(svc), decodeEntityRequest, encodeRolloutResponse, opts...),
+		stats:        kitgrpc.NewServer(engineStatsEndpoint(svc), decodeListAllRequest, encodeEngineStatsResponse, opts...),
+		setAlerts:    kitgrpc.NewServer(setAlertPolicyEndpoint(svc), decodeAlertPolicyRequest, encodeAlertPolicyResponse, opts...),
+		viewAlerts:   kitgrpc.NewServer(viewAlertPolicyEndpoint(svc), decodeListAllRequest, encodeAlertPolicyResponse, opts...),
+		removeAlerts: kitgrpc.NewServer(removeAlertPolicyEndpoint(svc), decodeListAllRequest, encodeRemoveAlertPolicyResponse, opts...),
+		createHook:   kitgrpc.NewServer(createWebhookEndpoint(svc), decodeWebhookRequest, encodeWebhookResponse, opts...),
+		listHooks:    kitgrpc.NewServer(listWebhooksEndpoint(svc), decodeListAllRequest, encodeWebhooksResponse, opts...),
+		removeHook:   kitgrpc.NewServer(removeWebhookEndpoint(svc), decodeEntityRequest, encodeRemoveWebhookResponse, opts...),
+		createFolder: kitgrpc.NewServer(createFolderEndpoint(svc), decodeFolderRequest, encodeFolderResponse, opts...),
+		listFolders:  kitgrpc.NewServer(listFoldersEndpoint(svc), decodeListAllRequest, encodeFoldersResponse, opts...),
+		removeFolder: kitgrpc.NewServer(removeFolderEndpoint(svc), decodeEntityRequest, encodeRemoveFolderResponse, opts...),
+		moveRules:    kitgrpc.NewServer(moveRulesEndpoint(svc), decodeMoveRulesRequest, encodeBulkReportResponse, opts...),
+		pause:        kitgrpc.NewServer(pauseEndpoint(svc), decodeListAllRequest, encodeBulkReportResponse, opts...),
+		resume:       kitgrpc.NewServer(resumeEndpoint(svc), decodeListAllRequest, encodeBulkReportResponse, opts...),
+		viewMaint:    kitgrpc.NewServer(viewMaintenanceEndpoint(svc), decodeListAllRequest, encodeMaintenanceResponse, opts...),
+		createChain:  kitgrpc.NewServer(createChainEndpoint(svc), decodeChainRequest, encodeChainResponse, opts...),
+		listChains:   kitgrpc.NewServer(listChainsEndpoint(svc), decodeListAllRequest, encodeChainsResponse, opts...),
+		removeChain:  kitgrpc.NewServer(removeChainEndpoint(svc), decodeEntityRequest, encodeRemoveChainResponse, opts...),
+		saveSecret:   kitgrpc.NewServer(saveSecretEndpoint(svc), decodeSecretRequest, encodeSecretResponse, opts...),
+		listSecrets:  kitgrpc.NewServer(listSecretsEndpoint(svc), decodeListAllRequest, encodeSecretsResponse, opts...),
+		removeSecret: kitgrpc.NewServer(removeSecretEndpoint(svc), decodeEntityRequest, encodeRemoveSecretResponse, opts...),
+		createTmpl:   kitgrpc.NewServer(createTemplateEndpoint(svc), decodeTemplateRequest, encodeTemplateResponse, opts...),
+		viewTmpl:     kitgrpc.NewServer(viewTemplateEndpoint(svc), decodeEntityRequest, encodeTemplateResponse, opts...),
+		listTmpls:    kitgrpc.NewServer(listTemplatesEndpoint(svc), decodeListTemplatesRequest, encodeTemplatesResponse, opts...),
+		removeTmpl:   kitgrpc.NewServer(removeTemplateEndpoint(svc), decodeEntityRequest, encodeRemoveTemplateResponse, opts...),
+		instantiate:  kitgrpc.NewServer(instantiateTemplateEndpoint(svc), decodeInstantiateRequest, encodeRuleResponse, opts...),
+		createPlugin: kitgrpc.NewServer(createPluginEndpoint(svc), decodePluginRequest, encodeResultResponse, opts...),
+		listPlugins:  kitgrpc.NewServer(listPluginsEndpoint(svc), decodeListPluginsRequest, encodePluginsResponse, opts...),
+		deletePlugin: kitgrpc.NewServer(deletePluginEndpoint(svc), decodeDeletePluginRequest, encodeResultResponse, opts...),
+		registerSvc:  kitgrpc.NewServer(registerExternalServiceEndpoint(svc), decodeExternalServiceRequest, encodeResultResponse, opts...),
+		listSvcs:     kitgrpc.NewServer(listExternalServicesEndpoint(svc), decodeListExternalServicesRequest, encodeExternalServicesResponse, opts...),
+		deleteSvc:    kitgrpc.NewServer(entityCommandEndpoint(svc.DeleteExternalService), decodeEntityRequest, encodeResultResponse, opts...),
+		listFuncs:    kitgrpc.NewServer(listExternalFunctionsEndpoint(svc), decodeListExternalFunctionsRequest, encodeExternalFunctionsResponse, opts...),
+		saveConfKey:  kitgrpc.NewServer(saveConfKeyEndpoint(svc), decodeConfKeyRequest, encodeResultResponse, opts...),
+		listConfKeys: kitgrpc.NewServer(listConfKeysEndpoint(svc), decodeListConfKeysRequest, encodeConfKeysResponse, opts...),
+		delConfKey:   kitgrpc.NewServer(entityCommandEndpoint(svc.DeleteConfKey), decodeEntityRequest, encodeResultResponse, opts...),
+	}
+}
+
+func (s *grpcServer) Info(ctx context.Context, req *InfoReq) (*InfoRes, error) {
+	_, res, err := s.info.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*InfoRes), nil
+}
+
+func (s *grpcServer) CreateStream(ctx context.Context, req *CreateStreamReq) (*Result, error) {
+	return serveResult(ctx, s.createStream, req)
+}
+
+func (s *grpcServer) ListStreams(ctx context.Context, req *ListReq) (*StreamsPage, error) {
+	_, res, err := s.listStreams.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*StreamsPage), nil
+}
+
+func (s *grpcServer) ViewStream(ctx context.Context, req *EntityReq) (*Stream, error) {
+	_, res, err := s.viewStream.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Stream), nil
+}
+
+func (s *grpcServer) ViewStreams(ctx context.Context, req *BatchReq) (*StreamsBatch, error) {
+	_, res, err := s.viewStreams.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*StreamsBatch), nil
+}
+
+func (s *grpcServer) DeleteStream(ctx context.Context, req *DeleteStreamReq) (*Result, error) {
+	return serveResult(ctx, s.deleteStream, req)
+}
+
+func (s *grpcServer) CreateTable(ctx context.Context, req *CreateTableReq) (*Result, error) {
+	return serveResult(ctx, s.createTable, req)
+}
+
+func (s *grpcServer) ListTables(ctx context.Context, req *ListReq) (*TablesPage, error) {
+	_, res, err := s.listTables.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*TablesPage), nil
+}
+
+func (s *grpcServer) ViewTable(ctx context.Context, req *EntityReq) (*Table, error) {
+	_, res, err := s.viewTable.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Table), nil
+}
+
+func (s *grpcServer) DeleteTable(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.deleteTable, req)
+}
+
+func (s *grpcServer) CreateRule(ctx context.Context, req *RuleReq) (*Result, error) {
+	return serveResult(ctx, s.createRule, req)
+}
+
+func (s *grpcServer) UpdateRule(ctx context.Context, req *RuleReq) (*Result, error) {
+	return serveResult(ctx, s.updateRule, req)
+}
+
+func (s *grpcServer) PatchRule(ctx context.Context, req *PatchRuleReq) (*Result, error) {
+	return serveResult(ctx, s.patchRule, req)
+}
+
+func (s *grpcServer) CloneRule(ctx context.Context, req *CloneRuleReq) (*Result, error) {
+	return serveResult(ctx, s.cloneRule, req)
+}
+
+func (s *grpcServer) ValidateRule(ctx context.Context, req *RuleReq) (*RuleValidation, error) {
+	_, res, err := s.validateRule.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RuleValidation), nil
+}
+
+func (s *grpcServer) TestRule(ctx context.Context, req *TestRuleReq) (*TrialResult, error) {
+	_, res, err := s.testRule.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*TrialResult), nil
+}
+
+func (s *grpcServer) ReplayRule(ctx context.Context, req *ReplayReq) (*ReplayResult, error) {
+	_, res, err := s.replayRule.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*ReplayResult), nil
+}
+
+// TailRule streams the rule results until the client cancels the stream.
+// Go kit has no streaming support, so the service is called directly.
+func (s *grpcServer) TailRule(req *EntityReq, stream RulesEngineService_TailRuleServer) error {
+	treq := entityReq{token: req.GetToken(), id: req.GetId()}
+	if err := treq.validate(); err != nil {
+		return encodeError(err)
+	}
+	ctx := stream.Context()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = readRequestID(ctx, md)
+	}
+	results, err := s.svc.TailRule(ctx, treq.token, treq.id)
+	if err != nil {
+		return encodeError(err)
+	}
+	// The headers tell the client that the sink is attached.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for res := range results {
+		msg, err := structpb.NewStruct(res)
+		if err != nil {
+			return encodeError(errors.Wrap(svcerr.ErrMalformedEntity, err))
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WatchRules streams the rule state changes until the client cancels the
+// stream.
+func (s *grpcServer) WatchRules(req *ListAllReq, stream RulesEngineService_WatchRulesServer) error {
+	wreq := listAllReq{token: req.GetToken()}
+	if err := wreq.validate(); err != nil {
+		return encodeError(err)
+	}
+	ctx := stream.Context()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = readRequestID(ctx, md)
+	}
+	changes, err := s.svc.WatchRules(ctx, wreq.token)
+	if err != nil {
+		return encodeError(err)
+	}
+	// The headers tell the client that the watch started.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for c := range changes {
+		if err := stream.Send(toProtoRuleStateChange(c)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *grpcServer) PushTail(ctx context.Context, req *PushTailReq) (*PushTailRes, error) {
+	_, res, err := s.pushTail.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*PushTailRes), nil
+}
+
+func (s *grpcServer) Ingest(ctx context.Context, req *IngestReq) (*IngestRes, error) {
+	_, res, err := s.ingest.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*IngestRes), nil
+}
+
+func (s *grpcServer) ViewRule(ctx context.Context, req *EntityReq) (*Rule, error) {
+	_, res, err := s.viewRule.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Rule), nil
+}
+
+func (s *grpcServer) ViewRules(ctx context.Context, req *BatchReq) (*RulesBatch, error) {
+	_, res, err := s.viewRules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RulesBatch), nil
+}
+
+func (s *grpcServer) ListRules(ctx context.Context, req *ListReq) (*RulesPage, error) {
+	_, res, err := s.listRules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RulesPage), nil
+}
+
+func (s *grpcServer) SearchRules(ctx context.Context, req *SearchRulesReq) (*RulesPage, error) {
+	_, res, err := s.searchRules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RulesPage), nil
+}
+
+func (s *grpcServer) DeleteRule(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.deleteRule, req)
+}
+
+func (s *grpcServer) StartRule(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.startRule, req)
+}
+
+func (s *grpcServer) StopRule(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.stopRule, req)
+}
+
+func (s *grpcServer) RestartRule(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.restartRule, req)
+}
+
+func (s *grpcServer) SaveDraft(ctx context.Context, req *RuleReq) (*Result, error) {
+	return serveResult(ctx, s.saveDraft, req)
+}
+
+func (s *grpcServer) PublishRule(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.publishRule, req)
+}
+
+func (s *grpcServer) UnpublishRule(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.unpublish, req)
+}
+
+func (s *grpcServer) RestoreRule(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.restoreRule, req)
+}
+
+func (s *grpcServer) RuleStatus(ctx context.Context, req *EntityReq) (*RuleStatusRes, error) {
+	_, res, err := s.ruleStatus.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RuleStatusRes), nil
+}
+
+func (s *grpcServer) RuleTopology(ctx context.Context, req *EntityReq) (*RuleTopologyRes, error) {
+	_, res, err := s.ruleTopo.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RuleTopologyRes), nil
+}
+
+func (s *grpcServer) RuleLogs(ctx context.Context, req *RuleLogsReq) (*RuleLogsRes, error) {
+	_, res, err := s.ruleLogs.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RuleLogsRes), nil
+}
+
+func (s *grpcServer) Reconcile(ctx context.Context, req *ReconcileReq) (*DriftReport, error) {
+	_, res, err := s.reconcile.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*DriftReport), nil
+}
+
+func (s *grpcServer) CollectOrphans(ctx context.Context, req *CollectOrphansReq) (*OrphanReport, error) {
+	_, res, err := s.orphans.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*OrphanReport), nil
+}
+
+func (s *grpcServer) Restore(ctx context.Context, req *RestoreReq) (*RestoreReport, error) {
+	_, res, err := s.restore.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RestoreReport), nil
+}
+
+func (s *grpcServer) ExportRuleset(ctx context.Context, req *ExportRulesetReq) (*Ruleset, error) {
+	_, res, err := s.exportRules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Ruleset), nil
+}
+
+func (s *grpcServer) ImportRuleset(ctx context.Context, req *ImportRulesetReq) (*ImportReport, error) {
+	_, res, err := s.importRules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*ImportReport), nil
+}
+
+func (s *grpcServer) BulkCreate(ctx context.Context, req *BulkCreateReq) (*BulkReport, error) {
+	_, res, err := s.bulkCreate.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*BulkReport), nil
+}
+
+func (s *grpcServer) BulkDelete(ctx context.Context, req *BulkDeleteReq) (*BulkReport, error) {
+	_, res, err := s.bulkDelete.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*BulkReport), nil
+}
+
+func (s *grpcServer) StartRules(ctx context.Context, req *RuleSelectionReq) (*BulkReport, error) {
+	_, res, err := s.startRules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*BulkReport), nil
+}
+
+func (s *grpcServer) StopRules(ctx context.Context, req *RuleSelectionReq) (*BulkReport, error) {
+	_, res, err := s.stopRules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*BulkReport), nil
+}
+
+func (s *grpcServer) ListAllStreams(ctx context.Context, req *ListAllReq) (*AllStreams, error) {
+	_, res, err := s.allStreams.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*AllStreams), nil
+}
+
+func (s *grpcServer) ListAllRules(ctx context.Context, req *ListAllReq) (*AllRules, error) {
+	_, res, err := s.allRules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*AllRules), nil
+}
+
+func (s *grpcServer) ViewQuota(ctx context.Context, req *EntityReq) (*UserQuota, error) {
+	_, res, err := s.viewQuota.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*UserQuota), nil
+}
+
+func (s *grpcServer) SetQuota(ctx context.Context, req *QuotaReq) (*UserQuota, error) {
+	_, res, err := s.setQuota.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*UserQuota), nil
+}
+
+func (s *grpcServer) RemoveQuota(ctx context.Context, req *EntityReq) (*RemoveQuotaRes, error) {
+	_, res, err := s.removeQuota.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RemoveQuotaRes), nil
+}
+
+func (s *grpcServer) ShareEntity(ctx context.Context, req *ShareReq) (*Share, error) {
+	_, res, err := s.share.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Share), nil
+}
+
+func (s *grpcServer) ListShares(ctx context.Context, req *SharesReq) (*SharesRes, error) {
+	_, res, err := s.listShares.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*SharesRes), nil
+}
+
+func (s *grpcServer) UnshareEntity(ctx context.Context, req *SharesReq) (*UnshareRes, error) {
+	_, res, err := s.unshare.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*UnshareRes), nil
+}
+
+func (s *grpcServer) Rename(ctx context.Context, req *RenameReq) (*Result, error) {
+	return serveResult(ctx, s.rename, req)
+}
+
+func (s *grpcServer) ListAuditEvents(ctx context.Context, req *AuditReq) (*AuditPage, error) {
+	_, res, err := s.auditEvents.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*AuditPage), nil
+}
+
+func (s *grpcServer) ListInstances(ctx context.Context, req *ListAllReq) (*InstancesRes, error) {
+	_, res, err := s.instances.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*InstancesRes), nil
+}
+
+func (s *grpcServer) AssignInstance(ctx context.Context, req *AssignInstanceReq) (*Assignment, error) {
+	_, res, err := s.assignInst.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Assignment), nil
+}
+
+func (s *grpcServer) SaveGateway(ctx context.Context, req *GatewayReq) (*Gateway, error) {
+	_, res, err := s.saveGw.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Gateway), nil
+}
+
+func (s *grpcServer) ListGateways(ctx context.Context, req *ListAllReq) (*GatewaysRes, error) {
+	_, res, err := s.listGws.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*GatewaysRes), nil
+}
+
+func (s *grpcServer) RemoveGateway(ctx context.Context, req *EntityReq) (*RemoveGatewayRes, error) {
+	_, res, err := s.removeGw.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RemoveGatewayRes), nil
+}
+
+func (s *grpcServer) DeployRule(ctx context.Context, req *DeployReq) (*Deployment, error) {
+	_, res, err := s.deploy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Deployment), nil
+}
+
+func (s *grpcServer) UndeployRule(ctx context.Context, req *DeployReq) (*Deployment, error) {
+	_, res, err := s.undeploy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Deployment), nil
+}
+
+func (s *grpcServer) ListDeployments(ctx context.Context, req *EntityReq) (*DeploymentsRes, error) {
+	_, res, err := s.deployments.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*DeploymentsRes), nil
+}
+
+func (s *grpcServer) DeployFleet(ctx context.Context, req *DeployFleetReq) (*Rollout, error) {
+	_, res, err := s.deployFleet.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Rollout), nil
+}
+
+func (s *grpcServer) RetryDeployments(ctx context.Context, req *EntityReq) (*Rollout, error) {
+	_, res, err := s.retryDeploy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Rollout), nil
+}
+
+func (s *grpcServer) ViewRollout(ctx context.Context, req *EntityReq) (*Rollout, error) {
+	_, res, err := s.rollout.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Rollout), nil
+}
+
+func (s *grpcServer) EngineStats(ctx context.Context, req *ListAllReq) (*EngineStatsRes, error) {
+	_, res, err := s.stats.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*EngineStatsRes), nil
+}
+
+func (s *grpcServer) SetAlertPolicy(ctx context.Context, req *AlertPolicyReq) (*AlertPolicy, error) {
+	_, res, err := s.setAlerts.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*AlertPolicy), nil
+}
+
+func (s *grpcServer) ViewAlertPolicy(ctx context.Context, req *ListAllReq) (*AlertPolicy, error) {
+	_, res, err := s.viewAlerts.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*AlertPolicy), nil
+}
+
+func (s *grpcServer) RemoveAlertPolicy(ctx context.Context, req *ListAllReq) (*RemoveAlertPolicyRes, error) {
+	_, res, err := s.removeAlerts.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RemoveAlertPolicyRes), nil
+}
+
+func (s *grpcServer) CreateWebhook(ctx context.Context, req *WebhookReq) (*Webhook, error) {
+	_, res, err := s.createHook.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Webhook), nil
+}
+
+func (s *grpcServer) ListWebhooks(ctx context.Context, req *ListAllReq) (*WebhooksRes, error) {
+	_, res, err := s.listHooks.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*WebhooksRes), nil
+}
+
+func (s *grpcServer) RemoveWebhook(ctx context.Context, req *EntityReq) (*RemoveWebhookRes, error) {
+	_, res, err := s.removeHook.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RemoveWebhookRes), nil
+}
+
+func (s *grpcServer) CreateFolder(ctx context.Context, req *FolderReq) (*Folder, error) {
+	_, res, err := s.createFolder.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Folder), nil
+}
+
+func (s *grpcServer) ListFolders(ctx context.Context, req *ListAllReq) (*FoldersRes, error) {
+	_, res, err := s.listFolders.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*FoldersRes), nil
+}
+
+func (s *grpcServer) RemoveFolder(ctx context.Context, req *EntityReq) (*RemoveFolderRes, error) {
+	_, res, err := s.removeFolder.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RemoveFolderRes), nil
+}
+
+func (s *grpcServer) MoveRules(ctx context.Context, req *MoveRulesReq) (*BulkReport, error) {
+	_, res, err := s.moveRules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*BulkReport), nil
+}
+
+func (s *grpcServer) Pause(ctx context.Context, req *ListAllReq) (*BulkReport, error) {
+	_, res, err := s.pause.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*BulkReport), nil
+}
+
+func (s *grpcServer) Resume(ctx context.Context, req *ListAllReq) (*BulkReport, error) {
+	_, res, err := s.resume.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*BulkReport), nil
+}
+
+func (s *grpcServer) ViewMaintenance(ctx context.Context, req *ListAllReq) (*Maintenance, error) {
+	_, res, err := s.viewMaint.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Maintenance), nil
+}
+
+func (s *grpcServer) CreateChain(ctx context.Context, req *ChainReq) (*Chain, error) {
+	_, res, err := s.createChain.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Chain), nil
+}
+
+func (s *grpcServer) ListChains(ctx context.Context, req *ListAllReq) (*ChainsRes, error) {
+	_, res, err := s.listChains.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*ChainsRes), nil
+}
+
+func (s *grpcServer) RemoveChain(ctx context.Context, req *EntityReq) (*RemoveChainRes, error) {
+	_, res, err := s.removeChain.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RemoveChainRes), nil
+}
+
+func (s *grpcServer) SaveSecret(ctx context.Context, req *SecretReq) (*Secret, error) {
+	_, res, err := s.saveSecret.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Secret), nil
+}
+
+func (s *grpcServer) ListSecrets(ctx context.Context, req *ListAllReq) (*SecretsRes, error) {
+	_, res, err := s.listSecrets.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*SecretsRes), nil
+}
+
+func (s *grpcServer) RemoveSecret(ctx context.Context, req *EntityReq) (*RemoveSecretRes, error) {
+	_, res, err := s.removeSecret.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RemoveSecretRes), nil
+}
+
+func (s *grpcServer) CreateTemplate(ctx context.Context, req *TemplateReq) (*Template, error) {
+	_, res, err := s.createTmpl.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Template), nil
+}
+
+func (s *grpcServer) ViewTemplate(ctx context.Context, req *EntityReq) (*Template, error) {
+	_, res, err := s.viewTmpl.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Template), nil
+}
+
+func (s *grpcServer) ListTemplates(ctx context.Context, req *ListTemplatesReq) (*TemplatesRes, error) {
+	_, res, err := s.listTmpls.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*TemplatesRes), nil
+}
+
+func (s *grpcServer) RemoveTemplate(ctx context.Context, req *EntityReq) (*RemoveTemplateRes, error) {
+	_, res, err := s.removeTmpl.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*RemoveTemplateRes), nil
+}
+
+func (s *grpcServer) InstantiateTemplate(ctx context.Context, req *InstantiateReq) (*Rule, error) {
+	_, res, err := s.instantiate.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Rule), nil
+}
+
+func (s *grpcServer) CreatePlugin(ctx context.Context, req *PluginReq) (*Result, error) {
+	return serveResult(ctx, s.createPlugin, req)
+}
+
+func (s *grpcServer) ListPlugins(ctx context.Context, req *ListPluginsReq) (*PluginsRes, error) {
+	_, res, err := s.listPlugins.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*PluginsRes), nil
+}
+
+func (s *grpcServer) DeletePlugin(ctx context.Context, req *DeletePluginReq) (*Result, error) {
+	return serveResult(ctx, s.deletePlugin, req)
+}
+
+func (s *grpcServer) RegisterExternalService(ctx context.Context, req *ExternalServiceReq) (*Result, error) {
+	return serveResult(ctx, s.registerSvc, req)
+}
+
+func (s *grpcServer) ListExternalServices(ctx context.Context, req *ListExternalServicesReq) (*ExternalServicesRes, error) {
+	_, res, err := s.listSvcs.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*ExternalServicesRes), nil
+}
+
+func (s *grpcServer) DeleteExternalService(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.deleteSvc, req)
+}
+
+func (s *grpcServer) ListExternalFunctions(ctx context.Context, req *ListExternalFunctionsReq) (*ExternalFunctionsRes, error) {
+	_, res, err := s.listFuncs.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*ExternalFunctionsRes), nil
+}
+
+func (s *grpcServer) SaveConfKey(ctx context.Context, req *ConfKeyReq) (*Result, error) {
+	return serveResult(ctx, s.saveConfKey, req)
+}
+
+func (s *grpcServer) ListConfKeys(ctx context.Context, req *ListConfKeysReq) (*ConfKeysRes, error) {
+	_, res, err := s.listConfKeys.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*ConfKeysRes), nil
+}
+
+func (s *grpcServer) DeleteConfKey(ctx context.Context, req *EntityReq) (*Result, error) {
+	return serveResult(ctx, s.delConfKey, req)
+}
+
+func serveResult(ctx context.Context, h kitgrpc.Handler, req interface{}) (*Result, error) {
+	_, res, err := h.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*Result), nil
+}
+
+func decodeInfoRequest(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeCreateStreamRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*CreateStreamReq)
+	def := re.StreamDef{
+		Name:        req.GetName(),
+		Topic:       req.GetTopic(),
+		Fields:      fromProtoFields(req.GetFields()),
+		Type:        req.GetType(),
+		Format:      req.GetFormat(),
+		Delimiter:   req.GetDelimiter(),
+		SchemaID:    req.GetSchemaId(),
+		SenML:       req.GetSenml(),
+		Description: req.GetDescription(),
+		Labels:      req.GetLabels(),
+		ConfKey:     req.GetConfKey(),
+		Attributes:  fromProtoAttributes(req.GetAttributes()),
+	}
+	return createStreamReq{token: req.GetToken(), def: def, update: req.GetUpdate()}, nil
+}
+
+func decodeCreateTableRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*CreateTableReq)
+	def := re.TableDef{
+		Name:        req.GetName(),
+		Topic:       req.GetTopic(),
+		Fields:      fromProtoFields(req.GetFields()),
+		Type:        req.GetType(),
+		Kind:        req.GetKind(),
+		Key:         req.GetKey(),
+		Description: req.GetDescription(),
+		Labels:      req.GetLabels(),
+	}
+	return createTableReq{token: req.GetToken(), def: def}, nil
+}
+
+func decodeListRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ListReq)
+	pm := re.PageMetadata{
+		Offset:  req.GetOffset(),
+		Limit:   req.GetLimit(),
+		Name:    req.GetName(),
+		Labels:  req.GetLabels(),
+		Owner:   req.GetOwner(),
+		Deleted: req.GetDeleted(),
+		Order:   req.GetOrder(),
+		Dir:     req.GetDir(),
+		Folder:  req.GetFolder(),
+	}
+	return listReq{token: req.GetToken(), pm: pm}, nil
+}
+
+func decodeSearchRulesRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*SearchRulesReq)
+	list, err := decodeListRequest(ctx, req.GetList())
+	if err != nil {
+		return nil, err
+	}
+	q := re.RuleQuery{Stream: req.GetStream(), Channel: req.GetChannel()}
+	return searchRulesReq{listReq: list.(listReq), q: q}, nil
+}
+
+func decodeEntityRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*EntityReq)
+	return entityReq{token: req.GetToken(), id: req.GetId()}, nil
+}
+
+func decodeBatchRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*BatchReq)
+	return batchReq{token: req.GetToken(), names: req.GetNames()}, nil
+}
+
+func decodeDeleteStreamRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*DeleteStreamReq)
+	return deleteStreamReq{entityReq: entityReq{token: req.GetToken(), id: req.GetName()}, cascade: req.GetCascade()}, nil
+}
+
+func decodeReconcileRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ReconcileReq)
+	return reconcileReq{token: req.GetToken(), repair: req.GetRepair()}, nil
+}
+
+func decodeCollectOrphansRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*CollectOrphansReq)
+	return collectOrphansReq{token: req.GetToken(), policy: re.OrphanPolicy{Remove: req.GetRemove(), MinAge: req.GetMinAge().AsDuration()}}, nil
+}
+
+func decodeRestoreRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*RestoreReq)
+	return restoreReq{token: req.GetToken(), dryRun: req.GetDryRun()}, nil
+}
+
+func decodeExportRulesetRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ExportRulesetReq)
+	return exportRulesetReq{token: req.GetToken()}, nil
+}
+
+func decodeImportRulesetRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ImportRulesetReq)
+	return importRulesetReq{token: req.GetToken(), rs: fromProtoRuleset(req.GetRuleset()), conflict: req.GetConflict()}, nil
+}
+
+func decodeBulkCreateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*BulkCreateReq)
+	return bulkCreateReq{token: req.GetToken(), rs: fromProtoRuleset(req.GetRuleset())}, nil
+}
+
+func decodeBulkDeleteRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*BulkDeleteReq)
+	return bulkDeleteReq{token: req.GetToken(), bd: re.BulkDeletion{Streams: req.GetStreams(), Rules: req.GetRules()}}, nil
+}
+
+func decodeRuleSelectionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*RuleSelectionReq)
+	return ruleSelectionReq{token: req.GetToken(), sel: re.RuleSelection{IDs: req.GetIds(), Labels: req.GetLabels()}}, nil
+}
+
+func decodeListAllRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ListAllReq)
+	return listAllReq{token: req.GetToken()}, nil
+}
+
+func decodeQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*QuotaReq)
+	q := re.Quota{MaxStreams: int(req.GetMaxStreams()), MaxRules: int(req.GetMaxRules())}
+	return quotaReq{token: req.GetToken(), userID: req.GetUserId(), quota: q}, nil
+}
+
+func decodeAlertPolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*AlertPolicyReq)
+	return alertPolicyReq{token: req.GetToken(), policy: fromProtoAlertPolicy(req.GetPolicy())}, nil
+}
+
+func decodeWebhookRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*WebhookReq)
+	return webhookReq{token: req.GetToken(), wh: fromProtoWebhook(req.GetWebhook())}, nil
+}
+
+func decodeFolderRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*FolderReq)
+	return folderReq{token: req.GetToken(), folder: fromProtoFolder(req.GetFolder())}, nil
+}
+
+func decodeMoveRulesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*MoveRulesReq)
+	return moveRulesReq{token: req.GetToken(), folder: req.GetFolder(), ids: req.GetIds()}, nil
+}
+
+func decodeChainRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ChainReq)
+	return chainReq{token: req.GetToken(), chain: fromProtoChain(req.GetChain())}, nil
+}
+
+func decodeSecretRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*SecretReq)
+	return secretReq{token: req.GetToken(), secret: fromProtoSecret(req.GetSecret())}, nil
+}
+
+func decodeShareRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ShareReq)
+	return shareReq{token: req.GetToken(), kind: req.GetKind(), name: req.GetName(), share: fromProtoShare(req.GetShare())}, nil
+}
+
+func decodeSharesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*SharesReq)
+	return sharesReq{token: req.GetToken(), kind: req.GetKind(), name: req.GetName()}, nil
+}
+
+func decodeRenameRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*RenameReq)
+	return renameReq{token: req.GetToken(), kind: req.GetKind(), name: req.GetName(), newName: req.GetNewName()}, nil
+}
+
+func decodeAuditRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*AuditReq)
+	q := re.AuditQuery{
+		Offset: req.GetOffset(),
+		Limit:  req.GetLimit(),
+		User:   req.GetUser(),
+		Owner:  req.GetOwner(),
+		Kind:   req.GetKind(),
+		Entity: req.GetEntity(),
+	}
+	if req.GetFrom() != nil {
+		q.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		q.To = req.GetTo().AsTime()
+	}
+
+	return auditReq{token: req.GetToken(), q: q}, nil
+}
+
+func decodeGatewayRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*GatewayReq)
+	return gatewayReq{token: req.GetToken(), gw: fromProtoGateway(req.GetGateway())}, nil
+}
+
+func decodeDeployRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*DeployReq)
+	return deployReq{token: req.GetToken(), id: req.GetId(), gateway: req.GetGateway()}, nil
+}
+
+func decodeDeployFleetRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*DeployFleetReq)
+	return deployFleetReq{token: req.GetToken(), id: req.GetId(), labels: req.GetLabels()}, nil
+}
+
+func decodeAssignInstanceRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*AssignInstanceReq)
+	return assignInstanceReq{token: req.GetToken(), userID: req.GetUserId(), instance: req.GetInstance()}, nil
+}
+
+func decodeUnshareRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*SharesReq)
+	return unshareReq{token: req.GetToken(), kind: req.GetKind(), name: req.GetName(), grantee: req.GetGrantee()}, nil
+}
+
+func decodeTemplateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*TemplateReq)
+	return templateReq{token: req.GetToken(), tmpl: fromProtoTemplate(req.GetTemplate())}, nil
+}
+
+func decodeListTemplatesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ListTemplatesReq)
+	return listTemplatesReq{token: req.GetToken()}, nil
+}
+
+func decodeInstantiateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*InstantiateReq)
+	inst := re.TemplateInstance{
+		ID:          req.GetId(),
+		Values:      req.GetValues(),
+		Description: req.GetDescription(),
+		Labels:      req.GetLabels(),
+	}
+	return instantiateReq{token: req.GetToken(), name: req.GetName(), inst: inst}, nil
+}
+
+func decodeRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*RuleReq)
+	return ruleReq{token: req.GetToken(), rule: fromProtoRule(req.GetRule())}, nil
+}
+
+func decodePatchRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*PatchRuleReq)
+	patch := re.RulePatch{SQL: req.Sql, Options: fromProtoRuleOptions(req.GetOptions())}
+	if len(req.GetActions()) > 0 {
+		patch.Actions = make([]re.Action, len(req.GetActions()))
+		for i, a := range req.GetActions() {
+			patch.Actions[i] = fromProtoAction(a)
+		}
+	}
+
+	return patchRuleReq{token: req.GetToken(), id: req.GetId(), patch: patch}, nil
+}
+
+func decodeCloneRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*CloneRuleReq)
+	return cloneRuleReq{token: req.GetToken(), id: req.GetId(), newID: req.GetNewId(), channel: req.GetChannel()}, nil
+}
+
+func decodeTestRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*TestRuleReq)
+	trial := re.RuleTrial{Rule: fromProtoRule(req.GetRule()), Samples: fromProtoSamples(req.GetSamples())}
+
+	return testRuleReq{token: req.GetToken(), trial: trial}, nil
+}
+
+func decodeReplayRuleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ReplayReq)
+	res := replayRuleReq{token: req.GetToken(), id: req.GetId()}
+	// Missing timestamps are left zero, so the request validation fails.
+	if req.GetFrom() != nil {
+		res.from = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		res.to = req.GetTo().AsTime()
+	}
+
+	return res, nil
+}
+
+func decodeRuleLogsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*RuleLogsReq)
+	res := ruleLogsReq{token: req.GetToken(), id: req.GetId()}
+	if req.GetSince() != nil {
+		res.since = req.GetSince().AsTime()
+	}
+
+	return res, nil
+}
+
+func decodePushTailRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*PushTailReq)
+	return pushTailReq{session: req.GetSession(), result: req.GetResult().AsMap()}, nil
+}
+
+func decodeIngestRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*IngestReq)
+	return ingestReq{key: req.GetKey(), stream: req.GetStream(), payload: req.GetPayload()}, nil
+}
+
+func encodeInfoResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(re.Info)
+	return &InfoRes{
+		Version:       res.Version,
+		Os:            res.OS,
+		UpTimeSeconds: int64(res.UpTimeSeconds),
+		Breaker:       res.Breaker,
+	}, nil
+}
+
+func encodeResultResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoResult(grpcRes.(re.Result)), nil
+}
+
+func encodeStreamsPageResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoStreamsPage(grpcRes.(re.StreamsPage)), nil
+}
+
+func encodeStreamResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoStream(grpcRes.(re.Stream))
+}
+
+func encodeStreamsBatchResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoStreamsBatch(grpcRes.(re.StreamsBatch))
+}
+
+func encodeTablesPageResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoTablesPage(grpcRes.(re.TablesPage)), nil
+}
+
+func encodeTableResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoTable(grpcRes.(re.Table))
+}
+
+func encodeRuleResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoRule(grpcRes.(re.Rule)), nil
+}
+
+func encodeRulesBatchResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoRulesBatch(grpcRes.(re.RulesBatch)), nil
+}
+
+func encodeRuleValidationResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoRuleValidation(grpcRes.(re.RuleValidation)), nil
+}
+
+func encodeTrialResultResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	results, err := toProtoStructs(grpcRes.(re.TrialResult).Results)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrialResult{Results: results}, nil
+}
+
+func encodeReplayResultResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoReplayResult(grpcRes.(re.ReplayResult))
+}
+
+func encodePushTailResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &PushTailRes{}, nil
+}
+
+func encodeIngestResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &IngestRes{}, nil
+}
+
+func encodeRulesPageResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoRulesPage(grpcRes.(re.RulesPage)), nil
+}
+
+func encodeAuditPageResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoAuditPage(grpcRes.(re.AuditPage)), nil
+}
+
+func encodeInstancesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoInstances(grpcRes.([]re.Instance)), nil
+}
+
+func encodeAssignmentResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	a := grpcRes.(re.Assignment)
+	return &Assignment{UserId: a.UserID, Instance: a.Instance, Assigned: a.Assigned}, nil
+}
+
+func encodeGatewayResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoGateway(grpcRes.(re.Gateway)), nil
+}
+
+func encodeGatewaysResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	gws := grpcRes.([]re.Gateway)
+	res := make([]*Gateway, len(gws))
+	for i, gw := range gws {
+		res[i] = toProtoGateway(gw)
+	}
+
+	return &GatewaysRes{Gateways: res}, nil
+}
+
+func encodeRemoveGatewayResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &RemoveGatewayRes{}, nil
+}
+
+func encodeDeploymentResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoDeployment(grpcRes.(re.Deployment)), nil
+}
+
+func encodeDeploymentsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	ds := grpcRes.([]re.Deployment)
+	res := make([]*Deployment, len(ds))
+	for i, d := range ds {
+		res[i] = toProtoDeployment(d)
+	}
+
+	return &DeploymentsRes{Deployments: res}, nil
+}
+
+func encodeRolloutResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoRollout(grpcRes.(re.Rollout)), nil
+}
+
+func encodeEngineStatsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoEngineStats(grpcRes.(re.EngineStats)), nil
+}
+
+func encodeRuleStatusResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoRuleStatus(grpcRes.(re.RuleStatus)), nil
+}
+
+func encodeRuleTopologyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoRuleTopology(grpcRes.(re.RuleTopology)), nil
+}
+
+func encodeRuleLogsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	logs := grpcRes.([]re.LogEntry)
+	res := &RuleLogsRes{Logs: make([]*LogEntry, len(logs))}
+	for i, l := range logs {
+		res.Logs[i] = toProtoLogEntry(l)
+	}
+
+	return res, nil
+}
+
+func encodeDriftReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoDriftReport(grpcRes.(re.DriftReport)), nil
+}
+
+func encodeOrphanReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoOrphanReport(grpcRes.(re.OrphanReport)), nil
+}
+
+func encodeRestoreReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoRestoreReport(grpcRes.(re.RestoreReport)), nil
+}
+
+func encodeRulesetResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoRuleset(grpcRes.(re.Ruleset)), nil
+}
+
+func encodeImportReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoImportReport(grpcRes.(re.ImportReport)), nil
+}
+
+func encodeBulkReportResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoBulkReport(grpcRes.(re.BulkReport)), nil
+}
+
+func encodeAllStreamsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoAllStreams(grpcRes.(re.AllStreams)), nil
+}
+
+func encodeAllRulesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoAllRules(grpcRes.(re.AllRules)), nil
+}
+
+func encodeUserQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoUserQuota(grpcRes.(re.UserQuota)), nil
+}
+
+func encodeRemoveQuotaResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &RemoveQuotaRes{}, nil
+}
+
+func encodeAlertPolicyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoAlertPolicy(grpcRes.(re.AlertPolicy)), nil
+}
+
+func encodeRemoveAlertPolicyResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &RemoveAlertPolicyRes{}, nil
+}
+
+func encodeWebhookResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoWebhook(grpcRes.(re.Webhook)), nil
+}
+
+func encodeWebhooksResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	whs := grpcRes.([]re.Webhook)
+	res := make([]*Webhook, len(whs))
+	for i, wh := range whs {
+		res[i] = toProtoWebhook(wh)
+	}
+
+	return &WebhooksRes{Webhooks: res}, nil
+}
+
+func encodeRemoveWebhookResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &RemoveWebhookRes{}, nil
+}
+
+func encodeFolderResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoFolder(grpcRes.(re.Folder)), nil
+}
+
+func encodeFoldersResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	folders := grpcRes.([]re.Folder)
+	res := make([]*Folder, len(folders))
+	for i, f := range folders {
+		res[i] = toProtoFolder(f)
+	}
+
+	return &FoldersRes{Folders: res}, nil
+}
+
+func encodeRemoveFolderResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &RemoveFolderRes{}, nil
+}
+
+func encodeMaintenanceResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoMaintenance(grpcRes.(re.Maintenance)), nil
+}
+
+func encodeChainResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoChain(grpcRes.(re.Chain)), nil
+}
+
+func encodeChainsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	chains := grpcRes.([]re.Chain)
+	res := make([]*Chain, len(chains))
+	for i, c := range chains {
+		res[i] = toProtoChain(c)
+	}
+
+	return &ChainsRes{Chains: res}, nil
+}
+
+func encodeRemoveChainResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &RemoveChainRes{}, nil
+}
+
+func encodeSecretResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoSecret(grpcRes.(re.Secret)), nil
+}
+
+func encodeSecretsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	secrets := grpcRes.([]re.Secret)
+	res := make([]*Secret, len(secrets))
+	for i, s := range secrets {
+		res[i] = toProtoSecret(s)
+	}
+
+	return &SecretsRes{Secrets: res}, nil
+}
+
+func encodeRemoveSecretResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &RemoveSecretRes{}, nil
+}
+
+func encodeShareResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoShare(grpcRes.(re.Share)), nil
+}
+
+func encodeSharesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	shares := grpcRes.([]re.Share)
+	res := &SharesRes{Shares: make([]*Share, len(shares))}
+	for i, s := range shares {
+		res.Shares[i] = toProtoShare(s)
+	}
+
+	return res, nil
+}
+
+func encodeUnshareResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &UnshareRes{}, nil
+}
+
+func encodeTemplateResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return toProtoTemplate(grpcRes.(re.Template)), nil
+}
+
+func encodeTemplatesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	tmpls := grpcRes.([]re.Template)
+	res := make([]*Template, len(tmpls))
+	for i, tmpl := range tmpls {
+		res[i] = toProtoTemplate(tmpl)
+	}
+
+	return &TemplatesRes{Templates: res}, nil
+}
+
+func encodeRemoveTemplateResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &RemoveTemplateRes{}, nil
+}
+
+func decodePluginRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*PluginReq)
+	plugin := re.Plugin{
+		Name:       req.GetName(),
+		File:       req.GetFile(),
+		ShellParas: req.GetShellParas(),
+		Functions:  req.GetFunctions(),
+	}
+	return pluginReq{token: req.GetToken(), kind: req.GetKind(), plugin: plugin}, nil
+}
+
+func decodeListPluginsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ListPluginsReq)
+	return listPluginsReq{token: req.GetToken(), kind: req.GetKind()}, nil
+}
+
+func decodeDeletePluginRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*DeletePluginReq)
+	return deletePluginReq{token: req.GetToken(), kind: req.GetKind(), name: req.GetName()}, nil
+}
+
+func encodePluginsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return &PluginsRes{Plugins: grpcRes.([]string)}, nil
+}
+
+func decodeExternalServiceRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ExternalServiceReq)
+	es := re.ExternalService{Name: req.GetName(), File: req.GetFile()}
+	return externalServiceReq{token: req.GetToken(), service: es}, nil
+}
+
+func decodeListExternalServicesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ListExternalServicesReq)
+	return listExternalServicesReq{token: req.GetToken()}, nil
+}
+
+func encodeExternalServicesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return &ExternalServicesRes{Services: grpcRes.([]string)}, nil
+}
+
+func decodeListExternalFunctionsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ListExternalFunctionsReq)
+	return listExternalFunctionsReq{token: req.GetToken()}, nil
+}
+
+func encodeExternalFunctionsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	funcs := grpcRes.([]re.ExternalFunction)
+	res := &ExternalFunctionsRes{Functions: make([]*ExternalFunction, len(funcs))}
+	for i, f := range funcs {
+		res.Functions[i] = toProtoExternalFunction(f)
+	}
+
+	return res, nil
+}
+
+func decodeConfKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ConfKeyReq)
+	conf := re.MQTTConf{
+		Server:             req.GetServer(),
+		Username:           req.GetUsername(),
+		Password:           req.GetPassword(),
+		ClientID:           req.GetClientId(),
+		QoS:                int(req.GetQos()),
+		ProtocolVersion:    req.GetProtocolVersion(),
+		CertificationRaw:   req.GetCertificationRaw(),
+		PrivateKeyRaw:      req.GetPrivateKeyRaw(),
+		RootCARaw:          req.GetRootCaRaw(),
+		InsecureSkipVerify: req.GetInsecureSkipVerify(),
+	}
+	return confKeyReq{token: req.GetToken(), name: req.GetName(), conf: conf}, nil
+}
+
+func decodeListConfKeysRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*ListConfKeysReq)
+	return listConfKeysReq{token: req.GetToken()}, nil
+}
+
+func encodeConfKeysResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return &ConfKeysRes{ConfKeys: grpcRes.([]string)}, nil
+}
+
+// readRequestID passes the request ID the client sent, or a new one if it
+// sent none, to the service.
+func readRequestID(ctx context.Context, md metadata.MD) context.Context {
+	id := re.NewRequestID()
+	if ids := md.Get(requestIDKey); len(ids) > 0 && ids[0] != "" && len(ids[0]) <= maxRequestIDSize {
+		id = ids[0]
+	}
+
+	return re.WithRequestID(ctx, id)
+}
+
+// readRevision passes the entity revision the client expects, if any, to the
+// service.
+func readRevision(ctx context.Context, md metadata.MD) context.Context {
+	if revs := md.Get(revisionKey); len(revs) > 0 && revs[0] != "" {
+		return re.WithRevision(ctx, revs[0])
+	}
+
+	return ctx
+}
+
+func encodeError(err error) error {
+	switch {
+	case errors.Contains(err, nil):
+		return nil
+	case errors.Contains(err, svcerr.ErrMalformedEntity),
+		err == apiutil.ErrMissingID,
+		err == apiutil.ErrLimitSize,
+		err == apiutil.ErrMissingSQL,
+		err == apiutil.ErrMissingFields,
+		err == apiutil.ErrMissingTopic,
+		err == apiutil.ErrMissingPluginFile,
+		err == apiutil.ErrMissingServiceFile,
+		err == apiutil.ErrMissingBrokerServer,
+		err == apiutil.ErrEmptyList,
+		err == apiutil.ErrMissingFrom,
+		err == apiutil.ErrMissingTo,
+		err == apiutil.ErrMissingNewName,
+		err == apiutil.ErrMissingReference:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Contains(err, svcerr.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Contains(err, svcerr.ErrAuthorization):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Contains(err, svcerr.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Contains(err, re.ErrStaleRevision):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Contains(err, re.ErrRevisionRequired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Contains(err, svcerr.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Contains(err, re.ErrKuiperUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Contains(err, re.ErrNotSupported):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Contains(err, re.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Contains(err, re.ErrRateLimited):
+		return rateLimitError(err)
+	case errors.Contains(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Contains(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// rateLimitError returns the resource exhausted status carrying the wait time
+// of the rate limited request as the retry details.
+func rateLimitError(err error) error {
+	st := status.New(codes.ResourceExhausted, err.Error())
+	after, ok := re.RetryAfter(err)
+	if !ok {
+		return st.Err()
+	}
+	if det, derr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(after)}); derr == nil {
+		st = det
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,1950 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+)
+
+var _ re.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    re.Service
+}
+
+// LoggingMiddleware adds logging facilities to the rules engine service.
+func LoggingMiddleware(svc re.Service, logger *slog.Logger) re.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) Info(ctx context.Context) (info re.Info, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View Kuiper info failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View Kuiper info completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Info(ctx)
+}
+
+func (lm *loggingMiddleware) CreateStream(ctx context.Context, token string, def re.StreamDef, update bool) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", def.Name),
+			slog.String("topic", def.Topic),
+			slog.String("type", def.Type),
+			slog.String("format", def.Format),
+			slog.Int("fields", len(def.Fields)),
+			slog.Bool("update", update),
+		}
+		if def.SenML {
+			args = append(args, slog.Bool("senml", def.SenML))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create stream failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Create stream completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateStream(ctx, token, def, update)
+}
+
+func (lm *loggingMiddleware) ListStreams(ctx context.Context, token string, pm re.PageMetadata) (page re.StreamsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if pm.Name != "" {
+			args = append(args, slog.String("name", pm.Name))
+		}
+		if len(pm.Labels) > 0 {
+			args = append(args, slog.Any("labels", pm.Labels))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List streams failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List streams completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListStreams(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) ViewStream(ctx context.Context, token, name string) (stream re.Stream, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View stream failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View stream completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewStream(ctx, token, name)
+}
+
+func (lm *loggingMiddleware) ViewStreams(ctx context.Context, token string, names []string) (batch re.StreamsBatch, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("streams", len(names)),
+			slog.Int("missing", len(batch.Missing)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View streams failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View streams completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewStreams(ctx, token, names)
+}
+
+func (lm *loggingMiddleware) DeleteStream(ctx context.Context, token, name string, cascade bool) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+			slog.Bool("cascade", cascade),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete stream failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Delete stream completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeleteStream(ctx, token, name, cascade)
+}
+
+func (lm *loggingMiddleware) CreateTable(ctx context.Context, token string, def re.TableDef) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", def.Name),
+			slog.String("topic", def.Topic),
+			slog.String("type", def.Type),
+			slog.String("kind", def.Kind),
+			slog.Int("fields", len(def.Fields)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create table failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Create table completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateTable(ctx, token, def)
+}
+
+func (lm *loggingMiddleware) ListTables(ctx context.Context, token string, pm re.PageMetadata) (page re.TablesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if pm.Name != "" {
+			args = append(args, slog.String("name", pm.Name))
+		}
+		if len(pm.Labels) > 0 {
+			args = append(args, slog.Any("labels", pm.Labels))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List tables failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List tables completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListTables(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) ViewTable(ctx context.Context, token, name string) (table re.Table, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View table failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View table completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewTable(ctx, token, name)
+}
+
+func (lm *loggingMiddleware) DeleteTable(ctx context.Context, token, name string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete table failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Delete table completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeleteTable(ctx, token, name)
+}
+
+func (lm *loggingMiddleware) CreateRule(ctx context.Context, token string, rule re.Rule) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", rule.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Create rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateRule(ctx, token, rule)
+}
+
+func (lm *loggingMiddleware) UpdateRule(ctx context.Context, token string, rule re.Rule) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", rule.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Update rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateRule(ctx, token, rule)
+}
+
+func (lm *loggingMiddleware) PatchRule(ctx context.Context, token, id string, patch re.RulePatch) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Patch rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Patch rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.PatchRule(ctx, token, id, patch)
+}
+
+func (lm *loggingMiddleware) SaveDraft(ctx context.Context, token string, rule re.Rule) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", rule.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Save draft rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Save draft rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SaveDraft(ctx, token, rule)
+}
+
+func (lm *loggingMiddleware) PublishRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Publish rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Publish rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.PublishRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RestoreRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Restore rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Restore rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RestoreRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) UnpublishRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unpublish rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Unpublish rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UnpublishRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) CloneRule(ctx context.Context, token, id, newID, channel string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.String("new_id", newID),
+		}
+		if channel != "" {
+			args = append(args, slog.String("channel", channel))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Clone rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Clone rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CloneRule(ctx, token, id, newID, channel)
+}
+
+func (lm *loggingMiddleware) ValidateRule(ctx context.Context, token string, rule re.Rule) (res re.RuleValidation, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", rule.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Validate rule failed to complete successfully", args...)
+			return
+		}
+		args = append(args, slog.Bool("valid", res.Valid))
+		lm.logger.Info("Validate rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ValidateRule(ctx, token, rule)
+}
+
+func (lm *loggingMiddleware) TestRule(ctx context.Context, token string, trial re.RuleTrial) (res re.TrialResult, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", trial.Rule.ID),
+			slog.Int("streams", len(trial.Samples)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Test rule failed to complete successfully", args...)
+			return
+		}
+		args = append(args, slog.Int("results", len(res.Results)))
+		lm.logger.Info("Test rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.TestRule(ctx, token, trial)
+}
+
+func (lm *loggingMiddleware) ReplayRule(ctx context.Context, token, id string, from, to time.Time) (res re.ReplayResult, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.Time("from", from),
+			slog.Time("to", to),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Replay rule failed to complete successfully", args...)
+			return
+		}
+		args = append(args, slog.Int("messages", res.Messages), slog.Int("results", len(res.Results)))
+		lm.logger.Info("Replay rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ReplayRule(ctx, token, id, from, to)
+}
+
+func (lm *loggingMiddleware) TailRule(ctx context.Context, token, id string) (res <-chan map[string]interface{}, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Tail rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Tail rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.TailRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) PushTail(ctx context.Context, session string, result map[string]interface{}) (err error) {
+	defer func(begin time.Time) {
+		// Results are pushed for every rule output, so only failures are logged.
+		if err != nil {
+			lm.logger.Warn("Push tail failed to complete successfully", slog.String("duration", time.Since(begin).String()), slog.Any("error", err))
+		}
+	}(time.Now())
+
+	return lm.svc.PushTail(ctx, session, result)
+}
+
+func (lm *loggingMiddleware) Ingest(ctx context.Context, key, stream string, payload []byte) (err error) {
+	defer func(begin time.Time) {
+		// Messages are pushed by the things continuously, so only failures are logged.
+		if err != nil {
+			lm.logger.Warn("Ingest failed to complete successfully", slog.String("duration", time.Since(begin).String()), slog.String("name", stream), slog.Any("error", err))
+		}
+	}(time.Now())
+
+	return lm.svc.Ingest(ctx, key, stream, payload)
+}
+
+func (lm *loggingMiddleware) WatchRules(ctx context.Context, token string) (res <-chan re.RuleStateChange, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Watch rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Watch rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.WatchRules(ctx, token)
+}
+
+func (lm *loggingMiddleware) ViewRule(ctx context.Context, token, id string) (rule re.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ViewRules(ctx context.Context, token string, ids []string) (batch re.RulesBatch, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("rules", len(ids)),
+			slog.Int("missing", len(batch.Missing)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewRules(ctx, token, ids)
+}
+
+func (lm *loggingMiddleware) ListRules(ctx context.Context, token string, pm re.PageMetadata) (page re.RulesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if pm.Name != "" {
+			args = append(args, slog.String("name", pm.Name))
+		}
+		if len(pm.Labels) > 0 {
+			args = append(args, slog.Any("labels", pm.Labels))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListRules(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) SearchRules(ctx context.Context, token string, q re.RuleQuery, pm re.PageMetadata) (page re.RulesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Group("page",
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if q.Stream != "" {
+			args = append(args, slog.String("stream", q.Stream))
+		}
+		if q.Channel != "" {
+			args = append(args, slog.String("channel", q.Channel))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Search rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Search rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SearchRules(ctx, token, q, pm)
+}
+
+func (lm *loggingMiddleware) DeleteRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Delete rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeleteRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) StartRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Start rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Start rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.StartRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) StopRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Stop rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Stop rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.StopRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RestartRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Restart rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Restart rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RestartRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RuleStatus(ctx context.Context, token, id string) (status re.RuleStatus, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.String("status", status.Status),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View rule status failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View rule status completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RuleStatus(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RuleTopology(ctx context.Context, token, id string) (topo re.RuleTopology, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.Int("nodes", len(topo.Nodes)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View rule topology failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View rule topology completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RuleTopology(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RuleLogs(ctx context.Context, token, id string, since time.Time) (logs []re.LogEntry, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View rule logs failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View rule logs completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RuleLogs(ctx, token, id, since)
+}
+
+func (lm *loggingMiddleware) Reconcile(ctx context.Context, token string, repair bool) (report re.DriftReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Bool("repair", repair),
+			slog.Int("drifts", len(report.Drifts)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Reconcile metadata failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Reconcile metadata completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Reconcile(ctx, token, repair)
+}
+
+func (lm *loggingMiddleware) CollectOrphans(ctx context.Context, token string, policy re.OrphanPolicy) (report re.OrphanReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Bool("remove", policy.Remove),
+			slog.String("min_age", policy.MinAge.String()),
+			slog.Int("orphans", len(report.Orphans)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Collect orphans failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Collect orphans completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CollectOrphans(ctx, token, policy)
+}
+
+func (lm *loggingMiddleware) Restore(ctx context.Context, token string, dryRun bool) (report re.RestoreReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Bool("dry_run", dryRun),
+			slog.Int("restored", report.Counts[re.RestoreRestored]),
+			slog.Int("failed", report.Counts[re.RestoreFailed]),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Restore Kuiper failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Restore Kuiper completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Restore(ctx, token, dryRun)
+}
+
+func (lm *loggingMiddleware) ExportRuleset(ctx context.Context, token string) (rs re.Ruleset, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("streams", len(rs.Streams)),
+			slog.Int("rules", len(rs.Rules)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Export ruleset failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Export ruleset completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ExportRuleset(ctx, token)
+}
+
+func (lm *loggingMiddleware) ImportRuleset(ctx context.Context, token string, rs re.Ruleset, conflict string) (report re.ImportReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("conflict", conflict),
+			slog.Int("streams", len(rs.Streams)),
+			slog.Int("rules", len(rs.Rules)),
+			slog.Int("failed", report.Counts[re.ImportFailed]),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Import ruleset failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Import ruleset completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ImportRuleset(ctx, token, rs, conflict)
+}
+
+func (lm *loggingMiddleware) BulkCreate(ctx context.Context, token string, rs re.Ruleset) (report re.BulkReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("streams", len(rs.Streams)),
+			slog.Int("rules", len(rs.Rules)),
+			slog.Int("failed", report.Failed),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Bulk create failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Bulk create completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.BulkCreate(ctx, token, rs)
+}
+
+func (lm *loggingMiddleware) BulkDelete(ctx context.Context, token string, bd re.BulkDeletion) (report re.BulkReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("streams", len(bd.Streams)),
+			slog.Int("rules", len(bd.Rules)),
+			slog.Int("failed", report.Failed),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Bulk delete failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Bulk delete completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.BulkDelete(ctx, token, bd)
+}
+
+func (lm *loggingMiddleware) StartRules(ctx context.Context, token string, sel re.RuleSelection) (report re.BulkReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("rules", len(report.Items)),
+			slog.Int("failed", report.Failed),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Start rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Start rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.StartRules(ctx, token, sel)
+}
+
+func (lm *loggingMiddleware) StopRules(ctx context.Context, token string, sel re.RuleSelection) (report re.BulkReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("rules", len(report.Items)),
+			slog.Int("failed", report.Failed),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Stop rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Stop rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.StopRules(ctx, token, sel)
+}
+
+func (lm *loggingMiddleware) ListAllStreams(ctx context.Context, token string) (all re.AllStreams, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("total", all.Total),
+			slog.Int("owners", len(all.Owners)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List all streams failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List all streams completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListAllStreams(ctx, token)
+}
+
+func (lm *loggingMiddleware) ListAllRules(ctx context.Context, token string) (all re.AllRules, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("total", all.Total),
+			slog.Int("owners", len(all.Owners)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List all rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List all rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListAllRules(ctx, token)
+}
+
+func (lm *loggingMiddleware) ViewQuota(ctx context.Context, token, userID string) (q re.UserQuota, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("user_id", userID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View quota failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View quota completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewQuota(ctx, token, userID)
+}
+
+func (lm *loggingMiddleware) SetQuota(ctx context.Context, token, userID string, q re.Quota) (res re.UserQuota, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("user_id", userID),
+			slog.Int("max_streams", q.MaxStreams),
+			slog.Int("max_rules", q.MaxRules),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Set quota failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Set quota completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SetQuota(ctx, token, userID, q)
+}
+
+func (lm *loggingMiddleware) RemoveQuota(ctx context.Context, token, userID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("user_id", userID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove quota failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Remove quota completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveQuota(ctx, token, userID)
+}
+
+func (lm *loggingMiddleware) ShareEntity(ctx context.Context, token, kind, name string, s re.Share) (res re.Share, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("kind", kind),
+			slog.String("name", name),
+			slog.String("grantee", s.Grantee),
+			slog.String("grantee_type", s.GranteeType),
+			slog.String("access", s.Access),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Share entity failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Share entity completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ShareEntity(ctx, token, kind, name, s)
+}
+
+func (lm *loggingMiddleware) ListShares(ctx context.Context, token, kind, name string) (shares []re.Share, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("kind", kind),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List shares failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List shares completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListShares(ctx, token, kind, name)
+}
+
+func (lm *loggingMiddleware) UnshareEntity(ctx context.Context, token, kind, name, grantee string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("kind", kind),
+			slog.String("name", name),
+			slog.String("grantee", grantee),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unshare entity failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Unshare entity completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UnshareEntity(ctx, token, kind, name, grantee)
+}
+
+func (lm *loggingMiddleware) Rename(ctx context.Context, token, kind, name, newName string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("kind", kind),
+			slog.String("name", name),
+			slog.String("new_name", newName),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Rename failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Rename completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Rename(ctx, token, kind, name, newName)
+}
+
+func (lm *loggingMiddleware) ListAuditEvents(ctx context.Context, token string, q re.AuditQuery) (page re.AuditPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Group("page",
+				slog.Uint64("offset", q.Offset),
+				slog.Uint64("limit", q.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if q.User != "" {
+			args = append(args, slog.String("user", q.User))
+		}
+		if q.Entity != "" {
+			args = append(args, slog.String("kind", q.Kind), slog.String("entity", q.Entity))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List audit events failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List audit events completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListAuditEvents(ctx, token, q)
+}
+
+func (lm *loggingMiddleware) ListInstances(ctx context.Context, token string) (instances []re.Instance, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("instances", len(instances)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List instances failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List instances completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListInstances(ctx, token)
+}
+
+func (lm *loggingMiddleware) AssignInstance(ctx context.Context, token, userID, instance string) (a re.Assignment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("user_id", userID),
+			slog.String("instance", a.Instance),
+			slog.Bool("assigned", a.Assigned),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Assign instance failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Assign instance completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.AssignInstance(ctx, token, userID, instance)
+}
+
+func (lm *loggingMiddleware) SaveGateway(ctx context.Context, token string, gw re.Gateway) (res re.Gateway, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", gw.ID),
+			slog.String("channel", res.Channel),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Save gateway failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Save gateway completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SaveGateway(ctx, token, gw)
+}
+
+func (lm *loggingMiddleware) ListGateways(ctx context.Context, token string) (gws []re.Gateway, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("gateways", len(gws)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List gateways failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List gateways completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListGateways(ctx, token)
+}
+
+func (lm *loggingMiddleware) RemoveGateway(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove gateway failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Remove gateway completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveGateway(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) DeployRule(ctx context.Context, token, id, gateway string) (d re.Deployment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.String("gateway", gateway),
+			slog.String("status", d.Status),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Deploy rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Deploy rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeployRule(ctx, token, id, gateway)
+}
+
+func (lm *loggingMiddleware) UndeployRule(ctx context.Context, token, id, gateway string) (d re.Deployment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.String("gateway", gateway),
+			slog.String("status", d.Status),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Undeploy rule failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Undeploy rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UndeployRule(ctx, token, id, gateway)
+}
+
+func (lm *loggingMiddleware) ListDeployments(ctx context.Context, token, id string) (ds []re.Deployment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.Int("deployments", len(ds)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List deployments failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List deployments completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListDeployments(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) DeployFleet(ctx context.Context, token, id string, labels map[string]string) (r re.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.String("status", r.Status),
+			slog.Int("deployments", r.Total),
+		}
+		if len(labels) > 0 {
+			args = append(args, slog.Any("labels", labels))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Deploy fleet failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Deploy fleet completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeployFleet(ctx, token, id, labels)
+}
+
+func (lm *loggingMiddleware) RetryDeployments(ctx context.Context, token, id string) (r re.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.String("status", r.Status),
+			slog.Int("failed", r.Failed),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retry deployments failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Retry deployments completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RetryDeployments(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ViewRollout(ctx context.Context, token, id string) (r re.Rollout, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+			slog.String("status", r.Status),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View rollout failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View rollout completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewRollout(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) EngineStats(ctx context.Context, token string) (stats re.EngineStats, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("rules", stats.Rules),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View engine stats failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View engine stats completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.EngineStats(ctx, token)
+}
+
+func (lm *loggingMiddleware) SetAlertPolicy(ctx context.Context, token string, p re.AlertPolicy) (res re.AlertPolicy, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int64("exceptions", p.Exceptions),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Set alert policy failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Set alert policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SetAlertPolicy(ctx, token, p)
+}
+
+func (lm *loggingMiddleware) ViewAlertPolicy(ctx context.Context, token string) (p re.AlertPolicy, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View alert policy failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View alert policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewAlertPolicy(ctx, token)
+}
+
+func (lm *loggingMiddleware) RemoveAlertPolicy(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove alert policy failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Remove alert policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveAlertPolicy(ctx, token)
+}
+
+func (lm *loggingMiddleware) CreateWebhook(ctx context.Context, token string, wh re.Webhook) (res re.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Any("events", wh.Events),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create webhook failed to complete successfully", args...)
+			return
+		}
+		args = append(args, slog.String("id", res.ID))
+		lm.logger.Info("Create webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateWebhook(ctx, token, wh)
+}
+
+func (lm *loggingMiddleware) ListWebhooks(ctx context.Context, token string) (whs []re.Webhook, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List webhooks failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List webhooks completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListWebhooks(ctx, token)
+}
+
+func (lm *loggingMiddleware) RemoveWebhook(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove webhook failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Remove webhook completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveWebhook(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) CreateFolder(ctx context.Context, token string, f re.Folder) (res re.Folder, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", f.Name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create folder failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Create folder completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateFolder(ctx, token, f)
+}
+
+func (lm *loggingMiddleware) ListFolders(ctx context.Context, token string) (folders []re.Folder, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List folders failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List folders completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListFolders(ctx, token)
+}
+
+func (lm *loggingMiddleware) RemoveFolder(ctx context.Context, token, name string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove folder failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Remove folder completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveFolder(ctx, token, name)
+}
+
+func (lm *loggingMiddleware) MoveRules(ctx context.Context, token, folder string, ids []string) (report re.BulkReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("folder", folder),
+			slog.Int("rules", len(ids)),
+			slog.Int("failed", report.Failed),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Move rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Move rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.MoveRules(ctx, token, folder, ids)
+}
+
+func (lm *loggingMiddleware) Pause(ctx context.Context, token string) (report re.BulkReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("rules", len(report.Items)),
+			slog.Int("failed", report.Failed),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Pause rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Pause rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Pause(ctx, token)
+}
+
+func (lm *loggingMiddleware) Resume(ctx context.Context, token string) (report re.BulkReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("rules", len(report.Items)),
+			slog.Int("failed", report.Failed),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Resume rules failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Resume rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Resume(ctx, token)
+}
+
+func (lm *loggingMiddleware) ViewMaintenance(ctx context.Context, token string) (m re.Maintenance, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View maintenance failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View maintenance completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewMaintenance(ctx, token)
+}
+
+func (lm *loggingMiddleware) CreateChain(ctx context.Context, token string, c re.Chain) (res re.Chain, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", c.Name),
+			slog.String("from", c.From),
+			slog.String("to", c.To),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create chain failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Create chain completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateChain(ctx, token, c)
+}
+
+func (lm *loggingMiddleware) ListChains(ctx context.Context, token string) (chains []re.Chain, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List chains failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List chains completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListChains(ctx, token)
+}
+
+func (lm *loggingMiddleware) RemoveChain(ctx context.Context, token, name string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove chain failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Remove chain completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveChain(ctx, token, name)
+}
+
+func (lm *loggingMiddleware) SaveSecret(ctx context.Context, token string, s re.Secret) (res re.Secret, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", s.Name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Save secret failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Save secret completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SaveSecret(ctx, token, s)
+}
+
+func (lm *loggingMiddleware) ListSecrets(ctx context.Context, token string) (secrets []re.Secret, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List secrets failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List secrets completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListSecrets(ctx, token)
+}
+
+func (lm *loggingMiddleware) RemoveSecret(ctx context.Context, token, name string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove secret failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Remove secret completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveSecret(ctx, token, name)
+}
+
+func (lm *loggingMiddleware) CreateTemplate(ctx context.Context, token string, tmpl re.Template) (res re.Template, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", tmpl.Name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create template failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Create template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateTemplate(ctx, token, tmpl)
+}
+
+func (lm *loggingMiddleware) ViewTemplate(ctx context.Context, token, name string) (tmpl re.Template, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View template failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("View template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewTemplate(ctx, token, name)
+}
+
+func (lm *loggingMiddleware) ListTemplates(ctx context.Context, token string) (tmpls []re.Template, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.Int("templates", len(tmpls)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List templates failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List templates completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListTemplates(ctx, token)
+}
+
+func (lm *loggingMiddleware) RemoveTemplate(ctx context.Context, token, name string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove template failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Remove template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveTemplate(ctx, token, name)
+}
+
+func (lm *loggingMiddleware) InstantiateTemplate(ctx context.Context, token, name string, inst re.TemplateInstance) (rule re.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("template", name),
+			slog.String("id", inst.ID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Instantiate template failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Instantiate template completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.InstantiateTemplate(ctx, token, name, inst)
+}
+
+func (lm *loggingMiddleware) CreatePlugin(ctx context.Context, token, kind string, plugin re.Plugin) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("kind", kind),
+			slog.String("name", plugin.Name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create plugin failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Create plugin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreatePlugin(ctx, token, kind, plugin)
+}
+
+func (lm *loggingMiddleware) ListPlugins(ctx context.Context, token, kind string) (names []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("kind", kind),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List plugins failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List plugins completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListPlugins(ctx, token, kind)
+}
+
+func (lm *loggingMiddleware) DeletePlugin(ctx context.Context, token, kind, name string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("kind", kind),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete plugin failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Delete plugin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeletePlugin(ctx, token, kind, name)
+}
+
+func (lm *loggingMiddleware) RegisterExternalService(ctx context.Context, token string, es re.ExternalService) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", es.Name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Register external service failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Register external service completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RegisterExternalService(ctx, token, es)
+}
+
+func (lm *loggingMiddleware) ListExternalServices(ctx context.Context, token string) (names []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List external services failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List external services completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListExternalServices(ctx, token)
+}
+
+func (lm *loggingMiddleware) DeleteExternalService(ctx context.Context, token, name string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete external service failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Delete external service completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeleteExternalService(ctx, token, name)
+}
+
+func (lm *loggingMiddleware) ListExternalFunctions(ctx context.Context, token string) (funcs []re.ExternalFunction, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List external functions failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List external functions completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListExternalFunctions(ctx, token)
+}
+
+func (lm *loggingMiddleware) SaveConfKey(ctx context.Context, token, name string, conf re.MQTTConf) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+			slog.String("server", conf.Server),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Save conf key failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Save conf key completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SaveConfKey(ctx, token, name, conf)
+}
+
+func (lm *loggingMiddleware) ListConfKeys(ctx context.Context, token string) (names []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List conf keys failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("List conf keys completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListConfKeys(ctx, token)
+}
+
+func (lm *loggingMiddleware) DeleteConfKey(ctx context.Context, token, name string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", re.RequestID(ctx)),
+			slog.String("name", name),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete conf key failed to complete successfully", args...)
+			return
+		}
+		lm.logger.Info("Delete conf key completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.DeleteConfKey(ctx, token, name)
+}
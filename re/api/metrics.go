@@ -0,0 +1,940 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ re.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     re.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc re.Service, counter metrics.Counter, latency metrics.Histogram) re.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) Info(ctx context.Context) (info re.Info, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "info").Add(1)
+		mm.latency.With("method", "info").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Info(ctx)
+}
+
+func (mm *metricsMiddleware) CreateStream(ctx context.Context, token string, def re.StreamDef, update bool) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_stream").Add(1)
+		mm.latency.With("method", "create_stream").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateStream(ctx, token, def, update)
+}
+
+func (mm *metricsMiddleware) ListStreams(ctx context.Context, token string, pm re.PageMetadata) (page re.StreamsPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_streams").Add(1)
+		mm.latency.With("method", "list_streams").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListStreams(ctx, token, pm)
+}
+
+func (mm *metricsMiddleware) ViewStream(ctx context.Context, token, name string) (stream re.Stream, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_stream").Add(1)
+		mm.latency.With("method", "view_stream").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewStream(ctx, token, name)
+}
+
+func (mm *metricsMiddleware) ViewStreams(ctx context.Context, token string, names []string) (batch re.StreamsBatch, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_streams").Add(1)
+		mm.latency.With("method", "view_streams").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewStreams(ctx, token, names)
+}
+
+func (mm *metricsMiddleware) DeleteStream(ctx context.Context, token, name string, cascade bool) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_stream").Add(1)
+		mm.latency.With("method", "delete_stream").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DeleteStream(ctx, token, name, cascade)
+}
+
+func (mm *metricsMiddleware) CreateTable(ctx context.Context, token string, def re.TableDef) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_table").Add(1)
+		mm.latency.With("method", "create_table").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateTable(ctx, token, def)
+}
+
+func (mm *metricsMiddleware) ListTables(ctx context.Context, token string, pm re.PageMetadata) (page re.TablesPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_tables").Add(1)
+		mm.latency.With("method", "list_tables").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListTables(ctx, token, pm)
+}
+
+func (mm *metricsMiddleware) ViewTable(ctx context.Context, token, name string) (table re.Table, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_table").Add(1)
+		mm.latency.With("method", "view_table").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewTable(ctx, token, name)
+}
+
+func (mm *metricsMiddleware) DeleteTable(ctx context.Context, token, name string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_table").Add(1)
+		mm.latency.With("method", "delete_table").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DeleteTable(ctx, token, name)
+}
+
+func (mm *metricsMiddleware) CreateRule(ctx context.Context, token string, rule re.Rule) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_rule").Add(1)
+		mm.latency.With("method", "create_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateRule(ctx, token, rule)
+}
+
+func (mm *metricsMiddleware) UpdateRule(ctx context.Context, token string, rule re.Rule) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_rule").Add(1)
+		mm.latency.With("method", "update_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateRule(ctx, token, rule)
+}
+
+func (mm *metricsMiddleware) PatchRule(ctx context.Context, token, id string, patch re.RulePatch) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "patch_rule").Add(1)
+		mm.latency.With("method", "patch_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PatchRule(ctx, token, id, patch)
+}
+
+func (mm *metricsMiddleware) SaveDraft(ctx context.Context, token string, rule re.Rule) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "save_draft").Add(1)
+		mm.latency.With("method", "save_draft").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SaveDraft(ctx, token, rule)
+}
+
+func (mm *metricsMiddleware) PublishRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_rule").Add(1)
+		mm.latency.With("method", "publish_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PublishRule(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) RestoreRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "restore_rule").Add(1)
+		mm.latency.With("method", "restore_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RestoreRule(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) UnpublishRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "unpublish_rule").Add(1)
+		mm.latency.With("method", "unpublish_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UnpublishRule(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) CloneRule(ctx context.Context, token, id, newID, channel string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "clone_rule").Add(1)
+		mm.latency.With("method", "clone_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CloneRule(ctx, token, id, newID, channel)
+}
+
+func (mm *metricsMiddleware) ValidateRule(ctx context.Context, token string, rule re.Rule) (res re.RuleValidation, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "validate_rule").Add(1)
+		mm.latency.With("method", "validate_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ValidateRule(ctx, token, rule)
+}
+
+func (mm *metricsMiddleware) TestRule(ctx context.Context, token string, trial re.RuleTrial) (res re.TrialResult, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "test_rule").Add(1)
+		mm.latency.With("method", "test_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.TestRule(ctx, token, trial)
+}
+
+func (mm *metricsMiddleware) ReplayRule(ctx context.Context, token, id string, from, to time.Time) (res re.ReplayResult, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "replay_rule").Add(1)
+		mm.latency.With("method", "replay_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ReplayRule(ctx, token, id, from, to)
+}
+
+func (mm *metricsMiddleware) TailRule(ctx context.Context, token, id string) (res <-chan map[string]interface{}, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "tail_rule").Add(1)
+		mm.latency.With("method", "tail_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.TailRule(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) PushTail(ctx context.Context, session string, result map[string]interface{}) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "push_tail").Add(1)
+		mm.latency.With("method", "push_tail").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.PushTail(ctx, session, result)
+}
+
+func (mm *metricsMiddleware) Ingest(ctx context.Context, key, stream string, payload []byte) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "ingest").Add(1)
+		mm.latency.With("method", "ingest").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Ingest(ctx, key, stream, payload)
+}
+
+func (mm *metricsMiddleware) WatchRules(ctx context.Context, token string) (<-chan re.RuleStateChange, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "watch_rules").Add(1)
+		mm.latency.With("method", "watch_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.WatchRules(ctx, token)
+}
+
+func (mm *metricsMiddleware) ViewRule(ctx context.Context, token, id string) (rule re.Rule, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_rule").Add(1)
+		mm.latency.With("method", "view_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewRule(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) ViewRules(ctx context.Context, token string, ids []string) (batch re.RulesBatch, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_rules").Add(1)
+		mm.latency.With("method", "view_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewRules(ctx, token, ids)
+}
+
+func (mm *metricsMiddleware) ListRules(ctx context.Context, token string, pm re.PageMetadata) (page re.RulesPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_rules").Add(1)
+		mm.latency.With("method", "list_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListRules(ctx, token, pm)
+}
+
+func (mm *metricsMiddleware) SearchRules(ctx context.Context, token string, q re.RuleQuery, pm re.PageMetadata) (page re.RulesPage, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "search_rules").Add(1)
+		mm.latency.With("method", "search_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SearchRules(ctx, token, q, pm)
+}
+
+func (mm *metricsMiddleware) DeleteRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_rule").Add(1)
+		mm.latency.With("method", "delete_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DeleteRule(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) StartRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "start_rule").Add(1)
+		mm.latency.With("method", "start_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.StartRule(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) StopRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "stop_rule").Add(1)
+		mm.latency.With("method", "stop_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.StopRule(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) RestartRule(ctx context.Context, token, id string) (res re.Result, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "restart_rule").Add(1)
+		mm.latency.With("method", "restart_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RestartRule(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) RuleStatus(ctx context.Context, token, id string) (status re.RuleStatus, err error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "rule_status").Add(1)
+		mm.latency.With("method", "rule_status").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RuleStatus(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) RuleTopology(ctx context.Context, token, id string) (re.RuleTopology, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "rule_topology").Add(1)
+		mm.latency.With("method", "rule_topology").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RuleTopology(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) RuleLogs(ctx context.Context, token, id string, since time.Time) ([]re.LogEntry, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "rule_logs").Add(1)
+		mm.latency.With("method", "rule_logs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RuleLogs(ctx, token, id, since)
+}
+
+func (mm *metricsMiddleware) Reconcile(ctx context.Context, token string, repair bool) (re.DriftReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "reconcile").Add(1)
+		mm.latency.With("method", "reconcile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Reconcile(ctx, token, repair)
+}
+
+func (mm *metricsMiddleware) CollectOrphans(ctx context.Context, token string, policy re.OrphanPolicy) (re.OrphanReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "collect_orphans").Add(1)
+		mm.latency.With("method", "collect_orphans").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CollectOrphans(ctx, token, policy)
+}
+
+func (mm *metricsMiddleware) Restore(ctx context.Context, token string, dryRun bool) (re.RestoreReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "restore").Add(1)
+		mm.latency.With("method", "restore").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Restore(ctx, token, dryRun)
+}
+
+func (mm *metricsMiddleware) ExportRuleset(ctx context.Context, token string) (re.Ruleset, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "export_ruleset").Add(1)
+		mm.latency.With("method", "export_ruleset").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ExportRuleset(ctx, token)
+}
+
+func (mm *metricsMiddleware) ImportRuleset(ctx context.Context, token string, rs re.Ruleset, conflict string) (re.ImportReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "import_ruleset").Add(1)
+		mm.latency.With("method", "import_ruleset").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ImportRuleset(ctx, token, rs, conflict)
+}
+
+func (mm *metricsMiddleware) BulkCreate(ctx context.Context, token string, rs re.Ruleset) (re.BulkReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "bulk_create").Add(1)
+		mm.latency.With("method", "bulk_create").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.BulkCreate(ctx, token, rs)
+}
+
+func (mm *metricsMiddleware) BulkDelete(ctx context.Context, token string, bd re.BulkDeletion) (re.BulkReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "bulk_delete").Add(1)
+		mm.latency.With("method", "bulk_delete").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.BulkDelete(ctx, token, bd)
+}
+
+func (mm *metricsMiddleware) StartRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "start_rules").Add(1)
+		mm.latency.With("method", "start_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.StartRules(ctx, token, sel)
+}
+
+func (mm *metricsMiddleware) StopRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "stop_rules").Add(1)
+		mm.latency.With("method", "stop_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.StopRules(ctx, token, sel)
+}
+
+func (mm *metricsMiddleware) ListAllStreams(ctx context.Context, token string) (re.AllStreams, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_all_streams").Add(1)
+		mm.latency.With("method", "list_all_streams").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListAllStreams(ctx, token)
+}
+
+func (mm *metricsMiddleware) ListAllRules(ctx context.Context, token string) (re.AllRules, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_all_rules").Add(1)
+		mm.latency.With("method", "list_all_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListAllRules(ctx, token)
+}
+
+func (mm *metricsMiddleware) ViewQuota(ctx context.Context, token, userID string) (re.UserQuota, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_quota").Add(1)
+		mm.latency.With("method", "view_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewQuota(ctx, token, userID)
+}
+
+func (mm *metricsMiddleware) SetQuota(ctx context.Context, token, userID string, q re.Quota) (re.UserQuota, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "set_quota").Add(1)
+		mm.latency.With("method", "set_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SetQuota(ctx, token, userID, q)
+}
+
+func (mm *metricsMiddleware) RemoveQuota(ctx context.Context, token, userID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_quota").Add(1)
+		mm.latency.With("method", "remove_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveQuota(ctx, token, userID)
+}
+
+func (mm *metricsMiddleware) ShareEntity(ctx context.Context, token, kind, name string, s re.Share) (re.Share, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "share_entity").Add(1)
+		mm.latency.With("method", "share_entity").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ShareEntity(ctx, token, kind, name, s)
+}
+
+func (mm *metricsMiddleware) ListShares(ctx context.Context, token, kind, name string) ([]re.Share, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_shares").Add(1)
+		mm.latency.With("method", "list_shares").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListShares(ctx, token, kind, name)
+}
+
+func (mm *metricsMiddleware) UnshareEntity(ctx context.Context, token, kind, name, grantee string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "unshare_entity").Add(1)
+		mm.latency.With("method", "unshare_entity").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UnshareEntity(ctx, token, kind, name, grantee)
+}
+
+func (mm *metricsMiddleware) Rename(ctx context.Context, token, kind, name, newName string) (re.Result, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "rename").Add(1)
+		mm.latency.With("method", "rename").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Rename(ctx, token, kind, name, newName)
+}
+
+func (mm *metricsMiddleware) ListAuditEvents(ctx context.Context, token string, q re.AuditQuery) (re.AuditPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_audit_events").Add(1)
+		mm.latency.With("method", "list_audit_events").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListAuditEvents(ctx, token, q)
+}
+
+func (mm *metricsMiddleware) ListInstances(ctx context.Context, token string) ([]re.Instance, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_instances").Add(1)
+		mm.latency.With("method", "list_instances").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListInstances(ctx, token)
+}
+
+func (mm *metricsMiddleware) AssignInstance(ctx context.Context, token, userID, instance string) (re.Assignment, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "assign_instance").Add(1)
+		mm.latency.With("method", "assign_instance").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.AssignInstance(ctx, token, userID, instance)
+}
+
+func (mm *metricsMiddleware) SaveGateway(ctx context.Context, token string, gw re.Gateway) (re.Gateway, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "save_gateway").Add(1)
+		mm.latency.With("method", "save_gateway").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SaveGateway(ctx, token, gw)
+}
+
+func (mm *metricsMiddleware) ListGateways(ctx context.Context, token string) ([]re.Gateway, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_gateways").Add(1)
+		mm.latency.With("method", "list_gateways").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListGateways(ctx, token)
+}
+
+func (mm *metricsMiddleware) RemoveGateway(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_gateway").Add(1)
+		mm.latency.With("method", "remove_gateway").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveGateway(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) DeployRule(ctx context.Context, token, id, gateway string) (re.Deployment, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "deploy_rule").Add(1)
+		mm.latency.With("method", "deploy_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DeployRule(ctx, token, id, gateway)
+}
+
+func (mm *metricsMiddleware) UndeployRule(ctx context.Context, token, id, gateway string) (re.Deployment, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "undeploy_rule").Add(1)
+		mm.latency.With("method", "undeploy_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UndeployRule(ctx, token, id, gateway)
+}
+
+func (mm *metricsMiddleware) ListDeployments(ctx context.Context, token, id string) ([]re.Deployment, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_deployments").Add(1)
+		mm.latency.With("method", "list_deployments").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListDeployments(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) DeployFleet(ctx context.Context, token, id string, labels map[string]string) (re.Rollout, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "deploy_fleet").Add(1)
+		mm.latency.With("method", "deploy_fleet").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DeployFleet(ctx, token, id, labels)
+}
+
+func (mm *metricsMiddleware) RetryDeployments(ctx context.Context, token, id string) (re.Rollout, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "retry_deployments").Add(1)
+		mm.latency.With("method", "retry_deployments").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RetryDeployments(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) ViewRollout(ctx context.Context, token, id string) (re.Rollout, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_rollout").Add(1)
+		mm.latency.With("method", "view_rollout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewRollout(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) EngineStats(ctx context.Context, token string) (re.EngineStats, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "engine_stats").Add(1)
+		mm.latency.With("method", "engine_stats").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.EngineStats(ctx, token)
+}
+
+func (mm *metricsMiddleware) SetAlertPolicy(ctx context.Context, token string, p re.AlertPolicy) (re.AlertPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "set_alert_policy").Add(1)
+		mm.latency.With("method", "set_alert_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SetAlertPolicy(ctx, token, p)
+}
+
+func (mm *metricsMiddleware) ViewAlertPolicy(ctx context.Context, token string) (re.AlertPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_alert_policy").Add(1)
+		mm.latency.With("method", "view_alert_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewAlertPolicy(ctx, token)
+}
+
+func (mm *metricsMiddleware) RemoveAlertPolicy(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_alert_policy").Add(1)
+		mm.latency.With("method", "remove_alert_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveAlertPolicy(ctx, token)
+}
+
+func (mm *metricsMiddleware) CreateWebhook(ctx context.Context, token string, wh re.Webhook) (re.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_webhook").Add(1)
+		mm.latency.With("method", "create_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateWebhook(ctx, token, wh)
+}
+
+func (mm *metricsMiddleware) ListWebhooks(ctx context.Context, token string) ([]re.Webhook, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_webhooks").Add(1)
+		mm.latency.With("method", "list_webhooks").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListWebhooks(ctx, token)
+}
+
+func (mm *metricsMiddleware) RemoveWebhook(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_webhook").Add(1)
+		mm.latency.With("method", "remove_webhook").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveWebhook(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) CreateFolder(ctx context.Context, token string, f re.Folder) (re.Folder, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_folder").Add(1)
+		mm.latency.With("method", "create_folder").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateFolder(ctx, token, f)
+}
+
+func (mm *metricsMiddleware) ListFolders(ctx context.Context, token string) ([]re.Folder, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_folders").Add(1)
+		mm.latency.With("method", "list_folders").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListFolders(ctx, token)
+}
+
+func (mm *metricsMiddleware) RemoveFolder(ctx context.Context, token, name string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_folder").Add(1)
+		mm.latency.With("method", "remove_folder").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveFolder(ctx, token, name)
+}
+
+func (mm *metricsMiddleware) MoveRules(ctx context.Context, token, folder string, ids []string) (re.BulkReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "move_rules").Add(1)
+		mm.latency.With("method", "move_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.MoveRules(ctx, token, folder, ids)
+}
+
+func (mm *metricsMiddleware) Pause(ctx context.Context, token string) (re.BulkReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "pause").Add(1)
+		mm.latency.With("method", "pause").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Pause(ctx, token)
+}
+
+func (mm *metricsMiddleware) Resume(ctx context.Context, token string) (re.BulkReport, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "resume").Add(1)
+		mm.latency.With("method", "resume").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Resume(ctx, token)
+}
+
+func (mm *metricsMiddleware) ViewMaintenance(ctx context.Context, token string) (re.Maintenance, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_maintenance").Add(1)
+		mm.latency.With("method", "view_maintenance").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewMaintenance(ctx, token)
+}
+
+func (mm *metricsMiddleware) CreateChain(ctx context.Context, token string, c re.Chain) (re.Chain, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_chain").Add(1)
+		mm.latency.With("method", "create_chain").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateChain(ctx, token, c)
+}
+
+func (mm *metricsMiddleware) ListChains(ctx context.Context, token string) ([]re.Chain, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_chains").Add(1)
+		mm.latency.With("method", "list_chains").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListChains(ctx, token)
+}
+
+func (mm *metricsMiddleware) RemoveChain(ctx context.Context, token, name string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_chain").Add(1)
+		mm.latency.With("method", "remove_chain").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveChain(ctx, token, name)
+}
+
+func (mm *metricsMiddleware) SaveSecret(ctx context.Context, token string, s re.Secret) (re.Secret, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "save_secret").Add(1)
+		mm.latency.With("method", "save_secret").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SaveSecret(ctx, token, s)
+}
+
+func (mm *metricsMiddleware) ListSecrets(ctx context.Context, token string) ([]re.Secret, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_secrets").Add(1)
+		mm.latency.With("method", "list_secrets").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListSecrets(ctx, token)
+}
+
+func (mm *metricsMiddleware) RemoveSecret(ctx context.Context, token, name string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_secret").Add(1)
+		mm.latency.With("method", "remove_secret").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveSecret(ctx, token, name)
+}
+
+func (mm *metricsMiddleware) CreateTemplate(ctx context.Context, token string, tmpl re.Template) (re.Template, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_template").Add(1)
+		mm.latency.With("method", "create_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateTemplate(ctx, token, tmpl)
+}
+
+func (mm *metricsMiddleware) ViewTemplate(ctx context.Context, token, name string) (re.Template, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_template").Add(1)
+		mm.latency.With("method", "view_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewTemplate(ctx, token, name)
+}
+
+func (mm *metricsMiddleware) ListTemplates(ctx context.Context, token string) ([]re.Template, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_templates").Add(1)
+		mm.latency.With("method", "list_templates").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListTemplates(ctx, token)
+}
+
+func (mm *metricsMiddleware) RemoveTemplate(ctx context.Context, token, name string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_template").Add(1)
+		mm.latency.With("method", "remove_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveTemplate(ctx, token, name)
+}
+
+func (mm *metricsMiddleware) InstantiateTemplate(ctx context.Context, token, name string, inst re.TemplateInstance) (re.Rule, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "instantiate_template").Add(1)
+		mm.latency.With("method", "instantiate_template").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.InstantiateTemplate(ctx, token, name, inst)
+}
+
+func (mm *metricsMiddleware) CreatePlugin(ctx context.Context, token, kind string, plugin re.Plugin) (re.Result, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_plugin").Add(1)
+		mm.latency.With("method", "create_plugin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreatePlugin(ctx, token, kind, plugin)
+}
+
+func (mm *metricsMiddleware) ListPlugins(ctx context.Context, token, kind string) ([]string, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_plugins").Add(1)
+		mm.latency.With("method", "list_plugins").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListPlugins(ctx, token, kind)
+}
+
+func (mm *metricsMiddleware) DeletePlugin(ctx context.Context, token, kind, name string) (re.Result, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_plugin").Add(1)
+		mm.latency.With("method", "delete_plugin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DeletePlugin(ctx, token, kind, name)
+}
+
+func (mm *metricsMiddleware) RegisterExternalService(ctx context.Context, token string, es re.ExternalService) (re.Result, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "register_external_service").Add(1)
+		mm.latency.With("method", "register_external_service").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RegisterExternalService(ctx, token, es)
+}
+
+func (mm *metricsMiddleware) ListExternalServices(ctx context.Context, token string) ([]string, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_external_services").Add(1)
+		mm.latency.With("method", "list_external_services").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListExternalServices(ctx, token)
+}
+
+func (mm *metricsMiddleware) DeleteExternalService(ctx context.Context, token, name string) (re.Result, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_external_service").Add(1)
+		mm.latency.With("method", "delete_external_service").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DeleteExternalService(ctx, token, name)
+}
+
+func (mm *metricsMiddleware) ListExternalFunctions(ctx context.Context, token string) ([]re.ExternalFunction, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_external_functions").Add(1)
+		mm.latency.With("method", "list_external_functions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListExternalFunctions(ctx, token)
+}
+
+func (mm *metricsMiddleware) SaveConfKey(ctx context.Context, token, name string, conf re.MQTTConf) (re.Result, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "save_conf_key").Add(1)
+		mm.latency.With("method", "save_conf_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SaveConfKey(ctx, token, name, conf)
+}
+
+func (mm *metricsMiddleware) ListConfKeys(ctx context.Context, token string) ([]string, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_conf_keys").Add(1)
+		mm.latency.With("method", "list_conf_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListConfKeys(ctx, token)
+}
+
+func (mm *metricsMiddleware) DeleteConfKey(ctx context.Context, token, name string) (re.Result, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_conf_key").Add(1)
+		mm.latency.With("method", "delete_conf_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DeleteConfKey(ctx, token, name)
+}
@@ -0,0 +1,950 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/internal/apiutil"
+	"github.com/absmach/magistrala/re"
+)
+
+type streamReq struct {
+	token  string
+	update bool
+	re.StreamDef
+}
+
+func (req streamReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Topic == "" {
+		return apiutil.ErrMissingTopic
+	}
+	if len(req.Fields) == 0 && !req.SenML {
+		return apiutil.ErrMissingFields
+	}
+
+	return nil
+}
+
+type tableReq struct {
+	token string
+	re.TableDef
+}
+
+func (req tableReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Topic == "" {
+		return apiutil.ErrMissingTopic
+	}
+	if len(req.Fields) == 0 {
+		return apiutil.ErrMissingFields
+	}
+
+	return nil
+}
+
+type ruleReq struct {
+	token string
+	re.Rule
+}
+
+func (req ruleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.ID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.SQL == "" {
+		return apiutil.ErrMissingSQL
+	}
+	if len(req.Actions) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type patchRuleReq struct {
+	token string
+	id    string
+	re.RulePatch
+}
+
+func (req patchRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.SQL != nil && *req.SQL == "" {
+		return apiutil.ErrMissingSQL
+	}
+	if req.Actions != nil && len(req.Actions) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+// validateRuleReq contains the rule to validate. Missing rule fields are
+// reported as the rule diagnostics instead of failing the request.
+type validateRuleReq struct {
+	token string
+	re.Rule
+}
+
+func (req validateRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type testRuleReq struct {
+	token string
+	re.RuleTrial
+}
+
+func (req testRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Rule.ID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Rule.SQL == "" {
+		return apiutil.ErrMissingSQL
+	}
+	if len(req.Samples) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type replayRuleReq struct {
+	token string
+	id    string
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+}
+
+func (req replayRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.From.IsZero() {
+		return apiutil.ErrMissingFrom
+	}
+	if req.To.IsZero() {
+		return apiutil.ErrMissingTo
+	}
+
+	return nil
+}
+
+type ruleLogsReq struct {
+	token string
+	id    string
+	since time.Time
+}
+
+func (req ruleLogsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type tailRuleReq struct {
+	token string
+	id    string
+}
+
+func (req tailRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type pushTailReq struct {
+	session string
+	result  map[string]interface{}
+}
+
+func (req pushTailReq) validate() error {
+	if req.session == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type ingestReq struct {
+	key     string
+	stream  string
+	payload []byte
+}
+
+func (req ingestReq) validate() error {
+	if req.key == "" {
+		return apiutil.ErrBearerKey
+	}
+	if req.stream == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listReq struct {
+	token string
+	re.PageMetadata
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Limit > api.MaxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	switch req.Order {
+	case "", re.OrderName, re.OrderCreated, re.OrderUpdated, re.OrderState:
+	default:
+		return apiutil.ErrInvalidOrder
+	}
+	switch req.Dir {
+	case "", re.DirAsc, re.DirDesc:
+	default:
+		return apiutil.ErrInvalidDirection
+	}
+
+	return nil
+}
+
+type searchRulesReq struct {
+	listReq
+	re.RuleQuery
+}
+
+func (req searchRulesReq) validate() error {
+	if err := req.listReq.validate(); err != nil {
+		return err
+	}
+	if req.Stream == "" && req.Channel == "" {
+		return apiutil.ErrMissingReference
+	}
+
+	return nil
+}
+
+type viewReq struct {
+	token string
+	id    string
+}
+
+func (req viewReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type viewStreamsReq struct {
+	token string
+	Names []string `json:"names"`
+}
+
+func (req viewStreamsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.Names) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type viewRulesReq struct {
+	token string
+	IDs   []string `json:"ids"`
+}
+
+func (req viewRulesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.IDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+// moveRulesReq moves the rules to the folder, or out of their folders if
+// the folder is empty.
+type moveRulesReq struct {
+	token  string
+	Folder string   `json:"folder"`
+	IDs    []string `json:"ids"`
+}
+
+func (req moveRulesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.IDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type deleteStreamReq struct {
+	viewReq
+	cascade bool
+}
+
+type reconcileReq struct {
+	token  string
+	repair bool
+}
+
+func (req reconcileReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type collectOrphansReq struct {
+	token  string
+	policy re.OrphanPolicy
+}
+
+func (req collectOrphansReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.policy.MinAge < 0 {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+type restoreReq struct {
+	token  string
+	dryRun bool
+}
+
+func (req restoreReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type exportRulesetReq struct {
+	token string
+}
+
+func (req exportRulesetReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type importRulesetReq struct {
+	token    string
+	conflict string
+	re.Ruleset
+}
+
+func (req importRulesetReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	switch req.conflict {
+	case re.ConflictSkip, re.ConflictOverwrite, re.ConflictRename:
+		return nil
+	default:
+		return apiutil.ErrInvalidQueryParams
+	}
+}
+
+type bulkCreateReq struct {
+	token string
+	re.Ruleset
+}
+
+func (req bulkCreateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.Streams)+len(req.Rules) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type bulkDeleteReq struct {
+	token string
+	re.BulkDeletion
+}
+
+func (req bulkDeleteReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.Streams)+len(req.Rules) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type ruleSelectionReq struct {
+	token string
+	re.RuleSelection
+}
+
+func (req ruleSelectionReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if len(req.IDs)+len(req.Labels) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type listAllReq struct {
+	token string
+}
+
+func (req listAllReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type quotaReq struct {
+	token  string
+	userID string
+	re.Quota
+}
+
+func (req quotaReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type alertPolicyReq struct {
+	token string
+	re.AlertPolicy
+}
+
+func (req alertPolicyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type webhookReq struct {
+	token string
+	re.Webhook
+}
+
+func (req webhookReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	return nil
+}
+
+type folderReq struct {
+	token string
+	re.Folder
+}
+
+func (req folderReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+type chainReq struct {
+	token string
+	re.Chain
+}
+
+func (req chainReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+type secretReq struct {
+	token string
+	re.Secret
+}
+
+func (req secretReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingName
+	}
+
+	return nil
+}
+
+// assignInstanceReq assigns the user to the Kuiper instance, an empty
+// instance removing the assignment.
+type assignInstanceReq struct {
+	token    string
+	userID   string
+	Instance string `json:"instance"`
+}
+
+func (req assignInstanceReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type gatewayReq struct {
+	token string
+	re.Gateway
+}
+
+func (req gatewayReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.ID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type deployReq struct {
+	token   string
+	id      string
+	gateway string
+}
+
+func (req deployReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" || req.gateway == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type deployFleetReq struct {
+	token  string
+	id     string
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func (req deployFleetReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type shareReq struct {
+	token string
+	kind  string
+	name  string
+	re.Share
+}
+
+func (req shareReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" || req.Grantee == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listSharesReq struct {
+	token string
+	kind  string
+	name  string
+}
+
+func (req listSharesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type unshareReq struct {
+	token   string
+	kind    string
+	name    string
+	grantee string
+}
+
+func (req unshareReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" || req.grantee == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type cloneRuleReq struct {
+	token   string
+	id      string
+	NewID   string `json:"id"`
+	Channel string `json:"channel,omitempty"`
+}
+
+func (req cloneRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.NewID == "" {
+		return apiutil.ErrMissingNewName
+	}
+
+	return nil
+}
+
+type renameReq struct {
+	token   string
+	kind    string
+	name    string
+	NewName string `json:"name"`
+}
+
+func (req renameReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.NewName == "" {
+		return apiutil.ErrMissingNewName
+	}
+
+	return nil
+}
+
+type auditReq struct {
+	token string
+	re.AuditQuery
+}
+
+func (req auditReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Limit > api.MaxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type templateReq struct {
+	token string
+	re.Template
+}
+
+func (req templateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.SQL == "" {
+		return apiutil.ErrMissingSQL
+	}
+	if len(req.Actions) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
+
+type listTemplatesReq struct {
+	token string
+}
+
+func (req listTemplatesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type pluginReq struct {
+	token string
+	kind  string
+	re.Plugin
+}
+
+func (req pluginReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.File == "" {
+		return apiutil.ErrMissingPluginFile
+	}
+
+	return nil
+}
+
+type listPluginsReq struct {
+	token string
+	kind  string
+}
+
+func (req listPluginsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type deletePluginReq struct {
+	token string
+	kind  string
+	name  string
+}
+
+func (req deletePluginReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type externalServiceReq struct {
+	token string
+	re.ExternalService
+}
+
+func (req externalServiceReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.File == "" {
+		return apiutil.ErrMissingServiceFile
+	}
+
+	return nil
+}
+
+type listExternalServicesReq struct {
+	token string
+}
+
+func (req listExternalServicesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type deleteExternalServiceReq struct {
+	token string
+	name  string
+}
+
+func (req deleteExternalServiceReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listExternalFunctionsReq struct {
+	token string
+}
+
+func (req listExternalFunctionsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type confKeyReq struct {
+	token string
+	name  string
+	re.MQTTConf
+}
+
+func (req confKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Server == "" {
+		return apiutil.ErrMissingBrokerServer
+	}
+
+	return nil
+}
+
+type listConfKeysReq struct {
+	token string
+}
+
+func (req listConfKeysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type deleteConfKeyReq struct {
+	token string
+	name  string
+}
+
+func (req deleteConfKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type instantiateReq struct {
+	token string
+	name  string
+	re.TemplateInstance
+}
+
+func (req instantiateReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.name == "" || req.ID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/internal/apiutil"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+)
+
+var valid = "valid"
+
+func TestStreamReqValidation(t *testing.T) {
+	fields := []re.Field{{Name: "v", Type: re.FloatType}}
+
+	cases := []struct {
+		desc string
+		req  streamReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  streamReq{token: valid, StreamDef: re.StreamDef{Name: valid, Topic: valid, Fields: fields}},
+			err:  nil,
+		},
+		{
+			desc: "empty token",
+			req:  streamReq{StreamDef: re.StreamDef{Name: valid, Topic: valid, Fields: fields}},
+			err:  apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty name",
+			req:  streamReq{token: valid, StreamDef: re.StreamDef{Topic: valid, Fields: fields}},
+			err:  apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty topic",
+			req:  streamReq{token: valid, StreamDef: re.StreamDef{Name: valid, Fields: fields}},
+			err:  apiutil.ErrMissingTopic,
+		},
+		{
+			desc: "empty fields",
+			req:  streamReq{token: valid, StreamDef: re.StreamDef{Name: valid, Topic: valid}},
+			err:  apiutil.ErrMissingFields,
+		},
+		{
+			desc: "senml stream without fields",
+			req:  streamReq{token: valid, StreamDef: re.StreamDef{Name: valid, Topic: valid, SenML: true}},
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRuleReqValidation(t *testing.T) {
+	actions := []re.Action{{Mainflux: &re.MainfluxSink{Channel: valid}}}
+
+	cases := []struct {
+		desc string
+		req  ruleReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  ruleReq{token: valid, Rule: re.Rule{ID: valid, SQL: "SELECT * FROM s", Actions: actions}},
+			err:  nil,
+		},
+		{
+			desc: "empty token",
+			req:  ruleReq{Rule: re.Rule{ID: valid, SQL: "SELECT * FROM s", Actions: actions}},
+			err:  apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty ID",
+			req:  ruleReq{token: valid, Rule: re.Rule{SQL: "SELECT * FROM s", Actions: actions}},
+			err:  apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty SQL",
+			req:  ruleReq{token: valid, Rule: re.Rule{ID: valid, Actions: actions}},
+			err:  apiutil.ErrMissingSQL,
+		},
+		{
+			desc: "empty actions",
+			req:  ruleReq{token: valid, Rule: re.Rule{ID: valid, SQL: "SELECT * FROM s"}},
+			err:  apiutil.ErrEmptyList,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestViewReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  viewReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  viewReq{token: valid, id: valid},
+			err:  nil,
+		},
+		{
+			desc: "empty token",
+			req:  viewReq{id: valid},
+			err:  apiutil.ErrBearerToken,
+		},
+		{
+			desc: "empty ID",
+			req:  viewReq{token: valid},
+			err:  apiutil.ErrMissingID,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListReqValidation(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  listReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  listReq{token: valid, PageMetadata: re.PageMetadata{Limit: api.MaxLimitSize}},
+			err:  nil,
+		},
+		{
+			desc: "empty token",
+			req:  listReq{PageMetadata: re.PageMetadata{Limit: api.DefLimit}},
+			err:  apiutil.ErrBearerToken,
+		},
+		{
+			desc: "limit too big",
+			req:  listReq{token: valid, PageMetadata: re.PageMetadata{Limit: api.MaxLimitSize + 1}},
+			err:  apiutil.ErrLimitSize,
+		},
+		{
+			desc: "sorted by update time in descending order",
+			req:  listReq{token: valid, PageMetadata: re.PageMetadata{Limit: api.DefLimit, Order: re.OrderUpdated, Dir: re.DirDesc}},
+			err:  nil,
+		},
+		{
+			desc: "invalid order",
+			req:  listReq{token: valid, PageMetadata: re.PageMetadata{Limit: api.DefLimit, Order: "owner"}},
+			err:  apiutil.ErrInvalidOrder,
+		},
+		{
+			desc: "invalid direction",
+			req:  listReq{token: valid, PageMetadata: re.PageMetadata{Limit: api.DefLimit, Dir: "up"}},
+			err:  apiutil.ErrInvalidDirection,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,1119 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/re"
+)
+
+var (
+	_ magistrala.Response = (*infoRes)(nil)
+	_ magistrala.Response = (*readyRes)(nil)
+	_ magistrala.Response = (*resultRes)(nil)
+	_ magistrala.Response = (*listStreamsRes)(nil)
+	_ magistrala.Response = (*viewStreamRes)(nil)
+	_ magistrala.Response = (*listTablesRes)(nil)
+	_ magistrala.Response = (*viewTableRes)(nil)
+	_ magistrala.Response = (*listRulesRes)(nil)
+	_ magistrala.Response = (*viewRuleRes)(nil)
+	_ magistrala.Response = (*ruleStatusRes)(nil)
+	_ magistrala.Response = (*ruleTopologyRes)(nil)
+	_ magistrala.Response = (*ruleLogsRes)(nil)
+	_ magistrala.Response = (*validateRuleRes)(nil)
+	_ magistrala.Response = (*trialRes)(nil)
+	_ magistrala.Response = (*replayRes)(nil)
+	_ magistrala.Response = (*pushTailRes)(nil)
+	_ magistrala.Response = (*ingestRes)(nil)
+	_ magistrala.Response = (*driftRes)(nil)
+	_ magistrala.Response = (*restoreRes)(nil)
+	_ magistrala.Response = (*rulesetRes)(nil)
+	_ magistrala.Response = (*bulkRes)(nil)
+	_ magistrala.Response = (*allStreamsRes)(nil)
+	_ magistrala.Response = (*allRulesRes)(nil)
+	_ magistrala.Response = (*quotaRes)(nil)
+	_ magistrala.Response = (*removeQuotaRes)(nil)
+	_ magistrala.Response = (*alertPolicyRes)(nil)
+	_ magistrala.Response = (*removeAlertPolicyRes)(nil)
+	_ magistrala.Response = (*webhookRes)(nil)
+	_ magistrala.Response = (*listWebhooksRes)(nil)
+	_ magistrala.Response = (*removeWebhookRes)(nil)
+	_ magistrala.Response = (*folderRes)(nil)
+	_ magistrala.Response = (*listFoldersRes)(nil)
+	_ magistrala.Response = (*removeFolderRes)(nil)
+	_ magistrala.Response = (*chainRes)(nil)
+	_ magistrala.Response = (*listChainsRes)(nil)
+	_ magistrala.Response = (*removeChainRes)(nil)
+	_ magistrala.Response = (*secretRes)(nil)
+	_ magistrala.Response = (*listSecretsRes)(nil)
+	_ magistrala.Response = (*removeSecretRes)(nil)
+	_ magistrala.Response = (*listInstancesRes)(nil)
+	_ magistrala.Response = (*assignmentRes)(nil)
+	_ magistrala.Response = (*gatewayRes)(nil)
+	_ magistrala.Response = (*listGatewaysRes)(nil)
+	_ magistrala.Response = (*removeGatewayRes)(nil)
+	_ magistrala.Response = (*deploymentRes)(nil)
+	_ magistrala.Response = (*listDeploymentsRes)(nil)
+	_ magistrala.Response = (*rolloutRes)(nil)
+	_ magistrala.Response = (*engineStatsRes)(nil)
+	_ magistrala.Response = (*shareRes)(nil)
+	_ magistrala.Response = (*listSharesRes)(nil)
+	_ magistrala.Response = (*unshareRes)(nil)
+	_ magistrala.Response = (*importRes)(nil)
+	_ magistrala.Response = (*templateRes)(nil)
+	_ magistrala.Response = (*listTemplatesRes)(nil)
+	_ magistrala.Response = (*removeTemplateRes)(nil)
+	_ magistrala.Response = (*listPluginsRes)(nil)
+	_ magistrala.Response = (*listExternalServicesRes)(nil)
+	_ magistrala.Response = (*listExternalFunctionsRes)(nil)
+	_ magistrala.Response = (*listConfKeysRes)(nil)
+)
+
+type infoRes struct {
+	re.Info `json:",inline"`
+}
+
+func (res infoRes) Code() int {
+	return http.StatusOK
+}
+
+func (res infoRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res infoRes) Empty() bool {
+	return false
+}
+
+// kuiperStatus reports whether Kuiper is reachable and which version of
+// Kuiper is running.
+type kuiperStatus struct {
+	Reachable bool   `json:"reachable"`
+	Version   string `json:"version,omitempty"`
+	Breaker   string `json:"breaker,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type readyRes struct {
+	Status string       `json:"status"`
+	Kuiper kuiperStatus `json:"kuiper"`
+}
+
+func (res readyRes) Code() int {
+	if res.Kuiper.Reachable {
+		return http.StatusOK
+	}
+
+	return http.StatusServiceUnavailable
+}
+
+func (res readyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res readyRes) Empty() bool {
+	return false
+}
+
+type resultRes struct {
+	re.Result `json:",inline"`
+	created   bool
+}
+
+func (res resultRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res resultRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res resultRes) Empty() bool {
+	return false
+}
+
+type listStreamsRes struct {
+	re.StreamsPage `json:",inline"`
+}
+
+func (res listStreamsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listStreamsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listStreamsRes) Empty() bool {
+	return false
+}
+
+type viewStreamRes struct {
+	re.Stream `json:",inline"`
+}
+
+func (res viewStreamRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewStreamRes) Headers() map[string]string {
+	return map[string]string{etagHeader: etag(res.Revision())}
+}
+
+func (res viewStreamRes) Empty() bool {
+	return false
+}
+
+type viewStreamsRes struct {
+	re.StreamsBatch `json:",inline"`
+}
+
+func (res viewStreamsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewStreamsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewStreamsRes) Empty() bool {
+	return false
+}
+
+type listTablesRes struct {
+	re.TablesPage `json:",inline"`
+}
+
+func (res listTablesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listTablesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listTablesRes) Empty() bool {
+	return false
+}
+
+type viewTableRes struct {
+	re.Table `json:",inline"`
+}
+
+func (res viewTableRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewTableRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewTableRes) Empty() bool {
+	return false
+}
+
+type listRulesRes struct {
+	re.RulesPage `json:",inline"`
+}
+
+func (res listRulesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listRulesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listRulesRes) Empty() bool {
+	return false
+}
+
+type viewRuleRes struct {
+	re.Rule `json:",inline"`
+	created bool
+}
+
+func (res viewRuleRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res viewRuleRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{}
+	}
+
+	return map[string]string{etagHeader: etag(res.Revision())}
+}
+
+func (res viewRuleRes) Empty() bool {
+	return false
+}
+
+type viewRulesRes struct {
+	re.RulesBatch `json:",inline"`
+}
+
+func (res viewRulesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewRulesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewRulesRes) Empty() bool {
+	return false
+}
+
+type ruleStatusRes struct {
+	re.RuleStatus `json:",inline"`
+}
+
+func (res ruleStatusRes) Code() int {
+	return http.StatusOK
+}
+
+func (res ruleStatusRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res ruleStatusRes) Empty() bool {
+	return false
+}
+
+type ruleTopologyRes struct {
+	re.RuleTopology `json:",inline"`
+}
+
+func (res ruleTopologyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res ruleTopologyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res ruleTopologyRes) Empty() bool {
+	return false
+}
+
+type ruleLogsRes struct {
+	Logs []re.LogEntry `json:"logs"`
+}
+
+func (res ruleLogsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res ruleLogsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res ruleLogsRes) Empty() bool {
+	return false
+}
+
+type validateRuleRes struct {
+	re.RuleValidation `json:",inline"`
+}
+
+func (res validateRuleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res validateRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res validateRuleRes) Empty() bool {
+	return false
+}
+
+type trialRes struct {
+	re.TrialResult `json:",inline"`
+}
+
+func (res trialRes) Code() int {
+	return http.StatusOK
+}
+
+func (res trialRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res trialRes) Empty() bool {
+	return false
+}
+
+type replayRes struct {
+	re.ReplayResult `json:",inline"`
+}
+
+func (res replayRes) Code() int {
+	return http.StatusOK
+}
+
+func (res replayRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res replayRes) Empty() bool {
+	return false
+}
+
+type driftRes struct {
+	re.DriftReport `json:",inline"`
+}
+
+func (res driftRes) Code() int {
+	return http.StatusOK
+}
+
+func (res driftRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res driftRes) Empty() bool {
+	return false
+}
+
+type orphansRes struct {
+	re.OrphanReport `json:",inline"`
+}
+
+func (res orphansRes) Code() int {
+	return http.StatusOK
+}
+
+func (res orphansRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res orphansRes) Empty() bool {
+	return false
+}
+
+type auditPageRes struct {
+	re.AuditPage `json:",inline"`
+}
+
+func (res auditPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res auditPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res auditPageRes) Empty() bool {
+	return false
+}
+
+type restoreRes struct {
+	re.RestoreReport `json:",inline"`
+}
+
+func (res restoreRes) Code() int {
+	return http.StatusOK
+}
+
+func (res restoreRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res restoreRes) Empty() bool {
+	return false
+}
+
+type rulesetRes struct {
+	re.Ruleset `json:",inline"`
+}
+
+func (res rulesetRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rulesetRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rulesetRes) Empty() bool {
+	return false
+}
+
+type importRes struct {
+	re.ImportReport `json:",inline"`
+}
+
+func (res importRes) Code() int {
+	return http.StatusOK
+}
+
+func (res importRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res importRes) Empty() bool {
+	return false
+}
+
+type bulkRes struct {
+	re.BulkReport `json:",inline"`
+}
+
+func (res bulkRes) Code() int {
+	return http.StatusOK
+}
+
+func (res bulkRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res bulkRes) Empty() bool {
+	return false
+}
+
+type allStreamsRes struct {
+	re.AllStreams `json:",inline"`
+}
+
+func (res allStreamsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res allStreamsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res allStreamsRes) Empty() bool {
+	return false
+}
+
+type allRulesRes struct {
+	re.AllRules `json:",inline"`
+}
+
+func (res allRulesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res allRulesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res allRulesRes) Empty() bool {
+	return false
+}
+
+type quotaRes struct {
+	re.UserQuota `json:",inline"`
+}
+
+func (res quotaRes) Code() int {
+	return http.StatusOK
+}
+
+func (res quotaRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res quotaRes) Empty() bool {
+	return false
+}
+
+type removeQuotaRes struct{}
+
+func (res removeQuotaRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeQuotaRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeQuotaRes) Empty() bool {
+	return true
+}
+
+type alertPolicyRes struct {
+	re.AlertPolicy `json:",inline"`
+}
+
+func (res alertPolicyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res alertPolicyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res alertPolicyRes) Empty() bool {
+	return false
+}
+
+type removeAlertPolicyRes struct{}
+
+func (res removeAlertPolicyRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeAlertPolicyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeAlertPolicyRes) Empty() bool {
+	return true
+}
+
+type listInstancesRes struct {
+	Instances []re.Instance `json:"instances"`
+}
+
+func (res listInstancesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listInstancesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listInstancesRes) Empty() bool {
+	return false
+}
+
+type assignmentRes struct {
+	re.Assignment `json:",inline"`
+}
+
+func (res assignmentRes) Code() int {
+	return http.StatusOK
+}
+
+func (res assignmentRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res assignmentRes) Empty() bool {
+	return false
+}
+
+type gatewayRes struct {
+	re.Gateway `json:",inline"`
+}
+
+func (res gatewayRes) Code() int {
+	return http.StatusOK
+}
+
+func (res gatewayRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res gatewayRes) Empty() bool {
+	return false
+}
+
+// webhookRes is the created webhook, the only response containing its
+// secret.
+type webhookRes struct {
+	re.Webhook `json:",inline"`
+}
+
+func (res webhookRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res webhookRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res webhookRes) Empty() bool {
+	return false
+}
+
+type listWebhooksRes struct {
+	Webhooks []re.Webhook `json:"webhooks"`
+}
+
+func (res listWebhooksRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listWebhooksRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listWebhooksRes) Empty() bool {
+	return false
+}
+
+type removeWebhookRes struct{}
+
+func (res removeWebhookRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeWebhookRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeWebhookRes) Empty() bool {
+	return true
+}
+
+type folderRes struct {
+	re.Folder `json:",inline"`
+}
+
+func (res folderRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res folderRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res folderRes) Empty() bool {
+	return false
+}
+
+type listFoldersRes struct {
+	Folders []re.Folder `json:"folders"`
+}
+
+func (res listFoldersRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listFoldersRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listFoldersRes) Empty() bool {
+	return false
+}
+
+type removeFolderRes struct{}
+
+func (res removeFolderRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeFolderRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeFolderRes) Empty() bool {
+	return true
+}
+
+type maintenanceRes struct {
+	re.Maintenance `json:",inline"`
+}
+
+func (res maintenanceRes) Code() int {
+	return http.StatusOK
+}
+
+func (res maintenanceRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res maintenanceRes) Empty() bool {
+	return false
+}
+
+type chainRes struct {
+	re.Chain `json:",inline"`
+}
+
+func (res chainRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res chainRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res chainRes) Empty() bool {
+	return false
+}
+
+type listChainsRes struct {
+	Chains []re.Chain `json:"chains"`
+}
+
+func (res listChainsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listChainsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listChainsRes) Empty() bool {
+	return false
+}
+
+type removeChainRes struct{}
+
+func (res removeChainRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeChainRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeChainRes) Empty() bool {
+	return true
+}
+
+type secretRes struct {
+	re.Secret `json:",inline"`
+}
+
+func (res secretRes) Code() int {
+	return http.StatusOK
+}
+
+func (res secretRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res secretRes) Empty() bool {
+	return false
+}
+
+type listSecretsRes struct {
+	Secrets []re.Secret `json:"secrets"`
+}
+
+func (res listSecretsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listSecretsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listSecretsRes) Empty() bool {
+	return false
+}
+
+type removeSecretRes struct{}
+
+func (res removeSecretRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeSecretRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeSecretRes) Empty() bool {
+	return true
+}
+
+type listGatewaysRes struct {
+	Gateways []re.Gateway `json:"gateways"`
+}
+
+func (res listGatewaysRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listGatewaysRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listGatewaysRes) Empty() bool {
+	return false
+}
+
+type removeGatewayRes struct{}
+
+func (res removeGatewayRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeGatewayRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeGatewayRes) Empty() bool {
+	return true
+}
+
+// deploymentRes is accepted, since the gateway runs or removes the rule
+// once it receives the command.
+type deploymentRes struct {
+	re.Deployment `json:",inline"`
+}
+
+func (res deploymentRes) Code() int {
+	return http.StatusAccepted
+}
+
+func (res deploymentRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deploymentRes) Empty() bool {
+	return false
+}
+
+type listDeploymentsRes struct {
+	Deployments []re.Deployment `json:"deployments"`
+}
+
+func (res listDeploymentsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listDeploymentsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listDeploymentsRes) Empty() bool {
+	return false
+}
+
+// rolloutRes is accepted when the rule is deployed to the fleet, since the
+// gateways run the rule once they receive the command.
+type rolloutRes struct {
+	re.Rollout `json:",inline"`
+	accepted   bool
+}
+
+func (res rolloutRes) Code() int {
+	if res.accepted {
+		return http.StatusAccepted
+	}
+
+	return http.StatusOK
+}
+
+func (res rolloutRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rolloutRes) Empty() bool {
+	return false
+}
+
+type engineStatsRes struct {
+	re.EngineStats `json:",inline"`
+}
+
+func (res engineStatsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res engineStatsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res engineStatsRes) Empty() bool {
+	return false
+}
+
+type shareRes struct {
+	re.Share `json:",inline"`
+}
+
+func (res shareRes) Code() int {
+	return http.StatusOK
+}
+
+func (res shareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res shareRes) Empty() bool {
+	return false
+}
+
+type listSharesRes struct {
+	Shares []re.Share `json:"shares"`
+}
+
+func (res listSharesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listSharesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listSharesRes) Empty() bool {
+	return false
+}
+
+type unshareRes struct{}
+
+func (res unshareRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unshareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unshareRes) Empty() bool {
+	return true
+}
+
+type templateRes struct {
+	re.Template `json:",inline"`
+	created     bool
+}
+
+func (res templateRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res templateRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res templateRes) Empty() bool {
+	return false
+}
+
+type listTemplatesRes struct {
+	Templates []re.Template `json:"templates"`
+}
+
+func (res listTemplatesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listTemplatesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listTemplatesRes) Empty() bool {
+	return false
+}
+
+type removeTemplateRes struct{}
+
+func (res removeTemplateRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeTemplateRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeTemplateRes) Empty() bool {
+	return true
+}
+
+type pushTailRes struct{}
+
+func (res pushTailRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res pushTailRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res pushTailRes) Empty() bool {
+	return true
+}
+
+type ingestRes struct{}
+
+func (res ingestRes) Code() int {
+	return http.StatusAccepted
+}
+
+func (res ingestRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res ingestRes) Empty() bool {
+	return true
+}
+
+type listPluginsRes struct {
+	Plugins []string `json:"plugins"`
+}
+
+func (res listPluginsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listPluginsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listPluginsRes) Empty() bool {
+	return false
+}
+
+type listExternalServicesRes struct {
+	Services []string `json:"services"`
+}
+
+func (res listExternalServicesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listExternalServicesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listExternalServicesRes) Empty() bool {
+	return false
+}
+
+type listExternalFunctionsRes struct {
+	Functions []re.ExternalFunction `json:"functions"`
+}
+
+func (res listExternalFunctionsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listExternalFunctionsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listExternalFunctionsRes) Empty() bool {
+	return false
+}
+
+type listConfKeysRes struct {
+	ConfKeys []string `json:"conf_keys"`
+}
+
+func (res listConfKeysRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listConfKeysRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listConfKeysRes) Empty() bool {
+	return false
+}
@@ -0,0 +1,1922 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/internal/api"
+	"github.com/absmach/magistrala/internal/apiutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/re"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	nameKey     = "name"
+	idKey       = "id"
+	dryRunKey   = "dry_run"
+	conflictKey = "conflict"
+	statusPass  = "pass"
+	statusFail  = "fail"
+	sessionKey  = "session"
+	kindKey     = "kind"
+	granteeKey  = "grantee"
+	ownerKey    = "owner"
+	labelsKey   = "labels"
+	streamKey   = "stream"
+	channelKey  = "channel"
+	cascadeKey  = "cascade"
+	minAgeKey   = "min_age"
+	deletedKey  = "deleted"
+	userKey     = "user"
+	entityKey   = "entity"
+	fromKey     = "from"
+	toKey       = "to"
+	sinceKey    = "since"
+	gatewayKey  = "gateway"
+	folderKey   = "folder"
+	// tailProtocol is the WebSocket subprotocol of the rule tails, and
+	// tokenProtocol prefixes the token the browsers, which can't set the
+	// headers of WebSocket requests, offer as another subprotocol. The
+	// token protocol is never selected, so the token isn't sent back.
+	tailProtocol  = "tail"
+	tokenProtocol = "bearer."
+	// maxRequestIDSize limits the request IDs the clients send, which are
+	// written to the logs.
+	maxRequestIDSize = 128
+	// ifMatchHeader carries the entity revision the updates and the
+	// deletions expect, and etagHeader the revision of the viewed entity.
+	ifMatchHeader = "If-Match"
+	etagHeader    = "ETag"
+)
+
+// closeErrorCode is added to the HTTP status of the error the WebSocket is
+// closed with, maxCloseReasonSize limits the close reason to the size the
+// control frames allow and closeTimeout limits sending the close message.
+const (
+	closeErrorCode     = 4000
+	maxCloseReasonSize = 123
+	closeTimeout       = time.Second
+)
+
+// keepAliveInterval is how often the comment lines are sent while there
+// are no rule events, so the proxies don't close the idle streams.
+const keepAliveInterval = 15 * time.Second
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc re.Service, cfg re.Config, logger *slog.Logger, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+	// Updates and deletions expect the entity revision of the If-Match
+	// header.
+	revOpts := append([]kithttp.ServerOption{kithttp.ServerBefore(readRevision)}, opts...)
+	// WebSockets aren't subject to the same-origin policy, so the origin of
+	// the tail requests is checked here.
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{tailProtocol},
+		CheckOrigin:  checkOrigin(cfg.Tail.Origins),
+	}
+
+	mux := chi.NewRouter()
+	mux.Use(requestID)
+
+	mux.Get("/info", otelhttp.NewHandler(kithttp.NewServer(
+		infoEndpoint(svc),
+		decodeNoop,
+		api.EncodeResponse,
+		opts...,
+	), "info").ServeHTTP)
+
+	mux.Get("/ready", otelhttp.NewHandler(kithttp.NewServer(
+		readyEndpoint(svc),
+		decodeNoop,
+		api.EncodeResponse,
+		opts...,
+	), "ready").ServeHTTP)
+
+	mux.Route("/streams", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createStreamEndpoint(svc),
+			decodeCreateStream,
+			api.EncodeResponse,
+			opts...,
+		), "create_stream").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listStreamsEndpoint(svc),
+			decodeList,
+			api.EncodeResponse,
+			opts...,
+		), "list_streams").ServeHTTP)
+		r.Post("/batch", otelhttp.NewHandler(kithttp.NewServer(
+			viewStreamsEndpoint(svc),
+			decodeViewStreams,
+			api.EncodeResponse,
+			opts...,
+		), "view_streams").ServeHTTP)
+		r.Route("/{name}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewStreamEndpoint(svc),
+				decodeView(nameKey),
+				api.EncodeResponse,
+				opts...,
+			), "view_stream").ServeHTTP)
+			r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+				createStreamEndpoint(svc),
+				decodeUpdateStream,
+				api.EncodeResponse,
+				revOpts...,
+			), "update_stream").ServeHTTP)
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				deleteStreamEndpoint(svc),
+				decodeDeleteStream,
+				api.EncodeResponse,
+				revOpts...,
+			), "delete_stream").ServeHTTP)
+			r.Post("/rename", otelhttp.NewHandler(kithttp.NewServer(
+				renameEndpoint(svc),
+				decodeRename(re.StreamKind, nameKey),
+				api.EncodeResponse,
+				opts...,
+			), "rename_stream").ServeHTTP)
+			sharesRoutes(r, svc, re.StreamKind, nameKey, "stream", opts)
+		})
+	})
+
+	mux.Route("/tables", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createTableEndpoint(svc),
+			decodeCreateTable,
+			api.EncodeResponse,
+			opts...,
+		), "create_table").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listTablesEndpoint(svc),
+			decodeList,
+			api.EncodeResponse,
+			opts...,
+		), "list_tables").ServeHTTP)
+		r.Route("/{name}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewTableEndpoint(svc),
+				decodeView(nameKey),
+				api.EncodeResponse,
+				opts...,
+			), "view_table").ServeHTTP)
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				deleteTableEndpoint(svc),
+				decodeView(nameKey),
+				api.EncodeResponse,
+				opts...,
+			), "delete_table").ServeHTTP)
+		})
+	})
+
+	mux.Route("/rules", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createRuleEndpoint(svc),
+			decodeCreateRule,
+			api.EncodeResponse,
+			opts...,
+		), "create_rule").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listRulesEndpoint(svc),
+			decodeList,
+			api.EncodeResponse,
+			opts...,
+		), "list_rules").ServeHTTP)
+		r.Post("/batch", otelhttp.NewHandler(kithttp.NewServer(
+			viewRulesEndpoint(svc),
+			decodeViewRules,
+			api.EncodeResponse,
+			opts...,
+		), "view_rules").ServeHTTP)
+		r.Post("/move", otelhttp.NewHandler(kithttp.NewServer(
+			moveRulesEndpoint(svc),
+			decodeMoveRules,
+			api.EncodeResponse,
+			opts...,
+		), "move_rules").ServeHTTP)
+		r.Post("/validate", otelhttp.NewHandler(kithttp.NewServer(
+			validateRuleEndpoint(svc),
+			decodeValidateRule,
+			api.EncodeResponse,
+			opts...,
+		), "validate_rule").ServeHTTP)
+		r.Post("/test", otelhttp.NewHandler(kithttp.NewServer(
+			testRuleEndpoint(svc),
+			decodeTestRule,
+			api.EncodeResponse,
+			opts...,
+		), "test_rule").ServeHTTP)
+		r.Get("/events", otelhttp.NewHandler(watchRulesHandler(svc, logger), "watch_rules").ServeHTTP)
+		r.Route("/{id}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewRuleEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				opts...,
+			), "view_rule").ServeHTTP)
+			r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+				updateRuleEndpoint(svc),
+				decodeUpdateRule,
+				api.EncodeResponse,
+				revOpts...,
+			), "update_rule").ServeHTTP)
+			r.Patch("/", otelhttp.NewHandler(kithttp.NewServer(
+				patchRuleEndpoint(svc),
+				decodePatchRule,
+				api.EncodeResponse,
+				revOpts...,
+			), "patch_rule").ServeHTTP)
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				deleteRuleEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				revOpts...,
+			), "delete_rule").ServeHTTP)
+			r.Get("/status", otelhttp.NewHandler(kithttp.NewServer(
+				ruleStatusEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				opts...,
+			), "rule_status").ServeHTTP)
+			r.Get("/topology", otelhttp.NewHandler(kithttp.NewServer(
+				ruleTopologyEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				opts...,
+			), "rule_topology").ServeHTTP)
+			r.Get("/logs", otelhttp.NewHandler(kithttp.NewServer(
+				ruleLogsEndpoint(svc),
+				decodeRuleLogs,
+				api.EncodeResponse,
+				opts...,
+			), "rule_logs").ServeHTTP)
+			r.Post("/start", otelhttp.NewHandler(kithttp.NewServer(
+				startRuleEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				opts...,
+			), "start_rule").ServeHTTP)
+			r.Post("/stop", otelhttp.NewHandler(kithttp.NewServer(
+				stopRuleEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				opts...,
+			), "stop_rule").ServeHTTP)
+			r.Post("/restart", otelhttp.NewHandler(kithttp.NewServer(
+				restartRuleEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				opts...,
+			), "restart_rule").ServeHTTP)
+			r.Put("/draft", otelhttp.NewHandler(kithttp.NewServer(
+				saveDraftEndpoint(svc),
+				decodeUpdateRule,
+				api.EncodeResponse,
+				opts...,
+			), "save_draft").ServeHTTP)
+			r.Post("/publish", otelhttp.NewHandler(kithttp.NewServer(
+				publishRuleEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				opts...,
+			), "publish_rule").ServeHTTP)
+			r.Post("/unpublish", otelhttp.NewHandler(kithttp.NewServer(
+				unpublishRuleEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				opts...,
+			), "unpublish_rule").ServeHTTP)
+			r.Post("/restore", otelhttp.NewHandler(kithttp.NewServer(
+				restoreRuleEndpoint(svc),
+				decodeView(idKey),
+				api.EncodeResponse,
+				opts...,
+			), "restore_rule").ServeHTTP)
+			r.Post("/replay", otelhttp.NewHandler(kithttp.NewServer(
+				replayRuleEndpoint(svc),
+				decodeReplayRule,
+				api.EncodeResponse,
+				opts...,
+			), "replay_rule").ServeHTTP)
+			r.Get("/tail", otelhttp.NewHandler(tailRuleHandler(svc, upgrader, logger), "tail_rule").ServeHTTP)
+			r.Post("/rename", otelhttp.NewHandler(kithttp.NewServer(
+				renameEndpoint(svc),
+				decodeRename(re.RuleKind, idKey),
+				api.EncodeResponse,
+				opts...,
+			), "rename_rule").ServeHTTP)
+			r.Post("/clone", otelhttp.NewHandler(kithttp.NewServer(
+				cloneRuleEndpoint(svc),
+				decodeCloneRule,
+				api.EncodeResponse,
+				opts...,
+			), "clone_rule").ServeHTTP)
+			sharesRoutes(r, svc, re.RuleKind, idKey, "rule", opts)
+			r.Route("/deployments", func(r chi.Router) {
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listDeploymentsEndpoint(svc),
+					decodeView(idKey),
+					api.EncodeResponse,
+					opts...,
+				), "list_deployments").ServeHTTP)
+				r.Put("/{gateway}", otelhttp.NewHandler(kithttp.NewServer(
+					deployRuleEndpoint(svc),
+					decodeDeploy,
+					api.EncodeResponse,
+					opts...,
+				), "deploy_rule").ServeHTTP)
+				r.Delete("/{gateway}", otelhttp.NewHandler(kithttp.NewServer(
+					undeployRuleEndpoint(svc),
+					decodeDeploy,
+					api.EncodeResponse,
+					opts...,
+				), "undeploy_rule").ServeHTTP)
+			})
+			r.Route("/rollout", func(r chi.Router) {
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					viewRolloutEndpoint(svc),
+					decodeView(idKey),
+					api.EncodeResponse,
+					opts...,
+				), "view_rollout").ServeHTTP)
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					deployFleetEndpoint(svc),
+					decodeDeployFleet,
+					api.EncodeResponse,
+					opts...,
+				), "deploy_fleet").ServeHTTP)
+				r.Post("/retry", otelhttp.NewHandler(kithttp.NewServer(
+					retryDeploymentsEndpoint(svc),
+					decodeView(idKey),
+					api.EncodeResponse,
+					opts...,
+				), "retry_deployments").ServeHTTP)
+			})
+		})
+	})
+
+	mux.Route("/drift", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			reconcileEndpoint(svc),
+			decodeReconcile(false),
+			api.EncodeResponse,
+			opts...,
+		), "view_drift").ServeHTTP)
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			reconcileEndpoint(svc),
+			decodeReconcile(true),
+			api.EncodeResponse,
+			opts...,
+		), "repair_drift").ServeHTTP)
+	})
+
+	mux.Route("/orphans", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			collectOrphansEndpoint(svc),
+			decodeCollectOrphans(false),
+			api.EncodeResponse,
+			opts...,
+		), "view_orphans").ServeHTTP)
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			collectOrphansEndpoint(svc),
+			decodeCollectOrphans(true),
+			api.EncodeResponse,
+			opts...,
+		), "remove_orphans").ServeHTTP)
+	})
+
+	mux.Get("/search", otelhttp.NewHandler(kithttp.NewServer(
+		searchRulesEndpoint(svc),
+		decodeSearchRules,
+		api.EncodeResponse,
+		opts...,
+	), "search_rules").ServeHTTP)
+
+	mux.Get("/audit", otelhttp.NewHandler(kithttp.NewServer(
+		listAuditEventsEndpoint(svc),
+		decodeListAuditEvents,
+		api.EncodeResponse,
+		opts...,
+	), "list_audit_events").ServeHTTP)
+
+	mux.Get("/stats", otelhttp.NewHandler(kithttp.NewServer(
+		engineStatsEndpoint(svc),
+		decodeListAll,
+		api.EncodeResponse,
+		opts...,
+	), "engine_stats").ServeHTTP)
+
+	mux.Post("/restore", otelhttp.NewHandler(kithttp.NewServer(
+		restoreEndpoint(svc),
+		decodeRestore,
+		api.EncodeResponse,
+		opts...,
+	), "restore").ServeHTTP)
+
+	mux.Route("/ruleset", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			exportRulesetEndpoint(svc),
+			decodeExportRuleset,
+			api.EncodeResponse,
+			opts...,
+		), "export_ruleset").ServeHTTP)
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			importRulesetEndpoint(svc),
+			decodeImportRuleset,
+			api.EncodeResponse,
+			opts...,
+		), "import_ruleset").ServeHTTP)
+	})
+
+	mux.Route("/bulk", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			bulkCreateEndpoint(svc),
+			decodeBulkCreate,
+			api.EncodeResponse,
+			opts...,
+		), "bulk_create").ServeHTTP)
+		r.Post("/delete", otelhttp.NewHandler(kithttp.NewServer(
+			bulkDeleteEndpoint(svc),
+			decodeBulkDelete,
+			api.EncodeResponse,
+			opts...,
+		), "bulk_delete").ServeHTTP)
+		r.Post("/start", otelhttp.NewHandler(kithttp.NewServer(
+			startRulesEndpoint(svc),
+			decodeRuleSelection,
+			api.EncodeResponse,
+			opts...,
+		), "start_rules").ServeHTTP)
+		r.Post("/stop", otelhttp.NewHandler(kithttp.NewServer(
+			stopRulesEndpoint(svc),
+			decodeRuleSelection,
+			api.EncodeResponse,
+			opts...,
+		), "stop_rules").ServeHTTP)
+	})
+
+	mux.Route("/all", func(r chi.Router) {
+		r.Get("/streams", otelhttp.NewHandler(kithttp.NewServer(
+			listAllStreamsEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "list_all_streams").ServeHTTP)
+		r.Get("/rules", otelhttp.NewHandler(kithttp.NewServer(
+			listAllRulesEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "list_all_rules").ServeHTTP)
+	})
+
+	mux.Route("/alerts", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			viewAlertPolicyEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "view_alert_policy").ServeHTTP)
+		r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+			setAlertPolicyEndpoint(svc),
+			decodeSetAlertPolicy,
+			api.EncodeResponse,
+			opts...,
+		), "set_alert_policy").ServeHTTP)
+		r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+			removeAlertPolicyEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "remove_alert_policy").ServeHTTP)
+	})
+
+	mux.Route("/webhooks", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createWebhookEndpoint(svc),
+			decodeCreateWebhook,
+			api.EncodeResponse,
+			opts...,
+		), "create_webhook").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listWebhooksEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "list_webhooks").ServeHTTP)
+		r.Delete("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+			removeWebhookEndpoint(svc),
+			decodeView(idKey),
+			api.EncodeResponse,
+			opts...,
+		), "remove_webhook").ServeHTTP)
+	})
+
+	mux.Route("/folders", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createFolderEndpoint(svc),
+			decodeCreateFolder,
+			api.EncodeResponse,
+			opts...,
+		), "create_folder").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listFoldersEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "list_folders").ServeHTTP)
+		r.Delete("/{name}", otelhttp.NewHandler(kithttp.NewServer(
+			removeFolderEndpoint(svc),
+			decodeView(nameKey),
+			api.EncodeResponse,
+			opts...,
+		), "remove_folder").ServeHTTP)
+	})
+
+	mux.Route("/maintenance", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			pauseEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "pause").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			viewMaintenanceEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "view_maintenance").ServeHTTP)
+		r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+			resumeEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "resume").ServeHTTP)
+	})
+
+	mux.Route("/chains", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createChainEndpoint(svc),
+			decodeCreateChain,
+			api.EncodeResponse,
+			opts...,
+		), "create_chain").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listChainsEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "list_chains").ServeHTTP)
+		r.Delete("/{name}", otelhttp.NewHandler(kithttp.NewServer(
+			removeChainEndpoint(svc),
+			decodeView(nameKey),
+			api.EncodeResponse,
+			opts...,
+		), "remove_chain").ServeHTTP)
+	})
+
+	mux.Route("/secrets", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listSecretsEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "list_secrets").ServeHTTP)
+		r.Put("/{name}", otelhttp.NewHandler(kithttp.NewServer(
+			saveSecretEndpoint(svc),
+			decodeSaveSecret,
+			api.EncodeResponse,
+			opts...,
+		), "save_secret").ServeHTTP)
+		r.Delete("/{name}", otelhttp.NewHandler(kithttp.NewServer(
+			removeSecretEndpoint(svc),
+			decodeView(nameKey),
+			api.EncodeResponse,
+			opts...,
+		), "remove_secret").ServeHTTP)
+	})
+
+	mux.Route("/quotas/{id}", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			viewQuotaEndpoint(svc),
+			decodeView(idKey),
+			api.EncodeResponse,
+			opts...,
+		), "view_quota").ServeHTTP)
+		r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+			setQuotaEndpoint(svc),
+			decodeSetQuota,
+			api.EncodeResponse,
+			opts...,
+		), "set_quota").ServeHTTP)
+		r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+			removeQuotaEndpoint(svc),
+			decodeView(idKey),
+			api.EncodeResponse,
+			opts...,
+		), "remove_quota").ServeHTTP)
+	})
+
+	mux.Route("/instances", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listInstancesEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "list_instances").ServeHTTP)
+		r.Put("/assignments/{id}", otelhttp.NewHandler(kithttp.NewServer(
+			assignInstanceEndpoint(svc),
+			decodeAssignInstance,
+			api.EncodeResponse,
+			opts...,
+		), "assign_instance").ServeHTTP)
+		r.Delete("/assignments/{id}", otelhttp.NewHandler(kithttp.NewServer(
+			assignInstanceEndpoint(svc),
+			decodeUnassignInstance,
+			api.EncodeResponse,
+			opts...,
+		), "unassign_instance").ServeHTTP)
+	})
+
+	mux.Route("/gateways", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listGatewaysEndpoint(svc),
+			decodeListAll,
+			api.EncodeResponse,
+			opts...,
+		), "list_gateways").ServeHTTP)
+		r.Put("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+			saveGatewayEndpoint(svc),
+			decodeSaveGateway,
+			api.EncodeResponse,
+			opts...,
+		), "save_gateway").ServeHTTP)
+		r.Delete("/{id}", otelhttp.NewHandler(kithttp.NewServer(
+			removeGatewayEndpoint(svc),
+			decodeView(idKey),
+			api.EncodeResponse,
+			opts...,
+		), "remove_gateway").ServeHTTP)
+	})
+
+	mux.Route("/templates", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createTemplateEndpoint(svc),
+			decodeCreateTemplate,
+			api.EncodeResponse,
+			opts...,
+		), "create_template").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listTemplatesEndpoint(svc),
+			decodeListTemplates,
+			api.EncodeResponse,
+			opts...,
+		), "list_templates").ServeHTTP)
+		r.Route("/{name}", func(r chi.Router) {
+			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+				viewTemplateEndpoint(svc),
+				decodeView(nameKey),
+				api.EncodeResponse,
+				opts...,
+			), "view_template").ServeHTTP)
+			r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+				removeTemplateEndpoint(svc),
+				decodeView(nameKey),
+				api.EncodeResponse,
+				opts...,
+			), "remove_template").ServeHTTP)
+			r.Post("/rules", otelhttp.NewHandler(kithttp.NewServer(
+				instantiateTemplateEndpoint(svc),
+				decodeInstantiateTemplate,
+				api.EncodeResponse,
+				opts...,
+			), "instantiate_template").ServeHTTP)
+		})
+	})
+
+	mux.Route("/plugins/{kind}", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createPluginEndpoint(svc),
+			decodeCreatePlugin,
+			api.EncodeResponse,
+			opts...,
+		), "create_plugin").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listPluginsEndpoint(svc),
+			decodeListPlugins,
+			api.EncodeResponse,
+			opts...,
+		), "list_plugins").ServeHTTP)
+		r.Delete("/{name}", otelhttp.NewHandler(kithttp.NewServer(
+			deletePluginEndpoint(svc),
+			decodeDeletePlugin,
+			api.EncodeResponse,
+			opts...,
+		), "delete_plugin").ServeHTTP)
+	})
+
+	mux.Route("/services", func(r chi.Router) {
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			registerExternalServiceEndpoint(svc),
+			decodeRegisterExternalService,
+			api.EncodeResponse,
+			opts...,
+		), "register_external_service").ServeHTTP)
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listExternalServicesEndpoint(svc),
+			decodeListExternalServices,
+			api.EncodeResponse,
+			opts...,
+		), "list_external_services").ServeHTTP)
+		r.Delete("/{name}", otelhttp.NewHandler(kithttp.NewServer(
+			deleteExternalServiceEndpoint(svc),
+			decodeDeleteExternalService,
+			api.EncodeResponse,
+			opts...,
+		), "delete_external_service").ServeHTTP)
+	})
+
+	mux.Route("/confkeys", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listConfKeysEndpoint(svc),
+			decodeListConfKeys,
+			api.EncodeResponse,
+			opts...,
+		), "list_conf_keys").ServeHTTP)
+		r.Put("/{name}", otelhttp.NewHandler(kithttp.NewServer(
+			saveConfKeyEndpoint(svc),
+			decodeSaveConfKey,
+			api.EncodeResponse,
+			opts...,
+		), "save_conf_key").ServeHTTP)
+		r.Delete("/{name}", otelhttp.NewHandler(kithttp.NewServer(
+			deleteConfKeyEndpoint(svc),
+			decodeDeleteConfKey,
+			api.EncodeResponse,
+			opts...,
+		), "delete_conf_key").ServeHTTP)
+	})
+
+	mux.Get("/functions", otelhttp.NewHandler(kithttp.NewServer(
+		listExternalFunctionsEndpoint(svc),
+		decodeListExternalFunctions,
+		api.EncodeResponse,
+		opts...,
+	), "list_external_functions").ServeHTTP)
+
+	mux.Post("/tail/{session}", otelhttp.NewHandler(kithttp.NewServer(
+		pushTailEndpoint(svc),
+		decodePushTail,
+		api.EncodeResponse,
+		opts...,
+	), "push_tail").ServeHTTP)
+
+	mux.With(maxBytes(cfg.Push.MaxSize)).Post("/ingest/{name}", otelhttp.NewHandler(kithttp.NewServer(
+		ingestEndpoint(svc),
+		decodeIngest,
+		api.EncodeResponse,
+		opts...,
+	), "ingest").ServeHTTP)
+
+	mux.Get("/health", magistrala.Health("re", instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+// maxBytes limits the request body to one byte over the maximum size, so
+// the bodies exceeding it are cut short instead of being read whole, while
+// the service still tells the ones just over the size from the ones that
+// fit.
+func maxBytes(size int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, int64(size)+1)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestID passes the request ID the client sent, or a new one if it sent
+// none, to the service and returns it in the response header.
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(re.RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDSize {
+			id = re.NewRequestID()
+		}
+		w.Header().Set(re.RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(re.WithRequestID(r.Context(), id)))
+	})
+}
+
+// readRevision passes the entity revision of the If-Match header, if any,
+// to the service.
+func readRevision(ctx context.Context, r *http.Request) context.Context {
+	rev := strings.TrimPrefix(strings.TrimSpace(r.Header.Get(ifMatchHeader)), "W/")
+	if rev == "" {
+		return ctx
+	}
+
+	return re.WithRevision(ctx, strings.Trim(rev, `"`))
+}
+
+// etag returns the entity tag of the entity revision.
+func etag(rev string) string {
+	return `"` + rev + `"`
+}
+
+func decodeNoop(_ context.Context, _ *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeCreateStream(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := streamReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeCreateTable(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := tableReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeUpdateStream(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeCreateStream(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	sr := req.(streamReq)
+	sr.Name = chi.URLParam(r, nameKey)
+	sr.update = true
+
+	return sr, nil
+}
+
+func decodeCreateRule(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := ruleReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Rule); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeUpdateRule(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeCreateRule(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	rr := req.(ruleReq)
+	rr.ID = chi.URLParam(r, idKey)
+
+	return rr, nil
+}
+
+func decodePatchRule(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := patchRuleReq{token: apiutil.ExtractBearerToken(r), id: chi.URLParam(r, idKey)}
+	if err := json.NewDecoder(r.Body).Decode(&req.RulePatch); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeCloneRule(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := cloneRuleReq{token: apiutil.ExtractBearerToken(r), id: chi.URLParam(r, idKey)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeValidateRule(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeCreateRule(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateRuleReq(req.(ruleReq)), nil
+}
+
+func decodeTestRule(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := testRuleReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.RuleTrial); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeReplayRule(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := replayRuleReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, idKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	name, err := apiutil.ReadStringQuery(r, api.NameKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	owner, err := apiutil.ReadStringQuery(r, ownerKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	labels, err := readLabelsQuery(r)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	deleted, err := apiutil.ReadBoolQuery(r, deletedKey, false)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	order, err := apiutil.ReadStringQuery(r, api.OrderKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	dir, err := apiutil.ReadStringQuery(r, api.DirKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	folder, err := apiutil.ReadStringQuery(r, folderKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listReq{
+		token: apiutil.ExtractBearerToken(r),
+		PageMetadata: re.PageMetadata{
+			Offset:  offset,
+			Limit:   limit,
+			Name:    name,
+			Labels:  labels,
+			Owner:   owner,
+			Deleted: deleted,
+			Order:   order,
+			Dir:     dir,
+			Folder:  folder,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeSearchRules(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeList(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := apiutil.ReadStringQuery(r, streamKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	channel, err := apiutil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return searchRulesReq{
+		listReq:   req.(listReq),
+		RuleQuery: re.RuleQuery{Stream: stream, Channel: channel},
+	}, nil
+}
+
+// readLabelsQuery reads the labels filter, which is the JSON object of the
+// label values, e.g. {"env":"prod"}.
+func readLabelsQuery(r *http.Request) (map[string]string, error) {
+	vals := r.URL.Query()[labelsKey]
+	if len(vals) > 1 {
+		return nil, apiutil.ErrInvalidQueryParams
+	}
+	if len(vals) == 0 {
+		return nil, nil
+	}
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(vals[0]), &labels); err != nil {
+		return nil, errors.Wrap(apiutil.ErrInvalidQueryParams, err)
+	}
+
+	return labels, nil
+}
+
+func decodeView(key string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		req := viewReq{
+			token: apiutil.ExtractBearerToken(r),
+			id:    chi.URLParam(r, key),
+		}
+
+		return req, nil
+	}
+}
+
+func decodeDeleteStream(_ context.Context, r *http.Request) (interface{}, error) {
+	cascade, err := apiutil.ReadBoolQuery(r, cascadeKey, false)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := deleteStreamReq{
+		viewReq: viewReq{
+			token: apiutil.ExtractBearerToken(r),
+			id:    chi.URLParam(r, nameKey),
+		},
+		cascade: cascade,
+	}
+
+	return req, nil
+}
+
+func decodeReconcile(repair bool) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		req := reconcileReq{
+			token:  apiutil.ExtractBearerToken(r),
+			repair: repair,
+		}
+
+		return req, nil
+	}
+}
+
+func decodeCollectOrphans(remove bool) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		minAge, err := apiutil.ReadStringQuery(r, minAgeKey, "0s")
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		age, err := time.ParseDuration(minAge)
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(apiutil.ErrInvalidQueryParams, err))
+		}
+
+		req := collectOrphansReq{
+			token:  apiutil.ExtractBearerToken(r),
+			policy: re.OrphanPolicy{Remove: remove, MinAge: age},
+		}
+
+		return req, nil
+	}
+}
+
+func decodeListAuditEvents(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	user, err := apiutil.ReadStringQuery(r, userKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	owner, err := apiutil.ReadStringQuery(r, ownerKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	kind, err := apiutil.ReadStringQuery(r, kindKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	entity, err := apiutil.ReadStringQuery(r, entityKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	from, err := readTimeQuery(r, fromKey)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	to, err := readTimeQuery(r, toKey)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := auditReq{
+		token: apiutil.ExtractBearerToken(r),
+		AuditQuery: re.AuditQuery{
+			Offset: offset,
+			Limit:  limit,
+			User:   user,
+			Owner:  owner,
+			Kind:   kind,
+			Entity: entity,
+			From:   from,
+			To:     to,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeRuleLogs(_ context.Context, r *http.Request) (interface{}, error) {
+	since, err := readTimeQuery(r, sinceKey)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := ruleLogsReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    chi.URLParam(r, idKey),
+		since: since,
+	}
+
+	return req, nil
+}
+
+// readTimeQuery reads the RFC3339 time query parameter, returning the zero
+// time if the parameter is missing.
+func readTimeQuery(r *http.Request, key string) (time.Time, error) {
+	val, err := apiutil.ReadStringQuery(r, key, "")
+	if err != nil || val == "" {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, errors.Wrap(apiutil.ErrInvalidQueryParams, err)
+	}
+
+	return t, nil
+}
+
+func decodeRestore(_ context.Context, r *http.Request) (interface{}, error) {
+	dryRun, err := apiutil.ReadBoolQuery(r, dryRunKey, false)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := restoreReq{
+		token:  apiutil.ExtractBearerToken(r),
+		dryRun: dryRun,
+	}
+
+	return req, nil
+}
+
+func decodeExportRuleset(_ context.Context, r *http.Request) (interface{}, error) {
+	return exportRulesetReq{token: apiutil.ExtractBearerToken(r)}, nil
+}
+
+func decodeImportRuleset(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+	conflict, err := apiutil.ReadStringQuery(r, conflictKey, re.ConflictSkip)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := importRulesetReq{
+		token:    apiutil.ExtractBearerToken(r),
+		conflict: conflict,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.Ruleset); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeViewStreams(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := viewStreamsReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeViewRules(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := viewRulesReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeMoveRules(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := moveRulesReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeBulkCreate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := bulkCreateReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Ruleset); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeBulkDelete(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := bulkDeleteReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.BulkDeletion); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeRuleSelection(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := ruleSelectionReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.RuleSelection); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListAll(_ context.Context, r *http.Request) (interface{}, error) {
+	return listAllReq{token: apiutil.ExtractBearerToken(r)}, nil
+}
+
+func decodeSetQuota(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := quotaReq{token: apiutil.ExtractBearerToken(r), userID: chi.URLParam(r, idKey)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Quota); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeSetAlertPolicy(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := alertPolicyReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.AlertPolicy); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeCreateWebhook(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := webhookReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Webhook); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeCreateFolder(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := folderReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Folder); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeCreateChain(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := chainReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Chain); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeSaveSecret(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := secretReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Secret); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	req.Name = chi.URLParam(r, nameKey)
+
+	return req, nil
+}
+
+func decodeAssignInstance(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := assignInstanceReq{token: apiutil.ExtractBearerToken(r), userID: chi.URLParam(r, idKey)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeUnassignInstance(_ context.Context, r *http.Request) (interface{}, error) {
+	return assignInstanceReq{token: apiutil.ExtractBearerToken(r), userID: chi.URLParam(r, idKey)}, nil
+}
+
+func decodeSaveGateway(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := gatewayReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Gateway); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+	req.ID = chi.URLParam(r, idKey)
+
+	return req, nil
+}
+
+func decodeDeploy(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deployReq{
+		token:   apiutil.ExtractBearerToken(r),
+		id:      chi.URLParam(r, idKey),
+		gateway: chi.URLParam(r, gatewayKey),
+	}
+
+	return req, nil
+}
+
+func decodeDeployFleet(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := deployFleetReq{token: apiutil.ExtractBearerToken(r), id: chi.URLParam(r, idKey)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+// sharesRoutes registers the routes of the shares of the stream or rule
+// identified by the given URL parameter.
+func sharesRoutes(r chi.Router, svc re.Service, kind, key, op string, opts []kithttp.ServerOption) {
+	r.Route("/shares", func(r chi.Router) {
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listSharesEndpoint(svc),
+			decodeListShares(kind, key),
+			api.EncodeResponse,
+			opts...,
+		), "list_"+op+"_shares").ServeHTTP)
+		r.Put("/{grantee}", otelhttp.NewHandler(kithttp.NewServer(
+			shareEntityEndpoint(svc),
+			decodeShare(kind, key),
+			api.EncodeResponse,
+			opts...,
+		), "share_"+op).ServeHTTP)
+		r.Delete("/{grantee}", otelhttp.NewHandler(kithttp.NewServer(
+			unshareEntityEndpoint(svc),
+			decodeUnshare(kind, key),
+			api.EncodeResponse,
+			opts...,
+		), "unshare_"+op).ServeHTTP)
+	})
+}
+
+func decodeShare(kind, key string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+		}
+
+		req := shareReq{token: apiutil.ExtractBearerToken(r), kind: kind, name: chi.URLParam(r, key)}
+		if err := json.NewDecoder(r.Body).Decode(&req.Share); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+		}
+		req.Grantee = chi.URLParam(r, granteeKey)
+
+		return req, nil
+	}
+}
+
+func decodeRename(kind, key string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+		}
+
+		req := renameReq{token: apiutil.ExtractBearerToken(r), kind: kind, name: chi.URLParam(r, key)}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+		}
+
+		return req, nil
+	}
+}
+
+func decodeListShares(kind, key string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		req := listSharesReq{
+			token: apiutil.ExtractBearerToken(r),
+			kind:  kind,
+			name:  chi.URLParam(r, key),
+		}
+
+		return req, nil
+	}
+}
+
+func decodeUnshare(kind, key string) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		req := unshareReq{
+			token:   apiutil.ExtractBearerToken(r),
+			kind:    kind,
+			name:    chi.URLParam(r, key),
+			grantee: chi.URLParam(r, granteeKey),
+		}
+
+		return req, nil
+	}
+}
+
+func decodeCreateTemplate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := templateReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Template); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListTemplates(_ context.Context, r *http.Request) (interface{}, error) {
+	return listTemplatesReq{token: apiutil.ExtractBearerToken(r)}, nil
+}
+
+func decodeInstantiateTemplate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := instantiateReq{
+		token: apiutil.ExtractBearerToken(r),
+		name:  chi.URLParam(r, nameKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.TemplateInstance); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeCreatePlugin(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := pluginReq{
+		token: apiutil.ExtractBearerToken(r),
+		kind:  chi.URLParam(r, kindKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.Plugin); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListPlugins(_ context.Context, r *http.Request) (interface{}, error) {
+	req := listPluginsReq{
+		token: apiutil.ExtractBearerToken(r),
+		kind:  chi.URLParam(r, kindKey),
+	}
+
+	return req, nil
+}
+
+func decodeDeletePlugin(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deletePluginReq{
+		token: apiutil.ExtractBearerToken(r),
+		kind:  chi.URLParam(r, kindKey),
+		name:  chi.URLParam(r, nameKey),
+	}
+
+	return req, nil
+}
+
+func decodeRegisterExternalService(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := externalServiceReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.ExternalService); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListExternalServices(_ context.Context, r *http.Request) (interface{}, error) {
+	return listExternalServicesReq{token: apiutil.ExtractBearerToken(r)}, nil
+}
+
+func decodeDeleteExternalService(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deleteExternalServiceReq{
+		token: apiutil.ExtractBearerToken(r),
+		name:  chi.URLParam(r, nameKey),
+	}
+
+	return req, nil
+}
+
+func decodeListExternalFunctions(_ context.Context, r *http.Request) (interface{}, error) {
+	return listExternalFunctionsReq{token: apiutil.ExtractBearerToken(r)}, nil
+}
+
+func decodeSaveConfKey(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := confKeyReq{
+		token: apiutil.ExtractBearerToken(r),
+		name:  chi.URLParam(r, nameKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.MQTTConf); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeListConfKeys(_ context.Context, r *http.Request) (interface{}, error) {
+	return listConfKeysReq{token: apiutil.ExtractBearerToken(r)}, nil
+}
+
+func decodeDeleteConfKey(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deleteConfKeyReq{
+		token: apiutil.ExtractBearerToken(r),
+		name:  chi.URLParam(r, nameKey),
+	}
+
+	return req, nil
+}
+
+// tailRuleHandler streams the rule results over the WebSocket until the
+// client closes the connection. Failures are returned as the regular API
+// errors, before the connection is upgraded.
+func tailRuleHandler(svc re.Service, upgrader websocket.Upgrader, logger *slog.Logger) http.HandlerFunc {
+	encodeError := apiutil.LoggingErrorEncoder(logger, encodeError)
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := tailRuleReq{
+			token: apiutil.ExtractBearerToken(r),
+			id:    chi.URLParam(r, idKey),
+		}
+		if req.token == "" {
+			req.token = protocolToken(r)
+		}
+		if err := req.validate(); err != nil {
+			encodeError(r.Context(), errors.Wrap(apiutil.ErrValidation, err), w)
+			return
+		}
+
+		// The origin is checked and the connection upgraded before the tail
+		// sink is attached, so the rejected requests never change the rule.
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		results, err := svc.TailRule(ctx, req.token, req.id)
+		if err != nil {
+			closeError(r.Context(), conn, err, encodeError)
+			return
+		}
+
+		// Clients don't send messages, so reading only detects the closed
+		// connection.
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+		for res := range results {
+			if err := conn.WriteJSON(res); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// closeError closes the WebSocket with the API error, since the response
+// has already been sent. The close code is the HTTP status of the error
+// plus 4000, e.g. 4404 for the missing rule, and the reason is the error
+// message.
+func closeError(ctx context.Context, conn *websocket.Conn, err error, encodeError kithttp.ErrorEncoder) {
+	rec := httptest.NewRecorder()
+	encodeError(ctx, err, rec)
+	var res struct {
+		Error string `json:"error"`
+	}
+	reason := http.StatusText(rec.Code)
+	if json.Unmarshal(rec.Body.Bytes(), &res) == nil && res.Error != "" {
+		reason = res.Error
+	}
+	if len(reason) > maxCloseReasonSize {
+		reason = reason[:maxCloseReasonSize]
+	}
+	msg := websocket.FormatCloseMessage(closeErrorCode+rec.Code, reason)
+	_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(closeTimeout))
+}
+
+// protocolToken returns the token the client offered as the WebSocket
+// subprotocol, empty if it offered none.
+func protocolToken(r *http.Request) string {
+	for _, p := range websocket.Subprotocols(r) {
+		if strings.HasPrefix(p, tokenProtocol) {
+			return strings.TrimPrefix(p, tokenProtocol)
+		}
+	}
+
+	return ""
+}
+
+// checkOrigin allows the WebSockets opened by the clients other than the
+// browsers, which send no origin, by the pages of the rules engine itself
+// and by the pages of the allowed origins.
+func checkOrigin(origins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+
+		return slices.Contains(origins, strings.TrimSuffix(origin, "/"))
+	}
+}
+
+// watchRulesHandler streams the state changes of the user's rules as the
+// server-sent events until the client closes the connection. Failures are
+// returned as the regular API errors, before the stream starts.
+func watchRulesHandler(svc re.Service, logger *slog.Logger) http.HandlerFunc {
+	encodeError := apiutil.LoggingErrorEncoder(logger, encodeError)
+	return func(w http.ResponseWriter, r *http.Request) {
+		// The token is only read from the header, so it doesn't end up in
+		// the URLs the proxies log.
+		req := listAllReq{token: apiutil.ExtractBearerToken(r)}
+		if err := req.validate(); err != nil {
+			encodeError(r.Context(), errors.Wrap(apiutil.ErrValidation, err), w)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		changes, err := svc.WatchRules(ctx, req.token)
+		if err != nil {
+			encodeError(r.Context(), err, w)
+			return
+		}
+		rc := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		keepAlive := time.NewTicker(keepAliveInterval)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case c, ok := <-changes:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(c)
+				if err != nil {
+					return
+				}
+				if _, err := fmt.Fprintf(w, "event: state\ndata: %s\n\n", data); err != nil {
+					return
+				}
+			case <-keepAlive.C:
+				if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func decodePushTail(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := pushTailReq{session: chi.URLParam(r, sessionKey)}
+	if err := json.NewDecoder(r.Body).Decode(&req.result); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+// decodeIngest passes the message as it is, since its format is defined
+// by the stream.
+func decodeIngest(_ context.Context, r *http.Request) (interface{}, error) {
+	payload, err := io.ReadAll(r.Body)
+	if _, ok := err.(*http.MaxBytesError); ok {
+		return nil, errors.Wrap(apiutil.ErrValidation, re.ErrMessageTooLarge)
+	}
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	req := ingestReq{
+		key:     apiutil.ExtractThingKey(r),
+		stream:  chi.URLParam(r, nameKey),
+		payload: payload,
+	}
+
+	return req, nil
+}
+
+// encodeError encodes the typed Kuiper, revision, quota, message size and
+// rate limit errors with the failure description as the error, leaving the
+// other errors to the common encoder. Rate limited requests get the
+// Retry-After header in seconds.
+func encodeError(ctx context.Context, err error, w http.ResponseWriter) {
+	var status int
+	var kerr error
+	switch {
+	case errors.Contains(err, re.ErrInvalidSQL):
+		status, kerr = http.StatusBadRequest, re.ErrInvalidSQL
+	case errors.Contains(err, re.ErrRuleNotFound):
+		status, kerr = http.StatusNotFound, re.ErrRuleNotFound
+	case errors.Contains(err, re.ErrStreamInUse):
+		status, kerr = http.StatusConflict, re.ErrStreamInUse
+	case errors.Contains(err, re.ErrStaleRevision):
+		status, kerr = http.StatusConflict, re.ErrStaleRevision
+	case errors.Contains(err, re.ErrRevisionRequired):
+		status, kerr = http.StatusPreconditionRequired, re.ErrRevisionRequired
+	case errors.Contains(err, re.ErrConflict):
+		status, kerr = http.StatusConflict, re.ErrConflict
+	case errors.Contains(err, re.ErrNotSupported):
+		status, kerr = http.StatusNotImplemented, re.ErrNotSupported
+	case errors.Contains(err, re.ErrQuotaExceeded):
+		status, kerr = http.StatusForbidden, re.ErrQuotaExceeded
+	case errors.Contains(err, re.ErrMessageTooLarge):
+		status, kerr = http.StatusRequestEntityTooLarge, re.ErrMessageTooLarge
+	case errors.Contains(err, re.ErrRateLimited):
+		status, kerr = http.StatusTooManyRequests, re.ErrRateLimited
+		if after, ok := re.RetryAfter(err); ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(after.Seconds()))))
+		}
+	default:
+		api.EncodeError(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", api.ContentType)
+	w.WriteHeader(status)
+	if errorVal := typedError(err, kerr); errorVal != nil {
+		if err := json.NewEncoder(w).Encode(errorVal); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+// typedError returns the layer of the error wrapping the cause with the
+// typed error.
+func typedError(err, typed error) errors.Error {
+	for e, ok := err.(errors.Error); ok && e != nil; e = e.Err() {
+		if e.Msg() == typed.Error() {
+			return e
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/gofrs/uuid"
+)
+
+// Operations recorded in the audit log, in addition to the rule commands
+// start, stop and restart.
+const (
+	AuditCreate    = "create"
+	AuditUpdate    = "update"
+	AuditDelete    = "delete"
+	AuditDraft     = "draft"
+	AuditPublish   = "publish"
+	AuditUnpublish = "unpublish"
+	AuditRestore   = "restore"
+	AuditDeploy    = "deploy"
+	AuditUndeploy  = "undeploy"
+)
+
+const maxAuditLimit = 1000
+
+var (
+	errAuditKind  = errors.New("audit kind must be stream, table or rule")
+	errAuditRange = errors.New("audit range must end after it starts")
+)
+
+// AuditEvent records the operation the user performed on the stream, table
+// or rule. User is the ID of the user who performed the operation and Owner
+// the ID of the entity owner, which differ for the shared entities. Entity
+// is the name or ID of the entity without the owner prefix. Before and After
+// are the SQL of the entity before and after the operation, the DDL of
+// streams and tables, left empty if the entity has no SQL at that point.
+type AuditEvent struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"`
+	Owner     string    `json:"owner"`
+	Kind      string    `json:"kind"`
+	Entity    string    `json:"entity"`
+	Operation string    `json:"operation"`
+	Before    string    `json:"before,omitempty"`
+	After     string    `json:"after,omitempty"`
+}
+
+// AuditQuery filters the audit events. Empty fields match all the events
+// and zero From and To leave the time range open. Events are returned
+// newest first.
+type AuditQuery struct {
+	Offset uint64    `json:"offset"`
+	Limit  uint64    `json:"limit"`
+	User   string    `json:"user,omitempty"`
+	Owner  string    `json:"owner,omitempty"`
+	Kind   string    `json:"kind,omitempty"`
+	Entity string    `json:"entity,omitempty"`
+	From   time.Time `json:"from,omitempty"`
+	To     time.Time `json:"to,omitempty"`
+}
+
+// AuditPage contains the page of the audit events.
+type AuditPage struct {
+	Total  uint64       `json:"total"`
+	Offset uint64       `json:"offset"`
+	Limit  uint64       `json:"limit"`
+	Events []AuditEvent `json:"events"`
+}
+
+// AuditRepository specifies the persistence API of the audit log. Events
+// are only ever added.
+type AuditRepository interface {
+	// SaveAuditEvent stores the audit event.
+	SaveAuditEvent(ctx context.Context, ev AuditEvent) error
+
+	// RetrieveAuditEvents returns the page of the audit events matching
+	// the query, newest first.
+	RetrieveAuditEvents(ctx context.Context, q AuditQuery) (AuditPage, error)
+}
+
+func (q AuditQuery) validate() error {
+	if q.Kind != "" && q.Kind != StreamKind && q.Kind != TableKind && q.Kind != RuleKind {
+		return errAuditKind
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return errAuditRange
+	}
+
+	return nil
+}
+
+func (svc *reService) ListAuditEvents(ctx context.Context, token string, q AuditQuery) (AuditPage, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return AuditPage{}, err
+	}
+	if err := q.validate(); err != nil {
+		return AuditPage{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	// The platform administrator views the events of all the entities and
+	// the users view the events of their own entities, including the ones
+	// performed by the users the entities are shared with.
+	if q.Owner != userID {
+		if err := svc.checkAdmin(ctx, userID); err != nil {
+			if q.Owner != "" {
+				return AuditPage{}, err
+			}
+			q.Owner = userID
+		}
+	}
+	q.Limit = min(q.Limit, maxAuditLimit)
+
+	page, err := svc.repo.RetrieveAuditEvents(ctx, q)
+	if err != nil {
+		return AuditPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+// audit records the operation the user performed on the entity, whose SQL
+// is taken from the entity definitions before and after the operation.
+func (svc *reService) audit(ctx context.Context, userID, owner, kind, name, op, before, after string) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	ev := AuditEvent{
+		ID:        id.String(),
+		Time:      time.Now().UTC(),
+		User:      userID,
+		Owner:     owner,
+		Kind:      kind,
+		Entity:    name,
+		Operation: op,
+		Before:    definitionSQL(kind, name, before),
+		After:     definitionSQL(kind, name, after),
+	}
+	if err := svc.repo.SaveAuditEvent(ctx, ev); err != nil {
+		return errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+// definitionSQL returns the SQL of the stored stream, table or rule
+// definition, with the entity names the owner sees. Entities stored without
+// the definition have no SQL.
+func definitionSQL(kind, name, definition string) string {
+	if definition == "" {
+		return ""
+	}
+	var sql string
+	switch kind {
+	case RuleKind:
+		var rule Rule
+		if err := json.Unmarshal([]byte(definition), &rule); err == nil {
+			sql = rule.SQL
+		}
+	case TableKind:
+		var def TableDef
+		if err := json.Unmarshal([]byte(definition), &def); err == nil {
+			sql, _ = def.withDefaults().ddl(name, "")
+		}
+	default:
+		var def StreamDef
+		if err := json.Unmarshal([]byte(definition), &def); err == nil {
+			sql, _ = def.withDefaults().ddl(name, "")
+		}
+	}
+
+	return sql
+}
+
+// definition returns the stored definition of the entity, empty if the
+// entity has no metadata.
+func (md *Metadata) definition() string {
+	if md == nil {
+		return ""
+	}
+
+	return md.Definition
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditLog(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, _, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "readings", Topic: channelID, SenML: true}, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+	rule := re.Rule{ID: "alarm", SQL: "SELECT * FROM readings WHERE v > 30", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	rule.SQL = "SELECT * FROM readings WHERE v > 40"
+	_, err = svc.UpdateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("update rule: expected no error got %s\n", err))
+	_, err = svc.StopRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("stop rule: expected no error got %s\n", err))
+
+	adminCall := authorizeAdmin(auth, false)
+	page, err := svc.ListAuditEvents(context.Background(), validToken, re.AuditQuery{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list audit events: expected no error got %s\n", err))
+	ops := []string{}
+	for _, ev := range page.Events {
+		assert.Equal(t, userID, ev.User, fmt.Sprintf("expected audit user %s got %s\n", userID, ev.User))
+		assert.Equal(t, userID, ev.Owner, fmt.Sprintf("expected audit owner %s got %s\n", userID, ev.Owner))
+		ops = append(ops, ev.Kind+" "+ev.Operation)
+	}
+	assert.Equal(t, []string{"rule stop", "rule update", "rule create", "stream create"}, ops, fmt.Sprintf("unexpected audit events %v\n", ops))
+
+	page, err = svc.ListAuditEvents(context.Background(), validToken, re.AuditQuery{Limit: 10, Kind: re.RuleKind, Entity: rule.ID})
+	assert.Nil(t, err, fmt.Sprintf("list rule audit events: expected no error got %s\n", err))
+	assert.Equal(t, uint64(3), page.Total, fmt.Sprintf("expected 3 rule audit events got %d\n", page.Total))
+	if assert.Len(t, page.Events, 3, "expected three rule audit events") {
+		update := page.Events[1]
+		assert.Equal(t, "SELECT * FROM readings WHERE v > 30", update.Before, fmt.Sprintf("expected SQL before update got %s\n", update.Before))
+		assert.Equal(t, rule.SQL, update.After, fmt.Sprintf("expected SQL after update got %s\n", update.After))
+	}
+	adminCall.Unset()
+
+	cases := []struct {
+		desc       string
+		query      re.AuditQuery
+		authorized bool
+		err        error
+	}{
+		{
+			desc:  "list audit events of unknown kind",
+			query: re.AuditQuery{Limit: 10, Kind: "function"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "list audit events with range ending before it starts",
+			query: re.AuditQuery{Limit: 10, From: time.Now(), To: time.Now().Add(-time.Hour)},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "list audit events of other owner as user",
+			query: re.AuditQuery{Limit: 10, Owner: otherUserID},
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "list audit events of other owner as admin",
+			query:      re.AuditQuery{Limit: 10, Owner: otherUserID},
+			authorized: true,
+		},
+	}
+
+	for _, tc := range cases {
+		adminCall := authorizeAdmin(auth, tc.authorized)
+		_, err := svc.ListAuditEvents(context.Background(), validToken, tc.query)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		adminCall.Unset()
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// maxBatchItems limits the streams or rules fetched by a single call.
+const maxBatchItems = 100
+
+var errBatchSize = errors.New("batches are limited to 100 streams or rules")
+
+// StreamsBatch contains the streams fetched by ViewStreams, in the order of
+// the requested names, and the names of the requested streams that don't
+// exist or aren't shared with the user.
+type StreamsBatch struct {
+	Streams []Stream `json:"streams"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+// RulesBatch contains the rules fetched by ViewRules, in the order of the
+// requested IDs, and the IDs of the requested rules that don't exist or
+// aren't shared with the user.
+type RulesBatch struct {
+	Rules   []Rule   `json:"rules"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+func (svc *reService) ViewStreams(ctx context.Context, token string, names []string) (StreamsBatch, error) {
+	if len(names) > maxBatchItems {
+		return StreamsBatch{}, errors.Wrap(svcerr.ErrMalformedEntity, errBatchSize)
+	}
+	if _, err := svc.identify(ctx, token); err != nil {
+		return StreamsBatch{}, err
+	}
+
+	streams := make([]Stream, len(names))
+	errs := make([]error, len(names))
+	svc.bulk(StreamKind, len(names), func(i int) (string, error) {
+		streams[i], errs[i] = svc.ViewStream(ctx, token, names[i])
+		return names[i], errs[i]
+	})
+
+	batch := StreamsBatch{Streams: []Stream{}}
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			batch.Streams = append(batch.Streams, streams[i])
+		case errors.Contains(err, svcerr.ErrNotFound):
+			batch.Missing = append(batch.Missing, names[i])
+		default:
+			return StreamsBatch{}, err
+		}
+	}
+
+	return batch, nil
+}
+
+func (svc *reService) ViewRules(ctx context.Context, token string, ids []string) (RulesBatch, error) {
+	if len(ids) > maxBatchItems {
+		return RulesBatch{}, errors.Wrap(svcerr.ErrMalformedEntity, errBatchSize)
+	}
+	if _, err := svc.identify(ctx, token); err != nil {
+		return RulesBatch{}, err
+	}
+
+	rules := make([]Rule, len(ids))
+	errs := make([]error, len(ids))
+	svc.bulk(RuleKind, len(ids), func(i int) (string, error) {
+		rules[i], errs[i] = svc.ViewRule(ctx, token, ids[i])
+		return ids[i], errs[i]
+	})
+
+	batch := RulesBatch{Rules: []Rule{}}
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			batch.Rules = append(batch.Rules, rules[i])
+		case errors.Contains(err, svcerr.ErrNotFound):
+			batch.Missing = append(batch.Missing, ids[i])
+		default:
+			return RulesBatch{}, err
+		}
+	}
+
+	return batch, nil
+}
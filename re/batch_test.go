@@ -0,0 +1,139 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestViewStreams(t *testing.T) {
+	svc, k, auth, _ := newServiceWithConfig(t, re.Config{BulkWorkers: 4}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	k.streams[userPrefix+"temperature"] = ""
+
+	cases := []struct {
+		desc    string
+		token   string
+		names   []string
+		streams []string
+		missing []string
+		err     error
+	}{
+		{
+			desc:    "view streams",
+			token:   validToken,
+			names:   []string{"temperature", "stream"},
+			streams: []string{"temperature", "stream"},
+		},
+		{
+			desc:    "view existing and non-existing streams",
+			token:   validToken,
+			names:   []string{"unknown", "stream", "missing"},
+			streams: []string{"stream"},
+			missing: []string{"unknown", "missing"},
+		},
+		{
+			desc:    "view no streams",
+			token:   validToken,
+			streams: []string{},
+		},
+		{
+			desc:  "view too many streams",
+			token: validToken,
+			names: make([]string, 101),
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "view streams with invalid token",
+			token: invalidToken,
+			names: []string{"stream"},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		batch, err := svc.ViewStreams(context.Background(), tc.token, tc.names)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		names := []string{}
+		for _, s := range batch.Streams {
+			names = append(names, s.Name)
+		}
+		assert.Equal(t, tc.streams, names, fmt.Sprintf("%s: expected streams %v got %v\n", tc.desc, tc.streams, names))
+		assert.Equal(t, tc.missing, batch.Missing, fmt.Sprintf("%s: expected missing %v got %v\n", tc.desc, tc.missing, batch.Missing))
+	}
+}
+
+func TestViewRules(t *testing.T) {
+	svc, _, auth, _ := newServiceWithConfig(t, re.Config{BulkWorkers: 4}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	cases := []struct {
+		desc    string
+		token   string
+		ids     []string
+		rules   []string
+		missing []string
+		err     error
+	}{
+		{
+			desc:  "view rules",
+			token: validToken,
+			ids:   []string{"rule"},
+			rules: []string{"rule"},
+		},
+		{
+			desc:    "view existing and non-existing rules",
+			token:   validToken,
+			ids:     []string{"unknown", "rule"},
+			rules:   []string{"rule"},
+			missing: []string{"unknown"},
+		},
+		{
+			desc:  "view too many rules",
+			token: validToken,
+			ids:   make([]string, 101),
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "view rules with invalid token",
+			token: invalidToken,
+			ids:   []string{"rule"},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		batch, err := svc.ViewRules(context.Background(), tc.token, tc.ids)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		ids := []string{}
+		for _, r := range batch.Rules {
+			ids = append(ids, r.ID)
+		}
+		assert.Equal(t, tc.rules, ids, fmt.Sprintf("%s: expected rules %v got %v\n", tc.desc, tc.rules, ids))
+		assert.Equal(t, tc.missing, batch.Missing, fmt.Sprintf("%s: expected missing %v got %v\n", tc.desc, tc.missing, batch.Missing))
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+var (
+	errBuilderStream = errors.New("rule builder requires the stream to read from")
+	errBuilderWindow = errors.New("rule can have a single window")
+	errWindowLength  = errors.New("window length must be a positive multiple of millisecond")
+	errWindowCount   = errors.New("window count must be positive")
+	errCondition     = errors.New("condition must not be empty")
+)
+
+// windowUnit is the Kuiper window time unit.
+type windowUnit struct {
+	name string
+	d    time.Duration
+}
+
+// windowUnits are the Kuiper window time units, from the largest.
+var windowUnits = []windowUnit{
+	{"dd", 24 * time.Hour},
+	{"hh", time.Hour},
+	{"mi", time.Minute},
+	{"ss", time.Second},
+	{"ms", time.Millisecond},
+}
+
+// RuleBuilder constructs the rule reading from the single stream, so
+// integrators can create threshold and window rules without concatenating
+// the SQL, e.g.
+//
+//	rule, err := re.NewRuleBuilder().
+//		ID("alarm").
+//		From("temperature").
+//		Where("temp > 30").
+//		TumblingWindow(10 * time.Second).
+//		ToChannel(channelID).
+//		Build()
+//
+// Builder methods record the first error, which is returned by Build.
+type RuleBuilder struct {
+	rule       Rule
+	fields     []string
+	stream     string
+	conditions []string
+	groupBy    []string
+	window     string
+	err        error
+}
+
+// NewRuleBuilder returns the builder of the rule selecting all the fields.
+func NewRuleBuilder() *RuleBuilder {
+	return &RuleBuilder{}
+}
+
+// ID sets the rule ID.
+func (b *RuleBuilder) ID(id string) *RuleBuilder {
+	b.rule.ID = id
+	return b
+}
+
+// Select sets the selected fields or expressions, e.g. "avg(temp) AS temp".
+// All the fields are selected by default.
+func (b *RuleBuilder) Select(fields ...string) *RuleBuilder {
+	b.fields = append(b.fields, fields...)
+	return b
+}
+
+// From sets the stream the rule reads from.
+func (b *RuleBuilder) From(stream string) *RuleBuilder {
+	if err := validateName(stream); err != nil {
+		b.fail(errors.Wrap(fmt.Errorf("stream %q", stream), err))
+	}
+	b.stream = stream
+	return b
+}
+
+// Where adds the condition the selected data must satisfy. Conditions of
+// the subsequent calls are combined with AND.
+func (b *RuleBuilder) Where(condition string) *RuleBuilder {
+	condition = strings.TrimSpace(condition)
+	if condition == "" {
+		b.fail(errCondition)
+	}
+	b.conditions = append(b.conditions, condition)
+	return b
+}
+
+// GroupBy adds the fields the data is grouped by, along with the window.
+func (b *RuleBuilder) GroupBy(fields ...string) *RuleBuilder {
+	for _, f := range fields {
+		if err := validateName(f); err != nil {
+			b.fail(errors.Wrap(fmt.Errorf("field %q", f), err))
+		}
+	}
+	b.groupBy = append(b.groupBy, fields...)
+	return b
+}
+
+// TumblingWindow groups the data into non-overlapping windows of the given
+// length.
+func (b *RuleBuilder) TumblingWindow(length time.Duration) *RuleBuilder {
+	return b.timeWindow("TUMBLINGWINDOW", length)
+}
+
+// HoppingWindow groups the data into windows of the given length, starting
+// every hop, so the windows overlap if the hop is shorter than the length.
+func (b *RuleBuilder) HoppingWindow(length, hop time.Duration) *RuleBuilder {
+	return b.timeWindow("HOPPINGWINDOW", length, hop)
+}
+
+// SlidingWindow groups the data received within the given length before
+// each event.
+func (b *RuleBuilder) SlidingWindow(length time.Duration) *RuleBuilder {
+	return b.timeWindow("SLIDINGWINDOW", length)
+}
+
+// SessionWindow groups the data into windows closed after the timeout
+// without data or once they reach the maximal length.
+func (b *RuleBuilder) SessionWindow(length, timeout time.Duration) *RuleBuilder {
+	return b.timeWindow("SESSIONWINDOW", length, timeout)
+}
+
+// CountWindow groups the data into windows of the given number of events.
+func (b *RuleBuilder) CountWindow(count int) *RuleBuilder {
+	if count <= 0 {
+		b.fail(errWindowCount)
+	}
+	return b.setWindow(fmt.Sprintf("COUNTWINDOW(%d)", count))
+}
+
+// ToChannel adds the action publishing the results to the channel.
+func (b *RuleBuilder) ToChannel(channel string) *RuleBuilder {
+	return b.To(Action{Mainflux: &MainfluxSink{Channel: channel}})
+}
+
+// ToREST adds the action posting the results to the URL.
+func (b *RuleBuilder) ToREST(url string) *RuleBuilder {
+	return b.To(Action{REST: &RESTSink{URL: url}})
+}
+
+// To adds the action.
+func (b *RuleBuilder) To(action Action) *RuleBuilder {
+	b.rule.Actions = append(b.rule.Actions, action)
+	return b
+}
+
+// Options sets the rule options.
+func (b *RuleBuilder) Options(opts RuleOptions) *RuleBuilder {
+	b.rule.Options = &opts
+	return b
+}
+
+// Description sets the rule description.
+func (b *RuleBuilder) Description(description string) *RuleBuilder {
+	b.rule.Description = description
+	return b
+}
+
+// Label sets the rule label.
+func (b *RuleBuilder) Label(key, value string) *RuleBuilder {
+	if b.rule.Labels == nil {
+		b.rule.Labels = make(map[string]string)
+	}
+	b.rule.Labels[key] = value
+	return b
+}
+
+// SQL returns the rule SQL.
+func (b *RuleBuilder) SQL() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if b.stream == "" {
+		return "", errBuilderStream
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	if len(b.fields) == 0 {
+		sb.WriteString("*")
+	}
+	sb.WriteString(strings.Join(b.fields, ", "))
+	sb.WriteString(" FROM ")
+	sb.WriteString(b.stream)
+	if len(b.conditions) == 1 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(b.conditions[0])
+	}
+	if len(b.conditions) > 1 {
+		sb.WriteString(" WHERE (")
+		sb.WriteString(strings.Join(b.conditions, ") AND ("))
+		sb.WriteString(")")
+	}
+	groupBy := b.groupBy
+	if b.window != "" {
+		groupBy = append([]string{b.window}, groupBy...)
+	}
+	if len(groupBy) > 0 {
+		sb.WriteString(" GROUP BY ")
+		sb.WriteString(strings.Join(groupBy, ", "))
+	}
+
+	sql := sb.String()
+	// The conditions and expressions are checked to be well formed and not
+	// to read from other streams.
+	tokens, err := tokenize(sql)
+	if err != nil {
+		return "", err
+	}
+	refs, err := streamRefs(tokens)
+	if err != nil {
+		return "", err
+	}
+	if len(refs) != 1 {
+		return "", errors.Wrap(errBuilderStream, errors.New(sql))
+	}
+
+	return sql, nil
+}
+
+// Build returns the rule, or the first error of the builder methods. The
+// rule is validated once again when it's created.
+func (b *RuleBuilder) Build() (Rule, error) {
+	sql, err := b.SQL()
+	if err != nil {
+		return Rule{}, err
+	}
+	if len(b.rule.Actions) == 0 {
+		return Rule{}, errMissingActions
+	}
+	for i, a := range b.rule.Actions {
+		if _, err := a.sink(); err != nil {
+			return Rule{}, errors.Wrap(fmt.Errorf("action %d", i), err)
+		}
+		if err := a.validate(); err != nil {
+			return Rule{}, errors.Wrap(fmt.Errorf("action %d", i), err)
+		}
+	}
+
+	rule := b.rule
+	rule.SQL = sql
+	rule.Actions = append([]Action{}, b.rule.Actions...)
+
+	return rule, nil
+}
+
+func (b *RuleBuilder) timeWindow(name string, lengths ...time.Duration) *RuleBuilder {
+	unit, ok := unitOf(lengths...)
+	if !ok {
+		b.fail(errWindowLength)
+		return b
+	}
+	args := []string{unit.name}
+	for _, l := range lengths {
+		args = append(args, fmt.Sprint(int64(l/unit.d)))
+	}
+
+	return b.setWindow(fmt.Sprintf("%s(%s)", name, strings.Join(args, ", ")))
+}
+
+func (b *RuleBuilder) setWindow(window string) *RuleBuilder {
+	if b.window != "" {
+		b.fail(errBuilderWindow)
+	}
+	b.window = window
+	return b
+}
+
+func (b *RuleBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// unitOf returns the largest Kuiper time unit all the lengths are
+// multiples of.
+func unitOf(lengths ...time.Duration) (windowUnit, bool) {
+	for _, l := range lengths {
+		if l <= 0 {
+			return windowUnit{}, false
+		}
+	}
+	for _, u := range windowUnits {
+		whole := true
+		for _, l := range lengths {
+			whole = whole && l%u.d == 0
+		}
+		if whole {
+			return u, true
+		}
+	}
+
+	return windowUnit{}, false
+}
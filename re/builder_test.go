@@ -0,0 +1,142 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRuleBuilder(t *testing.T) {
+	cases := []struct {
+		desc    string
+		builder *re.RuleBuilder
+		sql     string
+		err     bool
+	}{
+		{
+			desc:    "build threshold rule",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").Where("temp > 30").ToChannel(channelID),
+			sql:     "SELECT * FROM stream WHERE temp > 30",
+		},
+		{
+			desc:    "build tumbling window rule",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").Where("temp > 30").TumblingWindow(10 * time.Second).ToChannel(channelID),
+			sql:     "SELECT * FROM stream WHERE temp > 30 GROUP BY TUMBLINGWINDOW(ss, 10)",
+		},
+		{
+			desc: "build hopping window rule with aggregates",
+			builder: re.NewRuleBuilder().ID("alarm").Select("device", "avg(temp) AS temp").From("stream").
+				Where("temp > 30").Where("device != 'test'").HoppingWindow(2*time.Minute, 30*time.Second).GroupBy("device").ToChannel(channelID),
+			sql: "SELECT device, avg(temp) AS temp FROM stream WHERE (temp > 30) AND (device != 'test') GROUP BY HOPPINGWINDOW(ss, 120, 30), device",
+		},
+		{
+			desc:    "build sliding window rule",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").SlidingWindow(1500 * time.Millisecond).ToChannel(channelID),
+			sql:     "SELECT * FROM stream GROUP BY SLIDINGWINDOW(ms, 1500)",
+		},
+		{
+			desc:    "build session window rule",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").SessionWindow(time.Hour, 5*time.Minute).ToChannel(channelID),
+			sql:     "SELECT * FROM stream GROUP BY SESSIONWINDOW(mi, 60, 5)",
+		},
+		{
+			desc:    "build count window rule",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").CountWindow(5).ToChannel(channelID),
+			sql:     "SELECT * FROM stream GROUP BY COUNTWINDOW(5)",
+		},
+		{
+			desc:    "build rule without stream",
+			builder: re.NewRuleBuilder().ID("alarm").Where("temp > 30").ToChannel(channelID),
+			err:     true,
+		},
+		{
+			desc:    "build rule with malformed stream name",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream; DROP").ToChannel(channelID),
+			err:     true,
+		},
+		{
+			desc:    "build rule reading from other stream",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").Where("temp > (SELECT temp FROM other)").ToChannel(channelID),
+			err:     true,
+		},
+		{
+			desc:    "build rule with unterminated condition",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").Where("device = 'test").ToChannel(channelID),
+			err:     true,
+		},
+		{
+			desc:    "build rule with empty condition",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").Where(" ").ToChannel(channelID),
+			err:     true,
+		},
+		{
+			desc:    "build rule with multiple windows",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").TumblingWindow(time.Second).CountWindow(5).ToChannel(channelID),
+			err:     true,
+		},
+		{
+			desc:    "build rule with invalid window length",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").TumblingWindow(time.Microsecond).ToChannel(channelID),
+			err:     true,
+		},
+		{
+			desc:    "build rule without actions",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream"),
+			err:     true,
+		},
+		{
+			desc:    "build rule with invalid REST action",
+			builder: re.NewRuleBuilder().ID("alarm").From("stream").ToREST("ftp://example.com"),
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		rule, err := tc.builder.Build()
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.sql, rule.SQL, fmt.Sprintf("%s: expected SQL %s got %s\n", tc.desc, tc.sql, rule.SQL))
+	}
+}
+
+func TestCreateBuiltRule(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	rule, err := re.NewRuleBuilder().
+		ID("alarm").
+		From("stream").
+		Where("temp > 30").
+		TumblingWindow(10*time.Second).
+		ToChannel(channelID).
+		Label("site", "a").
+		Build()
+	assert.Nil(t, err, fmt.Sprintf("build rule: expected no error got %s\n", err))
+
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create built rule: expected no error got %s\n", err))
+	sql := "SELECT * FROM " + userPrefix + "stream WHERE temp > 30 GROUP BY TUMBLINGWINDOW(ss, 10)"
+	assert.Equal(t, sql, k.rules[userPrefix+"alarm"].SQL, fmt.Sprintf("create built rule: expected SQL %s got %s\n", sql, k.rules[userPrefix+"alarm"].SQL))
+
+	res, err := svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create existing built rule: expected no error got %s\n", err))
+	assert.Equal(t, http.StatusOK, res.Status, fmt.Sprintf("create existing built rule: expected status %d got %d\n", http.StatusOK, res.Status))
+
+	rule.SQL = "SELECT * FROM stream WHERE temp > 40"
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("create existing built rule with another definition: expected %s got %s\n", svcerr.ErrConflict, err))
+}
@@ -0,0 +1,204 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// maxBulkItems limits the streams and rules of a single bulk operation.
+const maxBulkItems = 1000
+
+var (
+	errBulkSize  = errors.New("bulk operations are limited to 1000 streams and rules")
+	errSelection = errors.New("rules are selected either by IDs or by labels")
+)
+
+// BulkDeletion contains the names of the streams and the IDs of the rules
+// removed by a single bulk operation.
+type BulkDeletion struct {
+	Streams []string `json:"streams"`
+	Rules   []string `json:"rules"`
+}
+
+// RuleSelection selects the rules of the bulk start or stop either by their
+// IDs or by the labels the user's rules must all have with the same values.
+// Drafts and deleted rules are never selected by the labels.
+type RuleSelection struct {
+	IDs    []string          `json:"ids,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// BulkItem is the result of the bulk operation on the stream or rule with
+// the given name. Error is the reason the operation failed.
+type BulkItem struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkReport is the report of the bulk operation. Items are listed in the
+// order the streams and rules were sent in, streams first for creation and
+// rules first for removal.
+type BulkReport struct {
+	Succeeded int        `json:"succeeded"`
+	Failed    int        `json:"failed"`
+	Items     []BulkItem `json:"items"`
+}
+
+func (svc *reService) BulkCreate(ctx context.Context, token string, rs Ruleset) (BulkReport, error) {
+	if len(rs.Streams)+len(rs.Rules) > maxBulkItems {
+		return BulkReport{}, errors.Wrap(svcerr.ErrMalformedEntity, errBulkSize)
+	}
+	ctx, err := svc.writing(ctx, token)
+	if err != nil {
+		return BulkReport{}, err
+	}
+
+	// Streams are created first, since rules read from them.
+	report := BulkReport{Items: []BulkItem{}}
+	report.add(svc.bulk(StreamKind, len(rs.Streams), func(i int) (string, error) {
+		_, err := svc.CreateStream(ctx, token, rs.Streams[i], false)
+		return rs.Streams[i].Name, err
+	}))
+	report.add(svc.bulk(RuleKind, len(rs.Rules), func(i int) (string, error) {
+		_, err := svc.CreateRule(ctx, token, rs.Rules[i])
+		return rs.Rules[i].ID, err
+	}))
+
+	return report, nil
+}
+
+func (svc *reService) BulkDelete(ctx context.Context, token string, bd BulkDeletion) (BulkReport, error) {
+	if len(bd.Streams)+len(bd.Rules) > maxBulkItems {
+		return BulkReport{}, errors.Wrap(svcerr.ErrMalformedEntity, errBulkSize)
+	}
+	ctx, err := svc.writing(ctx, token)
+	if err != nil {
+		return BulkReport{}, err
+	}
+
+	// Rules are removed first, since Kuiper keeps the streams rules read from.
+	report := BulkReport{Items: []BulkItem{}}
+	report.add(svc.bulk(RuleKind, len(bd.Rules), func(i int) (string, error) {
+		_, err := svc.DeleteRule(ctx, token, bd.Rules[i])
+		return bd.Rules[i], err
+	}))
+	report.add(svc.bulk(StreamKind, len(bd.Streams), func(i int) (string, error) {
+		_, err := svc.DeleteStream(ctx, token, bd.Streams[i], false)
+		return bd.Streams[i], err
+	}))
+
+	return report, nil
+}
+
+func (svc *reService) StartRules(ctx context.Context, token string, sel RuleSelection) (BulkReport, error) {
+	return svc.controlRules(ctx, token, sel, svc.StartRule)
+}
+
+func (svc *reService) StopRules(ctx context.Context, token string, sel RuleSelection) (BulkReport, error) {
+	return svc.controlRules(ctx, token, sel, svc.StopRule)
+}
+
+// controlRules runs the control operation on the selected rules.
+func (svc *reService) controlRules(ctx context.Context, token string, sel RuleSelection, control func(ctx context.Context, token, id string) (Result, error)) (BulkReport, error) {
+	if (len(sel.IDs) == 0) == (len(sel.Labels) == 0) {
+		return BulkReport{}, errors.Wrap(svcerr.ErrMalformedEntity, errSelection)
+	}
+	if len(sel.IDs) > maxBulkItems {
+		return BulkReport{}, errors.Wrap(svcerr.ErrMalformedEntity, errBulkSize)
+	}
+	ctx, err := svc.writing(ctx, token)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	// The checks already passed, so this only identifies the user.
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	ids := sel.IDs
+	if len(sel.Labels) > 0 {
+		if ids, err = svc.labeledRules(ctx, userID, sel.Labels); err != nil {
+			return BulkReport{}, err
+		}
+	}
+
+	report := BulkReport{Items: []BulkItem{}}
+	report.add(svc.bulk(RuleKind, len(ids), func(i int) (string, error) {
+		_, err := control(ctx, token, ids[i])
+		return ids[i], err
+	}))
+
+	return report, nil
+}
+
+// labeledRules returns the sorted IDs of the user's live rules that have all
+// of the labels.
+func (svc *reService) labeledRules(ctx context.Context, userID string, labels map[string]string) ([]string, error) {
+	mds, err := svc.repo.RetrieveAll(ctx, RuleKind, userID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	pfx := prefix(userID)
+	ids := []string{}
+	for kuiperID, md := range mds {
+		if !md.Draft && !md.deleted() && hasLabels(md.Labels, labels) {
+			ids = append(ids, strings.TrimPrefix(kuiperID, pfx))
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > maxBulkItems {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, errBulkSize)
+	}
+
+	return ids, nil
+}
+
+// bulk runs the operation on the n entities of the kind using the pool of
+// the bulk workers and returns the results in the order of the entities.
+// The operation returns the name of the entity along with its error.
+func (svc *reService) bulk(kind string, n int, op func(i int) (string, error)) []BulkItem {
+	items := make([]BulkItem, n)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(svc.bulkWorkers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				name, err := op(i)
+				items[i] = BulkItem{Kind: kind, Name: name, Success: err == nil}
+				if err != nil {
+					items[i].Error = err.Error()
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return items
+}
+
+func (report *BulkReport) add(items []BulkItem) {
+	for _, item := range items {
+		if item.Success {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	report.Items = append(report.Items, items...)
+}
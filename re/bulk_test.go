@@ -0,0 +1,255 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBulkCreate(t *testing.T) {
+	rules := make([]re.Rule, 20)
+	for i := range rules {
+		rules[i] = re.Rule{
+			ID:      fmt.Sprintf("bulk%d", i),
+			SQL:     "SELECT * FROM bulk WHERE v > 10",
+			Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+		}
+	}
+	items := []re.BulkItem{
+		{Kind: re.StreamKind, Name: "bulk", Success: true},
+		{Kind: re.StreamKind, Name: "1stream"},
+	}
+	for _, rule := range rules {
+		items = append(items, re.BulkItem{Kind: re.RuleKind, Name: rule.ID, Success: true})
+	}
+	items = append(items, re.BulkItem{Kind: re.RuleKind, Name: "invalid"})
+	rs := re.Ruleset{
+		Streams: []re.StreamDef{
+			{Name: "bulk", Topic: channelID, SenML: true},
+			{Name: "1stream", Topic: channelID, SenML: true},
+		},
+		Rules: append(rules, re.Rule{ID: "invalid"}),
+	}
+
+	cases := []struct {
+		desc      string
+		token     string
+		rs        re.Ruleset
+		items     []re.BulkItem
+		succeeded int
+		failed    int
+		err       error
+	}{
+		{
+			desc:      "bulk create streams and rules",
+			token:     validToken,
+			rs:        rs,
+			items:     items,
+			succeeded: 21,
+			failed:    2,
+		},
+		{
+			desc:  "bulk create too many rules",
+			token: validToken,
+			rs:    re.Ruleset{Rules: make([]re.Rule, 1001)},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "bulk create with invalid token",
+			token: invalidToken,
+			rs:    rs,
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, k, auth, _ := newServiceWithConfig(t, re.Config{BulkWorkers: 4}, re.Notifiers{})
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+		channelCall := authorizeChannel(auth, validToken, channelID, true)
+
+		report, err := svc.BulkCreate(context.Background(), tc.token, tc.rs)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.succeeded, report.Succeeded, fmt.Sprintf("%s: expected %d succeeded got %d\n", tc.desc, tc.succeeded, report.Succeeded))
+			assert.Equal(t, tc.failed, report.Failed, fmt.Sprintf("%s: expected %d failed got %d\n", tc.desc, tc.failed, report.Failed))
+			for i, item := range report.Items {
+				if !item.Success {
+					assert.NotEmpty(t, item.Error, fmt.Sprintf("%s: expected error of failed %s %s\n", tc.desc, item.Kind, item.Name))
+					report.Items[i].Error = ""
+				}
+			}
+			assert.Equal(t, tc.items, report.Items, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.items, report.Items))
+			for _, rule := range rules {
+				_, ok := k.rules[userPrefix+rule.ID]
+				assert.True(t, ok, fmt.Sprintf("%s: expected rule %s to be created\n", tc.desc, rule.ID))
+			}
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		channelCall.Unset()
+	}
+}
+
+func TestBulkDelete(t *testing.T) {
+	cases := []struct {
+		desc      string
+		token     string
+		bd        re.BulkDeletion
+		items     []re.BulkItem
+		succeeded int
+		failed    int
+		err       error
+	}{
+		{
+			desc:  "bulk delete streams and rules",
+			token: validToken,
+			bd:    re.BulkDeletion{Streams: []string{"stream", "unknown"}, Rules: []string{"rule", "unknown"}},
+			items: []re.BulkItem{
+				{Kind: re.RuleKind, Name: "rule", Success: true},
+				{Kind: re.RuleKind, Name: "unknown"},
+				{Kind: re.StreamKind, Name: "stream", Success: true},
+				{Kind: re.StreamKind, Name: "unknown"},
+			},
+			succeeded: 2,
+			failed:    2,
+		},
+		{
+			desc:  "bulk delete too many streams",
+			token: validToken,
+			bd:    re.BulkDeletion{Streams: make([]string, 1001)},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "bulk delete with invalid token",
+			token: invalidToken,
+			bd:    re.BulkDeletion{Rules: []string{"rule"}},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, k, auth, _ := newServiceWithConfig(t, re.Config{BulkWorkers: 2}, re.Notifiers{})
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+
+		report, err := svc.BulkDelete(context.Background(), tc.token, tc.bd)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.succeeded, report.Succeeded, fmt.Sprintf("%s: expected %d succeeded got %d\n", tc.desc, tc.succeeded, report.Succeeded))
+			assert.Equal(t, tc.failed, report.Failed, fmt.Sprintf("%s: expected %d failed got %d\n", tc.desc, tc.failed, report.Failed))
+			for i := range report.Items {
+				report.Items[i].Error = ""
+			}
+			assert.Equal(t, tc.items, report.Items, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.items, report.Items))
+			_, ok := k.rules[userPrefix+"rule"]
+			assert.False(t, ok, fmt.Sprintf("%s: expected rule to be removed\n", tc.desc))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+	}
+}
+
+func TestControlRules(t *testing.T) {
+	svc, _, auth, _ := newServiceWithConfig(t, re.Config{BulkWorkers: 2}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	for id, site := range map[string]string{"plant_a1": "plant_a", "plant_a2": "plant_a", "plant_b1": "plant_b"} {
+		_, err := svc.CreateRule(context.Background(), validToken, re.Rule{
+			ID:      id,
+			SQL:     "SELECT * FROM stream WHERE v > 10",
+			Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+			Labels:  map[string]string{"site": site},
+		})
+		assert.Nil(t, err, fmt.Sprintf("create rule %s: expected no error got %s\n", id, err))
+	}
+
+	controls := map[string]func(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error){
+		"stop":  svc.StopRules,
+		"start": svc.StartRules,
+	}
+	cases := []struct {
+		desc  string
+		token string
+		sel   re.RuleSelection
+		items []re.BulkItem
+		err   error
+	}{
+		{
+			desc:  "control rules by IDs",
+			token: validToken,
+			sel:   re.RuleSelection{IDs: []string{"rule", "unknown"}},
+			items: []re.BulkItem{
+				{Kind: re.RuleKind, Name: "rule", Success: true},
+				{Kind: re.RuleKind, Name: "unknown"},
+			},
+		},
+		{
+			desc:  "control rules by labels",
+			token: validToken,
+			sel:   re.RuleSelection{Labels: map[string]string{"site": "plant_a"}},
+			items: []re.BulkItem{
+				{Kind: re.RuleKind, Name: "plant_a1", Success: true},
+				{Kind: re.RuleKind, Name: "plant_a2", Success: true},
+			},
+		},
+		{
+			desc:  "control rules by unmatched labels",
+			token: validToken,
+			sel:   re.RuleSelection{Labels: map[string]string{"site": "plant_c"}},
+			items: []re.BulkItem{},
+		},
+		{
+			desc:  "control rules by IDs and labels",
+			token: validToken,
+			sel:   re.RuleSelection{IDs: []string{"rule"}, Labels: map[string]string{"site": "plant_a"}},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "control no rules",
+			token: validToken,
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "control too many rules",
+			token: validToken,
+			sel:   re.RuleSelection{IDs: make([]string, 1001)},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "control rules with invalid token",
+			token: invalidToken,
+			sel:   re.RuleSelection{IDs: []string{"rule"}},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		for command, control := range controls {
+			report, err := control(context.Background(), tc.token, tc.sel)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s %s: expected %s got %s\n", command, tc.desc, tc.err, err))
+			if err != nil {
+				continue
+			}
+			for i := range report.Items {
+				report.Items[i].Error = ""
+			}
+			assert.Equal(t, tc.items, report.Items, fmt.Sprintf("%s %s: expected %v got %v\n", command, tc.desc, tc.items, report.Items))
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// chainTopicPrefix prefixes the memory topics of the chains, so they don't
+// clash with the memory topics of the user's own streams.
+const chainTopicPrefix = "chains/"
+
+var (
+	errChainLoop    = errors.New("rule can't feed itself")
+	errChainMissing = errors.New("chain doesn't exist")
+	errChainExists  = errors.New("chain already exists")
+	errChainInUse   = errors.New("chain stream is read by rules")
+	errChained      = errors.New("entity is chained")
+	errChainTarget  = errors.New("to rule must read from the chain stream")
+)
+
+// Chain feeds the results of the From rule to the To rule through the
+// memory stream with the chain Name and the Fields of the From rule results.
+// The service creates the stream and adds the memory sink publishing to it
+// to the From rule, while the To rule reads from the stream like from any
+// other stream, so it's created once the chain is and must read from the
+// stream whenever it's created or updated.
+type Chain struct {
+	Name      string    `json:"name"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Fields    []Field   `json:"fields"`
+	Owner     string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ChainRepository specifies the persistence API of the rule chains.
+type ChainRepository interface {
+	// SaveChain stores the chain. Chains are identified by the owner and the
+	// name.
+	SaveChain(ctx context.Context, c Chain) error
+
+	// RetrieveChains returns the chains of the owner.
+	RetrieveChains(ctx context.Context, owner string) ([]Chain, error)
+
+	// RemoveChain removes the owner's chain with the given name.
+	RemoveChain(ctx context.Context, owner, name string) error
+}
+
+func (svc *reService) CreateChain(ctx context.Context, token string, c Chain) (Chain, error) {
+	// The chain changes the From rule and the stream as they are.
+	ctx, err := svc.writing(ctx, token)
+	if err != nil {
+		return Chain{}, err
+	}
+	// The checks already passed, so this only identifies the user.
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Chain{}, err
+	}
+	for _, name := range []string{c.Name, c.From, c.To} {
+		if err := validateName(name); err != nil {
+			return Chain{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
+	if c.From == c.To {
+		return Chain{}, errors.Wrap(svcerr.ErrMalformedEntity, errChainLoop)
+	}
+	switch _, err := svc.chain(ctx, userID, c.Name); {
+	case err == nil:
+		return Chain{}, errors.Wrap(svcerr.ErrConflict, errChainExists)
+	case !errors.Contains(err, svcerr.ErrNotFound):
+		return Chain{}, err
+	}
+	from, err := svc.viewRule(ctx, token, c.From)
+	if err != nil {
+		return Chain{}, err
+	}
+	// The stream doesn't exist yet, so the existing To rule can't read from
+	// it.
+	switch _, err := svc.viewRule(ctx, token, c.To); {
+	case err == nil:
+		return Chain{}, errors.Wrap(svcerr.ErrConflict, errChainTarget)
+	case !errors.Contains(err, svcerr.ErrNotFound):
+		return Chain{}, err
+	}
+
+	def := StreamDef{Name: c.Name, Type: MemorySource, Topic: chainTopicPrefix + c.Name, Fields: c.Fields}
+	if _, err := svc.CreateStream(ctx, token, def, false); err != nil {
+		return Chain{}, err
+	}
+	// The From rule keeps its state, so the running rule isn't restarted
+	// and the stopped one isn't started.
+	actions := append(append([]Action{}, from.Actions...), Action{Memory: &MemorySink{Topic: def.Topic}})
+	if _, err := svc.patchRule(ctx, token, c.From, RulePatch{Actions: actions}, false); err != nil {
+		_, _ = svc.deleteStream(ctx, token, c.Name, false, true)
+		return Chain{}, err
+	}
+	c.Owner = userID
+	c.CreatedAt = time.Now().UTC()
+	if err := svc.repo.SaveChain(ctx, c); err != nil {
+		_, _ = svc.patchRule(ctx, token, c.From, RulePatch{Actions: from.Actions}, false)
+		_, _ = svc.deleteStream(ctx, token, c.Name, false, true)
+		if errors.Contains(err, repoerr.ErrConflict) {
+			return Chain{}, errors.Wrap(svcerr.ErrConflict, errChainExists)
+		}
+		return Chain{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return c, nil
+}
+
+func (svc *reService) ListChains(ctx context.Context, token string) ([]Chain, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	chains, err := svc.repo.RetrieveChains(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		return chains[i].Name < chains[j].Name
+	})
+
+	return chains, nil
+}
+
+func (svc *reService) RemoveChain(ctx context.Context, token, name string) error {
+	// Removing the chain changes the From rule and the stream as they are.
+	ctx, err := svc.writing(ctx, token)
+	if err != nil {
+		return err
+	}
+	// The checks already passed, so this only identifies the user.
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return err
+	}
+	c, err := svc.chain(ctx, userID, name)
+	if err != nil {
+		return err
+	}
+	// The rules reading from the chain stream are removed first, so the
+	// stream isn't dropped under them.
+	rules, err := svc.definedRules(ctx, userID, RuleQuery{Stream: name})
+	if err != nil {
+		return err
+	}
+	if len(rules) > 0 {
+		return errors.Wrap(svcerr.ErrConflict, errors.Wrap(errChainInUse, errors.New(strings.Join(rules, ", "))))
+	}
+
+	// The From rule stops publishing to the stream before it's dropped.
+	// Rules left without other actions discard their results.
+	from, err := svc.viewRule(ctx, token, c.From)
+	switch {
+	case errors.Contains(err, svcerr.ErrNotFound):
+	case err != nil:
+		return err
+	default:
+		actions := []Action{}
+		for _, a := range from.Actions {
+			if a.Memory == nil || a.Memory.Topic != chainTopicPrefix+name {
+				actions = append(actions, a)
+			}
+		}
+		if len(actions) == 0 {
+			actions = append(actions, Action{Nop: &NopSink{}})
+		}
+		if _, err := svc.patchRule(ctx, token, c.From, RulePatch{Actions: actions}, false); err != nil {
+			return err
+		}
+	}
+	if _, err := svc.deleteStream(ctx, token, name, false, true); err != nil {
+		return err
+	}
+	if err := svc.repo.RemoveChain(ctx, userID, name); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// chain returns the user's chain with the given name.
+func (svc *reService) chain(ctx context.Context, userID, name string) (Chain, error) {
+	chains, err := svc.repo.RetrieveChains(ctx, userID)
+	if err != nil {
+		return Chain{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for _, c := range chains {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+
+	return Chain{}, errors.Wrap(svcerr.ErrNotFound, errChainMissing)
+}
+
+// checkChainTarget checks that the owner's rule reads from the stream of
+// every chain the rule is the To rule of.
+func (svc *reService) checkChainTarget(ctx context.Context, owner string, rule Rule) error {
+	chains, err := svc.repo.RetrieveChains(ctx, owner)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for _, c := range chains {
+		if c.To == rule.ID && !(RuleQuery{Stream: c.Name}).match(rule) {
+			return errors.Wrap(svcerr.ErrMalformedEntity, errChainTarget)
+		}
+	}
+
+	return nil
+}
+
+// checkChained checks that the owner's rule doesn't feed a chain and that
+// the stream isn't the stream of a chain, so the chains are removed before
+// the entities they're made of.
+func (svc *reService) checkChained(ctx context.Context, owner, kind, name string) error {
+	chains, err := svc.repo.RetrieveChains(ctx, owner)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	var names []string
+	for _, c := range chains {
+		if (kind == RuleKind && c.From == name) || (kind == StreamKind && c.Name == name) {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return errors.Wrap(svcerr.ErrConflict, errors.Wrap(errChained, errors.New("remove chains "+strings.Join(names, ", ")+" first")))
+	}
+
+	return nil
+}
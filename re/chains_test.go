@@ -0,0 +1,147 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateChain(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	actions := []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}
+	_, err := svc.CreateRule(context.Background(), validToken, re.Rule{ID: "existing", SQL: "SELECT * FROM stream", Actions: actions})
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+
+	fields := []re.Field{{Name: "v", Type: "float"}}
+	cases := []struct {
+		desc  string
+		token string
+		chain re.Chain
+		err   error
+	}{
+		{
+			desc:  "create chain",
+			token: validToken,
+			chain: re.Chain{Name: "filtered", From: "rule", To: "alarm", Fields: fields},
+		},
+		{
+			desc:  "create existing chain",
+			token: validToken,
+			chain: re.Chain{Name: "filtered", From: "rule", To: "other", Fields: fields},
+			err:   svcerr.ErrConflict,
+		},
+		{
+			desc:  "create chain feeding the rule itself",
+			token: validToken,
+			chain: re.Chain{Name: "loop", From: "rule", To: "rule", Fields: fields},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "create chain with invalid name",
+			token: validToken,
+			chain: re.Chain{Name: "fil tered", From: "rule", To: "alarm", Fields: fields},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "create chain to existing rule",
+			token: validToken,
+			chain: re.Chain{Name: "existing", From: "rule", To: "existing", Fields: fields},
+			err:   svcerr.ErrConflict,
+		},
+		{
+			desc:  "create chain from missing rule",
+			token: validToken,
+			chain: re.Chain{Name: "missing", From: "unknown", To: "alarm", Fields: fields},
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "create chain with invalid token",
+			token: invalidToken,
+			chain: re.Chain{Name: "other", From: "rule", To: "alarm", Fields: fields},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := svc.CreateChain(context.Background(), tc.token, tc.chain)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.chain.Name, c.Name, fmt.Sprintf("%s: expected chain %s got %s\n", tc.desc, tc.chain.Name, c.Name))
+			assert.False(t, c.CreatedAt.IsZero(), fmt.Sprintf("%s: expected creation time\n", tc.desc))
+			// The From rule runs the updated definition without restarting.
+			assert.NotContains(t, k.last, "/restart", fmt.Sprintf("%s: expected From rule not to be restarted got %s\n", tc.desc, k.last))
+		}
+	}
+	_, err = svc.ViewStream(context.Background(), validToken, "existing")
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("view stream of rejected chain: expected %s got %s\n", svcerr.ErrNotFound, err))
+
+	// The From rule publishes its results to the chain stream.
+	rule, err := svc.ViewRule(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("view rule: expected no error got %s\n", err))
+	last := rule.Actions[len(rule.Actions)-1]
+	assert.Equal(t, &re.MemorySink{Topic: "chains/filtered"}, last.Memory, fmt.Sprintf("view rule: expected memory sink got %v\n", last))
+	_, err = svc.ViewStream(context.Background(), validToken, "filtered")
+	assert.Nil(t, err, fmt.Sprintf("view chain stream: expected no error got %s\n", err))
+
+	chains, err := svc.ListChains(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list chains: expected no error got %s\n", err))
+	assert.Len(t, chains, 1, fmt.Sprintf("list chains: expected 1 chain got %d\n", len(chains)))
+}
+
+func TestRemoveChain(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	_, err := svc.CreateChain(context.Background(), validToken, re.Chain{Name: "filtered", From: "rule", To: "alarm", Fields: []re.Field{{Name: "v", Type: "float"}}})
+	assert.Nil(t, err, fmt.Sprintf("create chain: expected no error got %s\n", err))
+	alarm := re.Rule{ID: "alarm", SQL: "SELECT * FROM stream", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	_, err = svc.CreateRule(context.Background(), validToken, alarm)
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("create chained rule reading other stream: expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+	alarm.SQL = "SELECT * FROM filtered"
+	_, err = svc.CreateRule(context.Background(), validToken, alarm)
+	assert.Nil(t, err, fmt.Sprintf("create chained rule: expected no error got %s\n", err))
+
+	// The chain is removed after the rules reading from it and before the
+	// entities it's made of.
+	_, err = svc.DeleteRule(context.Background(), validToken, "rule")
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("delete chained rule: expected %s got %s\n", svcerr.ErrConflict, err))
+	_, err = svc.DeleteStream(context.Background(), validToken, "filtered", false)
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("delete chain stream: expected %s got %s\n", svcerr.ErrConflict, err))
+	err = svc.RemoveChain(context.Background(), validToken, "filtered")
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("remove chain in use: expected %s got %s\n", svcerr.ErrConflict, err))
+
+	_, err = svc.DeleteRule(context.Background(), validToken, "alarm")
+	assert.Nil(t, err, fmt.Sprintf("delete chained rule: expected no error got %s\n", err))
+	err = svc.RemoveChain(context.Background(), validToken, "filtered")
+	assert.Nil(t, err, fmt.Sprintf("remove chain: expected no error got %s\n", err))
+	err = svc.RemoveChain(context.Background(), validToken, "filtered")
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("remove removed chain: expected %s got %s\n", svcerr.ErrNotFound, err))
+
+	rule, err := svc.ViewRule(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("view rule: expected no error got %s\n", err))
+	for _, a := range rule.Actions {
+		assert.Nil(t, a.Memory, fmt.Sprintf("view rule: expected no memory sink got %v\n", a.Memory))
+	}
+	_, err = svc.DeleteRule(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("delete unchained rule: expected no error got %s\n", err))
+}
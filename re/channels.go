@@ -0,0 +1,230 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/absmach/magistrala"
+	mgauth "github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// ChannelsHandler handles the events of the channels streams and rules use.
+// Handlers act on the streams and rules of all the users, so they are used
+// by the event consumers and never exposed over the API.
+type ChannelsHandler interface {
+	// CreateChannelHandler creates the SenML stream reading from the created
+	// channel for each of the channel administrators, so rules can be
+	// created for the channel right away.
+	CreateChannelHandler(ctx context.Context, id string) error
+
+	// RemoveChannelHandler removes the rules publishing to the removed
+	// channel or reading from its streams and then the streams reading from
+	// the channel, so they don't fail forever.
+	RemoveChannelHandler(ctx context.Context, id string) error
+}
+
+// channelStreamPrefix prefixes the names of the streams created for
+// channels, since stream names can't start with a digit.
+const channelStreamPrefix = "channel_"
+
+var _ ChannelsHandler = (*reService)(nil)
+
+// NewChannelsHandler instantiates the channels handler running the streams
+// and rules in the given engine.
+func NewChannelsHandler(engine RuleEngine, cfg Config, auth magistrala.AuthServiceClient, repo Repository) ChannelsHandler {
+	return newService(engine, cfg, auth, nil, Notifiers{}, nil, repo)
+}
+
+// ChannelStream returns the name of the stream created for the channel.
+func ChannelStream(id string) string {
+	return channelStreamPrefix + strings.ReplaceAll(id, "-", "_")
+}
+
+func (svc *reService) CreateChannelHandler(ctx context.Context, id string) error {
+	res, err := svc.auth.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
+		SubjectType: mgauth.UserType,
+		Permission:  mgauth.AdministratorRelation,
+		Object:      id,
+		ObjectType:  mgauth.GroupType,
+	})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	def := StreamDef{Name: ChannelStream(id), Topic: id, SenML: true}.withDefaults()
+	definition, err := streamDefinition(def)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	for _, domainUserID := range res.GetPolicies() {
+		_, userID := mgauth.DecodeDomainUserID(domainUserID)
+		if userID == "" {
+			continue
+		}
+		pfx := prefix(userID)
+		sql, err := def.ddl(pfx+def.Name, pfx)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		// Events can be redelivered, so existing streams are kept.
+		_, err = svc.saveStream(ctx, userID, def.Name, sql, false)
+		switch {
+		case errors.Contains(err, svcerr.ErrConflict):
+			continue
+		case err != nil:
+			return err
+		}
+		md := Metadata{
+			Owner:       userID,
+			Description: "SenML messages of the channel",
+			Labels:      map[string]string{"channel": id},
+			Definition:  definition,
+		}
+		if err := svc.saveMetadata(ctx, StreamKind, def.Name, md, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (svc *reService) RemoveChannelHandler(ctx context.Context, id string) error {
+	streams, err := svc.channelStreams(ctx, id)
+	if err != nil {
+		return err
+	}
+	rules, err := svc.channelRules(ctx, id, streams)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, r := range rules {
+		if err := svc.remove(ctx, RuleKind, r); err != nil {
+			failed = append(failed, fmt.Sprintf("rule %s: %s", r, err))
+		}
+	}
+	for s := range streams {
+		if err := svc.remove(ctx, StreamKind, s); err != nil {
+			failed = append(failed, fmt.Sprintf("stream %s: %s", s, err))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Wrap(ErrKuiperServer, errors.New(strings.Join(failed, "; ")))
+	}
+
+	return nil
+}
+
+// channelStreams returns the names of the Kuiper streams reading messages
+// from the channel with the given ID.
+func (svc *reService) channelStreams(ctx context.Context, id string) (map[string]bool, error) {
+	names, err := svc.engine.ListStreams(ctx, StreamKind)
+	if err != nil {
+		return nil, err
+	}
+
+	streams := make(map[string]bool)
+	for _, name := range names {
+		stream, err := svc.engine.ViewStream(ctx, StreamKind, name)
+		switch {
+		case errors.Contains(err, svcerr.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		if sourceChannel(stream) == id {
+			streams[name] = true
+		}
+	}
+
+	return streams, nil
+}
+
+// sourceChannel returns the ID of the channel the stream reads messages
+// from, or an empty string if the stream doesn't read from a channel.
+func sourceChannel(stream Stream) string {
+	opts := make(map[string]string, len(stream.Options))
+	for k, v := range stream.Options {
+		opts[strings.ToLower(k)] = v
+	}
+	typ, source := strings.ToLower(opts["type"]), opts["datasource"]
+	switch {
+	case typ == MainfluxSource:
+		return source
+	case typ == MQTTSource && strings.HasPrefix(source, "channels/") && strings.HasSuffix(source, "/messages"):
+		return strings.TrimSuffix(strings.TrimPrefix(source, "channels/"), "/messages")
+	default:
+		return ""
+	}
+}
+
+// channelRules returns the IDs of the Kuiper rules publishing to the channel
+// with the given ID or reading from any of the given streams.
+func (svc *reService) channelRules(ctx context.Context, id string, streams map[string]bool) ([]string, error) {
+	infos, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []string
+	for _, info := range infos {
+		kr, err := svc.engine.ViewRule(ctx, info.ID)
+		switch {
+		case errors.Contains(err, svcerr.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		if usesChannel(kr, id, streams) {
+			rules = append(rules, info.ID)
+		}
+	}
+
+	return rules, nil
+}
+
+// usesChannel reports whether the rule publishes to the channel, including
+// the notification actions and the dead letters, or reads from any of the
+// given streams.
+func usesChannel(kr EngineRule, id string, streams map[string]bool) bool {
+	for _, data := range kr.Actions {
+		var a Action
+		if err := json.Unmarshal(data, &a); err == nil && a.Mainflux != nil && a.Mainflux.Channel == id {
+			return true
+		}
+		if d, err := delivery(data); err == nil && d != nil && d.DeadLetter != nil && d.DeadLetter.Channel == id {
+			return true
+		}
+	}
+	used := false
+	_, _ = rewriteStreams(kr.SQL, func(name string) string {
+		used = used || streams[name]
+		return name
+	})
+
+	return used
+}
+
+// remove removes the Kuiper entity of the given kind and its metadata.
+// Entities that are already removed are ignored.
+func (svc *reService) remove(ctx context.Context, kind, name string) error {
+	var err error
+	if kind == RuleKind {
+		_, err = svc.engine.DeleteRule(ctx, name)
+	} else {
+		_, err = svc.engine.DeleteStream(ctx, kind, name)
+	}
+	if err != nil && !errors.Contains(err, svcerr.ErrNotFound) {
+		return err
+	}
+
+	return svc.removeMetadata(ctx, kind, name)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	otherChannelID = "c2d3e4f5-a6b7-4c8d-9e0f-1a2b3c4d5e6f"
+	domainID       = "d4e5f6a7-b8c9-4d0e-8f1a-2b3c4d5e6f7a"
+)
+
+func TestCreateChannelHandler(t *testing.T) {
+	stream := re.ChannelStream(channelID)
+
+	cases := []struct {
+		desc     string
+		admins   []string
+		existing bool
+		authErr  error
+		streams  []string
+		err      error
+	}{
+		{
+			desc:    "create channel",
+			admins:  []string{domainID + "_" + userID},
+			streams: []string{userPrefix + stream},
+		},
+		{
+			desc:     "create channel with existing stream",
+			admins:   []string{domainID + "_" + userID},
+			existing: true,
+			streams:  []string{userPrefix + stream},
+		},
+		{
+			desc:    "create channel with multiple administrators",
+			admins:  []string{domainID + "_" + userID, domainID + "_00000000-0000-0000-0000-000000000000"},
+			streams: []string{userPrefix + stream, otherPrefix + stream},
+		},
+		{
+			desc:    "create channel without administrators",
+			admins:  []string{},
+			streams: []string{},
+		},
+		{
+			desc:    "create channel with failed administrators lookup",
+			authErr: svcerr.ErrAuthorization,
+			streams: []string{},
+			err:     svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		k, url := newKuiper(t)
+		k.streams = map[string]string{}
+		if tc.existing {
+			k.streams[userPrefix+stream] = ""
+		}
+		auth := new(authmocks.AuthClient)
+		authCall := auth.On("ListAllSubjects", mock.Anything, &magistrala.ListSubjectsReq{
+			SubjectType: "user",
+			Permission:  "administrator",
+			Object:      channelID,
+			ObjectType:  "group",
+		}).Return(&magistrala.ListSubjectsRes{Policies: tc.admins}, tc.authErr)
+
+		cfg := re.Config{URL: url}
+		err := re.NewChannelsHandler(re.NewKuiper(cfg), cfg, auth, mocks.NewRepository()).CreateChannelHandler(context.Background(), channelID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.ElementsMatch(t, tc.streams, keys(k.streams), fmt.Sprintf("%s: expected streams %v got %v\n", tc.desc, tc.streams, keys(k.streams)))
+		if !tc.existing && tc.err == nil && len(tc.admins) > 0 {
+			assert.Contains(t, k.streams[userPrefix+stream], `TYPE = "mainflux"`, fmt.Sprintf("%s: expected SenML stream reading the channel\n", tc.desc))
+		}
+		authCall.Unset()
+	}
+}
+
+func TestRemoveChannelHandler(t *testing.T) {
+	cases := []struct {
+		desc     string
+		failures map[string]int
+		streams  []string
+		rules    []string
+		err      error
+	}{
+		{
+			desc:    "remove channel",
+			streams: []string{userPrefix + "other", otherPrefix + "stream"},
+			rules:   []string{userPrefix + "other", otherPrefix + "rule"},
+		},
+		{
+			desc:     "remove channel with failed stream listing",
+			failures: map[string]int{"/streams": http.StatusInternalServerError},
+			streams:  []string{userPrefix + "stream", userPrefix + "mqtt", userPrefix + "other", otherPrefix + "stream"},
+			rules:    []string{userPrefix + "rule", userPrefix + "notification", userPrefix + "other", otherPrefix + "rule"},
+			err:      re.ErrKuiperServer,
+		},
+		{
+			desc:     "remove channel with failed rule lookup",
+			failures: map[string]int{"/rules/" + userPrefix + "rule": http.StatusInternalServerError},
+			streams:  []string{userPrefix + "stream", userPrefix + "mqtt", userPrefix + "other", otherPrefix + "stream"},
+			rules:    []string{userPrefix + "rule", userPrefix + "notification", userPrefix + "other", otherPrefix + "rule"},
+			err:      re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		k, url := newKuiper(t)
+		k.failures = tc.failures
+		k.streams[userPrefix+"stream"] = `create stream ` + userPrefix + `stream () WITH (DATASOURCE = "` + channelID + `", FORMAT = "JSON", TYPE = "mainflux")`
+		k.streams[userPrefix+"mqtt"] = `create stream ` + userPrefix + `mqtt () WITH (DATASOURCE = "channels/` + channelID + `/messages", FORMAT = "JSON", TYPE = "mqtt")`
+		k.streams[userPrefix+"other"] = `create stream ` + userPrefix + `other () WITH (DATASOURCE = "` + otherChannelID + `", FORMAT = "JSON", TYPE = "mainflux")`
+		k.rules[userPrefix+"notification"] = re.Rule{
+			ID:      userPrefix + "notification",
+			SQL:     "SELECT * FROM " + userPrefix + "other",
+			Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID, Subtopic: "notifications.email.rule.0"}}},
+		}
+		k.rules[userPrefix+"other"] = re.Rule{
+			ID:      userPrefix + "other",
+			SQL:     "SELECT * FROM " + userPrefix + "other",
+			Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: otherChannelID}}},
+		}
+
+		cfg := re.Config{URL: url}
+		err := re.NewChannelsHandler(re.NewKuiper(cfg), cfg, new(authmocks.AuthClient), mocks.NewRepository()).RemoveChannelHandler(context.Background(), channelID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.ElementsMatch(t, tc.streams, keys(k.streams), fmt.Sprintf("%s: expected streams %v got %v\n", tc.desc, tc.streams, keys(k.streams)))
+		rules := make(map[string]string, len(k.rules))
+		for id := range k.rules {
+			rules[id] = ""
+		}
+		assert.ElementsMatch(t, tc.rules, keys(rules), fmt.Sprintf("%s: expected rules %v got %v\n", tc.desc, tc.rules, keys(rules)))
+	}
+}
+
+func keys(m map[string]string) []string {
+	var res []string
+	for k := range m {
+		res = append(res, k)
+	}
+
+	return res
+}
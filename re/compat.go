@@ -0,0 +1,146 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// adapter adapts the requests the service sends, written against the
+// eKuiper 1.x REST API, to the REST API of the Kuiper version the service
+// talks to.
+type adapter interface {
+	// route returns the path the backend serves the request on, or
+	// ErrNotSupported if the backend can't serve the request.
+	route(method, path string) (string, error)
+
+	// response converts the body of the successful response to the request
+	// on the given path to the form returned by eKuiper 1.x.
+	response(path string, body []byte) []byte
+}
+
+// kuiperAPI holds the adapter of the Kuiper version detected by the Info
+// call. Until the version is detected, eKuiper 1.x is assumed.
+type kuiperAPI struct {
+	mu      sync.RWMutex
+	adapter adapter
+}
+
+func (k *kuiperAPI) get() adapter {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.adapter == nil {
+		return ekuiperV1{}
+	}
+
+	return k.adapter
+}
+
+// detect sets the adapter for the Kuiper version, e.g. 1.10.2 or v0.4.2.
+func (k *kuiperAPI) detect(version string) {
+	var a adapter = ekuiperV1{}
+	if major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "."); major == "0" {
+		a = kuiperV0{}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.adapter = a
+}
+
+// ekuiperV1 passes the requests to eKuiper 1.x as they are.
+type ekuiperV1 struct{}
+
+func (ekuiperV1) route(_, path string) (string, error) {
+	return path, nil
+}
+
+func (ekuiperV1) response(_ string, body []byte) []byte {
+	return body
+}
+
+// kuiperV0 adapts the requests to Kuiper 0.x, which has no tables, external
+// services, source confKeys API, rule validation and rule tests, describes
+// streams with upper case option keys and reports the status of stopped
+// rules as plain text, e.g. "Stopped: canceled manually.".
+type kuiperV0 struct{}
+
+// v0Unsupported contains the prefixes of the eKuiper 1.x API paths Kuiper
+// 0.x doesn't serve.
+var v0Unsupported = []string{"/tables", validatePath, trialPath, servicesPath, "/metadata/"}
+
+func (kuiperV0) route(_, path string) (string, error) {
+	for _, p := range v0Unsupported {
+		if path == p || strings.HasPrefix(path, p+"/") || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return "", errors.Wrap(ErrNotSupported, errors.New(path))
+		}
+	}
+
+	return path, nil
+}
+
+func (kuiperV0) response(path string, body []byte) []byte {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[0] == "rules" && parts[2] == "status":
+		return v0Status(body)
+	case len(parts) == 2 && parts[0] == "streams":
+		return v0Stream(body)
+	default:
+		return body
+	}
+}
+
+// v0Status converts the Kuiper 0.x rule status to the eKuiper 1.x status,
+// which reports the state of the rule along with its metrics.
+func v0Status(body []byte) []byte {
+	var metrics map[string]interface{}
+	if err := json.Unmarshal(body, &metrics); err != nil {
+		state, msg, _ := strings.Cut(strings.TrimSpace(string(body)), ":")
+		metrics = map[string]interface{}{
+			"status":  strings.ToLower(strings.TrimSpace(state)),
+			"message": strings.TrimSpace(msg),
+		}
+	}
+	if _, ok := metrics["status"]; !ok {
+		metrics["status"] = "running"
+	}
+	data, err := json.Marshal(metrics)
+	if err != nil {
+		return body
+	}
+
+	return data
+}
+
+// v0Stream lower cases the option keys of the described stream.
+func v0Stream(body []byte) []byte {
+	var stream map[string]json.RawMessage
+	if err := json.Unmarshal(body, &stream); err != nil {
+		return body
+	}
+	var opts map[string]string
+	if err := json.Unmarshal(stream["Options"], &opts); err != nil {
+		return body
+	}
+	lower := make(map[string]string, len(opts))
+	for k, v := range opts {
+		lower[strings.ToLower(k)] = v
+	}
+	raw, err := json.Marshal(lower)
+	if err != nil {
+		return body
+	}
+	stream["Options"] = raw
+	data, err := json.Marshal(stream)
+	if err != nil {
+		return body
+	}
+
+	return data
+}
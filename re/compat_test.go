@@ -0,0 +1,132 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newKuiperV0 starts the fake Kuiper 0.x serving the stream and the stopped
+// rule of the user and returns the service using it along with the paths of
+// the requests the fake received.
+func newKuiperV0(t *testing.T) (re.Service, *[]string, *authmocks.AuthClient) {
+	url, paths := newKuiperV0Server(t)
+	auth := new(authmocks.AuthClient)
+
+	return re.New(re.Config{URL: url}, auth, new(sdkmocks.SDK), re.Notifiers{}, nil, mocks.NewRepository()), paths, auth
+}
+
+// newKuiperV0Server starts the fake Kuiper 0.x like newKuiperV0 and returns
+// its URL along with the paths of the requests it received.
+func newKuiperV0Server(t *testing.T) (string, *[]string) {
+	paths := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/":
+			_ = json.NewEncoder(w).Encode(re.Info{Version: "0.4.2", OS: "linux"})
+		case "/streams/" + userPrefix + "stream":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"Name":         userPrefix + "stream",
+				"StreamFields": []interface{}{},
+				"Options":      map[string]string{"DATASOURCE": "channels/" + channelID + "/messages", "TYPE": "mainflux"},
+			})
+		case "/rules/" + userPrefix + "rule/status":
+			_, _ = w.Write([]byte("Stopped: canceled manually."))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts.URL, &paths
+}
+
+func TestKuiperV0(t *testing.T) {
+	svc, paths, auth := newKuiperV0(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	info, err := svc.Info(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "0.4.2", info.Version, fmt.Sprintf("expected version 0.4.2 got %s", info.Version))
+
+	stream, err := svc.ViewStream(context.Background(), validToken, "stream")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	opts := map[string]string{"datasource": "channels/" + channelID + "/messages", "type": "mainflux"}
+	assert.Equal(t, opts, stream.Options, fmt.Sprintf("expected lower case options %v got %v", opts, stream.Options))
+
+	status, err := svc.RuleStatus(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := re.RuleStatus{Status: "stopped", Message: "canceled manually."}
+	assert.Equal(t, expected, status, fmt.Sprintf("expected status %v got %v", expected, status))
+
+	page, err := svc.ListTables(context.Background(), validToken, re.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Empty(t, page.Tables, fmt.Sprintf("expected no tables got %v", page.Tables))
+	_, err = svc.ViewTable(context.Background(), validToken, "table")
+	assert.True(t, errors.Contains(err, re.ErrNotSupported), fmt.Sprintf("expected %s got %s", re.ErrNotSupported, err))
+	_, err = svc.ListConfKeys(context.Background(), validToken)
+	assert.True(t, errors.Contains(err, re.ErrNotSupported), fmt.Sprintf("expected %s got %s", re.ErrNotSupported, err))
+	sent := []string{"/", "/streams/" + userPrefix + "stream", "/rules/" + userPrefix + "rule/status"}
+	assert.Equal(t, sent, *paths, fmt.Sprintf("expected unsupported requests not to be sent, got %v", *paths))
+}
+
+func TestKuiperV1(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.tables[userPrefix+"table"] = "create table " + userPrefix + "table () WITH (TYPE = \"memory\")"
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	_, err := svc.Info(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	page, err := svc.ListTables(context.Background(), validToken, re.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, []string{"table"}, page.Tables, fmt.Sprintf("expected own tables got %v", page.Tables))
+}
+
+func TestKuiperV0Instance(t *testing.T) {
+	_, url := newKuiper(t)
+	legacyURL, _ := newKuiperV0Server(t)
+	repo := mocks.NewRepository()
+	err := repo.SaveAssignment(context.Background(), userID, "legacy")
+	assert.Nil(t, err, fmt.Sprintf("save assignment: expected no error got %s\n", err))
+	cfg := re.Config{
+		URL: url,
+		Pool: re.PoolConfig{
+			Instances: map[string]string{"legacy": legacyURL},
+			Strategy:  re.StaticStrategy,
+			Refresh:   time.Minute,
+		},
+	}
+	auth := new(authmocks.AuthClient)
+	svc := re.NewWithEngine(re.NewEngine(cfg, repo), cfg, auth, new(sdkmocks.SDK), re.Notifiers{}, nil, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	info, err := svc.Info(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.NotEqual(t, "0.4.2", info.Version, "expected version of default instance")
+
+	// The user is on the pool instance, which is detected as Kuiper 0.x.
+	status, err := svc.RuleStatus(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	expected := re.RuleStatus{Status: "stopped", Message: "canceled manually."}
+	assert.Equal(t, expected, status, fmt.Sprintf("expected status %v got %v", expected, status))
+}
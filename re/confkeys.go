@@ -0,0 +1,169 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+const (
+	confKeysPath     = "/metadata/sources/mqtt/confKeys"
+	confKeysListPath = "/metadata/sources/yaml/mqtt"
+)
+
+var (
+	errConfQoS        = errors.New("qos must be 0, 1 or 2")
+	errConfProtocol   = errors.New("protocol version must be 3.1 or 3.1.1")
+	errConfCert       = errors.New("certificate, private key and root CA must be base64 encoded PEM")
+	errConfClientCert = errors.New("client certificate and private key must be set together")
+	errConfKeySource  = errors.New("only mqtt streams can use the conf key")
+	errConfKeyMissing = errors.New("conf key doesn't exist")
+
+	// protocolVersions contains the MQTT protocol versions supported by the
+	// Kuiper MQTT source.
+	protocolVersions = map[string]bool{
+		"3.1":   true,
+		"3.1.1": true,
+	}
+)
+
+// MQTTConf is the configuration of the connection to the MQTT broker the
+// Kuiper MQTT source subscribes with, stored in Kuiper as the confKey of the
+// mqtt source. Username and Password are usually the ID and the key of the
+// user's thing, so the broker authorizes the subscription of every user on
+// its own. The TLS client certificate, private key and root CA are base64
+// encoded PEM, since users can't store files on the Kuiper host.
+type MQTTConf struct {
+	Server             string `json:"server"`
+	Username           string `json:"username,omitempty"`
+	Password           string `json:"password,omitempty"`
+	ClientID           string `json:"clientid,omitempty"`
+	QoS                int    `json:"qos,omitempty"`
+	ProtocolVersion    string `json:"protocolVersion,omitempty"`
+	CertificationRaw   string `json:"certificationRaw,omitempty"`
+	PrivateKeyRaw      string `json:"privateKeyRaw,omitempty"`
+	RootCARaw          string `json:"rootCaRaw,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+func (svc *reService) SaveConfKey(ctx context.Context, token, name string, conf MQTTConf) (Result, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := validateName(name); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if err := conf.validate(); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.engine.SaveConfKey(ctx, prefix(userID)+name, conf)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(name, userID), nil
+}
+
+func (svc *reService) ListConfKeys(ctx context.Context, token string) ([]string, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := svc.confKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pfx := prefix(userID)
+	names := []string{}
+	for key := range keys {
+		if name, ok := strings.CutPrefix(key, pfx); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (svc *reService) DeleteConfKey(ctx context.Context, token, name string) (Result, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := validateName(name); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.engine.DeleteConfKey(ctx, prefix(userID)+name)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(name, userID), nil
+}
+
+// confKeys returns the names of all the confKeys of the Kuiper MQTT source,
+// including the default one.
+func (svc *reService) confKeys(ctx context.Context) (map[string]bool, error) {
+	names, err := svc.engine.ListConfKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(names))
+	for _, name := range names {
+		keys[name] = true
+	}
+
+	return keys, nil
+}
+
+// checkConfKey checks that the confKey the stream subscribes with exists,
+// since Kuiper silently falls back to the default confKey otherwise.
+func (svc *reService) checkConfKey(ctx context.Context, def StreamDef, pfx string) error {
+	if def.ConfKey == "" {
+		return nil
+	}
+	keys, err := svc.confKeys(ctx)
+	if err != nil {
+		return err
+	}
+	if !keys[pfx+def.ConfKey] {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errConfKeyMissing)
+	}
+
+	return nil
+}
+
+func (conf MQTTConf) validate() error {
+	u, err := url.Parse(conf.Server)
+	if err != nil || !brokerSchemes[u.Scheme] || u.Host == "" {
+		return errServer
+	}
+	if conf.QoS < 0 || conf.QoS > 2 {
+		return errConfQoS
+	}
+	if conf.ProtocolVersion != "" && !protocolVersions[conf.ProtocolVersion] {
+		return errConfProtocol
+	}
+	for _, raw := range []string{conf.CertificationRaw, conf.PrivateKeyRaw, conf.RootCARaw} {
+		if _, err := base64.StdEncoding.DecodeString(raw); err != nil {
+			return errConfCert
+		}
+	}
+	if (conf.CertificationRaw == "") != (conf.PrivateKeyRaw == "") {
+		return errConfClientCert
+	}
+
+	return nil
+}
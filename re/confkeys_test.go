@@ -0,0 +1,215 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const brokerServer = "ssl://mqtt.example.com:8883"
+
+func TestSaveConfKey(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	cases := []struct {
+		desc  string
+		token string
+		name  string
+		conf  re.MQTTConf
+		err   error
+	}{
+		{
+			desc:  "save conf key",
+			token: validToken,
+			name:  "broker",
+			conf:  re.MQTTConf{Server: brokerServer, Username: "thing", Password: "key", QoS: 1},
+		},
+		{
+			desc:  "replace conf key",
+			token: validToken,
+			name:  "broker",
+			conf:  re.MQTTConf{Server: brokerServer, Username: "thing", Password: "new key", ProtocolVersion: "3.1.1"},
+		},
+		{
+			desc:  "save conf key with TLS client certificate",
+			token: validToken,
+			name:  "tls",
+			conf:  re.MQTTConf{Server: brokerServer, CertificationRaw: "Y2VydA==", PrivateKeyRaw: "a2V5", RootCARaw: "Y2E="},
+		},
+		{
+			desc:  "save conf key with invalid token",
+			token: invalidToken,
+			name:  "other",
+			conf:  re.MQTTConf{Server: brokerServer},
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "save conf key with malformed name",
+			token: validToken,
+			name:  "../default",
+			conf:  re.MQTTConf{Server: brokerServer},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "save conf key with malformed server",
+			token: validToken,
+			name:  "other",
+			conf:  re.MQTTConf{Server: "http://mqtt.example.com"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "save conf key with invalid QoS",
+			token: validToken,
+			name:  "other",
+			conf:  re.MQTTConf{Server: brokerServer, QoS: 3},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "save conf key with unsupported protocol version",
+			token: validToken,
+			name:  "other",
+			conf:  re.MQTTConf{Server: brokerServer, ProtocolVersion: "5"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "save conf key with certificate file path",
+			token: validToken,
+			name:  "other",
+			conf:  re.MQTTConf{Server: brokerServer, RootCARaw: "/etc/ssl/ca.pem"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "save conf key with certificate without private key",
+			token: validToken,
+			name:  "other",
+			conf:  re.MQTTConf{Server: brokerServer, CertificationRaw: "Y2VydA=="},
+			err:   svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := svc.SaveConfKey(context.Background(), tc.token, tc.name, tc.conf)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.name, res.Name, fmt.Sprintf("%s: expected result name %s got %s\n", tc.desc, tc.name, res.Name))
+			conf := k.confKeys[userPrefix+tc.name]
+			assert.Equal(t, tc.conf, conf, fmt.Sprintf("%s: expected conf key %v got %v\n", tc.desc, tc.conf, conf))
+		}
+	}
+	_, ok := k.confKeys[userPrefix+"other"]
+	assert.False(t, ok, "expected invalid conf keys not to be saved")
+}
+
+func TestListConfKeys(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.confKeys[userPrefix+"broker"] = re.MQTTConf{Server: brokerServer}
+	k.confKeys[userPrefix+"backup"] = re.MQTTConf{Server: brokerServer}
+	k.confKeys[otherPrefix+"broker"] = re.MQTTConf{Server: brokerServer}
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	keys, err := svc.ListConfKeys(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, []string{"backup", "broker"}, keys, fmt.Sprintf("expected own conf keys got %v", keys))
+}
+
+func TestDeleteConfKey(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.confKeys[userPrefix+"broker"] = re.MQTTConf{Server: brokerServer}
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc string
+		name string
+		err  error
+	}{
+		{
+			desc: "delete conf key",
+			name: "broker",
+		},
+		{
+			desc: "delete non-existing conf key",
+			name: "broker",
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "delete conf key with path traversal",
+			name: "../default",
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := svc.DeleteConfKey(context.Background(), validToken, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.name, res.Name, fmt.Sprintf("%s: expected result name %s got %s\n", tc.desc, tc.name, res.Name))
+			_, ok := k.confKeys[userPrefix+tc.name]
+			assert.False(t, ok, fmt.Sprintf("%s: expected conf key to be removed\n", tc.desc))
+		}
+	}
+	_, ok := k.confKeys["default"]
+	assert.True(t, ok, "expected default conf key to be kept")
+}
+
+func TestCreateStreamWithConfKey(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.confKeys[userPrefix+"broker"] = re.MQTTConf{Server: brokerServer}
+	k.confKeys[otherPrefix+"foreign"] = re.MQTTConf{Server: brokerServer}
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	fields := []re.Field{{Name: "v", Type: re.FloatType}}
+	cases := []struct {
+		desc string
+		def  re.StreamDef
+		sql  string
+		err  error
+	}{
+		{
+			desc: "create mqtt stream with conf key",
+			def:  re.StreamDef{Name: "remote", Topic: channelID, Fields: fields, Type: re.MQTTSource, ConfKey: "broker"},
+			sql:  `create stream ` + userPrefix + `remote (v FLOAT) WITH (DATASOURCE = "channels/` + channelID + `/messages", FORMAT = "JSON", TYPE = "mqtt", CONF_KEY = "` + userPrefix + `broker")`,
+		},
+		{
+			desc: "create mqtt stream with non-existing conf key",
+			def:  re.StreamDef{Name: "missing", Topic: channelID, Fields: fields, Type: re.MQTTSource, ConfKey: "missing"},
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "create mqtt stream with conf key of other user",
+			def:  re.StreamDef{Name: "foreign", Topic: channelID, Fields: fields, Type: re.MQTTSource, ConfKey: "foreign"},
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "create mainflux stream with conf key",
+			def:  re.StreamDef{Name: "local", Topic: channelID, Fields: fields, ConfKey: "broker"},
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.CreateStream(context.Background(), validToken, tc.def, false)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		sql, ok := k.streams[userPrefix+tc.def.Name]
+		assert.Equal(t, tc.err == nil, ok, fmt.Sprintf("%s: expected stream to be created: %t\n", tc.desc, tc.err == nil))
+		assert.Equal(t, tc.sql, sql, fmt.Sprintf("%s: expected SQL %s got %s\n", tc.desc, tc.sql, sql))
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	// dataTemplateProp is the Kuiper sink property holding the data
+	// template.
+	dataTemplateProp = "dataTemplate"
+
+	// dataTemplateName names the data templates in the template errors.
+	dataTemplateName = "dataTemplate"
+)
+
+var (
+	errDataTemplate      = errors.New("malformed data template")
+	errDataTemplateTwice = errors.New("data template must be set either on the action or on the rest sink")
+
+	// templateErrRegexp matches the template errors, which hold the line and
+	// optionally the column of the error.
+	templateErrRegexp = regexp.MustCompile(`^template: ` + dataTemplateName + `:(\d+)(?::(\d+))?: (?:executing "` + dataTemplateName + `" )?(.*)$`)
+
+	// missingKeyRegexp matches the execution errors of the fields the
+	// sample record lacks.
+	missingKeyRegexp = regexp.MustCompile(`: map has no entry for key "[^"]*"$`)
+
+	// controlRegexp matches the template actions that only make sense along
+	// with the other actions, so they aren't parsed on their own.
+	controlRegexp = regexp.MustCompile(`^\{\{-?\s*(if|else|end|range|with|define|block|break|continue)\b`)
+
+	// kuiperFuncs are the functions Kuiper adds to the data templates.
+	kuiperFuncs = template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"base64": func(v interface{}) (string, error) {
+			switch v := v.(type) {
+			case string:
+				return base64.StdEncoding.EncodeToString([]byte(v)), nil
+			case []byte:
+				return base64.StdEncoding.EncodeToString(v), nil
+			default:
+				data, err := json.Marshal(v)
+				return base64.StdEncoding.EncodeToString(data), err
+			}
+		},
+	}
+
+	// sprigFuncs are the Sprig functions available in the Kuiper data
+	// templates. Templates calling them are only parsed, since they can't be
+	// rendered without Sprig.
+	sprigFuncs = strings.Fields(`
+		ago date dateInZone dateModify duration durationRound htmlDate htmlDateInZone mustDateModify mustToDate now toDate unixEpoch
+		abbrev abbrevboth trunc trim upper lower title untitle substr repeat trimAll trimSuffix trimPrefix nospace initials
+		randAlphaNum randAlpha randAscii randNumeric swapcase shuffle snakecase camelcase kebabcase wrap wrapWith
+		contains hasPrefix hasSuffix quote squote cat indent nindent replace plural sha1sum sha256sum adler32sum
+		toString atoi int64 int float64 seq toDecimal split splitList splitn toStrings until untilStep
+		add1 add sub div mod mul randInt add1f addf subf divf mulf biggest max min maxf minf ceil floor round
+		join sortAlpha default empty coalesce all any compact mustCompact fromJson toJson toPrettyJson toRawJson
+		mustFromJson mustToJson mustToPrettyJson mustToRawJson ternary deepCopy mustDeepCopy
+		typeOf typeIs typeIsLike kindOf kindIs deepEqual base dir clean ext isAbs b64enc b64dec b32enc b32dec
+		tuple list dict get set unset hasKey pluck keys pick omit merge mergeOverwrite mustMerge mustMergeOverwrite values
+		append push mustAppend mustPush prepend mustPrepend first mustFirst rest mustRest last mustLast
+		initial mustInitial reverse mustReverse uniq mustUniq without mustWithout has mustHas slice mustSlice concat dig chunk mustChunk
+		uuidv4 semver semverCompare fail regexMatch mustRegexMatch regexFindAll mustRegexFindAll regexFind mustRegexFind
+		regexReplaceAll mustRegexReplaceAll regexReplaceAllLiteral mustRegexReplaceAllLiteral regexSplit mustRegexSplit
+		regexQuoteMeta urlParse urlJoin`)
+
+	// sampleRecord is the SenML record the data templates are rendered with
+	// to catch the errors Kuiper would only report once the rule runs.
+	sampleRecord = map[string]interface{}{
+		"bn": "sensor:",
+		"bt": 1.7e9,
+		"bu": "Cel",
+		"n":  "temperature",
+		"u":  "Cel",
+		"v":  23.5,
+		"vs": "",
+		"vb": false,
+		"vd": "",
+		"s":  0.0,
+		"t":  1.7e9,
+		"ut": 0.0,
+	}
+)
+
+// validateTemplates validates the data templates of the action. The REST
+// sink accepts its own data template, so the action template must not be
+// set along with it.
+func (a Action) validateTemplates() error {
+	if a.REST != nil && a.REST.DataTemplate != "" {
+		if a.DataTemplate != "" {
+			return errDataTemplateTwice
+		}
+		return validateDataTemplate(a.REST.DataTemplate, a.REST.SendSingle)
+	}
+	single := (a.REST != nil && a.REST.SendSingle) || (a.MQTT != nil && a.MQTT.SendSingle)
+
+	return validateDataTemplate(a.DataTemplate, single)
+}
+
+// validateDataTemplate parses the data template and renders it with the
+// sample record, or with the list of the sample records unless the results
+// are sent one by one, so ranging over the record fields or formatting the
+// results list as a single result is caught. The results hold the fields
+// the rule selects, so the rendering stops without error at the first field
+// the sample record lacks. The returned error holds the line and the column
+// of the template error.
+func validateDataTemplate(text string, single bool) error {
+	if text == "" {
+		return nil
+	}
+	t, err := template.New(dataTemplateName).Funcs(templateFuncs()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return templateError(text, err)
+	}
+	sprig := make(map[string]bool, len(sprigFuncs))
+	for _, name := range sprigFuncs {
+		if _, ok := kuiperFuncs[name]; !ok {
+			sprig[name] = true
+		}
+	}
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil && calls(tmpl.Tree.Root, sprig) {
+			return nil
+		}
+	}
+	var data interface{} = []map[string]interface{}{sampleRecord}
+	if single {
+		data = sampleRecord
+	}
+	if err := t.Execute(io.Discard, data); err != nil && !missingKeyRegexp.MatchString(err.Error()) {
+		return templateError(text, err)
+	}
+
+	return nil
+}
+
+// templateFuncs returns the functions of the Kuiper data templates. Sprig
+// functions only need to be defined for the templates to be parsed.
+func templateFuncs() template.FuncMap {
+	funcs := make(template.FuncMap, len(sprigFuncs)+len(kuiperFuncs))
+	for _, name := range sprigFuncs {
+		funcs[name] = func(...interface{}) interface{} { return nil }
+	}
+	for name, f := range kuiperFuncs {
+		funcs[name] = f
+	}
+	// The references to the secrets are resolved before the rule is sent to
+	// Kuiper.
+	funcs["secret"] = func(string) string { return "" }
+
+	return funcs
+}
+
+// calls reports whether the template node calls any of the functions.
+func calls(node parse.Node, funcs map[string]bool) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, c := range n.Nodes {
+			if calls(c, funcs) {
+				return true
+			}
+		}
+	case *parse.ActionNode:
+		return calls(n.Pipe, funcs)
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, cmd := range n.Cmds {
+			if calls(cmd, funcs) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if calls(arg, funcs) {
+				return true
+			}
+		}
+	case *parse.ChainNode:
+		return calls(n.Node, funcs)
+	case *parse.IdentifierNode:
+		return funcs[n.Ident]
+	case *parse.IfNode:
+		return calls(n.Pipe, funcs) || calls(n.List, funcs) || calls(n.ElseList, funcs)
+	case *parse.RangeNode:
+		return calls(n.Pipe, funcs) || calls(n.List, funcs) || calls(n.ElseList, funcs)
+	case *parse.WithNode:
+		return calls(n.Pipe, funcs) || calls(n.List, funcs) || calls(n.ElseList, funcs)
+	case *parse.TemplateNode:
+		return calls(n.Pipe, funcs)
+	}
+
+	return false
+}
+
+// templateError returns the template error with the line and the column of
+// the error. Parse errors hold only the line, so the column is the one of
+// the first action on that line that fails to parse on its own.
+func templateError(text string, err error) error {
+	m := templateErrRegexp.FindStringSubmatch(err.Error())
+	if m == nil {
+		return errors.Wrap(errDataTemplate, err)
+	}
+	line, _ := strconv.Atoi(m[1])
+	var col int
+	switch m[2] {
+	case "":
+		col = errorColumn(text, line)
+	default:
+		// Execution errors hold the 0-based column.
+		col, _ = strconv.Atoi(m[2])
+		col++
+	}
+
+	return errors.Wrap(errDataTemplate, fmt.Errorf("line %d, column %d: %s", line, col, m[3]))
+}
+
+// errorColumn returns the column of the first action on the template line
+// that fails to parse on its own, or the column of the first action on the
+// line if every action parses.
+func errorColumn(text string, line int) int {
+	lines := strings.Split(text, "\n")
+	if line < 1 || line > len(lines) {
+		return 1
+	}
+	// offset is the offset of the line in the template.
+	offset := 0
+	for _, l := range lines[:line-1] {
+		offset += len(l) + 1
+	}
+	first := 0
+	for i := strings.Index(lines[line-1], "{{"); i >= 0; {
+		if first == 0 {
+			first = i + 1
+		}
+		action := text[offset+i:]
+		if end := strings.Index(action, "}}"); end >= 0 {
+			action = action[:end+2]
+		}
+		if !controlRegexp.MatchString(action) {
+			if _, err := template.New(dataTemplateName).Funcs(templateFuncs()).Parse(action); err != nil {
+				return i + 1
+			}
+		}
+		next := strings.Index(lines[line-1][i+2:], "{{")
+		if next < 0 {
+			break
+		}
+		i += next + 2
+	}
+	if first == 0 {
+		return 1
+	}
+
+	return first
+}
+
+// dataTemplate returns the data template set on the single sink of the
+// Kuiper action.
+func dataTemplate(data json.RawMessage) (string, error) {
+	var sinks map[string]struct {
+		DataTemplate string `json:"dataTemplate"`
+	}
+	if err := json.Unmarshal(data, &sinks); err != nil {
+		return "", err
+	}
+	for _, s := range sinks {
+		return s.DataTemplate, nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTemplates(t *testing.T) {
+	cases := []struct {
+		desc     string
+		action   Action
+		err      error
+		position string
+	}{
+		{
+			desc:   "action without template",
+			action: Action{Log: &LogSink{}},
+		},
+		{
+			desc:   "template of the results list",
+			action: Action{Mainflux: &MainfluxSink{Channel: streamChannel}, DataTemplate: `[{{range $i, $r := .}}{{if $i}},{{end}}{"temp":{{$r.v}}}{{end}}]`},
+		},
+		{
+			desc:   "template of the single result",
+			action: Action{MQTT: &MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms", SendSingle: true}, DataTemplate: `{"temp":{{.v}},"raw":{{json .}}}`},
+		},
+		{
+			desc:   "rest sink template",
+			action: Action{REST: &RESTSink{URL: "https://example.com/hook", SendSingle: true, DataTemplate: `{"text":"{{.n}} is {{.v}}"}`}},
+		},
+		{
+			desc:   "template calling sprig functions",
+			action: Action{Log: &LogSink{}, DataTemplate: `{{ (dict "temp" .v).temp | upper }}`},
+		},
+		{
+			desc:     "template with unclosed action",
+			action:   Action{Log: &LogSink{}, DataTemplate: `{"temp": {{ .v }}, "unit": {{ .u }`},
+			err:      errDataTemplate,
+			position: "line 1, column 28",
+		},
+		{
+			desc:     "template with malformed pipeline",
+			action:   Action{Log: &LogSink{}, DataTemplate: "[\n{\"temp\": {{.v}}, \"unit\": {{ .u ) }}}\n]"},
+			err:      errDataTemplate,
+			position: "line 2, column 26",
+		},
+		{
+			desc:     "template with unknown function",
+			action:   Action{Log: &LogSink{}, DataTemplate: "{{ .v }}\n{{ celsius .v }}"},
+			err:      errDataTemplate,
+			position: "line 2, column 1",
+		},
+		{
+			desc:     "template ranging over single result field",
+			action:   Action{REST: &RESTSink{URL: "https://example.com/hook", SendSingle: true, DataTemplate: `{{range .v}}{{.}}{{end}}`}},
+			err:      errDataTemplate,
+			position: "line 1, column 9",
+		},
+		{
+			desc:     "single result template of the results list",
+			action:   Action{Log: &LogSink{}, DataTemplate: `{"temp": {{.v}}}`},
+			err:      errDataTemplate,
+			position: "line 1, column 12",
+		},
+		{
+			desc:   "template of fields the rule selects",
+			action: Action{REST: &RESTSink{URL: "https://example.com/hook", SendSingle: true, DataTemplate: `{"alarm":{{.alarm}},"readings":[{{range $i, $r := .readings}}{{if $i}},{{end}}{{$r}}{{end}}]}`}},
+		},
+		{
+			desc:   "template of the results list with selected field",
+			action: Action{Log: &LogSink{}, DataTemplate: `{{range .}}{{if gt .avg_temp 30.0}}{{.avg_temp}}{{end}}{{end}}`},
+		},
+		{
+			desc:     "template ranging over single result field along with selected field",
+			action:   Action{MQTT: &MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms", SendSingle: true}, DataTemplate: `{{range .v}}{{.}}{{end}}{{.alarm}}`},
+			err:      errDataTemplate,
+			position: "line 1, column 9",
+		},
+		{
+			desc:   "template set on both action and rest sink",
+			action: Action{REST: &RESTSink{URL: "https://example.com/hook", DataTemplate: `{{json .}}`}, DataTemplate: `{{json .}}`},
+			err:    errDataTemplateTwice,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.action.validateTemplates()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.position != "" && err != nil {
+			assert.True(t, strings.Contains(err.Error(), tc.position), fmt.Sprintf("%s: expected error at %s got %s\n", tc.desc, tc.position, err))
+		}
+	}
+}
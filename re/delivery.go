@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// deadLetterPrefix prefixes the Kuiper resend destination of the dead
+// letters, followed by the channel ID and the optional subtopic.
+const deadLetterPrefix = "channels."
+
+var (
+	errDeliveryOption = errors.New("delivery retries, intervals and cache sizes must not be negative")
+	errBufferPage     = errors.New("cache sizes must be multiples of the buffer page size")
+	errDeadLetterSink = errors.New("dead letters are only supported by the sinks publishing to the broker")
+
+	// brokerSinks are the sinks Kuiper runs as the sinks publishing to the
+	// broker, which resend the dead letters to the topic of the dead-letter
+	// channel. The other sinks would take the resend destination for their
+	// own target, e.g. the URL path or the MQTT topic.
+	brokerSinks = map[string]bool{
+		MainfluxSinkType: true,
+		EmailSinkType:    true,
+		SMSSinkType:      true,
+	}
+)
+
+// Delivery sets how the Kuiper sink of the action delivers the results the
+// target fails to receive. The sink retries the delivery RetryCount times,
+// waiting RetryInterval milliseconds in between. With EnableCache, the
+// results that still fail are cached, up to MemoryCacheThreshold results in
+// memory and MaxDiskCache results on disk, in pages of BufferPageSize, and
+// resent every ResendInterval milliseconds once the target is back. The
+// cache is dropped when the rule stops if CleanCacheAtStop is set. Zero
+// values leave the Kuiper defaults.
+type Delivery struct {
+	RetryCount           int         `json:"retryCount,omitempty"`
+	RetryInterval        int64       `json:"retryInterval,omitempty"`
+	EnableCache          bool        `json:"enableCache,omitempty"`
+	MemoryCacheThreshold int         `json:"memoryCacheThreshold,omitempty"`
+	MaxDiskCache         int         `json:"maxDiskCache,omitempty"`
+	BufferPageSize       int         `json:"bufferPageSize,omitempty"`
+	ResendInterval       int64       `json:"resendInterval,omitempty"`
+	CleanCacheAtStop     bool        `json:"cleanCacheAtStop,omitempty"`
+	DeadLetter           *DeadLetter `json:"deadLetter,omitempty"`
+}
+
+// DeadLetter publishes the results the sink failed to deliver to the
+// Subtopic of the Magistrala Channel, so they aren't dropped while the
+// target is down. Dead letters enable the cache, which Kuiper resends
+// through the alternate queue to the dead-letter destination instead of the
+// target. Only the sinks publishing to the broker accept dead letters.
+type DeadLetter struct {
+	Channel  string `json:"channel"`
+	Subtopic string `json:"subtopic,omitempty"`
+}
+
+// kuiperDelivery holds the Kuiper sink properties the delivery is expanded
+// to, with the dead letter expanded to the alternate queue destination.
+type kuiperDelivery struct {
+	Delivery
+	ResendAlterQueue  bool   `json:"resendAlterQueue,omitempty"`
+	ResendDestination string `json:"resendDestination,omitempty"`
+}
+
+// validate validates the delivery of the sink of the given type.
+func (d *Delivery) validate(typ string) error {
+	if d == nil {
+		return nil
+	}
+	if d.RetryCount < 0 || d.RetryInterval < 0 || d.MemoryCacheThreshold < 0 || d.MaxDiskCache < 0 || d.BufferPageSize < 0 || d.ResendInterval < 0 {
+		return errDeliveryOption
+	}
+	if d.BufferPageSize > 0 && (d.MemoryCacheThreshold%d.BufferPageSize != 0 || d.MaxDiskCache%d.BufferPageSize != 0) {
+		return errBufferPage
+	}
+	if d.DeadLetter != nil {
+		if !brokerSinks[typ] {
+			return errDeadLetterSink
+		}
+		if d.DeadLetter.Channel == "" {
+			return errMissingChannel
+		}
+		return validateSubtopic(d.DeadLetter.Subtopic)
+	}
+
+	return nil
+}
+
+// props returns the Kuiper sink properties of the delivery.
+func (d *Delivery) props() (map[string]interface{}, error) {
+	props := map[string]interface{}{}
+	if d == nil {
+		return props, nil
+	}
+	kd := kuiperDelivery{Delivery: *d}
+	kd.Delivery.DeadLetter = nil
+	if dl := d.DeadLetter; dl != nil {
+		kd.EnableCache = true
+		kd.ResendAlterQueue = true
+		kd.ResendDestination = deadLetterPrefix + dl.Channel
+		if dl.Subtopic != "" {
+			kd.ResendDestination += "." + dl.Subtopic
+		}
+	}
+	opts, err := json.Marshal(kd)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(opts, &props); err != nil {
+		return nil, err
+	}
+
+	return props, nil
+}
+
+// withProps adds the properties to the single sink of the Kuiper action.
+func withProps(data json.RawMessage, props map[string]interface{}) (json.RawMessage, error) {
+	if len(props) == 0 {
+		return data, nil
+	}
+	var sinks map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &sinks); err != nil {
+		return nil, err
+	}
+	for typ, sink := range sinks {
+		if sink == nil {
+			sink = map[string]interface{}{}
+		}
+		for k, v := range props {
+			sink[k] = v
+		}
+		sinks[typ] = sink
+	}
+
+	return json.Marshal(sinks)
+}
+
+// delivery returns the delivery set on the single sink of the Kuiper
+// action. If the sink has none of the delivery properties, nil is returned.
+func delivery(data json.RawMessage) (*Delivery, error) {
+	var sinks map[string]kuiperDelivery
+	if err := json.Unmarshal(data, &sinks); err != nil {
+		return nil, err
+	}
+	for _, kd := range sinks {
+		d := kd.Delivery
+		if kd.ResendAlterQueue && strings.HasPrefix(kd.ResendDestination, deadLetterPrefix) {
+			parts := strings.SplitN(strings.TrimPrefix(kd.ResendDestination, deadLetterPrefix), ".", 2)
+			d.DeadLetter = &DeadLetter{Channel: parts[0]}
+			if len(parts) == 2 {
+				d.DeadLetter.Subtopic = parts[1]
+			}
+		}
+		if d != (Delivery{}) {
+			return &d, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDelivery(t *testing.T) {
+	cases := []struct {
+		desc     string
+		sink     string
+		delivery *Delivery
+		err      error
+	}{
+		{
+			desc:     "without delivery",
+			delivery: nil,
+		},
+		{
+			desc:     "valid delivery",
+			delivery: &Delivery{RetryCount: 3, RetryInterval: 500, EnableCache: true, MemoryCacheThreshold: 1024, MaxDiskCache: 4096, BufferPageSize: 256},
+		},
+		{
+			desc:     "valid dead letter",
+			sink:     MainfluxSinkType,
+			delivery: &Delivery{RetryCount: 3, DeadLetter: &DeadLetter{Channel: streamChannel, Subtopic: "failed.alarms"}},
+		},
+		{
+			desc:     "negative retry count",
+			delivery: &Delivery{RetryCount: -1},
+			err:      errDeliveryOption,
+		},
+		{
+			desc:     "negative resend interval",
+			delivery: &Delivery{EnableCache: true, ResendInterval: -10},
+			err:      errDeliveryOption,
+		},
+		{
+			desc:     "cache size not multiple of page size",
+			delivery: &Delivery{EnableCache: true, MemoryCacheThreshold: 1000, BufferPageSize: 256},
+			err:      errBufferPage,
+		},
+		{
+			desc:     "dead letter of notification sink",
+			sink:     EmailSinkType,
+			delivery: &Delivery{DeadLetter: &DeadLetter{Channel: streamChannel}},
+		},
+		{
+			desc:     "dead letter of rest sink",
+			sink:     RESTSinkType,
+			delivery: &Delivery{DeadLetter: &DeadLetter{Channel: streamChannel, Subtopic: "failed"}},
+			err:      errDeadLetterSink,
+		},
+		{
+			desc:     "dead letter of mqtt sink",
+			sink:     MQTTSinkType,
+			delivery: &Delivery{DeadLetter: &DeadLetter{Channel: streamChannel, Subtopic: "failed"}},
+			err:      errDeadLetterSink,
+		},
+		{
+			desc:     "dead letter without channel",
+			sink:     MainfluxSinkType,
+			delivery: &Delivery{DeadLetter: &DeadLetter{Subtopic: "failed"}},
+			err:      errMissingChannel,
+		},
+		{
+			desc:     "dead letter with wildcard subtopic",
+			sink:     MainfluxSinkType,
+			delivery: &Delivery{DeadLetter: &DeadLetter{Channel: streamChannel, Subtopic: "failed.>"}},
+			err:      ErrMalformedSubtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.delivery.validate(tc.sink)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestKuiperSinkProps(t *testing.T) {
+	cfg := WritersConfig{Postgres: SQLWriterConfig{URL: "postgres://postgres:5432/magistrala"}}
+	cases := []struct {
+		desc   string
+		action Action
+		props  map[string]interface{}
+	}{
+		{
+			desc:   "rest sink with retries",
+			action: Action{REST: &RESTSink{URL: "https://example.com/hook"}, Delivery: &Delivery{RetryCount: 3, RetryInterval: 500}},
+			props:  map[string]interface{}{"url": "https://example.com/hook", "retryCount": 3.0, "retryInterval": 500.0},
+		},
+		{
+			desc:   "mainflux sink with dead letter",
+			action: Action{Mainflux: &MainfluxSink{Channel: streamChannel, Subtopic: "alarms"}, Delivery: &Delivery{EnableCache: true, DeadLetter: &DeadLetter{Channel: streamChannel, Subtopic: "failed"}}},
+			props: map[string]interface{}{
+				"channel":           streamChannel,
+				"subtopic":          "alarms",
+				"enableCache":       true,
+				"resendAlterQueue":  true,
+				"resendDestination": "channels." + streamChannel + ".failed",
+			},
+		},
+		{
+			desc:   "writer sink with cache",
+			action: Action{Writer: &WriterSink{Type: PostgresWriter, Table: "alarms"}, Delivery: &Delivery{EnableCache: true, CleanCacheAtStop: true}},
+			props:  map[string]interface{}{"url": cfg.Postgres.URL, "table": streamPfx + "alarms", "enableCache": true, "cleanCacheAtStop": true},
+		},
+		{
+			desc:   "mainflux sink with data template",
+			action: Action{Mainflux: &MainfluxSink{Channel: streamChannel}, DataTemplate: `{{json .}}`, Delivery: &Delivery{RetryCount: 1}},
+			props:  map[string]interface{}{"channel": streamChannel, "dataTemplate": `{{json .}}`, "retryCount": 1.0},
+		},
+		{
+			desc:   "log sink without delivery",
+			action: Action{Log: &LogSink{}},
+			props:  map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		kr, err := toKuiper(Rule{ID: streamPfx + "rule", Actions: []Action{tc.action}}, streamPfx, cfg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		var sinks map[string]map[string]interface{}
+		err = json.Unmarshal(kr.Actions[0], &sinks)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Len(t, sinks, 1, fmt.Sprintf("%s: expected single sink got %v\n", tc.desc, sinks))
+		for _, props := range sinks {
+			assert.Equal(t, tc.props, props, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.props, props))
+		}
+
+		rule, err := fromKuiper(kr, streamPfx, cfg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, []Action{tc.action}, rule.Actions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.action, rule.Actions))
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package re contains the domain concept definitions needed to support
+// Magistrala rules engine service functionality.
+//
+// The rules engine service manages Kuiper streams and rules on behalf of
+// Magistrala users. Every stream and rule is namespaced with a prefix derived
+// from the owner ID, so users can only see and manage their own entities.
+package re
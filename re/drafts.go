@@ -0,0 +1,246 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// RuleDraft is the status of the draft rules, which are stored only in the
+// metadata and aren't deployed to Kuiper.
+const RuleDraft = "draft"
+
+var (
+	errDraftMissing = errors.New("draft rule not found")
+	errDraftExists  = errors.New("rule with the same ID is a draft")
+	errPublished    = errors.New("rule is already published")
+	errUnpublished  = errors.New("rule is already a draft")
+)
+
+func (svc *reService) SaveDraft(ctx context.Context, token string, rule Rule) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	owner, id, err := svc.namespace(ctx, token, userID, rule.ID)
+	if err != nil {
+		return Result{}, err
+	}
+	rule.ID = id
+	if rule.Actions, err = svc.unmask(ctx, owner, id, rule.Actions); err != nil {
+		return Result{}, err
+	}
+	if err := validateName(rule.ID); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if err := rule.Options.validate(); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if len(rule.Actions) == 0 {
+		return Result{}, svcerr.ErrMalformedEntity
+	}
+	if err := svc.authorizeActions(ctx, token, rule.Actions); err != nil {
+		return Result{}, err
+	}
+	definition, err := svc.sealedDefinition(rule)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	kuiperID := prefix(owner) + id
+	old, err := svc.metadata(ctx, RuleKind, kuiperID)
+	if err != nil {
+		return Result{}, err
+	}
+	if old != nil && !old.Draft {
+		return Result{}, errors.Wrap(svcerr.ErrConflict, errPublished)
+	}
+	// Rules created before their metadata was stored are only in Kuiper.
+	if old == nil {
+		_, err := svc.engine.ViewRule(ctx, kuiperID)
+		switch {
+		case err == nil:
+			return Result{}, errors.Wrap(svcerr.ErrConflict, errPublished)
+		case !errors.Contains(err, svcerr.ErrNotFound):
+			return Result{}, err
+		}
+	}
+
+	md := Metadata{Owner: owner, Description: rule.Description, Labels: rule.Labels, Attributes: rule.Attributes, Folder: old.folder(), ExpiresAt: rule.ExpiresAt, Schedule: rule.Schedule, Draft: true, Definition: definition}
+	if err := svc.saveMetadata(ctx, RuleKind, id, md, old != nil); err != nil {
+		return Result{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, id, AuditDraft, old.definition(), definition); err != nil {
+		return Result{}, err
+	}
+	res := Result{Status: http.StatusCreated, Message: fmt.Sprintf("Rule %s was saved as draft.", id)}
+	if old != nil {
+		res.Status = http.StatusOK
+	}
+
+	return res.owned(id, owner), nil
+}
+
+func (svc *reService) PublishRule(ctx context.Context, token, id string) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return Result{}, err
+	}
+	kuiperID := prefix(owner) + id
+	md, err := svc.metadata(ctx, RuleKind, kuiperID)
+	if err != nil {
+		return Result{}, err
+	}
+	switch {
+	case md == nil:
+		return Result{}, errors.Wrap(svcerr.ErrNotFound, errDraftMissing)
+	case !md.Draft:
+		return Result{}, errors.Wrap(svcerr.ErrConflict, errPublished)
+	}
+	var rule Rule
+	if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	// The access to the action channels may have been revoked since the
+	// draft was saved.
+	if err := svc.authorizeActions(ctx, token, rule.Actions); err != nil {
+		return Result{}, err
+	}
+	kr, err := svc.namespaceRule(ctx, rule, owner)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	release, err := svc.reserve(ctx, owner, RuleKind)
+	if err != nil {
+		return Result{}, err
+	}
+	defer release()
+
+	res, err := svc.engine.CreateRule(ctx, kr)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.subscribe(token, rule); err != nil {
+		_ = svc.unsubscribe(token, rule)
+		_, _ = svc.engine.DeleteRule(ctx, kr.ID)
+		return Result{}, err
+	}
+	md.Draft = false
+	md.UpdatedAt = time.Now().UTC()
+	md.LastStartedAt = md.UpdatedAt
+	if err := svc.repo.Save(ctx, RuleKind, kuiperID, *md); err != nil {
+		_ = svc.unsubscribe(token, rule)
+		_, _ = svc.engine.DeleteRule(ctx, kr.ID)
+		return Result{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, id, AuditPublish, "", md.Definition); err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(id, owner), nil
+}
+
+func (svc *reService) UnpublishRule(ctx context.Context, token, id string) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return Result{}, err
+	}
+	kuiperID := prefix(owner) + id
+	md, err := svc.metadata(ctx, RuleKind, kuiperID)
+	if err != nil {
+		return Result{}, err
+	}
+	if md != nil && md.Draft {
+		return Result{}, errors.Wrap(svcerr.ErrConflict, errUnpublished)
+	}
+	if _, err := svc.engine.ViewRule(ctx, kuiperID); err != nil {
+		return Result{}, err
+	}
+	if md == nil || md.Definition == "" {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, errMissingDefinition)
+	}
+	var rule Rule
+	if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.engine.DeleteRule(ctx, kuiperID)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.unsubscribe(token, rule); err != nil {
+		return Result{}, err
+	}
+	md.Draft, md.Stopped = true, false
+	md.UpdatedAt = time.Now().UTC()
+	if err := svc.repo.Save(ctx, RuleKind, kuiperID, *md); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, id, AuditUnpublish, md.Definition, ""); err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(id, owner), nil
+}
+
+// deleteDraft removes the draft rule with the given Kuiper ID, which has no
+// subscriptions since it was never deployed.
+func (svc *reService) deleteDraft(ctx context.Context, kuiperID, id, owner string) (Result, error) {
+	if err := svc.removeMetadata(ctx, RuleKind, kuiperID); err != nil {
+		return Result{}, err
+	}
+	if err := svc.removeShares(ctx, RuleKind, kuiperID); err != nil {
+		return Result{}, err
+	}
+	res := Result{Status: http.StatusOK, Message: fmt.Sprintf("Draft rule %s is dropped.", id)}
+
+	return res.owned(id, owner), nil
+}
+
+// draftRule returns the draft rule from its stored definition, which already
+// contains the notification contacts.
+func draftRule(id string, md *Metadata) (Rule, error) {
+	var rule Rule
+	if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+		return Rule{}, errors.Wrap(errReadResponse, err)
+	}
+	rule.ID = id
+	rule.Description, rule.Labels, rule.Attributes, rule.Metadata = md.Description, md.Labels, md.Attributes, md
+	rule.ExpiresAt, rule.Schedule = md.ExpiresAt, md.Schedule
+
+	return rule, nil
+}
+
+// checkDraft checks that the rule with the given Kuiper ID isn't a draft, so
+// creating the rule doesn't replace the draft, which is published instead.
+// Deleted rules are restored or purged instead.
+func (svc *reService) checkDraft(ctx context.Context, kuiperID string) error {
+	md, err := svc.metadata(ctx, RuleKind, kuiperID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case md != nil && md.deleted():
+		return errors.Wrap(svcerr.ErrConflict, errRuleDeleted)
+	case md != nil && md.Draft:
+		return errors.Wrap(svcerr.ErrConflict, errDraftExists)
+	}
+
+	return nil
+}
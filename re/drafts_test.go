@@ -0,0 +1,152 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDrafts(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "temperature", Topic: channelID, SenML: true}, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+	rule := re.Rule{
+		ID:          "alarm",
+		SQL:         "SELECT * FROM temperature WHERE v > 30",
+		Description: "overheating",
+		Actions:     []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+	}
+
+	saveCases := []struct {
+		desc   string
+		rule   re.Rule
+		status int
+		err    error
+	}{
+		{
+			desc:   "save new draft",
+			rule:   rule,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "save existing draft",
+			rule:   rule,
+			status: http.StatusOK,
+		},
+		{
+			desc: "save draft of deployed rule",
+			rule: re.Rule{ID: "rule", SQL: rule.SQL, Actions: rule.Actions},
+			err:  svcerr.ErrConflict,
+		},
+		{
+			desc: "save draft without actions",
+			rule: re.Rule{ID: "empty", SQL: rule.SQL},
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range saveCases {
+		res, err := svc.SaveDraft(context.Background(), validToken, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected status %d got %d\n", tc.desc, tc.status, res.Status))
+		}
+	}
+	_, ok := k.rules[userPrefix+rule.ID]
+	assert.False(t, ok, "expected draft not to be deployed")
+
+	draft, err := svc.ViewRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("view draft: expected no error got %s\n", err))
+	assert.Equal(t, rule.SQL, draft.SQL, fmt.Sprintf("view draft: expected SQL %s got %s\n", rule.SQL, draft.SQL))
+	assert.Equal(t, rule.Description, draft.Description, fmt.Sprintf("view draft: expected description %s got %s\n", rule.Description, draft.Description))
+	assert.True(t, draft.Metadata.Draft, "view draft: expected draft metadata")
+
+	page, err := svc.ListRules(context.Background(), validToken, re.PageMetadata{Limit: 10, Name: rule.ID})
+	assert.Nil(t, err, fmt.Sprintf("list rules: expected no error got %s\n", err))
+	assert.Equal(t, []re.RuleInfo{{ID: rule.ID, Status: re.RuleDraft, Metadata: draft.Metadata}}, page.Rules, fmt.Sprintf("list rules: expected draft got %v\n", page.Rules))
+
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("create rule with draft ID: expected %s got %s\n", svcerr.ErrConflict, err))
+
+	cases := []struct {
+		desc    string
+		publish bool
+		id      string
+		draft   bool
+		err     error
+	}{
+		{
+			desc:    "publish draft",
+			publish: true,
+			id:      rule.ID,
+		},
+		{
+			desc:    "publish published rule",
+			publish: true,
+			id:      rule.ID,
+			err:     svcerr.ErrConflict,
+		},
+		{
+			desc:    "publish non-existing draft",
+			publish: true,
+			id:      "unknown",
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:  "unpublish rule",
+			id:    rule.ID,
+			draft: true,
+		},
+		{
+			desc: "unpublish draft",
+			id:   rule.ID,
+			err:  svcerr.ErrConflict,
+		},
+		{
+			desc: "unpublish rule created before definitions were stored",
+			id:   "rule",
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		command := svc.UnpublishRule
+		if tc.publish {
+			command = svc.PublishRule
+		}
+		_, err := command(context.Background(), validToken, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		_, ok := k.rules[userPrefix+tc.id]
+		assert.Equal(t, !tc.draft, ok, fmt.Sprintf("%s: expected deployed %t got %t\n", tc.desc, !tc.draft, ok))
+		md, err := repo.Retrieve(context.Background(), re.RuleKind, userPrefix+tc.id)
+		assert.Nil(t, err, fmt.Sprintf("%s: retrieve metadata: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.draft, md.Draft, fmt.Sprintf("%s: expected draft %t got %t\n", tc.desc, tc.draft, md.Draft))
+	}
+
+	_, err = svc.DeleteRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("delete draft: expected no error got %s\n", err))
+	_, err = repo.Retrieve(context.Background(), re.RuleKind, userPrefix+rule.ID)
+	assert.NotNil(t, err, "delete draft: expected metadata to be removed")
+}
@@ -0,0 +1,458 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+)
+
+// Statuses of the rules deployed to the edge gateways. Deployments are
+// pending until the gateway reports the rule running or failed, and
+// removing until the gateway reports the rule removed.
+const (
+	DeployPending  = "pending"
+	DeployRunning  = "running"
+	DeployFailed   = "failed"
+	DeployRemoving = "removing"
+	DeployRemoved  = "removed"
+)
+
+// Subtopics of the gateway control channel the commands are published to
+// and the gateways report the deployment statuses to.
+const (
+	ControlSubtopic = "re.control"
+	StatusSubtopic  = "re.status"
+)
+
+const (
+	deployCommand   = "deploy"
+	undeployCommand = "delete"
+	edgePublisher   = "re"
+)
+
+var (
+	errEdgeDisabled          = errors.New("edge deployment is not configured")
+	errNoControlChannel      = errors.New("gateway has no control channel")
+	errGatewayTaken          = errors.New("gateway is registered by another user")
+	errGatewayInUse          = errors.New("gateway has rules deployed")
+	errDeployStatus          = errors.New("unknown deployment status")
+	errMissingEdgeDefinition = errors.New("entity created before its definition was stored can't be deployed")
+)
+
+// Gateway is the edge gateway running its own Kuiper instance. ID is the ID
+// of the gateway thing and Channel the control channel the gateway receives
+// the commands on and reports the deployment statuses to.
+type Gateway struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name,omitempty"`
+	Channel   string            `json:"channel"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Owner     string            `json:"-"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// Deployment is the rule deployed to the edge gateway. Error is the reason
+// the gateway reported the rule failed.
+type Deployment struct {
+	Rule      string    `json:"rule"`
+	Gateway   string    `json:"gateway"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DeploymentReport is the deployment status the gateway reported. The rule
+// ID is the ID of the rule on the gateway, without the owner prefix.
+type DeploymentReport struct {
+	Gateway string `json:"-"`
+	Channel string `json:"-"`
+	Rule    string `json:"rule"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// EdgeRepository specifies the edge gateway and deployment persistence API.
+// Deployments are identified by the Kuiper name of the rule, which contains
+// the owner prefix, and the gateway ID.
+type EdgeRepository interface {
+	// SaveGateway stores the gateway, replacing the existing one.
+	SaveGateway(ctx context.Context, gw Gateway) error
+
+	// RetrieveGateway returns the gateway with the given ID.
+	RetrieveGateway(ctx context.Context, id string) (Gateway, error)
+
+	// RetrieveGateways returns the gateways of the owner.
+	RetrieveGateways(ctx context.Context, owner string) ([]Gateway, error)
+
+	// RemoveGateway removes the gateway with the given ID.
+	RemoveGateway(ctx context.Context, id string) error
+
+	// SaveDeployment stores the deployment, replacing the existing one.
+	SaveDeployment(ctx context.Context, d Deployment) error
+
+	// RetrieveDeployments returns the deployments of the rule to the
+	// gateway. Empty rule or gateway match all the rules or gateways.
+	RetrieveDeployments(ctx context.Context, rule, gateway string) ([]Deployment, error)
+
+	// RemoveDeployment removes the deployment of the rule to the gateway.
+	RemoveDeployment(ctx context.Context, rule, gateway string) error
+}
+
+// DeploymentsHandler handles the deployment statuses the edge gateways
+// report. Reports are consumed from the message broker and never exposed
+// over the API.
+type DeploymentsHandler interface {
+	// ReportDeploymentHandler updates the status of the rule deployment the
+	// gateway reported. Reports of unknown gateways or deployments and the
+	// reports published to other channels than the gateway control channel
+	// are ignored.
+	ReportDeploymentHandler(ctx context.Context, r DeploymentReport) error
+}
+
+var _ DeploymentsHandler = (*reService)(nil)
+
+// NewDeploymentsHandler instantiates the deployments handler using the
+// given repository.
+func NewDeploymentsHandler(repo Repository) DeploymentsHandler {
+	return newService(nil, Config{}, nil, nil, Notifiers{}, nil, repo)
+}
+
+// edgeCommand is the command published to the gateway control channel. The
+// deploy command carries the Kuiper rule along with the DDL of the streams
+// and tables it reads from, and the delete command only the rule ID.
+type edgeCommand struct {
+	Command string      `json:"command"`
+	Rule    *EngineRule `json:"rule,omitempty"`
+	ID      string      `json:"id,omitempty"`
+	Streams []string    `json:"streams,omitempty"`
+}
+
+func (svc *reService) SaveGateway(ctx context.Context, token string, gw Gateway) (Gateway, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Gateway{}, err
+	}
+	if gw.ID == "" {
+		return Gateway{}, svcerr.ErrMalformedEntity
+	}
+	old, err := svc.repo.RetrieveGateway(ctx, gw.ID)
+	switch {
+	case err == nil && old.Owner != userID:
+		return Gateway{}, errors.Wrap(svcerr.ErrConflict, errGatewayTaken)
+	case err == nil:
+		gw.CreatedAt = old.CreatedAt
+	case errors.Contains(err, repoerr.ErrNotFound):
+		gw.CreatedAt = time.Now().UTC()
+	default:
+		return Gateway{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// The gateways provisioned through the bootstrap service use the first
+	// channel of their bootstrap configuration as the control channel.
+	if gw.Channel == "" {
+		if gw.Channel, err = svc.bootstrapChannel(gw.ID, token); err != nil {
+			return Gateway{}, err
+		}
+	}
+	if err := svc.authorizeChannel(ctx, token, gw.Channel); err != nil {
+		return Gateway{}, err
+	}
+	gw.Owner = userID
+	if err := svc.repo.SaveGateway(ctx, gw); err != nil {
+		return Gateway{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return gw, nil
+}
+
+func (svc *reService) ListGateways(ctx context.Context, token string) ([]Gateway, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	gws, err := svc.repo.RetrieveGateways(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	sort.Slice(gws, func(i, j int) bool {
+		return gws[i].ID < gws[j].ID
+	})
+
+	return gws, nil
+}
+
+func (svc *reService) RemoveGateway(ctx context.Context, token, id string) error {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return err
+	}
+	if _, err := svc.gateway(ctx, userID, id); err != nil {
+		return err
+	}
+	deployed, err := svc.repo.RetrieveDeployments(ctx, "", id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if len(deployed) > 0 {
+		return errors.Wrap(svcerr.ErrConflict, errGatewayInUse)
+	}
+	if err := svc.repo.RemoveGateway(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *reService) DeployRule(ctx context.Context, token, id, gateway string) (Deployment, error) {
+	if svc.edge == nil {
+		return Deployment{}, errors.Wrap(svcerr.ErrMalformedEntity, errEdgeDisabled)
+	}
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Deployment{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return Deployment{}, err
+	}
+	gw, err := svc.gateway(ctx, owner, gateway)
+	if err != nil {
+		return Deployment{}, err
+	}
+	cmd, err := svc.deployCommand(ctx, owner, id)
+	if err != nil {
+		return Deployment{}, err
+	}
+	d, err := svc.sendCommand(ctx, gw, prefix(owner)+id, DeployPending, cmd)
+	if err != nil {
+		return Deployment{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, id, AuditDeploy, "", gw.ID); err != nil {
+		return Deployment{}, err
+	}
+	d.Rule = id
+
+	return d, nil
+}
+
+func (svc *reService) UndeployRule(ctx context.Context, token, id, gateway string) (Deployment, error) {
+	if svc.edge == nil {
+		return Deployment{}, errors.Wrap(svcerr.ErrMalformedEntity, errEdgeDisabled)
+	}
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Deployment{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return Deployment{}, err
+	}
+	gw, err := svc.gateway(ctx, owner, gateway)
+	if err != nil {
+		return Deployment{}, err
+	}
+	name := prefix(owner) + id
+	deployed, err := svc.repo.RetrieveDeployments(ctx, name, gw.ID)
+	if err != nil {
+		return Deployment{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if len(deployed) == 0 {
+		return Deployment{}, svcerr.ErrNotFound
+	}
+	d, err := svc.sendCommand(ctx, gw, name, DeployRemoving, edgeCommand{Command: undeployCommand, ID: id})
+	if err != nil {
+		return Deployment{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, id, AuditUndeploy, gw.ID, ""); err != nil {
+		return Deployment{}, err
+	}
+	d.Rule = id
+
+	return d, nil
+}
+
+func (svc *reService) ListDeployments(ctx context.Context, token, id string) ([]Deployment, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ViewAccess)
+	if err != nil {
+		return nil, err
+	}
+	deployed, err := svc.repo.RetrieveDeployments(ctx, prefix(owner)+id, "")
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for i := range deployed {
+		deployed[i].Rule = id
+	}
+	sort.Slice(deployed, func(i, j int) bool {
+		return deployed[i].Gateway < deployed[j].Gateway
+	})
+
+	return deployed, nil
+}
+
+func (svc *reService) ReportDeploymentHandler(ctx context.Context, r DeploymentReport) error {
+	gw, err := svc.repo.RetrieveGateway(ctx, r.Gateway)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	case gw.Channel != r.Channel:
+		return nil
+	}
+	name := prefix(gw.Owner) + r.Rule
+	deployed, err := svc.repo.RetrieveDeployments(ctx, name, gw.ID)
+	if err != nil || len(deployed) == 0 {
+		return err
+	}
+
+	switch r.Status {
+	case DeployRemoved:
+		if err := svc.repo.RemoveDeployment(ctx, name, gw.ID); err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+			return err
+		}
+		return nil
+	case DeployRunning, DeployFailed:
+		d := Deployment{Rule: name, Gateway: gw.ID, Status: r.Status, Error: r.Error, UpdatedAt: time.Now().UTC()}
+		return svc.repo.SaveDeployment(ctx, d)
+	default:
+		return errDeployStatus
+	}
+}
+
+// gateway returns the owner's gateway with the given ID. Gateways of other
+// users aren't found.
+func (svc *reService) gateway(ctx context.Context, owner, id string) (Gateway, error) {
+	gw, err := svc.repo.RetrieveGateway(ctx, id)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound) || (err == nil && gw.Owner != owner):
+		return Gateway{}, svcerr.ErrNotFound
+	case err != nil:
+		return Gateway{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return gw, nil
+}
+
+// bootstrapChannel returns the first channel of the gateway's bootstrap
+// configuration.
+func (svc *reService) bootstrapChannel(id, token string) (string, error) {
+	cfg, sdkErr := svc.sdk.ViewBootstrap(id, token)
+	if sdkErr != nil {
+		return "", errors.Wrap(svcerr.ErrMalformedEntity, sdkErr)
+	}
+	switch chs := cfg.Channels.(type) {
+	case []string:
+		if len(chs) > 0 {
+			return chs[0], nil
+		}
+	case []mgsdk.Channel:
+		if len(chs) > 0 {
+			return chs[0].ID, nil
+		}
+	}
+
+	return "", errors.Wrap(svcerr.ErrMalformedEntity, errNoControlChannel)
+}
+
+// deployCommand returns the command deploying the owner's rule, created
+// from the stored definitions of the rule and the streams and tables it
+// reads from, with the names the owner sees.
+func (svc *reService) deployCommand(ctx context.Context, owner, id string) (edgeCommand, error) {
+	pfx := prefix(owner)
+	md, err := svc.metadata(ctx, RuleKind, pfx+id)
+	if err != nil {
+		return edgeCommand{}, err
+	}
+	if md == nil {
+		// Rules created before the metadata was stored exist only in Kuiper.
+		if _, err := svc.engine.ViewRule(ctx, pfx+id); err != nil {
+			return edgeCommand{}, err
+		}
+	}
+	if md.definition() == "" {
+		return edgeCommand{}, errors.Wrap(svcerr.ErrMalformedEntity, errMissingEdgeDefinition)
+	}
+	var rule Rule
+	if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+		return edgeCommand{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	rule.ID = id
+	// The gateway Kuiper gets the sensitive values and the secrets as well.
+	if rule.Actions, err = svc.openActions(rule.Actions); err != nil {
+		return edgeCommand{}, err
+	}
+	if rule.Actions, err = svc.resolveSecrets(ctx, owner, rule.Actions); err != nil {
+		return edgeCommand{}, err
+	}
+	kr, err := toKuiper(rule, "", svc.writers)
+	if err != nil {
+		return edgeCommand{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	cmd := edgeCommand{Command: deployCommand, Rule: &kr}
+	for _, name := range readStreams(rule.SQL, "") {
+		sql, err := svc.edgeDDL(ctx, pfx, name)
+		if err != nil {
+			return edgeCommand{}, err
+		}
+		cmd.Streams = append(cmd.Streams, sql)
+	}
+
+	return cmd, nil
+}
+
+// edgeDDL returns the DDL of the owner's stream or table with the given
+// name, created from its stored definition.
+func (svc *reService) edgeDDL(ctx context.Context, pfx, name string) (string, error) {
+	for _, kind := range []string{StreamKind, TableKind} {
+		md, err := svc.metadata(ctx, kind, pfx+name)
+		if err != nil {
+			return "", err
+		}
+		if sql := definitionSQL(kind, name, md.definition()); sql != "" {
+			return sql, nil
+		}
+	}
+
+	return "", errors.Wrap(svcerr.ErrMalformedEntity, errMissingEdgeDefinition)
+}
+
+// sendCommand publishes the command to the gateway control channel and
+// stores the deployment of the rule with the given Kuiper name in the
+// given status.
+func (svc *reService) sendCommand(ctx context.Context, gw Gateway, name, status string, cmd edgeCommand) (Deployment, error) {
+	payload, err := json.Marshal(cmd)
+	if err != nil {
+		return Deployment{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	msg := &messaging.Message{
+		Channel:   gw.Channel,
+		Subtopic:  ControlSubtopic,
+		Publisher: edgePublisher,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+	if err := svc.edge.Publish(ctx, gw.Channel, msg); err != nil {
+		return Deployment{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	d := Deployment{Rule: name, Gateway: gw.ID, Status: status, UpdatedAt: time.Now().UTC()}
+	if err := svc.repo.SaveDeployment(ctx, d); err != nil {
+		return Deployment{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return d, nil
+}
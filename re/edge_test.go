@@ -0,0 +1,243 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const gatewayID = "1bb1a2b0-8b8a-4b8c-9a68-0d0e1b1b1b1b"
+
+// publisher records the messages published to the gateway control channels,
+// or fails publishing them while failing is set.
+type publisher struct {
+	mu      sync.Mutex
+	msgs    []*messaging.Message
+	failing bool
+}
+
+func (p *publisher) Publish(_ context.Context, _ string, msg *messaging.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failing {
+		return errors.New("broker unavailable")
+	}
+	p.msgs = append(p.msgs, msg)
+
+	return nil
+}
+
+func (p *publisher) Close() error {
+	return nil
+}
+
+// command returns the command of the last published message.
+func (p *publisher) command(t *testing.T) map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.msgs) == 0 {
+		t.Fatal("expected published command")
+	}
+	var cmd map[string]interface{}
+	err := json.Unmarshal(p.msgs[len(p.msgs)-1].GetPayload(), &cmd)
+	assert.Nil(t, err, fmt.Sprintf("unmarshal command: expected no error got %s\n", err))
+
+	return cmd
+}
+
+func newEdgeService(t *testing.T, repo re.Repository) (re.Service, *publisher, *authmocks.AuthClient, *sdkmocks.SDK) {
+	_, url := newKuiper(t)
+	auth := new(authmocks.AuthClient)
+	sdk := new(sdkmocks.SDK)
+	pub := &publisher{}
+
+	return re.New(re.Config{URL: url}, auth, sdk, re.Notifiers{}, pub, repo), pub, auth, sdk
+}
+
+func TestDeployRule(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, pub, auth, sdk := newEdgeService(t, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+	bootstrapCall := sdk.On("ViewBootstrap", gatewayID, validToken).Return(mgsdk.BootstrapConfig{Channels: []string{channelID}}, nil)
+	defer bootstrapCall.Unset()
+
+	_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "readings", Topic: channelID, SenML: true}, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+	rule := re.Rule{ID: "alarm", SQL: "SELECT * FROM readings WHERE v > 30", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+
+	// The gateway registered without the channel uses its bootstrap channel.
+	gw, err := svc.SaveGateway(context.Background(), validToken, re.Gateway{ID: gatewayID, Name: "plant"})
+	assert.Nil(t, err, fmt.Sprintf("save gateway: expected no error got %s\n", err))
+	assert.Equal(t, channelID, gw.Channel, fmt.Sprintf("expected bootstrap channel %s got %s\n", channelID, gw.Channel))
+
+	d, err := svc.DeployRule(context.Background(), validToken, rule.ID, gatewayID)
+	assert.Nil(t, err, fmt.Sprintf("deploy rule: expected no error got %s\n", err))
+	assert.Equal(t, re.DeployPending, d.Status, fmt.Sprintf("expected pending deployment got %s\n", d.Status))
+	msg := pub.msgs[0]
+	assert.Equal(t, channelID, msg.GetChannel(), fmt.Sprintf("expected command on control channel got %s\n", msg.GetChannel()))
+	assert.Equal(t, re.ControlSubtopic, msg.GetSubtopic(), fmt.Sprintf("expected command on control subtopic got %s\n", msg.GetSubtopic()))
+	cmd := pub.command(t)
+	assert.Equal(t, "deploy", cmd["command"], fmt.Sprintf("expected deploy command got %v\n", cmd["command"]))
+	assert.Equal(t, rule.ID, cmd["rule"].(map[string]interface{})["id"], "expected rule without owner prefix")
+	if streams, ok := cmd["streams"].([]interface{}); assert.True(t, ok && len(streams) == 1, fmt.Sprintf("expected stream DDL got %v\n", cmd["streams"])) {
+		assert.Contains(t, streams[0], "create stream readings (", "expected stream without owner prefix")
+	}
+
+	// Reports of other things and channels are ignored.
+	handler := re.NewDeploymentsHandler(repo)
+	reports := []re.DeploymentReport{
+		{Gateway: otherUserID, Channel: channelID, Rule: rule.ID, Status: re.DeployFailed},
+		{Gateway: gatewayID, Channel: "other", Rule: rule.ID, Status: re.DeployFailed},
+		{Gateway: gatewayID, Channel: channelID, Rule: rule.ID, Status: re.DeployRunning},
+	}
+	for _, r := range reports {
+		err := handler.ReportDeploymentHandler(context.Background(), r)
+		assert.Nil(t, err, fmt.Sprintf("report deployment: expected no error got %s\n", err))
+	}
+	ds, err := svc.ListDeployments(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("list deployments: expected no error got %s\n", err))
+	if assert.Len(t, ds, 1, "expected one deployment") {
+		assert.Equal(t, re.DeployRunning, ds[0].Status, fmt.Sprintf("expected running deployment got %s\n", ds[0].Status))
+		assert.Equal(t, rule.ID, ds[0].Rule, fmt.Sprintf("expected rule %s got %s\n", rule.ID, ds[0].Rule))
+	}
+
+	err = svc.RemoveGateway(context.Background(), validToken, gatewayID)
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("remove gateway with deployments: expected %s got %s\n", svcerr.ErrConflict, err))
+
+	d, err = svc.UndeployRule(context.Background(), validToken, rule.ID, gatewayID)
+	assert.Nil(t, err, fmt.Sprintf("undeploy rule: expected no error got %s\n", err))
+	assert.Equal(t, re.DeployRemoving, d.Status, fmt.Sprintf("expected removing deployment got %s\n", d.Status))
+	assert.Equal(t, "delete", pub.command(t)["command"], "expected delete command")
+	err = handler.ReportDeploymentHandler(context.Background(), re.DeploymentReport{Gateway: gatewayID, Channel: channelID, Rule: rule.ID, Status: re.DeployRemoved})
+	assert.Nil(t, err, fmt.Sprintf("report removed deployment: expected no error got %s\n", err))
+	ds, err = svc.ListDeployments(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("list deployments: expected no error got %s\n", err))
+	assert.Empty(t, ds, fmt.Sprintf("expected no deployments got %v\n", ds))
+
+	cases := []struct {
+		desc    string
+		id      string
+		gateway string
+		err     error
+	}{
+		{
+			desc:    "deploy unknown rule",
+			id:      "unknown",
+			gateway: gatewayID,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:    "deploy rule to unknown gateway",
+			id:      rule.ID,
+			gateway: "unknown",
+			err:     svcerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		_, err := svc.DeployRule(context.Background(), validToken, tc.id, tc.gateway)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.RemoveGateway(context.Background(), validToken, gatewayID)
+	assert.Nil(t, err, fmt.Sprintf("remove gateway: expected no error got %s\n", err))
+}
+
+func TestDeployRuleDisabled(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	_, err := svc.DeployRule(context.Background(), validToken, "rule", gatewayID)
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("deploy rule without broker: expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+}
+
+func TestDeployFleet(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, pub, auth, _ := newEdgeService(t, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "readings", Topic: channelID, SenML: true}, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+	rule := re.Rule{ID: "alarm", SQL: "SELECT * FROM readings WHERE v > 30", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	for _, gw := range []re.Gateway{
+		{ID: "north", Channel: channelID, Labels: map[string]string{"site": "north"}},
+		{ID: "south", Channel: channelID, Labels: map[string]string{"site": "south"}},
+	} {
+		_, err := svc.SaveGateway(context.Background(), validToken, gw)
+		assert.Nil(t, err, fmt.Sprintf("save gateway %s: expected no error got %s\n", gw.ID, err))
+	}
+	handler := re.NewDeploymentsHandler(repo)
+	report := func(gateway string) {
+		err := handler.ReportDeploymentHandler(context.Background(), re.DeploymentReport{Gateway: gateway, Channel: channelID, Rule: rule.ID, Status: re.DeployRunning})
+		assert.Nil(t, err, fmt.Sprintf("report deployment: expected no error got %s\n", err))
+	}
+
+	// The gateways the command couldn't be published to are marked failed.
+	pub.failing = true
+	r, err := svc.DeployFleet(context.Background(), validToken, rule.ID, map[string]string{"site": "south"})
+	assert.Nil(t, err, fmt.Sprintf("deploy fleet: expected no error got %s\n", err))
+	assert.Equal(t, re.RolloutFailed, r.Status, fmt.Sprintf("expected failed rollout got %s\n", r.Status))
+	if assert.Len(t, r.Deployments, 1, "expected one deployment") {
+		assert.Equal(t, "south", r.Deployments[0].Gateway, fmt.Sprintf("expected deployment to south got %s\n", r.Deployments[0].Gateway))
+		assert.NotEmpty(t, r.Deployments[0].Error, "expected publish error")
+	}
+	pub.failing = false
+
+	r, err = svc.DeployFleet(context.Background(), validToken, rule.ID, map[string]string{"site": "north"})
+	assert.Nil(t, err, fmt.Sprintf("deploy fleet: expected no error got %s\n", err))
+	assert.Equal(t, re.Rollout{Rule: rule.ID, Status: re.RolloutPending, Total: 2, Pending: 1, Failed: 1}, stripDeployments(r), fmt.Sprintf("unexpected rollout %v\n", r))
+
+	report("north")
+	r, err = svc.ViewRollout(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("view rollout: expected no error got %s\n", err))
+	assert.Equal(t, re.Rollout{Rule: rule.ID, Status: re.RolloutDegraded, Total: 2, Running: 1, Failed: 1}, stripDeployments(r), fmt.Sprintf("unexpected rollout %v\n", r))
+
+	// Only the failed deployments are retried.
+	sent := len(pub.msgs)
+	r, err = svc.RetryDeployments(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("retry deployments: expected no error got %s\n", err))
+	assert.Equal(t, sent+1, len(pub.msgs), "expected command sent to the failed gateway only")
+	assert.Equal(t, re.Rollout{Rule: rule.ID, Status: re.RolloutPending, Total: 2, Pending: 1, Running: 1}, stripDeployments(r), fmt.Sprintf("unexpected rollout %v\n", r))
+
+	report("south")
+	r, err = svc.ViewRollout(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("view rollout: expected no error got %s\n", err))
+	assert.Equal(t, re.RolloutRunning, r.Status, fmt.Sprintf("expected running rollout got %s\n", r.Status))
+
+	_, err = svc.DeployFleet(context.Background(), validToken, rule.ID, map[string]string{"site": "east"})
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("deploy fleet without matching gateways: expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+}
+
+// stripDeployments returns the rollout without the deployments, leaving
+// the counts to compare.
+func stripDeployments(r re.Rollout) re.Rollout {
+	r.Deployments = nil
+	return r
+}
@@ -0,0 +1,386 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+const (
+	// MaskedValue replaces the values of the sensitive action fields in the
+	// returned rules. Updating the rule with the masked value keeps the
+	// stored value.
+	MaskedValue = "******"
+
+	// sealedPrefix prefixes the sensitive values sealed in the stored rule
+	// definitions.
+	sealedPrefix = "sealed:"
+
+	// dataKeySize is the size of the AES-256 data key each sensitive value
+	// is sealed with.
+	dataKeySize = 32
+)
+
+var (
+	errEncryptionDisabled = errors.New("encryption key is not configured")
+	errEncryptionKey      = errors.New("encryption key must be base64 encoded 32 bytes")
+	errSealedValue        = errors.New("failed to decrypt sealed value")
+	errMaskedValue        = errors.New("masked value can only be kept by updating the rule")
+	errMaskedClone        = errors.New("credentials of the rule shared for viewing can't be cloned")
+	errMaskedDestination  = errors.New("masked value can only be kept for the same destination")
+
+	// sensitiveHeaderRegexp matches the names of the REST sink headers
+	// carrying credentials.
+	sensitiveHeaderRegexp = regexp.MustCompile(`(?i)auth|token|key|secret|password|cookie|signature`)
+)
+
+// EncryptionConfig defines the envelope encryption of the sensitive action
+// fields in the stored rule definitions. Key is the base64 encoded 32 bytes
+// AES-256 master key wrapping the data keys the fields are encrypted with.
+// Without the key, the fields are stored as they are, but still masked.
+type EncryptionConfig struct {
+	Key string `env:"KEY" envDefault:""`
+}
+
+// Validate checks that the data keys can be wrapped with the master key.
+func (c EncryptionConfig) Validate() error {
+	if c.Key == "" {
+		return nil
+	}
+	_, err := newAEAD(c.Key, errEncryptionKey)
+
+	return err
+}
+
+func (c EncryptionConfig) aead() (cipher.AEAD, error) {
+	if c.Key == "" {
+		return nil, errEncryptionDisabled
+	}
+
+	return newAEAD(c.Key, errEncryptionKey)
+}
+
+// newAEAD returns AES-GCM with the base64 encoded 32 bytes key. Malformed
+// keys fail with the given error.
+func newAEAD(key string, errKey error) (cipher.AEAD, error) {
+	k, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(k) != 32 {
+		return nil, errKey
+	}
+	aead, err := gcm(k)
+	if err != nil {
+		return nil, errors.Wrap(errKey, err)
+	}
+
+	return aead, nil
+}
+
+func gcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the value with the new data key, which is wrapped with the
+// master key and stored along with the value.
+func (c EncryptionConfig) seal(value string) (string, error) {
+	master, err := c.aead()
+	if err != nil {
+		return "", err
+	}
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	data, err := gcm(key)
+	if err != nil {
+		return "", err
+	}
+	sealed := make([]byte, master.NonceSize(), master.NonceSize()+dataKeySize+master.Overhead()+data.NonceSize()+len(value)+data.Overhead())
+	if _, err := rand.Read(sealed); err != nil {
+		return "", err
+	}
+	sealed = master.Seal(sealed, sealed, key, nil)
+	nonce := make([]byte, data.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed = append(sealed, nonce...)
+	sealed = data.Seal(sealed, nonce, []byte(value), nil)
+
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts the sealed value.
+func (c EncryptionConfig) open(value string) (string, error) {
+	master, err := c.aead()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil {
+		return "", errors.Wrap(errSealedValue, err)
+	}
+	wrapped := master.NonceSize() + dataKeySize + master.Overhead()
+	if len(sealed) < wrapped+master.NonceSize() {
+		return "", errSealedValue
+	}
+	key, err := master.Open(nil, sealed[:master.NonceSize()], sealed[master.NonceSize():wrapped], nil)
+	if err != nil {
+		return "", errors.Wrap(errSealedValue, err)
+	}
+	data, err := gcm(key)
+	if err != nil {
+		return "", errors.Wrap(errSealedValue, err)
+	}
+	nonce, ciphertext := sealed[wrapped:wrapped+data.NonceSize()], sealed[wrapped+data.NonceSize():]
+	plain, err := data.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.Wrap(errSealedValue, err)
+	}
+
+	return string(plain), nil
+}
+
+// mapSensitive returns the copy of the action with the sensitive fields,
+// the MQTT sink password and the REST sink headers carrying credentials,
+// mapped with the function. The fields are identified by their paths, e.g.
+// "rest.headers.Authorization".
+func (a Action) mapSensitive(f func(path, value string) (string, error)) (Action, error) {
+	if a.MQTT != nil && a.MQTT.Password != "" {
+		mqtt := *a.MQTT
+		password, err := f("mqtt.password", mqtt.Password)
+		if err != nil {
+			return Action{}, err
+		}
+		mqtt.Password = password
+		a.MQTT = &mqtt
+	}
+	if a.REST != nil && len(a.REST.Headers) > 0 {
+		rest := *a.REST
+		rest.Headers = make(map[string]string, len(a.REST.Headers))
+		for name, value := range a.REST.Headers {
+			if sensitiveHeaderRegexp.MatchString(name) && value != "" {
+				v, err := f("rest.headers."+name, value)
+				if err != nil {
+					return Action{}, err
+				}
+				value = v
+			}
+			rest.Headers[name] = value
+		}
+		a.REST = &rest
+	}
+
+	return a, nil
+}
+
+// mapSensitive returns the copies of the actions with the sensitive fields
+// mapped with the function, which gets the index of the action as well.
+func mapSensitive(actions []Action, f func(i int, path, value string) (string, error)) ([]Action, error) {
+	res := make([]Action, len(actions))
+	for i, a := range actions {
+		m, err := a.mapSensitive(func(path, value string) (string, error) {
+			return f(i, path, value)
+		})
+		if err != nil {
+			return nil, err
+		}
+		res[i] = m
+	}
+
+	return res, nil
+}
+
+// sealActions returns the actions with the sensitive values sealed, so they
+// are stored encrypted. The sealed values and the references to the secrets
+// are kept. Without the encryption key, the actions are returned as they are.
+func (svc *reService) sealActions(actions []Action) ([]Action, error) {
+	if svc.encryption.Key == "" {
+		return actions, nil
+	}
+
+	return mapSensitive(actions, func(_ int, _, value string) (string, error) {
+		if strings.HasPrefix(value, sealedPrefix) || secretRegexp.MatchString(value) {
+			return value, nil
+		}
+		return svc.encryption.seal(value)
+	})
+}
+
+// openActions returns the actions with the sealed sensitive values
+// decrypted, which is only done for the actions sent to Kuiper.
+func (svc *reService) openActions(actions []Action) ([]Action, error) {
+	return mapSensitive(actions, func(_ int, _, value string) (string, error) {
+		if !strings.HasPrefix(value, sealedPrefix) {
+			return value, nil
+		}
+		plain, err := svc.encryption.open(value)
+		if err != nil {
+			return "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		return plain, nil
+	})
+}
+
+// sealedDefinition returns the JSON definition of the rule with the
+// sensitive values sealed.
+func (svc *reService) sealedDefinition(rule Rule) (string, error) {
+	actions, err := svc.sealActions(rule.Actions)
+	if err != nil {
+		return "", err
+	}
+	rule.Actions = actions
+
+	return ruleDefinition(rule)
+}
+
+// openDefinition returns the stored JSON rule definition with the sealed
+// values decrypted, so it's compared with the definition being created.
+func (svc *reService) openDefinition(definition string) (string, error) {
+	var rule Rule
+	if err := json.Unmarshal([]byte(definition), &rule); err != nil {
+		return "", err
+	}
+	actions, err := svc.openActions(rule.Actions)
+	if err != nil {
+		return "", err
+	}
+	rule.Actions = actions
+
+	return ruleDefinition(rule)
+}
+
+// unmask returns the actions with the masked values replaced with the
+// values of the owner's rule with the given ID, so the rule viewed with the
+// masked values can be saved as it is. The values are taken from the same
+// field of the action at the same position.
+func (svc *reService) unmask(ctx context.Context, owner, id string, actions []Action) ([]Action, error) {
+	if !masked(actions) {
+		return actions, nil
+	}
+	var old []Action
+	md, err := svc.metadata(ctx, RuleKind, prefix(owner)+id)
+	if err != nil {
+		return nil, err
+	}
+	switch md.definition() {
+	case "":
+		// Rules created before their definitions were stored are read
+		// from Kuiper.
+		kr, err := svc.engine.ViewRule(ctx, prefix(owner)+id)
+		switch {
+		case errors.Contains(err, svcerr.ErrNotFound):
+		case err != nil:
+			return nil, err
+		default:
+			rule, err := svc.kuiperRule(kr, prefix(owner))
+			if err != nil {
+				return nil, errors.Wrap(errReadResponse, err)
+			}
+			old = rule.Actions
+		}
+	default:
+		var rule Rule
+		if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+			return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		old = rule.Actions
+	}
+
+	return keepMasked(actions, old)
+}
+
+// keepMasked returns the actions with the masked values replaced with the
+// values of the old actions. The values are kept only for the sinks still
+// sending them to the same destination, so they can't be redirected to
+// another host.
+func keepMasked(actions, old []Action) ([]Action, error) {
+	values := map[int]map[string]string{}
+	_, _ = mapSensitive(old, func(i int, path, value string) (string, error) {
+		if values[i] == nil {
+			values[i] = map[string]string{}
+		}
+		values[i][path] = value
+		return value, nil
+	})
+
+	return mapSensitive(actions, func(i int, path, value string) (string, error) {
+		if value != MaskedValue {
+			return value, nil
+		}
+		value, ok := values[i][path]
+		if !ok {
+			return "", errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(errMaskedValue, errors.New(path)))
+		}
+		if !slices.Equal(actions[i].destination(), old[i].destination()) {
+			return "", errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(errMaskedDestination, errors.New(path)))
+		}
+		return value, nil
+	})
+}
+
+// destination returns the sink type and the fields of the sinks with the
+// sensitive fields that determine where the values are sent.
+func (a Action) destination() []string {
+	switch {
+	case a.MQTT != nil:
+		return []string{"mqtt", a.MQTT.Server, a.MQTT.Topic}
+	case a.REST != nil:
+		return []string{"rest", a.REST.URL}
+	default:
+		return nil
+	}
+}
+
+// masked reports whether any of the actions has the masked value.
+func masked(actions []Action) bool {
+	found := false
+	_, _ = mapSensitive(actions, func(_ int, _, value string) (string, error) {
+		found = found || value == MaskedValue
+		return value, nil
+	})
+
+	return found
+}
+
+// maskActions returns the actions with the sensitive values masked. The
+// values referring to the secrets don't hold the credentials, so they're
+// kept.
+func maskActions(actions []Action) []Action {
+	res, _ := mapSensitive(actions, func(_ int, _, value string) (string, error) {
+		if secretRegexp.MatchString(value) {
+			return value, nil
+		}
+		return MaskedValue, nil
+	})
+
+	return res
+}
+
+// LogValue returns the action with the sensitive values masked, so they're
+// never logged.
+func (a Action) LogValue() slog.Value {
+	data, err := json.Marshal(maskActions([]Action{a})[0])
+	if err != nil {
+		return slog.StringValue(a.Type())
+	}
+
+	return slog.StringValue(string(data))
+}
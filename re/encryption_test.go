@@ -0,0 +1,190 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var encryptionConfig = re.EncryptionConfig{Key: base64.StdEncoding.EncodeToString([]byte(strings.Repeat("m", 32)))}
+
+func TestEncryptActions(t *testing.T) {
+	cases := []struct {
+		desc       string
+		encryption re.EncryptionConfig
+		stored     bool
+	}{
+		{
+			desc:       "rule with encryption key",
+			encryption: encryptionConfig,
+		},
+		{
+			desc:   "rule without encryption key",
+			stored: true,
+		},
+	}
+
+	for _, tc := range cases {
+		repo := mocks.NewRepository()
+		svc, k, auth, _ := newServiceWithRepo(t, re.Config{Encryption: tc.encryption}, re.Notifiers{}, repo)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+
+		rule := re.Rule{
+			ID:  "forward",
+			SQL: "SELECT * FROM stream",
+			Actions: []re.Action{
+				{MQTT: &re.MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms", Username: "re", Password: "s3cr3t"}},
+				{REST: &re.RESTSink{URL: "https://example.com/hook", Headers: map[string]string{"Authorization": "Bearer t0ken", "Content-Type": "application/json"}}},
+			},
+		}
+		_, err := svc.CreateRule(context.Background(), validToken, rule)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+
+		raw := string(k.raw[userPrefix+rule.ID])
+		assert.Contains(t, raw, "s3cr3t", fmt.Sprintf("%s: expected password sent to Kuiper got %s\n", tc.desc, raw))
+		assert.Contains(t, raw, "Bearer t0ken", fmt.Sprintf("%s: expected header sent to Kuiper got %s\n", tc.desc, raw))
+		md, err := repo.Retrieve(context.Background(), re.RuleKind, userPrefix+rule.ID)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.stored, strings.Contains(md.Definition, "s3cr3t"), fmt.Sprintf("%s: expected password stored %t got %s\n", tc.desc, tc.stored, md.Definition))
+		assert.Equal(t, tc.stored, strings.Contains(md.Definition, "t0ken"), fmt.Sprintf("%s: expected header stored %t got %s\n", tc.desc, tc.stored, md.Definition))
+
+		viewed, err := svc.ViewRule(context.Background(), validToken, rule.ID)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, re.MaskedValue, viewed.Actions[0].MQTT.Password, fmt.Sprintf("%s: expected masked password got %s\n", tc.desc, viewed.Actions[0].MQTT.Password))
+		assert.Equal(t, re.MaskedValue, viewed.Actions[1].REST.Headers["Authorization"], fmt.Sprintf("%s: expected masked header got %s\n", tc.desc, viewed.Actions[1].REST.Headers["Authorization"]))
+		assert.Equal(t, "application/json", viewed.Actions[1].REST.Headers["Content-Type"], fmt.Sprintf("%s: expected header not masked got %s\n", tc.desc, viewed.Actions[1].REST.Headers["Content-Type"]))
+
+		// Creating the same rule again is repeated safely.
+		_, err = svc.CreateRule(context.Background(), validToken, rule)
+		assert.Nil(t, err, fmt.Sprintf("%s: create existing rule: expected no error got %s\n", tc.desc, err))
+
+		// The viewed rule is updated with the masked values kept.
+		viewed.Actions[0].MQTT.QoS = 1
+		_, err = svc.UpdateRule(context.Background(), validToken, re.Rule{ID: rule.ID, SQL: viewed.SQL, Actions: viewed.Actions})
+		assert.Nil(t, err, fmt.Sprintf("%s: update masked rule: expected no error got %s\n", tc.desc, err))
+		raw = string(k.raw[userPrefix+rule.ID])
+		assert.Contains(t, raw, "s3cr3t", fmt.Sprintf("%s: expected password kept in Kuiper got %s\n", tc.desc, raw))
+		assert.Contains(t, raw, `"qos":1`, fmt.Sprintf("%s: expected updated QoS in Kuiper got %s\n", tc.desc, raw))
+		assert.NotContains(t, raw, re.MaskedValue, fmt.Sprintf("%s: expected no masked value in Kuiper got %s\n", tc.desc, raw))
+
+		_, err = svc.CloneRule(context.Background(), validToken, rule.ID, "copy", "")
+		assert.Nil(t, err, fmt.Sprintf("%s: clone rule: expected no error got %s\n", tc.desc, err))
+		raw = string(k.raw[userPrefix+"copy"])
+		assert.Contains(t, raw, "s3cr3t", fmt.Sprintf("%s: expected password cloned in Kuiper got %s\n", tc.desc, raw))
+
+		_, err = svc.CreateRule(context.Background(), validToken, re.Rule{ID: "masked", SQL: viewed.SQL, Actions: viewed.Actions})
+		assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("%s: create masked rule: expected %s got %s\n", tc.desc, svcerr.ErrMalformedEntity, err))
+
+		rs, err := svc.ExportRuleset(context.Background(), validToken)
+		assert.Nil(t, err, fmt.Sprintf("%s: export ruleset: expected no error got %s\n", tc.desc, err))
+		for _, r := range rs.Rules {
+			if r.Actions[0].MQTT == nil {
+				continue
+			}
+			assert.Equal(t, re.MaskedValue, r.Actions[0].MQTT.Password, fmt.Sprintf("%s: expected exported password masked got %s\n", tc.desc, r.Actions[0].MQTT.Password))
+		}
+
+		authCall.Unset()
+	}
+}
+
+func TestMaskedDestination(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	rule := re.Rule{
+		ID:  "forward",
+		SQL: "SELECT * FROM stream",
+		Actions: []re.Action{
+			{MQTT: &re.MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms", Username: "re", Password: "s3cr3t"}},
+			{REST: &re.RESTSink{URL: "https://example.com/hook", Headers: map[string]string{"Authorization": "Bearer t0ken"}}},
+		},
+	}
+	_, err := svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	viewed, err := svc.ViewRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("view rule: expected no error got %s\n", err))
+
+	cases := []struct {
+		desc   string
+		change func(a []re.Action) []re.Action
+		err    error
+	}{
+		{
+			desc: "update masked rule keeping destinations",
+			change: func(a []re.Action) []re.Action {
+				a[0].MQTT.QoS = 1
+				return a
+			},
+		},
+		{
+			desc: "update masked rule changing MQTT server",
+			change: func(a []re.Action) []re.Action {
+				a[0].MQTT.Server = "tcp://attacker.example.com:1883"
+				return a
+			},
+			err: svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "update masked rule changing MQTT topic",
+			change: func(a []re.Action) []re.Action {
+				a[0].MQTT.Topic = "leaked"
+				return a
+			},
+			err: svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "update masked rule changing REST URL",
+			change: func(a []re.Action) []re.Action {
+				a[1].REST.URL = "https://attacker.example.com/hook"
+				return a
+			},
+			err: svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "update masked rule changing sink type",
+			change: func(a []re.Action) []re.Action {
+				return []re.Action{a[0], {MQTT: &re.MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms", Password: re.MaskedValue}}}
+			},
+			err: svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		before := string(k.raw[userPrefix+rule.ID])
+		actions := []re.Action{}
+		for _, a := range viewed.Actions {
+			c := a
+			if a.MQTT != nil {
+				mqtt := *a.MQTT
+				c.MQTT = &mqtt
+			}
+			if a.REST != nil {
+				rest := *a.REST
+				c.REST = &rest
+			}
+			actions = append(actions, c)
+		}
+		_, err := svc.UpdateRule(context.Background(), validToken, re.Rule{ID: rule.ID, SQL: viewed.SQL, Actions: tc.change(actions)})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		raw := string(k.raw[userPrefix+rule.ID])
+		assert.NotContains(t, raw, re.MaskedValue, fmt.Sprintf("%s: expected no masked value in Kuiper got %s\n", tc.desc, raw))
+		if tc.err != nil {
+			assert.Equal(t, before, raw, fmt.Sprintf("%s: expected rule left unchanged got %s\n", tc.desc, raw))
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import "context"
+
+// RuleEngine is the stream processing engine running the streams, tables
+// and rules of the users, Kuiper by default. The service namespaces the
+// names it passes to the engine with the owner prefix and checks the access
+// to them, so the engine works with the entities of all the users. Results
+// of the engine operations contain the engine status code and message,
+// while the service sets the name and the owner.
+//
+// Streams and tables are defined by the Kuiper DDL and rules use the Kuiper
+// rule format, which the engines other than Kuiper translate to their own.
+type RuleEngine interface {
+	// Info returns information about the engine. If the engine is
+	// temporarily unavailable, only the circuit breaker state is returned.
+	Info(ctx context.Context) (Info, error)
+
+	// CreateStream creates the stream or the table, depending on the kind,
+	// using the given DDL.
+	CreateStream(ctx context.Context, kind, sql string) (Result, error)
+
+	// UpdateStream replaces the stream or the table with the given name
+	// using the given DDL.
+	UpdateStream(ctx context.Context, kind, name, sql string) (Result, error)
+
+	// ListStreams returns the names of all the streams or tables.
+	ListStreams(ctx context.Context, kind string) ([]string, error)
+
+	// ViewStream returns the stream or the table with the given name.
+	ViewStream(ctx context.Context, kind, name string) (Stream, error)
+
+	// DeleteStream removes the stream or the table with the given name.
+	DeleteStream(ctx context.Context, kind, name string) (Result, error)
+
+	// CreateRule creates and starts the rule.
+	CreateRule(ctx context.Context, rule EngineRule) (Result, error)
+
+	// UpdateRule replaces the rule with the same ID and restarts it.
+	UpdateRule(ctx context.Context, rule EngineRule) (Result, error)
+
+	// ListRules returns the IDs and the states of all the rules.
+	ListRules(ctx context.Context) ([]RuleInfo, error)
+
+	// ViewRule returns the rule with the given ID.
+	ViewRule(ctx context.Context, id string) (EngineRule, error)
+
+	// DeleteRule stops and removes the rule with the given ID.
+	DeleteRule(ctx context.Context, id string) (Result, error)
+
+	// ControlRule sends the start, stop or restart command to the rule.
+	ControlRule(ctx context.Context, id, command string) (Result, error)
+
+	// RuleStatus returns the runtime status of the rule.
+	RuleStatus(ctx context.Context, id string) (RuleStatus, error)
+
+	// RuleTopology returns the graph of the operators the rule runs as.
+	RuleTopology(ctx context.Context, id string) (RuleTopology, error)
+
+	// ValidateRule validates the rule without creating it. The engines that
+	// can't validate rules return ErrNotSupported.
+	ValidateRule(ctx context.Context, rule EngineRule) error
+
+	// RunTrial runs the rule trial and returns the results the rule
+	// produced from the sample messages.
+	RunTrial(ctx context.Context, trial EngineTrial) (TrialResult, error)
+
+	// CreatePlugin installs the plugin of the given kind.
+	CreatePlugin(ctx context.Context, kind string, plugin Plugin) (Result, error)
+
+	// ListPlugins returns the names of the installed plugins of the kind.
+	ListPlugins(ctx context.Context, kind string) ([]string, error)
+
+	// DeletePlugin uninstalls the plugin of the kind with the given name.
+	DeletePlugin(ctx context.Context, kind, name string) (Result, error)
+
+	// RegisterService registers the external service.
+	RegisterService(ctx context.Context, es ExternalService) (Result, error)
+
+	// ListServices returns the names of the registered external services.
+	ListServices(ctx context.Context) ([]string, error)
+
+	// DeleteService removes the external service with the given name.
+	DeleteService(ctx context.Context, name string) (Result, error)
+
+	// ListFunctions returns the functions of the external services.
+	ListFunctions(ctx context.Context) ([]ExternalFunction, error)
+
+	// SaveConfKey creates or replaces the MQTT source conf key.
+	SaveConfKey(ctx context.Context, name string, conf MQTTConf) (Result, error)
+
+	// ListConfKeys returns the names of all the MQTT source conf keys,
+	// including the default one.
+	ListConfKeys(ctx context.Context) ([]string, error)
+
+	// DeleteConfKey removes the MQTT source conf key with the given name.
+	DeleteConfKey(ctx context.Context, name string) (Result, error)
+
+	// Push sends the message to the push endpoint of the httppush stream
+	// with the given name.
+	Push(ctx context.Context, stream string, payload []byte) error
+}
+
+// owned returns the engine result with the name and the owner set.
+func (res Result) owned(name, owner string) Result {
+	res.Name, res.Owner = name, owner
+
+	return res
+}
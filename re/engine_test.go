@@ -0,0 +1,103 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// streamsEngine is the rule engine serving only the stream operations.
+type streamsEngine struct {
+	re.RuleEngine
+	streams []string
+	deleted []string
+}
+
+func (e *streamsEngine) ListStreams(_ context.Context, kind string) ([]string, error) {
+	if kind != re.StreamKind {
+		return []string{}, nil
+	}
+
+	return e.streams, nil
+}
+
+func (e *streamsEngine) DeleteStream(_ context.Context, _, name string) (re.Result, error) {
+	e.deleted = append(e.deleted, name)
+
+	return re.Result{Status: 200}, nil
+}
+
+func TestNewWithEngine(t *testing.T) {
+	engine := &streamsEngine{streams: []string{userPrefix + "temperature", otherPrefix + "humidity"}}
+	auth := new(authmocks.AuthClient)
+	svc := re.NewWithEngine(engine, re.Config{}, auth, new(sdkmocks.SDK), re.Notifiers{}, nil, mocks.NewRepository())
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	page, err := svc.ListStreams(context.Background(), validToken, re.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, []string{"temperature"}, page.Streams, fmt.Sprintf("expected own engine streams got %v", page.Streams))
+
+	res, err := svc.DeleteStream(context.Background(), validToken, "temperature", false)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, re.Result{Name: "temperature", Status: 200, Owner: userID}, res, fmt.Sprintf("expected engine result got %v", res))
+	assert.Equal(t, []string{userPrefix + "temperature"}, engine.deleted, fmt.Sprintf("expected namespaced stream to be deleted got %v", engine.deleted))
+}
+
+func TestInMemoryService(t *testing.T) {
+	auth := new(authmocks.AuthClient)
+	sdk := new(sdkmocks.SDK)
+	svc := mocks.NewInMemoryService(auth, sdk)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	def := re.StreamDef{Name: "readings", Topic: channelID, Fields: []re.Field{{Name: "v", Type: re.FloatType}}}
+	_, err := svc.CreateStream(context.Background(), validToken, def, false)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	_, err = svc.CreateStream(context.Background(), validToken, def, false)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	other := def
+	other.Fields = []re.Field{{Name: "v", Type: re.BigintType}}
+	_, err = svc.CreateStream(context.Background(), validToken, other, false)
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("expected %s got %s", svcerr.ErrConflict, err))
+	stream, err := svc.ViewStream(context.Background(), validToken, "readings")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, channelID, stream.Options["datasource"], fmt.Sprintf("expected stream to read from the channel got %v", stream.Options))
+
+	rule := re.Rule{
+		ID:      "alarm",
+		SQL:     "SELECT * FROM readings WHERE v > 30",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+	}
+	res, err := svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "alarm", res.Name, fmt.Sprintf("expected rule result got %v", res))
+	_, err = svc.StopRule(context.Background(), validToken, "alarm")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	status, err := svc.RuleStatus(context.Background(), validToken, "alarm")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "stopped", status.Status, fmt.Sprintf("expected stopped rule got %s", status.Status))
+	viewed, err := svc.ViewRule(context.Background(), validToken, "alarm")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, rule.SQL, viewed.SQL, fmt.Sprintf("expected rule SQL %s got %s", rule.SQL, viewed.SQL))
+
+	_, err = svc.DeleteRule(context.Background(), validToken, "alarm")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	_, err = svc.RuleStatus(context.Background(), validToken, "alarm")
+	assert.True(t, errors.Contains(err, re.ErrRuleNotFound), fmt.Sprintf("expected %s got %s", re.ErrRuleNotFound, err))
+}
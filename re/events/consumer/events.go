@@ -0,0 +1,12 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+type createEvent struct {
+	id string
+}
+
+type removeEvent struct {
+	id string
+}
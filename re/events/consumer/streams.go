@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/re"
+)
+
+const (
+	channelPrefix = "group."
+	channelCreate = channelPrefix + "create"
+	channelRemove = channelPrefix + "remove"
+)
+
+type eventHandler struct {
+	handler     re.ChannelsHandler
+	autoStreams bool
+}
+
+// NewEventHandler returns new event store handler. If autoStreams is set,
+// streams are created for the created channels.
+func NewEventHandler(handler re.ChannelsHandler, autoStreams bool) events.EventHandler {
+	return &eventHandler{
+		handler:     handler,
+		autoStreams: autoStreams,
+	}
+}
+
+func (es *eventHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch msg["operation"] {
+	case channelCreate:
+		if !es.autoStreams {
+			return nil
+		}
+		cce := decodeCreateChannel(msg)
+		err = es.handler.CreateChannelHandler(ctx, cce.id)
+	case channelRemove:
+		rce := decodeRemoveChannel(msg)
+		err = es.handler.RemoveChannelHandler(ctx, rce.id)
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func decodeCreateChannel(event map[string]interface{}) createEvent {
+	return createEvent{
+		id: read(event, "id", ""),
+	}
+}
+
+func decodeRemoveChannel(event map[string]interface{}) removeEvent {
+	return removeEvent{
+		id: read(event, "id", ""),
+	}
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
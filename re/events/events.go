@@ -0,0 +1,196 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/re"
+)
+
+const (
+	streamPrefix = "stream."
+	streamCreate = streamPrefix + "create"
+	streamUpdate = streamPrefix + "update"
+	streamRemove = streamPrefix + "remove"
+	streamRename = streamPrefix + "rename"
+
+	tablePrefix = "table."
+	tableCreate = tablePrefix + "create"
+	tableRemove = tablePrefix + "remove"
+
+	rulePrefix    = "rule."
+	ruleCreate    = rulePrefix + "create"
+	ruleUpdate    = rulePrefix + "update"
+	ruleRemove    = rulePrefix + "remove"
+	ruleRename    = rulePrefix + "rename"
+	ruleStart     = rulePrefix + "start"
+	ruleStop      = rulePrefix + "stop"
+	ruleRestart   = rulePrefix + "restart"
+	ruleDraft     = rulePrefix + "draft"
+	rulePublish   = rulePrefix + "publish"
+	ruleUnpublish = rulePrefix + "unpublish"
+	ruleRestore   = rulePrefix + "restore"
+)
+
+var (
+	_ events.Event = (*createStreamEvent)(nil)
+	_ events.Event = (*removeStreamEvent)(nil)
+	_ events.Event = (*createTableEvent)(nil)
+	_ events.Event = (*removeTableEvent)(nil)
+	_ events.Event = (*saveRuleEvent)(nil)
+	_ events.Event = (*ruleEvent)(nil)
+	_ events.Event = (*renameEvent)(nil)
+)
+
+type createStreamEvent struct {
+	re.StreamDef
+	owner  string
+	update bool
+}
+
+func (cse createStreamEvent) Encode() (map[string]interface{}, error) {
+	operation := streamCreate
+	if cse.update {
+		operation = streamUpdate
+	}
+	val := map[string]interface{}{
+		"operation": operation,
+		"name":      cse.Name,
+		"owner":     cse.owner,
+		"type":      cse.Type,
+		"format":    cse.Format,
+		"topic":     cse.Topic,
+	}
+	if cse.SenML {
+		val["senml"] = true
+	}
+
+	return val, nil
+}
+
+type removeStreamEvent struct {
+	name    string
+	owner   string
+	cascade bool
+}
+
+func (rse removeStreamEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": streamRemove,
+		"name":      rse.name,
+		"owner":     rse.owner,
+		"cascade":   rse.cascade,
+	}, nil
+}
+
+type createTableEvent struct {
+	re.TableDef
+	owner string
+}
+
+func (cte createTableEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": tableCreate,
+		"name":      cte.Name,
+		"owner":     cte.owner,
+		"type":      cte.Type,
+		"kind":      cte.Kind,
+		"topic":     cte.Topic,
+	}, nil
+}
+
+type removeTableEvent struct {
+	name  string
+	owner string
+}
+
+func (rte removeTableEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": tableRemove,
+		"name":      rte.name,
+		"owner":     rte.owner,
+	}, nil
+}
+
+type saveRuleEvent struct {
+	re.Rule
+	owner    string
+	update   bool
+	draft    bool
+	template string
+	source   string
+}
+
+// Encode encodes the rule without the action configs, which can contain
+// credentials and contacts, so only the types of the action sinks are sent.
+func (sre saveRuleEvent) Encode() (map[string]interface{}, error) {
+	operation := ruleCreate
+	switch {
+	case sre.draft:
+		operation = ruleDraft
+	case sre.update:
+		operation = ruleUpdate
+	}
+	sinks := make([]string, len(sre.Actions))
+	for i, a := range sre.Actions {
+		sinks[i] = a.Type()
+	}
+
+	val := map[string]interface{}{
+		"operation": operation,
+		"id":        sre.ID,
+		"owner":     sre.owner,
+		"sql":       sre.SQL,
+		"sinks":     strings.Join(sinks, ","),
+	}
+	if sre.template != "" {
+		val["template"] = sre.template
+	}
+	if sre.source != "" {
+		val["source"] = sre.source
+	}
+
+	return val, nil
+}
+
+// ruleEvent is the event of the operation over the existing rule, e.g.
+// starting or removing the rule.
+type ruleEvent struct {
+	operation string
+	id        string
+	owner     string
+}
+
+func (rev ruleEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": rev.operation,
+		"id":        rev.id,
+		"owner":     rev.owner,
+	}, nil
+}
+
+// renameEvent is the event of renaming the stream or rule, which keeps its
+// ID, so consumers tracking the entity by name follow the new name.
+type renameEvent struct {
+	kind    string
+	name    string
+	newName string
+	owner   string
+}
+
+func (rne renameEvent) Encode() (map[string]interface{}, error) {
+	operation := ruleRename
+	if rne.kind == re.StreamKind {
+		operation = streamRename
+	}
+
+	return map[string]interface{}{
+		"operation": operation,
+		"name":      rne.name,
+		"new_name":  rne.newName,
+		"owner":     rne.owner,
+	}, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/re"
+)
+
+var _ re.Expirer = (*expirerStore)(nil)
+
+type expirerStore struct {
+	events.Publisher
+	expirer re.Expirer
+}
+
+// NewExpirerMiddleware returns wrapper around rules expirer that sends the
+// rule remove events of the expired rules to event store.
+func NewExpirerMiddleware(ctx context.Context, expirer re.Expirer, url string) (re.Expirer, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &expirerStore{
+		expirer:   expirer,
+		Publisher: publisher,
+	}, nil
+}
+
+func (es *expirerStore) Expire(ctx context.Context) ([]re.ExpiredRule, error) {
+	expired, err := es.expirer.Expire(ctx)
+	if err != nil {
+		return expired, err
+	}
+
+	for _, r := range expired {
+		if r.Error != "" {
+			continue
+		}
+		event := ruleEvent{
+			operation: ruleRemove,
+			id:        r.ID,
+			owner:     r.Owner,
+		}
+		if err := es.Publish(ctx, event); err != nil {
+			return expired, err
+		}
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,627 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/re"
+)
+
+const streamID = "magistrala.re"
+
+var _ re.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	events.Publisher
+	svc re.Service
+}
+
+// NewEventStoreMiddleware returns wrapper around rules engine service that
+// sends stream and rule lifecycle events to event store.
+func NewEventStoreMiddleware(ctx context.Context, svc re.Service, url string) (re.Service, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventStore{
+		svc:       svc,
+		Publisher: publisher,
+	}, nil
+}
+
+func (es *eventStore) Info(ctx context.Context) (re.Info, error) {
+	return es.svc.Info(ctx)
+}
+
+func (es *eventStore) CreateStream(ctx context.Context, token string, def re.StreamDef, update bool) (re.Result, error) {
+	res, err := es.svc.CreateStream(ctx, token, def, update)
+	if err != nil {
+		return res, err
+	}
+
+	event := createStreamEvent{
+		StreamDef: def,
+		owner:     res.Owner,
+		update:    update,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (es *eventStore) ListStreams(ctx context.Context, token string, pm re.PageMetadata) (re.StreamsPage, error) {
+	return es.svc.ListStreams(ctx, token, pm)
+}
+
+func (es *eventStore) ViewStream(ctx context.Context, token, name string) (re.Stream, error) {
+	return es.svc.ViewStream(ctx, token, name)
+}
+
+func (es *eventStore) ViewStreams(ctx context.Context, token string, names []string) (re.StreamsBatch, error) {
+	return es.svc.ViewStreams(ctx, token, names)
+}
+
+func (es *eventStore) DeleteStream(ctx context.Context, token, name string, cascade bool) (re.Result, error) {
+	res, err := es.svc.DeleteStream(ctx, token, name, cascade)
+	if err != nil {
+		return res, err
+	}
+
+	event := removeStreamEvent{
+		name:    name,
+		owner:   res.Owner,
+		cascade: cascade,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (es *eventStore) CreateTable(ctx context.Context, token string, def re.TableDef) (re.Result, error) {
+	res, err := es.svc.CreateTable(ctx, token, def)
+	if err != nil {
+		return res, err
+	}
+
+	event := createTableEvent{
+		TableDef: def,
+		owner:    res.Owner,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (es *eventStore) ListTables(ctx context.Context, token string, pm re.PageMetadata) (re.TablesPage, error) {
+	return es.svc.ListTables(ctx, token, pm)
+}
+
+func (es *eventStore) ViewTable(ctx context.Context, token, name string) (re.Table, error) {
+	return es.svc.ViewTable(ctx, token, name)
+}
+
+func (es *eventStore) DeleteTable(ctx context.Context, token, name string) (re.Result, error) {
+	res, err := es.svc.DeleteTable(ctx, token, name)
+	if err != nil {
+		return res, err
+	}
+
+	event := removeTableEvent{
+		name:  name,
+		owner: res.Owner,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (es *eventStore) CreateRule(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	res, err := es.svc.CreateRule(ctx, token, rule)
+	if err != nil {
+		return res, err
+	}
+
+	event := saveRuleEvent{
+		Rule:  rule,
+		owner: res.Owner,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (es *eventStore) UpdateRule(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	res, err := es.svc.UpdateRule(ctx, token, rule)
+	if err != nil {
+		return res, err
+	}
+
+	event := saveRuleEvent{
+		Rule:   rule,
+		owner:  res.Owner,
+		update: true,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// PatchRule publishes the rule update event with the patched rule, which is
+// read back from the service since the patch contains only its changes.
+func (es *eventStore) PatchRule(ctx context.Context, token, id string, patch re.RulePatch) (re.Result, error) {
+	res, err := es.svc.PatchRule(ctx, token, id, patch)
+	if err != nil {
+		return res, err
+	}
+	rule, err := es.svc.ViewRule(ctx, token, id)
+	if err != nil {
+		return res, err
+	}
+
+	event := saveRuleEvent{
+		Rule:   rule,
+		owner:  res.Owner,
+		update: true,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// CloneRule publishes the rule creation event with the clone, which is read
+// back from the service along with the ID of the cloned rule.
+func (es *eventStore) CloneRule(ctx context.Context, token, id, newID, channel string) (re.Result, error) {
+	res, err := es.svc.CloneRule(ctx, token, id, newID, channel)
+	if err != nil {
+		return res, err
+	}
+	rule, err := es.svc.ViewRule(ctx, token, newID)
+	if err != nil {
+		return res, err
+	}
+
+	event := saveRuleEvent{
+		Rule:   rule,
+		owner:  res.Owner,
+		draft:  rule.Metadata != nil && rule.Metadata.Draft,
+		source: id,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (es *eventStore) SaveDraft(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	res, err := es.svc.SaveDraft(ctx, token, rule)
+	if err != nil {
+		return res, err
+	}
+
+	event := saveRuleEvent{
+		Rule:  rule,
+		owner: res.Owner,
+		draft: true,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (es *eventStore) PublishRule(ctx context.Context, token, id string) (re.Result, error) {
+	return es.ruleEvent(ctx, rulePublish, es.svc.PublishRule, token, id)
+}
+
+func (es *eventStore) UnpublishRule(ctx context.Context, token, id string) (re.Result, error) {
+	return es.ruleEvent(ctx, ruleUnpublish, es.svc.UnpublishRule, token, id)
+}
+
+func (es *eventStore) RestoreRule(ctx context.Context, token, id string) (re.Result, error) {
+	return es.ruleEvent(ctx, ruleRestore, es.svc.RestoreRule, token, id)
+}
+
+func (es *eventStore) ValidateRule(ctx context.Context, token string, rule re.Rule) (re.RuleValidation, error) {
+	return es.svc.ValidateRule(ctx, token, rule)
+}
+
+func (es *eventStore) TestRule(ctx context.Context, token string, trial re.RuleTrial) (re.TrialResult, error) {
+	return es.svc.TestRule(ctx, token, trial)
+}
+
+func (es *eventStore) ReplayRule(ctx context.Context, token, id string, from, to time.Time) (re.ReplayResult, error) {
+	return es.svc.ReplayRule(ctx, token, id, from, to)
+}
+
+func (es *eventStore) TailRule(ctx context.Context, token, id string) (<-chan map[string]interface{}, error) {
+	return es.svc.TailRule(ctx, token, id)
+}
+
+func (es *eventStore) PushTail(ctx context.Context, session string, result map[string]interface{}) error {
+	return es.svc.PushTail(ctx, session, result)
+}
+
+func (es *eventStore) Ingest(ctx context.Context, key, stream string, payload []byte) error {
+	return es.svc.Ingest(ctx, key, stream, payload)
+}
+
+func (es *eventStore) WatchRules(ctx context.Context, token string) (<-chan re.RuleStateChange, error) {
+	return es.svc.WatchRules(ctx, token)
+}
+
+func (es *eventStore) ViewRule(ctx context.Context, token, id string) (re.Rule, error) {
+	return es.svc.ViewRule(ctx, token, id)
+}
+
+func (es *eventStore) ViewRules(ctx context.Context, token string, ids []string) (re.RulesBatch, error) {
+	return es.svc.ViewRules(ctx, token, ids)
+}
+
+func (es *eventStore) ListRules(ctx context.Context, token string, pm re.PageMetadata) (re.RulesPage, error) {
+	return es.svc.ListRules(ctx, token, pm)
+}
+
+func (es *eventStore) SearchRules(ctx context.Context, token string, q re.RuleQuery, pm re.PageMetadata) (re.RulesPage, error) {
+	return es.svc.SearchRules(ctx, token, q, pm)
+}
+
+func (es *eventStore) DeleteRule(ctx context.Context, token, id string) (re.Result, error) {
+	return es.ruleEvent(ctx, ruleRemove, es.svc.DeleteRule, token, id)
+}
+
+func (es *eventStore) StartRule(ctx context.Context, token, id string) (re.Result, error) {
+	return es.ruleEvent(ctx, ruleStart, es.svc.StartRule, token, id)
+}
+
+func (es *eventStore) StopRule(ctx context.Context, token, id string) (re.Result, error) {
+	return es.ruleEvent(ctx, ruleStop, es.svc.StopRule, token, id)
+}
+
+func (es *eventStore) RestartRule(ctx context.Context, token, id string) (re.Result, error) {
+	return es.ruleEvent(ctx, ruleRestart, es.svc.RestartRule, token, id)
+}
+
+func (es *eventStore) RuleStatus(ctx context.Context, token, id string) (re.RuleStatus, error) {
+	return es.svc.RuleStatus(ctx, token, id)
+}
+
+func (es *eventStore) RuleTopology(ctx context.Context, token, id string) (re.RuleTopology, error) {
+	return es.svc.RuleTopology(ctx, token, id)
+}
+
+func (es *eventStore) RuleLogs(ctx context.Context, token, id string, since time.Time) ([]re.LogEntry, error) {
+	return es.svc.RuleLogs(ctx, token, id, since)
+}
+
+func (es *eventStore) Reconcile(ctx context.Context, token string, repair bool) (re.DriftReport, error) {
+	return es.svc.Reconcile(ctx, token, repair)
+}
+
+func (es *eventStore) CollectOrphans(ctx context.Context, token string, policy re.OrphanPolicy) (re.OrphanReport, error) {
+	return es.svc.CollectOrphans(ctx, token, policy)
+}
+
+func (es *eventStore) Restore(ctx context.Context, token string, dryRun bool) (re.RestoreReport, error) {
+	return es.svc.Restore(ctx, token, dryRun)
+}
+
+func (es *eventStore) ExportRuleset(ctx context.Context, token string) (re.Ruleset, error) {
+	return es.svc.ExportRuleset(ctx, token)
+}
+
+func (es *eventStore) ImportRuleset(ctx context.Context, token string, rs re.Ruleset, conflict string) (re.ImportReport, error) {
+	return es.svc.ImportRuleset(ctx, token, rs, conflict)
+}
+
+func (es *eventStore) BulkCreate(ctx context.Context, token string, rs re.Ruleset) (re.BulkReport, error) {
+	return es.svc.BulkCreate(ctx, token, rs)
+}
+
+func (es *eventStore) BulkDelete(ctx context.Context, token string, bd re.BulkDeletion) (re.BulkReport, error) {
+	return es.svc.BulkDelete(ctx, token, bd)
+}
+
+func (es *eventStore) StartRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	return es.svc.StartRules(ctx, token, sel)
+}
+
+func (es *eventStore) StopRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	return es.svc.StopRules(ctx, token, sel)
+}
+
+func (es *eventStore) ListAllStreams(ctx context.Context, token string) (re.AllStreams, error) {
+	return es.svc.ListAllStreams(ctx, token)
+}
+
+func (es *eventStore) ListAllRules(ctx context.Context, token string) (re.AllRules, error) {
+	return es.svc.ListAllRules(ctx, token)
+}
+
+func (es *eventStore) ViewQuota(ctx context.Context, token, userID string) (re.UserQuota, error) {
+	return es.svc.ViewQuota(ctx, token, userID)
+}
+
+func (es *eventStore) SetQuota(ctx context.Context, token, userID string, q re.Quota) (re.UserQuota, error) {
+	return es.svc.SetQuota(ctx, token, userID, q)
+}
+
+func (es *eventStore) RemoveQuota(ctx context.Context, token, userID string) error {
+	return es.svc.RemoveQuota(ctx, token, userID)
+}
+
+func (es *eventStore) ShareEntity(ctx context.Context, token, kind, name string, s re.Share) (re.Share, error) {
+	return es.svc.ShareEntity(ctx, token, kind, name, s)
+}
+
+func (es *eventStore) ListShares(ctx context.Context, token, kind, name string) ([]re.Share, error) {
+	return es.svc.ListShares(ctx, token, kind, name)
+}
+
+func (es *eventStore) UnshareEntity(ctx context.Context, token, kind, name, grantee string) error {
+	return es.svc.UnshareEntity(ctx, token, kind, name, grantee)
+}
+
+func (es *eventStore) Rename(ctx context.Context, token, kind, name, newName string) (re.Result, error) {
+	res, err := es.svc.Rename(ctx, token, kind, name, newName)
+	if err != nil {
+		return res, err
+	}
+
+	event := renameEvent{
+		kind:    kind,
+		name:    name,
+		newName: newName,
+		owner:   res.Owner,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (es *eventStore) ListAuditEvents(ctx context.Context, token string, q re.AuditQuery) (re.AuditPage, error) {
+	return es.svc.ListAuditEvents(ctx, token, q)
+}
+
+func (es *eventStore) ListInstances(ctx context.Context, token string) ([]re.Instance, error) {
+	return es.svc.ListInstances(ctx, token)
+}
+
+func (es *eventStore) AssignInstance(ctx context.Context, token, userID, instance string) (re.Assignment, error) {
+	return es.svc.AssignInstance(ctx, token, userID, instance)
+}
+
+func (es *eventStore) SaveGateway(ctx context.Context, token string, gw re.Gateway) (re.Gateway, error) {
+	return es.svc.SaveGateway(ctx, token, gw)
+}
+
+func (es *eventStore) ListGateways(ctx context.Context, token string) ([]re.Gateway, error) {
+	return es.svc.ListGateways(ctx, token)
+}
+
+func (es *eventStore) RemoveGateway(ctx context.Context, token, id string) error {
+	return es.svc.RemoveGateway(ctx, token, id)
+}
+
+func (es *eventStore) DeployRule(ctx context.Context, token, id, gateway string) (re.Deployment, error) {
+	return es.svc.DeployRule(ctx, token, id, gateway)
+}
+
+func (es *eventStore) UndeployRule(ctx context.Context, token, id, gateway string) (re.Deployment, error) {
+	return es.svc.UndeployRule(ctx, token, id, gateway)
+}
+
+func (es *eventStore) ListDeployments(ctx context.Context, token, id string) ([]re.Deployment, error) {
+	return es.svc.ListDeployments(ctx, token, id)
+}
+
+func (es *eventStore) DeployFleet(ctx context.Context, token, id string, labels map[string]string) (re.Rollout, error) {
+	return es.svc.DeployFleet(ctx, token, id, labels)
+}
+
+func (es *eventStore) RetryDeployments(ctx context.Context, token, id string) (re.Rollout, error) {
+	return es.svc.RetryDeployments(ctx, token, id)
+}
+
+func (es *eventStore) ViewRollout(ctx context.Context, token, id string) (re.Rollout, error) {
+	return es.svc.ViewRollout(ctx, token, id)
+}
+
+func (es *eventStore) EngineStats(ctx context.Context, token string) (re.EngineStats, error) {
+	return es.svc.EngineStats(ctx, token)
+}
+
+func (es *eventStore) SetAlertPolicy(ctx context.Context, token string, p re.AlertPolicy) (re.AlertPolicy, error) {
+	return es.svc.SetAlertPolicy(ctx, token, p)
+}
+
+func (es *eventStore) ViewAlertPolicy(ctx context.Context, token string) (re.AlertPolicy, error) {
+	return es.svc.ViewAlertPolicy(ctx, token)
+}
+
+func (es *eventStore) RemoveAlertPolicy(ctx context.Context, token string) error {
+	return es.svc.RemoveAlertPolicy(ctx, token)
+}
+
+func (es *eventStore) CreateWebhook(ctx context.Context, token string, wh re.Webhook) (re.Webhook, error) {
+	return es.svc.CreateWebhook(ctx, token, wh)
+}
+
+func (es *eventStore) ListWebhooks(ctx context.Context, token string) ([]re.Webhook, error) {
+	return es.svc.ListWebhooks(ctx, token)
+}
+
+func (es *eventStore) RemoveWebhook(ctx context.Context, token, id string) error {
+	return es.svc.RemoveWebhook(ctx, token, id)
+}
+
+func (es *eventStore) CreateFolder(ctx context.Context, token string, f re.Folder) (re.Folder, error) {
+	return es.svc.CreateFolder(ctx, token, f)
+}
+
+func (es *eventStore) ListFolders(ctx context.Context, token string) ([]re.Folder, error) {
+	return es.svc.ListFolders(ctx, token)
+}
+
+func (es *eventStore) RemoveFolder(ctx context.Context, token, name string) error {
+	return es.svc.RemoveFolder(ctx, token, name)
+}
+
+func (es *eventStore) MoveRules(ctx context.Context, token, folder string, ids []string) (re.BulkReport, error) {
+	return es.svc.MoveRules(ctx, token, folder, ids)
+}
+
+func (es *eventStore) Pause(ctx context.Context, token string) (re.BulkReport, error) {
+	return es.svc.Pause(ctx, token)
+}
+
+func (es *eventStore) Resume(ctx context.Context, token string) (re.BulkReport, error) {
+	return es.svc.Resume(ctx, token)
+}
+
+func (es *eventStore) ViewMaintenance(ctx context.Context, token string) (re.Maintenance, error) {
+	return es.svc.ViewMaintenance(ctx, token)
+}
+
+func (es *eventStore) CreateChain(ctx context.Context, token string, c re.Chain) (re.Chain, error) {
+	return es.svc.CreateChain(ctx, token, c)
+}
+
+func (es *eventStore) ListChains(ctx context.Context, token string) ([]re.Chain, error) {
+	return es.svc.ListChains(ctx, token)
+}
+
+func (es *eventStore) RemoveChain(ctx context.Context, token, name string) error {
+	return es.svc.RemoveChain(ctx, token, name)
+}
+
+func (es *eventStore) SaveSecret(ctx context.Context, token string, s re.Secret) (re.Secret, error) {
+	return es.svc.SaveSecret(ctx, token, s)
+}
+
+func (es *eventStore) ListSecrets(ctx context.Context, token string) ([]re.Secret, error) {
+	return es.svc.ListSecrets(ctx, token)
+}
+
+func (es *eventStore) RemoveSecret(ctx context.Context, token, name string) error {
+	return es.svc.RemoveSecret(ctx, token, name)
+}
+
+func (es *eventStore) CreateTemplate(ctx context.Context, token string, tmpl re.Template) (re.Template, error) {
+	return es.svc.CreateTemplate(ctx, token, tmpl)
+}
+
+func (es *eventStore) ViewTemplate(ctx context.Context, token, name string) (re.Template, error) {
+	return es.svc.ViewTemplate(ctx, token, name)
+}
+
+func (es *eventStore) ListTemplates(ctx context.Context, token string) ([]re.Template, error) {
+	return es.svc.ListTemplates(ctx, token)
+}
+
+func (es *eventStore) RemoveTemplate(ctx context.Context, token, name string) error {
+	return es.svc.RemoveTemplate(ctx, token, name)
+}
+
+func (es *eventStore) InstantiateTemplate(ctx context.Context, token, name string, inst re.TemplateInstance) (re.Rule, error) {
+	rule, err := es.svc.InstantiateTemplate(ctx, token, name, inst)
+	if err != nil {
+		return rule, err
+	}
+
+	event := saveRuleEvent{
+		Rule:     rule,
+		template: name,
+	}
+	if rule.Metadata != nil {
+		event.owner = rule.Metadata.Owner
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return rule, err
+	}
+
+	return rule, nil
+}
+
+func (es *eventStore) CreatePlugin(ctx context.Context, token, kind string, plugin re.Plugin) (re.Result, error) {
+	return es.svc.CreatePlugin(ctx, token, kind, plugin)
+}
+
+func (es *eventStore) ListPlugins(ctx context.Context, token, kind string) ([]string, error) {
+	return es.svc.ListPlugins(ctx, token, kind)
+}
+
+func (es *eventStore) DeletePlugin(ctx context.Context, token, kind, name string) (re.Result, error) {
+	return es.svc.DeletePlugin(ctx, token, kind, name)
+}
+
+func (es *eventStore) RegisterExternalService(ctx context.Context, token string, ext re.ExternalService) (re.Result, error) {
+	return es.svc.RegisterExternalService(ctx, token, ext)
+}
+
+func (es *eventStore) ListExternalServices(ctx context.Context, token string) ([]string, error) {
+	return es.svc.ListExternalServices(ctx, token)
+}
+
+func (es *eventStore) DeleteExternalService(ctx context.Context, token, name string) (re.Result, error) {
+	return es.svc.DeleteExternalService(ctx, token, name)
+}
+
+func (es *eventStore) ListExternalFunctions(ctx context.Context, token string) ([]re.ExternalFunction, error) {
+	return es.svc.ListExternalFunctions(ctx, token)
+}
+
+func (es *eventStore) SaveConfKey(ctx context.Context, token, name string, conf re.MQTTConf) (re.Result, error) {
+	return es.svc.SaveConfKey(ctx, token, name, conf)
+}
+
+func (es *eventStore) ListConfKeys(ctx context.Context, token string) ([]string, error) {
+	return es.svc.ListConfKeys(ctx, token)
+}
+
+func (es *eventStore) DeleteConfKey(ctx context.Context, token, name string) (re.Result, error) {
+	return es.svc.DeleteConfKey(ctx, token, name)
+}
+
+// ruleEvent performs the operation over the existing rule and publishes the
+// event if the operation succeeds.
+func (es *eventStore) ruleEvent(ctx context.Context, operation string, op func(context.Context, string, string) (re.Result, error), token, id string) (re.Result, error) {
+	res, err := op(ctx, token, id)
+	if err != nil {
+		return res, err
+	}
+
+	event := ruleEvent{
+		operation: operation,
+		id:        id,
+		owner:     res.Owner,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	evmocks "github.com/absmach/magistrala/pkg/events/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	token = "token"
+	owner = "6f8a2b1c-3d4e-4f50-8a9b-0c1d2e3f4a5b"
+)
+
+func newEventStore() (*eventStore, *mocks.Service, *evmocks.Publisher) {
+	svc := new(mocks.Service)
+	pub := new(evmocks.Publisher)
+
+	return &eventStore{svc: svc, Publisher: pub}, svc, pub
+}
+
+func TestStreamEvents(t *testing.T) {
+	es, svc, pub := newEventStore()
+	def := re.StreamDef{Name: "temperature", Topic: "channel", Type: re.MainfluxSource, Format: re.JSONFormat, SenML: true}
+	res := re.Result{Name: def.Name, Status: 201, Owner: owner}
+
+	cases := []struct {
+		desc   string
+		update bool
+		event  map[string]interface{}
+	}{
+		{
+			desc: "create stream",
+			event: map[string]interface{}{
+				"operation": streamCreate, "name": def.Name, "owner": owner, "type": re.MainfluxSource, "format": re.JSONFormat, "topic": "channel", "senml": true,
+			},
+		},
+		{
+			desc:   "update stream",
+			update: true,
+			event: map[string]interface{}{
+				"operation": streamUpdate, "name": def.Name, "owner": owner, "type": re.MainfluxSource, "format": re.JSONFormat, "topic": "channel", "senml": true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("CreateStream", mock.Anything, token, def, tc.update).Return(res, nil)
+		var event map[string]interface{}
+		pubCall := pub.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			event, _ = args.Get(1).(createStreamEvent).Encode()
+		}).Return(nil)
+		_, err := es.CreateStream(context.Background(), token, def, tc.update)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected event %v got %v\n", tc.desc, tc.event, event))
+		svcCall.Unset()
+		pubCall.Unset()
+	}
+
+	svcCall := svc.On("DeleteStream", mock.Anything, token, def.Name, false).Return(re.Result{Name: def.Name, Owner: owner}, nil)
+	defer svcCall.Unset()
+	pubCall := pub.On("Publish", mock.Anything, removeStreamEvent{name: def.Name, owner: owner}).Return(nil)
+	defer pubCall.Unset()
+	_, err := es.DeleteStream(context.Background(), token, def.Name, false)
+	assert.Nil(t, err, fmt.Sprintf("delete stream: expected no error got %s\n", err))
+	pub.AssertExpectations(t)
+}
+
+func TestRuleEvents(t *testing.T) {
+	es, svc, pub := newEventStore()
+	rule := re.Rule{
+		ID:  "alarm",
+		SQL: "SELECT * FROM temperature WHERE v > 30",
+		Actions: []re.Action{
+			{Mainflux: &re.MainfluxSink{Channel: "channel"}},
+			{MQTT: &re.MQTTSink{Server: "tcp://broker:1883", Topic: "alarms", Password: "secret"}},
+		},
+	}
+	res := re.Result{Name: rule.ID, Owner: owner}
+
+	svc.On("CreateRule", mock.Anything, token, rule).Return(res, nil)
+	svc.On("UpdateRule", mock.Anything, token, rule).Return(res, nil)
+	svc.On("SaveDraft", mock.Anything, token, rule).Return(res, nil)
+	var saved []map[string]interface{}
+	pub.On("Publish", mock.Anything, mock.AnythingOfType("events.saveRuleEvent")).Run(func(args mock.Arguments) {
+		event, _ := args.Get(1).(saveRuleEvent).Encode()
+		saved = append(saved, event)
+	}).Return(nil).Times(3)
+	_, err := es.CreateRule(context.Background(), token, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	_, err = es.UpdateRule(context.Background(), token, rule)
+	assert.Nil(t, err, fmt.Sprintf("update rule: expected no error got %s\n", err))
+	_, err = es.SaveDraft(context.Background(), token, rule)
+	assert.Nil(t, err, fmt.Sprintf("save draft: expected no error got %s\n", err))
+	expected := []map[string]interface{}{
+		{"operation": ruleCreate, "id": rule.ID, "owner": owner, "sql": rule.SQL, "sinks": "mainflux,mqtt"},
+		{"operation": ruleUpdate, "id": rule.ID, "owner": owner, "sql": rule.SQL, "sinks": "mainflux,mqtt"},
+		{"operation": ruleDraft, "id": rule.ID, "owner": owner, "sql": rule.SQL, "sinks": "mainflux,mqtt"},
+	}
+	assert.Equal(t, expected, saved, fmt.Sprintf("save rule: expected events %v got %v\n", expected, saved))
+
+	cases := []struct {
+		desc      string
+		method    string
+		operation string
+		call      func(context.Context, string, string) (re.Result, error)
+	}{
+		{desc: "delete rule", method: "DeleteRule", operation: ruleRemove, call: es.DeleteRule},
+		{desc: "start rule", method: "StartRule", operation: ruleStart, call: es.StartRule},
+		{desc: "stop rule", method: "StopRule", operation: ruleStop, call: es.StopRule},
+		{desc: "restart rule", method: "RestartRule", operation: ruleRestart, call: es.RestartRule},
+		{desc: "publish rule", method: "PublishRule", operation: rulePublish, call: es.PublishRule},
+		{desc: "unpublish rule", method: "UnpublishRule", operation: ruleUnpublish, call: es.UnpublishRule},
+		{desc: "restore rule", method: "RestoreRule", operation: ruleRestore, call: es.RestoreRule},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On(tc.method, mock.Anything, token, rule.ID).Return(res, nil)
+		pubCall := pub.On("Publish", mock.Anything, ruleEvent{operation: tc.operation, id: rule.ID, owner: owner}).Return(nil).Once()
+		_, err := tc.call(context.Background(), token, rule.ID)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		svcCall.Unset()
+		pubCall.Unset()
+	}
+}
+
+func TestRenameEvents(t *testing.T) {
+	es, svc, pub := newEventStore()
+
+	cases := []struct {
+		desc  string
+		kind  string
+		event map[string]interface{}
+	}{
+		{
+			desc:  "rename stream",
+			kind:  re.StreamKind,
+			event: map[string]interface{}{"operation": streamRename, "name": "old", "new_name": "new", "owner": owner},
+		},
+		{
+			desc:  "rename rule",
+			kind:  re.RuleKind,
+			event: map[string]interface{}{"operation": ruleRename, "name": "old", "new_name": "new", "owner": owner},
+		},
+	}
+
+	for _, tc := range cases {
+		svcCall := svc.On("Rename", mock.Anything, token, tc.kind, "old", "new").Return(re.Result{Name: "new", Owner: owner}, nil)
+		var event map[string]interface{}
+		pubCall := pub.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			event, _ = args.Get(1).(renameEvent).Encode()
+		}).Return(nil)
+		_, err := es.Rename(context.Background(), token, tc.kind, "old", "new")
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected event %v got %v\n", tc.desc, tc.event, event))
+		svcCall.Unset()
+		pubCall.Unset()
+	}
+}
+
+func TestCloneRuleEvents(t *testing.T) {
+	es, svc, pub := newEventStore()
+	clone := re.Rule{
+		ID:      "alarm_test",
+		SQL:     "SELECT * FROM temperature WHERE v > 30",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: "test"}}},
+	}
+
+	svcCall := svc.On("CloneRule", mock.Anything, token, "alarm", clone.ID, "test").Return(re.Result{Name: clone.ID, Owner: owner}, nil)
+	defer svcCall.Unset()
+	viewCall := svc.On("ViewRule", mock.Anything, token, clone.ID).Return(clone, nil)
+	defer viewCall.Unset()
+	var event map[string]interface{}
+	pubCall := pub.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		event, _ = args.Get(1).(saveRuleEvent).Encode()
+	}).Return(nil)
+	defer pubCall.Unset()
+
+	_, err := es.CloneRule(context.Background(), token, "alarm", clone.ID, "test")
+	assert.Nil(t, err, fmt.Sprintf("clone rule: expected no error got %s\n", err))
+	expected := map[string]interface{}{"operation": ruleCreate, "id": clone.ID, "owner": owner, "sql": clone.SQL, "sinks": "mainflux", "source": "alarm"}
+	assert.Equal(t, expected, event, fmt.Sprintf("clone rule: expected event %v got %v\n", expected, event))
+}
+
+func TestFailedOperationEvents(t *testing.T) {
+	es, svc, pub := newEventStore()
+
+	svc.On("DeleteRule", mock.Anything, token, "alarm").Return(re.Result{}, svcerr.ErrNotFound)
+	_, err := es.DeleteRule(context.Background(), token, "alarm")
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("delete missing rule: expected %s got %s\n", svcerr.ErrNotFound, err))
+
+	svc.On("DeleteStream", mock.Anything, token, "temperature", false).Return(re.Result{}, svcerr.ErrAuthentication)
+	_, err = es.DeleteStream(context.Background(), token, "temperature", false)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("delete stream with invalid token: expected %s got %s\n", svcerr.ErrAuthentication, err))
+
+	pub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var errExpiryPassed = errors.New("rule expiry time has passed")
+
+// ExpiredRule is the temporary rule removed once its expiry time passed.
+// Name is the Kuiper name of the rule, ID the rule ID the owner sees and
+// Error why the removal failed.
+type ExpiredRule struct {
+	Name      string    `json:"name"`
+	ID        string    `json:"id"`
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Expirer removes the expired temporary rules of all the users, so it's used
+// by the background expiry job and never exposed over the API.
+type Expirer interface {
+	// Expire removes the rules whose expiry time passed like the deleted
+	// ones and returns them.
+	Expire(ctx context.Context) ([]ExpiredRule, error)
+}
+
+type expirer struct {
+	svc *reService
+}
+
+// NewExpirer instantiates the expirer running the rules in the given engine.
+func NewExpirer(engine RuleEngine, cfg Config, repo Repository) Expirer {
+	return &expirer{svc: newService(engine, cfg, nil, nil, Notifiers{}, nil, repo)}
+}
+
+func (e *expirer) Expire(ctx context.Context) ([]ExpiredRule, error) {
+	mds, err := e.svc.repo.RetrieveAll(ctx, RuleKind, "")
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	now := time.Now().UTC()
+	expired := []ExpiredRule{}
+	for name, md := range mds {
+		if md.ExpiresAt.IsZero() || md.ExpiresAt.After(now) || md.deleted() {
+			continue
+		}
+		r := ExpiredRule{Name: name, ID: strings.TrimPrefix(name, prefix(md.Owner)), Owner: md.Owner, ExpiresAt: md.ExpiresAt}
+		// Expired rules are removed like the deleted ones, permanently and
+		// on behalf of no user.
+		if _, err := e.svc.deleteOwnedRule(ctx, "", "", md.Owner, r.ID, true); err != nil {
+			r.Error = err.Error()
+			expired = append(expired, r)
+			continue
+		}
+		if !md.Draft {
+			e.svc.emit(ctx, "", md.Owner, EventRuleStopped, r.ID, errExpiryPassed.Error())
+		}
+		expired = append(expired, r)
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].Name < expired[j].Name
+	})
+
+	return expired, nil
+}
+
+// checkExpiry checks that the expiry time of the temporary rule, if any,
+// hasn't passed.
+func checkExpiry(expiresAt time.Time) error {
+	if !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return errExpiryPassed
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTemporaryRule(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	expiresAt := time.Now().UTC().Add(2 * time.Hour).Truncate(time.Second)
+	cases := []struct {
+		desc      string
+		id        string
+		expiresAt time.Time
+		err       error
+	}{
+		{
+			desc:      "create temporary rule",
+			id:        "debug",
+			expiresAt: expiresAt,
+		},
+		{
+			desc:      "create temporary rule with passed expiry time",
+			id:        "expired",
+			expiresAt: time.Now().Add(-time.Minute),
+			err:       svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		rule := re.Rule{
+			ID:        tc.id,
+			SQL:       "SELECT * FROM stream",
+			Actions:   []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+			ExpiresAt: tc.expiresAt,
+		}
+		_, err := svc.CreateRule(context.Background(), validToken, rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		rule, err = svc.ViewRule(context.Background(), validToken, tc.id)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.expiresAt, rule.ExpiresAt, fmt.Sprintf("%s: expected expiry time %s got %s\n", tc.desc, tc.expiresAt, rule.ExpiresAt))
+	}
+}
+
+func TestExpire(t *testing.T) {
+	k, url := newKuiper(t)
+	repo := mocks.NewRepository()
+	now := time.Now().UTC()
+	k.rules[userPrefix+"expired"] = re.Rule{ID: userPrefix + "expired"}
+	k.rules[userPrefix+"temporary"] = re.Rule{ID: userPrefix + "temporary"}
+	k.rules[userPrefix+"chained"] = re.Rule{ID: userPrefix + "chained"}
+	err := repo.SaveChain(context.Background(), re.Chain{Name: "chain", Owner: userID, From: "chained", To: "next"})
+	assert.Nil(t, err, fmt.Sprintf("save chain: expected no error got %s\n", err))
+	mds := map[string]re.Metadata{
+		"expired":   {Owner: userID, ExpiresAt: now.Add(-time.Minute)},
+		"chained":   {Owner: userID, ExpiresAt: now.Add(-time.Minute)},
+		"temporary": {Owner: userID, ExpiresAt: now.Add(time.Hour)},
+		"draft":     {Owner: userID, ExpiresAt: now.Add(-time.Minute), Draft: true, Definition: `{"id":"draft"}`},
+		"rule":      {Owner: userID},
+	}
+	for name, md := range mds {
+		err := repo.Save(context.Background(), re.RuleKind, userPrefix+name, md)
+		assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	}
+
+	cfg := re.Config{URL: url}
+	e := re.NewExpirer(re.NewKuiper(cfg), cfg, repo)
+	expired, err := e.Expire(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("expire: expected no error got %s\n", err))
+	ids := []string{}
+	for _, r := range expired {
+		if r.ID == "chained" {
+			assert.NotEmpty(t, r.Error, "expected error removing chained rule")
+			continue
+		}
+		assert.Empty(t, r.Error, fmt.Sprintf("expected no error removing %s got %s\n", r.Name, r.Error))
+		ids = append(ids, r.ID)
+	}
+	assert.Equal(t, []string{"draft", "expired"}, ids, fmt.Sprintf("expected expired draft and rule got %v\n", ids))
+	_, ok := k.rules[userPrefix+"chained"]
+	assert.True(t, ok, "expected chained rule to be kept")
+	_, ok = k.rules[userPrefix+"expired"]
+	assert.False(t, ok, "expected expired rule to be removed from Kuiper")
+	_, ok = k.rules[userPrefix+"temporary"]
+	assert.True(t, ok, "expected temporary rule that hasn't expired to be kept")
+	_, ok = k.rules[userPrefix+"rule"]
+	assert.True(t, ok, "expected rule without expiry time to be kept")
+	all, err := repo.RetrieveAll(context.Background(), re.RuleKind, userID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve metadata: expected no error got %s\n", err))
+	assert.Len(t, all, 3, fmt.Sprintf("expected metadata of 3 rules left got %v\n", all))
+	page, err := repo.RetrieveAuditEvents(context.Background(), re.AuditQuery{Owner: userID, Entity: "expired", Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("retrieve audit events: expected no error got %s\n", err))
+	assert.Len(t, page.Events, 1, fmt.Sprintf("expected audit event of expired rule got %v\n", page.Events))
+	for _, ev := range page.Events {
+		assert.Equal(t, re.AuditDelete, ev.Operation, fmt.Sprintf("expected %s audit event got %s\n", re.AuditDelete, ev.Operation))
+		assert.Empty(t, ev.User, fmt.Sprintf("expected audit event without user got %s\n", ev.User))
+	}
+}
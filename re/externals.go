@@ -0,0 +1,104 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+const (
+	servicesPath  = "/services"
+	functionsPath = "/services/functions"
+)
+
+// ExternalService is the external gRPC, REST or msgpack-rpc service Kuiper
+// calls as SQL functions, e.g. for ML inference or geo lookups. Kuiper
+// downloads the zip File containing the service descriptor JSON and the
+// schema files of its interfaces from the http or https URL.
+type ExternalService struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
+// ExternalFunction is the SQL function mapped to the method of the
+// interface of the external service.
+type ExternalFunction struct {
+	Name          string `json:"name"`
+	ServiceName   string `json:"serviceName"`
+	InterfaceName string `json:"interfaceName"`
+	MethodName    string `json:"methodName"`
+}
+
+func (svc *reService) RegisterExternalService(ctx context.Context, token string, es ExternalService) (Result, error) {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return Result{}, err
+	}
+	if err := validateName(es.Name); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if err := validateFileURL(es.File); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.engine.RegisterService(ctx, es)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res.Name = es.Name
+
+	return res, nil
+}
+
+func (svc *reService) ListExternalServices(ctx context.Context, token string) ([]string, error) {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return nil, err
+	}
+
+	names, err := svc.engine.ListServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (svc *reService) DeleteExternalService(ctx context.Context, token, name string) (Result, error) {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return Result{}, err
+	}
+	if err := validateName(name); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.engine.DeleteService(ctx, name)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res.Name = name
+
+	return res, nil
+}
+
+func (svc *reService) ListExternalFunctions(ctx context.Context, token string) ([]ExternalFunction, error) {
+	if _, err := svc.identify(ctx, token); err != nil {
+		return nil, err
+	}
+
+	funcs, err := svc.engine.ListFunctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(funcs, func(i, j int) bool {
+		return funcs[i].Name < funcs[j].Name
+	})
+
+	return funcs, nil
+}
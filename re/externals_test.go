@@ -0,0 +1,209 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const serviceFile = "https://example.com/services/geo.zip"
+
+func TestRegisterExternalService(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	cases := []struct {
+		desc       string
+		token      string
+		authorized bool
+		service    re.ExternalService
+		err        error
+	}{
+		{
+			desc:       "register external service",
+			token:      validToken,
+			authorized: true,
+			service:    re.ExternalService{Name: "geo", File: serviceFile},
+		},
+		{
+			desc:       "register existing external service",
+			token:      validToken,
+			authorized: true,
+			service:    re.ExternalService{Name: "geo", File: serviceFile},
+			err:        svcerr.ErrConflict,
+		},
+		{
+			desc:    "register external service with invalid token",
+			token:   invalidToken,
+			service: re.ExternalService{Name: "other", File: serviceFile},
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "register external service by non-admin user",
+			token:   validToken,
+			service: re.ExternalService{Name: "other", File: serviceFile},
+			err:     svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "register external service with malformed name",
+			token:      validToken,
+			authorized: true,
+			service:    re.ExternalService{Name: "../other", File: serviceFile},
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "register external service from local file",
+			token:      validToken,
+			authorized: true,
+			service:    re.ExternalService{Name: "other", File: "file:///etc/passwd"},
+			err:        svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall2 := authorizeAdmin(auth, tc.authorized)
+		res, err := svc.RegisterExternalService(context.Background(), tc.token, tc.service)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.service.Name, res.Name, fmt.Sprintf("%s: expected result name %s got %s\n", tc.desc, tc.service.Name, res.Name))
+			es := k.services[tc.service.Name]
+			assert.Equal(t, tc.service, es, fmt.Sprintf("%s: expected service %v got %v\n", tc.desc, tc.service, es))
+		}
+		authCall2.Unset()
+	}
+	_, ok := k.services["other"]
+	assert.False(t, ok, "expected invalid services not to be registered")
+}
+
+func TestListExternalServices(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.services["geo"] = re.ExternalService{Name: "geo"}
+	k.services["anomaly"] = re.ExternalService{Name: "anomaly"}
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc       string
+		authorized bool
+		services   []string
+		err        error
+	}{
+		{
+			desc:       "list external services",
+			authorized: true,
+			services:   []string{"anomaly", "geo"},
+		},
+		{
+			desc: "list external services by non-admin user",
+			err:  svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall1 := authorizeAdmin(auth, tc.authorized)
+		services, err := svc.ListExternalServices(context.Background(), validToken)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.services, services, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.services, services))
+		authCall1.Unset()
+	}
+}
+
+func TestDeleteExternalService(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.services["geo"] = re.ExternalService{Name: "geo"}
+	k.functions = []re.ExternalFunction{{Name: "distance", ServiceName: "geo"}}
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc       string
+		authorized bool
+		name       string
+		err        error
+	}{
+		{
+			desc: "delete external service by non-admin user",
+			name: "geo",
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "delete external service",
+			authorized: true,
+			name:       "geo",
+		},
+		{
+			desc:       "delete non-existing external service",
+			authorized: true,
+			name:       "geo",
+			err:        svcerr.ErrNotFound,
+		},
+		{
+			desc:       "delete external service with path traversal",
+			authorized: true,
+			name:       "../rules/rule",
+			err:        svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall1 := authorizeAdmin(auth, tc.authorized)
+		res, err := svc.DeleteExternalService(context.Background(), validToken, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.name, res.Name, fmt.Sprintf("%s: expected result name %s got %s\n", tc.desc, tc.name, res.Name))
+			_, ok := k.services[tc.name]
+			assert.False(t, ok, fmt.Sprintf("%s: expected service to be removed\n", tc.desc))
+		}
+		authCall1.Unset()
+	}
+	assert.Empty(t, k.functions, "expected functions of the removed service to be removed")
+}
+
+func TestListExternalFunctions(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	inside := re.ExternalFunction{Name: "inside", ServiceName: "geo", InterfaceName: "geo", MethodName: "Inside"}
+	distance := re.ExternalFunction{Name: "distance", ServiceName: "geo", InterfaceName: "geo", MethodName: "Distance"}
+	k.functions = []re.ExternalFunction{inside, distance}
+
+	cases := []struct {
+		desc  string
+		token string
+		funcs []re.ExternalFunction
+		err   error
+	}{
+		{
+			desc:  "list external functions by non-admin user",
+			token: validToken,
+			funcs: []re.ExternalFunction{distance, inside},
+		},
+		{
+			desc:  "list external functions with invalid token",
+			token: invalidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		funcs, err := svc.ListExternalFunctions(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.funcs, funcs, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.funcs, funcs))
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Aggregate statuses of the rule deployed to the fleet of edge gateways.
+// The rollout is pending while any gateway hasn't reported the rule yet,
+// running once all the gateways run it, failed if all of them failed and
+// degraded if only some did. The rollout without deployments is empty.
+const (
+	RolloutEmpty    = "empty"
+	RolloutPending  = "pending"
+	RolloutRunning  = "running"
+	RolloutDegraded = "degraded"
+	RolloutFailed   = "failed"
+)
+
+var errNoGateways = errors.New("no gateway matches the labels")
+
+// Rollout is the aggregate status of the rule deployed to the edge
+// gateways along with the deployments to each of them. Deployments being
+// removed are counted but don't affect the aggregate status.
+type Rollout struct {
+	Rule        string       `json:"rule"`
+	Status      string       `json:"status"`
+	Total       int          `json:"total"`
+	Pending     int          `json:"pending"`
+	Running     int          `json:"running"`
+	Failed      int          `json:"failed"`
+	Removing    int          `json:"removing"`
+	Deployments []Deployment `json:"deployments"`
+}
+
+func (svc *reService) DeployFleet(ctx context.Context, token, id string, labels map[string]string) (Rollout, error) {
+	if svc.edge == nil {
+		return Rollout{}, errors.Wrap(svcerr.ErrMalformedEntity, errEdgeDisabled)
+	}
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Rollout{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return Rollout{}, err
+	}
+	all, err := svc.repo.RetrieveGateways(ctx, owner)
+	if err != nil {
+		return Rollout{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	var gws []Gateway
+	for _, gw := range all {
+		if hasLabels(gw.Labels, labels) {
+			gws = append(gws, gw)
+		}
+	}
+	if len(gws) == 0 {
+		return Rollout{}, errors.Wrap(svcerr.ErrMalformedEntity, errNoGateways)
+	}
+	if err := svc.fanOut(ctx, userID, owner, id, gws); err != nil {
+		return Rollout{}, err
+	}
+
+	return svc.rollout(ctx, owner, id)
+}
+
+func (svc *reService) RetryDeployments(ctx context.Context, token, id string) (Rollout, error) {
+	if svc.edge == nil {
+		return Rollout{}, errors.Wrap(svcerr.ErrMalformedEntity, errEdgeDisabled)
+	}
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Rollout{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return Rollout{}, err
+	}
+	deployed, err := svc.repo.RetrieveDeployments(ctx, prefix(owner)+id, "")
+	if err != nil {
+		return Rollout{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	var gws []Gateway
+	for _, d := range deployed {
+		if d.Status != DeployFailed {
+			continue
+		}
+		gw, err := svc.gateway(ctx, owner, d.Gateway)
+		if err != nil {
+			return Rollout{}, err
+		}
+		gws = append(gws, gw)
+	}
+	if len(gws) > 0 {
+		if err := svc.fanOut(ctx, userID, owner, id, gws); err != nil {
+			return Rollout{}, err
+		}
+	}
+
+	return svc.rollout(ctx, owner, id)
+}
+
+func (svc *reService) ViewRollout(ctx context.Context, token, id string) (Rollout, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Rollout{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ViewAccess)
+	if err != nil {
+		return Rollout{}, err
+	}
+
+	return svc.rollout(ctx, owner, id)
+}
+
+// fanOut deploys the owner's rule to the gateways. The deploy command is
+// built once, and the gateways the command couldn't be published to are
+// marked failed, so they are deployed to again on retry instead of failing
+// the rollout to the rest of the fleet.
+func (svc *reService) fanOut(ctx context.Context, userID, owner, id string, gws []Gateway) error {
+	cmd, err := svc.deployCommand(ctx, owner, id)
+	if err != nil {
+		return err
+	}
+	name := prefix(owner) + id
+	for _, gw := range gws {
+		if _, err := svc.sendCommand(ctx, gw, name, DeployPending, cmd); err != nil {
+			d := Deployment{Rule: name, Gateway: gw.ID, Status: DeployFailed, Error: err.Error(), UpdatedAt: time.Now().UTC()}
+			if err := svc.repo.SaveDeployment(ctx, d); err != nil {
+				return errors.Wrap(svcerr.ErrUpdateEntity, err)
+			}
+			continue
+		}
+		if err := svc.audit(ctx, userID, owner, RuleKind, id, AuditDeploy, "", gw.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollout returns the aggregate status of the owner's rule deployments.
+func (svc *reService) rollout(ctx context.Context, owner, id string) (Rollout, error) {
+	deployed, err := svc.repo.RetrieveDeployments(ctx, prefix(owner)+id, "")
+	if err != nil {
+		return Rollout{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	sort.Slice(deployed, func(i, j int) bool {
+		return deployed[i].Gateway < deployed[j].Gateway
+	})
+
+	r := Rollout{Rule: id, Total: len(deployed), Deployments: deployed}
+	for i := range r.Deployments {
+		r.Deployments[i].Rule = id
+		switch r.Deployments[i].Status {
+		case DeployPending:
+			r.Pending++
+		case DeployRunning:
+			r.Running++
+		case DeployFailed:
+			r.Failed++
+		case DeployRemoving:
+			r.Removing++
+		}
+	}
+	if r.Deployments == nil {
+		r.Deployments = []Deployment{}
+	}
+
+	switch active := r.Total - r.Removing; {
+	case active == 0:
+		r.Status = RolloutEmpty
+	case r.Pending > 0:
+		r.Status = RolloutPending
+	case r.Running == active:
+		r.Status = RolloutRunning
+	case r.Failed == active:
+		r.Status = RolloutFailed
+	default:
+		r.Status = RolloutDegraded
+	}
+
+	return r, nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var (
+	errFolderMissing = errors.New("folder doesn't exist")
+	errFolderOwner   = errors.New("folders contain only the user's own rules")
+)
+
+// Folder organizes the user's rules, by project or site for example. The
+// folder of the rule is stored in the rule metadata, and Rules is the number
+// of the live rules in the folder.
+type Folder struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Owner       string    `json:"-"`
+	Rules       int       `json:"rules"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FolderRepository specifies the persistence API of the rule folders.
+type FolderRepository interface {
+	// SaveFolder stores the folder. Folders are identified by the owner and
+	// the name.
+	SaveFolder(ctx context.Context, f Folder) error
+
+	// RetrieveFolders returns the folders of the owner.
+	RetrieveFolders(ctx context.Context, owner string) ([]Folder, error)
+
+	// RemoveFolder removes the owner's folder with the given name.
+	RemoveFolder(ctx context.Context, owner, name string) error
+}
+
+func (svc *reService) CreateFolder(ctx context.Context, token string, f Folder) (Folder, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Folder{}, err
+	}
+	if err := validateName(f.Name); err != nil {
+		return Folder{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	f.Owner = userID
+	f.Rules = 0
+	f.CreatedAt = time.Now().UTC()
+	switch err := svc.repo.SaveFolder(ctx, f); {
+	case errors.Contains(err, repoerr.ErrConflict):
+		return Folder{}, errors.Wrap(svcerr.ErrConflict, err)
+	case err != nil:
+		return Folder{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return f, nil
+}
+
+func (svc *reService) ListFolders(ctx context.Context, token string) ([]Folder, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := svc.repo.RetrieveFolders(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	mds, err := svc.repo.RetrieveAll(ctx, RuleKind, userID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	rules := map[string]int{}
+	for _, md := range mds {
+		if md.Folder != "" && !md.deleted() {
+			rules[md.Folder]++
+		}
+	}
+	for i := range folders {
+		folders[i].Rules = rules[folders[i].Name]
+	}
+	sort.Slice(folders, func(i, j int) bool {
+		return folders[i].Name < folders[j].Name
+	})
+
+	return folders, nil
+}
+
+func (svc *reService) RemoveFolder(ctx context.Context, token, name string) error {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	switch err := svc.repo.RemoveFolder(ctx, userID, name); {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	// The rules of the removed folder are left without a folder.
+	mds, err := svc.repo.RetrieveAll(ctx, RuleKind, userID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	for kuiperID, md := range mds {
+		if md.Folder != name {
+			continue
+		}
+		md.Folder = ""
+		if err := svc.repo.Save(ctx, RuleKind, kuiperID, md); err != nil {
+			return errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (svc *reService) MoveRules(ctx context.Context, token, folder string, ids []string) (BulkReport, error) {
+	if len(ids) > maxBulkItems {
+		return BulkReport{}, errors.Wrap(svcerr.ErrMalformedEntity, errBulkSize)
+	}
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	if folder != "" {
+		if err := svc.checkFolder(ctx, userID, folder); err != nil {
+			return BulkReport{}, err
+		}
+	}
+
+	report := BulkReport{Items: []BulkItem{}}
+	report.add(svc.bulk(RuleKind, len(ids), func(i int) (string, error) {
+		return ids[i], svc.moveRule(ctx, token, userID, ids[i], folder)
+	}))
+
+	return report, nil
+}
+
+// checkFolder checks that the owner's folder exists.
+func (svc *reService) checkFolder(ctx context.Context, owner, name string) error {
+	folders, err := svc.repo.RetrieveFolders(ctx, owner)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for _, f := range folders {
+		if f.Name == name {
+			return nil
+		}
+	}
+
+	return errors.Wrap(svcerr.ErrNotFound, errFolderMissing)
+}
+
+// moveRule moves the user's rule to the folder, or out of its folder if the
+// folder is empty. The metadata is stored for the rules created before their
+// metadata was.
+func (svc *reService) moveRule(ctx context.Context, token, userID, id, folder string) error {
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return err
+	}
+	if owner != userID {
+		return errors.Wrap(svcerr.ErrAuthorization, errFolderOwner)
+	}
+	kuiperID := prefix(owner) + id
+	md, err := svc.metadata(ctx, RuleKind, kuiperID)
+	if err != nil {
+		return err
+	}
+	if md == nil {
+		if _, err := svc.engine.ViewRule(ctx, kuiperID); err != nil {
+			return err
+		}
+		md = &Metadata{Owner: owner, CreatedAt: time.Now().UTC()}
+	}
+	md.Folder = folder
+	if err := svc.repo.Save(ctx, RuleKind, kuiperID, *md); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+// folder returns the folder of the rule, empty if the rule has no metadata.
+func (md *Metadata) folder() string {
+	if md == nil {
+		return ""
+	}
+
+	return md.Folder
+}
@@ -0,0 +1,131 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateFolder(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	cases := []struct {
+		desc   string
+		token  string
+		folder re.Folder
+		err    error
+	}{
+		{
+			desc:   "create folder",
+			token:  validToken,
+			folder: re.Folder{Name: "site_a", Description: "Site A rules"},
+		},
+		{
+			desc:   "create existing folder",
+			token:  validToken,
+			folder: re.Folder{Name: "site_a"},
+			err:    svcerr.ErrConflict,
+		},
+		{
+			desc:   "create folder with invalid name",
+			token:  validToken,
+			folder: re.Folder{Name: "site a"},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "create folder with invalid token",
+			token:  invalidToken,
+			folder: re.Folder{Name: "site_b"},
+			err:    svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		f, err := svc.CreateFolder(context.Background(), tc.token, tc.folder)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.folder.Name, f.Name, fmt.Sprintf("%s: expected folder %s got %s\n", tc.desc, tc.folder.Name, f.Name))
+			assert.False(t, f.CreatedAt.IsZero(), fmt.Sprintf("%s: expected creation time\n", tc.desc))
+		}
+	}
+}
+
+func TestMoveRules(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	_, err := svc.CreateFolder(context.Background(), validToken, re.Folder{Name: "site_a"})
+	assert.Nil(t, err, fmt.Sprintf("create folder: expected no error got %s\n", err))
+
+	_, err = svc.MoveRules(context.Background(), validToken, "site_b", []string{"rule"})
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("move rules to missing folder: expected %s got %s\n", svcerr.ErrNotFound, err))
+
+	report, err := svc.MoveRules(context.Background(), validToken, "site_a", []string{"rule", "unknown"})
+	assert.Nil(t, err, fmt.Sprintf("move rules: expected no error got %s\n", err))
+	assert.Equal(t, 1, report.Succeeded, fmt.Sprintf("move rules: expected 1 moved rule got %d\n", report.Succeeded))
+	assert.Equal(t, 1, report.Failed, fmt.Sprintf("move rules: expected 1 failed rule got %d\n", report.Failed))
+
+	page, err := svc.ListRules(context.Background(), validToken, re.PageMetadata{Limit: 10, Folder: "site_a"})
+	assert.Nil(t, err, fmt.Sprintf("list folder rules: expected no error got %s\n", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("list folder rules: expected 1 rule got %d\n", page.Total))
+	page, err = svc.ListRules(context.Background(), validToken, re.PageMetadata{Limit: 10, Folder: "site_b"})
+	assert.Nil(t, err, fmt.Sprintf("list other folder rules: expected no error got %s\n", err))
+	assert.Equal(t, uint64(0), page.Total, fmt.Sprintf("list other folder rules: expected no rules got %d\n", page.Total))
+
+	// Updates keep the rule in its folder.
+	rule, err := svc.ViewRule(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("view rule: expected no error got %s\n", err))
+	assert.Equal(t, "site_a", rule.Metadata.Folder, fmt.Sprintf("view rule: expected folder site_a got %s\n", rule.Metadata.Folder))
+	_, err = svc.UpdateRule(context.Background(), validToken, re.Rule{ID: "rule", SQL: "SELECT * FROM stream WHERE v > 20", Actions: rule.Actions})
+	assert.Nil(t, err, fmt.Sprintf("update rule: expected no error got %s\n", err))
+	folders, err := svc.ListFolders(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list folders: expected no error got %s\n", err))
+	assert.Equal(t, []re.Folder{{Name: "site_a", Owner: userID, Rules: 1, CreatedAt: folders[0].CreatedAt}}, folders, fmt.Sprintf("list folders: expected site_a with 1 rule got %v\n", folders))
+
+	_, err = svc.MoveRules(context.Background(), validToken, "", []string{"rule"})
+	assert.Nil(t, err, fmt.Sprintf("move rules out of folder: expected no error got %s\n", err))
+	folders, err = svc.ListFolders(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list folders: expected no error got %s\n", err))
+	assert.Equal(t, 0, folders[0].Rules, fmt.Sprintf("list folders: expected no rules got %d\n", folders[0].Rules))
+}
+
+func TestRemoveFolder(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	_, err := svc.CreateFolder(context.Background(), validToken, re.Folder{Name: "site_a"})
+	assert.Nil(t, err, fmt.Sprintf("create folder: expected no error got %s\n", err))
+	_, err = svc.MoveRules(context.Background(), validToken, "site_a", []string{"rule"})
+	assert.Nil(t, err, fmt.Sprintf("move rules: expected no error got %s\n", err))
+
+	err = svc.RemoveFolder(context.Background(), validToken, "site_a")
+	assert.Nil(t, err, fmt.Sprintf("remove folder: expected no error got %s\n", err))
+	err = svc.RemoveFolder(context.Background(), validToken, "site_a")
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("remove removed folder: expected %s got %s\n", svcerr.ErrNotFound, err))
+
+	// The rules of the removed folder aren't listed in the new folder with
+	// the same name.
+	_, err = svc.CreateFolder(context.Background(), validToken, re.Folder{Name: "site_a"})
+	assert.Nil(t, err, fmt.Sprintf("create folder again: expected no error got %s\n", err))
+	page, err := svc.ListRules(context.Background(), validToken, re.PageMetadata{Limit: 10, Folder: "site_a"})
+	assert.Nil(t, err, fmt.Sprintf("list folder rules: expected no error got %s\n", err))
+	assert.Equal(t, uint64(0), page.Total, fmt.Sprintf("list folder rules: expected no rules got %d\n", page.Total))
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"strings"
+
+	mgauth "github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var errGroupMember = errors.New("user isn't a member of the group")
+
+// namespace returns the owner and the name of the stream, table or rule the
+// user creates. Users create the entities in their own namespace or in the
+// namespace of the group they are members of, referred to by the group ID
+// and the name separated by a colon, e.g. "<group>:<name>". The entities of
+// the group namespace are owned by the group, so all its members manage
+// them.
+func (svc *reService) namespace(ctx context.Context, token, userID, ref string) (string, string, error) {
+	owner, name, grouped := strings.Cut(ref, ownerSeparator)
+	if !grouped {
+		return userID, ref, nil
+	}
+	if err := validateName(name); err != nil {
+		return "", "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if owner == userID {
+		return owner, name, nil
+	}
+	if err := validateName(prefix(owner) + name); err != nil {
+		return "", "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if !svc.member(ctx, token, owner) {
+		return "", "", errors.Wrap(svcerr.ErrAuthorization, errGroupMember)
+	}
+
+	return owner, name, nil
+}
+
+// listOwner returns the owner of the streams, tables or rules the user lists,
+// which is either the user or the group given in the page metadata.
+func (svc *reService) listOwner(ctx context.Context, token, userID string, pm PageMetadata) (string, error) {
+	if pm.Owner == "" || pm.Owner == userID {
+		return userID, nil
+	}
+	if !svc.member(ctx, token, pm.Owner) {
+		return "", errors.Wrap(svcerr.ErrAuthorization, errGroupMember)
+	}
+
+	return pm.Owner, nil
+}
+
+// member reports whether the token's user is a member of the group.
+func (svc *reService) member(ctx context.Context, token, groupID string) bool {
+	return svc.authorize(ctx, token, mgauth.MembershipPermission, mgauth.GroupType, groupID) == nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var groupPrefix = "u" + strings.ReplaceAll(groupID, "-", "") + "_"
+
+func TestGroupNamespace(t *testing.T) {
+	cases := []struct {
+		desc    string
+		member  bool
+		err     error
+		viewErr error
+	}{
+		{
+			desc:   "manage entities of group of user",
+			member: true,
+		},
+		{
+			desc:    "manage entities of group of other users",
+			err:     svcerr.ErrAuthorization,
+			viewErr: svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, k, auth, _ := newService(t)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := authorizeMember(auth, validToken, groupID, tc.member)
+		authCall2 := authorizeChannel(auth, validToken, channelID, true)
+
+		stream := re.StreamDef{Name: groupID + ":stream", Topic: channelID, SenML: true}
+		res, err := svc.CreateStream(context.Background(), validToken, stream, false)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: create stream: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, groupID, res.Owner, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, groupID, res.Owner))
+			_, ok := k.streams[groupPrefix+"stream"]
+			assert.True(t, ok, fmt.Sprintf("%s: expected stream in group namespace\n", tc.desc))
+			stream.ConfKey = "default"
+			_, err = svc.CreateStream(context.Background(), validToken, stream, true)
+			assert.True(t, errors.Contains(err, svcerr.ErrAuthorization), fmt.Sprintf("%s: update stream with confKey: expected %s got %s\n", tc.desc, svcerr.ErrAuthorization, err))
+		}
+
+		table := re.TableDef{Name: groupID + ":table", Topic: "devices.json", Fields: []re.Field{{Name: "id", Type: "string"}}}
+		_, err = svc.CreateTable(context.Background(), validToken, table)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: create table: expected %s got %s\n", tc.desc, tc.err, err))
+		_, err = svc.ViewTable(context.Background(), validToken, groupID+":table")
+		assert.True(t, errors.Contains(err, tc.viewErr), fmt.Sprintf("%s: view table: expected %s got %s\n", tc.desc, tc.viewErr, err))
+
+		rule := re.Rule{
+			ID:      groupID + ":rule",
+			SQL:     "SELECT * FROM stream WHERE v > 30",
+			Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+		}
+		_, err = svc.CreateRule(context.Background(), validToken, rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: create rule: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			sql := "SELECT * FROM " + groupPrefix + "stream WHERE v > 30"
+			assert.Equal(t, sql, k.rules[groupPrefix+"rule"].SQL, fmt.Sprintf("%s: expected SQL %s got %s\n", tc.desc, sql, k.rules[groupPrefix+"rule"].SQL))
+		}
+		_, err = svc.StopRule(context.Background(), validToken, groupID+":rule")
+		assert.True(t, errors.Contains(err, tc.viewErr), fmt.Sprintf("%s: stop rule: expected %s got %s\n", tc.desc, tc.viewErr, err))
+
+		page, err := svc.ListRules(context.Background(), validToken, re.PageMetadata{Limit: 10, Owner: groupID})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: list rules: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Len(t, page.Rules, 1, fmt.Sprintf("%s: expected 1 group rule got %d\n", tc.desc, len(page.Rules)))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// IdentityCacheConfig defines the cache of the users identified by their
+// tokens, which cuts the auth service round trips of bursty rule management.
+// The identities are kept for TTL, and up to Size tokens are cached. Zero TTL
+// disables caching.
+type IdentityCacheConfig struct {
+	TTL  time.Duration `env:"TTL"  envDefault:"10s"`
+	Size int           `env:"SIZE" envDefault:"10000"`
+}
+
+// identities caches the user IDs by the token hashes, so the tokens
+// themselves are never kept in memory.
+type identities struct {
+	ttl  time.Duration
+	size int
+	mu   sync.Mutex
+	ids  map[[sha256.Size]byte]identity
+}
+
+type identity struct {
+	userID   string
+	domainID string
+	expires  time.Time
+}
+
+func newIdentities(cfg IdentityCacheConfig) *identities {
+	return &identities{
+		ttl:  cfg.TTL,
+		size: cfg.Size,
+		ids:  make(map[[sha256.Size]byte]identity),
+	}
+}
+
+// get returns the cached identity of the token.
+func (c *identities) get(token string) (identity, bool) {
+	if c.ttl <= 0 {
+		return identity{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := sha256.Sum256([]byte(token))
+	id, ok := c.ids[key]
+	if !ok {
+		return identity{}, false
+	}
+	if time.Now().After(id.expires) {
+		delete(c.ids, key)
+		return identity{}, false
+	}
+
+	return id, true
+}
+
+// set caches the identity of the token. If the cache is full,
+// the expired identities are dropped first and the identity isn't cached
+// if that doesn't free the space.
+func (c *identities) set(token string, id identity) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.ids) >= c.size {
+		for key, id := range c.ids {
+			if now.After(id.expires) {
+				delete(c.ids, key)
+			}
+		}
+		if len(c.ids) >= c.size {
+			return
+		}
+	}
+	id.expires = now.Add(c.ttl)
+	c.ids[sha256.Sum256([]byte(token))] = id
+}
+
+// remove drops the cached identity of the token.
+func (c *identities) remove(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.ids, sha256.Sum256([]byte(token)))
+}
+
+// forget drops the cached identity of the token if the SDK call made with
+// the token failed to authenticate, e.g. because the token was revoked
+// after it was cached.
+func (svc *reService) forget(token string, err errors.SDKError) {
+	if err != nil && err.StatusCode() == http.StatusUnauthorized {
+		svc.identities.remove(token)
+	}
+}
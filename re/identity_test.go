@@ -0,0 +1,103 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdentityCache(t *testing.T) {
+	stream := re.StreamDef{Name: "stream", Topic: channelID, SenML: true}
+
+	cases := []struct {
+		desc        string
+		cfg         re.IdentityCacheConfig
+		token       string
+		identifyErr error
+		authzErr    error
+		ops         []string
+		calls       int
+	}{
+		{
+			desc:  "identify without cache",
+			token: validToken,
+			ops:   []string{"list", "list", "list"},
+			calls: 3,
+		},
+		{
+			desc:  "identify with cache",
+			cfg:   re.IdentityCacheConfig{TTL: time.Minute, Size: 10},
+			token: validToken,
+			ops:   []string{"list", "list", "list"},
+			calls: 1,
+		},
+		{
+			desc:  "identify after cached identity expired",
+			cfg:   re.IdentityCacheConfig{TTL: 10 * time.Millisecond, Size: 10},
+			token: validToken,
+			ops:   []string{"list", "list", "wait", "list"},
+			calls: 2,
+		},
+		{
+			desc:        "identify invalid token with cache",
+			cfg:         re.IdentityCacheConfig{TTL: time.Minute, Size: 10},
+			token:       invalidToken,
+			identifyErr: svcerr.ErrAuthentication,
+			ops:         []string{"list", "list"},
+			calls:       2,
+		},
+		{
+			desc:  "identify with full cache",
+			cfg:   re.IdentityCacheConfig{TTL: time.Minute},
+			token: validToken,
+			ops:   []string{"list", "list"},
+			calls: 2,
+		},
+		{
+			desc:     "identify after token was revoked",
+			cfg:      re.IdentityCacheConfig{TTL: time.Minute, Size: 10},
+			token:    validToken,
+			authzErr: svcerr.ErrAuthentication,
+			ops:      []string{"list", "update", "list", "list"},
+			calls:    2,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _, auth, _ := newServiceWithConfig(t, re.Config{IdentityCache: tc.cfg}, re.Notifiers{})
+		res := &magistrala.IdentityRes{UserId: userID}
+		if tc.identifyErr != nil {
+			res = nil
+		}
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: tc.token}).Return(res, tc.identifyErr)
+		channelCall := auth.On("Authorize", mock.Anything, mock.Anything).Return(&magistrala.AuthorizeRes{Authorized: tc.authzErr == nil}, tc.authzErr)
+
+		for _, op := range tc.ops {
+			var err error
+			switch op {
+			case "list":
+				_, err = svc.ListStreams(context.Background(), tc.token, re.PageMetadata{Limit: 10})
+				assert.True(t, errors.Contains(err, tc.identifyErr), fmt.Sprintf("%s: list streams: expected %s got %s\n", tc.desc, tc.identifyErr, err))
+			case "update":
+				_, err = svc.CreateStream(context.Background(), tc.token, stream, true)
+				assert.True(t, errors.Contains(err, tc.authzErr), fmt.Sprintf("%s: update stream: expected %s got %s\n", tc.desc, tc.authzErr, err))
+			case "wait":
+				time.Sleep(20 * time.Millisecond)
+			}
+		}
+		auth.AssertNumberOfCalls(t, "Identify", tc.calls)
+		authCall.Unset()
+		channelCall.Unset()
+	}
+}
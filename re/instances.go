@@ -0,0 +1,155 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var (
+	errUnknownInstance = errors.New("unknown Kuiper instance")
+	errInstanceInUse   = errors.New("user has streams or rules on another Kuiper instance")
+)
+
+// Instance describes the Kuiper instance of the pool. Info is the
+// information the instance reported, or Error the reason it couldn't be
+// reached. Users are the IDs of the users the platform administrator
+// assigned to the instance.
+type Instance struct {
+	Name    string   `json:"name"`
+	Default bool     `json:"default"`
+	Info    *Info    `json:"info,omitempty"`
+	Error   string   `json:"error,omitempty"`
+	Users   []string `json:"users"`
+}
+
+// Assignment is the Kuiper instance the user's streams, tables and rules
+// are on. Assigned tells the instance was assigned by the platform
+// administrator rather than picked by the pool strategy.
+type Assignment struct {
+	UserID   string `json:"user_id"`
+	Instance string `json:"instance"`
+	Assigned bool   `json:"assigned"`
+}
+
+func (svc *reService) ListInstances(ctx context.Context, token string) ([]Instance, error) {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return nil, err
+	}
+	assigned, err := svc.repo.RetrieveAssignments(ctx)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	users := make(map[string][]string)
+	for userID, name := range assigned {
+		users[name] = append(users[name], userID)
+	}
+
+	engines := svc.instances()
+	instances := make([]Instance, 0, len(engines))
+	for name, engine := range engines {
+		inst := Instance{Name: name, Default: name == DefaultInstance, Users: users[name]}
+		if inst.Users == nil {
+			inst.Users = []string{}
+		}
+		sort.Strings(inst.Users)
+		switch info, err := engine.Info(ctx); {
+		case err != nil:
+			inst.Error = err.Error()
+		default:
+			inst.Info = &info
+		}
+		instances = append(instances, inst)
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Name < instances[j].Name
+	})
+
+	return instances, nil
+}
+
+func (svc *reService) AssignInstance(ctx context.Context, token, userID, instance string) (Assignment, error) {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return Assignment{}, err
+	}
+	if _, ok := svc.instances()[instance]; instance != "" && !ok {
+		return Assignment{}, errors.Wrap(svcerr.ErrMalformedEntity, errUnknownInstance)
+	}
+	pool, ok := svc.engine.(enginePool)
+	if !ok {
+		// All the users are on the default instance without the pool.
+		if err := svc.saveAssignment(ctx, userID, instance); err != nil {
+			return Assignment{}, err
+		}
+		return Assignment{UserID: userID, Instance: DefaultInstance, Assigned: instance != ""}, nil
+	}
+
+	pfx := prefix(userID)
+	current, err := pool.instanceOf(ctx, pfx)
+	if err != nil {
+		return Assignment{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	next := instance
+	if next == "" {
+		next = pool.placement(pfx)
+	}
+	// The entities stay on the instance they were created on, so the
+	// users are moved only before they create any.
+	if next != current {
+		if err := svc.checkNoEntities(ctx, pfx); err != nil {
+			return Assignment{}, err
+		}
+	}
+	if err := svc.saveAssignment(ctx, userID, instance); err != nil {
+		return Assignment{}, err
+	}
+	pool.reload()
+
+	return Assignment{UserID: userID, Instance: next, Assigned: instance != ""}, nil
+}
+
+// instances returns the Kuiper instances by their names.
+func (svc *reService) instances() map[string]RuleEngine {
+	if pool, ok := svc.engine.(enginePool); ok {
+		return pool.instances()
+	}
+
+	return map[string]RuleEngine{DefaultInstance: svc.engine}
+}
+
+// saveAssignment assigns the user to the instance or, if the instance is
+// empty, removes the assignment of the user.
+func (svc *reService) saveAssignment(ctx context.Context, userID, instance string) error {
+	if instance != "" {
+		if err := svc.repo.SaveAssignment(ctx, userID, instance); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		return nil
+	}
+	if err := svc.repo.RemoveAssignment(ctx, userID); err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// checkNoEntities checks the owner has no streams, tables or rules.
+func (svc *reService) checkNoEntities(ctx context.Context, pfx string) error {
+	for _, kind := range []string{StreamKind, TableKind, RuleKind} {
+		names, err := svc.ownedNames(ctx, kind, pfx)
+		if err != nil {
+			return err
+		}
+		if len(names) > 0 {
+			return errors.Wrap(svcerr.ErrConflict, errInstanceInUse)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newPoolService creates the service spreading the users over the default
+// fake Kuiper and the "dedicated" one.
+func newPoolService(t *testing.T, strategy string) (re.Service, *kuiper, *kuiper, *authmocks.AuthClient) {
+	k, url := newKuiper(t)
+	dedicated, dedicatedURL := newKuiper(t)
+	dedicated.streams = map[string]string{}
+	dedicated.rules = map[string]re.Rule{}
+	auth := new(authmocks.AuthClient)
+	cfg := re.Config{
+		URL: url,
+		Pool: re.PoolConfig{
+			Instances: map[string]string{"dedicated": dedicatedURL},
+			Strategy:  strategy,
+			Refresh:   time.Minute,
+		},
+	}
+
+	return re.New(cfg, auth, new(sdkmocks.SDK), re.Notifiers{}, nil, mocks.NewRepository()), k, dedicated, auth
+}
+
+func TestAssignInstance(t *testing.T) {
+	svc, k, dedicated, auth := newPoolService(t, re.StaticStrategy)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+	adminCall := authorizeAdmin(auth, true)
+
+	// The user starts without streams and rules on the default instance.
+	delete(k.streams, userPrefix+"stream")
+	delete(k.rules, userPrefix+"rule")
+
+	a, err := svc.AssignInstance(context.Background(), validToken, userID, "dedicated")
+	assert.Nil(t, err, fmt.Sprintf("assign instance: expected no error got %s\n", err))
+	assert.Equal(t, re.Assignment{UserID: userID, Instance: "dedicated", Assigned: true}, a, fmt.Sprintf("unexpected assignment %v\n", a))
+
+	_, err = svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "readings", Topic: channelID, SenML: true}, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+	assert.Contains(t, dedicated.streams, userPrefix+"readings", "expected stream on the dedicated instance")
+	assert.NotContains(t, k.streams, userPrefix+"readings", "expected no stream on the default instance")
+	page, err := svc.ListStreams(context.Background(), validToken, re.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list streams: expected no error got %s\n", err))
+	assert.Equal(t, []string{"readings"}, page.Streams, fmt.Sprintf("expected stream of the dedicated instance got %v\n", page.Streams))
+
+	instances, err := svc.ListInstances(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list instances: expected no error got %s\n", err))
+	if assert.Len(t, instances, 2, "expected two instances") {
+		assert.Equal(t, re.DefaultInstance, instances[1].Name, fmt.Sprintf("expected default instance got %s\n", instances[1].Name))
+		assert.True(t, instances[1].Default, "expected default instance to be marked")
+		assert.Equal(t, "dedicated", instances[0].Name, fmt.Sprintf("expected dedicated instance got %s\n", instances[0].Name))
+		assert.Equal(t, []string{userID}, instances[0].Users, fmt.Sprintf("expected user assigned to dedicated instance got %v\n", instances[0].Users))
+		assert.NotNil(t, instances[0].Info, "expected info of dedicated instance")
+	}
+	adminCall.Unset()
+
+	cases := []struct {
+		desc       string
+		userID     string
+		instance   string
+		authorized bool
+		err        error
+	}{
+		{
+			desc:     "assign user as non-admin",
+			userID:   otherUserID,
+			instance: "dedicated",
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "assign user to unknown instance",
+			userID:     otherUserID,
+			instance:   "unknown",
+			authorized: true,
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "move user with streams to another instance",
+			userID:     otherUserID,
+			instance:   "dedicated",
+			authorized: true,
+			err:        svcerr.ErrConflict,
+		},
+		{
+			desc:       "unassign user with streams",
+			userID:     userID,
+			authorized: true,
+			err:        svcerr.ErrConflict,
+		},
+		{
+			desc:       "assign user to the instance the user is on",
+			userID:     otherUserID,
+			instance:   re.DefaultInstance,
+			authorized: true,
+		},
+		{
+			desc:       "unassign user staying on the instance",
+			userID:     otherUserID,
+			authorized: true,
+		},
+	}
+
+	for _, tc := range cases {
+		adminCall := authorizeAdmin(auth, tc.authorized)
+		_, err := svc.AssignInstance(context.Background(), validToken, tc.userID, tc.instance)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		adminCall.Unset()
+	}
+}
+
+func TestHashStrategy(t *testing.T) {
+	svc, k, dedicated, auth := newPoolService(t, re.HashStrategy)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	adminCall := authorizeAdmin(auth, true)
+	defer adminCall.Unset()
+
+	// The hash strategy picks the same instance for the user every time.
+	delete(k.streams, userPrefix+"stream")
+	delete(k.rules, userPrefix+"rule")
+	a, err := svc.AssignInstance(context.Background(), validToken, userID, "")
+	assert.Nil(t, err, fmt.Sprintf("unassign instance: expected no error got %s\n", err))
+	assert.False(t, a.Assigned, "expected instance picked by the strategy")
+	for i := 0; i < 3; i++ {
+		b, err := svc.AssignInstance(context.Background(), validToken, userID, "")
+		assert.Nil(t, err, fmt.Sprintf("unassign instance: expected no error got %s\n", err))
+		assert.Equal(t, a.Instance, b.Instance, fmt.Sprintf("expected stable instance %s got %s\n", a.Instance, b.Instance))
+	}
+
+	// Plugins are installed on all the instances.
+	plugin := re.Plugin{Name: "influx", File: "https://example.com/influx.zip"}
+	_, err = svc.CreatePlugin(context.Background(), validToken, re.SinkPlugin, plugin)
+	assert.Nil(t, err, fmt.Sprintf("create plugin: expected no error got %s\n", err))
+	assert.Contains(t, k.plugins, "sinks/influx", "expected plugin on the default instance")
+	assert.Contains(t, dedicated.plugins, "sinks/influx", "expected plugin on the dedicated instance")
+}
+
+func TestPoolConfigValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  re.PoolConfig
+		err  bool
+	}{
+		{
+			desc: "valid pool",
+			cfg:  re.PoolConfig{Strategy: re.HashStrategy, Instances: map[string]string{"eu": "http://kuiper-eu:9081"}},
+		},
+		{
+			desc: "no instances",
+			cfg:  re.PoolConfig{Strategy: re.StaticStrategy},
+		},
+		{
+			desc: "unknown strategy",
+			cfg:  re.PoolConfig{Strategy: "random"},
+			err:  true,
+		},
+		{
+			desc: "instance named default",
+			cfg:  re.PoolConfig{Strategy: re.HashStrategy, Instances: map[string]string{re.DefaultInstance: "http://kuiper:9081"}},
+			err:  true,
+		},
+		{
+			desc: "instance without URL",
+			cfg:  re.PoolConfig{Strategy: re.HashStrategy, Instances: map[string]string{"eu": ""}},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.cfg.Validate()
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v\n", tc.desc, err))
+	}
+}
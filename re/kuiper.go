@@ -0,0 +1,668 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// maxErrorSize limits the size of Kuiper error description read from the
+// response body.
+const maxErrorSize = 4096
+
+// Config defines the options used to connect to Kuiper. URL contains the
+// scheme, host, port and optional base path of the Kuiper REST API. TLS
+// configures the connections to the https Kuiper URL and Auth authenticates
+// the requests to Kuiper. Pool adds the Kuiper instances the tenants are
+// spread over. BulkWorkers limits the streams and rules each bulk operation
+// creates or removes concurrently. Quota is the default quota of the users
+// and RateLimit limits how often each user changes streams, tables and
+// rules. IdentityCache caches the users identified by the tokens. Roles
+// requires the domain edit permission for changing streams, tables and
+// rules, giving the domain viewers read-only access. RequireRevision rejects
+// the updates and the deletions that don't expect any entity revision.
+// DeleteRetention is the period the deleted rules can be restored for, 0
+// deleting the rules immediately. Writers are the writer databases rules can
+// write to. Secrets encrypts the users' secrets the rule actions refer to
+// and Encryption the sensitive action fields of the stored rule definitions.
+// Push forwards the messages the things push to the httppush streams.
+type Config struct {
+	URL             string              `env:"URL"               envDefault:"http://localhost:9081"`
+	Timeout         time.Duration       `env:"TIMEOUT"           envDefault:"10s"`
+	KeepAlive       time.Duration       `env:"KEEP_ALIVE"        envDefault:"30s"`
+	MaxIdleConns    int                 `env:"MAX_IDLE_CONNS"    envDefault:"100"`
+	IdleConnTimeout time.Duration       `env:"IDLE_CONN_TIMEOUT" envDefault:"90s"`
+	TLS             TLSConfig           `envPrefix:"TLS_"`
+	Auth            AuthConfig          `envPrefix:"AUTH_"`
+	Pool            PoolConfig          `envPrefix:"POOL_"`
+	BulkWorkers     int                 `env:"BULK_WORKERS"      envDefault:"8"`
+	Retry           RetryConfig         `envPrefix:"RETRY_"`
+	Breaker         BreakerConfig       `envPrefix:"BREAKER_"`
+	Quota           QuotaConfig         `envPrefix:"QUOTA_"`
+	RateLimit       RateLimitConfig     `envPrefix:"RATE_LIMIT_"`
+	IdentityCache   IdentityCacheConfig `envPrefix:"IDENTITY_CACHE_"`
+	Roles           bool                `env:"ROLES"             envDefault:"true"`
+	RequireRevision bool                `env:"REQUIRE_REVISION"  envDefault:"true"`
+	DeleteRetention time.Duration       `env:"DELETE_RETENTION"  envDefault:"168h"`
+	Writers         WritersConfig       `envPrefix:"WRITERS_"`
+	Trial           TrialConfig         `envPrefix:"TRIAL_"`
+	Tail            TailConfig          `envPrefix:"TAIL_"`
+	Watch           WatchConfig         `envPrefix:"WATCH_"`
+	Logs            LogsConfig          `envPrefix:"LOGS_"`
+	Secrets         SecretsConfig       `envPrefix:"SECRETS_"`
+	Encryption      EncryptionConfig    `envPrefix:"ENCRYPTION_"`
+	Push            PushConfig          `envPrefix:"PUSH_"`
+	Webhooks        WebhooksConfig      `envPrefix:"WEBHOOKS_"`
+}
+
+// RetryConfig defines how idempotent Kuiper requests (GET, PUT and DELETE)
+// are retried on transient failures. Delay between attempts grows
+// exponentially from BaseDelay up to MaxDelay and is randomized by Jitter
+// (0 disables randomization).
+type RetryConfig struct {
+	MaxAttempts uint64        `env:"MAX_ATTEMPTS" envDefault:"3"`
+	BaseDelay   time.Duration `env:"BASE_DELAY"   envDefault:"100ms"`
+	MaxDelay    time.Duration `env:"MAX_DELAY"    envDefault:"2s"`
+	Jitter      float64       `env:"JITTER"       envDefault:"0.5"`
+}
+
+// BreakerConfig defines the circuit breaker around Kuiper requests. The
+// breaker opens after Failures consecutive failed requests and rejects
+// requests for Timeout, after which up to MaxRequests probe requests are let
+// through. Failure counts are cleared every Interval while the breaker is
+// closed. Setting Failures to 0 disables the breaker.
+type BreakerConfig struct {
+	Failures    uint32        `env:"FAILURES"     envDefault:"5"`
+	Timeout     time.Duration `env:"TIMEOUT"      envDefault:"30s"`
+	MaxRequests uint32        `env:"MAX_REQUESTS" envDefault:"1"`
+	Interval    time.Duration `env:"INTERVAL"     envDefault:"60s"`
+}
+
+// TLSConfig defines how the rules engine verifies Kuiper and authenticates
+// to it. CACerts is the path to the trusted CAs in PEM format, in addition to
+// the system ones. ClientCert and ClientKey are the paths to the client
+// certificate and key for mutual TLS. InsecureSkipVerify disables the
+// verification of the Kuiper certificate and should only be used for
+// testing.
+type TLSConfig struct {
+	CACerts            string `env:"CA_CERTS"    envDefault:""`
+	ClientCert         string `env:"CLIENT_CERT" envDefault:""`
+	ClientKey          string `env:"CLIENT_KEY"  envDefault:""`
+	InsecureSkipVerify bool   `env:"SKIP_VERIFY" envDefault:"false"`
+}
+
+// Load returns the TLS configuration of the Kuiper connections, nil if the
+// default one is used.
+func (c TLSConfig) Load() (*tls.Config, error) {
+	if c == (TLSConfig{}) {
+		return nil, nil
+	}
+	conf := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CACerts != "" {
+		pem, err := os.ReadFile(c.CACerts)
+		if err != nil {
+			return nil, errors.Wrap(errKuiperTLS, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Wrap(errKuiperTLS, errors.New("no certificates found in "+c.CACerts))
+		}
+		conf.RootCAs = pool
+	}
+	if c.ClientCert != "" || c.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCert, c.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(errKuiperTLS, err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+
+	return conf, nil
+}
+
+// newBreaker creates circuit breaker shared by all the Kuiper requests. Only
+// failures to communicate with Kuiper trip the breaker, while canceled
+// requests and Kuiper responses to invalid requests do not.
+func newBreaker(cfg BreakerConfig) *gobreaker.CircuitBreaker {
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        "kuiper",
+		MaxRequests: cfg.MaxRequests,
+		Interval:    cfg.Interval,
+		Timeout:     cfg.Timeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return cfg.Failures > 0 && counts.ConsecutiveFailures >= cfg.Failures
+		},
+		IsSuccessful: func(err error) bool {
+			return !errors.Contains(err, ErrKuiperServer)
+		},
+	})
+}
+
+// newClient creates HTTP client shared by all the Kuiper requests. Each
+// request is traced as a child span of the service call and carries the
+// trace context in its headers. If the TLS or authentication configuration
+// can't be loaded, all the requests fail with the loading error, which the
+// service start reports up front.
+func newClient(cfg Config) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   cfg.Timeout,
+		KeepAlive: cfg.KeepAlive,
+	}
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dialer.DialContext,
+		MaxIdleConns:        cfg.MaxIdleConns,
+		MaxIdleConnsPerHost: cfg.MaxIdleConns,
+		IdleConnTimeout:     cfg.IdleConnTimeout,
+	}
+	tlsConf, err := cfg.TLS.Load()
+	if err != nil {
+		return failingClient(err)
+	}
+	transport.TLSClientConfig = tlsConf
+	var rt http.RoundTripper = transport
+	if cfg.Auth.enabled() {
+		signer, err := newTokenSigner(cfg.Auth)
+		if err != nil {
+			return failingClient(err)
+		}
+		rt = &authTransport{next: transport, signer: signer}
+	}
+
+	return &http.Client{
+		Transport: otelhttp.NewTransport(rt, otelhttp.WithSpanNameFormatter(kuiperSpanName)),
+		Timeout:   cfg.Timeout,
+	}
+}
+
+func failingClient(err error) *http.Client {
+	return &http.Client{Transport: failingTransport{err}}
+}
+
+// failingTransport fails all the requests with the error.
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+func kuiperSpanName(_ string, r *http.Request) string {
+	return "kuiper_" + strings.ToLower(r.Method) + " " + r.URL.Path
+}
+
+// kuiperEngine is the rule engine using the Kuiper REST API.
+type kuiperEngine struct {
+	host    string
+	push    string
+	client  *http.Client
+	retry   RetryConfig
+	breaker *gobreaker.CircuitBreaker
+	trial   TrialConfig
+	api     *kuiperAPI
+}
+
+var _ RuleEngine = (*kuiperEngine)(nil)
+
+// NewKuiper instantiates the rule engine using the Kuiper REST API.
+func NewKuiper(cfg Config) RuleEngine {
+	return newKuiper(cfg, newClient(cfg))
+}
+
+// newKuiper instantiates the Kuiper engine sending the requests with the
+// given client. Each engine has its own circuit breaker, so an unreachable
+// instance doesn't reject the requests to the others.
+func newKuiper(cfg Config, client *http.Client) *kuiperEngine {
+	return &kuiperEngine{
+		host:    strings.TrimSuffix(cfg.URL, "/"),
+		push:    strings.TrimSuffix(cfg.Push.URL, "/"),
+		client:  client,
+		retry:   cfg.Retry,
+		breaker: newBreaker(cfg.Breaker),
+		trial:   cfg.Trial,
+		api:     &kuiperAPI{},
+	}
+}
+
+func (k *kuiperEngine) Info(ctx context.Context) (Info, error) {
+	var info Info
+	err := k.get(ctx, "", &info)
+	info.Breaker = k.breaker.State().String()
+	switch {
+	case errors.Contains(err, ErrKuiperUnavailable):
+		return Info{Breaker: info.Breaker}, nil
+	case err != nil:
+		return Info{}, err
+	}
+	k.api.detect(info.Version)
+
+	return info, nil
+}
+
+func (k *kuiperEngine) CreateStream(ctx context.Context, kind, sql string) (Result, error) {
+	return k.send(ctx, http.MethodPost, "/"+kind+"s", map[string]string{"sql": sql})
+}
+
+func (k *kuiperEngine) UpdateStream(ctx context.Context, kind, name, sql string) (Result, error) {
+	return k.send(ctx, http.MethodPut, "/"+kind+"s/"+name, map[string]string{"sql": sql})
+}
+
+func (k *kuiperEngine) ListStreams(ctx context.Context, kind string) ([]string, error) {
+	var names []string
+	if err := k.get(ctx, "/"+kind+"s", &names); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (k *kuiperEngine) ViewStream(ctx context.Context, kind, name string) (Stream, error) {
+	var stream Stream
+	if err := k.get(ctx, "/"+kind+"s/"+name, &stream); err != nil {
+		return Stream{}, err
+	}
+
+	return stream, nil
+}
+
+func (k *kuiperEngine) DeleteStream(ctx context.Context, kind, name string) (Result, error) {
+	return k.send(ctx, http.MethodDelete, "/"+kind+"s/"+name, nil)
+}
+
+func (k *kuiperEngine) CreateRule(ctx context.Context, rule EngineRule) (Result, error) {
+	return k.send(ctx, http.MethodPost, "/rules", rule)
+}
+
+func (k *kuiperEngine) UpdateRule(ctx context.Context, rule EngineRule) (Result, error) {
+	return k.send(ctx, http.MethodPut, "/rules/"+rule.ID, rule)
+}
+
+func (k *kuiperEngine) ListRules(ctx context.Context) ([]RuleInfo, error) {
+	var rules []RuleInfo
+	if err := k.get(ctx, "/rules", &rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (k *kuiperEngine) ViewRule(ctx context.Context, id string) (EngineRule, error) {
+	var rule EngineRule
+	if err := k.get(ctx, "/rules/"+id, &rule); err != nil {
+		return EngineRule{}, err
+	}
+
+	return rule, nil
+}
+
+func (k *kuiperEngine) DeleteRule(ctx context.Context, id string) (Result, error) {
+	return k.send(ctx, http.MethodDelete, "/rules/"+id, nil)
+}
+
+func (k *kuiperEngine) ControlRule(ctx context.Context, id, command string) (Result, error) {
+	return k.send(ctx, http.MethodPost, "/rules/"+id+"/"+command, nil)
+}
+
+func (k *kuiperEngine) RuleStatus(ctx context.Context, id string) (RuleStatus, error) {
+	var metrics map[string]interface{}
+	if err := k.get(ctx, "/rules/"+id+"/status", &metrics); err != nil {
+		return RuleStatus{}, err
+	}
+
+	return parseStatus(metrics), nil
+}
+
+func (k *kuiperEngine) RuleTopology(ctx context.Context, id string) (RuleTopology, error) {
+	var topo engineTopology
+	if err := k.get(ctx, "/rules/"+id+"/topo", &topo); err != nil {
+		return RuleTopology{}, err
+	}
+
+	return parseTopology(topo), nil
+}
+
+func (k *kuiperEngine) ValidateRule(ctx context.Context, rule EngineRule) error {
+	_, err := k.send(ctx, http.MethodPost, validatePath, rule)
+	return err
+}
+
+func (k *kuiperEngine) CreatePlugin(ctx context.Context, kind string, plugin Plugin) (Result, error) {
+	return k.send(ctx, http.MethodPost, pluginsPath(kind), plugin)
+}
+
+func (k *kuiperEngine) ListPlugins(ctx context.Context, kind string) ([]string, error) {
+	names := []string{}
+	if err := k.get(ctx, pluginsPath(kind), &names); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (k *kuiperEngine) DeletePlugin(ctx context.Context, kind, name string) (Result, error) {
+	return k.send(ctx, http.MethodDelete, pluginsPath(kind)+"/"+name, nil)
+}
+
+func (k *kuiperEngine) RegisterService(ctx context.Context, es ExternalService) (Result, error) {
+	return k.send(ctx, http.MethodPost, servicesPath, es)
+}
+
+func (k *kuiperEngine) ListServices(ctx context.Context) ([]string, error) {
+	names := []string{}
+	if err := k.get(ctx, servicesPath, &names); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (k *kuiperEngine) DeleteService(ctx context.Context, name string) (Result, error) {
+	return k.send(ctx, http.MethodDelete, servicesPath+"/"+name, nil)
+}
+
+func (k *kuiperEngine) ListFunctions(ctx context.Context) ([]ExternalFunction, error) {
+	funcs := []ExternalFunction{}
+	if err := k.get(ctx, functionsPath, &funcs); err != nil {
+		return nil, err
+	}
+
+	return funcs, nil
+}
+
+func (k *kuiperEngine) SaveConfKey(ctx context.Context, name string, conf MQTTConf) (Result, error) {
+	return k.send(ctx, http.MethodPut, confKeysPath+"/"+name, conf)
+}
+
+func (k *kuiperEngine) ListConfKeys(ctx context.Context) ([]string, error) {
+	var confs map[string]any
+	if err := k.get(ctx, confKeysListPath, &confs); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(confs))
+	for key := range confs {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (k *kuiperEngine) DeleteConfKey(ctx context.Context, name string) (Result, error) {
+	return k.send(ctx, http.MethodDelete, confKeysPath+"/"+name, nil)
+}
+
+// Push sends the message to the Kuiper httppush server. The server is
+// separate from the REST API, so the request isn't routed by the API
+// adapter, nor does it go through the breaker. Kuiper serves the push
+// endpoint of the stream only while a rule reads the stream.
+func (k *kuiperEngine) Push(ctx context.Context, stream string, payload []byte) error {
+	if k.push == "" {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errPushDisabled)
+	}
+	path := pushPath(stream)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.push+path, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(ErrKuiperServer, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if id := RequestID(ctx); id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+
+	res, err := k.client.Do(req)
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil:
+		return errors.Wrap(ErrKuiperServer, err)
+	}
+	defer res.Body.Close()
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return errors.Wrap(svcerr.ErrNotFound, errPushEndpoint)
+	case !successful(res.StatusCode):
+		return statusError(res, path)
+	}
+
+	return nil
+}
+
+// get fetches the Kuiper resource on the given path and decodes it to v.
+func (k *kuiperEngine) get(ctx context.Context, path string, v interface{}) error {
+	res, err := k.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if !successful(res.StatusCode) {
+		return statusError(res, path)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(errReadResponse, err)
+	}
+	if err := json.Unmarshal(k.api.get().response(path, data), v); err != nil {
+		return errors.Wrap(errReadResponse, err)
+	}
+
+	return nil
+}
+
+// send sends the request with JSON encoded body to Kuiper and returns
+// the result of the operation. Only successful Kuiper responses produce the
+// result, while the others are returned as errors.
+func (k *kuiperEngine) send(ctx context.Context, method, path string, body interface{}) (Result, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
+
+	res, err := k.do(ctx, method, path, data)
+	if err != nil {
+		return Result{}, err
+	}
+	defer res.Body.Close()
+	if !successful(res.StatusCode) {
+		return Result{}, statusError(res, path)
+	}
+
+	msg, err := io.ReadAll(res.Body)
+	if err != nil {
+		return Result{}, errors.Wrap(errReadResponse, err)
+	}
+
+	result := Result{
+		Status:  res.StatusCode,
+		Message: strings.TrimSpace(string(msg)),
+	}
+
+	return result, nil
+}
+
+// do sends the request to Kuiper through the circuit breaker. While the
+// breaker is open, the request fails fast with ErrKuiperUnavailable. The
+// request is routed by the adapter of the detected Kuiper version, so the
+// requests the version doesn't support fail with ErrNotSupported.
+func (k *kuiperEngine) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	path, err := k.api.get().route(method, path)
+	if err != nil {
+		return nil, err
+	}
+	res, err := k.breaker.Execute(func() (interface{}, error) {
+		return k.attempt(ctx, method, path, body)
+	})
+	switch err {
+	case nil:
+		return res.(*http.Response), nil
+	case gobreaker.ErrOpenState, gobreaker.ErrTooManyRequests:
+		return nil, errors.Wrap(ErrKuiperUnavailable, err)
+	default:
+		return nil, err
+	}
+}
+
+// attempt sends the request to Kuiper. Idempotent requests are retried with
+// exponential backoff on transport errors and temporary Kuiper unavailability.
+// If the request failed because the context is canceled or its deadline
+// exceeded, the context error is returned as is, so callers can distinguish
+// it from Kuiper failures.
+func (k *kuiperEngine) attempt(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	var res *http.Response
+	op := func() error {
+		req, err := http.NewRequestWithContext(ctx, method, k.host+path, bytes.NewReader(body))
+		if err != nil {
+			return backoff.Permanent(errors.Wrap(ErrKuiperServer, err))
+		}
+		req.Header.Set("Content-Type", contentType)
+		if id := RequestID(ctx); id != "" {
+			req.Header.Set(RequestIDHeader, id)
+		}
+
+		res, err = k.client.Do(req)
+		switch {
+		case ctx.Err() != nil:
+			return backoff.Permanent(ctx.Err())
+		case err != nil:
+			return errors.Wrap(ErrKuiperServer, err)
+		case temporary(res.StatusCode):
+			res.Body.Close()
+			return errors.Wrap(ErrKuiperServer, errors.New(res.Status))
+		}
+
+		return nil
+	}
+
+	if !idempotent(method) {
+		if err := op(); err != nil {
+			return nil, unwrapPermanent(err)
+		}
+		return res, nil
+	}
+
+	if err := backoff.Retry(op, backoff.WithContext(k.backoff(), ctx)); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, unwrapPermanent(err)
+	}
+
+	return res, nil
+}
+
+func (k *kuiperEngine) backoff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = k.retry.BaseDelay
+	b.MaxInterval = k.retry.MaxDelay
+	b.RandomizationFactor = k.retry.Jitter
+	b.MaxElapsedTime = 0
+	attempts := k.retry.MaxAttempts
+	if attempts > 0 {
+		attempts--
+	}
+
+	return backoff.WithMaxRetries(b, attempts)
+}
+
+// statusError maps unsuccessful Kuiper response to the service error. The
+// failure description Kuiper sends in the response body is kept as the cause,
+// wrapped by the typed error when the failure is recognized.
+func statusError(res *http.Response, path string) error {
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxErrorSize))
+	if err != nil {
+		return errors.Wrap(errReadResponse, err)
+	}
+	var kerr struct {
+		Message string `json:"message"`
+	}
+	msg := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &kerr) == nil && kerr.Message != "" {
+		msg = kerr.Message
+	}
+	if msg == "" {
+		msg = res.Status
+	}
+	cause := errors.New(msg)
+
+	// Kuiper reports most failures, including missing and existing
+	// entities, as bad requests, so they're told apart by the message.
+	lower := strings.ToLower(msg)
+	// Only the requests on an existing rule are told to miss the rule, as
+	// creating the rule fails with missing streams the same way.
+	rule := strings.HasPrefix(path, "/rules/") && !strings.Contains(lower, "stream")
+	switch {
+	case res.StatusCode == http.StatusConflict, strings.Contains(lower, "already exist"):
+		return errors.Wrap(svcerr.ErrConflict, errors.Wrap(ErrConflict, cause))
+	case strings.HasPrefix(path, "/streams/") && strings.Contains(lower, "referenced by"):
+		return errors.Wrap(svcerr.ErrConflict, errors.Wrap(ErrStreamInUse, cause))
+	case rule && (res.StatusCode == http.StatusNotFound || strings.Contains(lower, "not found")):
+		return errors.Wrap(svcerr.ErrNotFound, errors.Wrap(ErrRuleNotFound, cause))
+	case strings.Contains(lower, "parse sql"), strings.Contains(lower, "found \"") && strings.Contains(lower, "expected"):
+		return errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(ErrInvalidSQL, cause))
+	}
+
+	switch res.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return errors.Wrap(svcerr.ErrMalformedEntity, cause)
+	case http.StatusNotFound:
+		return errors.Wrap(svcerr.ErrNotFound, cause)
+	default:
+		return errors.Wrap(ErrKuiperServer, cause)
+	}
+}
+
+func successful(status int) bool {
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func temporary(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func unwrapPermanent(err error) error {
+	if perr, ok := err.(*backoff.PermanentError); ok {
+		return perr.Err
+	}
+
+	return err
+}
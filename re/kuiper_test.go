@@ -0,0 +1,295 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestKuiperErrors(t *testing.T) {
+	// Kuiper answers the failures with bad requests, as it does for most of
+	// them, so the errors are told apart by the message.
+	failures := map[string]string{
+		"GET /rules/" + userPrefix + "missing":    "rule " + userPrefix + "missing is not found in registry",
+		"DELETE /streams/" + userPrefix + "used":  "stream " + userPrefix + "used is referenced by rules [" + userPrefix + "rule]",
+		"DELETE /streams/" + userPrefix + "stuck": "failed to drop stream " + userPrefix + "stuck",
+		"POST /streams existing":                  "stream " + userPrefix + "existing already exists",
+		"POST /streams invalid":                   "Parse SQL CREATE STREAM error: found \"(\", expected FROM.",
+	}
+	ks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		if r.Method == http.MethodPost {
+			var body struct {
+				SQL string `json:"sql"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			for _, name := range []string{"existing", "invalid"} {
+				if strings.Contains(body.SQL, userPrefix+name) {
+					key += " " + name
+				}
+			}
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": 1000, "message": failures[key]})
+	}))
+	defer ks.Close()
+
+	auth := new(authmocks.AuthClient)
+	auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	sdk := new(sdkmocks.SDK)
+	authorizeChannel(auth, validToken, channelID, true)
+	svc := re.New(re.Config{URL: ks.URL}, auth, sdk, re.Notifiers{}, nil, mocks.NewRepository())
+
+	cases := []struct {
+		desc   string
+		call   func() error
+		err    error
+		svcErr error
+	}{
+		{
+			desc: "view missing rule",
+			call: func() error {
+				_, err := svc.ViewRule(context.Background(), validToken, "missing")
+				return err
+			},
+			err:    re.ErrRuleNotFound,
+			svcErr: svcerr.ErrNotFound,
+		},
+		{
+			desc: "delete stream used by rules",
+			call: func() error {
+				_, err := svc.DeleteStream(context.Background(), validToken, "used", false)
+				return err
+			},
+			err:    re.ErrStreamInUse,
+			svcErr: svcerr.ErrConflict,
+		},
+		{
+			desc: "create existing stream",
+			call: func() error {
+				_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "existing", Topic: channelID, SenML: true}, false)
+				return err
+			},
+			err:    re.ErrConflict,
+			svcErr: svcerr.ErrConflict,
+		},
+		{
+			desc: "create stream with invalid SQL",
+			call: func() error {
+				_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "invalid", Topic: channelID, SenML: true}, false)
+				return err
+			},
+			err:    re.ErrInvalidSQL,
+			svcErr: svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "delete stream with unrecognized failure",
+			call: func() error {
+				_, err := svc.DeleteStream(context.Background(), validToken, "stuck", false)
+				return err
+			},
+			svcErr: svcerr.ErrMalformedEntity,
+		},
+	}
+
+	typed := []error{re.ErrConflict, re.ErrInvalidSQL, re.ErrRuleNotFound, re.ErrStreamInUse}
+	for _, tc := range cases {
+		err := tc.call()
+		assert.True(t, errors.Contains(err, tc.svcErr), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.svcErr, err))
+		for _, e := range typed {
+			assert.Equal(t, e == tc.err, errors.Contains(err, e), fmt.Sprintf("%s: unexpected typed error in %s\n", tc.desc, err))
+		}
+	}
+}
+
+func TestKuiperTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+		_ = tp.Shutdown(context.Background())
+	})
+
+	var traceparent string
+	ks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("Stream readings is created."))
+	}))
+	defer ks.Close()
+
+	ctx, span := tp.Tracer("re").Start(context.Background(), "svc_create_stream")
+	_, err := re.NewKuiper(re.Config{URL: ks.URL}).CreateStream(ctx, re.StreamKind, "CREATE STREAM readings () WITH (TYPE=\"mqtt\")")
+	span.End()
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+
+	traceID := span.SpanContext().TraceID().String()
+	assert.Contains(t, traceparent, traceID, fmt.Sprintf("expected Kuiper request to carry trace %s got %q\n", traceID, traceparent))
+	var names []string
+	for _, s := range recorder.Ended() {
+		names = append(names, s.Name())
+		if s.Name() == "kuiper_post /streams" {
+			assert.Equal(t, span.SpanContext().SpanID(), s.Parent().SpanID(), "expected Kuiper span to be child of service span")
+		}
+	}
+	assert.Contains(t, names, "kuiper_post /streams", fmt.Sprintf("expected Kuiper request span got %v\n", names))
+}
+
+// writeClientCert writes the self-signed client certificate and its key to
+// the directory, returning their paths and the certificate.
+func writeClientCert(t *testing.T, dir string) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate client key: %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "re"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create client certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse client certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal client key: %s", err)
+	}
+	certPath, keyPath := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	writePEM(t, certPath, "CERTIFICATE", der)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+
+	return certPath, keyPath, cert
+}
+
+func writePEM(t *testing.T, path, kind string, der []byte) {
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write %s: %s", path, err)
+	}
+}
+
+func TestKuiperTLS(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath, clientCert := writeClientCert(t, dir)
+
+	info := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(re.Info{Version: "1.10.0"})
+	})
+	ks := httptest.NewTLSServer(info)
+	defer ks.Close()
+	caPath := filepath.Join(dir, "ca.crt")
+	writePEM(t, caPath, "CERTIFICATE", ks.Certificate().Raw)
+
+	mks := httptest.NewUnstartedServer(info)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	mks.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	mks.StartTLS()
+	defer mks.Close()
+	mcaPath := filepath.Join(dir, "mca.crt")
+	writePEM(t, mcaPath, "CERTIFICATE", mks.Certificate().Raw)
+
+	cases := []struct {
+		desc    string
+		url     string
+		tls     re.TLSConfig
+		loadErr bool
+		err     error
+	}{
+		{
+			desc: "connect to Kuiper with untrusted certificate",
+			url:  ks.URL,
+			err:  re.ErrKuiperServer,
+		},
+		{
+			desc: "connect to Kuiper with trusted certificate",
+			url:  ks.URL,
+			tls:  re.TLSConfig{CACerts: caPath},
+		},
+		{
+			desc: "connect to Kuiper skipping verification",
+			url:  ks.URL,
+			tls:  re.TLSConfig{InsecureSkipVerify: true},
+		},
+		{
+			desc:    "connect to Kuiper with missing CA file",
+			url:     ks.URL,
+			tls:     re.TLSConfig{CACerts: filepath.Join(dir, "missing.crt")},
+			loadErr: true,
+			err:     re.ErrKuiperServer,
+		},
+		{
+			desc: "connect to Kuiper requiring client certificate without one",
+			url:  mks.URL,
+			tls:  re.TLSConfig{CACerts: mcaPath},
+			err:  re.ErrKuiperServer,
+		},
+		{
+			desc: "connect to Kuiper requiring client certificate with one",
+			url:  mks.URL,
+			tls:  re.TLSConfig{CACerts: mcaPath, ClientCert: certPath, ClientKey: keyPath},
+		},
+		{
+			desc:    "connect to Kuiper with client certificate without key",
+			url:     mks.URL,
+			tls:     re.TLSConfig{CACerts: mcaPath, ClientCert: certPath},
+			loadErr: true,
+			err:     re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := tc.tls.Load()
+		assert.Equal(t, tc.loadErr, err != nil, fmt.Sprintf("%s: expected load error %t got %s\n", tc.desc, tc.loadErr, err))
+
+		cfg := re.Config{URL: tc.url, Retry: re.RetryConfig{MaxAttempts: 1}, TLS: tc.tls}
+		info, err := re.NewKuiper(cfg).Info(context.Background())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, "1.10.0", info.Version, fmt.Sprintf("%s: expected version 1.10.0 got %s\n", tc.desc, info.Version))
+		}
+	}
+}
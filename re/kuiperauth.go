@@ -0,0 +1,156 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+var errKuiperAuth = errors.New("failed to load Kuiper authentication configuration")
+
+// AuthConfig defines how the requests authenticate to the eKuiper REST API
+// protected with JWT authentication. Token is sent as is in the
+// Authorization header. Otherwise, the tokens are signed with the RSA
+// private key in PEM format KeyFile points to, for the Issuer, which is the
+// name of the matching public key file in the eKuiper mgmt directory, and
+// the Audience, and are signed anew before they expire after TTL.
+type AuthConfig struct {
+	Token    string        `env:"TOKEN"    envDefault:""`
+	KeyFile  string        `env:"KEY_FILE" envDefault:""`
+	Issuer   string        `env:"ISSUER"   envDefault:""`
+	Audience string        `env:"AUDIENCE" envDefault:"eKuiper"`
+	TTL      time.Duration `env:"TTL"      envDefault:"1h"`
+}
+
+// Validate checks that the tokens can be signed with the configuration.
+func (c AuthConfig) Validate() error {
+	if !c.enabled() {
+		return nil
+	}
+	_, err := newTokenSigner(c)
+
+	return err
+}
+
+func (c AuthConfig) enabled() bool {
+	return c.Token != "" || c.KeyFile != ""
+}
+
+// tokenSigner provides the tokens of the Kuiper requests, signing the new
+// one once the previous is about to expire.
+type tokenSigner struct {
+	cfg     AuthConfig
+	key     interface{}
+	mu      sync.Mutex
+	token   string
+	renewAt time.Time
+}
+
+func newTokenSigner(cfg AuthConfig) (*tokenSigner, error) {
+	if cfg.Token != "" {
+		return &tokenSigner{cfg: cfg, token: cfg.Token}, nil
+	}
+	if cfg.Issuer == "" {
+		return nil, errors.Wrap(errKuiperAuth, errors.New("token issuer is required"))
+	}
+	if cfg.TTL <= 0 {
+		return nil, errors.Wrap(errKuiperAuth, errors.New("token TTL must be positive"))
+	}
+	data, err := os.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(errKuiperAuth, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Wrap(errKuiperAuth, errors.New("no PEM key found in "+cfg.KeyFile))
+	}
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(errKuiperAuth, err)
+	}
+
+	return &tokenSigner{cfg: cfg, key: key}, nil
+}
+
+// parsePrivateKey parses the RSA private key in PKCS #1 or PKCS #8 form.
+func parsePrivateKey(der []byte) (interface{}, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+
+	return x509.ParsePKCS8PrivateKey(der)
+}
+
+// get returns the token of the request.
+func (s *tokenSigner) get() (string, error) {
+	if s.key == nil {
+		return s.token, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.token != "" && now.Before(s.renewAt) {
+		return s.token, nil
+	}
+	tkn, err := jwt.NewBuilder().
+		Issuer(s.cfg.Issuer).
+		Audience([]string{s.cfg.Audience}).
+		IssuedAt(now).
+		Expiration(now.Add(s.cfg.TTL)).
+		Build()
+	if err != nil {
+		return "", errors.Wrap(errKuiperAuth, err)
+	}
+	signed, err := jwt.Sign(tkn, jwt.WithKey(jwa.RS256, s.key))
+	if err != nil {
+		return "", errors.Wrap(errKuiperAuth, err)
+	}
+	s.token = string(signed)
+	// The token is signed anew when 90% of its lifetime passed, so it
+	// doesn't expire in flight.
+	s.renewAt = now.Add(s.cfg.TTL * 9 / 10)
+
+	return s.token, nil
+}
+
+// reset makes the next request sign the new token, after Kuiper rejected
+// the current one.
+func (s *tokenSigner) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.key != nil {
+		s.token = ""
+	}
+}
+
+// authTransport adds the token to the Kuiper requests.
+type authTransport struct {
+	next   http.RoundTripper
+	signer *tokenSigner
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.signer.get()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", token)
+	res, err := t.next.RoundTrip(req)
+	if err == nil && res.StatusCode == http.StatusUnauthorized {
+		t.signer.reset()
+	}
+
+	return res, err
+}
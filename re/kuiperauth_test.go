@@ -0,0 +1,122 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/re"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKuiperAuth(t *testing.T) {
+	dir := t.TempDir()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %s", err)
+	}
+	keyPath := filepath.Join(dir, "re.key")
+	writePEM(t, keyPath, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %s", err)
+	}
+	pkcs8Path := filepath.Join(dir, "re8.key")
+	writePEM(t, pkcs8Path, "PRIVATE KEY", pkcs8)
+	invalidPath := filepath.Join(dir, "invalid.key")
+	if err := os.WriteFile(invalidPath, []byte("key"), 0o600); err != nil {
+		t.Fatalf("write key: %s", err)
+	}
+
+	// Kuiper accepts the static token and the tokens signed for the re.pub
+	// public key, like eKuiper with JWT authentication does.
+	ks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")
+		if token != "static" {
+			if _, err := jwt.Parse([]byte(token), jwt.WithKey(jwa.RS256, &key.PublicKey), jwt.WithValidate(true), jwt.WithIssuer("re.pub"), jwt.WithAudience("eKuiper")); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		_ = json.NewEncoder(w).Encode(re.Info{Version: "1.10.0"})
+	}))
+	defer ks.Close()
+
+	cases := []struct {
+		desc     string
+		auth     re.AuthConfig
+		validErr bool
+		err      error
+	}{
+		{
+			desc: "request Kuiper without token",
+			err:  re.ErrKuiperServer,
+		},
+		{
+			desc: "request Kuiper with static token",
+			auth: re.AuthConfig{Token: "static"},
+		},
+		{
+			desc: "request Kuiper with invalid static token",
+			auth: re.AuthConfig{Token: "invalid"},
+			err:  re.ErrKuiperServer,
+		},
+		{
+			desc: "request Kuiper with signed token",
+			auth: re.AuthConfig{KeyFile: keyPath, Issuer: "re.pub", Audience: "eKuiper", TTL: time.Hour},
+		},
+		{
+			desc: "request Kuiper with token signed by PKCS #8 key",
+			auth: re.AuthConfig{KeyFile: pkcs8Path, Issuer: "re.pub", Audience: "eKuiper", TTL: time.Hour},
+		},
+		{
+			desc: "request Kuiper with token of unknown issuer",
+			auth: re.AuthConfig{KeyFile: keyPath, Issuer: "other.pub", Audience: "eKuiper", TTL: time.Hour},
+			err:  re.ErrKuiperServer,
+		},
+		{
+			desc:     "request Kuiper with token without issuer",
+			auth:     re.AuthConfig{KeyFile: keyPath, Audience: "eKuiper", TTL: time.Hour},
+			validErr: true,
+			err:      re.ErrKuiperServer,
+		},
+		{
+			desc:     "request Kuiper with missing key file",
+			auth:     re.AuthConfig{KeyFile: filepath.Join(dir, "missing.key"), Issuer: "re.pub", TTL: time.Hour},
+			validErr: true,
+			err:      re.ErrKuiperServer,
+		},
+		{
+			desc:     "request Kuiper with invalid key file",
+			auth:     re.AuthConfig{KeyFile: invalidPath, Issuer: "re.pub", TTL: time.Hour},
+			validErr: true,
+			err:      re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.auth.Validate()
+		assert.Equal(t, tc.validErr, err != nil, fmt.Sprintf("%s: expected validation error %t got %s\n", tc.desc, tc.validErr, err))
+
+		k := re.NewKuiper(re.Config{URL: ks.URL, Retry: re.RetryConfig{MaxAttempts: 1}, Auth: tc.auth})
+		for i := 0; i < 2; i++ {
+			_, err = k.Info(context.Background())
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		}
+	}
+}
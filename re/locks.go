@@ -0,0 +1,48 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import "sync"
+
+// keyLocks keeps a lock for each key held or waited for, e.g. the user whose
+// creations or the Kuiper rule whose changes are serialized.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	mu sync.Mutex
+	// refs is the number of the callers holding or waiting for the lock.
+	refs int
+}
+
+func newKeyLocks() *keyLocks {
+	return &keyLocks{locks: make(map[string]*keyLock)}
+}
+
+// lock locks the lock of the key and returns the function unlocking it.
+// Locks nobody holds or waits for are dropped, so the map stays bounded by
+// the number of the concurrent callers.
+func (k *keyLocks) lock(key string) func() {
+	k.mu.Lock()
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	l.mu.Lock()
+
+	return func() {
+		l.mu.Unlock()
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(k.locks, key)
+		}
+	}
+}
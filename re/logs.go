@@ -0,0 +1,141 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// logsTimeout limits the requests to the Kuiper logs URL.
+const logsTimeout = 10 * time.Second
+
+// logTimeFormat is the timestamp format of the Kuiper log lines.
+const logTimeFormat = "2006-01-02 15:04:05"
+
+// maxLogLineSize is the size of the longest Kuiper log line read.
+const maxLogLineSize = 1024 * 1024
+
+var (
+	errLogsDisabled = errors.New("rule logs are disabled")
+	errLogs         = errors.New("failed to fetch Kuiper logs")
+)
+
+// logField matches the key=value fields of the Kuiper log lines, which are
+// quoted if they contain spaces or special characters.
+var logField = regexp.MustCompile(`(\w+)=("(?:[^"\\]|\\.)*"|\S*)`)
+
+// LogsConfig defines the rule logs. URL serves the Kuiper log file as plain
+// text, usually through the log-forwarding sidecar sharing the Kuiper log
+// volume, and Limit is the maximum number of the latest log lines returned.
+// Logs are disabled if the URL is empty.
+type LogsConfig struct {
+	URL   string `env:"URL"   envDefault:""`
+	Limit int    `env:"LIMIT" envDefault:"500"`
+}
+
+// LogEntry is the line of the Kuiper log related to the rule. File is the
+// Kuiper source file that logged the line.
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+	File    string    `json:"file,omitempty"`
+}
+
+func (svc *reService) RuleLogs(ctx context.Context, token, id string, since time.Time) ([]LogEntry, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if svc.logs.URL == "" {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, errLogsDisabled)
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ViewAccess)
+	if err != nil {
+		return nil, err
+	}
+	// Unknown rules are reported as such, rather than as the rules without
+	// logs.
+	if _, err := svc.engine.ViewRule(ctx, prefix(owner)+id); err != nil {
+		return nil, err
+	}
+
+	return svc.fetchLogs(ctx, prefix(owner), id, since)
+}
+
+// fetchLogs returns the latest log lines of the rule with the given ID and
+// owner prefix logged since the given time, with the owner prefix removed.
+func (svc *reService) fetchLogs(ctx context.Context, pfx, id string, since time.Time) ([]LogEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, logsTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, svc.logs.URL, nil)
+	if err != nil {
+		return nil, errors.Wrap(ErrKuiperServer, errors.Wrap(errLogs, err))
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(ErrKuiperServer, errors.Wrap(errLogs, err))
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(ErrKuiperServer, errors.Wrap(errLogs, errors.New(res.Status)))
+	}
+
+	limit := max(svc.logs.Limit, 1)
+	entries := []LogEntry{}
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		fields := parseLogLine(scanner.Text())
+		if fields["rule"] != pfx+id {
+			continue
+		}
+		ts, err := time.ParseInLocation(logTimeFormat, fields["time"], time.UTC)
+		if err != nil || ts.Before(since) {
+			continue
+		}
+		// Only the latest lines are kept.
+		if len(entries) == limit {
+			entries = entries[1:]
+		}
+		entries = append(entries, LogEntry{
+			Time:    ts,
+			Level:   fields["level"],
+			Message: strings.ReplaceAll(fields["msg"], pfx, ""),
+			File:    fields["file"],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(ErrKuiperServer, errors.Wrap(errLogs, err))
+	}
+
+	return entries, nil
+}
+
+// parseLogLine returns the fields of the Kuiper log line, as in
+// time="2024-01-02 15:04:05" level=error msg="..." file="..." rule=alarm.
+func parseLogLine(line string) map[string]string {
+	fields := make(map[string]string)
+	for _, m := range logField.FindAllStringSubmatch(line, -1) {
+		val := m[2]
+		if strings.HasPrefix(val, `"`) {
+			if v, err := strconv.Unquote(val); err == nil {
+				val = v
+			}
+		}
+		fields[m[1]] = val
+	}
+
+	return fields
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRuleLogs(t *testing.T) {
+	logs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `time="2024-05-01 10:00:00" level=info msg="Rule `+userPrefix+`rule is started" file="server/rule_manager.go:120" rule=`+userPrefix+`rule`)
+		fmt.Fprintln(w, `time="2024-05-01 10:00:01" level=info msg="Kuiper is serving" file="server/server.go:300"`)
+		fmt.Fprintln(w, `time="2024-05-01 10:00:02" level=error msg="rest sink fails to send out the data: connection refused" file="sink/rest_sink.go:90" rule=`+otherPrefix+`rule`)
+		fmt.Fprintln(w, `time="2024-05-01 10:05:00" level=error msg="rest sink fails to send out the data: connection refused" file="sink/rest_sink.go:90" rule=`+userPrefix+`rule`)
+	}))
+	defer logs.Close()
+
+	svc, _, auth, _ := newServiceWithConfig(t, re.Config{Logs: re.LogsConfig{URL: logs.URL, Limit: 10}}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	started := re.LogEntry{Time: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Level: "info", Message: "Rule rule is started", File: "server/rule_manager.go:120"}
+	failed := re.LogEntry{Time: time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC), Level: "error", Message: "rest sink fails to send out the data: connection refused", File: "sink/rest_sink.go:90"}
+	cases := []struct {
+		desc  string
+		id    string
+		since time.Time
+		logs  []re.LogEntry
+		err   error
+	}{
+		{
+			desc: "rule logs",
+			id:   "rule",
+			logs: []re.LogEntry{started, failed},
+		},
+		{
+			desc:  "rule logs since time",
+			id:    "rule",
+			since: time.Date(2024, 5, 1, 10, 1, 0, 0, time.UTC),
+			logs:  []re.LogEntry{failed},
+		},
+		{
+			desc: "logs of non-existing rule",
+			id:   "unknown",
+			err:  svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		logs, err := svc.RuleLogs(context.Background(), validToken, tc.id, tc.since)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.logs, logs, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.logs, logs))
+	}
+
+	// Only the latest lines are returned.
+	svc, _, auth, _ = newServiceWithConfig(t, re.Config{Logs: re.LogsConfig{URL: logs.URL, Limit: 1}}, re.Notifiers{})
+	authCall = auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	entries, err := svc.RuleLogs(context.Background(), validToken, "rule", time.Time{})
+	assert.Nil(t, err, fmt.Sprintf("limited rule logs: expected no error got %s\n", err))
+	assert.Equal(t, []re.LogEntry{failed}, entries, fmt.Sprintf("limited rule logs: expected %v got %v\n", []re.LogEntry{failed}, entries))
+
+	// Logs are disabled without the URL.
+	svc, _, auth, _ = newService(t)
+	authCall = auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	_, err = svc.RuleLogs(context.Background(), validToken, "rule", time.Time{})
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("disabled rule logs: expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+}
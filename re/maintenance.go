@@ -0,0 +1,133 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var (
+	errPaused    = errors.New("rules are already paused")
+	errNotPaused = errors.New("rules aren't paused")
+)
+
+// Maintenance is the maintenance mode of the user, entered with Pause since
+// the given time. Rules are the IDs of the user's rules that were running
+// when the rules were paused, which Resume starts again.
+type Maintenance struct {
+	Owner string    `json:"-"`
+	Rules []string  `json:"rules"`
+	Since time.Time `json:"since"`
+}
+
+// MaintenanceRepository specifies the persistence API of the maintenance
+// modes.
+type MaintenanceRepository interface {
+	// SaveMaintenance stores the maintenance mode of the owner.
+	SaveMaintenance(ctx context.Context, m Maintenance) error
+
+	// RetrieveMaintenance returns the maintenance mode of the owner.
+	RetrieveMaintenance(ctx context.Context, owner string) (Maintenance, error)
+
+	// RemoveMaintenance removes the maintenance mode of the owner.
+	RemoveMaintenance(ctx context.Context, owner string) error
+}
+
+func (svc *reService) Pause(ctx context.Context, token string) (BulkReport, error) {
+	ctx, err := svc.writing(ctx, token)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	// The checks already passed, so this only identifies the user.
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	rules, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	pfx := prefix(userID)
+	ids := []string{}
+	for _, r := range rules {
+		if strings.HasPrefix(r.ID, pfx) && strings.HasPrefix(strings.ToLower(r.Status), RuleRunning) {
+			ids = append(ids, strings.TrimPrefix(r.ID, pfx))
+		}
+	}
+	sort.Strings(ids)
+
+	// The running rules are recorded before they're stopped, so they aren't
+	// lost if the service fails in the meantime.
+	m := Maintenance{Owner: userID, Rules: ids, Since: time.Now().UTC()}
+	switch err := svc.repo.SaveMaintenance(ctx, m); {
+	case errors.Contains(err, repoerr.ErrConflict):
+		return BulkReport{}, errors.Wrap(svcerr.ErrConflict, errPaused)
+	case err != nil:
+		return BulkReport{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	report := BulkReport{Items: []BulkItem{}}
+	report.add(svc.bulk(RuleKind, len(ids), func(i int) (string, error) {
+		_, err := svc.StopRule(ctx, token, ids[i])
+		return ids[i], err
+	}))
+
+	return report, nil
+}
+
+func (svc *reService) Resume(ctx context.Context, token string) (BulkReport, error) {
+	ctx, err := svc.writing(ctx, token)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	m, err := svc.maintenance(ctx, userID)
+	if err != nil {
+		return BulkReport{}, err
+	}
+
+	report := BulkReport{Items: []BulkItem{}}
+	report.add(svc.bulk(RuleKind, len(m.Rules), func(i int) (string, error) {
+		_, err := svc.StartRule(ctx, token, m.Rules[i])
+		return m.Rules[i], err
+	}))
+	if err := svc.repo.RemoveMaintenance(ctx, userID); err != nil {
+		return BulkReport{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return report, nil
+}
+
+func (svc *reService) ViewMaintenance(ctx context.Context, token string) (Maintenance, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Maintenance{}, err
+	}
+
+	return svc.maintenance(ctx, userID)
+}
+
+// maintenance returns the maintenance mode of the user, failing if the
+// user's rules aren't paused.
+func (svc *reService) maintenance(ctx context.Context, userID string) (Maintenance, error) {
+	m, err := svc.repo.RetrieveMaintenance(ctx, userID)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return Maintenance{}, errors.Wrap(svcerr.ErrNotFound, errNotPaused)
+	case err != nil:
+		return Maintenance{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return m, nil
+}
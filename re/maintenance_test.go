@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMaintenance(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	for _, id := range []string{"humidity", "overheat"} {
+		_, err := svc.CreateRule(context.Background(), validToken, re.Rule{
+			ID:      id,
+			SQL:     "SELECT * FROM stream WHERE v > 10",
+			Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+		})
+		assert.Nil(t, err, fmt.Sprintf("create rule %s: expected no error got %s\n", id, err))
+	}
+	_, err := svc.StopRule(context.Background(), validToken, "humidity")
+	assert.Nil(t, err, fmt.Sprintf("stop rule: expected no error got %s\n", err))
+
+	_, err = svc.ViewMaintenance(context.Background(), validToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("view maintenance before pause: expected %s got %s\n", svcerr.ErrNotFound, err))
+	_, err = svc.Resume(context.Background(), validToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("resume before pause: expected %s got %s\n", svcerr.ErrNotFound, err))
+	_, err = svc.Pause(context.Background(), invalidToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("pause with invalid token: expected %s got %s\n", svcerr.ErrAuthentication, err))
+
+	running := []re.BulkItem{
+		{Kind: re.RuleKind, Name: "overheat", Success: true},
+		{Kind: re.RuleKind, Name: "rule", Success: true},
+	}
+	report, err := svc.Pause(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("pause: expected no error got %s\n", err))
+	assert.Equal(t, running, report.Items, fmt.Sprintf("pause: expected %v got %v\n", running, report.Items))
+	k.mu.Lock()
+	stopped := k.stopped[userPrefix+"overheat"] && k.stopped[userPrefix+"rule"]
+	k.mu.Unlock()
+	assert.True(t, stopped, "pause: expected running rules to be stopped")
+	_, err = svc.Pause(context.Background(), validToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("pause paused rules: expected %s got %s\n", svcerr.ErrConflict, err))
+
+	m, err := svc.ViewMaintenance(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("view maintenance: expected no error got %s\n", err))
+	assert.Equal(t, []string{"overheat", "rule"}, m.Rules, fmt.Sprintf("view maintenance: expected paused rules got %v\n", m.Rules))
+	assert.False(t, m.Since.IsZero(), "view maintenance: expected pause time")
+
+	report, err = svc.Resume(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("resume: expected no error got %s\n", err))
+	assert.Equal(t, running, report.Items, fmt.Sprintf("resume: expected %v got %v\n", running, report.Items))
+	k.mu.Lock()
+	started := !k.stopped[userPrefix+"overheat"] && !k.stopped[userPrefix+"rule"]
+	humidity := k.stopped[userPrefix+"humidity"]
+	k.mu.Unlock()
+	assert.True(t, started, "resume: expected paused rules to be started")
+	assert.True(t, humidity, "resume: expected rule stopped before pause to stay stopped")
+	_, err = svc.ViewMaintenance(context.Background(), validToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("view maintenance after resume: expected %s got %s\n", svcerr.ErrNotFound, err))
+}
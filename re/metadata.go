@@ -0,0 +1,226 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/gofrs/uuid"
+)
+
+var (
+	errEntityOwner       = errors.New("entity belongs to another user")
+	errDefinitionDiffers = errors.New("entity exists with another definition")
+)
+
+// Kinds of the entities metadata is stored for.
+const (
+	StreamKind = "stream"
+	TableKind  = "table"
+	RuleKind   = "rule"
+)
+
+// Metadata contains the information about streams and rules that Kuiper
+// doesn't store. Attributes are the free-form information the owner
+// documents the entity with, and Folder is the name of the owner's folder
+// the rule is organized in. ID identifies the entity independently of its
+// name, so it survives the renames. Owner is the ID of the user the entity
+// belongs to. Stopped reports whether the owner stopped the rule, which is
+// kept stopped when Kuiper restarts, and LastStartedAt is the time the rule
+// was last started by creating, updating, starting, restarting, publishing
+// or restoring it, so the rules left stopped are told apart from active
+// ones. Draft reports whether the rule is the draft that's stored only in
+// the metadata and isn't deployed to Kuiper. DeletedAt is the time the rule
+// was deleted, which is kept stopped and can be restored until the retention
+// period expires. ExpiresAt is the time the temporary rule is removed at and
+// Schedule the times the rule runs at. Definition is the JSON stream or rule definition the
+// entity is restored and exported from. It's never returned by the API,
+// since rule definitions contain notification contacts.
+type Metadata struct {
+	ID            string                 `json:"id,omitempty"`
+	Owner         string                 `json:"owner"`
+	Description   string                 `json:"description,omitempty"`
+	Labels        map[string]string      `json:"labels,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+	Folder        string                 `json:"folder,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at,omitempty"`
+	LastStartedAt time.Time              `json:"last_started_at,omitempty"`
+	Stopped       bool                   `json:"stopped,omitempty"`
+	Draft         bool                   `json:"draft,omitempty"`
+	DeletedAt     time.Time              `json:"deleted_at,omitempty"`
+	ExpiresAt     time.Time              `json:"expires_at,omitempty"`
+	Schedule      *Schedule              `json:"schedule,omitempty"`
+	Definition    string                 `json:"-"`
+}
+
+// Repository specifies the metadata persistence API. Entities are identified
+// by their kind and Kuiper name, which contains the owner prefix.
+type Repository interface {
+	// Save stores the entity metadata, replacing the existing metadata. If
+	// the update time is set, the original ID and creation time are kept.
+	Save(ctx context.Context, kind, name string, md Metadata) error
+
+	// Retrieve returns the metadata of the entity.
+	Retrieve(ctx context.Context, kind, name string) (Metadata, error)
+
+	// RetrieveAll returns the metadata of all the entities of the kind that
+	// belong to the owner, mapped by the Kuiper names. If the owner is
+	// empty, the metadata of the entities of all the owners is returned.
+	RetrieveAll(ctx context.Context, kind, owner string) (map[string]Metadata, error)
+
+	// Remove removes the entity metadata.
+	Remove(ctx context.Context, kind, name string) error
+
+	// Touch sets the update time of the entity to now, provided the entity
+	// was last updated at the given time, and fails with the conflict error
+	// otherwise. Only one of the concurrent changes expecting the same
+	// entity revision succeeds.
+	Touch(ctx context.Context, kind, name string, updated, now time.Time) error
+
+	TemplateRepository
+	QuotaRepository
+	ShareRepository
+	AuditRepository
+	AssignmentRepository
+	EdgeRepository
+	AlertRepository
+	WebhookRepository
+	FolderRepository
+	MaintenanceRepository
+	ChainRepository
+	SecretRepository
+}
+
+// lastUpdate returns the time the entity was last updated, which is its
+// creation time if it was never updated.
+func (md Metadata) lastUpdate() time.Time {
+	if md.UpdatedAt.IsZero() {
+		return md.CreatedAt
+	}
+
+	return md.UpdatedAt
+}
+
+// saveMetadata stores the metadata of the entity the owner created or
+// updated. Updates set the update time, leaving the ID and the creation
+// time of the existing metadata intact. Created and updated rules are
+// started, unless they're drafts.
+func (svc *reService) saveMetadata(ctx context.Context, kind, name string, md Metadata, update bool) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	md.ID = id.String()
+	now := time.Now().UTC()
+	md.CreatedAt = now
+	wrapper := svcerr.ErrCreateEntity
+	if update {
+		md.UpdatedAt = now
+		wrapper = svcerr.ErrUpdateEntity
+	}
+	if kind == RuleKind && !md.Draft {
+		md.LastStartedAt = now
+	}
+	if err := svc.repo.Save(ctx, kind, prefix(md.Owner)+name, md); err != nil {
+		return errors.Wrap(wrapper, err)
+	}
+
+	return nil
+}
+
+// existing checks the entity of the owner with the given name that is
+// being created again. The entity that already exists with the same
+// definition is returned as the result, so creating it can be safely
+// repeated, and the entity with another definition is a conflict. Entities
+// without the stored definition are left to the engine, which rejects the
+// existing ones.
+func (svc *reService) existing(ctx context.Context, kind, owner, name, definition string) (Result, bool, error) {
+	kuiperName := prefix(owner) + name
+	md, err := svc.metadata(ctx, kind, kuiperName)
+	if err != nil || md == nil || md.Definition == "" || md.Draft || md.deleted() {
+		return Result{}, false, err
+	}
+	// The metadata of the entity missing from the engine is replaced once
+	// the entity is created.
+	if kind == RuleKind {
+		_, err = svc.engine.ViewRule(ctx, kuiperName)
+	} else {
+		_, err = svc.engine.ViewStream(ctx, kind, kuiperName)
+	}
+	switch {
+	case errors.Contains(err, svcerr.ErrNotFound):
+		return Result{}, false, nil
+	case err != nil:
+		return Result{}, false, err
+	}
+	stored := md.Definition
+	// The sealed values differ each time they're sealed, so the values are
+	// compared.
+	if kind == RuleKind && strings.Contains(stored, sealedPrefix) {
+		if stored, err = svc.openDefinition(stored); err != nil {
+			return Result{}, false, err
+		}
+	}
+	if stored != definition {
+		return Result{}, false, errors.Wrap(svcerr.ErrConflict, errors.Wrap(ErrConflict, errDefinitionDiffers))
+	}
+	res := Result{
+		Status:  http.StatusOK,
+		Message: fmt.Sprintf("%s %s already exists.", strings.ToUpper(kind[:1])+kind[1:], name),
+	}
+
+	return res.owned(name, owner), true, nil
+}
+
+// metadata returns the metadata of the entity with the given Kuiper name.
+// Entities created before their metadata was stored have no metadata.
+func (svc *reService) metadata(ctx context.Context, kind, name string) (*Metadata, error) {
+	md, err := svc.repo.Retrieve(ctx, kind, name)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return &md, nil
+}
+
+// ownedMetadata returns the metadata of the entity with the given Kuiper
+// name after verifying that the entity belongs to the user. Resolved is the
+// name of the entity Kuiper resolved the name to, which must be the name
+// itself. Entities of other users are reported as missing, so their names
+// aren't disclosed.
+func (svc *reService) ownedMetadata(ctx context.Context, kind, userID, name, resolved string) (*Metadata, error) {
+	if resolved != name || !strings.HasPrefix(name, prefix(userID)) {
+		return nil, errors.Wrap(svcerr.ErrNotFound, errEntityOwner)
+	}
+	md, err := svc.metadata(ctx, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	if md != nil && md.Owner != userID {
+		return nil, errors.Wrap(svcerr.ErrNotFound, errEntityOwner)
+	}
+
+	return md, nil
+}
+
+// removeMetadata removes the metadata of the entity with the given Kuiper
+// name, if any.
+func (svc *reService) removeMetadata(ctx context.Context, kind, name string) error {
+	if err := svc.repo.Remove(ctx, kind, name); err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// failingRepo is the metadata repository that fails to save metadata.
+type failingRepo struct {
+	re.Repository
+}
+
+func (failingRepo) Save(context.Context, string, string, re.Metadata) error {
+	return repoerr.ErrCreateEntity
+}
+
+func TestStreamMetadata(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, _, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	labels := map[string]string{"site": "plant"}
+	attrs := map[string]interface{}{"owner_team": "maintenance", "sensors": []interface{}{"t1", "t2"}}
+	def := re.StreamDef{Name: "readings", Topic: channelID, SenML: true, Description: "plant readings", Labels: labels, Attributes: attrs}
+	_, err := svc.CreateStream(context.Background(), validToken, def, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+
+	stream, err := svc.ViewStream(context.Background(), validToken, def.Name)
+	assert.Nil(t, err, fmt.Sprintf("view stream: expected no error got %s\n", err))
+	md := stream.Metadata
+	if assert.NotNil(t, md, "view stream: expected stream metadata") {
+		assert.Equal(t, userID, md.Owner, fmt.Sprintf("view stream: expected owner %s got %s\n", userID, md.Owner))
+		assert.Equal(t, def.Description, md.Description, fmt.Sprintf("view stream: expected description %s got %s\n", def.Description, md.Description))
+		assert.Equal(t, labels, md.Labels, fmt.Sprintf("view stream: expected labels %v got %v\n", labels, md.Labels))
+		assert.Equal(t, attrs, md.Attributes, fmt.Sprintf("view stream: expected attributes %v got %v\n", attrs, md.Attributes))
+		assert.False(t, md.CreatedAt.IsZero(), "view stream: expected creation time")
+		assert.True(t, md.UpdatedAt.IsZero(), "view stream: expected no update time")
+	}
+
+	def.Description = "updated"
+	_, err = svc.CreateStream(context.Background(), validToken, def, true)
+	assert.Nil(t, err, fmt.Sprintf("update stream: expected no error got %s\n", err))
+	page, err := svc.ListStreams(context.Background(), validToken, re.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list streams: expected no error got %s\n", err))
+	listed, ok := page.Metadata[def.Name]
+	assert.True(t, ok, "list streams: expected stream metadata")
+	assert.Equal(t, "updated", listed.Description, fmt.Sprintf("list streams: expected description updated got %s\n", listed.Description))
+	assert.False(t, listed.UpdatedAt.IsZero(), "list streams: expected update time")
+	if md != nil {
+		assert.Equal(t, md.CreatedAt, listed.CreatedAt, fmt.Sprintf("list streams: expected creation time %s got %s\n", md.CreatedAt, listed.CreatedAt))
+	}
+	_, ok = page.Metadata["stream"]
+	assert.False(t, ok, "list streams: expected no metadata of stream created without it")
+
+	_, err = svc.DeleteStream(context.Background(), validToken, def.Name, false)
+	assert.Nil(t, err, fmt.Sprintf("delete stream: expected no error got %s\n", err))
+	_, err = repo.Retrieve(context.Background(), re.StreamKind, userPrefix+def.Name)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("delete stream: expected metadata to be removed got %s\n", err))
+}
+
+func TestRuleMetadata(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	rule := re.Rule{
+		ID:          "alarm",
+		SQL:         "SELECT * FROM stream WHERE v > 30",
+		Actions:     []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+		Description: "high temperature",
+		Labels:      map[string]string{"severity": "high"},
+		Attributes:  map[string]interface{}{"runbook": "https://example.com/runbook", "escalation": map[string]interface{}{"after": 15}},
+	}
+	_, err := svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	assert.NotContains(t, string(k.raw[userPrefix+rule.ID]), rule.Description, "create rule: expected metadata not to be sent to Kuiper")
+	assert.NotContains(t, string(k.raw[userPrefix+rule.ID]), "runbook", "create rule: expected attributes not to be sent to Kuiper")
+
+	viewed, err := svc.ViewRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("view rule: expected no error got %s\n", err))
+	assert.Equal(t, rule.Description, viewed.Description, fmt.Sprintf("view rule: expected description %s got %s\n", rule.Description, viewed.Description))
+	assert.Equal(t, rule.Labels, viewed.Labels, fmt.Sprintf("view rule: expected labels %v got %v\n", rule.Labels, viewed.Labels))
+	assert.Equal(t, rule.Attributes, viewed.Attributes, fmt.Sprintf("view rule: expected attributes %v got %v\n", rule.Attributes, viewed.Attributes))
+	if assert.NotNil(t, viewed.Metadata, "view rule: expected rule metadata") {
+		assert.Equal(t, userID, viewed.Metadata.Owner, fmt.Sprintf("view rule: expected owner %s got %s\n", userID, viewed.Metadata.Owner))
+		assert.Equal(t, viewed.Metadata.CreatedAt, viewed.Metadata.LastStartedAt, fmt.Sprintf("view rule: expected last start time %s got %s\n", viewed.Metadata.CreatedAt, viewed.Metadata.LastStartedAt))
+	}
+
+	page, err := svc.ListRules(context.Background(), validToken, re.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list rules: expected no error got %s\n", err))
+	for _, r := range page.Rules {
+		hasMetadata := r.Metadata != nil
+		assert.Equal(t, r.ID == rule.ID, hasMetadata, fmt.Sprintf("list rules: expected metadata of rule %s: %t got %t\n", r.ID, r.ID == rule.ID, hasMetadata))
+	}
+}
+
+func TestMetadataFailure(t *testing.T) {
+	svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, failingRepo{mocks.NewRepository()})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "readings", Topic: channelID, SenML: true}, false)
+	assert.True(t, errors.Contains(err, svcerr.ErrCreateEntity), fmt.Sprintf("create stream: expected %s got %s\n", svcerr.ErrCreateEntity, err))
+	_, ok := k.streams[userPrefix+"readings"]
+	assert.False(t, ok, "create stream: expected stream to be removed")
+
+	_, err = svc.CreateRule(context.Background(), validToken, re.Rule{ID: "alarm", SQL: "SELECT * FROM stream", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}})
+	assert.True(t, errors.Contains(err, svcerr.ErrCreateEntity), fmt.Sprintf("create rule: expected %s got %s\n", svcerr.ErrCreateEntity, err))
+	_, ok = k.rules[userPrefix+"alarm"]
+	assert.False(t, ok, "create rule: expected rule to be removed")
+
+	_, err = svc.UpdateRule(context.Background(), validToken, re.Rule{ID: "rule", SQL: "SELECT * FROM stream", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}})
+	assert.True(t, errors.Contains(err, svcerr.ErrUpdateEntity), fmt.Sprintf("update rule: expected %s got %s\n", svcerr.ErrUpdateEntity, err))
+}
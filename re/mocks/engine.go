@@ -0,0 +1,405 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/re"
+)
+
+var (
+	_ re.RuleEngine = (*engineMock)(nil)
+
+	// streamOption matches the options in the WITH clause of the DDL.
+	streamOption = regexp.MustCompile(`(\w+) = "([^"]*)"`)
+
+	// ruleStates maps the rule commands to the states of the rule.
+	ruleStates = map[string]string{
+		"start":   "running",
+		"stop":    "stopped",
+		"restart": "running",
+	}
+
+	// controlled maps the rule commands to the Kuiper responses to them.
+	controlled = map[string]string{
+		"start":   "started",
+		"stop":    "stopped",
+		"restart": "restarted",
+	}
+)
+
+type engineMock struct {
+	mu       sync.Mutex
+	streams  map[string]map[string]re.Stream
+	rules    map[string]re.EngineRule
+	states   map[string]string
+	plugins  map[string]map[string]re.Plugin
+	services map[string]re.ExternalService
+	confKeys map[string]re.MQTTConf
+}
+
+// NewEngine creates in-memory rule engine, which stores the streams,
+// tables and rules without running them. Rules are reported running until
+// they're stopped and rule trials aren't supported.
+func NewEngine() re.RuleEngine {
+	return &engineMock{
+		streams: map[string]map[string]re.Stream{
+			re.StreamKind: {},
+			re.TableKind:  {},
+		},
+		rules:  make(map[string]re.EngineRule),
+		states: make(map[string]string),
+		plugins: map[string]map[string]re.Plugin{
+			re.SourcePlugin:   {},
+			re.SinkPlugin:     {},
+			re.FunctionPlugin: {},
+		},
+		services: make(map[string]re.ExternalService),
+		confKeys: map[string]re.MQTTConf{"default": {Server: "tcp://localhost:1883"}},
+	}
+}
+
+// NewInMemoryService creates the rules engine service keeping the streams,
+// rules and their metadata in memory, so the dependents of the service can
+// be tested without Kuiper.
+func NewInMemoryService(auth magistrala.AuthServiceClient, sdk mgsdk.SDK) re.Service {
+	return re.NewWithEngine(NewEngine(), re.Config{}, auth, sdk, re.Notifiers{}, nil, NewRepository())
+}
+
+func (e *engineMock) Info(_ context.Context) (re.Info, error) {
+	return re.Info{Version: "1.10.0", OS: "linux", Breaker: "closed"}, nil
+}
+
+func (e *engineMock) CreateStream(_ context.Context, kind, sql string) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	words := strings.Fields(sql)
+	if len(words) < 3 {
+		return re.Result{}, errors.Wrap(svcerr.ErrMalformedEntity, errors.New("invalid DDL"))
+	}
+	name := words[2]
+	if _, ok := e.streams[kind][name]; ok {
+		return re.Result{}, conflict(kind, name)
+	}
+	e.streams[kind][name] = parseStream(name, sql)
+
+	return re.Result{Status: http.StatusCreated, Message: fmt.Sprintf("%s %s is created.", title(kind), name)}, nil
+}
+
+func (e *engineMock) UpdateStream(_ context.Context, kind, name, sql string) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.streams[kind][name]; !ok {
+		return re.Result{}, notFound(kind, name)
+	}
+	e.streams[kind][name] = parseStream(name, sql)
+
+	return re.Result{Status: http.StatusOK, Message: fmt.Sprintf("%s %s is updated.", title(kind), name)}, nil
+}
+
+func (e *engineMock) ListStreams(_ context.Context, kind string) ([]string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return sortedKeys(e.streams[kind]), nil
+}
+
+func (e *engineMock) ViewStream(_ context.Context, kind, name string) (re.Stream, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	stream, ok := e.streams[kind][name]
+	if !ok {
+		return re.Stream{}, notFound(kind, name)
+	}
+
+	return stream, nil
+}
+
+func (e *engineMock) DeleteStream(_ context.Context, kind, name string) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.streams[kind][name]; !ok {
+		return re.Result{}, notFound(kind, name)
+	}
+	delete(e.streams[kind], name)
+
+	return re.Result{Status: http.StatusOK, Message: fmt.Sprintf("%s %s is dropped.", title(kind), name)}, nil
+}
+
+func (e *engineMock) CreateRule(_ context.Context, rule re.EngineRule) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.rules[rule.ID]; ok {
+		return re.Result{}, conflict(re.RuleKind, rule.ID)
+	}
+	e.rules[rule.ID] = rule
+	e.states[rule.ID] = "running"
+
+	return re.Result{Status: http.StatusCreated, Message: fmt.Sprintf("Rule %s was created successfully.", rule.ID)}, nil
+}
+
+func (e *engineMock) UpdateRule(_ context.Context, rule re.EngineRule) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.rules[rule.ID]; !ok {
+		return re.Result{}, ruleNotFound(rule.ID)
+	}
+	e.rules[rule.ID] = rule
+	e.states[rule.ID] = "running"
+
+	return re.Result{Status: http.StatusOK, Message: fmt.Sprintf("Rule %s was updated successfully.", rule.ID)}, nil
+}
+
+func (e *engineMock) ListRules(_ context.Context) ([]re.RuleInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rules := []re.RuleInfo{}
+	for _, id := range sortedKeys(e.rules) {
+		rules = append(rules, re.RuleInfo{ID: id, Status: title(e.states[id])})
+	}
+
+	return rules, nil
+}
+
+func (e *engineMock) ViewRule(_ context.Context, id string) (re.EngineRule, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rule, ok := e.rules[id]
+	if !ok {
+		return re.EngineRule{}, ruleNotFound(id)
+	}
+
+	return rule, nil
+}
+
+func (e *engineMock) DeleteRule(_ context.Context, id string) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.rules[id]; !ok {
+		return re.Result{}, ruleNotFound(id)
+	}
+	delete(e.rules, id)
+	delete(e.states, id)
+
+	return re.Result{Status: http.StatusOK, Message: fmt.Sprintf("Rule %s is dropped.", id)}, nil
+}
+
+func (e *engineMock) ControlRule(_ context.Context, id, command string) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.rules[id]; !ok {
+		return re.Result{}, ruleNotFound(id)
+	}
+	state, ok := ruleStates[command]
+	if !ok {
+		return re.Result{}, errors.Wrap(svcerr.ErrMalformedEntity, errors.New("unknown rule command"))
+	}
+	e.states[id] = state
+
+	return re.Result{Status: http.StatusOK, Message: fmt.Sprintf("Rule %s was %s.", id, controlled[command])}, nil
+}
+
+func (e *engineMock) RuleStatus(_ context.Context, id string) (re.RuleStatus, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.rules[id]; !ok {
+		return re.RuleStatus{}, ruleNotFound(id)
+	}
+
+	return re.RuleStatus{Status: e.states[id]}, nil
+}
+
+func (e *engineMock) RuleTopology(_ context.Context, id string) (re.RuleTopology, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.rules[id]; !ok {
+		return re.RuleTopology{}, ruleNotFound(id)
+	}
+
+	return re.RuleTopology{}, errors.Wrap(re.ErrNotSupported, errors.New("in-memory engine doesn't run rules"))
+}
+
+func (e *engineMock) ValidateRule(_ context.Context, _ re.EngineRule) error {
+	return nil
+}
+
+func (e *engineMock) RunTrial(_ context.Context, _ re.EngineTrial) (re.TrialResult, error) {
+	return re.TrialResult{}, errors.Wrap(re.ErrNotSupported, errors.New("in-memory engine doesn't run rules"))
+}
+
+func (e *engineMock) CreatePlugin(_ context.Context, kind string, plugin re.Plugin) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.plugins[kind][plugin.Name]; ok {
+		return re.Result{}, conflict("plugin", plugin.Name)
+	}
+	e.plugins[kind][plugin.Name] = plugin
+
+	return re.Result{Status: http.StatusCreated, Message: fmt.Sprintf("Plugin %s is created.", plugin.Name)}, nil
+}
+
+func (e *engineMock) ListPlugins(_ context.Context, kind string) ([]string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return sortedKeys(e.plugins[kind]), nil
+}
+
+func (e *engineMock) DeletePlugin(_ context.Context, kind, name string) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.plugins[kind][name]; !ok {
+		return re.Result{}, notFound("plugin", name)
+	}
+	delete(e.plugins[kind], name)
+
+	return re.Result{Status: http.StatusOK, Message: fmt.Sprintf("Plugin %s is deleted.", name)}, nil
+}
+
+func (e *engineMock) RegisterService(_ context.Context, es re.ExternalService) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.services[es.Name]; ok {
+		return re.Result{}, conflict("service", es.Name)
+	}
+	e.services[es.Name] = es
+
+	return re.Result{Status: http.StatusCreated, Message: fmt.Sprintf("Service %s is created.", es.Name)}, nil
+}
+
+func (e *engineMock) ListServices(_ context.Context) ([]string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return sortedKeys(e.services), nil
+}
+
+func (e *engineMock) DeleteService(_ context.Context, name string) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.services[name]; !ok {
+		return re.Result{}, notFound("service", name)
+	}
+	delete(e.services, name)
+
+	return re.Result{Status: http.StatusOK, Message: fmt.Sprintf("Service %s is deleted.", name)}, nil
+}
+
+// ListFunctions returns no functions, since the in-memory engine doesn't
+// read the descriptors of the external services.
+func (e *engineMock) ListFunctions(_ context.Context) ([]re.ExternalFunction, error) {
+	return []re.ExternalFunction{}, nil
+}
+
+func (e *engineMock) SaveConfKey(_ context.Context, name string, conf re.MQTTConf) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.confKeys[name] = conf
+
+	return re.Result{Status: http.StatusOK}, nil
+}
+
+func (e *engineMock) ListConfKeys(_ context.Context) ([]string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return sortedKeys(e.confKeys), nil
+}
+
+func (e *engineMock) DeleteConfKey(_ context.Context, name string) (re.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.confKeys[name]; !ok {
+		return re.Result{}, errors.Wrap(svcerr.ErrMalformedEntity, errors.New("conf key not found"))
+	}
+	delete(e.confKeys, name)
+
+	return re.Result{Status: http.StatusOK}, nil
+}
+
+// Push drops the message, since the rules aren't run, after checking that
+// the stream exists.
+func (e *engineMock) Push(_ context.Context, stream string, _ []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := e.streams[re.StreamKind][stream]; !ok {
+		return notFound(re.StreamKind, stream)
+	}
+
+	return nil
+}
+
+// parseStream returns the stream with the options set in the DDL.
+func parseStream(name, sql string) re.Stream {
+	stream := re.Stream{Name: name, StreamFields: []re.StreamField{}, Options: make(map[string]string)}
+	if _, with, ok := strings.Cut(sql, " WITH "); ok {
+		for _, opt := range streamOption.FindAllStringSubmatch(with, -1) {
+			stream.Options[strings.ToLower(opt[1])] = opt[2]
+		}
+	}
+
+	return stream
+}
+
+// conflict returns the error Kuiper responds with to the creation of the
+// existing entity.
+func conflict(kind, name string) error {
+	return errors.Wrap(svcerr.ErrConflict, errors.Wrap(re.ErrConflict, fmt.Errorf("%s %s already exists", kind, name)))
+}
+
+func notFound(kind, name string) error {
+	return errors.Wrap(svcerr.ErrNotFound, fmt.Errorf("%s %s is not found", kind, name))
+}
+
+func ruleNotFound(id string) error {
+	return errors.Wrap(svcerr.ErrNotFound, errors.Wrap(re.ErrRuleNotFound, fmt.Errorf("rule %s is not found", id)))
+}
+
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,657 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+var _ re.Repository = (*repositoryMock)(nil)
+
+type repositoryMock struct {
+	mu        sync.Mutex
+	metadata  map[string]map[string]re.Metadata
+	templates map[string]re.Template
+	quotas    map[string]re.Quota
+	shares    map[string][]re.Share
+	events    []re.AuditEvent
+	assigned  map[string]string
+	gateways  map[string]re.Gateway
+	deployed  map[string]map[string]re.Deployment
+	alerts    map[string]re.AlertPolicy
+	webhooks  map[string]re.Webhook
+	folders   map[string]map[string]re.Folder
+	paused    map[string]re.Maintenance
+	chains    map[string]map[string]re.Chain
+	secrets   map[string]map[string]re.Secret
+}
+
+// NewRepository creates in-memory metadata, template, quota, share, audit,
+// instance assignment, edge deployment, alert policy, webhook, folder,
+// maintenance, chain and secret repository.
+func NewRepository() re.Repository {
+	return &repositoryMock{
+		metadata: map[string]map[string]re.Metadata{
+			re.StreamKind: {},
+			re.TableKind:  {},
+			re.RuleKind:   {},
+		},
+		templates: make(map[string]re.Template),
+		quotas:    make(map[string]re.Quota),
+		shares:    make(map[string][]re.Share),
+		assigned:  make(map[string]string),
+		gateways:  make(map[string]re.Gateway),
+		deployed:  make(map[string]map[string]re.Deployment),
+		alerts:    make(map[string]re.AlertPolicy),
+		webhooks:  make(map[string]re.Webhook),
+		folders:   make(map[string]map[string]re.Folder),
+		paused:    make(map[string]re.Maintenance),
+		chains:    make(map[string]map[string]re.Chain),
+		secrets:   make(map[string]map[string]re.Secret),
+	}
+}
+
+func (repo *repositoryMock) Save(_ context.Context, kind, name string, md re.Metadata) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if old, ok := repo.metadata[kind][name]; ok && !md.UpdatedAt.IsZero() {
+		md.CreatedAt = old.CreatedAt
+		if old.ID != "" {
+			md.ID = old.ID
+		}
+	}
+	repo.metadata[kind][name] = md
+
+	return nil
+}
+
+func (repo *repositoryMock) Retrieve(_ context.Context, kind, name string) (re.Metadata, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	md, ok := repo.metadata[kind][name]
+	if !ok {
+		return re.Metadata{}, repoerr.ErrNotFound
+	}
+
+	return md, nil
+}
+
+func (repo *repositoryMock) RetrieveAll(_ context.Context, kind, owner string) (map[string]re.Metadata, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	res := make(map[string]re.Metadata)
+	for name, md := range repo.metadata[kind] {
+		if owner == "" || md.Owner == owner {
+			res[name] = md
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *repositoryMock) Remove(_ context.Context, kind, name string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.metadata[kind][name]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.metadata[kind], name)
+
+	return nil
+}
+
+func (repo *repositoryMock) Touch(_ context.Context, kind, name string, updated, now time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	md, ok := repo.metadata[kind][name]
+	last := md.UpdatedAt
+	if last.IsZero() {
+		last = md.CreatedAt
+	}
+	if !ok || !last.Equal(updated) {
+		return repoerr.ErrConflict
+	}
+	md.UpdatedAt = now
+	repo.metadata[kind][name] = md
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveTemplate(_ context.Context, tmpl re.Template) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.templates[tmpl.Name]; ok {
+		return repoerr.ErrConflict
+	}
+	repo.templates[tmpl.Name] = tmpl
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveTemplate(_ context.Context, name string) (re.Template, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	tmpl, ok := repo.templates[name]
+	if !ok {
+		return re.Template{}, repoerr.ErrNotFound
+	}
+
+	return tmpl, nil
+}
+
+func (repo *repositoryMock) RetrieveAllTemplates(_ context.Context) ([]re.Template, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	tmpls := []re.Template{}
+	for _, tmpl := range repo.templates {
+		tmpls = append(tmpls, tmpl)
+	}
+	sort.Slice(tmpls, func(i, j int) bool { return tmpls[i].Name < tmpls[j].Name })
+
+	return tmpls, nil
+}
+
+func (repo *repositoryMock) RemoveTemplate(_ context.Context, name string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.templates[name]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.templates, name)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveQuota(_ context.Context, userID string, q re.Quota) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.quotas[userID] = q
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveQuota(_ context.Context, userID string) (re.Quota, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	q, ok := repo.quotas[userID]
+	if !ok {
+		return re.Quota{}, repoerr.ErrNotFound
+	}
+
+	return q, nil
+}
+
+func (repo *repositoryMock) RemoveQuota(_ context.Context, userID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.quotas[userID]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.quotas, userID)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveShare(_ context.Context, kind, name string, s re.Share) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	key := kind + "/" + name
+	for i, share := range repo.shares[key] {
+		if share.Grantee == s.Grantee {
+			repo.shares[key][i] = s
+			return nil
+		}
+	}
+	repo.shares[key] = append(repo.shares[key], s)
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveShares(_ context.Context, kind, name string) ([]re.Share, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	shares := append([]re.Share{}, repo.shares[kind+"/"+name]...)
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].Grantee < shares[j].Grantee
+	})
+
+	return shares, nil
+}
+
+func (repo *repositoryMock) RemoveShare(_ context.Context, kind, name, grantee string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	key := kind + "/" + name
+	for i, share := range repo.shares[key] {
+		if share.Grantee == grantee {
+			repo.shares[key] = append(repo.shares[key][:i], repo.shares[key][i+1:]...)
+			return nil
+		}
+	}
+
+	return repoerr.ErrNotFound
+}
+
+func (repo *repositoryMock) RemoveShares(_ context.Context, kind, name string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.shares, kind+"/"+name)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveAuditEvent(_ context.Context, ev re.AuditEvent) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.events = append(repo.events, ev)
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveAuditEvents(_ context.Context, q re.AuditQuery) (re.AuditPage, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	events := []re.AuditEvent{}
+	for i := len(repo.events) - 1; i >= 0; i-- {
+		ev := repo.events[i]
+		switch {
+		case q.User != "" && ev.User != q.User,
+			q.Owner != "" && ev.Owner != q.Owner,
+			q.Kind != "" && ev.Kind != q.Kind,
+			q.Entity != "" && ev.Entity != q.Entity,
+			!q.From.IsZero() && ev.Time.Before(q.From),
+			!q.To.IsZero() && ev.Time.After(q.To):
+			continue
+		}
+		events = append(events, ev)
+	}
+
+	page := re.AuditPage{Total: uint64(len(events)), Offset: q.Offset, Limit: q.Limit, Events: []re.AuditEvent{}}
+	if q.Offset < uint64(len(events)) {
+		end := min(q.Offset+q.Limit, uint64(len(events)))
+		page.Events = events[q.Offset:end]
+	}
+
+	return page, nil
+}
+
+func (repo *repositoryMock) SaveAssignment(_ context.Context, userID, instance string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.assigned[userID] = instance
+
+	return nil
+}
+
+func (repo *repositoryMock) RemoveAssignment(_ context.Context, userID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.assigned[userID]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.assigned, userID)
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveAssignments(_ context.Context) (map[string]string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	assigned := make(map[string]string, len(repo.assigned))
+	for userID, instance := range repo.assigned {
+		assigned[userID] = instance
+	}
+
+	return assigned, nil
+}
+
+func (repo *repositoryMock) SaveGateway(_ context.Context, gw re.Gateway) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.gateways[gw.ID] = gw
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveGateway(_ context.Context, id string) (re.Gateway, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	gw, ok := repo.gateways[id]
+	if !ok {
+		return re.Gateway{}, repoerr.ErrNotFound
+	}
+
+	return gw, nil
+}
+
+func (repo *repositoryMock) RetrieveGateways(_ context.Context, owner string) ([]re.Gateway, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	gws := []re.Gateway{}
+	for _, gw := range repo.gateways {
+		if gw.Owner == owner {
+			gws = append(gws, gw)
+		}
+	}
+
+	return gws, nil
+}
+
+func (repo *repositoryMock) RemoveGateway(_ context.Context, id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.gateways[id]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.gateways, id)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveDeployment(_ context.Context, d re.Deployment) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.deployed[d.Rule] == nil {
+		repo.deployed[d.Rule] = make(map[string]re.Deployment)
+	}
+	repo.deployed[d.Rule][d.Gateway] = d
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveDeployments(_ context.Context, rule, gateway string) ([]re.Deployment, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	ds := []re.Deployment{}
+	for name, byGateway := range repo.deployed {
+		if rule != "" && name != rule {
+			continue
+		}
+		for id, d := range byGateway {
+			if gateway == "" || id == gateway {
+				ds = append(ds, d)
+			}
+		}
+	}
+
+	return ds, nil
+}
+
+func (repo *repositoryMock) RemoveDeployment(_ context.Context, rule, gateway string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.deployed[rule][gateway]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.deployed[rule], gateway)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveAlertPolicy(_ context.Context, owner string, p re.AlertPolicy) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.alerts[owner] = p
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveAlertPolicy(_ context.Context, owner string) (re.AlertPolicy, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	p, ok := repo.alerts[owner]
+	if !ok {
+		return re.AlertPolicy{}, repoerr.ErrNotFound
+	}
+
+	return p, nil
+}
+
+func (repo *repositoryMock) RetrieveAlertPolicies(_ context.Context) (map[string]re.AlertPolicy, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	policies := make(map[string]re.AlertPolicy, len(repo.alerts))
+	for owner, p := range repo.alerts {
+		policies[owner] = p
+	}
+
+	return policies, nil
+}
+
+func (repo *repositoryMock) RemoveAlertPolicy(_ context.Context, owner string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.alerts[owner]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.alerts, owner)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveWebhook(_ context.Context, wh re.Webhook) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.webhooks[wh.ID] = wh
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveWebhooks(_ context.Context, owner string) ([]re.Webhook, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	whs := []re.Webhook{}
+	for _, wh := range repo.webhooks {
+		if owner == "" || wh.Owner == owner {
+			whs = append(whs, wh)
+		}
+	}
+
+	return whs, nil
+}
+
+func (repo *repositoryMock) RemoveWebhook(_ context.Context, owner, id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if wh, ok := repo.webhooks[id]; !ok || wh.Owner != owner {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.webhooks, id)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveFolder(_ context.Context, f re.Folder) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.folders[f.Owner][f.Name]; ok {
+		return repoerr.ErrConflict
+	}
+	if repo.folders[f.Owner] == nil {
+		repo.folders[f.Owner] = make(map[string]re.Folder)
+	}
+	repo.folders[f.Owner][f.Name] = f
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveFolders(_ context.Context, owner string) ([]re.Folder, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	folders := []re.Folder{}
+	for _, f := range repo.folders[owner] {
+		folders = append(folders, f)
+	}
+
+	return folders, nil
+}
+
+func (repo *repositoryMock) RemoveFolder(_ context.Context, owner, name string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.folders[owner][name]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.folders[owner], name)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveMaintenance(_ context.Context, m re.Maintenance) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.paused[m.Owner]; ok {
+		return repoerr.ErrConflict
+	}
+	repo.paused[m.Owner] = m
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveMaintenance(_ context.Context, owner string) (re.Maintenance, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	m, ok := repo.paused[owner]
+	if !ok {
+		return re.Maintenance{}, repoerr.ErrNotFound
+	}
+
+	return m, nil
+}
+
+func (repo *repositoryMock) RemoveMaintenance(_ context.Context, owner string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.paused[owner]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.paused, owner)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveChain(_ context.Context, c re.Chain) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.chains[c.Owner][c.Name]; ok {
+		return repoerr.ErrConflict
+	}
+	if repo.chains[c.Owner] == nil {
+		repo.chains[c.Owner] = make(map[string]re.Chain)
+	}
+	repo.chains[c.Owner][c.Name] = c
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveChains(_ context.Context, owner string) ([]re.Chain, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	chains := []re.Chain{}
+	for _, c := range repo.chains[owner] {
+		chains = append(chains, c)
+	}
+
+	return chains, nil
+}
+
+func (repo *repositoryMock) RemoveChain(_ context.Context, owner, name string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.chains[owner][name]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.chains[owner], name)
+
+	return nil
+}
+
+func (repo *repositoryMock) SaveSecret(_ context.Context, s re.Secret) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.secrets[s.Owner] == nil {
+		repo.secrets[s.Owner] = make(map[string]re.Secret)
+	}
+	if old, ok := repo.secrets[s.Owner][s.Name]; ok {
+		s.CreatedAt = old.CreatedAt
+	}
+	repo.secrets[s.Owner][s.Name] = s
+
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveSecrets(_ context.Context, owner string) ([]re.Secret, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	secrets := []re.Secret{}
+	for _, s := range repo.secrets[owner] {
+		secrets = append(secrets, s)
+	}
+
+	return secrets, nil
+}
+
+func (repo *repositoryMock) RemoveSecret(_ context.Context, owner, name string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.secrets[owner][name]; !ok {
+		return repoerr.ErrNotFound
+	}
+	delete(repo.secrets[owner], name)
+
+	return nil
+}
@@ -0,0 +1,2783 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+// Copyright (c) Abstract Machines
+
+package mocks
+
+import (
+	context "context"
+
+	re "github.com/absmach/magistrala/re"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// AssignInstance provides a mock function with given fields: ctx, token, userID, instance
+func (_m *Service) AssignInstance(ctx context.Context, token string, userID string, instance string) (re.Assignment, error) {
+	ret := _m.Called(ctx, token, userID, instance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignInstance")
+	}
+
+	var r0 re.Assignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (re.Assignment, error)); ok {
+		return rf(ctx, token, userID, instance)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) re.Assignment); ok {
+		r0 = rf(ctx, token, userID, instance)
+	} else {
+		r0 = ret.Get(0).(re.Assignment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, userID, instance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkCreate provides a mock function with given fields: ctx, token, rs
+func (_m *Service) BulkCreate(ctx context.Context, token string, rs re.Ruleset) (re.BulkReport, error) {
+	ret := _m.Called(ctx, token, rs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreate")
+	}
+
+	var r0 re.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Ruleset) (re.BulkReport, error)); ok {
+		return rf(ctx, token, rs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Ruleset) re.BulkReport); ok {
+		r0 = rf(ctx, token, rs)
+	} else {
+		r0 = ret.Get(0).(re.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Ruleset) error); ok {
+		r1 = rf(ctx, token, rs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkDelete provides a mock function with given fields: ctx, token, bd
+func (_m *Service) BulkDelete(ctx context.Context, token string, bd re.BulkDeletion) (re.BulkReport, error) {
+	ret := _m.Called(ctx, token, bd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkDelete")
+	}
+
+	var r0 re.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.BulkDeletion) (re.BulkReport, error)); ok {
+		return rf(ctx, token, bd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.BulkDeletion) re.BulkReport); ok {
+		r0 = rf(ctx, token, bd)
+	} else {
+		r0 = ret.Get(0).(re.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.BulkDeletion) error); ok {
+		r1 = rf(ctx, token, bd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloneRule provides a mock function with given fields: ctx, token, id, newID, channel
+func (_m *Service) CloneRule(ctx context.Context, token string, id string, newID string, channel string) (re.Result, error) {
+	ret := _m.Called(ctx, token, id, newID, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, id, newID, channel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) re.Result); ok {
+		r0 = rf(ctx, token, id, newID, channel)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, token, id, newID, channel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CollectOrphans provides a mock function with given fields: ctx, token, policy
+func (_m *Service) CollectOrphans(ctx context.Context, token string, policy re.OrphanPolicy) (re.OrphanReport, error) {
+	ret := _m.Called(ctx, token, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CollectOrphans")
+	}
+
+	var r0 re.OrphanReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.OrphanPolicy) (re.OrphanReport, error)); ok {
+		return rf(ctx, token, policy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.OrphanPolicy) re.OrphanReport); ok {
+		r0 = rf(ctx, token, policy)
+	} else {
+		r0 = ret.Get(0).(re.OrphanReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.OrphanPolicy) error); ok {
+		r1 = rf(ctx, token, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateChain provides a mock function with given fields: ctx, token, c
+func (_m *Service) CreateChain(ctx context.Context, token string, c re.Chain) (re.Chain, error) {
+	ret := _m.Called(ctx, token, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateChain")
+	}
+
+	var r0 re.Chain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Chain) (re.Chain, error)); ok {
+		return rf(ctx, token, c)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Chain) re.Chain); ok {
+		r0 = rf(ctx, token, c)
+	} else {
+		r0 = ret.Get(0).(re.Chain)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Chain) error); ok {
+		r1 = rf(ctx, token, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFolder provides a mock function with given fields: ctx, token, f
+func (_m *Service) CreateFolder(ctx context.Context, token string, f re.Folder) (re.Folder, error) {
+	ret := _m.Called(ctx, token, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFolder")
+	}
+
+	var r0 re.Folder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Folder) (re.Folder, error)); ok {
+		return rf(ctx, token, f)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Folder) re.Folder); ok {
+		r0 = rf(ctx, token, f)
+	} else {
+		r0 = ret.Get(0).(re.Folder)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Folder) error); ok {
+		r1 = rf(ctx, token, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePlugin provides a mock function with given fields: ctx, token, kind, plugin
+func (_m *Service) CreatePlugin(ctx context.Context, token string, kind string, plugin re.Plugin) (re.Result, error) {
+	ret := _m.Called(ctx, token, kind, plugin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePlugin")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.Plugin) (re.Result, error)); ok {
+		return rf(ctx, token, kind, plugin)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.Plugin) re.Result); ok {
+		r0 = rf(ctx, token, kind, plugin)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, re.Plugin) error); ok {
+		r1 = rf(ctx, token, kind, plugin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRule provides a mock function with given fields: ctx, token, rule
+func (_m *Service) CreateRule(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	ret := _m.Called(ctx, token, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Rule) (re.Result, error)); ok {
+		return rf(ctx, token, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Rule) re.Result); ok {
+		r0 = rf(ctx, token, rule)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Rule) error); ok {
+		r1 = rf(ctx, token, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateStream provides a mock function with given fields: ctx, token, def, update
+func (_m *Service) CreateStream(ctx context.Context, token string, def re.StreamDef, update bool) (re.Result, error) {
+	ret := _m.Called(ctx, token, def, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStream")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.StreamDef, bool) (re.Result, error)); ok {
+		return rf(ctx, token, def, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.StreamDef, bool) re.Result); ok {
+		r0 = rf(ctx, token, def, update)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.StreamDef, bool) error); ok {
+		r1 = rf(ctx, token, def, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTable provides a mock function with given fields: ctx, token, def
+func (_m *Service) CreateTable(ctx context.Context, token string, def re.TableDef) (re.Result, error) {
+	ret := _m.Called(ctx, token, def)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTable")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.TableDef) (re.Result, error)); ok {
+		return rf(ctx, token, def)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.TableDef) re.Result); ok {
+		r0 = rf(ctx, token, def)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.TableDef) error); ok {
+		r1 = rf(ctx, token, def)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTemplate provides a mock function with given fields: ctx, token, tmpl
+func (_m *Service) CreateTemplate(ctx context.Context, token string, tmpl re.Template) (re.Template, error) {
+	ret := _m.Called(ctx, token, tmpl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTemplate")
+	}
+
+	var r0 re.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Template) (re.Template, error)); ok {
+		return rf(ctx, token, tmpl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Template) re.Template); ok {
+		r0 = rf(ctx, token, tmpl)
+	} else {
+		r0 = ret.Get(0).(re.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Template) error); ok {
+		r1 = rf(ctx, token, tmpl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, token, wh
+func (_m *Service) CreateWebhook(ctx context.Context, token string, wh re.Webhook) (re.Webhook, error) {
+	ret := _m.Called(ctx, token, wh)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 re.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Webhook) (re.Webhook, error)); ok {
+		return rf(ctx, token, wh)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Webhook) re.Webhook); ok {
+		r0 = rf(ctx, token, wh)
+	} else {
+		r0 = ret.Get(0).(re.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Webhook) error); ok {
+		r1 = rf(ctx, token, wh)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteConfKey provides a mock function with given fields: ctx, token, name
+func (_m *Service) DeleteConfKey(ctx context.Context, token string, name string) (re.Result, error) {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConfKey")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExternalService provides a mock function with given fields: ctx, token, name
+func (_m *Service) DeleteExternalService(ctx context.Context, token string, name string) (re.Result, error) {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExternalService")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePlugin provides a mock function with given fields: ctx, token, kind, name
+func (_m *Service) DeletePlugin(ctx context.Context, token string, kind string, name string) (re.Result, error) {
+	ret := _m.Called(ctx, token, kind, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePlugin")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, kind, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) re.Result); ok {
+		r0 = rf(ctx, token, kind, name)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, kind, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRule provides a mock function with given fields: ctx, token, id
+func (_m *Service) DeleteRule(ctx context.Context, token string, id string) (re.Result, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteStream provides a mock function with given fields: ctx, token, name, cascade
+func (_m *Service) DeleteStream(ctx context.Context, token string, name string, cascade bool) (re.Result, error) {
+	ret := _m.Called(ctx, token, name, cascade)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStream")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (re.Result, error)); ok {
+		return rf(ctx, token, name, cascade)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) re.Result); ok {
+		r0 = rf(ctx, token, name, cascade)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(ctx, token, name, cascade)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTable provides a mock function with given fields: ctx, token, name
+func (_m *Service) DeleteTable(ctx context.Context, token string, name string) (re.Result, error) {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTable")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeployFleet provides a mock function with given fields: ctx, token, id, labels
+func (_m *Service) DeployFleet(ctx context.Context, token string, id string, labels map[string]string) (re.Rollout, error) {
+	ret := _m.Called(ctx, token, id, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeployFleet")
+	}
+
+	var r0 re.Rollout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, map[string]string) (re.Rollout, error)); ok {
+		return rf(ctx, token, id, labels)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, map[string]string) re.Rollout); ok {
+		r0 = rf(ctx, token, id, labels)
+	} else {
+		r0 = ret.Get(0).(re.Rollout)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, map[string]string) error); ok {
+		r1 = rf(ctx, token, id, labels)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeployRule provides a mock function with given fields: ctx, token, id, gateway
+func (_m *Service) DeployRule(ctx context.Context, token string, id string, gateway string) (re.Deployment, error) {
+	ret := _m.Called(ctx, token, id, gateway)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeployRule")
+	}
+
+	var r0 re.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (re.Deployment, error)); ok {
+		return rf(ctx, token, id, gateway)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) re.Deployment); ok {
+		r0 = rf(ctx, token, id, gateway)
+	} else {
+		r0 = ret.Get(0).(re.Deployment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, id, gateway)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EngineStats provides a mock function with given fields: ctx, token
+func (_m *Service) EngineStats(ctx context.Context, token string) (re.EngineStats, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EngineStats")
+	}
+
+	var r0 re.EngineStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (re.EngineStats, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) re.EngineStats); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(re.EngineStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportRuleset provides a mock function with given fields: ctx, token
+func (_m *Service) ExportRuleset(ctx context.Context, token string) (re.Ruleset, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportRuleset")
+	}
+
+	var r0 re.Ruleset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (re.Ruleset, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) re.Ruleset); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(re.Ruleset)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportRuleset provides a mock function with given fields: ctx, token, rs, conflict
+func (_m *Service) ImportRuleset(ctx context.Context, token string, rs re.Ruleset, conflict string) (re.ImportReport, error) {
+	ret := _m.Called(ctx, token, rs, conflict)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportRuleset")
+	}
+
+	var r0 re.ImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Ruleset, string) (re.ImportReport, error)); ok {
+		return rf(ctx, token, rs, conflict)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Ruleset, string) re.ImportReport); ok {
+		r0 = rf(ctx, token, rs, conflict)
+	} else {
+		r0 = ret.Get(0).(re.ImportReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Ruleset, string) error); ok {
+		r1 = rf(ctx, token, rs, conflict)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Info provides a mock function with given fields: ctx
+func (_m *Service) Info(ctx context.Context) (re.Info, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Info")
+	}
+
+	var r0 re.Info
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (re.Info, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) re.Info); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(re.Info)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ingest provides a mock function with given fields: ctx, key, stream, payload
+func (_m *Service) Ingest(ctx context.Context, key string, stream string, payload []byte) error {
+	ret := _m.Called(ctx, key, stream, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ingest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = rf(ctx, key, stream, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InstantiateTemplate provides a mock function with given fields: ctx, token, name, inst
+func (_m *Service) InstantiateTemplate(ctx context.Context, token string, name string, inst re.TemplateInstance) (re.Rule, error) {
+	ret := _m.Called(ctx, token, name, inst)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InstantiateTemplate")
+	}
+
+	var r0 re.Rule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.TemplateInstance) (re.Rule, error)); ok {
+		return rf(ctx, token, name, inst)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.TemplateInstance) re.Rule); ok {
+		r0 = rf(ctx, token, name, inst)
+	} else {
+		r0 = ret.Get(0).(re.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, re.TemplateInstance) error); ok {
+		r1 = rf(ctx, token, name, inst)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAllRules provides a mock function with given fields: ctx, token
+func (_m *Service) ListAllRules(ctx context.Context, token string) (re.AllRules, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllRules")
+	}
+
+	var r0 re.AllRules
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (re.AllRules, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) re.AllRules); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(re.AllRules)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAllStreams provides a mock function with given fields: ctx, token
+func (_m *Service) ListAllStreams(ctx context.Context, token string) (re.AllStreams, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllStreams")
+	}
+
+	var r0 re.AllStreams
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (re.AllStreams, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) re.AllStreams); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(re.AllStreams)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuditEvents provides a mock function with given fields: ctx, token, q
+func (_m *Service) ListAuditEvents(ctx context.Context, token string, q re.AuditQuery) (re.AuditPage, error) {
+	ret := _m.Called(ctx, token, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEvents")
+	}
+
+	var r0 re.AuditPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.AuditQuery) (re.AuditPage, error)); ok {
+		return rf(ctx, token, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.AuditQuery) re.AuditPage); ok {
+		r0 = rf(ctx, token, q)
+	} else {
+		r0 = ret.Get(0).(re.AuditPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.AuditQuery) error); ok {
+		r1 = rf(ctx, token, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListChains provides a mock function with given fields: ctx, token
+func (_m *Service) ListChains(ctx context.Context, token string) ([]re.Chain, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChains")
+	}
+
+	var r0 []re.Chain
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]re.Chain, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []re.Chain); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.Chain)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListConfKeys provides a mock function with given fields: ctx, token
+func (_m *Service) ListConfKeys(ctx context.Context, token string) ([]string, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListConfKeys")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeployments provides a mock function with given fields: ctx, token, id
+func (_m *Service) ListDeployments(ctx context.Context, token string, id string) ([]re.Deployment, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeployments")
+	}
+
+	var r0 []re.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]re.Deployment, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []re.Deployment); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExternalFunctions provides a mock function with given fields: ctx, token
+func (_m *Service) ListExternalFunctions(ctx context.Context, token string) ([]re.ExternalFunction, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExternalFunctions")
+	}
+
+	var r0 []re.ExternalFunction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]re.ExternalFunction, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []re.ExternalFunction); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.ExternalFunction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExternalServices provides a mock function with given fields: ctx, token
+func (_m *Service) ListExternalServices(ctx context.Context, token string) ([]string, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExternalServices")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFolders provides a mock function with given fields: ctx, token
+func (_m *Service) ListFolders(ctx context.Context, token string) ([]re.Folder, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFolders")
+	}
+
+	var r0 []re.Folder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]re.Folder, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []re.Folder); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.Folder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGateways provides a mock function with given fields: ctx, token
+func (_m *Service) ListGateways(ctx context.Context, token string) ([]re.Gateway, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGateways")
+	}
+
+	var r0 []re.Gateway
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]re.Gateway, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []re.Gateway); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.Gateway)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInstances provides a mock function with given fields: ctx, token
+func (_m *Service) ListInstances(ctx context.Context, token string) ([]re.Instance, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInstances")
+	}
+
+	var r0 []re.Instance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]re.Instance, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []re.Instance); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.Instance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPlugins provides a mock function with given fields: ctx, token, kind
+func (_m *Service) ListPlugins(ctx context.Context, token string, kind string) ([]string, error) {
+	ret := _m.Called(ctx, token, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPlugins")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, token, kind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, token, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRules provides a mock function with given fields: ctx, token, pm
+func (_m *Service) ListRules(ctx context.Context, token string, pm re.PageMetadata) (re.RulesPage, error) {
+	ret := _m.Called(ctx, token, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRules")
+	}
+
+	var r0 re.RulesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.PageMetadata) (re.RulesPage, error)); ok {
+		return rf(ctx, token, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.PageMetadata) re.RulesPage); ok {
+		r0 = rf(ctx, token, pm)
+	} else {
+		r0 = ret.Get(0).(re.RulesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.PageMetadata) error); ok {
+		r1 = rf(ctx, token, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSecrets provides a mock function with given fields: ctx, token
+func (_m *Service) ListSecrets(ctx context.Context, token string) ([]re.Secret, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSecrets")
+	}
+
+	var r0 []re.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]re.Secret, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []re.Secret); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListShares provides a mock function with given fields: ctx, token, kind, name
+func (_m *Service) ListShares(ctx context.Context, token string, kind string, name string) ([]re.Share, error) {
+	ret := _m.Called(ctx, token, kind, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListShares")
+	}
+
+	var r0 []re.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]re.Share, error)); ok {
+		return rf(ctx, token, kind, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []re.Share); ok {
+		r0 = rf(ctx, token, kind, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.Share)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, kind, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStreams provides a mock function with given fields: ctx, token, pm
+func (_m *Service) ListStreams(ctx context.Context, token string, pm re.PageMetadata) (re.StreamsPage, error) {
+	ret := _m.Called(ctx, token, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStreams")
+	}
+
+	var r0 re.StreamsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.PageMetadata) (re.StreamsPage, error)); ok {
+		return rf(ctx, token, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.PageMetadata) re.StreamsPage); ok {
+		r0 = rf(ctx, token, pm)
+	} else {
+		r0 = ret.Get(0).(re.StreamsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.PageMetadata) error); ok {
+		r1 = rf(ctx, token, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTables provides a mock function with given fields: ctx, token, pm
+func (_m *Service) ListTables(ctx context.Context, token string, pm re.PageMetadata) (re.TablesPage, error) {
+	ret := _m.Called(ctx, token, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTables")
+	}
+
+	var r0 re.TablesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.PageMetadata) (re.TablesPage, error)); ok {
+		return rf(ctx, token, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.PageMetadata) re.TablesPage); ok {
+		r0 = rf(ctx, token, pm)
+	} else {
+		r0 = ret.Get(0).(re.TablesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.PageMetadata) error); ok {
+		r1 = rf(ctx, token, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTemplates provides a mock function with given fields: ctx, token
+func (_m *Service) ListTemplates(ctx context.Context, token string) ([]re.Template, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTemplates")
+	}
+
+	var r0 []re.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]re.Template, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []re.Template); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhooks provides a mock function with given fields: ctx, token
+func (_m *Service) ListWebhooks(ctx context.Context, token string) ([]re.Webhook, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhooks")
+	}
+
+	var r0 []re.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]re.Webhook, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []re.Webhook); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveRules provides a mock function with given fields: ctx, token, folder, ids
+func (_m *Service) MoveRules(ctx context.Context, token string, folder string, ids []string) (re.BulkReport, error) {
+	ret := _m.Called(ctx, token, folder, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveRules")
+	}
+
+	var r0 re.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) (re.BulkReport, error)); ok {
+		return rf(ctx, token, folder, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) re.BulkReport); ok {
+		r0 = rf(ctx, token, folder, ids)
+	} else {
+		r0 = ret.Get(0).(re.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string) error); ok {
+		r1 = rf(ctx, token, folder, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchRule provides a mock function with given fields: ctx, token, id, patch
+func (_m *Service) PatchRule(ctx context.Context, token string, id string, patch re.RulePatch) (re.Result, error) {
+	ret := _m.Called(ctx, token, id, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.RulePatch) (re.Result, error)); ok {
+		return rf(ctx, token, id, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.RulePatch) re.Result); ok {
+		r0 = rf(ctx, token, id, patch)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, re.RulePatch) error); ok {
+		r1 = rf(ctx, token, id, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Pause provides a mock function with given fields: ctx, token
+func (_m *Service) Pause(ctx context.Context, token string) (re.BulkReport, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pause")
+	}
+
+	var r0 re.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (re.BulkReport, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) re.BulkReport); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(re.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishRule provides a mock function with given fields: ctx, token, id
+func (_m *Service) PublishRule(ctx context.Context, token string, id string) (re.Result, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PushTail provides a mock function with given fields: ctx, session, result
+func (_m *Service) PushTail(ctx context.Context, session string, result map[string]interface{}) error {
+	ret := _m.Called(ctx, session, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushTail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]interface{}) error); ok {
+		r0 = rf(ctx, session, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reconcile provides a mock function with given fields: ctx, token, repair
+func (_m *Service) Reconcile(ctx context.Context, token string, repair bool) (re.DriftReport, error) {
+	ret := _m.Called(ctx, token, repair)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reconcile")
+	}
+
+	var r0 re.DriftReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (re.DriftReport, error)); ok {
+		return rf(ctx, token, repair)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) re.DriftReport); ok {
+		r0 = rf(ctx, token, repair)
+	} else {
+		r0 = ret.Get(0).(re.DriftReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, token, repair)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegisterExternalService provides a mock function with given fields: ctx, token, es
+func (_m *Service) RegisterExternalService(ctx context.Context, token string, es re.ExternalService) (re.Result, error) {
+	ret := _m.Called(ctx, token, es)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterExternalService")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.ExternalService) (re.Result, error)); ok {
+		return rf(ctx, token, es)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.ExternalService) re.Result); ok {
+		r0 = rf(ctx, token, es)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.ExternalService) error); ok {
+		r1 = rf(ctx, token, es)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveAlertPolicy provides a mock function with given fields: ctx, token
+func (_m *Service) RemoveAlertPolicy(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAlertPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveChain provides a mock function with given fields: ctx, token, name
+func (_m *Service) RemoveChain(ctx context.Context, token string, name string) error {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveChain")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveFolder provides a mock function with given fields: ctx, token, name
+func (_m *Service) RemoveFolder(ctx context.Context, token string, name string) error {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveFolder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveGateway provides a mock function with given fields: ctx, token, id
+func (_m *Service) RemoveGateway(ctx context.Context, token string, id string) error {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveGateway")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveQuota provides a mock function with given fields: ctx, token, userID
+func (_m *Service) RemoveQuota(ctx context.Context, token string, userID string) error {
+	ret := _m.Called(ctx, token, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveQuota")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveSecret provides a mock function with given fields: ctx, token, name
+func (_m *Service) RemoveSecret(ctx context.Context, token string, name string) error {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveTemplate provides a mock function with given fields: ctx, token, name
+func (_m *Service) RemoveTemplate(ctx context.Context, token string, name string) error {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveWebhook provides a mock function with given fields: ctx, token, id
+func (_m *Service) RemoveWebhook(ctx context.Context, token string, id string) error {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Rename provides a mock function with given fields: ctx, token, kind, name, newName
+func (_m *Service) Rename(ctx context.Context, token string, kind string, name string, newName string) (re.Result, error) {
+	ret := _m.Called(ctx, token, kind, name, newName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, kind, name, newName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) re.Result); ok {
+		r0 = rf(ctx, token, kind, name, newName)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, token, kind, name, newName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplayRule provides a mock function with given fields: ctx, token, id, from, to
+func (_m *Service) ReplayRule(ctx context.Context, token string, id string, from time.Time, to time.Time) (re.ReplayResult, error) {
+	ret := _m.Called(ctx, token, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayRule")
+	}
+
+	var r0 re.ReplayResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (re.ReplayResult, error)); ok {
+		return rf(ctx, token, id, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) re.ReplayResult); ok {
+		r0 = rf(ctx, token, id, from, to)
+	} else {
+		r0 = ret.Get(0).(re.ReplayResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, token, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestartRule provides a mock function with given fields: ctx, token, id
+func (_m *Service) RestartRule(ctx context.Context, token string, id string) (re.Result, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestartRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, token, dryRun
+func (_m *Service) Restore(ctx context.Context, token string, dryRun bool) (re.RestoreReport, error) {
+	ret := _m.Called(ctx, token, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 re.RestoreReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (re.RestoreReport, error)); ok {
+		return rf(ctx, token, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) re.RestoreReport); ok {
+		r0 = rf(ctx, token, dryRun)
+	} else {
+		r0 = ret.Get(0).(re.RestoreReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, token, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRule provides a mock function with given fields: ctx, token, id
+func (_m *Service) RestoreRule(ctx context.Context, token string, id string) (re.Result, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resume provides a mock function with given fields: ctx, token
+func (_m *Service) Resume(ctx context.Context, token string) (re.BulkReport, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 re.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (re.BulkReport, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) re.BulkReport); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(re.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryDeployments provides a mock function with given fields: ctx, token, id
+func (_m *Service) RetryDeployments(ctx context.Context, token string, id string) (re.Rollout, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryDeployments")
+	}
+
+	var r0 re.Rollout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Rollout, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Rollout); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Rollout)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RuleLogs provides a mock function with given fields: ctx, token, id, since
+func (_m *Service) RuleLogs(ctx context.Context, token string, id string, since time.Time) ([]re.LogEntry, error) {
+	ret := _m.Called(ctx, token, id, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleLogs")
+	}
+
+	var r0 []re.LogEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) ([]re.LogEntry, error)); ok {
+		return rf(ctx, token, id, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) []re.LogEntry); ok {
+		r0 = rf(ctx, token, id, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.LogEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, token, id, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RuleStatus provides a mock function with given fields: ctx, token, id
+func (_m *Service) RuleStatus(ctx context.Context, token string, id string) (re.RuleStatus, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleStatus")
+	}
+
+	var r0 re.RuleStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.RuleStatus, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.RuleStatus); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.RuleStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RuleTopology provides a mock function with given fields: ctx, token, id
+func (_m *Service) RuleTopology(ctx context.Context, token string, id string) (re.RuleTopology, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleTopology")
+	}
+
+	var r0 re.RuleTopology
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.RuleTopology, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.RuleTopology); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.RuleTopology)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveConfKey provides a mock function with given fields: ctx, token, name, conf
+func (_m *Service) SaveConfKey(ctx context.Context, token string, name string, conf re.MQTTConf) (re.Result, error) {
+	ret := _m.Called(ctx, token, name, conf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveConfKey")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.MQTTConf) (re.Result, error)); ok {
+		return rf(ctx, token, name, conf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.MQTTConf) re.Result); ok {
+		r0 = rf(ctx, token, name, conf)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, re.MQTTConf) error); ok {
+		r1 = rf(ctx, token, name, conf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveDraft provides a mock function with given fields: ctx, token, rule
+func (_m *Service) SaveDraft(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	ret := _m.Called(ctx, token, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDraft")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Rule) (re.Result, error)); ok {
+		return rf(ctx, token, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Rule) re.Result); ok {
+		r0 = rf(ctx, token, rule)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Rule) error); ok {
+		r1 = rf(ctx, token, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveGateway provides a mock function with given fields: ctx, token, gw
+func (_m *Service) SaveGateway(ctx context.Context, token string, gw re.Gateway) (re.Gateway, error) {
+	ret := _m.Called(ctx, token, gw)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveGateway")
+	}
+
+	var r0 re.Gateway
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Gateway) (re.Gateway, error)); ok {
+		return rf(ctx, token, gw)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Gateway) re.Gateway); ok {
+		r0 = rf(ctx, token, gw)
+	} else {
+		r0 = ret.Get(0).(re.Gateway)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Gateway) error); ok {
+		r1 = rf(ctx, token, gw)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveSecret provides a mock function with given fields: ctx, token, s
+func (_m *Service) SaveSecret(ctx context.Context, token string, s re.Secret) (re.Secret, error) {
+	ret := _m.Called(ctx, token, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSecret")
+	}
+
+	var r0 re.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Secret) (re.Secret, error)); ok {
+		return rf(ctx, token, s)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Secret) re.Secret); ok {
+		r0 = rf(ctx, token, s)
+	} else {
+		r0 = ret.Get(0).(re.Secret)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Secret) error); ok {
+		r1 = rf(ctx, token, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchRules provides a mock function with given fields: ctx, token, q, pm
+func (_m *Service) SearchRules(ctx context.Context, token string, q re.RuleQuery, pm re.PageMetadata) (re.RulesPage, error) {
+	ret := _m.Called(ctx, token, q, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchRules")
+	}
+
+	var r0 re.RulesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.RuleQuery, re.PageMetadata) (re.RulesPage, error)); ok {
+		return rf(ctx, token, q, pm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.RuleQuery, re.PageMetadata) re.RulesPage); ok {
+		r0 = rf(ctx, token, q, pm)
+	} else {
+		r0 = ret.Get(0).(re.RulesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.RuleQuery, re.PageMetadata) error); ok {
+		r1 = rf(ctx, token, q, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAlertPolicy provides a mock function with given fields: ctx, token, p
+func (_m *Service) SetAlertPolicy(ctx context.Context, token string, p re.AlertPolicy) (re.AlertPolicy, error) {
+	ret := _m.Called(ctx, token, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAlertPolicy")
+	}
+
+	var r0 re.AlertPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.AlertPolicy) (re.AlertPolicy, error)); ok {
+		return rf(ctx, token, p)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.AlertPolicy) re.AlertPolicy); ok {
+		r0 = rf(ctx, token, p)
+	} else {
+		r0 = ret.Get(0).(re.AlertPolicy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.AlertPolicy) error); ok {
+		r1 = rf(ctx, token, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetQuota provides a mock function with given fields: ctx, token, userID, q
+func (_m *Service) SetQuota(ctx context.Context, token string, userID string, q re.Quota) (re.UserQuota, error) {
+	ret := _m.Called(ctx, token, userID, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetQuota")
+	}
+
+	var r0 re.UserQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.Quota) (re.UserQuota, error)); ok {
+		return rf(ctx, token, userID, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, re.Quota) re.UserQuota); ok {
+		r0 = rf(ctx, token, userID, q)
+	} else {
+		r0 = ret.Get(0).(re.UserQuota)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, re.Quota) error); ok {
+		r1 = rf(ctx, token, userID, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShareEntity provides a mock function with given fields: ctx, token, kind, name, s
+func (_m *Service) ShareEntity(ctx context.Context, token string, kind string, name string, s re.Share) (re.Share, error) {
+	ret := _m.Called(ctx, token, kind, name, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShareEntity")
+	}
+
+	var r0 re.Share
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, re.Share) (re.Share, error)); ok {
+		return rf(ctx, token, kind, name, s)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, re.Share) re.Share); ok {
+		r0 = rf(ctx, token, kind, name, s)
+	} else {
+		r0 = ret.Get(0).(re.Share)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, re.Share) error); ok {
+		r1 = rf(ctx, token, kind, name, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartRule provides a mock function with given fields: ctx, token, id
+func (_m *Service) StartRule(ctx context.Context, token string, id string) (re.Result, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartRules provides a mock function with given fields: ctx, token, sel
+func (_m *Service) StartRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	ret := _m.Called(ctx, token, sel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartRules")
+	}
+
+	var r0 re.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.RuleSelection) (re.BulkReport, error)); ok {
+		return rf(ctx, token, sel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.RuleSelection) re.BulkReport); ok {
+		r0 = rf(ctx, token, sel)
+	} else {
+		r0 = ret.Get(0).(re.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.RuleSelection) error); ok {
+		r1 = rf(ctx, token, sel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StopRule provides a mock function with given fields: ctx, token, id
+func (_m *Service) StopRule(ctx context.Context, token string, id string) (re.Result, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StopRules provides a mock function with given fields: ctx, token, sel
+func (_m *Service) StopRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	ret := _m.Called(ctx, token, sel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopRules")
+	}
+
+	var r0 re.BulkReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.RuleSelection) (re.BulkReport, error)); ok {
+		return rf(ctx, token, sel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.RuleSelection) re.BulkReport); ok {
+		r0 = rf(ctx, token, sel)
+	} else {
+		r0 = ret.Get(0).(re.BulkReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.RuleSelection) error); ok {
+		r1 = rf(ctx, token, sel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TailRule provides a mock function with given fields: ctx, token, id
+func (_m *Service) TailRule(ctx context.Context, token string, id string) (<-chan map[string]interface{}, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TailRule")
+	}
+
+	var r0 <-chan map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (<-chan map[string]interface{}, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan map[string]interface{}); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TestRule provides a mock function with given fields: ctx, token, trial
+func (_m *Service) TestRule(ctx context.Context, token string, trial re.RuleTrial) (re.TrialResult, error) {
+	ret := _m.Called(ctx, token, trial)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestRule")
+	}
+
+	var r0 re.TrialResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.RuleTrial) (re.TrialResult, error)); ok {
+		return rf(ctx, token, trial)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.RuleTrial) re.TrialResult); ok {
+		r0 = rf(ctx, token, trial)
+	} else {
+		r0 = ret.Get(0).(re.TrialResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.RuleTrial) error); ok {
+		r1 = rf(ctx, token, trial)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UndeployRule provides a mock function with given fields: ctx, token, id, gateway
+func (_m *Service) UndeployRule(ctx context.Context, token string, id string, gateway string) (re.Deployment, error) {
+	ret := _m.Called(ctx, token, id, gateway)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndeployRule")
+	}
+
+	var r0 re.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (re.Deployment, error)); ok {
+		return rf(ctx, token, id, gateway)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) re.Deployment); ok {
+		r0 = rf(ctx, token, id, gateway)
+	} else {
+		r0 = ret.Get(0).(re.Deployment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, token, id, gateway)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnpublishRule provides a mock function with given fields: ctx, token, id
+func (_m *Service) UnpublishRule(ctx context.Context, token string, id string) (re.Result, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpublishRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Result, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Result); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnshareEntity provides a mock function with given fields: ctx, token, kind, name, grantee
+func (_m *Service) UnshareEntity(ctx context.Context, token string, kind string, name string, grantee string) error {
+	ret := _m.Called(ctx, token, kind, name, grantee)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnshareEntity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, token, kind, name, grantee)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRule provides a mock function with given fields: ctx, token, rule
+func (_m *Service) UpdateRule(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	ret := _m.Called(ctx, token, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRule")
+	}
+
+	var r0 re.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Rule) (re.Result, error)); ok {
+		return rf(ctx, token, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Rule) re.Result); ok {
+		r0 = rf(ctx, token, rule)
+	} else {
+		r0 = ret.Get(0).(re.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Rule) error); ok {
+		r1 = rf(ctx, token, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateRule provides a mock function with given fields: ctx, token, rule
+func (_m *Service) ValidateRule(ctx context.Context, token string, rule re.Rule) (re.RuleValidation, error) {
+	ret := _m.Called(ctx, token, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateRule")
+	}
+
+	var r0 re.RuleValidation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Rule) (re.RuleValidation, error)); ok {
+		return rf(ctx, token, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, re.Rule) re.RuleValidation); ok {
+		r0 = rf(ctx, token, rule)
+	} else {
+		r0 = ret.Get(0).(re.RuleValidation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, re.Rule) error); ok {
+		r1 = rf(ctx, token, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewAlertPolicy provides a mock function with given fields: ctx, token
+func (_m *Service) ViewAlertPolicy(ctx context.Context, token string) (re.AlertPolicy, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewAlertPolicy")
+	}
+
+	var r0 re.AlertPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (re.AlertPolicy, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) re.AlertPolicy); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(re.AlertPolicy)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewMaintenance provides a mock function with given fields: ctx, token
+func (_m *Service) ViewMaintenance(ctx context.Context, token string) (re.Maintenance, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewMaintenance")
+	}
+
+	var r0 re.Maintenance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (re.Maintenance, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) re.Maintenance); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(re.Maintenance)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewQuota provides a mock function with given fields: ctx, token, userID
+func (_m *Service) ViewQuota(ctx context.Context, token string, userID string) (re.UserQuota, error) {
+	ret := _m.Called(ctx, token, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewQuota")
+	}
+
+	var r0 re.UserQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.UserQuota, error)); ok {
+		return rf(ctx, token, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.UserQuota); ok {
+		r0 = rf(ctx, token, userID)
+	} else {
+		r0 = ret.Get(0).(re.UserQuota)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewRollout provides a mock function with given fields: ctx, token, id
+func (_m *Service) ViewRollout(ctx context.Context, token string, id string) (re.Rollout, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRollout")
+	}
+
+	var r0 re.Rollout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Rollout, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Rollout); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Rollout)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewRule provides a mock function with given fields: ctx, token, id
+func (_m *Service) ViewRule(ctx context.Context, token string, id string) (re.Rule, error) {
+	ret := _m.Called(ctx, token, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRule")
+	}
+
+	var r0 re.Rule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Rule, error)); ok {
+		return rf(ctx, token, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Rule); ok {
+		r0 = rf(ctx, token, id)
+	} else {
+		r0 = ret.Get(0).(re.Rule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewRules provides a mock function with given fields: ctx, token, ids
+func (_m *Service) ViewRules(ctx context.Context, token string, ids []string) (re.RulesBatch, error) {
+	ret := _m.Called(ctx, token, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRules")
+	}
+
+	var r0 re.RulesBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (re.RulesBatch, error)); ok {
+		return rf(ctx, token, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) re.RulesBatch); ok {
+		r0 = rf(ctx, token, ids)
+	} else {
+		r0 = ret.Get(0).(re.RulesBatch)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, token, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewStream provides a mock function with given fields: ctx, token, name
+func (_m *Service) ViewStream(ctx context.Context, token string, name string) (re.Stream, error) {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewStream")
+	}
+
+	var r0 re.Stream
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Stream, error)); ok {
+		return rf(ctx, token, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Stream); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Get(0).(re.Stream)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewStreams provides a mock function with given fields: ctx, token, names
+func (_m *Service) ViewStreams(ctx context.Context, token string, names []string) (re.StreamsBatch, error) {
+	ret := _m.Called(ctx, token, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewStreams")
+	}
+
+	var r0 re.StreamsBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (re.StreamsBatch, error)); ok {
+		return rf(ctx, token, names)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) re.StreamsBatch); ok {
+		r0 = rf(ctx, token, names)
+	} else {
+		r0 = ret.Get(0).(re.StreamsBatch)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, token, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewTable provides a mock function with given fields: ctx, token, name
+func (_m *Service) ViewTable(ctx context.Context, token string, name string) (re.Table, error) {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewTable")
+	}
+
+	var r0 re.Table
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Table, error)); ok {
+		return rf(ctx, token, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Table); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Get(0).(re.Table)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ViewTemplate provides a mock function with given fields: ctx, token, name
+func (_m *Service) ViewTemplate(ctx context.Context, token string, name string) (re.Template, error) {
+	ret := _m.Called(ctx, token, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewTemplate")
+	}
+
+	var r0 re.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (re.Template, error)); ok {
+		return rf(ctx, token, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) re.Template); ok {
+		r0 = rf(ctx, token, name)
+	} else {
+		r0 = ret.Get(0).(re.Template)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WatchRules provides a mock function with given fields: ctx, token
+func (_m *Service) WatchRules(ctx context.Context, token string) (<-chan re.RuleStateChange, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchRules")
+	}
+
+	var r0 <-chan re.RuleStateChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan re.RuleStateChange, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan re.RuleStateChange); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan re.RuleStateChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
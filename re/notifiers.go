@@ -0,0 +1,131 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+)
+
+// Notification sink types.
+const (
+	EmailSinkType = "email"
+	SMSSinkType   = "sms"
+)
+
+// notificationsSubtopic is the subtopic prefix of the messages published by
+// notification actions.
+const notificationsSubtopic = "notifications"
+
+// maxContacts limits the number of contacts of the notification action.
+const maxContacts = 20
+
+// ErrNotifier indicates failure to manage subscriptions of the notifier.
+var ErrNotifier = errors.New("failed to manage notifier subscriptions")
+
+var (
+	errMissingChannel   = errors.New("missing channel")
+	errMissingContacts  = errors.New("contacts must contain between 1 and 20 contacts")
+	errEmail            = errors.New("malformed email address")
+	errPhone            = errors.New("phone number must be in E.164 format")
+	errNotifierDisabled = errors.New("notifier is not configured")
+
+	phoneRegexp = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+)
+
+// Notifiers contains SDKs connected to the SMTP and SMPP notifier services,
+// which send email and SMS notifications to the contacts subscribed to the
+// channel topics. Actions of the notifier that is nil are rejected.
+type Notifiers struct {
+	Email mgsdk.SDK
+	SMS   mgsdk.SDK
+}
+
+// NotificationSink sends rule results to the contacts through the notifier.
+// Results are published to the channel, on the subtopic reserved for the
+// action, and the contacts are subscribed to it.
+type NotificationSink struct {
+	Channel  string   `json:"channel"`
+	Contacts []string `json:"contacts"`
+}
+
+func (n Notifiers) notifier(typ string) mgsdk.SDK {
+	switch typ {
+	case EmailSinkType:
+		return n.Email
+	case SMSSinkType:
+		return n.SMS
+	default:
+		return nil
+	}
+}
+
+// notification returns the type and the sink of the notification action.
+func (a Action) notification() (string, *NotificationSink) {
+	switch {
+	case a.Email != nil:
+		return EmailSinkType, a.Email
+	case a.SMS != nil:
+		return SMSSinkType, a.SMS
+	default:
+		return "", nil
+	}
+}
+
+func (s *NotificationSink) validate(typ string) error {
+	if s.Channel == "" {
+		return errMissingChannel
+	}
+	if len(s.Contacts) == 0 || len(s.Contacts) > maxContacts {
+		return errMissingContacts
+	}
+	for _, c := range s.Contacts {
+		switch typ {
+		case EmailSinkType:
+			if addr, err := mail.ParseAddress(c); err != nil || addr.Address != c {
+				return errors.Wrap(errEmail, errors.New(c))
+			}
+		case SMSSinkType:
+			if !phoneRegexp.MatchString(c) {
+				return errors.Wrap(errPhone, errors.New(c))
+			}
+		}
+	}
+
+	return nil
+}
+
+// notificationSubtopic returns the subtopic of the i-th rule action.
+func notificationSubtopic(typ, ruleID string, i int) string {
+	return fmt.Sprintf("%s.%s.%s.%d", notificationsSubtopic, typ, ruleID, i)
+}
+
+// parseNotificationSubtopic returns the notification type of the rule
+// action publishing to the given subtopic. If the subtopic isn't the
+// notification subtopic of the rule action, empty string is returned.
+func parseNotificationSubtopic(subtopic, ruleID string) string {
+	parts := strings.Split(subtopic, ".")
+	if len(parts) != 4 || parts[0] != notificationsSubtopic || parts[2] != ruleID {
+		return ""
+	}
+	if _, err := strconv.Atoi(parts[3]); err != nil {
+		return ""
+	}
+	if parts[1] != EmailSinkType && parts[1] != SMSSinkType {
+		return ""
+	}
+
+	return parts[1]
+}
+
+// notificationTopic returns the notifier subscription topic of the action.
+func notificationTopic(channel, subtopic string) string {
+	return channel + "." + subtopic
+}
@@ -0,0 +1,291 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala"
+	mgauth "github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Reasons the entity is orphaned.
+const (
+	// OrphanUnused is the stream no rule reads from.
+	OrphanUnused = "unused"
+	// OrphanMissingStream is the rule reading from a stream or table that
+	// doesn't exist.
+	OrphanMissingStream = "missing_stream"
+	// OrphanMissingChannel is the rule publishing to a removed channel.
+	OrphanMissingChannel = "missing_channel"
+)
+
+// Orphan is the stream or rule that can't do any useful work. Name is the
+// Kuiper name of the entity and Reason why it's orphaned. Removed reports
+// whether the orphan was removed and Error why the removal failed.
+type Orphan struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Owner   string `json:"owner,omitempty"`
+	Reason  string `json:"reason"`
+	Removed bool   `json:"removed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// OrphanReport contains the orphans found at the given time.
+type OrphanReport struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Orphans   []Orphan  `json:"orphans"`
+}
+
+// OrphanPolicy tells what to do with the orphans. Orphans are removed only
+// if Remove is set, otherwise they are only reported. Entities created or
+// updated less than MinAge ago are never orphans, so the streams created for
+// the rules yet to be written are kept.
+type OrphanPolicy struct {
+	Remove bool          `json:"remove"`
+	MinAge time.Duration `json:"min_age"`
+}
+
+// Collector finds the orphaned entities of all the users, so it's used by
+// the background garbage collection and never exposed over the API.
+type Collector interface {
+	// CollectOrphans returns the orphans and removes them if the policy
+	// says so.
+	CollectOrphans(ctx context.Context, policy OrphanPolicy) (OrphanReport, error)
+}
+
+type collector struct {
+	svc *reService
+}
+
+// NewCollector instantiates the collector running the streams and rules in
+// the given engine. The auth service tells whether the channels still exist.
+func NewCollector(engine RuleEngine, cfg Config, auth magistrala.AuthServiceClient, repo Repository) Collector {
+	return &collector{svc: newService(engine, cfg, auth, nil, Notifiers{}, nil, repo)}
+}
+
+func (c *collector) CollectOrphans(ctx context.Context, policy OrphanPolicy) (OrphanReport, error) {
+	return c.svc.collectOrphans(ctx, policy)
+}
+
+func (svc *reService) CollectOrphans(ctx context.Context, token string, policy OrphanPolicy) (OrphanReport, error) {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return OrphanReport{}, err
+	}
+
+	return svc.collectOrphans(ctx, policy)
+}
+
+func (svc *reService) collectOrphans(ctx context.Context, policy OrphanPolicy) (OrphanReport, error) {
+	report := OrphanReport{CheckedAt: time.Now().UTC(), Orphans: []Orphan{}}
+	streams, err := svc.kuiperNames(ctx, StreamKind)
+	if err != nil {
+		return OrphanReport{}, err
+	}
+	tables, err := svc.kuiperNames(ctx, TableKind)
+	if err != nil {
+		return OrphanReport{}, err
+	}
+	sources := make(map[string]bool, len(streams)+len(tables))
+	for _, name := range append(streams, tables...) {
+		sources[name] = true
+	}
+
+	// Streams the orphaned rules read from aren't in use, since the
+	// orphans are removed, or are about to be.
+	rules, used, err := svc.orphanRules(ctx, sources, report.CheckedAt.Add(-policy.MinAge))
+	if err != nil {
+		return OrphanReport{}, err
+	}
+	report.Orphans = append(report.Orphans, rules...)
+	unused, err := svc.unusedStreams(ctx, streams, used, report.CheckedAt.Add(-policy.MinAge))
+	if err != nil {
+		return OrphanReport{}, err
+	}
+	report.Orphans = append(report.Orphans, unused...)
+
+	// Rules are removed first, since Kuiper refuses to drop the streams
+	// rules read from.
+	if policy.Remove {
+		for i, o := range report.Orphans {
+			err := svc.remove(ctx, o.Kind, o.Name)
+			if err == nil {
+				err = svc.removeShares(ctx, o.Kind, o.Name)
+			}
+			report.Orphans[i].Removed, report.Orphans[i].Error = repaired(err)
+		}
+	}
+	sort.SliceStable(report.Orphans, func(i, j int) bool {
+		if report.Orphans[i].Kind != report.Orphans[j].Kind {
+			return report.Orphans[i].Kind < report.Orphans[j].Kind
+		}
+		return report.Orphans[i].Name < report.Orphans[j].Name
+	})
+
+	return report, nil
+}
+
+// orphanRules returns the deployed rules reading from the streams and tables
+// that aren't among the sources or publishing to the removed channels, along
+// with the Kuiper names of the streams the rest of the rules, drafts
+// included, read from. Rules changed after the given time aren't orphans.
+func (svc *reService) orphanRules(ctx context.Context, sources map[string]bool, before time.Time) ([]Orphan, map[string]bool, error) {
+	infos, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	mds, err := svc.repo.RetrieveAll(ctx, RuleKind, "")
+	if err != nil {
+		return nil, nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	var orphans []Orphan
+	used := make(map[string]bool)
+	channels := make(map[string]bool)
+	for _, info := range infos {
+		owner, ok := nameOwner(info.ID)
+		if !ok {
+			continue
+		}
+		kr, err := svc.engine.ViewRule(ctx, info.ID)
+		switch {
+		case errors.Contains(err, svcerr.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, nil, err
+		}
+		reads := readStreams(kr.SQL, "")
+		reason, err := svc.orphanReason(ctx, kr, reads, sources, channels)
+		if err != nil {
+			return nil, nil, err
+		}
+		md, ok := mds[info.ID]
+		if reason == "" || ok && changedAfter(md, before) {
+			for _, name := range reads {
+				used[name] = true
+			}
+			continue
+		}
+		orphans = append(orphans, Orphan{Kind: RuleKind, Name: info.ID, Owner: owner, Reason: reason})
+	}
+	for _, md := range mds {
+		if !md.Draft {
+			continue
+		}
+		var rule Rule
+		if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+			continue
+		}
+		for _, name := range readStreams(rule.SQL, prefix(md.Owner)) {
+			used[name] = true
+		}
+	}
+
+	return orphans, used, nil
+}
+
+// orphanReason returns why the Kuiper rule reading from the given streams is
+// orphaned, or an empty string if it isn't. Channels caches whether the
+// channels exist.
+func (svc *reService) orphanReason(ctx context.Context, kr EngineRule, reads []string, sources, channels map[string]bool) (string, error) {
+	for _, name := range reads {
+		if !sources[name] {
+			return OrphanMissingStream, nil
+		}
+	}
+	for _, data := range kr.Actions {
+		var a Action
+		if err := json.Unmarshal(data, &a); err != nil {
+			continue
+		}
+		channel := ""
+		switch _, n := a.notification(); {
+		case a.Mainflux != nil:
+			channel = a.Mainflux.Channel
+		case n != nil:
+			channel = n.Channel
+		}
+		if channel == "" {
+			continue
+		}
+		exists, ok := channels[channel]
+		if !ok {
+			var err error
+			if exists, err = svc.channelExists(ctx, channel); err != nil {
+				return "", err
+			}
+			channels[channel] = exists
+		}
+		if !exists {
+			return OrphanMissingChannel, nil
+		}
+	}
+
+	return "", nil
+}
+
+// channelExists reports whether the channel with the given ID exists. Since
+// every channel has administrators, channels without any are removed.
+func (svc *reService) channelExists(ctx context.Context, id string) (bool, error) {
+	res, err := svc.auth.ListAllSubjects(ctx, &magistrala.ListSubjectsReq{
+		SubjectType: mgauth.UserType,
+		Permission:  mgauth.AdministratorRelation,
+		Object:      id,
+		ObjectType:  mgauth.GroupType,
+	})
+	if err != nil {
+		return false, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	return len(res.GetPolicies()) > 0, nil
+}
+
+// unusedStreams returns the streams with the given Kuiper names no rule reads
+// from. The streams created for the channels and the streams changed after
+// the given time aren't orphans.
+func (svc *reService) unusedStreams(ctx context.Context, streams []string, used map[string]bool, before time.Time) ([]Orphan, error) {
+	mds, err := svc.repo.RetrieveAll(ctx, StreamKind, "")
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	var orphans []Orphan
+	for _, name := range streams {
+		owner, ok := nameOwner(name)
+		if !ok || used[name] || strings.HasPrefix(strings.TrimPrefix(name, prefix(owner)), channelStreamPrefix) {
+			continue
+		}
+		if md, ok := mds[name]; ok && changedAfter(md, before) {
+			continue
+		}
+		orphans = append(orphans, Orphan{Kind: StreamKind, Name: name, Owner: owner, Reason: OrphanUnused})
+	}
+
+	return orphans, nil
+}
+
+// readStreams returns the names of the streams the SQL reads from, prefixed
+// with the given prefix. SQL that can't be parsed reads from no streams.
+func readStreams(sql, pfx string) []string {
+	var names []string
+	_, _ = rewriteStreams(sql, func(name string) string {
+		names = append(names, pfx+name)
+		return name
+	})
+
+	return names
+}
+
+// changedAfter reports whether the entity was created or updated after the
+// given time.
+func changedAfter(md Metadata, t time.Time) bool {
+	return md.CreatedAt.After(t) || md.UpdatedAt.After(t)
+}
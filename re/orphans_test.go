@@ -0,0 +1,179 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const removedChannelID = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+
+// seedOrphans adds the orphans and the entities that look like orphans, but
+// aren't, to the fake Kuiper and the repository.
+func seedOrphans(t *testing.T, k *kuiper, repo re.Repository) {
+	for _, name := range []string{"lonely", "fresh", "feed", "drafted", re.ChannelStream(channelID)} {
+		k.streams[userPrefix+name] = ""
+	}
+	k.rules[userPrefix+"broken"] = re.Rule{
+		ID:      userPrefix + "broken",
+		SQL:     "SELECT * FROM " + userPrefix + "missing",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+	}
+	k.rules[userPrefix+"forward"] = re.Rule{
+		ID:      userPrefix + "forward",
+		SQL:     "SELECT * FROM " + userPrefix + "feed",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: removedChannelID}}},
+	}
+
+	old := time.Now().UTC().Add(-48 * time.Hour)
+	err := repo.Save(context.Background(), re.StreamKind, userPrefix+"lonely", re.Metadata{Owner: userID, CreatedAt: old})
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	err = repo.Save(context.Background(), re.StreamKind, userPrefix+"fresh", re.Metadata{Owner: userID, CreatedAt: time.Now().UTC()})
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	definition, err := json.Marshal(re.Rule{SQL: "SELECT * FROM drafted", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}})
+	assert.Nil(t, err, fmt.Sprintf("marshal draft: expected no error got %s\n", err))
+	err = repo.Save(context.Background(), re.RuleKind, userPrefix+"draft", re.Metadata{Owner: userID, CreatedAt: old, Draft: true, Definition: string(definition)})
+	assert.Nil(t, err, fmt.Sprintf("save draft: expected no error got %s\n", err))
+}
+
+// listAdmins mocks the lookup of the administrators of the existing and the
+// removed channel.
+func listAdmins(auth *authmocks.AuthClient) (*mock.Call, *mock.Call) {
+	call := auth.On("ListAllSubjects", mock.Anything, &magistrala.ListSubjectsReq{
+		SubjectType: "user",
+		Permission:  "administrator",
+		Object:      channelID,
+		ObjectType:  "group",
+	}).Return(&magistrala.ListSubjectsRes{Policies: []string{userID}}, nil)
+	call1 := auth.On("ListAllSubjects", mock.Anything, &magistrala.ListSubjectsReq{
+		SubjectType: "user",
+		Permission:  "administrator",
+		Object:      removedChannelID,
+		ObjectType:  "group",
+	}).Return(&magistrala.ListSubjectsRes{}, nil)
+
+	return call, call1
+}
+
+func TestCollectOrphans(t *testing.T) {
+	orphans := []re.Orphan{
+		{Kind: re.RuleKind, Name: userPrefix + "broken", Owner: userID, Reason: re.OrphanMissingStream},
+		{Kind: re.RuleKind, Name: userPrefix + "forward", Owner: userID, Reason: re.OrphanMissingChannel},
+		{Kind: re.StreamKind, Name: otherPrefix + "stream", Owner: otherUserID, Reason: re.OrphanUnused},
+		{Kind: re.StreamKind, Name: userPrefix + "feed", Owner: userID, Reason: re.OrphanUnused},
+		{Kind: re.StreamKind, Name: userPrefix + "lonely", Owner: userID, Reason: re.OrphanUnused},
+	}
+	removed := make([]re.Orphan, len(orphans))
+	for i, o := range orphans {
+		o.Removed = true
+		removed[i] = o
+	}
+	withFresh := append([]re.Orphan{}, orphans[:4]...)
+	withFresh = append(withFresh, re.Orphan{Kind: re.StreamKind, Name: userPrefix + "fresh", Owner: userID, Reason: re.OrphanUnused}, orphans[4])
+
+	cases := []struct {
+		desc       string
+		token      string
+		authorized bool
+		policy     re.OrphanPolicy
+		orphans    []re.Orphan
+		err        error
+	}{
+		{
+			desc:       "view orphans",
+			token:      validToken,
+			authorized: true,
+			policy:     re.OrphanPolicy{MinAge: time.Hour},
+			orphans:    orphans,
+		},
+		{
+			desc:       "view orphans without minimum age",
+			token:      validToken,
+			authorized: true,
+			orphans:    withFresh,
+		},
+		{
+			desc:       "remove orphans",
+			token:      validToken,
+			authorized: true,
+			policy:     re.OrphanPolicy{Remove: true, MinAge: time.Hour},
+			orphans:    removed,
+		},
+		{
+			desc:  "view orphans with invalid token",
+			token: invalidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "view orphans as non-admin user",
+			token: validToken,
+			err:   svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		repo := mocks.NewRepository()
+		svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+		seedOrphans(t, k, repo)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+		authCall2 := authorizeAdmin(auth, tc.authorized)
+		adminsCall, adminsCall1 := listAdmins(auth)
+
+		report, err := svc.CollectOrphans(context.Background(), tc.token, tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.orphans, report.Orphans, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.orphans, report.Orphans))
+		for _, o := range tc.orphans {
+			_, ok := k.streams[o.Name]
+			if o.Kind == re.RuleKind {
+				_, ok = k.rules[o.Name]
+			}
+			assert.Equal(t, !o.Removed, ok, fmt.Sprintf("%s: expected %s %s kept %t got %t\n", tc.desc, o.Kind, o.Name, !o.Removed, ok))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+		adminsCall.Unset()
+		adminsCall1.Unset()
+	}
+}
+
+func TestCollector(t *testing.T) {
+	k, url := newKuiper(t)
+	repo := mocks.NewRepository()
+	seedOrphans(t, k, repo)
+	auth := new(authmocks.AuthClient)
+	adminsCall, adminsCall1 := listAdmins(auth)
+	defer adminsCall.Unset()
+	defer adminsCall1.Unset()
+	cfg := re.Config{URL: url}
+	c := re.NewCollector(re.NewKuiper(cfg), cfg, auth, repo)
+
+	report, err := c.CollectOrphans(context.Background(), re.OrphanPolicy{Remove: true, MinAge: time.Hour})
+	assert.Nil(t, err, fmt.Sprintf("remove orphans: expected no error got %s\n", err))
+	assert.Len(t, report.Orphans, 5, fmt.Sprintf("remove orphans: expected 5 orphans got %d\n", len(report.Orphans)))
+	_, err = repo.Retrieve(context.Background(), re.StreamKind, userPrefix+"lonely")
+	assert.NotNil(t, err, "remove orphans: expected metadata of removed stream to be removed")
+
+	report, err = c.CollectOrphans(context.Background(), re.OrphanPolicy{MinAge: time.Hour})
+	assert.Nil(t, err, fmt.Sprintf("view orphans: expected no error got %s\n", err))
+	assert.Empty(t, report.Orphans, fmt.Sprintf("view orphans: expected no orphans got %v\n", report.Orphans))
+	for _, name := range []string{"stream", "fresh", "drafted", re.ChannelStream(channelID)} {
+		_, ok := k.streams[userPrefix+name]
+		assert.True(t, ok, fmt.Sprintf("expected stream %s to be kept\n", name))
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"sort"
+	"strings"
+)
+
+// List orders.
+const (
+	OrderName    = "name"
+	OrderCreated = "created"
+	OrderUpdated = "updated"
+	OrderState   = "state"
+)
+
+// List directions.
+const (
+	DirAsc  = "asc"
+	DirDesc = "desc"
+)
+
+// PageMetadata contains page parameters of the list requests. If Name is
+// set, only the entities whose name contains it are listed. If Labels are
+// set, only the entities that have all of the labels with the same values
+// are listed. If Folder is set, only the rules in the user's folder with that
+// name are listed. If Deleted is set, only the deleted rules are listed instead of
+// the live ones. Order sorts the entities by name (default), creation time,
+// last update time or state, in the ascending (default) or descending Dir.
+// Streams and tables have no state, so they're sorted by name instead, while
+// the entities with the same creation or update time are sorted by name.
+type PageMetadata struct {
+	Offset  uint64            `json:"offset"`
+	Limit   uint64            `json:"limit"`
+	Name    string            `json:"name,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Folder  string            `json:"folder,omitempty"`
+	Deleted bool              `json:"deleted,omitempty"`
+	Order   string            `json:"order,omitempty"`
+	Dir     string            `json:"dir,omitempty"`
+	// Owner is the ID of the group whose entities are listed instead of
+	// the user's.
+	Owner string `json:"owner,omitempty"`
+}
+
+// StreamsPage contains page related metadata as well as list of the stream
+// names that belong to this page and their metadata, mapped by the names.
+type StreamsPage struct {
+	Total    uint64              `json:"total"`
+	Offset   uint64              `json:"offset"`
+	Limit    uint64              `json:"limit"`
+	Streams  []string            `json:"streams"`
+	Metadata map[string]Metadata `json:"metadata,omitempty"`
+}
+
+// TablesPage contains page related metadata as well as list of the table
+// names that belong to this page and their metadata, mapped by the names.
+type TablesPage struct {
+	Total    uint64              `json:"total"`
+	Offset   uint64              `json:"offset"`
+	Limit    uint64              `json:"limit"`
+	Tables   []string            `json:"tables"`
+	Metadata map[string]Metadata `json:"metadata,omitempty"`
+}
+
+// RulesPage contains page related metadata as well as list of the rules that
+// belong to this page.
+type RulesPage struct {
+	Total  uint64     `json:"total"`
+	Offset uint64     `json:"offset"`
+	Limit  uint64     `json:"limit"`
+	Rules  []RuleInfo `json:"rules"`
+}
+
+// match reports whether the entity with the given name and metadata matches
+// the page name, labels, folder and deletion filters.
+func (pm PageMetadata) match(name string, md Metadata) bool {
+	if md.deleted() != pm.Deleted || (pm.Folder != "" && md.Folder != pm.Folder) {
+		return false
+	}
+	if !strings.Contains(strings.ToLower(name), strings.ToLower(pm.Name)) {
+		return false
+	}
+
+	return hasLabels(md.Labels, pm.Labels)
+}
+
+// hasLabels reports whether the labels contain all the selector labels.
+func hasLabels(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// listed is the listed entity the page is sorted by.
+type listed struct {
+	name  string
+	state string
+	md    Metadata
+}
+
+// before reports whether the entity a goes before the entity b in the page
+// order.
+func (pm PageMetadata) before(a, b listed) bool {
+	var c int
+	switch pm.Order {
+	case OrderCreated:
+		c = a.md.CreatedAt.Compare(b.md.CreatedAt)
+	case OrderUpdated:
+		c = a.md.lastUpdate().Compare(b.md.lastUpdate())
+	case OrderState:
+		c = strings.Compare(strings.ToLower(a.state), strings.ToLower(b.state))
+	}
+	if c == 0 {
+		c = strings.Compare(a.name, b.name)
+	}
+	if pm.Dir == DirDesc {
+		return c > 0
+	}
+
+	return c < 0
+}
+
+// sortNames sorts the names of the streams or tables with the given
+// metadata in the page order.
+func (pm PageMetadata) sortNames(names []string, mds func(name string) Metadata) {
+	sort.Slice(names, func(i, j int) bool {
+		return pm.before(listed{name: names[i], md: mds(names[i])}, listed{name: names[j], md: mds(names[j])})
+	})
+}
+
+// bounds returns the indices of the first and after the last entity of the
+// page out of the total number of entities.
+func (pm PageMetadata) bounds(total int) (int, int) {
+	start := total
+	if pm.Offset < uint64(total) {
+		start = int(pm.Offset)
+	}
+	end := total
+	if pm.Limit < uint64(total-start) {
+		end = start + int(pm.Limit)
+	}
+
+	return start, end
+}
+
+func pageStreams(names []string, mds func(name string) Metadata, pm PageMetadata) StreamsPage {
+	pm.sortNames(names, mds)
+	start, end := pm.bounds(len(names))
+
+	return StreamsPage{
+		Total:   uint64(len(names)),
+		Offset:  pm.Offset,
+		Limit:   pm.Limit,
+		Streams: names[start:end],
+	}
+}
+
+func pageTables(names []string, mds func(name string) Metadata, pm PageMetadata) TablesPage {
+	pm.sortNames(names, mds)
+	start, end := pm.bounds(len(names))
+
+	return TablesPage{
+		Total:  uint64(len(names)),
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Tables: names[start:end],
+	}
+}
+
+// pageRules returns the page of the rules, which already contain their
+// metadata and final status.
+func pageRules(rules []RuleInfo, pm PageMetadata) RulesPage {
+	sort.Slice(rules, func(i, j int) bool {
+		return pm.before(rules[i].listed(), rules[j].listed())
+	})
+	start, end := pm.bounds(len(rules))
+
+	return RulesPage{
+		Total:  uint64(len(rules)),
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Rules:  rules[start:end],
+	}
+}
+
+func (r RuleInfo) listed() listed {
+	l := listed{name: r.ID, state: r.Status}
+	if r.Metadata != nil {
+		l.md = *r.Metadata
+	}
+
+	return l
+}
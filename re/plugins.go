@@ -0,0 +1,136 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"net/url"
+	"sort"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Kuiper plugin kinds.
+const (
+	SourcePlugin   = "sources"
+	SinkPlugin     = "sinks"
+	FunctionPlugin = "functions"
+)
+
+var (
+	errPluginKind      = errors.New("plugin kind must be sources, sinks or functions")
+	errFileURL         = errors.New("file must be http or https URL")
+	errPluginFunctions = errors.New("only function plugins export functions")
+)
+
+// Plugin is the Kuiper plugin installed from the zip file Kuiper downloads
+// from the File URL. ShellParas are the arguments of the install script of
+// the plugin, if any. Functions lists the functions exported by the
+// function plugin, which defaults to the single function named like the
+// plugin.
+type Plugin struct {
+	Name       string   `json:"name"`
+	File       string   `json:"file"`
+	ShellParas []string `json:"shellParas,omitempty"`
+	Functions  []string `json:"functions,omitempty"`
+}
+
+func (svc *reService) CreatePlugin(ctx context.Context, token, kind string, plugin Plugin) (Result, error) {
+	if err := svc.authorizePlugins(ctx, token, kind); err != nil {
+		return Result{}, err
+	}
+	if err := plugin.validate(kind); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.engine.CreatePlugin(ctx, kind, plugin)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res.Name = plugin.Name
+
+	return res, nil
+}
+
+func (svc *reService) ListPlugins(ctx context.Context, token, kind string) ([]string, error) {
+	if err := svc.authorizePlugins(ctx, token, kind); err != nil {
+		return nil, err
+	}
+
+	names, err := svc.engine.ListPlugins(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (svc *reService) DeletePlugin(ctx context.Context, token, kind, name string) (Result, error) {
+	if err := svc.authorizePlugins(ctx, token, kind); err != nil {
+		return Result{}, err
+	}
+	if err := validateName(name); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.engine.DeletePlugin(ctx, kind, name)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res.Name = name
+
+	return res, nil
+}
+
+// authorizePlugins checks that the user identified by the token is the
+// platform administrator, since plugins are shared by all the users, and
+// that the plugin kind is supported.
+func (svc *reService) authorizePlugins(ctx context.Context, token, kind string) error {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return err
+	}
+	if kind != SourcePlugin && kind != SinkPlugin && kind != FunctionPlugin {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errPluginKind)
+	}
+
+	return nil
+}
+
+func (plugin Plugin) validate(kind string) error {
+	if err := validateName(plugin.Name); err != nil {
+		return err
+	}
+	if err := validateFileURL(plugin.File); err != nil {
+		return err
+	}
+	for _, f := range plugin.Functions {
+		if err := validateName(f); err != nil {
+			return err
+		}
+	}
+	if len(plugin.Functions) > 0 && kind != FunctionPlugin {
+		return errPluginFunctions
+	}
+
+	return nil
+}
+
+// validateFileURL checks that the file Kuiper downloads is served over
+// http or https, so Kuiper doesn't read the files of its own host.
+func validateFileURL(file string) error {
+	u, err := url.Parse(file)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errFileURL
+	}
+
+	return nil
+}
+
+func pluginsPath(kind string) string {
+	return "/plugins/" + kind
+}
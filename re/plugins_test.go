@@ -0,0 +1,222 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const pluginFile = "https://example.com/plugins/sinks/mainflux.zip"
+
+func TestCreatePlugin(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	cases := []struct {
+		desc       string
+		token      string
+		authorized bool
+		kind       string
+		plugin     re.Plugin
+		err        error
+	}{
+		{
+			desc:       "create sink plugin",
+			token:      validToken,
+			authorized: true,
+			kind:       re.SinkPlugin,
+			plugin:     re.Plugin{Name: "mainflux", File: pluginFile},
+		},
+		{
+			desc:       "create function plugin",
+			token:      validToken,
+			authorized: true,
+			kind:       re.FunctionPlugin,
+			plugin:     re.Plugin{Name: "geo", File: "https://example.com/plugins/functions/geo.zip", Functions: []string{"distance", "inside"}},
+		},
+		{
+			desc:       "create existing plugin",
+			token:      validToken,
+			authorized: true,
+			kind:       re.SinkPlugin,
+			plugin:     re.Plugin{Name: "mainflux", File: pluginFile},
+			err:        svcerr.ErrConflict,
+		},
+		{
+			desc:   "create plugin with invalid token",
+			token:  invalidToken,
+			kind:   re.SinkPlugin,
+			plugin: re.Plugin{Name: "other", File: pluginFile},
+			err:    svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "create plugin by non-admin user",
+			token:  validToken,
+			kind:   re.SinkPlugin,
+			plugin: re.Plugin{Name: "other", File: pluginFile},
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "create plugin of unsupported kind",
+			token:      validToken,
+			authorized: true,
+			kind:       "portables",
+			plugin:     re.Plugin{Name: "other", File: pluginFile},
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create plugin with malformed name",
+			token:      validToken,
+			authorized: true,
+			kind:       re.SinkPlugin,
+			plugin:     re.Plugin{Name: "../other", File: pluginFile},
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create plugin from local file",
+			token:      validToken,
+			authorized: true,
+			kind:       re.SinkPlugin,
+			plugin:     re.Plugin{Name: "other", File: "file:///etc/passwd"},
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create sink plugin exporting functions",
+			token:      validToken,
+			authorized: true,
+			kind:       re.SinkPlugin,
+			plugin:     re.Plugin{Name: "other", File: pluginFile, Functions: []string{"distance"}},
+			err:        svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall2 := authorizeAdmin(auth, tc.authorized)
+		res, err := svc.CreatePlugin(context.Background(), tc.token, tc.kind, tc.plugin)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.plugin.Name, res.Name, fmt.Sprintf("%s: expected result name %s got %s\n", tc.desc, tc.plugin.Name, res.Name))
+			plugin := k.plugins[tc.kind+"/"+tc.plugin.Name]
+			assert.Equal(t, tc.plugin, plugin, fmt.Sprintf("%s: expected plugin %v got %v\n", tc.desc, tc.plugin, plugin))
+		}
+		authCall2.Unset()
+	}
+	_, ok := k.plugins[re.SinkPlugin+"/other"]
+	assert.False(t, ok, "expected invalid plugins not to be created")
+}
+
+func TestListPlugins(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.plugins[re.SinkPlugin+"/mainflux"] = re.Plugin{Name: "mainflux"}
+	k.plugins[re.SinkPlugin+"/influx"] = re.Plugin{Name: "influx"}
+	k.plugins[re.SourcePlugin+"/random"] = re.Plugin{Name: "random"}
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc       string
+		authorized bool
+		kind       string
+		plugins    []string
+		err        error
+	}{
+		{
+			desc:       "list sink plugins",
+			authorized: true,
+			kind:       re.SinkPlugin,
+			plugins:    []string{"influx", "mainflux"},
+		},
+		{
+			desc:       "list function plugins",
+			authorized: true,
+			kind:       re.FunctionPlugin,
+			plugins:    []string{},
+		},
+		{
+			desc: "list plugins by non-admin user",
+			kind: re.SinkPlugin,
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "list plugins of unsupported kind",
+			authorized: true,
+			kind:       "portables",
+			err:        svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall1 := authorizeAdmin(auth, tc.authorized)
+		plugins, err := svc.ListPlugins(context.Background(), validToken, tc.kind)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.plugins, plugins, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.plugins, plugins))
+		authCall1.Unset()
+	}
+}
+
+func TestDeletePlugin(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.plugins[re.SinkPlugin+"/mainflux"] = re.Plugin{Name: "mainflux"}
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc       string
+		authorized bool
+		kind       string
+		name       string
+		err        error
+	}{
+		{
+			desc: "delete plugin by non-admin user",
+			kind: re.SinkPlugin,
+			name: "mainflux",
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "delete plugin",
+			authorized: true,
+			kind:       re.SinkPlugin,
+			name:       "mainflux",
+		},
+		{
+			desc:       "delete non-existing plugin",
+			authorized: true,
+			kind:       re.SinkPlugin,
+			name:       "mainflux",
+			err:        svcerr.ErrNotFound,
+		},
+		{
+			desc:       "delete plugin with path traversal",
+			authorized: true,
+			kind:       re.SinkPlugin,
+			name:       "../../rules/rule",
+			err:        svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall1 := authorizeAdmin(auth, tc.authorized)
+		res, err := svc.DeletePlugin(context.Background(), validToken, tc.kind, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.name, res.Name, fmt.Sprintf("%s: expected result name %s got %s\n", tc.desc, tc.name, res.Name))
+			_, ok := k.plugins[tc.kind+"/"+tc.name]
+			assert.False(t, ok, fmt.Sprintf("%s: expected plugin to be removed\n", tc.desc))
+		}
+		authCall1.Unset()
+	}
+}
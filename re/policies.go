@@ -0,0 +1,109 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala"
+	mgauth "github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var errReadOnly = errors.New("user role doesn't allow changing streams, tables and rules")
+
+// writeKey marks the context of the operation that already passed the write
+// checks, so the operations it runs (e.g. bulk creation of the streams and
+// rules) aren't checked again and take no more rate limit tokens.
+type writeKey struct{}
+
+// identifyWriter identifies the user, checks that the user's domain role
+// allows changing streams, tables and rules and takes a token from the
+// user's rate limit bucket. It guards the operations that change the Kuiper
+// entities.
+func (svc *reService) identifyWriter(ctx context.Context, token string) (string, error) {
+	id, err := svc.identity(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if ctx.Value(writeKey{}) != nil {
+		return id.userID, nil
+	}
+	if svc.roles {
+		if err := svc.authorizeDomain(ctx, token, id.domainID); err != nil {
+			return "", err
+		}
+	}
+	if err := svc.limiter.take(id.userID); err != nil {
+		return "", err
+	}
+
+	return id.userID, nil
+}
+
+// writing runs the write checks of the operation changing many entities and
+// returns the context the operation runs the changes with. No single entity
+// revision applies to the changes, so any revision is accepted.
+func (svc *reService) writing(ctx context.Context, token string) (context.Context, error) {
+	if _, err := svc.identifyWriter(ctx, token); err != nil {
+		return ctx, err
+	}
+
+	return written(ctx), nil
+}
+
+// written returns the context of the operation that passed the write checks,
+// so the operations it runs on its behalf (e.g. the rules a cascade removes)
+// aren't checked again. Their revisions were claimed by the operation, if
+// any, so any revision is accepted.
+func written(ctx context.Context) context.Context {
+	return WithRevision(context.WithValue(ctx, writeKey{}, true), AnyRevision)
+}
+
+// authorizeDomain checks that the token's user can edit the domain, which
+// the domain viewers, having the read-only role, can't.
+func (svc *reService) authorizeDomain(ctx context.Context, token, domainID string) error {
+	if domainID == "" {
+		return errors.Wrap(svcerr.ErrAuthorization, errReadOnly)
+	}
+	err := svc.authorize(ctx, token, mgauth.EditPermission, mgauth.DomainType, domainID)
+	if errors.Contains(err, svcerr.ErrAuthorization) {
+		return errors.Wrap(err, errReadOnly)
+	}
+
+	return err
+}
+
+// authorizeChannel checks that the token's user has write access to the
+// channel.
+func (svc *reService) authorizeChannel(ctx context.Context, token, channel string) error {
+	return svc.authorize(ctx, token, mgauth.EditPermission, mgauth.GroupType, channel)
+}
+
+// authorize checks the permission of the token's user on the object using
+// the auth service policies. Since the auth service identifies the token
+// again, rejected tokens drop their cached identities.
+func (svc *reService) authorize(ctx context.Context, token, permission, objectType, object string) error {
+	res, err := svc.auth.Authorize(ctx, &magistrala.AuthorizeReq{
+		SubjectType: mgauth.UserType,
+		SubjectKind: mgauth.TokenKind,
+		Subject:     token,
+		Permission:  permission,
+		ObjectType:  objectType,
+		Object:      object,
+	})
+	if err != nil {
+		if errors.Contains(err, svcerr.ErrAuthentication) {
+			svc.identities.remove(token)
+			return errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
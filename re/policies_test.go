@@ -0,0 +1,113 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// authorizeDomain mocks the check of the edit permission of the token's user
+// on the domain.
+func authorizeDomain(auth *authmocks.AuthClient, authorized bool) *mock.Call {
+	return auth.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+		SubjectType: "user",
+		SubjectKind: "token",
+		Subject:     validToken,
+		Permission:  "edit",
+		ObjectType:  "domain",
+		Object:      domainID,
+	}).Return(&magistrala.AuthorizeRes{Authorized: authorized}, nil)
+}
+
+func TestRoles(t *testing.T) {
+	stream := re.StreamDef{Name: "stream", Topic: channelID, SenML: true}
+
+	cases := []struct {
+		desc     string
+		roles    bool
+		domainID string
+		editor   bool
+		viewErr  error
+		writeErr error
+	}{
+		{
+			desc:     "change as domain editor",
+			roles:    true,
+			domainID: domainID,
+			editor:   true,
+		},
+		{
+			desc:     "change as domain viewer",
+			roles:    true,
+			domainID: domainID,
+			writeErr: svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "change without domain",
+			roles:    true,
+			writeErr: svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "change as domain viewer without roles",
+			domainID: domainID,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _, auth, _ := newServiceWithConfig(t, re.Config{Roles: tc.roles}, re.Notifiers{})
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID, DomainId: tc.domainID}, nil)
+		authCall1 := authorizeDomain(auth, tc.editor)
+		authCall2 := authorizeChannel(auth, validToken, channelID, true)
+
+		_, err := svc.ListStreams(context.Background(), validToken, re.PageMetadata{Limit: 10})
+		assert.True(t, errors.Contains(err, tc.viewErr), fmt.Sprintf("%s: list streams: expected %s got %s\n", tc.desc, tc.viewErr, err))
+		_, err = svc.ViewRule(context.Background(), validToken, "rule")
+		assert.True(t, errors.Contains(err, tc.viewErr), fmt.Sprintf("%s: view rule: expected %s got %s\n", tc.desc, tc.viewErr, err))
+		_, err = svc.CreateStream(context.Background(), validToken, stream, true)
+		assert.True(t, errors.Contains(err, tc.writeErr), fmt.Sprintf("%s: update stream: expected %s got %s\n", tc.desc, tc.writeErr, err))
+		_, err = svc.StopRule(context.Background(), validToken, "rule")
+		assert.True(t, errors.Contains(err, tc.writeErr), fmt.Sprintf("%s: stop rule: expected %s got %s\n", tc.desc, tc.writeErr, err))
+		_, err = svc.DeleteRule(context.Background(), validToken, "rule")
+		assert.True(t, errors.Contains(err, tc.writeErr), fmt.Sprintf("%s: delete rule: expected %s got %s\n", tc.desc, tc.writeErr, err))
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+	}
+}
+
+func TestRolesBulk(t *testing.T) {
+	svc, _, auth, _ := newServiceWithConfig(t, re.Config{Roles: true}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID, DomainId: domainID}, nil)
+	defer authCall.Unset()
+	authCall1 := authorizeDomain(auth, true)
+	defer authCall1.Unset()
+	authCall2 := authorizeChannel(auth, validToken, channelID, true)
+	defer authCall2.Unset()
+
+	rs := re.Ruleset{Streams: []re.StreamDef{
+		{Name: "bulk1", Topic: channelID, SenML: true},
+		{Name: "bulk2", Topic: channelID, SenML: true},
+	}}
+	report, err := svc.BulkCreate(context.Background(), validToken, rs)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, 2, report.Succeeded, fmt.Sprintf("expected 2 created streams got %d", report.Succeeded))
+	// The domain role is checked once for the whole bulk operation.
+	domainChecks := 0
+	for _, call := range auth.Calls {
+		if req, ok := call.Arguments.Get(1).(*magistrala.AuthorizeReq); ok && req.ObjectType == "domain" {
+			domainChecks++
+		}
+	}
+	assert.Equal(t, 1, domainChecks, fmt.Sprintf("expected single domain check got %d", domainChecks))
+}
@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveAlertPolicy(ctx context.Context, owner string, p re.AlertPolicy) error {
+	q := `INSERT INTO alert_policies (owner, policy) VALUES (:owner, :policy)
+		ON CONFLICT (owner) DO UPDATE SET policy = EXCLUDED.policy`
+
+	policy, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbAlertPolicy{Owner: owner, Policy: policy}); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveAlertPolicy(ctx context.Context, owner string) (re.AlertPolicy, error) {
+	q := `SELECT owner, policy FROM alert_policies WHERE owner = :owner`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbAlertPolicy{Owner: owner})
+	if err != nil {
+		return re.AlertPolicy{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return re.AlertPolicy{}, repoerr.ErrNotFound
+	}
+	var dbp dbAlertPolicy
+	if err := rows.StructScan(&dbp); err != nil {
+		return re.AlertPolicy{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toAlertPolicy(dbp)
+}
+
+func (repo *repository) RetrieveAlertPolicies(ctx context.Context) (map[string]re.AlertPolicy, error) {
+	q := `SELECT owner, policy FROM alert_policies`
+
+	rows, err := repo.db.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	policies := make(map[string]re.AlertPolicy)
+	for rows.Next() {
+		var dbp dbAlertPolicy
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		p, err := toAlertPolicy(dbp)
+		if err != nil {
+			return nil, err
+		}
+		policies[dbp.Owner] = p
+	}
+
+	return policies, nil
+}
+
+func (repo *repository) RemoveAlertPolicy(ctx context.Context, owner string) error {
+	q := `DELETE FROM alert_policies WHERE owner = $1`
+
+	res, err := repo.db.ExecContext(ctx, q, owner)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbAlertPolicy struct {
+	Owner  string `db:"owner"`
+	Policy []byte `db:"policy"`
+}
+
+func toAlertPolicy(dbp dbAlertPolicy) (re.AlertPolicy, error) {
+	var p re.AlertPolicy
+	if err := json.Unmarshal(dbp.Policy, &p); err != nil {
+		return re.AlertPolicy{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return p, nil
+}
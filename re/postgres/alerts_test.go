@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const alertOwnerID = "0b7e3c4d-5f6a-4b7c-8d9e-0f1a2b3c4d5e"
+
+func TestAlertPolicySave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alert_policies")
+		require.Nil(t, err, fmt.Sprintf("clean alert policies unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	cases := []struct {
+		desc   string
+		policy re.AlertPolicy
+	}{
+		{
+			desc:   "save alert policy",
+			policy: re.AlertPolicy{Exceptions: 10, Webhook: "https://example.com/alerts"},
+		},
+		{
+			desc:   "replace alert policy",
+			policy: re.AlertPolicy{Email: &re.NotificationSink{Channel: "channel", Contacts: []string{"ops@example.com"}}},
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveAlertPolicy(context.Background(), alertOwnerID, tc.policy)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		p, err := repo.RetrieveAlertPolicy(context.Background(), alertOwnerID)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.policy, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policy, p))
+		all, err := repo.RetrieveAlertPolicies(context.Background())
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, map[string]re.AlertPolicy{alertOwnerID: tc.policy}, all, fmt.Sprintf("%s: unexpected policies %v\n", tc.desc, all))
+	}
+}
+
+func TestAlertPolicyRemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alert_policies")
+		require.Nil(t, err, fmt.Sprintf("clean alert policies unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	err := repo.SaveAlertPolicy(context.Background(), alertOwnerID, re.AlertPolicy{Webhook: "https://example.com/alerts"})
+	require.Nil(t, err, fmt.Sprintf("save alert policy unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			desc: "remove alert policy",
+		},
+		{
+			desc: "remove removed alert policy",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.RemoveAlertPolicy(context.Background(), alertOwnerID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	_, err = repo.RetrieveAlertPolicy(context.Background(), alertOwnerID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve removed alert policy: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+)
+
+func (repo *repository) SaveAssignment(ctx context.Context, userID, instance string) error {
+	query := `INSERT INTO assignments (user_id, instance) VALUES (:user_id, :instance)
+		ON CONFLICT (user_id) DO UPDATE SET instance = EXCLUDED.instance`
+
+	dba := dbAssignment{UserID: userID, Instance: instance}
+	if _, err := repo.db.NamedExecContext(ctx, query, dba); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RemoveAssignment(ctx context.Context, userID string) error {
+	query := `DELETE FROM assignments WHERE user_id = $1`
+
+	res, err := repo.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveAssignments(ctx context.Context) (map[string]string, error) {
+	query := `SELECT user_id, instance FROM assignments`
+
+	rows, err := repo.db.QueryxContext(ctx, query)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	assigned := make(map[string]string)
+	for rows.Next() {
+		var dba dbAssignment
+		if err := rows.StructScan(&dba); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		assigned[dba.UserID] = dba.Instance
+	}
+
+	return assigned, nil
+}
+
+type dbAssignment struct {
+	UserID   string `db:"user_id"`
+	Instance string `db:"instance"`
+}
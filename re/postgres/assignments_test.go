@@ -0,0 +1,41 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignments(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM assignments")
+		require.Nil(t, err, fmt.Sprintf("clean assignments unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	err := repo.SaveAssignment(context.Background(), quotaUserID, "dedicated")
+	assert.Nil(t, err, fmt.Sprintf("save assignment: expected no error got %s\n", err))
+	err = repo.SaveAssignment(context.Background(), quotaUserID, "eu")
+	assert.Nil(t, err, fmt.Sprintf("replace assignment: expected no error got %s\n", err))
+
+	assigned, err := repo.RetrieveAssignments(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("retrieve assignments: expected no error got %s\n", err))
+	assert.Equal(t, map[string]string{quotaUserID: "eu"}, assigned, fmt.Sprintf("unexpected assignments %v\n", assigned))
+
+	err = repo.RemoveAssignment(context.Background(), quotaUserID)
+	assert.Nil(t, err, fmt.Sprintf("remove assignment: expected no error got %s\n", err))
+	err = repo.RemoveAssignment(context.Background(), quotaUserID)
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("remove removed assignment: expected %s got %s\n", repoerr.ErrNotFound, err))
+
+	assigned, err = repo.RetrieveAssignments(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("retrieve assignments: expected no error got %s\n", err))
+	assert.Empty(t, assigned, fmt.Sprintf("expected no assignments got %v\n", assigned))
+}
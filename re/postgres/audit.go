@@ -0,0 +1,145 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveAuditEvent(ctx context.Context, ev re.AuditEvent) error {
+	q := `INSERT INTO audit (id, time, user_id, owner, kind, entity, operation, before, after)
+		VALUES (:id, :time, :user_id, :owner, :kind, :entity, :operation, :before, :after)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBAuditEvent(ev)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveAuditEvents(ctx context.Context, aq re.AuditQuery) (re.AuditPage, error) {
+	filter := auditFilter(aq)
+	params := dbAuditQuery{
+		User:   aq.User,
+		Owner:  aq.Owner,
+		Kind:   aq.Kind,
+		Entity: aq.Entity,
+		From:   aq.From,
+		To:     aq.To,
+		Offset: aq.Offset,
+		Limit:  aq.Limit,
+	}
+	q := fmt.Sprintf(`SELECT id, time, user_id, owner, kind, entity, operation, before, after FROM audit
+		%s ORDER BY time DESC, id OFFSET :offset LIMIT :limit`, filter)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return re.AuditPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	events := []re.AuditEvent{}
+	for rows.Next() {
+		var dbe dbAuditEvent
+		if err := rows.StructScan(&dbe); err != nil {
+			return re.AuditPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		events = append(events, toAuditEvent(dbe))
+	}
+
+	total, err := postgres.Total(ctx, repo.db, fmt.Sprintf(`SELECT COUNT(*) FROM audit %s`, filter), params)
+	if err != nil {
+		return re.AuditPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return re.AuditPage{Total: total, Offset: aq.Offset, Limit: aq.Limit, Events: events}, nil
+}
+
+// auditFilter returns the WHERE clause of the audit query, empty if the
+// query matches all the events.
+func auditFilter(aq re.AuditQuery) string {
+	var conds []string
+	if aq.User != "" {
+		conds = append(conds, "user_id = :user_id")
+	}
+	if aq.Owner != "" {
+		conds = append(conds, "owner = :owner")
+	}
+	if aq.Kind != "" {
+		conds = append(conds, "kind = :kind")
+	}
+	if aq.Entity != "" {
+		conds = append(conds, "entity = :entity")
+	}
+	if !aq.From.IsZero() {
+		conds = append(conds, "time >= :from")
+	}
+	if !aq.To.IsZero() {
+		conds = append(conds, "time <= :to")
+	}
+	if len(conds) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(conds, " AND ")
+}
+
+type dbAuditEvent struct {
+	ID        string         `db:"id"`
+	Time      time.Time      `db:"time"`
+	User      string         `db:"user_id"`
+	Owner     string         `db:"owner"`
+	Kind      string         `db:"kind"`
+	Entity    string         `db:"entity"`
+	Operation string         `db:"operation"`
+	Before    sql.NullString `db:"before"`
+	After     sql.NullString `db:"after"`
+}
+
+type dbAuditQuery struct {
+	User   string    `db:"user_id"`
+	Owner  string    `db:"owner"`
+	Kind   string    `db:"kind"`
+	Entity string    `db:"entity"`
+	From   time.Time `db:"from"`
+	To     time.Time `db:"to"`
+	Offset uint64    `db:"offset"`
+	Limit  uint64    `db:"limit"`
+}
+
+func toDBAuditEvent(ev re.AuditEvent) dbAuditEvent {
+	return dbAuditEvent{
+		ID:        ev.ID,
+		Time:      ev.Time,
+		User:      ev.User,
+		Owner:     ev.Owner,
+		Kind:      ev.Kind,
+		Entity:    ev.Entity,
+		Operation: ev.Operation,
+		Before:    sql.NullString{String: ev.Before, Valid: ev.Before != ""},
+		After:     sql.NullString{String: ev.After, Valid: ev.After != ""},
+	}
+}
+
+func toAuditEvent(dbe dbAuditEvent) re.AuditEvent {
+	return re.AuditEvent{
+		ID:        dbe.ID,
+		Time:      dbe.Time,
+		User:      dbe.User,
+		Owner:     dbe.Owner,
+		Kind:      dbe.Kind,
+		Entity:    dbe.Entity,
+		Operation: dbe.Operation,
+		Before:    dbe.Before.String,
+		After:     dbe.After.String,
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRetrieveEvents(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM audit")
+		require.Nil(t, err, fmt.Sprintf("clean audit unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	events := []re.AuditEvent{
+		{ID: "1", Time: now.Add(-2 * time.Hour), User: "user", Owner: "user", Kind: re.StreamKind, Entity: "readings", Operation: re.AuditCreate, After: "CREATE STREAM readings () WITH (TYPE=\"mqtt\")"},
+		{ID: "2", Time: now.Add(-time.Hour), User: "user", Owner: "user", Kind: re.RuleKind, Entity: "alarm", Operation: re.AuditCreate, After: "SELECT * FROM readings"},
+		{ID: "3", Time: now, User: "other", Owner: "user", Kind: re.RuleKind, Entity: "alarm", Operation: re.AuditUpdate, Before: "SELECT * FROM readings", After: "SELECT * FROM readings WHERE v > 30"},
+	}
+	for _, ev := range events {
+		err := repo.SaveAuditEvent(context.Background(), ev)
+		require.Nil(t, err, fmt.Sprintf("save audit event unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		query re.AuditQuery
+		total uint64
+		ids   []string
+	}{
+		{
+			desc:  "retrieve all audit events",
+			query: re.AuditQuery{Limit: 10},
+			total: 3,
+			ids:   []string{"3", "2", "1"},
+		},
+		{
+			desc:  "retrieve audit events of rule",
+			query: re.AuditQuery{Limit: 10, Kind: re.RuleKind, Entity: "alarm"},
+			total: 2,
+			ids:   []string{"3", "2"},
+		},
+		{
+			desc:  "retrieve audit events of user",
+			query: re.AuditQuery{Limit: 10, User: "other"},
+			total: 1,
+			ids:   []string{"3"},
+		},
+		{
+			desc:  "retrieve audit events in time range",
+			query: re.AuditQuery{Limit: 10, From: now.Add(-90 * time.Minute), To: now.Add(-time.Minute)},
+			total: 1,
+			ids:   []string{"2"},
+		},
+		{
+			desc:  "retrieve audit events page",
+			query: re.AuditQuery{Offset: 1, Limit: 1},
+			total: 3,
+			ids:   []string{"2"},
+		},
+		{
+			desc:  "retrieve audit events of unknown owner",
+			query: re.AuditQuery{Limit: 10, Owner: "unknown"},
+			ids:   []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAuditEvents(context.Background(), tc.query)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		ids := []string{}
+		for _, ev := range page.Events {
+			ids = append(ids, ev.ID)
+		}
+		assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected events %v got %v\n", tc.desc, tc.ids, ids))
+	}
+}
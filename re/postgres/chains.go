@@ -0,0 +1,109 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveChain(ctx context.Context, c re.Chain) error {
+	q := `INSERT INTO chains (owner, name, from_rule, to_rule, fields, created_at)
+		VALUES (:owner, :name, :from_rule, :to_rule, :fields, :created_at)`
+
+	dbc, err := toDBChain(c)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbc); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveChains(ctx context.Context, owner string) ([]re.Chain, error) {
+	q := `SELECT owner, name, from_rule, to_rule, fields, created_at FROM chains WHERE owner = :owner`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbChain{Owner: owner})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	chains := []re.Chain{}
+	for rows.Next() {
+		var dbc dbChain
+		if err := rows.StructScan(&dbc); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		c, err := toChain(dbc)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		chains = append(chains, c)
+	}
+
+	return chains, nil
+}
+
+func (repo *repository) RemoveChain(ctx context.Context, owner, name string) error {
+	q := `DELETE FROM chains WHERE owner = $1 AND name = $2`
+
+	res, err := repo.db.ExecContext(ctx, q, owner, name)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbChain struct {
+	Owner     string    `db:"owner"`
+	Name      string    `db:"name"`
+	From      string    `db:"from_rule"`
+	To        string    `db:"to_rule"`
+	Fields    []byte    `db:"fields"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBChain(c re.Chain) (dbChain, error) {
+	fields, err := json.Marshal(c.Fields)
+	if err != nil {
+		return dbChain{}, err
+	}
+
+	return dbChain{
+		Owner:     c.Owner,
+		Name:      c.Name,
+		From:      c.From,
+		To:        c.To,
+		Fields:    fields,
+		CreatedAt: c.CreatedAt,
+	}, nil
+}
+
+func toChain(dbc dbChain) (re.Chain, error) {
+	c := re.Chain{
+		Owner:     dbc.Owner,
+		Name:      dbc.Name,
+		From:      dbc.From,
+		To:        dbc.To,
+		CreatedAt: dbc.CreatedAt,
+	}
+	if err := json.Unmarshal(dbc.Fields, &c.Fields); err != nil {
+		return re.Chain{}, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChains(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM chains")
+		require.Nil(t, err, fmt.Sprintf("clean chains unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	fields := []re.Field{{Name: "v", Type: re.FloatType}}
+	chains := []re.Chain{
+		{Owner: alertOwnerID, Name: "filtered", From: "filter", To: "alarm", Fields: fields, CreatedAt: now},
+		{Owner: quotaUserID, Name: "filtered", From: "filter", To: "average", Fields: fields, CreatedAt: now},
+	}
+	for _, c := range chains {
+		err := repo.SaveChain(context.Background(), c)
+		assert.Nil(t, err, fmt.Sprintf("save chain: expected no error got %s\n", err))
+	}
+	err := repo.SaveChain(context.Background(), chains[0])
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("save existing chain: expected %s got %s\n", repoerr.ErrConflict, err))
+
+	saved, err := repo.RetrieveChains(context.Background(), alertOwnerID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve chains: expected no error got %s\n", err))
+	assert.Equal(t, chains[:1], saved, fmt.Sprintf("expected chains %v got %v\n", chains[:1], saved))
+
+	err = repo.RemoveChain(context.Background(), alertOwnerID, "filtered")
+	assert.Nil(t, err, fmt.Sprintf("remove chain: expected no error got %s\n", err))
+	err = repo.RemoveChain(context.Background(), alertOwnerID, "filtered")
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("remove removed chain: expected %s got %s\n", repoerr.ErrNotFound, err))
+	saved, err = repo.RetrieveChains(context.Background(), quotaUserID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve chains of other owner: expected no error got %s\n", err))
+	assert.Equal(t, chains[1:], saved, fmt.Sprintf("expected chains %v got %v\n", chains[1:], saved))
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package postgres provides a postgres implementation of the rules engine
+// metadata, template and quota repository.
+package postgres
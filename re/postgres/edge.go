@@ -0,0 +1,214 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveGateway(ctx context.Context, gw re.Gateway) error {
+	q := `INSERT INTO gateways (id, owner, name, channel, labels, created_at)
+		VALUES (:id, :owner, :name, :channel, :labels, :created_at)
+		ON CONFLICT (id) DO UPDATE SET owner = EXCLUDED.owner, name = EXCLUDED.name,
+		channel = EXCLUDED.channel, labels = EXCLUDED.labels`
+
+	dbgw, err := toDBGateway(gw)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbgw); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveGateway(ctx context.Context, id string) (re.Gateway, error) {
+	q := `SELECT id, owner, name, channel, labels, created_at FROM gateways WHERE id = :id`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbGateway{ID: id})
+	if err != nil {
+		return re.Gateway{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return re.Gateway{}, repoerr.ErrNotFound
+	}
+	var dbgw dbGateway
+	if err := rows.StructScan(&dbgw); err != nil {
+		return re.Gateway{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toGateway(dbgw)
+}
+
+func (repo *repository) RetrieveGateways(ctx context.Context, owner string) ([]re.Gateway, error) {
+	q := `SELECT id, owner, name, channel, labels, created_at FROM gateways WHERE owner = $1`
+
+	rows, err := repo.db.QueryxContext(ctx, q, owner)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	gws := []re.Gateway{}
+	for rows.Next() {
+		var dbgw dbGateway
+		if err := rows.StructScan(&dbgw); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		gw, err := toGateway(dbgw)
+		if err != nil {
+			return nil, err
+		}
+		gws = append(gws, gw)
+	}
+
+	return gws, nil
+}
+
+func (repo *repository) RemoveGateway(ctx context.Context, id string) error {
+	q := `DELETE FROM gateways WHERE id = $1`
+
+	res, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) SaveDeployment(ctx context.Context, d re.Deployment) error {
+	q := `INSERT INTO deployments (rule, gateway, status, error, updated_at)
+		VALUES (:rule, :gateway, :status, :error, :updated_at)
+		ON CONFLICT (rule, gateway) DO UPDATE SET status = EXCLUDED.status,
+		error = EXCLUDED.error, updated_at = EXCLUDED.updated_at`
+
+	dbd := dbDeployment{
+		Rule:      d.Rule,
+		Gateway:   d.Gateway,
+		Status:    d.Status,
+		Error:     sql.NullString{String: d.Error, Valid: d.Error != ""},
+		UpdatedAt: d.UpdatedAt,
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbd); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveDeployments(ctx context.Context, rule, gateway string) ([]re.Deployment, error) {
+	q := `SELECT rule, gateway, status, error, updated_at FROM deployments WHERE TRUE`
+	if rule != "" {
+		q += ` AND rule = :rule`
+	}
+	if gateway != "" {
+		q += ` AND gateway = :gateway`
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbDeployment{Rule: rule, Gateway: gateway})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	ds := []re.Deployment{}
+	for rows.Next() {
+		var dbd dbDeployment
+		if err := rows.StructScan(&dbd); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		ds = append(ds, re.Deployment{
+			Rule:      dbd.Rule,
+			Gateway:   dbd.Gateway,
+			Status:    dbd.Status,
+			Error:     dbd.Error.String,
+			UpdatedAt: dbd.UpdatedAt,
+		})
+	}
+
+	return ds, nil
+}
+
+func (repo *repository) RemoveDeployment(ctx context.Context, rule, gateway string) error {
+	q := `DELETE FROM deployments WHERE rule = $1 AND gateway = $2`
+
+	res, err := repo.db.ExecContext(ctx, q, rule, gateway)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbGateway struct {
+	ID        string         `db:"id"`
+	Owner     string         `db:"owner"`
+	Name      sql.NullString `db:"name"`
+	Channel   string         `db:"channel"`
+	Labels    []byte         `db:"labels"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+type dbDeployment struct {
+	Rule      string         `db:"rule"`
+	Gateway   string         `db:"gateway"`
+	Status    string         `db:"status"`
+	Error     sql.NullString `db:"error"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+func toDBGateway(gw re.Gateway) (dbGateway, error) {
+	var labels []byte
+	if len(gw.Labels) > 0 {
+		b, err := json.Marshal(gw.Labels)
+		if err != nil {
+			return dbGateway{}, err
+		}
+		labels = b
+	}
+
+	return dbGateway{
+		ID:        gw.ID,
+		Owner:     gw.Owner,
+		Name:      sql.NullString{String: gw.Name, Valid: gw.Name != ""},
+		Channel:   gw.Channel,
+		Labels:    labels,
+		CreatedAt: gw.CreatedAt,
+	}, nil
+}
+
+func toGateway(dbgw dbGateway) (re.Gateway, error) {
+	var labels map[string]string
+	if dbgw.Labels != nil {
+		if err := json.Unmarshal(dbgw.Labels, &labels); err != nil {
+			return re.Gateway{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
+
+	return re.Gateway{
+		ID:        dbgw.ID,
+		Owner:     dbgw.Owner,
+		Name:      dbgw.Name.String,
+		Channel:   dbgw.Channel,
+		Labels:    labels,
+		CreatedAt: dbgw.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEdgeDeployments(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM gateways")
+		require.Nil(t, err, fmt.Sprintf("clean gateways unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	gw := re.Gateway{ID: "gateway", Owner: quotaUserID, Name: "plant", Channel: "channel", Labels: map[string]string{"site": "plant"}, CreatedAt: now}
+	err := repo.SaveGateway(context.Background(), gw)
+	assert.Nil(t, err, fmt.Sprintf("save gateway: expected no error got %s\n", err))
+	saved, err := repo.RetrieveGateway(context.Background(), gw.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve gateway: expected no error got %s\n", err))
+	assert.Equal(t, gw, saved, fmt.Sprintf("expected gateway %v got %v\n", gw, saved))
+	gws, err := repo.RetrieveGateways(context.Background(), "other")
+	assert.Nil(t, err, fmt.Sprintf("retrieve gateways: expected no error got %s\n", err))
+	assert.Empty(t, gws, fmt.Sprintf("expected no gateways of other owner got %v\n", gws))
+
+	d := re.Deployment{Rule: "user_alarm", Gateway: gw.ID, Status: re.DeployPending, UpdatedAt: now}
+	err = repo.SaveDeployment(context.Background(), d)
+	assert.Nil(t, err, fmt.Sprintf("save deployment: expected no error got %s\n", err))
+	d.Status, d.Error = re.DeployFailed, "unknown stream"
+	err = repo.SaveDeployment(context.Background(), d)
+	assert.Nil(t, err, fmt.Sprintf("replace deployment: expected no error got %s\n", err))
+	ds, err := repo.RetrieveDeployments(context.Background(), "", gw.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve deployments: expected no error got %s\n", err))
+	assert.Equal(t, []re.Deployment{d}, ds, fmt.Sprintf("unexpected deployments %v\n", ds))
+
+	err = repo.RemoveDeployment(context.Background(), d.Rule, gw.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove deployment: expected no error got %s\n", err))
+	err = repo.RemoveDeployment(context.Background(), d.Rule, gw.ID)
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("remove removed deployment: expected %s got %s\n", repoerr.ErrNotFound, err))
+	err = repo.RemoveGateway(context.Background(), gw.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove gateway: expected no error got %s\n", err))
+	_, err = repo.RetrieveGateway(context.Background(), gw.ID)
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("retrieve removed gateway: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveFolder(ctx context.Context, f re.Folder) error {
+	q := `INSERT INTO folders (owner, name, description, created_at)
+		VALUES (:owner, :name, :description, :created_at)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBFolder(f)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveFolders(ctx context.Context, owner string) ([]re.Folder, error) {
+	q := `SELECT owner, name, description, created_at FROM folders WHERE owner = :owner`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbFolder{Owner: owner})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	folders := []re.Folder{}
+	for rows.Next() {
+		var dbf dbFolder
+		if err := rows.StructScan(&dbf); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		folders = append(folders, toFolder(dbf))
+	}
+
+	return folders, nil
+}
+
+func (repo *repository) RemoveFolder(ctx context.Context, owner, name string) error {
+	q := `DELETE FROM folders WHERE owner = $1 AND name = $2`
+
+	res, err := repo.db.ExecContext(ctx, q, owner, name)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbFolder struct {
+	Owner       string         `db:"owner"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+func toDBFolder(f re.Folder) dbFolder {
+	return dbFolder{
+		Owner:       f.Owner,
+		Name:        f.Name,
+		Description: sql.NullString{String: f.Description, Valid: f.Description != ""},
+		CreatedAt:   f.CreatedAt,
+	}
+}
+
+func toFolder(dbf dbFolder) re.Folder {
+	return re.Folder{
+		Owner:       dbf.Owner,
+		Name:        dbf.Name,
+		Description: dbf.Description.String,
+		CreatedAt:   dbf.CreatedAt,
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFolders(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM folders")
+		require.Nil(t, err, fmt.Sprintf("clean folders unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	folders := []re.Folder{
+		{Owner: alertOwnerID, Name: "site_a", Description: "Site A rules", CreatedAt: now},
+		{Owner: quotaUserID, Name: "site_a", CreatedAt: now},
+	}
+	for _, f := range folders {
+		err := repo.SaveFolder(context.Background(), f)
+		assert.Nil(t, err, fmt.Sprintf("save folder: expected no error got %s\n", err))
+	}
+	err := repo.SaveFolder(context.Background(), folders[0])
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("save existing folder: expected %s got %s\n", repoerr.ErrConflict, err))
+
+	saved, err := repo.RetrieveFolders(context.Background(), alertOwnerID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve folders: expected no error got %s\n", err))
+	assert.Equal(t, folders[:1], saved, fmt.Sprintf("expected folders %v got %v\n", folders[:1], saved))
+
+	err = repo.RemoveFolder(context.Background(), alertOwnerID, "site_a")
+	assert.Nil(t, err, fmt.Sprintf("remove folder: expected no error got %s\n", err))
+	err = repo.RemoveFolder(context.Background(), alertOwnerID, "site_a")
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("remove removed folder: expected %s got %s\n", repoerr.ErrNotFound, err))
+	saved, err = repo.RetrieveFolders(context.Background(), quotaUserID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve folders of other owner: expected no error got %s\n", err))
+	assert.Equal(t, folders[1:], saved, fmt.Sprintf("expected folders %v got %v\n", folders[1:], saved))
+}
@@ -0,0 +1,334 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "re_01",
+				// Kuiper names contain the owner prefix, so they are unique
+				// per entity kind.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS metadata (
+						kind			VARCHAR(16) NOT NULL,
+						name			VARCHAR(254) NOT NULL,
+						owner			VARCHAR(36) NOT NULL,
+						description		TEXT,
+						labels			JSONB,
+						created_at		TIMESTAMP NOT NULL,
+						updated_at		TIMESTAMP,
+						PRIMARY KEY (kind, name)
+					)`,
+					`CREATE INDEX IF NOT EXISTS metadata_owner_idx ON metadata (kind, owner)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS metadata`,
+				},
+			},
+			{
+				Id: "re_02",
+				// Stream and rule definitions are stored to restore Kuiper
+				// and export rulesets.
+				Up: []string{
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS definition TEXT`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS definition`,
+				},
+			},
+			{
+				Id: "re_03",
+				// Template variables, SQL, actions and options are stored as
+				// the single JSON definition.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS templates (
+						name			VARCHAR(254) PRIMARY KEY,
+						description		TEXT,
+						definition		JSONB NOT NULL,
+						created_at		TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS templates`,
+				},
+			},
+			{
+				Id: "re_04",
+				// The rule states desired by the owners are restored when
+				// Kuiper restarts.
+				Up: []string{
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS stopped BOOLEAN NOT NULL DEFAULT FALSE`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS stopped`,
+				},
+			},
+			{
+				Id: "re_05",
+				// Quotas set by the platform administrator replace the
+				// default quota of the user.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS quotas (
+						user_id			VARCHAR(36) PRIMARY KEY,
+						max_streams		INTEGER NOT NULL,
+						max_rules		INTEGER NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS quotas`,
+				},
+			},
+			{
+				Id: "re_06",
+				// Streams and rules are shared with the users and the
+				// groups, which are identified by the grantee ID.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS shares (
+						kind			VARCHAR(16) NOT NULL,
+						name			VARCHAR(254) NOT NULL,
+						grantee			VARCHAR(36) NOT NULL,
+						grantee_type	VARCHAR(16) NOT NULL,
+						access			VARCHAR(16) NOT NULL,
+						PRIMARY KEY (kind, name, grantee)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS shares`,
+				},
+			},
+			{
+				Id: "re_07",
+				// Entities keep their ID when they're renamed. Entities
+				// created before have no ID until they're updated.
+				Up: []string{
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS id VARCHAR(36)`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS id`,
+				},
+			},
+			{
+				Id: "re_08",
+				// Draft rules are stored only in the metadata until
+				// they're published.
+				Up: []string{
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS draft BOOLEAN NOT NULL DEFAULT FALSE`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS draft`,
+				},
+			},
+			{
+				Id: "re_09",
+				// Deleted rules are kept until their retention period
+				// expires.
+				Up: []string{
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS deleted_at`,
+				},
+			},
+			{
+				Id: "re_10",
+				// Audit events outlive the entities, so they're stored by
+				// the entity name rather than referencing the metadata.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS audit (
+						id			VARCHAR(36) PRIMARY KEY,
+						time			TIMESTAMP NOT NULL,
+						user_id			VARCHAR(36) NOT NULL,
+						owner			VARCHAR(36) NOT NULL,
+						kind			VARCHAR(16) NOT NULL,
+						entity			VARCHAR(254) NOT NULL,
+						operation		VARCHAR(16) NOT NULL,
+						before			TEXT,
+						after			TEXT
+					)`,
+					`CREATE INDEX IF NOT EXISTS audit_owner_idx ON audit (owner, time)`,
+					`CREATE INDEX IF NOT EXISTS audit_entity_idx ON audit (kind, entity, time)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS audit`,
+				},
+			},
+			{
+				Id: "re_11",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS assignments (
+						user_id			VARCHAR(36) PRIMARY KEY,
+						instance		VARCHAR(254) NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS assignments`,
+				},
+			},
+			{
+				Id: "re_12",
+				// Rules are deployed to the edge gateways by the Kuiper
+				// name of the rule, which contains the owner prefix.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS gateways (
+						id				VARCHAR(36) PRIMARY KEY,
+						owner			VARCHAR(36) NOT NULL,
+						name			VARCHAR(254),
+						channel			VARCHAR(36) NOT NULL,
+						labels			JSONB,
+						created_at		TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS gateways_owner_idx ON gateways (owner)`,
+					`CREATE TABLE IF NOT EXISTS deployments (
+						rule			VARCHAR(254) NOT NULL,
+						gateway			VARCHAR(36) NOT NULL REFERENCES gateways (id) ON DELETE CASCADE,
+						status			VARCHAR(16) NOT NULL,
+						error			TEXT,
+						updated_at		TIMESTAMP NOT NULL,
+						PRIMARY KEY (rule, gateway)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS deployments`,
+					`DROP TABLE IF EXISTS gateways`,
+				},
+			},
+			{
+				Id: "re_13",
+				// The alert policy is stored as a whole, since it's only
+				// ever read and replaced as a whole.
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS alert_policies (
+						owner			VARCHAR(36) PRIMARY KEY,
+						policy			JSONB NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS alert_policies`,
+				},
+			},
+			{
+				Id: "re_14",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS webhooks (
+						id				VARCHAR(36) PRIMARY KEY,
+						owner			VARCHAR(36) NOT NULL,
+						url				TEXT NOT NULL,
+						secret			TEXT NOT NULL,
+						events			JSONB,
+						created_at		TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS webhooks_owner_idx ON webhooks (owner)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS webhooks`,
+				},
+			},
+			{
+				Id: "re_15",
+				Up: []string{
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS attributes JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS attributes`,
+				},
+			},
+			{
+				Id: "re_16",
+				Up: []string{
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS last_started_at TIMESTAMP`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS last_started_at`,
+				},
+			},
+			{
+				Id: "re_17",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS folders (
+						owner			VARCHAR(36) NOT NULL,
+						name			VARCHAR(254) NOT NULL,
+						description		TEXT,
+						created_at		TIMESTAMP NOT NULL,
+						PRIMARY KEY (owner, name)
+					)`,
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS folder VARCHAR(254)`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS folder`,
+					`DROP TABLE IF EXISTS folders`,
+				},
+			},
+			{
+				Id: "re_18",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS maintenance (
+						owner			VARCHAR(36) PRIMARY KEY,
+						rules			JSONB NOT NULL,
+						since			TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS maintenance`,
+				},
+			},
+			{
+				Id: "re_19",
+				Up: []string{
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS expires_at`,
+				},
+			},
+			{
+				Id: "re_20",
+				Up: []string{
+					`ALTER TABLE metadata ADD COLUMN IF NOT EXISTS schedule JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE metadata DROP COLUMN IF EXISTS schedule`,
+				},
+			},
+			{
+				Id: "re_21",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS chains (
+						owner			VARCHAR(36) NOT NULL,
+						name			VARCHAR(254) NOT NULL,
+						from_rule		VARCHAR(254) NOT NULL,
+						to_rule			VARCHAR(254) NOT NULL,
+						fields			JSONB NOT NULL,
+						created_at		TIMESTAMP NOT NULL,
+						PRIMARY KEY (owner, name)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS chains`,
+				},
+			},
+			{
+				Id: "re_22",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS secrets (
+						owner			VARCHAR(36) NOT NULL,
+						name			VARCHAR(254) NOT NULL,
+						value			BYTEA NOT NULL,
+						created_at		TIMESTAMP NOT NULL,
+						updated_at		TIMESTAMP NOT NULL,
+						PRIMARY KEY (owner, name)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS secrets`,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveMaintenance(ctx context.Context, m re.Maintenance) error {
+	q := `INSERT INTO maintenance (owner, rules, since) VALUES (:owner, :rules, :since)`
+
+	rules, err := json.Marshal(m.Rules)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	dbm := dbMaintenance{Owner: m.Owner, Rules: rules, Since: m.Since}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbm); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveMaintenance(ctx context.Context, owner string) (re.Maintenance, error) {
+	q := `SELECT owner, rules, since FROM maintenance WHERE owner = :owner`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbMaintenance{Owner: owner})
+	if err != nil {
+		return re.Maintenance{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return re.Maintenance{}, repoerr.ErrNotFound
+	}
+	var dbm dbMaintenance
+	if err := rows.StructScan(&dbm); err != nil {
+		return re.Maintenance{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	m := re.Maintenance{Owner: dbm.Owner, Since: dbm.Since}
+	if err := json.Unmarshal(dbm.Rules, &m.Rules); err != nil {
+		return re.Maintenance{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return m, nil
+}
+
+func (repo *repository) RemoveMaintenance(ctx context.Context, owner string) error {
+	q := `DELETE FROM maintenance WHERE owner = $1`
+
+	res, err := repo.db.ExecContext(ctx, q, owner)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbMaintenance struct {
+	Owner string    `db:"owner"`
+	Rules []byte    `db:"rules"`
+	Since time.Time `db:"since"`
+}
@@ -0,0 +1,43 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenance(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM maintenance")
+		require.Nil(t, err, fmt.Sprintf("clean maintenance unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	m := re.Maintenance{Owner: alertOwnerID, Rules: []string{"humidity", "overheat"}, Since: time.Now().UTC().Truncate(time.Microsecond)}
+	err := repo.SaveMaintenance(context.Background(), m)
+	assert.Nil(t, err, fmt.Sprintf("save maintenance: expected no error got %s\n", err))
+	err = repo.SaveMaintenance(context.Background(), m)
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("save existing maintenance: expected %s got %s\n", repoerr.ErrConflict, err))
+
+	saved, err := repo.RetrieveMaintenance(context.Background(), alertOwnerID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve maintenance: expected no error got %s\n", err))
+	assert.Equal(t, m, saved, fmt.Sprintf("expected maintenance %v got %v\n", m, saved))
+	_, err = repo.RetrieveMaintenance(context.Background(), quotaUserID)
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("retrieve missing maintenance: expected %s got %s\n", repoerr.ErrNotFound, err))
+
+	err = repo.RemoveMaintenance(context.Background(), alertOwnerID)
+	assert.Nil(t, err, fmt.Sprintf("remove maintenance: expected no error got %s\n", err))
+	err = repo.RemoveMaintenance(context.Background(), alertOwnerID)
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("remove removed maintenance: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
@@ -0,0 +1,260 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+type repository struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of the metadata
+// repository.
+func NewRepository(db postgres.Database) re.Repository {
+	return &repository{db: db}
+}
+
+func (repo *repository) Save(ctx context.Context, kind, name string, md re.Metadata) error {
+	q := `INSERT INTO metadata (kind, name, id, owner, description, labels, attributes, folder, created_at, updated_at, last_started_at, stopped, draft, deleted_at, expires_at, schedule, definition)
+		VALUES (:kind, :name, :id, :owner, :description, :labels, :attributes, :folder, :created_at, :updated_at, :last_started_at, :stopped, :draft, :deleted_at, :expires_at, :schedule, :definition)
+		ON CONFLICT (kind, name) DO UPDATE SET owner = EXCLUDED.owner, description = EXCLUDED.description,
+		labels = EXCLUDED.labels, attributes = EXCLUDED.attributes, folder = EXCLUDED.folder, updated_at = EXCLUDED.updated_at, last_started_at = EXCLUDED.last_started_at, stopped = EXCLUDED.stopped, draft = EXCLUDED.draft,
+		deleted_at = EXCLUDED.deleted_at, expires_at = EXCLUDED.expires_at, schedule = EXCLUDED.schedule, definition = EXCLUDED.definition,
+		created_at = CASE WHEN EXCLUDED.updated_at IS NULL THEN EXCLUDED.created_at ELSE metadata.created_at END,
+		id = CASE WHEN EXCLUDED.updated_at IS NULL THEN EXCLUDED.id ELSE COALESCE(metadata.id, EXCLUDED.id) END`
+
+	dbmd, err := toDBMetadata(kind, name, md)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbmd); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) Retrieve(ctx context.Context, kind, name string) (re.Metadata, error) {
+	q := `SELECT kind, name, id, owner, description, labels, attributes, folder, created_at, updated_at, last_started_at, stopped, draft, deleted_at, expires_at, schedule, definition FROM metadata WHERE kind = :kind AND name = :name`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbMetadata{Kind: kind, Name: name})
+	if err != nil {
+		return re.Metadata{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return re.Metadata{}, repoerr.ErrNotFound
+	}
+	var dbmd dbMetadata
+	if err := rows.StructScan(&dbmd); err != nil {
+		return re.Metadata{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toMetadata(dbmd)
+}
+
+func (repo *repository) RetrieveAll(ctx context.Context, kind, owner string) (map[string]re.Metadata, error) {
+	q := `SELECT kind, name, id, owner, description, labels, attributes, folder, created_at, updated_at, last_started_at, stopped, draft, deleted_at, expires_at, schedule, definition FROM metadata WHERE kind = :kind`
+	if owner != "" {
+		q += ` AND owner = :owner`
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbMetadata{Kind: kind, Owner: owner})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	mds := make(map[string]re.Metadata)
+	for rows.Next() {
+		var dbmd dbMetadata
+		if err := rows.StructScan(&dbmd); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		md, err := toMetadata(dbmd)
+		if err != nil {
+			return nil, err
+		}
+		mds[dbmd.Name] = md
+	}
+
+	return mds, nil
+}
+
+func (repo *repository) Remove(ctx context.Context, kind, name string) error {
+	q := `DELETE FROM metadata WHERE kind = $1 AND name = $2`
+
+	res, err := repo.db.ExecContext(ctx, q, kind, name)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) Touch(ctx context.Context, kind, name string, updated, now time.Time) error {
+	q := `UPDATE metadata SET updated_at = $4 WHERE kind = $1 AND name = $2 AND COALESCE(updated_at, created_at) = $3`
+
+	res, err := repo.db.ExecContext(ctx, q, kind, name, updated, now)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrConflict
+	}
+
+	return nil
+}
+
+type dbMetadata struct {
+	Kind          string         `db:"kind"`
+	Name          string         `db:"name"`
+	ID            sql.NullString `db:"id"`
+	Owner         string         `db:"owner"`
+	Description   sql.NullString `db:"description"`
+	Labels        []byte         `db:"labels"`
+	Attributes    []byte         `db:"attributes"`
+	Folder        sql.NullString `db:"folder"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     sql.NullTime   `db:"updated_at"`
+	LastStartedAt sql.NullTime   `db:"last_started_at"`
+	Stopped       bool           `db:"stopped"`
+	Draft         bool           `db:"draft"`
+	DeletedAt     sql.NullTime   `db:"deleted_at"`
+	ExpiresAt     sql.NullTime   `db:"expires_at"`
+	Schedule      []byte         `db:"schedule"`
+	Definition    sql.NullString `db:"definition"`
+}
+
+func toDBMetadata(kind, name string, md re.Metadata) (dbMetadata, error) {
+	var labels []byte
+	if len(md.Labels) > 0 {
+		b, err := json.Marshal(md.Labels)
+		if err != nil {
+			return dbMetadata{}, err
+		}
+		labels = b
+	}
+	var attributes []byte
+	if len(md.Attributes) > 0 {
+		b, err := json.Marshal(md.Attributes)
+		if err != nil {
+			return dbMetadata{}, err
+		}
+		attributes = b
+	}
+	var schedule []byte
+	if md.Schedule != nil {
+		b, err := json.Marshal(md.Schedule)
+		if err != nil {
+			return dbMetadata{}, err
+		}
+		schedule = b
+	}
+	var updatedAt sql.NullTime
+	if !md.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: md.UpdatedAt, Valid: true}
+	}
+	var lastStartedAt sql.NullTime
+	if !md.LastStartedAt.IsZero() {
+		lastStartedAt = sql.NullTime{Time: md.LastStartedAt, Valid: true}
+	}
+	var deletedAt sql.NullTime
+	if !md.DeletedAt.IsZero() {
+		deletedAt = sql.NullTime{Time: md.DeletedAt, Valid: true}
+	}
+	var expiresAt sql.NullTime
+	if !md.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: md.ExpiresAt, Valid: true}
+	}
+
+	return dbMetadata{
+		Kind:          kind,
+		Name:          name,
+		ID:            sql.NullString{String: md.ID, Valid: md.ID != ""},
+		Owner:         md.Owner,
+		Description:   sql.NullString{String: md.Description, Valid: md.Description != ""},
+		Labels:        labels,
+		Attributes:    attributes,
+		Folder:        sql.NullString{String: md.Folder, Valid: md.Folder != ""},
+		CreatedAt:     md.CreatedAt,
+		UpdatedAt:     updatedAt,
+		LastStartedAt: lastStartedAt,
+		Stopped:       md.Stopped,
+		Draft:         md.Draft,
+		DeletedAt:     deletedAt,
+		ExpiresAt:     expiresAt,
+		Schedule:      schedule,
+		Definition:    sql.NullString{String: md.Definition, Valid: md.Definition != ""},
+	}, nil
+}
+
+func toMetadata(dbmd dbMetadata) (re.Metadata, error) {
+	var labels map[string]string
+	if dbmd.Labels != nil {
+		if err := json.Unmarshal(dbmd.Labels, &labels); err != nil {
+			return re.Metadata{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
+	var attributes map[string]interface{}
+	if dbmd.Attributes != nil {
+		if err := json.Unmarshal(dbmd.Attributes, &attributes); err != nil {
+			return re.Metadata{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
+	var schedule *re.Schedule
+	if dbmd.Schedule != nil {
+		if err := json.Unmarshal(dbmd.Schedule, &schedule); err != nil {
+			return re.Metadata{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
+	var updatedAt time.Time
+	if dbmd.UpdatedAt.Valid {
+		updatedAt = dbmd.UpdatedAt.Time
+	}
+	var lastStartedAt time.Time
+	if dbmd.LastStartedAt.Valid {
+		lastStartedAt = dbmd.LastStartedAt.Time
+	}
+	var deletedAt time.Time
+	if dbmd.DeletedAt.Valid {
+		deletedAt = dbmd.DeletedAt.Time
+	}
+	var expiresAt time.Time
+	if dbmd.ExpiresAt.Valid {
+		expiresAt = dbmd.ExpiresAt.Time
+	}
+
+	return re.Metadata{
+		ID:            dbmd.ID.String,
+		Owner:         dbmd.Owner,
+		Description:   dbmd.Description.String,
+		Labels:        labels,
+		Attributes:    attributes,
+		Folder:        dbmd.Folder.String,
+		CreatedAt:     dbmd.CreatedAt,
+		UpdatedAt:     updatedAt,
+		LastStartedAt: lastStartedAt,
+		Stopped:       dbmd.Stopped,
+		Draft:         dbmd.Draft,
+		DeletedAt:     deletedAt,
+		ExpiresAt:     expiresAt,
+		Schedule:      schedule,
+		Definition:    dbmd.Definition.String,
+	}, nil
+}
@@ -0,0 +1,272 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataSave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM metadata")
+		require.Nil(t, err, fmt.Sprintf("clean metadata unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	owner := testsutil.GenerateUUID(t)
+	created := time.Now().UTC().Truncate(time.Microsecond)
+	updated := created.Add(time.Minute)
+	id := testsutil.GenerateUUID(t)
+	newID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc string
+		kind string
+		name string
+		md   re.Metadata
+		res  re.Metadata
+	}{
+		{
+			desc: "save stream metadata",
+			kind: re.StreamKind,
+			name: "u1234_stream",
+			md:   re.Metadata{ID: id, Owner: owner, Description: "stream", Labels: map[string]string{"site": "a"}, CreatedAt: created, Definition: `{"name":"stream"}`},
+			res:  re.Metadata{ID: id, Owner: owner, Description: "stream", Labels: map[string]string{"site": "a"}, CreatedAt: created, Definition: `{"name":"stream"}`},
+		},
+		{
+			desc: "save rule metadata with the stream name",
+			kind: re.RuleKind,
+			name: "u1234_stream",
+			md:   re.Metadata{Owner: owner, CreatedAt: created},
+			res:  re.Metadata{Owner: owner, CreatedAt: created},
+		},
+		{
+			desc: "save stopped rule metadata",
+			kind: re.RuleKind,
+			name: "u1234_stream",
+			md:   re.Metadata{Owner: owner, CreatedAt: created, Stopped: true},
+			res:  re.Metadata{Owner: owner, CreatedAt: created, Stopped: true},
+		},
+		{
+			desc: "save started rule metadata",
+			kind: re.RuleKind,
+			name: "u1234_started",
+			md:   re.Metadata{Owner: owner, CreatedAt: created, LastStartedAt: created.Add(time.Hour)},
+			res:  re.Metadata{Owner: owner, CreatedAt: created, LastStartedAt: created.Add(time.Hour)},
+		},
+		{
+			desc: "save rule metadata with attributes",
+			kind: re.RuleKind,
+			name: "u1234_documented",
+			md:   re.Metadata{Owner: owner, CreatedAt: created, Attributes: map[string]interface{}{"runbook": "https://example.com/runbook", "severity": 2}},
+			res:  re.Metadata{Owner: owner, CreatedAt: created, Attributes: map[string]interface{}{"runbook": "https://example.com/runbook", "severity": float64(2)}},
+		},
+		{
+			desc: "save rule metadata with folder",
+			kind: re.RuleKind,
+			name: "u1234_organized",
+			md:   re.Metadata{Owner: owner, CreatedAt: created, Folder: "site_a"},
+			res:  re.Metadata{Owner: owner, CreatedAt: created, Folder: "site_a"},
+		},
+		{
+			desc: "save temporary rule metadata",
+			kind: re.RuleKind,
+			name: "u1234_temporary",
+			md:   re.Metadata{Owner: owner, CreatedAt: created, ExpiresAt: created.Add(2 * time.Hour)},
+			res:  re.Metadata{Owner: owner, CreatedAt: created, ExpiresAt: created.Add(2 * time.Hour)},
+		},
+		{
+			desc: "save scheduled rule metadata",
+			kind: re.RuleKind,
+			name: "u1234_scheduled",
+			md:   re.Metadata{Owner: owner, CreatedAt: created, Schedule: &re.Schedule{Windows: []re.Window{{Days: []string{"mon"}, Start: "09:00", End: "17:00"}}}},
+			res:  re.Metadata{Owner: owner, CreatedAt: created, Schedule: &re.Schedule{Windows: []re.Window{{Days: []string{"mon"}, Start: "09:00", End: "17:00"}}}},
+		},
+		{
+			desc: "save draft rule metadata",
+			kind: re.RuleKind,
+			name: "u1234_draft",
+			md:   re.Metadata{Owner: owner, CreatedAt: created, Draft: true, Definition: `{"id":"draft"}`},
+			res:  re.Metadata{Owner: owner, CreatedAt: created, Draft: true, Definition: `{"id":"draft"}`},
+		},
+		{
+			desc: "update stream metadata",
+			kind: re.StreamKind,
+			name: "u1234_stream",
+			md:   re.Metadata{ID: newID, Owner: owner, Description: "updated", CreatedAt: updated, UpdatedAt: updated},
+			res:  re.Metadata{ID: id, Owner: owner, Description: "updated", CreatedAt: created, UpdatedAt: updated},
+		},
+		{
+			desc: "recreate stream metadata",
+			kind: re.StreamKind,
+			name: "u1234_stream",
+			md:   re.Metadata{ID: newID, Owner: owner, CreatedAt: updated},
+			res:  re.Metadata{ID: newID, Owner: owner, CreatedAt: updated},
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.kind, tc.name, tc.md)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		md, err := repo.Retrieve(context.Background(), tc.kind, tc.name)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.res, md, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, md))
+	}
+}
+
+func TestMetadataRetrieveAll(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM metadata")
+		require.Nil(t, err, fmt.Sprintf("clean metadata unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	owner := testsutil.GenerateUUID(t)
+	md := re.Metadata{Owner: owner, CreatedAt: time.Now().UTC().Truncate(time.Microsecond)}
+	for _, name := range []string{"u1234_a", "u1234_b"} {
+		err := repo.Save(context.Background(), re.StreamKind, name, md)
+		require.Nil(t, err, fmt.Sprintf("save metadata unexpected error: %s", err))
+	}
+	err := repo.Save(context.Background(), re.RuleKind, "u1234_c", md)
+	require.Nil(t, err, fmt.Sprintf("save metadata unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		kind  string
+		owner string
+		res   map[string]re.Metadata
+	}{
+		{
+			desc:  "retrieve streams metadata",
+			kind:  re.StreamKind,
+			owner: owner,
+			res:   map[string]re.Metadata{"u1234_a": md, "u1234_b": md},
+		},
+		{
+			desc:  "retrieve rules metadata",
+			kind:  re.RuleKind,
+			owner: owner,
+			res:   map[string]re.Metadata{"u1234_c": md},
+		},
+		{
+			desc:  "retrieve metadata of other owner",
+			kind:  re.StreamKind,
+			owner: testsutil.GenerateUUID(t),
+			res:   map[string]re.Metadata{},
+		},
+		{
+			desc: "retrieve metadata of all owners",
+			kind: re.StreamKind,
+			res:  map[string]re.Metadata{"u1234_a": md, "u1234_b": md},
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := repo.RetrieveAll(context.Background(), tc.kind, tc.owner)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+	}
+}
+
+func TestMetadataRemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM metadata")
+		require.Nil(t, err, fmt.Sprintf("clean metadata unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	md := re.Metadata{Owner: testsutil.GenerateUUID(t), CreatedAt: time.Now().UTC()}
+	err := repo.Save(context.Background(), re.RuleKind, "u1234_rule", md)
+	require.Nil(t, err, fmt.Sprintf("save metadata unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		kind string
+		name string
+		err  error
+	}{
+		{
+			desc: "remove metadata of other kind",
+			kind: re.StreamKind,
+			name: "u1234_rule",
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "remove metadata",
+			kind: re.RuleKind,
+			name: "u1234_rule",
+		},
+		{
+			desc: "remove removed metadata",
+			kind: re.RuleKind,
+			name: "u1234_rule",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(context.Background(), tc.kind, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	_, err = repo.Retrieve(context.Background(), re.RuleKind, "u1234_rule")
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve removed metadata: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
+
+func TestMetadataTouch(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM metadata")
+		require.Nil(t, err, fmt.Sprintf("clean metadata unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	created := time.Now().UTC().Truncate(time.Microsecond)
+	touched := created.Add(time.Minute)
+	md := re.Metadata{Owner: testsutil.GenerateUUID(t), CreatedAt: created}
+	err := repo.Save(context.Background(), re.RuleKind, "u1234_rule", md)
+	require.Nil(t, err, fmt.Sprintf("save metadata unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		name    string
+		updated time.Time
+		err     error
+	}{
+		{
+			desc:    "touch metadata",
+			name:    "u1234_rule",
+			updated: created,
+		},
+		{
+			desc:    "touch metadata with stale update time",
+			name:    "u1234_rule",
+			updated: created,
+			err:     repoerr.ErrConflict,
+		},
+		{
+			desc:    "touch missing metadata",
+			name:    "u1234_missing",
+			updated: created,
+			err:     repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Touch(context.Background(), re.RuleKind, tc.name, tc.updated, touched)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	res, err := repo.Retrieve(context.Background(), re.RuleKind, "u1234_rule")
+	assert.Nil(t, err, fmt.Sprintf("retrieve metadata unexpected error: %s", err))
+	assert.Equal(t, touched, res.UpdatedAt, fmt.Sprintf("expected update time %s got %s", touched, res.UpdatedAt))
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveQuota(ctx context.Context, userID string, q re.Quota) error {
+	query := `INSERT INTO quotas (user_id, max_streams, max_rules)
+		VALUES (:user_id, :max_streams, :max_rules)
+		ON CONFLICT (user_id) DO UPDATE SET max_streams = EXCLUDED.max_streams, max_rules = EXCLUDED.max_rules`
+
+	dbq := dbQuota{UserID: userID, MaxStreams: q.MaxStreams, MaxRules: q.MaxRules}
+	if _, err := repo.db.NamedExecContext(ctx, query, dbq); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveQuota(ctx context.Context, userID string) (re.Quota, error) {
+	query := `SELECT user_id, max_streams, max_rules FROM quotas WHERE user_id = :user_id`
+
+	rows, err := repo.db.NamedQueryContext(ctx, query, dbQuota{UserID: userID})
+	if err != nil {
+		return re.Quota{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return re.Quota{}, repoerr.ErrNotFound
+	}
+	var dbq dbQuota
+	if err := rows.StructScan(&dbq); err != nil {
+		return re.Quota{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return re.Quota{MaxStreams: dbq.MaxStreams, MaxRules: dbq.MaxRules}, nil
+}
+
+func (repo *repository) RemoveQuota(ctx context.Context, userID string) error {
+	query := `DELETE FROM quotas WHERE user_id = $1`
+
+	res, err := repo.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbQuota struct {
+	UserID     string `db:"user_id"`
+	MaxStreams int    `db:"max_streams"`
+	MaxRules   int    `db:"max_rules"`
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const quotaUserID = "6f8a2b1c-3d4e-4f50-8a9b-0c1d2e3f4a5b"
+
+func TestQuotaSave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM quotas")
+		require.Nil(t, err, fmt.Sprintf("clean quotas unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	cases := []struct {
+		desc  string
+		quota re.Quota
+	}{
+		{
+			desc:  "save quota",
+			quota: re.Quota{MaxStreams: 10, MaxRules: 20},
+		},
+		{
+			desc:  "replace quota",
+			quota: re.Quota{MaxRules: 5},
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveQuota(context.Background(), quotaUserID, tc.quota)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		q, err := repo.RetrieveQuota(context.Background(), quotaUserID)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.quota, q, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.quota, q))
+	}
+}
+
+func TestQuotaRemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM quotas")
+		require.Nil(t, err, fmt.Sprintf("clean quotas unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	err := repo.SaveQuota(context.Background(), quotaUserID, re.Quota{MaxRules: 5})
+	require.Nil(t, err, fmt.Sprintf("save quota unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			desc: "remove quota",
+		},
+		{
+			desc: "remove removed quota",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.RemoveQuota(context.Background(), quotaUserID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	_, err = repo.RetrieveQuota(context.Background(), quotaUserID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve removed quota: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveSecret(ctx context.Context, s re.Secret) error {
+	q := `INSERT INTO secrets (owner, name, value, created_at, updated_at)
+		VALUES (:owner, :name, :value, :created_at, :updated_at)
+		ON CONFLICT (owner, name) DO UPDATE SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBSecret(s)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveSecrets(ctx context.Context, owner string) ([]re.Secret, error) {
+	q := `SELECT owner, name, value, created_at, updated_at FROM secrets WHERE owner = :owner`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbSecret{Owner: owner})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	secrets := []re.Secret{}
+	for rows.Next() {
+		var dbs dbSecret
+		if err := rows.StructScan(&dbs); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		secrets = append(secrets, toSecret(dbs))
+	}
+
+	return secrets, nil
+}
+
+func (repo *repository) RemoveSecret(ctx context.Context, owner, name string) error {
+	q := `DELETE FROM secrets WHERE owner = $1 AND name = $2`
+
+	res, err := repo.db.ExecContext(ctx, q, owner, name)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbSecret struct {
+	Owner     string    `db:"owner"`
+	Name      string    `db:"name"`
+	Value     []byte    `db:"value"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func toDBSecret(s re.Secret) dbSecret {
+	return dbSecret{
+		Owner:     s.Owner,
+		Name:      s.Name,
+		Value:     s.Ciphertext,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func toSecret(dbs dbSecret) re.Secret {
+	return re.Secret{
+		Owner:      dbs.Owner,
+		Name:       dbs.Name,
+		Ciphertext: dbs.Value,
+		CreatedAt:  dbs.CreatedAt,
+		UpdatedAt:  dbs.UpdatedAt,
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecrets(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM secrets")
+		require.Nil(t, err, fmt.Sprintf("clean secrets unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	secrets := []re.Secret{
+		{Owner: alertOwnerID, Name: "token", Ciphertext: []byte("sealed"), CreatedAt: now, UpdatedAt: now},
+		{Owner: quotaUserID, Name: "token", Ciphertext: []byte("other"), CreatedAt: now, UpdatedAt: now},
+	}
+	for _, s := range secrets {
+		err := repo.SaveSecret(context.Background(), s)
+		assert.Nil(t, err, fmt.Sprintf("save secret: expected no error got %s\n", err))
+	}
+
+	replaced := secrets[0]
+	replaced.Ciphertext = []byte("resealed")
+	replaced.CreatedAt = now.Add(time.Hour)
+	replaced.UpdatedAt = now.Add(time.Hour)
+	err := repo.SaveSecret(context.Background(), replaced)
+	assert.Nil(t, err, fmt.Sprintf("replace secret: expected no error got %s\n", err))
+
+	saved, err := repo.RetrieveSecrets(context.Background(), alertOwnerID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve secrets: expected no error got %s\n", err))
+	replaced.CreatedAt = now
+	assert.Equal(t, []re.Secret{replaced}, saved, fmt.Sprintf("expected secrets %v got %v\n", []re.Secret{replaced}, saved))
+
+	err = repo.RemoveSecret(context.Background(), alertOwnerID, "token")
+	assert.Nil(t, err, fmt.Sprintf("remove secret: expected no error got %s\n", err))
+	err = repo.RemoveSecret(context.Background(), alertOwnerID, "token")
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("remove removed secret: expected %s got %s\n", repoerr.ErrNotFound, err))
+	saved, err = repo.RetrieveSecrets(context.Background(), quotaUserID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve secrets of other owner: expected no error got %s\n", err))
+	assert.Equal(t, secrets[1:], saved, fmt.Sprintf("expected secrets %v got %v\n", secrets[1:], saved))
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveShare(ctx context.Context, kind, name string, s re.Share) error {
+	query := `INSERT INTO shares (kind, name, grantee, grantee_type, access)
+		VALUES (:kind, :name, :grantee, :grantee_type, :access)
+		ON CONFLICT (kind, name, grantee) DO UPDATE SET grantee_type = EXCLUDED.grantee_type, access = EXCLUDED.access`
+
+	dbs := dbShare{Kind: kind, Name: name, Grantee: s.Grantee, GranteeType: s.GranteeType, Access: s.Access}
+	if _, err := repo.db.NamedExecContext(ctx, query, dbs); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveShares(ctx context.Context, kind, name string) ([]re.Share, error) {
+	query := `SELECT kind, name, grantee, grantee_type, access FROM shares
+		WHERE kind = :kind AND name = :name ORDER BY grantee`
+
+	rows, err := repo.db.NamedQueryContext(ctx, query, dbShare{Kind: kind, Name: name})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	shares := []re.Share{}
+	for rows.Next() {
+		var dbs dbShare
+		if err := rows.StructScan(&dbs); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		shares = append(shares, re.Share{Grantee: dbs.Grantee, GranteeType: dbs.GranteeType, Access: dbs.Access})
+	}
+
+	return shares, nil
+}
+
+func (repo *repository) RemoveShare(ctx context.Context, kind, name, grantee string) error {
+	query := `DELETE FROM shares WHERE kind = $1 AND name = $2 AND grantee = $3`
+
+	res, err := repo.db.ExecContext(ctx, query, kind, name, grantee)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *repository) RemoveShares(ctx context.Context, kind, name string) error {
+	query := `DELETE FROM shares WHERE kind = $1 AND name = $2`
+
+	if _, err := repo.db.ExecContext(ctx, query, kind, name); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbShare struct {
+	Kind        string `db:"kind"`
+	Name        string `db:"name"`
+	Grantee     string `db:"grantee"`
+	GranteeType string `db:"grantee_type"`
+	Access      string `db:"access"`
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	shareName    = "u6f8a2b1c3d4e4f508a9b0c1d2e3f4a5b_stream"
+	shareGrantee = "0e1f2a3b-4c5d-4e6f-8a7b-9c0d1e2f3a4b"
+)
+
+func TestShareSave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM shares")
+		require.Nil(t, err, fmt.Sprintf("clean shares unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	cases := []struct {
+		desc  string
+		share re.Share
+	}{
+		{
+			desc:  "save share",
+			share: re.Share{Grantee: shareGrantee, GranteeType: re.UserGrantee, Access: re.ViewAccess},
+		},
+		{
+			desc:  "replace share",
+			share: re.Share{Grantee: shareGrantee, GranteeType: re.UserGrantee, Access: re.ManageAccess},
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveShare(context.Background(), re.StreamKind, shareName, tc.share)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		shares, err := repo.RetrieveShares(context.Background(), re.StreamKind, shareName)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, []re.Share{tc.share}, shares, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.share, shares))
+	}
+}
+
+func TestShareRemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM shares")
+		require.Nil(t, err, fmt.Sprintf("clean shares unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	share := re.Share{Grantee: shareGrantee, GranteeType: re.GroupGrantee, Access: re.ViewAccess}
+	err := repo.SaveShare(context.Background(), re.RuleKind, shareName, share)
+	require.Nil(t, err, fmt.Sprintf("save share unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			desc: "remove share",
+		},
+		{
+			desc: "remove removed share",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.RemoveShare(context.Background(), re.RuleKind, shareName, shareGrantee)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = repo.SaveShare(context.Background(), re.RuleKind, shareName, share)
+	require.Nil(t, err, fmt.Sprintf("save share unexpected error: %s", err))
+	err = repo.RemoveShares(context.Background(), re.RuleKind, shareName)
+	assert.Nil(t, err, fmt.Sprintf("remove shares: expected no error got %s\n", err))
+	shares, err := repo.RetrieveShares(context.Background(), re.RuleKind, shareName)
+	assert.Nil(t, err, fmt.Sprintf("retrieve removed shares: expected no error got %s\n", err))
+	assert.Empty(t, shares, fmt.Sprintf("retrieve removed shares: expected no shares got %v\n", shares))
+}
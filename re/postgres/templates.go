@@ -0,0 +1,142 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveTemplate(ctx context.Context, tmpl re.Template) error {
+	q := `INSERT INTO templates (name, description, definition, created_at)
+		VALUES (:name, :description, :definition, :created_at)`
+
+	dbt, err := toDBTemplate(tmpl)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbt); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveTemplate(ctx context.Context, name string) (re.Template, error) {
+	q := `SELECT name, description, definition, created_at FROM templates WHERE name = :name`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbTemplate{Name: name})
+	if err != nil {
+		return re.Template{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return re.Template{}, repoerr.ErrNotFound
+	}
+	var dbt dbTemplate
+	if err := rows.StructScan(&dbt); err != nil {
+		return re.Template{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toTemplate(dbt)
+}
+
+func (repo *repository) RetrieveAllTemplates(ctx context.Context) ([]re.Template, error) {
+	q := `SELECT name, description, definition, created_at FROM templates ORDER BY name`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbTemplate{})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	tmpls := []re.Template{}
+	for rows.Next() {
+		var dbt dbTemplate
+		if err := rows.StructScan(&dbt); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		tmpl, err := toTemplate(dbt)
+		if err != nil {
+			return nil, err
+		}
+		tmpls = append(tmpls, tmpl)
+	}
+
+	return tmpls, nil
+}
+
+func (repo *repository) RemoveTemplate(ctx context.Context, name string) error {
+	q := `DELETE FROM templates WHERE name = $1`
+
+	res, err := repo.db.ExecContext(ctx, q, name)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbTemplate struct {
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	Definition  []byte         `db:"definition"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// templateDefinition is the stored part of the template, other than its
+// name, description and creation time.
+type templateDefinition struct {
+	Variables []re.Variable   `json:"variables"`
+	SQL       string          `json:"sql"`
+	Actions   []re.Action     `json:"actions"`
+	Options   *re.RuleOptions `json:"options,omitempty"`
+}
+
+func toDBTemplate(tmpl re.Template) (dbTemplate, error) {
+	def, err := json.Marshal(templateDefinition{
+		Variables: tmpl.Variables,
+		SQL:       tmpl.SQL,
+		Actions:   tmpl.Actions,
+		Options:   tmpl.Options,
+	})
+	if err != nil {
+		return dbTemplate{}, err
+	}
+
+	return dbTemplate{
+		Name:        tmpl.Name,
+		Description: sql.NullString{String: tmpl.Description, Valid: tmpl.Description != ""},
+		Definition:  def,
+		CreatedAt:   tmpl.CreatedAt,
+	}, nil
+}
+
+func toTemplate(dbt dbTemplate) (re.Template, error) {
+	var def templateDefinition
+	if err := json.Unmarshal(dbt.Definition, &def); err != nil {
+		return re.Template{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return re.Template{
+		Name:        dbt.Name,
+		Description: dbt.Description.String,
+		Variables:   def.Variables,
+		SQL:         def.SQL,
+		Actions:     def.Actions,
+		Options:     def.Options,
+		CreatedAt:   dbt.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateSave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM templates")
+		require.Nil(t, err, fmt.Sprintf("clean templates unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	tmpl := re.Template{
+		Name:        "threshold",
+		Description: "threshold alarm",
+		Variables:   []re.Variable{{Name: "threshold", Type: re.NumberVariable, Default: "30"}},
+		SQL:         "SELECT * FROM stream WHERE v > {threshold}",
+		Actions:     []re.Action{{Log: &re.LogSink{}}},
+		CreatedAt:   time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	cases := []struct {
+		desc string
+		tmpl re.Template
+		err  error
+	}{
+		{
+			desc: "save template",
+			tmpl: tmpl,
+		},
+		{
+			desc: "save existing template",
+			tmpl: tmpl,
+			err:  repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveTemplate(context.Background(), tc.tmpl)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	res, err := repo.RetrieveTemplate(context.Background(), tmpl.Name)
+	assert.Nil(t, err, fmt.Sprintf("retrieve template: expected no error got %s\n", err))
+	assert.Equal(t, tmpl, res, fmt.Sprintf("retrieve template: expected %v got %v\n", tmpl, res))
+}
+
+func TestTemplateRetrieveAll(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM templates")
+		require.Nil(t, err, fmt.Sprintf("clean templates unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	res, err := repo.RetrieveAllTemplates(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("retrieve templates: expected no error got %s\n", err))
+	assert.Empty(t, res, fmt.Sprintf("retrieve templates: expected no templates got %v\n", res))
+
+	var tmpls []re.Template
+	for _, name := range []string{"b", "a"} {
+		tmpl := re.Template{
+			Name:      name,
+			SQL:       "SELECT * FROM stream",
+			Actions:   []re.Action{{Log: &re.LogSink{}}},
+			CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		}
+		err := repo.SaveTemplate(context.Background(), tmpl)
+		require.Nil(t, err, fmt.Sprintf("save template unexpected error: %s", err))
+		tmpls = append([]re.Template{tmpl}, tmpls...)
+	}
+
+	res, err = repo.RetrieveAllTemplates(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("retrieve templates: expected no error got %s\n", err))
+	assert.Equal(t, tmpls, res, fmt.Sprintf("retrieve templates: expected %v got %v\n", tmpls, res))
+}
+
+func TestTemplateRemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM templates")
+		require.Nil(t, err, fmt.Sprintf("clean templates unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	tmpl := re.Template{Name: "threshold", SQL: "SELECT * FROM stream", Actions: []re.Action{{Log: &re.LogSink{}}}, CreatedAt: time.Now().UTC()}
+	err := repo.SaveTemplate(context.Background(), tmpl)
+	require.Nil(t, err, fmt.Sprintf("save template unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		name string
+		err  error
+	}{
+		{
+			desc: "remove template",
+			name: tmpl.Name,
+		},
+		{
+			desc: "remove removed template",
+			name: tmpl.Name,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.RemoveTemplate(context.Background(), tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	_, err = repo.RetrieveTemplate(context.Background(), tmpl.Name)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve removed template: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
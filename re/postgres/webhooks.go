@@ -0,0 +1,119 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/internal/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+)
+
+func (repo *repository) SaveWebhook(ctx context.Context, wh re.Webhook) error {
+	q := `INSERT INTO webhooks (id, owner, url, secret, events, created_at)
+		VALUES (:id, :owner, :url, :secret, :events, :created_at)`
+
+	dbwh, err := toDBWebhook(wh)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dbwh); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *repository) RetrieveWebhooks(ctx context.Context, owner string) ([]re.Webhook, error) {
+	q := `SELECT id, owner, url, secret, events, created_at FROM webhooks`
+	if owner != "" {
+		q += ` WHERE owner = :owner`
+	}
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbWebhook{Owner: owner})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	whs := []re.Webhook{}
+	for rows.Next() {
+		var dbwh dbWebhook
+		if err := rows.StructScan(&dbwh); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		wh, err := toWebhook(dbwh)
+		if err != nil {
+			return nil, err
+		}
+		whs = append(whs, wh)
+	}
+
+	return whs, nil
+}
+
+func (repo *repository) RemoveWebhook(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM webhooks WHERE owner = $1 AND id = $2`
+
+	res, err := repo.db.ExecContext(ctx, q, owner, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbWebhook struct {
+	ID        string    `db:"id"`
+	Owner     string    `db:"owner"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	Events    []byte    `db:"events"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBWebhook(wh re.Webhook) (dbWebhook, error) {
+	var events []byte
+	if len(wh.Events) > 0 {
+		b, err := json.Marshal(wh.Events)
+		if err != nil {
+			return dbWebhook{}, err
+		}
+		events = b
+	}
+
+	return dbWebhook{
+		ID:        wh.ID,
+		Owner:     wh.Owner,
+		URL:       wh.URL,
+		Secret:    wh.Secret,
+		Events:    events,
+		CreatedAt: wh.CreatedAt,
+	}, nil
+}
+
+func toWebhook(dbwh dbWebhook) (re.Webhook, error) {
+	var events []string
+	if dbwh.Events != nil {
+		if err := json.Unmarshal(dbwh.Events, &events); err != nil {
+			return re.Webhook{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
+
+	return re.Webhook{
+		ID:        dbwh.ID,
+		Owner:     dbwh.Owner,
+		URL:       dbwh.URL,
+		Secret:    dbwh.Secret,
+		Events:    events,
+		CreatedAt: dbwh.CreatedAt,
+	}, nil
+}
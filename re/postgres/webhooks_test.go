@@ -0,0 +1,49 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooks(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM webhooks")
+		require.Nil(t, err, fmt.Sprintf("clean webhooks unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	whs := []re.Webhook{
+		{ID: "2f0e4c1a-3b5d-4e6f-8a9b-0c1d2e3f4a5b", Owner: alertOwnerID, URL: "https://example.com/hooks", Secret: "secret", Events: []string{re.EventRuleErrored}, CreatedAt: now},
+		{ID: "3a1f5d2b-4c6e-4f70-9bac-1d2e3f4a5b6c", Owner: quotaUserID, URL: "https://example.com/ci", Secret: "other", CreatedAt: now},
+	}
+	for _, wh := range whs {
+		err := repo.SaveWebhook(context.Background(), wh)
+		assert.Nil(t, err, fmt.Sprintf("save webhook: expected no error got %s\n", err))
+	}
+
+	saved, err := repo.RetrieveWebhooks(context.Background(), alertOwnerID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve webhooks: expected no error got %s\n", err))
+	assert.Equal(t, whs[:1], saved, fmt.Sprintf("expected webhooks %v got %v\n", whs[:1], saved))
+	all, err := repo.RetrieveWebhooks(context.Background(), "")
+	assert.Nil(t, err, fmt.Sprintf("retrieve all webhooks: expected no error got %s\n", err))
+	assert.ElementsMatch(t, whs, all, fmt.Sprintf("expected webhooks %v got %v\n", whs, all))
+
+	err = repo.RemoveWebhook(context.Background(), quotaUserID, whs[0].ID)
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("remove webhook of other owner: expected %s got %s\n", repoerr.ErrNotFound, err))
+	err = repo.RemoveWebhook(context.Background(), alertOwnerID, whs[0].ID)
+	assert.Nil(t, err, fmt.Sprintf("remove webhook: expected no error got %s\n", err))
+	err = repo.RemoveWebhook(context.Background(), alertOwnerID, whs[0].ID)
+	assert.Equal(t, repoerr.ErrNotFound, err, fmt.Sprintf("remove removed webhook: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
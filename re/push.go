@@ -0,0 +1,171 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/absmach/magistrala"
+	mgauth "github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// ingestPath is the path of the rules engine API the things push the
+// messages of the httppush streams to, followed by the Kuiper name of the
+// stream.
+const ingestPath = "/ingest/"
+
+// ErrMessageTooLarge indicates that the message pushed to the httppush
+// stream exceeds the maximum size.
+var ErrMessageTooLarge = errors.New("message exceeds maximum size")
+
+var (
+	errPushDisabled = errors.New("httppush streams are disabled")
+	errPushConfig   = errors.New("invalid httppush configuration")
+	errPushStream   = errors.New("stream isn't httppush stream")
+	errPushEndpoint = errors.New("stream isn't read by any running rule")
+)
+
+// PushConfig defines the httppush streams. URL is the Kuiper httppush
+// server URL as reached from the rules engine, which the messages the things
+// push to the stream ingestion URLs are forwarded to. The pool instances
+// are reached on the same port of their hosts. IngestURL is the rules
+// engine HTTP API URL as reached from the things, which the ingestion URLs
+// are built from, and MaxSize limits the size of the pushed messages. The
+// httppush streams are disabled if the URL is empty.
+type PushConfig struct {
+	URL       string `env:"URL"        envDefault:""`
+	IngestURL string `env:"INGEST_URL" envDefault:""`
+	MaxSize   int    `env:"MAX_SIZE"   envDefault:"1048576"`
+}
+
+// Validate checks that the Kuiper httppush server URL is absolute and the
+// messages can be pushed.
+func (c PushConfig) Validate() error {
+	if c.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return errors.Wrap(errPushConfig, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Wrap(errPushConfig, errors.New("URL must be absolute http or https URL"))
+	}
+	if c.MaxSize <= 0 {
+		return errors.Wrap(errPushConfig, errors.New("maximum size must be positive"))
+	}
+
+	return nil
+}
+
+// instance returns the configuration of the pool instance with the given
+// API URL, whose httppush server is on the instance host.
+func (c PushConfig) instance(apiURL string) PushConfig {
+	if c.URL == "" {
+		return c
+	}
+	api, err := url.Parse(apiURL)
+	if err != nil {
+		c.URL = ""
+		return c
+	}
+	push, err := url.Parse(c.URL)
+	if err != nil {
+		c.URL = ""
+		return c
+	}
+	push.Host = api.Hostname()
+	if port := push.Port(); port != "" {
+		push.Host = net.JoinHostPort(api.Hostname(), port)
+	}
+	c.URL = push.String()
+
+	return c
+}
+
+// pushPath returns the path of the Kuiper push endpoint of the stream with
+// the given Kuiper name.
+func pushPath(kuiperName string) string {
+	return "/" + kuiperName
+}
+
+// ingestURL returns the URL the things push the messages of the stream with
+// the given Kuiper name to.
+func (c PushConfig) ingestURL(kuiperName string) string {
+	return strings.TrimSuffix(c.IngestURL, "/") + ingestPath + kuiperName
+}
+
+func (svc *reService) Ingest(ctx context.Context, key, stream string, payload []byte) error {
+	if svc.push.URL == "" {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errPushDisabled)
+	}
+	if err := validateName(stream); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if len(payload) > svc.push.MaxSize {
+		return errors.Wrap(svcerr.ErrMalformedEntity, ErrMessageTooLarge)
+	}
+	thingID, sdkErr := svc.sdk.IdentifyThing(key)
+	if sdkErr != nil {
+		return errors.Wrap(svcerr.ErrAuthentication, sdkErr)
+	}
+	channel, err := svc.pushChannel(ctx, stream)
+	if err != nil {
+		return err
+	}
+	if err := svc.authorizePublish(ctx, thingID, channel); err != nil {
+		return err
+	}
+
+	return svc.engine.Push(ctx, stream, payload)
+}
+
+// pushChannel returns the channel of the httppush stream with the given
+// Kuiper name. Streams of the other types are reported as missing, so
+// they can't be fed through the ingestion URLs.
+func (svc *reService) pushChannel(ctx context.Context, stream string) (string, error) {
+	md, err := svc.metadata(ctx, StreamKind, stream)
+	if err != nil {
+		return "", err
+	}
+	definition := md.definition()
+	if definition == "" {
+		return "", errors.Wrap(svcerr.ErrNotFound, errPushStream)
+	}
+	var def StreamDef
+	if err := json.Unmarshal([]byte(definition), &def); err != nil {
+		return "", errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if def.Type != HTTPPushSource {
+		return "", errors.Wrap(svcerr.ErrNotFound, errPushStream)
+	}
+
+	return def.Topic, nil
+}
+
+// authorizePublish checks that the thing can publish to the channel, as
+// the things do through the protocol adapters.
+func (svc *reService) authorizePublish(ctx context.Context, thingID, channel string) error {
+	res, err := svc.auth.Authorize(ctx, &magistrala.AuthorizeReq{
+		SubjectType: mgauth.GroupType,
+		Subject:     channel,
+		Permission:  mgauth.PublishPermission,
+		ObjectType:  mgauth.ThingType,
+		Object:      thingID,
+	})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	thingKey     = "thingKey"
+	thingID      = "5d2f4c1a-7b8e-4a9f-9c0d-1e2f3a4b5c6d"
+	otherThingID = "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+)
+
+func TestIngest(t *testing.T) {
+	k, url := newKuiper(t)
+	auth := new(authmocks.AuthClient)
+	sdk := new(sdkmocks.SDK)
+	cfg := re.Config{URL: url, Push: re.PushConfig{URL: url, IngestURL: "https://re.example.com/", MaxSize: 64}}
+	svc := re.New(cfg, auth, sdk, re.Notifiers{}, nil, mocks.NewRepository())
+
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := authorizeChannel(auth, validToken, channelID, true)
+	defer authCall1.Unset()
+	authCall2 := authorizePublish(auth, thingID, true)
+	defer authCall2.Unset()
+	authCall3 := authorizePublish(auth, otherThingID, false)
+	defer authCall3.Unset()
+	sdkCall := sdk.On("IdentifyThing", thingKey).Return(thingID, nil)
+	defer sdkCall.Unset()
+	sdkCall1 := sdk.On("IdentifyThing", "otherKey").Return(otherThingID, nil)
+	defer sdkCall1.Unset()
+	sdkCall2 := sdk.On("IdentifyThing", "invalidKey").Return("", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized))
+	defer sdkCall2.Unset()
+
+	fields := []re.Field{{Name: "v", Type: re.FloatType}}
+	_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "webhook", Topic: channelID, Fields: fields, Type: re.HTTPPushSource}, false)
+	assert.Nil(t, err, fmt.Sprintf("create httppush stream: expected no error got %s\n", err))
+	_, err = svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "readings", Topic: channelID, Fields: fields}, false)
+	assert.Nil(t, err, fmt.Sprintf("create mainflux stream: expected no error got %s\n", err))
+
+	stream, err := svc.ViewStream(context.Background(), validToken, "webhook")
+	assert.Nil(t, err, fmt.Sprintf("view httppush stream: expected no error got %s\n", err))
+	assert.Equal(t, "https://re.example.com/ingest/"+userPrefix+"webhook", stream.IngestURL, fmt.Sprintf("view httppush stream: expected ingestion URL got %s\n", stream.IngestURL))
+	stream, err = svc.ViewStream(context.Background(), validToken, "readings")
+	assert.Nil(t, err, fmt.Sprintf("view mainflux stream: expected no error got %s\n", err))
+	assert.Empty(t, stream.IngestURL, fmt.Sprintf("view mainflux stream: expected no ingestion URL got %s\n", stream.IngestURL))
+
+	cases := []struct {
+		desc    string
+		key     string
+		stream  string
+		payload string
+		err     error
+	}{
+		{
+			desc:    "ingest message",
+			key:     thingKey,
+			stream:  userPrefix + "webhook",
+			payload: `{"v": 21.5}`,
+		},
+		{
+			desc:    "ingest message with invalid key",
+			key:     "invalidKey",
+			stream:  userPrefix + "webhook",
+			payload: `{"v": 21.5}`,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "ingest message of thing not connected to channel",
+			key:     "otherKey",
+			stream:  userPrefix + "webhook",
+			payload: `{"v": 21.5}`,
+			err:     svcerr.ErrAuthorization,
+		},
+		{
+			desc:    "ingest message to mainflux stream",
+			key:     thingKey,
+			stream:  userPrefix + "readings",
+			payload: `{"v": 21.5}`,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:    "ingest message to missing stream",
+			key:     thingKey,
+			stream:  userPrefix + "missing",
+			payload: `{"v": 21.5}`,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:    "ingest message to malformed stream",
+			key:     thingKey,
+			stream:  "../rules",
+			payload: `{"v": 21.5}`,
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "ingest too large message",
+			key:     thingKey,
+			stream:  userPrefix + "webhook",
+			payload: `{"v": "` + strings.Repeat("a", 64) + `"}`,
+			err:     svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		delete(k.pushed, tc.stream)
+		err := svc.Ingest(context.Background(), tc.key, tc.stream, []byte(tc.payload))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.payload, string(k.pushed[tc.stream]), fmt.Sprintf("%s: expected message pushed to Kuiper got %s\n", tc.desc, k.pushed[tc.stream]))
+			continue
+		}
+		assert.Nil(t, k.pushed[tc.stream], fmt.Sprintf("%s: expected no message pushed to Kuiper got %s\n", tc.desc, k.pushed[tc.stream]))
+	}
+}
+
+func TestIngestDisabled(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	def := re.StreamDef{Name: "webhook", Topic: channelID, Fields: []re.Field{{Name: "v", Type: re.FloatType}}, Type: re.HTTPPushSource}
+	_, err := svc.CreateStream(context.Background(), validToken, def, false)
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("create httppush stream without push URL: expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+
+	err = svc.Ingest(context.Background(), thingKey, userPrefix+"webhook", []byte(`{"v": 21.5}`))
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("ingest without push URL: expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+}
+
+// authorizePublish mocks the check of the thing's permission to publish to
+// the channel.
+func authorizePublish(auth *authmocks.AuthClient, thing string, authorized bool) *mock.Call {
+	return auth.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+		SubjectType: "group",
+		Subject:     channelID,
+		Permission:  "publish",
+		ObjectType:  "thing",
+		Object:      thing,
+	}).Return(&magistrala.AuthorizeRes{Authorized: authorized}, nil)
+}
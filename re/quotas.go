@@ -0,0 +1,164 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var (
+	errNegativeQuota = errors.New("quota limits must not be negative")
+	errStreamQuota   = errors.New("stream limit reached")
+	errRuleQuota     = errors.New("rule limit reached")
+)
+
+// QuotaConfig defines the default limits of the streams and rules each user
+// can create. Zero means no limit.
+type QuotaConfig struct {
+	MaxStreams int `env:"MAX_STREAMS" envDefault:"0"`
+	MaxRules   int `env:"MAX_RULES"   envDefault:"0"`
+}
+
+// Quota contains the limits of the streams and rules the user can create.
+// Zero means no limit.
+type Quota struct {
+	MaxStreams int `json:"max_streams"`
+	MaxRules   int `json:"max_rules"`
+}
+
+// UserQuota is the quota of the user along with the numbers of the streams
+// and rules the user has. Override reports whether the platform
+// administrator set the quota of the user, replacing the default quota.
+type UserQuota struct {
+	UserID   string `json:"user_id"`
+	Quota    `json:",inline"`
+	Override bool `json:"override"`
+	Streams  int  `json:"streams"`
+	Rules    int  `json:"rules"`
+}
+
+// QuotaRepository specifies the persistence API of the quotas the platform
+// administrator set for the users.
+type QuotaRepository interface {
+	// SaveQuota stores the quota of the user, replacing the existing quota.
+	SaveQuota(ctx context.Context, userID string, q Quota) error
+
+	// RetrieveQuota returns the quota of the user.
+	RetrieveQuota(ctx context.Context, userID string) (Quota, error)
+
+	// RemoveQuota removes the quota of the user.
+	RemoveQuota(ctx context.Context, userID string) error
+}
+
+func (svc *reService) ViewQuota(ctx context.Context, token, userID string) (UserQuota, error) {
+	id, err := svc.identify(ctx, token)
+	if err != nil {
+		return UserQuota{}, err
+	}
+	// Users view their own quotas.
+	if id != userID {
+		if err := svc.checkAdmin(ctx, id); err != nil {
+			return UserQuota{}, err
+		}
+	}
+
+	return svc.userQuota(ctx, userID)
+}
+
+func (svc *reService) SetQuota(ctx context.Context, token, userID string, q Quota) (UserQuota, error) {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return UserQuota{}, err
+	}
+	if q.MaxStreams < 0 || q.MaxRules < 0 {
+		return UserQuota{}, errors.Wrap(svcerr.ErrMalformedEntity, errNegativeQuota)
+	}
+	if err := svc.repo.SaveQuota(ctx, userID, q); err != nil {
+		return UserQuota{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return svc.userQuota(ctx, userID)
+}
+
+func (svc *reService) RemoveQuota(ctx context.Context, token, userID string) error {
+	if err := svc.authorizeAdmin(ctx, token); err != nil {
+		return err
+	}
+
+	switch err := svc.repo.RemoveQuota(ctx, userID); {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// userQuota returns the quota of the user along with the numbers of the
+// streams and rules the user has.
+func (svc *reService) userQuota(ctx context.Context, userID string) (UserQuota, error) {
+	q, override, err := svc.quota(ctx, userID)
+	if err != nil {
+		return UserQuota{}, err
+	}
+	streams, err := svc.ownedNames(ctx, StreamKind, prefix(userID))
+	if err != nil {
+		return UserQuota{}, err
+	}
+	rules, err := svc.ownedNames(ctx, RuleKind, prefix(userID))
+	if err != nil {
+		return UserQuota{}, err
+	}
+
+	return UserQuota{UserID: userID, Quota: q, Override: override, Streams: len(streams), Rules: len(rules)}, nil
+}
+
+// quota returns the quota of the user, which is the default quota unless
+// the platform administrator set the quota of the user.
+func (svc *reService) quota(ctx context.Context, userID string) (Quota, bool, error) {
+	q, err := svc.repo.RetrieveQuota(ctx, userID)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return svc.quotas, false, nil
+	case err != nil:
+		return Quota{}, false, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return q, true, nil
+}
+
+// reserve checks that the user can create another stream or rule of the
+// kind. Creations of each user with limits are serialized until the
+// returned function is called, so concurrent creations can't exceed the
+// quota while the creations of other users proceed.
+func (svc *reService) reserve(ctx context.Context, userID, kind string) (func(), error) {
+	q, _, err := svc.quota(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	limit, errLimit := q.MaxRules, errRuleQuota
+	if kind == StreamKind {
+		limit, errLimit = q.MaxStreams, errStreamQuota
+	}
+	if limit == 0 {
+		return func() {}, nil
+	}
+
+	unlock := svc.quotaLocks.lock(userID)
+	names, err := svc.ownedNames(ctx, kind, prefix(userID))
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	if len(names) >= limit {
+		unlock()
+		return nil, errors.Wrap(ErrQuotaExceeded, errLimit)
+	}
+
+	return unlock, nil
+}
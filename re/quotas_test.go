@@ -0,0 +1,266 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQuotaEnforcement(t *testing.T) {
+	rule := re.Rule{
+		ID:      "alarm",
+		SQL:     "SELECT * FROM stream WHERE v > 30",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+	}
+	stream := re.StreamDef{Name: "alerts", Topic: channelID, SenML: true}
+
+	cases := []struct {
+		desc      string
+		cfg       re.QuotaConfig
+		quota     *re.Quota
+		streamErr error
+		ruleErr   error
+	}{
+		{
+			desc: "create without limits",
+		},
+		{
+			desc: "create below limits",
+			cfg:  re.QuotaConfig{MaxStreams: 2, MaxRules: 2},
+		},
+		{
+			desc:      "create at default limits",
+			cfg:       re.QuotaConfig{MaxStreams: 1, MaxRules: 1},
+			streamErr: re.ErrQuotaExceeded,
+			ruleErr:   re.ErrQuotaExceeded,
+		},
+		{
+			desc:  "create at default limits raised for user",
+			cfg:   re.QuotaConfig{MaxStreams: 1, MaxRules: 1},
+			quota: &re.Quota{MaxStreams: 5},
+		},
+		{
+			desc:      "create below default limits lowered for user",
+			cfg:       re.QuotaConfig{MaxStreams: 5, MaxRules: 5},
+			quota:     &re.Quota{MaxStreams: 1, MaxRules: 1},
+			streamErr: re.ErrQuotaExceeded,
+			ruleErr:   re.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		repo := mocks.NewRepository()
+		if tc.quota != nil {
+			err := repo.SaveQuota(context.Background(), userID, *tc.quota)
+			assert.Nil(t, err, fmt.Sprintf("%s: save quota: expected no error got %s\n", tc.desc, err))
+		}
+		svc, _, auth, _ := newServiceWithRepo(t, re.Config{Quota: tc.cfg}, re.Notifiers{}, repo)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		channelCall := authorizeChannel(auth, validToken, channelID, true)
+
+		_, err := svc.CreateStream(context.Background(), validToken, stream, false)
+		assert.True(t, errors.Contains(err, tc.streamErr), fmt.Sprintf("%s: create stream: expected %s got %s\n", tc.desc, tc.streamErr, err))
+		_, err = svc.CreateRule(context.Background(), validToken, rule)
+		assert.True(t, errors.Contains(err, tc.ruleErr), fmt.Sprintf("%s: create rule: expected %s got %s\n", tc.desc, tc.ruleErr, err))
+		// Updates are never limited.
+		_, err = svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "stream", Topic: channelID, SenML: true}, true)
+		assert.Nil(t, err, fmt.Sprintf("%s: update stream: expected no error got %s\n", tc.desc, err))
+		authCall.Unset()
+		channelCall.Unset()
+	}
+}
+
+func TestConcurrentQuota(t *testing.T) {
+	// The user has a single rule, so the limit allows 2 more.
+	svc, _, auth, _ := newServiceWithConfig(t, re.Config{Quota: re.QuotaConfig{MaxRules: 3}}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	created := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rule := re.Rule{
+				ID:      fmt.Sprintf("alarm%d", i),
+				SQL:     "SELECT * FROM stream WHERE v > 30",
+				Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+			}
+			_, err := svc.CreateRule(context.Background(), validToken, rule)
+			if err == nil {
+				mu.Lock()
+				created++
+				mu.Unlock()
+				return
+			}
+			assert.True(t, errors.Contains(err, re.ErrQuotaExceeded), fmt.Sprintf("create rule concurrently: expected %s got %s\n", re.ErrQuotaExceeded, err))
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 2, created, fmt.Sprintf("create rules concurrently: expected 2 rules created got %d\n", created))
+}
+
+func TestViewQuota(t *testing.T) {
+	repo := mocks.NewRepository()
+	err := repo.SaveQuota(context.Background(), otherUserID, re.Quota{MaxStreams: 10})
+	assert.Nil(t, err, fmt.Sprintf("save quota: expected no error got %s\n", err))
+
+	cases := []struct {
+		desc       string
+		token      string
+		userID     string
+		authorized bool
+		quota      re.UserQuota
+		err        error
+	}{
+		{
+			desc:   "view own default quota",
+			token:  validToken,
+			userID: userID,
+			quota:  re.UserQuota{UserID: userID, Quota: re.Quota{MaxStreams: 3, MaxRules: 4}, Streams: 1, Rules: 1},
+		},
+		{
+			desc:       "view quota of other user",
+			token:      validToken,
+			userID:     otherUserID,
+			authorized: true,
+			quota:      re.UserQuota{UserID: otherUserID, Quota: re.Quota{MaxStreams: 10}, Override: true, Streams: 1, Rules: 1},
+		},
+		{
+			desc:   "view quota of other user as non-admin user",
+			token:  validToken,
+			userID: otherUserID,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "view quota with invalid token",
+			token:  invalidToken,
+			userID: userID,
+			err:    svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _, auth, _ := newServiceWithRepo(t, re.Config{Quota: re.QuotaConfig{MaxStreams: 3, MaxRules: 4}}, re.Notifiers{}, repo)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+		authCall2 := authorizeAdmin(auth, tc.authorized)
+
+		q, err := svc.ViewQuota(context.Background(), tc.token, tc.userID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.quota, q, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.quota, q))
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+	}
+}
+
+func TestSetQuota(t *testing.T) {
+	cases := []struct {
+		desc       string
+		token      string
+		authorized bool
+		quota      re.Quota
+		err        error
+	}{
+		{
+			desc:       "set quota",
+			token:      validToken,
+			authorized: true,
+			quota:      re.Quota{MaxStreams: 10, MaxRules: 20},
+		},
+		{
+			desc:       "set negative quota",
+			token:      validToken,
+			authorized: true,
+			quota:      re.Quota{MaxRules: -1},
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "set quota as non-admin user",
+			token: validToken,
+			quota: re.Quota{MaxRules: 20},
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:  "set quota with invalid token",
+			token: invalidToken,
+			quota: re.Quota{MaxRules: 20},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _, auth, _ := newService(t)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+		authCall2 := authorizeAdmin(auth, tc.authorized)
+
+		q, err := svc.SetQuota(context.Background(), tc.token, otherUserID, tc.quota)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			expected := re.UserQuota{UserID: otherUserID, Quota: tc.quota, Override: true, Streams: 1, Rules: 1}
+			assert.Equal(t, expected, q, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, expected, q))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+	}
+}
+
+func TestRemoveQuota(t *testing.T) {
+	repo := mocks.NewRepository()
+	err := repo.SaveQuota(context.Background(), otherUserID, re.Quota{MaxStreams: 10})
+	assert.Nil(t, err, fmt.Sprintf("save quota: expected no error got %s\n", err))
+	svc, _, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+
+	cases := []struct {
+		desc       string
+		token      string
+		authorized bool
+		err        error
+	}{
+		{
+			desc:  "remove quota as non-admin user",
+			token: validToken,
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "remove quota",
+			token:      validToken,
+			authorized: true,
+		},
+		{
+			desc:       "remove removed quota",
+			token:      validToken,
+			authorized: true,
+			err:        svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := authorizeAdmin(auth, tc.authorized)
+
+		err := svc.RemoveQuota(context.Background(), tc.token, otherUserID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		authCall.Unset()
+		authCall1.Unset()
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// RateLimitConfig defines the per-user token bucket limiting the operations
+// that change streams, tables and rules. Each user can run up to Burst
+// operations at once, after which the operations are allowed at Rate per
+// second. Zero Rate disables rate limiting.
+type RateLimitConfig struct {
+	Rate  float64 `env:"RATE"  envDefault:"0"`
+	Burst int     `env:"BURST" envDefault:"10"`
+}
+
+var _ errors.Error = (*RetryError)(nil)
+
+// RetryError tells how long the user should wait before retrying the
+// operation rejected with ErrRateLimited.
+type RetryError struct {
+	After time.Duration
+}
+
+func (e *RetryError) Error() string {
+	return e.Msg()
+}
+
+func (e *RetryError) Msg() string {
+	return fmt.Sprintf("retry in %s", e.After.Round(time.Millisecond))
+}
+
+func (e *RetryError) Err() errors.Error {
+	return nil
+}
+
+func (e *RetryError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Msg string `json:"message"`
+	}{
+		Msg: e.Msg(),
+	})
+}
+
+// RetryAfter returns the wait time carried by the error if the operation
+// was rejected with ErrRateLimited.
+func RetryAfter(err error) (time.Duration, bool) {
+	for e, ok := err.(errors.Error); ok && e != nil; e = e.Err() {
+		if retry, ok := e.(*RetryError); ok {
+			return retry.After, true
+		}
+	}
+
+	return 0, false
+}
+
+// limiter keeps a token bucket for each user.
+type limiter struct {
+	rate  float64
+	burst float64
+	// full is the time an unused bucket takes to refill.
+	full    time.Duration
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	pruned  time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(cfg RateLimitConfig) *limiter {
+	l := &limiter{
+		rate:    cfg.Rate,
+		burst:   float64(max(cfg.Burst, 1)),
+		buckets: make(map[string]*bucket),
+	}
+	if l.rate > 0 {
+		l.full = time.Duration(l.burst / l.rate * float64(time.Second))
+	}
+
+	return l
+}
+
+// take takes a token from the bucket of the user, failing with
+// ErrRateLimited if the bucket is empty.
+func (l *limiter) take(userID string) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.prune(now)
+	b, ok := l.buckets[userID]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[userID] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		after := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return errors.Wrap(ErrRateLimited, &RetryError{After: after})
+	}
+	b.tokens--
+
+	return nil
+}
+
+// prune drops the buckets that refilled since they were last used, as they
+// don't differ from the new ones. The buckets are pruned at most once per
+// refill time so the map stays bounded by the number of active users.
+func (l *limiter) prune(now time.Time) {
+	if now.Sub(l.pruned) < l.full {
+		return
+	}
+	for id, b := range l.buckets {
+		if now.Sub(b.last) >= l.full {
+			delete(l.buckets, id)
+		}
+	}
+	l.pruned = now
+}
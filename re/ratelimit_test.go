@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const otherToken = "other"
+
+func TestRateLimit(t *testing.T) {
+	stream := re.StreamDef{Name: "stream", Topic: channelID, SenML: true}
+	bulk := re.Ruleset{Streams: []re.StreamDef{
+		{Name: "bulk1", Topic: channelID, SenML: true},
+		{Name: "bulk2", Topic: channelID, SenML: true},
+		{Name: "bulk3", Topic: channelID, SenML: true},
+	}}
+	actions := []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}
+	rules := re.Ruleset{Rules: []re.Rule{
+		{ID: "bulk1", SQL: "SELECT * FROM stream", Actions: actions},
+		{ID: "bulk2", SQL: "SELECT * FROM stream", Actions: actions},
+		{ID: "bulk3", SQL: "SELECT * FROM stream", Actions: actions},
+	}}
+
+	cases := []struct {
+		desc string
+		cfg  re.RateLimitConfig
+		ops  []string
+		errs []error
+	}{
+		{
+			desc: "change without rate limit",
+			ops:  []string{"update", "update", "update", "delete"},
+			errs: []error{nil, nil, nil, nil},
+		},
+		{
+			desc: "change within burst",
+			cfg:  re.RateLimitConfig{Rate: 0.001, Burst: 3},
+			ops:  []string{"update", "update", "delete"},
+			errs: []error{nil, nil, nil},
+		},
+		{
+			desc: "change over burst",
+			cfg:  re.RateLimitConfig{Rate: 0.001, Burst: 2},
+			ops:  []string{"update", "update", "delete", "update"},
+			errs: []error{nil, nil, re.ErrRateLimited, re.ErrRateLimited},
+		},
+		{
+			desc: "view over burst",
+			cfg:  re.RateLimitConfig{Rate: 0.001, Burst: 1},
+			ops:  []string{"update", "view", "view", "update"},
+			errs: []error{nil, nil, nil, re.ErrRateLimited},
+		},
+		{
+			desc: "bulk create over burst",
+			cfg:  re.RateLimitConfig{Rate: 0.001, Burst: 1},
+			ops:  []string{"bulk", "update"},
+			errs: []error{nil, re.ErrRateLimited},
+		},
+		{
+			desc: "cascade over burst",
+			cfg:  re.RateLimitConfig{Rate: 0.001, Burst: 2},
+			ops:  []string{"rules", "cascade"},
+			errs: []error{nil, nil},
+		},
+		{
+			desc: "patch within burst",
+			cfg:  re.RateLimitConfig{Rate: 0.001, Burst: 2},
+			ops:  []string{"rules", "patch"},
+			errs: []error{nil, nil},
+		},
+		{
+			desc: "clone within burst",
+			cfg:  re.RateLimitConfig{Rate: 0.001, Burst: 2},
+			ops:  []string{"rules", "clone"},
+			errs: []error{nil, nil},
+		},
+		{
+			desc: "tail within burst",
+			cfg:  re.RateLimitConfig{Rate: 0.001, Burst: 2},
+			ops:  []string{"rules", "tail"},
+			errs: []error{nil, nil},
+		},
+		{
+			desc: "change after refill",
+			cfg:  re.RateLimitConfig{Rate: 100, Burst: 1},
+			ops:  []string{"update", "update", "wait", "update"},
+			errs: []error{nil, re.ErrRateLimited, nil, nil},
+		},
+		{
+			desc: "change over burst of other user",
+			cfg:  re.RateLimitConfig{Rate: 0.001, Burst: 1},
+			ops:  []string{"update", "other", "other"},
+			errs: []error{nil, nil, re.ErrRateLimited},
+		},
+	}
+
+	for _, tc := range cases {
+		svc, _, auth, _ := newServiceWithConfig(t, re.Config{RateLimit: tc.cfg, Tail: re.TailConfig{URL: "http://re:9008"}}, re.Notifiers{})
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: otherToken}).Return(&magistrala.IdentityRes{UserId: otherUserID}, nil)
+		channelCall := authorizeChannel(auth, validToken, channelID, true)
+		channelCall1 := authorizeChannel(auth, otherToken, channelID, true)
+
+		for i, op := range tc.ops {
+			var err error
+			switch op {
+			case "update":
+				_, err = svc.CreateStream(context.Background(), validToken, stream, true)
+			case "other":
+				_, err = svc.CreateStream(context.Background(), otherToken, stream, true)
+			case "delete":
+				_, err = svc.DeleteRule(context.Background(), validToken, "rule")
+			case "view":
+				_, err = svc.ViewStream(context.Background(), validToken, "stream")
+			case "bulk":
+				var report re.BulkReport
+				report, err = svc.BulkCreate(context.Background(), validToken, bulk)
+				assert.Equal(t, len(bulk.Streams), report.Succeeded, fmt.Sprintf("%s: expected %d created streams got %d\n", tc.desc, len(bulk.Streams), report.Succeeded))
+			case "rules":
+				var report re.BulkReport
+				report, err = svc.BulkCreate(context.Background(), validToken, rules)
+				assert.Equal(t, len(rules.Rules), report.Succeeded, fmt.Sprintf("%s: expected %d created rules got %d\n", tc.desc, len(rules.Rules), report.Succeeded))
+			case "patch":
+				sql := "SELECT * FROM stream WHERE v > 10"
+				_, err = svc.PatchRule(context.Background(), validToken, "bulk1", re.RulePatch{SQL: &sql})
+			case "clone":
+				_, err = svc.CloneRule(context.Background(), validToken, "bulk1", "clone", "")
+			case "tail":
+				ctx, cancel := context.WithCancel(context.Background())
+				_, err = svc.TailRule(ctx, validToken, "bulk1")
+				cancel()
+			case "cascade":
+				_, err = svc.DeleteStream(context.Background(), validToken, "stream", true)
+			case "wait":
+				time.Sleep(20 * time.Millisecond)
+			}
+			assert.True(t, errors.Contains(err, tc.errs[i]), fmt.Sprintf("%s: %s %d: expected %s got %s\n", tc.desc, op, i, tc.errs[i], err))
+			if tc.errs[i] != nil {
+				after, ok := re.RetryAfter(err)
+				assert.True(t, ok && after > 0, fmt.Sprintf("%s: %s %d: expected positive retry time got %s\n", tc.desc, op, i, after))
+			}
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		channelCall.Unset()
+		channelCall1.Unset()
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala"
+	mgauth "github.com/absmach/magistrala/auth"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Stores the entity is missing from.
+const (
+	MissingInKuiper   = "kuiper"
+	MissingInMetadata = "metadata"
+)
+
+// ownerName matches the Kuiper names of the entities created through the
+// service, capturing the owner ID without dashes.
+var ownerName = regexp.MustCompile(`^u([0-9a-f]{32})_.`)
+
+// Drift is the entity that exists only in Kuiper or only in the metadata
+// store. Name is the Kuiper name of the entity and Missing is the store the
+// entity is missing from. Repaired reports whether the drift was repaired
+// and Error why the repair failed.
+type Drift struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Owner    string `json:"owner,omitempty"`
+	Missing  string `json:"missing"`
+	Repaired bool   `json:"repaired"`
+	Error    string `json:"error,omitempty"`
+}
+
+// DriftReport contains the drifts found by comparing the metadata store and
+// Kuiper at the given time.
+type DriftReport struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Drifts    []Drift   `json:"drifts"`
+}
+
+// Reconciler compares the stored metadata with the Kuiper state. It checks
+// the entities of all the users, so it's used by the background sync job
+// and never exposed over the API.
+type Reconciler interface {
+	// Reconcile returns the drifts between the metadata store and Kuiper.
+	// If repair is set, the metadata of the entities missing in Kuiper is
+	// removed and the missing metadata of the Kuiper entities is created.
+	Reconcile(ctx context.Context, repair bool) (DriftReport, error)
+
+	// RestoreStates starts or stops the rules Kuiper runs in other states
+	// than their owners want them in, once Kuiper restarts, and returns the
+	// rules brought back to their desired states.
+	RestoreStates(ctx context.Context) ([]StateChange, error)
+}
+
+type reconciler struct {
+	svc *reService
+	mu  sync.Mutex
+	// boot is the Kuiper boot time seen by the last RestoreStates call.
+	boot time.Time
+}
+
+// NewReconciler instantiates the reconciler running the streams and rules in
+// the given engine.
+func NewReconciler(engine RuleEngine, cfg Config, repo Repository) Reconciler {
+	return &reconciler{svc: newService(engine, cfg, nil, nil, Notifiers{}, nil, repo)}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, repair bool) (DriftReport, error) {
+	return r.svc.reconcile(ctx, repair)
+}
+
+func (svc *reService) Reconcile(ctx context.Context, token string, repair bool) (DriftReport, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return DriftReport{}, err
+	}
+	if err := svc.checkAdmin(ctx, userID); err != nil {
+		return DriftReport{}, err
+	}
+
+	return svc.reconcile(ctx, repair)
+}
+
+func (svc *reService) reconcile(ctx context.Context, repair bool) (DriftReport, error) {
+	report := DriftReport{CheckedAt: time.Now().UTC(), Drifts: []Drift{}}
+	for _, kind := range []string{StreamKind, RuleKind} {
+		names, err := svc.kuiperNames(ctx, kind)
+		if err != nil {
+			return DriftReport{}, err
+		}
+		drifts, err := svc.drifts(ctx, kind, names, repair)
+		if err != nil {
+			return DriftReport{}, err
+		}
+		report.Drifts = append(report.Drifts, drifts...)
+	}
+	sort.Slice(report.Drifts, func(i, j int) bool {
+		if report.Drifts[i].Kind != report.Drifts[j].Kind {
+			return report.Drifts[i].Kind < report.Drifts[j].Kind
+		}
+		return report.Drifts[i].Name < report.Drifts[j].Name
+	})
+
+	return report, nil
+}
+
+// kuiperNames returns the Kuiper names of all the entities of the kind.
+// Kuiper versions without tables have no tables.
+func (svc *reService) kuiperNames(ctx context.Context, kind string) ([]string, error) {
+	if kind == StreamKind || kind == TableKind {
+		names, err := svc.engine.ListStreams(ctx, kind)
+		switch {
+		case kind == TableKind && errors.Contains(err, ErrNotSupported):
+			return []string{}, nil
+		case err != nil:
+			return nil, err
+		}
+		return names, nil
+	}
+
+	rules, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(rules))
+	for i, r := range rules {
+		names[i] = r.ID
+	}
+
+	return names, nil
+}
+
+// drifts compares the Kuiper entities of the kind with the given names to
+// the stored metadata. Kuiper entities that weren't created through the
+// service are ignored.
+func (svc *reService) drifts(ctx context.Context, kind string, names []string, repair bool) ([]Drift, error) {
+	mds, err := svc.repo.RetrieveAll(ctx, kind, "")
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	var drifts []Drift
+	for _, name := range names {
+		if _, ok := mds[name]; ok {
+			delete(mds, name)
+			continue
+		}
+		owner, ok := nameOwner(name)
+		if !ok {
+			continue
+		}
+		d := Drift{Kind: kind, Name: name, Owner: owner, Missing: MissingInMetadata}
+		if repair {
+			err := svc.repo.Save(ctx, kind, name, Metadata{Owner: owner, CreatedAt: time.Now().UTC()})
+			d.Repaired, d.Error = repaired(err)
+		}
+		drifts = append(drifts, d)
+	}
+	for name, md := range mds {
+		// Draft rules are never deployed to Kuiper.
+		if md.Draft {
+			continue
+		}
+		d := Drift{Kind: kind, Name: name, Owner: md.Owner, Missing: MissingInKuiper}
+		if repair {
+			d.Repaired, d.Error = repaired(svc.removeMetadata(ctx, kind, name))
+		}
+		drifts = append(drifts, d)
+	}
+
+	return drifts, nil
+}
+
+// nameOwner returns the ID of the owner of the entity with the given Kuiper
+// name, restoring the dashes the prefix strips from the ID.
+func nameOwner(name string) (string, bool) {
+	m := ownerName.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+	id := m[1]
+
+	return id[:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:], true
+}
+
+func repaired(err error) (bool, string) {
+	if err != nil {
+		return false, err.Error()
+	}
+
+	return true, ""
+}
+
+// authorizeAdmin checks that the user identified by the token is the
+// platform administrator.
+func (svc *reService) authorizeAdmin(ctx context.Context, token string) error {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return svc.checkAdmin(ctx, userID)
+}
+
+// checkAdmin checks that the user is the platform administrator.
+func (svc *reService) checkAdmin(ctx context.Context, userID string) error {
+	res, err := svc.auth.Authorize(ctx, &magistrala.AuthorizeReq{
+		SubjectType: mgauth.UserType,
+		SubjectKind: mgauth.UsersKind,
+		Subject:     userID,
+		Permission:  mgauth.AdminPermission,
+		ObjectType:  mgauth.PlatformType,
+		Object:      mgauth.MagistralaObject,
+	})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
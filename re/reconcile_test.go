@@ -0,0 +1,153 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const otherUserID = "00000000-0000-0000-0000-000000000000"
+
+// newDriftRepo returns the repository with the metadata of the user's
+// stream, which exists in Kuiper, and of the removed stream, which doesn't.
+func newDriftRepo(t *testing.T) re.Repository {
+	repo := mocks.NewRepository()
+	md := re.Metadata{Owner: userID, CreatedAt: time.Now().UTC()}
+	for _, name := range []string{userPrefix + "stream", userPrefix + "removed"} {
+		err := repo.Save(context.Background(), re.StreamKind, name, md)
+		assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	}
+
+	return repo
+}
+
+// authorizeAdmin mocks the check whether the user is the platform
+// administrator.
+func authorizeAdmin(auth *authmocks.AuthClient, authorized bool) *mock.Call {
+	return auth.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+		SubjectType: "user",
+		SubjectKind: "users",
+		Subject:     userID,
+		Permission:  "admin",
+		ObjectType:  "platform",
+		Object:      "magistrala",
+	}).Return(&magistrala.AuthorizeRes{Authorized: authorized}, nil)
+}
+
+func TestReconcile(t *testing.T) {
+	drifts := []re.Drift{
+		{Kind: re.RuleKind, Name: otherPrefix + "rule", Owner: otherUserID, Missing: re.MissingInMetadata},
+		{Kind: re.RuleKind, Name: userPrefix + "rule", Owner: userID, Missing: re.MissingInMetadata},
+		{Kind: re.StreamKind, Name: otherPrefix + "stream", Owner: otherUserID, Missing: re.MissingInMetadata},
+		{Kind: re.StreamKind, Name: userPrefix + "removed", Owner: userID, Missing: re.MissingInKuiper},
+	}
+	repaired := make([]re.Drift, len(drifts))
+	for i, d := range drifts {
+		d.Repaired = true
+		repaired[i] = d
+	}
+
+	cases := []struct {
+		desc       string
+		token      string
+		authorized bool
+		repair     bool
+		failure    string
+		drifts     []re.Drift
+		err        error
+	}{
+		{
+			desc:       "view drift",
+			token:      validToken,
+			authorized: true,
+			drifts:     drifts,
+		},
+		{
+			desc:       "repair drift",
+			token:      validToken,
+			authorized: true,
+			repair:     true,
+			drifts:     repaired,
+		},
+		{
+			desc:  "view drift with invalid token",
+			token: invalidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "view drift as non-admin user",
+			token: validToken,
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "view drift with failed rules lookup",
+			token:      validToken,
+			authorized: true,
+			failure:    "/rules",
+			err:        re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, newDriftRepo(t))
+		k.streams["demo"] = ""
+		if tc.failure != "" {
+			k.failures[tc.failure] = http.StatusInternalServerError
+		}
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+		authCall2 := authorizeAdmin(auth, tc.authorized)
+
+		report, err := svc.Reconcile(context.Background(), tc.token, tc.repair)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.drifts, report.Drifts, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.drifts, report.Drifts))
+		if tc.err == nil {
+			assert.False(t, report.CheckedAt.IsZero(), fmt.Sprintf("%s: expected check time\n", tc.desc))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	k, url := newKuiper(t)
+	repo := newDriftRepo(t)
+	cfg := re.Config{URL: url}
+	r := re.NewReconciler(re.NewKuiper(cfg), cfg, repo)
+
+	report, err := r.Reconcile(context.Background(), true)
+	assert.Nil(t, err, fmt.Sprintf("repair drift: expected no error got %s\n", err))
+	assert.Len(t, report.Drifts, 4, fmt.Sprintf("repair drift: expected 4 drifts got %d\n", len(report.Drifts)))
+
+	md, err := repo.Retrieve(context.Background(), re.RuleKind, otherPrefix+"rule")
+	assert.Nil(t, err, fmt.Sprintf("repair drift: expected created metadata got %s\n", err))
+	assert.Equal(t, otherUserID, md.Owner, fmt.Sprintf("repair drift: expected owner %s got %s\n", otherUserID, md.Owner))
+	_, err = repo.Retrieve(context.Background(), re.StreamKind, userPrefix+"removed")
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("repair drift: expected removed metadata got %s\n", err))
+
+	report, err = r.Reconcile(context.Background(), false)
+	assert.Nil(t, err, fmt.Sprintf("view drift: expected no error got %s\n", err))
+	assert.Empty(t, report.Drifts, fmt.Sprintf("view drift: expected no drifts got %v\n", report.Drifts))
+
+	delete(k.rules, userPrefix+"rule")
+	report, err = r.Reconcile(context.Background(), false)
+	assert.Nil(t, err, fmt.Sprintf("view drift: expected no error got %s\n", err))
+	drifts := []re.Drift{{Kind: re.RuleKind, Name: userPrefix + "rule", Owner: userID, Missing: re.MissingInKuiper}}
+	assert.Equal(t, drifts, report.Drifts, fmt.Sprintf("view drift: expected %v got %v\n", drifts, report.Drifts))
+}
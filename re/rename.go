@@ -0,0 +1,239 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/gofrs/uuid"
+)
+
+var (
+	errRenameKind        = errors.New("only streams and rules can be renamed")
+	errMissingDefinition = errors.New("entity created before its definition was stored can't be renamed")
+)
+
+func (svc *reService) Rename(ctx context.Context, token, kind, name, newName string) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	if kind != StreamKind && kind != RuleKind {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, errRenameKind)
+	}
+	if err := validateName(newName); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	owner, name, err := svc.resolve(ctx, token, userID, kind, name, ManageAccess)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if err := svc.ownEntity(ctx, kind, owner, name); err != nil {
+		return Result{}, err
+	}
+	if err := svc.checkChained(ctx, owner, kind, name); err != nil {
+		return Result{}, err
+	}
+	pfx := prefix(owner)
+	md, err := svc.metadata(ctx, kind, pfx+name)
+	if err != nil {
+		return Result{}, err
+	}
+	if md == nil || md.Definition == "" {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, errMissingDefinition)
+	}
+
+	var res Result
+	if kind == StreamKind {
+		res, err = svc.renameStream(ctx, md, name, newName)
+	} else {
+		res, err = svc.renameRule(ctx, token, md, name, newName)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.moveShares(ctx, kind, pfx+name, pfx+newName); err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(newName, owner), nil
+}
+
+// renameStream recreates the stream under the new name and updates the
+// rules of the owner reading from the stream to read from the renamed one,
+// since Kuiper doesn't remove the streams the rules read from.
+func (svc *reService) renameStream(ctx context.Context, md *Metadata, name, newName string) (Result, error) {
+	var def StreamDef
+	if err := json.Unmarshal([]byte(md.Definition), &def); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	pfx := prefix(md.Owner)
+	def.Name = newName
+	sql, err := def.ddl(pfx+newName, pfx)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if md.Definition, err = streamDefinition(def); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.engine.CreateStream(ctx, StreamKind, sql)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.retargetRules(ctx, md.Owner, name, newName); err != nil {
+		return Result{}, err
+	}
+	if _, err := svc.engine.DeleteStream(ctx, StreamKind, pfx+name); err != nil {
+		return Result{}, err
+	}
+	if err := svc.moveMetadata(ctx, StreamKind, pfx+name, pfx+newName, *md); err != nil {
+		return Result{}, err
+	}
+
+	return res, nil
+}
+
+// retargetRules updates the rules of the owner reading from the stream to
+// read from the stream with the new name. Rules created before their
+// definitions were stored aren't updated, so they keep the stream from
+// being removed.
+func (svc *reService) retargetRules(ctx context.Context, owner, name, newName string) error {
+	mds, err := svc.repo.RetrieveAll(ctx, RuleKind, owner)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	for kuiperID, md := range mds {
+		if md.Definition == "" {
+			continue
+		}
+		var rule Rule
+		if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		reads := false
+		sql, err := rewriteStreams(rule.SQL, func(stream string) string {
+			if stream != name {
+				return stream
+			}
+			reads = true
+			return newName
+		})
+		if err != nil {
+			return err
+		}
+		if !reads {
+			continue
+		}
+
+		rule.SQL = sql
+		kr, err := svc.namespaceRule(ctx, rule, owner)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		if _, err := svc.updateRule(ctx, kr); err != nil {
+			return err
+		}
+		if md.Stopped {
+			if _, err := svc.engine.ControlRule(ctx, kuiperID, "stop"); err != nil {
+				return err
+			}
+		}
+		if md.Definition, err = ruleDefinition(rule); err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		md.UpdatedAt = time.Now().UTC()
+		if err := svc.repo.Save(ctx, RuleKind, kuiperID, md); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+// renameRule recreates the rule under the new ID, keeping it stopped if the
+// owner stopped it, and moves the subscriptions of its notification actions
+// to the topics of the new ID.
+func (svc *reService) renameRule(ctx context.Context, token string, md *Metadata, id, newID string) (Result, error) {
+	var old Rule
+	if err := json.Unmarshal([]byte(md.Definition), &old); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	pfx := prefix(md.Owner)
+	rule := old
+	rule.ID = newID
+	kr, err := svc.namespaceRule(ctx, rule, md.Owner)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if md.Definition, err = ruleDefinition(rule); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.engine.CreateRule(ctx, kr)
+	if err != nil {
+		return Result{}, err
+	}
+	if md.Stopped {
+		if _, err := svc.engine.ControlRule(ctx, kr.ID, "stop"); err != nil {
+			_, _ = svc.engine.DeleteRule(ctx, kr.ID)
+			return Result{}, err
+		}
+	}
+	if _, err := svc.engine.DeleteRule(ctx, pfx+id); err != nil {
+		_, _ = svc.engine.DeleteRule(ctx, kr.ID)
+		return Result{}, err
+	}
+	if err := svc.unsubscribe(token, old); err != nil {
+		return Result{}, err
+	}
+	if err := svc.subscribe(token, rule); err != nil {
+		return Result{}, err
+	}
+	if err := svc.moveMetadata(ctx, RuleKind, pfx+id, pfx+newID, *md); err != nil {
+		return Result{}, err
+	}
+
+	return res, nil
+}
+
+// moveMetadata stores the metadata of the renamed entity under the new
+// Kuiper name, keeping its ID and creation time. Entities created before
+// the IDs were stored get the ID once they're renamed.
+func (svc *reService) moveMetadata(ctx context.Context, kind, name, newName string, md Metadata) error {
+	if md.ID == "" {
+		id, err := uuid.NewV4()
+		if err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		md.ID = id.String()
+	}
+	md.UpdatedAt = time.Now().UTC()
+	if err := svc.repo.Save(ctx, kind, newName, md); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return svc.removeMetadata(ctx, kind, name)
+}
+
+// moveShares moves the shares of the renamed entity to the new Kuiper name.
+func (svc *reService) moveShares(ctx context.Context, kind, name, newName string) error {
+	shares, err := svc.repo.RetrieveShares(ctx, kind, name)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for _, s := range shares {
+		if err := svc.repo.SaveShare(ctx, kind, newName, s); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return svc.removeShares(ctx, kind, name)
+}
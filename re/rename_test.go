@@ -0,0 +1,130 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRename(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "temperature", Topic: channelID, SenML: true}, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+	rule := re.Rule{
+		ID:      "alarm",
+		SQL:     "SELECT * FROM temperature WHERE v > 30",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+	}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	_, err = svc.StopRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("stop rule: expected no error got %s\n", err))
+	share := re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ViewAccess}
+	_, err = svc.ShareEntity(context.Background(), validToken, re.RuleKind, rule.ID, share)
+	assert.Nil(t, err, fmt.Sprintf("share rule: expected no error got %s\n", err))
+
+	cases := []struct {
+		desc    string
+		kind    string
+		name    string
+		newName string
+		err     error
+	}{
+		{
+			desc:    "rename stream",
+			kind:    re.StreamKind,
+			name:    "temperature",
+			newName: "celsius",
+		},
+		{
+			desc:    "rename rule",
+			kind:    re.RuleKind,
+			name:    "alarm",
+			newName: "overheat",
+		},
+		{
+			desc:    "rename rule to existing rule",
+			kind:    re.RuleKind,
+			name:    "overheat",
+			newName: "rule",
+			err:     svcerr.ErrConflict,
+		},
+		{
+			desc:    "rename rule created before definitions were stored",
+			kind:    re.RuleKind,
+			name:    "rule",
+			newName: "legacy",
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "rename non-existing rule",
+			kind:    re.RuleKind,
+			name:    "unknown",
+			newName: "known",
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:    "rename rule to invalid name",
+			kind:    re.RuleKind,
+			name:    "overheat",
+			newName: "x/../../rules/" + otherPrefix + "rule",
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "rename table",
+			kind:    re.TableKind,
+			name:    "table",
+			newName: "devices",
+			err:     svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		old, _ := repo.Retrieve(context.Background(), tc.kind, userPrefix+tc.name)
+		res, err := svc.Rename(context.Background(), validToken, tc.kind, tc.name, tc.newName)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, tc.newName, res.Name, fmt.Sprintf("%s: expected name %s got %s\n", tc.desc, tc.newName, res.Name))
+		assert.Equal(t, userID, res.Owner, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, userID, res.Owner))
+		md, err := repo.Retrieve(context.Background(), tc.kind, userPrefix+tc.newName)
+		assert.Nil(t, err, fmt.Sprintf("%s: retrieve metadata: expected no error got %s\n", tc.desc, err))
+		assert.NotEmpty(t, md.ID, fmt.Sprintf("%s: expected metadata ID\n", tc.desc))
+		assert.Equal(t, old.ID, md.ID, fmt.Sprintf("%s: expected ID %s got %s\n", tc.desc, old.ID, md.ID))
+		assert.Equal(t, old.CreatedAt, md.CreatedAt, fmt.Sprintf("%s: expected creation time %s got %s\n", tc.desc, old.CreatedAt, md.CreatedAt))
+		_, err = repo.Retrieve(context.Background(), tc.kind, userPrefix+tc.name)
+		assert.NotNil(t, err, fmt.Sprintf("%s: expected metadata of old name to be removed\n", tc.desc))
+	}
+
+	_, ok := k.streams[userPrefix+"temperature"]
+	assert.False(t, ok, "expected stream to be removed under old name")
+	_, ok = k.streams[userPrefix+"celsius"]
+	assert.True(t, ok, "expected stream to exist under new name")
+	_, ok = k.rules[userPrefix+"alarm"]
+	assert.False(t, ok, "expected rule to be removed under old name")
+	sql := "SELECT * FROM " + userPrefix + "celsius WHERE v > 30"
+	assert.Equal(t, sql, k.rules[userPrefix+"overheat"].SQL, fmt.Sprintf("expected renamed rule to read from renamed stream got %s\n", k.rules[userPrefix+"overheat"].SQL))
+	assert.True(t, k.stopped[userPrefix+"overheat"], "expected renamed rule to stay stopped")
+	shares, err := repo.RetrieveShares(context.Background(), re.RuleKind, userPrefix+"overheat")
+	assert.Nil(t, err, fmt.Sprintf("retrieve shares: expected no error got %s\n", err))
+	assert.Equal(t, []re.Share{share}, shares, fmt.Sprintf("expected shares %v got %v\n", []re.Share{share}, shares))
+}
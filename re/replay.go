@@ -0,0 +1,216 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+const (
+	// replayPageSize is the largest page of messages returned by the readers.
+	replayPageSize = 1000
+	// maxReplayMessages limits the number of messages replayed from each
+	// channel, so long periods can't exhaust the memory.
+	maxReplayMessages = 10000
+)
+
+var (
+	errReplayPeriod  = errors.New("replay period must end after it starts")
+	errReplaySource  = errors.New("replayed rule must read only from the channel streams")
+	errReadMessages  = errors.New("failed to read channel messages")
+	errReplayStreams = errors.New("replayed rule doesn't read from any stream")
+	errReplayDraft   = errors.New("draft rule can't be replayed")
+)
+
+// ReplayResult contains the results the rule produced from the historical
+// messages of the channels its streams read from. Messages is the number of
+// replayed messages. If the period contains more messages than the replay
+// limit, only the latest ones are replayed and Truncated is set.
+type ReplayResult struct {
+	Messages  int                      `json:"messages"`
+	Truncated bool                     `json:"truncated"`
+	Results   []map[string]interface{} `json:"results"`
+}
+
+func (svc *reService) ReplayRule(ctx context.Context, token, id string, from, to time.Time) (ReplayResult, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return ReplayResult{}, err
+	}
+	if !to.After(from) {
+		return ReplayResult{}, errors.Wrap(svcerr.ErrMalformedEntity, errReplayPeriod)
+	}
+	// Replaying the rule only views it, so the users it's shared with can
+	// replay it as well.
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ViewAccess)
+	if err != nil {
+		return ReplayResult{}, err
+	}
+
+	pfx := prefix(owner)
+	md, err := svc.metadata(ctx, RuleKind, pfx+id)
+	if err != nil {
+		return ReplayResult{}, err
+	}
+	if md != nil && md.Draft {
+		return ReplayResult{}, errors.Wrap(svcerr.ErrMalformedEntity, errReplayDraft)
+	}
+	kr, err := svc.engine.ViewRule(ctx, pfx+id)
+	if err != nil {
+		return ReplayResult{}, err
+	}
+	// Tables the rule joins keep reading their own sources, so only the
+	// streams are replayed.
+	tables, err := svc.ownedNames(ctx, TableKind, pfx)
+	if err != nil {
+		return ReplayResult{}, err
+	}
+	joined := make(map[string]bool, len(tables))
+	for _, name := range tables {
+		joined[pfx+name] = true
+	}
+	var streams []string
+	if _, err := rewriteStreams(kr.SQL, func(name string) string {
+		if !joined[name] {
+			streams = append(streams, name)
+		}
+		return name
+	}); err != nil {
+		return ReplayResult{}, errors.Wrap(errReadResponse, err)
+	}
+	if len(streams) == 0 {
+		return ReplayResult{}, errors.Wrap(svcerr.ErrMalformedEntity, errReplayStreams)
+	}
+
+	res := ReplayResult{Results: []map[string]interface{}{}}
+	kt := EngineTrial{
+		SQL:        kr.SQL,
+		MockSource: make(map[string]MockSource),
+		SinkProps:  map[string]any{"sendSingle": true},
+	}
+	read := make(map[string][]map[string]interface{})
+	for _, name := range streams {
+		if _, ok := kt.MockSource[name]; ok {
+			continue
+		}
+		stream, err := svc.engine.ViewStream(ctx, StreamKind, name)
+		if err != nil {
+			return ReplayResult{}, err
+		}
+		channel := sourceChannel(stream)
+		if channel == "" {
+			return ReplayResult{}, errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(errReplaySource, errors.New(name)))
+		}
+		// Streams reading from the same channel replay the same messages.
+		msgs, ok := read[channel]
+		if !ok {
+			var truncated bool
+			if msgs, truncated, err = svc.channelMessages(token, channel, from, to); err != nil {
+				return ReplayResult{}, err
+			}
+			read[channel] = msgs
+			res.Messages += len(msgs)
+			res.Truncated = res.Truncated || truncated
+		}
+		kt.MockSource[name] = MockSource{Data: msgs, Interval: 1}
+	}
+	// Kuiper can't test rules without messages, which produce no results.
+	if res.Messages == 0 {
+		return res, nil
+	}
+
+	if kt.ID, err = trialID(pfx + id); err != nil {
+		return ReplayResult{}, err
+	}
+	tr, err := svc.engine.RunTrial(ctx, kt)
+	if err != nil {
+		return ReplayResult{}, err
+	}
+	res.Results = tr.Results
+
+	return res, nil
+}
+
+// channelMessages reads the SenML messages the channel received in the
+// given period from the readers and returns them as SenML records sorted by
+// time. The readers return the latest messages first, so the oldest ones
+// are left out if there are more than maxReplayMessages.
+func (svc *reService) channelMessages(token, channel string, from, to time.Time) ([]map[string]interface{}, bool, error) {
+	pm := mgsdk.MessagePageMetadata{
+		PageMetadata: mgsdk.PageMetadata{Limit: replayPageSize},
+		From:         float64(from.UnixNano()) / float64(time.Second),
+		To:           float64(to.UnixNano()) / float64(time.Second),
+	}
+	var msgs []senml.Message
+	var total uint64
+	for {
+		page, sdkErr := svc.sdk.ReadMessages(pm, channel, token)
+		if sdkErr != nil {
+			svc.forget(token, sdkErr)
+			switch sdkErr.StatusCode() {
+			case http.StatusUnauthorized, http.StatusForbidden:
+				return nil, false, errors.Wrap(svcerr.ErrAuthorization, sdkErr)
+			default:
+				return nil, false, errors.Wrap(errReadMessages, sdkErr)
+			}
+		}
+		msgs = append(msgs, page.Messages...)
+		total = page.Total
+		pm.Offset += uint64(len(page.Messages))
+		if len(page.Messages) == 0 || pm.Offset >= total || len(msgs) >= maxReplayMessages {
+			break
+		}
+	}
+	if len(msgs) > maxReplayMessages {
+		msgs = msgs[:maxReplayMessages]
+	}
+	truncated := uint64(len(msgs)) < total
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].Time < msgs[j].Time
+	})
+
+	records := make([]map[string]interface{}, len(msgs))
+	for i, msg := range msgs {
+		records[i] = senmlRecord(msg)
+	}
+
+	return records, truncated, nil
+}
+
+// senmlRecord returns the SenML record (RFC 8428) of the resolved message,
+// as read by the SenML streams.
+func senmlRecord(msg senml.Message) map[string]interface{} {
+	rec := map[string]interface{}{"n": msg.Name, "t": msg.Time}
+	if msg.Unit != "" {
+		rec["u"] = msg.Unit
+	}
+	if msg.UpdateTime != 0 {
+		rec["ut"] = msg.UpdateTime
+	}
+	if msg.Value != nil {
+		rec["v"] = *msg.Value
+	}
+	if msg.StringValue != nil {
+		rec["vs"] = *msg.StringValue
+	}
+	if msg.DataValue != nil {
+		rec["vd"] = *msg.DataValue
+	}
+	if msg.BoolValue != nil {
+		rec["vb"] = *msg.BoolValue
+	}
+	if msg.Sum != nil {
+		rec["s"] = *msg.Sum
+	}
+
+	return rec
+}
@@ -0,0 +1,201 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReplayRule(t *testing.T) {
+	cfg := re.Config{Trial: re.TrialConfig{Timeout: 2 * time.Second, Idle: 200 * time.Millisecond}}
+	repo := mocks.NewRepository()
+	svc, k, auth, sdk := newServiceWithRepo(t, cfg, re.Notifiers{}, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	k.streams[userPrefix+"readings"] = fmt.Sprintf(`CREATE STREAM readings () WITH (TYPE = "mainflux", DATASOURCE = "%s", FORMAT = "json")`, channelID)
+	k.streams[userPrefix+"buffer"] = `CREATE STREAM buffer () WITH (TYPE = "memory", DATASOURCE = "buffer", FORMAT = "json")`
+	sql := "SELECT * FROM " + userPrefix + "readings WHERE v > 10"
+	k.rules[userPrefix+"replayed"] = re.Rule{ID: userPrefix + "replayed", SQL: sql}
+	k.rules[userPrefix+"buffered"] = re.Rule{ID: userPrefix + "buffered", SQL: "SELECT * FROM " + userPrefix + "buffer"}
+	err := repo.Save(context.Background(), re.RuleKind, userPrefix+"drafted", re.Metadata{Owner: userID, Draft: true, Definition: fmt.Sprintf(`{"id":"drafted","sql":"SELECT * FROM readings","actions":[{"mainflux":{"channel":"%s"}}]}`, channelID)})
+	assert.Nil(t, err, fmt.Sprintf("save draft: expected no error got %s\n", err))
+
+	value := func(v float64) *float64 { return &v }
+	// Readers return the latest messages first.
+	page := mgsdk.MessagesPage{Messages: []senml.Message{
+		{Name: "temp", Unit: "C", Time: 3, Value: value(30)},
+		{Name: "temp", Unit: "C", Time: 2, Value: value(20)},
+		{Name: "temp", Unit: "C", Time: 1, Value: value(10)},
+	}}
+	page.Total = 3
+	results := []map[string]interface{}{
+		{"n": "temp", "u": "C", "t": 1.0, "v": 10.0},
+		{"n": "temp", "u": "C", "t": 2.0, "v": 20.0},
+		{"n": "temp", "u": "C", "t": 3.0, "v": 30.0},
+	}
+	full := mgsdk.MessagesPage{Messages: make([]senml.Message, 1000)}
+	full.Total = 20000
+
+	to := time.Now()
+	from := to.Add(-time.Hour)
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		from     time.Time
+		page     mgsdk.MessagesPage
+		readErr  errors.SDKError
+		messages int
+		results  []map[string]interface{}
+		truncate bool
+		err      error
+	}{
+		{
+			desc:     "replay rule",
+			token:    validToken,
+			id:       "replayed",
+			from:     from,
+			page:     page,
+			messages: 3,
+			results:  results,
+		},
+		{
+			desc:    "replay rule without messages",
+			token:   validToken,
+			id:      "replayed",
+			from:    from,
+			results: []map[string]interface{}{},
+		},
+		{
+			desc:     "replay rule with more messages than the limit",
+			token:    validToken,
+			id:       "replayed",
+			from:     from,
+			page:     full,
+			messages: 10000,
+			truncate: true,
+		},
+		{
+			desc:  "replay rule with invalid token",
+			token: invalidToken,
+			id:    "replayed",
+			from:  from,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "replay rule with invalid period",
+			token: validToken,
+			id:    "replayed",
+			from:  to.Add(time.Hour),
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "replay unknown rule",
+			token: validToken,
+			id:    "unknown",
+			from:  from,
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "replay draft rule",
+			token: validToken,
+			id:    "drafted",
+			from:  from,
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "replay rule reading from memory stream",
+			token: validToken,
+			id:    "buffered",
+			from:  from,
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "replay rule with inaccessible channel",
+			token:   validToken,
+			id:      "replayed",
+			from:    from,
+			readErr: errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			err:     svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		tk := newTrialKuiper(t)
+		k.trial = tk
+		sdkCall := sdk.On("ReadMessages", mock.Anything, channelID, validToken).Return(tc.page, tc.readErr)
+		res, err := svc.ReplayRule(context.Background(), tc.token, tc.id, tc.from, to)
+		sdkCall.Unset()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, tc.messages, res.Messages, fmt.Sprintf("%s: expected %d messages got %d\n", tc.desc, tc.messages, res.Messages))
+		assert.Equal(t, tc.truncate, res.Truncated, fmt.Sprintf("%s: expected truncated %t got %t\n", tc.desc, tc.truncate, res.Truncated))
+		if tc.results != nil {
+			assert.Equal(t, tc.results, res.Results, fmt.Sprintf("%s: expected results %v got %v\n", tc.desc, tc.results, res.Results))
+		}
+		tk.mu.Lock()
+		for _, kt := range tk.trials {
+			assert.Equal(t, sql, kt.SQL, fmt.Sprintf("%s: expected rule SQL got %s\n", tc.desc, kt.SQL))
+			assert.Len(t, kt.MockSource[userPrefix+"readings"].Data, tc.messages, fmt.Sprintf("%s: expected replayed messages\n", tc.desc))
+		}
+		if tc.messages == 0 {
+			assert.Empty(t, tk.trials, fmt.Sprintf("%s: expected no trial\n", tc.desc))
+		}
+		tk.mu.Unlock()
+	}
+}
+
+func TestReplaySharedRule(t *testing.T) {
+	cfg := re.Config{Trial: re.TrialConfig{Timeout: 2 * time.Second, Idle: 200 * time.Millisecond}}
+	svc, k, auth, sdk := newServiceWithConfig(t, cfg, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: otherToken}).Return(&magistrala.IdentityRes{UserId: otherUserID}, nil)
+	defer authCall1.Unset()
+	authCall2 := authorizeChannel(auth, validToken, channelID, true)
+	defer authCall2.Unset()
+	authCall3 := authorizeMember(auth, otherToken, userID, false)
+	defer authCall3.Unset()
+
+	k.streams[userPrefix+"readings"] = fmt.Sprintf(`CREATE STREAM readings () WITH (TYPE = "mainflux", DATASOURCE = "%s", FORMAT = "json")`, channelID)
+	_, err := svc.CreateRule(context.Background(), validToken, re.Rule{ID: "replayed", SQL: "SELECT * FROM readings", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}})
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	sdkCall := sdk.On("ReadMessages", mock.Anything, channelID, otherToken).Return(mgsdk.MessagesPage{}, nil)
+	defer sdkCall.Unset()
+
+	to := time.Now()
+	from := to.Add(-time.Hour)
+	// The rule isn't found in the user's own namespace, nor before it's
+	// shared.
+	_, err = svc.ReplayRule(context.Background(), otherToken, "replayed", from, to)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("replay rule of own namespace: expected %s got %s\n", svcerr.ErrNotFound, err))
+	_, err = svc.ReplayRule(context.Background(), otherToken, userID+":replayed", from, to)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("replay unshared rule: expected %s got %s\n", svcerr.ErrNotFound, err))
+
+	_, err = svc.ShareEntity(context.Background(), validToken, re.RuleKind, "replayed", re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ViewAccess})
+	assert.Nil(t, err, fmt.Sprintf("share rule: expected no error got %s\n", err))
+	k.trial = newTrialKuiper(t)
+	res, err := svc.ReplayRule(context.Background(), otherToken, userID+":replayed", from, to)
+	assert.Nil(t, err, fmt.Sprintf("replay shared rule: expected no error got %s\n", err))
+	assert.Equal(t, 0, res.Messages, fmt.Sprintf("replay shared rule: expected no messages got %d\n", res.Messages))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// RequestIDHeader is the header carrying the ID of the user request, which
+// is passed on to Kuiper so the request can be followed through the logs.
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDKey struct{}
+
+// WithRequestID returns the context carrying the ID of the user request.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the ID of the user request the context carries, empty if
+// the context doesn't carry one.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+
+	return id
+}
+
+// NewRequestID returns the new random request ID, empty if it can't be
+// generated.
+func NewRequestID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return ""
+	}
+
+	return id.String()
+}
@@ -0,0 +1,158 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Statuses of the restored entities.
+const (
+	// RestoreRestored marks the entity created in Kuiper.
+	RestoreRestored = "restored"
+
+	// RestorePending marks the entity the dry run would create.
+	RestorePending = "pending"
+
+	// RestoreExists marks the entity that already exists in Kuiper.
+	RestoreExists = "exists"
+
+	// RestoreSkipped marks the entity without the stored definition, which
+	// was created before definitions were stored.
+	RestoreSkipped = "skipped"
+
+	// RestoreFailed marks the entity Kuiper failed to create.
+	RestoreFailed = "failed"
+)
+
+// RestoredEntity is the stream or rule replayed into Kuiper. Name is the
+// Kuiper name of the entity and Error is the reason the restore failed.
+type RestoredEntity struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Owner  string `json:"owner"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RestoreReport is the progress report of the restore. Counts maps the
+// statuses to the number of entities with the status. Entities are listed
+// in the order they are restored in.
+type RestoreReport struct {
+	DryRun     bool             `json:"dry_run"`
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+	Counts     map[string]int   `json:"counts"`
+	Entities   []RestoredEntity `json:"entities"`
+}
+
+func (svc *reService) Restore(ctx context.Context, token string, dryRun bool) (RestoreReport, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return RestoreReport{}, err
+	}
+	if err := svc.checkAdmin(ctx, userID); err != nil {
+		return RestoreReport{}, err
+	}
+
+	return svc.restore(ctx, dryRun)
+}
+
+// restore replays the stored definitions of the entities missing in Kuiper.
+// Streams are restored before rules, since rules read from streams.
+func (svc *reService) restore(ctx context.Context, dryRun bool) (RestoreReport, error) {
+	report := RestoreReport{
+		DryRun:    dryRun,
+		StartedAt: time.Now().UTC(),
+		Counts:    make(map[string]int),
+		Entities:  []RestoredEntity{},
+	}
+	for _, kind := range []string{StreamKind, RuleKind} {
+		names, err := svc.kuiperNames(ctx, kind)
+		if err != nil {
+			return RestoreReport{}, err
+		}
+		existing := make(map[string]bool, len(names))
+		for _, name := range names {
+			existing[name] = true
+		}
+		mds, err := svc.repo.RetrieveAll(ctx, kind, "")
+		if err != nil {
+			return RestoreReport{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+
+		stored := make([]string, 0, len(mds))
+		for name := range mds {
+			stored = append(stored, name)
+		}
+		sort.Strings(stored)
+		for _, name := range stored {
+			md := mds[name]
+			if md.Draft {
+				continue
+			}
+			e := RestoredEntity{Kind: kind, Name: name, Owner: md.Owner}
+			switch {
+			case existing[name]:
+				e.Status = RestoreExists
+			case md.Definition == "":
+				e.Status = RestoreSkipped
+			case dryRun:
+				e.Status = RestorePending
+			default:
+				e.Status = RestoreRestored
+				switch err := svc.restoreEntity(ctx, kind, name, md); {
+				case errors.Contains(err, svcerr.ErrConflict):
+					e.Status = RestoreExists
+				case err != nil:
+					e.Status, e.Error = RestoreFailed, err.Error()
+				}
+			}
+			report.Counts[e.Status]++
+			report.Entities = append(report.Entities, e)
+		}
+	}
+	report.FinishedAt = time.Now().UTC()
+
+	return report, nil
+}
+
+// restoreEntity creates the Kuiper entity from its stored definition.
+// Stream DDLs are rendered and rules namespaced again, so the writer
+// actions use the current writers configuration.
+func (svc *reService) restoreEntity(ctx context.Context, kind, name string, md Metadata) error {
+	if kind == StreamKind {
+		var def StreamDef
+		if err := json.Unmarshal([]byte(md.Definition), &def); err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		sql, err := def.ddl(name, prefix(md.Owner))
+		if err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		_, err = svc.engine.CreateStream(ctx, StreamKind, sql)
+		return err
+	}
+
+	var rule Rule
+	if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	kr, err := svc.namespaceRule(ctx, rule, md.Owner)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if _, err = svc.engine.CreateRule(ctx, kr); err != nil || !md.Stopped && !md.deleted() {
+		return err
+	}
+	_, err = svc.engine.ControlRule(ctx, name, "stop")
+
+	return err
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRestore(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "readings", Topic: channelID, SenML: true}, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+	rule := re.Rule{ID: "alarm", SQL: "SELECT * FROM readings WHERE v > 30", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	md := re.Metadata{Owner: userID, CreatedAt: time.Now().UTC()}
+	err = repo.Save(context.Background(), re.RuleKind, userPrefix+"legacy", md)
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	md.Definition = "{"
+	err = repo.Save(context.Background(), re.RuleKind, userPrefix+"malformed", md)
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+
+	ddl := k.streams[userPrefix+"readings"]
+	k.streams, k.rules, k.raw = map[string]string{}, map[string]re.Rule{}, map[string][]byte{}
+
+	cases := []struct {
+		desc       string
+		authorized bool
+		dryRun     bool
+		statuses   []string
+		counts     map[string]int
+		err        error
+	}{
+		{
+			desc:   "restore as non-admin user",
+			dryRun: true,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "dry run restore",
+			authorized: true,
+			dryRun:     true,
+			statuses:   []string{re.RestorePending, re.RestorePending, re.RestoreSkipped, re.RestorePending},
+			counts:     map[string]int{re.RestorePending: 3, re.RestoreSkipped: 1},
+		},
+		{
+			desc:       "restore",
+			authorized: true,
+			statuses:   []string{re.RestoreRestored, re.RestoreRestored, re.RestoreSkipped, re.RestoreFailed},
+			counts:     map[string]int{re.RestoreRestored: 2, re.RestoreSkipped: 1, re.RestoreFailed: 1},
+		},
+		{
+			desc:       "restore restored Kuiper",
+			authorized: true,
+			statuses:   []string{re.RestoreExists, re.RestoreExists, re.RestoreSkipped, re.RestoreFailed},
+			counts:     map[string]int{re.RestoreExists: 2, re.RestoreSkipped: 1, re.RestoreFailed: 1},
+		},
+	}
+
+	names := []string{userPrefix + "readings", userPrefix + "alarm", userPrefix + "legacy", userPrefix + "malformed"}
+	for _, tc := range cases {
+		adminCall := authorizeAdmin(auth, tc.authorized)
+		report, err := svc.Restore(context.Background(), validToken, tc.dryRun)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		var statuses []string
+		for i, e := range report.Entities {
+			assert.Equal(t, names[i], e.Name, fmt.Sprintf("%s: expected entity %s got %s\n", tc.desc, names[i], e.Name))
+			statuses = append(statuses, e.Status)
+		}
+		assert.Equal(t, tc.statuses, statuses, fmt.Sprintf("%s: expected statuses %v got %v\n", tc.desc, tc.statuses, statuses))
+		if tc.err == nil {
+			assert.Equal(t, tc.counts, report.Counts, fmt.Sprintf("%s: expected counts %v got %v\n", tc.desc, tc.counts, report.Counts))
+			assert.Equal(t, tc.dryRun, report.DryRun, fmt.Sprintf("%s: expected dry run %t got %t\n", tc.desc, tc.dryRun, report.DryRun))
+		}
+		if tc.dryRun {
+			assert.Empty(t, k.streams, fmt.Sprintf("%s: expected no restored streams\n", tc.desc))
+			assert.Empty(t, k.rules, fmt.Sprintf("%s: expected no restored rules\n", tc.desc))
+		}
+		adminCall.Unset()
+	}
+
+	assert.Equal(t, ddl, k.streams[userPrefix+"readings"], fmt.Sprintf("expected stream DDL %s got %s\n", ddl, k.streams[userPrefix+"readings"]))
+	restored := k.rules[userPrefix+"alarm"]
+	assert.Equal(t, "SELECT * FROM "+userPrefix+"readings WHERE v > 30", restored.SQL, fmt.Sprintf("expected rule SQL to be namespaced got %s\n", restored.SQL))
+}
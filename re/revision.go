@@ -0,0 +1,161 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// AnyRevision is the expected revision matching any revision of the entity.
+const AnyRevision = "*"
+
+var (
+	// ErrStaleRevision indicates that the entity changed since the revision
+	// the update or the deletion expects was read.
+	ErrStaleRevision = errors.New("entity changed since it was read")
+
+	// ErrRevisionRequired indicates that the update or the deletion doesn't
+	// expect any revision, while the service requires one.
+	ErrRevisionRequired = errors.New("entity revision is required")
+)
+
+type revisionKey struct{}
+
+// WithRevision returns the context carrying the revision of the entity the
+// update or the deletion expects, so the entity changed by someone else in
+// the meantime isn't silently overwritten.
+func WithRevision(ctx context.Context, rev string) context.Context {
+	return context.WithValue(ctx, revisionKey{}, rev)
+}
+
+// ExpectedRevision returns the entity revision the context carries, empty if
+// the context doesn't carry one.
+func ExpectedRevision(ctx context.Context) string {
+	rev, _ := ctx.Value(revisionKey{}).(string)
+
+	return rev
+}
+
+// Revision returns the revision of the rule, which changes whenever the rule
+// is updated.
+func (rule Rule) Revision() string {
+	return revision(struct {
+		ID          string
+		SQL         string
+		Actions     []Action
+		Options     *RuleOptions
+		Description string
+		Labels      map[string]string
+		Attributes  map[string]interface{}
+		Updated     int64
+	}{rule.ID, rule.SQL, rule.Actions, rule.Options, rule.Description, rule.Labels, rule.Attributes, updated(rule.Metadata)})
+}
+
+// Revision returns the revision of the stream, which changes whenever the
+// stream is updated.
+func (stream Stream) Revision() string {
+	var description string
+	var labels map[string]string
+	var attributes map[string]interface{}
+	if stream.Metadata != nil {
+		description, labels, attributes = stream.Metadata.Description, stream.Metadata.Labels, stream.Metadata.Attributes
+	}
+
+	return revision(struct {
+		Name        string
+		Fields      []StreamField
+		Options     map[string]string
+		Description string
+		Labels      map[string]string
+		Attributes  map[string]interface{}
+		Updated     int64
+	}{stream.Name, stream.StreamFields, stream.Options, description, labels, attributes, updated(stream.Metadata)})
+}
+
+// updated returns the last update time of the entity with the given
+// metadata, so the revisions don't depend on the metadata fields only some
+// of the APIs return or on the time zone.
+func updated(md *Metadata) int64 {
+	if md == nil {
+		return 0
+	}
+
+	return md.lastUpdate().UnixNano()
+}
+
+// revision returns the hash of the entity as it's viewed.
+func revision(entity interface{}) string {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// checkRevision fails if the context carries the revision other than the
+// current revision of the stream or the rule of the given kind and
+// reference. Without the revision, the check passes unless the revisions are
+// required.
+func (svc *reService) checkRevision(ctx context.Context, token, kind, ref string) error {
+	expected := ExpectedRevision(ctx)
+	switch expected {
+	case "":
+		if svc.requireRevision {
+			return ErrRevisionRequired
+		}
+		return nil
+	case AnyRevision:
+		return nil
+	}
+
+	var current, name string
+	var md *Metadata
+	switch kind {
+	case RuleKind:
+		rule, err := svc.ViewRule(ctx, token, ref)
+		if err != nil {
+			return err
+		}
+		current, name, md = rule.Revision(), rule.ID, rule.Metadata
+	default:
+		stream, err := svc.ViewStream(ctx, token, ref)
+		if err != nil {
+			return err
+		}
+		current, name, md = stream.Revision(), stream.Name, stream.Metadata
+	}
+	if current != expected {
+		return errors.Wrap(svcerr.ErrConflict, ErrStaleRevision)
+	}
+
+	return svc.claimRevision(ctx, kind, name, md)
+}
+
+// claimRevision claims the revision of the entity with the given metadata,
+// as it was read, by moving the entity's update time on. Of the concurrent
+// changes reading the same revision, only the first one claims it and the
+// others fail as stale. Entities without metadata can't be claimed.
+func (svc *reService) claimRevision(ctx context.Context, kind, name string, md *Metadata) error {
+	if md == nil {
+		return nil
+	}
+	switch err := svc.repo.Touch(ctx, kind, prefix(md.Owner)+name, md.lastUpdate(), time.Now().UTC()); {
+	case errors.Contains(err, repoerr.ErrConflict):
+		return errors.Wrap(svcerr.ErrConflict, ErrStaleRevision)
+	case err != nil:
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
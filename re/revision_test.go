@@ -0,0 +1,204 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRuleRevision(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	rule, err := svc.ViewRule(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("view rule: expected no error got %s\n", err))
+	read := rule.Revision()
+	assert.NotEmpty(t, read, "view rule: expected rule revision")
+	assert.Equal(t, read, rule.Revision(), "view rule: expected stable rule revision")
+
+	update := re.Rule{ID: "rule", SQL: "SELECT * FROM stream WHERE v > 20", Actions: rule.Actions}
+	cases := []struct {
+		desc     string
+		revision string
+		err      error
+	}{
+		{
+			desc:     "update rule with stale revision",
+			revision: "0123456789abcdef",
+			err:      re.ErrStaleRevision,
+		},
+		{
+			desc:     "update rule with current revision",
+			revision: read,
+		},
+		{
+			desc:     "update rule with revision read before the update",
+			revision: read,
+			err:      re.ErrStaleRevision,
+		},
+		{
+			desc:     "update rule with any revision",
+			revision: re.AnyRevision,
+		},
+		{
+			desc: "update rule without revision",
+		},
+	}
+
+	for _, tc := range cases {
+		ctx := context.Background()
+		if tc.revision != "" {
+			ctx = re.WithRevision(ctx, tc.revision)
+		}
+		_, err := svc.UpdateRule(ctx, validToken, update)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, svcerr.ErrConflict, err))
+		}
+	}
+
+	rule, err = svc.ViewRule(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("view updated rule: expected no error got %s\n", err))
+	_, err = svc.DeleteRule(re.WithRevision(context.Background(), read), validToken, "rule")
+	assert.True(t, errors.Contains(err, re.ErrStaleRevision), fmt.Sprintf("delete rule with stale revision: expected %s got %s\n", re.ErrStaleRevision, err))
+	_, err = svc.DeleteRule(re.WithRevision(context.Background(), rule.Revision()), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("delete rule with current revision: expected no error got %s\n", err))
+}
+
+func TestPatchRuleRevision(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	actions := []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}
+	_, err := svc.CreateRule(context.Background(), validToken, re.Rule{ID: "patched", SQL: "SELECT * FROM stream", Actions: actions})
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	rule, err := svc.ViewRule(context.Background(), validToken, "patched")
+	assert.Nil(t, err, fmt.Sprintf("view rule: expected no error got %s\n", err))
+	read := rule.Revision()
+
+	patch := re.RulePatch{Options: &re.RuleOptions{QoS: 1}}
+	cases := []struct {
+		desc     string
+		revision string
+		err      error
+	}{
+		{
+			desc:     "patch rule with stale revision",
+			revision: "0123456789abcdef",
+			err:      re.ErrStaleRevision,
+		},
+		{
+			desc:     "patch rule with current revision",
+			revision: read,
+		},
+		{
+			desc:     "patch rule with revision read before the patch",
+			revision: read,
+			err:      re.ErrStaleRevision,
+		},
+		{
+			desc:     "patch rule with any revision",
+			revision: re.AnyRevision,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.PatchRule(re.WithRevision(context.Background(), tc.revision), validToken, "patched", patch)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestConcurrentRevision(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	actions := []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}
+	_, err := svc.CreateRule(context.Background(), validToken, re.Rule{ID: "concurrent", SQL: "SELECT * FROM stream", Actions: actions})
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	rule, err := svc.ViewRule(context.Background(), validToken, "concurrent")
+	assert.Nil(t, err, fmt.Sprintf("view rule: expected no error got %s\n", err))
+
+	// Of the updates expecting the same revision, only one succeeds.
+	const updates = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, updates)
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			update := re.Rule{ID: "concurrent", SQL: fmt.Sprintf("SELECT * FROM stream WHERE v > %d", i), Actions: rule.Actions}
+			_, err := svc.UpdateRule(re.WithRevision(context.Background(), rule.Revision()), validToken, update)
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	var succeeded int
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		default:
+			assert.True(t, errors.Contains(err, re.ErrStaleRevision), fmt.Sprintf("concurrent update: expected %s got %s\n", re.ErrStaleRevision, err))
+		}
+	}
+	assert.Equal(t, 1, succeeded, fmt.Sprintf("concurrent updates: expected 1 succeeded update got %d\n", succeeded))
+}
+
+func TestStreamRevision(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	stream, err := svc.ViewStream(context.Background(), validToken, "stream")
+	assert.Nil(t, err, fmt.Sprintf("view stream: expected no error got %s\n", err))
+	def := re.StreamDef{Name: "stream", Topic: channelID, Fields: []re.Field{{Name: "v", Type: re.FloatType}}}
+
+	_, err = svc.CreateStream(re.WithRevision(context.Background(), "0123456789abcdef"), validToken, def, true)
+	assert.True(t, errors.Contains(err, re.ErrStaleRevision), fmt.Sprintf("update stream with stale revision: expected %s got %s\n", re.ErrStaleRevision, err))
+	_, err = svc.CreateStream(re.WithRevision(context.Background(), stream.Revision()), validToken, def, true)
+	assert.Nil(t, err, fmt.Sprintf("update stream with current revision: expected no error got %s\n", err))
+	_, err = svc.DeleteStream(re.WithRevision(context.Background(), stream.Revision()), validToken, "stream", false)
+	assert.True(t, errors.Contains(err, re.ErrStaleRevision), fmt.Sprintf("delete stream with stale revision: expected %s got %s\n", re.ErrStaleRevision, err))
+}
+
+func TestRequireRevision(t *testing.T) {
+	svc, _, auth, _ := newServiceWithConfig(t, re.Config{RequireRevision: true}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	_, err := svc.DeleteRule(context.Background(), validToken, "rule")
+	assert.True(t, errors.Contains(err, re.ErrRevisionRequired), fmt.Sprintf("delete rule without revision: expected %s got %s\n", re.ErrRevisionRequired, err))
+	_, err = svc.PatchRule(context.Background(), validToken, "rule", re.RulePatch{Options: &re.RuleOptions{QoS: 1}})
+	assert.True(t, errors.Contains(err, re.ErrRevisionRequired), fmt.Sprintf("patch rule without revision: expected %s got %s\n", re.ErrRevisionRequired, err))
+	_, err = svc.DeleteStream(context.Background(), validToken, "stream", false)
+	assert.True(t, errors.Contains(err, re.ErrRevisionRequired), fmt.Sprintf("delete stream without revision: expected %s got %s\n", re.ErrRevisionRequired, err))
+
+	// Bulk operations change many entities, so they don't expect revisions.
+	report, err := svc.BulkDelete(context.Background(), validToken, re.BulkDeletion{Rules: []string{"rule"}})
+	assert.Nil(t, err, fmt.Sprintf("bulk delete: expected no error got %s\n", err))
+	assert.Equal(t, 1, report.Succeeded, fmt.Sprintf("bulk delete: expected 1 deleted rule got %d\n", report.Succeeded))
+}
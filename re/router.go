@@ -0,0 +1,489 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// DefaultInstance is the name of the Kuiper instance at the configured URL.
+const DefaultInstance = "default"
+
+// Strategies of spreading the tenants over the Kuiper instances.
+const (
+	HashStrategy   = "hash"
+	StaticStrategy = "static"
+)
+
+var errPoolConfig = errors.New("invalid Kuiper pool configuration")
+
+// PoolConfig defines the Kuiper instances the tenants are spread over, in
+// addition to the default instance at the Kuiper URL. Instances maps the
+// instance names to their URLs and share the rest of the Kuiper
+// configuration. The hash Strategy spreads the tenants over all the
+// instances by their IDs, while the static one keeps them on the default
+// instance. Either way, the tenants the platform administrator assigned to
+// an instance use that instance. Each service replica reloads the
+// assignments every Refresh.
+type PoolConfig struct {
+	Instances map[string]string `env:"INSTANCES" envSeparator:"," envKeyValSeparator:"="`
+	Strategy  string            `env:"STRATEGY"  envDefault:"hash"`
+	Refresh   time.Duration     `env:"REFRESH"   envDefault:"10s"`
+}
+
+// Validate checks the names and the URLs of the instances and the strategy.
+func (c PoolConfig) Validate() error {
+	if c.Strategy != HashStrategy && c.Strategy != StaticStrategy {
+		return errors.Wrap(errPoolConfig, errors.New("unknown strategy "+c.Strategy))
+	}
+	for name, url := range c.Instances {
+		if name == DefaultInstance || validateName(name) != nil {
+			return errors.Wrap(errPoolConfig, errors.New("invalid instance name "+name))
+		}
+		if url == "" {
+			return errors.Wrap(errPoolConfig, errors.New("missing URL of instance "+name))
+		}
+	}
+
+	return nil
+}
+
+// AssignmentRepository specifies the persistence API of the Kuiper instances
+// the platform administrator assigned the users to.
+type AssignmentRepository interface {
+	// SaveAssignment assigns the user to the instance, replacing the
+	// existing assignment.
+	SaveAssignment(ctx context.Context, userID, instance string) error
+
+	// RemoveAssignment removes the assignment of the user.
+	RemoveAssignment(ctx context.Context, userID string) error
+
+	// RetrieveAssignments returns the instances of all the assigned users.
+	RetrieveAssignments(ctx context.Context) (map[string]string, error)
+}
+
+// enginePool is the engine spreading the tenants over several engine
+// instances.
+type enginePool interface {
+	// instances returns the engine instances by their names.
+	instances() map[string]RuleEngine
+
+	// instanceOf returns the name of the instance the entities with the
+	// owner prefix are on.
+	instanceOf(ctx context.Context, pfx string) (string, error)
+
+	// placement returns the name of the instance the strategy places the
+	// entities with the owner prefix on, ignoring the assignments.
+	placement(pfx string) string
+
+	// reload makes the pool reload the assignments on the next request.
+	reload()
+}
+
+// NewEngine creates the Kuiper engine or, if the pool instances are set, the
+// router spreading the tenants over the default and the pool instances. The
+// instances share the HTTP client and its connection pool, so the engine is
+// created once and shared by all the services using it.
+func NewEngine(cfg Config, repo AssignmentRepository) RuleEngine {
+	client := newClient(cfg)
+	if len(cfg.Pool.Instances) == 0 {
+		return newKuiper(cfg, client)
+	}
+	engines := map[string]RuleEngine{DefaultInstance: newKuiper(cfg, client)}
+	for name, url := range cfg.Pool.Instances {
+		if name == DefaultInstance {
+			continue
+		}
+		icfg := cfg
+		icfg.URL = url
+		icfg.Push = cfg.Push.instance(url)
+		engines[name] = newKuiper(icfg, client)
+	}
+
+	return newRouter(engines, cfg.Pool, repo)
+}
+
+// router is the engine routing the requests of each tenant to the Kuiper
+// instance the tenant is on. The tenant is told by the owner prefix of the
+// stream, table and rule names. Plugins, external services and conf keys are
+// shared by the tenants, so they are changed on all the instances and listed
+// from the default one.
+type router struct {
+	names    []string
+	engines  map[string]RuleEngine
+	static   bool
+	refresh  time.Duration
+	repo     AssignmentRepository
+	mu       sync.Mutex
+	assigned map[string]string
+	loadedAt time.Time
+}
+
+var (
+	_ RuleEngine = (*router)(nil)
+	_ enginePool = (*router)(nil)
+)
+
+func newRouter(engines map[string]RuleEngine, cfg PoolConfig, repo AssignmentRepository) *router {
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &router{
+		names:   names,
+		engines: engines,
+		static:  cfg.Strategy == StaticStrategy,
+		refresh: cfg.Refresh,
+		repo:    repo,
+	}
+}
+
+func (r *router) instances() map[string]RuleEngine {
+	return r.engines
+}
+
+func (r *router) reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.assigned = nil
+}
+
+func (r *router) instanceOf(ctx context.Context, pfx string) (string, error) {
+	assigned, err := r.assignments(ctx)
+	if err != nil {
+		return "", err
+	}
+	// Users assigned to the instances removed from the pool fall back to
+	// the strategy.
+	if name, ok := assigned[pfx]; ok {
+		if _, ok := r.engines[name]; ok {
+			return name, nil
+		}
+	}
+
+	return r.placement(pfx), nil
+}
+
+func (r *router) placement(pfx string) string {
+	if r.static || pfx == "" {
+		return DefaultInstance
+	}
+
+	// Rendezvous hashing moves only the tenants of the added or removed
+	// instance when the pool changes.
+	var best string
+	var bestScore uint64
+	for _, name := range r.names {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(name + "/" + pfx))
+		if score := h.Sum64(); best == "" || score > bestScore {
+			best, bestScore = name, score
+		}
+	}
+
+	return best
+}
+
+// assignments returns the instances of the assigned users by their owner
+// prefixes, reloading them if they are older than the refresh period.
+func (r *router) assignments(ctx context.Context) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.assigned != nil && time.Since(r.loadedAt) < r.refresh {
+		return r.assigned, nil
+	}
+	all, err := r.repo.RetrieveAssignments(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ErrKuiperServer, err)
+	}
+	r.assigned = make(map[string]string, len(all))
+	for userID, name := range all {
+		r.assigned[prefix(userID)] = name
+	}
+	r.loadedAt = time.Now()
+
+	return r.assigned, nil
+}
+
+// route returns the instance the entity with the given name is on.
+func (r *router) route(ctx context.Context, name string) (RuleEngine, error) {
+	inst, err := r.instanceOf(ctx, ownerPrefix(name))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.engines[inst], nil
+}
+
+// ownerPrefix returns the owner prefix of the entity name, empty if the
+// name isn't namespaced.
+func ownerPrefix(name string) string {
+	if !strings.HasPrefix(name, "u") {
+		return ""
+	}
+	i := strings.Index(name, "_")
+	if i < 0 {
+		return ""
+	}
+
+	return name[:i+1]
+}
+
+// ddlName returns the name of the stream or the table the DDL creates.
+func ddlName(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) < 3 {
+		return ""
+	}
+	name, _, _ := strings.Cut(fields[2], "(")
+
+	return name
+}
+
+// Info returns the information about the default instance. The other
+// instances are asked as well, so each of them is called through the
+// adapter of its own Kuiper version. The instances that don't answer keep
+// the version they had until the next call.
+func (r *router) Info(ctx context.Context) (Info, error) {
+	for _, name := range r.names {
+		if name != DefaultInstance {
+			_, _ = r.engines[name].Info(ctx)
+		}
+	}
+
+	return r.engines[DefaultInstance].Info(ctx)
+}
+
+func (r *router) CreateStream(ctx context.Context, kind, sql string) (Result, error) {
+	e, err := r.route(ctx, ddlName(sql))
+	if err != nil {
+		return Result{}, err
+	}
+
+	return e.CreateStream(ctx, kind, sql)
+}
+
+func (r *router) UpdateStream(ctx context.Context, kind, name, sql string) (Result, error) {
+	e, err := r.route(ctx, name)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return e.UpdateStream(ctx, kind, name, sql)
+}
+
+func (r *router) ListStreams(ctx context.Context, kind string) ([]string, error) {
+	var all []string
+	for _, name := range r.names {
+		names, err := r.engines[name].ListStreams(ctx, kind)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, names...)
+	}
+
+	return all, nil
+}
+
+func (r *router) ViewStream(ctx context.Context, kind, name string) (Stream, error) {
+	e, err := r.route(ctx, name)
+	if err != nil {
+		return Stream{}, err
+	}
+
+	return e.ViewStream(ctx, kind, name)
+}
+
+func (r *router) DeleteStream(ctx context.Context, kind, name string) (Result, error) {
+	e, err := r.route(ctx, name)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return e.DeleteStream(ctx, kind, name)
+}
+
+func (r *router) CreateRule(ctx context.Context, rule EngineRule) (Result, error) {
+	e, err := r.route(ctx, rule.ID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return e.CreateRule(ctx, rule)
+}
+
+func (r *router) UpdateRule(ctx context.Context, rule EngineRule) (Result, error) {
+	e, err := r.route(ctx, rule.ID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return e.UpdateRule(ctx, rule)
+}
+
+func (r *router) ListRules(ctx context.Context) ([]RuleInfo, error) {
+	var all []RuleInfo
+	for _, name := range r.names {
+		rules, err := r.engines[name].ListRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, rules...)
+	}
+
+	return all, nil
+}
+
+func (r *router) ViewRule(ctx context.Context, id string) (EngineRule, error) {
+	e, err := r.route(ctx, id)
+	if err != nil {
+		return EngineRule{}, err
+	}
+
+	return e.ViewRule(ctx, id)
+}
+
+func (r *router) DeleteRule(ctx context.Context, id string) (Result, error) {
+	e, err := r.route(ctx, id)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return e.DeleteRule(ctx, id)
+}
+
+func (r *router) ControlRule(ctx context.Context, id, command string) (Result, error) {
+	e, err := r.route(ctx, id)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return e.ControlRule(ctx, id, command)
+}
+
+func (r *router) RuleStatus(ctx context.Context, id string) (RuleStatus, error) {
+	e, err := r.route(ctx, id)
+	if err != nil {
+		return RuleStatus{}, err
+	}
+
+	return e.RuleStatus(ctx, id)
+}
+
+func (r *router) RuleTopology(ctx context.Context, id string) (RuleTopology, error) {
+	e, err := r.route(ctx, id)
+	if err != nil {
+		return RuleTopology{}, err
+	}
+
+	return e.RuleTopology(ctx, id)
+}
+
+func (r *router) ValidateRule(ctx context.Context, rule EngineRule) error {
+	e, err := r.route(ctx, rule.ID)
+	if err != nil {
+		return err
+	}
+
+	return e.ValidateRule(ctx, rule)
+}
+
+func (r *router) RunTrial(ctx context.Context, trial EngineTrial) (TrialResult, error) {
+	e, err := r.route(ctx, trial.ID)
+	if err != nil {
+		return TrialResult{}, err
+	}
+
+	return e.RunTrial(ctx, trial)
+}
+
+func (r *router) CreatePlugin(ctx context.Context, kind string, plugin Plugin) (Result, error) {
+	return r.broadcast(func(e RuleEngine) (Result, error) {
+		return e.CreatePlugin(ctx, kind, plugin)
+	})
+}
+
+func (r *router) ListPlugins(ctx context.Context, kind string) ([]string, error) {
+	return r.engines[DefaultInstance].ListPlugins(ctx, kind)
+}
+
+func (r *router) DeletePlugin(ctx context.Context, kind, name string) (Result, error) {
+	return r.broadcast(func(e RuleEngine) (Result, error) {
+		return e.DeletePlugin(ctx, kind, name)
+	})
+}
+
+func (r *router) RegisterService(ctx context.Context, es ExternalService) (Result, error) {
+	return r.broadcast(func(e RuleEngine) (Result, error) {
+		return e.RegisterService(ctx, es)
+	})
+}
+
+func (r *router) ListServices(ctx context.Context) ([]string, error) {
+	return r.engines[DefaultInstance].ListServices(ctx)
+}
+
+func (r *router) DeleteService(ctx context.Context, name string) (Result, error) {
+	return r.broadcast(func(e RuleEngine) (Result, error) {
+		return e.DeleteService(ctx, name)
+	})
+}
+
+func (r *router) ListFunctions(ctx context.Context) ([]ExternalFunction, error) {
+	return r.engines[DefaultInstance].ListFunctions(ctx)
+}
+
+func (r *router) SaveConfKey(ctx context.Context, name string, conf MQTTConf) (Result, error) {
+	return r.broadcast(func(e RuleEngine) (Result, error) {
+		return e.SaveConfKey(ctx, name, conf)
+	})
+}
+
+func (r *router) ListConfKeys(ctx context.Context) ([]string, error) {
+	return r.engines[DefaultInstance].ListConfKeys(ctx)
+}
+
+func (r *router) DeleteConfKey(ctx context.Context, name string) (Result, error) {
+	return r.broadcast(func(e RuleEngine) (Result, error) {
+		return e.DeleteConfKey(ctx, name)
+	})
+}
+
+func (r *router) Push(ctx context.Context, stream string, payload []byte) error {
+	e, err := r.route(ctx, stream)
+	if err != nil {
+		return err
+	}
+
+	return e.Push(ctx, stream, payload)
+}
+
+// broadcast runs the operation on all the instances, starting with the
+// default one, and returns the result of the default instance. The
+// operation stops at the first failing instance.
+func (r *router) broadcast(op func(e RuleEngine) (Result, error)) (Result, error) {
+	res, err := op(r.engines[DefaultInstance])
+	if err != nil {
+		return Result{}, err
+	}
+	for _, name := range r.names {
+		if name == DefaultInstance {
+			continue
+		}
+		if _, err := op(r.engines[name]); err != nil {
+			return Result{}, err
+		}
+	}
+
+	return res, nil
+}
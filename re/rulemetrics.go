@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// RuleMetrics are the execution metrics of the rule Kuiper reports. The
+// records and the exceptions are counted since the rule started, as in
+// EngineStats, and the latency is the sum of the latencies of the rule
+// operators, the time a record takes through the whole rule.
+type RuleMetrics struct {
+	Owner      string
+	Rule       string
+	Running    bool
+	RecordsIn  int64
+	RecordsOut int64
+	Exceptions int64
+	LatencyUs  int64
+}
+
+// Scraper reads the execution metrics of the rules of all the users, so
+// it's used by the background metrics job and never exposed over the API.
+type Scraper interface {
+	// Scrape returns the metrics of the rules Kuiper runs. The rules whose
+	// status can't be read, the deleted rules and the rules without the
+	// metadata are left out.
+	Scrape(ctx context.Context) ([]RuleMetrics, error)
+}
+
+type scraper struct {
+	svc *reService
+}
+
+// NewScraper instantiates the scraper running the rules in the given engine.
+func NewScraper(engine RuleEngine, cfg Config, repo Repository) Scraper {
+	return &scraper{svc: newService(engine, cfg, nil, nil, Notifiers{}, nil, repo)}
+}
+
+func (s *scraper) Scrape(ctx context.Context) ([]RuleMetrics, error) {
+	mds, err := s.svc.repo.RetrieveAll(ctx, RuleKind, "")
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	all, err := s.svc.engine.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rules []RuleInfo
+	for _, r := range all {
+		if md, ok := mds[r.ID]; ok && !md.deleted() {
+			rules = append(rules, r)
+		}
+	}
+
+	scraped := make([]*RuleMetrics, len(rules))
+	s.svc.bulk(RuleKind, len(rules), func(i int) (string, error) {
+		status, err := s.svc.engine.RuleStatus(ctx, rules[i].ID)
+		if err != nil {
+			return rules[i].ID, err
+		}
+		c := status.counters()
+		m := RuleMetrics{
+			Owner:      mds[rules[i].ID].Owner,
+			Rule:       strings.TrimPrefix(rules[i].ID, ownerPrefix(rules[i].ID)),
+			Running:    statsState(rules[i].Status) == StatsRunning,
+			RecordsIn:  c.in,
+			RecordsOut: c.out,
+			Exceptions: c.exceptions,
+		}
+		for _, op := range status.Operators {
+			m.LatencyUs += op.ProcessLatencyUs
+		}
+		scraped[i] = &m
+		return rules[i].ID, nil
+	})
+
+	metrics := []RuleMetrics{}
+	for _, m := range scraped {
+		if m != nil {
+			metrics = append(metrics, *m)
+		}
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrape(t *testing.T) {
+	k, url := newKuiper(t)
+	repo := mocks.NewRepository()
+	k.rules[userPrefix+"deleted"] = re.Rule{ID: userPrefix + "deleted"}
+	k.stopped[userPrefix+"deleted"] = true
+	err := repo.Save(context.Background(), re.RuleKind, userPrefix+"rule", re.Metadata{Owner: userID})
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	err = repo.Save(context.Background(), re.RuleKind, userPrefix+"deleted", re.Metadata{Owner: userID, DeletedAt: time.Now().UTC()})
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+
+	// The other user's rule has no metadata, so it's left out along with
+	// the deleted rule.
+	cfg := re.Config{URL: url}
+	s := re.NewScraper(re.NewKuiper(cfg), cfg, repo)
+	metrics, err := s.Scrape(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("scrape: expected no error got %s\n", err))
+	expected := []re.RuleMetrics{{Owner: userID, Rule: "rule", Running: true, RecordsIn: 10, Exceptions: 1}}
+	assert.Equal(t, expected, metrics, fmt.Sprintf("expected %v got %v\n", expected, metrics))
+}
@@ -0,0 +1,286 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// ErrMalformedSubtopic indicates that the action subtopic contains
+// wildcards or empty segments, so messages can't be published to it.
+var ErrMalformedSubtopic = errors.New("malformed subtopic")
+
+// maxConcurrency limits the number of instances of each rule operator.
+const maxConcurrency = 32
+
+var (
+	errQoS           = errors.New("qos must be 0 (at most once), 1 (at least once) or 2 (exactly once)")
+	errCheckpoint    = errors.New("checkpoint interval must not be negative")
+	errLateTolerance = errors.New("late tolerance must not be negative and requires event time")
+	errConcurrency   = errors.New("concurrency must be between 0 and 32")
+	errEmptyPatch    = errors.New("rule patch must change the SQL, actions or options")
+)
+
+// Rule represents Kuiper rule. SQL selects data from the user's streams,
+// possibly joining several of them, and Actions define where the results
+// are sent to. Options are optional and Kuiper defaults are used for the
+// options that are not set. Description, Labels, the free-form Attributes,
+// ExpiresAt, the time the temporary rule is removed at, and Schedule, the
+// times the rule runs at, are stored as the rule metadata, which is returned
+// in Metadata when the rule is viewed.
+type Rule struct {
+	ID      string       `json:"id"`
+	SQL     string       `json:"sql"`
+	Actions []Action     `json:"actions"`
+	Options *RuleOptions `json:"options,omitempty"`
+
+	Description string                 `json:"description,omitempty"`
+	Labels      map[string]string      `json:"labels,omitempty"`
+	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+	ExpiresAt   time.Time              `json:"expires_at,omitempty"`
+	Schedule    *Schedule              `json:"schedule,omitempty"`
+	Metadata    *Metadata              `json:"metadata,omitempty"`
+}
+
+// RulePatch contains the rule fields changed by the rule patch, while the
+// fields that aren't set are kept. Actions and Options replace all the rule
+// actions and options.
+type RulePatch struct {
+	SQL     *string      `json:"sql,omitempty"`
+	Actions []Action     `json:"actions,omitempty"`
+	Options *RuleOptions `json:"options,omitempty"`
+}
+
+// apply returns the rule with the patch applied.
+func (patch RulePatch) apply(rule Rule) Rule {
+	if patch.SQL != nil {
+		rule.SQL = *patch.SQL
+	}
+	if patch.Actions != nil {
+		rule.Actions = patch.Actions
+	}
+	if patch.Options != nil {
+		rule.Options = patch.Options
+	}
+
+	return rule
+}
+
+func (patch RulePatch) empty() bool {
+	return patch.SQL == nil && patch.Actions == nil && patch.Options == nil
+}
+
+// retarget returns the actions with the Mainflux sinks publishing to the
+// channel, leaving the given actions unchanged.
+func retarget(actions []Action, channel string) []Action {
+	ret := make([]Action, len(actions))
+	for i, a := range actions {
+		if a.Mainflux != nil {
+			sink := *a.Mainflux
+			sink.Channel = channel
+			a.Mainflux = &sink
+		}
+		ret[i] = a
+	}
+
+	return ret
+}
+
+// EngineRule is the rule as stored by the rule engine, in the Kuiper rule
+// format. Writer actions are expanded to the Kuiper sinks connected to the
+// writer databases and notification actions to the Mainflux sinks publishing
+// to the notification subtopics.
+type EngineRule struct {
+	ID      string            `json:"id"`
+	SQL     string            `json:"sql"`
+	Actions []json.RawMessage `json:"actions"`
+	Options *RuleOptions      `json:"options,omitempty"`
+}
+
+// RuleOptions represents Kuiper rule options. QoS is the processing
+// guarantee: 0 (at most once), 1 (at least once) or 2 (exactly once).
+// CheckpointInterval is the interval in milliseconds between the rule state
+// checkpoints used by QoS 1 and 2. IsEventTime enables event time windows
+// and LateTolerance is the time in milliseconds late events are accepted
+// for. SendMetaToSink sends message metadata to the actions and Concurrency
+// is the number of instances of each rule operator.
+type RuleOptions struct {
+	QoS                int   `json:"qos,omitempty"`
+	CheckpointInterval int64 `json:"checkpointInterval,omitempty"`
+	IsEventTime        bool  `json:"isEventTime,omitempty"`
+	LateTolerance      int64 `json:"lateTolerance,omitempty"`
+	SendMetaToSink     bool  `json:"sendMetaToSink,omitempty"`
+	Concurrency        int   `json:"concurrency,omitempty"`
+}
+
+// RuleInfo represents the rule summary returned when listing rules.
+type RuleInfo struct {
+	ID       string    `json:"id"`
+	Status   string    `json:"status"`
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
+
+// RuleStatus represents runtime status of the rule and metrics of each
+// of its operators (sources, operators and sinks).
+type RuleStatus struct {
+	Status    string            `json:"status"`
+	Message   string            `json:"message,omitempty"`
+	Operators []OperatorMetrics `json:"operators,omitempty"`
+}
+
+// OperatorMetrics represents runtime metrics of a single rule operator instance.
+type OperatorMetrics struct {
+	Name              string `json:"name"`
+	RecordsIn         int64  `json:"records_in"`
+	RecordsOut        int64  `json:"records_out"`
+	Exceptions        int64  `json:"exceptions"`
+	LastException     string `json:"last_exception,omitempty"`
+	LastExceptionTime string `json:"last_exception_time,omitempty"`
+	ProcessLatencyUs  int64  `json:"process_latency_us"`
+	BufferLength      int64  `json:"buffer_length"`
+	LastInvocation    string `json:"last_invocation,omitempty"`
+}
+
+// toKuiper returns the Kuiper rule of the namespaced rule.
+func toKuiper(rule Rule, pfx string, writers WritersConfig) (EngineRule, error) {
+	id := strings.TrimPrefix(rule.ID, pfx)
+	kr := EngineRule{ID: rule.ID, SQL: rule.SQL, Options: rule.Options}
+	for i, a := range rule.Actions {
+		// The delivery and the data template are set on the sink itself
+		// rather than on the action.
+		props, err := a.Delivery.props()
+		if err != nil {
+			return EngineRule{}, err
+		}
+		if a.DataTemplate != "" {
+			props[dataTemplateProp] = a.DataTemplate
+		}
+		a.Delivery, a.DataTemplate = nil, ""
+		var v interface{} = a
+		if a.Writer != nil {
+			v = writers.sink(*a.Writer, pfx)
+		}
+		if typ, n := a.notification(); n != nil {
+			v = Action{Mainflux: &MainfluxSink{Channel: n.Channel, Subtopic: notificationSubtopic(typ, id, i)}}
+		}
+		if a.Memory != nil {
+			v = Action{Memory: &MemorySink{Topic: pfx + a.Memory.Topic}}
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return EngineRule{}, err
+		}
+		if data, err = withProps(data, props); err != nil {
+			return EngineRule{}, err
+		}
+		kr.Actions = append(kr.Actions, data)
+	}
+
+	return kr, nil
+}
+
+// ruleDefinition returns the JSON definition of the rule as created by the
+// owner, stored to restore the rule. Description, labels and attributes are
+// left out, since they are stored as the rule metadata.
+func ruleDefinition(rule Rule) (string, error) {
+	rule.Description, rule.Labels, rule.Attributes, rule.ExpiresAt, rule.Schedule, rule.Metadata = "", nil, nil, time.Time{}, nil, nil
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// fromKuiper returns the rule stored by Kuiper, with the owner prefix
+// removed from the rule and the memory topics and the delivery and the data
+// template taken out of the sinks. Writer actions are returned without the
+// database settings and notification actions without the contacts, which
+// are kept by the notifiers.
+func fromKuiper(kr EngineRule, pfx string, writers WritersConfig) (Rule, error) {
+	id := strings.TrimPrefix(kr.ID, pfx)
+	rule := Rule{ID: id, SQL: removePrefix(kr.SQL, pfx), Options: kr.Options, Actions: []Action{}}
+	for _, data := range kr.Actions {
+		d, err := delivery(data)
+		if err != nil {
+			return Rule{}, err
+		}
+		tmpl, err := dataTemplate(data)
+		if err != nil {
+			return Rule{}, err
+		}
+		w, err := writers.writer(data, pfx)
+		if err != nil {
+			return Rule{}, err
+		}
+		if w != nil {
+			rule.Actions = append(rule.Actions, Action{Writer: w, Delivery: d, DataTemplate: tmpl})
+			continue
+		}
+		var a Action
+		if err := json.Unmarshal(data, &a); err != nil {
+			return Rule{}, err
+		}
+		if a.Memory != nil {
+			a.Memory.Topic = strings.TrimPrefix(a.Memory.Topic, pfx)
+		}
+		if a.Mainflux != nil {
+			n := &NotificationSink{Channel: a.Mainflux.Channel}
+			switch parseNotificationSubtopic(a.Mainflux.Subtopic, id) {
+			case EmailSinkType:
+				a = Action{Email: n}
+			case SMSSinkType:
+				a = Action{SMS: n}
+			}
+		}
+		a.Delivery = d
+		// REST sinks keep their own data template.
+		if a.REST == nil {
+			a.DataTemplate = tmpl
+		}
+		rule.Actions = append(rule.Actions, a)
+	}
+
+	return rule, nil
+}
+
+// validateSubtopic checks that the subtopic can be published to. Subtopic
+// segments are separated with "." or "/" and must be non-empty and free of
+// the "*" and ">" wildcards.
+func validateSubtopic(subtopic string) error {
+	if subtopic == "" {
+		return nil
+	}
+	for _, elem := range strings.Split(strings.ReplaceAll(subtopic, "/", "."), ".") {
+		if elem == "" || strings.ContainsAny(elem, "*>") {
+			return ErrMalformedSubtopic
+		}
+	}
+
+	return nil
+}
+
+// validate checks that the rule options are supported by Kuiper. Rules
+// without options are valid.
+func (opts *RuleOptions) validate() error {
+	if opts == nil {
+		return nil
+	}
+	switch {
+	case opts.QoS < 0 || opts.QoS > 2:
+		return errQoS
+	case opts.CheckpointInterval < 0:
+		return errCheckpoint
+	case opts.LateTolerance < 0 || opts.LateTolerance > 0 && !opts.IsEventTime:
+		return errLateTolerance
+	case opts.Concurrency < 0 || opts.Concurrency > maxConcurrency:
+		return errConcurrency
+	default:
+		return nil
+	}
+}
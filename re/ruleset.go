@@ -0,0 +1,246 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Strategies resolving the conflicts of the imported entities with the
+// existing ones.
+const (
+	// ConflictSkip keeps the existing entity.
+	ConflictSkip = "skip"
+
+	// ConflictOverwrite replaces the existing entity.
+	ConflictOverwrite = "overwrite"
+
+	// ConflictRename imports the entity under the first free name with the
+	// numeric suffix, e.g. "alarm_1".
+	ConflictRename = "rename"
+)
+
+// Statuses of the imported entities.
+const (
+	// ImportCreated marks the entity created without a conflict.
+	ImportCreated = "created"
+
+	// ImportSkipped marks the entity that already exists and was kept,
+	// including the identical existing entity with any strategy.
+	ImportSkipped = "skipped"
+
+	// ImportOverwritten marks the existing entity replaced by the imported one.
+	ImportOverwritten = "overwritten"
+
+	// ImportRenamed marks the entity created under a new name.
+	ImportRenamed = "renamed"
+
+	// ImportFailed marks the entity that failed to be created.
+	ImportFailed = "failed"
+)
+
+// maxRenames limits the names tried for the renamed entity.
+const maxRenames = 100
+
+var errConflictStrategy = errors.New("conflict strategy must be skip, overwrite or rename")
+
+// Ruleset is the document containing all the streams and rules of the user,
+// named without the owner prefix, so it can be imported into another
+// environment. Skipped contains the names of the streams that can't be
+// exported, since they were created before their definitions were stored.
+type Ruleset struct {
+	Streams []StreamDef `json:"streams"`
+	Rules   []Rule      `json:"rules"`
+	Skipped []string    `json:"skipped,omitempty"`
+}
+
+// ImportedEntity is the imported stream or rule. Renamed is the name the
+// entity was created under and Error is the reason the import failed.
+type ImportedEntity struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Renamed string `json:"renamed,omitempty"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ImportReport is the report of the ruleset import using the conflict
+// strategy. Counts maps the statuses to the number of entities with the
+// status. Entities are listed in the order they are imported in.
+type ImportReport struct {
+	Conflict string           `json:"conflict"`
+	Counts   map[string]int   `json:"counts"`
+	Entities []ImportedEntity `json:"entities"`
+}
+
+func (svc *reService) ExportRuleset(ctx context.Context, token string) (Ruleset, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	pfx := prefix(userID)
+	rs := Ruleset{Streams: []StreamDef{}, Rules: []Rule{}}
+	streams, err := svc.ownedNames(ctx, StreamKind, pfx)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	mds, err := svc.repo.RetrieveAll(ctx, StreamKind, userID)
+	if err != nil {
+		return Ruleset{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for _, name := range streams {
+		md := mds[pfx+name]
+		var def StreamDef
+		if err := json.Unmarshal([]byte(md.Definition), &def); err != nil {
+			rs.Skipped = append(rs.Skipped, name)
+			continue
+		}
+		def.Description, def.Labels, def.Attributes = md.Description, md.Labels, md.Attributes
+		rs.Streams = append(rs.Streams, def)
+	}
+
+	rules, err := svc.ownedNames(ctx, RuleKind, pfx)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	if mds, err = svc.repo.RetrieveAll(ctx, RuleKind, userID); err != nil {
+		return Ruleset{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for _, id := range rules {
+		md := mds[pfx+id]
+		if md.deleted() {
+			continue
+		}
+		var rule Rule
+		// Rules created before their definitions were stored are read
+		// from Kuiper.
+		if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+			if rule, err = svc.ViewRule(ctx, token, id); err != nil {
+				return Ruleset{}, err
+			}
+			rule.Metadata = nil
+		}
+		rule.Description, rule.Labels, rule.Attributes = md.Description, md.Labels, md.Attributes
+		rule.Actions = maskActions(rule.Actions)
+		rs.Rules = append(rs.Rules, rule)
+	}
+
+	return rs, nil
+}
+
+// ownedNames returns the sorted names of the Kuiper entities of the kind
+// that belong to the owner with the given prefix, without the prefix.
+func (svc *reService) ownedNames(ctx context.Context, kind, pfx string) ([]string, error) {
+	all, err := svc.kuiperNames(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range all {
+		if strings.HasPrefix(name, pfx) {
+			names = append(names, strings.TrimPrefix(name, pfx))
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (svc *reService) ImportRuleset(ctx context.Context, token string, rs Ruleset, conflict string) (ImportReport, error) {
+	if conflict == "" {
+		conflict = ConflictSkip
+	}
+	if conflict != ConflictSkip && conflict != ConflictOverwrite && conflict != ConflictRename {
+		return ImportReport{}, errors.Wrap(svcerr.ErrMalformedEntity, errConflictStrategy)
+	}
+	ctx, err := svc.writing(ctx, token)
+	if err != nil {
+		return ImportReport{}, err
+	}
+
+	report := ImportReport{Conflict: conflict, Counts: make(map[string]int), Entities: []ImportedEntity{}}
+	add := func(e ImportedEntity) {
+		report.Counts[e.Status]++
+		report.Entities = append(report.Entities, e)
+	}
+
+	// Streams are imported first, since rules read from them.
+	renamed := make(map[string]string)
+	for _, def := range rs.Streams {
+		e := importEntity(StreamKind, def.Name, conflict, func(name string, update bool) (Result, error) {
+			def := def
+			def.Name = name
+			return svc.CreateStream(ctx, token, def, update)
+		})
+		if e.Renamed != "" {
+			renamed[def.Name] = e.Renamed
+		}
+		add(e)
+	}
+	for _, rule := range rs.Rules {
+		sql, err := rewriteStreams(rule.SQL, func(name string) string {
+			if r, ok := renamed[name]; ok {
+				return r
+			}
+			return name
+		})
+		if err != nil {
+			add(ImportedEntity{Kind: RuleKind, Name: rule.ID, Status: ImportFailed, Error: err.Error()})
+			continue
+		}
+		rule.SQL, rule.Metadata = sql, nil
+		add(importEntity(RuleKind, rule.ID, conflict, func(id string, update bool) (Result, error) {
+			rule := rule
+			rule.ID = id
+			if update {
+				return svc.UpdateRule(ctx, token, rule)
+			}
+			return svc.CreateRule(ctx, token, rule)
+		}))
+	}
+
+	return report, nil
+}
+
+// importEntity creates the entity with the given name and resolves the
+// conflict with the existing entity using the strategy. Create creates the
+// entity with the given name, replacing the existing entity on update. The
+// identical existing entity isn't a conflict and is kept.
+func importEntity(kind, name, conflict string, create func(name string, update bool) (Result, error)) ImportedEntity {
+	e := ImportedEntity{Kind: kind, Name: name, Status: ImportCreated}
+	res, err := create(name, false)
+	if err == nil && res.Status == http.StatusOK {
+		e.Status = ImportSkipped
+	}
+	if errors.Contains(err, svcerr.ErrConflict) {
+		switch conflict {
+		case ConflictSkip:
+			e.Status, err = ImportSkipped, nil
+		case ConflictOverwrite:
+			e.Status = ImportOverwritten
+			_, err = create(name, true)
+		default:
+			e.Status = ImportRenamed
+			for i := 1; i <= maxRenames && errors.Contains(err, svcerr.ErrConflict); i++ {
+				e.Renamed = fmt.Sprintf("%s_%d", name, i)
+				_, err = create(e.Renamed, false)
+			}
+		}
+	}
+	if err != nil {
+		e.Renamed, e.Status, e.Error = "", ImportFailed, err.Error()
+	}
+
+	return e
+}
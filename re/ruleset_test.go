@@ -0,0 +1,194 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportRuleset(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	def := re.StreamDef{Name: "readings", Topic: channelID, SenML: true, Description: "readings"}
+	_, err := svc.CreateStream(context.Background(), validToken, def, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+	alarm := re.Rule{
+		ID:      "alarm",
+		SQL:     "SELECT * FROM readings WHERE v > 30",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+		Labels:  map[string]string{"site": "a"},
+	}
+	_, err = svc.CreateRule(context.Background(), validToken, alarm)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		failure string
+		rs      re.Ruleset
+		err     error
+	}{
+		{
+			desc:  "export ruleset",
+			token: validToken,
+			rs: re.Ruleset{
+				Streams: []re.StreamDef{{Name: "readings", Topic: channelID, Type: re.MainfluxSource, Format: re.JSONFormat, SenML: true, Description: "readings"}},
+				Rules: []re.Rule{
+					alarm,
+					{
+						ID:      "rule",
+						SQL:     "SELECT * FROM stream WHERE v > 10",
+						Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+					},
+				},
+				Skipped: []string{"stream"},
+			},
+		},
+		{
+			desc:  "export ruleset with invalid token",
+			token: invalidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "export ruleset with failed rules lookup",
+			token:   validToken,
+			failure: "/rules",
+			err:     re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		if tc.failure != "" {
+			k.failures[tc.failure] = http.StatusInternalServerError
+		}
+		rs, err := svc.ExportRuleset(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.rs, rs, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rs, rs))
+		delete(k.failures, tc.failure)
+	}
+}
+
+func TestImportRuleset(t *testing.T) {
+	rs := re.Ruleset{
+		Streams: []re.StreamDef{{Name: "stream", Topic: channelID, SenML: true}},
+		Rules: []re.Rule{
+			{ID: "rule", SQL: "SELECT * FROM stream WHERE v > 10", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}},
+			{ID: "alarm", SQL: "SELECT * FROM stream WHERE v > 30", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}},
+		},
+	}
+	malformed := re.Ruleset{
+		Streams: []re.StreamDef{{Name: "1stream", Topic: channelID, SenML: true}},
+		Rules:   []re.Rule{{ID: "alarm", SQL: "SELECT * FROM stream"}},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		rs       re.Ruleset
+		conflict string
+		entities []re.ImportedEntity
+		alarmSQL string
+		err      error
+	}{
+		{
+			desc:  "import ruleset skipping existing entities",
+			token: validToken,
+			rs:    rs,
+			entities: []re.ImportedEntity{
+				{Kind: re.StreamKind, Name: "stream", Status: re.ImportSkipped},
+				{Kind: re.RuleKind, Name: "rule", Status: re.ImportSkipped},
+				{Kind: re.RuleKind, Name: "alarm", Status: re.ImportCreated},
+			},
+			alarmSQL: "SELECT * FROM " + userPrefix + "stream WHERE v > 30",
+		},
+		{
+			desc:     "import ruleset overwriting existing entities",
+			token:    validToken,
+			rs:       rs,
+			conflict: re.ConflictOverwrite,
+			entities: []re.ImportedEntity{
+				{Kind: re.StreamKind, Name: "stream", Status: re.ImportOverwritten},
+				{Kind: re.RuleKind, Name: "rule", Status: re.ImportOverwritten},
+				{Kind: re.RuleKind, Name: "alarm", Status: re.ImportCreated},
+			},
+			alarmSQL: "SELECT * FROM " + userPrefix + "stream WHERE v > 30",
+		},
+		{
+			desc:     "import ruleset renaming existing entities",
+			token:    validToken,
+			rs:       rs,
+			conflict: re.ConflictRename,
+			entities: []re.ImportedEntity{
+				{Kind: re.StreamKind, Name: "stream", Renamed: "stream_1", Status: re.ImportRenamed},
+				{Kind: re.RuleKind, Name: "rule", Renamed: "rule_1", Status: re.ImportRenamed},
+				{Kind: re.RuleKind, Name: "alarm", Status: re.ImportCreated},
+			},
+			alarmSQL: "SELECT * FROM " + userPrefix + "stream_1 WHERE v > 30",
+		},
+		{
+			desc:  "import malformed ruleset",
+			token: validToken,
+			rs:    malformed,
+			entities: []re.ImportedEntity{
+				{Kind: re.StreamKind, Name: "1stream", Status: re.ImportFailed},
+				{Kind: re.RuleKind, Name: "alarm", Status: re.ImportFailed},
+			},
+		},
+		{
+			desc:     "import ruleset with unknown conflict strategy",
+			token:    validToken,
+			rs:       rs,
+			conflict: "merge",
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "import ruleset with invalid token",
+			token: invalidToken,
+			rs:    rs,
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, k, auth, _ := newService(t)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+		channelCall := authorizeChannel(auth, validToken, channelID, true)
+
+		report, err := svc.ImportRuleset(context.Background(), tc.token, tc.rs, tc.conflict)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		var entities []re.ImportedEntity
+		for _, e := range report.Entities {
+			if e.Status == re.ImportFailed {
+				assert.NotEmpty(t, e.Error, fmt.Sprintf("%s: expected error of failed %s %s\n", tc.desc, e.Kind, e.Name))
+				e.Error = ""
+			}
+			entities = append(entities, e)
+		}
+		assert.Equal(t, tc.entities, entities, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.entities, entities))
+		if tc.alarmSQL != "" {
+			sql := k.rules[userPrefix+"alarm"].SQL
+			assert.Equal(t, tc.alarmSQL, sql, fmt.Sprintf("%s: expected alarm SQL %s got %s\n", tc.desc, tc.alarmSQL, sql))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		channelCall.Unset()
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// maxScheduleDuration limits how long the rule runs after each activation of
+// its cron schedule.
+const maxScheduleDuration = 7 * 24 * time.Hour
+
+var (
+	errScheduleKind     = errors.New("schedule has either the cron expression or the daily windows")
+	errCronExpression   = errors.New("cron expression has the minute, hour, day of month, month and day of week fields")
+	errScheduleDuration = errors.New("cron schedule duration must be between 1m and 168h")
+	errWindowTime       = errors.New("window start and end must be different times in the 15:04 format")
+	errWindowDay        = errors.New("window days must be mon, tue, wed, thu, fri, sat or sun")
+	errTimezone         = errors.New("unknown schedule timezone")
+
+	weekdays = map[string]time.Weekday{
+		"sun": time.Sunday,
+		"mon": time.Monday,
+		"tue": time.Tuesday,
+		"wed": time.Wednesday,
+		"thu": time.Thursday,
+		"fri": time.Friday,
+		"sat": time.Saturday,
+	}
+
+	// cronFields are the lowest and the highest values of the cron fields.
+	cronFields = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+)
+
+// Schedule activates the rule only at the given times, so the scheduler
+// runs the rule while the schedule is active and stops it otherwise. The
+// rule is active either for Duration, e.g. "8h", after each time matching
+// the Cron expression, e.g. "0 9 * * 1-5", or within any of the daily
+// Windows. Times are in the Timezone, e.g. "Europe/Belgrade", UTC by
+// default.
+type Schedule struct {
+	Cron     string   `json:"cron,omitempty"`
+	Duration string   `json:"duration,omitempty"`
+	Windows  []Window `json:"windows,omitempty"`
+	Timezone string   `json:"timezone,omitempty"`
+}
+
+// Window is the daily activation window from Start to End, e.g. "09:00"
+// and "17:00", on the given Days, e.g. "mon", or every day if there are no
+// Days. Windows ending before they start end on the following day.
+type Window struct {
+	Days  []string `json:"days,omitempty"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+}
+
+// Scheduler runs the scheduled rules of all the users only while their
+// schedules are active, so it's used by the background scheduling and never
+// exposed over the API.
+type Scheduler interface {
+	// Schedule starts the scheduled rules whose schedules became active and
+	// stops the rules whose schedules became inactive, and returns them.
+	Schedule(ctx context.Context) ([]StateChange, error)
+}
+
+type scheduler struct {
+	svc *reService
+}
+
+// NewScheduler instantiates the scheduler running the rules in the given
+// engine.
+func NewScheduler(engine RuleEngine, cfg Config, repo Repository) Scheduler {
+	return &scheduler{svc: newService(engine, cfg, nil, nil, Notifiers{}, nil, repo)}
+}
+
+func (s *scheduler) Schedule(ctx context.Context) ([]StateChange, error) {
+	return s.svc.applyStates(ctx, func(md Metadata) bool {
+		return md.Schedule != nil
+	})
+}
+
+func (sch *Schedule) validate() error {
+	if sch == nil {
+		return nil
+	}
+	if (sch.Cron == "") == (len(sch.Windows) == 0) {
+		return errScheduleKind
+	}
+	if _, err := time.LoadLocation(sch.Timezone); err != nil {
+		return errors.Wrap(errTimezone, err)
+	}
+	if sch.Cron != "" {
+		if _, err := parseCron(sch.Cron); err != nil {
+			return err
+		}
+		d, err := time.ParseDuration(sch.Duration)
+		if err != nil || d < time.Minute || d > maxScheduleDuration {
+			return errScheduleDuration
+		}
+		return nil
+	}
+	for _, w := range sch.Windows {
+		start, err := clock(w.Start)
+		if err != nil {
+			return err
+		}
+		end, err := clock(w.End)
+		if err != nil {
+			return err
+		}
+		if start == end {
+			return errWindowTime
+		}
+		for _, d := range w.Days {
+			if _, ok := weekdays[strings.ToLower(d)]; !ok {
+				return errWindowDay
+			}
+		}
+	}
+
+	return nil
+}
+
+// active reports whether the rule with the schedule runs at the given time.
+// Rules without the schedule always run.
+func (sch *Schedule) active(now time.Time) bool {
+	if sch == nil {
+		return true
+	}
+	loc, err := time.LoadLocation(sch.Timezone)
+	if err != nil {
+		return false
+	}
+	now = now.In(loc)
+	if sch.Cron != "" {
+		return sch.cronActive(now)
+	}
+	for _, w := range sch.Windows {
+		if w.active(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cronActive reports whether the cron expression matched any minute within
+// the schedule duration before the given time.
+func (sch *Schedule) cronActive(now time.Time) bool {
+	c, err := parseCron(sch.Cron)
+	if err != nil {
+		return false
+	}
+	d, err := time.ParseDuration(sch.Duration)
+	if err != nil {
+		return false
+	}
+	since := now.Add(-d)
+	for t := now.Truncate(time.Minute); t.After(since); t = t.Add(-time.Minute) {
+		if c.matches(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w Window) active(now time.Time) bool {
+	start, err := clock(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := clock(w.End)
+	if err != nil {
+		return false
+	}
+	minute := now.Hour()*60 + now.Minute()
+	if start < end {
+		return w.on(now.Weekday()) && minute >= start && minute < end
+	}
+	// The window ending on the following day started either today or the
+	// day before.
+	yesterday := now.AddDate(0, 0, -1).Weekday()
+
+	return (w.on(now.Weekday()) && minute >= start) || (w.on(yesterday) && minute < end)
+}
+
+// on reports whether the window starts on the given day of the week.
+func (w Window) on(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if weekdays[strings.ToLower(d)] == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clock returns the minutes since midnight of the time in the 15:04 format.
+func clock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errWindowTime
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// cron is the parsed cron expression, holding the values matching each of
+// its fields.
+type cron struct {
+	fields [5]map[int]bool
+	// anyDay reports whether either of the day of month and the day of week
+	// fields is "*", in which case the days must match both fields, rather
+	// than either of them.
+	anyDay bool
+}
+
+// parseCron parses the standard cron expression with the minute, hour, day
+// of month, month and day of week fields. Fields are lists of values,
+// ranges and steps, e.g. "*/15", "1-5" or "0,30". Sunday is either 0 or 7.
+func parseCron(expr string) (cron, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return cron{}, errCronExpression
+	}
+	var c cron
+	for i, part := range parts {
+		values, err := cronField(part, cronFields[i][0], cronFields[i][1])
+		if err != nil {
+			return cron{}, err
+		}
+		c.fields[i] = values
+	}
+	if c.fields[4][7] {
+		c.fields[4][0] = true
+	}
+	c.anyDay = parts[2] == "*" || parts[4] == "*"
+
+	return c, nil
+}
+
+func cronField(field string, low, high int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, item := range strings.Split(field, ",") {
+		rng, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s < 1 {
+				return nil, errCronExpression
+			}
+			rng, step = item[:i], s
+		}
+		from, to := low, high
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+			f, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, errCronExpression
+			}
+			t, err := strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, errCronExpression
+			}
+			from, to = f, t
+		default:
+			v, err := strconv.Atoi(rng)
+			if err != nil {
+				return nil, errCronExpression
+			}
+			from, to = v, v
+			if step > 1 {
+				to = high
+			}
+		}
+		if from < low || to > high || from > to {
+			return nil, errCronExpression
+		}
+		for v := from; v <= to; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+// matches reports whether the minute of the given time matches the cron
+// expression.
+func (c cron) matches(t time.Time) bool {
+	if !c.fields[0][t.Minute()] || !c.fields[1][t.Hour()] || !c.fields[3][int(t.Month())] {
+		return false
+	}
+	dom, dow := c.fields[2][t.Day()], c.fields[4][int(t.Weekday())]
+	if c.anyDay {
+		return dom && dow
+	}
+
+	return dom || dow
+}
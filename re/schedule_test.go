@@ -0,0 +1,160 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateScheduledRule(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	businessHours := []re.Window{{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "17:00"}}
+	cases := []struct {
+		desc     string
+		id       string
+		schedule *re.Schedule
+		err      error
+	}{
+		{
+			desc:     "create rule with daily windows",
+			id:       "business",
+			schedule: &re.Schedule{Windows: businessHours, Timezone: "Europe/Belgrade"},
+		},
+		{
+			desc:     "create rule with overnight window",
+			id:       "night",
+			schedule: &re.Schedule{Windows: []re.Window{{Start: "22:00", End: "06:00"}}},
+		},
+		{
+			desc:     "create rule with cron schedule",
+			id:       "morning",
+			schedule: &re.Schedule{Cron: "0 9 * * 1-5", Duration: "2h"},
+		},
+		{
+			desc:     "create rule with both cron and windows",
+			id:       "both",
+			schedule: &re.Schedule{Cron: "0 9 * * *", Duration: "1h", Windows: businessHours},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create rule with empty schedule",
+			id:       "empty",
+			schedule: &re.Schedule{},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create rule with invalid cron expression",
+			id:       "cron",
+			schedule: &re.Schedule{Cron: "0 25 * * *", Duration: "1h"},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create rule with cron schedule without duration",
+			id:       "duration",
+			schedule: &re.Schedule{Cron: "0 9 * * *"},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create rule with invalid window time",
+			id:       "window",
+			schedule: &re.Schedule{Windows: []re.Window{{Start: "9am", End: "17:00"}}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create rule with invalid window day",
+			id:       "day",
+			schedule: &re.Schedule{Windows: []re.Window{{Days: []string{"monday"}, Start: "09:00", End: "17:00"}}},
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create rule with unknown timezone",
+			id:       "timezone",
+			schedule: &re.Schedule{Windows: businessHours, Timezone: "Mars/Olympus"},
+			err:      svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		rule := re.Rule{
+			ID:       tc.id,
+			SQL:      "SELECT * FROM stream",
+			Actions:  []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+			Schedule: tc.schedule,
+		}
+		_, err := svc.CreateRule(context.Background(), validToken, rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		rule, err = svc.ViewRule(context.Background(), validToken, tc.id)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.schedule, rule.Schedule, fmt.Sprintf("%s: expected schedule %v got %v\n", tc.desc, tc.schedule, rule.Schedule))
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	k, url := newKuiper(t)
+	repo := mocks.NewRepository()
+	now := time.Now().UTC()
+	// The windows are relative to the current time, so the test doesn't
+	// depend on the time it runs at.
+	active := re.Window{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")}
+	inactive := re.Window{Start: now.Add(time.Hour).Format("15:04"), End: now.Add(2 * time.Hour).Format("15:04")}
+	later := now.Add(3 * time.Hour)
+	for _, name := range []string{"closed", "open", "cron", "idle", "paused", "rule"} {
+		k.rules[userPrefix+name] = re.Rule{ID: userPrefix + name}
+	}
+	k.stopped[userPrefix+"open"] = true
+	k.stopped[userPrefix+"paused"] = true
+	mds := map[string]re.Metadata{
+		"closed": {Owner: userID, Schedule: &re.Schedule{Windows: []re.Window{inactive}}},
+		"open":   {Owner: userID, Schedule: &re.Schedule{Windows: []re.Window{active}}},
+		"cron":   {Owner: userID, Schedule: &re.Schedule{Cron: "* * * * *", Duration: "1m"}},
+		"idle":   {Owner: userID, Schedule: &re.Schedule{Cron: fmt.Sprintf("%d %d * * *", later.Minute(), later.Hour()), Duration: "1h"}},
+		"paused": {Owner: userID, Stopped: true, Schedule: &re.Schedule{Windows: []re.Window{active}}},
+		"rule":   {Owner: userID},
+	}
+	for name, md := range mds {
+		err := repo.Save(context.Background(), re.RuleKind, userPrefix+name, md)
+		assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	}
+
+	cfg := re.Config{URL: url}
+	s := re.NewScheduler(re.NewKuiper(cfg), cfg, repo)
+	changes, err := s.Schedule(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("schedule: expected no error got %s\n", err))
+	expected := []re.StateChange{
+		{Name: userPrefix + "closed", Owner: userID, State: re.RuleStopped},
+		{Name: userPrefix + "idle", Owner: userID, State: re.RuleStopped},
+		{Name: userPrefix + "open", Owner: userID, State: re.RuleRunning},
+	}
+	assert.Equal(t, expected, changes, fmt.Sprintf("schedule: expected %v got %v\n", expected, changes))
+	k.mu.Lock()
+	assert.True(t, k.stopped[userPrefix+"closed"], "expected rule outside of its window to be stopped")
+	assert.False(t, k.stopped[userPrefix+"open"], "expected rule within its window to be started")
+	assert.False(t, k.stopped[userPrefix+"cron"], "expected rule within its cron schedule to keep running")
+	assert.True(t, k.stopped[userPrefix+"paused"], "expected rule stopped by its owner to stay stopped")
+	assert.False(t, k.stopped[userPrefix+"rule"], "expected rule without schedule to keep running")
+	k.mu.Unlock()
+
+	changes, err = s.Schedule(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("schedule again: expected no error got %s\n", err))
+	assert.Empty(t, changes, fmt.Sprintf("schedule again: expected no changes got %v\n", changes))
+}
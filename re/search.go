@@ -0,0 +1,153 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var errMissingQuery = errors.New("missing stream or channel")
+
+// RuleQuery selects the rules by the entities they reference. Stream selects
+// the rules reading from the stream with the given name and Channel the rules
+// publishing to the channel with the given ID, including the notification
+// actions. If both are set, the rules must reference both.
+type RuleQuery struct {
+	Stream  string `json:"stream,omitempty"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// match reports whether the rule references the entities of the query.
+func (q RuleQuery) match(rule Rule) bool {
+	if q.Channel != "" && !publishesTo(rule.Actions, q.Channel) {
+		return false
+	}
+	if q.Stream == "" {
+		return true
+	}
+	reads := false
+	_, _ = rewriteStreams(rule.SQL, func(name string) string {
+		reads = reads || name == q.Stream
+		return name
+	})
+
+	return reads
+}
+
+// publishesTo reports whether any of the actions publishes to the channel.
+func publishesTo(actions []Action, channel string) bool {
+	for _, a := range actions {
+		if a.Mainflux != nil && a.Mainflux.Channel == channel {
+			return true
+		}
+		if _, n := a.notification(); n != nil && n.Channel == channel {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (svc *reService) SearchRules(ctx context.Context, token string, q RuleQuery, pm PageMetadata) (RulesPage, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return RulesPage{}, err
+	}
+	if q.Stream == "" && q.Channel == "" {
+		return RulesPage{}, errors.Wrap(svcerr.ErrMalformedEntity, errMissingQuery)
+	}
+	owner, err := svc.listOwner(ctx, token, userID, pm)
+	if err != nil {
+		return RulesPage{}, err
+	}
+
+	all, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return RulesPage{}, err
+	}
+	mds, err := svc.repo.RetrieveAll(ctx, RuleKind, owner)
+	if err != nil {
+		return RulesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	pfx := prefix(owner)
+	rules := []RuleInfo{}
+	for _, r := range all {
+		if !strings.HasPrefix(r.ID, pfx) || !pm.match(strings.TrimPrefix(r.ID, pfx), mds[r.ID]) {
+			continue
+		}
+		// The rules are read from Kuiper, since the rules created before
+		// their definitions were stored have no definition.
+		kr, err := svc.engine.ViewRule(ctx, r.ID)
+		switch {
+		case errors.Contains(err, svcerr.ErrNotFound):
+			continue
+		case err != nil:
+			return RulesPage{}, err
+		}
+		rule, err := svc.kuiperRule(kr, pfx)
+		if err != nil {
+			return RulesPage{}, errors.Wrap(errReadResponse, err)
+		}
+		if q.match(rule) {
+			r.ID = rule.ID
+			rules = append(rules, r)
+		}
+	}
+	for kuiperID, md := range mds {
+		id := strings.TrimPrefix(kuiperID, pfx)
+		if !md.Draft || !pm.match(id, md) {
+			continue
+		}
+		var rule Rule
+		if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+			return RulesPage{}, errors.Wrap(errReadResponse, err)
+		}
+		if q.match(rule) {
+			rules = append(rules, RuleInfo{ID: id, Status: RuleDraft})
+		}
+	}
+
+	for i, r := range rules {
+		if md, ok := mds[pfx+r.ID]; ok {
+			rules[i].Metadata = &md
+		}
+	}
+
+	return pageRules(rules, pm), nil
+}
+
+// definedRules returns the sorted IDs of the rules of the owner, including the
+// drafts, whose stored definitions match the query. Rules created before
+// their definitions were stored aren't returned.
+func (svc *reService) definedRules(ctx context.Context, owner string, q RuleQuery) ([]string, error) {
+	mds, err := svc.repo.RetrieveAll(ctx, RuleKind, owner)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	pfx := prefix(owner)
+	var ids []string
+	for kuiperID, md := range mds {
+		if md.Definition == "" {
+			continue
+		}
+		var rule Rule
+		if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+			return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		if q.match(rule) {
+			ids = append(ids, strings.TrimPrefix(kuiperID, pfx))
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchRules(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+
+	const alarmsChannelID = "5f1c5b67-0b4c-4b8e-9f5e-3c3f2d8e4a11"
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+	alarmsCall := authorizeChannel(auth, validToken, alarmsChannelID, true)
+	defer alarmsCall.Unset()
+
+	_, err := svc.CreateStream(context.Background(), validToken, re.StreamDef{Name: "temperature", Topic: channelID, SenML: true}, false)
+	assert.Nil(t, err, fmt.Sprintf("create stream: expected no error got %s\n", err))
+	_, err = svc.CreateRule(context.Background(), validToken, re.Rule{
+		ID:      "alarm",
+		SQL:     "SELECT * FROM temperature WHERE v > 30",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+	})
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	_, err = svc.SaveDraft(context.Background(), validToken, re.Rule{
+		ID:      "overheat",
+		SQL:     "SELECT * FROM temperature WHERE v > 40",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: alarmsChannelID}}},
+	})
+	assert.Nil(t, err, fmt.Sprintf("save draft: expected no error got %s\n", err))
+
+	cases := []struct {
+		desc  string
+		query re.RuleQuery
+		rules []string
+		err   error
+	}{
+		{
+			desc:  "search by stream",
+			query: re.RuleQuery{Stream: "temperature"},
+			rules: []string{"alarm", "overheat"},
+		},
+		{
+			desc:  "search by channel",
+			query: re.RuleQuery{Channel: channelID},
+			rules: []string{"alarm", "rule"},
+		},
+		{
+			desc:  "search by stream and channel",
+			query: re.RuleQuery{Stream: "temperature", Channel: channelID},
+			rules: []string{"alarm"},
+		},
+		{
+			desc:  "search by channel of draft",
+			query: re.RuleQuery{Channel: alarmsChannelID},
+			rules: []string{"overheat"},
+		},
+		{
+			desc:  "search by unused stream",
+			query: re.RuleQuery{Stream: "humidity"},
+			rules: []string{},
+		},
+		{
+			desc: "search without stream and channel",
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.SearchRules(context.Background(), validToken, tc.query, re.PageMetadata{Limit: 10})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		rules := []string{}
+		for _, r := range page.Rules {
+			rules = append(rules, r.ID)
+		}
+		assert.Equal(t, tc.rules, rules, fmt.Sprintf("%s: expected rules %v got %v\n", tc.desc, tc.rules, rules))
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// maxSecretSize limits the size of the secret value.
+const maxSecretSize = 64 * 1024
+
+var (
+	errSecretsDisabled = errors.New("secrets key is not configured")
+	errSecretsKey      = errors.New("secrets key must be base64 encoded 32 bytes")
+	errSecretValue     = errors.New("secret value must not be empty or longer than 64KiB")
+	errSecretMissing   = errors.New("secret doesn't exist")
+	errSecretDecrypt   = errors.New("failed to decrypt secret")
+
+	// secretRegexp matches the references to the user's secrets in the
+	// action fields, e.g. {{secret "token"}}.
+	secretRegexp = regexp.MustCompile(`\{\{\s*secret\s+"([^"]*)"\s*\}\}`)
+)
+
+// SecretsConfig defines the encryption of the users' secrets. Key is the
+// base64 encoded 32 bytes AES-256 key the secrets are encrypted with before
+// they're stored. Secrets are disabled without the key.
+type SecretsConfig struct {
+	Key string `env:"KEY" envDefault:""`
+}
+
+// Validate checks that the secrets can be encrypted with the key.
+func (c SecretsConfig) Validate() error {
+	if c.Key == "" {
+		return nil
+	}
+	_, err := c.aead()
+
+	return err
+}
+
+func (c SecretsConfig) aead() (cipher.AEAD, error) {
+	if c.Key == "" {
+		return nil, errSecretsDisabled
+	}
+
+	return newAEAD(c.Key, errSecretsKey)
+}
+
+// Secret is the credential the user's rule actions refer to by the Name,
+// e.g. with {{secret "token"}} in the REST sink headers or the MQTT sink
+// password, so the credentials aren't stored in the rule definitions. The
+// references are resolved when the rule is sent to Kuiper. The Value is
+// only set by the user and is stored encrypted as the Ciphertext, so it's
+// never returned.
+type Secret struct {
+	Name       string    `json:"name"`
+	Value      string    `json:"value,omitempty"`
+	Ciphertext []byte    `json:"-"`
+	Owner      string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// SecretRepository specifies the persistence API of the users' secrets.
+type SecretRepository interface {
+	// SaveSecret creates or replaces the secret. Secrets are identified by
+	// the owner and the name, and the replaced secret keeps its creation
+	// time.
+	SaveSecret(ctx context.Context, s Secret) error
+
+	// RetrieveSecrets returns the secrets of the owner.
+	RetrieveSecrets(ctx context.Context, owner string) ([]Secret, error)
+
+	// RemoveSecret removes the owner's secret with the given name.
+	RemoveSecret(ctx context.Context, owner, name string) error
+}
+
+func (svc *reService) SaveSecret(ctx context.Context, token string, s Secret) (Secret, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Secret{}, err
+	}
+	if err := validateName(s.Name); err != nil {
+		return Secret{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if s.Value == "" || len(s.Value) > maxSecretSize {
+		return Secret{}, errors.Wrap(svcerr.ErrMalformedEntity, errSecretValue)
+	}
+	aead, err := svc.secrets.aead()
+	if err != nil {
+		return Secret{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return Secret{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	// The owner and the name authenticate the ciphertext, so it can't be
+	// moved to another secret.
+	s.Ciphertext = aead.Seal(nonce, nonce, []byte(s.Value), []byte(userID+"/"+s.Name))
+	s.Owner = userID
+	s.CreatedAt = time.Now().UTC()
+	s.UpdatedAt = s.CreatedAt
+	if err := svc.repo.SaveSecret(ctx, s); err != nil {
+		return Secret{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	s.Value, s.Ciphertext = "", nil
+
+	return s, nil
+}
+
+func (svc *reService) ListSecrets(ctx context.Context, token string) ([]Secret, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := svc.repo.RetrieveSecrets(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for i := range secrets {
+		secrets[i].Value, secrets[i].Ciphertext = "", nil
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	return secrets, nil
+}
+
+func (svc *reService) RemoveSecret(ctx context.Context, token, name string) error {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return err
+	}
+	switch err := svc.repo.RemoveSecret(ctx, userID, name); {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return errors.Wrap(svcerr.ErrNotFound, errSecretMissing)
+	case err != nil:
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// secretValues returns the decrypted values of the owner's secrets by
+// their names. Without the secrets key, the owner has no secrets.
+func (svc *reService) secretValues(ctx context.Context, owner string) (map[string]string, error) {
+	if svc.secrets.Key == "" {
+		return map[string]string{}, nil
+	}
+	secrets, err := svc.repo.RetrieveSecrets(ctx, owner)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if len(secrets) == 0 {
+		return map[string]string{}, nil
+	}
+	aead, err := svc.secrets.aead()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(secrets))
+	for _, s := range secrets {
+		if len(s.Ciphertext) < aead.NonceSize() {
+			return nil, errors.Wrap(errSecretDecrypt, errors.New(s.Name))
+		}
+		nonce, ciphertext := s.Ciphertext[:aead.NonceSize()], s.Ciphertext[aead.NonceSize():]
+		value, err := aead.Open(nil, nonce, ciphertext, []byte(owner+"/"+s.Name))
+		if err != nil {
+			return nil, errors.Wrap(errSecretDecrypt, errors.New(s.Name))
+		}
+		values[s.Name] = string(value)
+	}
+
+	return values, nil
+}
+
+// resolveSecrets returns the actions with the references to the owner's
+// secrets replaced with the secret values. Referring to the missing secret
+// fails.
+func (svc *reService) resolveSecrets(ctx context.Context, owner string, actions []Action) ([]Action, error) {
+	if !refersSecrets(actions) {
+		return actions, nil
+	}
+	if svc.secrets.Key == "" {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, errSecretsDisabled)
+	}
+	values, err := svc.secretValues(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapActionStrings(actions, func(s string) (string, error) {
+		var missing []string
+		s = secretRegexp.ReplaceAllStringFunc(s, func(ref string) string {
+			name := secretRegexp.FindStringSubmatch(ref)[1]
+			value, ok := values[name]
+			if !ok {
+				missing = append(missing, name)
+			}
+			return value
+		})
+		if len(missing) > 0 {
+			return "", errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(errSecretMissing, errors.New(strings.Join(missing, ", "))))
+		}
+		return s, nil
+	})
+}
+
+// redactSecrets returns the actions of the rule with the given metadata,
+// read from the engine, with the actions resolving the owner's secrets
+// replaced with the actions of the stored rule definition, which refer to
+// the secrets instead, so the secret values are never returned.
+func (svc *reService) redactSecrets(md *Metadata, actions []Action) ([]Action, error) {
+	if md.definition() == "" {
+		return actions, nil
+	}
+	var stored Rule
+	if err := json.Unmarshal([]byte(md.Definition), &stored); err != nil {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if !refersSecrets(stored.Actions) {
+		return actions, nil
+	}
+
+	return svc.openActions(stored.Actions)
+}
+
+// refersSecrets reports whether any of the actions refers to the secrets.
+func refersSecrets(actions []Action) bool {
+	refers := false
+	_, _ = mapActionStrings(actions, func(s string) (string, error) {
+		refers = refers || secretRegexp.MatchString(s)
+		return s, nil
+	})
+
+	return refers
+}
+
+// mapActionStrings returns the copies of the actions with all the string
+// fields mapped with the function.
+func mapActionStrings(actions []Action, f func(string) (string, error)) ([]Action, error) {
+	res := make([]Action, len(actions))
+	for i, a := range actions {
+		data, err := json.Marshal(a)
+		if err != nil {
+			return nil, err
+		}
+		// The numbers are kept as they are rather than converted to floats.
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		if v, err = mapStrings(v, f); err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &res[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func mapStrings(v interface{}, f func(string) (string, error)) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		return f(v)
+	case map[string]interface{}:
+		for k, e := range v {
+			m, err := mapStrings(e, f)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = m
+		}
+		return v, nil
+	case []interface{}:
+		for i, e := range v {
+			m, err := mapStrings(e, f)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = m
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
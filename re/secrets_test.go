@@ -0,0 +1,164 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var secretsConfig = re.SecretsConfig{Key: base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))}
+
+func TestSaveSecret(t *testing.T) {
+	svc, _, auth, _ := newServiceWithConfig(t, re.Config{Secrets: secretsConfig}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	cases := []struct {
+		desc   string
+		token  string
+		secret re.Secret
+		err    error
+	}{
+		{
+			desc:   "save secret",
+			token:  validToken,
+			secret: re.Secret{Name: "password", Value: "s3cr3t"},
+		},
+		{
+			desc:   "replace secret",
+			token:  validToken,
+			secret: re.Secret{Name: "password", Value: "n3w"},
+		},
+		{
+			desc:   "save secret with invalid name",
+			token:  validToken,
+			secret: re.Secret{Name: "pass word", Value: "s3cr3t"},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "save secret without value",
+			token:  validToken,
+			secret: re.Secret{Name: "password"},
+			err:    svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:   "save secret with invalid token",
+			token:  invalidToken,
+			secret: re.Secret{Name: "password", Value: "s3cr3t"},
+			err:    svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		s, err := svc.SaveSecret(context.Background(), tc.token, tc.secret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Empty(t, s.Value, fmt.Sprintf("%s: expected no value got %s\n", tc.desc, s.Value))
+		}
+	}
+
+	secrets, err := svc.ListSecrets(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list secrets: expected no error got %s\n", err))
+	assert.Len(t, secrets, 1, fmt.Sprintf("list secrets: expected single secret got %v\n", secrets))
+
+	err = svc.RemoveSecret(context.Background(), validToken, "password")
+	assert.Nil(t, err, fmt.Sprintf("remove secret: expected no error got %s\n", err))
+	err = svc.RemoveSecret(context.Background(), validToken, "password")
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("remove removed secret: expected %s got %s\n", svcerr.ErrNotFound, err))
+}
+
+func TestSaveSecretDisabled(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	_, err := svc.SaveSecret(context.Background(), validToken, re.Secret{Name: "password", Value: "s3cr3t"})
+	assert.True(t, errors.Contains(err, svcerr.ErrCreateEntity), fmt.Sprintf("save secret without key: expected %s got %s\n", svcerr.ErrCreateEntity, err))
+
+	rule := re.Rule{
+		ID:      "forward",
+		SQL:     "SELECT * FROM stream",
+		Actions: []re.Action{{MQTT: &re.MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms", Password: `{{secret "password"}}`}}},
+	}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("create rule referring to secret without key: expected %s got %s\n", svcerr.ErrMalformedEntity, err))
+}
+
+func TestRuleSecrets(t *testing.T) {
+	svc, k, auth, _ := newServiceWithConfig(t, re.Config{Secrets: secretsConfig}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	_, err := svc.SaveSecret(context.Background(), validToken, re.Secret{Name: "password", Value: "s3cr3t"})
+	assert.Nil(t, err, fmt.Sprintf("save secret: expected no error got %s\n", err))
+
+	ref := `{{secret "password"}}`
+	cases := []struct {
+		desc string
+		rule re.Rule
+		err  error
+	}{
+		{
+			desc: "create rule referring to secret",
+			rule: re.Rule{
+				ID:      "forward",
+				SQL:     "SELECT * FROM stream",
+				Actions: []re.Action{{MQTT: &re.MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms", Username: "re", Password: ref}}},
+			},
+		},
+		{
+			desc: "create rule referring to secret in headers",
+			rule: re.Rule{
+				ID:      "hook",
+				SQL:     "SELECT * FROM stream",
+				Actions: []re.Action{{REST: &re.RESTSink{URL: "https://example.com/hook", Headers: map[string]string{"Authorization": "Bearer " + ref}}}},
+			},
+		},
+		{
+			desc: "create rule with secret value in plain text",
+			rule: re.Rule{
+				ID:      "plain",
+				SQL:     "SELECT * FROM stream",
+				Actions: []re.Action{{REST: &re.RESTSink{URL: "https://example.com/s3cr3t"}}},
+			},
+		},
+		{
+			desc: "create rule referring to missing secret",
+			rule: re.Rule{
+				ID:      "missing",
+				SQL:     "SELECT * FROM stream",
+				Actions: []re.Action{{MQTT: &re.MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms", Password: `{{secret "token"}}`}}},
+			},
+			err: svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.CreateRule(context.Background(), validToken, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		raw := string(k.raw[userPrefix+tc.rule.ID])
+		assert.Contains(t, raw, "s3cr3t", fmt.Sprintf("%s: expected secret resolved in Kuiper got %s\n", tc.desc, raw))
+		assert.NotContains(t, raw, "secret", fmt.Sprintf("%s: expected no reference in Kuiper got %s\n", tc.desc, raw))
+
+		rule, err := svc.ViewRule(context.Background(), validToken, tc.rule.ID)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.rule.Actions, rule.Actions, fmt.Sprintf("%s: expected redacted actions %v got %v\n", tc.desc, tc.rule.Actions, rule.Actions))
+	}
+}
@@ -0,0 +1,1678 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/pkg/messaging"
+	mgsdk "github.com/absmach/magistrala/pkg/sdk/go"
+)
+
+const contentType = "application/json"
+
+var (
+	// ErrKuiperServer indicates failure to communicate with the Kuiper server.
+	ErrKuiperServer = errors.New("failed to communicate with Kuiper server")
+
+	// ErrKuiperUnavailable indicates that the request was rejected without
+	// contacting Kuiper because the circuit breaker is open.
+	ErrKuiperUnavailable = errors.New("Kuiper server is temporarily unavailable")
+
+	// ErrNotSupported indicates that the detected Kuiper version doesn't
+	// support the operation.
+	ErrNotSupported = errors.New("operation not supported by the Kuiper version")
+
+	// ErrConflict indicates that Kuiper rejected the entity because an
+	// entity with the same name already exists.
+	ErrConflict = errors.New("entity already exists in Kuiper")
+
+	// ErrInvalidSQL indicates that Kuiper failed to parse the rule or
+	// stream SQL.
+	ErrInvalidSQL = errors.New("invalid SQL statement")
+
+	// ErrRuleNotFound indicates that the rule doesn't exist in Kuiper.
+	ErrRuleNotFound = errors.New("rule not found")
+
+	// ErrStreamInUse indicates that Kuiper refused to drop the stream
+	// because rules read from it.
+	ErrStreamInUse = errors.New("stream is used by rules")
+
+	// ErrQuotaExceeded indicates that the user reached the limit of the
+	// streams or rules the user can create.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
+	// ErrRateLimited indicates that the user runs the operations that
+	// change streams, tables and rules too often.
+	ErrRateLimited = errors.New("rate limit exceeded")
+
+	errReadResponse = errors.New("failed to read Kuiper response")
+	errKuiperTLS    = errors.New("failed to load Kuiper TLS configuration")
+)
+
+var _ Service = (*reService)(nil)
+
+// Result represents the outcome of the operation successfully performed by
+// Kuiper. Name is the name or ID of the entity without the owner prefix,
+// Status is the HTTP status code returned by Kuiper and Message is the raw
+// Kuiper response message. Owner is the ID of the entity owner, used by the
+// service middlewares (e.g. the event store) and never returned by the API.
+// Operations Kuiper fails to perform are returned as errors instead.
+type Result struct {
+	Name    string `json:"name"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Owner   string `json:"-"`
+}
+
+// Info contains information about the Kuiper instance and the state of the
+// circuit breaker guarding it.
+type Info struct {
+	Version       string `json:"version"`
+	OS            string `json:"os"`
+	UpTimeSeconds int    `json:"upTimeSeconds"`
+	Breaker       string `json:"breaker"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// Info returns information about the Kuiper instance. If the circuit
+	// breaker is open, only the breaker state is returned.
+	Info(ctx context.Context) (Info, error)
+
+	// CreateStream creates new stream with the given definition. If update
+	// is true, the existing stream with the same name is replaced.
+	CreateStream(ctx context.Context, token string, def StreamDef, update bool) (Result, error)
+
+	// ListStreams returns a page of names of the streams that belong to the
+	// user identified by the given token, sorted by name.
+	ListStreams(ctx context.Context, token string, pm PageMetadata) (StreamsPage, error)
+
+	// ViewStream returns the stream with the given name that belongs to the
+	// user identified by the given token.
+	ViewStream(ctx context.Context, token, name string) (Stream, error)
+
+	// ViewStreams returns the streams with the given names concurrently, so
+	// many streams are fetched in a single call. The names of the missing
+	// streams are returned along with the streams.
+	ViewStreams(ctx context.Context, token string, names []string) (StreamsBatch, error)
+
+	// DeleteStream removes the stream with the given name that belongs to
+	// the user identified by the given token. Streams the rules read from
+	// aren't removed, unless cascade is set, which removes the rules first.
+	// The streams of the chains are removed only along with the chains.
+	DeleteStream(ctx context.Context, token, name string, cascade bool) (Result, error)
+
+	// CreateTable creates new table with the given definition, which rules
+	// can join the streams with.
+	CreateTable(ctx context.Context, token string, def TableDef) (Result, error)
+
+	// ListTables returns a page of names of the tables that belong to the
+	// user identified by the given token.
+	ListTables(ctx context.Context, token string, pm PageMetadata) (TablesPage, error)
+
+	// ViewTable returns the table with the given name that belongs to the
+	// user identified by the given token.
+	ViewTable(ctx context.Context, token, name string) (Table, error)
+
+	// DeleteTable removes the table with the given name that belongs to
+	// the user identified by the given token.
+	DeleteTable(ctx context.Context, token, name string) (Result, error)
+
+	// CreateRule creates new rule.
+	CreateRule(ctx context.Context, token string, rule Rule) (Result, error)
+
+	// UpdateRule replaces the existing rule with the same ID.
+	UpdateRule(ctx context.Context, token string, rule Rule) (Result, error)
+
+	// PatchRule changes only the SQL, actions or options of the existing
+	// rule set in the patch. The patched rule is validated like by
+	// ValidateRule and, once updated, restarted if it should run and kept
+	// stopped otherwise. Patched drafts are saved as drafts.
+	PatchRule(ctx context.Context, token, id string, patch RulePatch) (Result, error)
+
+	// CloneRule creates the rule with the new ID and the SQL, actions and
+	// options of the rule with the given ID. If the channel is set, the
+	// Mainflux actions of the clone publish to that channel instead.
+	CloneRule(ctx context.Context, token, id, newID, channel string) (Result, error)
+
+	// SaveDraft validates the rule and stores it as the draft, which isn't
+	// deployed to Kuiper until it's published. Saving the existing draft
+	// replaces it.
+	SaveDraft(ctx context.Context, token string, rule Rule) (Result, error)
+
+	// PublishRule deploys the draft rule with the given ID to Kuiper.
+	PublishRule(ctx context.Context, token, id string) (Result, error)
+
+	// UnpublishRule removes the published rule with the given ID from
+	// Kuiper, keeping it as the draft.
+	UnpublishRule(ctx context.Context, token, id string) (Result, error)
+
+	// ValidateRule checks the rule like CreateRule does, without creating
+	// it, and returns the diagnostics of all the problems found.
+	ValidateRule(ctx context.Context, token string, rule Rule) (RuleValidation, error)
+
+	// TestRule feeds the sample messages through the rule without creating
+	// it and returns the results the rule produced, so the rule can be
+	// debugged before it goes live.
+	TestRule(ctx context.Context, token string, trial RuleTrial) (TrialResult, error)
+
+	// ReplayRule feeds the messages the channels of the rule streams
+	// received in the given period through a temporary instance of the rule
+	// and returns the results, so the rule can be backtested against the
+	// historical data. The rule itself isn't affected.
+	ReplayRule(ctx context.Context, token, id string, from, to time.Time) (ReplayResult, error)
+
+	// TailRule attaches the temporary sink to the rule and returns the
+	// channel of the results the rule produces from then on. The sink is
+	// removed and the channel closed once the context is done.
+	TailRule(ctx context.Context, token, id string) (<-chan map[string]interface{}, error)
+
+	// PushTail delivers the rule result Kuiper sent to the sink of the tail
+	// session with the given ID. The session ID authorizes the request, so
+	// no token is required.
+	PushTail(ctx context.Context, session string, result map[string]interface{}) error
+
+	// Ingest feeds the message pushed by the thing with the given key to
+	// the httppush stream with the given Kuiper name. The thing must be
+	// able to publish to the channel of the stream.
+	Ingest(ctx context.Context, key, stream string, payload []byte) error
+
+	// WatchRules returns the channel of the state changes of the rules that
+	// belong to the user identified by the given token, starting with their
+	// current states. The channel is closed once the context is done.
+	WatchRules(ctx context.Context, token string) (<-chan RuleStateChange, error)
+
+	// ViewRule returns the rule with the given ID that belongs to the user
+	// identified by the given token.
+	ViewRule(ctx context.Context, token, id string) (Rule, error)
+
+	// ViewRules returns the rules with the given IDs concurrently, so many
+	// rules are fetched in a single call. The IDs of the missing rules are
+	// returned along with the rules.
+	ViewRules(ctx context.Context, token string, ids []string) (RulesBatch, error)
+
+	// ListRules returns a page of IDs and statuses of the rules that belong
+	// to the user identified by the given token, sorted by ID.
+	ListRules(ctx context.Context, token string, pm PageMetadata) (RulesPage, error)
+
+	// SearchRules returns a page of IDs and statuses of the rules that read
+	// from the stream or publish to the channel of the query, so the rules
+	// depending on the stream or the channel are found before removing it.
+	SearchRules(ctx context.Context, token string, q RuleQuery, pm PageMetadata) (RulesPage, error)
+
+	// DeleteRule removes the rule with the given ID that belongs to the user
+	// identified by the given token. If the retention period is set, the
+	// rule is stopped and marked deleted instead, so it can be restored until
+	// the period expires. Deleting the deleted rule removes it permanently.
+	// Rules feeding the chains are deleted once the chains are removed.
+	DeleteRule(ctx context.Context, token, id string) (Result, error)
+
+	// RestoreRule restores the deleted rule with the given ID, which runs
+	// again unless it was stopped before it was deleted.
+	RestoreRule(ctx context.Context, token, id string) (Result, error)
+
+	// StartRule starts the rule with the given ID.
+	StartRule(ctx context.Context, token, id string) (Result, error)
+
+	// StopRule stops the rule with the given ID without removing it.
+	StopRule(ctx context.Context, token, id string) (Result, error)
+
+	// RestartRule restarts the rule with the given ID.
+	RestartRule(ctx context.Context, token, id string) (Result, error)
+
+	// RuleStatus returns runtime status and metrics of the rule with the
+	// given ID.
+	RuleStatus(ctx context.Context, token, id string) (RuleStatus, error)
+
+	// RuleTopology returns the graph of the operators the rule runs as, so
+	// the data flow through the rule can be rendered.
+	RuleTopology(ctx context.Context, token, id string) (RuleTopology, error)
+
+	// RuleLogs returns the latest Kuiper log lines of the rule with the
+	// given ID logged since the given time, so the users can find out why
+	// the rule fails, e.g. why its sinks fail to deliver the results.
+	RuleLogs(ctx context.Context, token, id string, since time.Time) ([]LogEntry, error)
+
+	// Reconcile returns the drifts between the stored metadata and Kuiper
+	// for the entities of all the users and optionally repairs them. Only
+	// the platform administrator can reconcile.
+	Reconcile(ctx context.Context, token string, repair bool) (DriftReport, error)
+
+	// CollectOrphans returns the streams no rule reads from and the rules
+	// reading from missing streams or publishing to removed channels of all
+	// the users, and removes them if the policy says so. Only the platform
+	// administrator can collect the orphans.
+	CollectOrphans(ctx context.Context, token string, policy OrphanPolicy) (OrphanReport, error)
+
+	// Restore replays the stored stream and rule definitions of the
+	// entities missing in Kuiper, e.g. after Kuiper lost its data. Dry run
+	// only reports the entities that would be restored. Only the platform
+	// administrator can restore.
+	Restore(ctx context.Context, token string, dryRun bool) (RestoreReport, error)
+
+	// ExportRuleset returns all the streams and rules of the user identified
+	// by the given token, named without the owner prefix.
+	ExportRuleset(ctx context.Context, token string) (Ruleset, error)
+
+	// ImportRuleset creates the streams and rules of the ruleset for the
+	// user identified by the given token. Conflicts with the existing
+	// entities are resolved using the conflict strategy, which defaults to
+	// skip. Rules reading from the renamed streams read from the new names.
+	ImportRuleset(ctx context.Context, token string, rs Ruleset, conflict string) (ImportReport, error)
+
+	// BulkCreate creates the streams and then the rules of the ruleset
+	// concurrently and reports the result of each of them. The streams and
+	// rules failing to be created don't stop the rest.
+	BulkCreate(ctx context.Context, token string, rs Ruleset) (BulkReport, error)
+
+	// BulkDelete removes the rules and then the streams concurrently and
+	// reports the result of each of them.
+	BulkDelete(ctx context.Context, token string, bd BulkDeletion) (BulkReport, error)
+
+	// ListAllStreams returns the streams of all the users grouped by owner.
+	// Only the platform administrator can list them.
+	ListAllStreams(ctx context.Context, token string) (AllStreams, error)
+
+	// ListAllRules returns the rules of all the users grouped by owner, along
+	// with the number of the rules in each state. Only the platform
+	// administrator can list them.
+	ListAllRules(ctx context.Context, token string) (AllRules, error)
+
+	// ViewQuota returns the quota of the user with the given ID along with
+	// the numbers of the streams and rules the user has. Users view their
+	// own quotas, while the platform administrator views any.
+	ViewQuota(ctx context.Context, token, userID string) (UserQuota, error)
+
+	// SetQuota replaces the default quota of the user with the given ID.
+	// Only the platform administrator can set quotas.
+	SetQuota(ctx context.Context, token, userID string, q Quota) (UserQuota, error)
+
+	// RemoveQuota removes the quota set for the user with the given ID, so
+	// the default quota applies. Only the platform administrator can remove
+	// quotas.
+	RemoveQuota(ctx context.Context, token, userID string) error
+
+	// ShareEntity shares the user's stream or rule with the given kind and
+	// name with another user or the members of a group. Users the entity is
+	// shared with refer to it as "<owner ID>:<name>".
+	ShareEntity(ctx context.Context, token, kind, name string, s Share) (Share, error)
+
+	// ListShares returns the shares of the user's stream or rule.
+	ListShares(ctx context.Context, token, kind, name string) ([]Share, error)
+
+	// UnshareEntity revokes the share of the user's stream or rule with the
+	// given grantee.
+	UnshareEntity(ctx context.Context, token, kind, name, grantee string) error
+
+	// Rename recreates the user's stream or rule under the new name, keeping
+	// its ID, metadata and shares. The rules reading from the renamed stream
+	// are updated to read from it under the new name.
+	Rename(ctx context.Context, token, kind, name, newName string) (Result, error)
+
+	// ListAuditEvents returns the audit events of the operations performed
+	// on the streams, tables and rules, filtered by the query. Users view
+	// the events of their own entities and the platform administrator the
+	// events of all the entities.
+	ListAuditEvents(ctx context.Context, token string, q AuditQuery) (AuditPage, error)
+
+	// ListInstances returns the Kuiper instances along with the users
+	// assigned to them. Only the platform administrator can list them.
+	ListInstances(ctx context.Context, token string) ([]Instance, error)
+
+	// AssignInstance assigns the user with the given ID to the Kuiper
+	// instance, or back to the instance the pool strategy picks if the
+	// instance is empty. Users are moved to another instance only before
+	// they create streams, tables or rules. Only the platform administrator
+	// can assign users.
+	AssignInstance(ctx context.Context, token, userID, instance string) (Assignment, error)
+
+	// SaveGateway registers the user's edge gateway or updates the
+	// registered one. Gateways registered without the control channel use
+	// the first channel of their bootstrap configuration.
+	SaveGateway(ctx context.Context, token string, gw Gateway) (Gateway, error)
+
+	// ListGateways returns the edge gateways the user registered.
+	ListGateways(ctx context.Context, token string) ([]Gateway, error)
+
+	// RemoveGateway removes the user's edge gateway that has no rules
+	// deployed.
+	RemoveGateway(ctx context.Context, token, id string) error
+
+	// DeployRule publishes the rule, along with the streams and tables it
+	// reads from, to the control channel of the gateway, whose Kuiper
+	// instance runs it. The deployment is pending until the gateway reports
+	// its status.
+	DeployRule(ctx context.Context, token, id, gateway string) (Deployment, error)
+
+	// UndeployRule publishes the command removing the rule from the
+	// gateway. The deployment is removed once the gateway reports the rule
+	// removed.
+	UndeployRule(ctx context.Context, token, id, gateway string) (Deployment, error)
+
+	// ListDeployments returns the deployments of the rule to the edge
+	// gateways along with their statuses.
+	ListDeployments(ctx context.Context, token, id string) ([]Deployment, error)
+
+	// DeployFleet deploys the rule to all the user's edge gateways having
+	// the given labels, or to all of them if no labels are given. Gateways
+	// the rule couldn't be published to are marked failed rather than
+	// failing the rollout.
+	DeployFleet(ctx context.Context, token, id string, labels map[string]string) (Rollout, error)
+
+	// RetryDeployments deploys the rule again to the gateways it failed on.
+	RetryDeployments(ctx context.Context, token, id string) (Rollout, error)
+
+	// ViewRollout returns the aggregate status of the rule deployed to the
+	// edge gateways.
+	ViewRollout(ctx context.Context, token, id string) (Rollout, error)
+
+	// EngineStats summarizes the user's streams, tables and rules, counting
+	// the rules by their states along with the records they processed and
+	// their exceptions, in total and since the user's previous statistics.
+	EngineStats(ctx context.Context, token string) (EngineStats, error)
+
+	// SetAlertPolicy replaces the policy the user is alerted about the
+	// failing rules with, subscribing the policy contacts to the alerts.
+	SetAlertPolicy(ctx context.Context, token string, p AlertPolicy) (AlertPolicy, error)
+
+	// ViewAlertPolicy returns the user's alert policy.
+	ViewAlertPolicy(ctx context.Context, token string) (AlertPolicy, error)
+
+	// RemoveAlertPolicy removes the user's alert policy along with the
+	// subscriptions of its contacts, so the user is no longer alerted.
+	RemoveAlertPolicy(ctx context.Context, token string) error
+
+	// CreateWebhook registers the webhook called on the lifecycle events of
+	// the user's rules, generating the secret if it isn't given.
+	CreateWebhook(ctx context.Context, token string, wh Webhook) (Webhook, error)
+
+	// ListWebhooks returns the user's webhooks without their secrets.
+	ListWebhooks(ctx context.Context, token string) ([]Webhook, error)
+
+	// RemoveWebhook removes the user's webhook.
+	RemoveWebhook(ctx context.Context, token, id string) error
+
+	// StartRules starts the selected rules concurrently, reporting the
+	// result for each of them.
+	StartRules(ctx context.Context, token string, sel RuleSelection) (BulkReport, error)
+
+	// StopRules stops the selected rules concurrently without removing
+	// them, reporting the result for each of them.
+	StopRules(ctx context.Context, token string, sel RuleSelection) (BulkReport, error)
+
+	// CreateFolder creates the folder the user's rules are organized in.
+	CreateFolder(ctx context.Context, token string, f Folder) (Folder, error)
+
+	// ListFolders returns the user's folders along with the number of the
+	// rules in them.
+	ListFolders(ctx context.Context, token string) ([]Folder, error)
+
+	// RemoveFolder removes the user's folder, leaving its rules without a
+	// folder.
+	RemoveFolder(ctx context.Context, token, name string) error
+
+	// MoveRules moves the user's rules with the given IDs to the folder, or
+	// out of their folders if the folder is empty, reporting the result for
+	// each of the rules.
+	MoveRules(ctx context.Context, token, folder string, ids []string) (BulkReport, error)
+
+	// Pause puts the user in the maintenance mode, stopping all of the
+	// user's running rules and recording which rules were running.
+	Pause(ctx context.Context, token string) (BulkReport, error)
+
+	// Resume ends the user's maintenance mode, starting exactly the rules
+	// that were running when the rules were paused.
+	Resume(ctx context.Context, token string) (BulkReport, error)
+
+	// ViewMaintenance returns the user's maintenance mode, failing if the
+	// user's rules aren't paused.
+	ViewMaintenance(ctx context.Context, token string) (Maintenance, error)
+
+	// CreateChain feeds the results of the user's rule to another rule,
+	// creating the memory stream the rule publishes the results to and the
+	// other rule reads from.
+	CreateChain(ctx context.Context, token string, c Chain) (Chain, error)
+
+	// ListChains returns the user's chains sorted by name.
+	ListChains(ctx context.Context, token string) ([]Chain, error)
+
+	// RemoveChain removes the user's chain that no rule reads from, along
+	// with its stream and the sink publishing to it.
+	RemoveChain(ctx context.Context, token, name string) error
+
+	// SaveSecret creates or replaces the user's secret the rule actions
+	// refer to, storing its value encrypted. The value isn't returned.
+	SaveSecret(ctx context.Context, token string, s Secret) (Secret, error)
+
+	// ListSecrets returns the user's secrets sorted by name, without the
+	// values.
+	ListSecrets(ctx context.Context, token string) ([]Secret, error)
+
+	// RemoveSecret removes the user's secret. Rules referring to it keep
+	// the value they were sent to Kuiper with.
+	RemoveSecret(ctx context.Context, token, name string) error
+
+	// CreateTemplate registers the rule template. Only the platform
+	// administrator can register templates.
+	CreateTemplate(ctx context.Context, token string, tmpl Template) (Template, error)
+
+	// ViewTemplate returns the template with the given name.
+	ViewTemplate(ctx context.Context, token, name string) (Template, error)
+
+	// ListTemplates returns all the templates sorted by name.
+	ListTemplates(ctx context.Context, token string) ([]Template, error)
+
+	// RemoveTemplate removes the template with the given name. Rules
+	// created from the template are kept. Only the platform administrator
+	// can remove templates.
+	RemoveTemplate(ctx context.Context, token, name string) error
+
+	// InstantiateTemplate creates the rule of the user identified by the
+	// given token from the template, substituting the template variables
+	// with the instance values.
+	InstantiateTemplate(ctx context.Context, token, name string, inst TemplateInstance) (Rule, error)
+
+	// CreatePlugin installs the Kuiper plugin of the given kind: sources,
+	// sinks or functions. Plugins are shared by all the users, so only the
+	// platform administrator can manage them.
+	CreatePlugin(ctx context.Context, token, kind string, plugin Plugin) (Result, error)
+
+	// ListPlugins returns the names of the installed Kuiper plugins of the
+	// given kind sorted by name.
+	ListPlugins(ctx context.Context, token, kind string) ([]string, error)
+
+	// DeletePlugin removes the Kuiper plugin of the given kind with the
+	// given name.
+	DeletePlugin(ctx context.Context, token, kind, name string) (Result, error)
+
+	// SaveConfKey creates or replaces the confKey of the Kuiper MQTT source
+	// with the given name, which the MQTT streams of the user identified by
+	// the given token subscribe with.
+	SaveConfKey(ctx context.Context, token, name string, conf MQTTConf) (Result, error)
+
+	// ListConfKeys returns the names of the confKeys of the Kuiper MQTT
+	// source that belong to the user identified by the given token, sorted
+	// by name.
+	ListConfKeys(ctx context.Context, token string) ([]string, error)
+
+	// DeleteConfKey removes the confKey of the Kuiper MQTT source with the
+	// given name that belongs to the user identified by the given token.
+	DeleteConfKey(ctx context.Context, token, name string) (Result, error)
+
+	// RegisterExternalService registers the external service Kuiper calls
+	// as SQL functions. External services are shared by all the users, so
+	// only the platform administrator can manage them.
+	RegisterExternalService(ctx context.Context, token string, es ExternalService) (Result, error)
+
+	// ListExternalServices returns the names of the registered external
+	// services sorted by name.
+	ListExternalServices(ctx context.Context, token string) ([]string, error)
+
+	// DeleteExternalService removes the external service with the given
+	// name along with its functions.
+	DeleteExternalService(ctx context.Context, token, name string) (Result, error)
+
+	// ListExternalFunctions returns the SQL functions of the registered
+	// external services sorted by name, available to all the users.
+	ListExternalFunctions(ctx context.Context, token string) ([]ExternalFunction, error)
+}
+
+type reService struct {
+	engine    RuleEngine
+	auth      magistrala.AuthServiceClient
+	sdk       mgsdk.SDK
+	notifiers Notifiers
+	// edge publishes the commands to the control channels of the gateways.
+	edge    messaging.Publisher
+	writers WritersConfig
+	tail    TailConfig
+	tails   *tails
+	watch   WatchConfig
+	logs    LogsConfig
+	repo    Repository
+	// bulkWorkers is the size of the worker pool of each bulk operation.
+	bulkWorkers int
+	// quotas is the default quota of the users.
+	quotas Quota
+	// quotaLocks serialize the creations of each user with limits.
+	quotaLocks *keyLocks
+	limiter    *limiter
+	// identities caches the users identified by the tokens.
+	identities *identities
+	// roles enables the read-only domain roles.
+	roles bool
+	// retention is the period the deleted rules can be restored for.
+	retention time.Duration
+	// snapshots are the previous engine statistics of the users.
+	snapshots *snapshots
+	// requireRevision rejects the updates and the deletions that don't
+	// expect any entity revision.
+	requireRevision bool
+	// secrets encrypts the users' secrets.
+	secrets SecretsConfig
+	// encryption encrypts the sensitive action fields of the stored rule
+	// definitions.
+	encryption EncryptionConfig
+	// push forwards the messages pushed to the httppush streams.
+	push PushConfig
+	// webhooks restricts the addresses the webhooks call, and the
+	// webhookClient calls them.
+	webhooks      WebhooksConfig
+	webhookClient *http.Client
+}
+
+// New instantiates the rules engine service implementation running the
+// streams and rules in Kuiper. Rules are deployed to the edge gateways
+// through the given publisher, and edge deployment is disabled if it's nil.
+func New(cfg Config, auth magistrala.AuthServiceClient, sdk mgsdk.SDK, notifiers Notifiers, edge messaging.Publisher, repo Repository) Service {
+	return newService(NewEngine(cfg, repo), cfg, auth, sdk, notifiers, edge, repo)
+}
+
+// NewWithEngine instantiates the rules engine service implementation running
+// the streams and rules in the given engine. The Kuiper connection options
+// of the configuration are ignored.
+func NewWithEngine(engine RuleEngine, cfg Config, auth magistrala.AuthServiceClient, sdk mgsdk.SDK, notifiers Notifiers, edge messaging.Publisher, repo Repository) Service {
+	return newService(engine, cfg, auth, sdk, notifiers, edge, repo)
+}
+
+func newService(engine RuleEngine, cfg Config, auth magistrala.AuthServiceClient, sdk mgsdk.SDK, notifiers Notifiers, edge messaging.Publisher, repo Repository) *reService {
+	return &reService{
+		engine:    engine,
+		auth:      auth,
+		sdk:       sdk,
+		notifiers: notifiers,
+		edge:      edge,
+		writers:   cfg.Writers,
+		tail:      cfg.Tail,
+		tails:     newTails(),
+		watch:     cfg.Watch,
+		logs:      cfg.Logs,
+		repo:      repo,
+		// Bulk operations run sequentially if the workers aren't set.
+		bulkWorkers: max(cfg.BulkWorkers, 1),
+		quotas:      Quota(cfg.Quota),
+		quotaLocks:  newKeyLocks(),
+		limiter:     newLimiter(cfg.RateLimit),
+		identities:  newIdentities(cfg.IdentityCache),
+		roles:       cfg.Roles,
+		retention:   cfg.DeleteRetention,
+		snapshots:   &snapshots{owners: make(map[string]statsSnapshot)},
+
+		requireRevision: cfg.RequireRevision,
+		secrets:         cfg.Secrets,
+		encryption:      cfg.Encryption,
+		push:            cfg.Push,
+		webhooks:        cfg.Webhooks,
+		webhookClient:   newWebhookClient(cfg.Webhooks),
+	}
+}
+
+func (svc *reService) Info(ctx context.Context) (Info, error) {
+	return svc.engine.Info(ctx)
+}
+
+func (svc *reService) CreateStream(ctx context.Context, token string, def StreamDef, update bool) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	var owner, name string
+	if update {
+		owner, name, err = svc.resolve(ctx, token, userID, StreamKind, def.Name, ManageAccess)
+	} else {
+		owner, name, err = svc.namespace(ctx, token, userID, def.Name)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	if update {
+		if err := svc.checkRevision(ctx, token, StreamKind, def.Name); err != nil {
+			return Result{}, err
+		}
+	}
+	def.Name = name
+	// ConfKeys contain the user's credentials.
+	if owner != userID && def.ConfKey != "" {
+		return Result{}, errors.Wrap(svcerr.ErrAuthorization, errSharedConfKey)
+	}
+
+	def = def.withDefaults()
+	pfx := prefix(owner)
+	kuiperName := pfx + def.Name
+	sql, err := def.ddl(kuiperName, pfx)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	definition, err := streamDefinition(def)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if def.Type == HTTPPushSource && svc.push.URL == "" {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, errPushDisabled)
+	}
+	if def.channelBound() {
+		if err := svc.authorizeChannel(ctx, token, def.Topic); err != nil {
+			return Result{}, err
+		}
+	}
+	if err := svc.checkConfKey(ctx, def, pfx); err != nil {
+		return Result{}, err
+	}
+	op := AuditCreate
+	var old *Metadata
+	if update {
+		if old, err = svc.metadata(ctx, StreamKind, kuiperName); err != nil {
+			return Result{}, err
+		}
+		op = AuditUpdate
+	} else {
+		if res, ok, err := svc.existing(ctx, StreamKind, owner, def.Name, definition); err != nil || ok {
+			return res, err
+		}
+		release, err := svc.reserve(ctx, owner, StreamKind)
+		if err != nil {
+			return Result{}, err
+		}
+		defer release()
+	}
+
+	res, err := svc.saveStream(ctx, owner, def.Name, sql, update)
+	if err != nil {
+		return Result{}, err
+	}
+	md := Metadata{Owner: owner, Description: def.Description, Labels: def.Labels, Attributes: def.Attributes, Definition: definition}
+	if err := svc.saveMetadata(ctx, StreamKind, def.Name, md, update); err != nil {
+		if !update {
+			_, _ = svc.engine.DeleteStream(ctx, StreamKind, kuiperName)
+		}
+		return Result{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, StreamKind, def.Name, op, old.definition(), definition); err != nil {
+		return Result{}, err
+	}
+
+	return res, nil
+}
+
+// saveStream creates or updates the Kuiper stream of the user using the
+// given DDL.
+func (svc *reService) saveStream(ctx context.Context, userID, name, sql string, update bool) (Result, error) {
+	var res Result
+	var err error
+	if update {
+		res, err = svc.engine.UpdateStream(ctx, StreamKind, prefix(userID)+name, sql)
+	} else {
+		res, err = svc.engine.CreateStream(ctx, StreamKind, sql)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(name, userID), nil
+}
+
+func (svc *reService) ListStreams(ctx context.Context, token string, pm PageMetadata) (StreamsPage, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return StreamsPage{}, err
+	}
+	owner, err := svc.listOwner(ctx, token, userID, pm)
+	if err != nil {
+		return StreamsPage{}, err
+	}
+
+	all, err := svc.engine.ListStreams(ctx, StreamKind)
+	if err != nil {
+		return StreamsPage{}, err
+	}
+
+	mds, err := svc.repo.RetrieveAll(ctx, StreamKind, owner)
+	if err != nil {
+		return StreamsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	pfx := prefix(owner)
+	streams := []string{}
+	for _, name := range all {
+		if !strings.HasPrefix(name, pfx) {
+			continue
+		}
+		md := mds[name]
+		if name = strings.TrimPrefix(name, pfx); pm.match(name, md) {
+			streams = append(streams, name)
+		}
+	}
+
+	page := pageStreams(streams, func(name string) Metadata { return mds[pfx+name] }, pm)
+	for _, name := range page.Streams {
+		if md, ok := mds[pfx+name]; ok {
+			if page.Metadata == nil {
+				page.Metadata = make(map[string]Metadata)
+			}
+			page.Metadata[name] = md
+		}
+	}
+
+	return page, nil
+}
+
+func (svc *reService) ViewStream(ctx context.Context, token, name string) (Stream, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Stream{}, err
+	}
+	owner, name, err := svc.resolve(ctx, token, userID, StreamKind, name, ViewAccess)
+	if err != nil {
+		return Stream{}, err
+	}
+
+	pfx := prefix(owner)
+	stream, err := svc.engine.ViewStream(ctx, StreamKind, pfx+name)
+	if err != nil {
+		return Stream{}, err
+	}
+	if stream.Metadata, err = svc.ownedMetadata(ctx, StreamKind, owner, pfx+name, stream.Name); err != nil {
+		return Stream{}, err
+	}
+	if stream.Options["type"] == HTTPPushSource {
+		stream.IngestURL = svc.push.ingestURL(stream.Name)
+	}
+	stream.Name = strings.TrimPrefix(stream.Name, pfx)
+
+	return stream, nil
+}
+
+func (svc *reService) DeleteStream(ctx context.Context, token, name string, cascade bool) (Result, error) {
+	return svc.deleteStream(ctx, token, name, cascade, false)
+}
+
+// deleteStream removes the stream like DeleteStream. The streams of the
+// chains are removed only along with their chains, which chain reports.
+func (svc *reService) deleteStream(ctx context.Context, token, name string, cascade, chain bool) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	ref := name
+	owner, name, err := svc.resolve(ctx, token, userID, StreamKind, ref, ManageAccess)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.checkRevision(ctx, token, StreamKind, ref); err != nil {
+		return Result{}, err
+	}
+
+	kuiperName := prefix(owner) + name
+	md, err := svc.ownedMetadata(ctx, StreamKind, owner, kuiperName, kuiperName)
+	if err != nil {
+		return Result{}, err
+	}
+	if !chain {
+		if err := svc.checkChained(ctx, owner, StreamKind, name); err != nil {
+			return Result{}, err
+		}
+	}
+	// Kuiper refuses to drop the streams the deployed rules read from, but
+	// the drafts would fail when published, so the stored definitions are
+	// checked as well.
+	rules, err := svc.definedRules(ctx, owner, RuleQuery{Stream: name})
+	if err != nil {
+		return Result{}, err
+	}
+	if len(rules) > 0 && !cascade {
+		return Result{}, errors.Wrap(svcerr.ErrConflict, errors.Wrap(ErrStreamInUse, errors.New("referenced by rules "+strings.Join(rules, ", "))))
+	}
+	// Deleted rules still read from the stream, so the rules are purged. The
+	// user passed the write checks once for the whole cascade.
+	ctx = written(ctx)
+	for _, id := range rules {
+		if owner != userID {
+			id = owner + ownerSeparator + id
+		}
+		if _, err := svc.deleteRule(ctx, token, id, true); err != nil {
+			return Result{}, err
+		}
+	}
+	res, err := svc.engine.DeleteStream(ctx, StreamKind, kuiperName)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.removeMetadata(ctx, StreamKind, kuiperName); err != nil {
+		return Result{}, err
+	}
+	if err := svc.removeShares(ctx, StreamKind, kuiperName); err != nil {
+		return Result{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, StreamKind, name, AuditDelete, md.definition(), ""); err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(name, owner), nil
+}
+
+func (svc *reService) CreateRule(ctx context.Context, token string, rule Rule) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	owner, id, err := svc.namespace(ctx, token, userID, rule.ID)
+	if err != nil {
+		return Result{}, err
+	}
+	rule.ID = id
+	if rule.Actions, err = svc.unmask(ctx, owner, id, rule.Actions); err != nil {
+		return Result{}, err
+	}
+	kr, err := svc.prepareRule(ctx, token, userID, owner, rule)
+	if err != nil {
+		return Result{}, err
+	}
+	plain, err := ruleDefinition(rule)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	definition, err := svc.sealedDefinition(rule)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if err := svc.checkDraft(ctx, kr.ID); err != nil {
+		return Result{}, err
+	}
+	if res, ok, err := svc.existing(ctx, RuleKind, owner, rule.ID, plain); err != nil || ok {
+		return res, err
+	}
+	release, err := svc.reserve(ctx, owner, RuleKind)
+	if err != nil {
+		return Result{}, err
+	}
+	defer release()
+
+	res, err := svc.engine.CreateRule(ctx, kr)
+	if err != nil {
+		return Result{}, err
+	}
+	// Subscriptions are created once the rule exists, so creating a rule
+	// that already exists never changes the subscriptions of that rule. The
+	// subscriptions the expired rule with the same ID left are removed
+	// first.
+	if err := svc.unsubscribe(token, rule); err != nil {
+		_, _ = svc.engine.DeleteRule(ctx, kr.ID)
+		return Result{}, err
+	}
+	if err := svc.subscribe(token, rule); err != nil {
+		_ = svc.unsubscribe(token, rule)
+		_, _ = svc.engine.DeleteRule(ctx, kr.ID)
+		return Result{}, err
+	}
+	md := Metadata{Owner: owner, Description: rule.Description, Labels: rule.Labels, Attributes: rule.Attributes, ExpiresAt: rule.ExpiresAt, Schedule: rule.Schedule, Definition: definition}
+	if err := svc.saveMetadata(ctx, RuleKind, rule.ID, md, false); err != nil {
+		_ = svc.unsubscribe(token, rule)
+		_, _ = svc.engine.DeleteRule(ctx, kr.ID)
+		return Result{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, rule.ID, AuditCreate, "", definition); err != nil {
+		return Result{}, err
+	}
+	svc.emit(ctx, userID, owner, EventRuleCreated, rule.ID, "")
+
+	return res.owned(rule.ID, owner), nil
+}
+
+func (svc *reService) UpdateRule(ctx context.Context, token string, rule Rule) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, rule.ID, ManageAccess)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.checkRevision(ctx, token, RuleKind, rule.ID); err != nil {
+		return Result{}, err
+	}
+	rule.ID = id
+	if rule.Actions, err = svc.unmask(ctx, owner, id, rule.Actions); err != nil {
+		return Result{}, err
+	}
+	// Tail sinks belong to the tail sessions, so they're never persisted
+	// with the rule definition.
+	rule.Actions = svc.untailedActions(rule.Actions)
+	kr, err := svc.prepareRule(ctx, token, userID, owner, rule)
+	if err != nil {
+		return Result{}, err
+	}
+	definition, err := svc.sealedDefinition(rule)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	old, err := svc.notifications(ctx, prefix(owner), rule.ID)
+	if err != nil {
+		return Result{}, err
+	}
+	// The contacts are read, so they're subscribed again on rollback.
+	if err := svc.contacts(token, old); err != nil {
+		return Result{}, err
+	}
+	oldMd, err := svc.metadata(ctx, RuleKind, kr.ID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	prev, err := svc.engine.ViewRule(ctx, kr.ID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res, err := svc.updateRule(ctx, kr)
+	if err != nil {
+		return Result{}, err
+	}
+	// If the update fails after Kuiper accepted it, the previous rule and
+	// its subscriptions are restored, like the created rule is removed.
+	if err := svc.unsubscribe(token, old); err != nil {
+		_ = svc.subscribe(token, old)
+		_, _ = svc.updateRule(ctx, prev)
+		return Result{}, err
+	}
+	if err := svc.subscribe(token, rule); err != nil {
+		_ = svc.unsubscribe(token, rule)
+		_ = svc.subscribe(token, old)
+		_, _ = svc.updateRule(ctx, prev)
+		return Result{}, err
+	}
+	// Updates keep the rule in its folder.
+	md := Metadata{Owner: owner, Description: rule.Description, Labels: rule.Labels, Attributes: rule.Attributes, Folder: oldMd.folder(), ExpiresAt: rule.ExpiresAt, Schedule: rule.Schedule, Definition: definition}
+	if err := svc.saveMetadata(ctx, RuleKind, rule.ID, md, true); err != nil {
+		_ = svc.unsubscribe(token, rule)
+		_ = svc.subscribe(token, old)
+		_, _ = svc.updateRule(ctx, prev)
+		return Result{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, rule.ID, AuditUpdate, oldMd.definition(), definition); err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(rule.ID, owner), nil
+}
+
+func (svc *reService) PatchRule(ctx context.Context, token, id string, patch RulePatch) (Result, error) {
+	return svc.patchRule(ctx, token, id, patch, true)
+}
+
+// patchRule patches the rule like PatchRule. Unless restart is set, the
+// rule that should run is left to run the patched definition the engine
+// applied, rather than restarted.
+func (svc *reService) patchRule(ctx context.Context, token, id string, patch RulePatch, restart bool) (Result, error) {
+	if patch.empty() {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, errEmptyPatch)
+	}
+	expected := ExpectedRevision(ctx)
+	if expected == "" && svc.requireRevision {
+		return Result{}, ErrRevisionRequired
+	}
+	// Only the users managing the rule claim its revision.
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	if _, _, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess); err != nil {
+		return Result{}, err
+	}
+	rule, err := svc.viewRule(ctx, token, id)
+	if err != nil {
+		return Result{}, err
+	}
+	md := rule.Metadata
+	// The patch applies to the rule as it was read here, so it's this
+	// revision that is checked and claimed, and not the one the update
+	// below would read again.
+	if expected != "" && expected != AnyRevision {
+		viewed := rule
+		viewed.Actions = maskActions(rule.Actions)
+		if viewed.Revision() != expected {
+			return Result{}, errors.Wrap(svcerr.ErrConflict, ErrStaleRevision)
+		}
+		if err := svc.claimRevision(ctx, RuleKind, rule.ID, md); err != nil {
+			return Result{}, err
+		}
+	}
+	// The patch passed the write checks, so the update runs without
+	// checking them again, and the revision was claimed above.
+	ctx = written(ctx)
+	// The shared rule is referred to by its owner and ID.
+	rule = patch.apply(rule)
+	rule.ID = id
+	rule.Metadata = nil
+	if md != nil && md.Draft {
+		return svc.SaveDraft(ctx, token, rule)
+	}
+	validation, err := svc.ValidateRule(ctx, token, rule)
+	if err != nil {
+		return Result{}, err
+	}
+	if !validation.Valid {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, validation.err())
+	}
+	// The desired state is read before the update, which starts the rule.
+	run, err := svc.desiredRunning(ctx, md)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res, err := svc.UpdateRule(ctx, token, rule)
+	if err != nil {
+		return Result{}, err
+	}
+	// Rules without metadata keep the state the engine gives them.
+	if md == nil || (run && !restart) {
+		return res, nil
+	}
+	// Engines don't necessarily restart the updated rule, so the rule that
+	// should run is restarted to run the patched definition, and the rule
+	// that shouldn't is stopped.
+	kuiperID := prefix(res.Owner) + res.Name
+	command := "stop"
+	if run {
+		command = "restart"
+	}
+	if _, err := svc.engine.ControlRule(ctx, kuiperID, command); err != nil {
+		return Result{}, err
+	}
+	if md.Stopped {
+		if err := svc.saveState(ctx, kuiperID, true); err != nil {
+			return Result{}, err
+		}
+	}
+
+	return res, nil
+}
+
+func (svc *reService) CloneRule(ctx context.Context, token, id, newID, channel string) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	rule, err := svc.viewRule(ctx, token, id)
+	if err != nil {
+		return Result{}, err
+	}
+	// Only the users managing the rule clone its credentials, so the rule
+	// shared for viewing is cloned only if it has none.
+	switch _, _, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess); {
+	case errors.Contains(err, svcerr.ErrAuthorization):
+		if rule.Actions = maskActions(rule.Actions); masked(rule.Actions) {
+			return Result{}, errors.Wrap(err, errMaskedClone)
+		}
+	case err != nil:
+		return Result{}, err
+	}
+	draft := rule.Metadata != nil && rule.Metadata.Draft
+	rule.ID = newID
+	rule.Metadata = nil
+	if channel != "" {
+		rule.Actions = retarget(rule.Actions, channel)
+	}
+	// The clone passed the write checks, so it's created without checking
+	// them again.
+	ctx = written(ctx)
+	if draft {
+		return svc.SaveDraft(ctx, token, rule)
+	}
+
+	return svc.CreateRule(ctx, token, rule)
+}
+
+func (svc *reService) ViewRule(ctx context.Context, token, id string) (Rule, error) {
+	rule, err := svc.viewRule(ctx, token, id)
+	if err != nil {
+		return Rule{}, err
+	}
+	rule.Actions = maskActions(rule.Actions)
+
+	return rule, nil
+}
+
+// viewRule returns the rule with the sensitive values unmasked, so the rule
+// can be saved again, e.g. patched or cloned.
+func (svc *reService) viewRule(ctx context.Context, token, id string) (Rule, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Rule{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ViewAccess)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	pfx := prefix(owner)
+	md, err := svc.metadata(ctx, RuleKind, pfx+id)
+	if err != nil {
+		return Rule{}, err
+	}
+	if md != nil && md.Draft {
+		return draftRule(id, md)
+	}
+	kr, err := svc.engine.ViewRule(ctx, pfx+id)
+	if err != nil {
+		return Rule{}, err
+	}
+	rule, err := svc.kuiperRule(kr, pfx)
+	if err != nil {
+		return Rule{}, errors.Wrap(errReadResponse, err)
+	}
+	if rule.Actions, err = svc.redactSecrets(md, rule.Actions); err != nil {
+		return Rule{}, err
+	}
+	if err := svc.contacts(token, rule); err != nil {
+		return Rule{}, err
+	}
+	if rule.Metadata = md; rule.Metadata != nil {
+		rule.Description, rule.Labels, rule.Attributes = rule.Metadata.Description, rule.Metadata.Labels, rule.Metadata.Attributes
+		rule.ExpiresAt, rule.Schedule = rule.Metadata.ExpiresAt, rule.Metadata.Schedule
+	}
+
+	return rule, nil
+}
+
+func (svc *reService) ListRules(ctx context.Context, token string, pm PageMetadata) (RulesPage, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return RulesPage{}, err
+	}
+	owner, err := svc.listOwner(ctx, token, userID, pm)
+	if err != nil {
+		return RulesPage{}, err
+	}
+
+	all, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return RulesPage{}, err
+	}
+	mds, err := svc.repo.RetrieveAll(ctx, RuleKind, owner)
+	if err != nil {
+		return RulesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	pfx := prefix(owner)
+	rules := []RuleInfo{}
+	for _, r := range all {
+		if !strings.HasPrefix(r.ID, pfx) {
+			continue
+		}
+		md := mds[r.ID]
+		if r.ID = strings.TrimPrefix(r.ID, pfx); pm.match(r.ID, md) {
+			rules = append(rules, r)
+		}
+	}
+	for kuiperID, md := range mds {
+		if id := strings.TrimPrefix(kuiperID, pfx); md.Draft && pm.match(id, md) {
+			rules = append(rules, RuleInfo{ID: id, Status: RuleDraft})
+		}
+	}
+	for i, r := range rules {
+		if md, ok := mds[pfx+r.ID]; ok {
+			rules[i].Metadata = &md
+			if md.deleted() {
+				rules[i].Status = RuleDeleted
+			}
+		}
+	}
+
+	return pageRules(rules, pm), nil
+}
+
+func (svc *reService) DeleteRule(ctx context.Context, token, id string) (Result, error) {
+	return svc.deleteRule(ctx, token, id, false)
+}
+
+// deleteRule removes the rule like DeleteRule. If purge is set, the rule is
+// removed permanently regardless of the retention period.
+func (svc *reService) deleteRule(ctx context.Context, token, id string, purge bool) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	// Deleted rules are purged by deleting them again.
+	ref := id
+	owner, id, err := svc.resolveRef(ctx, token, userID, RuleKind, ref, ManageAccess)
+	if err != nil {
+		return Result{}, err
+	}
+	if !purge {
+		if err := svc.checkRevision(ctx, token, RuleKind, ref); err != nil {
+			return Result{}, err
+		}
+	}
+
+	return svc.deleteOwnedRule(ctx, token, userID, owner, id, purge)
+}
+
+// deleteOwnedRule removes the owner's rule like deleteRule, once the user is
+// authorized to. The background jobs remove rules without the user and the
+// notifiers, so the subscriptions of these rules are left until the rules
+// with the same IDs are created.
+func (svc *reService) deleteOwnedRule(ctx context.Context, token, userID, owner, id string, purge bool) (Result, error) {
+	if err := svc.checkChained(ctx, owner, RuleKind, id); err != nil {
+		return Result{}, err
+	}
+	kuiperID := prefix(owner) + id
+	md, err := svc.metadata(ctx, RuleKind, kuiperID)
+	if err != nil {
+		return Result{}, err
+	}
+	res, err := svc.removeRule(ctx, token, kuiperID, id, owner, md, purge)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, id, AuditDelete, md.definition(), ""); err != nil {
+		return Result{}, err
+	}
+
+	return res, nil
+}
+
+// removeRule deletes the rule with the given Kuiper ID and metadata,
+// softly unless the rule is already deleted or purge is set.
+func (svc *reService) removeRule(ctx context.Context, token, kuiperID, id, owner string, md *Metadata, purge bool) (Result, error) {
+	switch {
+	case md != nil && md.deleted():
+		return svc.purgeRule(ctx, kuiperID, id, *md)
+	case md != nil && svc.retention > 0 && !purge:
+		return svc.softDelete(ctx, token, kuiperID, id, *md)
+	case md != nil && md.Draft:
+		return svc.deleteDraft(ctx, kuiperID, id, owner)
+	}
+	old, err := svc.notifications(ctx, prefix(owner), id)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res, err := svc.engine.DeleteRule(ctx, kuiperID)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.unsubscribe(token, old); err != nil {
+		return Result{}, err
+	}
+	if err := svc.removeMetadata(ctx, RuleKind, kuiperID); err != nil {
+		return Result{}, err
+	}
+	if err := svc.removeShares(ctx, RuleKind, kuiperID); err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(id, owner), nil
+}
+
+func (svc *reService) StartRule(ctx context.Context, token, id string) (Result, error) {
+	return svc.controlRule(ctx, token, id, "start")
+}
+
+func (svc *reService) StopRule(ctx context.Context, token, id string) (Result, error) {
+	return svc.controlRule(ctx, token, id, "stop")
+}
+
+func (svc *reService) RestartRule(ctx context.Context, token, id string) (Result, error) {
+	return svc.controlRule(ctx, token, id, "restart")
+}
+
+func (svc *reService) RuleStatus(ctx context.Context, token, id string) (RuleStatus, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return RuleStatus{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ViewAccess)
+	if err != nil {
+		return RuleStatus{}, err
+	}
+
+	status, err := svc.engine.RuleStatus(ctx, prefix(owner)+id)
+	if err != nil {
+		return RuleStatus{}, err
+	}
+
+	return status.withoutPrefix(prefix(owner)), nil
+}
+
+func (svc *reService) RuleTopology(ctx context.Context, token, id string) (RuleTopology, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return RuleTopology{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ViewAccess)
+	if err != nil {
+		return RuleTopology{}, err
+	}
+
+	topo, err := svc.engine.RuleTopology(ctx, prefix(owner)+id)
+	if err != nil {
+		return RuleTopology{}, err
+	}
+
+	return topo.withoutPrefix(prefix(owner)), nil
+}
+
+// controlRule sends the given command to the user's rule. Since the rule ID
+// is namespaced with the owner prefix, only the owner and the users the rule
+// is shared with for management can control the rule.
+func (svc *reService) controlRule(ctx context.Context, token, id, command string) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return Result{}, err
+	}
+
+	res, err := svc.engine.ControlRule(ctx, prefix(owner)+id, command)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.saveState(ctx, prefix(owner)+id, command == "stop"); err != nil {
+		return Result{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, id, command, "", ""); err != nil {
+		return Result{}, err
+	}
+	event := EventRuleStarted
+	if command == "stop" {
+		event = EventRuleStopped
+	}
+	svc.emit(ctx, userID, owner, event, id, "")
+
+	return res.owned(id, owner), nil
+}
+
+// prepareRule validates the rule ID and options, checks that the user can
+// publish to the rule's channels and returns the Kuiper rule with the rule
+// ID, the streams it reads from and the tables it writes to namespaced with
+// the owner prefix. The user other than the owner manages the shared rule.
+func (svc *reService) prepareRule(ctx context.Context, token, userID, owner string, rule Rule) (EngineRule, error) {
+	if err := validateName(rule.ID); err != nil {
+		return EngineRule{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if err := rule.Options.validate(); err != nil {
+		return EngineRule{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if len(rule.Actions) == 0 {
+		return EngineRule{}, svcerr.ErrMalformedEntity
+	}
+	if err := checkExpiry(rule.ExpiresAt); err != nil {
+		return EngineRule{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if err := rule.Schedule.validate(); err != nil {
+		return EngineRule{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if err := svc.authorizeActions(ctx, token, rule.Actions); err != nil {
+		return EngineRule{}, err
+	}
+	if err := svc.checkChainTarget(ctx, owner, rule); err != nil {
+		return EngineRule{}, err
+	}
+
+	pfx := prefix(owner)
+	// Group members read from all the streams of the group.
+	if owner != userID && !svc.member(ctx, token, owner) {
+		old, err := svc.engine.ViewRule(ctx, pfx+rule.ID)
+		if err != nil {
+			return EngineRule{}, err
+		}
+		if err := svc.checkSharedStreams(ctx, token, userID, owner, old.SQL, rule.SQL); err != nil {
+			return EngineRule{}, err
+		}
+	}
+
+	return svc.namespaceRule(ctx, rule, owner)
+}
+
+// namespaceRule returns the Kuiper rule with the rule ID and the streams it
+// reads from prefixed with the owner prefix, the sealed sensitive values
+// decrypted and the references to the owner's secrets resolved.
+func (svc *reService) namespaceRule(ctx context.Context, rule Rule, owner string) (EngineRule, error) {
+	pfx := prefix(owner)
+	rule.ID = pfx + rule.ID
+	sql, err := addPrefix(rule.SQL, pfx)
+	if err != nil {
+		return EngineRule{}, err
+	}
+	rule.SQL = sql
+	if rule.Actions, err = svc.openActions(rule.Actions); err != nil {
+		return EngineRule{}, err
+	}
+	if rule.Actions, err = svc.resolveSecrets(ctx, owner, rule.Actions); err != nil {
+		return EngineRule{}, err
+	}
+
+	return toKuiper(rule, pfx, svc.writers)
+}
+
+// notifications returns the existing rule of the user with the given prefix,
+// so the subscriptions of its notification actions can be removed. If no
+// notifier is configured, the rule isn't retrieved, since it can't have
+// notification subscriptions.
+func (svc *reService) notifications(ctx context.Context, pfx, id string) (Rule, error) {
+	if svc.notifiers.Email == nil && svc.notifiers.SMS == nil {
+		return Rule{}, nil
+	}
+	kr, err := svc.engine.ViewRule(ctx, pfx+id)
+	if err != nil {
+		return Rule{}, err
+	}
+	rule, err := svc.kuiperRule(kr, pfx)
+	if err != nil {
+		return Rule{}, errors.Wrap(errReadResponse, err)
+	}
+
+	return rule, nil
+}
+
+// authorizeActions checks that every action has a single valid sink, valid
+// delivery and data templates, that Mainflux sinks publish to a valid
+// subtopic of a channel the user has write access to and that notification
+// sinks and dead letters publish to such a channel. Instead of stopping at
+// the first failed action, the returned error reports the failures of all
+// the actions.
+func (svc *reService) authorizeActions(ctx context.Context, token string, actions []Action) error {
+	var malformed, unauthorized []string
+	for _, f := range svc.checkActions(ctx, token, actions) {
+		msg := fmt.Sprintf("action %d: %s", f.index, f.message)
+		if f.unauthorized {
+			unauthorized = append(unauthorized, msg)
+			continue
+		}
+		malformed = append(malformed, msg)
+	}
+
+	switch {
+	case len(malformed) > 0:
+		return errors.Wrap(svcerr.ErrMalformedEntity, errors.New(strings.Join(malformed, "; ")))
+	case len(unauthorized) > 0:
+		return errors.Wrap(svcerr.ErrAuthorization, errors.New(strings.Join(unauthorized, "; ")))
+	default:
+		return nil
+	}
+}
+
+// actionFailure describes why the action at the index of the rule actions
+// can't be used.
+type actionFailure struct {
+	index        int
+	message      string
+	unauthorized bool
+}
+
+// checkActions returns the failures of all the actions, checked as
+// described by authorizeActions.
+func (svc *reService) checkActions(ctx context.Context, token string, actions []Action) []actionFailure {
+	var failures []actionFailure
+	malformed := func(i int, format string, args ...interface{}) {
+		failures = append(failures, actionFailure{index: i, message: fmt.Sprintf(format, args...)})
+	}
+	checked := make(map[string]error)
+	for i, action := range actions {
+		typ, err := action.sink()
+		if err != nil {
+			malformed(i, "%s", err)
+			continue
+		}
+		if err := action.Delivery.validate(typ); err != nil {
+			malformed(i, "delivery: %s", err)
+			continue
+		}
+		if err := action.validateTemplates(); err != nil {
+			malformed(i, "%s", err)
+			continue
+		}
+		var channels []string
+		if action.Delivery != nil && action.Delivery.DeadLetter != nil {
+			channels = append(channels, action.Delivery.DeadLetter.Channel)
+		}
+		switch typ {
+		case MainfluxSinkType:
+			sink := action.Mainflux
+			if sink.Channel == "" {
+				malformed(i, "missing channel")
+				continue
+			}
+			if err := validateSubtopic(sink.Subtopic); err != nil {
+				malformed(i, "subtopic %s: %s", sink.Subtopic, err)
+				continue
+			}
+			channels = append(channels, sink.Channel)
+		default:
+			if err := action.validate(); err != nil {
+				malformed(i, "%s sink: %s", typ, err)
+				continue
+			}
+			if typ == WriterSinkType && !svc.writers.enabled(action.Writer.Type) {
+				malformed(i, "%s writer: %s", action.Writer.Type, errWriterDisabled)
+				continue
+			}
+			if _, n := action.notification(); n != nil {
+				if svc.notifiers.notifier(typ) == nil {
+					malformed(i, "%s %s", typ, errNotifierDisabled)
+					continue
+				}
+				channels = append(channels, n.Channel)
+			}
+		}
+		for _, channel := range channels {
+			err, ok := checked[channel]
+			if !ok {
+				err = svc.authorizeChannel(ctx, token, channel)
+				checked[channel] = err
+			}
+			if err != nil {
+				failures = append(failures, actionFailure{index: i, message: fmt.Sprintf("channel %s: %s", channel, err), unauthorized: true})
+			}
+		}
+	}
+
+	return failures
+}
+
+func (svc *reService) identify(ctx context.Context, token string) (string, error) {
+	id, err := svc.identity(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	return id.userID, nil
+}
+
+// identity returns the user and the domain the token identifies, asking the
+// auth service only if the identity isn't cached. Failed identification
+// drops the cached identity.
+func (svc *reService) identity(ctx context.Context, token string) (identity, error) {
+	if id, ok := svc.identities.get(token); ok {
+		return id, nil
+	}
+	res, err := svc.auth.Identify(ctx, &magistrala.IdentityReq{Token: token})
+	if err != nil {
+		svc.identities.remove(token)
+		return identity{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	id := identity{userID: res.GetUserId(), domainID: res.GetDomainId()}
+	svc.identities.set(token, id)
+
+	return id, nil
+}
+
+// prefix returns the prefix used to namespace Kuiper entities of the user.
+func prefix(userID string) string {
+	return "u" + strings.ReplaceAll(userID, "-", "") + "_"
+}
+
+// subscribe subscribes the contacts of the rule's notification actions to
+// the action topics.
+func (svc *reService) subscribe(token string, rule Rule) error {
+	for i, a := range rule.Actions {
+		typ, n := a.notification()
+		if n == nil {
+			continue
+		}
+		topic := notificationTopic(n.Channel, notificationSubtopic(typ, rule.ID, i))
+		for _, c := range n.Contacts {
+			if _, err := svc.notifiers.notifier(typ).CreateSubscription(topic, c, token); err != nil {
+				return errors.Wrap(ErrNotifier, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// unsubscribe removes the subscriptions to the topics of the rule's
+// notification actions.
+func (svc *reService) unsubscribe(token string, rule Rule) error {
+	return svc.subscriptions(token, rule, func(notifier mgsdk.SDK, _ *NotificationSink, sub mgsdk.Subscription) error {
+		return notifier.DeleteSubscription(sub.ID, token)
+	})
+}
+
+// contacts sets the contacts of the rule's notification actions to the
+// contacts subscribed to the action topics.
+func (svc *reService) contacts(token string, rule Rule) error {
+	return svc.subscriptions(token, rule, func(_ mgsdk.SDK, n *NotificationSink, sub mgsdk.Subscription) error {
+		n.Contacts = append(n.Contacts, sub.Contact)
+		return nil
+	})
+}
+
+// subscriptions calls fn for every subscription to the topics of the rule's
+// notification actions. Actions of the notifiers that aren't configured are
+// skipped.
+func (svc *reService) subscriptions(token string, rule Rule, fn func(mgsdk.SDK, *NotificationSink, mgsdk.Subscription) error) error {
+	for i, a := range rule.Actions {
+		typ, n := a.notification()
+		notifier := svc.notifiers.notifier(typ)
+		if n == nil || notifier == nil {
+			continue
+		}
+		pm := mgsdk.PageMetadata{Topic: notificationTopic(n.Channel, notificationSubtopic(typ, rule.ID, i)), Limit: maxContacts}
+		page, err := notifier.ListSubscriptions(pm, token)
+		if err != nil {
+			return errors.Wrap(ErrNotifier, err)
+		}
+		for _, sub := range page.Subscriptions {
+			if err := fn(notifier, n, sub); err != nil {
+				return errors.Wrap(ErrNotifier, err)
+			}
+		}
+	}
+
+	return nil
+}
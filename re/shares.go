@@ -0,0 +1,304 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Access levels of the shared streams and rules. Viewers can view the
+// entity, while managers can also update, control and delete it.
+const (
+	ViewAccess   = "view"
+	ManageAccess = "manage"
+)
+
+// Types of the users the streams and rules are shared with.
+const (
+	UserGrantee  = "user"
+	GroupGrantee = "group"
+)
+
+// ownerSeparator separates the owner ID from the name of the entity the
+// owner shared, e.g. "<owner>:<name>".
+const ownerSeparator = ":"
+
+var (
+	errShareKind     = errors.New("only streams and rules can be shared")
+	errShareAccess   = errors.New("access must be view or manage")
+	errGranteeType   = errors.New("grantee type must be user or group")
+	errMissingGrant  = errors.New("missing grantee")
+	errSelfShare     = errors.New("entity can't be shared with its owner")
+	errManageAccess  = errors.New("entity is shared for viewing only")
+	errSharedConfKey = errors.New("only the owner's streams can use confKeys")
+	errSharedStream  = errors.New("stream isn't shared with the user")
+)
+
+// Share grants the user or the members of the group the access to the
+// stream or rule of another user.
+type Share struct {
+	Grantee     string `json:"grantee"`
+	GranteeType string `json:"grantee_type"`
+	Access      string `json:"access"`
+}
+
+func (s Share) validate() error {
+	if s.Grantee == "" {
+		return errMissingGrant
+	}
+	if s.GranteeType != UserGrantee && s.GranteeType != GroupGrantee {
+		return errGranteeType
+	}
+	if s.Access != ViewAccess && s.Access != ManageAccess {
+		return errShareAccess
+	}
+
+	return nil
+}
+
+// ShareRepository specifies the persistence API of the shares of the
+// streams and rules, identified by their kind and Kuiper name.
+type ShareRepository interface {
+	// SaveShare stores the share of the entity, replacing the existing
+	// share with the same grantee.
+	SaveShare(ctx context.Context, kind, name string, s Share) error
+
+	// RetrieveShares returns the shares of the entity.
+	RetrieveShares(ctx context.Context, kind, name string) ([]Share, error)
+
+	// RemoveShare removes the share of the entity with the grantee.
+	RemoveShare(ctx context.Context, kind, name, grantee string) error
+
+	// RemoveShares removes all the shares of the entity.
+	RemoveShares(ctx context.Context, kind, name string) error
+}
+
+func (svc *reService) ShareEntity(ctx context.Context, token, kind, name string, s Share) (Share, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Share{}, err
+	}
+	if err := validateShare(kind, name); err != nil {
+		return Share{}, err
+	}
+	if err := s.validate(); err != nil {
+		return Share{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if s.GranteeType == UserGrantee && s.Grantee == userID {
+		return Share{}, errors.Wrap(svcerr.ErrMalformedEntity, errSelfShare)
+	}
+	if err := svc.ownEntity(ctx, kind, userID, name); err != nil {
+		return Share{}, err
+	}
+
+	if err := svc.repo.SaveShare(ctx, kind, prefix(userID)+name, s); err != nil {
+		return Share{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return s, nil
+}
+
+func (svc *reService) ListShares(ctx context.Context, token, kind, name string) ([]Share, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateShare(kind, name); err != nil {
+		return nil, err
+	}
+	if err := svc.ownEntity(ctx, kind, userID, name); err != nil {
+		return nil, err
+	}
+
+	shares, err := svc.repo.RetrieveShares(ctx, kind, prefix(userID)+name)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return shares, nil
+}
+
+func (svc *reService) UnshareEntity(ctx context.Context, token, kind, name, grantee string) error {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := validateShare(kind, name); err != nil {
+		return err
+	}
+
+	switch err := svc.repo.RemoveShare(ctx, kind, prefix(userID)+name, grantee); {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func validateShare(kind, name string) error {
+	if kind != StreamKind && kind != RuleKind {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errShareKind)
+	}
+	if err := validateName(name); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
+
+// ownEntity checks that the stream or rule with the given name exists and
+// belongs to the user.
+func (svc *reService) ownEntity(ctx context.Context, kind, userID, name string) error {
+	kuiperName := prefix(userID) + name
+	resolved := kuiperName
+	if kind == StreamKind {
+		stream, err := svc.engine.ViewStream(ctx, StreamKind, kuiperName)
+		if err != nil {
+			return err
+		}
+		resolved = stream.Name
+	} else if _, err := svc.engine.ViewRule(ctx, kuiperName); err != nil {
+		return err
+	}
+	_, err := svc.ownedMetadata(ctx, kind, userID, kuiperName, resolved)
+
+	return err
+}
+
+// resolve returns the owner and the name of the stream, table or rule the
+// user refers to. Users refer to their own entities by name and to the
+// entities other users shared with them, or the entities of the groups they
+// are members of, by the owner ID and the name separated by a colon.
+// Referring to the shared entity requires at least the given access, while
+// group members have full access to the group entities. Deleted rules are
+// reported as missing.
+func (svc *reService) resolve(ctx context.Context, token, userID, kind, ref, access string) (string, string, error) {
+	owner, name, err := svc.resolveRef(ctx, token, userID, kind, ref, access)
+	if err != nil {
+		return "", "", err
+	}
+	if kind == RuleKind && svc.retention > 0 {
+		if err := svc.checkDeleted(ctx, prefix(owner)+name); err != nil {
+			return "", "", err
+		}
+	}
+
+	return owner, name, nil
+}
+
+// resolveRef resolves the entity reference like resolve, including the
+// deleted rules.
+func (svc *reService) resolveRef(ctx context.Context, token, userID, kind, ref, access string) (string, string, error) {
+	owner, name, shared := strings.Cut(ref, ownerSeparator)
+	if !shared {
+		owner, name = userID, ref
+	}
+	if err := validateName(name); err != nil {
+		return "", "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if owner == userID {
+		return owner, name, nil
+	}
+	// The owner ID becomes a part of the Kuiper name.
+	if err := validateName(prefix(owner) + name); err != nil {
+		return "", "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if err := svc.checkShare(ctx, token, userID, kind, prefix(owner)+name, access); err != nil {
+		if errors.Contains(err, svcerr.ErrViewEntity) || !svc.member(ctx, token, owner) {
+			return "", "", err
+		}
+	}
+
+	return owner, name, nil
+}
+
+// checkShare checks that the entity with the given Kuiper name is shared
+// with the user, or a group the user is a member of, with at least the given
+// access. Entities that aren't shared with the user are reported as missing,
+// so their names aren't disclosed.
+func (svc *reService) checkShare(ctx context.Context, token, userID, kind, name, access string) error {
+	shares, err := svc.repo.RetrieveShares(ctx, kind, name)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	viewer := false
+	for _, s := range shares {
+		if !svc.granted(ctx, token, userID, s) {
+			continue
+		}
+		if s.Access == ManageAccess || s.Access == access {
+			return nil
+		}
+		viewer = true
+	}
+	if viewer {
+		return errors.Wrap(svcerr.ErrAuthorization, errManageAccess)
+	}
+
+	return errors.Wrap(svcerr.ErrNotFound, errEntityOwner)
+}
+
+// granted reports whether the share grants the access to the user.
+func (svc *reService) granted(ctx context.Context, token, userID string, s Share) bool {
+	switch s.GranteeType {
+	case UserGrantee:
+		return s.Grantee == userID
+	case GroupGrantee:
+		return svc.member(ctx, token, s.Grantee)
+	default:
+		return false
+	}
+}
+
+// checkSharedStreams checks that the manager of the shared rule reads only
+// from the streams the rule already reads from or the streams shared with
+// the manager, so sharing the rule doesn't disclose the other streams of
+// the owner. The old SQL is the SQL of the Kuiper rule.
+func (svc *reService) checkSharedStreams(ctx context.Context, token, userID, owner, oldSQL, sql string) error {
+	pfx := prefix(owner)
+	read := make(map[string]bool)
+	if _, err := rewriteStreams(oldSQL, func(name string) string {
+		read[name] = true
+		return name
+	}); err != nil {
+		return errors.Wrap(errReadResponse, err)
+	}
+	var names []string
+	if _, err := rewriteStreams(sql, func(name string) string {
+		names = append(names, pfx+name)
+		return name
+	}); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if read[name] {
+			continue
+		}
+		if err := svc.checkShare(ctx, token, userID, StreamKind, name, ViewAccess); err != nil {
+			return errors.Wrap(svcerr.ErrAuthorization, errSharedStream)
+		}
+		read[name] = true
+	}
+
+	return nil
+}
+
+// removeShares removes the shares of the deleted entity with the given
+// Kuiper name.
+func (svc *reService) removeShares(ctx context.Context, kind, name string) error {
+	if err := svc.repo.RemoveShares(ctx, kind, name); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,420 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const groupID = "3c4d5e6f-7a8b-4c9d-8e0f-1a2b3c4d5e6f"
+
+// authorizeMember mocks the check of the membership of the token's user in
+// the group.
+func authorizeMember(auth *authmocks.AuthClient, token, group string, member bool) *mock.Call {
+	return auth.On("Authorize", mock.Anything, &magistrala.AuthorizeReq{
+		SubjectType: "user",
+		SubjectKind: "token",
+		Subject:     token,
+		Permission:  "membership",
+		ObjectType:  "group",
+		Object:      group,
+	}).Return(&magistrala.AuthorizeRes{Authorized: member}, nil)
+}
+
+func TestShareEntity(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc  string
+		kind  string
+		name  string
+		share re.Share
+		err   error
+	}{
+		{
+			desc:  "share stream with user",
+			kind:  re.StreamKind,
+			name:  "stream",
+			share: re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ViewAccess},
+		},
+		{
+			desc:  "share rule with group",
+			kind:  re.RuleKind,
+			name:  "rule",
+			share: re.Share{Grantee: groupID, GranteeType: re.GroupGrantee, Access: re.ManageAccess},
+		},
+		{
+			desc:  "share table",
+			kind:  re.TableKind,
+			name:  "table",
+			share: re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ViewAccess},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "share with invalid access",
+			kind:  re.RuleKind,
+			name:  "rule",
+			share: re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: "admin"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "share with invalid grantee type",
+			kind:  re.RuleKind,
+			name:  "rule",
+			share: re.Share{Grantee: otherUserID, GranteeType: "domain", Access: re.ViewAccess},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "share with owner",
+			kind:  re.RuleKind,
+			name:  "rule",
+			share: re.Share{Grantee: userID, GranteeType: re.UserGrantee, Access: re.ViewAccess},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "share non-existing rule",
+			kind:  re.RuleKind,
+			name:  "unknown",
+			share: re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ViewAccess},
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "share shared rule",
+			kind:  re.RuleKind,
+			name:  otherUserID + ":rule",
+			share: re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ViewAccess},
+			err:   svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		s, err := svc.ShareEntity(context.Background(), validToken, tc.kind, tc.name, tc.share)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.share, s, fmt.Sprintf("%s: expected share %v got %v\n", tc.desc, tc.share, s))
+		}
+	}
+}
+
+func TestListShares(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	shares := []re.Share{
+		{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ViewAccess},
+		{Grantee: groupID, GranteeType: re.GroupGrantee, Access: re.ManageAccess},
+	}
+	for _, s := range shares {
+		_, err := svc.ShareEntity(context.Background(), validToken, re.RuleKind, "rule", s)
+		assert.Nil(t, err, fmt.Sprintf("share rule: expected no error got %s\n", err))
+	}
+
+	cases := []struct {
+		desc   string
+		kind   string
+		name   string
+		shares []re.Share
+		err    error
+	}{
+		{
+			desc:   "list shares of rule",
+			kind:   re.RuleKind,
+			name:   "rule",
+			shares: []re.Share{shares[0], shares[1]},
+		},
+		{
+			desc:   "list shares of unshared stream",
+			kind:   re.StreamKind,
+			name:   "stream",
+			shares: []re.Share{},
+		},
+		{
+			desc: "list shares of rule of other user",
+			kind: re.RuleKind,
+			name: "x/../../rules/" + otherPrefix + "rule",
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "list shares of non-existing rule",
+			kind: re.RuleKind,
+			name: "unknown",
+			err:  svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := svc.ListShares(context.Background(), validToken, tc.kind, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.ElementsMatch(t, tc.shares, res, fmt.Sprintf("%s: expected shares %v got %v\n", tc.desc, tc.shares, res))
+		}
+	}
+}
+
+func TestUnshareEntity(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	s := re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ViewAccess}
+	_, err := svc.ShareEntity(context.Background(), validToken, re.StreamKind, "stream", s)
+	assert.Nil(t, err, fmt.Sprintf("share stream: expected no error got %s\n", err))
+
+	cases := []struct {
+		desc    string
+		kind    string
+		grantee string
+		err     error
+	}{
+		{
+			desc:    "unshare stream",
+			kind:    re.StreamKind,
+			grantee: otherUserID,
+		},
+		{
+			desc:    "unshare unshared stream",
+			kind:    re.StreamKind,
+			grantee: otherUserID,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:    "unshare table",
+			kind:    re.TableKind,
+			grantee: otherUserID,
+			err:     svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UnshareEntity(context.Background(), validToken, tc.kind, "stream", tc.grantee)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSharedAccess(t *testing.T) {
+	cases := []struct {
+		desc      string
+		share     *re.Share
+		member    bool
+		viewErr   error
+		manageErr error
+	}{
+		{
+			desc:      "access unshared rule",
+			viewErr:   svcerr.ErrNotFound,
+			manageErr: svcerr.ErrNotFound,
+		},
+		{
+			desc:      "access rule shared for viewing",
+			share:     &re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ViewAccess},
+			manageErr: svcerr.ErrAuthorization,
+		},
+		{
+			desc:  "access rule shared for management",
+			share: &re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ManageAccess},
+		},
+		{
+			desc:   "access rule shared with group of user",
+			share:  &re.Share{Grantee: groupID, GranteeType: re.GroupGrantee, Access: re.ManageAccess},
+			member: true,
+		},
+		{
+			desc:      "access rule shared with group of other users",
+			share:     &re.Share{Grantee: groupID, GranteeType: re.GroupGrantee, Access: re.ManageAccess},
+			viewErr:   svcerr.ErrNotFound,
+			manageErr: svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, k, auth, _ := newService(t)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: otherToken}).Return(&magistrala.IdentityRes{UserId: otherUserID}, nil)
+		authCall2 := authorizeMember(auth, otherToken, groupID, tc.member)
+		authCall3 := authorizeChannel(auth, otherToken, channelID, true)
+		// The owner is a user, so it has no members.
+		authCall4 := authorizeMember(auth, otherToken, userID, false)
+		if tc.share != nil {
+			_, err := svc.ShareEntity(context.Background(), validToken, re.RuleKind, "rule", *tc.share)
+			assert.Nil(t, err, fmt.Sprintf("%s: share rule: expected no error got %s\n", tc.desc, err))
+		}
+
+		ref := userID + ":rule"
+		rule, err := svc.ViewRule(context.Background(), otherToken, ref)
+		assert.True(t, errors.Contains(err, tc.viewErr), fmt.Sprintf("%s: view rule: expected %s got %s\n", tc.desc, tc.viewErr, err))
+		if tc.viewErr == nil {
+			assert.Equal(t, "rule", rule.ID, fmt.Sprintf("%s: expected rule ID rule got %s\n", tc.desc, rule.ID))
+		}
+		_, err = svc.RuleStatus(context.Background(), otherToken, ref)
+		assert.True(t, errors.Contains(err, tc.viewErr), fmt.Sprintf("%s: rule status: expected %s got %s\n", tc.desc, tc.viewErr, err))
+		res, err := svc.StopRule(context.Background(), otherToken, ref)
+		assert.True(t, errors.Contains(err, tc.manageErr), fmt.Sprintf("%s: stop rule: expected %s got %s\n", tc.desc, tc.manageErr, err))
+		if tc.manageErr == nil {
+			assert.Equal(t, userID, res.Owner, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, userID, res.Owner))
+			assert.True(t, k.stopped[userPrefix+"rule"], fmt.Sprintf("%s: expected rule of owner to be stopped\n", tc.desc))
+		}
+		update := re.Rule{
+			ID:      ref,
+			SQL:     "SELECT * FROM stream WHERE v > 30",
+			Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+		}
+		_, err = svc.UpdateRule(context.Background(), otherToken, update)
+		assert.True(t, errors.Contains(err, tc.manageErr), fmt.Sprintf("%s: update rule: expected %s got %s\n", tc.desc, tc.manageErr, err))
+		if tc.manageErr == nil {
+			sql := "SELECT * FROM " + userPrefix + "stream WHERE v > 30"
+			assert.Equal(t, sql, k.rules[userPrefix+"rule"].SQL, fmt.Sprintf("%s: expected SQL %s got %s\n", tc.desc, sql, k.rules[userPrefix+"rule"].SQL))
+		}
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+		authCall3.Unset()
+		authCall4.Unset()
+	}
+}
+
+func TestCloneSharedRule(t *testing.T) {
+	hook := re.Action{REST: &re.RESTSink{URL: "https://example.com/hook", Headers: map[string]string{"Authorization": "Bearer t0ken"}}}
+	cases := []struct {
+		desc    string
+		access  string
+		actions []re.Action
+		secret  bool
+		err     error
+	}{
+		{
+			desc:    "clone rule with credentials shared for viewing",
+			access:  re.ViewAccess,
+			actions: []re.Action{hook},
+			err:     svcerr.ErrAuthorization,
+		},
+		{
+			desc:    "clone rule without credentials shared for viewing",
+			access:  re.ViewAccess,
+			actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+		},
+		{
+			desc:    "clone rule with credentials shared for management",
+			access:  re.ManageAccess,
+			actions: []re.Action{hook},
+			secret:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		svc, k, auth, _ := newService(t)
+		authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+		authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: otherToken}).Return(&magistrala.IdentityRes{UserId: otherUserID}, nil)
+		authCall2 := authorizeChannel(auth, validToken, channelID, true)
+		authCall3 := authorizeChannel(auth, otherToken, channelID, true)
+		authCall4 := authorizeMember(auth, otherToken, userID, false)
+
+		_, err := svc.CreateRule(context.Background(), validToken, re.Rule{ID: "hook", SQL: "SELECT * FROM stream", Actions: tc.actions})
+		assert.Nil(t, err, fmt.Sprintf("%s: create rule: expected no error got %s\n", tc.desc, err))
+		_, err = svc.ShareEntity(context.Background(), validToken, re.RuleKind, "hook", re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: tc.access})
+		assert.Nil(t, err, fmt.Sprintf("%s: share rule: expected no error got %s\n", tc.desc, err))
+
+		_, err = svc.CloneRule(context.Background(), otherToken, userID+":hook", "copy", "")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		raw := string(k.raw[otherPrefix+"copy"])
+		if tc.err != nil {
+			assert.Empty(t, raw, fmt.Sprintf("%s: expected no clone in Kuiper got %s\n", tc.desc, raw))
+		}
+		assert.Equal(t, tc.secret, strings.Contains(raw, "t0ken"), fmt.Sprintf("%s: expected header cloned %t got %s\n", tc.desc, tc.secret, raw))
+		assert.NotContains(t, raw, re.MaskedValue, fmt.Sprintf("%s: expected no masked value in Kuiper got %s\n", tc.desc, raw))
+		authCall.Unset()
+		authCall1.Unset()
+		authCall2.Unset()
+		authCall3.Unset()
+		authCall4.Unset()
+	}
+}
+
+func TestSharedStreams(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+	k.streams[userPrefix+"private"] = ""
+	k.streams[userPrefix+"public"] = ""
+
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: otherToken}).Return(&magistrala.IdentityRes{UserId: otherUserID}, nil)
+	defer authCall1.Unset()
+	channelCall := authorizeChannel(auth, otherToken, channelID, true)
+	defer channelCall.Unset()
+	memberCall := authorizeMember(auth, otherToken, userID, false)
+	defer memberCall.Unset()
+
+	manager := re.Share{Grantee: otherUserID, GranteeType: re.UserGrantee, Access: re.ManageAccess}
+	for _, s := range []struct{ kind, name string }{{re.RuleKind, "rule"}, {re.StreamKind, "public"}, {re.StreamKind, "stream"}} {
+		_, err := svc.ShareEntity(context.Background(), validToken, s.kind, s.name, manager)
+		assert.Nil(t, err, fmt.Sprintf("share %s %s: expected no error got %s\n", s.kind, s.name, err))
+	}
+
+	cases := []struct {
+		desc string
+		sql  string
+		err  error
+	}{
+		{
+			desc: "update shared rule to read from stream it reads from",
+			sql:  "SELECT * FROM stream",
+		},
+		{
+			desc: "update shared rule to read from shared stream",
+			sql:  "SELECT * FROM public",
+		},
+		{
+			desc: "update shared rule to read from private stream",
+			sql:  "SELECT * FROM private",
+			err:  svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		rule := re.Rule{
+			ID:      userID + ":rule",
+			SQL:     tc.sql,
+			Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+		}
+		_, err := svc.UpdateRule(context.Background(), otherToken, rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	def := re.StreamDef{Name: userID + ":stream", Topic: channelID, SenML: true, ConfKey: "default"}
+	_, err := svc.CreateStream(context.Background(), otherToken, def, true)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthorization), fmt.Sprintf("update shared stream with confKey: expected %s got %s\n", svcerr.ErrAuthorization, err))
+	def.ConfKey = ""
+	res, err := svc.CreateStream(context.Background(), otherToken, def, true)
+	assert.Nil(t, err, fmt.Sprintf("update shared stream: expected no error got %s\n", err))
+	assert.Equal(t, userID, res.Owner, fmt.Sprintf("update shared stream: expected owner %s got %s\n", userID, res.Owner))
+
+	// The shared rule reads from the shared stream since its last update.
+	_, err = svc.DeleteStream(context.Background(), otherToken, userID+":public", false)
+	assert.True(t, errors.Contains(err, re.ErrStreamInUse), fmt.Sprintf("delete shared stream used by rules: expected %s got %s\n", re.ErrStreamInUse, err))
+	_, err = svc.DeleteStream(context.Background(), otherToken, userID+":public", true)
+	assert.Nil(t, err, fmt.Sprintf("delete shared stream: expected no error got %s\n", err))
+	_, ok := k.rules[userPrefix+"rule"]
+	assert.False(t, ok, "expected shared rule reading from the stream to be removed")
+	shares, err := repo.RetrieveShares(context.Background(), re.StreamKind, userPrefix+"public")
+	assert.Nil(t, err, fmt.Sprintf("retrieve shares: expected no error got %s\n", err))
+	assert.Empty(t, shares, fmt.Sprintf("expected shares of deleted stream to be removed got %v\n", shares))
+}
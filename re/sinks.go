@@ -0,0 +1,230 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// Kuiper sink types.
+const (
+	MainfluxSinkType = "mainflux"
+	RESTSinkType     = "rest"
+	MQTTSinkType     = "mqtt"
+	LogSinkType      = "log"
+	NopSinkType      = "nop"
+	WriterSinkType   = "writer"
+	MemorySinkType   = "memory"
+)
+
+var (
+	errMissingSink   = errors.New("missing sink")
+	errMultipleSinks = errors.New("action must have a single sink")
+	errMissingURL    = errors.New("url must be absolute http or https URL")
+	errMethod        = errors.New("unsupported method")
+	errBodyType      = errors.New("unsupported body type")
+	errTimeout       = errors.New("timeout must not be negative")
+	errServer        = errors.New("server must be tcp, ssl, ws or wss URL")
+	errSinkTopic     = errors.New("topic must not be empty or contain wildcards")
+	errSinkQoS       = errors.New("qos must be 0, 1 or 2")
+
+	// methods contains the HTTP methods of the REST sink.
+	methods = map[string]bool{
+		"GET":    true,
+		"POST":   true,
+		"PUT":    true,
+		"PATCH":  true,
+		"DELETE": true,
+	}
+
+	// bodyTypes contains the body types of the REST sink.
+	bodyTypes = map[string]bool{
+		"none":       true,
+		"text":       true,
+		"json":       true,
+		"html":       true,
+		"xml":        true,
+		"javascript": true,
+		"form":       true,
+	}
+
+	// brokerSchemes contains the URL schemes of the MQTT sink server.
+	brokerSchemes = map[string]bool{
+		"tcp": true,
+		"ssl": true,
+		"ws":  true,
+		"wss": true,
+	}
+)
+
+// Action represents Kuiper rule sink. Exactly one of the sinks must be set,
+// while the optional Delivery and DataTemplate apply to any of them.
+type Action struct {
+	Mainflux     *MainfluxSink     `json:"mainflux,omitempty"`
+	REST         *RESTSink         `json:"rest,omitempty"`
+	MQTT         *MQTTSink         `json:"mqtt,omitempty"`
+	Log          *LogSink          `json:"log,omitempty"`
+	Nop          *NopSink          `json:"nop,omitempty"`
+	Writer       *WriterSink       `json:"writer,omitempty"`
+	Email        *NotificationSink `json:"email,omitempty"`
+	SMS          *NotificationSink `json:"sms,omitempty"`
+	Memory       *MemorySink       `json:"memory,omitempty"`
+	Delivery     *Delivery         `json:"delivery,omitempty"`
+	DataTemplate string            `json:"dataTemplate,omitempty"`
+}
+
+// MainfluxSink publishes rule results to the Magistrala channel.
+type MainfluxSink struct {
+	Host     string `json:"host,omitempty"`
+	Port     string `json:"port,omitempty"`
+	Channel  string `json:"channel"`
+	Subtopic string `json:"subtopic,omitempty"`
+}
+
+// RESTSink sends rule results to the HTTP endpoint, e.g. a webhook. Method
+// defaults to POST and BodyType to json. Timeout is in milliseconds.
+type RESTSink struct {
+	URL          string            `json:"url"`
+	Method       string            `json:"method,omitempty"`
+	BodyType     string            `json:"bodyType,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Timeout      int64             `json:"timeout,omitempty"`
+	SendSingle   bool              `json:"sendSingle,omitempty"`
+	DataTemplate string            `json:"dataTemplate,omitempty"`
+}
+
+// MQTTSink publishes rule results to the topic of the external MQTT broker.
+type MQTTSink struct {
+	Server     string `json:"server"`
+	Topic      string `json:"topic"`
+	QoS        int    `json:"qos,omitempty"`
+	ClientID   string `json:"clientId,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+	Retained   bool   `json:"retained,omitempty"`
+	SendSingle bool   `json:"sendSingle,omitempty"`
+}
+
+// LogSink writes rule results to the Kuiper log.
+type LogSink struct{}
+
+// NopSink discards rule results. If Log is set, results are also written to
+// the Kuiper log.
+type NopSink struct {
+	Log bool `json:"log,omitempty"`
+}
+
+// MemorySink publishes rule results to the memory topic, read by the memory
+// streams of other rules. Like the memory stream topics, the Topic is
+// namespaced with the owner prefix.
+type MemorySink struct {
+	Topic string `json:"topic"`
+}
+
+// Type returns the type of the action sink. If the action doesn't have
+// exactly one sink, empty string is returned.
+func (a Action) Type() string {
+	typ, _ := a.sink()
+	return typ
+}
+
+// sink returns the type of the action sink.
+func (a Action) sink() (string, error) {
+	var types []string
+	if a.Mainflux != nil {
+		types = append(types, MainfluxSinkType)
+	}
+	if a.REST != nil {
+		types = append(types, RESTSinkType)
+	}
+	if a.MQTT != nil {
+		types = append(types, MQTTSinkType)
+	}
+	if a.Log != nil {
+		types = append(types, LogSinkType)
+	}
+	if a.Nop != nil {
+		types = append(types, NopSinkType)
+	}
+	if a.Writer != nil {
+		types = append(types, WriterSinkType)
+	}
+	if a.Email != nil {
+		types = append(types, EmailSinkType)
+	}
+	if a.SMS != nil {
+		types = append(types, SMSSinkType)
+	}
+	if a.Memory != nil {
+		types = append(types, MemorySinkType)
+	}
+	switch len(types) {
+	case 0:
+		return "", errMissingSink
+	case 1:
+		return types[0], nil
+	default:
+		return "", errors.Wrap(errMultipleSinks, errors.New(strings.Join(types, ", ")))
+	}
+}
+
+// validate validates sinks other than Mainflux, whose channel is checked
+// against the user's permissions instead.
+func (a Action) validate() error {
+	switch {
+	case a.REST != nil:
+		return a.REST.validate()
+	case a.MQTT != nil:
+		return a.MQTT.validate()
+	case a.Writer != nil:
+		return a.Writer.validate()
+	case a.Email != nil:
+		return a.Email.validate(EmailSinkType)
+	case a.SMS != nil:
+		return a.SMS.validate(SMSSinkType)
+	case a.Memory != nil:
+		if !pathRegexp.MatchString(a.Memory.Topic) {
+			return errMalformedSource
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (s *RESTSink) validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errMissingURL
+	}
+	if s.Method != "" && !methods[strings.ToUpper(s.Method)] {
+		return errors.Wrap(errMethod, errors.New(s.Method))
+	}
+	if s.BodyType != "" && !bodyTypes[strings.ToLower(s.BodyType)] {
+		return errors.Wrap(errBodyType, errors.New(s.BodyType))
+	}
+	if s.Timeout < 0 {
+		return errTimeout
+	}
+
+	return nil
+}
+
+func (s *MQTTSink) validate() error {
+	u, err := url.Parse(s.Server)
+	if err != nil || !brokerSchemes[u.Scheme] || u.Host == "" {
+		return errServer
+	}
+	if s.Topic == "" || strings.ContainsAny(s.Topic, "+#") {
+		return errSinkTopic
+	}
+	if s.QoS < 0 || s.QoS > 2 {
+		return errSinkQoS
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionSink(t *testing.T) {
+	cases := []struct {
+		desc   string
+		action Action
+		typ    string
+		err    error
+	}{
+		{
+			desc:   "mainflux sink",
+			action: Action{Mainflux: &MainfluxSink{Channel: streamChannel}},
+			typ:    MainfluxSinkType,
+		},
+		{
+			desc:   "rest sink",
+			action: Action{REST: &RESTSink{URL: "http://example.com"}},
+			typ:    RESTSinkType,
+		},
+		{
+			desc:   "mqtt sink",
+			action: Action{MQTT: &MQTTSink{Server: "tcp://example.com:1883", Topic: "t"}},
+			typ:    MQTTSinkType,
+		},
+		{
+			desc:   "log sink",
+			action: Action{Log: &LogSink{}},
+			typ:    LogSinkType,
+		},
+		{
+			desc:   "nop sink",
+			action: Action{Nop: &NopSink{}},
+			typ:    NopSinkType,
+		},
+		{
+			desc:   "memory sink",
+			action: Action{Memory: &MemorySink{Topic: "chains/filtered"}},
+			typ:    MemorySinkType,
+		},
+		{
+			desc:   "missing sink",
+			action: Action{},
+			err:    errMissingSink,
+		},
+		{
+			desc:   "multiple sinks",
+			action: Action{Log: &LogSink{}, Nop: &NopSink{}},
+			err:    errMultipleSinks,
+		},
+	}
+
+	for _, tc := range cases {
+		typ, err := tc.action.sink()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.typ, typ, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.typ, typ))
+	}
+}
+
+func TestValidateSink(t *testing.T) {
+	cases := []struct {
+		desc   string
+		action Action
+		err    error
+	}{
+		{
+			desc:   "valid rest sink",
+			action: Action{REST: &RESTSink{URL: "https://example.com/hook", Method: "put", BodyType: "text", Timeout: 1000}},
+		},
+		{
+			desc:   "rest sink without URL",
+			action: Action{REST: &RESTSink{}},
+			err:    errMissingURL,
+		},
+		{
+			desc:   "rest sink with relative URL",
+			action: Action{REST: &RESTSink{URL: "/hook"}},
+			err:    errMissingURL,
+		},
+		{
+			desc:   "rest sink with unsupported scheme",
+			action: Action{REST: &RESTSink{URL: "ftp://example.com"}},
+			err:    errMissingURL,
+		},
+		{
+			desc:   "rest sink with unsupported method",
+			action: Action{REST: &RESTSink{URL: "http://example.com", Method: "CONNECT"}},
+			err:    errMethod,
+		},
+		{
+			desc:   "rest sink with unsupported body type",
+			action: Action{REST: &RESTSink{URL: "http://example.com", BodyType: "yaml"}},
+			err:    errBodyType,
+		},
+		{
+			desc:   "rest sink with negative timeout",
+			action: Action{REST: &RESTSink{URL: "http://example.com", Timeout: -1}},
+			err:    errTimeout,
+		},
+		{
+			desc:   "valid mqtt sink",
+			action: Action{MQTT: &MQTTSink{Server: "ssl://example.com:8883", Topic: "alarms/high", QoS: 2}},
+		},
+		{
+			desc:   "mqtt sink with unsupported scheme",
+			action: Action{MQTT: &MQTTSink{Server: "http://example.com", Topic: "alarms"}},
+			err:    errServer,
+		},
+		{
+			desc:   "mqtt sink without topic",
+			action: Action{MQTT: &MQTTSink{Server: "tcp://example.com:1883"}},
+			err:    errSinkTopic,
+		},
+		{
+			desc:   "mqtt sink with wildcard topic",
+			action: Action{MQTT: &MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms/+"}},
+			err:    errSinkTopic,
+		},
+		{
+			desc:   "mqtt sink with invalid qos",
+			action: Action{MQTT: &MQTTSink{Server: "tcp://example.com:1883", Topic: "alarms", QoS: 3}},
+			err:    errSinkQoS,
+		},
+		{
+			desc:   "log sink",
+			action: Action{Log: &LogSink{}},
+		},
+		{
+			desc:   "valid memory sink",
+			action: Action{Memory: &MemorySink{Topic: "chains/filtered"}},
+		},
+		{
+			desc:   "memory sink with wildcard topic",
+			action: Action{Memory: &MemorySink{Topic: "chains/#"}},
+			err:    errMalformedSource,
+		},
+		{
+			desc:   "valid sms sink",
+			action: Action{SMS: &NotificationSink{Channel: streamChannel, Contacts: []string{"+381601234567"}}},
+		},
+		{
+			desc:   "sms sink with local phone number",
+			action: Action{SMS: &NotificationSink{Channel: streamChannel, Contacts: []string{"0601234567"}}},
+			err:    errPhone,
+		},
+		{
+			desc:   "email sink with phone number",
+			action: Action{Email: &NotificationSink{Channel: streamChannel, Contacts: []string{"+381601234567"}}},
+			err:    errEmail,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.action.validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		}
+	}
+}
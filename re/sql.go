@@ -0,0 +1,254 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+var (
+	errMissingStream = errors.New("rule SQL doesn't select from any stream")
+	errUnterminated  = errors.New("unterminated string or quoted identifier")
+	errStreamRef     = errors.New("expected stream name")
+)
+
+// sqlError is the error found at the byte offset of the rule SQL, so the
+// clients can point to the malformed part of the SQL.
+type sqlError struct {
+	pos int
+	err error
+}
+
+func (e *sqlError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.err, e.pos+1)
+}
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	quotedToken
+	stringToken
+	numberToken
+	punctToken
+)
+
+// token is a lexical token of the rule SQL. Start and end are byte offsets
+// of the token in the SQL, so the SQL can be rewritten in place without
+// changing its formatting.
+type token struct {
+	kind  tokenKind
+	text  string
+	start int
+	end   int
+}
+
+func (t token) keyword(kw string) bool {
+	return t.kind == identToken && strings.EqualFold(t.text, kw)
+}
+
+func (t token) punct(p string) bool {
+	return t.kind == punctToken && t.text == p
+}
+
+// name returns the identifier without the backquotes.
+func (t token) name() string {
+	if t.kind == quotedToken {
+		return t.text[1 : len(t.text)-1]
+	}
+
+	return t.text
+}
+
+// reserved contains keywords that can follow the stream reference, so they
+// are never treated as the stream alias.
+var reserved = map[string]bool{
+	"where": true, "group": true, "order": true, "having": true, "limit": true,
+	"join": true, "inner": true, "left": true, "right": true, "full": true,
+	"cross": true, "on": true, "union": true, "filter": true, "over": true,
+}
+
+// tokenize splits the rule SQL into tokens, skipping whitespace and
+// comments. Strings are quoted with single or double quotes and
+// identifiers may be quoted with backquotes.
+func tokenize(sql string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil, &sqlError{pos: i, err: errUnterminated}
+			}
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(sql[i+1:], c)
+			if end < 0 {
+				return nil, &sqlError{pos: i, err: errUnterminated}
+			}
+			kind := stringToken
+			if c == '`' {
+				kind = quotedToken
+			}
+			tokens = append(tokens, token{kind: kind, text: sql[i : i+end+2], start: i, end: i + end + 2})
+			i += end + 2
+		case identStart(c):
+			j := i + 1
+			for j < len(sql) && identPart(sql[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: identToken, text: sql[i:j], start: i, end: j})
+			i = j
+		case c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(sql) && (identPart(sql[j]) || sql[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: numberToken, text: sql[i:j], start: i, end: j})
+			i = j
+		default:
+			tokens = append(tokens, token{kind: punctToken, text: sql[i : i+1], start: i, end: i + 1})
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+func identStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func identPart(c byte) bool {
+	return identStart(c) || c >= '0' && c <= '9'
+}
+
+// streamRefs returns indexes of the tokens that reference streams. Those are
+// stream names in FROM and JOIN clauses, including the comma separated lists
+// of streams, and the stream names used to qualify the fields of streams
+// that are not aliased (e.g. "demo" in "SELECT demo.temp FROM demo").
+func streamRefs(tokens []token) ([]int, error) {
+	var refs []int
+	unaliased := make(map[string]bool)
+	for i := 0; i < len(tokens); i++ {
+		if !tokens[i].keyword("from") && !tokens[i].keyword("join") {
+			continue
+		}
+		for {
+			i++
+			if i < len(tokens) && tokens[i].punct("(") {
+				// Subqueries are rewritten as the scan continues.
+				i--
+				break
+			}
+			if i >= len(tokens) {
+				return nil, &sqlError{pos: tokens[len(tokens)-1].end, err: errStreamRef}
+			}
+			if tokens[i].kind != identToken && tokens[i].kind != quotedToken || reserved[strings.ToLower(tokens[i].text)] {
+				return nil, &sqlError{pos: tokens[i].start, err: errStreamRef}
+			}
+			refs = append(refs, i)
+			aliased := false
+			if i+1 < len(tokens) && tokens[i+1].keyword("as") {
+				i++
+				aliased = true
+			}
+			if next := i + 1; next < len(tokens) && (tokens[next].kind == identToken || tokens[next].kind == quotedToken) && !reserved[strings.ToLower(tokens[next].text)] {
+				i++
+				aliased = true
+			}
+			if !aliased {
+				unaliased[tokens[refs[len(refs)-1]].name()] = true
+			}
+			if i+1 >= len(tokens) || !tokens[i+1].punct(",") {
+				break
+			}
+			i++
+		}
+	}
+	if len(refs) == 0 {
+		return nil, errMissingStream
+	}
+
+	for i := 0; i+1 < len(tokens); i++ {
+		if (tokens[i].kind == identToken || tokens[i].kind == quotedToken) && tokens[i+1].punct(".") && unaliased[tokens[i].name()] {
+			if i > 0 && tokens[i-1].punct(".") {
+				continue
+			}
+			refs = append(refs, i)
+		}
+	}
+
+	return refs, nil
+}
+
+// rewriteStreams replaces every stream reference in the SQL with the result
+// of the rename function, leaving the rest of the SQL intact.
+func rewriteStreams(sql string, rename func(string) string) (string, error) {
+	tokens, err := tokenize(sql)
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	refs, err := streamRefs(tokens)
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	replaced := make(map[int]bool, len(refs))
+	for _, r := range refs {
+		replaced[tokens[r].start] = true
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, t := range tokens {
+		if !replaced[t.start] {
+			continue
+		}
+		sb.WriteString(sql[last:t.start])
+		name := rename(t.name())
+		if t.kind == quotedToken {
+			name = fmt.Sprintf("`%s`", name)
+		}
+		sb.WriteString(name)
+		last = t.end
+	}
+	sb.WriteString(sql[last:])
+
+	return sb.String(), nil
+}
+
+// addPrefix namespaces every stream the rule SQL reads from with the owner
+// prefix, so the rule can only read the owner's streams.
+func addPrefix(sql, pfx string) (string, error) {
+	return rewriteStreams(sql, func(name string) string {
+		return pfx + name
+	})
+}
+
+// removePrefix removes the owner prefix from the streams the rule SQL reads
+// from. If the SQL can't be parsed, it's returned as is.
+func removePrefix(sql, pfx string) string {
+	res, err := rewriteStreams(sql, func(name string) string {
+		return strings.TrimPrefix(name, pfx)
+	})
+	if err != nil {
+		return sql
+	}
+
+	return res
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const pfx = "u1234_"
+
+func TestAddPrefix(t *testing.T) {
+	cases := []struct {
+		desc string
+		sql  string
+		res  string
+		err  error
+	}{
+		{
+			desc: "prefix stream",
+			sql:  "SELECT * FROM demo WHERE v > 30",
+			res:  "SELECT * FROM u1234_demo WHERE v > 30",
+		},
+		{
+			desc: "prefix stream with lowercase keywords and newlines",
+			sql:  "select temp\nfrom\n  demo\nwhere temp > 30",
+			res:  "select temp\nfrom\n  u1234_demo\nwhere temp > 30",
+		},
+		{
+			desc: "prefix aliased stream",
+			sql:  "SELECT d.temp FROM demo AS d WHERE d.temp > 30",
+			res:  "SELECT d.temp FROM u1234_demo AS d WHERE d.temp > 30",
+		},
+		{
+			desc: "prefix stream qualifying fields",
+			sql:  "SELECT demo.temp, demo.* FROM demo WHERE demo.temp > 30",
+			res:  "SELECT u1234_demo.temp, u1234_demo.* FROM u1234_demo WHERE u1234_demo.temp > 30",
+		},
+		{
+			desc: "prefix joined streams",
+			sql:  "SELECT d.temp, h.hum FROM demo d LEFT JOIN humidity h ON d.id = h.id GROUP BY TUMBLINGWINDOW(ss, 10)",
+			res:  "SELECT d.temp, h.hum FROM u1234_demo d LEFT JOIN u1234_humidity h ON d.id = h.id GROUP BY TUMBLINGWINDOW(ss, 10)",
+		},
+		{
+			desc: "prefix stream list",
+			sql:  "SELECT * FROM demo, humidity",
+			res:  "SELECT * FROM u1234_demo, u1234_humidity",
+		},
+		{
+			desc: "prefix quoted stream",
+			sql:  "SELECT * FROM `my demo` WHERE v > 30",
+			res:  "SELECT * FROM `u1234_my demo` WHERE v > 30",
+		},
+		{
+			desc: "prefix stream in subquery",
+			sql:  "SELECT * FROM (SELECT temp FROM demo)",
+			res:  "SELECT * FROM (SELECT temp FROM u1234_demo)",
+		},
+		{
+			desc: "ignore keywords in strings and comments",
+			sql:  "SELECT * FROM demo /* from other */ WHERE name = 'from other' -- join other",
+			res:  "SELECT * FROM u1234_demo /* from other */ WHERE name = 'from other' -- join other",
+		},
+		{
+			desc: "prefix SQL without stream",
+			sql:  "SELECT 1",
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "prefix SQL with missing stream name",
+			sql:  "SELECT * FROM WHERE v > 30",
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "prefix SQL with unterminated string",
+			sql:  "SELECT * FROM demo WHERE name = 'demo",
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := addPrefix(tc.sql, pfx)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.res, res))
+		if tc.err == nil {
+			orig := removePrefix(res, pfx)
+			assert.Equal(t, tc.sql, orig, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.sql, orig))
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Rule states desired by the rule owners.
+const (
+	RuleRunning = "running"
+	RuleStopped = "stopped"
+)
+
+// bootSkew is the largest difference between the Kuiper boot times derived
+// from two Info calls that isn't taken for a Kuiper restart, since uptime
+// is reported in whole seconds and requests take time.
+const bootSkew = 5 * time.Second
+
+// StateChange is the rule brought back to the State its owner wants it in,
+// running or stopped, after Kuiper restarted. Error is why the state of the
+// rule couldn't be restored.
+type StateChange struct {
+	Name  string `json:"name"`
+	Owner string `json:"owner"`
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+// RestoreStates brings the rules back to the states desired by their owners
+// if Kuiper restarted since the previous call. The first call restores the
+// states, since Kuiper may have restarted before the service started.
+func (r *reconciler) RestoreStates(ctx context.Context) ([]StateChange, error) {
+	info, err := r.svc.engine.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Only the breaker state is known while Kuiper is unavailable.
+	if info.Version == "" {
+		return []StateChange{}, nil
+	}
+
+	boot := time.Now().Add(-time.Duration(info.UpTimeSeconds) * time.Second)
+	r.mu.Lock()
+	restarted := r.boot.IsZero() || boot.Sub(r.boot) > bootSkew
+	r.boot = boot
+	r.mu.Unlock()
+	if !restarted {
+		return []StateChange{}, nil
+	}
+
+	changes, err := r.svc.restoreStates(ctx)
+	if err != nil {
+		// Restore the states on the next call.
+		r.mu.Lock()
+		r.boot = time.Time{}
+		r.mu.Unlock()
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// restoreStates starts the running rules Kuiper reports stopped and stops
+// the stopped rules Kuiper runs. Rules missing in Kuiper are left to the
+// restore.
+func (svc *reService) restoreStates(ctx context.Context) ([]StateChange, error) {
+	return svc.applyStates(ctx, func(Metadata) bool {
+		return true
+	})
+}
+
+// applyStates brings the rules with the metadata the filter selects to the
+// states desired by their owners. The rules outside of their schedules are
+// kept stopped.
+func (svc *reService) applyStates(ctx context.Context, filter func(Metadata) bool) ([]StateChange, error) {
+	mds, err := svc.repo.RetrieveAll(ctx, RuleKind, "")
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	rules, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	changes := []StateChange{}
+	for _, r := range rules {
+		md, ok := mds[r.ID]
+		if !ok || !filter(md) {
+			continue
+		}
+		state, command := RuleRunning, "start"
+		if md.Stopped || md.deleted() || !md.Schedule.active(now) {
+			state, command = RuleStopped, "stop"
+		}
+		if strings.HasPrefix(strings.ToLower(r.Status), state) {
+			continue
+		}
+		c := StateChange{Name: r.ID, Owner: md.Owner, State: state}
+		if _, err := svc.engine.ControlRule(ctx, r.ID, command); err != nil {
+			c.Error = err.Error()
+		}
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes, nil
+}
+
+// saveState stores the state of the rule with the given Kuiper name desired
+// by its owner, along with the time the rule was started. Rules without
+// metadata keep the state Kuiper gives them.
+func (svc *reService) saveState(ctx context.Context, name string, stopped bool) error {
+	md, err := svc.metadata(ctx, RuleKind, name)
+	if err != nil || md == nil || (stopped && md.Stopped) {
+		return err
+	}
+	md.Stopped = stopped
+	if !stopped {
+		md.LastStartedAt = time.Now().UTC()
+	}
+	if err := svc.repo.Save(ctx, RuleKind, name, *md); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+// desiredRunning reports whether the rule with the given metadata should
+// run: its owner wants it running, the owner's rules aren't paused and the
+// rule's schedule is active.
+func (svc *reService) desiredRunning(ctx context.Context, md *Metadata) (bool, error) {
+	if md == nil || md.Stopped || md.deleted() || !md.Schedule.active(time.Now()) {
+		return false, nil
+	}
+	_, err := svc.maintenance(ctx, md.Owner)
+	switch {
+	case errors.Contains(err, svcerr.ErrNotFound):
+		return true, nil
+	case err != nil:
+		return false, err
+	}
+
+	return false, nil
+}
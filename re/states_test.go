@@ -0,0 +1,120 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSaveRuleState(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, _, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	err := repo.Save(context.Background(), re.RuleKind, userPrefix+"rule", re.Metadata{Owner: userID, CreatedAt: time.Now().UTC()})
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+
+	cases := []struct {
+		desc    string
+		control func(ctx context.Context, token, id string) (re.Result, error)
+		stopped bool
+	}{
+		{
+			desc:    "stop rule",
+			control: svc.StopRule,
+			stopped: true,
+		},
+		{
+			desc:    "restart rule",
+			control: svc.RestartRule,
+			stopped: false,
+		},
+		{
+			desc:    "stop rule again",
+			control: svc.StopRule,
+			stopped: true,
+		},
+		{
+			desc:    "start rule",
+			control: svc.StartRule,
+			stopped: false,
+		},
+		{
+			desc:    "restart running rule",
+			control: svc.RestartRule,
+			stopped: false,
+		},
+	}
+
+	var started time.Time
+	for _, tc := range cases {
+		_, err := tc.control(context.Background(), validToken, "rule")
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		md, err := repo.Retrieve(context.Background(), re.RuleKind, userPrefix+"rule")
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.stopped, md.Stopped, fmt.Sprintf("%s: expected stopped %t got %t\n", tc.desc, tc.stopped, md.Stopped))
+		// Stopping the rule keeps the time it was last started.
+		switch tc.stopped {
+		case true:
+			assert.Equal(t, started, md.LastStartedAt, fmt.Sprintf("%s: expected last start time %s got %s\n", tc.desc, started, md.LastStartedAt))
+		default:
+			assert.True(t, md.LastStartedAt.After(started), fmt.Sprintf("%s: expected last start time after %s got %s\n", tc.desc, started, md.LastStartedAt))
+		}
+		started = md.LastStartedAt
+	}
+
+	// Rules without metadata keep having no metadata.
+	_, err = svc.StopRule(context.Background(), validToken, "unknown")
+	assert.NotNil(t, err, "stop non-existing rule: expected error")
+	_, err = repo.Retrieve(context.Background(), re.RuleKind, userPrefix+"unknown")
+	assert.NotNil(t, err, "stop non-existing rule: expected no metadata")
+}
+
+func TestRestoreStates(t *testing.T) {
+	k, url := newKuiper(t)
+	k.uptime = 3600
+	k.stopped[otherPrefix+"rule"] = true
+	repo := mocks.NewRepository()
+	mds := map[string]re.Metadata{
+		userPrefix + "rule":  {Owner: userID, CreatedAt: time.Now().UTC(), Stopped: true},
+		otherPrefix + "rule": {Owner: otherUserID, CreatedAt: time.Now().UTC()},
+	}
+	for name, md := range mds {
+		err := repo.Save(context.Background(), re.RuleKind, name, md)
+		assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	}
+	cfg := re.Config{URL: url}
+	r := re.NewReconciler(re.NewKuiper(cfg), cfg, repo)
+
+	changes, err := r.RestoreStates(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("first check: expected no error got %s\n", err))
+	expected := []re.StateChange{
+		{Name: otherPrefix + "rule", Owner: otherUserID, State: re.RuleRunning},
+		{Name: userPrefix + "rule", Owner: userID, State: re.RuleStopped},
+	}
+	assert.Equal(t, expected, changes, fmt.Sprintf("first check: expected %v got %v\n", expected, changes))
+	assert.True(t, k.stopped[userPrefix+"rule"], "first check: expected stopped rule to be stopped")
+	assert.False(t, k.stopped[otherPrefix+"rule"], "first check: expected running rule to be started")
+
+	k.stopped[userPrefix+"rule"] = false
+	changes, err = r.RestoreStates(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("check without restart: expected no error got %s\n", err))
+	assert.Empty(t, changes, fmt.Sprintf("check without restart: expected no changes got %v\n", changes))
+
+	k.uptime = 0
+	changes, err = r.RestoreStates(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("check after restart: expected no error got %s\n", err))
+	expected = []re.StateChange{{Name: userPrefix + "rule", Owner: userID, State: re.RuleStopped}}
+	assert.Equal(t, expected, changes, fmt.Sprintf("check after restart: expected %v got %v\n", expected, changes))
+	assert.True(t, k.stopped[userPrefix+"rule"], "check after restart: expected stopped rule to be stopped")
+}
@@ -0,0 +1,188 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rule states the engine statistics count the rules by. Rules Kuiper
+// stopped for another reason than the stop command are counted as errors.
+const (
+	StatsRunning = "running"
+	StatsStopped = "stopped"
+	StatsError   = "error"
+)
+
+// manualStop is the reason Kuiper reports for the rules stopped by the stop
+// command, as in "Stopped: canceled manually.".
+const manualStop = "canceled manually"
+
+// EngineStats summarizes the user's footprint in the engine. The records
+// are the records the sources of the user's rules read, sent out by their
+// sinks and the exceptions of all their operators, counted by Kuiper since
+// each rule started. Interval holds the same counts since the user's
+// previous statistics, and is omitted for the first ones.
+type EngineStats struct {
+	Streams    int            `json:"streams"`
+	Tables     int            `json:"tables"`
+	Rules      int            `json:"rules"`
+	States     map[string]int `json:"states"`
+	RecordsIn  int64          `json:"records_in"`
+	RecordsOut int64          `json:"records_out"`
+	Exceptions int64          `json:"exceptions"`
+	Interval   *StatsInterval `json:"interval,omitempty"`
+}
+
+// StatsInterval contains the records and the exceptions of the user's rules
+// since the previous statistics were taken.
+type StatsInterval struct {
+	Since      time.Time `json:"since"`
+	RecordsIn  int64     `json:"records_in"`
+	RecordsOut int64     `json:"records_out"`
+	Exceptions int64     `json:"exceptions"`
+}
+
+// ruleCounters are the records and the exceptions of the single rule.
+type ruleCounters struct {
+	in, out, exceptions int64
+}
+
+// statsSnapshot holds the rule counters of the owner's previous statistics.
+type statsSnapshot struct {
+	at    time.Time
+	rules map[string]ruleCounters
+}
+
+// snapshots keeps the previous statistics of each owner. Snapshots aren't
+// shared between the service replicas, so the interval of the statistics
+// served by another replica starts at the previous statistics it served.
+type snapshots struct {
+	mu     sync.Mutex
+	owners map[string]statsSnapshot
+}
+
+// swap stores the owner's snapshot and returns the previous one.
+func (s *snapshots) swap(owner string, snap statsSnapshot) (statsSnapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.owners[owner]
+	s.owners[owner] = snap
+
+	return prev, ok
+}
+
+func (svc *reService) EngineStats(ctx context.Context, token string) (EngineStats, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return EngineStats{}, err
+	}
+	pfx := prefix(userID)
+	streams, err := svc.ownedNames(ctx, StreamKind, pfx)
+	if err != nil {
+		return EngineStats{}, err
+	}
+	tables, err := svc.ownedNames(ctx, TableKind, pfx)
+	if err != nil {
+		return EngineStats{}, err
+	}
+	all, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return EngineStats{}, err
+	}
+	var rules []RuleInfo
+	for _, r := range all {
+		if strings.HasPrefix(r.ID, pfx) {
+			rules = append(rules, r)
+		}
+	}
+
+	stats := EngineStats{Streams: len(streams), Tables: len(tables), Rules: len(rules), States: map[string]int{StatsRunning: 0, StatsStopped: 0, StatsError: 0}}
+	for _, r := range rules {
+		stats.States[statsState(r.Status)]++
+	}
+
+	// The statuses are fetched by the bulk workers, and the rules whose
+	// status can't be fetched are left out of the counts.
+	counters := make([]*ruleCounters, len(rules))
+	svc.bulk(RuleKind, len(rules), func(i int) (string, error) {
+		status, err := svc.engine.RuleStatus(ctx, rules[i].ID)
+		if err != nil {
+			return rules[i].ID, err
+		}
+		c := status.counters()
+		counters[i] = &c
+		return rules[i].ID, nil
+	})
+	snap := statsSnapshot{at: time.Now().UTC(), rules: make(map[string]ruleCounters)}
+	for i, c := range counters {
+		if c == nil {
+			continue
+		}
+		stats.RecordsIn += c.in
+		stats.RecordsOut += c.out
+		stats.Exceptions += c.exceptions
+		snap.rules[rules[i].ID] = *c
+	}
+
+	if prev, ok := svc.snapshots.swap(userID, snap); ok {
+		iv := StatsInterval{Since: prev.at}
+		for id, c := range snap.rules {
+			p := prev.rules[id]
+			iv.RecordsIn += delta(c.in, p.in)
+			iv.RecordsOut += delta(c.out, p.out)
+			iv.Exceptions += delta(c.exceptions, p.exceptions)
+		}
+		stats.Interval = &iv
+	}
+
+	return stats, nil
+}
+
+// counters returns the records the sources of the rule read, the records
+// its sinks sent out and the exceptions of all its operators.
+func (rs RuleStatus) counters() ruleCounters {
+	var c ruleCounters
+	for _, op := range rs.Operators {
+		switch nodeType(op.Name) {
+		case SourceNode:
+			c.in += op.RecordsIn
+		case SinkNode:
+			c.out += op.RecordsOut
+		}
+		c.exceptions += op.Exceptions
+	}
+
+	return c
+}
+
+// delta returns the increase of the counter since the previous value. The
+// counters restart from zero with the rule, in which case the whole current
+// value is the increase.
+func delta(cur, prev int64) int64 {
+	if cur < prev {
+		return cur
+	}
+
+	return cur - prev
+}
+
+// statsState returns the state the statistics count the rule with the
+// given Kuiper status in.
+func statsState(status string) string {
+	state, reason, _ := strings.Cut(status, ":")
+	switch strings.ToLower(strings.TrimSpace(state)) {
+	case StatsRunning:
+		return StatsRunning
+	case StatsStopped:
+		if reason = strings.TrimSpace(reason); reason == "" || strings.Contains(reason, manualStop) {
+			return StatsStopped
+		}
+	}
+
+	return StatsError
+}
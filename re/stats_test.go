@@ -0,0 +1,52 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEngineStats(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	// The first statistics come without the interval.
+	stats, err := svc.EngineStats(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("engine stats: expected no error got %s\n", err))
+	expected := re.EngineStats{
+		Streams:    1,
+		Rules:      1,
+		States:     map[string]int{re.StatsRunning: 1, re.StatsStopped: 0, re.StatsError: 0},
+		RecordsIn:  10,
+		Exceptions: 1,
+	}
+	assert.Equal(t, expected, stats, fmt.Sprintf("expected %v got %v\n", expected, stats))
+
+	// Kuiper reports the same counters, so nothing happened in the interval.
+	k.stopped[userPrefix+"rule"] = true
+	stats, err = svc.EngineStats(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("engine stats: expected no error got %s\n", err))
+	assert.Equal(t, 1, stats.States[re.StatsStopped], fmt.Sprintf("expected stopped rule got %v\n", stats.States))
+	if assert.NotNil(t, stats.Interval, "expected interval since the previous stats") {
+		assert.False(t, stats.Interval.Since.IsZero(), "expected interval start")
+		assert.Zero(t, stats.Interval.RecordsIn, fmt.Sprintf("expected no records in the interval got %d\n", stats.Interval.RecordsIn))
+		assert.Zero(t, stats.Interval.Exceptions, fmt.Sprintf("expected no exceptions in the interval got %d\n", stats.Interval.Exceptions))
+	}
+
+	// The previous statistics are kept for each user.
+	authCall.Unset()
+	authCall = auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: otherUserID}, nil)
+	stats, err = svc.EngineStats(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("engine stats: expected no error got %s\n", err))
+	assert.Nil(t, stats.Interval, "expected no interval for the other user's first stats")
+}
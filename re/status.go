@@ -0,0 +1,115 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kuiper reports rule metrics as a flat map with keys in the form
+// <operator>_<instance>_<metric>, e.g. source_demo_0_records_in_total.
+const (
+	recordsIn         = "records_in_total"
+	recordsOut        = "records_out_total"
+	exceptions        = "exceptions_total"
+	lastException     = "last_exception"
+	lastExceptionTime = "last_exception_time"
+	processLatency    = "process_latency_us"
+	bufferLength      = "buffer_length"
+	lastInvocation    = "last_invocation"
+)
+
+// Metric suffixes sorted so that longer suffixes sharing a common ending
+// (e.g. last_exception_time and last_exception) are matched first.
+var metricNames = []string{
+	lastExceptionTime,
+	lastException,
+	recordsIn,
+	recordsOut,
+	exceptions,
+	processLatency,
+	bufferLength,
+	lastInvocation,
+}
+
+// parseStatus converts the Kuiper rule status response to RuleStatus.
+func parseStatus(metrics map[string]interface{}) RuleStatus {
+	var rs RuleStatus
+	ops := make(map[string]*OperatorMetrics)
+	for key, val := range metrics {
+		switch key {
+		case "status":
+			rs.Status = fmt.Sprint(val)
+			continue
+		case "message":
+			rs.Message = fmt.Sprint(val)
+			continue
+		}
+		for _, metric := range metricNames {
+			if !strings.HasSuffix(key, "_"+metric) {
+				continue
+			}
+			name := strings.TrimSuffix(key, "_"+metric)
+			op, ok := ops[name]
+			if !ok {
+				op = &OperatorMetrics{Name: name}
+				ops[name] = op
+			}
+			setMetric(op, metric, val)
+			break
+		}
+	}
+
+	for _, op := range ops {
+		rs.Operators = append(rs.Operators, *op)
+	}
+	sort.Slice(rs.Operators, func(i, j int) bool {
+		return rs.Operators[i].Name < rs.Operators[j].Name
+	})
+
+	return rs
+}
+
+// withoutPrefix removes the owner prefix from the operator names.
+func (rs RuleStatus) withoutPrefix(pfx string) RuleStatus {
+	for i := range rs.Operators {
+		rs.Operators[i].Name = strings.Replace(rs.Operators[i].Name, pfx, "", 1)
+	}
+	sort.Slice(rs.Operators, func(i, j int) bool {
+		return rs.Operators[i].Name < rs.Operators[j].Name
+	})
+
+	return rs
+}
+
+func setMetric(op *OperatorMetrics, metric string, val interface{}) {
+	switch metric {
+	case recordsIn:
+		op.RecordsIn = toInt(val)
+	case recordsOut:
+		op.RecordsOut = toInt(val)
+	case exceptions:
+		op.Exceptions = toInt(val)
+	case processLatency:
+		op.ProcessLatencyUs = toInt(val)
+	case bufferLength:
+		op.BufferLength = toInt(val)
+	case lastException:
+		op.LastException = fmt.Sprint(val)
+	case lastExceptionTime:
+		op.LastExceptionTime = fmt.Sprint(val)
+	case lastInvocation:
+		op.LastInvocation = fmt.Sprint(val)
+	}
+}
+
+func toInt(val interface{}) int64 {
+	if f, ok := val.(float64); ok {
+		return int64(f)
+	}
+
+	return 0
+}
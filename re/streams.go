@@ -0,0 +1,415 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/gofrs/uuid"
+)
+
+// Stream represents Kuiper stream definition as returned by the Kuiper
+// describe stream API, along with the stored stream metadata.
+type Stream struct {
+	Name         string            `json:"Name"`
+	StreamFields []StreamField     `json:"StreamFields"`
+	Options      map[string]string `json:"Options"`
+	Metadata     *Metadata         `json:"metadata,omitempty"`
+	// IngestURL is the rules engine URL the things push the messages of
+	// the httppush stream to.
+	IngestURL string `json:"ingest_url,omitempty"`
+}
+
+// StreamDef defines the stream created in Kuiper. Topic is the data source
+// of the stream, whose meaning depends on the source Type:
+//   - mainflux and mqtt streams read messages from the channel with the
+//     Topic ID,
+//   - memory streams read from the memory topic published to by other rules,
+//   - file streams read the file with the Topic name from the Kuiper data
+//     directory,
+//   - httppush streams read the messages the things connected to the
+//     channel with the Topic ID push to the stream ingestion URL.
+//
+// Memory topics and file names are namespaced with the owner prefix. The
+// Kuiper push endpoint is shared by all users and doesn't authenticate the
+// requests, so httppush streams are only fed through the rules engine, which
+// authenticates the things by their keys.
+//
+// MQTT streams subscribe with the ConfKey of the owner, managed with
+// SaveConfKey, or with the default Kuiper MQTT source configuration if
+// ConfKey is empty. Like topics, the ConfKey is namespaced with the owner
+// prefix, so the streams can't use the credentials of other users.
+//
+// Format defines how the messages are decoded. Delimiter is used by the
+// delimited format and SchemaID identifies the protobuf message of the
+// protobuf format. Type and Format default to mainflux and json.
+//
+// SenML streams read SenML messages from the channel, so their fields are
+// generated from the SenML record and can't be set.
+//
+// Description, Labels and the free-form Attributes aren't sent to Kuiper, but
+// stored as the stream metadata.
+type StreamDef struct {
+	Name      string  `json:"name"`
+	Topic     string  `json:"topic"`
+	Fields    []Field `json:"fields"`
+	Type      string  `json:"type,omitempty"`
+	Format    string  `json:"format,omitempty"`
+	Delimiter string  `json:"delimiter,omitempty"`
+	SchemaID  string  `json:"schema_id,omitempty"`
+	SenML     bool    `json:"senml,omitempty"`
+	ConfKey   string  `json:"conf_key,omitempty"`
+
+	Description string                 `json:"description,omitempty"`
+	Labels      map[string]string      `json:"labels,omitempty"`
+	Attributes  map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// Kuiper stream source types.
+const (
+	MainfluxSource = "mainflux"
+	MQTTSource     = "mqtt"
+	MemorySource   = "memory"
+	FileSource     = "file"
+	HTTPPushSource = "httppush"
+)
+
+// Kuiper stream formats.
+const (
+	JSONFormat      = "json"
+	BinaryFormat    = "binary"
+	DelimitedFormat = "delimited"
+	ProtobufFormat  = "protobuf"
+)
+
+// Field represents a field of the stream schema. Type is one of the Kuiper
+// field types. Items is the element type of the array field, while Fields
+// contains fields of the struct field or of the array elements that are
+// structs.
+type Field struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Items  string  `json:"items,omitempty"`
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// Kuiper stream field types.
+const (
+	BigintType   = "bigint"
+	FloatType    = "float"
+	StringType   = "string"
+	DatetimeType = "datetime"
+	BooleanType  = "boolean"
+	ByteaType    = "bytea"
+	ArrayType    = "array"
+	StructType   = "struct"
+)
+
+// StreamField represents a single field of the stream schema.
+type StreamField struct {
+	Name      string      `json:"Name"`
+	FieldType interface{} `json:"FieldType"`
+}
+
+const maxNameSize = 100
+
+var (
+	errMalformedName   = errors.New("name must start with a letter or underscore and contain only letters, digits and underscores")
+	errMalformedTopic  = errors.New("topic must be a channel ID")
+	errMissingFields   = errors.New("missing schema fields")
+	errDuplicateField  = errors.New("duplicate field")
+	errMalformedArray  = errors.New("array field must define type of its items")
+	errUnsupportedType = errors.New("unsupported field type")
+	errSourceType      = errors.New("unsupported stream type")
+	errFormat          = errors.New("unsupported stream format")
+	errMalformedSource = errors.New("malformed stream data source")
+	errBinarySchema    = errors.New("binary stream must have a single bytea field")
+	errDelimiter       = errors.New("delimiter must be a single printable character other than quotes and backslash")
+	errSchemaID        = errors.New("protobuf stream must have schema ID in the form schema.message")
+	errSenMLSource     = errors.New("senml stream must read from the channel")
+	errSenMLFormat     = errors.New("senml stream must use json format")
+	errSenMLFields     = errors.New("senml stream fields are generated and can't be set")
+
+	nameRegexp     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	pathRegexp     = regexp.MustCompile(`^[A-Za-z0-9_-]+(/[A-Za-z0-9_-]+)*$`)
+	fileRegexp     = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9_.-]*$`)
+	schemaIDRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\.[A-Za-z_][A-Za-z0-9_.]*$`)
+
+	// sourceTypes contains the allowed stream source types.
+	sourceTypes = map[string]bool{
+		MainfluxSource: true,
+		MQTTSource:     true,
+		MemorySource:   true,
+		FileSource:     true,
+		HTTPPushSource: true,
+	}
+
+	// formats contains the allowed stream formats.
+	formats = map[string]bool{
+		JSONFormat:      true,
+		BinaryFormat:    true,
+		DelimitedFormat: true,
+		ProtobufFormat:  true,
+	}
+
+	// senmlFields contains fields of the SenML record (RFC 8428).
+	senmlFields = []Field{
+		{Name: "bn", Type: StringType},
+		{Name: "bt", Type: FloatType},
+		{Name: "bu", Type: StringType},
+		{Name: "bv", Type: FloatType},
+		{Name: "bs", Type: FloatType},
+		{Name: "bver", Type: BigintType},
+		{Name: "n", Type: StringType},
+		{Name: "u", Type: StringType},
+		{Name: "v", Type: FloatType},
+		{Name: "vs", Type: StringType},
+		{Name: "vb", Type: BooleanType},
+		{Name: "vd", Type: StringType},
+		{Name: "s", Type: FloatType},
+		{Name: "t", Type: FloatType},
+		{Name: "ut", Type: FloatType},
+	}
+
+	// fieldTypes contains the primitive stream field types supported by Kuiper.
+	fieldTypes = map[string]bool{
+		BigintType:   true,
+		FloatType:    true,
+		StringType:   true,
+		DatetimeType: true,
+		BooleanType:  true,
+		ByteaType:    true,
+	}
+)
+
+// validateName checks that the stream, rule or field name is a plain
+// identifier, so it can be safely used in the stream DDL and in the Kuiper
+// API paths.
+func validateName(name string) error {
+	if len(name) > maxNameSize || !nameRegexp.MatchString(name) {
+		return errMalformedName
+	}
+
+	return nil
+}
+
+// validateTopic checks that the topic is the channel ID.
+func validateTopic(topic string) error {
+	id, err := uuid.FromString(topic)
+	if err != nil || id.String() != topic {
+		return errMalformedTopic
+	}
+
+	return nil
+}
+
+// channelSource reports whether the stream reads messages from the channel.
+func (def StreamDef) channelSource() bool {
+	return def.Type == MainfluxSource || def.Type == MQTTSource
+}
+
+// channelBound reports whether the stream is bound to the channel, either
+// reading its messages or ingesting the messages of its things.
+func (def StreamDef) channelBound() bool {
+	return def.channelSource() || def.Type == HTTPPushSource
+}
+
+// withDefaults returns the definition with the default type and format set
+// and both of them in lower case.
+func (def StreamDef) withDefaults() StreamDef {
+	def.Type = strings.ToLower(def.Type)
+	def.Format = strings.ToLower(def.Format)
+	if def.Type == "" {
+		def.Type = MainfluxSource
+	}
+	if def.Format == "" {
+		def.Format = JSONFormat
+	}
+
+	return def
+}
+
+// streamDefinition returns the JSON definition of the stream as created by
+// the owner, stored to restore and export the stream. Description, labels
+// and attributes are left out, since they are stored as the stream metadata.
+func streamDefinition(def StreamDef) (string, error) {
+	def.Description, def.Labels, def.Attributes = "", nil, nil
+	data, err := json.Marshal(def)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// ddl validates the stream definition and renders the Kuiper DDL creating
+// the stream with the given Kuiper name. Data sources other than channels
+// are namespaced with the owner prefix.
+func (def StreamDef) ddl(kuiperName, pfx string) (string, error) {
+	if err := validateName(def.Name); err != nil {
+		return "", err
+	}
+	if !sourceTypes[def.Type] {
+		return "", errors.Wrap(errSourceType, errors.New(def.Type))
+	}
+	if !formats[def.Format] {
+		return "", errors.Wrap(errFormat, errors.New(def.Format))
+	}
+	source, err := def.source(pfx)
+	if err != nil {
+		return "", err
+	}
+	fields := def.Fields
+	if def.SenML {
+		if err := def.validateSenML(); err != nil {
+			return "", err
+		}
+		fields = senmlFields
+	}
+	row, err := schema(fields)
+	if err != nil {
+		return "", err
+	}
+
+	opts := []string{
+		fmt.Sprintf("DATASOURCE = \"%s\"", source),
+		fmt.Sprintf("FORMAT = \"%s\"", strings.ToUpper(def.Format)),
+		fmt.Sprintf("TYPE = \"%s\"", def.Type),
+	}
+	switch def.Format {
+	case BinaryFormat:
+		if len(def.Fields) != 1 || !strings.EqualFold(def.Fields[0].Type, ByteaType) {
+			return "", errBinarySchema
+		}
+	case DelimitedFormat:
+		if def.Delimiter != "" {
+			if len(def.Delimiter) != 1 || strings.ContainsAny(def.Delimiter, "\"'`\\") || def.Delimiter[0] < ' ' || def.Delimiter[0] > '~' {
+				return "", errDelimiter
+			}
+			opts = append(opts, fmt.Sprintf("DELIMITER = \"%s\"", def.Delimiter))
+		}
+	case ProtobufFormat:
+		if !schemaIDRegexp.MatchString(def.SchemaID) {
+			return "", errSchemaID
+		}
+		opts = append(opts, fmt.Sprintf("SCHEMAID = \"%s\"", def.SchemaID))
+	}
+	if def.ConfKey != "" {
+		if def.Type != MQTTSource {
+			return "", errConfKeySource
+		}
+		if err := validateName(def.ConfKey); err != nil {
+			return "", err
+		}
+		opts = append(opts, fmt.Sprintf("CONF_KEY = \"%s\"", pfx+def.ConfKey))
+	}
+
+	return fmt.Sprintf("create stream %s (%s) WITH (%s)", kuiperName, row, strings.Join(opts, ", ")), nil
+}
+
+// validateSenML checks that the SenML stream reads JSON messages from the
+// channel and doesn't define its own fields.
+func (def StreamDef) validateSenML() error {
+	switch {
+	case !def.channelSource():
+		return errSenMLSource
+	case def.Format != JSONFormat:
+		return errSenMLFormat
+	case len(def.Fields) > 0:
+		return errSenMLFields
+	default:
+		return nil
+	}
+}
+
+// source validates the topic and returns the Kuiper data source of the stream.
+func (def StreamDef) source(pfx string) (string, error) {
+	switch def.Type {
+	case MainfluxSource:
+		if err := validateTopic(def.Topic); err != nil {
+			return "", err
+		}
+		return def.Topic, nil
+	case MQTTSource:
+		if err := validateTopic(def.Topic); err != nil {
+			return "", err
+		}
+		return "channels/" + def.Topic + "/messages", nil
+	case MemorySource:
+		if !pathRegexp.MatchString(def.Topic) {
+			return "", errMalformedSource
+		}
+		return pfx + def.Topic, nil
+	case HTTPPushSource:
+		// Each stream has its own push endpoint, so the messages pushed to
+		// the stream aren't read by the other streams of the channel.
+		if err := validateTopic(def.Topic); err != nil {
+			return "", err
+		}
+		return pushPath(pfx + def.Name), nil
+	default:
+		if !fileRegexp.MatchString(def.Topic) {
+			return "", errMalformedSource
+		}
+		return pfx + def.Topic, nil
+	}
+}
+
+// schema validates the fields and renders the schema of the stream DDL, e.g.
+// "v FLOAT, loc STRUCT(lat FLOAT, lon FLOAT)".
+func schema(fields []Field) (string, error) {
+	if len(fields) == 0 {
+		return "", errMissingFields
+	}
+	defs := make([]string, len(fields))
+	names := make(map[string]bool, len(fields))
+	for i, f := range fields {
+		if err := validateName(f.Name); err != nil {
+			return "", errors.Wrap(fmt.Errorf("field %q", f.Name), err)
+		}
+		if names[strings.ToLower(f.Name)] {
+			return "", errors.Wrap(fmt.Errorf("field %q", f.Name), errDuplicateField)
+		}
+		names[strings.ToLower(f.Name)] = true
+		typ, err := fieldType(f.Type, f.Items, f.Fields)
+		if err != nil {
+			return "", errors.Wrap(fmt.Errorf("field %q", f.Name), err)
+		}
+		defs[i] = f.Name + " " + typ
+	}
+
+	return strings.Join(defs, ", "), nil
+}
+
+// fieldType renders the field type. Element type of arrays is given by
+// items and fields of structs, or of array elements that are structs, by
+// fields.
+func fieldType(typ, items string, fields []Field) (string, error) {
+	switch typ = strings.ToLower(typ); typ {
+	case ArrayType:
+		if items == "" || items == ArrayType {
+			return "", errMalformedArray
+		}
+		elem, err := fieldType(items, "", fields)
+		if err != nil {
+			return "", err
+		}
+		return "ARRAY(" + elem + ")", nil
+	case StructType:
+		inner, err := schema(fields)
+		if err != nil {
+			return "", err
+		}
+		return "STRUCT(" + inner + ")", nil
+	default:
+		if !fieldTypes[typ] {
+			return "", errors.Wrap(errUnsupportedType, errors.New(typ))
+		}
+		return strings.ToUpper(typ), nil
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	streamPfx     = "u1234_"
+	streamChannel = "b1c2d3e4-f5a6-4b7c-8d9e-0f1a2b3c4d5e"
+)
+
+func TestWithDefaults(t *testing.T) {
+	cases := []struct {
+		desc string
+		def  StreamDef
+		res  StreamDef
+	}{
+		{
+			desc: "set default type and format",
+			def:  StreamDef{Name: "s"},
+			res:  StreamDef{Name: "s", Type: MainfluxSource, Format: JSONFormat},
+		},
+		{
+			desc: "lower case type and format",
+			def:  StreamDef{Name: "s", Type: "MQTT", Format: "Delimited"},
+			res:  StreamDef{Name: "s", Type: MQTTSource, Format: DelimitedFormat},
+		},
+	}
+
+	for _, tc := range cases {
+		res := tc.def.withDefaults()
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+	}
+}
+
+func TestSource(t *testing.T) {
+	cases := []struct {
+		desc   string
+		typ    string
+		topic  string
+		source string
+		err    error
+	}{
+		{
+			desc:   "mainflux source reads channel",
+			typ:    MainfluxSource,
+			topic:  streamChannel,
+			source: streamChannel,
+		},
+		{
+			desc:   "mqtt source subscribes to channel messages",
+			typ:    MQTTSource,
+			topic:  streamChannel,
+			source: "channels/" + streamChannel + "/messages",
+		},
+		{
+			desc:  "mqtt source with topic that is not a channel ID",
+			typ:   MQTTSource,
+			topic: "channels/#",
+			err:   errMalformedTopic,
+		},
+		{
+			desc:   "memory source is prefixed",
+			typ:    MemorySource,
+			topic:  "alarms/high",
+			source: streamPfx + "alarms/high",
+		},
+		{
+			desc:  "memory source with wildcard",
+			typ:   MemorySource,
+			topic: "alarms/#",
+			err:   errMalformedSource,
+		},
+		{
+			desc:  "memory source escaping namespace",
+			typ:   MemorySource,
+			topic: "../alarms",
+			err:   errMalformedSource,
+		},
+		{
+			desc:   "file source is prefixed",
+			typ:    FileSource,
+			topic:  "readings.json",
+			source: streamPfx + "readings.json",
+		},
+		{
+			desc:  "file source with path",
+			typ:   FileSource,
+			topic: "../../etc/passwd",
+			err:   errMalformedSource,
+		},
+		{
+			desc:  "file source with hidden file",
+			typ:   FileSource,
+			topic: ".env",
+			err:   errMalformedSource,
+		},
+		{
+			desc:   "httppush source is stream push endpoint",
+			typ:    HTTPPushSource,
+			topic:  streamChannel,
+			source: "/" + streamPfx + "s",
+		},
+		{
+			desc:  "httppush source with topic that is not a channel ID",
+			typ:   HTTPPushSource,
+			topic: "data",
+			err:   errMalformedTopic,
+		},
+	}
+
+	for _, tc := range cases {
+		source, err := StreamDef{Name: "s", Type: tc.typ, Topic: tc.topic}.source(streamPfx)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.source, source, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.source, source))
+	}
+}
+
+func TestDDL(t *testing.T) {
+	value := []Field{{Name: "v", Type: FloatType}}
+	image := []Field{{Name: "image", Type: ByteaType}}
+
+	cases := []struct {
+		desc string
+		def  StreamDef
+		ddl  string
+		err  error
+	}{
+		{
+			desc: "json mainflux stream",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value},
+			ddl:  `create stream u1234_s (v FLOAT) WITH (DATASOURCE = "` + streamChannel + `", FORMAT = "JSON", TYPE = "mainflux")`,
+		},
+		{
+			desc: "json mqtt stream",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Type: MQTTSource},
+			ddl:  `create stream u1234_s (v FLOAT) WITH (DATASOURCE = "channels/` + streamChannel + `/messages", FORMAT = "JSON", TYPE = "mqtt")`,
+		},
+		{
+			desc: "json memory stream",
+			def:  StreamDef{Name: "s", Topic: "alarms", Fields: value, Type: MemorySource},
+			ddl:  `create stream u1234_s (v FLOAT) WITH (DATASOURCE = "u1234_alarms", FORMAT = "JSON", TYPE = "memory")`,
+		},
+		{
+			desc: "json file stream",
+			def:  StreamDef{Name: "s", Topic: "readings.json", Fields: value, Type: FileSource},
+			ddl:  `create stream u1234_s (v FLOAT) WITH (DATASOURCE = "u1234_readings.json", FORMAT = "JSON", TYPE = "file")`,
+		},
+		{
+			desc: "json httppush stream",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Type: HTTPPushSource},
+			ddl:  `create stream u1234_s (v FLOAT) WITH (DATASOURCE = "/u1234_s", FORMAT = "JSON", TYPE = "httppush")`,
+		},
+		{
+			desc: "senml httppush stream",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Type: HTTPPushSource, SenML: true},
+			err:  errSenMLSource,
+		},
+		{
+			desc: "binary stream",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: image, Format: BinaryFormat},
+			ddl:  `create stream u1234_s (image BYTEA) WITH (DATASOURCE = "` + streamChannel + `", FORMAT = "BINARY", TYPE = "mainflux")`,
+		},
+		{
+			desc: "binary stream with non-bytea field",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Format: BinaryFormat},
+			err:  errBinarySchema,
+		},
+		{
+			desc: "binary stream with multiple fields",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: append(image, value...), Format: BinaryFormat},
+			err:  errBinarySchema,
+		},
+		{
+			desc: "delimited stream with default delimiter",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Format: DelimitedFormat},
+			ddl:  `create stream u1234_s (v FLOAT) WITH (DATASOURCE = "` + streamChannel + `", FORMAT = "DELIMITED", TYPE = "mainflux")`,
+		},
+		{
+			desc: "delimited stream with delimiter",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Format: DelimitedFormat, Delimiter: ";"},
+			ddl:  `create stream u1234_s (v FLOAT) WITH (DATASOURCE = "` + streamChannel + `", FORMAT = "DELIMITED", TYPE = "mainflux", DELIMITER = ";")`,
+		},
+		{
+			desc: "delimited stream with quote delimiter",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Format: DelimitedFormat, Delimiter: `"`},
+			err:  errDelimiter,
+		},
+		{
+			desc: "delimited stream with multi-character delimiter",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Format: DelimitedFormat, Delimiter: ";;"},
+			err:  errDelimiter,
+		},
+		{
+			desc: "protobuf stream",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Format: ProtobufFormat, SchemaID: "readings.Reading"},
+			ddl:  `create stream u1234_s (v FLOAT) WITH (DATASOURCE = "` + streamChannel + `", FORMAT = "PROTOBUF", TYPE = "mainflux", SCHEMAID = "readings.Reading")`,
+		},
+		{
+			desc: "protobuf stream without schema ID",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Format: ProtobufFormat},
+			err:  errSchemaID,
+		},
+		{
+			desc: "protobuf stream with malformed schema ID",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Format: ProtobufFormat, SchemaID: `readings.Reading", TYPE = "file`},
+			err:  errSchemaID,
+		},
+		{
+			desc: "senml stream",
+			def:  StreamDef{Name: "s", Topic: streamChannel, SenML: true},
+			ddl: `create stream u1234_s (bn STRING, bt FLOAT, bu STRING, bv FLOAT, bs FLOAT, bver BIGINT, n STRING, u STRING, v FLOAT, vs STRING, ` +
+				`vb BOOLEAN, vd STRING, s FLOAT, t FLOAT, ut FLOAT) WITH (DATASOURCE = "` + streamChannel + `", FORMAT = "JSON", TYPE = "mainflux")`,
+		},
+		{
+			desc: "senml mqtt stream",
+			def:  StreamDef{Name: "s", Topic: streamChannel, SenML: true, Type: MQTTSource},
+			ddl: `create stream u1234_s (bn STRING, bt FLOAT, bu STRING, bv FLOAT, bs FLOAT, bver BIGINT, n STRING, u STRING, v FLOAT, vs STRING, ` +
+				`vb BOOLEAN, vd STRING, s FLOAT, t FLOAT, ut FLOAT) WITH (DATASOURCE = "channels/` + streamChannel + `/messages", FORMAT = "JSON", TYPE = "mqtt")`,
+		},
+		{
+			desc: "mqtt stream with conf key",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Type: MQTTSource, ConfKey: "broker"},
+			ddl:  `create stream u1234_s (v FLOAT) WITH (DATASOURCE = "channels/` + streamChannel + `/messages", FORMAT = "JSON", TYPE = "mqtt", CONF_KEY = "u1234_broker")`,
+		},
+		{
+			desc: "mqtt stream with malformed conf key",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Type: MQTTSource, ConfKey: `broker", TYPE = "file`},
+			err:  errMalformedName,
+		},
+		{
+			desc: "mainflux stream with conf key",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, ConfKey: "broker"},
+			err:  errConfKeySource,
+		},
+		{
+			desc: "senml stream with fields",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, SenML: true},
+			err:  errSenMLFields,
+		},
+		{
+			desc: "senml stream with binary format",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Format: BinaryFormat, SenML: true},
+			err:  errSenMLFormat,
+		},
+		{
+			desc: "senml memory stream",
+			def:  StreamDef{Name: "s", Topic: "alarms", Type: MemorySource, SenML: true},
+			err:  errSenMLSource,
+		},
+		{
+			desc: "stream with unsupported type",
+			def:  StreamDef{Name: "s", Topic: "data", Fields: value, Type: "redis"},
+			err:  errSourceType,
+		},
+		{
+			desc: "stream with unsupported format",
+			def:  StreamDef{Name: "s", Topic: streamChannel, Fields: value, Format: "avro"},
+			err:  errFormat,
+		},
+	}
+
+	for _, tc := range cases {
+		ddl, err := tc.def.withDefaults().ddl(streamPfx+tc.def.Name, streamPfx)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.ddl, ddl, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.ddl, ddl))
+	}
+}
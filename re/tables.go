@@ -0,0 +1,279 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Table represents Kuiper table definition as returned by the Kuiper
+// describe table API, along with the stored table metadata.
+type Table struct {
+	Name         string            `json:"Name"`
+	StreamFields []StreamField     `json:"StreamFields"`
+	Options      map[string]string `json:"Options"`
+	Metadata     *Metadata         `json:"metadata,omitempty"`
+}
+
+// TableDef defines the table created in Kuiper. Tables hold the reference
+// data rules join the streams with. Topic is the data source of the table,
+// whose meaning depends on the source Type:
+//   - file tables read the JSON file with the Topic name from the Kuiper
+//     data directory,
+//   - memory tables read from the memory topic published to by other rules.
+//
+// Like with streams, memory topics and file names are namespaced with the
+// owner prefix. Type defaults to file.
+//
+// Kind is either scan, whose tables keep the rows read from the source in
+// memory, or lookup, whose tables look up the rows by the Key field in the
+// source on demand. Kind defaults to scan and only memory tables can be
+// lookup tables.
+//
+// Description and Labels aren't sent to Kuiper, but stored as the table
+// metadata.
+type TableDef struct {
+	Name   string  `json:"name"`
+	Topic  string  `json:"topic"`
+	Fields []Field `json:"fields"`
+	Type   string  `json:"type,omitempty"`
+	Kind   string  `json:"kind,omitempty"`
+	Key    string  `json:"key,omitempty"`
+
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// Kuiper table kinds.
+const (
+	ScanTable   = "scan"
+	LookupTable = "lookup"
+)
+
+var (
+	errTableType   = errors.New("unsupported table type")
+	errTableKind   = errors.New("unsupported table kind")
+	errLookupTable = errors.New("lookup table must read from memory topic")
+	errTableKey    = errors.New("lookup table key must be one of its fields")
+)
+
+func (svc *reService) CreateTable(ctx context.Context, token string, def TableDef) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	owner, name, err := svc.namespace(ctx, token, userID, def.Name)
+	if err != nil {
+		return Result{}, err
+	}
+	def.Name = name
+
+	def = def.withDefaults()
+	pfx := prefix(owner)
+	kuiperName := pfx + def.Name
+	sql, err := def.ddl(kuiperName, pfx)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	definition, err := tableDefinition(def)
+	if err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if res, ok, err := svc.existing(ctx, TableKind, owner, def.Name, definition); err != nil || ok {
+		return res, err
+	}
+
+	res, err := svc.engine.CreateStream(ctx, TableKind, sql)
+	if err != nil {
+		return Result{}, err
+	}
+	md := Metadata{Owner: owner, Description: def.Description, Labels: def.Labels, Definition: definition}
+	if err := svc.saveMetadata(ctx, TableKind, def.Name, md, false); err != nil {
+		_, _ = svc.engine.DeleteStream(ctx, TableKind, kuiperName)
+		return Result{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, TableKind, def.Name, AuditCreate, "", definition); err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(def.Name, owner), nil
+}
+
+func (svc *reService) ListTables(ctx context.Context, token string, pm PageMetadata) (TablesPage, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return TablesPage{}, err
+	}
+	owner, err := svc.listOwner(ctx, token, userID, pm)
+	if err != nil {
+		return TablesPage{}, err
+	}
+
+	pfx := prefix(owner)
+	names, err := svc.ownedNames(ctx, TableKind, pfx)
+	if err != nil {
+		return TablesPage{}, err
+	}
+	mds, err := svc.repo.RetrieveAll(ctx, TableKind, owner)
+	if err != nil {
+		return TablesPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	tables := []string{}
+	for _, name := range names {
+		if pm.match(name, mds[pfx+name]) {
+			tables = append(tables, name)
+		}
+	}
+
+	page := pageTables(tables, func(name string) Metadata { return mds[pfx+name] }, pm)
+	for _, name := range page.Tables {
+		if md, ok := mds[pfx+name]; ok {
+			if page.Metadata == nil {
+				page.Metadata = make(map[string]Metadata)
+			}
+			page.Metadata[name] = md
+		}
+	}
+
+	return page, nil
+}
+
+func (svc *reService) ViewTable(ctx context.Context, token, name string) (Table, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Table{}, err
+	}
+	owner, name, err := svc.resolve(ctx, token, userID, TableKind, name, ViewAccess)
+	if err != nil {
+		return Table{}, err
+	}
+
+	pfx := prefix(owner)
+	stream, err := svc.engine.ViewStream(ctx, TableKind, pfx+name)
+	if err != nil {
+		return Table{}, err
+	}
+	table := Table{Name: stream.Name, StreamFields: stream.StreamFields, Options: stream.Options}
+	if table.Metadata, err = svc.ownedMetadata(ctx, TableKind, owner, pfx+name, table.Name); err != nil {
+		return Table{}, err
+	}
+	table.Name = strings.TrimPrefix(table.Name, pfx)
+
+	return table, nil
+}
+
+func (svc *reService) DeleteTable(ctx context.Context, token, name string) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	owner, name, err := svc.resolve(ctx, token, userID, TableKind, name, ManageAccess)
+	if err != nil {
+		return Result{}, err
+	}
+
+	kuiperName := prefix(owner) + name
+	md, err := svc.ownedMetadata(ctx, TableKind, owner, kuiperName, kuiperName)
+	if err != nil {
+		return Result{}, err
+	}
+	res, err := svc.engine.DeleteStream(ctx, TableKind, kuiperName)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := svc.removeMetadata(ctx, TableKind, kuiperName); err != nil {
+		return Result{}, err
+	}
+	if err := svc.audit(ctx, userID, owner, TableKind, name, AuditDelete, md.definition(), ""); err != nil {
+		return Result{}, err
+	}
+
+	return res.owned(name, owner), nil
+}
+
+// withDefaults returns the definition with the default type and kind set
+// and both of them in lower case.
+func (def TableDef) withDefaults() TableDef {
+	def.Type = strings.ToLower(def.Type)
+	def.Kind = strings.ToLower(def.Kind)
+	if def.Type == "" {
+		def.Type = FileSource
+	}
+	if def.Kind == "" {
+		def.Kind = ScanTable
+	}
+
+	return def
+}
+
+// tableDefinition returns the JSON definition of the table as created by
+// the owner. Description and labels are left out, since they are stored as
+// the table metadata.
+func tableDefinition(def TableDef) (string, error) {
+	def.Description, def.Labels = "", nil
+	data, err := json.Marshal(def)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// ddl validates the table definition and renders the Kuiper DDL creating
+// the table with the given Kuiper name.
+func (def TableDef) ddl(kuiperName, pfx string) (string, error) {
+	if err := validateName(def.Name); err != nil {
+		return "", err
+	}
+	if def.Type != FileSource && def.Type != MemorySource {
+		return "", errors.Wrap(errTableType, errors.New(def.Type))
+	}
+	if def.Kind != ScanTable && def.Kind != LookupTable {
+		return "", errors.Wrap(errTableKind, errors.New(def.Kind))
+	}
+	source, err := StreamDef{Type: def.Type, Topic: def.Topic}.source(pfx)
+	if err != nil {
+		return "", err
+	}
+	row, err := schema(def.Fields)
+	if err != nil {
+		return "", err
+	}
+
+	opts := []string{
+		fmt.Sprintf("DATASOURCE = \"%s\"", source),
+		fmt.Sprintf("FORMAT = \"%s\"", strings.ToUpper(JSONFormat)),
+		fmt.Sprintf("TYPE = \"%s\"", def.Type),
+		fmt.Sprintf("KIND = \"%s\"", def.Kind),
+	}
+	if def.Kind == LookupTable {
+		if def.Type != MemorySource {
+			return "", errLookupTable
+		}
+		if !def.hasField(def.Key) {
+			return "", errTableKey
+		}
+		opts = append(opts, fmt.Sprintf("KEY = \"%s\"", def.Key))
+	}
+
+	return fmt.Sprintf("create table %s (%s) WITH (%s)", kuiperName, row, strings.Join(opts, ", ")), nil
+}
+
+// hasField reports whether the table has the top level field with the
+// given name.
+func (def TableDef) hasField(name string) bool {
+	for _, f := range def.Fields {
+		if f.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
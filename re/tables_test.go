@@ -0,0 +1,296 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTable(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	fields := []re.Field{{Name: "id", Type: re.StringType}, {Name: "location", Type: re.StringType}}
+	cases := []struct {
+		desc  string
+		token string
+		def   re.TableDef
+		ddl   string
+		err   error
+	}{
+		{
+			desc:  "create file table",
+			token: validToken,
+			def:   re.TableDef{Name: "devices", Topic: "devices.json", Fields: fields, Description: "device locations"},
+			ddl:   `create table ` + userPrefix + `devices (id STRING, location STRING) WITH (DATASOURCE = "` + userPrefix + `devices.json", FORMAT = "JSON", TYPE = "file", KIND = "scan")`,
+		},
+		{
+			desc:  "create lookup memory table",
+			token: validToken,
+			def:   re.TableDef{Name: "limits", Topic: "limits", Fields: fields, Type: "Memory", Kind: re.LookupTable, Key: "id"},
+			ddl:   `create table ` + userPrefix + `limits (id STRING, location STRING) WITH (DATASOURCE = "` + userPrefix + `limits", FORMAT = "JSON", TYPE = "memory", KIND = "lookup", KEY = "id")`,
+		},
+		{
+			desc:  "create existing table",
+			token: validToken,
+			def:   re.TableDef{Name: "devices", Topic: "devices.json", Fields: fields, Description: "device locations"},
+			ddl:   `create table ` + userPrefix + `devices (id STRING, location STRING) WITH (DATASOURCE = "` + userPrefix + `devices.json", FORMAT = "JSON", TYPE = "file", KIND = "scan")`,
+		},
+		{
+			desc:  "create existing table with another definition",
+			token: validToken,
+			def:   re.TableDef{Name: "devices", Topic: "locations.json", Fields: fields},
+			err:   svcerr.ErrConflict,
+		},
+		{
+			desc:  "create table with invalid token",
+			token: invalidToken,
+			def:   re.TableDef{Name: "other", Topic: "devices.json", Fields: fields},
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "create table with malformed name",
+			token: validToken,
+			def:   re.TableDef{Name: "1devices", Topic: "devices.json", Fields: fields},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "create table of unsupported type",
+			token: validToken,
+			def:   re.TableDef{Name: "other", Topic: channelID, Fields: fields, Type: re.MQTTSource},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "create table of unsupported kind",
+			token: validToken,
+			def:   re.TableDef{Name: "other", Topic: "devices.json", Fields: fields, Kind: "stream"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "create lookup file table",
+			token: validToken,
+			def:   re.TableDef{Name: "other", Topic: "devices.json", Fields: fields, Kind: re.LookupTable, Key: "id"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "create lookup table with unknown key",
+			token: validToken,
+			def:   re.TableDef{Name: "other", Topic: "limits", Fields: fields, Type: re.MemorySource, Kind: re.LookupTable, Key: "serial"},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "create file table escaping namespace",
+			token: validToken,
+			def:   re.TableDef{Name: "other", Topic: "../devices.json", Fields: fields},
+			err:   svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := svc.CreateTable(context.Background(), tc.token, tc.def)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, tc.def.Name, res.Name, fmt.Sprintf("%s: expected result name %s got %s\n", tc.desc, tc.def.Name, res.Name))
+		ddl := k.tables[userPrefix+tc.def.Name]
+		assert.Equal(t, tc.ddl, ddl, fmt.Sprintf("%s: expected DDL %s got %s\n", tc.desc, tc.ddl, ddl))
+		md, err := repo.Retrieve(context.Background(), re.TableKind, userPrefix+tc.def.Name)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error retrieving metadata: %s\n", tc.desc, err))
+		assert.Equal(t, userID, md.Owner, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, userID, md.Owner))
+		assert.Equal(t, tc.def.Description, md.Description, fmt.Sprintf("%s: expected description %s got %s\n", tc.desc, tc.def.Description, md.Description))
+	}
+	_, ok := k.tables[userPrefix+"other"]
+	assert.False(t, ok, "expected invalid tables not to be created")
+}
+
+func TestListTables(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.tables[userPrefix+"devices"] = ""
+	k.tables[userPrefix+"limits"] = ""
+	k.tables[otherPrefix+"devices"] = ""
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    re.PageMetadata
+		page  re.TablesPage
+		err   error
+	}{
+		{
+			desc:  "list tables",
+			token: validToken,
+			pm:    re.PageMetadata{Limit: 10},
+			page:  re.TablesPage{Total: 2, Limit: 10, Tables: []string{"devices", "limits"}},
+		},
+		{
+			desc:  "list tables by name",
+			token: validToken,
+			pm:    re.PageMetadata{Limit: 10, Name: "lim"},
+			page:  re.TablesPage{Total: 1, Limit: 10, Tables: []string{"limits"}},
+		},
+		{
+			desc:  "list tables with offset",
+			token: validToken,
+			pm:    re.PageMetadata{Offset: 1, Limit: 10},
+			page:  re.TablesPage{Total: 2, Offset: 1, Limit: 10, Tables: []string{"limits"}},
+		},
+		{
+			desc:  "list tables with invalid token",
+			token: invalidToken,
+			pm:    re.PageMetadata{Limit: 10},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListTables(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.page, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.page, page))
+	}
+}
+
+func TestViewTable(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+	k.tables[userPrefix+"devices"] = ""
+	k.tables[userPrefix+"foreign"] = ""
+	k.tables[otherPrefix+"devices"] = ""
+	err := repo.Save(context.Background(), re.TableKind, userPrefix+"foreign", re.Metadata{Owner: "other"})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error saving metadata: %s", err))
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc  string
+		name  string
+		table re.Table
+		err   error
+	}{
+		{
+			desc:  "view table",
+			name:  "devices",
+			table: re.Table{Name: "devices"},
+		},
+		{
+			desc: "view non-existing table",
+			name: "unknown",
+			err:  svcerr.ErrNotFound,
+		},
+		{
+			desc: "view table owned by other user",
+			name: "foreign",
+			err:  svcerr.ErrNotFound,
+		},
+		{
+			desc: "view table of other user with path traversal",
+			name: "x/../../tables/" + otherPrefix + "devices",
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		table, err := svc.ViewTable(context.Background(), validToken, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.table, table, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.table, table))
+	}
+}
+
+func TestDeleteTable(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, k, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+	k.tables[userPrefix+"devices"] = ""
+	k.tables[userPrefix+"foreign"] = ""
+	k.tables[otherPrefix+"devices"] = ""
+	err := repo.Save(context.Background(), re.TableKind, userPrefix+"devices", re.Metadata{Owner: userID})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error saving metadata: %s", err))
+	err = repo.Save(context.Background(), re.TableKind, userPrefix+"foreign", re.Metadata{Owner: "other"})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error saving metadata: %s", err))
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc string
+		name string
+		err  error
+	}{
+		{
+			desc: "delete table",
+			name: "devices",
+		},
+		{
+			desc: "delete non-existing table",
+			name: "devices",
+			err:  svcerr.ErrNotFound,
+		},
+		{
+			desc: "delete table owned by other user",
+			name: "foreign",
+			err:  svcerr.ErrNotFound,
+		},
+		{
+			desc: "delete table of other user with path traversal",
+			name: "x/../../tables/" + otherPrefix + "devices",
+			err:  svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := svc.DeleteTable(context.Background(), validToken, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.name, res.Name, fmt.Sprintf("%s: expected result name %s got %s\n", tc.desc, tc.name, res.Name))
+			_, ok := k.tables[userPrefix+tc.name]
+			assert.False(t, ok, fmt.Sprintf("%s: expected table to be removed\n", tc.desc))
+			_, err := repo.Retrieve(context.Background(), re.TableKind, userPrefix+tc.name)
+			assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("%s: expected metadata to be removed got %s\n", tc.desc, err))
+		}
+	}
+	_, ok := k.tables[otherPrefix+"devices"]
+	assert.True(t, ok, "expected table of other user to be kept")
+	_, ok = k.tables[userPrefix+"foreign"]
+	assert.True(t, ok, "expected table owned by other user to be kept")
+}
+
+func TestValidateRuleJoiningTable(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	k.tables[userPrefix+"devices"] = ""
+	k.tables[otherPrefix+"limits"] = ""
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	rule := re.Rule{
+		ID:      "located",
+		SQL:     "SELECT * FROM stream INNER JOIN devices ON stream.id = devices.id",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+	}
+	res, err := svc.ValidateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, res.Valid, fmt.Sprintf("expected rule joining owned table to be valid got %v", res.Diagnostics))
+
+	rule.SQL = "SELECT * FROM stream INNER JOIN limits ON stream.id = limits.id"
+	res, err = svc.ValidateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, res.Valid, "expected rule joining table of other user to be invalid")
+}
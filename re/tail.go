@@ -0,0 +1,284 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/gofrs/uuid"
+)
+
+// tailPath is the path of the rules engine API the tail sinks send the
+// rule results to, followed by the tail session ID.
+const tailPath = "/tail/"
+
+var (
+	errTailDisabled = errors.New("rule tails are disabled")
+	errTailSession  = errors.New("tail session doesn't exist")
+)
+
+// TailConfig defines the rule tails. URL is the rules engine HTTP API URL
+// as reached from Kuiper, which Kuiper sends the rule results to, and
+// Buffer is the number of results buffered for each tail. Origins are the
+// origins of the web pages, other than the rules engine's own, allowed to
+// open the tail WebSockets. Tails are disabled if the URL is empty.
+type TailConfig struct {
+	URL     string   `env:"URL"     envDefault:""`
+	Buffer  int      `env:"BUFFER"  envDefault:"100"`
+	Origins []string `env:"ORIGINS" envSeparator:","`
+}
+
+// tails contains the channels of the active tail sessions and the sessions
+// of the tailed rules.
+type tails struct {
+	mu       sync.Mutex
+	sessions map[string]chan map[string]interface{}
+	rules    map[string]map[string]bool
+	// locks serialize the changes of the Kuiper rules, so the tail sinks
+	// aren't lost when the tailed rule is updated concurrently.
+	locks *keyLocks
+}
+
+func newTails() *tails {
+	return &tails{
+		sessions: make(map[string]chan map[string]interface{}),
+		rules:    make(map[string]map[string]bool),
+		locks:    newKeyLocks(),
+	}
+}
+
+func (svc *reService) TailRule(ctx context.Context, token, id string) (<-chan map[string]interface{}, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	// Attaching the sink updates and restarts the rule, so tailing requires
+	// the manage access.
+	owner, id, err := svc.resolve(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return nil, err
+	}
+	if svc.tail.URL == "" {
+		return nil, errors.Wrap(svcerr.ErrMalformedEntity, errTailDisabled)
+	}
+
+	sid, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.Wrap(ErrKuiperServer, err)
+	}
+	// The session ID is the only credential of the tail sink requests, so
+	// it's random and never returned to the user.
+	session := strings.ReplaceAll(sid.String(), "-", "")
+	results := make(chan map[string]interface{}, svc.tail.Buffer)
+
+	kuiperID := prefix(owner) + id
+	if err := svc.attachTail(ctx, kuiperID, session, results); err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		// The sink is removed even if the rule changed in the meantime, so
+		// the detach doesn't depend on the cancelled context.
+		_ = svc.detachTail(context.WithoutCancel(ctx), kuiperID, session)
+	}()
+
+	return results, nil
+}
+
+func (svc *reService) PushTail(_ context.Context, session string, result map[string]interface{}) error {
+	svc.tails.mu.Lock()
+	defer svc.tails.mu.Unlock()
+
+	results, ok := svc.tails.sessions[session]
+	if !ok {
+		return errors.Wrap(svcerr.ErrNotFound, errTailSession)
+	}
+	// Results are dropped while the buffer is full, so the slow clients
+	// don't block Kuiper.
+	select {
+	case results <- result:
+	default:
+	}
+
+	return nil
+}
+
+// attachTail opens the tail session and adds its REST sink to the Kuiper
+// rule. Updating the rule restarts it.
+func (svc *reService) attachTail(ctx context.Context, kuiperID, session string, results chan map[string]interface{}) error {
+	unlock := svc.tails.locks.lock(kuiperID)
+	defer unlock()
+
+	svc.tails.mu.Lock()
+	svc.tails.sessions[session] = results
+	if svc.tails.rules[kuiperID] == nil {
+		svc.tails.rules[kuiperID] = make(map[string]bool)
+	}
+	svc.tails.rules[kuiperID][session] = true
+	svc.tails.mu.Unlock()
+
+	if err := svc.syncTails(ctx, kuiperID); err != nil {
+		svc.closeTail(kuiperID, session)
+		return err
+	}
+
+	return nil
+}
+
+// detachTail closes the tail session and removes its sink from the Kuiper
+// rule, keeping the changes made to the rule while it was tailed.
+func (svc *reService) detachTail(ctx context.Context, kuiperID, session string) error {
+	unlock := svc.tails.locks.lock(kuiperID)
+	defer unlock()
+
+	// The results channel is closed once the sink is removed, so the rule
+	// doesn't send the results to the closed session.
+	defer svc.closeTail(kuiperID, session)
+	svc.tails.mu.Lock()
+	delete(svc.tails.rules[kuiperID], session)
+	svc.tails.mu.Unlock()
+
+	err := svc.syncTails(ctx, kuiperID)
+	if errors.Contains(err, svcerr.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// syncTails replaces the tail sinks of the Kuiper rule with the sinks of
+// the rule's active tail sessions. The caller must hold the rule lock.
+func (svc *reService) syncTails(ctx context.Context, kuiperID string) error {
+	kr, err := svc.engine.ViewRule(ctx, kuiperID)
+	if err != nil {
+		return err
+	}
+	actions := svc.untailed(kr.Actions)
+	sinks, err := svc.tailSinks(kuiperID)
+	if err != nil {
+		return err
+	}
+	if len(actions) == len(kr.Actions) && len(sinks) == 0 {
+		return nil
+	}
+	kr.Actions = append(actions, sinks...)
+	_, err = svc.engine.UpdateRule(ctx, kr)
+
+	return err
+}
+
+// updateRule updates the Kuiper rule, keeping the sinks of its active tail
+// sessions.
+func (svc *reService) updateRule(ctx context.Context, kr EngineRule) (Result, error) {
+	unlock := svc.tails.locks.lock(kr.ID)
+	defer unlock()
+
+	sinks, err := svc.tailSinks(kr.ID)
+	if err != nil {
+		return Result{}, err
+	}
+	kr.Actions = append(svc.untailed(kr.Actions), sinks...)
+
+	return svc.engine.UpdateRule(ctx, kr)
+}
+
+// tailSinks returns the REST sinks sending the rule results to the active
+// tail sessions of the Kuiper rule.
+func (svc *reService) tailSinks(kuiperID string) ([]json.RawMessage, error) {
+	svc.tails.mu.Lock()
+	sessions := make([]string, 0, len(svc.tails.rules[kuiperID]))
+	for session := range svc.tails.rules[kuiperID] {
+		sessions = append(sessions, session)
+	}
+	svc.tails.mu.Unlock()
+	sort.Strings(sessions)
+
+	var sinks []json.RawMessage
+	for _, session := range sessions {
+		sink, err := json.Marshal(map[string]RESTSink{
+			RESTSinkType: {
+				URL:        svc.tailURL(session),
+				Method:     http.MethodPost,
+				BodyType:   "json",
+				SendSingle: true,
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return sinks, nil
+}
+
+// untailed returns the Kuiper rule actions without the tail sinks, which
+// aren't a part of the rule definition.
+func (svc *reService) untailed(actions []json.RawMessage) []json.RawMessage {
+	if svc.tail.URL == "" {
+		return actions
+	}
+	untailed := make([]json.RawMessage, 0, len(actions))
+	for _, data := range actions {
+		var a Action
+		if err := json.Unmarshal(data, &a); err == nil && svc.tailed(a) {
+			continue
+		}
+		untailed = append(untailed, data)
+	}
+
+	return untailed
+}
+
+// untailedActions returns the rule actions without the tail sinks.
+func (svc *reService) untailedActions(actions []Action) []Action {
+	if svc.tail.URL == "" {
+		return actions
+	}
+	untailed := make([]Action, 0, len(actions))
+	for _, a := range actions {
+		if !svc.tailed(a) {
+			untailed = append(untailed, a)
+		}
+	}
+
+	return untailed
+}
+
+// tailed reports whether the action is the sink of a tail session.
+func (svc *reService) tailed(a Action) bool {
+	return svc.tail.URL != "" && a.REST != nil && strings.HasPrefix(a.REST.URL, svc.tailURL(""))
+}
+
+// kuiperRule converts the Kuiper rule like fromKuiper, leaving out the tail
+// sinks.
+func (svc *reService) kuiperRule(kr EngineRule, pfx string) (Rule, error) {
+	kr.Actions = svc.untailed(kr.Actions)
+
+	return fromKuiper(kr, pfx, svc.writers)
+}
+
+func (svc *reService) closeTail(kuiperID, session string) {
+	svc.tails.mu.Lock()
+	defer svc.tails.mu.Unlock()
+
+	if results, ok := svc.tails.sessions[session]; ok {
+		delete(svc.tails.sessions, session)
+		close(results)
+	}
+	if delete(svc.tails.rules[kuiperID], session); len(svc.tails.rules[kuiperID]) == 0 {
+		delete(svc.tails.rules, kuiperID)
+	}
+}
+
+func (svc *reService) tailURL(session string) string {
+	return strings.TrimSuffix(svc.tail.URL, "/") + tailPath + session
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const tailURL = "http://re:9021"
+
+// tailSessions returns the sessions of the tail sinks of the rule.
+func tailSessions(t *testing.T, k *kuiper, id string) []string {
+	var kr struct {
+		Actions []re.Action `json:"actions"`
+	}
+	err := json.Unmarshal(k.raw[id], &kr)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error decoding rule: %s", err))
+	var sessions []string
+	for _, a := range kr.Actions {
+		if a.REST != nil && strings.HasPrefix(a.REST.URL, tailURL+"/tail/") {
+			sessions = append(sessions, strings.TrimPrefix(a.REST.URL, tailURL+"/tail/"))
+		}
+	}
+
+	return sessions
+}
+
+func TestTailRule(t *testing.T) {
+	cfg := re.Config{Tail: re.TailConfig{URL: tailURL + "/", Buffer: 2}}
+	svc, k, auth, _ := newServiceWithConfig(t, cfg, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := svc.TailRule(ctx, validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sessions := tailSessions(t, k, userPrefix+"rule")
+	assert.Len(t, sessions, 1, "expected tail sink attached to the rule")
+	assert.Len(t, k.rules[userPrefix+"rule"].Actions, 2, "expected rule actions kept")
+
+	// Tail sinks are hidden from the rule and kept when the rule is updated.
+	rule, err := svc.ViewRule(context.Background(), validToken, "rule")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error viewing rule: %s", err))
+	assert.Len(t, rule.Actions, 1, "expected tail sink hidden from the rule")
+	_, err = svc.UpdateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error updating rule: %s", err))
+	assert.Equal(t, sessions, tailSessions(t, k, userPrefix+"rule"), "expected tail sink kept after the update")
+
+	// Results exceeding the buffer are dropped.
+	for i := 0; i < 3; i++ {
+		err := svc.PushTail(context.Background(), sessions[0], map[string]interface{}{"v": float64(i)})
+		assert.Nil(t, err, fmt.Sprintf("unexpected error pushing result: %s", err))
+	}
+	err = svc.PushTail(context.Background(), "unknown", map[string]interface{}{"v": 1.0})
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("expected %s got %s", svcerr.ErrNotFound, err))
+
+	cancel()
+	var received []map[string]interface{}
+	timeout := time.After(time.Second)
+	for done := false; !done; {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				done = true
+				break
+			}
+			received = append(received, res)
+		case <-timeout:
+			t.Fatal("tail wasn't closed")
+		}
+	}
+	expected := []map[string]interface{}{{"v": 0.0}, {"v": 1.0}}
+	assert.Equal(t, expected, received, fmt.Sprintf("expected results %v got %v", expected, received))
+	assert.Empty(t, tailSessions(t, k, userPrefix+"rule"), "expected tail sink removed from the rule")
+	assert.Len(t, k.rules[userPrefix+"rule"].Actions, 1, "expected rule actions restored")
+	err = svc.PushTail(context.Background(), sessions[0], map[string]interface{}{"v": 1.0})
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("expected %s got %s", svcerr.ErrNotFound, err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "tail rule with invalid token",
+			token: invalidToken,
+			id:    "rule",
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "tail rule with invalid ID",
+			token: validToken,
+			id:    "1rule",
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "tail unknown rule",
+			token: validToken,
+			id:    "unknown",
+			err:   svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.TailRule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	disabled, _, dauth, _ := newService(t)
+	dauthCall := dauth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer dauthCall.Unset()
+	_, err = disabled.TailRule(context.Background(), validToken, "rule")
+	assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("tail with disabled tails: expected %s got %s", svcerr.ErrMalformedEntity, err))
+}
@@ -0,0 +1,410 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// Types of the template variables.
+const (
+	// StreamVariable is the name of the user's stream.
+	StreamVariable = "stream"
+
+	// FieldVariable is the name of the stream field.
+	FieldVariable = "field"
+
+	// NumberVariable is the integer or decimal number.
+	NumberVariable = "number"
+
+	// StringVariable is the string, rendered in the SQL as the quoted
+	// string literal.
+	StringVariable = "string"
+
+	// ChannelVariable is the channel ID.
+	ChannelVariable = "channel"
+)
+
+// templateLabel is the label of the rules created from the template, set to
+// the template name.
+const templateLabel = "template"
+
+// placeholderRegexp matches the variable placeholders, e.g. "{threshold}".
+var placeholderRegexp = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+var (
+	errVariableType       = errors.New("variable type must be stream, field, number, string or channel")
+	errDuplicateVariable  = errors.New("duplicate variable")
+	errUndeclaredVariable = errors.New("undeclared variable")
+	errUnknownVariable    = errors.New("unknown variable")
+	errMissingValue       = errors.New("missing variable value")
+	errVariableValue      = errors.New("invalid variable value")
+	errMissingActions     = errors.New("missing actions")
+)
+
+// sampleValues contain the valid values of each variable type, used to
+// check that the template renders the valid rule.
+var sampleValues = map[string]string{
+	StreamVariable:  "stream",
+	FieldVariable:   "field",
+	NumberVariable:  "0",
+	StringVariable:  "string",
+	ChannelVariable: "00000000-0000-0000-0000-000000000000",
+}
+
+// Template is the parameterized rule registered by the platform
+// administrator, so users can create common rules without writing SQL.
+// The SQL and the string settings of the actions contain placeholders,
+// e.g. "SELECT * FROM {stream} WHERE {field} > {threshold}", replaced by
+// the values of the variables with the same names.
+type Template struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Variables   []Variable   `json:"variables"`
+	SQL         string       `json:"sql"`
+	Actions     []Action     `json:"actions"`
+	Options     *RuleOptions `json:"options,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// Variable is the template variable. Type restricts the values of the
+// variable, so they can be safely substituted into the rule SQL. Variables
+// without the default value are required.
+type Variable struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+}
+
+// TemplateInstance contains the ID of the rule created from the template
+// and the values of the template variables mapped by the variable names.
+// Description and Labels are stored as the rule metadata.
+type TemplateInstance struct {
+	ID          string            `json:"id"`
+	Values      map[string]string `json:"values"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// TemplateRepository specifies the template persistence API.
+type TemplateRepository interface {
+	// SaveTemplate stores the new template.
+	SaveTemplate(ctx context.Context, tmpl Template) error
+
+	// RetrieveTemplate returns the template with the given name.
+	RetrieveTemplate(ctx context.Context, name string) (Template, error)
+
+	// RetrieveAllTemplates returns all the templates sorted by name.
+	RetrieveAllTemplates(ctx context.Context) ([]Template, error)
+
+	// RemoveTemplate removes the template with the given name.
+	RemoveTemplate(ctx context.Context, name string) error
+}
+
+func (svc *reService) CreateTemplate(ctx context.Context, token string, tmpl Template) (Template, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return Template{}, err
+	}
+	if err := svc.checkAdmin(ctx, userID); err != nil {
+		return Template{}, err
+	}
+	if err := tmpl.validate(); err != nil {
+		return Template{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	tmpl.CreatedAt = time.Now().UTC()
+	switch err := svc.repo.SaveTemplate(ctx, tmpl); {
+	case errors.Contains(err, repoerr.ErrConflict):
+		return Template{}, errors.Wrap(svcerr.ErrConflict, err)
+	case err != nil:
+		return Template{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return tmpl, nil
+}
+
+func (svc *reService) ViewTemplate(ctx context.Context, token, name string) (Template, error) {
+	if _, err := svc.identify(ctx, token); err != nil {
+		return Template{}, err
+	}
+
+	return svc.template(ctx, name)
+}
+
+func (svc *reService) ListTemplates(ctx context.Context, token string) ([]Template, error) {
+	if _, err := svc.identify(ctx, token); err != nil {
+		return nil, err
+	}
+
+	tmpls, err := svc.repo.RetrieveAllTemplates(ctx)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return tmpls, nil
+}
+
+func (svc *reService) RemoveTemplate(ctx context.Context, token, name string) error {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if err := svc.checkAdmin(ctx, userID); err != nil {
+		return err
+	}
+	if err := validateName(name); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	switch err := svc.repo.RemoveTemplate(ctx, name); {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *reService) InstantiateTemplate(ctx context.Context, token, name string, inst TemplateInstance) (Rule, error) {
+	if _, err := svc.identify(ctx, token); err != nil {
+		return Rule{}, err
+	}
+	tmpl, err := svc.template(ctx, name)
+	if err != nil {
+		return Rule{}, err
+	}
+	rule, err := tmpl.render(inst)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	res, err := svc.CreateRule(ctx, token, rule)
+	if err != nil {
+		return Rule{}, err
+	}
+	if rule.Metadata, err = svc.metadata(ctx, RuleKind, prefix(res.Owner)+rule.ID); err != nil {
+		return Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// template returns the template with the given name.
+func (svc *reService) template(ctx context.Context, name string) (Template, error) {
+	if err := validateName(name); err != nil {
+		return Template{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	tmpl, err := svc.repo.RetrieveTemplate(ctx, name)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return Template{}, errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return Template{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return tmpl, nil
+}
+
+// validate checks the template name and variables, that the placeholders
+// refer to the declared variables and that the template renders the rule
+// with the valid SQL and actions.
+func (tmpl Template) validate() error {
+	if err := validateName(tmpl.Name); err != nil {
+		return err
+	}
+	if len(tmpl.Actions) == 0 {
+		return errMissingActions
+	}
+	declared := make(map[string]bool, len(tmpl.Variables))
+	samples := make(map[string]string, len(tmpl.Variables))
+	for _, v := range tmpl.Variables {
+		if err := validateName(v.Name); err != nil {
+			return errors.Wrap(fmt.Errorf("variable %q", v.Name), err)
+		}
+		if declared[v.Name] {
+			return errors.Wrap(fmt.Errorf("variable %q", v.Name), errDuplicateVariable)
+		}
+		declared[v.Name] = true
+		sample, ok := sampleValues[v.Type]
+		if !ok {
+			return errors.Wrap(fmt.Errorf("variable %q", v.Name), errVariableType)
+		}
+		if v.Default != "" {
+			if _, err := sqlValue(v.Type, v.Default); err != nil {
+				return errors.Wrap(fmt.Errorf("variable %q", v.Name), err)
+			}
+		}
+		samples[v.Name] = sample
+	}
+
+	actions, err := json.Marshal(tmpl.Actions)
+	if err != nil {
+		return err
+	}
+	for _, m := range placeholderRegexp.FindAllStringSubmatch(tmpl.SQL+string(actions), -1) {
+		if !declared[m[1]] {
+			return errors.Wrap(fmt.Errorf("placeholder %q", m[0]), errUndeclaredVariable)
+		}
+	}
+
+	rule, err := tmpl.render(TemplateInstance{ID: tmpl.Name, Values: samples})
+	if err != nil {
+		return err
+	}
+	if _, err := addPrefix(rule.SQL, ""); err != nil {
+		return err
+	}
+	for i, a := range rule.Actions {
+		if _, err := a.sink(); err != nil {
+			return errors.Wrap(fmt.Errorf("action %d", i), err)
+		}
+	}
+
+	return nil
+}
+
+// render returns the rule created from the template using the instance
+// values. Missing values are set to the variable defaults.
+func (tmpl Template) render(inst TemplateInstance) (Rule, error) {
+	types := make(map[string]string, len(tmpl.Variables))
+	values := make(map[string]string, len(tmpl.Variables))
+	for _, v := range tmpl.Variables {
+		types[v.Name] = v.Type
+		val, ok := inst.Values[v.Name]
+		if !ok {
+			val = v.Default
+		}
+		if val == "" {
+			return Rule{}, errors.Wrap(fmt.Errorf("variable %q", v.Name), errMissingValue)
+		}
+		values[v.Name] = val
+	}
+	for name := range inst.Values {
+		if _, ok := types[name]; !ok {
+			return Rule{}, errors.Wrap(fmt.Errorf("variable %q", name), errUnknownVariable)
+		}
+	}
+
+	var err error
+	sql := placeholderRegexp.ReplaceAllStringFunc(tmpl.SQL, func(p string) string {
+		name := p[1 : len(p)-1]
+		val, e := sqlValue(types[name], values[name])
+		if e != nil && err == nil {
+			err = errors.Wrap(fmt.Errorf("variable %q", name), e)
+		}
+		return val
+	})
+	if err != nil {
+		return Rule{}, err
+	}
+	actions, err := renderActions(tmpl.Actions, values)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	labels := map[string]string{templateLabel: tmpl.Name}
+	for k, v := range inst.Labels {
+		labels[k] = v
+	}
+
+	return Rule{
+		ID:          inst.ID,
+		SQL:         sql,
+		Actions:     actions,
+		Options:     tmpl.Options,
+		Description: inst.Description,
+		Labels:      labels,
+	}, nil
+}
+
+// sqlValue validates the value of the variable of the given type and
+// returns the value as substituted into the rule SQL.
+func sqlValue(typ, val string) (string, error) {
+	switch typ {
+	case StreamVariable, FieldVariable:
+		if err := validateName(val); err != nil {
+			return "", errors.Wrap(errVariableValue, err)
+		}
+		return val, nil
+	case NumberVariable:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) || strings.ContainsAny(val, "xXpP_") {
+			return "", errVariableValue
+		}
+		return val, nil
+	case ChannelVariable:
+		if err := validateTopic(val); err != nil {
+			return "", errors.Wrap(errVariableValue, err)
+		}
+		return val, nil
+	default:
+		if strings.ContainsAny(val, "\"'`\\") || strings.IndexFunc(val, func(r rune) bool { return r < ' ' }) >= 0 {
+			return "", errVariableValue
+		}
+		return `"` + val + `"`, nil
+	}
+}
+
+// renderActions replaces the placeholders in the string settings of the
+// actions with the raw variable values.
+func renderActions(actions []Action, values map[string]string) ([]Action, error) {
+	data, err := json.Marshal(actions)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(substitute(v, values)); err != nil {
+		return nil, err
+	}
+	var res []Action
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// substitute replaces the placeholders in the strings of the decoded JSON
+// value.
+func substitute(v interface{}, values map[string]string) interface{} {
+	switch v := v.(type) {
+	case string:
+		return placeholderRegexp.ReplaceAllStringFunc(v, func(p string) string {
+			if val, ok := values[p[1:len(p)-1]]; ok {
+				return val
+			}
+			return p
+		})
+	case []interface{}:
+		for i := range v {
+			v[i] = substitute(v[i], values)
+		}
+		return v
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = substitute(v[k], values)
+		}
+		return v
+	default:
+		return v
+	}
+}
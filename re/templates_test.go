@@ -0,0 +1,320 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var thresholdTemplate = re.Template{
+	Name:        "threshold",
+	Description: "alert when the field exceeds the threshold",
+	Variables: []re.Variable{
+		{Name: "stream", Type: re.StreamVariable},
+		{Name: "field", Type: re.FieldVariable, Default: "v"},
+		{Name: "threshold", Type: re.NumberVariable},
+		{Name: "channel", Type: re.ChannelVariable},
+	},
+	SQL:     "SELECT * FROM {stream} WHERE {field} > {threshold}",
+	Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: "{channel}", Subtopic: "alarms.{field}"}}},
+}
+
+func TestCreateTemplate(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	withTemplate := func(f func(tmpl *re.Template)) re.Template {
+		tmpl := thresholdTemplate
+		tmpl.Variables = append([]re.Variable{}, thresholdTemplate.Variables...)
+		f(&tmpl)
+		return tmpl
+	}
+
+	cases := []struct {
+		desc       string
+		token      string
+		authorized bool
+		tmpl       re.Template
+		err        error
+	}{
+		{
+			desc:       "create template",
+			token:      validToken,
+			authorized: true,
+			tmpl:       thresholdTemplate,
+		},
+		{
+			desc:       "create existing template",
+			token:      validToken,
+			authorized: true,
+			tmpl:       thresholdTemplate,
+			err:        svcerr.ErrConflict,
+		},
+		{
+			desc:  "create template as non-admin user",
+			token: validToken,
+			tmpl:  withTemplate(func(tmpl *re.Template) { tmpl.Name = "other" }),
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:  "create template with invalid token",
+			token: invalidToken,
+			tmpl:  withTemplate(func(tmpl *re.Template) { tmpl.Name = "other" }),
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:       "create template with invalid name",
+			token:      validToken,
+			authorized: true,
+			tmpl:       withTemplate(func(tmpl *re.Template) { tmpl.Name = "1threshold" }),
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create template with unknown variable type",
+			token:      validToken,
+			authorized: true,
+			tmpl:       withTemplate(func(tmpl *re.Template) { tmpl.Name, tmpl.Variables[2].Type = "other", "date" }),
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create template with duplicate variable",
+			token:      validToken,
+			authorized: true,
+			tmpl:       withTemplate(func(tmpl *re.Template) { tmpl.Name, tmpl.Variables[1].Name = "other", "stream" }),
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create template with invalid default value",
+			token:      validToken,
+			authorized: true,
+			tmpl:       withTemplate(func(tmpl *re.Template) { tmpl.Name, tmpl.Variables[1].Default = "other", "v OR 1 = 1" }),
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create template with undeclared variable",
+			token:      validToken,
+			authorized: true,
+			tmpl:       withTemplate(func(tmpl *re.Template) { tmpl.Name, tmpl.SQL = "other", "SELECT * FROM {source}" }),
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create template with invalid SQL",
+			token:      validToken,
+			authorized: true,
+			tmpl:       withTemplate(func(tmpl *re.Template) { tmpl.Name, tmpl.SQL = "other", "SELECT * WHERE {field} > {threshold}" }),
+			err:        svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:       "create template without actions",
+			token:      validToken,
+			authorized: true,
+			tmpl:       withTemplate(func(tmpl *re.Template) { tmpl.Name, tmpl.Actions = "other", nil }),
+			err:        svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall2 := authorizeAdmin(auth, tc.authorized)
+		tmpl, err := svc.CreateTemplate(context.Background(), tc.token, tc.tmpl)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.False(t, tmpl.CreatedAt.IsZero(), fmt.Sprintf("%s: expected creation time\n", tc.desc))
+		}
+		authCall2.Unset()
+	}
+}
+
+func TestViewTemplate(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	authCall2 := authorizeAdmin(auth, true)
+	tmpl, err := svc.CreateTemplate(context.Background(), validToken, thresholdTemplate)
+	assert.Nil(t, err, fmt.Sprintf("create template: expected no error got %s\n", err))
+	authCall2.Unset()
+
+	cases := []struct {
+		desc  string
+		token string
+		name  string
+		tmpl  re.Template
+		err   error
+	}{
+		{
+			desc:  "view template",
+			token: validToken,
+			name:  tmpl.Name,
+			tmpl:  tmpl,
+		},
+		{
+			desc:  "view non-existing template",
+			token: validToken,
+			name:  "missing",
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "view template with invalid token",
+			token: invalidToken,
+			name:  tmpl.Name,
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := svc.ViewTemplate(context.Background(), tc.token, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.tmpl, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.tmpl, res))
+	}
+
+	tmpls, err := svc.ListTemplates(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list templates: expected no error got %s\n", err))
+	assert.Equal(t, []re.Template{tmpl}, tmpls, fmt.Sprintf("list templates: expected %v got %v\n", []re.Template{tmpl}, tmpls))
+}
+
+func TestRemoveTemplate(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := authorizeAdmin(auth, true)
+	_, err := svc.CreateTemplate(context.Background(), validToken, thresholdTemplate)
+	assert.Nil(t, err, fmt.Sprintf("create template: expected no error got %s\n", err))
+	authCall1.Unset()
+
+	cases := []struct {
+		desc       string
+		authorized bool
+		name       string
+		err        error
+	}{
+		{
+			desc: "remove template as non-admin user",
+			name: thresholdTemplate.Name,
+			err:  svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "remove template",
+			authorized: true,
+			name:       thresholdTemplate.Name,
+		},
+		{
+			desc:       "remove removed template",
+			authorized: true,
+			name:       thresholdTemplate.Name,
+			err:        svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall1 := authorizeAdmin(auth, tc.authorized)
+		err := svc.RemoveTemplate(context.Background(), validToken, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		authCall1.Unset()
+	}
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+	authCall2 := authorizeAdmin(auth, true)
+	_, err := svc.CreateTemplate(context.Background(), validToken, thresholdTemplate)
+	assert.Nil(t, err, fmt.Sprintf("create template: expected no error got %s\n", err))
+	authCall2.Unset()
+
+	values := map[string]string{"stream": "stream", "threshold": "30", "channel": channelID}
+
+	cases := []struct {
+		desc   string
+		token  string
+		name   string
+		inst   re.TemplateInstance
+		sql    string
+		labels map[string]string
+		err    error
+	}{
+		{
+			desc:   "instantiate template",
+			token:  validToken,
+			name:   thresholdTemplate.Name,
+			inst:   re.TemplateInstance{ID: "alarm", Values: values, Labels: map[string]string{"site": "a"}},
+			sql:    "SELECT * FROM " + userPrefix + "stream WHERE v > 30",
+			labels: map[string]string{"site": "a", "template": thresholdTemplate.Name},
+		},
+		{
+			desc:  "instantiate template with existing rule ID",
+			token: validToken,
+			name:  thresholdTemplate.Name,
+			inst:  re.TemplateInstance{ID: "alarm", Values: map[string]string{"stream": "stream", "threshold": "40", "channel": channelID}},
+			err:   svcerr.ErrConflict,
+		},
+		{
+			desc:  "instantiate template with missing value",
+			token: validToken,
+			name:  thresholdTemplate.Name,
+			inst:  re.TemplateInstance{ID: "other", Values: map[string]string{"stream": "stream", "channel": channelID}},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "instantiate template with unknown variable",
+			token: validToken,
+			name:  thresholdTemplate.Name,
+			inst:  re.TemplateInstance{ID: "other", Values: map[string]string{"stream": "stream", "threshold": "30", "channel": channelID, "unit": "C"}},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "instantiate template with injected SQL",
+			token: validToken,
+			name:  thresholdTemplate.Name,
+			inst:  re.TemplateInstance{ID: "other", Values: map[string]string{"stream": "stream", "threshold": "30 OR 1 = 1", "channel": channelID}},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "instantiate non-existing template",
+			token: validToken,
+			name:  "missing",
+			inst:  re.TemplateInstance{ID: "other", Values: values},
+			err:   svcerr.ErrNotFound,
+		},
+		{
+			desc:  "instantiate template with invalid token",
+			token: invalidToken,
+			name:  thresholdTemplate.Name,
+			inst:  re.TemplateInstance{ID: "other", Values: values},
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		rule, err := svc.InstantiateTemplate(context.Background(), tc.token, tc.name, tc.inst)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, tc.labels, rule.Labels, fmt.Sprintf("%s: expected labels %v got %v\n", tc.desc, tc.labels, rule.Labels))
+		assert.NotNil(t, rule.Metadata, fmt.Sprintf("%s: expected rule metadata\n", tc.desc))
+		kr := k.rules[userPrefix+tc.inst.ID]
+		assert.Equal(t, tc.sql, kr.SQL, fmt.Sprintf("%s: expected SQL %s got %s\n", tc.desc, tc.sql, kr.SQL))
+		sink := rule.Actions[0].Mainflux
+		assert.Equal(t, channelID, sink.Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, channelID, sink.Channel))
+		assert.Equal(t, "alarms.v", sink.Subtopic, fmt.Sprintf("%s: expected subtopic alarms.v got %s\n", tc.desc, sink.Subtopic))
+	}
+}
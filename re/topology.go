@@ -0,0 +1,106 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"sort"
+	"strings"
+)
+
+// Types of the rule topology nodes. Kuiper names the source nodes after
+// the streams and tables the rule reads from, e.g. source_readings, the
+// sink nodes after the actions, e.g. sink_mqtt_0, and the other nodes after
+// the operators of the execution plan, e.g. op_2_filter.
+const (
+	SourceNode   = "source"
+	OperatorNode = "operator"
+	SinkNode     = "sink"
+)
+
+// RuleTopology is the graph of the operators the rule runs as. The data
+// flows from the source nodes through the edges to the sink nodes.
+type RuleTopology struct {
+	Nodes []TopologyNode `json:"nodes"`
+	Edges []TopologyEdge `json:"edges"`
+}
+
+// TopologyNode is the operator of the rule topology.
+type TopologyNode struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TopologyEdge connects the node sending the data to the node receiving it.
+type TopologyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// engineTopology is the topology Kuiper returns, with the names of the
+// source nodes and the names of the nodes each node sends the data to.
+type engineTopology struct {
+	Sources []string            `json:"sources"`
+	Edges   map[string][]string `json:"edges"`
+}
+
+// parseTopology converts the Kuiper rule topology to RuleTopology with the
+// nodes and the edges sorted by their names.
+func parseTopology(et engineTopology) RuleTopology {
+	names := make(map[string]bool)
+	for _, src := range et.Sources {
+		names[src] = true
+	}
+	topo := RuleTopology{Nodes: []TopologyNode{}, Edges: []TopologyEdge{}}
+	for from, tos := range et.Edges {
+		names[from] = true
+		for _, to := range tos {
+			names[to] = true
+			topo.Edges = append(topo.Edges, TopologyEdge{From: from, To: to})
+		}
+	}
+	for name := range names {
+		topo.Nodes = append(topo.Nodes, TopologyNode{Name: name, Type: nodeType(name)})
+	}
+	topo.sort()
+
+	return topo
+}
+
+// nodeType returns the type of the node with the given Kuiper name.
+func nodeType(name string) string {
+	switch {
+	case strings.HasPrefix(name, SourceNode+"_"):
+		return SourceNode
+	case strings.HasPrefix(name, SinkNode+"_"):
+		return SinkNode
+	default:
+		return OperatorNode
+	}
+}
+
+// withoutPrefix removes the owner prefix from the node names.
+func (topo RuleTopology) withoutPrefix(pfx string) RuleTopology {
+	for i := range topo.Nodes {
+		topo.Nodes[i].Name = strings.Replace(topo.Nodes[i].Name, pfx, "", 1)
+	}
+	for i := range topo.Edges {
+		topo.Edges[i].From = strings.Replace(topo.Edges[i].From, pfx, "", 1)
+		topo.Edges[i].To = strings.Replace(topo.Edges[i].To, pfx, "", 1)
+	}
+	topo.sort()
+
+	return topo
+}
+
+func (topo RuleTopology) sort() {
+	sort.Slice(topo.Nodes, func(i, j int) bool {
+		return topo.Nodes[i].Name < topo.Nodes[j].Name
+	})
+	sort.Slice(topo.Edges, func(i, j int) bool {
+		if topo.Edges[i].From != topo.Edges[j].From {
+			return topo.Edges[i].From < topo.Edges[j].From
+		}
+		return topo.Edges[i].To < topo.Edges[j].To
+	})
+}
@@ -0,0 +1,899 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ re.Service = (*tracingMiddleware)(nil)
+
+type tracingMiddleware struct {
+	tracer trace.Tracer
+	svc    re.Service
+}
+
+// New returns a new rules engine service with tracing capabilities.
+func New(svc re.Service, tracer trace.Tracer) re.Service {
+	return &tracingMiddleware{tracer, svc}
+}
+
+// Info traces the "Info" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) Info(ctx context.Context) (re.Info, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_info")
+	defer span.End()
+
+	return tm.svc.Info(ctx)
+}
+
+// CreateStream traces the "CreateStream" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CreateStream(ctx context.Context, token string, def re.StreamDef, update bool) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_stream", trace.WithAttributes(
+		attribute.String("name", def.Name),
+		attribute.Bool("update", update),
+	))
+	defer span.End()
+
+	return tm.svc.CreateStream(ctx, token, def, update)
+}
+
+// ListStreams traces the "ListStreams" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListStreams(ctx context.Context, token string, pm re.PageMetadata) (re.StreamsPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_streams", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListStreams(ctx, token, pm)
+}
+
+// ViewStream traces the "ViewStream" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewStream(ctx context.Context, token, name string) (re.Stream, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_stream", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.ViewStream(ctx, token, name)
+}
+
+// ViewStreams traces the "ViewStreams" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewStreams(ctx context.Context, token string, names []string) (re.StreamsBatch, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_streams", trace.WithAttributes(attribute.Int("streams", len(names))))
+	defer span.End()
+
+	return tm.svc.ViewStreams(ctx, token, names)
+}
+
+// DeleteStream traces the "DeleteStream" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) DeleteStream(ctx context.Context, token, name string, cascade bool) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_stream", trace.WithAttributes(
+		attribute.String("name", name),
+		attribute.Bool("cascade", cascade),
+	))
+	defer span.End()
+
+	return tm.svc.DeleteStream(ctx, token, name, cascade)
+}
+
+// CreateTable traces the "CreateTable" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CreateTable(ctx context.Context, token string, def re.TableDef) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_table", trace.WithAttributes(attribute.String("name", def.Name)))
+	defer span.End()
+
+	return tm.svc.CreateTable(ctx, token, def)
+}
+
+// ListTables traces the "ListTables" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListTables(ctx context.Context, token string, pm re.PageMetadata) (re.TablesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_tables", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListTables(ctx, token, pm)
+}
+
+// ViewTable traces the "ViewTable" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewTable(ctx context.Context, token, name string) (re.Table, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_table", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.ViewTable(ctx, token, name)
+}
+
+// DeleteTable traces the "DeleteTable" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) DeleteTable(ctx context.Context, token, name string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_table", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.DeleteTable(ctx, token, name)
+}
+
+// CreateRule traces the "CreateRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CreateRule(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_rule", trace.WithAttributes(attribute.String("id", rule.ID)))
+	defer span.End()
+
+	return tm.svc.CreateRule(ctx, token, rule)
+}
+
+// UpdateRule traces the "UpdateRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) UpdateRule(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_update_rule", trace.WithAttributes(attribute.String("id", rule.ID)))
+	defer span.End()
+
+	return tm.svc.UpdateRule(ctx, token, rule)
+}
+
+// PatchRule traces the "PatchRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) PatchRule(ctx context.Context, token, id string, patch re.RulePatch) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_patch_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.PatchRule(ctx, token, id, patch)
+}
+
+// SaveDraft traces the "SaveDraft" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) SaveDraft(ctx context.Context, token string, rule re.Rule) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_save_draft", trace.WithAttributes(attribute.String("id", rule.ID)))
+	defer span.End()
+
+	return tm.svc.SaveDraft(ctx, token, rule)
+}
+
+// PublishRule traces the "PublishRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) PublishRule(ctx context.Context, token, id string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_publish_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.PublishRule(ctx, token, id)
+}
+
+// RestoreRule traces the "RestoreRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RestoreRule(ctx context.Context, token, id string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_restore_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RestoreRule(ctx, token, id)
+}
+
+// UnpublishRule traces the "UnpublishRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) UnpublishRule(ctx context.Context, token, id string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_unpublish_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.UnpublishRule(ctx, token, id)
+}
+
+// CloneRule traces the "CloneRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CloneRule(ctx context.Context, token, id, newID, channel string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_clone_rule", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("new_id", newID),
+		attribute.String("channel", channel),
+	))
+	defer span.End()
+
+	return tm.svc.CloneRule(ctx, token, id, newID, channel)
+}
+
+// ValidateRule traces the "ValidateRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ValidateRule(ctx context.Context, token string, rule re.Rule) (re.RuleValidation, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_validate_rule", trace.WithAttributes(attribute.String("id", rule.ID)))
+	defer span.End()
+
+	return tm.svc.ValidateRule(ctx, token, rule)
+}
+
+// TestRule traces the "TestRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) TestRule(ctx context.Context, token string, trial re.RuleTrial) (re.TrialResult, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_test_rule", trace.WithAttributes(attribute.String("id", trial.Rule.ID)))
+	defer span.End()
+
+	return tm.svc.TestRule(ctx, token, trial)
+}
+
+// ReplayRule traces the "ReplayRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ReplayRule(ctx context.Context, token, id string, from, to time.Time) (re.ReplayResult, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_replay_rule", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("from", from.Format(time.RFC3339)),
+		attribute.String("to", to.Format(time.RFC3339)),
+	))
+	defer span.End()
+
+	return tm.svc.ReplayRule(ctx, token, id, from, to)
+}
+
+// TailRule traces the "TailRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) TailRule(ctx context.Context, token, id string) (<-chan map[string]interface{}, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_tail_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.TailRule(ctx, token, id)
+}
+
+// PushTail traces the "PushTail" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) PushTail(ctx context.Context, session string, result map[string]interface{}) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_push_tail")
+	defer span.End()
+
+	return tm.svc.PushTail(ctx, session, result)
+}
+
+// Ingest traces the "Ingest" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) Ingest(ctx context.Context, key, stream string, payload []byte) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_ingest", trace.WithAttributes(attribute.String("name", stream)))
+	defer span.End()
+
+	return tm.svc.Ingest(ctx, key, stream, payload)
+}
+
+// WatchRules traces the "WatchRules" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) WatchRules(ctx context.Context, token string) (<-chan re.RuleStateChange, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_watch_rules")
+	defer span.End()
+
+	return tm.svc.WatchRules(ctx, token)
+}
+
+// ViewRule traces the "ViewRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewRule(ctx context.Context, token, id string) (re.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ViewRule(ctx, token, id)
+}
+
+// ViewRules traces the "ViewRules" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewRules(ctx context.Context, token string, ids []string) (re.RulesBatch, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_rules", trace.WithAttributes(attribute.Int("rules", len(ids))))
+	defer span.End()
+
+	return tm.svc.ViewRules(ctx, token, ids)
+}
+
+// ListRules traces the "ListRules" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListRules(ctx context.Context, token string, pm re.PageMetadata) (re.RulesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_rules", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListRules(ctx, token, pm)
+}
+
+// SearchRules traces the "SearchRules" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) SearchRules(ctx context.Context, token string, q re.RuleQuery, pm re.PageMetadata) (re.RulesPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_search_rules", trace.WithAttributes(
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.SearchRules(ctx, token, q, pm)
+}
+
+// DeleteRule traces the "DeleteRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) DeleteRule(ctx context.Context, token, id string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.DeleteRule(ctx, token, id)
+}
+
+// StartRule traces the "StartRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) StartRule(ctx context.Context, token, id string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_start_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.StartRule(ctx, token, id)
+}
+
+// StopRule traces the "StopRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) StopRule(ctx context.Context, token, id string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_stop_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.StopRule(ctx, token, id)
+}
+
+// RestartRule traces the "RestartRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RestartRule(ctx context.Context, token, id string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_restart_rule", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RestartRule(ctx, token, id)
+}
+
+// RuleStatus traces the "RuleStatus" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RuleStatus(ctx context.Context, token, id string) (re.RuleStatus, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_rule_status", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RuleStatus(ctx, token, id)
+}
+
+// RuleTopology traces the "RuleTopology" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RuleTopology(ctx context.Context, token, id string) (re.RuleTopology, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_rule_topology", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RuleTopology(ctx, token, id)
+}
+
+// RuleLogs traces the "RuleLogs" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RuleLogs(ctx context.Context, token, id string, since time.Time) ([]re.LogEntry, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_rule_logs", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RuleLogs(ctx, token, id, since)
+}
+
+// Reconcile traces the "Reconcile" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) Reconcile(ctx context.Context, token string, repair bool) (re.DriftReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_reconcile", trace.WithAttributes(attribute.Bool("repair", repair)))
+	defer span.End()
+
+	return tm.svc.Reconcile(ctx, token, repair)
+}
+
+// CollectOrphans traces the "CollectOrphans" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CollectOrphans(ctx context.Context, token string, policy re.OrphanPolicy) (re.OrphanReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_collect_orphans")
+	defer span.End()
+
+	return tm.svc.CollectOrphans(ctx, token, policy)
+}
+
+// Restore traces the "Restore" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) Restore(ctx context.Context, token string, dryRun bool) (re.RestoreReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_restore", trace.WithAttributes(attribute.Bool("dry_run", dryRun)))
+	defer span.End()
+
+	return tm.svc.Restore(ctx, token, dryRun)
+}
+
+// ExportRuleset traces the "ExportRuleset" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ExportRuleset(ctx context.Context, token string) (re.Ruleset, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_export_ruleset")
+	defer span.End()
+
+	return tm.svc.ExportRuleset(ctx, token)
+}
+
+// ImportRuleset traces the "ImportRuleset" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ImportRuleset(ctx context.Context, token string, rs re.Ruleset, conflict string) (re.ImportReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_import_ruleset", trace.WithAttributes(attribute.String("conflict", conflict)))
+	defer span.End()
+
+	return tm.svc.ImportRuleset(ctx, token, rs, conflict)
+}
+
+// BulkCreate traces the "BulkCreate" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) BulkCreate(ctx context.Context, token string, rs re.Ruleset) (re.BulkReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_bulk_create")
+	defer span.End()
+
+	return tm.svc.BulkCreate(ctx, token, rs)
+}
+
+// BulkDelete traces the "BulkDelete" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) BulkDelete(ctx context.Context, token string, bd re.BulkDeletion) (re.BulkReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_bulk_delete")
+	defer span.End()
+
+	return tm.svc.BulkDelete(ctx, token, bd)
+}
+
+// StartRules traces the "StartRules" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) StartRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_start_rules")
+	defer span.End()
+
+	return tm.svc.StartRules(ctx, token, sel)
+}
+
+// StopRules traces the "StopRules" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) StopRules(ctx context.Context, token string, sel re.RuleSelection) (re.BulkReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_stop_rules")
+	defer span.End()
+
+	return tm.svc.StopRules(ctx, token, sel)
+}
+
+// ListAllStreams traces the "ListAllStreams" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListAllStreams(ctx context.Context, token string) (re.AllStreams, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_all_streams")
+	defer span.End()
+
+	return tm.svc.ListAllStreams(ctx, token)
+}
+
+// ListAllRules traces the "ListAllRules" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListAllRules(ctx context.Context, token string) (re.AllRules, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_all_rules")
+	defer span.End()
+
+	return tm.svc.ListAllRules(ctx, token)
+}
+
+// ViewQuota traces the "ViewQuota" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewQuota(ctx context.Context, token, userID string) (re.UserQuota, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_quota", trace.WithAttributes(attribute.String("user_id", userID)))
+	defer span.End()
+
+	return tm.svc.ViewQuota(ctx, token, userID)
+}
+
+// SetQuota traces the "SetQuota" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) SetQuota(ctx context.Context, token, userID string, q re.Quota) (re.UserQuota, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_set_quota", trace.WithAttributes(attribute.String("user_id", userID)))
+	defer span.End()
+
+	return tm.svc.SetQuota(ctx, token, userID, q)
+}
+
+// RemoveQuota traces the "RemoveQuota" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RemoveQuota(ctx context.Context, token, userID string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_quota", trace.WithAttributes(attribute.String("user_id", userID)))
+	defer span.End()
+
+	return tm.svc.RemoveQuota(ctx, token, userID)
+}
+
+// ShareEntity traces the "ShareEntity" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ShareEntity(ctx context.Context, token, kind, name string, s re.Share) (re.Share, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_share_entity", trace.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("name", name),
+		attribute.String("grantee", s.Grantee),
+	))
+	defer span.End()
+
+	return tm.svc.ShareEntity(ctx, token, kind, name, s)
+}
+
+// ListShares traces the "ListShares" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListShares(ctx context.Context, token, kind, name string) ([]re.Share, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_shares", trace.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("name", name),
+	))
+	defer span.End()
+
+	return tm.svc.ListShares(ctx, token, kind, name)
+}
+
+// UnshareEntity traces the "UnshareEntity" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) UnshareEntity(ctx context.Context, token, kind, name, grantee string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_unshare_entity", trace.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("name", name),
+		attribute.String("grantee", grantee),
+	))
+	defer span.End()
+
+	return tm.svc.UnshareEntity(ctx, token, kind, name, grantee)
+}
+
+// Rename traces the "Rename" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) Rename(ctx context.Context, token, kind, name, newName string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_rename", trace.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("name", name),
+		attribute.String("new_name", newName),
+	))
+	defer span.End()
+
+	return tm.svc.Rename(ctx, token, kind, name, newName)
+}
+
+// ListAuditEvents traces the "ListAuditEvents" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListAuditEvents(ctx context.Context, token string, q re.AuditQuery) (re.AuditPage, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_audit_events", trace.WithAttributes(
+		attribute.String("kind", q.Kind),
+		attribute.String("entity", q.Entity),
+	))
+	defer span.End()
+
+	return tm.svc.ListAuditEvents(ctx, token, q)
+}
+
+// ListInstances traces the "ListInstances" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListInstances(ctx context.Context, token string) ([]re.Instance, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_instances")
+	defer span.End()
+
+	return tm.svc.ListInstances(ctx, token)
+}
+
+// AssignInstance traces the "AssignInstance" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) AssignInstance(ctx context.Context, token, userID, instance string) (re.Assignment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_assign_instance", trace.WithAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("instance", instance),
+	))
+	defer span.End()
+
+	return tm.svc.AssignInstance(ctx, token, userID, instance)
+}
+
+// SaveGateway traces the "SaveGateway" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) SaveGateway(ctx context.Context, token string, gw re.Gateway) (re.Gateway, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_save_gateway", trace.WithAttributes(
+		attribute.String("id", gw.ID),
+		attribute.String("channel", gw.Channel),
+	))
+	defer span.End()
+
+	return tm.svc.SaveGateway(ctx, token, gw)
+}
+
+// ListGateways traces the "ListGateways" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListGateways(ctx context.Context, token string) ([]re.Gateway, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_gateways")
+	defer span.End()
+
+	return tm.svc.ListGateways(ctx, token)
+}
+
+// RemoveGateway traces the "RemoveGateway" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RemoveGateway(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_gateway", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RemoveGateway(ctx, token, id)
+}
+
+// DeployRule traces the "DeployRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) DeployRule(ctx context.Context, token, id, gateway string) (re.Deployment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_deploy_rule", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("gateway", gateway),
+	))
+	defer span.End()
+
+	return tm.svc.DeployRule(ctx, token, id, gateway)
+}
+
+// UndeployRule traces the "UndeployRule" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) UndeployRule(ctx context.Context, token, id, gateway string) (re.Deployment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_undeploy_rule", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("gateway", gateway),
+	))
+	defer span.End()
+
+	return tm.svc.UndeployRule(ctx, token, id, gateway)
+}
+
+// ListDeployments traces the "ListDeployments" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListDeployments(ctx context.Context, token, id string) ([]re.Deployment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_deployments", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ListDeployments(ctx, token, id)
+}
+
+// DeployFleet traces the "DeployFleet" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) DeployFleet(ctx context.Context, token, id string, labels map[string]string) (re.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_deploy_fleet", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.DeployFleet(ctx, token, id, labels)
+}
+
+// RetryDeployments traces the "RetryDeployments" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RetryDeployments(ctx context.Context, token, id string) (re.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_retry_deployments", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RetryDeployments(ctx, token, id)
+}
+
+// ViewRollout traces the "ViewRollout" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewRollout(ctx context.Context, token, id string) (re.Rollout, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_rollout", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ViewRollout(ctx, token, id)
+}
+
+// EngineStats traces the "EngineStats" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) EngineStats(ctx context.Context, token string) (re.EngineStats, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_engine_stats")
+	defer span.End()
+
+	return tm.svc.EngineStats(ctx, token)
+}
+
+// SetAlertPolicy traces the "SetAlertPolicy" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) SetAlertPolicy(ctx context.Context, token string, p re.AlertPolicy) (re.AlertPolicy, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_set_alert_policy")
+	defer span.End()
+
+	return tm.svc.SetAlertPolicy(ctx, token, p)
+}
+
+// ViewAlertPolicy traces the "ViewAlertPolicy" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewAlertPolicy(ctx context.Context, token string) (re.AlertPolicy, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_alert_policy")
+	defer span.End()
+
+	return tm.svc.ViewAlertPolicy(ctx, token)
+}
+
+// RemoveAlertPolicy traces the "RemoveAlertPolicy" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RemoveAlertPolicy(ctx context.Context, token string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_alert_policy")
+	defer span.End()
+
+	return tm.svc.RemoveAlertPolicy(ctx, token)
+}
+
+// CreateWebhook traces the "CreateWebhook" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CreateWebhook(ctx context.Context, token string, wh re.Webhook) (re.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_webhook", trace.WithAttributes(attribute.StringSlice("events", wh.Events)))
+	defer span.End()
+
+	return tm.svc.CreateWebhook(ctx, token, wh)
+}
+
+// ListWebhooks traces the "ListWebhooks" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListWebhooks(ctx context.Context, token string) ([]re.Webhook, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_webhooks")
+	defer span.End()
+
+	return tm.svc.ListWebhooks(ctx, token)
+}
+
+// RemoveWebhook traces the "RemoveWebhook" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RemoveWebhook(ctx context.Context, token, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_webhook", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RemoveWebhook(ctx, token, id)
+}
+
+// CreateFolder traces the "CreateFolder" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CreateFolder(ctx context.Context, token string, f re.Folder) (re.Folder, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_folder", trace.WithAttributes(attribute.String("name", f.Name)))
+	defer span.End()
+
+	return tm.svc.CreateFolder(ctx, token, f)
+}
+
+// ListFolders traces the "ListFolders" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListFolders(ctx context.Context, token string) ([]re.Folder, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_folders")
+	defer span.End()
+
+	return tm.svc.ListFolders(ctx, token)
+}
+
+// RemoveFolder traces the "RemoveFolder" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RemoveFolder(ctx context.Context, token, name string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_folder", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.RemoveFolder(ctx, token, name)
+}
+
+// MoveRules traces the "MoveRules" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) MoveRules(ctx context.Context, token, folder string, ids []string) (re.BulkReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_move_rules", trace.WithAttributes(attribute.String("folder", folder)))
+	defer span.End()
+
+	return tm.svc.MoveRules(ctx, token, folder, ids)
+}
+
+// Pause traces the "Pause" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) Pause(ctx context.Context, token string) (re.BulkReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_pause")
+	defer span.End()
+
+	return tm.svc.Pause(ctx, token)
+}
+
+// Resume traces the "Resume" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) Resume(ctx context.Context, token string) (re.BulkReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_resume")
+	defer span.End()
+
+	return tm.svc.Resume(ctx, token)
+}
+
+// ViewMaintenance traces the "ViewMaintenance" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewMaintenance(ctx context.Context, token string) (re.Maintenance, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_maintenance")
+	defer span.End()
+
+	return tm.svc.ViewMaintenance(ctx, token)
+}
+
+// CreateChain traces the "CreateChain" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CreateChain(ctx context.Context, token string, c re.Chain) (re.Chain, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_chain", trace.WithAttributes(
+		attribute.String("name", c.Name),
+		attribute.String("from", c.From),
+		attribute.String("to", c.To),
+	))
+	defer span.End()
+
+	return tm.svc.CreateChain(ctx, token, c)
+}
+
+// ListChains traces the "ListChains" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListChains(ctx context.Context, token string) ([]re.Chain, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_chains")
+	defer span.End()
+
+	return tm.svc.ListChains(ctx, token)
+}
+
+// RemoveChain traces the "RemoveChain" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RemoveChain(ctx context.Context, token, name string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_chain", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.RemoveChain(ctx, token, name)
+}
+
+// SaveSecret traces the "SaveSecret" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) SaveSecret(ctx context.Context, token string, s re.Secret) (re.Secret, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_save_secret", trace.WithAttributes(attribute.String("name", s.Name)))
+	defer span.End()
+
+	return tm.svc.SaveSecret(ctx, token, s)
+}
+
+// ListSecrets traces the "ListSecrets" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListSecrets(ctx context.Context, token string) ([]re.Secret, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_secrets")
+	defer span.End()
+
+	return tm.svc.ListSecrets(ctx, token)
+}
+
+// RemoveSecret traces the "RemoveSecret" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RemoveSecret(ctx context.Context, token, name string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_secret", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.RemoveSecret(ctx, token, name)
+}
+
+// CreateTemplate traces the "CreateTemplate" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CreateTemplate(ctx context.Context, token string, tmpl re.Template) (re.Template, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_template", trace.WithAttributes(attribute.String("name", tmpl.Name)))
+	defer span.End()
+
+	return tm.svc.CreateTemplate(ctx, token, tmpl)
+}
+
+// ViewTemplate traces the "ViewTemplate" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ViewTemplate(ctx context.Context, token, name string) (re.Template, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_view_template", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.ViewTemplate(ctx, token, name)
+}
+
+// ListTemplates traces the "ListTemplates" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListTemplates(ctx context.Context, token string) ([]re.Template, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_templates")
+	defer span.End()
+
+	return tm.svc.ListTemplates(ctx, token)
+}
+
+// RemoveTemplate traces the "RemoveTemplate" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RemoveTemplate(ctx context.Context, token, name string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_remove_template", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.RemoveTemplate(ctx, token, name)
+}
+
+// InstantiateTemplate traces the "InstantiateTemplate" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) InstantiateTemplate(ctx context.Context, token, name string, inst re.TemplateInstance) (re.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_instantiate_template", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.InstantiateTemplate(ctx, token, name, inst)
+}
+
+// CreatePlugin traces the "CreatePlugin" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) CreatePlugin(ctx context.Context, token, kind string, plugin re.Plugin) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_create_plugin", trace.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("name", plugin.Name),
+	))
+	defer span.End()
+
+	return tm.svc.CreatePlugin(ctx, token, kind, plugin)
+}
+
+// ListPlugins traces the "ListPlugins" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListPlugins(ctx context.Context, token, kind string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_plugins", trace.WithAttributes(attribute.String("kind", kind)))
+	defer span.End()
+
+	return tm.svc.ListPlugins(ctx, token, kind)
+}
+
+// DeletePlugin traces the "DeletePlugin" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) DeletePlugin(ctx context.Context, token, kind, name string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_plugin", trace.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("name", name),
+	))
+	defer span.End()
+
+	return tm.svc.DeletePlugin(ctx, token, kind, name)
+}
+
+// RegisterExternalService traces the "RegisterExternalService" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) RegisterExternalService(ctx context.Context, token string, es re.ExternalService) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_register_external_service", trace.WithAttributes(attribute.String("name", es.Name)))
+	defer span.End()
+
+	return tm.svc.RegisterExternalService(ctx, token, es)
+}
+
+// ListExternalServices traces the "ListExternalServices" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListExternalServices(ctx context.Context, token string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_external_services")
+	defer span.End()
+
+	return tm.svc.ListExternalServices(ctx, token)
+}
+
+// DeleteExternalService traces the "DeleteExternalService" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) DeleteExternalService(ctx context.Context, token, name string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_external_service", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.DeleteExternalService(ctx, token, name)
+}
+
+// ListExternalFunctions traces the "ListExternalFunctions" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListExternalFunctions(ctx context.Context, token string) ([]re.ExternalFunction, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_external_functions")
+	defer span.End()
+
+	return tm.svc.ListExternalFunctions(ctx, token)
+}
+
+// SaveConfKey traces the "SaveConfKey" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) SaveConfKey(ctx context.Context, token, name string, conf re.MQTTConf) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_save_conf_key", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.SaveConfKey(ctx, token, name, conf)
+}
+
+// ListConfKeys traces the "ListConfKeys" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) ListConfKeys(ctx context.Context, token string) ([]string, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_conf_keys")
+	defer span.End()
+
+	return tm.svc.ListConfKeys(ctx, token)
+}
+
+// DeleteConfKey traces the "DeleteConfKey" operation of the wrapped re.Service.
+func (tm *tracingMiddleware) DeleteConfKey(ctx context.Context, token, name string) (re.Result, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_delete_conf_key", trace.WithAttributes(attribute.String("name", name)))
+	defer span.End()
+
+	return tm.svc.DeleteConfKey(ctx, token, name)
+}
@@ -0,0 +1,204 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+// RuleDeleted is the status of the deleted rules, which are kept stopped
+// until they're restored or purged.
+const RuleDeleted = "deleted"
+
+var (
+	errRuleDeleted    = errors.New("rule is deleted")
+	errNotDeleted     = errors.New("rule isn't deleted")
+	errRetentionEnded = errors.New("retention period of the deleted rule expired")
+)
+
+// PurgedRule is the deleted rule removed permanently once its retention
+// period expired. Name is the Kuiper name of the rule and Error why the
+// removal failed.
+type PurgedRule struct {
+	Name      string    `json:"name"`
+	Owner     string    `json:"owner"`
+	DeletedAt time.Time `json:"deleted_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Purger removes the deleted rules of all the users permanently, so it's
+// used by the background purge job and never exposed over the API.
+type Purger interface {
+	// Purge removes the rules deleted longer than the retention period ago
+	// and returns them.
+	Purge(ctx context.Context) ([]PurgedRule, error)
+}
+
+type purger struct {
+	svc *reService
+}
+
+// NewPurger instantiates the purger running the rules in the given engine,
+// using the configured retention period the deleted rules are kept for.
+func NewPurger(engine RuleEngine, cfg Config, repo Repository) Purger {
+	return &purger{svc: newService(engine, cfg, nil, nil, Notifiers{}, nil, repo)}
+}
+
+func (p *purger) Purge(ctx context.Context) ([]PurgedRule, error) {
+	mds, err := p.svc.repo.RetrieveAll(ctx, RuleKind, "")
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	expired := time.Now().UTC().Add(-p.svc.retention)
+	purged := []PurgedRule{}
+	for name, md := range mds {
+		if !md.deleted() || md.DeletedAt.After(expired) {
+			continue
+		}
+		r := PurgedRule{Name: name, Owner: md.Owner, DeletedAt: md.DeletedAt}
+		err := p.svc.remove(ctx, RuleKind, name)
+		if err == nil {
+			err = p.svc.removeShares(ctx, RuleKind, name)
+		}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		purged = append(purged, r)
+	}
+	sort.Slice(purged, func(i, j int) bool {
+		return purged[i].Name < purged[j].Name
+	})
+
+	return purged, nil
+}
+
+func (svc *reService) RestoreRule(ctx context.Context, token, id string) (Result, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Result{}, err
+	}
+	owner, id, err := svc.resolveRef(ctx, token, userID, RuleKind, id, ManageAccess)
+	if err != nil {
+		return Result{}, err
+	}
+	kuiperID := prefix(owner) + id
+	md, err := svc.metadata(ctx, RuleKind, kuiperID)
+	if err != nil {
+		return Result{}, err
+	}
+	switch {
+	case md == nil:
+		return Result{}, errors.Wrap(svcerr.ErrNotFound, errNotDeleted)
+	case !md.deleted():
+		return Result{}, errors.Wrap(svcerr.ErrConflict, errNotDeleted)
+	case time.Since(md.DeletedAt) > svc.retention:
+		return Result{}, errors.Wrap(svcerr.ErrNotFound, errRetentionEnded)
+	}
+
+	// Drafts aren't deployed, so they have neither the subscriptions nor
+	// the state.
+	if !md.Draft {
+		// Rules created before their definitions were stored have no
+		// notification contacts to subscribe.
+		if md.Definition != "" {
+			var rule Rule
+			if err := json.Unmarshal([]byte(md.Definition), &rule); err != nil {
+				return Result{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+			}
+			rule.ID = id
+			if err := svc.subscribe(token, rule); err != nil {
+				_ = svc.unsubscribe(token, rule)
+				return Result{}, err
+			}
+		}
+		if !md.Stopped {
+			if _, err := svc.engine.ControlRule(ctx, kuiperID, "start"); err != nil {
+				return Result{}, err
+			}
+		}
+	}
+	md.DeletedAt = time.Time{}
+	md.UpdatedAt = time.Now().UTC()
+	if !md.Draft && !md.Stopped {
+		md.LastStartedAt = md.UpdatedAt
+	}
+	if err := svc.repo.Save(ctx, RuleKind, kuiperID, *md); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if err := svc.audit(ctx, userID, owner, RuleKind, id, AuditRestore, "", md.Definition); err != nil {
+		return Result{}, err
+	}
+	res := Result{Status: http.StatusOK, Message: fmt.Sprintf("Rule %s is restored.", id)}
+
+	return res.owned(id, owner), nil
+}
+
+// softDelete stops the rule with the given Kuiper ID and marks it deleted,
+// keeping it in Kuiper until it's restored or purged. The subscriptions of
+// the rule's notification actions are removed, since the purge job has no
+// token to remove them with, and subscribed again once the rule is restored.
+func (svc *reService) softDelete(ctx context.Context, token, kuiperID, id string, md Metadata) (Result, error) {
+	if !md.Draft {
+		old, err := svc.notifications(ctx, prefix(md.Owner), id)
+		if err != nil {
+			return Result{}, err
+		}
+		if _, err := svc.engine.ControlRule(ctx, kuiperID, "stop"); err != nil {
+			return Result{}, err
+		}
+		if err := svc.unsubscribe(token, old); err != nil {
+			return Result{}, err
+		}
+	}
+	md.DeletedAt = time.Now().UTC()
+	if err := svc.repo.Save(ctx, RuleKind, kuiperID, md); err != nil {
+		return Result{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	until := md.DeletedAt.Add(svc.retention).Format(time.RFC3339)
+	res := Result{Status: http.StatusOK, Message: fmt.Sprintf("Rule %s is deleted and can be restored until %s.", id, until)}
+
+	return res.owned(id, md.Owner), nil
+}
+
+// purgeRule removes the deleted rule with the given Kuiper ID permanently.
+// Its subscriptions were removed when it was deleted.
+func (svc *reService) purgeRule(ctx context.Context, kuiperID, id string, md Metadata) (Result, error) {
+	if err := svc.remove(ctx, RuleKind, kuiperID); err != nil {
+		return Result{}, err
+	}
+	if err := svc.removeShares(ctx, RuleKind, kuiperID); err != nil {
+		return Result{}, err
+	}
+	res := Result{Status: http.StatusOK, Message: fmt.Sprintf("Rule %s is purged.", id)}
+
+	return res.owned(id, md.Owner), nil
+}
+
+// checkDeleted reports the rule with the given Kuiper ID as missing if it's
+// deleted.
+func (svc *reService) checkDeleted(ctx context.Context, kuiperID string) error {
+	md, err := svc.metadata(ctx, RuleKind, kuiperID)
+	if err != nil {
+		return err
+	}
+	if md != nil && md.deleted() {
+		return errors.Wrap(svcerr.ErrNotFound, errRuleDeleted)
+	}
+
+	return nil
+}
+
+// deleted reports whether the entity is deleted and waits to be purged.
+func (md Metadata) deleted() bool {
+	return !md.DeletedAt.IsZero()
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSoftDeleteRule(t *testing.T) {
+	repo := mocks.NewRepository()
+	svc, k, auth, _ := newServiceWithRepo(t, re.Config{DeleteRetention: time.Hour}, re.Notifiers{}, repo)
+
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	rule := re.Rule{
+		ID:      "alarm",
+		SQL:     "SELECT * FROM stream WHERE v > 30",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+	}
+	_, err := svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+
+	_, err = svc.RestoreRule(context.Background(), validToken, rule.ID)
+	assert.True(t, errors.Contains(err, svcerr.ErrConflict), fmt.Sprintf("restore rule that isn't deleted: expected %s got %s\n", svcerr.ErrConflict, err))
+
+	_, err = svc.DeleteRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("delete rule: expected no error got %s\n", err))
+	_, ok := k.rules[userPrefix+rule.ID]
+	assert.True(t, ok, "expected deleted rule to be kept in Kuiper")
+	assert.True(t, k.stopped[userPrefix+rule.ID], "expected deleted rule to be stopped")
+
+	_, err = svc.ViewRule(context.Background(), validToken, rule.ID)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("view deleted rule: expected %s got %s\n", svcerr.ErrNotFound, err))
+	_, err = svc.StartRule(context.Background(), validToken, rule.ID)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("start deleted rule: expected %s got %s\n", svcerr.ErrNotFound, err))
+
+	page, err := svc.ListRules(context.Background(), validToken, re.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list rules: expected no error got %s\n", err))
+	for _, r := range page.Rules {
+		assert.NotEqual(t, rule.ID, r.ID, "expected deleted rule not to be listed")
+	}
+	page, err = svc.ListRules(context.Background(), validToken, re.PageMetadata{Limit: 10, Deleted: true})
+	assert.Nil(t, err, fmt.Sprintf("list deleted rules: expected no error got %s\n", err))
+	if assert.Len(t, page.Rules, 1, "expected one deleted rule") {
+		assert.Equal(t, rule.ID, page.Rules[0].ID, fmt.Sprintf("expected deleted rule %s got %s\n", rule.ID, page.Rules[0].ID))
+		assert.Equal(t, re.RuleDeleted, page.Rules[0].Status, fmt.Sprintf("expected status %s got %s\n", re.RuleDeleted, page.Rules[0].Status))
+	}
+
+	_, err = svc.RestoreRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("restore rule: expected no error got %s\n", err))
+	assert.False(t, k.stopped[userPrefix+rule.ID], "expected restored rule to be started")
+	_, err = svc.ViewRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("view restored rule: expected no error got %s\n", err))
+
+	_, err = svc.DeleteRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("delete rule: expected no error got %s\n", err))
+	_, err = svc.DeleteRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("delete deleted rule: expected no error got %s\n", err))
+	_, ok = k.rules[userPrefix+rule.ID]
+	assert.False(t, ok, "expected deleted rule deleted again to be purged")
+	_, err = svc.RestoreRule(context.Background(), validToken, rule.ID)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("restore purged rule: expected %s got %s\n", svcerr.ErrNotFound, err))
+}
+
+func TestPurge(t *testing.T) {
+	k, url := newKuiper(t)
+	repo := mocks.NewRepository()
+	now := time.Now().UTC()
+	k.rules[userPrefix+"expired"] = re.Rule{ID: userPrefix + "expired"}
+	k.rules[userPrefix+"recent"] = re.Rule{ID: userPrefix + "recent"}
+	err := repo.Save(context.Background(), re.RuleKind, userPrefix+"expired", re.Metadata{Owner: userID, DeletedAt: now.Add(-2 * time.Hour)})
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	err = repo.Save(context.Background(), re.RuleKind, userPrefix+"recent", re.Metadata{Owner: userID, DeletedAt: now.Add(-time.Minute)})
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+	err = repo.Save(context.Background(), re.RuleKind, userPrefix+"rule", re.Metadata{Owner: userID})
+	assert.Nil(t, err, fmt.Sprintf("save metadata: expected no error got %s\n", err))
+
+	cfg := re.Config{URL: url, DeleteRetention: time.Hour}
+	p := re.NewPurger(re.NewKuiper(cfg), cfg, repo)
+	purged, err := p.Purge(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("purge: expected no error got %s\n", err))
+	if assert.Len(t, purged, 1, "expected one purged rule") {
+		assert.Equal(t, userPrefix+"expired", purged[0].Name, fmt.Sprintf("expected purged rule %s got %s\n", userPrefix+"expired", purged[0].Name))
+		assert.Empty(t, purged[0].Error, fmt.Sprintf("expected no purge error got %s\n", purged[0].Error))
+	}
+	_, ok := k.rules[userPrefix+"expired"]
+	assert.False(t, ok, "expected expired rule to be removed from Kuiper")
+	_, ok = k.rules[userPrefix+"recent"]
+	assert.True(t, ok, "expected recently deleted rule to be kept")
+	_, ok = k.rules[userPrefix+"rule"]
+	assert.True(t, ok, "expected rule that isn't deleted to be kept")
+}
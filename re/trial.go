@@ -0,0 +1,238 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	trialPath = "/ruletest"
+	// maxTrialResults limits the number of results collected by the trial,
+	// so rules emitting results without end can't exhaust the memory.
+	maxTrialResults = 1000
+)
+
+var (
+	errMissingSamples   = errors.New("missing sample messages")
+	errSampleStream     = errors.New("rule doesn't read from the sample stream")
+	errTrialUnsupported = errors.New("rule trials require Kuiper 1.11 or later")
+	errTrialOutput      = errors.New("failed to read rule trial output")
+)
+
+// TrialConfig defines how long the rule trial waits for the rule results.
+// The trial ends once the rule produces no results for Idle or after Timeout.
+type TrialConfig struct {
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"10s"`
+	Idle    time.Duration `env:"IDLE"    envDefault:"1s"`
+}
+
+// RuleTrial contains the rule to test and the sample messages fed to it,
+// keyed by the names of the streams the rule reads from. Rule actions
+// aren't executed, so the rule needs only the ID and the SQL.
+type RuleTrial struct {
+	Rule    Rule                                `json:"rule"`
+	Samples map[string][]map[string]interface{} `json:"samples"`
+}
+
+// TrialResult contains the results the rule produced from the sample
+// messages, in the order they were produced.
+type TrialResult struct {
+	Results []map[string]interface{} `json:"results"`
+}
+
+// EngineTrial is the rule test in the Kuiper rule test format, which
+// replaces the rule streams with the mock sources emitting the sample
+// messages.
+type EngineTrial struct {
+	ID         string                `json:"id"`
+	SQL        string                `json:"sql"`
+	MockSource map[string]MockSource `json:"mockSource"`
+	SinkProps  map[string]any        `json:"sinkProps"`
+}
+
+// MockSource emits the sample messages in place of the stream.
+type MockSource struct {
+	Data     []map[string]interface{} `json:"data"`
+	Interval int                      `json:"interval"`
+	Loop     bool                     `json:"loop"`
+}
+
+func (svc *reService) TestRule(ctx context.Context, token string, trial RuleTrial) (TrialResult, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return TrialResult{}, err
+	}
+	rule := trial.Rule
+	// The rule is tested in the namespace it would be created in, so the
+	// group rules read from the group streams.
+	owner, id, err := svc.namespace(ctx, token, userID, rule.ID)
+	if err != nil {
+		return TrialResult{}, err
+	}
+	if err := validateName(id); err != nil {
+		return TrialResult{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if len(trial.Samples) == 0 {
+		return TrialResult{}, errors.Wrap(svcerr.ErrMalformedEntity, errMissingSamples)
+	}
+
+	pfx := prefix(owner)
+	streams := make(map[string]bool)
+	sql, err := rewriteStreams(rule.SQL, func(name string) string {
+		streams[name] = true
+		return pfx + name
+	})
+	if err != nil {
+		return TrialResult{}, err
+	}
+	kt := EngineTrial{
+		SQL:        sql,
+		MockSource: make(map[string]MockSource),
+		SinkProps:  map[string]any{"sendSingle": true},
+	}
+	for name, msgs := range trial.Samples {
+		if !streams[name] {
+			return TrialResult{}, errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(errSampleStream, errors.New(name)))
+		}
+		kt.MockSource[pfx+name] = MockSource{Data: msgs, Interval: 1}
+	}
+	if kt.ID, err = trialID(pfx + id); err != nil {
+		return TrialResult{}, err
+	}
+
+	return svc.engine.RunTrial(ctx, kt)
+}
+
+// trialID returns the unique ID of the trial of the rule with the given
+// Kuiper ID. Trials of the same rule run concurrently, so each one has its
+// own ID.
+func trialID(ruleID string) (string, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return "", errors.Wrap(ErrKuiperServer, err)
+	}
+
+	return ruleID + "_" + strings.ReplaceAll(id.String(), "-", ""), nil
+}
+
+// RunTrial creates the Kuiper rule test, collects its results from the
+// Kuiper WebSocket and removes the test.
+func (k *kuiperEngine) RunTrial(ctx context.Context, kt EngineTrial) (TrialResult, error) {
+	res, err := k.send(ctx, http.MethodPost, trialPath, kt)
+	switch {
+	case errors.Contains(err, svcerr.ErrNotFound):
+		return TrialResult{}, errors.Wrap(ErrKuiperServer, errTrialUnsupported)
+	case errors.Contains(err, ErrNotSupported):
+		return TrialResult{}, errors.Wrap(ErrNotSupported, errTrialUnsupported)
+	case err != nil:
+		return TrialResult{}, err
+	}
+	defer func() {
+		_, _ = k.send(context.WithoutCancel(ctx), http.MethodDelete, trialPath+"/"+kt.ID, nil)
+	}()
+	var created struct {
+		Port int `json:"port"`
+	}
+	if err := json.Unmarshal([]byte(res.Message), &created); err != nil {
+		return TrialResult{}, errors.Wrap(errReadResponse, err)
+	}
+
+	addr, err := k.trialURL(created.Port, kt.ID)
+	if err != nil {
+		return TrialResult{}, errors.Wrap(ErrKuiperServer, err)
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, addr, nil)
+	if err != nil {
+		return TrialResult{}, errors.Wrap(ErrKuiperServer, err)
+	}
+	defer conn.Close()
+	// The trial is started once the connection is open, so no result is
+	// sent before the service reads them.
+	if _, err := k.send(ctx, http.MethodPost, trialPath+"/"+kt.ID+"/start", nil); err != nil {
+		return TrialResult{}, err
+	}
+
+	return k.trialResults(ctx, conn)
+}
+
+// trialResults reads the results until the rule stays idle or the trial
+// times out. The results read before the context deadline are returned.
+func (k *kuiperEngine) trialResults(ctx context.Context, conn *websocket.Conn) (TrialResult, error) {
+	end := time.Now().Add(k.trial.Timeout)
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(end) {
+		end = deadline
+	}
+
+	results := []map[string]interface{}{}
+	for len(results) < maxTrialResults {
+		deadline := time.Now().Add(k.trial.Idle)
+		if deadline.After(end) {
+			deadline = end
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return TrialResult{}, errors.Wrap(errTrialOutput, err)
+		}
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			if nerr, ok := err.(net.Error); ok && nerr.Timeout() {
+				break
+			}
+			return TrialResult{}, errors.Wrap(errTrialOutput, err)
+		}
+		res, err := decodeResults(msg)
+		if err != nil {
+			return TrialResult{}, errors.Wrap(errTrialOutput, err)
+		}
+		results = append(results, res...)
+	}
+
+	return TrialResult{Results: results}, nil
+}
+
+// trialURL returns the URL of the WebSocket Kuiper sends the results of
+// the trial to. The WebSocket listens on the Kuiper host.
+func (k *kuiperEngine) trialURL(port int, id string) (string, error) {
+	u, err := url.Parse(k.host)
+	if err != nil {
+		return "", err
+	}
+	scheme := "ws"
+	if u.Scheme == "https" {
+		scheme = "wss"
+	}
+	ws := url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(u.Hostname(), fmt.Sprint(port)),
+		Path:   "/test/" + id,
+	}
+
+	return ws.String(), nil
+}
+
+// decodeResults decodes the single result or the array of results.
+func decodeResults(msg []byte) ([]map[string]interface{}, error) {
+	var results []map[string]interface{}
+	if err := json.Unmarshal(msg, &results); err == nil {
+		return results, nil
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return nil, err
+	}
+
+	return []map[string]interface{}{result}, nil
+}
@@ -0,0 +1,217 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTrial struct {
+	ID         string `json:"id"`
+	SQL        string `json:"sql"`
+	MockSource map[string]struct {
+		Data []map[string]interface{} `json:"data"`
+	} `json:"mockSource"`
+}
+
+// trialKuiper is the fake Kuiper rule test API. Started trials send every
+// sample message as the rule result over the WebSocket.
+type trialKuiper struct {
+	mu      sync.Mutex
+	ws      *httptest.Server
+	trials  map[string]mockTrial
+	started map[string]chan struct{}
+	deleted []string
+}
+
+func newTrialKuiper(t *testing.T) *trialKuiper {
+	tk := &trialKuiper{
+		trials:  map[string]mockTrial{},
+		started: map[string]chan struct{}{},
+	}
+	upgrader := websocket.Upgrader{}
+	tk.ws = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/test/")
+		tk.mu.Lock()
+		trial, ok := tk.trials[id]
+		started := tk.started[id]
+		tk.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			return
+		}
+		for _, src := range trial.MockSource {
+			for _, msg := range src.Data {
+				if err := conn.WriteJSON(msg); err != nil {
+					return
+				}
+			}
+		}
+		// Keep the connection open like Kuiper does until the trial is deleted.
+		_, _, _ = conn.ReadMessage()
+	}))
+	t.Cleanup(tk.ws.Close)
+
+	return tk
+}
+
+func (tk *trialKuiper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		var trial mockTrial
+		_ = json.NewDecoder(r.Body).Decode(&trial)
+		tk.trials[trial.ID] = trial
+		tk.started[trial.ID] = make(chan struct{})
+		u, _ := url.Parse(tk.ws.URL)
+		fmt.Fprintf(w, `{"id":%q,"port":%s}`, trial.ID, u.Port())
+	case len(parts) == 3 && parts[2] == "start":
+		close(tk.started[parts[1]])
+		fmt.Fprintf(w, "Test rule %s was started", parts[1])
+	case r.Method == http.MethodDelete:
+		tk.deleted = append(tk.deleted, parts[1])
+		fmt.Fprintf(w, "Test rule %s was deleted", parts[1])
+	}
+}
+
+func TestTestRule(t *testing.T) {
+	cfg := re.Config{Trial: re.TrialConfig{Timeout: 2 * time.Second, Idle: 200 * time.Millisecond}}
+	svc, k, auth, _ := newServiceWithConfig(t, cfg, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	authCall2 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: otherToken}).Return(&magistrala.IdentityRes{UserId: otherUserID}, nil)
+	defer authCall2.Unset()
+	authCall3 := authorizeMember(auth, validToken, groupID, true)
+	defer authCall3.Unset()
+	authCall4 := authorizeMember(auth, otherToken, groupID, false)
+	defer authCall4.Unset()
+
+	samples := []map[string]interface{}{{"v": 5.0}, {"v": 15.0}}
+	trial := re.RuleTrial{
+		Rule:    re.Rule{ID: "alarm", SQL: "SELECT * FROM stream WHERE v > 10"},
+		Samples: map[string][]map[string]interface{}{"stream": samples},
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		trial       re.RuleTrial
+		unsupported bool
+		prefix      string
+		results     []map[string]interface{}
+		err         error
+	}{
+		{
+			desc:    "test rule",
+			token:   validToken,
+			trial:   trial,
+			prefix:  userPrefix,
+			results: samples,
+		},
+		{
+			desc:    "test group rule",
+			token:   validToken,
+			trial:   re.RuleTrial{Rule: re.Rule{ID: groupID + ":alarm", SQL: trial.Rule.SQL}, Samples: trial.Samples},
+			prefix:  groupPrefix,
+			results: samples,
+		},
+		{
+			desc:  "test group rule as non-member",
+			token: otherToken,
+			trial: re.RuleTrial{Rule: re.Rule{ID: groupID + ":alarm", SQL: trial.Rule.SQL}, Samples: trial.Samples},
+			err:   svcerr.ErrAuthorization,
+		},
+		{
+			desc:  "test rule with invalid token",
+			token: invalidToken,
+			trial: trial,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "test rule with invalid ID",
+			token: validToken,
+			trial: re.RuleTrial{Rule: re.Rule{ID: "1alarm", SQL: trial.Rule.SQL}, Samples: trial.Samples},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "test rule without samples",
+			token: validToken,
+			trial: re.RuleTrial{Rule: trial.Rule},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "test rule with samples of unread stream",
+			token: validToken,
+			trial: re.RuleTrial{Rule: trial.Rule, Samples: map[string][]map[string]interface{}{"other": samples}},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "test rule with invalid SQL",
+			token: validToken,
+			trial: re.RuleTrial{Rule: re.Rule{ID: "alarm", SQL: "SELECT * FROM stream WHERE v = 'on"}, Samples: trial.Samples},
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "test rule with Kuiper that can't test rules",
+			token:       validToken,
+			trial:       trial,
+			unsupported: true,
+			err:         re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		tk := newTrialKuiper(t)
+		k.trial = tk
+		if tc.unsupported {
+			k.trial = nil
+		}
+		res, err := svc.TestRule(context.Background(), tc.token, tc.trial)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, tc.results, res.Results, fmt.Sprintf("%s: expected results %v got %v\n", tc.desc, tc.results, res.Results))
+		tk.mu.Lock()
+		assert.Len(t, tk.trials, 1, fmt.Sprintf("%s: expected a single trial", tc.desc))
+		for id, kt := range tk.trials {
+			assert.True(t, strings.HasPrefix(id, tc.prefix+"alarm_"), fmt.Sprintf("%s: expected trial ID with owner prefix got %s\n", tc.desc, id))
+			assert.Equal(t, "SELECT * FROM "+tc.prefix+"stream WHERE v > 10", kt.SQL, fmt.Sprintf("%s: expected namespaced SQL got %s\n", tc.desc, kt.SQL))
+			assert.Contains(t, kt.MockSource, tc.prefix+"stream", fmt.Sprintf("%s: expected namespaced mock source\n", tc.desc))
+			assert.Equal(t, []string{id}, tk.deleted, fmt.Sprintf("%s: expected trial %s to be deleted got %v\n", tc.desc, id, tk.deleted))
+		}
+		tk.mu.Unlock()
+	}
+}
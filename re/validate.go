@@ -0,0 +1,158 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+const validatePath = "/rules/validate"
+
+// Diagnostic is the problem found in the rule. Field is the rule field the
+// diagnostic refers to, or empty if it refers to the whole rule. Position
+// is the 1-based byte offset in the rule SQL, set only for the SQL
+// diagnostics that point to the malformed part of the SQL.
+type Diagnostic struct {
+	Field    string `json:"field,omitempty"`
+	Position int    `json:"position,omitempty"`
+	Message  string `json:"message"`
+}
+
+// RuleValidation is the result of the rule validation. The rule is valid if
+// there are no diagnostics.
+type RuleValidation struct {
+	Valid       bool         `json:"valid"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// err returns the error describing all the diagnostics.
+func (v RuleValidation) err() error {
+	msgs := make([]string, len(v.Diagnostics))
+	for i, d := range v.Diagnostics {
+		msgs[i] = d.Message
+		if d.Field != "" {
+			msgs[i] = d.Field + ": " + d.Message
+		}
+	}
+
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+func (svc *reService) ValidateRule(ctx context.Context, token string, rule Rule) (RuleValidation, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return RuleValidation{}, err
+	}
+	// The shared rule is validated in the namespace of its owner.
+	owner := userID
+	if strings.Contains(rule.ID, ownerSeparator) {
+		if owner, rule.ID, err = svc.resolve(ctx, token, userID, RuleKind, rule.ID, ViewAccess); err != nil {
+			return RuleValidation{}, err
+		}
+	}
+	pfx := prefix(owner)
+
+	diags := []Diagnostic{}
+	if err := validateName(rule.ID); err != nil {
+		diags = append(diags, Diagnostic{Field: "id", Message: err.Error()})
+	}
+	sqlDiags, err := svc.sqlDiagnostics(ctx, rule.SQL, pfx)
+	if err != nil {
+		return RuleValidation{}, err
+	}
+	diags = append(diags, sqlDiags...)
+	if len(rule.Actions) == 0 {
+		diags = append(diags, Diagnostic{Field: "actions", Message: errMissingActions.Error()})
+	}
+	for _, f := range svc.checkActions(ctx, token, rule.Actions) {
+		diags = append(diags, Diagnostic{Field: fmt.Sprintf("actions[%d]", f.index), Message: f.message})
+	}
+	if err := rule.Options.validate(); err != nil {
+		diags = append(diags, Diagnostic{Field: "options", Message: err.Error()})
+	}
+
+	// Kuiper stops at the first problem, so it only checks the rules
+	// without local diagnostics, e.g. for unknown SQL functions.
+	if len(diags) == 0 {
+		kr, err := svc.namespaceRule(ctx, rule, owner)
+		if err != nil {
+			return RuleValidation{}, err
+		}
+		if diags, err = svc.kuiperDiagnostics(ctx, kr, pfx); err != nil {
+			return RuleValidation{}, err
+		}
+	}
+
+	return RuleValidation{Valid: len(diags) == 0, Diagnostics: diags}, nil
+}
+
+// sqlDiagnostics parses the rule SQL and checks that the streams it reads
+// from belong to the user with the given prefix.
+func (svc *reService) sqlDiagnostics(ctx context.Context, sql, pfx string) ([]Diagnostic, error) {
+	tokens, err := tokenize(sql)
+	var refs []int
+	if err == nil {
+		refs, err = streamRefs(tokens)
+	}
+	if err != nil {
+		d := Diagnostic{Field: "sql", Message: err.Error()}
+		if serr, ok := err.(*sqlError); ok {
+			d.Position = serr.pos + 1
+			d.Message = serr.err.Error()
+		}
+		return []Diagnostic{d}, nil
+	}
+
+	names, err := svc.ownedNames(ctx, StreamKind, pfx)
+	if err != nil {
+		return nil, err
+	}
+	// Rules join the streams with the tables.
+	tables, err := svc.ownedNames(ctx, TableKind, pfx)
+	if err != nil {
+		return nil, err
+	}
+	names = append(names, tables...)
+	streams := make(map[string]bool, len(names))
+	for _, name := range names {
+		streams[name] = true
+	}
+	var diags []Diagnostic
+	for _, r := range refs {
+		name := tokens[r].name()
+		if streams[name] {
+			continue
+		}
+		// Every missing stream is reported once, at its first reference.
+		streams[name] = true
+		diags = append(diags, Diagnostic{
+			Field:    "sql",
+			Position: tokens[r].start + 1,
+			Message:  fmt.Sprintf("stream %s doesn't exist", name),
+		})
+	}
+
+	return diags, nil
+}
+
+// kuiperDiagnostics validates the rule with Kuiper without creating it.
+// Kuiper versions that can't validate rules answer with 404 or aren't sent
+// the request at all, in which case the rule is considered valid. The owner prefix is removed from the Kuiper
+// messages.
+func (svc *reService) kuiperDiagnostics(ctx context.Context, kr EngineRule, pfx string) ([]Diagnostic, error) {
+	switch err := svc.engine.ValidateRule(ctx, kr); {
+	case err == nil, errors.Contains(err, svcerr.ErrNotFound), errors.Contains(err, ErrNotSupported):
+		return []Diagnostic{}, nil
+	case errors.Contains(err, svcerr.ErrMalformedEntity):
+		_, cause := errors.Unwrap(err)
+		return []Diagnostic{{Message: strings.ReplaceAll(cause.Error(), pfx, "")}}, nil
+	default:
+		return nil, err
+	}
+}
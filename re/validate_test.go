@@ -0,0 +1,152 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestValidateRule(t *testing.T) {
+	svc, k, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(nil, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+	channelCall1 := authorizeChannel(auth, validToken, "denied", false)
+	defer channelCall1.Unset()
+
+	valid := re.Rule{
+		ID:      "alarm",
+		SQL:     "SELECT * FROM stream WHERE v > 10",
+		Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}},
+	}
+	withRule := func(f func(rule *re.Rule)) re.Rule {
+		rule := valid
+		f(&rule)
+		return rule
+	}
+
+	cases := []struct {
+		desc    string
+		token   string
+		rule    re.Rule
+		failure int
+		diags   []re.Diagnostic
+		err     error
+	}{
+		{
+			desc:  "validate valid rule",
+			token: validToken,
+			rule:  valid,
+			diags: []re.Diagnostic{},
+		},
+		{
+			desc:  "validate rule with invalid token",
+			token: invalidToken,
+			rule:  valid,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "validate rule with unterminated string",
+			token: validToken,
+			rule:  withRule(func(rule *re.Rule) { rule.SQL = "SELECT * FROM stream WHERE v = 'on" }),
+			diags: []re.Diagnostic{{Field: "sql", Position: 32, Message: "unterminated string or quoted identifier"}},
+		},
+		{
+			desc:  "validate rule with missing stream name",
+			token: validToken,
+			rule:  withRule(func(rule *re.Rule) { rule.SQL = "SELECT * FROM WHERE v > 10" }),
+			diags: []re.Diagnostic{{Field: "sql", Position: 15, Message: "expected stream name"}},
+		},
+		{
+			desc:  "validate rule reading from unknown streams",
+			token: validToken,
+			rule:  withRule(func(rule *re.Rule) { rule.SQL = "SELECT * FROM stream JOIN missing ON missing.id = stream.id" }),
+			diags: []re.Diagnostic{{Field: "sql", Position: 27, Message: "stream missing doesn't exist"}},
+		},
+		{
+			desc:  "validate rule reading from other user's stream",
+			token: validToken,
+			rule:  withRule(func(rule *re.Rule) { rule.SQL = "SELECT * FROM " + otherPrefix + "stream" }),
+			diags: []re.Diagnostic{{Field: "sql", Position: 15, Message: fmt.Sprintf("stream %sstream doesn't exist", otherPrefix)}},
+		},
+		{
+			desc:  "validate rule with all problems",
+			token: validToken,
+			rule: re.Rule{
+				ID:  "1alarm",
+				SQL: "SELECT 1",
+				Actions: []re.Action{
+					{Mainflux: &re.MainfluxSink{Channel: channelID}},
+					{Mainflux: &re.MainfluxSink{Channel: channelID, Subtopic: "a..b"}},
+					{Mainflux: &re.MainfluxSink{Channel: "denied"}},
+					{},
+				},
+				Options: &re.RuleOptions{QoS: 3},
+			},
+			diags: []re.Diagnostic{
+				{Field: "id", Message: "name must start with a letter or underscore and contain only letters, digits and underscores"},
+				{Field: "sql", Message: "rule SQL doesn't select from any stream"},
+				{Field: "actions[1]", Message: "subtopic a..b: malformed subtopic"},
+				{Field: "actions[2]", Message: "channel denied: " + svcerr.ErrAuthorization.Error()},
+				{Field: "actions[3]", Message: "missing sink"},
+				{Field: "options", Message: "qos must be 0 (at most once), 1 (at least once) or 2 (exactly once)"},
+			},
+		},
+		{
+			desc:  "validate rule without actions",
+			token: validToken,
+			rule:  withRule(func(rule *re.Rule) { rule.Actions = nil }),
+			diags: []re.Diagnostic{{Field: "actions", Message: "missing actions"}},
+		},
+		{
+			desc:    "validate rule rejected by Kuiper",
+			token:   validToken,
+			rule:    valid,
+			failure: http.StatusBadRequest,
+			diags:   []re.Diagnostic{{Message: http.StatusText(http.StatusBadRequest)}},
+		},
+		{
+			desc:    "validate rule with Kuiper that can't validate rules",
+			token:   validToken,
+			rule:    valid,
+			failure: http.StatusNotFound,
+			diags:   []re.Diagnostic{},
+		},
+		{
+			desc:    "validate rule with failing Kuiper",
+			token:   validToken,
+			rule:    valid,
+			failure: http.StatusInternalServerError,
+			err:     re.ErrKuiperServer,
+		},
+	}
+
+	for _, tc := range cases {
+		delete(k.failures, "/rules/validate")
+		if tc.failure != 0 {
+			k.failures["/rules/validate"] = tc.failure
+		}
+		res, err := svc.ValidateRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.diags, res.Diagnostics, fmt.Sprintf("%s: expected diagnostics %v got %v\n", tc.desc, tc.diags, res.Diagnostics))
+			assert.Equal(t, len(tc.diags) == 0, res.Valid, fmt.Sprintf("%s: expected valid %t got %t\n", tc.desc, len(tc.diags) == 0, res.Valid))
+		}
+	}
+	_, created := k.rules[userPrefix+"alarm"]
+	assert.False(t, created, "validated rule must not be created")
+}
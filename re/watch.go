@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WatchConfig defines the rule state watches. The rule states are polled
+// from Kuiper every Interval for each watch.
+type WatchConfig struct {
+	Interval time.Duration `env:"INTERVAL" envDefault:"5s"`
+}
+
+// RuleStateChange is the state transition of the rule, where the states
+// are the ones the engine statistics count the rules in. From is empty for
+// the rules the watch finds, including the rules existing when the watch
+// starts, and To is empty for the removed rules. Error is the reason
+// Kuiper stopped the rule with, for the rules that changed to the error
+// state.
+type RuleStateChange struct {
+	Rule  string    `json:"rule"`
+	From  string    `json:"from,omitempty"`
+	To    string    `json:"to,omitempty"`
+	Error string    `json:"error,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+func (svc *reService) WatchRules(ctx context.Context, token string) (<-chan RuleStateChange, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	// The first poll reports the initial states, and its failure is
+	// returned, so the unreachable Kuiper isn't mistaken for a user without
+	// rules.
+	states := make(map[string]string)
+	initial, err := svc.pollStates(ctx, userID, states)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := svc.watch.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	changes := make(chan RuleStateChange, len(initial))
+	for _, c := range initial {
+		changes <- c
+	}
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			// Failed polls are skipped, so Kuiper outages don't end the
+			// watch and don't report the rules as removed.
+			polled, err := svc.pollStates(ctx, userID, states)
+			if err != nil {
+				continue
+			}
+			for _, c := range polled {
+				select {
+				case changes <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// pollStates lists the states of the user's rules and returns the changes
+// since the given states, which are updated in place, sorted by rule.
+func (svc *reService) pollStates(ctx context.Context, userID string, states map[string]string) ([]RuleStateChange, error) {
+	all, err := svc.engine.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	pfx := prefix(userID)
+	seen := make(map[string]bool, len(states))
+	var changes []RuleStateChange
+	for _, r := range all {
+		if !strings.HasPrefix(r.ID, pfx) {
+			continue
+		}
+		id := strings.TrimPrefix(r.ID, pfx)
+		seen[id] = true
+		state := statsState(r.Status)
+		if prev, ok := states[id]; ok && prev == state {
+			continue
+		}
+		c := RuleStateChange{Rule: id, From: states[id], To: state, Time: now}
+		if state == StatsError {
+			_, c.Error, _ = strings.Cut(r.Status, ":")
+			c.Error = strings.TrimSpace(c.Error)
+		}
+		states[id] = state
+		changes = append(changes, c)
+	}
+	for id, state := range states {
+		if !seen[id] {
+			delete(states, id)
+			changes = append(changes, RuleStateChange{Rule: id, From: state, Time: now})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Rule < changes[j].Rule
+	})
+
+	return changes, nil
+}
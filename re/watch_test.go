@@ -0,0 +1,82 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWatchRules(t *testing.T) {
+	k, url := newKuiper(t)
+	k.rules[userPrefix+"alarm"] = re.Rule{ID: userPrefix + "alarm"}
+	k.rules[userPrefix+"report"] = re.Rule{ID: userPrefix + "report"}
+	k.stopped[userPrefix+"report"] = true
+	k.rules[otherPrefix+"alarm"] = re.Rule{ID: otherPrefix + "alarm"}
+	auth := new(authmocks.AuthClient)
+	svc := re.New(re.Config{URL: url, Watch: re.WatchConfig{Interval: 10 * time.Millisecond}}, auth, new(sdkmocks.SDK), re.Notifiers{}, nil, mocks.NewRepository())
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	authCall1 := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: invalidToken}).Return(&magistrala.IdentityRes{}, svcerr.ErrAuthentication)
+	defer authCall1.Unset()
+
+	_, err := svc.WatchRules(context.Background(), invalidToken)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthentication), fmt.Sprintf("watch rules with invalid token: expected %s got %s\n", svcerr.ErrAuthentication, err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := svc.WatchRules(ctx, validToken)
+	assert.Nil(t, err, fmt.Sprintf("watch rules: expected no error got %s\n", err))
+	next := func(desc string) re.RuleStateChange {
+		select {
+		case c := <-changes:
+			c.Time = time.Time{}
+			return c
+		case <-time.After(time.Second):
+			t.Fatalf("%s: state change wasn't received", desc)
+			return re.RuleStateChange{}
+		}
+	}
+
+	// The current states are sent first, and the other user's rules aren't
+	// watched.
+	assert.Equal(t, re.RuleStateChange{Rule: "alarm", To: re.StatsRunning}, next("initial states"))
+	assert.Equal(t, re.RuleStateChange{Rule: "report", To: re.StatsStopped}, next("initial states"))
+	assert.Equal(t, re.RuleStateChange{Rule: "rule", To: re.StatsRunning}, next("initial states"))
+
+	k.mu.Lock()
+	k.failed[userPrefix+"alarm"] = "connection refused."
+	k.mu.Unlock()
+	assert.Equal(t, re.RuleStateChange{Rule: "alarm", From: re.StatsRunning, To: re.StatsError, Error: "connection refused."}, next("errored rule"))
+
+	k.mu.Lock()
+	delete(k.stopped, userPrefix+"report")
+	k.mu.Unlock()
+	assert.Equal(t, re.RuleStateChange{Rule: "report", From: re.StatsStopped, To: re.StatsRunning}, next("started rule"))
+
+	k.mu.Lock()
+	delete(k.rules, userPrefix+"alarm")
+	k.mu.Unlock()
+	assert.Equal(t, re.RuleStateChange{Rule: "alarm", From: re.StatsError}, next("removed rule"))
+
+	cancel()
+	select {
+	case _, ok := <-changes:
+		assert.False(t, ok, "expected closed channel once the context is done")
+	case <-time.After(time.Second):
+		t.Fatal("channel wasn't closed once the context is done")
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/gofrs/uuid"
+)
+
+// Rule lifecycle events the webhooks are called on. Rules are errored when
+// the failing rules check finds that Kuiper stopped them on error.
+const (
+	EventRuleCreated = "rule.created"
+	EventRuleStarted = "rule.started"
+	EventRuleStopped = "rule.stopped"
+	EventRuleErrored = "rule.errored"
+)
+
+// Headers of the webhook requests. The signature is the hex encoded
+// HMAC-SHA256 of the request body keyed with the webhook secret, prefixed
+// with "sha256=".
+const (
+	WebhookEventHeader     = "X-RE-Event"
+	WebhookSignatureHeader = "X-RE-Signature-256"
+)
+
+// webhookSecretSize is the size in bytes of the generated webhook secrets.
+const webhookSecretSize = 32
+
+var (
+	errWebhookEvent   = errors.New("unknown webhook event")
+	errWebhookAddress = errors.New("webhook must not be loopback, link-local or private address")
+)
+
+// WebhooksConfig defines the calls of the webhooks and the alert webhooks,
+// which are made from inside the service network. Unless AllowPrivate is
+// set, e.g. to call the services of the same network, the webhooks can't
+// call the loopback, link-local, including the cloud metadata, and private
+// addresses, whether they're given in the URL or the URL host resolves to
+// them.
+type WebhooksConfig struct {
+	AllowPrivate bool `env:"ALLOW_PRIVATE" envDefault:"false"`
+}
+
+// validateURL checks that the webhook URL is the HTTP URL, which doesn't
+// refer to the internal address unless it's allowed. The addresses the host
+// resolves to are checked when the webhook is called.
+func (c WebhooksConfig) validateURL(webhook string) error {
+	u, err := url.Parse(webhook)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errWebhook
+	}
+	if c.AllowPrivate {
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errWebhookAddress
+	}
+	if ip := net.ParseIP(host); ip != nil && internalAddress(ip) {
+		return errWebhookAddress
+	}
+
+	return nil
+}
+
+// newWebhookClient returns the client calling the webhooks, which refuses
+// to connect to the internal addresses unless they're allowed. Since the
+// address is checked when connecting, neither the redirects nor the hosts
+// resolving to the different address later can reach them.
+func newWebhookClient(cfg WebhooksConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: webhookTimeout}
+	if !cfg.AllowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || internalAddress(ip) {
+				return errWebhookAddress
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: webhookTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookTimeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// internalAddress reports whether the IP address is the loopback,
+// link-local, private, unspecified or multicast address.
+func internalAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast()
+}
+
+// Webhook is the URL called on the lifecycle events of the user's rules,
+// and of the rules the user creates, starts or stops, e.g. the rules of the
+// user's groups. Webhooks without events are called on all of them. Secret
+// signs the payloads and is returned only when the webhook is created.
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events,omitempty"`
+	Owner     string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookEvent is the payload the webhooks are called with. Error is the
+// reason Kuiper stopped the errored rule.
+type WebhookEvent struct {
+	ID    string    `json:"id"`
+	Event string    `json:"event"`
+	Owner string    `json:"owner"`
+	Rule  string    `json:"rule"`
+	Error string    `json:"error,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+// WebhookRepository specifies the persistence API of the webhooks.
+type WebhookRepository interface {
+	// SaveWebhook stores the webhook.
+	SaveWebhook(ctx context.Context, wh Webhook) error
+
+	// RetrieveWebhooks returns the webhooks of the owner, or of all the
+	// owners if the owner is empty.
+	RetrieveWebhooks(ctx context.Context, owner string) ([]Webhook, error)
+
+	// RemoveWebhook removes the owner's webhook with the given ID.
+	RemoveWebhook(ctx context.Context, owner, id string) error
+}
+
+func (svc *reService) CreateWebhook(ctx context.Context, token string, wh Webhook) (Webhook, error) {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return Webhook{}, err
+	}
+	if err := wh.validate(svc.webhooks); err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	if wh.Secret == "" {
+		secret := make([]byte, webhookSecretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+		wh.Secret = hex.EncodeToString(secret)
+	}
+	wh.ID = id.String()
+	wh.Owner = userID
+	wh.CreatedAt = time.Now().UTC()
+	// The secret is stored sealed like the credentials of the actions.
+	stored := wh
+	if svc.encryption.Key != "" {
+		if stored.Secret, err = svc.encryption.seal(wh.Secret); err != nil {
+			return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+	}
+	if err := svc.repo.SaveWebhook(ctx, stored); err != nil {
+		return Webhook{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return wh, nil
+}
+
+func (svc *reService) ListWebhooks(ctx context.Context, token string) ([]Webhook, error) {
+	userID, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	whs, err := svc.repo.RetrieveWebhooks(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	for i := range whs {
+		whs[i].Secret = ""
+	}
+	sort.Slice(whs, func(i, j int) bool {
+		return whs[i].CreatedAt.Before(whs[j].CreatedAt)
+	})
+
+	return whs, nil
+}
+
+func (svc *reService) RemoveWebhook(ctx context.Context, token, id string) error {
+	userID, err := svc.identifyWriter(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	switch err := svc.repo.RemoveWebhook(ctx, userID, id); {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return errors.Wrap(svcerr.ErrNotFound, err)
+	case err != nil:
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (wh Webhook) validate(cfg WebhooksConfig) error {
+	if err := cfg.validateURL(wh.URL); err != nil {
+		return err
+	}
+	for _, ev := range wh.Events {
+		switch ev {
+		case EventRuleCreated, EventRuleStarted, EventRuleStopped, EventRuleErrored:
+		default:
+			return errors.Wrap(errWebhookEvent, errors.New(ev))
+		}
+	}
+
+	return nil
+}
+
+// subscribed returns whether the webhook is called on the event.
+func (wh Webhook) subscribed(event string) bool {
+	if len(wh.Events) == 0 {
+		return true
+	}
+	for _, ev := range wh.Events {
+		if ev == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// emit calls the webhooks subscribed to the event of the rule in the
+// background, so the operation that caused the event doesn't wait for the
+// webhooks. The webhooks of the owner and of the user causing the event are
+// called, so the events of the group rules reach the members acting on
+// them. Events the service causes on its own, e.g. of the expired rules,
+// are caused by no user. Failed calls aren't retried.
+func (svc *reService) emit(ctx context.Context, userID, owner, event, rule, reason string) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		whs, err := svc.repo.RetrieveWebhooks(ctx, owner)
+		if err != nil {
+			return
+		}
+		if userID != "" && userID != owner {
+			own, err := svc.repo.RetrieveWebhooks(ctx, userID)
+			if err != nil {
+				return
+			}
+			whs = append(whs, own...)
+		}
+		if len(whs) == 0 {
+			return
+		}
+		id, err := uuid.NewV4()
+		if err != nil {
+			return
+		}
+		payload, err := json.Marshal(WebhookEvent{
+			ID:    id.String(),
+			Event: event,
+			Owner: owner,
+			Rule:  rule,
+			Error: reason,
+			Time:  time.Now().UTC(),
+		})
+		if err != nil {
+			return
+		}
+		for _, wh := range whs {
+			if wh.subscribed(event) {
+				_ = svc.callWebhook(ctx, wh, event, payload)
+			}
+		}
+	}()
+}
+
+// callWebhook posts the signed event payload to the webhook.
+func (svc *reService) callWebhook(ctx context.Context, wh Webhook, event string, payload []byte) error {
+	secret := wh.Secret
+	if strings.HasPrefix(secret, sealedPrefix) {
+		var err error
+		if secret, err = svc.encryption.open(secret); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	res, err := svc.webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
@@ -0,0 +1,280 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala"
+	authmocks "github.com/absmach/magistrala/auth/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	sdkmocks "github.com/absmach/magistrala/pkg/sdk/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// webhookServer records the events the webhooks are called with, keeping
+// only the events whose signature matches the secret.
+type webhookServer struct {
+	mu     sync.Mutex
+	secret string
+	events []re.WebhookEvent
+}
+
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write(body)
+	if r.Header.Get(re.WebhookSignatureHeader) != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var ev re.WebhookEvent
+	_ = json.Unmarshal(body, &ev)
+	if r.Header.Get(re.WebhookEventHeader) != ev.Event {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, ev)
+}
+
+// received returns the names of the received events.
+func (s *webhookServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := []string{}
+	for _, ev := range s.events {
+		events = append(events, ev.Event)
+	}
+
+	return events
+}
+
+func TestCreateWebhook(t *testing.T) {
+	svc, _, auth, _ := newService(t)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+
+	cases := []struct {
+		desc    string
+		webhook re.Webhook
+		err     error
+	}{
+		{
+			desc:    "create webhook with secret",
+			webhook: re.Webhook{URL: "https://example.com/hooks", Secret: "secret", Events: []string{re.EventRuleCreated}},
+		},
+		{
+			desc:    "create webhook without secret",
+			webhook: re.Webhook{URL: "https://example.com/hooks"},
+		},
+		{
+			desc:    "create webhook with invalid URL",
+			webhook: re.Webhook{URL: "example.com/hooks"},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create webhook with loopback address",
+			webhook: re.Webhook{URL: "http://127.0.0.1:8080/hooks"},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create webhook with localhost",
+			webhook: re.Webhook{URL: "http://localhost/hooks"},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create webhook with metadata address",
+			webhook: re.Webhook{URL: "http://169.254.169.254/latest/meta-data"},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create webhook with private address",
+			webhook: re.Webhook{URL: "https://[fd00::1]/hooks"},
+			err:     svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:    "create webhook with unknown event",
+			webhook: re.Webhook{URL: "https://example.com/hooks", Events: []string{"rule.updated"}},
+			err:     svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		wh, err := svc.CreateWebhook(context.Background(), validToken, tc.webhook)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.NotEmpty(t, wh.ID, fmt.Sprintf("%s: expected webhook ID\n", tc.desc))
+		assert.NotEmpty(t, wh.Secret, fmt.Sprintf("%s: expected webhook secret\n", tc.desc))
+		if tc.webhook.Secret != "" {
+			assert.Equal(t, tc.webhook.Secret, wh.Secret, fmt.Sprintf("%s: expected secret %s got %s\n", tc.desc, tc.webhook.Secret, wh.Secret))
+		}
+	}
+
+	// The secrets are never listed.
+	whs, err := svc.ListWebhooks(context.Background(), validToken)
+	assert.Nil(t, err, fmt.Sprintf("list webhooks: expected no error got %s\n", err))
+	assert.Len(t, whs, 2, fmt.Sprintf("list webhooks: expected 2 webhooks got %v\n", whs))
+	for _, wh := range whs {
+		assert.Empty(t, wh.Secret, fmt.Sprintf("list webhooks: expected no secret got %s\n", wh.Secret))
+	}
+
+	err = svc.RemoveWebhook(context.Background(), validToken, whs[0].ID)
+	assert.Nil(t, err, fmt.Sprintf("remove webhook: expected no error got %s\n", err))
+	err = svc.RemoveWebhook(context.Background(), validToken, whs[0].ID)
+	assert.True(t, errors.Contains(err, svcerr.ErrNotFound), fmt.Sprintf("remove removed webhook: expected %s got %s\n", svcerr.ErrNotFound, err))
+}
+
+func TestWebhookEvents(t *testing.T) {
+	hooks := &webhookServer{secret: "secret"}
+	ts := httptest.NewServer(hooks)
+	defer ts.Close()
+
+	k, url := newKuiper(t)
+	repo := mocks.NewRepository()
+	auth := new(authmocks.AuthClient)
+	cfg := re.Config{URL: url, Webhooks: re.WebhooksConfig{AllowPrivate: true}}
+	svc := re.New(cfg, auth, new(sdkmocks.SDK), re.Notifiers{}, nil, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	_, err := svc.CreateWebhook(context.Background(), validToken, re.Webhook{URL: ts.URL, Secret: hooks.secret, Events: []string{re.EventRuleCreated, re.EventRuleStopped, re.EventRuleErrored}})
+	assert.Nil(t, err, fmt.Sprintf("create webhook: expected no error got %s\n", err))
+
+	rule := re.Rule{ID: "alarm", SQL: "SELECT * FROM stream WHERE v > 30", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	assert.Eventually(t, func() bool { return len(hooks.received()) == 1 }, time.Second, 10*time.Millisecond, "expected rule created event")
+
+	// The webhook isn't subscribed to the started events.
+	_, err = svc.StartRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("start rule: expected no error got %s\n", err))
+	_, err = svc.StopRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("stop rule: expected no error got %s\n", err))
+	assert.Eventually(t, func() bool { return len(hooks.received()) == 2 }, time.Second, 10*time.Millisecond, "expected rule stopped event")
+
+	// Errored rules are found by the monitor, which reports them once.
+	k.failed[userPrefix+rule.ID] = "connection refused."
+	m := re.NewMonitor(re.NewKuiper(cfg), cfg, repo, nil)
+	for i := 0; i < 2; i++ {
+		alerts, err := m.Check(context.Background())
+		assert.Nil(t, err, fmt.Sprintf("check: expected no error got %s\n", err))
+		assert.Empty(t, alerts, fmt.Sprintf("check: expected no alerts without alert policy got %v\n", alerts))
+	}
+	assert.Eventually(t, func() bool { return len(hooks.received()) == 3 }, time.Second, 10*time.Millisecond, "expected rule errored event")
+	time.Sleep(50 * time.Millisecond)
+	expected := []string{re.EventRuleCreated, re.EventRuleStopped, re.EventRuleErrored}
+	assert.Equal(t, expected, hooks.received(), fmt.Sprintf("expected events %v got %v\n", expected, hooks.received()))
+
+	hooks.mu.Lock()
+	errored := hooks.events[2]
+	hooks.mu.Unlock()
+	assert.Equal(t, userID, errored.Owner, fmt.Sprintf("expected owner %s got %s\n", userID, errored.Owner))
+	assert.Equal(t, rule.ID, errored.Rule, fmt.Sprintf("expected rule %s got %s\n", rule.ID, errored.Rule))
+	assert.Equal(t, "connection refused.", errored.Error, fmt.Sprintf("expected error %s got %s\n", "connection refused.", errored.Error))
+}
+
+func TestWebhookInternalAddress(t *testing.T) {
+	hooks := &webhookServer{secret: "secret"}
+	ts := httptest.NewServer(hooks)
+	defer ts.Close()
+
+	// The webhook stored before the addresses were restricted, or whose
+	// host resolves to the loopback address, isn't called.
+	repo := mocks.NewRepository()
+	err := repo.SaveWebhook(context.Background(), re.Webhook{ID: "hook", URL: ts.URL, Secret: hooks.secret, Owner: userID})
+	assert.Nil(t, err, fmt.Sprintf("save webhook: expected no error got %s\n", err))
+	svc, _, auth, _ := newServiceWithRepo(t, re.Config{}, re.Notifiers{}, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	rule := re.Rule{ID: "alarm", SQL: "SELECT * FROM stream WHERE v > 30", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, hooks.received(), fmt.Sprintf("expected no events got %v\n", hooks.received()))
+}
+
+func TestWebhookSealedSecret(t *testing.T) {
+	hooks := &webhookServer{secret: "secret"}
+	ts := httptest.NewServer(hooks)
+	defer ts.Close()
+
+	repo := mocks.NewRepository()
+	svc, _, auth, _ := newServiceWithRepo(t, re.Config{Encryption: encryptionConfig, Webhooks: re.WebhooksConfig{AllowPrivate: true}}, re.Notifiers{}, repo)
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+
+	wh, err := svc.CreateWebhook(context.Background(), validToken, re.Webhook{URL: ts.URL, Secret: hooks.secret})
+	assert.Nil(t, err, fmt.Sprintf("create webhook: expected no error got %s\n", err))
+	assert.Equal(t, hooks.secret, wh.Secret, fmt.Sprintf("expected secret %s got %s\n", hooks.secret, wh.Secret))
+	stored, err := repo.RetrieveWebhooks(context.Background(), userID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve webhooks: expected no error got %s\n", err))
+	assert.Len(t, stored, 1, fmt.Sprintf("expected 1 stored webhook got %v\n", stored))
+	assert.True(t, strings.HasPrefix(stored[0].Secret, "sealed:"), fmt.Sprintf("expected sealed secret got %s\n", stored[0].Secret))
+
+	// The events are signed with the opened secret.
+	rule := re.Rule{ID: "alarm", SQL: "SELECT * FROM stream WHERE v > 30", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	assert.Eventually(t, func() bool { return len(hooks.received()) == 1 }, time.Second, 10*time.Millisecond, "expected rule created event")
+}
+
+func TestGroupRuleWebhook(t *testing.T) {
+	hooks := &webhookServer{secret: "secret"}
+	ts := httptest.NewServer(hooks)
+	defer ts.Close()
+
+	svc, _, auth, _ := newServiceWithConfig(t, re.Config{Webhooks: re.WebhooksConfig{AllowPrivate: true}}, re.Notifiers{})
+	authCall := auth.On("Identify", mock.Anything, &magistrala.IdentityReq{Token: validToken}).Return(&magistrala.IdentityRes{UserId: userID}, nil)
+	defer authCall.Unset()
+	channelCall := authorizeChannel(auth, validToken, channelID, true)
+	defer channelCall.Unset()
+	memberCall := authorizeMember(auth, validToken, groupID, true)
+	defer memberCall.Unset()
+
+	_, err := svc.CreateWebhook(context.Background(), validToken, re.Webhook{URL: ts.URL, Secret: hooks.secret})
+	assert.Nil(t, err, fmt.Sprintf("create webhook: expected no error got %s\n", err))
+
+	// The group has no webhooks, so the member creating and stopping the
+	// group rule is notified.
+	rule := re.Rule{ID: groupID + ":alarm", SQL: "SELECT * FROM stream WHERE v > 30", Actions: []re.Action{{Mainflux: &re.MainfluxSink{Channel: channelID}}}}
+	_, err = svc.CreateRule(context.Background(), validToken, rule)
+	assert.Nil(t, err, fmt.Sprintf("create rule: expected no error got %s\n", err))
+	_, err = svc.StopRule(context.Background(), validToken, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("stop rule: expected no error got %s\n", err))
+	assert.Eventually(t, func() bool { return len(hooks.received()) == 2 }, time.Second, 10*time.Millisecond, "expected rule created and stopped events")
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	for _, ev := range hooks.events {
+		assert.Equal(t, groupID, ev.Owner, fmt.Sprintf("expected owner %s got %s\n", groupID, ev.Owner))
+		assert.Equal(t, "alarm", ev.Rule, fmt.Sprintf("expected rule %s got %s\n", "alarm", ev.Rule))
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromKuiper(t *testing.T) {
+	cfg := WritersConfig{
+		Postgres:  SQLWriterConfig{URL: "postgres://postgres:5432/magistrala"},
+		Timescale: SQLWriterConfig{URL: "postgres://timescale:5432/magistrala"},
+	}
+
+	cases := []struct {
+		desc   string
+		action string
+		res    Action
+	}{
+		{
+			desc:   "influxdb sink",
+			action: `{"influx2":{"addr":"http://influxdb:8086","token":"secret","measurement":"u1234_alarms"}}`,
+			res:    Action{Writer: &WriterSink{Type: InfluxDBWriter, Table: "alarms"}},
+		},
+		{
+			desc:   "postgres sink",
+			action: `{"sql":{"url":"postgres://postgres:5432/magistrala","table":"u1234_alarms","fields":["v"]}}`,
+			res:    Action{Writer: &WriterSink{Type: PostgresWriter, Table: "alarms", Fields: []string{"v"}}},
+		},
+		{
+			desc:   "timescale sink",
+			action: `{"sql":{"url":"postgres://timescale:5432/magistrala","table":"u1234_alarms"}}`,
+			res:    Action{Writer: &WriterSink{Type: TimescaleWriter, Table: "alarms"}},
+		},
+		{
+			desc:   "mainflux sink",
+			action: `{"mainflux":{"channel":"` + streamChannel + `"}}`,
+			res:    Action{Mainflux: &MainfluxSink{Channel: streamChannel}},
+		},
+	}
+
+	for _, tc := range cases {
+		rule, err := fromKuiper(EngineRule{Actions: []json.RawMessage{json.RawMessage(tc.action)}}, streamPfx, cfg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, []Action{tc.res}, rule.Actions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, rule.Actions))
+	}
+}